@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// AccessControlPolicySpec defines the desired state of AccessControlPolicy.
+//
+// +kubebuilder:validation:XValidation:rule="self.targetRefs.all(ref, (ref.group == 'gateway.networking.k8s.io' && ref.kind in ['Gateway', 'HTTPRoute']) || (ref.group == 'networking.datumapis.com' && ref.kind == 'HTTPProxy'))", message="this policy can only target a gateway.networking.k8s.io Gateway/HTTPRoute or a networking.datumapis.com HTTPProxy"
+// +kubebuilder:validation:XValidation:rule="has(self.allow) || has(self.deny)", message="at least one of allow or deny must be specified"
+type AccessControlPolicySpec struct {
+	// TargetRefs are the Gateways, HTTPRoutes and HTTPProxies this policy is
+	// attached to. A sectionName selects a listener of a Gateway, or a named
+	// rule of an HTTPRoute or HTTPProxy.
+	//
+	// A target conflicts with the targets of older AccessControlPolicies and
+	// AuthenticationPolicies that cover the same listener or rule, and with
+	// HTTPProxies that configure auth. Policies attached to a route are
+	// combined with those attached to its Gateway.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	TargetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// Allow is the list of client address ranges permitted to connect. When
+	// set, requests from any other address are denied.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	Allow []CIDR `json:"allow,omitempty"`
+
+	// Deny is the list of client address ranges that are denied. Deny takes
+	// precedence over Allow, so an address in both lists is denied.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	Deny []CIDR `json:"deny,omitempty"`
+}
+
+// CIDR is an IPv4 or IPv6 address range, for example `192.0.2.0/24` or
+// `2001:db8::/32`.
+//
+// +kubebuilder:validation:MaxLength=64
+// +kubebuilder:validation:XValidation:rule="isCIDR(self)", message="must be a valid CIDR"
+type CIDR string
+
+// AccessControlPolicyStatus defines the observed state of AccessControlPolicy.
+type AccessControlPolicyStatus struct {
+	gatewayv1alpha2.PolicyStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=acp
+
+// AccessControlPolicy is the Schema for the accesscontrolpolicies API.
+type AccessControlPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   AccessControlPolicySpec   `json:"spec,omitempty"`
+	Status AccessControlPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccessControlPolicyList contains a list of AccessControlPolicy.
+type AccessControlPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessControlPolicy `json:"items"`
+}
@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// AccessLogPolicySpec defines the desired state of AccessLogPolicy.
+//
+// +kubebuilder:validation:XValidation:rule="self.targetRefs.all(ref, (ref.group == 'gateway.networking.k8s.io' && ref.kind == 'Gateway') || (ref.group == 'networking.datumapis.com' && ref.kind == 'HTTPProxy'))", message="this policy can only target a gateway.networking.k8s.io Gateway or a networking.datumapis.com HTTPProxy"
+// +kubebuilder:validation:XValidation:rule="self.targetRefs.all(ref, ref.kind != 'HTTPProxy' || !has(ref.sectionName))", message="sectionName is not supported for HTTPProxy targets"
+type AccessLogPolicySpec struct {
+	// TargetRefs are the Gateways and HTTPProxies whose requests are logged. A
+	// sectionName selects a listener of a Gateway.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	TargetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// Format is the format of access log entries. When unset, entries are
+	// written in the default format of Envoy Gateway.
+	//
+	// +kubebuilder:validation:Optional
+	Format *AccessLogFormat `json:"format,omitempty"`
+
+	// SamplePercent is the percentage of requests that are logged.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=100
+	SamplePercent *int32 `json:"samplePercent,omitempty"`
+
+	// Sinks are the destinations access log entries are written to. Every entry
+	// is written to each sink.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=4
+	Sinks []AccessLogSink `json:"sinks"`
+}
+
+// AccessLogFormatType is the encoding of access log entries.
+//
+// +kubebuilder:validation:Enum=Text;JSON
+type AccessLogFormatType string
+
+const (
+	AccessLogFormatText AccessLogFormatType = "Text"
+	AccessLogFormatJSON AccessLogFormatType = "JSON"
+)
+
+// AccessLogFormat defines the format of access log entries.
+//
+// +kubebuilder:validation:XValidation:rule="self.type == 'Text' ? has(self.text) && !has(self.json) : has(self.json) && !has(self.text)", message="text must be set for the Text format, and json for the JSON format"
+type AccessLogFormat struct {
+	// Type is Text for entries formatted from a format string, or JSON for
+	// entries encoded as JSON objects.
+	//
+	// +kubebuilder:validation:Required
+	Type AccessLogFormatType `json:"type"`
+
+	// Text is the format string of Text entries, which may use Envoy command
+	// operators such as `%REQ(:AUTHORITY)%`.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	Text *string `json:"text,omitempty"`
+
+	// JSON are the fields of JSON entries. Values may use Envoy command
+	// operators such as `%RESPONSE_CODE%`.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinProperties=1
+	// +kubebuilder:validation:MaxProperties=64
+	JSON map[string]string `json:"json,omitempty"`
+}
+
+// AccessLogSinkType is the kind of destination of access log entries.
+//
+// +kubebuilder:validation:Enum=Stdout;OTLP;S3
+type AccessLogSinkType string
+
+const (
+	// AccessLogSinkStdout writes entries to the standard output of the proxies.
+	AccessLogSinkStdout AccessLogSinkType = "Stdout"
+
+	// AccessLogSinkOTLP exports entries to an OpenTelemetry collector.
+	AccessLogSinkOTLP AccessLogSinkType = "OTLP"
+
+	// AccessLogSinkS3 writes entries to a bucket of an S3-compatible object
+	// store.
+	AccessLogSinkS3 AccessLogSinkType = "S3"
+)
+
+// AccessLogSink defines a destination of access log entries.
+//
+// +kubebuilder:validation:XValidation:rule="self.type == 'OTLP' ? has(self.otlp) : !has(self.otlp)", message="otlp must be set only for the OTLP sink type"
+// +kubebuilder:validation:XValidation:rule="self.type == 'S3' ? has(self.s3) : !has(self.s3)", message="s3 must be set only for the S3 sink type"
+type AccessLogSink struct {
+	// Type is the kind of destination.
+	//
+	// +kubebuilder:validation:Required
+	Type AccessLogSinkType `json:"type"`
+
+	// OTLP is the OpenTelemetry collector entries are exported to over gRPC.
+	//
+	// +kubebuilder:validation:Optional
+	OTLP *AccessLogOTLPSink `json:"otlp,omitempty"`
+
+	// S3 is the bucket entries are written to.
+	//
+	// +kubebuilder:validation:Optional
+	S3 *AccessLogS3Sink `json:"s3,omitempty"`
+}
+
+// AccessLogOTLPSink defines an OpenTelemetry collector that access log entries
+// are exported to.
+type AccessLogOTLPSink struct {
+	// Endpoint is the `host:port` of the OTLP gRPC endpoint of the collector.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Endpoint string `json:"endpoint"`
+
+	// Headers are sent with export requests, for example to authenticate with
+	// the collector.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	Headers []gatewayv1.HTTPHeader `json:"headers,omitempty"`
+
+	// ResourceAttributes are added to the resource of exported entries, along
+	// with attributes that identify the policy they were logged for.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=32
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
+}
+
+// AccessLogS3Sink defines a bucket of an S3-compatible object store that access
+// log entries are written to.
+//
+// Entries are delivered by the collector of the platform, which must be granted
+// permission to write objects to the bucket.
+type AccessLogS3Sink struct {
+	// Bucket is the name of the bucket.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Bucket string `json:"bucket"`
+
+	// Endpoint is the URL of the object store. When unset, the bucket is
+	// located in AWS S3.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:XValidation:rule="isURL(self) && url(self).getScheme() == 'https'", message="endpoint must be an https URL"
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Region is the region of the bucket.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=64
+	Region *string `json:"region,omitempty"`
+
+	// Prefix is prepended to the keys of written objects.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=512
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// AccessLogPolicyStatus defines the observed state of AccessLogPolicy.
+type AccessLogPolicyStatus struct {
+	gatewayv1alpha2.PolicyStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=alp
+
+// AccessLogPolicy is the Schema for the accesslogpolicies API.
+type AccessLogPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   AccessLogPolicySpec   `json:"spec,omitempty"`
+	Status AccessLogPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccessLogPolicyList contains a list of AccessLogPolicy.
+type AccessLogPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessLogPolicy `json:"items"`
+}
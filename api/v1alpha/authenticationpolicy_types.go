@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// AuthenticationPolicySpec defines the desired state of AuthenticationPolicy.
+//
+// +kubebuilder:validation:XValidation:rule="self.targetRefs.all(ref, (ref.group == 'gateway.networking.k8s.io' && ref.kind in ['Gateway', 'HTTPRoute']) || (ref.group == 'networking.datumapis.com' && ref.kind == 'HTTPProxy'))", message="this policy can only target a gateway.networking.k8s.io Gateway/HTTPRoute or a networking.datumapis.com HTTPProxy"
+// +kubebuilder:validation:XValidation:rule="has(self.jwt) || has(self.oidc)", message="at least one of jwt or oidc must be specified"
+type AuthenticationPolicySpec struct {
+	// TargetRefs are the Gateways, HTTPRoutes and HTTPProxies this policy is
+	// attached to. A sectionName selects a listener of a Gateway, or a named
+	// rule of an HTTPRoute or HTTPProxy.
+	//
+	// A target conflicts with the targets of older AccessControlPolicies and
+	// AuthenticationPolicies that cover the same listener or rule, and with
+	// HTTPProxies that configure auth. Policies attached to a route are
+	// combined with those attached to its Gateway.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	TargetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// JWT authenticates requests by the JSON Web Token they carry in the
+	// Authorization header.
+	//
+	// +kubebuilder:validation:Optional
+	JWT *JWTAuthentication `json:"jwt,omitempty"`
+
+	// OIDC authenticates browser requests by redirecting them to the login
+	// flow of an OpenID Connect provider.
+	//
+	// +kubebuilder:validation:Optional
+	OIDC *OIDCAuthentication `json:"oidc,omitempty"`
+}
+
+// JWTAuthentication defines the providers of the tokens that are accepted.
+type JWTAuthentication struct {
+	// Optional allows requests without a token. Requests with an invalid
+	// token are still rejected.
+	//
+	// +kubebuilder:validation:Optional
+	Optional *bool `json:"optional,omitempty"`
+
+	// Providers are the issuers of the tokens that are accepted. A token is
+	// accepted when it is valid for any of the providers.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=4
+	// +listType=map
+	// +listMapKey=name
+	Providers []JWTProvider `json:"providers"`
+}
+
+// JWTProvider defines an issuer of JSON Web Tokens.
+type JWTProvider struct {
+	// Name identifies the provider in the policy.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Issuer is the principal that issued the tokens, which must match the
+	// `iss` claim of the tokens when set.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	Issuer string `json:"issuer,omitempty"`
+
+	// JWKSURI is the HTTPS URL of the JSON Web Key Set used to verify the
+	// signature of the tokens.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:XValidation:rule="isURL(self) && url(self).getScheme() == 'https'", message="must be an https URL"
+	JWKSURI string `json:"jwksURI"`
+
+	// Audiences are the audiences the tokens must be issued for, at least one
+	// of which must be in the `aud` claim of the tokens. Any audience is
+	// accepted when unset.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	Audiences []string `json:"audiences,omitempty"`
+
+	// ClaimToHeaders copies claims of the tokens to request headers, which are
+	// forwarded to the backends.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	ClaimToHeaders []ClaimToHeader `json:"claimToHeaders,omitempty"`
+}
+
+// ClaimToHeader copies a claim of a token to a request header.
+type ClaimToHeader struct {
+	// Claim is the name of the claim. Nested claims are selected with a
+	// period, for example `user.email`.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Claim string `json:"claim"`
+
+	// Header is the name of the request header the claim is copied to.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`
+	Header string `json:"header"`
+}
+
+// OIDCAuthentication defines the OpenID Connect login flow of a policy.
+type OIDCAuthentication struct {
+	// Issuer is the HTTPS URL of the OpenID Connect provider, from which its
+	// endpoints are discovered.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:XValidation:rule="isURL(self) && url(self).getScheme() == 'https'", message="must be an https URL"
+	Issuer string `json:"issuer"`
+
+	// ClientID is the identifier of the client registered with the provider.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef references an Opaque Secret in the namespace of the
+	// policy whose `client-secret` key holds the secret of the client.
+	//
+	// +kubebuilder:validation:Required
+	ClientSecretRef corev1.LocalObjectReference `json:"clientSecretRef"`
+
+	// RedirectURL is the URL the provider redirects to after login. It
+	// defaults to `%REQ(x-forwarded-proto)%://%REQ(:authority)%/oauth2/callback`.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	RedirectURL *string `json:"redirectURL,omitempty"`
+
+	// LogoutPath is the path that logs out of the session. It defaults to
+	// `/logout`.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^/`
+	LogoutPath *string `json:"logoutPath,omitempty"`
+
+	// Scopes are the scopes requested in addition to `openid`.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	Scopes []string `json:"scopes,omitempty"`
+
+	// ForwardAccessToken forwards the access token of the session to the
+	// backends in the Authorization header.
+	//
+	// +kubebuilder:validation:Optional
+	ForwardAccessToken *bool `json:"forwardAccessToken,omitempty"`
+}
+
+// AuthenticationPolicyStatus defines the observed state of AuthenticationPolicy.
+type AuthenticationPolicyStatus struct {
+	gatewayv1alpha2.PolicyStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=authnp
+
+// AuthenticationPolicy is the Schema for the authenticationpolicies API.
+type AuthenticationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   AuthenticationPolicySpec   `json:"spec,omitempty"`
+	Status AuthenticationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AuthenticationPolicyList contains a list of AuthenticationPolicy.
+type AuthenticationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AuthenticationPolicy `json:"items"`
+}
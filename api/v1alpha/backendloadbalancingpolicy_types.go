@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// BackendLoadBalancingPolicySpec defines the desired state of
+// BackendLoadBalancingPolicy.
+//
+// +kubebuilder:validation:XValidation:rule="self.targetRefs.all(ref, (size(ref.group) == 0 && ref.kind == 'Service') || (ref.group == 'discovery.k8s.io' && ref.kind == 'EndpointSlice') || (ref.group == 'gateway.envoyproxy.io' && ref.kind == 'Backend'))", message="this policy can only target a Service, a discovery.k8s.io EndpointSlice or a gateway.envoyproxy.io Backend"
+type BackendLoadBalancingPolicySpec struct {
+	// TargetRefs are the backends this policy is attached to. The policy
+	// applies to the rules of the HTTPRoutes in the namespace that have a
+	// backendRef to one of the targets, and so to every backend of those
+	// rules. The rules of an HTTPRoute with several rules must be named for the
+	// policy to apply to them.
+	//
+	// A rule conflicts with the targets of older BackendLoadBalancingPolicies,
+	// RateLimitPolicies and PayloadPolicies that cover it, and with the rules
+	// of an HTTPProxy that health check or load balance their backends.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	TargetRefs []gatewayv1alpha2.LocalPolicyTargetReference `json:"targetRefs"`
+
+	// LoadBalancer configures how requests are balanced across the endpoints
+	// of the backends.
+	//
+	// +kubebuilder:validation:Required
+	LoadBalancer HTTPProxyBackendLoadBalancer `json:"loadBalancer"`
+}
+
+// BackendLoadBalancingPolicyStatus defines the observed state of
+// BackendLoadBalancingPolicy.
+type BackendLoadBalancingPolicyStatus struct {
+	gatewayv1alpha2.PolicyStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=blbp
+
+// BackendLoadBalancingPolicy is the Schema for the backendloadbalancingpolicies
+// API.
+type BackendLoadBalancingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   BackendLoadBalancingPolicySpec   `json:"spec,omitempty"`
+	Status BackendLoadBalancingPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackendLoadBalancingPolicyList contains a list of BackendLoadBalancingPolicy.
+type BackendLoadBalancingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackendLoadBalancingPolicy `json:"items"`
+}
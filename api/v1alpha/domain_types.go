@@ -8,6 +8,7 @@ import (
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // Domain represents a domain name in the Datum system
 //
@@ -15,6 +16,8 @@ import (
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Verified",type="string",JSONPath=`.status.conditions[?(@.type=="Verified")].status`
 // +kubebuilder:printcolumn:name="Verification Message",type="string",JSONPath=`.status.conditions[?(@.type=="Verified")].message`,priority=1
+// +kubebuilder:printcolumn:name="Apex",type="boolean",JSONPath=".status.apex",priority=1
+// +kubebuilder:printcolumn:name="Next Verification",type="date",JSONPath=".status.verification.nextVerificationAttempt",priority=1
 type Domain struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -60,6 +63,26 @@ type DomainStatus struct {
 	// Apex is true when spec.domainName is the registered domain (eTLD+1).
 	Apex       bool               `json:"apex,omitempty"`
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Consumers lists the Gateways, HTTPProxies and DNSZones in the namespace
+	// of the Domain with a hostname or domain name that falls under it. At most
+	// MaxDomainConsumers are listed.
+	//
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=atomic
+	Consumers []DomainConsumer `json:"consumers,omitempty"`
+}
+
+// MaxDomainConsumers is the most consumers listed in the status of a Domain.
+const MaxDomainConsumers = 100
+
+// DomainConsumer references a resource that uses a Domain.
+type DomainConsumer struct {
+	// Kind is the kind of the consumer: Gateway, HTTPProxy or DNSZone.
+	Kind string `json:"kind"`
+
+	// Name is the name of the consumer, in the namespace of the Domain.
+	Name string `json:"name"`
 }
 
 const (
@@ -79,6 +102,15 @@ const (
 
 	// This condition tracks verification attempts via managed DNS (DNSZone).
 	DomainConditionVerifiedDNSZone = "VerifiedDNSZone"
+
+	// This condition tracks the most recent refresh of registration data.
+	DomainConditionRegistrationRefreshed = "RegistrationRefreshed"
+
+	// DomainConditionDNSSECValid indicates whether the DNSSEC chain of trust of
+	// the registered domain is intact. It is only present when registration
+	// data reports DNSSEC as enabled. DNS records should not be created in a
+	// zone whose DNSSEC is broken, as validating resolvers will reject them.
+	DomainConditionDNSSECValid = "DNSSECValid"
 )
 
 const (
@@ -102,6 +134,10 @@ const (
 	// was encountered during verification.
 	DomainReasonVerificationInternalError = "InternalError"
 
+	// DomainReasonVerificationExpired indicates the ownership of a previously
+	// verified domain could not be re-verified.
+	DomainReasonVerificationExpired = "VerificationExpired"
+
 	// DomainReasonVerified indicates domain ownership has been successfully
 	// verified
 	DomainReasonVerified = "Verified"
@@ -122,6 +158,45 @@ const (
 
 	// DomainReasonValid indicates the provided domain name is registrable.
 	DomainReasonValid = "Valid"
+
+	// DomainReasonRegistrationRefreshed indicates registration data was
+	// refreshed from the registry.
+	DomainReasonRegistrationRefreshed = "Refreshed"
+
+	// DomainReasonRateLimited indicates the registration refresh was deferred
+	// because lookups to a registry provider are rate limited.
+	DomainReasonRateLimited = "RateLimited"
+
+	// DomainReasonRegistrationLookupFailed indicates registration data could not
+	// be looked up.
+	DomainReasonRegistrationLookupFailed = "LookupFailed"
+
+	// DomainReasonDNSSECValid indicates the DNSSEC chain of trust is intact.
+	DomainReasonDNSSECValid = "Valid"
+
+	// DomainReasonDNSSECDSNotFound indicates DNSSEC is enabled at the registry,
+	// but no DS record is published in the parent zone.
+	DomainReasonDNSSECDSNotFound = "DSNotFound"
+
+	// DomainReasonDNSSECDNSKEYNotFound indicates DS records are published, but
+	// the zone has no DNSKEY records.
+	DomainReasonDNSSECDNSKEYNotFound = "DNSKEYNotFound"
+
+	// DomainReasonDNSSECDSMismatch indicates no DS record matches a DNSKEY of
+	// the zone.
+	DomainReasonDNSSECDSMismatch = "DSMismatch"
+
+	// DomainReasonDNSSECSignatureExpired indicates the signature of the DNSKEY
+	// RRset is outside its validity period.
+	DomainReasonDNSSECSignatureExpired = "SignatureExpired"
+
+	// DomainReasonDNSSECSignatureInvalid indicates the DNSKEY RRset is not
+	// signed by a key referenced from the parent zone.
+	DomainReasonDNSSECSignatureInvalid = "SignatureInvalid"
+
+	// DomainReasonDNSSECLookupFailed indicates the DNSSEC records could not be
+	// looked up.
+	DomainReasonDNSSECLookupFailed = "LookupFailed"
 )
 
 // DomainVerificationStatus represents the verification status of a domain
@@ -130,6 +205,10 @@ type DomainVerificationStatus struct {
 	HTTPToken               HTTPVerificationToken `json:"httpToken,omitempty"`
 	NextVerificationAttempt metav1.Time           `json:"nextVerificationAttempt,omitempty"`
 	LastVerificationAttempt metav1.Time           `json:"lastVerificationAttempt,omitempty"`
+
+	// ReverificationFailures is the number of consecutive failed attempts to
+	// re-verify the ownership of a verified Domain.
+	ReverificationFailures int32 `json:"reverificationFailures,omitempty"`
 }
 
 // DNSVerificationRecord represents a DNS record required for verification
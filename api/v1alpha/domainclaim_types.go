@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DomainClaimSpec defines the domain names of a DomainClaim
+type DomainClaimSpec struct {
+	// DomainNames are the fully qualified domain names to create Domains for.
+	//
+	// A Domain named after each domain name is created in the namespace of the
+	// claim, unless a Domain with the same `spec.domainName` already exists, in
+	// which case the existing Domain is reported on instead. Domains created by
+	// the claim are deleted when their domain name is removed from the claim, or
+	// the claim is deleted.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=1000
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=253
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)+$`
+	// +listType=set
+	DomainNames []string `json:"domainNames"`
+}
+
+// DomainClaimStatus summarizes the verification state of the Domains of a
+// DomainClaim
+type DomainClaimStatus struct {
+	// DomainCount is the number of domain names in the claim.
+	DomainCount int32 `json:"domainCount,omitempty"`
+
+	// VerifiedCount is the number of Domains that have been verified.
+	VerifiedCount int32 `json:"verifiedCount,omitempty"`
+
+	// PendingCount is the number of Domains that are waiting to be verified.
+	PendingCount int32 `json:"pendingCount,omitempty"`
+
+	// FailedCount is the number of domain names that failed, see `failed`.
+	FailedCount int32 `json:"failedCount,omitempty"`
+
+	// Failed lists domain names whose Domain could not be created, is not a
+	// registrable domain, or whose verification expired. At most 100 entries
+	// are listed.
+	//
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=map
+	// +listMapKey=domainName
+	Failed []DomainClaimFailure `json:"failed,omitempty"`
+
+	// Conditions describe the current state of the claim.
+	//
+	// Known condition types are:
+	//
+	// * "Verified"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// DomainClaimFailure describes a domain name of a DomainClaim that failed
+type DomainClaimFailure struct {
+	// DomainName is the failed domain name.
+	DomainName string `json:"domainName"`
+
+	// Domain is the name of the Domain for the domain name, if any.
+	Domain string `json:"domain,omitempty"`
+
+	// Reason is a machine readable reason for the failure.
+	Reason string `json:"reason"`
+
+	// Message is a human readable description of the failure.
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	// DomainClaimConditionVerified is true when every Domain of the claim has
+	// been verified.
+	DomainClaimConditionVerified = "Verified"
+)
+
+const (
+	// DomainClaimReasonAllVerified indicates every Domain of the claim has been
+	// verified.
+	DomainClaimReasonAllVerified = "AllVerified"
+
+	// DomainClaimReasonPending indicates some Domains of the claim are waiting
+	// to be verified.
+	DomainClaimReasonPending = "VerificationPending"
+
+	// DomainClaimReasonFailed indicates some domain names of the claim failed.
+	DomainClaimReasonFailed = "DomainsFailed"
+
+	// DomainClaimReasonConflict indicates a Domain with the name of a domain
+	// name already exists for a different domain name.
+	DomainClaimReasonConflict = "Conflict"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// DomainClaim creates a Domain for each of a list of domain names, and
+// summarizes their verification state, so that large numbers of hostnames can
+// be onboarded without creating and tracking each Domain.
+//
+// +kubebuilder:printcolumn:name="Domains",type="integer",JSONPath=".status.domainCount"
+// +kubebuilder:printcolumn:name="Verified",type="integer",JSONPath=".status.verifiedCount"
+// +kubebuilder:printcolumn:name="Pending",type="integer",JSONPath=".status.pendingCount"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failedCount"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type DomainClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec DomainClaimSpec `json:"spec,omitempty"`
+
+	// +kubebuilder:default={conditions: {{type: "Verified", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}}
+	Status DomainClaimStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DomainClaimList contains a list of DomainClaim
+type DomainClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DomainClaim `json:"items"`
+}
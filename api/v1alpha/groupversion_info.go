@@ -24,10 +24,26 @@ var (
 
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(GroupVersion,
+		&AccessControlPolicy{},
+		&AccessControlPolicyList{},
+		&AccessLogPolicy{},
+		&AccessLogPolicyList{},
+		&AuthenticationPolicy{},
+		&AuthenticationPolicyList{},
+		&BackendLoadBalancingPolicy{},
+		&BackendLoadBalancingPolicyList{},
 		&Domain{},
 		&DomainList{},
+		&DomainClaim{},
+		&DomainClaimList{},
+		&HostnameBlocklist{},
+		&HostnameBlocklistList{},
 		&HTTPProxy{},
 		&HTTPProxyList{},
+		&IPAddressClaim{},
+		&IPAddressClaimList{},
+		&IPPool{},
+		&IPPoolList{},
 		&Location{},
 		&LocationList{},
 		&LocationBinding{},
@@ -40,6 +56,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&NetworkContextList{},
 		&NetworkPolicy{},
 		&NetworkPolicyList{},
+		&PayloadPolicy{},
+		&PayloadPolicyList{},
+		&RateLimitPolicy{},
+		&RateLimitPolicyList{},
 		&Subnet{},
 		&SubnetList{},
 		&SubnetClaim{},
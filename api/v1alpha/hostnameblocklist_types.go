@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HostnameBlocklistSpec defines the hostnames that may not be programmed on
+// any Gateway or HTTPProxy.
+type HostnameBlocklistSpec struct {
+	// Hostnames that are blocked when matched exactly.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=1000
+	// +listType=set
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// Suffixes block any hostname that is equal to the suffix, or is a sub
+	// domain of it. For example, `example.com` blocks both `example.com` and
+	// `www.example.com`.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=1000
+	// +listType=set
+	Suffixes []string `json:"suffixes,omitempty"`
+
+	// Patterns are RE2 regular expressions that block any hostname they match
+	// in full. Invalid patterns are rejected.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=set
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// HostnameBlocklist is managed by platform operators to prevent hostnames from
+// being programmed on any Gateway or HTTPProxy. Matching hostnames are rejected
+// with a PolicyViolation reason.
+//
+// Blocklists are read from the downstream control plane.
+type HostnameBlocklist struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HostnameBlocklistSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HostnameBlocklistList contains a list of HostnameBlocklist.
+type HostnameBlocklistList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HostnameBlocklist `json:"items"`
+}
+
+const (
+	// This reason is used when a hostname defined in an HTTPProxy or Gateway
+	// matches a HostnameBlocklist.
+	HostnamePolicyViolationReason = "PolicyViolation"
+)
@@ -4,6 +4,7 @@
 package v1alpha
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -58,6 +59,172 @@ type HTTPProxySpec struct {
 	// +kubebuilder:validation:XValidation:message="Rule name must be unique within the route",rule="self.all(l1, !has(l1.name) || self.exists_one(l2, has(l2.name) && l1.name == l2.name))"
 	// +kubebuilder:validation:XValidation:message="While 16 rules and 64 matches per rule are allowed, the total number of matches across all rules in a route must be less than 128",rule="(self.size() > 0 ? self[0].matches.size() : 0) + (self.size() > 1 ? self[1].matches.size() : 0) + (self.size() > 2 ? self[2].matches.size() : 0) + (self.size() > 3 ? self[3].matches.size() : 0) + (self.size() > 4 ? self[4].matches.size() : 0) + (self.size() > 5 ? self[5].matches.size() : 0) + (self.size() > 6 ? self[6].matches.size() : 0) + (self.size() > 7 ? self[7].matches.size() : 0) + (self.size() > 8 ? self[8].matches.size() : 0) + (self.size() > 9 ? self[9].matches.size() : 0) + (self.size() > 10 ? self[10].matches.size() : 0) + (self.size() > 11 ? self[11].matches.size() : 0) + (self.size() > 12 ? self[12].matches.size() : 0) + (self.size() > 13 ? self[13].matches.size() : 0) + (self.size() > 14 ? self[14].matches.size() : 0) + (self.size() > 15 ? self[15].matches.size() : 0) <= 128"
 	Rules []HTTPProxyRule `json:"rules,omitempty"`
+
+	// ResponseHeaders modifies the headers of responses returned to clients by
+	// every rule, for example to control the Cache-Control or Surrogate-Control
+	// headers emitted by the proxy.
+	//
+	// Rules may override entries for individual headers via their own
+	// `responseHeaders` field. Headers managed by the platform, such as
+	// `Server`, `Date` and `Content-Length`, may not be modified.
+	//
+	// +kubebuilder:validation:Optional
+	ResponseHeaders *gatewayv1.HTTPHeaderFilter `json:"responseHeaders,omitempty"`
+
+	// TLS configures the TLS certificate served for the hostnames of the
+	// proxy. When unset, a certificate is issued for each hostname.
+	//
+	// +kubebuilder:validation:Optional
+	TLS *HTTPProxyTLS `json:"tls,omitempty"`
+
+	// TLSPolicy configures the TLS versions, cipher suites and application
+	// protocols negotiated with clients of the proxy. The platform enforces a
+	// minimum policy, and settings weaker than it are not applied.
+	//
+	// +kubebuilder:validation:Optional
+	TLSPolicy *TLSPolicy `json:"tlsPolicy,omitempty"`
+
+	// ClientValidation requires clients of the proxy to present a TLS
+	// certificate signed by one of the referenced CA certificates.
+	//
+	// +kubebuilder:validation:Optional
+	ClientValidation *HTTPProxyClientValidation `json:"clientValidation,omitempty"`
+
+	// Auth requires clients of the proxy to authenticate with a username and
+	// password before requests are forwarded to the backends. For single
+	// sign-on, target the proxy with an AuthenticationPolicy instead.
+	//
+	// AccessControlPolicies and AuthenticationPolicies that target the proxy,
+	// or one of its rules, conflict with Auth and are not programmed. Those
+	// attached to the Gateway of the proxy are combined with Auth.
+	//
+	// +kubebuilder:validation:Optional
+	Auth *HTTPProxyAuth `json:"auth,omitempty"`
+
+	// Protocols configures the HTTP versions served to clients of the proxy.
+	//
+	// +kubebuilder:validation:Optional
+	Protocols *HTTPProxyProtocols `json:"protocols,omitempty"`
+
+	// Paused answers every request to the proxy with the maintenance response
+	// of the platform instead of forwarding it to the backends, for instance
+	// while the backends are migrated. The configuration of the proxy is kept
+	// programmed, so traffic resumes as soon as the proxy is unpaused.
+	//
+	// +kubebuilder:validation:Optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// HTTPProxyProtocols configures the HTTP versions served to clients.
+type HTTPProxyProtocols struct {
+	// HTTP3 serves HTTP/3 over QUIC on the HTTPS listener of the proxy, in
+	// addition to HTTP/1.1 and HTTP/2. Clients discover HTTP/3 support through
+	// the `alt-svc` header of responses received over HTTP/1.1 or HTTP/2.
+	//
+	// HTTP/3 is only served when it is made available by the platform.
+	//
+	// +kubebuilder:validation:Optional
+	HTTP3 bool `json:"http3,omitempty"`
+}
+
+// HTTPProxyAuth configures the authentication of clients of an HTTPProxy.
+type HTTPProxyAuth struct {
+	// Basic requires clients to send a username and password with HTTP basic
+	// authentication.
+	//
+	// +kubebuilder:validation:Required
+	Basic *HTTPProxyBasicAuth `json:"basic"`
+}
+
+// HTTPProxyBasicAuth configures HTTP basic authentication.
+type HTTPProxyBasicAuth struct {
+	// UsersRef references an Opaque Secret in the namespace of the HTTPProxy
+	// whose `.htpasswd` key holds the users in htpasswd format. Passwords must
+	// be SHA hashed, as generated by `htpasswd -s`.
+	//
+	// +kubebuilder:validation:Required
+	UsersRef corev1.LocalObjectReference `json:"usersRef"`
+
+	// ForwardUsernameHeader is the request header the authenticated username
+	// is forwarded to the backends in.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`
+	ForwardUsernameHeader *string `json:"forwardUsernameHeader,omitempty"`
+}
+
+// HTTPProxyClientValidation configures validation of client certificates.
+type HTTPProxyClientValidation struct {
+	// CACertificateRef references a ConfigMap in the namespace of the HTTPProxy
+	// with the PEM encoded CA certificates in the `ca.crt` key.
+	//
+	// +kubebuilder:validation:Required
+	CACertificateRef corev1.LocalObjectReference `json:"caCertificateRef"`
+
+	// Mode is AllowValidOnly to reject connections without a valid client
+	// certificate, or AllowInsecureFallback to also accept connections
+	// without one.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=AllowValidOnly
+	Mode gatewayv1.FrontendValidationModeType `json:"mode,omitempty"`
+}
+
+// HTTPProxyTLS configures the TLS certificate of an HTTPProxy.
+type HTTPProxyTLS struct {
+	// CertificateRef references a Secret of type `kubernetes.io/tls` in the
+	// namespace of the HTTPProxy. The certificate must cover every hostname of
+	// the proxy, and is kept in sync as the Secret changes.
+	//
+	// +kubebuilder:validation:Required
+	CertificateRef corev1.LocalObjectReference `json:"certificateRef"`
+}
+
+// TLSVersion is a TLS protocol version.
+//
+// +kubebuilder:validation:Enum="1.2";"1.3"
+type TLSVersion string
+
+const (
+	TLSVersion12 TLSVersion = "1.2"
+	TLSVersion13 TLSVersion = "1.3"
+)
+
+// ALPNProtocol is an application protocol negotiated with ALPN.
+//
+// +kubebuilder:validation:Enum=h2;"http/1.1"
+type ALPNProtocol string
+
+const (
+	ALPNProtocolHTTP2  ALPNProtocol = "h2"
+	ALPNProtocolHTTP11 ALPNProtocol = "http/1.1"
+)
+
+// TLSPolicy configures how TLS connections from clients are negotiated.
+type TLSPolicy struct {
+	// MinVersion is the minimum TLS version clients must use.
+	//
+	// +kubebuilder:validation:Optional
+	MinVersion *TLSVersion `json:"minVersion,omitempty"`
+
+	// CipherSuites are the cipher suites that may be negotiated with TLS 1.2
+	// clients, for example `ECDHE-RSA-AES128-GCM-SHA256`. Cipher suites that
+	// are not permitted by the platform are not applied.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=set
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+
+	// ALPNProtocols are the application protocols negotiated with clients, in
+	// order of preference.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=2
+	// +listType=set
+	ALPNProtocols []ALPNProtocol `json:"alpnProtocols,omitempty"`
 }
 
 // HTTPProxyRule defines semantics for matching an HTTP request based on
@@ -89,6 +256,10 @@ type HTTPProxyRule struct {
 	// Filters define the filters that are applied to requests that match
 	// this rule.
 	//
+	// RequestHeaderModifier and ResponseHeaderModifier filters add, set or
+	// remove headers. The Host request header may only be overridden with
+	// `set`, and response headers managed by the platform may not be modified.
+	//
 	// See documentation for the `filters` field in the `HTTPRouteRule` type at
 	// https://gateway-api.sigs.k8s.io/reference/spec/#httprouterule
 	//
@@ -98,20 +269,196 @@ type HTTPProxyRule struct {
 	// +kubebuilder:validation:XValidation:message="ResponseHeaderModifier filter cannot be repeated",rule="self.filter(f, f.type == 'ResponseHeaderModifier').size() <= 1"
 	// +kubebuilder:validation:XValidation:message="RequestRedirect filter cannot be repeated",rule="self.filter(f, f.type == 'RequestRedirect').size() <= 1"
 	// +kubebuilder:validation:XValidation:message="URLRewrite filter cannot be repeated",rule="self.filter(f, f.type == 'URLRewrite').size() <= 1"
+	// +kubebuilder:validation:XValidation:message="CORS filter cannot be repeated",rule="self.filter(f, f.type == 'CORS').size() <= 1"
 	Filters []gatewayv1.HTTPRouteFilter `json:"filters,omitempty"`
 
+	// Mirror sends a copy of the rule's requests to a secondary backend.
+	// Responses from the mirror backend are ignored.
+	//
+	// Mirroring requires the rule to have backends.
+	//
+	// +kubebuilder:validation:Optional
+	Mirror *HTTPProxyRequestMirror `json:"mirror,omitempty"`
+
 	// Backends defines the backend(s) where matching requests should be
 	// sent.
 	//
-	// Note: While this field is a list, only a single element is permitted at
-	// this time due to underlying Gateway limitations. Once addressed, MaxItems
-	// will be increased to allow for multiple backends on any given route.
+	// Exactly one primary backend is permitted, unless every primary backend
+	// sets a weight, in which case requests are split between them in
+	// proportion to their weights. Additional backends must have the Backup
+	// role, and only receive traffic when the primary backend fails its health
+	// checks, or the Canary role, and receive the share of traffic configured
+	// by `trafficPolicy.canary`. Rules with a `trafficPolicy.blueGreen` have
+	// backends with the Blue and Green roles instead.
 	//
 	// +kubebuilder:validation:MinItems=0
-	// +kubebuilder:validation:MaxItems=1
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:XValidation:message="Exactly one primary backend is required unless every primary backend sets a weight",rule="self.size() == 0 || self.filter(b, !has(b.role) || b.role == 'Primary').size() == 1 || self.filter(b, !has(b.role) || b.role == 'Primary').all(b, has(b.weight))"
 	Backends []HTTPProxyRuleBackend `json:"backends,omitempty"`
+
+	// TrafficPolicy configures how requests are split between the backends of
+	// the rule.
+	//
+	// +kubebuilder:validation:Optional
+	TrafficPolicy *HTTPProxyTrafficPolicy `json:"trafficPolicy,omitempty"`
+
+	// HealthCheck configures active health checking of the rule's backends.
+	//
+	// A health check is required when the rule has backup backends.
+	//
+	// +kubebuilder:validation:Optional
+	HealthCheck *HTTPProxyHealthCheck `json:"healthCheck,omitempty"`
+
+	// ResponseHeaders modifies the headers of responses returned by this rule.
+	//
+	// Entries override any entry for the same header in the HTTPProxy's
+	// `spec.responseHeaders`. A ResponseHeaderModifier filter on the rule takes
+	// precedence over both.
+	//
+	// +kubebuilder:validation:Optional
+	ResponseHeaders *gatewayv1.HTTPHeaderFilter `json:"responseHeaders,omitempty"`
+
+	// Timeouts configures the timeouts of requests matching the rule. Timeouts
+	// that are not set default to the timeouts configured for the platform.
+	//
+	// See documentation for the `timeouts` field in the `HTTPRouteRule` type at
+	// https://gateway-api.sigs.k8s.io/reference/spec/#httprouterule
+	//
+	// +kubebuilder:validation:Optional
+	Timeouts *gatewayv1.HTTPRouteTimeouts `json:"timeouts,omitempty"`
+
+	// Retry configures retrying requests matching the rule that fail with one
+	// of the listed status codes or a connection error. When attempts or
+	// backoff are not set, they default to the values configured for the
+	// platform.
+	//
+	// See documentation for the `retry` field in the `HTTPRouteRule` type at
+	// https://gateway-api.sigs.k8s.io/reference/spec/#httprouterule
+	//
+	// +kubebuilder:validation:Optional
+	Retry *gatewayv1.HTTPRouteRetry `json:"retry,omitempty"`
 }
 
+// HTTPProxyRequestMirror configures mirroring of a rule's requests to a
+// secondary backend.
+type HTTPProxyRequestMirror struct {
+	// Endpoint of the backend that requests are mirrored to. Must be a valid
+	// URL.
+	//
+	// Supports http and https protocols, IPs or DNS addresses in the host, and
+	// custom ports. HTTPS endpoints must use a DNS address, which is used to
+	// validate the certificate of the backend.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="isURL(self) && url(self).getScheme() in ['http', 'https']", message="endpoint must be an http or https URL"
+	Endpoint string `json:"endpoint"`
+
+	// Percent of requests that are mirrored. Defaults to 100.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percent *int32 `json:"percent,omitempty"`
+}
+
+// HTTPProxyBackendProtocol is the HTTP version used to connect to a backend.
+//
+// +kubebuilder:validation:Enum=HTTP1;H2C
+type HTTPProxyBackendProtocol string
+
+const (
+	// HTTPProxyBackendProtocolHTTP1 backends are reached with HTTP/1.1.
+	HTTPProxyBackendProtocolHTTP1 HTTPProxyBackendProtocol = "HTTP1"
+
+	// HTTPProxyBackendProtocolH2C backends are reached with HTTP/2 without TLS.
+	HTTPProxyBackendProtocolH2C HTTPProxyBackendProtocol = "H2C"
+)
+
+// HTTPProxyBackendRole is the role of a backend within a rule.
+//
+// +kubebuilder:validation:Enum=Primary;Backup;Canary;Blue;Green
+type HTTPProxyBackendRole string
+
+const (
+	// HTTPProxyBackendRolePrimary backends receive traffic while healthy.
+	HTTPProxyBackendRolePrimary HTTPProxyBackendRole = "Primary"
+
+	// HTTPProxyBackendRoleBackup backends receive traffic when the primary
+	// backend is unhealthy.
+	HTTPProxyBackendRoleBackup HTTPProxyBackendRole = "Backup"
+
+	// HTTPProxyBackendRoleCanary backends receive the share of traffic
+	// configured by the rule's canary traffic policy.
+	HTTPProxyBackendRoleCanary HTTPProxyBackendRole = "Canary"
+
+	// HTTPProxyBackendRoleBlue backends form the blue set of backends of the
+	// rule's blue/green traffic policy.
+	HTTPProxyBackendRoleBlue HTTPProxyBackendRole = "Blue"
+
+	// HTTPProxyBackendRoleGreen backends form the green set of backends of the
+	// rule's blue/green traffic policy.
+	HTTPProxyBackendRoleGreen HTTPProxyBackendRole = "Green"
+)
+
+// HTTPProxyTrafficPolicy configures how requests are split between the
+// backends of a rule.
+type HTTPProxyTrafficPolicy struct {
+	// Canary sends a share of the rule's requests to the backend with the
+	// Canary role, and the remaining requests to the primary backend.
+	//
+	// +kubebuilder:validation:Optional
+	Canary *HTTPProxyCanary `json:"canary,omitempty"`
+
+	// BlueGreen sends the rule's requests to the active set of backends, out of
+	// the backends with the Blue and Green roles.
+	//
+	// +kubebuilder:validation:Optional
+	BlueGreen *HTTPProxyBlueGreen `json:"blueGreen,omitempty"`
+}
+
+// HTTPProxyBlueGreen configures a blue/green deployment of a rule's backends.
+type HTTPProxyBlueGreen struct {
+	// Active is the set of backends that receives the rule's requests.
+	//
+	// Changing the active set swaps every request to the other set at once.
+	// The backends of the previously active set keep serving the requests and
+	// connections in flight for the drain timeout, and no new requests are sent
+	// to them.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Blue;Green
+	Active HTTPProxyBackendRole `json:"active"`
+
+	// DrainTimeout is how long the backends of the previously active set are
+	// kept after a swap, so that requests in flight can complete. Defaults to
+	// 30s.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="30s"
+	DrainTimeout *gatewayv1.Duration `json:"drainTimeout,omitempty"`
+}
+
+// HTTPProxyCanary configures a canary rollout of a rule's backends.
+type HTTPProxyCanary struct {
+	// Percentage of requests sent to the canary backend.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage int32 `json:"percentage"`
+
+	// Headers routes requests that match every header to the canary backend,
+	// regardless of the percentage, for example to let testers opt in to a
+	// canary with a cookie or header.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=atomic
+	Headers []gatewayv1.HTTPHeaderMatch `json:"headers,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:message="H2C may only be used with http endpoints",rule="!has(self.protocol) || self.protocol != 'H2C' || !has(self.endpoint) || !isURL(self.endpoint) || url(self.endpoint).getScheme() == 'http'"
 type HTTPProxyRuleBackend struct {
 	// Endpoint for the backend. Must be a valid URL.
 	//
@@ -119,6 +466,8 @@ type HTTPProxyRuleBackend struct {
 	// ports, and paths.
 	//
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="isURL(self) && url(self).getScheme() in ['http', 'https']", message="endpoint must be an http or https URL"
 	Endpoint string `json:"endpoint,omitempty"`
 
 	// Connector references the Connector that should be used for this backend.
@@ -137,6 +486,56 @@ type HTTPProxyRuleBackend struct {
 	// +kubebuilder:validation:Optional
 	TLS *HTTPProxyBackendTLS `json:"tls,omitempty"`
 
+	// Protocol is the HTTP version used to connect to the backend. Defaults to
+	// HTTP1, where `https` endpoints may negotiate HTTP/2 during the TLS
+	// handshake.
+	//
+	// H2C connects to `http` endpoints with HTTP/2 without TLS, for example to
+	// reach gRPC servers.
+	//
+	// +kubebuilder:validation:Optional
+	Protocol HTTPProxyBackendProtocol `json:"protocol,omitempty"`
+
+	// Role of the backend within the rule. Defaults to Primary.
+	//
+	// Backends of rules with more than one backend must use a DNS hostname in
+	// their endpoint, share a scheme, and may not define filters or a
+	// connector.
+	//
+	// +kubebuilder:validation:Optional
+	Role HTTPProxyBackendRole `json:"role,omitempty"`
+
+	// Weight of the backend, relative to the weights of the other primary
+	// backends of the rule, or of the other backends of the same set in rules
+	// with a blue/green traffic policy. A backend with a weight of 0 receives
+	// no requests.
+	//
+	// Weights may not be set on backup backends, or in rules with a canary
+	// traffic policy, where the canary percentage determines the split.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000
+	Weight *int32 `json:"weight,omitempty"`
+
+	// LoadBalancer configures how requests are balanced across the endpoints
+	// of this backend.
+	//
+	// When not set, the gateway default is used.
+	//
+	// +kubebuilder:validation:Optional
+	LoadBalancer *HTTPProxyBackendLoadBalancer `json:"loadBalancer,omitempty"`
+
+	// HealthCheck configures active health checking of this backend. A backend
+	// that fails its health checks stops receiving traffic until it passes them
+	// again.
+	//
+	// Backends of rules with backup backends are health checked with the rule's
+	// healthCheck instead, and connector backends may not be health checked.
+	//
+	// +kubebuilder:validation:Optional
+	HealthCheck *HTTPProxyHealthCheck `json:"healthCheck,omitempty"`
+
 	// Filters defined at this level should be executed if and only if the
 	// request is being forwarded to the backend defined here.
 	//
@@ -167,6 +566,143 @@ type HTTPProxyBackendTLS struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=253
 	Hostname *string `json:"hostname,omitempty"`
+
+	// CACertificateRef references a ConfigMap or Secret in the namespace of the
+	// HTTPProxy with the PEM encoded CA certificates that the certificate of
+	// the backend is validated against, in the `ca.crt` key. Use this to proxy
+	// to backends with certificates issued by a private CA.
+	//
+	// When not set, the certificate is validated against the system CA
+	// certificates.
+	//
+	// +kubebuilder:validation:Optional
+	CACertificateRef *HTTPProxyCACertificateReference `json:"caCertificateRef,omitempty"`
+
+	// SubjectAltNames are the Subject Alternative Names that the certificate of
+	// the backend is validated against. The certificate must contain at least
+	// one of them.
+	//
+	// When set, the hostname is only used for SNI, and is not required to be
+	// present in the certificate.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=5
+	SubjectAltNames []gatewayv1.SubjectAltName `json:"subjectAltNames,omitempty"`
+}
+
+// HTTPProxyCACertificateReference references a ConfigMap or Secret with PEM
+// encoded CA certificates in the `ca.crt` key.
+type HTTPProxyCACertificateReference struct {
+	// Kind is the kind of the referenced object.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// +kubebuilder:default=ConfigMap
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the referenced object.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+}
+
+// HTTPProxyHealthCheck configures active HTTP health checks for the backends
+// of a rule.
+type HTTPProxyHealthCheck struct {
+	// Path requested when health checking a backend.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="/"
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+
+	// Interval between health checks.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="10s"
+	Interval *gatewayv1.Duration `json:"interval,omitempty"`
+
+	// Timeout to wait for a health check response.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1s"
+	Timeout *gatewayv1.Duration `json:"timeout,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failed health checks
+	// before a backend is considered unhealthy.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	UnhealthyThreshold *uint32 `json:"unhealthyThreshold,omitempty"`
+
+	// HealthyThreshold is the number of consecutive successful health checks
+	// before an unhealthy backend is considered healthy again.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	HealthyThreshold *uint32 `json:"healthyThreshold,omitempty"`
+}
+
+// HTTPProxyBackendLoadBalancerType is the load balancing algorithm used for a
+// backend.
+//
+// +kubebuilder:validation:Enum=RoundRobin;LeastRequest;RingHash
+type HTTPProxyBackendLoadBalancerType string
+
+const (
+	// HTTPProxyBackendLoadBalancerRoundRobin selects endpoints in turn.
+	HTTPProxyBackendLoadBalancerRoundRobin HTTPProxyBackendLoadBalancerType = "RoundRobin"
+
+	// HTTPProxyBackendLoadBalancerLeastRequest prefers endpoints with the fewest
+	// active requests.
+	HTTPProxyBackendLoadBalancerLeastRequest HTTPProxyBackendLoadBalancerType = "LeastRequest"
+
+	// HTTPProxyBackendLoadBalancerRingHash consistently maps requests to
+	// endpoints based on a hash key.
+	HTTPProxyBackendLoadBalancerRingHash HTTPProxyBackendLoadBalancerType = "RingHash"
+)
+
+// HTTPProxyBackendLoadBalancer contains load balancing configuration for a
+// backend.
+//
+// +kubebuilder:validation:XValidation:message="hashKey may only be set when type is RingHash",rule="!has(self.hashKey) || self.type == 'RingHash'"
+type HTTPProxyBackendLoadBalancer struct {
+	// Type is the load balancing algorithm.
+	//
+	// +kubebuilder:validation:Required
+	Type HTTPProxyBackendLoadBalancerType `json:"type"`
+
+	// HashKey selects the request attribute hashed by the RingHash algorithm.
+	//
+	// When not set, the client IP address is used.
+	//
+	// +kubebuilder:validation:Optional
+	HashKey *HTTPProxyBackendHashKey `json:"hashKey,omitempty"`
+}
+
+// HTTPProxyBackendHashKey selects the request attribute used as a hash key.
+//
+// +kubebuilder:validation:XValidation:message="Exactly one of header or cookie must be set",rule="has(self.header) != has(self.cookie)"
+type HTTPProxyBackendHashKey struct {
+	// Header is the name of a request header to hash.
+	//
+	// +kubebuilder:validation:Optional
+	Header *gatewayv1.HTTPHeaderName `json:"header,omitempty"`
+
+	// Cookie is the name of a request cookie to hash.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Cookie *string `json:"cookie,omitempty"`
 }
 
 // ConnectorReference references a Connector by name.
@@ -177,9 +713,11 @@ type ConnectorReference struct {
 	Name string `json:"name"`
 }
 
-// HostnameStatus captures the per-hostname verification and DNS programming status.
-// Each hostname configured on an HTTPProxy has a corresponding entry tracking
-// its lifecycle from domain ownership verification through DNS record creation.
+// HostnameStatus captures the per-hostname verification, DNS, certificate and
+// routing status. Each hostname configured on an HTTPProxy has a corresponding
+// entry tracking its lifecycle from domain ownership verification through DNS
+// record creation, certificate issuance and route programming, so that the
+// hostname blocking the HTTPProxy from being programmed can be identified.
 type HostnameStatus struct {
 	// Hostname is the fully qualified domain name being tracked.
 	// Must be a valid RFC 1123 hostname without a trailing dot.
@@ -188,7 +726,8 @@ type HostnameStatus struct {
 	Hostname string `json:"hostname"`
 
 	// Conditions contains the current status conditions for this hostname.
-	// Standard condition types include Verified and DNSRecordProgrammed.
+	// Standard condition types include Verified, Available,
+	// DNSRecordProgrammed, CertificateReady and RouteProgrammed.
 	//
 	// +listType=map
 	// +listMapKey=type
@@ -233,6 +772,53 @@ type HTTPProxyStatus struct {
 	// +optional
 	HostnameStatuses []HostnameStatus `json:"hostnameStatuses,omitempty"`
 
+	// HostnameCount is the number of hostnames in HostnameStatuses.
+	//
+	// +optional
+	HostnameCount int32 `json:"hostnameCount,omitempty"`
+
+	// VerifiedHostnameCount is the number of hostnames whose ownership has been
+	// verified.
+	//
+	// +optional
+	VerifiedHostnameCount int32 `json:"verifiedHostnameCount,omitempty"`
+
+	// Readiness breaks down the gates that must all be satisfied before the
+	// `Ready` condition is set to True, including which hostnames or route
+	// parents are still pending for each gate.
+	//
+	// +optional
+	Readiness *HTTPProxyReadiness `json:"readiness,omitempty"`
+
+	// Backends lists the addresses resolved for backend hostnames. It is only
+	// populated when the operator resolves backend hostnames into IP addresses.
+	//
+	// +listType=atomic
+	// +optional
+	Backends []HTTPProxyBackendStatus `json:"backends,omitempty"`
+
+	// BlueGreen describes the active and draining backend sets of the rules
+	// with a blue/green traffic policy.
+	//
+	// +listType=atomic
+	// +optional
+	BlueGreen []HTTPProxyBlueGreenStatus `json:"blueGreen,omitempty"`
+
+	// BackendHealth reports the health of the endpoints of the rules whose
+	// backends are health checked, as observed by the gateway. It is only
+	// reported when the platform collects data plane health.
+	//
+	// +optional
+	BackendHealth *HTTPProxyBackendHealth `json:"backendHealth,omitempty"`
+
+	// Warnings lists configurations in the spec that are valid, but likely to
+	// be unintended.
+	//
+	// +listType=atomic
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	Warnings []Warning `json:"warnings,omitempty"`
+
 	// Conditions describe the current conditions of the HTTPProxy.
 	//
 	// +listType=map
@@ -240,6 +826,173 @@ type HTTPProxyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// HTTPProxyBackendStatus describes the resolved addresses of a backend.
+type HTTPProxyBackendStatus struct {
+	// RuleIndex is the index of the rule the backend belongs to.
+	//
+	// +kubebuilder:validation:Required
+	RuleIndex int32 `json:"ruleIndex"`
+
+	// BackendIndex is the index of the backend within the rule.
+	//
+	// +kubebuilder:validation:Required
+	BackendIndex int32 `json:"backendIndex"`
+
+	// Endpoint is the endpoint of the backend.
+	//
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+
+	// Addresses are the IP addresses the backend hostname resolved to.
+	//
+	// +listType=atomic
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+
+	// LastResolvedTime is when the backend hostname was last resolved.
+	//
+	// +optional
+	LastResolvedTime *metav1.Time `json:"lastResolvedTime,omitempty"`
+}
+
+// HTTPProxyBlueGreenStatus describes the backend sets of a rule with a
+// blue/green traffic policy.
+type HTTPProxyBlueGreenStatus struct {
+	// RuleIndex is the index of the rule.
+	//
+	// +kubebuilder:validation:Required
+	RuleIndex int32 `json:"ruleIndex"`
+
+	// Active is the set of backends that receives the rule's requests.
+	//
+	// +kubebuilder:validation:Required
+	Active HTTPProxyBackendRole `json:"active"`
+
+	// Draining is the previously active set of backends, while it is kept to
+	// complete the requests in flight after a swap.
+	//
+	// +optional
+	Draining HTTPProxyBackendRole `json:"draining,omitempty"`
+
+	// SwapTime is when the active set of backends last changed.
+	//
+	// +optional
+	SwapTime *metav1.Time `json:"swapTime,omitempty"`
+}
+
+// HTTPProxyBackendHealth reports the health of the endpoints of the health
+// checked rules of an HTTPProxy.
+type HTTPProxyBackendHealth struct {
+	// ObservedTime is when the health of the endpoints was last collected.
+	//
+	// +kubebuilder:validation:Required
+	ObservedTime metav1.Time `json:"observedTime"`
+
+	// Rules lists the health of the endpoints of each health checked rule.
+	//
+	// +listType=atomic
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Rules []HTTPProxyRuleHealth `json:"rules,omitempty"`
+}
+
+// HTTPProxyRuleHealth is the health of the endpoints of the backends of a
+// rule, summed across the data planes of the gateway.
+type HTTPProxyRuleHealth struct {
+	// RuleIndex is the index of the rule.
+	//
+	// +kubebuilder:validation:Required
+	RuleIndex int32 `json:"ruleIndex"`
+
+	// HealthyEndpoints is the number of endpoints that pass their health
+	// checks.
+	//
+	// +kubebuilder:validation:Required
+	HealthyEndpoints int32 `json:"healthyEndpoints"`
+
+	// TotalEndpoints is the number of endpoints of the rule's backends.
+	//
+	// +kubebuilder:validation:Required
+	TotalEndpoints int32 `json:"totalEndpoints"`
+
+	// PrimaryHealthyEndpoints is the number of endpoints of the primary
+	// backends of a rule with backup backends that pass their health checks
+	// on every data plane. It is only reported when the gateway reports the
+	// health of each endpoint.
+	//
+	// +optional
+	PrimaryHealthyEndpoints *int32 `json:"primaryHealthyEndpoints,omitempty"`
+
+	// BackupHealthyEndpoints is the number of endpoints of the backup backends
+	// of a rule with backup backends that pass their health checks on every
+	// data plane. It is only reported when the gateway reports the health of
+	// each endpoint.
+	//
+	// +optional
+	BackupHealthyEndpoints *int32 `json:"backupHealthyEndpoints,omitempty"`
+}
+
+// HTTPProxyReadiness describes the state of each readiness gate of an
+// HTTPProxy.
+type HTTPProxyReadiness struct {
+	// Gates lists the readiness gates evaluated for the HTTPProxy.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Gates []HTTPProxyReadinessGate `json:"gates,omitempty"`
+}
+
+// HTTPProxyReadinessGate captures whether a single readiness gate is
+// satisfied.
+type HTTPProxyReadinessGate struct {
+	// Name of the readiness gate.
+	//
+	// +kubebuilder:validation:Required
+	Name HTTPProxyReadinessGateName `json:"name"`
+
+	// Status is True when the gate is satisfied.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status metav1.ConditionStatus `json:"status"`
+
+	// Pending lists the hostnames or route parents that have not yet satisfied
+	// the gate.
+	//
+	// +optional
+	Pending []string `json:"pending,omitempty"`
+
+	// Message is a human readable description of the gate state.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Message string `json:"message,omitempty"`
+}
+
+// HTTPProxyReadinessGateName is the name of an HTTPProxy readiness gate.
+//
+// +kubebuilder:validation:Enum=GatewayProgrammed;CertificatesReady;DNSRecordsProgrammed;RoutesAccepted
+type HTTPProxyReadinessGateName string
+
+const (
+	// HTTPProxyReadinessGateGatewayProgrammed is satisfied when the generated
+	// Gateway has been programmed.
+	HTTPProxyReadinessGateGatewayProgrammed HTTPProxyReadinessGateName = "GatewayProgrammed"
+
+	// HTTPProxyReadinessGateCertificatesReady is satisfied when certificates
+	// have been issued for all HTTPS hostnames.
+	HTTPProxyReadinessGateCertificatesReady HTTPProxyReadinessGateName = "CertificatesReady"
+
+	// HTTPProxyReadinessGateDNSRecordsProgrammed is satisfied when DNS records
+	// have been programmed for all hostnames using Datum-managed DNS.
+	HTTPProxyReadinessGateDNSRecordsProgrammed HTTPProxyReadinessGateName = "DNSRecordsProgrammed"
+
+	// HTTPProxyReadinessGateRoutesAccepted is satisfied when every parent of the
+	// generated HTTPRoute has accepted the route.
+	HTTPProxyReadinessGateRoutesAccepted HTTPProxyReadinessGateName = "RoutesAccepted"
+)
+
 const (
 	// This condition is true when the HTTPProxy configuration has been determined
 	// to be valid, and can be programmed into the underlying Gateway resources.
@@ -264,6 +1017,48 @@ const (
 
 	// This condition is true when all HTTPS hostnames have ready TLS certificates.
 	HTTPProxyConditionCertificatesReady = "CertificatesReady"
+
+	// This condition is present when one or more rules have backup backends,
+	// and is true while the traffic of a rule has failed over to its backup
+	// backends because no endpoint of its primary backends passes its health
+	// checks. It is unknown until the gateway reports the health of the
+	// endpoints of the rules.
+	HTTPProxyConditionBackendFailover = "BackendFailover"
+
+	// This condition is present when one or more rules health check their
+	// backends, and is true when the gateway has accepted every health check.
+	// It does not reflect the health of the backends, which is reported by
+	// the BackendsHealthy condition.
+	HTTPProxyConditionHealthChecksProgrammed = "HealthChecksProgrammed"
+
+	// This condition is present when one or more rules health check their
+	// backends and the platform collects data plane health. It is true when
+	// the backends of every health checked rule have an endpoint that passes
+	// its health checks.
+	HTTPProxyConditionBackendsHealthy = "BackendsHealthy"
+
+	// This condition is present when the platform resolves the hostnames of
+	// backends into IP addresses, and is false while the hostnames of one or
+	// more backends cannot be resolved. Those backends are resolved by the
+	// gateway in the meantime.
+	HTTPProxyConditionBackendsResolved = "BackendsResolved"
+
+	// This condition is true when every readiness gate listed in
+	// `status.readiness` is satisfied.
+	HTTPProxyConditionReady = "Ready"
+
+	// This condition is present when `spec.paused` is set, and is true once
+	// requests are answered with the maintenance response.
+	HTTPProxyConditionPaused = "Paused"
+
+	// This condition is present and true when the data plane rejected the
+	// downstream EnvoyPatchPolicy generated for the HTTPProxy, which was rolled
+	// back to the last programmed patch.
+	HTTPProxyConditionPolicyProgrammingFailed = "PolicyProgrammingFailed"
+
+	// This condition is present and true while changes to the HTTPProxy are
+	// paused because the API server of the edge control plane is failing.
+	HTTPProxyConditionDownstreamDegraded = "DownstreamDegraded"
 )
 
 const (
@@ -272,6 +1067,12 @@ const (
 	// False when one or more hostnames failed DNS record creation, and omitted
 	// when no hostnames use Datum-managed DNS.
 	HTTPProxyConditionDNSRecordsProgrammed = "DNSRecordsProgrammed"
+
+	// HTTPProxyConditionDNSRecordsResolvable is True when public resolvers
+	// answer the hostnames of the gateway with its addresses, and False while
+	// the DNS records are propagating. It is omitted when DNS records are not
+	// verified.
+	HTTPProxyConditionDNSRecordsResolvable = "DNSRecordsResolvable"
 )
 
 // Per-hostname condition types (used in HostnameStatus.Conditions).
@@ -290,6 +1091,38 @@ const (
 	// HostnameConditionCertificateReady tracks whether a TLS certificate has been
 	// provisioned for this hostname (cert-manager Certificate in the downstream cluster).
 	HostnameConditionCertificateReady = "CertificateReady"
+
+	// HostnameConditionRouteProgrammed tracks whether the Gateway listeners
+	// serving this hostname have been programmed with the routes of the
+	// HTTPProxy.
+	HostnameConditionRouteProgrammed = "RouteProgrammed"
+)
+
+// Reasons for HostnameConditionVerified.
+const (
+	// HostnameVerifiedReasonVerified indicates the ownership of the domain of
+	// the hostname has been verified.
+	HostnameVerifiedReasonVerified = "Verified"
+
+	// HostnameVerifiedReasonUnverified indicates the ownership of the domain of
+	// the hostname has not been verified. Check the status of the Domains in
+	// the same namespace.
+	HostnameVerifiedReasonUnverified = "Unverified"
+)
+
+// Reasons for HostnameConditionRouteProgrammed.
+const (
+	// RouteProgrammedReasonProgrammed indicates the listeners serving the
+	// hostname are programmed and have routes attached.
+	RouteProgrammedReasonProgrammed = "Programmed"
+
+	// RouteProgrammedReasonPending indicates the listeners serving the hostname
+	// have not been programmed yet.
+	RouteProgrammedReasonPending = "Pending"
+
+	// RouteProgrammedReasonNoAttachedRoutes indicates the listeners serving the
+	// hostname are programmed, but no route is attached to them.
+	RouteProgrammedReasonNoAttachedRoutes = "NoAttachedRoutes"
 )
 
 // Reasons for HostnameConditionCertificateReady.
@@ -315,6 +1148,10 @@ const (
 	// HostnameAvailableReasonInUse indicates the hostname is already claimed by
 	// another Gateway or HTTPProxy.
 	HostnameAvailableReasonInUse = "InUse"
+
+	// HostnameAvailableReasonBlocked indicates the hostname matches a
+	// HostnameBlocklist.
+	HostnameAvailableReasonBlocked = "Blocked"
 )
 
 // Reasons for HostnameConditionDNSRecordProgrammed.
@@ -382,6 +1219,21 @@ const (
 	DNSRecordsProgrammedReasonPartialFailure = "PartialFailure"
 )
 
+// Reasons for HTTPProxyConditionDNSRecordsResolvable.
+const (
+	// DNSRecordsResolvableReasonResolvable indicates that every resolver
+	// answers the hostnames with the addresses of the gateway.
+	DNSRecordsResolvableReasonResolvable = "Resolvable"
+
+	// DNSRecordsResolvableReasonPropagating indicates that one or more
+	// resolvers don't answer a hostname with the addresses of the gateway yet.
+	DNSRecordsResolvableReasonPropagating = "Propagating"
+
+	// DNSRecordsResolvableReasonLookupFailed indicates that the hostnames
+	// could not be looked up on one or more resolvers.
+	DNSRecordsResolvableReasonLookupFailed = "LookupFailed"
+)
+
 // Reasons for HTTPProxyConditionCertificatesReady.
 const (
 	// CertificatesReadyReasonAllCertificatesReady indicates all HTTPS hostnames have ready certificates.
@@ -402,6 +1254,67 @@ const (
 	// HTTPProxyReasonProgrammed indicates that the HTTP proxy has been programmed.
 	HTTPProxyReasonProgrammed = "Programmed"
 
+	// HTTPProxyReasonReady indicates that all readiness gates of the HTTP proxy
+	// are satisfied.
+	HTTPProxyReasonReady = "Ready"
+
+	// HTTPProxyReasonReadinessGatesPending indicates that one or more readiness
+	// gates of the HTTP proxy are not yet satisfied.
+	HTTPProxyReasonReadinessGatesPending = "ReadinessGatesPending"
+
+	// HTTPProxyReasonPaused indicates that requests to the HTTP proxy are
+	// answered with the maintenance response.
+	HTTPProxyReasonPaused = "Paused"
+
+	// HTTPProxyReasonCircuitOpen indicates that changes to the HTTP proxy are
+	// paused until the edge control plane recovers.
+	HTTPProxyReasonCircuitOpen = "CircuitOpen"
+
+	// HTTPProxyReasonFailoverConfigured indicates that backup backends have been
+	// configured for one or more rules, and that the health of their endpoints
+	// is not known yet.
+	HTTPProxyReasonFailoverConfigured = "FailoverConfigured"
+
+	// HTTPProxyReasonFailedOver indicates that the traffic of one or more rules
+	// is sent to their backup backends.
+	HTTPProxyReasonFailedOver = "FailedOver"
+
+	// HTTPProxyReasonPrimaryBackendsServing indicates that the traffic of every
+	// rule with backup backends is sent to its primary backends.
+	HTTPProxyReasonPrimaryBackendsServing = "PrimaryBackendsServing"
+
+	// HTTPProxyReasonHealthChecksProgrammed indicates that the gateway has
+	// accepted the health checks of every health checked rule.
+	HTTPProxyReasonHealthChecksProgrammed = "HealthChecksProgrammed"
+
+	// HTTPProxyReasonHealthChecksPending indicates that the gateway has not yet
+	// accepted the health checks of one or more rules.
+	HTTPProxyReasonHealthChecksPending = "HealthChecksPending"
+
+	// HTTPProxyReasonHealthChecksRejected indicates that the gateway rejected
+	// the health checks of one or more rules.
+	HTTPProxyReasonHealthChecksRejected = "HealthChecksRejected"
+
+	// HTTPProxyReasonBackendsHealthy indicates that every health checked rule
+	// has a healthy endpoint.
+	HTTPProxyReasonBackendsHealthy = "BackendsHealthy"
+
+	// HTTPProxyReasonBackendsUnhealthy indicates that no endpoint of one or
+	// more health checked rules passes its health checks.
+	HTTPProxyReasonBackendsUnhealthy = "BackendsUnhealthy"
+
+	// HTTPProxyReasonBackendHealthUnknown indicates that the gateway has not
+	// reported the health of the endpoints of one or more rules.
+	HTTPProxyReasonBackendHealthUnknown = "BackendHealthUnknown"
+
+	// HTTPProxyReasonBackendsResolved indicates that the hostnames of every
+	// backend were resolved.
+	HTTPProxyReasonBackendsResolved = "BackendsResolved"
+
+	// HTTPProxyReasonResolutionFailed indicates that the hostnames of one or
+	// more backends could not be resolved.
+	HTTPProxyReasonResolutionFailed = "ResolutionFailed"
+
 	// HTTPProxyReasonConnectorMetadataApplied indicates connector metadata has been applied.
 	HTTPProxyReasonConnectorMetadataApplied = "ConnectorMetadataApplied"
 
@@ -429,14 +1342,18 @@ const (
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // An HTTPProxy builds on top of Gateway API resources to provide a more convenient
 // method to manage simple reverse proxy use cases.
 //
-// +kubebuilder:printcolumn:name="Hostname",type=string,JSONPath=`.status.hostnames[*]`
+// +kubebuilder:printcolumn:name="Hostnames",type=integer,JSONPath=`.status.hostnameCount`
+// +kubebuilder:printcolumn:name="Verified",type=integer,JSONPath=`.status.verifiedHostnameCount`
 // +kubebuilder:printcolumn:name="Programmed",type=string,JSONPath=`.status.conditions[?(@.type=="Programmed")].status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="Certificates",type=string,JSONPath=`.status.conditions[?(@.type=="CertificatesReady")].status`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:printcolumn:name="Hostname",type=string,JSONPath=`.status.hostnames[*]`,priority=1
 type HTTPProxy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IPAddressClaimSpec defines the desired state of IPAddressClaim
+type IPAddressClaimSpec struct {
+	// The class of the IP pool the address is allocated from
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="subnetClass is immutable"
+	SubnetClass string `json:"subnetClass"`
+
+	// The IP family of the address
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="ipFamily is immutable"
+	IPFamily IPFamily `json:"ipFamily"`
+
+	// The address to claim. When empty, the claim is allocated the first free
+	// address of its IP pool.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="isIP(self)", message="address must be a valid IP address"
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="address is immutable"
+	Address *string `json:"address,omitempty"`
+}
+
+// IPAddressClaimStatus defines the observed state of IPAddressClaim
+type IPAddressClaimStatus struct {
+	// The address allocated to the claim
+	Address string `json:"address,omitempty"`
+
+	// The IP pool the address is allocated from
+	IPPool string `json:"ipPool,omitempty"`
+
+	// Represents the observations of an IP address claim's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// IPAddressClaimAllocated indicates that the claim has been allocated an
+	// address
+	IPAddressClaimAllocated = "Allocated"
+)
+
+const (
+	// IPAddressClaimAllocatedReasonAllocated indicates that the claim has been
+	// allocated an address
+	IPAddressClaimAllocatedReasonAllocated = "Allocated"
+
+	// IPAddressClaimAllocatedReasonPending indicates that the claim has not
+	// been allocated an address yet
+	IPAddressClaimAllocatedReasonPending = "Pending"
+
+	// IPAddressClaimAllocatedReasonUnsupportedSubnetClass indicates that
+	// addresses of the subnet class of the claim may not be claimed
+	IPAddressClaimAllocatedReasonUnsupportedSubnetClass = "UnsupportedSubnetClass"
+
+	// IPAddressClaimAllocatedReasonNoIPPool indicates that there is no IP pool
+	// for the subnet class and IP family of the claim
+	IPAddressClaimAllocatedReasonNoIPPool = "NoIPPool"
+
+	// IPAddressClaimAllocatedReasonPoolExhausted indicates that the IP pool of
+	// the claim has no free address
+	IPAddressClaimAllocatedReasonPoolExhausted = "PoolExhausted"
+
+	// IPAddressClaimAllocatedReasonInvalidAddress indicates that the requested
+	// address can't be allocated from the IP pool of the claim
+	IPAddressClaimAllocatedReasonInvalidAddress = "InvalidAddress"
+
+	// IPAddressClaimAllocatedReasonInUse indicates that a deleted claim keeps
+	// its address while gateways reference it
+	IPAddressClaimAllocatedReasonInUse = "InUse"
+)
+
+// LocalIPAddressClaimReference references an IPAddressClaim in the same
+// namespace
+type LocalIPAddressClaimReference struct {
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// IPAddressClaim reserves a stable IP address from an IP pool, which gateways
+// reference as a named address.
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Subnet Class",type=string,JSONPath=`.spec.subnetClass`
+// +kubebuilder:printcolumn:name="Address",type=string,JSONPath=`.status.address`
+// +kubebuilder:printcolumn:name="Allocated",type=string,JSONPath=`.status.conditions[?(@.type=="Allocated")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Allocated")].reason`
+type IPAddressClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IPAddressClaimSpec `json:"spec,omitempty"`
+
+	// +kubebuilder:default={conditions:{{type:"Allocated",status:"Unknown",reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}}
+	Status IPAddressClaimStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IPAddressClaimList contains a list of IPAddressClaim
+type IPAddressClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPAddressClaim `json:"items"`
+}
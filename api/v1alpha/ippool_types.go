@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IPPoolSpec defines the desired state of IPPool
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.defaultPrefixLength) || self.defaultPrefixLength <= self.blockPrefixLength", message="defaultPrefixLength must not be longer than blockPrefixLength"
+type IPPoolSpec struct {
+	// The class of subnets allocated from the pool
+	//
+	// +kubebuilder:validation:Required
+	SubnetClass string `json:"subnetClass"`
+
+	// The IP family of the pool
+	//
+	// +kubebuilder:validation:Required
+	IPFamily IPFamily `json:"ipFamily"`
+
+	// The CIDRs that subnets are allocated from. CIDRs are used in order, and
+	// must be of the IP family of the pool.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.all(c, isCIDR(c))", message="cidrs must be valid CIDRs"
+	// +listType=set
+	CIDRs []string `json:"cidrs"`
+
+	// The prefix length of the smallest subnet allocated from the pool. A CIDR
+	// of the pool may hold at most 65536 subnets of this length.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=128
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="blockPrefixLength is immutable"
+	BlockPrefixLength int32 `json:"blockPrefixLength"`
+
+	// The prefix length of subnets that don't request one. Defaults to the
+	// block prefix length.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=128
+	DefaultPrefixLength *int32 `json:"defaultPrefixLength,omitempty"`
+}
+
+// IPPoolStatus defines the observed state of IPPool
+type IPPoolStatus struct {
+	// The allocation state of each CIDR of the pool
+	CIDRs []IPPoolCIDRStatus `json:"cidrs,omitempty"`
+
+	// The prefixes allocated from the pool
+	Allocations []IPPoolAllocation `json:"allocations,omitempty"`
+
+	// The prefixes released by subnets that are not allocated to other subnets
+	// until their grace period ends
+	Reclaiming []IPPoolReclaim `json:"reclaiming,omitempty"`
+
+	// Represents the observations of a pool's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// IPPoolCIDRStatus is the allocation state of a CIDR of an IPPool.
+type IPPoolCIDRStatus struct {
+	// The CIDR
+	CIDR string `json:"cidr"`
+
+	// The allocated blocks of the CIDR, where bit i of byte i/8 is set when
+	// block i is allocated.
+	Bitmap []byte `json:"bitmap"`
+
+	// The number of blocks of the CIDR
+	Blocks int32 `json:"blocks"`
+
+	// The number of allocated blocks of the CIDR
+	AllocatedBlocks int32 `json:"allocatedBlocks"`
+}
+
+// IPPoolAllocation is a prefix allocated from an IPPool.
+type IPPoolAllocation struct {
+	// The allocated prefix
+	Prefix string `json:"prefix"`
+
+	// The subnet the prefix is allocated to
+	SubnetRef *LocalSubnetReference `json:"subnetRef,omitempty"`
+
+	// The IP address claim the prefix is allocated to
+	IPAddressClaimRef *LocalIPAddressClaimReference `json:"ipAddressClaimRef,omitempty"`
+}
+
+// IPPoolReclaim is a prefix released by a subnet of an IPPool that is kept
+// from other subnets until its grace period ends.
+type IPPoolReclaim struct {
+	// The released prefix
+	Prefix string `json:"prefix"`
+
+	// The subnet the prefix was allocated to
+	SubnetRef LocalSubnetReference `json:"subnetRef"`
+
+	// The time after which the prefix may be allocated to other subnets
+	ReclaimAfter metav1.Time `json:"reclaimAfter"`
+}
+
+const (
+	// IPPoolReady indicates that the pool is ready for allocations
+	IPPoolReady = "Ready"
+
+	// IPPoolExhausted indicates that the pool has no free prefix for a subnet
+	IPPoolExhausted = "Exhausted"
+)
+
+const (
+	// IPPoolReadyReasonReady indicates that the pool is ready for allocations
+	IPPoolReadyReasonReady = "Ready"
+
+	// IPPoolReadyReasonInvalidCIDRs indicates that a CIDR of the pool is invalid
+	IPPoolReadyReasonInvalidCIDRs = "InvalidCIDRs"
+
+	// IPPoolExhaustedReasonExhausted indicates that the pool could not
+	// allocate a prefix for a subnet
+	IPPoolExhaustedReasonExhausted = "Exhausted"
+
+	// IPPoolExhaustedReasonAvailable indicates that the pool has free prefixes
+	IPPoolExhaustedReasonAvailable = "Available"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// IPPool is the Schema for the ippools API
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Subnet Class",type=string,JSONPath=`.spec.subnetClass`
+// +kubebuilder:printcolumn:name="IP Family",type=string,JSONPath=`.spec.ipFamily`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Exhausted",type=string,JSONPath=`.status.conditions[?(@.type=="Exhausted")].status`
+type IPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IPPoolSpec   `json:"spec,omitempty"`
+	Status IPPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IPPoolList contains a list of IPPool
+type IPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPPool `json:"items"`
+}
@@ -67,10 +67,35 @@ type NetworkIPAM struct {
 
 // NetworkStatus defines the observed state of Network
 type NetworkStatus struct {
+	// ContextCount is the number of NetworkContexts of the network.
+	//
+	// +optional
+	ContextCount int32 `json:"contextCount,omitempty"`
+
+	// ReadyContextCount is the number of NetworkContexts of the network that
+	// are ready.
+	//
+	// +optional
+	ReadyContextCount int32 `json:"readyContextCount,omitempty"`
+
 	// Represents the observations of a network's current state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// NetworkReady indicates that all NetworkContexts of the network are ready
+	NetworkReady = "Ready"
+)
+
+const (
+	// NetworkReadyReasonReady indicates that the network is ready
+	NetworkReadyReasonReady = "Ready"
+
+	// NetworkReadyReasonContextsNotReady indicates that not all NetworkContexts
+	// of the network are ready
+	NetworkReadyReasonContextsNotReady = "ContextsNotReady"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".metadata.name"
@@ -80,6 +105,7 @@ type NetworkStatus struct {
 // +kubebuilder:printcolumn:name="IPAM",type="string",JSONPath=".spec.ipam.mode"
 // +kubebuilder:printcolumn:name="IPFamilies",type="string",JSONPath=".spec.ipFamilies"
 // +kubebuilder:printcolumn:name="MTU",type="integer",JSONPath=".spec.mtu"
+// +kubebuilder:printcolumn:name="Contexts",type="integer",JSONPath=".status.contextCount"
 
 // Network is the Schema for the networks API
 type Network struct {
@@ -8,10 +8,32 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// NetworkPolicyNetworkLabel selects the instances a NetworkPolicy applies to.
+// The policy is enforced on the pods in the downstream namespace of its
+// namespace whose NetworkPolicyNetworkLabel is the name of its network.
+//
+// The workload controllers that run instances set this label on the pods of
+// the instances attached to a network. Pods without the label are not
+// selected by any NetworkPolicy.
+const NetworkPolicyNetworkLabel = "networking.datumapis.com/network"
+
 // NetworkPolicySpec defines the desired state of NetworkPolicy
 type NetworkPolicySpec struct {
-	// TODO(jreese) complete this - currently the ingress rule is used directly
-	// in an instance's interface policy.
+	// The network whose instances the policy applies to. Instances are selected
+	// by the networking.datumapis.com/network label of their pods.
+	//
+	// +kubebuilder:validation:Required
+	NetworkRef LocalNetworkRef `json:"networkRef"`
+
+	// ingress is a list of ingress rules which allow traffic to the instances
+	// of the network. Traffic that no rule allows is denied, so a policy
+	// without rules denies all ingress traffic. Rules that are invalid are not
+	// enforced, and are reported in the status of the policy.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=atomic
+	Ingress []NetworkPolicyIngressRule `json:"ingress,omitempty"`
 }
 
 // See k8s network policy types for inspiration here
@@ -93,12 +115,66 @@ type IPBlock struct {
 
 // NetworkPolicyStatus defines the observed state of NetworkPolicy
 type NetworkPolicyStatus struct {
+	// The validation errors of the ingress rules that are not enforced
+	RuleErrors []NetworkPolicyRuleError `json:"ruleErrors,omitempty"`
+
+	// Represents the observations of a network policy's current state.
+	// Known condition types are: "Accepted", "Programmed"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// NetworkPolicyRuleError is a validation error of an ingress rule of a
+// NetworkPolicy.
+type NetworkPolicyRuleError struct {
+	// The index of the ingress rule
+	Index int32 `json:"index"`
+
+	// The path of the invalid field, such as spec.ingress[0].from[1].ipBlock.cidr
+	Field string `json:"field"`
+
+	// The validation error
+	Message string `json:"message"`
+}
+
+const (
+	// NetworkPolicyAccepted indicates whether all rules of the policy are valid
+	NetworkPolicyAccepted = "Accepted"
+
+	// NetworkPolicyProgrammed indicates whether the policy is enforced on the
+	// instances of its network
+	NetworkPolicyProgrammed = "Programmed"
+)
+
+const (
+	// NetworkPolicyAcceptedReasonAccepted indicates that all rules of the policy
+	// are valid
+	NetworkPolicyAcceptedReasonAccepted = "Accepted"
+
+	// NetworkPolicyAcceptedReasonInvalidRules indicates that rules of the policy
+	// are invalid, and are not enforced
+	NetworkPolicyAcceptedReasonInvalidRules = "InvalidRules"
+
+	// NetworkPolicyProgrammedReasonProgrammed indicates that the policy is
+	// enforced on the instances of its network
+	NetworkPolicyProgrammedReasonProgrammed = "Programmed"
+
+	// NetworkPolicyProgrammedReasonNetworkNotFound indicates that the network of
+	// the policy does not exist
+	NetworkPolicyProgrammedReasonNetworkNotFound = "NetworkNotFound"
+
+	// NetworkPolicyProgrammedReasonNoInstances indicates that the policy is
+	// programmed, but no instances of its network are selected yet
+	NetworkPolicyProgrammedReasonNoInstances = "NoInstances"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
 // NetworkPolicy is the Schema for the networkpolicies API
+// +kubebuilder:printcolumn:name="Network",type="string",JSONPath=".spec.networkRef.name"
+// +kubebuilder:printcolumn:name="Accepted",type="string",JSONPath=`.status.conditions[?(@.type=="Accepted")].status`
+// +kubebuilder:printcolumn:name="Programmed",type="string",JSONPath=`.status.conditions[?(@.type=="Programmed")].status`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type NetworkPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
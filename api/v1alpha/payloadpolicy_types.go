@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// PayloadPolicySpec defines the desired state of PayloadPolicy.
+//
+// +kubebuilder:validation:XValidation:rule="self.targetRefs.all(ref, (ref.group == 'gateway.networking.k8s.io' && ref.kind in ['Gateway', 'HTTPRoute']) || (ref.group == 'networking.datumapis.com' && ref.kind == 'HTTPProxy'))", message="this policy can only target a gateway.networking.k8s.io Gateway/HTTPRoute or a networking.datumapis.com HTTPProxy"
+// +kubebuilder:validation:XValidation:rule="has(self.compression) || has(self.requestBodyLimit)", message="at least one of compression or requestBodyLimit must be specified"
+type PayloadPolicySpec struct {
+	// TargetRefs are the Gateways, HTTPRoutes and HTTPProxies this policy is
+	// attached to. A sectionName selects a listener of a Gateway, or a named
+	// rule of an HTTPRoute or HTTPProxy.
+	//
+	// A target conflicts with the targets of older PayloadPolicies,
+	// RateLimitPolicies and BackendLoadBalancingPolicies that cover the same
+	// listener or rule, and with the rules of an HTTPProxy that health check or
+	// load balance their backends.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	TargetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// Compression compresses the responses to clients that accept one of the
+	// selected encodings.
+	//
+	// +kubebuilder:validation:Optional
+	Compression *PayloadCompression `json:"compression,omitempty"`
+
+	// RequestBodyLimit is the largest request body accepted, for example
+	// `10Mi`. Larger requests are rejected with a 413 status code.
+	//
+	// Requests are received in full before they are forwarded to the backends,
+	// so the limit must not be set for targets that serve streaming requests
+	// or WebSockets. The platform limits the largest value that may be set.
+	//
+	// +kubebuilder:validation:Optional
+	RequestBodyLimit *resource.Quantity `json:"requestBodyLimit,omitempty"`
+}
+
+// PayloadCompressor is an encoding responses are compressed with.
+//
+// +kubebuilder:validation:Enum=Brotli;Gzip;Zstd
+type PayloadCompressor string
+
+const (
+	PayloadCompressorBrotli PayloadCompressor = "Brotli"
+	PayloadCompressorGzip   PayloadCompressor = "Gzip"
+	PayloadCompressorZstd   PayloadCompressor = "Zstd"
+)
+
+// PayloadCompression configures the compression of responses.
+type PayloadCompression struct {
+	// Compressors are the encodings responses may be compressed with, in order
+	// of preference. The encodings of the platform are used when unset.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=3
+	// +listType=set
+	Compressors []PayloadCompressor `json:"compressors,omitempty"`
+
+	// MinSize is the size of the smallest response that is compressed, for
+	// example `1Ki`. The minimum of the platform is used when unset.
+	//
+	// +kubebuilder:validation:Optional
+	MinSize *resource.Quantity `json:"minSize,omitempty"`
+}
+
+// PayloadPolicyStatus defines the observed state of PayloadPolicy.
+type PayloadPolicyStatus struct {
+	gatewayv1alpha2.PolicyStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=plp
+
+// PayloadPolicy is the Schema for the payloadpolicies API.
+type PayloadPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   PayloadPolicySpec   `json:"spec,omitempty"`
+	Status PayloadPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PayloadPolicyList contains a list of PayloadPolicy.
+type PayloadPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PayloadPolicy `json:"items"`
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// RateLimitPolicySpec defines the desired state of RateLimitPolicy.
+//
+// +kubebuilder:validation:XValidation:rule="self.targetRefs.all(ref, (ref.group == 'gateway.networking.k8s.io' && ref.kind in ['Gateway', 'HTTPRoute']) || (ref.group == 'networking.datumapis.com' && ref.kind == 'HTTPProxy'))", message="this policy can only target a gateway.networking.k8s.io Gateway/HTTPRoute or a networking.datumapis.com HTTPProxy"
+type RateLimitPolicySpec struct {
+	// TargetRefs are the Gateways, HTTPRoutes and HTTPProxies this policy is
+	// attached to. A sectionName selects a listener of a Gateway, or a named
+	// rule of an HTTPRoute or HTTPProxy.
+	//
+	// A target conflicts with the targets of older RateLimitPolicies,
+	// PayloadPolicies and BackendLoadBalancingPolicies that cover the same
+	// listener or rule, and with the rules of an HTTPProxy that health check or
+	// load balance their backends.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	TargetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// Rules are the rate limits applied to requests. A request is limited if it
+	// exceeds the limit of any rule it matches.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Rules []RateLimitRule `json:"rules"`
+}
+
+// RateLimitUnit is the unit of time a rate limit applies to.
+//
+// +kubebuilder:validation:Enum=Second;Minute;Hour;Day
+type RateLimitUnit string
+
+const (
+	RateLimitUnitSecond RateLimitUnit = "Second"
+	RateLimitUnitMinute RateLimitUnit = "Minute"
+	RateLimitUnitHour   RateLimitUnit = "Hour"
+	RateLimitUnitDay    RateLimitUnit = "Day"
+)
+
+// RateLimitRule limits the requests that match its client selectors.
+type RateLimitRule struct {
+	// ClientSelectors select the requests the limit applies to. A request
+	// matches the rule when it matches every selector. When unset, the limit
+	// applies to all requests.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	ClientSelectors []RateLimitClientSelector `json:"clientSelectors,omitempty"`
+
+	// Limit is the number of requests permitted per unit of time.
+	//
+	// +kubebuilder:validation:Required
+	Limit RateLimitValue `json:"limit"`
+}
+
+// RateLimitValue is a number of requests per unit of time.
+type RateLimitValue struct {
+	// Requests is the number of requests permitted per unit.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Requests uint32 `json:"requests"`
+
+	// Unit is the unit of time of the limit.
+	//
+	// +kubebuilder:validation:Required
+	Unit RateLimitUnit `json:"unit"`
+}
+
+// RateLimitMatchType selects how a request attribute is matched.
+//
+// +kubebuilder:validation:Enum=Exact;Distinct
+type RateLimitMatchType string
+
+const (
+	// RateLimitMatchExact matches requests with the given value, which share a
+	// single limit.
+	RateLimitMatchExact RateLimitMatchType = "Exact"
+
+	// RateLimitMatchDistinct applies a separate limit to each distinct value.
+	RateLimitMatchDistinct RateLimitMatchType = "Distinct"
+)
+
+// RateLimitClientSelector selects requests by their headers or client address.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.headers) || has(self.sourceCIDR)", message="at least one of headers or sourceCIDR must be specified"
+type RateLimitClientSelector struct {
+	// Headers match request headers.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	Headers []RateLimitHeaderMatch `json:"headers,omitempty"`
+
+	// SourceCIDR matches the address of the client.
+	//
+	// +kubebuilder:validation:Optional
+	SourceCIDR *RateLimitSourceCIDRMatch `json:"sourceCIDR,omitempty"`
+}
+
+// RateLimitHeaderMatch matches a request header.
+//
+// +kubebuilder:validation:XValidation:rule="self.type == 'Distinct' ? !has(self.value) : has(self.value)", message="value must be set for Exact matches, and unset for Distinct matches"
+type RateLimitHeaderMatch struct {
+	// Type is Exact to match the value of the header, or Distinct to limit
+	// each value of the header separately.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Exact
+	Type RateLimitMatchType `json:"type,omitempty"`
+
+	// Name is the name of the header.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name"`
+
+	// Value is the value of the header for Exact matches.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=1024
+	Value *string `json:"value,omitempty"`
+}
+
+// RateLimitSourceCIDRMatch matches the address of the client.
+type RateLimitSourceCIDRMatch struct {
+	// Type is Exact to share the limit between all clients in the CIDR, or
+	// Distinct to limit each client address in the CIDR separately.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Exact
+	Type RateLimitMatchType `json:"type,omitempty"`
+
+	// Value is the CIDR, for example `192.0.2.0/24` or `0.0.0.0/0`.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Value string `json:"value"`
+}
+
+// RateLimitPolicyStatus defines the observed state of RateLimitPolicy.
+type RateLimitPolicyStatus struct {
+	gatewayv1alpha2.PolicyStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=rlp
+
+// RateLimitPolicy is the Schema for the ratelimitpolicies API.
+type RateLimitPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   RateLimitPolicySpec   `json:"spec,omitempty"`
+	Status RateLimitPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RateLimitPolicyList contains a list of RateLimitPolicy.
+type RateLimitPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RateLimitPolicy `json:"items"`
+}
@@ -28,17 +28,49 @@ type SubnetSpec struct {
 	// +kubebuilder:validation:Required
 	IPFamily IPFamily `json:"ipFamily"`
 
-	// The start address of a subnet
+	// The IP family policy of a subnet. A DualStack subnet is also allocated a
+	// prefix of the other IP family.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=SingleStack
+	IPFamilyPolicy IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// The start address of a subnet. When empty, the subnet is allocated the
+	// first free prefix of its IP pool.
 	//
 	// +kubebuilder:validation:Required
 	StartAddress string `json:"startAddress"`
 
-	// The prefix length of a subnet
+	// The prefix length of a subnet. When zero, the subnet is allocated a prefix
+	// of the default prefix length of its IP pool.
 	//
 	// +kubebuilder:validation:Required
 	PrefixLength int32 `json:"prefixLength"`
 }
 
+// +kubebuilder:validation:Enum=SingleStack;DualStack
+type IPFamilyPolicy string
+
+const (
+	IPFamilyPolicySingleStack IPFamilyPolicy = "SingleStack"
+	IPFamilyPolicyDualStack   IPFamilyPolicy = "DualStack"
+)
+
+// SubnetPrefix is a prefix allocated to a subnet
+type SubnetPrefix struct {
+	// The IP family of the prefix
+	IPFamily IPFamily `json:"ipFamily"`
+
+	// The start address of the prefix
+	StartAddress string `json:"startAddress"`
+
+	// The prefix length of the prefix
+	PrefixLength int32 `json:"prefixLength"`
+
+	// The IP pool the prefix is allocated from
+	IPPool string `json:"ipPool"`
+}
+
 // SubnetStatus defines the observed state of a Subnet
 type SubnetStatus struct {
 	// The start address of a subnet
@@ -47,6 +79,10 @@ type SubnetStatus struct {
 	// The prefix length of a subnet
 	PrefixLength *int32 `json:"prefixLength,omitempty"`
 
+	// The prefixes allocated to a subnet, starting with the prefix of its IP
+	// family.
+	Prefixes []SubnetPrefix `json:"prefixes,omitempty"`
+
 	// Represents the observations of a subnet's current state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -74,6 +110,22 @@ const (
 
 	// SubnetReadyReasonReady indicates that the subnet is ready to use
 	SubnetReadyReasonReady = "Ready"
+
+	// SubnetAllocatedReasonPrefixAllocated indicates that the subnet has been
+	// allocated a prefix
+	SubnetAllocatedReasonPrefixAllocated = "PrefixAllocated"
+
+	// SubnetAllocatedReasonNoIPPool indicates that there is no IP pool for the
+	// subnet class and IP family of the subnet
+	SubnetAllocatedReasonNoIPPool = "NoIPPool"
+
+	// SubnetAllocatedReasonPoolExhausted indicates that the IP pool of the
+	// subnet has no free prefix of the requested length
+	SubnetAllocatedReasonPoolExhausted = "PoolExhausted"
+
+	// SubnetAllocatedReasonInvalidPrefix indicates that the requested prefix
+	// can't be allocated from the IP pool of the subnet
+	SubnetAllocatedReasonInvalidPrefix = "InvalidPrefix"
 )
 
 // +kubebuilder:object:root=true
@@ -83,8 +135,10 @@ const (
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Programmed",type=string,JSONPath=`.status.conditions[?(@.type=="Programmed")].status`
 // +kubebuilder:printcolumn:name="Start Address",type=string,JSONPath=`.status.startAddress`
 // +kubebuilder:printcolumn:name="Prefix Length",type=string,JSONPath=`.status.prefixLength`
+// +kubebuilder:printcolumn:name="IP Family Policy",type=string,JSONPath=`.spec.ipFamilyPolicy`,priority=1
 type Subnet struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -28,6 +28,13 @@ type SubnetClaimSpec struct {
 	// +kubebuilder:validation:Required
 	IPFamily IPFamily `json:"ipFamily"`
 
+	// The IP family policy of a subnet claim. A DualStack claim is also
+	// allocated a prefix of the other IP family.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=SingleStack
+	IPFamilyPolicy IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
 	// The start address of a subnet claim
 	//
 	// +kubebuilder:validation:Optional
@@ -50,10 +57,33 @@ type SubnetClaimStatus struct {
 	// The prefix length of a subnet claim
 	PrefixLength *int32 `json:"prefixLength,omitempty"`
 
+	// The prefixes allocated to a subnet claim
+	Prefixes []SubnetPrefix `json:"prefixes,omitempty"`
+
+	// The history of prefixes allocated to a subnet claim, oldest first
+	//
+	// +kubebuilder:validation:MaxItems=16
+	AllocationHistory []SubnetClaimAllocation `json:"allocationHistory,omitempty"`
+
 	// Represents the observations of a subnet claim's current state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// SubnetClaimAllocation records a prefix allocated to a subnet claim
+type SubnetClaimAllocation struct {
+	// The IP family of the prefix
+	IPFamily IPFamily `json:"ipFamily"`
+
+	// The allocated prefix
+	Prefix string `json:"prefix"`
+
+	// The time the prefix was allocated to the claim
+	AllocatedAt metav1.Time `json:"allocatedAt"`
+
+	// The time the prefix was released by the claim
+	ReleasedAt *metav1.Time `json:"releasedAt,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -0,0 +1,6 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+// Hub marks this type as a conversion hub.
+func (*TrafficProtectionPolicy) Hub() {}
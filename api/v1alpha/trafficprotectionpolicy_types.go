@@ -61,7 +61,21 @@ type TrafficProtectionPolicySpec struct {
 	// +kubebuilder:validation:MaxItems=16
 	// +kubebuilder:default={{"type": "OWASPCoreRuleSet", "owaspCoreRuleSet": {}}}
 	// +kubebuilder:validation:XValidation:message="OWASPCoreRuleSet filter cannot be repeated",rule="self.filter(f, f.type == 'OWASPCoreRuleSet').size() <= 1"
+	// +kubebuilder:validation:XValidation:message="Geo filter cannot be repeated",rule="self.filter(f, f.type == 'Geo').size() <= 1"
+	// +kubebuilder:validation:XValidation:message="CustomRules filter cannot be repeated",rule="self.filter(f, f.type == 'CustomRules').size() <= 1"
 	RuleSets []TrafficProtectionPolicyRuleSet `json:"ruleSets,omitempty"`
+
+	// CRSVersion pins the release of the OWASP ModSecurity Core Rule Set (CRS)
+	// the policy is evaluated with, such as "4.7.0". If not specified, the
+	// default release of the operator is used, which may change over time.
+	//
+	// The policy is not accepted, with the UnsupportedVersion reason, when the
+	// release isn't available.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[0-9A-Za-z]([0-9A-Za-z.+-]*[0-9A-Za-z])?$`
+	CRSVersion string `json:"crsVersion,omitempty"`
 }
 
 // TrafficProtectionPolicyRuleSetType identifies a type of TrafficProtectionPolicy ruleset.
@@ -69,13 +83,17 @@ type TrafficProtectionPolicyRuleSetType string
 
 const (
 	TrafficProtectionPolicyOWASPCoreRuleSet TrafficProtectionPolicyRuleSetType = "OWASPCoreRuleSet"
+	TrafficProtectionPolicyGeoRuleSet       TrafficProtectionPolicyRuleSetType = "Geo"
+	TrafficProtectionPolicyCustomRuleSet    TrafficProtectionPolicyRuleSetType = "CustomRules"
 )
 
+// +kubebuilder:validation:XValidation:message="geo must be specified if and only if type is Geo",rule="self.type == 'Geo' ? has(self.geo) : !has(self.geo)"
+// +kubebuilder:validation:XValidation:message="customRules must be specified if and only if type is CustomRules",rule="self.type == 'CustomRules' ? has(self.customRules) : !has(self.customRules)"
 type TrafficProtectionPolicyRuleSet struct {
 	// Type specifies the type of TrafficProtectionPolicy ruleset.
 	//
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=OWASPCoreRuleSet
+	// +kubebuilder:validation:Enum=OWASPCoreRuleSet;Geo;CustomRules
 	Type TrafficProtectionPolicyRuleSetType `json:"type"`
 
 	// OWASPCoreRuleSet defines configuration options for the OWASP ModSecurity
@@ -83,8 +101,66 @@ type TrafficProtectionPolicyRuleSet struct {
 	//
 	// +kubebuilder:validation:Optional
 	OWASPCoreRuleSet OWASPCRS `json:"owaspCoreRuleSet"`
+
+	// Geo defines the countries requests are allowed or denied from.
+	//
+	// +kubebuilder:validation:Optional
+	Geo *GeoRuleSet `json:"geo,omitempty"`
+
+	// CustomRules defines Coraza rules to evaluate in addition to the OWASP
+	// ModSecurity Core Rule Set (CRS).
+	//
+	// +kubebuilder:validation:Optional
+	CustomRules *CustomRuleSet `json:"customRules,omitempty"`
 }
 
+// CustomRuleSet contains Coraza SecLang directives, which are evaluated after
+// the OWASP ModSecurity Core Rule Set (CRS) directives.
+//
+// Only rule directives are accepted, such as SecRule, SecAction and
+// SecRuleRemoveById. Directives that change engine-wide settings are rejected.
+//
+// See: https://coraza.io/docs/seclang/directives/
+type CustomRuleSet struct {
+	// Directives is the list of SecLang directives, one directive per item.
+	// The combined length of all directives may not exceed 32KiB.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=4096
+	Directives []string `json:"directives"`
+}
+
+// GeoRuleSet allows or denies requests by the country of the client address,
+// as resolved from the platform's GeoIP database. Requests whose country cannot
+// be resolved are denied when AllowCountries is set.
+//
+// +kubebuilder:validation:XValidation:message="exactly one of allowCountries or denyCountries must be specified",rule="has(self.allowCountries) != has(self.denyCountries)"
+type GeoRuleSet struct {
+	// AllowCountries lists the only countries requests are allowed from.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=250
+	// +listType=set
+	AllowCountries []CountryCode `json:"allowCountries,omitempty"`
+
+	// DenyCountries lists the countries requests are denied from.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=250
+	// +listType=set
+	DenyCountries []CountryCode `json:"denyCountries,omitempty"`
+}
+
+// CountryCode is an ISO 3166-1 alpha-2 country code, such as `US`.
+//
+// +kubebuilder:validation:Pattern=`^[A-Z]{2}$`
+type CountryCode string
+
 // OWASPCRS defines configuration options for the OWASP ModSecurity Core Rule Set (CRS).
 type OWASPCRS struct {
 
@@ -142,6 +218,26 @@ func (s *TrafficProtectionPolicySpec) InvertedParanoiaLevels() *ParanoiaLevels {
 	return nil
 }
 
+// CustomRuleSet returns the CustomRules ruleset of the policy, if any.
+func (s *TrafficProtectionPolicySpec) CustomRuleSet() *CustomRuleSet {
+	for i := range s.RuleSets {
+		if s.RuleSets[i].Type == TrafficProtectionPolicyCustomRuleSet {
+			return s.RuleSets[i].CustomRules
+		}
+	}
+	return nil
+}
+
+// GeoRuleSet returns the Geo ruleset of the policy, if any.
+func (s *TrafficProtectionPolicySpec) GeoRuleSet() *GeoRuleSet {
+	for i := range s.RuleSets {
+		if s.RuleSets[i].Type == TrafficProtectionPolicyGeoRuleSet {
+			return s.RuleSets[i].Geo
+		}
+	}
+	return nil
+}
+
 type OWASPRuleExclusions struct {
 	// Tags is a list of rule tags to disable.
 	//
@@ -188,11 +284,80 @@ type OWASPScoreThresholds struct {
 // TrafficProtectionPolicyStatus defines the observed state of TrafficProtectionPolicy.
 type TrafficProtectionPolicyStatus struct {
 	gatewayv1alpha2.PolicyStatus `json:",inline"`
+
+	// Warnings lists configurations in the spec that are valid, but likely to
+	// be unintended.
+	//
+	// +listType=atomic
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	Warnings []Warning `json:"warnings,omitempty"`
+
+	// Events summarizes the requests the policy detected or blocked recently.
+	// Events are only reported when the platform collects them.
+	//
+	// +optional
+	Events *TrafficProtectionPolicyEvents `json:"events,omitempty"`
+
+	// TargetCount is the number of ancestors this policy is attached to.
+	//
+	// +optional
+	TargetCount int32 `json:"targetCount,omitempty"`
+
+	// AcceptedCount is the number of ancestors that accepted this policy.
+	//
+	// +optional
+	AcceptedCount int32 `json:"acceptedCount,omitempty"`
+}
+
+// TrafficProtectionPolicyEvents summarizes the requests a
+// TrafficProtectionPolicy detected or blocked within a window of time.
+type TrafficProtectionPolicyEvents struct {
+	// Window is the period covered by the counts, ending at ObservedTime.
+	Window metav1.Duration `json:"window"`
+
+	// ObservedTime is when the events were last collected.
+	ObservedTime metav1.Time `json:"observedTime"`
+
+	// Blocked is the number of requests denied by the policy.
+	Blocked int64 `json:"blocked"`
+
+	// Detected is the number of requests that violated the policy without
+	// being denied, such as in the Observe mode.
+	Detected int64 `json:"detected"`
+
+	// TopRules lists the rules triggered most often, most frequent first.
+	//
+	// +listType=atomic
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	TopRules []TrafficProtectionPolicyRuleEvents `json:"topRules,omitempty"`
+
+	// LastEventTime is when the policy last detected or blocked a request
+	// within the window.
+	//
+	// +optional
+	LastEventTime *metav1.Time `json:"lastEventTime,omitempty"`
+}
+
+// TrafficProtectionPolicyRuleEvents is the number of times a rule was
+// triggered.
+type TrafficProtectionPolicyRuleEvents struct {
+	// RuleID is the ID of the rule, such as `942100`.
+	RuleID string `json:"ruleID"`
+
+	// Count is the number of times the rule was triggered.
+	Count int64 `json:"count"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=tpp
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+// +kubebuilder:printcolumn:name="Targets",type="integer",JSONPath=".status.targetCount"
+// +kubebuilder:printcolumn:name="Accepted",type="integer",JSONPath=".status.acceptedCount"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // TrafficProtectionPolicy is the Schema for the trafficprotectionpolicies API.
 type TrafficProtectionPolicy struct {
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+// WarningCode is a stable identifier for a kind of warning. Clients may rely on
+// codes to present or suppress warnings, so codes are never repurposed.
+//
+// +kubebuilder:validation:MaxLength=64
+type WarningCode string
+
+const (
+	// WarningCodeHostnameCoveredByWildcard is reported when a hostname is
+	// already matched by a wildcard hostname on the same resource.
+	WarningCodeHostnameCoveredByWildcard WarningCode = "HostnameCoveredByWildcard"
+
+	// WarningCodeHealthCheckIntervalShort is reported when backends are health
+	// checked more frequently than recommended.
+	WarningCodeHealthCheckIntervalShort WarningCode = "HealthCheckIntervalShort"
+
+	// WarningCodeTrafficProtectionObserveOnly is reported when a traffic
+	// protection policy in Observe mode has rules meant to block requests,
+	// which are logged without blocking them.
+	WarningCodeTrafficProtectionObserveOnly WarningCode = "TrafficProtectionObserveOnly"
+
+	// WarningCodeTrafficProtectionPartialSampling is reported when a traffic
+	// protection policy only inspects part of the traffic.
+	WarningCodeTrafficProtectionPartialSampling WarningCode = "TrafficProtectionPartialSampling"
+
+	// WarningCodeDNSRecordTTLShort is reported when the DNS records of a
+	// resource are given a lower TTL than recommended.
+	WarningCodeDNSRecordTTLShort WarningCode = "DNSRecordTTLShort"
+)
+
+// Warning describes a configuration that is valid, but likely to be
+// unintended. Warnings never prevent a resource from being programmed.
+type Warning struct {
+	// Code is a stable, machine readable identifier for the warning.
+	//
+	// +kubebuilder:validation:Required
+	Code WarningCode `json:"code"`
+
+	// Field is the path of the field the warning applies to, if any.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	Field string `json:"field,omitempty"`
+
+	// Message is a human readable description of the warning.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=1024
+	Message string `json:"message"`
+}
@@ -8,10 +8,10 @@ package v1alpha
 
 import (
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
@@ -31,122 +31,168 @@ func (in *AbuseContact) DeepCopy() *AbuseContact {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConnectorReference) DeepCopyInto(out *ConnectorReference) {
+func (in *AccessControlPolicy) DeepCopyInto(out *AccessControlPolicy) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectorReference.
-func (in *ConnectorReference) DeepCopy() *ConnectorReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlPolicy.
+func (in *AccessControlPolicy) DeepCopy() *AccessControlPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ConnectorReference)
+	out := new(AccessControlPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessControlPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Contact) DeepCopyInto(out *Contact) {
+func (in *AccessControlPolicyList) DeepCopyInto(out *AccessControlPolicyList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccessControlPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Contact.
-func (in *Contact) DeepCopy() *Contact {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlPolicyList.
+func (in *AccessControlPolicyList) DeepCopy() *AccessControlPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(Contact)
+	out := new(AccessControlPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessControlPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContactSet) DeepCopyInto(out *ContactSet) {
+func (in *AccessControlPolicySpec) DeepCopyInto(out *AccessControlPolicySpec) {
 	*out = *in
-	if in.Registrant != nil {
-		in, out := &in.Registrant, &out.Registrant
-		*out = new(Contact)
-		**out = **in
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1alpha2.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Admin != nil {
-		in, out := &in.Admin, &out.Admin
-		*out = new(Contact)
-		**out = **in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]CIDR, len(*in))
+		copy(*out, *in)
 	}
-	if in.Tech != nil {
-		in, out := &in.Tech, &out.Tech
-		*out = new(Contact)
-		**out = **in
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]CIDR, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContactSet.
-func (in *ContactSet) DeepCopy() *ContactSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlPolicySpec.
+func (in *AccessControlPolicySpec) DeepCopy() *AccessControlPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ContactSet)
+	out := new(AccessControlPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSSECInfo) DeepCopyInto(out *DNSSECInfo) {
+func (in *AccessControlPolicyStatus) DeepCopyInto(out *AccessControlPolicyStatus) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DS != nil {
-		in, out := &in.DS, &out.DS
-		*out = make([]DSRecord, len(*in))
-		copy(*out, *in)
-	}
+	in.PolicyStatus.DeepCopyInto(&out.PolicyStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECInfo.
-func (in *DNSSECInfo) DeepCopy() *DNSSECInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlPolicyStatus.
+func (in *AccessControlPolicyStatus) DeepCopy() *AccessControlPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSSECInfo)
+	out := new(AccessControlPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSVerificationRecord) DeepCopyInto(out *DNSVerificationRecord) {
+func (in *AccessLogFormat) DeepCopyInto(out *AccessLogFormat) {
 	*out = *in
+	if in.Text != nil {
+		in, out := &in.Text, &out.Text
+		*out = new(string)
+		**out = **in
+	}
+	if in.JSON != nil {
+		in, out := &in.JSON, &out.JSON
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSVerificationRecord.
-func (in *DNSVerificationRecord) DeepCopy() *DNSVerificationRecord {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogFormat.
+func (in *AccessLogFormat) DeepCopy() *AccessLogFormat {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSVerificationRecord)
+	out := new(AccessLogFormat)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DSRecord) DeepCopyInto(out *DSRecord) {
+func (in *AccessLogOTLPSink) DeepCopyInto(out *AccessLogOTLPSink) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]v1.HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DSRecord.
-func (in *DSRecord) DeepCopy() *DSRecord {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogOTLPSink.
+func (in *AccessLogOTLPSink) DeepCopy() *AccessLogOTLPSink {
 	if in == nil {
 		return nil
 	}
-	out := new(DSRecord)
+	out := new(AccessLogOTLPSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Domain) DeepCopyInto(out *Domain) {
+func (in *AccessLogPolicy) DeepCopyInto(out *AccessLogPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -154,18 +200,18 @@ func (in *Domain) DeepCopyInto(out *Domain) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Domain.
-func (in *Domain) DeepCopy() *Domain {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogPolicy.
+func (in *AccessLogPolicy) DeepCopy() *AccessLogPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(Domain)
+	out := new(AccessLogPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Domain) DeepCopyObject() runtime.Object {
+func (in *AccessLogPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -173,31 +219,31 @@ func (in *Domain) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DomainList) DeepCopyInto(out *DomainList) {
+func (in *AccessLogPolicyList) DeepCopyInto(out *AccessLogPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Domain, len(*in))
+		*out = make([]AccessLogPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainList.
-func (in *DomainList) DeepCopy() *DomainList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogPolicyList.
+func (in *AccessLogPolicyList) DeepCopy() *AccessLogPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(DomainList)
+	out := new(AccessLogPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DomainList) DeepCopyObject() runtime.Object {
+func (in *AccessLogPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -205,103 +251,117 @@ func (in *DomainList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
+func (in *AccessLogPolicySpec) DeepCopyInto(out *AccessLogPolicySpec) {
 	*out = *in
-	if in.DesiredRegistrationRefreshAttempt != nil {
-		in, out := &in.DesiredRegistrationRefreshAttempt, &out.DesiredRegistrationRefreshAttempt
-		*out = (*in).DeepCopy()
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1alpha2.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.DesiredVerificationRefreshAttempt != nil {
-		in, out := &in.DesiredVerificationRefreshAttempt, &out.DesiredVerificationRefreshAttempt
-		*out = (*in).DeepCopy()
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(AccessLogFormat)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SamplePercent != nil {
+		in, out := &in.SamplePercent, &out.SamplePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]AccessLogSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainSpec.
-func (in *DomainSpec) DeepCopy() *DomainSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogPolicySpec.
+func (in *AccessLogPolicySpec) DeepCopy() *AccessLogPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DomainSpec)
+	out := new(AccessLogPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
+func (in *AccessLogPolicyStatus) DeepCopyInto(out *AccessLogPolicyStatus) {
 	*out = *in
-	if in.Verification != nil {
-		in, out := &in.Verification, &out.Verification
-		*out = new(DomainVerificationStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Registration != nil {
-		in, out := &in.Registration, &out.Registration
-		*out = new(Registration)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Nameservers != nil {
-		in, out := &in.Nameservers, &out.Nameservers
-		*out = make([]Nameserver, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.PolicyStatus.DeepCopyInto(&out.PolicyStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainStatus.
-func (in *DomainStatus) DeepCopy() *DomainStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogPolicyStatus.
+func (in *AccessLogPolicyStatus) DeepCopy() *AccessLogPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DomainStatus)
+	out := new(AccessLogPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DomainVerificationStatus) DeepCopyInto(out *DomainVerificationStatus) {
+func (in *AccessLogS3Sink) DeepCopyInto(out *AccessLogS3Sink) {
 	*out = *in
-	out.DNSRecord = in.DNSRecord
-	out.HTTPToken = in.HTTPToken
-	in.NextVerificationAttempt.DeepCopyInto(&out.NextVerificationAttempt)
-	in.LastVerificationAttempt.DeepCopyInto(&out.LastVerificationAttempt)
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainVerificationStatus.
-func (in *DomainVerificationStatus) DeepCopy() *DomainVerificationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogS3Sink.
+func (in *AccessLogS3Sink) DeepCopy() *AccessLogS3Sink {
 	if in == nil {
 		return nil
 	}
-	out := new(DomainVerificationStatus)
+	out := new(AccessLogS3Sink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPLocationProvider) DeepCopyInto(out *GCPLocationProvider) {
+func (in *AccessLogSink) DeepCopyInto(out *AccessLogSink) {
 	*out = *in
+	if in.OTLP != nil {
+		in, out := &in.OTLP, &out.OTLP
+		*out = new(AccessLogOTLPSink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(AccessLogS3Sink)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPLocationProvider.
-func (in *GCPLocationProvider) DeepCopy() *GCPLocationProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogSink.
+func (in *AccessLogSink) DeepCopy() *AccessLogSink {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPLocationProvider)
+	out := new(AccessLogSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPProxy) DeepCopyInto(out *HTTPProxy) {
+func (in *AuthenticationPolicy) DeepCopyInto(out *AuthenticationPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -309,18 +369,18 @@ func (in *HTTPProxy) DeepCopyInto(out *HTTPProxy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxy.
-func (in *HTTPProxy) DeepCopy() *HTTPProxy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationPolicy.
+func (in *AuthenticationPolicy) DeepCopy() *AuthenticationPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPProxy)
+	out := new(AuthenticationPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HTTPProxy) DeepCopyObject() runtime.Object {
+func (in *AuthenticationPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -328,51 +388,31 @@ func (in *HTTPProxy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPProxyBackendTLS) DeepCopyInto(out *HTTPProxyBackendTLS) {
-	*out = *in
-	if in.Hostname != nil {
-		in, out := &in.Hostname, &out.Hostname
-		*out = new(string)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyBackendTLS.
-func (in *HTTPProxyBackendTLS) DeepCopy() *HTTPProxyBackendTLS {
-	if in == nil {
-		return nil
-	}
-	out := new(HTTPProxyBackendTLS)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPProxyList) DeepCopyInto(out *HTTPProxyList) {
+func (in *AuthenticationPolicyList) DeepCopyInto(out *AuthenticationPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]HTTPProxy, len(*in))
+		*out = make([]AuthenticationPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyList.
-func (in *HTTPProxyList) DeepCopy() *HTTPProxyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationPolicyList.
+func (in *AuthenticationPolicyList) DeepCopy() *AuthenticationPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPProxyList)
+	out := new(AuthenticationPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HTTPProxyList) DeepCopyObject() runtime.Object {
+func (in *AuthenticationPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -380,250 +420,301 @@ func (in *HTTPProxyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPProxyRule) DeepCopyInto(out *HTTPProxyRule) {
+func (in *AuthenticationPolicySpec) DeepCopyInto(out *AuthenticationPolicySpec) {
 	*out = *in
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(apisv1.SectionName)
-		**out = **in
-	}
-	if in.Matches != nil {
-		in, out := &in.Matches, &out.Matches
-		*out = make([]apisv1.HTTPRouteMatch, len(*in))
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1alpha2.LocalPolicyTargetReferenceWithSectionName, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Filters != nil {
-		in, out := &in.Filters, &out.Filters
-		*out = make([]apisv1.HTTPRouteFilter, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTAuthentication)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Backends != nil {
-		in, out := &in.Backends, &out.Backends
-		*out = make([]HTTPProxyRuleBackend, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCAuthentication)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyRule.
-func (in *HTTPProxyRule) DeepCopy() *HTTPProxyRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationPolicySpec.
+func (in *AuthenticationPolicySpec) DeepCopy() *AuthenticationPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPProxyRule)
+	out := new(AuthenticationPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPProxyRuleBackend) DeepCopyInto(out *HTTPProxyRuleBackend) {
+func (in *AuthenticationPolicyStatus) DeepCopyInto(out *AuthenticationPolicyStatus) {
 	*out = *in
-	if in.Connector != nil {
-		in, out := &in.Connector, &out.Connector
-		*out = new(ConnectorReference)
-		**out = **in
-	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(HTTPProxyBackendTLS)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Filters != nil {
-		in, out := &in.Filters, &out.Filters
-		*out = make([]apisv1.HTTPRouteFilter, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.PolicyStatus.DeepCopyInto(&out.PolicyStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationPolicyStatus.
+func (in *AuthenticationPolicyStatus) DeepCopy() *AuthenticationPolicyStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(AuthenticationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyRuleBackend.
-func (in *HTTPProxyRuleBackend) DeepCopy() *HTTPProxyRuleBackend {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendLoadBalancingPolicy) DeepCopyInto(out *BackendLoadBalancingPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendLoadBalancingPolicy.
+func (in *BackendLoadBalancingPolicy) DeepCopy() *BackendLoadBalancingPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPProxyRuleBackend)
+	out := new(BackendLoadBalancingPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackendLoadBalancingPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPProxySpec) DeepCopyInto(out *HTTPProxySpec) {
+func (in *BackendLoadBalancingPolicyList) DeepCopyInto(out *BackendLoadBalancingPolicyList) {
 	*out = *in
-	if in.Hostnames != nil {
-		in, out := &in.Hostnames, &out.Hostnames
-		*out = make([]apisv1.Hostname, len(*in))
-		copy(*out, *in)
-	}
-	if in.Rules != nil {
-		in, out := &in.Rules, &out.Rules
-		*out = make([]HTTPProxyRule, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackendLoadBalancingPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxySpec.
-func (in *HTTPProxySpec) DeepCopy() *HTTPProxySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendLoadBalancingPolicyList.
+func (in *BackendLoadBalancingPolicyList) DeepCopy() *BackendLoadBalancingPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPProxySpec)
+	out := new(BackendLoadBalancingPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackendLoadBalancingPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPProxyStatus) DeepCopyInto(out *HTTPProxyStatus) {
+func (in *BackendLoadBalancingPolicySpec) DeepCopyInto(out *BackendLoadBalancingPolicySpec) {
 	*out = *in
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]apisv1.GatewayStatusAddress, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Hostnames != nil {
-		in, out := &in.Hostnames, &out.Hostnames
-		*out = make([]apisv1.Hostname, len(*in))
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1alpha2.LocalPolicyTargetReference, len(*in))
 		copy(*out, *in)
 	}
-	if in.HostnameStatuses != nil {
-		in, out := &in.HostnameStatuses, &out.HostnameStatuses
-		*out = make([]HostnameStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.LoadBalancer.DeepCopyInto(&out.LoadBalancer)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendLoadBalancingPolicySpec.
+func (in *BackendLoadBalancingPolicySpec) DeepCopy() *BackendLoadBalancingPolicySpec {
+	if in == nil {
+		return nil
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(BackendLoadBalancingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendLoadBalancingPolicyStatus) DeepCopyInto(out *BackendLoadBalancingPolicyStatus) {
+	*out = *in
+	in.PolicyStatus.DeepCopyInto(&out.PolicyStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendLoadBalancingPolicyStatus.
+func (in *BackendLoadBalancingPolicyStatus) DeepCopy() *BackendLoadBalancingPolicyStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(BackendLoadBalancingPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyStatus.
-func (in *HTTPProxyStatus) DeepCopy() *HTTPProxyStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimToHeader) DeepCopyInto(out *ClaimToHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimToHeader.
+func (in *ClaimToHeader) DeepCopy() *ClaimToHeader {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPProxyStatus)
+	out := new(ClaimToHeader)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPVerificationToken) DeepCopyInto(out *HTTPVerificationToken) {
+func (in *ConnectorReference) DeepCopyInto(out *ConnectorReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPVerificationToken.
-func (in *HTTPVerificationToken) DeepCopy() *HTTPVerificationToken {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectorReference.
+func (in *ConnectorReference) DeepCopy() *ConnectorReference {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPVerificationToken)
+	out := new(ConnectorReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostnameStatus) DeepCopyInto(out *HostnameStatus) {
+func (in *Contact) DeepCopyInto(out *Contact) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Contact.
+func (in *Contact) DeepCopy() *Contact {
+	if in == nil {
+		return nil
 	}
+	out := new(Contact)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostnameStatus.
-func (in *HostnameStatus) DeepCopy() *HostnameStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContactSet) DeepCopyInto(out *ContactSet) {
+	*out = *in
+	if in.Registrant != nil {
+		in, out := &in.Registrant, &out.Registrant
+		*out = new(Contact)
+		**out = **in
+	}
+	if in.Admin != nil {
+		in, out := &in.Admin, &out.Admin
+		*out = new(Contact)
+		**out = **in
+	}
+	if in.Tech != nil {
+		in, out := &in.Tech, &out.Tech
+		*out = new(Contact)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContactSet.
+func (in *ContactSet) DeepCopy() *ContactSet {
 	if in == nil {
 		return nil
 	}
-	out := new(HostnameStatus)
+	out := new(ContactSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPBlock) DeepCopyInto(out *IPBlock) {
+func (in *CustomRuleSet) DeepCopyInto(out *CustomRuleSet) {
 	*out = *in
-	if in.Except != nil {
-		in, out := &in.Except, &out.Except
+	if in.Directives != nil {
+		in, out := &in.Directives, &out.Directives
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPBlock.
-func (in *IPBlock) DeepCopy() *IPBlock {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomRuleSet.
+func (in *CustomRuleSet) DeepCopy() *CustomRuleSet {
 	if in == nil {
 		return nil
 	}
-	out := new(IPBlock)
+	out := new(CustomRuleSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalNetworkContextRef) DeepCopyInto(out *LocalNetworkContextRef) {
+func (in *DNSSECInfo) DeepCopyInto(out *DNSSECInfo) {
 	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DS != nil {
+		in, out := &in.DS, &out.DS
+		*out = make([]DSRecord, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalNetworkContextRef.
-func (in *LocalNetworkContextRef) DeepCopy() *LocalNetworkContextRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECInfo.
+func (in *DNSSECInfo) DeepCopy() *DNSSECInfo {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalNetworkContextRef)
+	out := new(DNSSECInfo)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalNetworkRef) DeepCopyInto(out *LocalNetworkRef) {
+func (in *DNSVerificationRecord) DeepCopyInto(out *DNSVerificationRecord) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalNetworkRef.
-func (in *LocalNetworkRef) DeepCopy() *LocalNetworkRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSVerificationRecord.
+func (in *DNSVerificationRecord) DeepCopy() *DNSVerificationRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalNetworkRef)
+	out := new(DNSVerificationRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalSubnetReference) DeepCopyInto(out *LocalSubnetReference) {
+func (in *DSRecord) DeepCopyInto(out *DSRecord) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSubnetReference.
-func (in *LocalSubnetReference) DeepCopy() *LocalSubnetReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DSRecord.
+func (in *DSRecord) DeepCopy() *DSRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalSubnetReference)
+	out := new(DSRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Location) DeepCopyInto(out *Location) {
+func (in *Domain) DeepCopyInto(out *Domain) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -631,18 +722,18 @@ func (in *Location) DeepCopyInto(out *Location) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Location.
-func (in *Location) DeepCopy() *Location {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Domain.
+func (in *Domain) DeepCopy() *Domain {
 	if in == nil {
 		return nil
 	}
-	out := new(Location)
+	out := new(Domain)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Location) DeepCopyObject() runtime.Object {
+func (in *Domain) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -650,7 +741,7 @@ func (in *Location) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocationBinding) DeepCopyInto(out *LocationBinding) {
+func (in *DomainClaim) DeepCopyInto(out *DomainClaim) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -658,18 +749,18 @@ func (in *LocationBinding) DeepCopyInto(out *LocationBinding) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationBinding.
-func (in *LocationBinding) DeepCopy() *LocationBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainClaim.
+func (in *DomainClaim) DeepCopy() *DomainClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(LocationBinding)
+	out := new(DomainClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocationBinding) DeepCopyObject() runtime.Object {
+func (in *DomainClaim) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -677,31 +768,46 @@ func (in *LocationBinding) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocationBindingList) DeepCopyInto(out *LocationBindingList) {
+func (in *DomainClaimFailure) DeepCopyInto(out *DomainClaimFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainClaimFailure.
+func (in *DomainClaimFailure) DeepCopy() *DomainClaimFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainClaimFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainClaimList) DeepCopyInto(out *DomainClaimList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]LocationBinding, len(*in))
+		*out = make([]DomainClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationBindingList.
-func (in *LocationBindingList) DeepCopy() *LocationBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainClaimList.
+func (in *DomainClaimList) DeepCopy() *DomainClaimList {
 	if in == nil {
 		return nil
 	}
-	out := new(LocationBindingList)
+	out := new(DomainClaimList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocationBindingList) DeepCopyObject() runtime.Object {
+func (in *DomainClaimList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -709,76 +815,93 @@ func (in *LocationBindingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocationBindingSpec) DeepCopyInto(out *LocationBindingSpec) {
+func (in *DomainClaimSpec) DeepCopyInto(out *DomainClaimSpec) {
 	*out = *in
-	out.LocationRef = in.LocationRef
-	if in.Topology != nil {
-		in, out := &in.Topology, &out.Topology
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.DomainNames != nil {
+		in, out := &in.DomainNames, &out.DomainNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationBindingSpec.
-func (in *LocationBindingSpec) DeepCopy() *LocationBindingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainClaimSpec.
+func (in *DomainClaimSpec) DeepCopy() *DomainClaimSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LocationBindingSpec)
+	out := new(DomainClaimSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocationBindingStatus) DeepCopyInto(out *LocationBindingStatus) {
+func (in *DomainClaimStatus) DeepCopyInto(out *DomainClaimStatus) {
 	*out = *in
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = make([]DomainClaimFailure, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationBindingStatus.
-func (in *LocationBindingStatus) DeepCopy() *LocationBindingStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainClaimStatus.
+func (in *DomainClaimStatus) DeepCopy() *DomainClaimStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LocationBindingStatus)
+	out := new(DomainClaimStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocationList) DeepCopyInto(out *LocationList) {
+func (in *DomainConsumer) DeepCopyInto(out *DomainConsumer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainConsumer.
+func (in *DomainConsumer) DeepCopy() *DomainConsumer {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainConsumer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainList) DeepCopyInto(out *DomainList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Location, len(*in))
+		*out = make([]Domain, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationList.
-func (in *LocationList) DeepCopy() *LocationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainList.
+func (in *DomainList) DeepCopy() *DomainList {
 	if in == nil {
 		return nil
 	}
-	out := new(LocationList)
+	out := new(DomainList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocationList) DeepCopyObject() runtime.Object {
+func (in *DomainList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -786,122 +909,133 @@ func (in *LocationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocationProvider) DeepCopyInto(out *LocationProvider) {
+func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 	*out = *in
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPLocationProvider)
-		**out = **in
+	if in.DesiredRegistrationRefreshAttempt != nil {
+		in, out := &in.DesiredRegistrationRefreshAttempt, &out.DesiredRegistrationRefreshAttempt
+		*out = (*in).DeepCopy()
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationProvider.
-func (in *LocationProvider) DeepCopy() *LocationProvider {
-	if in == nil {
-		return nil
+	if in.DesiredVerificationRefreshAttempt != nil {
+		in, out := &in.DesiredVerificationRefreshAttempt, &out.DesiredVerificationRefreshAttempt
+		*out = (*in).DeepCopy()
 	}
-	out := new(LocationProvider)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocationReference) DeepCopyInto(out *LocationReference) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationReference.
-func (in *LocationReference) DeepCopy() *LocationReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainSpec.
+func (in *DomainSpec) DeepCopy() *DomainSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LocationReference)
+	out := new(DomainSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocationSpec) DeepCopyInto(out *LocationSpec) {
+func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
 	*out = *in
-	if in.Topology != nil {
-		in, out := &in.Topology, &out.Topology
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(DomainVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Registration != nil {
+		in, out := &in.Registration, &out.Registration
+		*out = new(Registration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]Nameserver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.Provider.DeepCopyInto(&out.Provider)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]DomainConsumer, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationSpec.
-func (in *LocationSpec) DeepCopy() *LocationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainStatus.
+func (in *DomainStatus) DeepCopy() *DomainStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LocationSpec)
+	out := new(DomainStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocationStatus) DeepCopyInto(out *LocationStatus) {
+func (in *DomainVerificationStatus) DeepCopyInto(out *DomainVerificationStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.DNSRecord = in.DNSRecord
+	out.HTTPToken = in.HTTPToken
+	in.NextVerificationAttempt.DeepCopyInto(&out.NextVerificationAttempt)
+	in.LastVerificationAttempt.DeepCopyInto(&out.LastVerificationAttempt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationStatus.
-func (in *LocationStatus) DeepCopy() *LocationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainVerificationStatus.
+func (in *DomainVerificationStatus) DeepCopy() *DomainVerificationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LocationStatus)
+	out := new(DomainVerificationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Nameserver) DeepCopyInto(out *Nameserver) {
+func (in *GCPLocationProvider) DeepCopyInto(out *GCPLocationProvider) {
 	*out = *in
-	if in.IPs != nil {
-		in, out := &in.IPs, &out.IPs
-		*out = make([]NameserverIP, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nameserver.
-func (in *Nameserver) DeepCopy() *Nameserver {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPLocationProvider.
+func (in *GCPLocationProvider) DeepCopy() *GCPLocationProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(Nameserver)
+	out := new(GCPLocationProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NameserverIP) DeepCopyInto(out *NameserverIP) {
+func (in *GeoRuleSet) DeepCopyInto(out *GeoRuleSet) {
 	*out = *in
+	if in.AllowCountries != nil {
+		in, out := &in.AllowCountries, &out.AllowCountries
+		*out = make([]CountryCode, len(*in))
+		copy(*out, *in)
+	}
+	if in.DenyCountries != nil {
+		in, out := &in.DenyCountries, &out.DenyCountries
+		*out = make([]CountryCode, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NameserverIP.
-func (in *NameserverIP) DeepCopy() *NameserverIP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoRuleSet.
+func (in *GeoRuleSet) DeepCopy() *GeoRuleSet {
 	if in == nil {
 		return nil
 	}
-	out := new(NameserverIP)
+	out := new(GeoRuleSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Network) DeepCopyInto(out *Network) {
+func (in *HTTPProxy) DeepCopyInto(out *HTTPProxy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -909,18 +1043,18 @@ func (in *Network) DeepCopyInto(out *Network) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
-func (in *Network) DeepCopy() *Network {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxy.
+func (in *HTTPProxy) DeepCopy() *HTTPProxy {
 	if in == nil {
 		return nil
 	}
-	out := new(Network)
+	out := new(HTTPProxy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Network) DeepCopyObject() runtime.Object {
+func (in *HTTPProxy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -928,287 +1062,321 @@ func (in *Network) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkBinding) DeepCopyInto(out *NetworkBinding) {
+func (in *HTTPProxyAuth) DeepCopyInto(out *HTTPProxyAuth) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Basic != nil {
+		in, out := &in.Basic, &out.Basic
+		*out = new(HTTPProxyBasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBinding.
-func (in *NetworkBinding) DeepCopy() *NetworkBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyAuth.
+func (in *HTTPProxyAuth) DeepCopy() *HTTPProxyAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkBinding)
+	out := new(HTTPProxyAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NetworkBinding) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkBindingList) DeepCopyInto(out *NetworkBindingList) {
+func (in *HTTPProxyBackendHealth) DeepCopyInto(out *HTTPProxyBackendHealth) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]NetworkBinding, len(*in))
+	in.ObservedTime.DeepCopyInto(&out.ObservedTime)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]HTTPProxyRuleHealth, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBindingList.
-func (in *NetworkBindingList) DeepCopy() *NetworkBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyBackendHealth.
+func (in *HTTPProxyBackendHealth) DeepCopy() *HTTPProxyBackendHealth {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkBindingList)
+	out := new(HTTPProxyBackendHealth)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NetworkBindingList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkBindingObjectReference) DeepCopyInto(out *NetworkBindingObjectReference) {
+func (in *HTTPProxyBackendHashKey) DeepCopyInto(out *HTTPProxyBackendHashKey) {
 	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(v1.HTTPHeaderName)
+		**out = **in
+	}
+	if in.Cookie != nil {
+		in, out := &in.Cookie, &out.Cookie
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBindingObjectReference.
-func (in *NetworkBindingObjectReference) DeepCopy() *NetworkBindingObjectReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyBackendHashKey.
+func (in *HTTPProxyBackendHashKey) DeepCopy() *HTTPProxyBackendHashKey {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkBindingObjectReference)
+	out := new(HTTPProxyBackendHashKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkBindingSpec) DeepCopyInto(out *NetworkBindingSpec) {
+func (in *HTTPProxyBackendLoadBalancer) DeepCopyInto(out *HTTPProxyBackendLoadBalancer) {
 	*out = *in
-	out.Network = in.Network
-	out.Location = in.Location
+	if in.HashKey != nil {
+		in, out := &in.HashKey, &out.HashKey
+		*out = new(HTTPProxyBackendHashKey)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBindingSpec.
-func (in *NetworkBindingSpec) DeepCopy() *NetworkBindingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyBackendLoadBalancer.
+func (in *HTTPProxyBackendLoadBalancer) DeepCopy() *HTTPProxyBackendLoadBalancer {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkBindingSpec)
+	out := new(HTTPProxyBackendLoadBalancer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkBindingStatus) DeepCopyInto(out *NetworkBindingStatus) {
+func (in *HTTPProxyBackendStatus) DeepCopyInto(out *HTTPProxyBackendStatus) {
 	*out = *in
-	if in.NetworkContextRef != nil {
-		in, out := &in.NetworkContextRef, &out.NetworkContextRef
-		*out = new(NetworkContextRef)
-		**out = **in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.LastResolvedTime != nil {
+		in, out := &in.LastResolvedTime, &out.LastResolvedTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBindingStatus.
-func (in *NetworkBindingStatus) DeepCopy() *NetworkBindingStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyBackendStatus.
+func (in *HTTPProxyBackendStatus) DeepCopy() *HTTPProxyBackendStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkBindingStatus)
+	out := new(HTTPProxyBackendStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkContext) DeepCopyInto(out *NetworkContext) {
+func (in *HTTPProxyBackendTLS) DeepCopyInto(out *HTTPProxyBackendTLS) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(string)
+		**out = **in
+	}
+	if in.CACertificateRef != nil {
+		in, out := &in.CACertificateRef, &out.CACertificateRef
+		*out = new(HTTPProxyCACertificateReference)
+		**out = **in
+	}
+	if in.SubjectAltNames != nil {
+		in, out := &in.SubjectAltNames, &out.SubjectAltNames
+		*out = make([]v1.SubjectAltName, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkContext.
-func (in *NetworkContext) DeepCopy() *NetworkContext {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyBackendTLS.
+func (in *HTTPProxyBackendTLS) DeepCopy() *HTTPProxyBackendTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkContext)
+	out := new(HTTPProxyBackendTLS)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NetworkContext) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkContextList) DeepCopyInto(out *NetworkContextList) {
+func (in *HTTPProxyBasicAuth) DeepCopyInto(out *HTTPProxyBasicAuth) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]NetworkContext, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.UsersRef = in.UsersRef
+	if in.ForwardUsernameHeader != nil {
+		in, out := &in.ForwardUsernameHeader, &out.ForwardUsernameHeader
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkContextList.
-func (in *NetworkContextList) DeepCopy() *NetworkContextList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyBasicAuth.
+func (in *HTTPProxyBasicAuth) DeepCopy() *HTTPProxyBasicAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkContextList)
+	out := new(HTTPProxyBasicAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NetworkContextList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkContextRef) DeepCopyInto(out *NetworkContextRef) {
+func (in *HTTPProxyBlueGreen) DeepCopyInto(out *HTTPProxyBlueGreen) {
 	*out = *in
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkContextRef.
-func (in *NetworkContextRef) DeepCopy() *NetworkContextRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyBlueGreen.
+func (in *HTTPProxyBlueGreen) DeepCopy() *HTTPProxyBlueGreen {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkContextRef)
+	out := new(HTTPProxyBlueGreen)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkContextSpec) DeepCopyInto(out *NetworkContextSpec) {
+func (in *HTTPProxyBlueGreenStatus) DeepCopyInto(out *HTTPProxyBlueGreenStatus) {
 	*out = *in
-	out.Network = in.Network
-	out.Location = in.Location
+	if in.SwapTime != nil {
+		in, out := &in.SwapTime, &out.SwapTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkContextSpec.
-func (in *NetworkContextSpec) DeepCopy() *NetworkContextSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyBlueGreenStatus.
+func (in *HTTPProxyBlueGreenStatus) DeepCopy() *HTTPProxyBlueGreenStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkContextSpec)
+	out := new(HTTPProxyBlueGreenStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkContextStatus) DeepCopyInto(out *NetworkContextStatus) {
+func (in *HTTPProxyCACertificateReference) DeepCopyInto(out *HTTPProxyCACertificateReference) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyCACertificateReference.
+func (in *HTTPProxyCACertificateReference) DeepCopy() *HTTPProxyCACertificateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyCACertificateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyCanary) DeepCopyInto(out *HTTPProxyCanary) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]v1.HTTPHeaderMatch, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkContextStatus.
-func (in *NetworkContextStatus) DeepCopy() *NetworkContextStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyCanary.
+func (in *HTTPProxyCanary) DeepCopy() *HTTPProxyCanary {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkContextStatus)
+	out := new(HTTPProxyCanary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkIPAM) DeepCopyInto(out *NetworkIPAM) {
+func (in *HTTPProxyClientValidation) DeepCopyInto(out *HTTPProxyClientValidation) {
 	*out = *in
-	if in.IPV4Range != nil {
-		in, out := &in.IPV4Range, &out.IPV4Range
-		*out = new(string)
+	out.CACertificateRef = in.CACertificateRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyClientValidation.
+func (in *HTTPProxyClientValidation) DeepCopy() *HTTPProxyClientValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyClientValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyHealthCheck) DeepCopyInto(out *HTTPProxyHealthCheck) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.IPV6Range != nil {
-		in, out := &in.IPV6Range, &out.IPV6Range
-		*out = new(string)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UnhealthyThreshold != nil {
+		in, out := &in.UnhealthyThreshold, &out.UnhealthyThreshold
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.HealthyThreshold != nil {
+		in, out := &in.HealthyThreshold, &out.HealthyThreshold
+		*out = new(uint32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkIPAM.
-func (in *NetworkIPAM) DeepCopy() *NetworkIPAM {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyHealthCheck.
+func (in *HTTPProxyHealthCheck) DeepCopy() *HTTPProxyHealthCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkIPAM)
+	out := new(HTTPProxyHealthCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkList) DeepCopyInto(out *NetworkList) {
+func (in *HTTPProxyList) DeepCopyInto(out *HTTPProxyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Network, len(*in))
+		*out = make([]HTTPProxy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkList.
-func (in *NetworkList) DeepCopy() *NetworkList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyList.
+func (in *HTTPProxyList) DeepCopy() *HTTPProxyList {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkList)
+	out := new(HTTPProxyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NetworkList) DeepCopyObject() runtime.Object {
+func (in *HTTPProxyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1216,87 +1384,1990 @@ func (in *NetworkList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+func (in *HTTPProxyProtocols) DeepCopyInto(out *HTTPProxyProtocols) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
-func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyProtocols.
+func (in *HTTPProxyProtocols) DeepCopy() *HTTPProxyProtocols {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPolicy)
+	out := new(HTTPProxyProtocols)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NetworkPolicy) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyReadiness) DeepCopyInto(out *HTTPProxyReadiness) {
+	*out = *in
+	if in.Gates != nil {
+		in, out := &in.Gates, &out.Gates
+		*out = make([]HTTPProxyReadinessGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyReadiness.
+func (in *HTTPProxyReadiness) DeepCopy() *HTTPProxyReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyReadiness)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPolicyIngressRule) DeepCopyInto(out *NetworkPolicyIngressRule) {
+func (in *HTTPProxyReadinessGate) DeepCopyInto(out *HTTPProxyReadinessGate) {
 	*out = *in
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
-		*out = make([]NetworkPolicyPort, len(*in))
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyReadinessGate.
+func (in *HTTPProxyReadinessGate) DeepCopy() *HTTPProxyReadinessGate {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyReadinessGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyRequestMirror) DeepCopyInto(out *HTTPProxyRequestMirror) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyRequestMirror.
+func (in *HTTPProxyRequestMirror) DeepCopy() *HTTPProxyRequestMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyRequestMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyRule) DeepCopyInto(out *HTTPProxyRule) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(v1.SectionName)
+		**out = **in
+	}
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]v1.HTTPRouteMatch, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.From != nil {
-		in, out := &in.From, &out.From
-		*out = make([]NetworkPolicyPeer, len(*in))
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]v1.HTTPRouteFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(HTTPProxyRequestMirror)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]HTTPProxyRuleBackend, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TrafficPolicy != nil {
+		in, out := &in.TrafficPolicy, &out.TrafficPolicy
+		*out = new(HTTPProxyTrafficPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HTTPProxyHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = new(v1.HTTPHeaderFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(v1.HTTPRouteTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(v1.HTTPRouteRetry)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyIngressRule.
-func (in *NetworkPolicyIngressRule) DeepCopy() *NetworkPolicyIngressRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyRule.
+func (in *HTTPProxyRule) DeepCopy() *HTTPProxyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyRuleBackend) DeepCopyInto(out *HTTPProxyRuleBackend) {
+	*out = *in
+	if in.Connector != nil {
+		in, out := &in.Connector, &out.Connector
+		*out = new(ConnectorReference)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(HTTPProxyBackendTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LoadBalancer != nil {
+		in, out := &in.LoadBalancer, &out.LoadBalancer
+		*out = new(HTTPProxyBackendLoadBalancer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HTTPProxyHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]v1.HTTPRouteFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyRuleBackend.
+func (in *HTTPProxyRuleBackend) DeepCopy() *HTTPProxyRuleBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyRuleBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyRuleHealth) DeepCopyInto(out *HTTPProxyRuleHealth) {
+	*out = *in
+	if in.PrimaryHealthyEndpoints != nil {
+		in, out := &in.PrimaryHealthyEndpoints, &out.PrimaryHealthyEndpoints
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackupHealthyEndpoints != nil {
+		in, out := &in.BackupHealthyEndpoints, &out.BackupHealthyEndpoints
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyRuleHealth.
+func (in *HTTPProxyRuleHealth) DeepCopy() *HTTPProxyRuleHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyRuleHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxySpec) DeepCopyInto(out *HTTPProxySpec) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]v1.Hostname, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]HTTPProxyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = new(v1.HTTPHeaderFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(HTTPProxyTLS)
+		**out = **in
+	}
+	if in.TLSPolicy != nil {
+		in, out := &in.TLSPolicy, &out.TLSPolicy
+		*out = new(TLSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientValidation != nil {
+		in, out := &in.ClientValidation, &out.ClientValidation
+		*out = new(HTTPProxyClientValidation)
+		**out = **in
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(HTTPProxyAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Protocols != nil {
+		in, out := &in.Protocols, &out.Protocols
+		*out = new(HTTPProxyProtocols)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxySpec.
+func (in *HTTPProxySpec) DeepCopy() *HTTPProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyStatus) DeepCopyInto(out *HTTPProxyStatus) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]v1.GatewayStatusAddress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]v1.Hostname, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostnameStatuses != nil {
+		in, out := &in.HostnameStatuses, &out.HostnameStatuses
+		*out = make([]HostnameStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(HTTPProxyReadiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]HTTPProxyBackendStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = make([]HTTPProxyBlueGreenStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackendHealth != nil {
+		in, out := &in.BackendHealth, &out.BackendHealth
+		*out = new(HTTPProxyBackendHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]Warning, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyStatus.
+func (in *HTTPProxyStatus) DeepCopy() *HTTPProxyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyTLS) DeepCopyInto(out *HTTPProxyTLS) {
+	*out = *in
+	out.CertificateRef = in.CertificateRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyTLS.
+func (in *HTTPProxyTLS) DeepCopy() *HTTPProxyTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyTrafficPolicy) DeepCopyInto(out *HTTPProxyTrafficPolicy) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(HTTPProxyCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(HTTPProxyBlueGreen)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyTrafficPolicy.
+func (in *HTTPProxyTrafficPolicy) DeepCopy() *HTTPProxyTrafficPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyTrafficPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPVerificationToken) DeepCopyInto(out *HTTPVerificationToken) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPVerificationToken.
+func (in *HTTPVerificationToken) DeepCopy() *HTTPVerificationToken {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPVerificationToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostnameBlocklist) DeepCopyInto(out *HostnameBlocklist) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostnameBlocklist.
+func (in *HostnameBlocklist) DeepCopy() *HostnameBlocklist {
+	if in == nil {
+		return nil
+	}
+	out := new(HostnameBlocklist)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostnameBlocklist) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostnameBlocklistList) DeepCopyInto(out *HostnameBlocklistList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostnameBlocklist, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostnameBlocklistList.
+func (in *HostnameBlocklistList) DeepCopy() *HostnameBlocklistList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostnameBlocklistList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostnameBlocklistList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostnameBlocklistSpec) DeepCopyInto(out *HostnameBlocklistSpec) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Suffixes != nil {
+		in, out := &in.Suffixes, &out.Suffixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostnameBlocklistSpec.
+func (in *HostnameBlocklistSpec) DeepCopy() *HostnameBlocklistSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostnameBlocklistSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostnameStatus) DeepCopyInto(out *HostnameStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostnameStatus.
+func (in *HostnameStatus) DeepCopy() *HostnameStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostnameStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAddressClaim) DeepCopyInto(out *IPAddressClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAddressClaim.
+func (in *IPAddressClaim) DeepCopy() *IPAddressClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAddressClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPAddressClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAddressClaimList) DeepCopyInto(out *IPAddressClaimList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IPAddressClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAddressClaimList.
+func (in *IPAddressClaimList) DeepCopy() *IPAddressClaimList {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAddressClaimList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPAddressClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAddressClaimSpec) DeepCopyInto(out *IPAddressClaimSpec) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAddressClaimSpec.
+func (in *IPAddressClaimSpec) DeepCopy() *IPAddressClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAddressClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAddressClaimStatus) DeepCopyInto(out *IPAddressClaimStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAddressClaimStatus.
+func (in *IPAddressClaimStatus) DeepCopy() *IPAddressClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAddressClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPBlock) DeepCopyInto(out *IPBlock) {
+	*out = *in
+	if in.Except != nil {
+		in, out := &in.Except, &out.Except
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPBlock.
+func (in *IPBlock) DeepCopy() *IPBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(IPBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPool) DeepCopyInto(out *IPPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPool.
+func (in *IPPool) DeepCopy() *IPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolAllocation) DeepCopyInto(out *IPPoolAllocation) {
+	*out = *in
+	if in.SubnetRef != nil {
+		in, out := &in.SubnetRef, &out.SubnetRef
+		*out = new(LocalSubnetReference)
+		**out = **in
+	}
+	if in.IPAddressClaimRef != nil {
+		in, out := &in.IPAddressClaimRef, &out.IPAddressClaimRef
+		*out = new(LocalIPAddressClaimReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolAllocation.
+func (in *IPPoolAllocation) DeepCopy() *IPPoolAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolCIDRStatus) DeepCopyInto(out *IPPoolCIDRStatus) {
+	*out = *in
+	if in.Bitmap != nil {
+		in, out := &in.Bitmap, &out.Bitmap
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolCIDRStatus.
+func (in *IPPoolCIDRStatus) DeepCopy() *IPPoolCIDRStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolCIDRStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolList) DeepCopyInto(out *IPPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolList.
+func (in *IPPoolList) DeepCopy() *IPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolReclaim) DeepCopyInto(out *IPPoolReclaim) {
+	*out = *in
+	out.SubnetRef = in.SubnetRef
+	in.ReclaimAfter.DeepCopyInto(&out.ReclaimAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolReclaim.
+func (in *IPPoolReclaim) DeepCopy() *IPPoolReclaim {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolReclaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultPrefixLength != nil {
+		in, out := &in.DefaultPrefixLength, &out.DefaultPrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolSpec.
+func (in *IPPoolSpec) DeepCopy() *IPPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolStatus) DeepCopyInto(out *IPPoolStatus) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]IPPoolCIDRStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([]IPPoolAllocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Reclaiming != nil {
+		in, out := &in.Reclaiming, &out.Reclaiming
+		*out = make([]IPPoolReclaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolStatus.
+func (in *IPPoolStatus) DeepCopy() *IPPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTAuthentication) DeepCopyInto(out *JWTAuthentication) {
+	*out = *in
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]JWTProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuthentication.
+func (in *JWTAuthentication) DeepCopy() *JWTAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTProvider) DeepCopyInto(out *JWTProvider) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimToHeaders != nil {
+		in, out := &in.ClaimToHeaders, &out.ClaimToHeaders
+		*out = make([]ClaimToHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTProvider.
+func (in *JWTProvider) DeepCopy() *JWTProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalIPAddressClaimReference) DeepCopyInto(out *LocalIPAddressClaimReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalIPAddressClaimReference.
+func (in *LocalIPAddressClaimReference) DeepCopy() *LocalIPAddressClaimReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalIPAddressClaimReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalNetworkContextRef) DeepCopyInto(out *LocalNetworkContextRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalNetworkContextRef.
+func (in *LocalNetworkContextRef) DeepCopy() *LocalNetworkContextRef {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalNetworkContextRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalNetworkRef) DeepCopyInto(out *LocalNetworkRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalNetworkRef.
+func (in *LocalNetworkRef) DeepCopy() *LocalNetworkRef {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalNetworkRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalSubnetReference) DeepCopyInto(out *LocalSubnetReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSubnetReference.
+func (in *LocalSubnetReference) DeepCopy() *LocalSubnetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalSubnetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Location) DeepCopyInto(out *Location) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Location.
+func (in *Location) DeepCopy() *Location {
+	if in == nil {
+		return nil
+	}
+	out := new(Location)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Location) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationBinding) DeepCopyInto(out *LocationBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationBinding.
+func (in *LocationBinding) DeepCopy() *LocationBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocationBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationBindingList) DeepCopyInto(out *LocationBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LocationBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationBindingList.
+func (in *LocationBindingList) DeepCopy() *LocationBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocationBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationBindingSpec) DeepCopyInto(out *LocationBindingSpec) {
+	*out = *in
+	out.LocationRef = in.LocationRef
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationBindingSpec.
+func (in *LocationBindingSpec) DeepCopy() *LocationBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationBindingStatus) DeepCopyInto(out *LocationBindingStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationBindingStatus.
+func (in *LocationBindingStatus) DeepCopy() *LocationBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationList) DeepCopyInto(out *LocationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Location, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationList.
+func (in *LocationList) DeepCopy() *LocationList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationProvider) DeepCopyInto(out *LocationProvider) {
+	*out = *in
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPLocationProvider)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationProvider.
+func (in *LocationProvider) DeepCopy() *LocationProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationReference) DeepCopyInto(out *LocationReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationReference.
+func (in *LocationReference) DeepCopy() *LocationReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationSpec) DeepCopyInto(out *LocationSpec) {
+	*out = *in
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Provider.DeepCopyInto(&out.Provider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationSpec.
+func (in *LocationSpec) DeepCopy() *LocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocationStatus) DeepCopyInto(out *LocationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocationStatus.
+func (in *LocationStatus) DeepCopy() *LocationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LocationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Nameserver) DeepCopyInto(out *Nameserver) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]NameserverIP, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nameserver.
+func (in *Nameserver) DeepCopy() *Nameserver {
+	if in == nil {
+		return nil
+	}
+	out := new(Nameserver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NameserverIP) DeepCopyInto(out *NameserverIP) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NameserverIP.
+func (in *NameserverIP) DeepCopy() *NameserverIP {
+	if in == nil {
+		return nil
+	}
+	out := new(NameserverIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Network) DeepCopyInto(out *Network) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
+func (in *Network) DeepCopy() *Network {
+	if in == nil {
+		return nil
+	}
+	out := new(Network)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Network) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkBinding) DeepCopyInto(out *NetworkBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBinding.
+func (in *NetworkBinding) DeepCopy() *NetworkBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkBindingList) DeepCopyInto(out *NetworkBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NetworkBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBindingList.
+func (in *NetworkBindingList) DeepCopy() *NetworkBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkBindingObjectReference) DeepCopyInto(out *NetworkBindingObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBindingObjectReference.
+func (in *NetworkBindingObjectReference) DeepCopy() *NetworkBindingObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkBindingObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkBindingSpec) DeepCopyInto(out *NetworkBindingSpec) {
+	*out = *in
+	out.Network = in.Network
+	out.Location = in.Location
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBindingSpec.
+func (in *NetworkBindingSpec) DeepCopy() *NetworkBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkBindingStatus) DeepCopyInto(out *NetworkBindingStatus) {
+	*out = *in
+	if in.NetworkContextRef != nil {
+		in, out := &in.NetworkContextRef, &out.NetworkContextRef
+		*out = new(NetworkContextRef)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkBindingStatus.
+func (in *NetworkBindingStatus) DeepCopy() *NetworkBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkContext) DeepCopyInto(out *NetworkContext) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkContext.
+func (in *NetworkContext) DeepCopy() *NetworkContext {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkContext) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkContextList) DeepCopyInto(out *NetworkContextList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NetworkContext, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkContextList.
+func (in *NetworkContextList) DeepCopy() *NetworkContextList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkContextList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkContextList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkContextRef) DeepCopyInto(out *NetworkContextRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkContextRef.
+func (in *NetworkContextRef) DeepCopy() *NetworkContextRef {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkContextRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkContextSpec) DeepCopyInto(out *NetworkContextSpec) {
+	*out = *in
+	out.Network = in.Network
+	out.Location = in.Location
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkContextSpec.
+func (in *NetworkContextSpec) DeepCopy() *NetworkContextSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkContextSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkContextStatus) DeepCopyInto(out *NetworkContextStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkContextStatus.
+func (in *NetworkContextStatus) DeepCopy() *NetworkContextStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkContextStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkIPAM) DeepCopyInto(out *NetworkIPAM) {
+	*out = *in
+	if in.IPV4Range != nil {
+		in, out := &in.IPV4Range, &out.IPV4Range
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPV6Range != nil {
+		in, out := &in.IPV6Range, &out.IPV6Range
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkIPAM.
+func (in *NetworkIPAM) DeepCopy() *NetworkIPAM {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkIPAM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkList) DeepCopyInto(out *NetworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Network, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkList.
+func (in *NetworkList) DeepCopy() *NetworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyIngressRule) DeepCopyInto(out *NetworkPolicyIngressRule) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]NetworkPolicyPort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyIngressRule.
+func (in *NetworkPolicyIngressRule) DeepCopy() *NetworkPolicyIngressRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyIngressRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyList) DeepCopyInto(out *NetworkPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NetworkPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyList.
+func (in *NetworkPolicyList) DeepCopy() *NetworkPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyPeer) DeepCopyInto(out *NetworkPolicyPeer) {
+	*out = *in
+	if in.IPBlock != nil {
+		in, out := &in.IPBlock, &out.IPBlock
+		*out = new(IPBlock)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyPeer.
+func (in *NetworkPolicyPeer) DeepCopy() *NetworkPolicyPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyPort) DeepCopyInto(out *NetworkPolicyPort) {
+	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(corev1.Protocol)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.EndPort != nil {
+		in, out := &in.EndPort, &out.EndPort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyPort.
+func (in *NetworkPolicyPort) DeepCopy() *NetworkPolicyPort {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyRuleError) DeepCopyInto(out *NetworkPolicyRuleError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyRuleError.
+func (in *NetworkPolicyRuleError) DeepCopy() *NetworkPolicyRuleError {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyRuleError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+	out.NetworkRef = in.NetworkRef
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyStatus) DeepCopyInto(out *NetworkPolicyStatus) {
+	*out = *in
+	if in.RuleErrors != nil {
+		in, out := &in.RuleErrors, &out.RuleErrors
+		*out = make([]NetworkPolicyRuleError, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyStatus.
+func (in *NetworkPolicyStatus) DeepCopy() *NetworkPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkRef) DeepCopyInto(out *NetworkRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkRef.
+func (in *NetworkRef) DeepCopy() *NetworkRef {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	in.IPAM.DeepCopyInto(&out.IPAM)
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]IPFamily, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStatus.
+func (in *NetworkStatus) DeepCopy() *NetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCAuthentication) DeepCopyInto(out *OIDCAuthentication) {
+	*out = *in
+	out.ClientSecretRef = in.ClientSecretRef
+	if in.RedirectURL != nil {
+		in, out := &in.RedirectURL, &out.RedirectURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogoutPath != nil {
+		in, out := &in.LogoutPath, &out.LogoutPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForwardAccessToken != nil {
+		in, out := &in.ForwardAccessToken, &out.ForwardAccessToken
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCAuthentication.
+func (in *OIDCAuthentication) DeepCopy() *OIDCAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OWASPCRS) DeepCopyInto(out *OWASPCRS) {
+	*out = *in
+	out.ParanoiaLevels = in.ParanoiaLevels
+	out.ScoreThresholds = in.ScoreThresholds
+	if in.RuleExclusions != nil {
+		in, out := &in.RuleExclusions, &out.RuleExclusions
+		*out = new(OWASPRuleExclusions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OWASPCRS.
+func (in *OWASPCRS) DeepCopy() *OWASPCRS {
+	if in == nil {
+		return nil
+	}
+	out := new(OWASPCRS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OWASPRuleExclusions) DeepCopyInto(out *OWASPRuleExclusions) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]OWASPTag, len(*in))
+		copy(*out, *in)
+	}
+	if in.IDs != nil {
+		in, out := &in.IDs, &out.IDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.IDRanges != nil {
+		in, out := &in.IDRanges, &out.IDRanges
+		*out = make([]OWASPIDRange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OWASPRuleExclusions.
+func (in *OWASPRuleExclusions) DeepCopy() *OWASPRuleExclusions {
+	if in == nil {
+		return nil
+	}
+	out := new(OWASPRuleExclusions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OWASPScoreThresholds) DeepCopyInto(out *OWASPScoreThresholds) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OWASPScoreThresholds.
+func (in *OWASPScoreThresholds) DeepCopy() *OWASPScoreThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(OWASPScoreThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParanoiaLevels) DeepCopyInto(out *ParanoiaLevels) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParanoiaLevels.
+func (in *ParanoiaLevels) DeepCopy() *ParanoiaLevels {
+	if in == nil {
+		return nil
+	}
+	out := new(ParanoiaLevels)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PayloadCompression) DeepCopyInto(out *PayloadCompression) {
+	*out = *in
+	if in.Compressors != nil {
+		in, out := &in.Compressors, &out.Compressors
+		*out = make([]PayloadCompressor, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PayloadCompression.
+func (in *PayloadCompression) DeepCopy() *PayloadCompression {
+	if in == nil {
+		return nil
+	}
+	out := new(PayloadCompression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PayloadPolicy) DeepCopyInto(out *PayloadPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PayloadPolicy.
+func (in *PayloadPolicy) DeepCopy() *PayloadPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPolicyIngressRule)
+	out := new(PayloadPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PayloadPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPolicyList) DeepCopyInto(out *NetworkPolicyList) {
+func (in *PayloadPolicyList) DeepCopyInto(out *PayloadPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NetworkPolicy, len(*in))
+		*out = make([]PayloadPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyList.
-func (in *NetworkPolicyList) DeepCopy() *NetworkPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PayloadPolicyList.
+func (in *PayloadPolicyList) DeepCopy() *PayloadPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPolicyList)
+	out := new(PayloadPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NetworkPolicyList) DeepCopyObject() runtime.Object {
+func (in *PayloadPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1304,221 +3375,253 @@ func (in *NetworkPolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPolicyPeer) DeepCopyInto(out *NetworkPolicyPeer) {
+func (in *PayloadPolicySpec) DeepCopyInto(out *PayloadPolicySpec) {
 	*out = *in
-	if in.IPBlock != nil {
-		in, out := &in.IPBlock, &out.IPBlock
-		*out = new(IPBlock)
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1alpha2.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(PayloadCompression)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RequestBodyLimit != nil {
+		in, out := &in.RequestBodyLimit, &out.RequestBodyLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyPeer.
-func (in *NetworkPolicyPeer) DeepCopy() *NetworkPolicyPeer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PayloadPolicySpec.
+func (in *PayloadPolicySpec) DeepCopy() *PayloadPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPolicyPeer)
+	out := new(PayloadPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPolicyPort) DeepCopyInto(out *NetworkPolicyPort) {
+func (in *PayloadPolicyStatus) DeepCopyInto(out *PayloadPolicyStatus) {
 	*out = *in
-	if in.Protocol != nil {
-		in, out := &in.Protocol, &out.Protocol
-		*out = new(corev1.Protocol)
-		**out = **in
-	}
-	if in.Port != nil {
-		in, out := &in.Port, &out.Port
-		*out = new(intstr.IntOrString)
-		**out = **in
-	}
-	if in.EndPort != nil {
-		in, out := &in.EndPort, &out.EndPort
-		*out = new(int32)
-		**out = **in
-	}
+	in.PolicyStatus.DeepCopyInto(&out.PolicyStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyPort.
-func (in *NetworkPolicyPort) DeepCopy() *NetworkPolicyPort {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PayloadPolicyStatus.
+func (in *PayloadPolicyStatus) DeepCopy() *PayloadPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPolicyPort)
+	out := new(PayloadPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+func (in *RateLimitClientSelector) DeepCopyInto(out *RateLimitClientSelector) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]RateLimitHeaderMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SourceCIDR != nil {
+		in, out := &in.SourceCIDR, &out.SourceCIDR
+		*out = new(RateLimitSourceCIDRMatch)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
-func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitClientSelector.
+func (in *RateLimitClientSelector) DeepCopy() *RateLimitClientSelector {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPolicySpec)
+	out := new(RateLimitClientSelector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPolicyStatus) DeepCopyInto(out *NetworkPolicyStatus) {
+func (in *RateLimitHeaderMatch) DeepCopyInto(out *RateLimitHeaderMatch) {
 	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyStatus.
-func (in *NetworkPolicyStatus) DeepCopy() *NetworkPolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitHeaderMatch.
+func (in *RateLimitHeaderMatch) DeepCopy() *RateLimitHeaderMatch {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPolicyStatus)
+	out := new(RateLimitHeaderMatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkRef) DeepCopyInto(out *NetworkRef) {
+func (in *RateLimitPolicy) DeepCopyInto(out *RateLimitPolicy) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkRef.
-func (in *NetworkRef) DeepCopy() *NetworkRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicy.
+func (in *RateLimitPolicy) DeepCopy() *RateLimitPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkRef)
+	out := new(RateLimitPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RateLimitPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+func (in *RateLimitPolicyList) DeepCopyInto(out *RateLimitPolicyList) {
 	*out = *in
-	in.IPAM.DeepCopyInto(&out.IPAM)
-	if in.IPFamilies != nil {
-		in, out := &in.IPFamilies, &out.IPFamilies
-		*out = make([]IPFamily, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RateLimitPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
-func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicyList.
+func (in *RateLimitPolicyList) DeepCopy() *RateLimitPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkSpec)
+	out := new(RateLimitPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RateLimitPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
+func (in *RateLimitPolicySpec) DeepCopyInto(out *RateLimitPolicySpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1alpha2.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RateLimitRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStatus.
-func (in *NetworkStatus) DeepCopy() *NetworkStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicySpec.
+func (in *RateLimitPolicySpec) DeepCopy() *RateLimitPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkStatus)
+	out := new(RateLimitPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OWASPCRS) DeepCopyInto(out *OWASPCRS) {
+func (in *RateLimitPolicyStatus) DeepCopyInto(out *RateLimitPolicyStatus) {
 	*out = *in
-	out.ParanoiaLevels = in.ParanoiaLevels
-	out.ScoreThresholds = in.ScoreThresholds
-	if in.RuleExclusions != nil {
-		in, out := &in.RuleExclusions, &out.RuleExclusions
-		*out = new(OWASPRuleExclusions)
-		(*in).DeepCopyInto(*out)
-	}
+	in.PolicyStatus.DeepCopyInto(&out.PolicyStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OWASPCRS.
-func (in *OWASPCRS) DeepCopy() *OWASPCRS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicyStatus.
+func (in *RateLimitPolicyStatus) DeepCopy() *RateLimitPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OWASPCRS)
+	out := new(RateLimitPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OWASPRuleExclusions) DeepCopyInto(out *OWASPRuleExclusions) {
+func (in *RateLimitRule) DeepCopyInto(out *RateLimitRule) {
 	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]OWASPTag, len(*in))
-		copy(*out, *in)
-	}
-	if in.IDs != nil {
-		in, out := &in.IDs, &out.IDs
-		*out = make([]int, len(*in))
-		copy(*out, *in)
-	}
-	if in.IDRanges != nil {
-		in, out := &in.IDRanges, &out.IDRanges
-		*out = make([]OWASPIDRange, len(*in))
-		copy(*out, *in)
+	if in.ClientSelectors != nil {
+		in, out := &in.ClientSelectors, &out.ClientSelectors
+		*out = make([]RateLimitClientSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
+	out.Limit = in.Limit
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OWASPRuleExclusions.
-func (in *OWASPRuleExclusions) DeepCopy() *OWASPRuleExclusions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitRule.
+func (in *RateLimitRule) DeepCopy() *RateLimitRule {
 	if in == nil {
 		return nil
 	}
-	out := new(OWASPRuleExclusions)
+	out := new(RateLimitRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OWASPScoreThresholds) DeepCopyInto(out *OWASPScoreThresholds) {
+func (in *RateLimitSourceCIDRMatch) DeepCopyInto(out *RateLimitSourceCIDRMatch) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OWASPScoreThresholds.
-func (in *OWASPScoreThresholds) DeepCopy() *OWASPScoreThresholds {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSourceCIDRMatch.
+func (in *RateLimitSourceCIDRMatch) DeepCopy() *RateLimitSourceCIDRMatch {
 	if in == nil {
 		return nil
 	}
-	out := new(OWASPScoreThresholds)
+	out := new(RateLimitSourceCIDRMatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ParanoiaLevels) DeepCopyInto(out *ParanoiaLevels) {
+func (in *RateLimitValue) DeepCopyInto(out *RateLimitValue) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParanoiaLevels.
-func (in *ParanoiaLevels) DeepCopy() *ParanoiaLevels {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitValue.
+func (in *RateLimitValue) DeepCopy() *RateLimitValue {
 	if in == nil {
 		return nil
 	}
-	out := new(ParanoiaLevels)
+	out := new(RateLimitValue)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1666,6 +3769,26 @@ func (in *SubnetClaim) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetClaimAllocation) DeepCopyInto(out *SubnetClaimAllocation) {
+	*out = *in
+	in.AllocatedAt.DeepCopyInto(&out.AllocatedAt)
+	if in.ReleasedAt != nil {
+		in, out := &in.ReleasedAt, &out.ReleasedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetClaimAllocation.
+func (in *SubnetClaimAllocation) DeepCopy() *SubnetClaimAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetClaimAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetClaimList) DeepCopyInto(out *SubnetClaimList) {
 	*out = *in
@@ -1743,9 +3866,21 @@ func (in *SubnetClaimStatus) DeepCopyInto(out *SubnetClaimStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]SubnetPrefix, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllocationHistory != nil {
+		in, out := &in.AllocationHistory, &out.AllocationHistory
+		*out = make([]SubnetClaimAllocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1794,6 +3929,21 @@ func (in *SubnetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetPrefix) DeepCopyInto(out *SubnetPrefix) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetPrefix.
+func (in *SubnetPrefix) DeepCopy() *SubnetPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
@@ -1824,9 +3974,14 @@ func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]SubnetPrefix, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1843,6 +3998,36 @@ func (in *SubnetStatus) DeepCopy() *SubnetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSPolicy) DeepCopyInto(out *TLSPolicy) {
+	*out = *in
+	if in.MinVersion != nil {
+		in, out := &in.MinVersion, &out.MinVersion
+		*out = new(TLSVersion)
+		**out = **in
+	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ALPNProtocols != nil {
+		in, out := &in.ALPNProtocols, &out.ALPNProtocols
+		*out = make([]ALPNProtocol, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSPolicy.
+func (in *TLSPolicy) DeepCopy() *TLSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrafficProtectionPolicy) DeepCopyInto(out *TrafficProtectionPolicy) {
 	*out = *in
@@ -1870,6 +4055,32 @@ func (in *TrafficProtectionPolicy) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficProtectionPolicyEvents) DeepCopyInto(out *TrafficProtectionPolicyEvents) {
+	*out = *in
+	out.Window = in.Window
+	in.ObservedTime.DeepCopyInto(&out.ObservedTime)
+	if in.TopRules != nil {
+		in, out := &in.TopRules, &out.TopRules
+		*out = make([]TrafficProtectionPolicyRuleEvents, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastEventTime != nil {
+		in, out := &in.LastEventTime, &out.LastEventTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficProtectionPolicyEvents.
+func (in *TrafficProtectionPolicyEvents) DeepCopy() *TrafficProtectionPolicyEvents {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficProtectionPolicyEvents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrafficProtectionPolicyList) DeepCopyInto(out *TrafficProtectionPolicyList) {
 	*out = *in
@@ -1902,10 +4113,35 @@ func (in *TrafficProtectionPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficProtectionPolicyRuleEvents) DeepCopyInto(out *TrafficProtectionPolicyRuleEvents) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficProtectionPolicyRuleEvents.
+func (in *TrafficProtectionPolicyRuleEvents) DeepCopy() *TrafficProtectionPolicyRuleEvents {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficProtectionPolicyRuleEvents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrafficProtectionPolicyRuleSet) DeepCopyInto(out *TrafficProtectionPolicyRuleSet) {
 	*out = *in
 	in.OWASPCoreRuleSet.DeepCopyInto(&out.OWASPCoreRuleSet)
+	if in.Geo != nil {
+		in, out := &in.Geo, &out.Geo
+		*out = new(GeoRuleSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomRules != nil {
+		in, out := &in.CustomRules, &out.CustomRules
+		*out = new(CustomRuleSet)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficProtectionPolicyRuleSet.
@@ -1951,6 +4187,16 @@ func (in *TrafficProtectionPolicySpec) DeepCopy() *TrafficProtectionPolicySpec {
 func (in *TrafficProtectionPolicyStatus) DeepCopyInto(out *TrafficProtectionPolicyStatus) {
 	*out = *in
 	in.PolicyStatus.DeepCopyInto(&out.PolicyStatus)
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]Warning, len(*in))
+		copy(*out, *in)
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = new(TrafficProtectionPolicyEvents)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficProtectionPolicyStatus.
@@ -1962,3 +4208,18 @@ func (in *TrafficProtectionPolicyStatus) DeepCopy() *TrafficProtectionPolicyStat
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Warning) DeepCopyInto(out *Warning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Warning.
+func (in *Warning) DeepCopy() *Warning {
+	if in == nil {
+		return nil
+	}
+	out := new(Warning)
+	in.DeepCopyInto(out)
+	return out
+}
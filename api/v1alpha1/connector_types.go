@@ -160,6 +160,49 @@ type ConnectorStatus struct {
 	//
 	// +kubebuilder:validation:Optional
 	LeaseRef *corev1.LocalObjectReference `json:"leaseRef,omitempty"`
+
+	// Attachments lists the resources that route traffic through the connector,
+	// and whether their downstream routes are programmed with the current
+	// addressing of the connector.
+	//
+	// +listType=atomic
+	// +kubebuilder:validation:Optional
+	Attachments []ConnectorAttachmentStatus `json:"attachments,omitempty"`
+
+	// LastAddressingPushTime is when the addressing of the connector was last
+	// pushed to the edge, so that the routes backed by it are re-programmed.
+	//
+	// +kubebuilder:validation:Optional
+	LastAddressingPushTime *metav1.Time `json:"lastAddressingPushTime,omitempty"`
+}
+
+// ConnectorAttachmentStatus describes a resource that routes traffic through a
+// connector.
+type ConnectorAttachmentStatus struct {
+	// Kind of the resource, e.g. HTTPProxy.
+	//
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// Name of the resource.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Gateway is the name of the Gateway that serves the routes of the
+	// resource.
+	//
+	// +kubebuilder:validation:Optional
+	Gateway string `json:"gateway,omitempty"`
+
+	// Programmed is true when the downstream routes of the resource are
+	// programmed.
+	Programmed bool `json:"programmed"`
+
+	// AddressingCurrent is true when the downstream routes of the resource are
+	// programmed and the edge has been pushed the current addressing of the
+	// connector.
+	AddressingCurrent bool `json:"addressingCurrent"`
 }
 
 const (
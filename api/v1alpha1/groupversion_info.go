@@ -38,6 +38,17 @@ var (
 // resource-agnostic and carries no bespoke schema.
 const UpstreamStatusAnnotation = "networking.datumapis.com/upstream-status"
 
+// ConnectorAddressingSweepAnnotation is stamped onto downstream Connectors when
+// an HTTPProxy that references them becomes Programmed. Its value changes with
+// every sweep, so the edge re-programs the routes backed by the Connector with
+// its current addressing instead of waiting for the next liveness change.
+const ConnectorAddressingSweepAnnotation = "networking.datumapis.com/connector-addressing-sweep"
+
+// ConnectorAddressingPushTimeAnnotation records, in RFC 3339 format, when the
+// current addressing of a Connector was last pushed to its downstream copy by
+// a sweep.
+const ConnectorAddressingPushTimeAnnotation = "networking.datumapis.com/connector-addressing-push-time"
+
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(GroupVersion,
 		&Connector{},
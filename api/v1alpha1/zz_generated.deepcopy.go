@@ -185,6 +185,21 @@ func (in *ConnectorAdvertisementStatus) DeepCopy() *ConnectorAdvertisementStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectorAttachmentStatus) DeepCopyInto(out *ConnectorAttachmentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectorAttachmentStatus.
+func (in *ConnectorAttachmentStatus) DeepCopy() *ConnectorAttachmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectorAttachmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectorCapability) DeepCopyInto(out *ConnectorCapability) {
 	*out = *in
@@ -468,6 +483,15 @@ func (in *ConnectorStatus) DeepCopyInto(out *ConnectorStatus) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.Attachments != nil {
+		in, out := &in.Attachments, &out.Attachments
+		*out = make([]ConnectorAttachmentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAddressingPushTime != nil {
+		in, out := &in.LastAddressingPushTime, &out.LastAddressingPushTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectorStatus.
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha2_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	networkingv1alpha2 "go.datum.net/network-services-operator/api/v1alpha2"
+)
+
+func TestDomainConversion(t *testing.T) {
+	t.Parallel()
+
+	lastAttempt := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	hub := &networkingv1alpha.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-com", Namespace: "default"},
+		Spec:       networkingv1alpha.DomainSpec{DomainName: "example.com"},
+		Status: networkingv1alpha.DomainStatus{
+			Verification: &networkingv1alpha.DomainVerificationStatus{
+				LastVerificationAttempt: lastAttempt,
+			},
+			Registration: &networkingv1alpha.Registration{
+				Domain: "example.com",
+			},
+			Consumers: []networkingv1alpha.DomainConsumer{
+				{Kind: "Gateway", Name: "gateway"},
+				{Kind: "DNSZone", Name: "zone"},
+			},
+		},
+	}
+
+	spoke := &networkingv1alpha2.Domain{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+
+	assert.Equal(t, hub.ObjectMeta, spoke.ObjectMeta)
+	assert.Equal(t, hub.Spec, spoke.Spec)
+	require.NotNil(t, spoke.Status.Verification)
+	assert.Nil(t, spoke.Status.Verification.NextVerificationAttempt, "unset timestamps should be omitted")
+	assert.Equal(t, &lastAttempt, spoke.Status.Verification.LastVerificationAttempt)
+	require.NotNil(t, spoke.Status.Registration)
+	assert.Nil(t, spoke.Status.Registration.NextRefreshAttempt)
+	assert.Nil(t, spoke.Status.Registration.LastRefreshAttempt)
+	assert.Equal(t, hub.Status.Consumers, spoke.Status.Consumers)
+
+	roundTripped := &networkingv1alpha.Domain{}
+	require.NoError(t, spoke.ConvertTo(roundTripped))
+	assert.Equal(t, hub, roundTripped)
+}
+
+func TestHTTPProxyConversion(t *testing.T) {
+	t.Parallel()
+
+	spoke := &networkingv1alpha2.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{Name: "proxy", Namespace: "default"},
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Hostnames: []gatewayv1.Hostname{"verified.example.com", "pending.example.com"},
+		},
+		Status: networkingv1alpha2.HTTPProxyStatus{
+			HostnameStatuses: []networkingv1alpha.HostnameStatus{
+				{
+					Hostname: "verified.example.com",
+					Conditions: []metav1.Condition{
+						{Type: networkingv1alpha.HostnameConditionVerified, Status: metav1.ConditionTrue},
+					},
+				},
+				{
+					Hostname: "pending.example.com",
+					Conditions: []metav1.Condition{
+						{Type: networkingv1alpha.HostnameConditionVerified, Status: metav1.ConditionFalse},
+					},
+				},
+			},
+			HostnameCount:         2,
+			VerifiedHostnameCount: 1,
+		},
+	}
+
+	hub := &networkingv1alpha.HTTPProxy{}
+	require.NoError(t, spoke.ConvertTo(hub))
+
+	assert.Equal(t, spoke.Spec, hub.Spec)
+	assert.Equal(t, []gatewayv1.Hostname{"verified.example.com"}, hub.Status.Hostnames,
+		"deprecated hostnames should list verified hostnames")
+	assert.Equal(t, spoke.Status.HostnameStatuses, hub.Status.HostnameStatuses)
+
+	roundTripped := &networkingv1alpha2.HTTPProxy{}
+	require.NoError(t, roundTripped.ConvertFrom(hub))
+	assert.Equal(t, spoke, roundTripped)
+}
+
+func TestTrafficProtectionPolicyConversion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		paranoiaLevels *networkingv1alpha2.ParanoiaLevels
+		wantBlocking   int
+		wantDetection  int
+	}{
+		{
+			name:          "defaults",
+			wantBlocking:  1,
+			wantDetection: 1,
+		},
+		{
+			name:           "detection defaults to blocking",
+			paranoiaLevels: &networkingv1alpha2.ParanoiaLevels{Blocking: ptr.To[int32](2)},
+			wantBlocking:   2,
+			wantDetection:  2,
+		},
+		{
+			name: "explicit levels",
+			paranoiaLevels: &networkingv1alpha2.ParanoiaLevels{
+				Blocking:  ptr.To[int32](1),
+				Detection: ptr.To[int32](3),
+			},
+			wantBlocking:  1,
+			wantDetection: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spoke := &networkingv1alpha2.TrafficProtectionPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "tpp", Namespace: "default"},
+				Spec: networkingv1alpha2.TrafficProtectionPolicySpec{
+					Mode:               networkingv1alpha.TrafficProtectionPolicyEnforce,
+					SamplingPercentage: 50,
+					RuleSets: []networkingv1alpha2.TrafficProtectionPolicyRuleSet{
+						{
+							Type: networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet,
+							OWASPCoreRuleSet: &networkingv1alpha2.OWASPCRS{
+								ParanoiaLevels: tt.paranoiaLevels,
+							},
+						},
+						{
+							Type: networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+							Geo:  &networkingv1alpha.GeoRuleSet{},
+						},
+					},
+				},
+			}
+
+			hub := &networkingv1alpha.TrafficProtectionPolicy{}
+			require.NoError(t, spoke.ConvertTo(hub))
+
+			assert.Equal(t, 50, hub.Spec.SamplingPercentage)
+			require.Len(t, hub.Spec.RuleSets, 2)
+			levels := hub.Spec.RuleSets[0].OWASPCoreRuleSet.ParanoiaLevels
+			assert.Equal(t, tt.wantBlocking, levels.Blocking)
+			assert.Equal(t, tt.wantDetection, levels.Detection)
+
+			roundTripped := &networkingv1alpha2.TrafficProtectionPolicy{}
+			require.NoError(t, roundTripped.ConvertFrom(hub))
+
+			assert.Equal(t, spoke, roundTripped, "conversion should round trip")
+		})
+	}
+}
+
+func TestTrafficProtectionPolicyConversionModifiedHub(t *testing.T) {
+	t.Parallel()
+
+	spoke := &networkingv1alpha2.TrafficProtectionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "tpp", Namespace: "default"},
+		Spec: networkingv1alpha2.TrafficProtectionPolicySpec{
+			RuleSets: []networkingv1alpha2.TrafficProtectionPolicyRuleSet{
+				{
+					Type: networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet,
+					OWASPCoreRuleSet: &networkingv1alpha2.OWASPCRS{
+						ParanoiaLevels: &networkingv1alpha2.ParanoiaLevels{Blocking: ptr.To[int32](2)},
+					},
+				},
+			},
+		},
+	}
+
+	hub := &networkingv1alpha.TrafficProtectionPolicy{}
+	require.NoError(t, spoke.ConvertTo(hub))
+	hub.Spec.RuleSets[0].OWASPCoreRuleSet.ParanoiaLevels.Detection = 4
+
+	converted := &networkingv1alpha2.TrafficProtectionPolicy{}
+	require.NoError(t, converted.ConvertFrom(hub))
+
+	assert.Empty(t, converted.Annotations)
+	owasp := converted.Spec.RuleSets[0].OWASPCoreRuleSet
+	require.NotNil(t, owasp)
+	require.NotNil(t, owasp.ParanoiaLevels)
+	assert.Equal(t, ptr.To[int32](2), owasp.ParanoiaLevels.Blocking)
+	assert.Equal(t, ptr.To[int32](4), owasp.ParanoiaLevels.Detection,
+		"changes made through v1alpha should not be overwritten by the recorded configuration")
+}
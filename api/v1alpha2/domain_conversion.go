@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+var _ conversion.Convertible = &Domain{}
+
+// ConvertTo converts this Domain to the Hub version (v1alpha).
+func (src *Domain) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*networkingv1alpha.Domain)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = src.Spec
+
+	dst.Status = networkingv1alpha.DomainStatus{
+		Nameservers: src.Status.Nameservers,
+		Apex:        src.Status.Apex,
+		Conditions:  src.Status.Conditions,
+		Consumers:   src.Status.Consumers,
+	}
+	if v := src.Status.Verification; v != nil {
+		dst.Status.Verification = &networkingv1alpha.DomainVerificationStatus{
+			DNSRecord:               v.DNSRecord,
+			HTTPToken:               v.HTTPToken,
+			NextVerificationAttempt: timeValue(v.NextVerificationAttempt),
+			LastVerificationAttempt: timeValue(v.LastVerificationAttempt),
+			ReverificationFailures:  v.ReverificationFailures,
+		}
+	}
+	if r := src.Status.Registration; r != nil {
+		dst.Status.Registration = &networkingv1alpha.Registration{
+			Domain:             r.Domain,
+			RegistryDomainID:   r.RegistryDomainID,
+			Handle:             r.Handle,
+			Source:             r.Source,
+			Registrar:          r.Registrar,
+			Registry:           r.Registry,
+			CreatedAt:          r.CreatedAt,
+			UpdatedAt:          r.UpdatedAt,
+			ExpiresAt:          r.ExpiresAt,
+			Statuses:           r.Statuses,
+			DNSSEC:             r.DNSSEC,
+			Contacts:           r.Contacts,
+			Abuse:              r.Abuse,
+			NextRefreshAttempt: timeValue(r.NextRefreshAttempt),
+			LastRefreshAttempt: timeValue(r.LastRefreshAttempt),
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha) to this version.
+func (dst *Domain) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*networkingv1alpha.Domain)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = src.Spec
+
+	dst.Status = DomainStatus{
+		Nameservers: src.Status.Nameservers,
+		Apex:        src.Status.Apex,
+		Conditions:  src.Status.Conditions,
+		Consumers:   src.Status.Consumers,
+	}
+	if v := src.Status.Verification; v != nil {
+		dst.Status.Verification = &DomainVerificationStatus{
+			DNSRecord:               v.DNSRecord,
+			HTTPToken:               v.HTTPToken,
+			NextVerificationAttempt: timePointer(v.NextVerificationAttempt),
+			LastVerificationAttempt: timePointer(v.LastVerificationAttempt),
+			ReverificationFailures:  v.ReverificationFailures,
+		}
+	}
+	if r := src.Status.Registration; r != nil {
+		dst.Status.Registration = &Registration{
+			Domain:             r.Domain,
+			RegistryDomainID:   r.RegistryDomainID,
+			Handle:             r.Handle,
+			Source:             r.Source,
+			Registrar:          r.Registrar,
+			Registry:           r.Registry,
+			CreatedAt:          r.CreatedAt,
+			UpdatedAt:          r.UpdatedAt,
+			ExpiresAt:          r.ExpiresAt,
+			Statuses:           r.Statuses,
+			DNSSEC:             r.DNSSEC,
+			Contacts:           r.Contacts,
+			Abuse:              r.Abuse,
+			NextRefreshAttempt: timePointer(r.NextRefreshAttempt),
+			LastRefreshAttempt: timePointer(r.LastRefreshAttempt),
+		}
+	}
+	return nil
+}
+
+// timeValue returns the time t points to, or the zero time if t is nil.
+func timeValue(t *metav1.Time) metav1.Time {
+	if t == nil {
+		return metav1.Time{}
+	}
+	return *t
+}
+
+// timePointer returns a pointer to t, or nil if t is the zero time.
+func timePointer(t metav1.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
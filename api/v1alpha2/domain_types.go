@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Domain represents a domain name in the Datum system
+//
+// +kubebuilder:printcolumn:name="Domain Name",type="string",JSONPath=".spec.domainName"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Verified",type="string",JSONPath=`.status.conditions[?(@.type=="Verified")].status`
+// +kubebuilder:printcolumn:name="Verification Message",type="string",JSONPath=`.status.conditions[?(@.type=="Verified")].message`,priority=1
+// +kubebuilder:printcolumn:name="Apex",type="boolean",JSONPath=".status.apex",priority=1
+// +kubebuilder:printcolumn:name="Next Verification",type="date",JSONPath=".status.verification.nextVerificationAttempt",priority=1
+type Domain struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec networkingv1alpha.DomainSpec `json:"spec,omitempty"`
+
+	// +kubebuilder:default={conditions: {{type: "Verified", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"},{type: "VerifiedDNS", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"},{type: "VerifiedHTTP", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}}
+	Status DomainStatus `json:"status,omitempty"`
+}
+
+// DomainStatus defines the observed state of Domain
+type DomainStatus struct {
+	Verification *DomainVerificationStatus `json:"verification,omitempty"`
+	Registration *Registration             `json:"registration,omitempty"`
+	// Nameservers lists the authoritative NS for the *effective* domain name:
+	// - If Apex == true: taken from RDAP for the registered domain (eTLD+1)
+	// - If Apex == false: taken from DNS delegation for the subdomain; falls back to apex NS if no cut
+	Nameservers []networkingv1alpha.Nameserver `json:"nameservers,omitempty"`
+	// Apex is true when spec.domainName is the registered domain (eTLD+1).
+	Apex       bool               `json:"apex,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Consumers lists the Gateways, HTTPProxies and DNSZones in the namespace
+	// of the Domain with a hostname or domain name that falls under it. At most
+	// MaxDomainConsumers are listed.
+	//
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=atomic
+	Consumers []networkingv1alpha.DomainConsumer `json:"consumers,omitempty"`
+}
+
+// DomainVerificationStatus represents the verification status of a domain.
+//
+// Unlike v1alpha, attempts that haven't happened are omitted instead of being
+// reported as null timestamps.
+type DomainVerificationStatus struct {
+	DNSRecord               networkingv1alpha.DNSVerificationRecord `json:"dnsRecord,omitempty"`
+	HTTPToken               networkingv1alpha.HTTPVerificationToken `json:"httpToken,omitempty"`
+	NextVerificationAttempt *metav1.Time                            `json:"nextVerificationAttempt,omitempty"`
+	LastVerificationAttempt *metav1.Time                            `json:"lastVerificationAttempt,omitempty"`
+
+	// ReverificationFailures is the number of consecutive failed attempts to
+	// re-verify the ownership of a verified Domain.
+	ReverificationFailures int32 `json:"reverificationFailures,omitempty"`
+}
+
+// Registration represents the registration information for a domain
+//
+// Unlike v1alpha, refresh attempts that haven't happened are omitted instead
+// of being reported as null timestamps.
+type Registration struct {
+	// Identity & provenance
+	Domain           string `json:"domain,omitempty"`           // FQDN as observed (punycode)
+	RegistryDomainID string `json:"registryDomainID,omitempty"` // e.g., "12345-EXAMPLE"
+	Handle           string `json:"handle,omitempty"`           // RDAP handle if present
+	Source           string `json:"source,omitempty"`           // "rdap" | "whois"
+
+	Registrar *networkingv1alpha.RegistrarInfo `json:"registrar,omitempty"`
+	Registry  *networkingv1alpha.RegistryInfo  `json:"registry,omitempty"`
+
+	// Lifecycle
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Raw statuses that will either be rdap rfc8056 or whois EPP status strings
+	Statuses []string `json:"statuses,omitempty"` // e.g., clientTransferProhibited (EPP) or client transfer prohibited (RDAP)
+
+	// DNSSEC (from RDAP secureDNS, with WHOIS fallback when parsable)
+	DNSSEC *networkingv1alpha.DNSSECInfo `json:"dnssec,omitempty"`
+
+	// Contacts (minimal, non-PII summary if available)
+	Contacts *networkingv1alpha.ContactSet `json:"contacts,omitempty"`
+
+	// Abuse / support contacts (registrar/registry)
+	Abuse *networkingv1alpha.AbuseContact `json:"abuse,omitempty"`
+
+	NextRefreshAttempt *metav1.Time `json:"nextRefreshAttempt,omitempty"`
+	LastRefreshAttempt *metav1.Time `json:"lastRefreshAttempt,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DomainList contains a list of Domain
+type DomainList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Domain `json:"items"`
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package v1alpha2 contains API Schema definitions for the networking v1alpha2 API group.
+//
+// v1alpha2 revises the Domain, HTTPProxy and TrafficProtectionPolicy APIs of
+// v1alpha. v1alpha remains the storage version, and objects are converted
+// between the versions by the conversion webhook of the operator.
+// +kubebuilder:object:generate=true
+// +groupName=networking.datumapis.com
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// GroupVersion is group version used to register these objects.
+	GroupVersion = schema.GroupVersion{Group: "networking.datumapis.com", Version: "v1alpha2"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(GroupVersion,
+		&Domain{},
+		&DomainList{},
+		&HTTPProxy{},
+		&HTTPProxyList{},
+		&TrafficProtectionPolicy{},
+		&TrafficProtectionPolicyList{},
+	)
+	metav1.AddToGroupVersion(scheme, GroupVersion)
+	return nil
+}
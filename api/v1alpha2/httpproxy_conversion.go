@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha2
+
+import (
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+var _ conversion.Convertible = &HTTPProxy{}
+
+// ConvertTo converts this HTTPProxy to the Hub version (v1alpha).
+func (src *HTTPProxy) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*networkingv1alpha.HTTPProxy)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = src.Spec
+
+	dst.Status = networkingv1alpha.HTTPProxyStatus{
+		Addresses:             src.Status.Addresses,
+		CanonicalHostname:     src.Status.CanonicalHostname,
+		HostnameStatuses:      src.Status.HostnameStatuses,
+		HostnameCount:         src.Status.HostnameCount,
+		VerifiedHostnameCount: src.Status.VerifiedHostnameCount,
+		Readiness:             src.Status.Readiness,
+		Backends:              src.Status.Backends,
+		BlueGreen:             src.Status.BlueGreen,
+		BackendHealth:         src.Status.BackendHealth,
+		Warnings:              src.Status.Warnings,
+		Conditions:            src.Status.Conditions,
+	}
+
+	// The deprecated hostnames of v1alpha are the verified hostnames.
+	for _, hs := range src.Status.HostnameStatuses {
+		if apimeta.IsStatusConditionTrue(hs.Conditions, networkingv1alpha.HostnameConditionVerified) {
+			dst.Status.Hostnames = append(dst.Status.Hostnames, gatewayv1.Hostname(hs.Hostname))
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha) to this version.
+func (dst *HTTPProxy) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*networkingv1alpha.HTTPProxy)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = src.Spec
+
+	dst.Status = HTTPProxyStatus{
+		Addresses:             src.Status.Addresses,
+		CanonicalHostname:     src.Status.CanonicalHostname,
+		HostnameStatuses:      src.Status.HostnameStatuses,
+		HostnameCount:         src.Status.HostnameCount,
+		VerifiedHostnameCount: src.Status.VerifiedHostnameCount,
+		Readiness:             src.Status.Readiness,
+		Backends:              src.Status.Backends,
+		BlueGreen:             src.Status.BlueGreen,
+		BackendHealth:         src.Status.BackendHealth,
+		Warnings:              src.Status.Warnings,
+		Conditions:            src.Status.Conditions,
+	}
+	return nil
+}
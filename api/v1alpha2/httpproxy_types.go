@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// HTTPProxyStatus defines the observed state of HTTPProxy.
+//
+// The deprecated `hostnames` field of v1alpha is removed, see
+// `hostnameStatuses` instead.
+type HTTPProxyStatus struct {
+	// Addresses lists the network addresses that have been bound to the
+	// HTTPProxy.
+	//
+	// This field will not contain custom hostnames defined in the HTTPProxy. See
+	// the `hostnameStatuses` field
+	//
+	// +kubebuilder:validation:MaxItems=16
+	Addresses []gatewayv1.GatewayStatusAddress `json:"addresses,omitempty"`
+
+	// CanonicalHostname is the platform-managed stable hostname assigned to this
+	// HTTPProxy (e.g., "<uid>.datumproxy.net"). Users may create external CNAME
+	// or ALIAS records pointing to this hostname to route traffic through the
+	// platform. The platform manages A/AAAA records for this hostname in the
+	// datumproxy.net zone.
+	//
+	// +optional
+	CanonicalHostname string `json:"canonicalHostname,omitempty"`
+
+	// HostnameStatuses lists the per-hostname status for each hostname configured
+	// on this HTTPProxy. Each entry includes verification and DNS record
+	// programming conditions.
+	//
+	// +optional
+	HostnameStatuses []networkingv1alpha.HostnameStatus `json:"hostnameStatuses,omitempty"`
+
+	// HostnameCount is the number of hostnames in HostnameStatuses.
+	//
+	// +optional
+	HostnameCount int32 `json:"hostnameCount,omitempty"`
+
+	// VerifiedHostnameCount is the number of hostnames whose ownership has been
+	// verified.
+	//
+	// +optional
+	VerifiedHostnameCount int32 `json:"verifiedHostnameCount,omitempty"`
+
+	// Readiness breaks down the gates that must all be satisfied before the
+	// `Ready` condition is set to True, including which hostnames or route
+	// parents are still pending for each gate.
+	//
+	// +optional
+	Readiness *networkingv1alpha.HTTPProxyReadiness `json:"readiness,omitempty"`
+
+	// Backends lists the addresses resolved for backend hostnames. It is only
+	// populated when the operator resolves backend hostnames into IP addresses.
+	//
+	// +listType=atomic
+	// +optional
+	Backends []networkingv1alpha.HTTPProxyBackendStatus `json:"backends,omitempty"`
+
+	// BlueGreen describes the active and draining backend sets of the rules
+	// with a blue/green traffic policy.
+	//
+	// +listType=atomic
+	// +optional
+	BlueGreen []networkingv1alpha.HTTPProxyBlueGreenStatus `json:"blueGreen,omitempty"`
+
+	// BackendHealth reports the health of the endpoints of the rules whose
+	// backends are health checked, as observed by the gateway. It is only
+	// reported when the platform collects data plane health.
+	//
+	// +optional
+	BackendHealth *networkingv1alpha.HTTPProxyBackendHealth `json:"backendHealth,omitempty"`
+
+	// Warnings lists configurations in the spec that are valid, but likely to
+	// be unintended.
+	//
+	// +listType=atomic
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	Warnings []networkingv1alpha.Warning `json:"warnings,omitempty"`
+
+	// Conditions describe the current conditions of the HTTPProxy.
+	//
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// An HTTPProxy builds on top of Gateway API resources to provide a more convenient
+// method to manage simple reverse proxy use cases.
+//
+// +kubebuilder:printcolumn:name="Hostnames",type=integer,JSONPath=`.status.hostnameCount`
+// +kubebuilder:printcolumn:name="Verified",type=integer,JSONPath=`.status.verifiedHostnameCount`
+// +kubebuilder:printcolumn:name="Programmed",type=string,JSONPath=`.status.conditions[?(@.type=="Programmed")].status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Certificates",type=string,JSONPath=`.status.conditions[?(@.type=="CertificatesReady")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type HTTPProxy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of an HTTPProxy.
+	// +kubebuilder:validation:Required
+	Spec networkingv1alpha.HTTPProxySpec `json:"spec,omitempty"`
+
+	// Status defines the current state of an HTTPProxy.
+	//
+	// +kubebuilder:default={conditions: {{type: "Accepted", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"},{type: "Programmed", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}}
+	Status HTTPProxyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HTTPProxyList contains a list of HTTPProxy.
+type HTTPProxyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HTTPProxy `json:"items"`
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+var _ conversion.Convertible = &TrafficProtectionPolicy{}
+
+// defaultParanoiaLevel is the paranoia level used when none is specified.
+const defaultParanoiaLevel = 1
+
+// owaspCoreRuleSetAnnotation records the OWASP Core Rule Set configuration of a
+// TrafficProtectionPolicy converted to v1alpha, when v1alpha cannot represent
+// it. v1alpha always sets both paranoia levels, so the levels left unset in
+// v1alpha2 are restored from it when converting back.
+const owaspCoreRuleSetAnnotation = "networking.datumapis.com/v1alpha2-owasp-core-rule-set"
+
+// ConvertTo converts this TrafficProtectionPolicy to the Hub version (v1alpha).
+func (src *TrafficProtectionPolicy) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*networkingv1alpha.TrafficProtectionPolicy)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	dst.Spec = networkingv1alpha.TrafficProtectionPolicySpec{
+		TargetRefs:         src.Spec.TargetRefs,
+		Mode:               src.Spec.Mode,
+		SamplingPercentage: int(src.Spec.SamplingPercentage),
+		CRSVersion:         src.Spec.CRSVersion,
+	}
+	for _, ruleSet := range src.Spec.RuleSets {
+		dstRuleSet := networkingv1alpha.TrafficProtectionPolicyRuleSet{
+			Type:             ruleSet.Type,
+			Geo:              ruleSet.Geo,
+			CustomRules:      ruleSet.CustomRules,
+			OWASPCoreRuleSet: hubOWASPCRS(ruleSet.OWASPCoreRuleSet),
+		}
+		if ruleSet.Type == networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet &&
+			!equality.Semantic.DeepEqual(spokeOWASPCRS(dstRuleSet.OWASPCoreRuleSet), ruleSet.OWASPCoreRuleSet) {
+			data, err := json.Marshal(ruleSet.OWASPCoreRuleSet)
+			if err != nil {
+				return fmt.Errorf("failed encoding owaspCoreRuleSet: %w", err)
+			}
+			dst.Annotations = maps.Clone(dst.Annotations)
+			if dst.Annotations == nil {
+				dst.Annotations = map[string]string{}
+			}
+			dst.Annotations[owaspCoreRuleSetAnnotation] = string(data)
+		}
+		dst.Spec.RuleSets = append(dst.Spec.RuleSets, dstRuleSet)
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha) to this version.
+func (dst *TrafficProtectionPolicy) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*networkingv1alpha.TrafficProtectionPolicy)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	// The recorded configuration is only restored while it still converts to
+	// the configuration of the hub, which may have been changed since.
+	var recorded *OWASPCRS
+	data, hasRecorded := src.Annotations[owaspCoreRuleSetAnnotation]
+	if hasRecorded {
+		dst.Annotations = maps.Clone(dst.Annotations)
+		delete(dst.Annotations, owaspCoreRuleSetAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+		if err := json.Unmarshal([]byte(data), &recorded); err != nil {
+			hasRecorded = false
+		}
+	}
+
+	dst.Spec = TrafficProtectionPolicySpec{
+		TargetRefs:         src.Spec.TargetRefs,
+		Mode:               src.Spec.Mode,
+		SamplingPercentage: int32(src.Spec.SamplingPercentage),
+		CRSVersion:         src.Spec.CRSVersion,
+	}
+	for _, ruleSet := range src.Spec.RuleSets {
+		dstRuleSet := TrafficProtectionPolicyRuleSet{
+			Type:        ruleSet.Type,
+			Geo:         ruleSet.Geo,
+			CustomRules: ruleSet.CustomRules,
+		}
+		if ruleSet.Type == networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet {
+			dstRuleSet.OWASPCoreRuleSet = spokeOWASPCRS(ruleSet.OWASPCoreRuleSet)
+			if hasRecorded && equality.Semantic.DeepEqual(hubOWASPCRS(recorded), ruleSet.OWASPCoreRuleSet) {
+				dstRuleSet.OWASPCoreRuleSet = recorded
+			}
+		}
+		dst.Spec.RuleSets = append(dst.Spec.RuleSets, dstRuleSet)
+	}
+	return nil
+}
+
+// hubOWASPCRS converts an OWASP Core Rule Set configuration to v1alpha, which
+// always carries the configuration with both paranoia levels set.
+func hubOWASPCRS(owasp *OWASPCRS) networkingv1alpha.OWASPCRS {
+	if owasp == nil {
+		owasp = &OWASPCRS{}
+	}
+	levels := ptr.Deref(owasp.ParanoiaLevels, ParanoiaLevels{})
+	blocking := ptr.Deref(levels.Blocking, defaultParanoiaLevel)
+	return networkingv1alpha.OWASPCRS{
+		ParanoiaLevels: networkingv1alpha.ParanoiaLevels{
+			Blocking:  int(blocking),
+			Detection: int(ptr.Deref(levels.Detection, blocking)),
+		},
+		ScoreThresholds: owasp.ScoreThresholds,
+		RuleExclusions:  owasp.RuleExclusions,
+	}
+}
+
+// spokeOWASPCRS converts an OWASP Core Rule Set configuration from v1alpha.
+func spokeOWASPCRS(owasp networkingv1alpha.OWASPCRS) *OWASPCRS {
+	return &OWASPCRS{
+		ParanoiaLevels: &ParanoiaLevels{
+			Blocking:  paranoiaLevel(owasp.ParanoiaLevels.Blocking),
+			Detection: paranoiaLevel(owasp.ParanoiaLevels.Detection),
+		},
+		ScoreThresholds: owasp.ScoreThresholds,
+		RuleExclusions:  owasp.RuleExclusions,
+	}
+}
+
+// paranoiaLevel returns a pointer to level, or nil if level isn't set.
+func paranoiaLevel(level int) *int32 {
+	if level == 0 {
+		return nil
+	}
+	return ptr.To(int32(level))
+}
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// TrafficProtectionPolicySpec defines the desired state of TrafficProtectionPolicy.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.targetRefs) ? self.targetRefs.all(ref, ref.group == 'gateway.networking.k8s.io') : true ", message="this policy can only have a targetRefs[*].group of gateway.networking.k8s.io"
+// +kubebuilder:validation:XValidation:rule="has(self.targetRefs) ? self.targetRefs.all(ref, ref.kind in ['Gateway', 'HTTPRoute']) : true ", message="this policy can only have a targetRefs[*].kind of Gateway/HTTPRoute"
+type TrafficProtectionPolicySpec struct {
+	// TargetRefs are the names of the Gateway resources this policy
+	// is being attached to.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	TargetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs,omitempty"`
+
+	// Mode specifies the mode of traffic protection to apply.
+	// If not specified, defaults to "Observe".
+	//
+	// +kubebuilder:default=Observe
+	Mode networkingv1alpha.TrafficProtectionPolicyMode `json:"mode,omitempty"`
+
+	// SamplingPercentage controls the percentage of traffic that will be processed
+	// by the TrafficProtectionPolicy.
+	//
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	SamplingPercentage int32 `json:"samplingPercentage,omitempty"`
+
+	// RuleSets specifies the TrafficProtectionPolicy rulesets to apply.
+	//
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:default={{"type": "OWASPCoreRuleSet", "owaspCoreRuleSet": {}}}
+	// +kubebuilder:validation:XValidation:message="OWASPCoreRuleSet filter cannot be repeated",rule="self.filter(f, f.type == 'OWASPCoreRuleSet').size() <= 1"
+	// +kubebuilder:validation:XValidation:message="Geo filter cannot be repeated",rule="self.filter(f, f.type == 'Geo').size() <= 1"
+	// +kubebuilder:validation:XValidation:message="CustomRules filter cannot be repeated",rule="self.filter(f, f.type == 'CustomRules').size() <= 1"
+	RuleSets []TrafficProtectionPolicyRuleSet `json:"ruleSets,omitempty"`
+
+	// CRSVersion pins the release of the OWASP ModSecurity Core Rule Set (CRS)
+	// the policy is evaluated with, such as "4.7.0". If not specified, the
+	// default release of the operator is used, which may change over time.
+	//
+	// The policy is not accepted, with the UnsupportedVersion reason, when the
+	// release isn't available.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[0-9A-Za-z]([0-9A-Za-z.+-]*[0-9A-Za-z])?$`
+	CRSVersion string `json:"crsVersion,omitempty"`
+}
+
+// TrafficProtectionPolicyRuleSet is a ruleset of a TrafficProtectionPolicy.
+//
+// Unlike v1alpha, owaspCoreRuleSet is only set on rulesets of the
+// OWASPCoreRuleSet type.
+//
+// +kubebuilder:validation:XValidation:message="owaspCoreRuleSet may only be specified if type is OWASPCoreRuleSet",rule="self.type == 'OWASPCoreRuleSet' || !has(self.owaspCoreRuleSet)"
+// +kubebuilder:validation:XValidation:message="geo must be specified if and only if type is Geo",rule="self.type == 'Geo' ? has(self.geo) : !has(self.geo)"
+// +kubebuilder:validation:XValidation:message="customRules must be specified if and only if type is CustomRules",rule="self.type == 'CustomRules' ? has(self.customRules) : !has(self.customRules)"
+type TrafficProtectionPolicyRuleSet struct {
+	// Type specifies the type of TrafficProtectionPolicy ruleset.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=OWASPCoreRuleSet;Geo;CustomRules
+	Type networkingv1alpha.TrafficProtectionPolicyRuleSetType `json:"type"`
+
+	// OWASPCoreRuleSet defines configuration options for the OWASP ModSecurity
+	// Core Rule Set (CRS).
+	//
+	// +kubebuilder:validation:Optional
+	OWASPCoreRuleSet *OWASPCRS `json:"owaspCoreRuleSet,omitempty"`
+
+	// Geo defines the countries requests are allowed or denied from.
+	//
+	// +kubebuilder:validation:Optional
+	Geo *networkingv1alpha.GeoRuleSet `json:"geo,omitempty"`
+
+	// CustomRules defines Coraza rules to evaluate in addition to the OWASP
+	// ModSecurity Core Rule Set (CRS).
+	//
+	// +kubebuilder:validation:Optional
+	CustomRules *networkingv1alpha.CustomRuleSet `json:"customRules,omitempty"`
+}
+
+// OWASPCRS defines configuration options for the OWASP ModSecurity Core Rule Set (CRS).
+type OWASPCRS struct {
+	// ParanoiaLevels specifies the OWASP ModSecurity Core Rule Set (CRS)
+	// paranoia levels to use. Both levels default to 1.
+	//
+	// +kubebuilder:validation:Optional
+	ParanoiaLevels *ParanoiaLevels `json:"paranoiaLevels,omitempty"`
+
+	// ScoreThresholds specifies the OWASP ModSecurity Core Rule Set (CRS)
+	// score thresholds to block a request or response.
+	//
+	// See: https://coreruleset.org/docs/2-how-crs-works/2-1-anomaly_scoring/
+	//
+	// +kubebuilder:default={}
+	ScoreThresholds networkingv1alpha.OWASPScoreThresholds `json:"scoreThresholds,omitempty"`
+
+	// RuleExclusions can be used to disable specific OWASP ModSecurity Rules.
+	// This allows operators to disable specific rules that may be causing false
+	// positives.
+	//
+	// +kubebuilder:validation:Optional
+	RuleExclusions *networkingv1alpha.OWASPRuleExclusions `json:"ruleExclusions,omitempty"`
+}
+
+// ParanoiaLevels specifies the OWASP ModSecurity Core Rule Set (CRS) paranoia
+// levels. A level that isn't specified defaults to 1, and the detection level
+// defaults to the blocking level when only the blocking level is specified.
+//
+// +kubebuilder:validation:XValidation:message="detection paranoia level must be greater than or equal to blocking paranoia level",rule="!has(self.blocking) || !has(self.detection) || self.detection >= self.blocking"
+type ParanoiaLevels struct {
+	// Blocking specifies the paranoia level for blocking requests or responses.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4
+	Blocking *int32 `json:"blocking,omitempty"`
+
+	// Detection specifies the paranoia level for detection only. This allows
+	// setting a higher paranoia level for detection while keeping blocking at a
+	// lower level.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4
+	Detection *int32 `json:"detection,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=tpp
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+// +kubebuilder:printcolumn:name="Targets",type="integer",JSONPath=".status.targetCount"
+// +kubebuilder:printcolumn:name="Accepted",type="integer",JSONPath=".status.acceptedCount"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TrafficProtectionPolicy is the Schema for the trafficprotectionpolicies API.
+type TrafficProtectionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   TrafficProtectionPolicySpec                     `json:"spec,omitempty"`
+	Status networkingv1alpha.TrafficProtectionPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TrafficProtectionPolicyList contains a list of TrafficProtectionPolicy.
+type TrafficProtectionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TrafficProtectionPolicy `json:"items"`
+}
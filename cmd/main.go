@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	managercmd "go.datum.net/network-services-operator/internal/cmd/manager"
+	preflightcmd "go.datum.net/network-services-operator/internal/cmd/preflight"
 	extservercmd "go.datum.net/network-services-operator/internal/extensionserver/cmd"
 )
 
@@ -34,6 +35,7 @@ func main() {
 		BuildDate:    buildDate,
 	}))
 	root.AddCommand(extservercmd.NewCommand())
+	root.AddCommand(preflightcmd.NewCommand())
 	if err := root.Execute(); err != nil {
 		os.Exit(1)
 	}
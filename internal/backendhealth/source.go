@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package backendhealth reports the health of the endpoints of downstream
+// HTTPRoute rules, as recorded from the Envoy cluster membership metrics of
+// the gateway data planes. The health of each endpoint is only reported by
+// data planes with per endpoint stats enabled, see enablePerEndpointStats of
+// the EnvoyProxy metrics.
+package backendhealth
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// Envoy cluster membership gauges, labeled with the name of the Envoy cluster.
+const (
+	metricHealthy    = "envoy_cluster_membership_healthy"
+	metricTotal      = "envoy_cluster_membership_total"
+	labelClusterName = "envoy_cluster_name"
+)
+
+// Envoy per endpoint health gauge, labeled with the name of the Envoy cluster
+// and the address of the endpoint, and with its hostname when the endpoint
+// was resolved from one.
+const (
+	metricEndpointHealthy = "envoy_cluster_endpoint_healthy"
+	labelEndpointAddress  = "envoy_endpoint_address"
+	labelEndpointHostname = "envoy_endpoint_hostname"
+)
+
+// routeClusterRegex matches the Envoy clusters Envoy Gateway programs for the
+// rules of an HTTPRoute.
+const routeClusterRegex = `httproute/%s/%s/rule/[0-9]+`
+
+// RouteRef identifies a downstream HTTPRoute.
+type RouteRef struct {
+	Namespace string
+	Name      string
+}
+
+// RuleHealth is the number of healthy and total endpoints of a rule, summed
+// across the data planes the route is programmed on.
+type RuleHealth struct {
+	Healthy int64
+	Total   int64
+
+	// Endpoints is the health of each endpoint of the rule, ordered by
+	// address. It is empty when the data planes do not report per endpoint
+	// stats.
+	Endpoints []EndpointHealth
+}
+
+// EndpointHealth is the health of an endpoint of a rule.
+type EndpointHealth struct {
+	// Address is the address of the endpoint, as "<ip>:<port>".
+	Address string
+	// Hostname is the hostname the endpoint was resolved from, if any.
+	Hostname string
+	// Healthy is whether the endpoint passes its health checks on every data
+	// plane that reports it.
+	Healthy bool
+}
+
+// Source reports the health of the endpoints of routes.
+type Source interface {
+	// RouteHealth returns the health of the endpoints of each rule of the
+	// route, by rule index. Rules that no data plane reports are omitted.
+	RouteHealth(ctx context.Context, route RouteRef) (map[int]RuleHealth, error)
+}
+
+type prometheusSource struct {
+	api promv1.API
+}
+
+// NewPrometheusSource returns a Source that queries the Envoy cluster
+// membership metrics from the Prometheus compatible API at address.
+func NewPrometheusSource(address string) (Source, error) {
+	client, err := promapi.NewClient(promapi.Config{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus client: %w", err)
+	}
+	return &prometheusSource{api: promv1.NewAPI(client)}, nil
+}
+
+func (s *prometheusSource) RouteHealth(ctx context.Context, route RouteRef) (map[int]RuleHealth, error) {
+	now := time.Now()
+	clusterRegex := fmt.Sprintf(routeClusterRegex, regexp.QuoteMeta(route.Namespace), regexp.QuoteMeta(route.Name))
+
+	health := map[int]RuleHealth{}
+	for _, metric := range []string{metricTotal, metricHealthy} {
+		query := fmt.Sprintf("sum by (%s) (%s{%s=~%s})", labelClusterName, metric, labelClusterName, strconv.Quote(clusterRegex))
+		samples, err := s.queryVector(ctx, query, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range samples {
+			ruleIndex, ok := ruleIndexOf(string(sample.Metric[labelClusterName]))
+			if !ok {
+				continue
+			}
+			ruleHealth := health[ruleIndex]
+			if metric == metricTotal {
+				ruleHealth.Total = int64(sample.Value)
+			} else {
+				ruleHealth.Healthy = int64(sample.Value)
+			}
+			health[ruleIndex] = ruleHealth
+		}
+	}
+
+	query := fmt.Sprintf("min by (%s, %s, %s) (%s{%s=~%s})",
+		labelClusterName, labelEndpointAddress, labelEndpointHostname,
+		metricEndpointHealthy, labelClusterName, strconv.Quote(clusterRegex))
+	samples, err := s.queryVector(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, sample := range samples {
+		ruleIndex, ok := ruleIndexOf(string(sample.Metric[labelClusterName]))
+		if !ok {
+			continue
+		}
+		ruleHealth := health[ruleIndex]
+		ruleHealth.Endpoints = append(ruleHealth.Endpoints, EndpointHealth{
+			Address:  string(sample.Metric[labelEndpointAddress]),
+			Hostname: string(sample.Metric[labelEndpointHostname]),
+			Healthy:  sample.Value == 1,
+		})
+		health[ruleIndex] = ruleHealth
+	}
+	for ruleIndex, ruleHealth := range health {
+		slices.SortFunc(ruleHealth.Endpoints, func(a, b EndpointHealth) int {
+			return strings.Compare(a.Address, b.Address)
+		})
+		health[ruleIndex] = ruleHealth
+	}
+
+	return health, nil
+}
+
+func (s *prometheusSource) queryVector(ctx context.Context, query string, ts time.Time) (model.Vector, error) {
+	value, _, err := s.api.Query(ctx, query, ts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query backend health: %w", err)
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected backend health query result type %s", value.Type())
+	}
+	return vector, nil
+}
+
+// ruleIndexOf returns the rule index of an Envoy Gateway route rule cluster,
+// named httproute/<namespace>/<name>/rule/<index>.
+func ruleIndexOf(clusterName string) (int, bool) {
+	i := strings.LastIndex(clusterName, "/rule/")
+	if i < 0 {
+		return 0, false
+	}
+	ruleIndex, err := strconv.Atoi(clusterName[i+len("/rule/"):])
+	if err != nil {
+		return 0, false
+	}
+	return ruleIndex, true
+}
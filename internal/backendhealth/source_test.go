@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package backendhealth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusSourceRouteHealth(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		query := r.Form.Get("query")
+		queries = append(queries, query)
+
+		var result []map[string]any
+		switch {
+		case strings.Contains(query, "envoy_cluster_membership_total{"):
+			result = []map[string]any{
+				{"metric": map[string]string{"envoy_cluster_name": "httproute/ns-1234/proxy/rule/0"}, "value": []any{1700000000, "4"}},
+				{"metric": map[string]string{"envoy_cluster_name": "httproute/ns-1234/proxy/rule/2"}, "value": []any{1700000000, "2"}},
+			}
+		case strings.Contains(query, "envoy_cluster_membership_healthy{"):
+			result = []map[string]any{
+				{"metric": map[string]string{"envoy_cluster_name": "httproute/ns-1234/proxy/rule/0"}, "value": []any{1700000000, "3"}},
+				{"metric": map[string]string{"envoy_cluster_name": "httproute/ns-1234/proxy/rule/2"}, "value": []any{1700000000, "0"}},
+			}
+		case strings.Contains(query, "envoy_cluster_endpoint_healthy{"):
+			result = []map[string]any{
+				{"metric": map[string]string{
+					"envoy_cluster_name":      "httproute/ns-1234/proxy/rule/2",
+					"envoy_endpoint_address":  "192.0.2.2:443",
+					"envoy_endpoint_hostname": "backup.example.com",
+				}, "value": []any{1700000000, "1"}},
+				{"metric": map[string]string{
+					"envoy_cluster_name":      "httproute/ns-1234/proxy/rule/2",
+					"envoy_endpoint_address":  "192.0.2.1:443",
+					"envoy_endpoint_hostname": "primary.example.com",
+				}, "value": []any{1700000000, "0"}},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"status": "success",
+			"data":   map[string]any{"resultType": "vector", "result": result},
+		}))
+	}))
+	defer server.Close()
+
+	source, err := NewPrometheusSource(server.URL)
+	require.NoError(t, err)
+
+	health, err := source.RouteHealth(context.Background(), RouteRef{Namespace: "ns-1234", Name: "proxy"})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]RuleHealth{
+		0: {Healthy: 3, Total: 4},
+		2: {Healthy: 0, Total: 2, Endpoints: []EndpointHealth{
+			{Address: "192.0.2.1:443", Hostname: "primary.example.com", Healthy: false},
+			{Address: "192.0.2.2:443", Hostname: "backup.example.com", Healthy: true},
+		}},
+	}, health)
+
+	require.Len(t, queries, 3)
+	for _, query := range queries {
+		assert.Contains(t, query, `{envoy_cluster_name=~"httproute/ns-1234/proxy/rule/[0-9]+"}`)
+	}
+}
+
+func TestRuleIndexOf(t *testing.T) {
+	ruleIndex, ok := ruleIndexOf("httproute/ns-1234/proxy/rule/12")
+	assert.True(t, ok)
+	assert.Equal(t, 12, ruleIndex)
+
+	_, ok = ruleIndexOf("httproute/ns-1234/proxy")
+	assert.False(t, ok)
+}
@@ -4,6 +4,8 @@ package managercmd
 
 import (
 	"context"
+	"crypto/fips140"
+	"crypto/tls"
 	"errors"
 	"flag"
 	"fmt"
@@ -28,6 +30,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -43,8 +46,18 @@ import (
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
+	networkingv1alpha2 "go.datum.net/network-services-operator/api/v1alpha2"
+	"go.datum.net/network-services-operator/internal/backendhealth"
 	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/configreload"
 	"go.datum.net/network-services-operator/internal/controller"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/explain"
+	"go.datum.net/network-services-operator/internal/features"
+	"go.datum.net/network-services-operator/internal/multicluster/kubeconfigdir"
+	"go.datum.net/network-services-operator/internal/multicluster/projectfilter"
+	"go.datum.net/network-services-operator/internal/multicluster/staticshard"
+	"go.datum.net/network-services-operator/internal/wafevents"
 	networkingwebhook "go.datum.net/network-services-operator/internal/webhook"
 	networkinggatewayv1webhooks "go.datum.net/network-services-operator/internal/webhook/v1"
 	networkingv1alphawebhooks "go.datum.net/network-services-operator/internal/webhook/v1alpha"
@@ -65,6 +78,7 @@ func init() {
 	utilruntime.Must(config.AddToScheme(scheme))
 	utilruntime.Must(config.RegisterDefaults(scheme))
 	utilruntime.Must(networkingv1alpha.AddToScheme(scheme))
+	utilruntime.Must(networkingv1alpha2.AddToScheme(scheme))
 	utilruntime.Must(gatewayv1.Install(scheme))
 	utilruntime.Must(gatewayv1alpha2.Install(scheme))
 	utilruntime.Must(gatewayv1alpha3.Install(scheme))
@@ -96,8 +110,11 @@ func NewCommand(build BuildInfo) *cobra.Command {
 	var clusterShardingPeerWeight uint
 	var singletonControllersLeaderElection bool
 	var singletonControllersLeaderElectionID string
+	var enableExplainEndpoint bool
+	var enableInventoryEndpoint bool
 
 	var serverConfigFile string
+	var validateOnly bool
 
 	fs := flag.NewFlagSet("manager", flag.ContinueOnError)
 
@@ -143,11 +160,27 @@ func NewCommand(build BuildInfo) *cobra.Command {
 		"Leader election ID for singleton downstream controllers.",
 	)
 
+	fs.BoolVar(
+		&enableExplainEndpoint,
+		"enable-explain-endpoint",
+		false,
+		"Serve condition explanations for Gateways, HTTPProxies and Domains on the metrics server at "+explain.EndpointPath+".",
+	)
+
+	fs.BoolVar(
+		&enableInventoryEndpoint,
+		"enable-inventory-endpoint",
+		false,
+		"Serve the counts of managed downstream resources on the metrics server at "+controller.InventoryEndpointPath+".",
+	)
+
 	opts := zap.Options{
 		Development: true,
 	}
 
 	fs.StringVar(&serverConfigFile, "server-config", "", "path to the server config file")
+	fs.BoolVar(&validateOnly, "validate-only", false,
+		"Validate the server config file, print a report of every violation and exit without starting the manager.")
 
 	opts.BindFlags(fs)
 
@@ -156,7 +189,12 @@ func NewCommand(build BuildInfo) *cobra.Command {
 		Short: "Run the network-services-operator controller manager",
 		Args:  cobra.NoArgs,
 		// nolint:gocyclo
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if validateOnly {
+				cmd.SilenceUsage = true
+				return validateServerConfig(cmd.OutOrStdout(), serverConfigFile)
+			}
+
 			ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
 			setupLog.Info("starting network-services-operator",
@@ -166,7 +204,6 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				"buildDate", build.BuildDate,
 			)
 
-			var serverConfig config.NetworkServicesOperator
 			var configData []byte
 			if len(serverConfigFile) > 0 {
 				var err error
@@ -177,14 +214,12 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				}
 			}
 
-			if err := runtime.DecodeInto(codecs.UniversalDecoder(), configData, &serverConfig); err != nil {
+			serverConfig, err := decodeServerConfig(configData)
+			if err != nil {
 				setupLog.Error(err, "unable to decode server config")
 				os.Exit(1)
 			}
-
-			// Allow overriding Redis URL at runtime via env var.
-			if redisURL := strings.TrimSpace(os.Getenv("REDIS_URL")); redisURL != "" {
-				serverConfig.Redis.URL = redisURL
+			if strings.TrimSpace(os.Getenv("REDIS_URL")) != "" {
 				setupLog.Info("overriding redis.url from REDIS_URL")
 			}
 
@@ -194,6 +229,21 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				setupLog.Error(err, "invalid server config")
 				os.Exit(1)
 			}
+			features.RecordMetrics(serverConfig.FeatureGates)
+
+			// The reloadable fields of the server config file are applied
+			// without a restart when the file changes.
+			var reloadableConfig *config.Reloadable
+			if len(serverConfigFile) > 0 {
+				reloadableConfig = config.NewReloadable(serverConfig)
+			}
+
+			if serverConfig.CryptoPolicy.RequireFIPSModule && !fips140.Enabled() {
+				setupLog.Error(errors.New("FIPS 140-3 module is not enabled"),
+					"crypto policy requires the FIPS module; build with GOFIPS140 or run with GODEBUG=fips140=on")
+				os.Exit(1)
+			}
+			logCryptoPolicy(serverConfig.CryptoPolicy)
 
 			cfg := ctrl.GetConfigOrDie()
 			serverConfig.ControlPlaneClient.ApplyTo(cfg)
@@ -219,10 +269,13 @@ func NewCommand(build BuildInfo) *cobra.Command {
 			deploymentClusterClient := deploymentCluster.GetClient()
 
 			metricsServerOptions := serverConfig.MetricsServer.Options(ctx, deploymentClusterClient)
+			metricsServerOptions.TLSOpts = append(metricsServerOptions.TLSOpts,
+				serverConfig.CryptoPolicy.TLSOption(config.CryptoPolicyMetricsServer))
 
-			webhookServer := webhook.NewServer(
-				serverConfig.WebhookServer.Options(ctx, deploymentClusterClient),
-			)
+			webhookServerOptions := serverConfig.WebhookServer.Options(ctx, deploymentClusterClient)
+			webhookServerOptions.TLSOpts = append(webhookServerOptions.TLSOpts,
+				serverConfig.CryptoPolicy.TLSOption(config.CryptoPolicyWebhookServer))
+			webhookServer := webhook.NewServer(webhookServerOptions)
 
 			webhookServer = networkingwebhook.NewClusterAwareWebhookServer(webhookServer, serverConfig.Discovery.Mode)
 
@@ -265,6 +318,35 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				)
 			}
 
+			leaderElectionID := "6a7d51cc.datumapis.com"
+			staticSharding := serverConfig.Discovery.Sharding != nil
+			if staticSharding {
+				if enableClusterSharding {
+					setupLog.Error(errors.New("--cluster-sharding-enabled and discovery.sharding are mutually exclusive"),
+						"unable to set up cluster sharding")
+					os.Exit(1)
+				}
+				shardIndex, err := serverConfig.Discovery.Sharding.ShardIndex()
+				if err != nil {
+					setupLog.Error(err, "unable to determine cluster shard")
+					os.Exit(1)
+				}
+				setupLog.Info("enabling static cluster sharding",
+					"shard", shardIndex, "shards", serverConfig.Discovery.Sharding.Shards)
+
+				coordinator := staticshard.New(shardIndex, serverConfig.Discovery.Sharding.Shards)
+				if reloadableConfig != nil {
+					reloadableConfig.Subscribe(func(cfg *config.NetworkServicesOperator) {
+						coordinator.SetShards(cfg.Discovery.Sharding.Shards)
+					})
+				}
+				mcManagerOptions = append(mcManagerOptions, mcmanager.WithCoordinator(coordinator))
+
+				// Every shard elects its own leader, so that replicas of the same
+				// shard don't reconcile the same clusters.
+				leaderElectionID = fmt.Sprintf("%s-shard-%d", leaderElectionID, shardIndex)
+			}
+
 			primaryManagerLeaderElection := enableLeaderElection
 			if enableClusterSharding && enableLeaderElection {
 				setupLog.Info(
@@ -281,7 +363,7 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				WebhookServer:           webhookServer,
 				HealthProbeBindAddress:  probeAddr,
 				LeaderElection:          primaryManagerLeaderElection,
-				LeaderElectionID:        "6a7d51cc.datumapis.com",
+				LeaderElectionID:        leaderElectionID,
 				LeaderElectionNamespace: leaderElectionNamespace,
 				LeaseDuration:           &leaseDuration,
 				RenewDeadline:           &renewDeadline,
@@ -310,6 +392,26 @@ func NewCommand(build BuildInfo) *cobra.Command {
 			}
 			serverConfig.DownstreamClient.ApplyTo(downstreamRestConfig)
 
+			// The downstream audit hands the resources with a modified spec to the
+			// Gateway controller, which writes their desired spec again.
+			var downstreamSpecDrift chan event.TypedGenericEvent[client.Object]
+			if auditConfig := serverConfig.DownstreamResourceManagement.Audit; !auditConfig.Disabled && auditConfig.Repair {
+				downstreamSpecDrift = controller.NewDownstreamSpecDriftQueue()
+			}
+
+			var upstreamOutages *controller.UpstreamOutageTracker
+			if !serverConfig.UpstreamOutage.Disabled {
+				upstreamOutages = controller.NewUpstreamOutageTracker(serverConfig.UpstreamOutage)
+			}
+
+			// Each downstream cluster has its own circuit breaker, so a failing
+			// cluster does not pause writes to the others.
+			var downstreamCircuitBreakers *downstreamclient.CircuitBreakers
+			if !serverConfig.DownstreamCircuitBreaker.Disabled {
+				downstreamCircuitBreakers = downstreamclient.NewCircuitBreakers(serverConfig.DownstreamCircuitBreaker)
+			}
+			downstreamCircuitBreaker := downstreamCircuitBreakers.Wrap("downstream", downstreamRestConfig)
+
 			downstreamCluster, err := cluster.New(downstreamRestConfig, func(o *cluster.Options) {
 				o.Scheme = scheme
 				o.Client = client.Options{
@@ -325,7 +427,7 @@ func NewCommand(build BuildInfo) *cobra.Command {
 
 			var singletonMgr manager.Manager
 			singletonControllerMgr := mgr.GetLocalManager()
-			if enableClusterSharding {
+			if enableClusterSharding || staticSharding {
 				singletonMgr, err = manager.New(cfg, manager.Options{
 					Scheme:                  scheme,
 					Metrics:                 metricsserver.Options{BindAddress: "0"},
@@ -345,49 +447,84 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				singletonControllerMgr = singletonMgr
 			}
 
-			if err := (&controller.NetworkReconciler{}).SetupWithManager(mgr); err != nil {
+			disengagementHandler := &controller.ClusterDisengagementHandler{
+				Config:            serverConfig,
+				DownstreamCluster: downstreamCluster,
+			}
+			if err := disengagementHandler.SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create runnable", "runnable", "ClusterDisengagementHandler")
+				os.Exit(1)
+			}
+			// Controllers drop the requests of clusters disengaged from this
+			// replica rather than retrying them.
+			controllerMgr := disengagementHandler.ControllerManager()
+
+			if err := (&controller.NetworkReconciler{}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "Network")
 				os.Exit(1)
 			}
-			if err := (&controller.NetworkBindingReconciler{}).SetupWithManager(mgr); err != nil {
+			if err := (&controller.NetworkBindingReconciler{}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "NetworkBinding")
 				os.Exit(1)
 			}
-			if err := (&controller.NetworkContextReconciler{}).SetupWithManager(mgr); err != nil {
+			if err := (&controller.NetworkContextReconciler{}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "NetworkContext")
 				os.Exit(1)
 			}
-			if err := (&controller.NetworkPolicyReconciler{}).SetupWithManager(mgr); err != nil {
+			if err := (&controller.NetworkPolicyReconciler{
+				Config:            serverConfig,
+				DownstreamCluster: downstreamCluster,
+				UpstreamOutages:   upstreamOutages,
+			}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "NetworkPolicy")
 				os.Exit(1)
 			}
-			if err := (&controller.SubnetReconciler{}).SetupWithManager(mgr); err != nil {
+			if err := (&controller.SubnetReconciler{Config: serverConfig}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "Subnet")
 				os.Exit(1)
 			}
-			if err := (&controller.SubnetClaimReconciler{}).SetupWithManager(mgr); err != nil {
+			if err := (&controller.SubnetClaimReconciler{}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "SubnetClaim")
 				os.Exit(1)
 			}
+			if err := (&controller.IPAddressClaimReconciler{Config: serverConfig}).SetupWithManager(controllerMgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "IPAddressClaim")
+				os.Exit(1)
+			}
+
+			var backendHealthSource backendhealth.Source
+			if healthConfig := serverConfig.HTTPProxy.BackendHealth; healthConfig.Enabled() {
+				backendHealthSource, err = backendhealth.NewPrometheusSource(healthConfig.PrometheusAddress)
+				if err != nil {
+					setupLog.Error(err, "unable to create backend health source")
+					os.Exit(1)
+				}
+			}
 
 			if err := (&controller.HTTPProxyReconciler{
 				Config:            serverConfig,
 				DownstreamCluster: downstreamCluster,
-			}).SetupWithManager(mgr); err != nil {
+				BackendHealth:     backendHealthSource,
+				UpstreamOutages:   upstreamOutages,
+			}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "HTTPProxy")
 				os.Exit(1)
 			}
 
 			if err := (&controller.GatewayReconciler{
-				Config:            serverConfig,
-				DownstreamCluster: downstreamCluster,
-			}).SetupWithManager(mgr); err != nil {
+				Config:                   serverConfig,
+				ReloadableConfig:         reloadableConfig,
+				DownstreamCluster:        downstreamCluster,
+				DownstreamCircuitBreaker: downstreamCircuitBreaker,
+				UpstreamOutages:          upstreamOutages,
+				DownstreamSpecDrift:      downstreamSpecDrift,
+			}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "Gateway")
 				os.Exit(1)
 			}
 			if err := (&controller.GatewayClassReconciler{
 				Config: serverConfig,
-			}).SetupWithManager(mgr); err != nil {
+			}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "GatewayClass")
 				os.Exit(1)
 			}
@@ -395,33 +532,145 @@ func NewCommand(build BuildInfo) *cobra.Command {
 			if err := (&controller.GatewayDownstreamGCReconciler{
 				Config:            serverConfig,
 				DownstreamCluster: downstreamCluster,
-			}).SetupWithManager(mgr); err != nil {
+			}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "GatewayDownstreamGC")
 				os.Exit(1)
 			}
 
+			if serverConfig.FeatureEnabled(features.EndpointSliceGC) {
+				if err := (&controller.EndpointSliceGCReconciler{
+					Config:            serverConfig,
+					DownstreamCluster: downstreamCluster,
+				}).SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "EndpointSliceGC")
+					os.Exit(1)
+				}
+			}
+
+			if !serverConfig.DownstreamResourceManagement.Audit.Disabled {
+				if err := (&controller.DownstreamAuditor{
+					Config:            serverConfig,
+					DownstreamCluster: downstreamCluster,
+					SpecDrift:         downstreamSpecDrift,
+				}).SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create runnable", "runnable", "DownstreamAuditor")
+					os.Exit(1)
+				}
+			}
+
+			var downstreamInventory *controller.DownstreamInventory
+			if !serverConfig.DownstreamResourceManagement.Inventory.Disabled {
+				downstreamInventory = &controller.DownstreamInventory{
+					Config:            serverConfig,
+					DownstreamCluster: downstreamCluster,
+				}
+				if err := downstreamInventory.SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create runnable", "runnable", "DownstreamInventory")
+					os.Exit(1)
+				}
+			}
+
 			if err := (&controller.GatewayResourceReplicatorReconciler{
 				Config:            serverConfig,
 				DownstreamCluster: downstreamCluster,
-			}).SetupWithManager(mgr); err != nil {
+			}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "GatewayResourceReplicator")
 				os.Exit(1)
 			}
 
 			if !serverConfig.Gateway.Coraza.Disabled {
+				var wafEventSource wafevents.Source
+				if eventsConfig := serverConfig.Gateway.Coraza.Events; eventsConfig.Enabled() {
+					wafEventSource, err = wafevents.NewPrometheusSource(eventsConfig.PrometheusAddress, eventsConfig.MetricName)
+					if err != nil {
+						setupLog.Error(err, "unable to create WAF event source")
+						os.Exit(1)
+					}
+				}
+
 				if err = (&controller.TrafficProtectionPolicyReconciler{
 					Config:            serverConfig,
+					ReloadableConfig:  reloadableConfig,
 					DownstreamCluster: downstreamCluster,
-				}).SetupWithManager(mgr); err != nil {
+					WAFEvents:         wafEventSource,
+					UpstreamOutages:   upstreamOutages,
+				}).SetupWithManager(controllerMgr); err != nil {
 					setupLog.Error(err, "unable to create controller", "controller", "WAFSecurityPolicy")
 					os.Exit(1)
 				}
 			}
 
+			if serverConfig.FeatureEnabled(features.AccessControlPolicy) {
+				if err := (&controller.AccessControlPolicyReconciler{
+					Config:            serverConfig,
+					DownstreamCluster: downstreamCluster,
+					UpstreamOutages:   upstreamOutages,
+				}).SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "AccessControlPolicy")
+					os.Exit(1)
+				}
+			}
+
+			if serverConfig.FeatureEnabled(features.AuthenticationPolicy) {
+				if err := (&controller.AuthenticationPolicyReconciler{
+					Config:            serverConfig,
+					DownstreamCluster: downstreamCluster,
+					UpstreamOutages:   upstreamOutages,
+				}).SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "AuthenticationPolicy")
+					os.Exit(1)
+				}
+			}
+
+			if serverConfig.FeatureEnabled(features.PayloadPolicy) {
+				if err := (&controller.PayloadPolicyReconciler{
+					Config:            serverConfig,
+					DownstreamCluster: downstreamCluster,
+					UpstreamOutages:   upstreamOutages,
+				}).SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "PayloadPolicy")
+					os.Exit(1)
+				}
+			}
+
+			if serverConfig.FeatureEnabled(features.RateLimitPolicy) {
+				if err := (&controller.RateLimitPolicyReconciler{
+					Config:            serverConfig,
+					DownstreamCluster: downstreamCluster,
+					UpstreamOutages:   upstreamOutages,
+				}).SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "RateLimitPolicy")
+					os.Exit(1)
+				}
+			}
+
+			if serverConfig.FeatureEnabled(features.BackendLoadBalancingPolicy) {
+				if err := (&controller.BackendLoadBalancingPolicyReconciler{
+					Config:            serverConfig,
+					DownstreamCluster: downstreamCluster,
+					UpstreamOutages:   upstreamOutages,
+				}).SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "BackendLoadBalancingPolicy")
+					os.Exit(1)
+				}
+			}
+
+			if serverConfig.Gateway.AccessLogging.Enabled() {
+				if err := (&controller.AccessLogPolicyReconciler{
+					Config:            serverConfig,
+					DownstreamCluster: downstreamCluster,
+					UpstreamOutages:   upstreamOutages,
+				}).SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "AccessLogPolicy")
+					os.Exit(1)
+				}
+			}
+
 			if serverConfig.Gateway.EnableDownstreamCertificateSolver {
 				setupLog.Info("enabling GatewayDownstreamCertificateSolver controller")
 				if err := (&controller.GatewayDownstreamCertificateSolverReconciler{
 					Config:            serverConfig,
+					ReloadableConfig:  reloadableConfig,
 					DownstreamCluster: downstreamCluster,
 				}).SetupWithManager(singletonControllerMgr); err != nil {
 					setupLog.Error(err, "unable to create controller", "controller", "GatewayDownstreamCertificateSolver")
@@ -430,19 +679,44 @@ func NewCommand(build BuildInfo) *cobra.Command {
 			}
 
 			if err := (&controller.DomainReconciler{
-				Config: serverConfig,
-			}).SetupWithManager(mgr); err != nil {
+				Config:           serverConfig,
+				ReloadableConfig: reloadableConfig,
+			}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "Domain")
 				os.Exit(1)
 			}
 
+			if err := (&controller.DomainClaimReconciler{}).SetupWithManager(controllerMgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "DomainClaim")
+				os.Exit(1)
+			}
+
+			if serverConfig.FeatureEnabled(features.DomainConsumers) {
+				if err := (&controller.DomainConsumersReconciler{
+					Config: serverConfig,
+				}).SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "DomainConsumers")
+					os.Exit(1)
+				}
+			}
+
+			if !serverConfig.Gateway.DomainGC.Disabled {
+				if err := (&controller.GatewayDomainGCReconciler{
+					Config: serverConfig,
+				}).SetupWithManager(controllerMgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "GatewayDomainGC")
+					os.Exit(1)
+				}
+			}
+
 			if err := (&controller.ConnectorReconciler{
-				Config: serverConfig,
-			}).SetupWithManager(mgr); err != nil {
+				Config:            serverConfig,
+				DownstreamCluster: downstreamCluster,
+			}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "Connector")
 				os.Exit(1)
 			}
-			if err := (&controller.ConnectorAdvertisementReconciler{}).SetupWithManager(mgr); err != nil {
+			if err := (&controller.ConnectorAdvertisementReconciler{}).SetupWithManager(controllerMgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "ConnectorAdvertisement")
 				os.Exit(1)
 			}
@@ -454,6 +728,7 @@ func NewCommand(build BuildInfo) *cobra.Command {
 					setupLog.Error(err, "unable to load iroh dns downstream kubeconfig")
 					os.Exit(1)
 				}
+				downstreamCircuitBreakers.Wrap("iroh-dns", irohRestCfg)
 				irohDownstream, err = cluster.New(irohRestCfg, func(o *cluster.Options) {
 					o.Scheme = scheme
 				})
@@ -464,7 +739,7 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				if err := (&controller.IrohDNSReconciler{
 					Config:     serverConfig,
 					Downstream: irohDownstream,
-				}).SetupWithManager(mgr); err != nil {
+				}).SetupWithManager(controllerMgr); err != nil {
 					setupLog.Error(err, "unable to create controller", "controller", "IrohDNS")
 					os.Exit(1)
 				}
@@ -473,6 +748,7 @@ func NewCommand(build BuildInfo) *cobra.Command {
 			if serverConfig.Gateway.ShouldDeleteErroredChallenges() {
 				if err := (&controller.ChallengeReconciler{
 					Config:            serverConfig,
+					ReloadableConfig:  reloadableConfig,
 					DownstreamCluster: downstreamCluster,
 				}).SetupWithManager(singletonControllerMgr); err != nil {
 					setupLog.Error(err, "unable to create controller", "controller", "Challenge")
@@ -480,6 +756,16 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				}
 			}
 
+			if !serverConfig.DownstreamResourceManagement.NamespaceGC.Disabled {
+				if err := (&controller.DownstreamNamespaceGCReconciler{
+					Config:            serverConfig,
+					DownstreamCluster: downstreamCluster,
+				}).SetupWithManager(singletonControllerMgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "DownstreamNamespaceGC")
+					os.Exit(1)
+				}
+			}
+
 			if err := controller.AddIndexers(ctx, mgr); err != nil {
 				setupLog.Error(err, "unable to add indexers")
 				os.Exit(1)
@@ -507,7 +793,7 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				os.Exit(1)
 			}
 
-			if err := networkingv1alphawebhooks.SetupHTTPProxyWebhookWithManager(mgr); err != nil {
+			if err := networkingv1alphawebhooks.SetupHTTPProxyWebhookWithManager(mgr, serverConfig); err != nil {
 				setupLog.Error(err, "unable to create webhook", "webhook", "HTTPProxy")
 				os.Exit(1)
 			}
@@ -517,6 +803,25 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				os.Exit(1)
 			}
 
+			if serverConfig.FeatureEnabled(features.PayloadPolicy) {
+				if err := networkingv1alphawebhooks.SetupPayloadPolicyWebhookWithManager(mgr, serverConfig); err != nil {
+					setupLog.Error(err, "unable to create webhook", "webhook", "PayloadPolicy")
+					os.Exit(1)
+				}
+			}
+
+			if serverConfig.FeatureEnabled(features.HostnameBlocklist) {
+				if err := networkingv1alphawebhooks.SetupHostnameBlocklistWebhookWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create webhook", "webhook", "HostnameBlocklist")
+					os.Exit(1)
+				}
+			}
+
+			if err := networkingv1alphawebhooks.SetupTrafficProtectionPolicyWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", "TrafficProtectionPolicy")
+				os.Exit(1)
+			}
+
 			if err = webhookgatewayv1alpha1.SetupBackendTrafficPolicyWebhookWithManager(mgr, serverConfig); err != nil {
 				setupLog.Error(err, "unable to create webhook", "webhook", "BackendTrafficPolicy")
 				os.Exit(1)
@@ -537,6 +842,36 @@ func NewCommand(build BuildInfo) *cobra.Command {
 				os.Exit(1)
 			}
 
+			if enableExplainEndpoint {
+				explainHandler := explain.NewHandler(mgr, downstreamCluster, serverConfig.Gateway.EnableDNSIntegration)
+				if err := mgr.AddMetricsServerExtraHandler(explain.EndpointPath, explainHandler); err != nil {
+					setupLog.Error(err, "unable to add explain endpoint")
+					os.Exit(1)
+				}
+			}
+
+			if enableInventoryEndpoint {
+				if downstreamInventory == nil {
+					setupLog.Error(nil, "the inventory endpoint requires the downstream inventory, which is disabled")
+					os.Exit(1)
+				}
+				if err := mgr.AddMetricsServerExtraHandler(controller.InventoryEndpointPath, downstreamInventory); err != nil {
+					setupLog.Error(err, "unable to add inventory endpoint")
+					os.Exit(1)
+				}
+			}
+
+			if reloadableConfig != nil {
+				if err := mgr.GetLocalManager().Add(&configreload.Watcher{
+					Path:   serverConfigFile,
+					Config: reloadableConfig,
+					Decode: decodeServerConfig,
+				}); err != nil {
+					setupLog.Error(err, "unable to add server config watcher")
+					os.Exit(1)
+				}
+			}
+
 			// +kubebuilder:scaffold:builder
 
 			if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -660,16 +995,34 @@ func initializeClusterDiscovery(
 			return nil, nil, fmt.Errorf("unable to create datum project provider: %w", err)
 		}
 
+		if projectFilter := serverConfig.Discovery.ProjectFilter; projectFilter != nil {
+			filter, err := projectfilter.NewFilter(*projectFilter)
+			if err != nil {
+				return nil, nil, err
+			}
+			provider, err = projectfilter.Wrap(provider, projectfilter.Options{
+				Filter:          filter,
+				Reader:          discoveryManager.GetClient(),
+				RecheckInterval: projectFilter.RecheckInterval.Duration,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
 		runnables = append(runnables, discoveryManager)
 
-	// case providers.ProviderKind:
-	// 	provider = mckind.New(mckind.Options{
-	// 		ClusterOptions: []cluster.Option{
-	// 			func(o *cluster.Options) {
-	// 				o.Scheme = scheme
-	// 			},
-	// 		},
-	// 	})
+	case config.DiscoveryModeKubeconfigDirectory:
+		// The provider engages its clusters when the manager starts it, and
+		// keeps them in sync with the files of the directory.
+		provider = kubeconfigdir.New(kubeconfigdir.Options{
+			Directory: serverConfig.Discovery.KubeconfigDirectory,
+			ClusterOptions: []cluster.Option{
+				func(o *cluster.Options) {
+					o.Scheme = scheme
+				},
+			},
+		})
 
 	default:
 		return nil, nil, fmt.Errorf(
@@ -687,3 +1040,40 @@ func ignoreCanceled(err error) error {
 	}
 	return err
 }
+
+// logCryptoPolicy reports the effective TLS settings of each component the
+// crypto policy applies to.
+func logCryptoPolicy(policy config.CryptoPolicyConfig) {
+	setupLog.Info("crypto policy", "mode", policy.Mode, "fips140", fips140.Enabled())
+	for _, component := range config.CryptoPolicyComponents {
+		settings := policy.Effective(component)
+		cipherSuites := "default"
+		if settings.CipherSuites != nil {
+			names := make([]string, 0, len(settings.CipherSuites))
+			for _, id := range settings.CipherSuites {
+				names = append(names, tls.CipherSuiteName(id))
+			}
+			cipherSuites = strings.Join(names, ",")
+		}
+		setupLog.Info("effective TLS settings",
+			"component", component,
+			"minVersion", tls.VersionName(settings.MinVersion),
+			"cipherSuites", cipherSuites,
+		)
+	}
+}
+
+// decodeServerConfig decodes and defaults the contents of the server config
+// file, applying the overrides taken from the environment.
+func decodeServerConfig(data []byte) (config.NetworkServicesOperator, error) {
+	var serverConfig config.NetworkServicesOperator
+	if err := runtime.DecodeInto(codecs.UniversalDecoder(), data, &serverConfig); err != nil {
+		return serverConfig, err
+	}
+
+	// Allow overriding Redis URL at runtime via env var.
+	if redisURL := strings.TrimSpace(os.Getenv("REDIS_URL")); redisURL != "" {
+		serverConfig.Redis.URL = redisURL
+	}
+	return serverConfig, nil
+}
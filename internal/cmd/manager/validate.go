@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package managercmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// errInvalidServerConfig is returned by --validate-only when the server config
+// file is rejected. The violations are written to the report.
+var errInvalidServerConfig = errors.New("server config is invalid")
+
+// validateServerConfig decodes and validates the server config file the same
+// way the manager does at startup, and writes a report of every violation to
+// out.
+func validateServerConfig(out io.Writer, path string) error {
+	if path == "" {
+		return errors.New("--server-config is required with --validate-only")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed reading server config: %w", err)
+	}
+
+	serverConfig, err := decodeServerConfig(data)
+	if err != nil {
+		// Strict decoding reports unknown and duplicate fields as well as
+		// malformed values.
+		writeValidationReport(out, path, fmt.Errorf("failed decoding: %w", err))
+		return errInvalidServerConfig
+	}
+	if err := serverConfig.Validate(); err != nil {
+		writeValidationReport(out, path, err)
+		return errInvalidServerConfig
+	}
+
+	fmt.Fprintf(out, "%s: server config is valid\n", path)
+	return nil
+}
+
+// writeValidationReport writes one line per violation joined in err.
+func writeValidationReport(out io.Writer, path string, err error) {
+	fmt.Fprintf(out, "%s: server config is invalid:\n", path)
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			fmt.Fprintf(out, "  - %s\n", line)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package managercmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateServerConfigSamples(t *testing.T) {
+	for _, path := range []string{
+		"../../../config/manager/config.yaml",
+		"../../../config/dev/config.yaml",
+		"../../../config/e2e/config.yaml",
+	} {
+		t.Run(path, func(t *testing.T) {
+			var out bytes.Buffer
+			assert.NoError(t, validateServerConfig(&out, path), out.String())
+		})
+	}
+}
+
+func TestValidateServerConfig(t *testing.T) {
+	scenarios := map[string]struct {
+		config     string
+		wantReport []string
+	}{
+		"valid": {
+			config: `
+apiVersion: apiserver.config.datumapis.com/v1alpha1
+kind: NetworkServicesOperator
+discovery:
+  mode: milo
+  internalServiceDiscovery: true
+gateway:
+  clusterIssuerMap:
+    auto: letsencrypt
+`,
+		},
+		"unknown field": {
+			config: `
+apiVersion: apiserver.config.datumapis.com/v1alpha1
+kind: NetworkServicesOperator
+gateway:
+  clusterIssuerMaps: {}
+`,
+			wantReport: []string{`unknown field "gateway.clusterIssuerMaps"`},
+		},
+		"every violation is reported": {
+			config: `
+apiVersion: apiserver.config.datumapis.com/v1alpha1
+kind: NetworkServicesOperator
+discovery:
+  mode: single
+  projectKubeconfigPath: /etc/project/kubeconfig
+webhookServer:
+  port: 70000
+metricsServer:
+  bindAddress: ":http-metrics"
+leaderElection:
+  leaseDuration: 10s
+  renewDeadline: 15s
+domainRegistration:
+  refreshInterval: -1h
+gateway:
+  clusterIssuerMap:
+    auto: Lets_Encrypt
+`,
+			wantReport: []string{
+				"  - discovery: projectKubeconfigPath is only supported in milo mode",
+				"  - webhookServer: port 70000 must be between 1 and 65535",
+				`  - metricsServer: bindAddress ":http-metrics" must have a port between 0 and 65535`,
+				"  - leaderElection: renewDeadline must be less than leaseDuration",
+				"  - domainRegistration: refreshInterval must not be negative",
+				`  - gateway.clusterIssuerMap: auto: invalid ClusterIssuer name "Lets_Encrypt"`,
+			},
+		},
+		"unsupported discovery mode": {
+			config: `
+apiVersion: apiserver.config.datumapis.com/v1alpha1
+kind: NetworkServicesOperator
+discovery:
+  mode: kind
+`,
+			wantReport: []string{`discovery: unsupported mode "kind"`},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(scenario.config), 0o600))
+
+			var out bytes.Buffer
+			err := validateServerConfig(&out, path)
+			if len(scenario.wantReport) == 0 {
+				assert.NoError(t, err, out.String())
+				assert.Contains(t, out.String(), "server config is valid")
+				return
+			}
+
+			assert.ErrorIs(t, err, errInvalidServerConfig)
+			report := out.String()
+			assert.True(t, strings.HasPrefix(report, path+": server config is invalid:\n"), report)
+			for _, want := range scenario.wantReport {
+				assert.Contains(t, report, want)
+			}
+		})
+	}
+}
+
+func TestValidateServerConfigRequiresFile(t *testing.T) {
+	assert.Error(t, validateServerConfig(&bytes.Buffer{}, ""))
+}
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package preflightcmd implements the "preflight" subcommand, which checks a
+// proposed Gateway or HTTPProxy against the current state of a project without
+// programming anything.
+package preflightcmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/preflight"
+	dnsutil "go.datum.net/network-services-operator/internal/util/dns"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme, serializer.EnableStrict)
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(config.AddToScheme(scheme))
+	utilruntime.Must(config.RegisterDefaults(scheme))
+	utilruntime.Must(networkingv1alpha.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
+	utilruntime.Must(dnsv1alpha1.AddToScheme(scheme))
+}
+
+type options struct {
+	file            string
+	namespace       string
+	kubeconfig      string
+	serverCfgFile   string
+	outputConfigMap string
+}
+
+// NewCommand returns the preflight subcommand.
+func NewCommand() *cobra.Command {
+	var o options
+
+	fs := flag.NewFlagSet("preflight", flag.ContinueOnError)
+	fs.StringVar(&o.file, "file", "", "Path to the Gateway or HTTPProxy manifest to check")
+	fs.StringVar(&o.namespace, "namespace", "default", "Namespace of the resource, when the manifest does not set one")
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "Path to the kubeconfig of the project control plane. Defaults to the in-cluster or KUBECONFIG configuration")
+	fs.StringVar(&o.serverCfgFile, "server-config", "", "Path to the operator config file")
+	fs.StringVar(&o.outputConfigMap, "output-configmap", "",
+		"Name of a ConfigMap in the namespace of the resource to write the report to, in addition to printing it")
+
+	cmd := &cobra.Command{
+		Use:   "preflight",
+		Short: "Check a proposed Gateway or HTTPProxy without programming it",
+		Long: "Evaluates the hostnames of a Gateway or HTTPProxy manifest against the Domains, DNSZones " +
+			"and Gateways of the project, and the CAA records of the hostnames, and reports the hostnames " +
+			"that would not be programmed. Nothing is created or changed, unless --output-configmap is set.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return run(cmd.Context(), o, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().AddGoFlagSet(fs)
+	return cmd
+}
+
+func run(ctx context.Context, o options, out io.Writer) error {
+	if o.file == "" {
+		return errors.New("--file is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var serverConfig config.NetworkServicesOperator
+	var configData []byte
+	if o.serverCfgFile != "" {
+		var err error
+		configData, err = os.ReadFile(o.serverCfgFile)
+		if err != nil {
+			return fmt.Errorf("failed reading server config: %w", err)
+		}
+	}
+	if err := runtime.DecodeInto(codecs.UniversalDecoder(), configData, &serverConfig); err != nil {
+		return fmt.Errorf("failed decoding server config: %w", err)
+	}
+
+	obj, err := readObject(o.file)
+	if err != nil {
+		return err
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(o.namespace)
+	}
+
+	restConfig, err := restConfig(o.kubeconfig)
+	if err != nil {
+		return err
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed creating client: %w", err)
+	}
+
+	checker := &preflight.Checker{
+		Client: cl,
+		Config: serverConfig,
+	}
+	if len(serverConfig.Gateway.CAA.IssuerDomains) > 0 {
+		exchange, err := dnsutil.NewExchange(serverConfig.Gateway.CAA.Resolver, preflight.CAALookupTimeout)
+		if err != nil {
+			return fmt.Errorf("failed creating CAA resolver: %w", err)
+		}
+		checker.Exchange = exchange
+	}
+
+	report, err := checker.Check(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if err := printReport(out, report); err != nil {
+		return err
+	}
+
+	if o.outputConfigMap != "" {
+		if err := writeConfigMap(ctx, cl, report, client.ObjectKey{Namespace: obj.GetNamespace(), Name: o.outputConfigMap}); err != nil {
+			return err
+		}
+	}
+
+	if !report.Passed() {
+		return fmt.Errorf("preflight of %s %s/%s failed", report.Kind, report.Namespace, report.Name)
+	}
+	return nil
+}
+
+// readObject decodes the Gateway or HTTPProxy manifest at path.
+func readObject(path string) (client.Object, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading manifest: %w", err)
+	}
+
+	decoded, _, err := codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding manifest: %w", err)
+	}
+
+	switch obj := decoded.(type) {
+	case *gatewayv1.Gateway:
+		return obj, nil
+	case *networkingv1alpha.HTTPProxy:
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("unsupported manifest kind %s, expected a Gateway or HTTPProxy", decoded.GetObjectKind().GroupVersionKind().Kind)
+	}
+}
+
+func restConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig == "" {
+		return ctrl.GetConfig()
+	}
+	cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading kubeconfig: %w", err)
+	}
+	return cfg, nil
+}
+
+func printReport(out io.Writer, report *preflight.Report) error {
+	fmt.Fprintf(out, "Preflight of %s %s/%s\n\n", report.Kind, report.Namespace, report.Name)
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tHOSTNAME\tSTATUS\tMESSAGE")
+	for _, result := range report.Results {
+		hostname := result.Hostname
+		if hostname == "" {
+			hostname = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Check, hostname, result.Status, strings.ReplaceAll(result.Message, "\n", " "))
+	}
+	return w.Flush()
+}
+
+func writeConfigMap(ctx context.Context, cl client.Client, report *preflight.Report, key client.ObjectKey) error {
+	desired, err := report.ConfigMap(key)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: desired.ObjectMeta}
+	if _, err := controllerutil.CreateOrUpdate(ctx, cl, configMap, func() error {
+		configMap.Data = desired.Data
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed writing preflight report to configmap %s: %w", key, err)
+	}
+	return nil
+}
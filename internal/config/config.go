@@ -5,9 +5,15 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	words "go.datum.net/network-services-operator/internal/words"
@@ -16,6 +22,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/utils/ptr"
@@ -29,7 +37,9 @@ import (
 	multiclusterproviders "go.miloapis.com/milo/pkg/multicluster-runtime"
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/features"
 	"go.datum.net/network-services-operator/internal/registrydata"
+	"go.datum.net/network-services-operator/internal/util/ipam"
 )
 
 const (
@@ -59,6 +69,9 @@ type NetworkServicesOperator struct {
 
 	Discovery DiscoveryConfig `json:"discovery"`
 
+	// IPAM configures the allocation of subnet prefixes.
+	IPAM IPAMConfig `json:"ipam,omitempty"`
+
 	DownstreamResourceManagement DownstreamResourceManagementConfig `json:"downstreamResourceManagement"`
 
 	// Redis provides shared Redis connection settings.
@@ -67,6 +80,11 @@ type NetworkServicesOperator struct {
 	// LeaderElection configures controller-runtime leader election timings.
 	LeaderElection LeaderElectionConfig `json:"leaderElection,omitempty"`
 
+	// Controllers tunes the concurrency and work queue rate limiting of
+	// controllers, keyed by controller name, such as "gateway", "httpproxy" or
+	// "domain". Controllers that are not listed use their defaults.
+	Controllers map[string]ControllerConfig `json:"controllers,omitempty"`
+
 	DomainVerification DomainVerificationConfig `json:"domainVerificationConfig"`
 
 	// DomainRegistration controls RDAP/WHOIS refresh behavior for Domain status.registration
@@ -82,9 +100,42 @@ type NetworkServicesOperator struct {
 	// data-plane resources are materialized.
 	DownstreamClient ClientConnectionConfig `json:"downstreamClient,omitempty"`
 
+	// DownstreamCircuitBreaker stops non-critical writes to the downstream
+	// cluster while its API server is returning errors.
+	DownstreamCircuitBreaker CircuitBreakerConfig `json:"downstreamCircuitBreaker,omitempty"`
+
+	// UpstreamOutage controls how reconciles of upstream resources behave
+	// while the API server of an upstream cluster is unreachable.
+	UpstreamOutage UpstreamOutageConfig `json:"upstreamOutage,omitempty"`
+
 	// ProjectClient configures the Kubernetes client connection used for both
 	// project discovery and per-project cluster connections.
 	ProjectClient ClientConnectionConfig `json:"projectClient,omitempty"`
+
+	// CryptoPolicy restricts the TLS versions and cipher suites used by the
+	// operator's servers and outbound clients.
+	CryptoPolicy CryptoPolicyConfig `json:"cryptoPolicy,omitempty"`
+
+	// FeatureGates enables or disables optional subsystems by feature name.
+	// Features that are not listed use their default. See the features
+	// package for the available gates.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+// ControllerConfig returns the configuration of the named controller, with
+// defaults applied to its rate limiter.
+func (c *NetworkServicesOperator) ControllerConfig(name string) ControllerConfig {
+	controller := c.Controllers[name]
+	if controller.RateLimiter != nil {
+		controller.RateLimiter = controller.RateLimiter.DeepCopy()
+		SetDefaults_RateLimiterConfig(controller.RateLimiter)
+	}
+	return controller
+}
+
+// FeatureEnabled returns whether the feature gate is enabled.
+func (c *NetworkServicesOperator) FeatureEnabled(feature features.Feature) bool {
+	return features.Enabled(c.FeatureGates, feature)
 }
 
 // +k8s:deepcopy-gen=true
@@ -124,6 +175,114 @@ func SetDefaults_ClientConnectionConfig(obj *ClientConnectionConfig) {
 	}
 }
 
+// +k8s:deepcopy-gen=true
+
+// CircuitBreakerConfig controls when the client for a cluster stops sending
+// requests to an API server that is failing.
+//
+// The breaker opens when at least MinimumRequests requests were made within
+// Window and the percentage of them that failed reaches ErrorRateThreshold.
+// While open, non-critical writes fail immediately. After OpenDuration a
+// single probe request is let through; the breaker closes if it succeeds and
+// opens again if it fails.
+type CircuitBreakerConfig struct {
+	// Disabled turns off the circuit breaker.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// ErrorRateThreshold is the percentage of failed requests that opens the
+	// breaker.
+	//
+	// +default=50
+	ErrorRateThreshold int32 `json:"errorRateThreshold,omitempty"`
+
+	// MinimumRequests is the number of requests that must be made within Window
+	// before the error rate is evaluated.
+	//
+	// +default=20
+	MinimumRequests int32 `json:"minimumRequests,omitempty"`
+
+	// Window is the period over which the error rate is measured. Defaults to
+	// 30 seconds.
+	Window metav1.Duration `json:"window,omitempty"`
+
+	// OpenDuration is how long the breaker stays open before a probe request is
+	// let through. Defaults to 30 seconds.
+	OpenDuration metav1.Duration `json:"openDuration,omitempty"`
+}
+
+func (c *CircuitBreakerConfig) validate() error {
+	var errs []error
+	if c.ErrorRateThreshold < 0 || c.ErrorRateThreshold > 100 {
+		errs = append(errs, errors.New("errorRateThreshold must be between 0 and 100"))
+	}
+	if c.MinimumRequests < 0 {
+		errs = append(errs, errors.New("minimumRequests must not be negative"))
+	}
+	errs = append(errs,
+		validateDuration("window", &c.Window),
+		validateDuration("openDuration", &c.OpenDuration),
+	)
+	return errors.Join(errs...)
+}
+
+func SetDefaults_CircuitBreakerConfig(obj *CircuitBreakerConfig) {
+	if obj.ErrorRateThreshold == 0 {
+		obj.ErrorRateThreshold = 50
+	}
+	if obj.MinimumRequests == 0 {
+		obj.MinimumRequests = 20
+	}
+	if obj.Window.Duration == 0 {
+		obj.Window = metav1.Duration{Duration: 30 * time.Second}
+	}
+	if obj.OpenDuration.Duration == 0 {
+		obj.OpenDuration = metav1.Duration{Duration: 30 * time.Second}
+	}
+}
+
+// +k8s:deepcopy-gen=true
+
+// UpstreamOutageConfig controls how reconciles of upstream resources tolerate
+// transient outages of an upstream cluster's API server. Only failures of
+// requests to the upstream API server are counted as an outage.
+//
+// Desired state is computed from the informer cache, which keeps serving the
+// last observed objects during an outage. While an outage is shorter than
+// Threshold, status updates are deferred and the resource is retried every
+// RetryInterval, so that conditions don't flap. Once the outage lasts longer
+// than Threshold, status updates resume and Gateways and HTTPProxies are
+// marked with an UpstreamUnreachable condition until the API server is
+// reachable again.
+type UpstreamOutageConfig struct {
+	// Disabled turns off outage tracking, so errors reaching the upstream API
+	// server fail reconciles immediately.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Threshold is how long an outage lasts before it is surfaced in status.
+	// Defaults to 2 minutes.
+	Threshold metav1.Duration `json:"threshold,omitempty"`
+
+	// RetryInterval is how often resources are retried while status updates
+	// are deferred. Defaults to 10 seconds.
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+}
+
+func (c *UpstreamOutageConfig) validate() error {
+	return errors.Join(
+		validateDuration("threshold", &c.Threshold),
+		validateDuration("retryInterval", &c.RetryInterval),
+	)
+}
+
+func SetDefaults_UpstreamOutageConfig(obj *UpstreamOutageConfig) {
+	if obj.Threshold.Duration == 0 {
+		obj.Threshold = metav1.Duration{Duration: 2 * time.Minute}
+	}
+	if obj.RetryInterval.Duration == 0 {
+		obj.RetryInterval = metav1.Duration{Duration: 10 * time.Second}
+	}
+}
+
 // +k8s:deepcopy-gen=true
 type LeaderElectionConfig struct {
 	// LeaseDuration is the duration that non-leader candidates wait to force
@@ -141,6 +300,24 @@ type LeaderElectionConfig struct {
 	RetryPeriod metav1.Duration `json:"retryPeriod,omitempty"`
 }
 
+// validate checks the ordering of the timings that client-go requires. Unset
+// timings are defaulted and are not compared.
+func (c *LeaderElectionConfig) validate() error {
+	errs := []error{
+		validateDuration("leaseDuration", &c.LeaseDuration),
+		validateDuration("renewDeadline", &c.RenewDeadline),
+		validateDuration("retryPeriod", &c.RetryPeriod),
+	}
+	lease, renew, retry := c.LeaseDuration.Duration, c.RenewDeadline.Duration, c.RetryPeriod.Duration
+	if lease > 0 && renew > 0 && renew >= lease {
+		errs = append(errs, errors.New("renewDeadline must be less than leaseDuration"))
+	}
+	if renew > 0 && retry > 0 && retry >= renew {
+		errs = append(errs, errors.New("retryPeriod must be less than renewDeadline"))
+	}
+	return errors.Join(errs...)
+}
+
 func SetDefaults_LeaderElectionConfig(obj *LeaderElectionConfig) {
 	if obj.LeaseDuration.Duration == 0 {
 		obj.LeaseDuration = metav1.Duration{Duration: 15 * time.Second}
@@ -153,6 +330,76 @@ func SetDefaults_LeaderElectionConfig(obj *LeaderElectionConfig) {
 	}
 }
 
+// +k8s:deepcopy-gen=true
+
+// ControllerConfig tunes how a controller processes its work queue.
+type ControllerConfig struct {
+	// MaxConcurrentReconciles is the maximum number of concurrent reconciles of
+	// the controller. Overrides the controller specific setting, such as
+	// gateway.maxConcurrentReconciles, when set.
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+
+	// RateLimiter replaces the default work queue rate limiter of the
+	// controller.
+	RateLimiter *RateLimiterConfig `json:"rateLimiter,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RateLimiterConfig configures the rate limiting of a controller's work queue.
+//
+// Requeues of a failing item back off exponentially from BaseDelay up to
+// MaxDelay. All items are additionally limited by a token bucket that refills
+// at QPS and holds up to Burst tokens.
+type RateLimiterConfig struct {
+	// BaseDelay is the delay of the first requeue of a failing item. Defaults
+	// to 5 milliseconds.
+	BaseDelay metav1.Duration `json:"baseDelay,omitempty"`
+
+	// MaxDelay is the maximum delay of a requeue of a failing item. Defaults
+	// to 1000 seconds.
+	MaxDelay metav1.Duration `json:"maxDelay,omitempty"`
+
+	// QPS is the overall rate at which items are admitted to the queue.
+	// Defaults to 10.
+	QPS float64 `json:"qps,omitempty"`
+
+	// Burst is the number of items admitted to the queue above QPS. Defaults
+	// to 100.
+	Burst int `json:"burst,omitempty"`
+}
+
+func SetDefaults_RateLimiterConfig(obj *RateLimiterConfig) {
+	if obj.BaseDelay.Duration == 0 {
+		obj.BaseDelay = metav1.Duration{Duration: 5 * time.Millisecond}
+	}
+	if obj.MaxDelay.Duration == 0 {
+		obj.MaxDelay = metav1.Duration{Duration: 1000 * time.Second}
+	}
+	if obj.QPS == 0 {
+		obj.QPS = 10
+	}
+	if obj.Burst == 0 {
+		obj.Burst = 100
+	}
+}
+
+func (c *RateLimiterConfig) validate() error {
+	if c.BaseDelay.Duration < 0 {
+		return fmt.Errorf("baseDelay must not be negative")
+	}
+	if c.MaxDelay.Duration < c.BaseDelay.Duration {
+		return fmt.Errorf("maxDelay must not be less than baseDelay")
+	}
+	if c.QPS < 0 {
+		return fmt.Errorf("qps must not be negative")
+	}
+	if c.Burst < 0 {
+		return fmt.Errorf("burst must not be negative")
+	}
+	return nil
+}
+
 // +k8s:deepcopy-gen=true
 type ConnectorConfig struct {
 	// LeaseDurationSeconds is the number of seconds the connector lease is valid for.
@@ -253,6 +500,13 @@ type WebhookServerConfig struct {
 	ClientCAName string `json:"clientCAName"`
 }
 
+func (c *WebhookServerConfig) validate() error {
+	if c.Port < 0 || c.Port > 65535 {
+		return fmt.Errorf("port %d must be between 1 and 65535", c.Port)
+	}
+	return nil
+}
+
 func (c *WebhookServerConfig) Options(ctx context.Context, secretsClient client.Client) webhook.Options {
 	opts := webhook.Options{
 		Host:     c.Host,
@@ -288,6 +542,21 @@ type MetricsServerConfig struct {
 	TLS TLSConfig `json:"tls"`
 }
 
+func (c *MetricsServerConfig) validate() error {
+	// "0" disables the metrics server.
+	if c.BindAddress == "" || c.BindAddress == "0" {
+		return nil
+	}
+	_, port, err := net.SplitHostPort(c.BindAddress)
+	if err != nil {
+		return fmt.Errorf("bindAddress %q must be a host:port address or \"0\": %w", c.BindAddress, err)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		return fmt.Errorf("bindAddress %q must have a port between 0 and 65535", c.BindAddress)
+	}
+	return nil
+}
+
 func SetDefaults_MetricsServerConfig(obj *MetricsServerConfig) {
 	if obj.SecureServing == nil {
 		obj.SecureServing = ptr.To(true)
@@ -404,6 +673,135 @@ type DownstreamResourceManagementConfig struct {
 	// downstream resources. When not provided, the operator will use the
 	// in-cluster config.
 	KubeconfigPath string `json:"kubeconfigPath"`
+
+	// Audit configures the periodic audit of the resources the operator
+	// manages in the downstream cluster.
+	Audit DownstreamAuditConfig `json:"audit,omitempty"`
+
+	// NamespaceGC configures the removal of downstream namespaces that no
+	// longer hold resources of upstream owners.
+	NamespaceGC DownstreamNamespaceGCConfig `json:"namespaceGC,omitempty"`
+
+	// ClusterDisengagement configures the handling of the downstream resources
+	// of upstream clusters that the discovery provider removed, for example
+	// because their project was deleted or suspended.
+	ClusterDisengagement DownstreamClusterDisengagementConfig `json:"clusterDisengagement,omitempty"`
+
+	// Inventory configures the periodic count of the resources the operator
+	// manages in the downstream cluster.
+	Inventory DownstreamInventoryConfig `json:"inventory,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// DownstreamAuditConfig controls the periodic audit of downstream resources.
+//
+// The audit compares the downstream Gateways, HTTPRoutes, Services and
+// EndpointSlices the operator manages against their upstream owners, and
+// reports resources whose owner no longer exists, or was replaced by a new
+// object of the same name, as drifted. Such resources are left behind when
+// upstream deletions are missed, for example while the operator is down.
+type DownstreamAuditConfig struct {
+	// Disabled turns off the audit.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Interval is how often the audit runs. Defaults to 10 minutes.
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// Repair deletes drifted resources. When false, drift is only reported.
+	// Resources with a modified spec are not deleted, but have their desired
+	// spec written again by the controllers of their owners.
+	Repair bool `json:"repair,omitempty"`
+}
+
+func (c *DownstreamResourceManagementConfig) validate() error {
+	return errors.Join(
+		validateDuration("audit.interval", &c.Audit.Interval),
+		validateDuration("namespaceGC.gracePeriod", &c.NamespaceGC.GracePeriod),
+		validateDuration("clusterDisengagement.gracePeriod", &c.ClusterDisengagement.GracePeriod),
+		validateDuration("inventory.interval", &c.Inventory.Interval),
+	)
+}
+
+func SetDefaults_DownstreamAuditConfig(obj *DownstreamAuditConfig) {
+	if obj.Interval.Duration == 0 {
+		obj.Interval = metav1.Duration{Duration: 10 * time.Minute}
+	}
+}
+
+// +k8s:deepcopy-gen=true
+
+// DownstreamNamespaceGCConfig controls the garbage collection of downstream
+// namespaces.
+//
+// Downstream namespaces are created on demand for the upstream namespaces that
+// resources are programmed for. Once the last upstream owner of resources in a
+// downstream namespace has been finalized and its anchor removed, the
+// namespace is deleted after the grace period. Namespaces annotated with
+// meta.datumapis.com/keep-namespace=true are never deleted.
+type DownstreamNamespaceGCConfig struct {
+	// Disabled turns off the garbage collection of downstream namespaces.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// GracePeriod is how long a downstream namespace must have been empty
+	// before it is deleted, so that namespaces are not churned while resources
+	// are being replaced. Defaults to 5 minutes.
+	GracePeriod metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+func SetDefaults_DownstreamNamespaceGCConfig(obj *DownstreamNamespaceGCConfig) {
+	if obj.GracePeriod.Duration == 0 {
+		obj.GracePeriod = metav1.Duration{Duration: 5 * time.Minute}
+	}
+}
+
+// +k8s:deepcopy-gen=true
+
+// DownstreamClusterDisengagementConfig controls the finalization of the
+// downstream resources of removed upstream clusters.
+//
+// The upstream owners of the downstream resources of a removed cluster are
+// never finalized, which would leave the resources and their namespaces behind.
+// Finalization deletes the anchors of these resources instead, so that they
+// are garbage collected, followed by their namespaces.
+type DownstreamClusterDisengagementConfig struct {
+	// FinalizeResources deletes the downstream resources of removed clusters.
+	// When false, they are left in place and only reported.
+	FinalizeResources bool `json:"finalizeResources,omitempty"`
+
+	// GracePeriod is how long a cluster must stay removed before its downstream
+	// resources are finalized, so that clusters that are removed briefly, such
+	// as while the provider reconnects, keep their resources. Defaults to 10
+	// minutes.
+	GracePeriod metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+func SetDefaults_DownstreamClusterDisengagementConfig(obj *DownstreamClusterDisengagementConfig) {
+	if obj.GracePeriod.Duration == 0 {
+		obj.GracePeriod = metav1.Duration{Duration: 10 * time.Minute}
+	}
+}
+
+// +k8s:deepcopy-gen=true
+
+// DownstreamInventoryConfig controls the inventory of downstream resources.
+//
+// The inventory counts the downstream resources the operator manages by kind,
+// upstream cluster and controller, and exports the counts by kind as the
+// nso_downstream_managed_resources metric, so that the resources affected by
+// a faulty controller can be measured.
+type DownstreamInventoryConfig struct {
+	// Disabled turns off the inventory.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Interval is how often the inventory is taken. Defaults to 5 minutes.
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+func SetDefaults_DownstreamInventoryConfig(obj *DownstreamInventoryConfig) {
+	if obj.Interval.Duration == 0 {
+		obj.Interval = metav1.Duration{Duration: 5 * time.Minute}
+	}
 }
 
 func (c *DownstreamResourceManagementConfig) RestConfig() (*rest.Config, error) {
@@ -447,6 +845,63 @@ type DomainVerificationConfig struct {
 	//
 	// +default=".well-known/datum-custom-hostname-challenge"
 	HTTPVerificationTokenPath string `json:"httpVerificationTokenPath"`
+
+	// Interval at which verified Domains are re-verified by checking their
+	// DNSZone, DNS record or HTTP token again. Set to 0 to disable
+	// re-verification.
+	//
+	// +default="24h"
+	ReverificationInterval *metav1.Duration `json:"reverificationInterval"`
+
+	// Interval to retry a failed re-verification attempt.
+	//
+	// +default="5m"
+	ReverificationRetryInterval *metav1.Duration `json:"reverificationRetryInterval"`
+
+	// Number of consecutive failed re-verification attempts after which a
+	// Domain is no longer considered verified.
+	//
+	// +default=3
+	ReverificationFailureThreshold int `json:"reverificationFailureThreshold"`
+}
+
+func (c *DomainVerificationConfig) validate() error {
+	var errs []error
+	for i, interval := range c.RetryIntervals {
+		if interval.Interval.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("retryIntervals[%d].interval must be positive", i))
+		}
+		errs = append(errs, validateDuration(fmt.Sprintf("retryIntervals[%d].maxElapsed", i), interval.MaxElapsed))
+	}
+	if c.RetryJitterMaxFactor < 0 {
+		errs = append(errs, errors.New("retryJitterMaxFactor must not be negative"))
+	}
+	if c.MaxConcurrentVerifications < 0 {
+		errs = append(errs, errors.New("maxConcurrentVerifications must not be negative"))
+	}
+	errs = append(errs,
+		validateDuration("reverificationInterval", c.ReverificationInterval),
+		validateDuration("reverificationRetryInterval", c.ReverificationRetryInterval),
+	)
+	if c.ReverificationFailureThreshold < 0 {
+		errs = append(errs, errors.New("reverificationFailureThreshold must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// ReverificationEnabled returns whether verified Domains are periodically
+// re-verified.
+func (c *DomainVerificationConfig) ReverificationEnabled() bool {
+	return c.ReverificationInterval != nil && c.ReverificationInterval.Duration > 0
+}
+
+// GetReverificationRetryInterval returns the interval to retry a failed
+// re-verification attempt. Returns 5 minutes if not set.
+func (c *DomainVerificationConfig) GetReverificationRetryInterval() time.Duration {
+	if c.ReverificationRetryInterval == nil || c.ReverificationRetryInterval.Duration <= 0 {
+		return 5 * time.Minute
+	}
+	return c.ReverificationRetryInterval.Duration
 }
 
 // GetRetryInterval returns the interval to retry for a given amount of elapsed
@@ -486,6 +941,11 @@ type DomainRegistrationConfig struct {
 
 	// RegistryData configures caching and rate limiting used by registry lookups.
 	RegistryData RegistryDataConfig `json:"registryData"`
+
+	// DNSSECResolver is the recursive resolver ("host:port") used to validate
+	// the DNSSEC chain of Domains whose registration reports DNSSEC enabled.
+	// Defaults to the first nameserver of /etc/resolv.conf.
+	DNSSECResolver string `json:"dnssecResolver,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -528,6 +988,13 @@ type RegistryDataCacheConfig struct {
 	// RedisKeyPrefix is used for keys when backend is "redis".
 	// +default="network-services-operator:"
 	RedisKeyPrefix string `json:"redisKeyPrefix,omitempty"`
+
+	// MaxEntries bounds the number of results held by the "memory" backend.
+	// When full, expired results are dropped first, then the results closest to
+	// expiry. A negative value leaves the cache unbounded. The "redis" backend
+	// relies on key expiry instead.
+	// +default=10000
+	MaxEntries int `json:"maxEntries,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -558,6 +1025,61 @@ type RegistryDataRateLimitsConfig struct {
 	// DefaultBlock is how long we block a provider after a rate limit response.
 	// +default="2s"
 	DefaultBlock *metav1.Duration `json:"defaultBlock,omitempty"`
+
+	// Providers overrides the default rate for specific RDAP or WHOIS providers,
+	// identified by host (e.g. "rdap.verisign.com" or "whois.iana.org").
+	// Lookups beyond the rate of a provider are deferred locally, and Domains
+	// report a RateLimited reason until they are retried.
+	Providers []RegistryDataProviderRateLimitConfig `json:"providers,omitempty"`
+}
+
+func (c *DomainRegistrationConfig) validate() error {
+	errs := []error{
+		validateDuration("refreshInterval", c.RefreshInterval),
+		validateDuration("retryBackoff", c.RetryBackoff),
+		validateDuration("lookupTimeout", c.LookupTimeout),
+	}
+	if c.JitterMaxFactor < 0 {
+		errs = append(errs, errors.New("jitterMaxFactor must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c *RegistryDataRateLimitsConfig) validate() error {
+	if c.DefaultRatePerSec < 0 {
+		return errors.New("defaultRatePerSec must not be negative")
+	}
+	if c.DefaultBurst < 0 {
+		return errors.New("defaultBurst must not be negative")
+	}
+	if c.DefaultBlock != nil && c.DefaultBlock.Duration < 0 {
+		return errors.New("defaultBlock must not be negative")
+	}
+	for i, provider := range c.Providers {
+		if provider.Provider == "" {
+			return fmt.Errorf("providers[%d].provider is required", i)
+		}
+		if provider.RequestsPerMinute <= 0 {
+			return fmt.Errorf("providers[%d].requestsPerMinute must be positive", i)
+		}
+		if provider.Burst < 0 {
+			return fmt.Errorf("providers[%d].burst must not be negative", i)
+		}
+	}
+	return nil
+}
+
+// +k8s:deepcopy-gen=true
+type RegistryDataProviderRateLimitConfig struct {
+	// Provider is the host of the RDAP base URL or WHOIS server.
+	Provider string `json:"provider"`
+
+	// RequestsPerMinute is the sustained request rate allowed to the provider.
+	RequestsPerMinute float64 `json:"requestsPerMinute"`
+
+	// Burst is the number of requests that may be made at once. Defaults to
+	// defaultBurst when unset.
+	Burst float64 `json:"burst,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -590,6 +1112,14 @@ type GatewayConfig struct {
 	// Defaults to [IPv4, IPv6]
 	IPFamilies []networkingv1alpha.IPFamily `json:"ipFamilies,omitempty"`
 
+	// StaticAddressSubnetClasses are the subnet classes of IPPools that static
+	// gateway addresses may be requested from. Pools are looked up in the
+	// namespace of the gateway, and may hold operator-managed addresses or
+	// prefixes announced by the customer (BYOIP).
+	//
+	// Gateways may not request static addresses when empty.
+	StaticAddressSubnetClasses []string `json:"staticAddressSubnetClasses,omitempty"`
+
 	// DownstreamGatewayClassName is the name of the GatewayClass that should be
 	// used when programming gateways in the downstream cluster.
 	DownstreamGatewayClassName string `json:"downstreamGatewayClassName"`
@@ -686,6 +1216,32 @@ type GatewayConfig struct {
 	// Defaults to false.
 	EnableDNSIntegration bool `json:"enableDNSIntegration,omitempty"`
 
+	// SharedDNSZoneSelector selects DNSZones outside of a Gateway's namespace
+	// that DNS records for the Gateway's hostnames may be placed in. A shared
+	// DNSZone is only used when a ReferenceGrant in the DNSZone's namespace
+	// allows Gateways from the Gateway's namespace to reference it. DNSZones in
+	// the Gateway's namespace are always preferred.
+	//
+	// When unset, only DNSZones in the Gateway's namespace are considered.
+	SharedDNSZoneSelector *metav1.LabelSelector `json:"sharedDNSZoneSelector,omitempty"`
+
+	// FilterNotReadyEndpoints removes endpoints that are not ready from the
+	// EndpointSlices mirrored to the downstream control plane. Terminating
+	// endpoints that are still serving are retained only when no ready
+	// endpoints remain.
+	//
+	// Defaults to false.
+	FilterNotReadyEndpoints bool `json:"filterNotReadyEndpoints,omitempty"`
+
+	// DownstreamBackendMode selects how the EndpointSlice backends of routes
+	// are programmed on the downstream cluster.
+	//
+	// EndpointSlices with FQDN endpoints are always programmed as Envoy Gateway
+	// Backends, and connector backends as Services.
+	//
+	// +default="Services"
+	DownstreamBackendMode DownstreamBackendMode `json:"downstreamBackendMode,omitempty"`
+
 	// DefaultListenerTLSSecretName, if provided, is the name of a
 	// pre-provisioned TLS certificate secret to use for the default HTTPS
 	// listener (named "default-https"). When set, this listener references
@@ -718,91 +1274,764 @@ type GatewayConfig struct {
 	// the deletion through the entire chain (CertificateRequest, Order,
 	// Challenge, solver resources).
 	CertificateReissuance CertificateReissuanceConfig `json:"certificateReissuance,omitempty"`
-}
 
-// +k8s:deepcopy-gen=true
+	// ListenerSharding splits the listeners of large gateways across multiple
+	// downstream Gateways.
+	ListenerSharding ListenerShardingConfig `json:"listenerSharding,omitempty"`
 
-// CertificateReissuanceConfig controls automatic recovery of failed certificate
-// issuance by deleting and recreating stuck Certificates.
-type CertificateReissuanceConfig struct {
-	// RetryInterval is the minimum time to wait after a Certificate failure
-	// before deleting it and recreating a fresh one. This prevents excessive
-	// requests to the ACME provider when the underlying issue persists (e.g.
-	// DNS not pointed to the Gateway).
+	// InvalidListenerPolicy selects how gateways that have invalid listeners,
+	// such as listeners with unverified hostnames, are programmed.
 	//
-	// Defaults to 5m via GetRetryInterval().
-	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+	// +default="ExcludeListeners"
+	InvalidListenerPolicy InvalidListenerPolicy `json:"invalidListenerPolicy,omitempty"`
 
-	// MaxRetries is the maximum number of times the gateway controller will
-	// fast-track re-issuance of a failed Certificate before falling back to
-	// cert-manager's built-in exponential backoff.
+	// CAA configures the inspection of CAA records before certificates are
+	// requested for listener hostnames.
+	CAA GatewayCAAConfig `json:"caa,omitempty"`
+
+	// TLSPolicy is the TLS policy enforced on client connections to downstream
+	// gateways. Gateways may select a stricter policy.
+	TLSPolicy GatewayTLSPolicyConfig `json:"tlsPolicy,omitempty"`
+
+	// HTTP3 selects the gateways that serve HTTP/3 over QUIC on their HTTPS
+	// listeners. Envoy Gateway adds the UDP listeners to the downstream
+	// gateways, and advertises HTTP/3 to clients with an alt-svc response
+	// header.
 	//
-	// +default=3
-	MaxRetries int `json:"maxRetries,omitempty"`
-}
+	// +default="Disabled"
+	HTTP3 GatewayHTTP3Mode `json:"http3,omitempty"`
 
-// HasDefaultListenerTLSSecret returns true when a shared TLS certificate
-// secret has been configured for default HTTPS listeners.
-func (c *GatewayConfig) HasDefaultListenerTLSSecret() bool {
-	return c.DefaultListenerTLSSecretName != ""
-}
+	// AccessLogging configures the programming of AccessLogPolicies.
+	// AccessLogPolicies are not reconciled unless an EnvoyProxy is configured.
+	AccessLogging GatewayAccessLoggingConfig `json:"accessLogging,omitempty"`
 
-// ShouldDeleteErroredChallenges returns whether the operator should automatically
-// delete errored ACME challenges. Defaults to true if not explicitly set.
-func (c *GatewayConfig) ShouldDeleteErroredChallenges() bool {
-	if c.DeleteErroredChallenges == nil {
-		return true // default enabled
-	}
-	return *c.DeleteErroredChallenges
-}
+	// DataPlaneSizes are the sizes of Envoy fleets that gateways may select
+	// with the networking.datumapis.com/data-plane-size annotation, keyed by
+	// the name of the size. Gateways that don't select a size are served by the
+	// fleet of the downstream GatewayClass.
+	//
+	// A size is programmed as the EnvoyProxy of the downstream Gateway, which
+	// is merged over the EnvoyProxy of the downstream GatewayClass. Envoy
+	// Gateway ignores it when gateways are merged, so the downstream
+	// GatewayClass must not enable mergeGateways.
+	DataPlaneSizes map[string]GatewayDataPlaneSize `json:"dataPlaneSizes,omitempty"`
 
-// IsEPPEmissionEnabled returns whether the operator should emit EnvoyPatchPolicy
-// objects. Defaults to true when not explicitly configured, preserving backward
-// compatibility. Set gateway.eppEmissionEnabled=false in the operator config to
-// disable EPP emission once the extension server is handling xDS mutation.
-func (c *GatewayConfig) IsEPPEmissionEnabled() bool {
-	if c.EPPEmissionEnabled == nil {
-		return true // default: EPP emission on
-	}
-	return *c.EPPEmissionEnabled
-}
+	// DNSEndpointRegistry configures the external-dns TXT registry records
+	// that are added to the DNSEndpoints of gateways.
+	DNSEndpointRegistry GatewayDNSEndpointRegistryConfig `json:"dnsEndpointRegistry,omitempty"`
 
-// GetRetryInterval returns the configured retry interval for certificate
-// re-issuance, defaulting to 5 minutes.
-func (c *CertificateReissuanceConfig) GetRetryInterval() time.Duration {
-	if c.RetryInterval.Duration > 0 {
-		return c.RetryInterval.Duration
-	}
-	return 5 * time.Minute
-}
+	// DNSRecords configures the DNS records that are programmed for gateways,
+	// both the DNSEndpoints of their canonical hostnames and the DNSRecordSets
+	// of their custom hostnames.
+	DNSRecords GatewayDNSRecordsConfig `json:"dnsRecords,omitempty"`
 
-// GetMaxRetries returns the configured maximum number of fast-track
-// re-issuance attempts, defaulting to 3.
-func (c *CertificateReissuanceConfig) GetMaxRetries() int {
-	if c.MaxRetries > 0 {
-		return c.MaxRetries
-	}
-	return 3
-}
+	// DNSVerification configures the verification that the DNS records of
+	// gateways resolve once they are programmed.
+	DNSVerification GatewayDNSVerificationConfig `json:"dnsVerification,omitempty"`
 
-func (c *GatewayConfig) GatewayDNSAddress(gateway *gatewayv1.Gateway) string {
-	seed := string(gateway.UID)
-	suffix := fmt.Sprintf(".%s", c.TargetDomain)
-	return words.WordsAndEntropy(suffix, seed)
-}
+	// DNSFailover configures the failover DNS records of gateways that are
+	// propagated to multiple member clusters of a federation control plane.
+	DNSFailover GatewayDNSFailoverConfig `json:"dnsFailover,omitempty"`
 
-func (c *GatewayConfig) ConnectorTunnelListenerName() string {
-	if c.ConnectorInternalListenerName == "" {
-		return "connector-tunnel"
-	}
-	return c.ConnectorInternalListenerName
+	// DomainGC configures the removal of the Domains that gateways create for
+	// hostnames without a matching Domain.
+	DomainGC GatewayDomainGCConfig `json:"domainGC,omitempty"`
+
+	// Maintenance configures the response served for gateways that are paused.
+	Maintenance GatewayMaintenanceConfig `json:"maintenance,omitempty"`
+
+	// RouteLimits bounds the HTTPRoutes programmed for each gateway, protecting
+	// shared data planes from a single gateway generating enormous route
+	// tables.
+	RouteLimits GatewayRouteLimitsConfig `json:"routeLimits,omitempty"`
+
+	// DeletionProtection guards gateways that still serve traffic against
+	// accidental deletion.
+	DeletionProtection GatewayDeletionProtectionConfig `json:"deletionProtection,omitempty"`
+
+	// PayloadPolicy configures the defaults and limits of the response
+	// compression and request body limits of PayloadPolicies.
+	PayloadPolicy GatewayPayloadPolicyConfig `json:"payloadPolicy,omitempty"`
 }
 
+// minCompressionMinSize is the smallest response size worth compressing.
+// Smaller responses grow once the headers of the encoding are added.
+var minCompressionMinSize = resource.MustParse("30")
+
 // +k8s:deepcopy-gen=true
 
-type CorazaConfig struct {
-	// Disable TrafficProtectionPolicy programming for Coraza.
-	Disabled bool `json:"disabled"`
+// GatewayPayloadPolicyConfig controls how PayloadPolicies are programmed.
+type GatewayPayloadPolicyConfig struct {
+	// DefaultCompressors are the encodings responses are compressed with when a
+	// PayloadPolicy does not select them, in order of preference.
+	DefaultCompressors []networkingv1alpha.PayloadCompressor `json:"defaultCompressors,omitempty"`
+
+	// DefaultCompressionMinSize is the size of the smallest response that is
+	// compressed when a PayloadPolicy does not set one.
+	DefaultCompressionMinSize resource.Quantity `json:"defaultCompressionMinSize,omitempty"`
+
+	// MaxRequestBodyLimit is the largest request body limit a PayloadPolicy may
+	// set. Request bodies are buffered by the data plane, so the limit bounds
+	// the memory a single request may hold.
+	MaxRequestBodyLimit resource.Quantity `json:"maxRequestBodyLimit,omitempty"`
+}
+
+func SetDefaults_GatewayPayloadPolicyConfig(obj *GatewayPayloadPolicyConfig) {
+	if len(obj.DefaultCompressors) == 0 {
+		obj.DefaultCompressors = []networkingv1alpha.PayloadCompressor{
+			networkingv1alpha.PayloadCompressorBrotli,
+			networkingv1alpha.PayloadCompressorGzip,
+		}
+	}
+	if obj.DefaultCompressionMinSize.IsZero() {
+		obj.DefaultCompressionMinSize = resource.MustParse("1Ki")
+	}
+	if obj.MaxRequestBodyLimit.IsZero() {
+		obj.MaxRequestBodyLimit = resource.MustParse("10Mi")
+	}
+}
+
+func (c *GatewayPayloadPolicyConfig) validate() error {
+	var errs []error
+	seen := sets.New[networkingv1alpha.PayloadCompressor]()
+	for _, compressor := range c.DefaultCompressors {
+		switch compressor {
+		case networkingv1alpha.PayloadCompressorBrotli,
+			networkingv1alpha.PayloadCompressorGzip,
+			networkingv1alpha.PayloadCompressorZstd:
+		default:
+			errs = append(errs, fmt.Errorf("defaultCompressors: unsupported compressor %q", compressor))
+		}
+		if seen.Has(compressor) {
+			errs = append(errs, fmt.Errorf("defaultCompressors: duplicate compressor %q", compressor))
+		}
+		seen.Insert(compressor)
+	}
+	if !c.DefaultCompressionMinSize.IsZero() && c.DefaultCompressionMinSize.Cmp(minCompressionMinSize) < 0 {
+		errs = append(errs, fmt.Errorf("defaultCompressionMinSize must be at least %s", minCompressionMinSize.String()))
+	}
+	if c.MaxRequestBodyLimit.Sign() < 0 {
+		errs = append(errs, errors.New("maxRequestBodyLimit must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayDeletionProtectionConfig controls the protection of gateways against
+// deletion while HTTPRoutes or an HTTPProxy still reference them. A protected
+// gateway is deleted by setting the networking.datumapis.com/force-delete
+// annotation to "true" on it first.
+type GatewayDeletionProtectionConfig struct {
+	// Enabled rejects the deletion of gateways that are still referenced.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayRouteLimitsConfig bounds the HTTPRoutes attached to a gateway. Routes
+// that exceed a limit are not programmed, and their parent status for the
+// gateway reports Accepted=False with the RouteLimitExceeded reason. A limit
+// of zero disables it.
+type GatewayRouteLimitsConfig struct {
+	// MaxAttachedRoutes is the maximum number of HTTPRoutes attached to a
+	// gateway. Routes are admitted oldest first, so that routes attached last
+	// are the ones rejected.
+	MaxAttachedRoutes int32 `json:"maxAttachedRoutes,omitempty"`
+
+	// MaxRulesPerRoute is the maximum number of rules of an HTTPRoute.
+	MaxRulesPerRoute int32 `json:"maxRulesPerRoute,omitempty"`
+}
+
+// Enabled returns whether a route limit is configured.
+func (c GatewayRouteLimitsConfig) Enabled() bool {
+	return c.MaxAttachedRoutes > 0 || c.MaxRulesPerRoute > 0
+}
+
+func (c *GatewayRouteLimitsConfig) validate() error {
+	var errs []error
+	if c.MaxAttachedRoutes < 0 {
+		errs = append(errs, errors.New("maxAttachedRoutes must not be negative"))
+	}
+	if c.MaxRulesPerRoute < 0 {
+		errs = append(errs, errors.New("maxRulesPerRoute must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayMaintenanceConfig controls the static response that the HTTPRoutes of
+// a paused gateway are swapped to. Gateways are paused with the
+// networking.datumapis.com/paused annotation, and HTTPProxies with their
+// spec.paused field.
+type GatewayMaintenanceConfig struct {
+	// StatusCode is the HTTP status code of the maintenance response.
+	//
+	// +default=503
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// ContentType is the content type of the maintenance response body.
+	//
+	// +default="text/plain"
+	ContentType string `json:"contentType,omitempty"`
+
+	// Body is the body of the maintenance response.
+	//
+	// +default="Service temporarily unavailable for maintenance"
+	Body string `json:"body,omitempty"`
+}
+
+func (c *GatewayMaintenanceConfig) validate() error {
+	if c.StatusCode != 0 && (c.StatusCode < 200 || c.StatusCode > 599) {
+		return fmt.Errorf("statusCode must be between 200 and 599, got %d", c.StatusCode)
+	}
+	return nil
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayDomainGCConfig controls the garbage collection of Domains created by
+// gateways.
+//
+// Gateways create a Domain for every hostname that has no matching Domain, and
+// label it with the name of the gateway. Such a Domain is deleted once it has
+// not been referenced by the hostnames of any Gateway or HTTPProxy in its
+// namespace for the retention period, unless it was ever verified.
+type GatewayDomainGCConfig struct {
+	// Disabled turns off the garbage collection of Domains created by gateways.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// RetentionPeriod is how long a Domain created by a gateway is kept after
+	// its last reference was removed, so that hostnames that are removed and
+	// added back keep their Domain and verification progress.
+	//
+	// +default="24h"
+	RetentionPeriod *metav1.Duration `json:"retentionPeriod"`
+}
+
+func (c *GatewayDomainGCConfig) validate() error {
+	return validateDuration("retentionPeriod", c.RetentionPeriod)
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayDNSFailoverConfig controls the DNS records of gateways that Karmada
+// propagates to multiple member clusters. Each member cluster is a target of
+// the DNS records of the gateway, and only the addresses of healthy targets
+// are published. Secondary targets are only published while no primary target
+// is healthy. When no target is healthy, the addresses of all targets are
+// published.
+type GatewayDNSFailoverConfig struct {
+	// Enabled programs failover DNS records for gateways bound to more than
+	// one member cluster. The ResourceBinding of the downstream gateway must
+	// reflect the status of the member gateways.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// SecondaryClusters are the member clusters that only serve the
+	// gateways propagated to them while no other member cluster is healthy.
+	SecondaryClusters []string `json:"secondaryClusters,omitempty"`
+
+	// RecheckInterval is how often the health of the targets of a gateway is
+	// checked.
+	//
+	// +default="30s"
+	RecheckInterval *metav1.Duration `json:"recheckInterval"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayDNSVerificationConfig controls the verification of the DNS records
+// of gateways. The hostnames of a gateway are looked up on each resolver, and
+// the DNSRecordsResolvable condition of the Gateway reports whether they are
+// answered with the addresses of the gateway, and how long the records took to
+// propagate.
+type GatewayDNSVerificationConfig struct {
+	// Resolvers are the public recursive resolvers ("host:port") that the
+	// hostnames of gateways are looked up on, for example "1.1.1.1:53".
+	//
+	// DNS records are not verified when empty.
+	Resolvers []string `json:"resolvers,omitempty"`
+
+	// RecheckInterval is how often the hostnames of a gateway are looked up
+	// while its DNS records are propagating.
+	//
+	// +default="30s"
+	RecheckInterval *metav1.Duration `json:"recheckInterval"`
+
+	// CacheTTL is how long a hostname that resolves to the addresses of its
+	// gateway is not looked up again.
+	//
+	// +default="10m"
+	CacheTTL *metav1.Duration `json:"cacheTTL"`
+}
+
+func (c *GatewayDNSVerificationConfig) validate() error {
+	for i, resolver := range c.Resolvers {
+		if _, _, err := net.SplitHostPort(resolver); err != nil {
+			return fmt.Errorf("resolvers[%d]: must be host:port: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayDNSRecordsConfig configures the DNS records of gateways. The
+// networking.datumapis.com/dns-record-ttl and
+// networking.datumapis.com/dns-record-policy annotations override the TTL and
+// policy for the gateways of a GatewayClass, and again for a single Gateway.
+type GatewayDNSRecordsConfig struct {
+	// TTL is the TTL, in seconds, of the DNS records of gateways.
+	//
+	// +default=300
+	TTL int64 `json:"ttl,omitempty"`
+
+	// MinTTL is the lowest TTL, in seconds, that annotations may set.
+	//
+	// +default=60
+	MinTTL int64 `json:"minTTL,omitempty"`
+
+	// MaxTTL is the highest TTL, in seconds, that annotations may set.
+	//
+	// +default=86400
+	MaxTTL int64 `json:"maxTTL,omitempty"`
+
+	// Policy selects the record type that points custom hostnames at the
+	// canonical hostname of their gateway.
+	//
+	// +default="Standard"
+	Policy DNSRecordPolicy `json:"policy,omitempty"`
+}
+
+// AllowsTTL returns whether a TTL set by an annotation is within the bounds.
+func (c *GatewayDNSRecordsConfig) AllowsTTL(ttl int64) bool {
+	return ttl > 0 && ttl >= c.MinTTL && (c.MaxTTL == 0 || ttl <= c.MaxTTL)
+}
+
+func (c *GatewayDNSRecordsConfig) validate() error {
+	if c.TTL < 0 || c.MinTTL < 0 || c.MaxTTL < 0 {
+		return fmt.Errorf("ttl, minTTL and maxTTL must not be negative")
+	}
+	if c.MaxTTL > 0 && c.MaxTTL < c.MinTTL {
+		return fmt.Errorf("maxTTL: must not be less than minTTL")
+	}
+	if c.TTL > 0 && !c.AllowsTTL(c.TTL) {
+		return fmt.Errorf("ttl: must be between minTTL and maxTTL")
+	}
+	if c.Policy != "" {
+		if err := c.Policy.Validate(); err != nil {
+			return fmt.Errorf("policy: %w", err)
+		}
+	}
+	return nil
+}
+
+// DNSRecordPolicy selects the record type of the DNS records of custom
+// hostnames.
+type DNSRecordPolicy string
+
+const (
+	// DNSRecordPolicyStandard points hostnames at their gateway with CNAME
+	// records, and apex domains with ALIAS records.
+	DNSRecordPolicyStandard DNSRecordPolicy = "Standard"
+	// DNSRecordPolicyAliasPreferred points every hostname at its gateway with
+	// ALIAS records, for DNS providers that flatten them to the addresses of
+	// the gateway.
+	DNSRecordPolicyAliasPreferred DNSRecordPolicy = "AliasPreferred"
+)
+
+// Validate returns an error when the policy is not known.
+func (p DNSRecordPolicy) Validate() error {
+	switch p {
+	case DNSRecordPolicyStandard, DNSRecordPolicyAliasPreferred:
+		return nil
+	default:
+		return fmt.Errorf("unknown policy %q", p)
+	}
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayDNSEndpointRegistryConfig configures the TXT registry records that
+// mark the A and AAAA records of gateways as owned by the operator, in the
+// format of the external-dns TXT registry. Operators sharing a zone leave the
+// records of other owners alone, and records whose owner record remains after
+// the gateway is gone are identified as stale.
+//
+// The external-dns instance that publishes the DNSEndpoints must include TXT
+// in its --managed-record-types.
+type GatewayDNSEndpointRegistryConfig struct {
+	// OwnerID is the owner recorded in the TXT registry records, matching the
+	// --txt-owner-id of external-dns.
+	//
+	// TXT registry records are not added when empty.
+	OwnerID string `json:"ownerID,omitempty"`
+
+	// Prefix is prepended to the name of TXT registry records, matching the
+	// --txt-prefix of external-dns.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// Enabled returns whether TXT registry records are added to DNSEndpoints.
+func (c *GatewayDNSEndpointRegistryConfig) Enabled() bool {
+	return c.OwnerID != ""
+}
+
+func (c *GatewayDNSEndpointRegistryConfig) validate() error {
+	// The labels of a TXT registry record are separated by commas, and a
+	// record with quotes can't be parsed by external-dns.
+	if strings.ContainsAny(c.OwnerID, `,="`) {
+		return fmt.Errorf("ownerID: must not contain ',', '=' or '\"'")
+	}
+	return nil
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayDataPlaneSize is the size of the Envoy fleet of a gateway.
+type GatewayDataPlaneSize struct {
+	// Replicas is the number of Envoy replicas. It can't be set along with
+	// Autoscaling.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources are the compute resources of each Envoy container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Autoscaling scales the number of Envoy replicas with their CPU usage.
+	Autoscaling *GatewayDataPlaneAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayDataPlaneAutoscaling bounds the number of Envoy replicas of a gateway.
+type GatewayDataPlaneAutoscaling struct {
+	// MinReplicas is the minimum number of replicas. Defaults to 1.
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the maximum number of replicas.
+	MaxReplicas int32 `json:"maxReplicas"`
+}
+
+func (s *GatewayDataPlaneSize) validate() error {
+	if s.Replicas != nil && *s.Replicas < 1 {
+		return fmt.Errorf("replicas must be at least 1")
+	}
+	if s.Autoscaling == nil {
+		return nil
+	}
+	if s.Replicas != nil {
+		return fmt.Errorf("replicas and autoscaling are mutually exclusive")
+	}
+	minReplicas := int32(1)
+	if s.Autoscaling.MinReplicas != nil {
+		minReplicas = *s.Autoscaling.MinReplicas
+	}
+	if minReplicas < 1 {
+		return fmt.Errorf("autoscaling.minReplicas must be at least 1")
+	}
+	if s.Autoscaling.MaxReplicas < minReplicas {
+		return fmt.Errorf("autoscaling.maxReplicas must be at least autoscaling.minReplicas")
+	}
+	return nil
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayAccessLoggingConfig controls how AccessLogPolicies are programmed on
+// downstream gateways.
+//
+// Downstream gateways are expected to share the Envoy fleet of their
+// GatewayClass, so the access logs of every AccessLogPolicy are programmed on
+// the EnvoyProxy of the downstream GatewayClass. Each policy only logs the
+// requests routed through the listeners and routes of its targets.
+type GatewayAccessLoggingConfig struct {
+	// EnvoyProxy is the downstream EnvoyProxy referenced by the downstream
+	// GatewayClass. The access log settings of AccessLogPolicies are appended
+	// to its own settings.
+	//
+	// Envoy Gateway only writes its default access log when an EnvoyProxy has
+	// no access log settings, so the access log of the platform must be
+	// configured explicitly on the EnvoyProxy.
+	EnvoyProxy *GatewayAccessLoggingEnvoyProxyRef `json:"envoyProxy,omitempty"`
+
+	// S3CollectorEndpoint is the "host:port" of the OTLP gRPC endpoint of the
+	// collector that writes access logs to S3-compatible buckets. The bucket,
+	// endpoint, region and prefix of each S3 sink are sent as resource
+	// attributes of the exported entries.
+	//
+	// AccessLogPolicies with S3 sinks are not programmed when empty.
+	S3CollectorEndpoint string `json:"s3CollectorEndpoint,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayAccessLoggingEnvoyProxyRef references a downstream EnvoyProxy.
+type GatewayAccessLoggingEnvoyProxyRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// Enabled returns whether AccessLogPolicies are reconciled.
+func (c *GatewayAccessLoggingConfig) Enabled() bool {
+	return c.EnvoyProxy != nil
+}
+
+func (c *GatewayAccessLoggingConfig) validate() error {
+	if c.EnvoyProxy != nil && (c.EnvoyProxy.Namespace == "" || c.EnvoyProxy.Name == "") {
+		return fmt.Errorf("envoyProxy: namespace and name are required")
+	}
+	if c.S3CollectorEndpoint != "" {
+		if _, _, err := net.SplitHostPort(c.S3CollectorEndpoint); err != nil {
+			return fmt.Errorf("s3CollectorEndpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayTLSPolicyConfig is the TLS policy programmed on downstream gateways as
+// an Envoy Gateway ClientTrafficPolicy.
+type GatewayTLSPolicyConfig struct {
+	// MinVersion is the minimum TLS version accepted from clients. Gateways may
+	// require a later version, but not an earlier one.
+	//
+	// +default="1.2"
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// CipherSuites are the cipher suites that may be negotiated with TLS 1.2
+	// clients. Gateways may select a subset of them. When empty, the Envoy
+	// defaults are used and gateways may select any cipher suite.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+
+	// ALPNProtocols are the application protocols negotiated with clients of
+	// gateways that do not select their own. When empty, the Envoy defaults
+	// are used.
+	ALPNProtocols []string `json:"alpnProtocols,omitempty"`
+}
+
+// Enabled returns whether a TLS policy is programmed on downstream gateways.
+func (c *GatewayTLSPolicyConfig) Enabled() bool {
+	return c.MinVersion != "" || len(c.CipherSuites) > 0 || len(c.ALPNProtocols) > 0
+}
+
+// +k8s:deepcopy-gen=true
+
+// GatewayCAAConfig controls the inspection of CAA records of listener
+// hostnames. When the CAA records of a hostname do not authorize any of the
+// issuer domains, the CertificateIssuanceAllowed condition of the Gateway
+// reports that certificate issuance is blocked.
+type GatewayCAAConfig struct {
+	// IssuerDomains are the CAA issuer domains of the certificate authorities
+	// used by the cluster issuers, for example "letsencrypt.org".
+	//
+	// CAA records are not inspected when empty.
+	IssuerDomains []string `json:"issuerDomains,omitempty"`
+
+	// Resolver is the recursive resolver ("host:port") used to look up CAA
+	// records. Defaults to the first nameserver of /etc/resolv.conf.
+	Resolver string `json:"resolver,omitempty"`
+
+	// CacheTTL is how long the result of a CAA lookup is reused.
+	//
+	// +default="5m"
+	CacheTTL *metav1.Duration `json:"cacheTTL"`
+}
+
+// InvalidListenerPolicy selects how gateways with invalid listeners are
+// programmed.
+type InvalidListenerPolicy string
+
+const (
+	// InvalidListenerPolicyExcludeListeners programs the valid listeners of a
+	// gateway and leaves out the invalid ones. The gateway is accepted with the
+	// ListenersNotValid reason.
+	InvalidListenerPolicyExcludeListeners InvalidListenerPolicy = "ExcludeListeners"
+	// InvalidListenerPolicyRejectGateway rejects a gateway as long as any of its
+	// listeners is invalid. The downstream gateway is left as it was last
+	// programmed, and is not created for new gateways.
+	InvalidListenerPolicyRejectGateway InvalidListenerPolicy = "RejectGateway"
+)
+
+func (p InvalidListenerPolicy) validate() error {
+	switch p {
+	case "", InvalidListenerPolicyExcludeListeners, InvalidListenerPolicyRejectGateway:
+		return nil
+	default:
+		return fmt.Errorf("unknown policy %q", p)
+	}
+}
+
+// DownstreamBackendMode selects how the EndpointSlice backends of routes are
+// programmed on the downstream cluster.
+type DownstreamBackendMode string
+
+const (
+	// DownstreamBackendModeServices programs each EndpointSlice backend as a
+	// headless Service and a copy of the EndpointSlice.
+	DownstreamBackendModeServices DownstreamBackendMode = "Services"
+	// DownstreamBackendModeBackends programs each EndpointSlice backend as an
+	// Envoy Gateway Backend, with the TLS settings of the backend attached to
+	// it directly.
+	DownstreamBackendModeBackends DownstreamBackendMode = "Backends"
+)
+
+func (m DownstreamBackendMode) validate() error {
+	switch m {
+	case "", DownstreamBackendModeServices, DownstreamBackendModeBackends:
+		return nil
+	default:
+		return fmt.Errorf("unknown mode %q", m)
+	}
+}
+
+// GatewayHTTP3Mode selects the gateways that serve HTTP/3.
+type GatewayHTTP3Mode string
+
+const (
+	// GatewayHTTP3Disabled serves HTTP/3 on no gateway.
+	GatewayHTTP3Disabled GatewayHTTP3Mode = "Disabled"
+	// GatewayHTTP3OptIn serves HTTP/3 on gateways that opt in with the
+	// networking.datumapis.com/http3 annotation, which HTTPProxies set from
+	// their protocols.http3 field.
+	GatewayHTTP3OptIn GatewayHTTP3Mode = "OptIn"
+	// GatewayHTTP3Enabled serves HTTP/3 on every gateway.
+	GatewayHTTP3Enabled GatewayHTTP3Mode = "Enabled"
+)
+
+func (m GatewayHTTP3Mode) validate() error {
+	switch m {
+	case "", GatewayHTTP3Disabled, GatewayHTTP3OptIn, GatewayHTTP3Enabled:
+		return nil
+	default:
+		return fmt.Errorf("unknown mode %q", m)
+	}
+}
+
+// ListenerShardingMode selects how gateway listeners are split across
+// downstream Gateways.
+type ListenerShardingMode string
+
+const (
+	// ListenerShardingNone programs all listeners on a single downstream
+	// Gateway.
+	ListenerShardingNone ListenerShardingMode = ""
+	// ListenerShardingProtocol programs listeners of each protocol on their own
+	// downstream Gateway.
+	ListenerShardingProtocol ListenerShardingMode = "Protocol"
+	// ListenerShardingCount programs at most MaxListenersPerGateway listeners on
+	// each downstream Gateway.
+	ListenerShardingCount ListenerShardingMode = "Count"
+)
+
+// +k8s:deepcopy-gen=true
+
+// ListenerShardingConfig controls how the listeners of an upstream Gateway are
+// split across downstream Gateways.
+//
+// The first shard keeps the name of the upstream Gateway, so gateways that fit
+// in a single shard are programmed exactly as when sharding is disabled. The
+// upstream Gateway reports a single status for all shards, and its DNS records
+// only target addresses shared by every shard. Shards are therefore expected to
+// be served by the same Envoy fleet, for example by enabling mergeGateways on
+// the downstream GatewayClass.
+//
+// Policies that target the Gateway itself only apply to the first shard.
+type ListenerShardingConfig struct {
+	// Mode selects how listeners are split. Sharding is disabled when empty.
+	Mode ListenerShardingMode `json:"mode,omitempty"`
+
+	// MaxListenersPerGateway is the maximum number of listeners on each
+	// downstream Gateway in Count mode.
+	//
+	// +default=64
+	MaxListenersPerGateway int `json:"maxListenersPerGateway,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CertificateReissuanceConfig controls automatic recovery of failed certificate
+// issuance by deleting and recreating stuck Certificates.
+type CertificateReissuanceConfig struct {
+	// RetryInterval is the minimum time to wait after a Certificate failure
+	// before deleting it and recreating a fresh one. This prevents excessive
+	// requests to the ACME provider when the underlying issue persists (e.g.
+	// DNS not pointed to the Gateway).
+	//
+	// Defaults to 5m via GetRetryInterval().
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+
+	// MaxRetries is the maximum number of times the gateway controller will
+	// fast-track re-issuance of a failed Certificate before falling back to
+	// cert-manager's built-in exponential backoff.
+	//
+	// +default=3
+	MaxRetries int `json:"maxRetries,omitempty"`
+}
+
+// HasDefaultListenerTLSSecret returns true when a shared TLS certificate
+// secret has been configured for default HTTPS listeners.
+func (c *GatewayConfig) HasDefaultListenerTLSSecret() bool {
+	return c.DefaultListenerTLSSecretName != ""
+}
+
+// ShouldDeleteErroredChallenges returns whether the operator should automatically
+// delete errored ACME challenges. Defaults to true if not explicitly set.
+func (c *GatewayConfig) ShouldDeleteErroredChallenges() bool {
+	if c.DeleteErroredChallenges == nil {
+		return true // default enabled
+	}
+	return *c.DeleteErroredChallenges
+}
+
+// IsEPPEmissionEnabled returns whether the operator should emit EnvoyPatchPolicy
+// objects. Defaults to true when not explicitly configured, preserving backward
+// compatibility. Set gateway.eppEmissionEnabled=false in the operator config to
+// disable EPP emission once the extension server is handling xDS mutation.
+func (c *GatewayConfig) IsEPPEmissionEnabled() bool {
+	if c.EPPEmissionEnabled == nil {
+		return true // default: EPP emission on
+	}
+	return *c.EPPEmissionEnabled
+}
+
+// GetRetryInterval returns the configured retry interval for certificate
+// re-issuance, defaulting to 5 minutes.
+func (c *CertificateReissuanceConfig) GetRetryInterval() time.Duration {
+	if c.RetryInterval.Duration > 0 {
+		return c.RetryInterval.Duration
+	}
+	return 5 * time.Minute
+}
+
+// GetMaxRetries returns the configured maximum number of fast-track
+// re-issuance attempts, defaulting to 3.
+func (c *CertificateReissuanceConfig) GetMaxRetries() int {
+	if c.MaxRetries > 0 {
+		return c.MaxRetries
+	}
+	return 3
+}
+
+func (c *GatewayConfig) GatewayDNSAddress(gateway *gatewayv1.Gateway) string {
+	seed := string(gateway.UID)
+	suffix := fmt.Sprintf(".%s", c.TargetDomain)
+	return words.WordsAndEntropy(suffix, seed)
+}
+
+func (c *GatewayConfig) ConnectorTunnelListenerName() string {
+	if c.ConnectorInternalListenerName == "" {
+		return "connector-tunnel"
+	}
+	return c.ConnectorInternalListenerName
+}
+
+// +k8s:deepcopy-gen=true
+
+type CorazaConfig struct {
+	// Disable TrafficProtectionPolicy programming for Coraza.
+	Disabled bool `json:"disabled"`
 
 	// Globally unique ID for a dynamic library file.
 	//
@@ -838,6 +2067,189 @@ type CorazaConfig struct {
 	// stored in Envoy routes to inject into trace span attributes. MUST return
 	// a map of string keys to values.
 	TraceRouteMetadataExtractor string `json:"traceRouteMetadataExtractor,omitempty"`
+
+	// GeoIP configures the country lookup used by Geo rulesets.
+	GeoIP GeoIPConfig `json:"geoIP,omitempty"`
+
+	// Events configures the reporting of the requests detected or blocked by
+	// TrafficProtectionPolicies into their status.
+	Events WAFEventsConfig `json:"events,omitempty"`
+
+	// CRSVersion is the OWASP Core Rule Set release embedded in the library
+	// above. TrafficProtectionPolicies pinning this crsVersion use the library.
+	CRSVersion string `json:"crsVersion,omitempty"`
+
+	// RuleBundles lists additional builds of the Coraza library embedding other
+	// releases of the OWASP Core Rule Set, which TrafficProtectionPolicies may
+	// pin with their crsVersion. Each bundle is installed as its own listener
+	// filter, so a new release can be rolled out one policy at a time.
+	RuleBundles []CorazaRuleBundle `json:"ruleBundles,omitempty"`
+
+	// DefaultCRSVersion is the crsVersion used by TrafficProtectionPolicies
+	// that don't pin one. When empty, the library above is used.
+	DefaultCRSVersion string `json:"defaultCRSVersion,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CorazaRuleBundle is a build of the Coraza library embedding a release of the
+// OWASP Core Rule Set.
+type CorazaRuleBundle struct {
+	// CRSVersion is the OWASP Core Rule Set release embedded in the library.
+	CRSVersion string `json:"crsVersion"`
+
+	// Path to the Coraza dynamic library file.
+	LibraryPath string `json:"libraryPath"`
+
+	// Globally unique ID for the dynamic library file. Defaults to the
+	// libraryID of the Coraza config suffixed with the CRS version.
+	LibraryID string `json:"libraryID,omitempty"`
+
+	// Name of the filter to use in Envoy listener configurations. Defaults to
+	// the filterName of the Coraza config suffixed with the CRS version.
+	FilterName string `json:"filterName,omitempty"`
+
+	// Name of the Coraza plugin in the library. Defaults to the pluginName of
+	// the Coraza config.
+	PluginName string `json:"pluginName,omitempty"`
+
+	// Base directives to define on route filter configs. Defaults to the
+	// routeBaseDirectives of the Coraza config.
+	RouteBaseDirectives []string `json:"routeBaseDirectives,omitempty"`
+}
+
+// Bundles returns the library configured at the top level of the Coraza
+// config, followed by the configured rule bundles, with their defaults
+// applied.
+func (c *CorazaConfig) Bundles() []CorazaRuleBundle {
+	bundles := make([]CorazaRuleBundle, 0, len(c.RuleBundles)+1)
+	bundles = append(bundles, CorazaRuleBundle{
+		CRSVersion:          c.CRSVersion,
+		LibraryPath:         c.LibraryPath,
+		LibraryID:           c.LibraryID,
+		FilterName:          c.FilterName,
+		PluginName:          c.PluginName,
+		RouteBaseDirectives: c.RouteBaseDirectives,
+	})
+	for _, bundle := range c.RuleBundles {
+		if bundle.LibraryID == "" {
+			bundle.LibraryID = c.LibraryID + "-" + bundle.CRSVersion
+		}
+		if bundle.FilterName == "" {
+			bundle.FilterName = c.FilterName + "-" + bundle.CRSVersion
+		}
+		if bundle.PluginName == "" {
+			bundle.PluginName = c.PluginName
+		}
+		if bundle.RouteBaseDirectives == nil {
+			bundle.RouteBaseDirectives = c.RouteBaseDirectives
+		}
+		bundles = append(bundles, bundle)
+	}
+	return bundles
+}
+
+// RuleBundle returns the bundle embedding the crsVersion release of the OWASP
+// Core Rule Set, or the default bundle when crsVersion is empty. It returns
+// false when no bundle embeds the release.
+func (c *CorazaConfig) RuleBundle(crsVersion string) (CorazaRuleBundle, bool) {
+	if crsVersion == "" {
+		crsVersion = c.DefaultCRSVersion
+	}
+	bundles := c.Bundles()
+	if crsVersion == "" {
+		return bundles[0], true
+	}
+	for _, bundle := range bundles {
+		if bundle.CRSVersion == crsVersion {
+			return bundle, true
+		}
+	}
+	return CorazaRuleBundle{}, false
+}
+
+func (c *CorazaConfig) validate() error {
+	var errs []error
+	versions := sets.New[string]()
+	if c.CRSVersion != "" {
+		versions.Insert(c.CRSVersion)
+	}
+	filterNames := sets.New(c.FilterName)
+	for i, bundle := range c.Bundles()[1:] {
+		switch {
+		case bundle.CRSVersion == "":
+			errs = append(errs, fmt.Errorf("ruleBundles[%d].crsVersion must not be empty", i))
+		case versions.Has(bundle.CRSVersion):
+			errs = append(errs, fmt.Errorf("ruleBundles[%d].crsVersion %q is duplicated", i, bundle.CRSVersion))
+		}
+		versions.Insert(bundle.CRSVersion)
+		if bundle.LibraryPath == "" {
+			errs = append(errs, fmt.Errorf("ruleBundles[%d].libraryPath must not be empty", i))
+		}
+		if filterNames.Has(bundle.FilterName) {
+			errs = append(errs, fmt.Errorf("ruleBundles[%d].filterName %q is duplicated", i, bundle.FilterName))
+		}
+		filterNames.Insert(bundle.FilterName)
+	}
+	if c.DefaultCRSVersion != "" && !versions.Has(c.DefaultCRSVersion) {
+		errs = append(errs, fmt.Errorf("defaultCRSVersion %q does not match the crsVersion of a rule bundle", c.DefaultCRSVersion))
+	}
+	return errors.Join(errs...)
+}
+
+const (
+	// GeoIPCountryHeader is the request header set to the ISO country code of
+	// the client address. Any value sent by the client is removed before the
+	// lookup.
+	GeoIPCountryHeader = "x-datum-geo-country"
+
+	// GeoIPFilterName is the name of the HTTP filter that looks up the country
+	// of the client address.
+	GeoIPFilterName = "envoy.filters.http.geoip"
+
+	// GeoIPHeaderStripFilterName is the name of the HTTP filter that removes
+	// the country header sent by the client, ahead of the GeoIP filter.
+	GeoIPHeaderStripFilterName = "envoy.filters.http.header_mutation/geoip"
+)
+
+// +k8s:deepcopy-gen=true
+
+type GeoIPConfig struct {
+	// CountryDatabasePath is the path to a MaxMind-compatible country database
+	// mounted in the downstream Envoy proxies. TrafficProtectionPolicies with a
+	// Geo ruleset are rejected when unset.
+	CountryDatabasePath string `json:"countryDatabasePath,omitempty"`
+}
+
+// Enabled returns whether a GeoIP database is configured.
+func (c GeoIPConfig) Enabled() bool {
+	return c.CountryDatabasePath != ""
+}
+
+// +k8s:deepcopy-gen=true
+
+type WAFEventsConfig struct {
+	// PrometheusAddress is the address of the Prometheus compatible API that
+	// WAF events are queried from. Event reporting is disabled when unset.
+	PrometheusAddress string `json:"prometheusAddress,omitempty"`
+
+	// MetricName is the counter of WAF events recorded from the downstream
+	// Coraza logs. It must be labeled with the project, namespace and policy
+	// of the TrafficProtectionPolicy, as found in the datum-gateway route
+	// metadata, the action ("blocked" or "detected"), and the rule_id.
+	//
+	// +default="datum_waf_events_total"
+	MetricName string `json:"metricName,omitempty"`
+
+	// RefreshInterval is how often the events of a policy are refreshed.
+	//
+	// +default="5m"
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// Enabled returns whether WAF event reporting is configured.
+func (c WAFEventsConfig) Enabled() bool {
+	return c.PrometheusAddress != ""
 }
 
 // +k8s:deepcopy-gen=true
@@ -1085,43 +2497,190 @@ type AuthorizationValidationOptions struct {
 
 // +k8s:deepcopy-gen=true
 
-type GatewayResourceReplicatorConfig struct {
-	// Resources lists the upstream resource types that should be mirrored into
-	// the downstream control plane.
-	Resources []ReplicatedResourceConfig `json:"resources"`
+type GatewayResourceReplicatorConfig struct {
+	// Resources lists the upstream resource types that should be mirrored into
+	// the downstream control plane.
+	Resources []ReplicatedResourceConfig `json:"resources"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type ReplicatedResourceConfig struct {
+	// Group is the API group of the upstream resource to replicate.
+	Group string `json:"group"`
+
+	// Version is the API version of the upstream resource to replicate.
+	Version string `json:"version"`
+
+	// Kind is the API kind of the upstream resource to replicate.
+	Kind string `json:"kind"`
+
+	// LabelSelector limits which upstream objects are replicated in the
+	// downstream control plane.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type HTTPProxyConfig struct {
+	// GatewayClassName specifies which GatewayClass to use when programming the
+	// underlying Gateway for an HTTPProxy.
+	// +default="datum-external-global-proxy"
+	GatewayClassName gatewayv1.ObjectName `json:"gatewayClassName"`
+
+	// MaxConcurrentReconciles is the maximum number of concurrent HTTPProxy
+	// reconciliations. Higher values allow the controller to process
+	// HTTPProxies across multiple projects in parallel.
+	//
+	// +default=5
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+
+	// BackendResolution configures resolving backend hostnames into IP
+	// addresses.
+	BackendResolution BackendResolutionConfig `json:"backendResolution,omitempty"`
+
+	// RequestPolicy configures the defaults and limits of the timeouts and
+	// retries of HTTPProxy rules.
+	RequestPolicy HTTPProxyRequestPolicyConfig `json:"requestPolicy,omitempty"`
+
+	// BackendHealth configures reporting the health of health checked
+	// backends in the status of HTTPProxies.
+	BackendHealth BackendHealthConfig `json:"backendHealth,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// HTTPProxyRequestPolicyConfig configures the timeouts and retries applied to
+// the rules of HTTPProxies, and the ranges users may configure them in.
+type HTTPProxyRequestPolicyConfig struct {
+	// DefaultRequestTimeout is the request timeout of rules that do not set
+	// one. When not set, the timeout of the downstream gateway applies.
+	DefaultRequestTimeout *metav1.Duration `json:"defaultRequestTimeout,omitempty"`
+
+	// DefaultBackendRequestTimeout is the backend request timeout of rules that
+	// do not set one. When not set, the timeout of the downstream gateway
+	// applies.
+	DefaultBackendRequestTimeout *metav1.Duration `json:"defaultBackendRequestTimeout,omitempty"`
+
+	// MaxRequestTimeout is the longest request and backend request timeout a
+	// rule may set. Rules may not disable their timeouts.
+	MaxRequestTimeout metav1.Duration `json:"maxRequestTimeout,omitempty"`
+
+	// DefaultRetryAttempts is the number of retries of rules that configure
+	// retries without setting attempts.
+	DefaultRetryAttempts int `json:"defaultRetryAttempts,omitempty"`
+
+	// MaxRetryAttempts is the largest number of retries a rule may set.
+	MaxRetryAttempts int `json:"maxRetryAttempts,omitempty"`
+
+	// DefaultRetryBackoff is the backoff between retries of rules that
+	// configure retries without setting a backoff.
+	DefaultRetryBackoff metav1.Duration `json:"defaultRetryBackoff,omitempty"`
+
+	// MaxRetryBackoff is the longest backoff between retries a rule may set.
+	MaxRetryBackoff metav1.Duration `json:"maxRetryBackoff,omitempty"`
+}
+
+func SetDefaults_HTTPProxyRequestPolicyConfig(obj *HTTPProxyRequestPolicyConfig) {
+	if obj.MaxRequestTimeout.Duration == 0 {
+		obj.MaxRequestTimeout = metav1.Duration{Duration: time.Hour}
+	}
+	if obj.DefaultRetryAttempts == 0 {
+		obj.DefaultRetryAttempts = 2
+	}
+	if obj.MaxRetryAttempts == 0 {
+		obj.MaxRetryAttempts = 5
+	}
+	if obj.DefaultRetryBackoff.Duration == 0 {
+		obj.DefaultRetryBackoff = metav1.Duration{Duration: 25 * time.Millisecond}
+	}
+	if obj.MaxRetryBackoff.Duration == 0 {
+		obj.MaxRetryBackoff = metav1.Duration{Duration: 10 * time.Second}
+	}
+}
+
+func (c *HTTPProxyRequestPolicyConfig) validate() error {
+	validTimeout := func(timeout *metav1.Duration) bool {
+		return timeout == nil || (timeout.Duration > 0 && timeout.Duration <= c.MaxRequestTimeout.Duration)
+	}
+	if !validTimeout(c.DefaultRequestTimeout) {
+		return errors.New("defaultRequestTimeout must be greater than 0s and not greater than maxRequestTimeout")
+	}
+	if !validTimeout(c.DefaultBackendRequestTimeout) {
+		return errors.New("defaultBackendRequestTimeout must be greater than 0s and not greater than maxRequestTimeout")
+	}
+	if c.DefaultRequestTimeout != nil && c.DefaultBackendRequestTimeout != nil &&
+		c.DefaultBackendRequestTimeout.Duration > c.DefaultRequestTimeout.Duration {
+		return errors.New("defaultBackendRequestTimeout must not be greater than defaultRequestTimeout")
+	}
+	if c.DefaultRetryAttempts < 0 || c.DefaultRetryAttempts > c.MaxRetryAttempts {
+		return errors.New("defaultRetryAttempts must not be negative or greater than maxRetryAttempts")
+	}
+	if c.DefaultRetryBackoff.Duration > c.MaxRetryBackoff.Duration {
+		return errors.New("defaultRetryBackoff must not be greater than maxRetryBackoff")
+	}
+	return nil
+}
+
+// +k8s:deepcopy-gen=true
+
+// BackendHealthConfig configures where the health of the endpoints of health
+// checked HTTPProxy rules is collected from.
+type BackendHealthConfig struct {
+	// PrometheusAddress is the address of the Prometheus compatible API that
+	// the Envoy cluster membership metrics of the downstream data planes are
+	// queried from. Backend health reporting is disabled when unset.
+	PrometheusAddress string `json:"prometheusAddress,omitempty"`
+
+	// RefreshInterval is how often the backend health of an HTTPProxy is
+	// refreshed. Defaults to 1m.
+	RefreshInterval metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// Enabled returns whether backend health reporting is configured.
+func (c BackendHealthConfig) Enabled() bool {
+	return c.PrometheusAddress != ""
 }
 
 // +k8s:deepcopy-gen=true
 
-type ReplicatedResourceConfig struct {
-	// Group is the API group of the upstream resource to replicate.
-	Group string `json:"group"`
+// BackendResolutionConfig configures how the operator resolves the hostnames of
+// HTTPProxy backends. When enabled, EndpointSlices for hostname backends carry
+// the resolved IP addresses instead of an FQDN, for downstream environments
+// that do not support FQDN endpoints. Addresses are re-resolved when the DNS
+// TTL expires.
+type BackendResolutionConfig struct {
+	// Enabled turns on resolution of backend hostnames.
+	Enabled bool `json:"enabled,omitempty"`
 
-	// Version is the API version of the upstream resource to replicate.
-	Version string `json:"version"`
+	// Nameserver is the address of the DNS server used to resolve backend
+	// hostnames, in host:port form. Defaults to the first nameserver in
+	// /etc/resolv.conf.
+	Nameserver string `json:"nameserver,omitempty"`
 
-	// Kind is the API kind of the upstream resource to replicate.
-	Kind string `json:"kind"`
+	// MinRefreshInterval is the shortest time resolved addresses are kept,
+	// regardless of the TTL of the DNS records.
+	MinRefreshInterval metav1.Duration `json:"minRefreshInterval,omitempty"`
 
-	// LabelSelector limits which upstream objects are replicated in the
-	// downstream control plane.
-	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// MaxRefreshInterval is the longest time resolved addresses are kept,
+	// regardless of the TTL of the DNS records.
+	MaxRefreshInterval metav1.Duration `json:"maxRefreshInterval,omitempty"`
 }
 
-// +k8s:deepcopy-gen=true
-
-type HTTPProxyConfig struct {
-	// GatewayClassName specifies which GatewayClass to use when programming the
-	// underlying Gateway for an HTTPProxy.
-	// +default="datum-external-global-proxy"
-	GatewayClassName gatewayv1.ObjectName `json:"gatewayClassName"`
+func SetDefaults_BackendResolutionConfig(obj *BackendResolutionConfig) {
+	if obj.MinRefreshInterval.Duration == 0 {
+		obj.MinRefreshInterval = metav1.Duration{Duration: 30 * time.Second}
+	}
+	if obj.MaxRefreshInterval.Duration == 0 {
+		obj.MaxRefreshInterval = metav1.Duration{Duration: time.Hour}
+	}
+}
 
-	// MaxConcurrentReconciles is the maximum number of concurrent HTTPProxy
-	// reconciliations. Higher values allow the controller to process
-	// HTTPProxies across multiple projects in parallel.
-	//
-	// +default=5
-	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+func (c *BackendResolutionConfig) validate() error {
+	if c.MinRefreshInterval.Duration > c.MaxRefreshInterval.Duration {
+		return errors.New("minRefreshInterval must not be greater than maxRefreshInterval")
+	}
+	return nil
 }
 
 // +k8s:deepcopy-gen=true
@@ -1194,10 +2753,43 @@ func (c *GatewayConfig) IPv6Enabled() bool {
 	return slices.Contains(c.IPFamilies, networkingv1alpha.IPv6Protocol)
 }
 
+// StaticAddressesEnabled returns true when gateways may request static
+// addresses.
+func (c *GatewayConfig) StaticAddressesEnabled() bool {
+	return len(c.StaticAddressSubnetClasses) > 0
+}
+
+// IPv6Only returns true when gateways are only given IPv6 addresses.
+func (c *GatewayConfig) IPv6Only() bool {
+	return c.IPv6Enabled() && !c.IPv4Enabled()
+}
+
+func validateGatewayIPFamilies(families []networkingv1alpha.IPFamily) error {
+	// A nil list is replaced with both families when defaulting.
+	if families != nil && len(families) == 0 {
+		return errors.New("at least one IP family must be enabled")
+	}
+	for i, family := range families {
+		if family != networkingv1alpha.IPv4Protocol && family != networkingv1alpha.IPv6Protocol {
+			return fmt.Errorf("unsupported IP family %q", family)
+		}
+		if slices.Contains(families[:i], family) {
+			return fmt.Errorf("duplicate IP family %q", family)
+		}
+	}
+	return nil
+}
+
+// DiscoveryModeKubeconfigDirectory discovers one cluster per kubeconfig file
+// in DiscoveryConfig.KubeconfigDirectory, for running the operator locally
+// against several kind or envtest clusters.
+const DiscoveryModeKubeconfigDirectory multiclusterproviders.Provider = "kubeconfig-directory"
+
 // +k8s:deepcopy-gen=true
 
 type DiscoveryConfig struct {
-	// Mode is the mode that the operator should use to discover clusters.
+	// Mode is the mode that the operator should use to discover clusters:
+	// "single", "milo" or "kubeconfig-directory".
 	//
 	// Defaults to "single"
 	Mode multiclusterproviders.Provider `json:"mode"`
@@ -1215,6 +2807,22 @@ type DiscoveryConfig struct {
 	// template when connecting to project control planes. When not provided,
 	// the operator will use the in-cluster config.
 	ProjectKubeconfigPath string `json:"projectKubeconfigPath"`
+
+	// KubeconfigDirectory is the directory of kubeconfig files used in
+	// kubeconfig-directory mode. Each file is a cluster named after the file
+	// without its extension. Required in kubeconfig-directory mode.
+	KubeconfigDirectory string `json:"kubeconfigDirectory,omitempty"`
+
+	// ProjectFilter restricts the projects engaged in milo mode to a subset, so
+	// that projects can be sharded across several operator deployments. All
+	// projects are engaged when not set.
+	ProjectFilter *ProjectFilterConfig `json:"projectFilter,omitempty"`
+
+	// Sharding splits the discovered clusters across a fixed number of
+	// replicas of the operator. Each replica engages the clusters that
+	// consistent hashing of the cluster name assigns to its shard. All clusters
+	// are engaged when not set.
+	Sharding *ClusterShardingConfig `json:"sharding,omitempty"`
 }
 
 func SetDefaults_DiscoveryConfig(obj *DiscoveryConfig) {
@@ -1223,6 +2831,166 @@ func SetDefaults_DiscoveryConfig(obj *DiscoveryConfig) {
 	}
 }
 
+// +k8s:deepcopy-gen=true
+
+// ClusterShardingConfig assigns the discovered clusters to shards.
+type ClusterShardingConfig struct {
+	// Shards is the number of shards, one per replica of the operator. Changes
+	// are applied without a restart, moving only the clusters that consistent
+	// hashing assigns to another shard.
+	Shards int32 `json:"shards"`
+
+	// Index is the shard of this replica, from 0 to shards-1. When not set, it
+	// is the ordinal at the end of the hostname, as given to the pods of a
+	// StatefulSet.
+	Index *int32 `json:"index,omitempty"`
+}
+
+func (c *ClusterShardingConfig) validate() error {
+	var errs []error
+	if c.Shards < 1 {
+		errs = append(errs, errors.New("shards must be at least 1"))
+	}
+	if c.Index != nil && (*c.Index < 0 || *c.Index >= c.Shards) {
+		errs = append(errs, fmt.Errorf("index %d must be between 0 and shards-1", *c.Index))
+	}
+	return errors.Join(errs...)
+}
+
+// ShardIndex returns the shard of this replica.
+func (c *ClusterShardingConfig) ShardIndex() (int32, error) {
+	if c.Index != nil {
+		return *c.Index, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return 0, fmt.Errorf("failed getting hostname: %w", err)
+	}
+	return shardIndexFromHostname(hostname)
+}
+
+// shardIndexFromHostname returns the ordinal at the end of the hostname of a
+// StatefulSet pod, such as 2 for "network-services-operator-2".
+func shardIndexFromHostname(hostname string) (int32, error) {
+	i := strings.LastIndex(hostname, "-")
+	index, err := strconv.ParseInt(hostname[i+1:], 10, 32)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("hostname %q does not end with a shard ordinal, set discovery.sharding.index", hostname)
+	}
+	return int32(index), nil
+}
+
+// +k8s:deepcopy-gen=true
+
+// ProjectFilterConfig selects the projects that an operator instance engages.
+// A project is engaged when it matches every include rule that is set and none
+// of the exclude rules.
+type ProjectFilterConfig struct {
+	// Selector engages only the projects whose labels match.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// ExcludeSelector skips the projects whose labels match, even if they match
+	// Selector.
+	ExcludeSelector *metav1.LabelSelector `json:"excludeSelector,omitempty"`
+
+	// Annotations engages only the projects that have all of these annotations.
+	// An empty value matches any value of the annotation.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ExcludeAnnotations skips the projects that have any of these annotations.
+	// An empty value matches any value of the annotation.
+	ExcludeAnnotations map[string]string `json:"excludeAnnotations,omitempty"`
+
+	// Phases engages only the projects whose status.phase is one of these
+	// phases, so that projects are not engaged before they are ready.
+	Phases []string `json:"phases,omitempty"`
+
+	// RecheckInterval is how often projects are evaluated again, engaging the
+	// projects that started matching and disengaging the projects that stopped
+	// matching.
+	//
+	// Defaults to 1 minute.
+	RecheckInterval metav1.Duration `json:"recheckInterval,omitempty"`
+}
+
+func SetDefaults_ProjectFilterConfig(obj *ProjectFilterConfig) {
+	if obj.RecheckInterval.Duration == 0 {
+		obj.RecheckInterval = metav1.Duration{Duration: time.Minute}
+	}
+}
+
+func (c *ProjectFilterConfig) validate() error {
+	var errs []error
+	if c.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(c.Selector); err != nil {
+			errs = append(errs, fmt.Errorf("selector: %w", err))
+		}
+	}
+	if c.ExcludeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(c.ExcludeSelector); err != nil {
+			errs = append(errs, fmt.Errorf("excludeSelector: %w", err))
+		}
+	}
+	errs = append(errs,
+		validateAnnotationKeys("annotations", c.Annotations),
+		validateAnnotationKeys("excludeAnnotations", c.ExcludeAnnotations),
+	)
+	for _, phase := range c.Phases {
+		if phase == "" {
+			errs = append(errs, errors.New("phases must not be empty"))
+			break
+		}
+	}
+	if c.RecheckInterval.Duration < 0 {
+		errs = append(errs, errors.New("recheckInterval must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// validate checks the discovery mode, and that the settings of other modes are
+// not set, where they would be silently ignored.
+func (c *DiscoveryConfig) validate() error {
+	var errs []error
+	switch c.Mode {
+	case "", multiclusterproviders.ProviderSingle, multiclusterproviders.ProviderMilo, DiscoveryModeKubeconfigDirectory:
+	default:
+		return fmt.Errorf("unsupported mode %q, must be one of %q, %q or %q", c.Mode,
+			multiclusterproviders.ProviderSingle, multiclusterproviders.ProviderMilo, DiscoveryModeKubeconfigDirectory)
+	}
+
+	if c.Mode != multiclusterproviders.ProviderMilo {
+		if c.InternalServiceDiscovery {
+			errs = append(errs, errors.New("internalServiceDiscovery is only supported in milo mode"))
+		}
+		if c.DiscoveryKubeconfigPath != "" {
+			errs = append(errs, errors.New("discoveryKubeconfigPath is only supported in milo mode"))
+		}
+		if c.ProjectKubeconfigPath != "" {
+			errs = append(errs, errors.New("projectKubeconfigPath is only supported in milo mode"))
+		}
+		if c.ProjectFilter != nil {
+			errs = append(errs, errors.New("projectFilter is only supported in milo mode"))
+		}
+	} else if c.ProjectFilter != nil {
+		errs = append(errs, prefixErrors("projectFilter", c.ProjectFilter.validate())...)
+	}
+	if c.Sharding != nil {
+		if c.Mode == "" || c.Mode == multiclusterproviders.ProviderSingle {
+			errs = append(errs, errors.New("sharding is not supported in single mode"))
+		} else {
+			errs = append(errs, prefixErrors("sharding", c.Sharding.validate())...)
+		}
+	}
+	if c.Mode == DiscoveryModeKubeconfigDirectory {
+		if c.KubeconfigDirectory == "" {
+			errs = append(errs, errors.New("kubeconfigDirectory is required in kubeconfig-directory mode"))
+		}
+	} else if c.KubeconfigDirectory != "" {
+		errs = append(errs, errors.New("kubeconfigDirectory is only supported in kubeconfig-directory mode"))
+	}
+	return errors.Join(errs...)
+}
+
 func (c *DiscoveryConfig) DiscoveryRestConfig() (*rest.Config, error) {
 	if c.DiscoveryKubeconfigPath == "" {
 		return ctrl.GetConfig()
@@ -1239,16 +3007,445 @@ func (c *DiscoveryConfig) ProjectRestConfig() (*rest.Config, error) {
 	return clientcmd.BuildConfigFromFlags("", c.ProjectKubeconfigPath)
 }
 
+// CryptoPolicyMode selects the TLS posture of the operator's servers and
+// outbound clients.
+type CryptoPolicyMode string
+
+const (
+	// CryptoPolicyDefault uses the Go cipher suite defaults, with TLS 1.2 as
+	// the minimum version.
+	CryptoPolicyDefault CryptoPolicyMode = ""
+
+	// CryptoPolicyFIPS restricts TLS to version 1.2 and above, and to the
+	// cipher suites and key exchanges approved for FIPS 140-3.
+	CryptoPolicyFIPS CryptoPolicyMode = "FIPS"
+)
+
+// CryptoPolicyComponent identifies a server or client that the crypto policy
+// applies to.
+type CryptoPolicyComponent string
+
+const (
+	CryptoPolicyWebhookServer            CryptoPolicyComponent = "webhookServer"
+	CryptoPolicyMetricsServer            CryptoPolicyComponent = "metricsServer"
+	CryptoPolicyRegistryDataClient       CryptoPolicyComponent = "registryDataClient"
+	CryptoPolicyDomainVerificationClient CryptoPolicyComponent = "domainVerificationClient"
+)
+
+// CryptoPolicyComponents lists the components the crypto policy applies to.
+var CryptoPolicyComponents = []CryptoPolicyComponent{
+	CryptoPolicyWebhookServer,
+	CryptoPolicyMetricsServer,
+	CryptoPolicyRegistryDataClient,
+	CryptoPolicyDomainVerificationClient,
+}
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+var fipsCurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+// +k8s:deepcopy-gen=true
+
+// CryptoPolicyConfig controls the TLS settings of the webhook and metrics
+// servers, and of the outbound RDAP and HTTP domain verification clients.
+// WHOIS lookups are plain text and are not affected.
+type CryptoPolicyConfig struct {
+	// Mode selects the base TLS settings. Defaults to the Go defaults.
+	Mode CryptoPolicyMode `json:"mode,omitempty"`
+
+	// RequireFIPSModule prevents the operator from starting unless the Go
+	// FIPS 140-3 module is enabled, either by building with GOFIPS140 or by
+	// running with GODEBUG=fips140=on.
+	RequireFIPSModule bool `json:"requireFIPSModule,omitempty"`
+
+	// TLS overrides the base TLS settings for all components.
+	TLS TLSSettings `json:"tls,omitempty"`
+
+	// WebhookServer overrides the TLS settings of the webhook server.
+	WebhookServer *TLSSettings `json:"webhookServer,omitempty"`
+
+	// MetricsServer overrides the TLS settings of the metrics server.
+	MetricsServer *TLSSettings `json:"metricsServer,omitempty"`
+
+	// RegistryDataClient overrides the TLS settings of the RDAP client used
+	// for domain registration lookups.
+	RegistryDataClient *TLSSettings `json:"registryDataClient,omitempty"`
+
+	// DomainVerificationClient overrides the TLS settings of the client used
+	// to fetch HTTP domain verification tokens.
+	DomainVerificationClient *TLSSettings `json:"domainVerificationClient,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type TLSSettings struct {
+	// MinVersion is the minimum TLS version, either "1.2" or "1.3".
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// CipherSuites are the IANA names of the cipher suites allowed for TLS 1.2.
+	// The TLS 1.3 cipher suites are not configurable.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// EffectiveTLSSettings are the TLS settings a component runs with.
+type EffectiveTLSSettings struct {
+	MinVersion uint16
+	// CipherSuites is nil when the Go defaults are used.
+	CipherSuites []uint16
+	// CurvePreferences is nil when the Go defaults are used.
+	CurvePreferences []tls.CurveID
+}
+
+// Apply sets the settings on a TLS config.
+func (s EffectiveTLSSettings) Apply(cfg *tls.Config) {
+	cfg.MinVersion = s.MinVersion
+	cfg.CipherSuites = s.CipherSuites
+	cfg.CurvePreferences = s.CurvePreferences
+}
+
+func (c *CryptoPolicyConfig) override(component CryptoPolicyComponent) *TLSSettings {
+	switch component {
+	case CryptoPolicyWebhookServer:
+		return c.WebhookServer
+	case CryptoPolicyMetricsServer:
+		return c.MetricsServer
+	case CryptoPolicyRegistryDataClient:
+		return c.RegistryDataClient
+	case CryptoPolicyDomainVerificationClient:
+		return c.DomainVerificationClient
+	}
+	return nil
+}
+
+// Effective returns the TLS settings of a component. The component override is
+// applied on top of the global TLS settings, which are applied on top of the
+// mode's base settings. Invalid settings are ignored, as they're rejected by
+// Validate.
+func (c *CryptoPolicyConfig) Effective(component CryptoPolicyComponent) EffectiveTLSSettings {
+	settings := EffectiveTLSSettings{MinVersion: tls.VersionTLS12}
+	if c.Mode == CryptoPolicyFIPS {
+		settings.CipherSuites = fipsCipherSuites
+		settings.CurvePreferences = fipsCurvePreferences
+	}
+
+	for _, s := range []*TLSSettings{&c.TLS, c.override(component)} {
+		if s == nil {
+			continue
+		}
+		if version, ok := tlsVersions[s.MinVersion]; ok {
+			settings.MinVersion = version
+		}
+		if len(s.CipherSuites) > 0 {
+			settings.CipherSuites = nil
+			for _, name := range s.CipherSuites {
+				if id, ok := cipherSuiteID(name); ok {
+					settings.CipherSuites = append(settings.CipherSuites, id)
+				}
+			}
+		}
+	}
+	return settings
+}
+
+// TLSOption returns a TLS config option that applies the settings of a
+// component.
+func (c *CryptoPolicyConfig) TLSOption(component CryptoPolicyComponent) func(*tls.Config) {
+	settings := c.Effective(component)
+	return settings.Apply
+}
+
+// HTTPClient returns an HTTP client for a component that uses its TLS settings.
+func (c *CryptoPolicyConfig) HTTPClient(component CryptoPolicyComponent, timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{}
+	c.Effective(component).Apply(transport.TLSClientConfig)
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+func cipherSuiteID(name string) (uint16, bool) {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite.ID, true
+		}
+	}
+	return 0, false
+}
+
+func (c *CryptoPolicyConfig) validate() error {
+	switch c.Mode {
+	case CryptoPolicyDefault, CryptoPolicyFIPS:
+	default:
+		return fmt.Errorf("unknown mode %q", c.Mode)
+	}
+
+	var errs []error
+	validateSettings := func(path string, s *TLSSettings) {
+		if s == nil {
+			return
+		}
+		if _, ok := tlsVersions[s.MinVersion]; s.MinVersion != "" && !ok {
+			errs = append(errs, fmt.Errorf("%s.minVersion: unsupported TLS version %q, must be 1.2 or 1.3", path, s.MinVersion))
+		}
+		for _, name := range s.CipherSuites {
+			id, ok := cipherSuiteID(name)
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s.cipherSuites: unknown or insecure cipher suite %q", path, name))
+			} else if c.Mode == CryptoPolicyFIPS && !slices.Contains(fipsCipherSuites, id) {
+				errs = append(errs, fmt.Errorf("%s.cipherSuites: cipher suite %q is not FIPS-approved", path, name))
+			}
+		}
+	}
+
+	validateSettings("tls", &c.TLS)
+	for _, component := range CryptoPolicyComponents {
+		validateSettings(string(component), c.override(component))
+	}
+	return errors.Join(errs...)
+}
+
+// +k8s:deepcopy-gen=true
+
+// IPAMConfig configures the allocation of subnet prefixes from IPPools.
+type IPAMConfig struct {
+	// Pools are the IPPools of the platform. A pool is created in the
+	// namespace of a subnet when the subnet is allocated and the namespace has
+	// no IPPool of the subnet class and IP family of the subnet. Existing
+	// pools are not updated when the configuration changes.
+	Pools []IPAMPoolConfig `json:"pools,omitempty"`
+
+	// ReclaimGracePeriod is how long a prefix released by a subnet is kept
+	// from other subnets. A subnet of the same name that is allocated within
+	// the grace period, such as the subnet of a recreated SubnetClaim, is
+	// allocated the prefix again. Defaults to 10 minutes.
+	ReclaimGracePeriod *metav1.Duration `json:"reclaimGracePeriod,omitempty"`
+}
+
+func SetDefaults_IPAMConfig(obj *IPAMConfig) {
+	if obj.ReclaimGracePeriod == nil {
+		obj.ReclaimGracePeriod = &metav1.Duration{Duration: 10 * time.Minute}
+	}
+}
+
+// +k8s:deepcopy-gen=true
+
+// IPAMPoolConfig is an IPPool of the platform.
+type IPAMPoolConfig struct {
+	// SubnetClass is the class of subnets allocated from the pool.
+	SubnetClass string `json:"subnetClass"`
+
+	// IPFamily is the IP family of the pool.
+	IPFamily networkingv1alpha.IPFamily `json:"ipFamily"`
+
+	// CIDRs are the CIDRs that subnets are allocated from.
+	CIDRs []string `json:"cidrs"`
+
+	// BlockPrefixLength is the prefix length of the smallest subnet allocated
+	// from the pool.
+	BlockPrefixLength int32 `json:"blockPrefixLength"`
+
+	// DefaultPrefixLength is the prefix length of subnets that don't request
+	// one. Defaults to BlockPrefixLength.
+	DefaultPrefixLength *int32 `json:"defaultPrefixLength,omitempty"`
+}
+
+// Pool returns the pool of a subnet class and IP family, or nil if there is
+// none.
+func (c *IPAMConfig) Pool(subnetClass string, ipFamily networkingv1alpha.IPFamily) *IPAMPoolConfig {
+	for i := range c.Pools {
+		if c.Pools[i].SubnetClass == subnetClass && c.Pools[i].IPFamily == ipFamily {
+			return &c.Pools[i]
+		}
+	}
+	return nil
+}
+
+func (c *IPAMConfig) validate() error {
+	if c.ReclaimGracePeriod != nil && c.ReclaimGracePeriod.Duration < 0 {
+		return fmt.Errorf("reclaimGracePeriod must not be negative")
+	}
+	for i, pool := range c.Pools {
+		if err := pool.validate(); err != nil {
+			return fmt.Errorf("pools[%d]: %w", i, err)
+		}
+		if c.Pool(pool.SubnetClass, pool.IPFamily) != &c.Pools[i] {
+			return fmt.Errorf("pools[%d]: duplicate pool for subnet class %q and IP family %s", i, pool.SubnetClass, pool.IPFamily)
+		}
+	}
+	return nil
+}
+
+func (c *IPAMPoolConfig) validate() error {
+	if c.SubnetClass == "" {
+		return fmt.Errorf("subnetClass is required")
+	}
+	if c.IPFamily != networkingv1alpha.IPv4Protocol && c.IPFamily != networkingv1alpha.IPv6Protocol {
+		return fmt.Errorf("unknown ipFamily %q", c.IPFamily)
+	}
+	if len(c.CIDRs) == 0 {
+		return fmt.Errorf("cidrs is required")
+	}
+	for _, cidr := range c.CIDRs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return fmt.Errorf("cidrs: %w", err)
+		}
+		if prefix.Addr().Is4() != (c.IPFamily == networkingv1alpha.IPv4Protocol) {
+			return fmt.Errorf("cidrs: %s is not an %s CIDR", cidr, c.IPFamily)
+		}
+		if _, err := ipam.NewPool(prefix, int(c.BlockPrefixLength), nil); err != nil {
+			return fmt.Errorf("cidrs: %w", err)
+		}
+	}
+	if c.DefaultPrefixLength != nil && *c.DefaultPrefixLength > c.BlockPrefixLength {
+		return fmt.Errorf("defaultPrefixLength must not be longer than blockPrefixLength")
+	}
+	return nil
+}
+
 // Validate returns a non-nil error if the loaded configuration violates a
 // known invariant. New cross-field rules should land here as the
-// codebase grows; today only Connector.Iroh is checked.
+// codebase grows.
+//
+// All violations are reported, joined with errors.Join, each prefixed with
+// the path of the offending field.
 func (c *NetworkServicesOperator) Validate() error {
-	if err := c.Connector.Iroh.validate(); err != nil {
-		return fmt.Errorf("connector.iroh: %w", err)
+	var errs []error
+	check := func(field string, err error) {
+		errs = append(errs, prefixErrors(field, err)...)
+	}
+
+	check("featureGates", features.Validate(c.FeatureGates))
+	check("discovery", c.Discovery.validate())
+	check("metricsServer", c.MetricsServer.validate())
+	check("webhookServer", c.WebhookServer.validate())
+	check("leaderElection", c.LeaderElection.validate())
+	check("downstreamCircuitBreaker", c.DownstreamCircuitBreaker.validate())
+	check("upstreamOutage", c.UpstreamOutage.validate())
+	check("downstreamResourceManagement", c.DownstreamResourceManagement.validate())
+	check("connector.iroh", c.Connector.Iroh.validate())
+	check("gateway.ipFamilies", validateGatewayIPFamilies(c.Gateway.IPFamilies))
+	check("gateway.listenerSharding", c.Gateway.ListenerSharding.validate())
+	check("gateway.invalidListenerPolicy", c.Gateway.InvalidListenerPolicy.validate())
+	check("gateway.downstreamBackendMode", c.Gateway.DownstreamBackendMode.validate())
+	check("gateway.http3", c.Gateway.HTTP3.validate())
+	check("gateway.accessLogging", c.Gateway.AccessLogging.validate())
+	check("gateway.dnsEndpointRegistry", c.Gateway.DNSEndpointRegistry.validate())
+	check("gateway.dnsRecords", c.Gateway.DNSRecords.validate())
+	check("gateway.dnsVerification", c.Gateway.DNSVerification.validate())
+	check("gateway.domainGC", c.Gateway.DomainGC.validate())
+	check("gateway.maintenance", c.Gateway.Maintenance.validate())
+	check("gateway.routeLimits", c.Gateway.RouteLimits.validate())
+	check("gateway.payloadPolicy", c.Gateway.PayloadPolicy.validate())
+	check("gateway.coraza", c.Gateway.Coraza.validate())
+	check("gateway.clusterIssuerMap", validateClusterIssuerMap(c.Gateway.ClusterIssuerMap))
+	for _, name := range slices.Sorted(maps.Keys(c.Gateway.DataPlaneSizes)) {
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			check("gateway.dataPlaneSizes", fmt.Errorf("invalid size name %q: %v", name, errs))
+			continue
+		}
+		size := c.Gateway.DataPlaneSizes[name]
+		check("gateway.dataPlaneSizes."+name, size.validate())
+	}
+	if c.Gateway.SharedDNSZoneSelector != nil {
+		_, err := metav1.LabelSelectorAsSelector(c.Gateway.SharedDNSZoneSelector)
+		check("gateway.sharedDNSZoneSelector", err)
+	}
+	check("ipam", c.IPAM.validate())
+	check("httpProxy.backendResolution", c.HTTPProxy.BackendResolution.validate())
+	check("httpProxy.requestPolicy", c.HTTPProxy.RequestPolicy.validate())
+	check("cryptoPolicy", c.CryptoPolicy.validate())
+	check("domainVerification", c.DomainVerification.validate())
+	check("domainRegistration", c.DomainRegistration.validate())
+	check("domainRegistration.registryData.rateLimits", c.DomainRegistration.RegistryData.RateLimits.validate())
+	for _, name := range slices.Sorted(maps.Keys(c.Controllers)) {
+		controller := c.ControllerConfig(name)
+		if controller.MaxConcurrentReconciles < 0 {
+			errs = append(errs, fmt.Errorf("controllers.%s.maxConcurrentReconciles must not be negative", name))
+		}
+		if controller.RateLimiter != nil {
+			check("controllers."+name+".rateLimiter", controller.RateLimiter.validate())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// prefixErrors prefixes err, and each of the errors it joins, with the path
+// of the field they apply to.
+func prefixErrors(field string, err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, err := range joined.Unwrap() {
+			errs = append(errs, prefixErrors(field, err)...)
+		}
+		return errs
+	}
+	return []error{fmt.Errorf("%s: %w", field, err)}
+}
+
+// validateDuration returns an error if a configured duration is negative.
+func validateDuration(field string, d *metav1.Duration) error {
+	if d != nil && d.Duration < 0 {
+		return fmt.Errorf("%s must not be negative", field)
 	}
 	return nil
 }
 
+// validateAnnotationKeys returns an error if a key of annotations is not a
+// valid annotation key.
+func validateAnnotationKeys(field string, annotations map[string]string) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("%s: invalid annotation key %q: %s", field, key, strings.Join(msgs, "; ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateClusterIssuerMap returns an error if a mapping of the cluster issuer
+// map doesn't name a ClusterIssuer.
+func validateClusterIssuerMap(issuers map[string]string) error {
+	var errs []error
+	for _, external := range slices.Sorted(maps.Keys(issuers)) {
+		internal := issuers[external]
+		if external == "" || internal == "" {
+			errs = append(errs, errors.New("issuer names must not be empty"))
+			continue
+		}
+		if msgs := validation.IsDNS1123Subdomain(internal); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("%s: invalid ClusterIssuer name %q: %s", external, internal, strings.Join(msgs, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *ListenerShardingConfig) validate() error {
+	switch c.Mode {
+	case ListenerShardingNone, ListenerShardingProtocol:
+		return nil
+	case ListenerShardingCount:
+		if c.MaxListenersPerGateway < 1 {
+			return errors.New("maxListenersPerGateway must be at least 1")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown mode %q", c.Mode)
+	}
+}
+
 func (c *IrohConnectorConfig) validate() error {
 	if !c.DNSEnabled {
 		return nil
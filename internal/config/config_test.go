@@ -1,8 +1,19 @@
 package config
 
 import (
+	"crypto/tls"
+	"net/http"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 )
 
 func TestNetworkServicesOperator_Validate_IrohDisabled(t *testing.T) {
@@ -110,3 +121,1072 @@ func TestSetObjectDefaults_IrohConnectorConfig(t *testing.T) {
 		t.Error("DNSEnabled should default to false")
 	}
 }
+
+func TestNetworkServicesOperator_Validate_FeatureGates(t *testing.T) {
+	cfg := &NetworkServicesOperator{
+		FeatureGates: map[string]bool{"DoesNotExist": true},
+	}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), `featureGates: unknown feature gate "DoesNotExist"`) {
+		t.Fatalf("expected unknown feature gate error, got %v", err)
+	}
+}
+
+func TestNetworkServicesOperator_Validate_ListenerSharding(t *testing.T) {
+	cases := map[string]struct {
+		sharding ListenerShardingConfig
+		wantErr  string
+	}{
+		"disabled": {},
+		"protocol": {sharding: ListenerShardingConfig{Mode: ListenerShardingProtocol}},
+		"count":    {sharding: ListenerShardingConfig{Mode: ListenerShardingCount, MaxListenersPerGateway: 10}},
+		"count without limit": {
+			sharding: ListenerShardingConfig{Mode: ListenerShardingCount},
+			wantErr:  "gateway.listenerSharding: maxListenersPerGateway must be at least 1",
+		},
+		"unknown mode": {
+			sharding: ListenerShardingConfig{Mode: "Hostname"},
+			wantErr:  `gateway.listenerSharding: unknown mode "Hostname"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{ListenerSharding: tc.sharding}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_GatewayIPFamilies(t *testing.T) {
+	cases := map[string]struct {
+		families []networkingv1alpha.IPFamily
+		wantErr  string
+	}{
+		"unset":     {},
+		"dual":      {families: []networkingv1alpha.IPFamily{networkingv1alpha.IPv4Protocol, networkingv1alpha.IPv6Protocol}},
+		"ipv6 only": {families: []networkingv1alpha.IPFamily{networkingv1alpha.IPv6Protocol}},
+		"empty": {
+			families: []networkingv1alpha.IPFamily{},
+			wantErr:  "gateway.ipFamilies: at least one IP family must be enabled",
+		},
+		"unknown family": {
+			families: []networkingv1alpha.IPFamily{"IPv5"},
+			wantErr:  `gateway.ipFamilies: unsupported IP family "IPv5"`,
+		},
+		"duplicate family": {
+			families: []networkingv1alpha.IPFamily{networkingv1alpha.IPv6Protocol, networkingv1alpha.IPv6Protocol},
+			wantErr:  `gateway.ipFamilies: duplicate IP family "IPv6"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{IPFamilies: tc.families}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_DownstreamBackendMode(t *testing.T) {
+	cases := map[string]struct {
+		mode    DownstreamBackendMode
+		wantErr string
+	}{
+		"unset":    {},
+		"services": {mode: DownstreamBackendModeServices},
+		"backends": {mode: DownstreamBackendModeBackends},
+		"unknown mode": {
+			mode:    "ServiceEntries",
+			wantErr: `gateway.downstreamBackendMode: unknown mode "ServiceEntries"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{DownstreamBackendMode: tc.mode}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_HTTP3(t *testing.T) {
+	cases := map[string]struct {
+		mode    GatewayHTTP3Mode
+		wantErr string
+	}{
+		"unset":    {},
+		"disabled": {mode: GatewayHTTP3Disabled},
+		"opt in":   {mode: GatewayHTTP3OptIn},
+		"enabled":  {mode: GatewayHTTP3Enabled},
+		"unknown mode": {
+			mode:    "Always",
+			wantErr: `gateway.http3: unknown mode "Always"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{HTTP3: tc.mode}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_RouteLimits(t *testing.T) {
+	cases := map[string]struct {
+		limits  GatewayRouteLimitsConfig
+		wantErr string
+	}{
+		"unset":   {},
+		"enabled": {limits: GatewayRouteLimitsConfig{MaxAttachedRoutes: 100, MaxRulesPerRoute: 16}},
+		"negative attached routes": {
+			limits:  GatewayRouteLimitsConfig{MaxAttachedRoutes: -1},
+			wantErr: "gateway.routeLimits: maxAttachedRoutes must not be negative",
+		},
+		"negative rules per route": {
+			limits:  GatewayRouteLimitsConfig{MaxRulesPerRoute: -1},
+			wantErr: "gateway.routeLimits: maxRulesPerRoute must not be negative",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{RouteLimits: tc.limits}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_PayloadPolicy(t *testing.T) {
+	cases := map[string]struct {
+		payloadPolicy GatewayPayloadPolicyConfig
+		wantErr       string
+	}{
+		"unset": {},
+		"configured": {payloadPolicy: GatewayPayloadPolicyConfig{
+			DefaultCompressors:        []networkingv1alpha.PayloadCompressor{networkingv1alpha.PayloadCompressorZstd, networkingv1alpha.PayloadCompressorGzip},
+			DefaultCompressionMinSize: resource.MustParse("512"),
+			MaxRequestBodyLimit:       resource.MustParse("1Mi"),
+		}},
+		"unsupported compressor": {
+			payloadPolicy: GatewayPayloadPolicyConfig{DefaultCompressors: []networkingv1alpha.PayloadCompressor{"Deflate"}},
+			wantErr:       `gateway.payloadPolicy: defaultCompressors: unsupported compressor "Deflate"`,
+		},
+		"duplicate compressor": {
+			payloadPolicy: GatewayPayloadPolicyConfig{DefaultCompressors: []networkingv1alpha.PayloadCompressor{networkingv1alpha.PayloadCompressorGzip, networkingv1alpha.PayloadCompressorGzip}},
+			wantErr:       `gateway.payloadPolicy: defaultCompressors: duplicate compressor "Gzip"`,
+		},
+		"small compression min size": {
+			payloadPolicy: GatewayPayloadPolicyConfig{DefaultCompressionMinSize: resource.MustParse("10")},
+			wantErr:       "gateway.payloadPolicy: defaultCompressionMinSize must be at least 30",
+		},
+		"negative max request body limit": {
+			payloadPolicy: GatewayPayloadPolicyConfig{MaxRequestBodyLimit: resource.MustParse("-1")},
+			wantErr:       "gateway.payloadPolicy: maxRequestBodyLimit must not be negative",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{PayloadPolicy: tc.payloadPolicy}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSetDefaults_GatewayPayloadPolicyConfig(t *testing.T) {
+	var cfg GatewayPayloadPolicyConfig
+	SetDefaults_GatewayPayloadPolicyConfig(&cfg)
+	if !slices.Equal(cfg.DefaultCompressors, []networkingv1alpha.PayloadCompressor{networkingv1alpha.PayloadCompressorBrotli, networkingv1alpha.PayloadCompressorGzip}) {
+		t.Fatalf("unexpected default compressors %v", cfg.DefaultCompressors)
+	}
+	if cfg.DefaultCompressionMinSize.String() != "1Ki" {
+		t.Fatalf("unexpected default compression min size %s", cfg.DefaultCompressionMinSize.String())
+	}
+	if cfg.MaxRequestBodyLimit.String() != "10Mi" {
+		t.Fatalf("unexpected max request body limit %s", cfg.MaxRequestBodyLimit.String())
+	}
+}
+
+func TestNetworkServicesOperator_Validate_DNSEndpointRegistry(t *testing.T) {
+	cases := map[string]struct {
+		registry GatewayDNSEndpointRegistryConfig
+		wantErr  string
+	}{
+		"unset":    {},
+		"owner id": {registry: GatewayDNSEndpointRegistryConfig{OwnerID: "network-services-operator", Prefix: "_owner."}},
+		"owner id with separator": {
+			registry: GatewayDNSEndpointRegistryConfig{OwnerID: "a,b"},
+			wantErr:  "gateway.dnsEndpointRegistry: ownerID:",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{DNSEndpointRegistry: tc.registry}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_DNSRecords(t *testing.T) {
+	cases := map[string]struct {
+		records GatewayDNSRecordsConfig
+		wantErr string
+	}{
+		"unset": {},
+		"defaults": {
+			records: GatewayDNSRecordsConfig{TTL: 300, MinTTL: 60, MaxTTL: 86400, Policy: DNSRecordPolicyStandard},
+		},
+		"alias preferred": {
+			records: GatewayDNSRecordsConfig{TTL: 60, MinTTL: 60, MaxTTL: 3600, Policy: DNSRecordPolicyAliasPreferred},
+		},
+		"ttl below min": {
+			records: GatewayDNSRecordsConfig{TTL: 30, MinTTL: 60, MaxTTL: 86400},
+			wantErr: "gateway.dnsRecords: ttl:",
+		},
+		"ttl above max": {
+			records: GatewayDNSRecordsConfig{TTL: 7200, MinTTL: 60, MaxTTL: 3600},
+			wantErr: "gateway.dnsRecords: ttl:",
+		},
+		"max below min": {
+			records: GatewayDNSRecordsConfig{MinTTL: 600, MaxTTL: 60},
+			wantErr: "gateway.dnsRecords: maxTTL:",
+		},
+		"negative ttl": {
+			records: GatewayDNSRecordsConfig{TTL: -1},
+			wantErr: "gateway.dnsRecords:",
+		},
+		"unknown policy": {
+			records: GatewayDNSRecordsConfig{Policy: "Proxied"},
+			wantErr: "gateway.dnsRecords: policy:",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{DNSRecords: tc.records}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_DNSVerification(t *testing.T) {
+	cases := map[string]struct {
+		verification GatewayDNSVerificationConfig
+		wantErr      string
+	}{
+		"unset":     {},
+		"resolvers": {verification: GatewayDNSVerificationConfig{Resolvers: []string{"1.1.1.1:53", "[2001:4860:4860::8888]:53"}}},
+		"resolver without port": {
+			verification: GatewayDNSVerificationConfig{Resolvers: []string{"8.8.8.8"}},
+			wantErr:      "gateway.dnsVerification: resolvers[0]:",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{DNSVerification: tc.verification}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_AccessLogging(t *testing.T) {
+	cases := map[string]struct {
+		accessLogging GatewayAccessLoggingConfig
+		wantErr       string
+	}{
+		"unset": {},
+		"envoy proxy and s3 collector": {
+			accessLogging: GatewayAccessLoggingConfig{
+				EnvoyProxy:          &GatewayAccessLoggingEnvoyProxyRef{Namespace: "envoy-gateway-system", Name: "proxy"},
+				S3CollectorEndpoint: "collector.observability:4317",
+			},
+		},
+		"envoy proxy without name": {
+			accessLogging: GatewayAccessLoggingConfig{
+				EnvoyProxy: &GatewayAccessLoggingEnvoyProxyRef{Namespace: "envoy-gateway-system"},
+			},
+			wantErr: "gateway.accessLogging: envoyProxy: namespace and name are required",
+		},
+		"s3 collector without port": {
+			accessLogging: GatewayAccessLoggingConfig{S3CollectorEndpoint: "collector.observability"},
+			wantErr:       "gateway.accessLogging: s3CollectorEndpoint:",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{AccessLogging: tc.accessLogging}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_DataPlaneSizes(t *testing.T) {
+	cases := map[string]struct {
+		size    GatewayDataPlaneSize
+		name    string
+		wantErr string
+	}{
+		"replicas": {size: GatewayDataPlaneSize{Replicas: ptr.To[int32](3)}},
+		"autoscaling": {size: GatewayDataPlaneSize{
+			Autoscaling: &GatewayDataPlaneAutoscaling{MinReplicas: ptr.To[int32](2), MaxReplicas: 10},
+		}},
+		"invalid name": {
+			name:    "Large",
+			size:    GatewayDataPlaneSize{Replicas: ptr.To[int32](3)},
+			wantErr: `gateway.dataPlaneSizes: invalid size name "Large"`,
+		},
+		"zero replicas": {
+			size:    GatewayDataPlaneSize{Replicas: ptr.To[int32](0)},
+			wantErr: "gateway.dataPlaneSizes.large: replicas must be at least 1",
+		},
+		"replicas and autoscaling": {
+			size: GatewayDataPlaneSize{
+				Replicas:    ptr.To[int32](3),
+				Autoscaling: &GatewayDataPlaneAutoscaling{MaxReplicas: 10},
+			},
+			wantErr: "gateway.dataPlaneSizes.large: replicas and autoscaling are mutually exclusive",
+		},
+		"max below min": {
+			size: GatewayDataPlaneSize{
+				Autoscaling: &GatewayDataPlaneAutoscaling{MinReplicas: ptr.To[int32](4), MaxReplicas: 2},
+			},
+			wantErr: "gateway.dataPlaneSizes.large: autoscaling.maxReplicas must be at least autoscaling.minReplicas",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sizeName := tc.name
+			if sizeName == "" {
+				sizeName = "large"
+			}
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{
+				DataPlaneSizes: map[string]GatewayDataPlaneSize{sizeName: tc.size},
+			}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_IPAM(t *testing.T) {
+	ipv4Pool := IPAMPoolConfig{
+		SubnetClass:       "private",
+		IPFamily:          networkingv1alpha.IPv4Protocol,
+		CIDRs:             []string{"10.128.0.0/9"},
+		BlockPrefixLength: 24,
+	}
+
+	cases := map[string]struct {
+		pools              func() []IPAMPoolConfig
+		reclaimGracePeriod *metav1.Duration
+		wantErr            string
+	}{
+		"dual stack": {pools: func() []IPAMPoolConfig {
+			return []IPAMPoolConfig{ipv4Pool, {
+				SubnetClass:         "private",
+				IPFamily:            networkingv1alpha.IPv6Protocol,
+				CIDRs:               []string{"fd20::/48"},
+				BlockPrefixLength:   64,
+				DefaultPrefixLength: ptr.To[int32](56),
+			}}
+		}},
+		"duplicate pool": {
+			pools:   func() []IPAMPoolConfig { return []IPAMPoolConfig{ipv4Pool, ipv4Pool} },
+			wantErr: `ipam: pools[1]: duplicate pool for subnet class "private" and IP family IPv4`,
+		},
+		"cidr of the other family": {
+			pools: func() []IPAMPoolConfig {
+				pool := ipv4Pool
+				pool.CIDRs = []string{"fd20::/48"}
+				return []IPAMPoolConfig{pool}
+			},
+			wantErr: "ipam: pools[0]: cidrs: fd20::/48 is not an IPv4 CIDR",
+		},
+		"too many blocks": {
+			pools: func() []IPAMPoolConfig {
+				pool := ipv4Pool
+				pool.BlockPrefixLength = 28
+				return []IPAMPoolConfig{pool}
+			},
+			wantErr: "ipam: pools[0]: cidrs: block prefix length 28 splits 10.128.0.0/9 in more than 2^16 blocks",
+		},
+		"default prefix longer than block": {
+			pools: func() []IPAMPoolConfig {
+				pool := ipv4Pool
+				pool.DefaultPrefixLength = ptr.To[int32](26)
+				return []IPAMPoolConfig{pool}
+			},
+			wantErr: "ipam: pools[0]: defaultPrefixLength must not be longer than blockPrefixLength",
+		},
+		"negative reclaim grace period": {
+			pools:              func() []IPAMPoolConfig { return []IPAMPoolConfig{ipv4Pool} },
+			reclaimGracePeriod: &metav1.Duration{Duration: -time.Minute},
+			wantErr:            "ipam: reclaimGracePeriod must not be negative",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{IPAM: IPAMConfig{Pools: tc.pools(), ReclaimGracePeriod: tc.reclaimGracePeriod}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_InvalidListenerPolicy(t *testing.T) {
+	cases := map[string]struct {
+		policy  InvalidListenerPolicy
+		wantErr string
+	}{
+		"unset":             {},
+		"exclude listeners": {policy: InvalidListenerPolicyExcludeListeners},
+		"reject gateway":    {policy: InvalidListenerPolicyRejectGateway},
+		"unknown policy": {
+			policy:  "Ignore",
+			wantErr: `gateway.invalidListenerPolicy: unknown policy "Ignore"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{InvalidListenerPolicy: tc.policy}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_Validate_CorazaRuleBundles(t *testing.T) {
+	cases := map[string]struct {
+		coraza  CorazaConfig
+		wantErr string
+	}{
+		"no bundles": {coraza: CorazaConfig{FilterName: "coraza-waf"}},
+		"bundles": {coraza: CorazaConfig{
+			FilterName:        "coraza-waf",
+			CRSVersion:        "4.7.0",
+			DefaultCRSVersion: "4.7.0",
+			RuleBundles:       []CorazaRuleBundle{{CRSVersion: "4.10.0", LibraryPath: "/opt/coraza-waf/coraza-waf-4.10.0.so"}},
+		}},
+		"missing version": {
+			coraza:  CorazaConfig{RuleBundles: []CorazaRuleBundle{{LibraryPath: "/opt/coraza-waf.so"}}},
+			wantErr: "gateway.coraza: ruleBundles[0].crsVersion must not be empty",
+		},
+		"missing library path": {
+			coraza:  CorazaConfig{RuleBundles: []CorazaRuleBundle{{CRSVersion: "4.10.0"}}},
+			wantErr: "gateway.coraza: ruleBundles[0].libraryPath must not be empty",
+		},
+		"duplicated version": {
+			coraza: CorazaConfig{CRSVersion: "4.10.0", RuleBundles: []CorazaRuleBundle{
+				{CRSVersion: "4.10.0", LibraryPath: "/opt/coraza-waf.so"},
+			}},
+			wantErr: `gateway.coraza: ruleBundles[0].crsVersion "4.10.0" is duplicated`,
+		},
+		"duplicated filter name": {
+			coraza: CorazaConfig{FilterName: "coraza-waf", RuleBundles: []CorazaRuleBundle{
+				{CRSVersion: "4.10.0", LibraryPath: "/opt/coraza-waf.so", FilterName: "coraza-waf"},
+			}},
+			wantErr: `gateway.coraza: ruleBundles[0].filterName "coraza-waf" is duplicated`,
+		},
+		"unknown default version": {
+			coraza:  CorazaConfig{DefaultCRSVersion: "4.10.0"},
+			wantErr: `gateway.coraza: defaultCRSVersion "4.10.0" does not match`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Gateway: GatewayConfig{Coraza: tc.coraza}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCorazaConfig_RuleBundle(t *testing.T) {
+	coraza := CorazaConfig{
+		LibraryID:           "coraza-waf",
+		LibraryPath:         "/opt/coraza-waf/coraza-waf.so",
+		FilterName:          "coraza-waf",
+		PluginName:          "coraza-waf",
+		RouteBaseDirectives: []string{"Include @crs-setup-conf"},
+		CRSVersion:          "4.7.0",
+		RuleBundles: []CorazaRuleBundle{
+			{CRSVersion: "4.10.0", LibraryPath: "/opt/coraza-waf/coraza-waf-4.10.0.so"},
+		},
+	}
+
+	bundle, ok := coraza.RuleBundle("")
+	if !ok || bundle.LibraryPath != coraza.LibraryPath || bundle.FilterName != coraza.FilterName {
+		t.Fatalf("expected the top-level library by default, got %+v", bundle)
+	}
+
+	bundle, ok = coraza.RuleBundle("4.10.0")
+	want := CorazaRuleBundle{
+		CRSVersion:          "4.10.0",
+		LibraryPath:         "/opt/coraza-waf/coraza-waf-4.10.0.so",
+		LibraryID:           "coraza-waf-4.10.0",
+		FilterName:          "coraza-waf-4.10.0",
+		PluginName:          "coraza-waf",
+		RouteBaseDirectives: []string{"Include @crs-setup-conf"},
+	}
+	if !ok || !equality.Semantic.DeepEqual(bundle, want) {
+		t.Fatalf("expected %+v, got %+v", want, bundle)
+	}
+
+	coraza.DefaultCRSVersion = "4.10.0"
+	if bundle, _ := coraza.RuleBundle(""); bundle.FilterName != "coraza-waf-4.10.0" {
+		t.Fatalf("expected the default bundle, got %+v", bundle)
+	}
+	if bundle, _ := coraza.RuleBundle("4.7.0"); bundle.FilterName != "coraza-waf" {
+		t.Fatalf("expected the top-level library, got %+v", bundle)
+	}
+	if _, ok := coraza.RuleBundle("3.3.0"); ok {
+		t.Fatal("expected no bundle for an unavailable version")
+	}
+}
+
+func TestNetworkServicesOperator_Validate_BackendResolution(t *testing.T) {
+	cfg := &NetworkServicesOperator{HTTPProxy: HTTPProxyConfig{BackendResolution: BackendResolutionConfig{
+		Enabled:            true,
+		MinRefreshInterval: metav1.Duration{Duration: time.Hour},
+		MaxRefreshInterval: metav1.Duration{Duration: time.Minute},
+	}}}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "httpProxy.backendResolution: minRefreshInterval") {
+		t.Fatalf("expected refresh interval error, got %v", err)
+	}
+
+	cfg.HTTPProxy.BackendResolution.MinRefreshInterval = metav1.Duration{}
+	SetDefaults_BackendResolutionConfig(&cfg.HTTPProxy.BackendResolution)
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+func TestNetworkServicesOperator_Validate_HTTPProxyRequestPolicy(t *testing.T) {
+	cfg := &NetworkServicesOperator{}
+	SetDefaults_HTTPProxyRequestPolicyConfig(&cfg.HTTPProxy.RequestPolicy)
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+
+	cfg.HTTPProxy.RequestPolicy.DefaultRequestTimeout = &metav1.Duration{Duration: 2 * time.Hour}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "httpProxy.requestPolicy: defaultRequestTimeout") {
+		t.Fatalf("expected request timeout error, got %v", err)
+	}
+
+	cfg.HTTPProxy.RequestPolicy.DefaultRequestTimeout = &metav1.Duration{Duration: 10 * time.Second}
+	cfg.HTTPProxy.RequestPolicy.DefaultBackendRequestTimeout = &metav1.Duration{Duration: time.Minute}
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "httpProxy.requestPolicy: defaultBackendRequestTimeout must not be greater") {
+		t.Fatalf("expected backend request timeout error, got %v", err)
+	}
+
+	cfg.HTTPProxy.RequestPolicy.DefaultBackendRequestTimeout = nil
+	cfg.HTTPProxy.RequestPolicy.DefaultRetryAttempts = 10
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "httpProxy.requestPolicy: defaultRetryAttempts") {
+		t.Fatalf("expected retry attempts error, got %v", err)
+	}
+}
+
+func TestNetworkServicesOperator_Validate_Controllers(t *testing.T) {
+	cases := map[string]struct {
+		controller ControllerConfig
+		wantErr    string
+	}{
+		"concurrency only": {controller: ControllerConfig{MaxConcurrentReconciles: 20}},
+		"defaulted rate limiter": {
+			controller: ControllerConfig{RateLimiter: &RateLimiterConfig{Burst: 500}},
+		},
+		"negative concurrency": {
+			controller: ControllerConfig{MaxConcurrentReconciles: -1},
+			wantErr:    "controllers.gateway.maxConcurrentReconciles must not be negative",
+		},
+		"max delay below defaulted base delay": {
+			controller: ControllerConfig{RateLimiter: &RateLimiterConfig{MaxDelay: metav1.Duration{Duration: time.Millisecond}}},
+			wantErr:    "controllers.gateway.rateLimiter: maxDelay must not be less than baseDelay",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{Controllers: map[string]ControllerConfig{"gateway": tc.controller}}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNetworkServicesOperator_ControllerConfig(t *testing.T) {
+	cfg := &NetworkServicesOperator{Controllers: map[string]ControllerConfig{
+		"domain": {MaxConcurrentReconciles: 50, RateLimiter: &RateLimiterConfig{MaxDelay: metav1.Duration{Duration: time.Minute}}},
+	}}
+
+	got := cfg.ControllerConfig("domain")
+	if got.MaxConcurrentReconciles != 50 {
+		t.Fatalf("expected 50 concurrent reconciles, got %d", got.MaxConcurrentReconciles)
+	}
+	want := RateLimiterConfig{
+		BaseDelay: metav1.Duration{Duration: 5 * time.Millisecond},
+		MaxDelay:  metav1.Duration{Duration: time.Minute},
+		QPS:       10,
+		Burst:     100,
+	}
+	if got.RateLimiter == nil || *got.RateLimiter != want {
+		t.Fatalf("expected rate limiter %+v, got %+v", want, got.RateLimiter)
+	}
+	if cfg.Controllers["domain"].RateLimiter.BaseDelay.Duration != 0 {
+		t.Fatal("expected the configuration to not be modified")
+	}
+
+	if got := cfg.ControllerConfig("gateway"); got.MaxConcurrentReconciles != 0 || got.RateLimiter != nil {
+		t.Fatalf("expected an empty configuration, got %+v", got)
+	}
+}
+
+func TestNetworkServicesOperator_Validate_SharedDNSZoneSelector(t *testing.T) {
+	cfg := &NetworkServicesOperator{Gateway: GatewayConfig{SharedDNSZoneSelector: &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "dns.datumapis.com/shared", Operator: "Equals"}},
+	}}}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "gateway.sharedDNSZoneSelector:") {
+		t.Fatalf("expected selector error, got %v", err)
+	}
+
+	cfg.Gateway.SharedDNSZoneSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"dns.datumapis.com/shared": "true"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+func TestNetworkServicesOperator_Validate_CryptoPolicy(t *testing.T) {
+	cases := map[string]struct {
+		policy  CryptoPolicyConfig
+		wantErr string
+	}{
+		"default": {},
+		"fips":    {policy: CryptoPolicyConfig{Mode: CryptoPolicyFIPS}},
+		"fips with approved suites": {policy: CryptoPolicyConfig{
+			Mode: CryptoPolicyFIPS,
+			TLS:  TLSSettings{MinVersion: "1.3", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+		}},
+		"unknown mode": {
+			policy:  CryptoPolicyConfig{Mode: "Strict"},
+			wantErr: `cryptoPolicy: unknown mode "Strict"`,
+		},
+		"old version": {
+			policy:  CryptoPolicyConfig{MetricsServer: &TLSSettings{MinVersion: "1.1"}},
+			wantErr: `cryptoPolicy: metricsServer.minVersion: unsupported TLS version "1.1"`,
+		},
+		"insecure suite": {
+			policy:  CryptoPolicyConfig{TLS: TLSSettings{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}},
+			wantErr: `cryptoPolicy: tls.cipherSuites: unknown or insecure cipher suite "TLS_RSA_WITH_RC4_128_SHA"`,
+		},
+		"non-FIPS suite in FIPS mode": {
+			policy: CryptoPolicyConfig{
+				Mode:               CryptoPolicyFIPS,
+				RegistryDataClient: &TLSSettings{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"}},
+			},
+			wantErr: "cryptoPolicy: registryDataClient.cipherSuites: cipher suite \"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256\" is not FIPS-approved",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &NetworkServicesOperator{CryptoPolicy: tc.policy}
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCryptoPolicyConfig_Effective(t *testing.T) {
+	policy := CryptoPolicyConfig{}
+	settings := policy.Effective(CryptoPolicyWebhookServer)
+	if settings.MinVersion != tls.VersionTLS12 || settings.CipherSuites != nil || settings.CurvePreferences != nil {
+		t.Fatalf("expected TLS 1.2 with Go defaults, got %+v", settings)
+	}
+
+	policy = CryptoPolicyConfig{
+		Mode:          CryptoPolicyFIPS,
+		TLS:           TLSSettings{MinVersion: "1.3"},
+		MetricsServer: &TLSSettings{MinVersion: "1.2", CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}},
+	}
+
+	settings = policy.Effective(CryptoPolicyWebhookServer)
+	if settings.MinVersion != tls.VersionTLS13 {
+		t.Fatalf("expected global minimum version, got %s", tls.VersionName(settings.MinVersion))
+	}
+	if !slices.Equal(settings.CipherSuites, fipsCipherSuites) || !slices.Equal(settings.CurvePreferences, fipsCurvePreferences) {
+		t.Fatalf("expected FIPS cipher suites and curves, got %+v", settings)
+	}
+
+	settings = policy.Effective(CryptoPolicyMetricsServer)
+	if settings.MinVersion != tls.VersionTLS12 {
+		t.Fatalf("expected component minimum version, got %s", tls.VersionName(settings.MinVersion))
+	}
+	if !slices.Equal(settings.CipherSuites, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384}) {
+		t.Fatalf("expected component cipher suites, got %v", settings.CipherSuites)
+	}
+
+	client := policy.HTTPClient(CryptoPolicyRegistryDataClient, time.Second)
+	transport := client.Transport.(*http.Transport)
+	if client.Timeout != time.Second || transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Fatalf("expected HTTP client to use the global settings, got %+v", transport.TLSClientConfig)
+	}
+}
+
+func TestNetworkServicesOperator_Validate_ServersAndTimings(t *testing.T) {
+	cases := map[string]struct {
+		cfg     NetworkServicesOperator
+		wantErr []string
+	}{
+		"defaults": {},
+		"milo discovery settings": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				Mode:                     "milo",
+				InternalServiceDiscovery: true,
+				DiscoveryKubeconfigPath:  "/etc/discovery/kubeconfig",
+			}},
+		},
+		"milo discovery settings in single mode": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				Mode:                     "single",
+				InternalServiceDiscovery: true,
+				DiscoveryKubeconfigPath:  "/etc/discovery/kubeconfig",
+			}},
+			wantErr: []string{
+				"discovery: internalServiceDiscovery is only supported in milo mode",
+				"discovery: discoveryKubeconfigPath is only supported in milo mode",
+			},
+		},
+		"kubeconfig directory discovery": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				Mode:                DiscoveryModeKubeconfigDirectory,
+				KubeconfigDirectory: "/tmp/nso-clusters",
+			}},
+		},
+		"kubeconfig directory discovery without a directory": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				Mode:                  DiscoveryModeKubeconfigDirectory,
+				ProjectKubeconfigPath: "/etc/project/kubeconfig",
+			}},
+			wantErr: []string{
+				"discovery: kubeconfigDirectory is required in kubeconfig-directory mode",
+				"discovery: projectKubeconfigPath is only supported in milo mode",
+			},
+		},
+		"kubeconfig directory in milo mode": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				Mode:                "milo",
+				KubeconfigDirectory: "/tmp/nso-clusters",
+			}},
+			wantErr: []string{"discovery: kubeconfigDirectory is only supported in kubeconfig-directory mode"},
+		},
+		"project filter": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				Mode: "milo",
+				ProjectFilter: &ProjectFilterConfig{
+					Selector:           &metav1.LabelSelector{MatchLabels: map[string]string{"shard": "a"}},
+					ExcludeAnnotations: map[string]string{"example.com/paused": ""},
+					Phases:             []string{"Ready"},
+				},
+			}},
+		},
+		"invalid project filter": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				Mode: "milo",
+				ProjectFilter: &ProjectFilterConfig{
+					ExcludeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "shard", Operator: "Like"},
+					}},
+					Annotations: map[string]string{"not a key": "x"},
+					Phases:      []string{""},
+				},
+			}},
+			wantErr: []string{
+				"discovery: projectFilter: excludeSelector:",
+				`discovery: projectFilter: annotations: invalid annotation key "not a key"`,
+				"discovery: projectFilter: phases must not be empty",
+			},
+		},
+		"project filter in single mode": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				ProjectFilter: &ProjectFilterConfig{Phases: []string{"Ready"}},
+			}},
+			wantErr: []string{"discovery: projectFilter is only supported in milo mode"},
+		},
+		"cluster sharding": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				Mode:     "milo",
+				Sharding: &ClusterShardingConfig{Shards: 3, Index: ptr.To[int32](2)},
+			}},
+		},
+		"invalid cluster sharding": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				Mode:                DiscoveryModeKubeconfigDirectory,
+				KubeconfigDirectory: "/tmp/nso-clusters",
+				Sharding:            &ClusterShardingConfig{Index: ptr.To[int32](1)},
+			}},
+			wantErr: []string{
+				"discovery: sharding: shards must be at least 1",
+				"discovery: sharding: index 1 must be between 0 and shards-1",
+			},
+		},
+		"cluster sharding in single mode": {
+			cfg: NetworkServicesOperator{Discovery: DiscoveryConfig{
+				Sharding: &ClusterShardingConfig{Shards: 2},
+			}},
+			wantErr: []string{"discovery: sharding is not supported in single mode"},
+		},
+		"unknown discovery mode": {
+			cfg:     NetworkServicesOperator{Discovery: DiscoveryConfig{Mode: "kind"}},
+			wantErr: []string{`discovery: unsupported mode "kind"`},
+		},
+		"server ports": {
+			cfg: NetworkServicesOperator{
+				WebhookServer: WebhookServerConfig{Port: -1},
+				MetricsServer: MetricsServerConfig{BindAddress: "8443"},
+			},
+			wantErr: []string{
+				"webhookServer: port -1 must be between 1 and 65535",
+				`metricsServer: bindAddress "8443" must be a host:port address`,
+			},
+		},
+		"metrics server address": {
+			cfg: NetworkServicesOperator{MetricsServer: MetricsServerConfig{BindAddress: ":8443"}},
+		},
+		"leader election timings": {
+			cfg: NetworkServicesOperator{LeaderElection: LeaderElectionConfig{
+				LeaseDuration: metav1.Duration{Duration: 15 * time.Second},
+				RenewDeadline: metav1.Duration{Duration: 10 * time.Second},
+				RetryPeriod:   metav1.Duration{Duration: 10 * time.Second},
+			}},
+			wantErr: []string{"leaderElection: retryPeriod must be less than renewDeadline"},
+		},
+		"negative durations": {
+			cfg: NetworkServicesOperator{
+				DownstreamCircuitBreaker: CircuitBreakerConfig{Window: metav1.Duration{Duration: -time.Second}},
+				UpstreamOutage:           UpstreamOutageConfig{Threshold: metav1.Duration{Duration: -time.Second}},
+				DownstreamResourceManagement: DownstreamResourceManagementConfig{
+					Audit:                DownstreamAuditConfig{Interval: metav1.Duration{Duration: -time.Second}},
+					ClusterDisengagement: DownstreamClusterDisengagementConfig{GracePeriod: metav1.Duration{Duration: -time.Second}},
+					Inventory:            DownstreamInventoryConfig{Interval: metav1.Duration{Duration: -time.Second}},
+				},
+			},
+			wantErr: []string{
+				"downstreamCircuitBreaker: window must not be negative",
+				"upstreamOutage: threshold must not be negative",
+				"downstreamResourceManagement: audit.interval must not be negative",
+				"downstreamResourceManagement: clusterDisengagement.gracePeriod must not be negative",
+				"downstreamResourceManagement: inventory.interval must not be negative",
+			},
+		},
+		"cluster issuer names": {
+			cfg: NetworkServicesOperator{Gateway: GatewayConfig{ClusterIssuerMap: map[string]string{
+				"auto":    "letsencrypt",
+				"staging": "Lets Encrypt",
+			}}},
+			wantErr: []string{`gateway.clusterIssuerMap: staging: invalid ClusterIssuer name "Lets Encrypt"`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if len(tc.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("expected nil, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %q, got nil", tc.wantErr)
+			}
+			for _, want := range tc.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to mention %q, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestShardIndexFromHostname(t *testing.T) {
+	cases := map[string]struct {
+		hostname string
+		want     int32
+		wantErr  bool
+	}{
+		"statefulset pod":    {hostname: "network-services-operator-2", want: 2},
+		"first pod":          {hostname: "nso-0", want: 0},
+		"deployment pod":     {hostname: "network-services-operator-6d4cf56db6-xk2lp", wantErr: true},
+		"without an ordinal": {hostname: "localhost", wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := shardIndexFromHostname(tc.hostname)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got shard %d", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected nil, got %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("expected shard %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
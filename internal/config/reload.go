@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package config
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// ReloadableFields lists the fields of the operator configuration that take
+// effect without restarting the manager. Changes to any other field are only
+// picked up on restart.
+var ReloadableFields = []string{
+	"discovery.sharding.shards",
+	"domainVerification.retryIntervals",
+	"domainVerification.retryJitterMaxFactor",
+	"domainVerification.reverificationRetryInterval",
+	"domainRegistration.registryData.rateLimits",
+	"gateway.clusterIssuerMap",
+	"gateway.coraza.listenerDirectives",
+	"gateway.coraza.routeBaseDirectives",
+}
+
+// Reloadable holds the operator configuration read by controllers for the
+// ReloadableFields, which are swapped atomically when the config file changes.
+// All other fields keep the values the manager was started with.
+type Reloadable struct {
+	current atomic.Pointer[NetworkServicesOperator]
+
+	mu          sync.Mutex
+	subscribers []func(*NetworkServicesOperator)
+}
+
+// NewReloadable returns a Reloadable holding cfg.
+func NewReloadable(cfg NetworkServicesOperator) *Reloadable {
+	r := &Reloadable{}
+	r.current.Store(cfg.DeepCopy())
+	return r
+}
+
+// Load returns the current configuration. The returned value must not be
+// modified.
+func (r *Reloadable) Load() *NetworkServicesOperator {
+	return r.current.Load()
+}
+
+// Subscribe registers fn to be called with the new configuration after every
+// successful Apply, for consumers that copy reloadable fields at setup.
+func (r *Reloadable) Subscribe(fn func(*NetworkServicesOperator)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// Apply validates next and swaps in its ReloadableFields. It reports whether
+// next also changes fields that require a restart, which are left unchanged.
+func (r *Reloadable) Apply(next NetworkServicesOperator) (restartRequired bool, err error) {
+	if err := next.Validate(); err != nil {
+		return false, fmt.Errorf("invalid server config: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.current.Load()
+
+	// Compare everything but the reloadable fields to detect changes that are
+	// ignored until the manager restarts.
+	unreloadable := next.DeepCopy()
+	copyReloadableFields(unreloadable, current)
+	restartRequired = !equality.Semantic.DeepEqual(unreloadable, current)
+
+	updated := current.DeepCopy()
+	copyReloadableFields(updated, &next)
+	r.current.Store(updated)
+
+	for _, fn := range r.subscribers {
+		fn(updated)
+	}
+	return restartRequired, nil
+}
+
+// copyReloadableFields copies the ReloadableFields of src to dst.
+func copyReloadableFields(dst, src *NetworkServicesOperator) {
+	src = src.DeepCopy()
+
+	if dst.Discovery.Sharding != nil && src.Discovery.Sharding != nil {
+		dst.Discovery.Sharding.Shards = src.Discovery.Sharding.Shards
+	}
+
+	dst.DomainVerification.RetryIntervals = src.DomainVerification.RetryIntervals
+	dst.DomainVerification.RetryJitterMaxFactor = src.DomainVerification.RetryJitterMaxFactor
+	dst.DomainVerification.ReverificationRetryInterval = src.DomainVerification.ReverificationRetryInterval
+	dst.DomainRegistration.RegistryData.RateLimits = src.DomainRegistration.RegistryData.RateLimits
+	dst.Gateway.ClusterIssuerMap = src.Gateway.ClusterIssuerMap
+	dst.Gateway.Coraza.ListenerDirectives = src.Gateway.Coraza.ListenerDirectives
+	dst.Gateway.Coraza.RouteBaseDirectives = src.Gateway.Coraza.RouteBaseDirectives
+}
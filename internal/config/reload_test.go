@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReloadable_Apply(t *testing.T) {
+	initial := NetworkServicesOperator{
+		Gateway: GatewayConfig{
+			TargetDomain:     "datumproxy.net",
+			ClusterIssuerMap: map[string]string{"auto": "letsencrypt"},
+		},
+		DomainVerification: DomainVerificationConfig{
+			RetryIntervals: []RetryInterval{{Interval: metav1.Duration{Duration: time.Minute}}},
+		},
+	}
+	reloadable := NewReloadable(initial)
+
+	var notified *NetworkServicesOperator
+	reloadable.Subscribe(func(cfg *NetworkServicesOperator) { notified = cfg })
+
+	next := *initial.DeepCopy()
+	next.Gateway.ClusterIssuerMap = map[string]string{"auto": "letsencrypt-staging"}
+	next.Gateway.Coraza.RouteBaseDirectives = []string{"SecRuleEngine On"}
+	next.DomainVerification.RetryIntervals = []RetryInterval{{Interval: metav1.Duration{Duration: 5 * time.Second}}}
+	next.DomainRegistration.RegistryData.RateLimits.DefaultRatePerSec = 2
+
+	restartRequired, err := reloadable.Apply(next)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if restartRequired {
+		t.Fatalf("expected no restart to be required for reloadable fields")
+	}
+
+	current := reloadable.Load()
+	if got := current.Gateway.ClusterIssuerMap["auto"]; got != "letsencrypt-staging" {
+		t.Fatalf("expected the cluster issuer map to be reloaded, got %q", got)
+	}
+	if !slices.Equal(current.Gateway.Coraza.RouteBaseDirectives, []string{"SecRuleEngine On"}) {
+		t.Fatalf("expected the route directives to be reloaded, got %v", current.Gateway.Coraza.RouteBaseDirectives)
+	}
+	if got := current.DomainVerification.GetRetryInterval(0); got != 5*time.Second {
+		t.Fatalf("expected the retry intervals to be reloaded, got %v", got)
+	}
+	if got := current.DomainRegistration.RegistryData.RateLimits.DefaultRatePerSec; got != 2 {
+		t.Fatalf("expected the rate limits to be reloaded, got %v", got)
+	}
+	if notified != current {
+		t.Fatalf("expected subscribers to be notified of the reloaded config")
+	}
+	if got := initial.Gateway.ClusterIssuerMap["auto"]; got != "letsencrypt" {
+		t.Fatalf("expected the initial config to be left unchanged, got %q", got)
+	}
+}
+
+func TestReloadable_Apply_RestartRequired(t *testing.T) {
+	reloadable := NewReloadable(NetworkServicesOperator{Gateway: GatewayConfig{TargetDomain: "datumproxy.net"}})
+
+	next := NetworkServicesOperator{Gateway: GatewayConfig{
+		TargetDomain:     "example.net",
+		ClusterIssuerMap: map[string]string{"auto": "letsencrypt"},
+	}}
+	restartRequired, err := reloadable.Apply(next)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if !restartRequired {
+		t.Fatalf("expected a restart to be required for gateway.targetDomain")
+	}
+
+	current := reloadable.Load()
+	if current.Gateway.TargetDomain != "datumproxy.net" {
+		t.Fatalf("expected gateway.targetDomain to be left unchanged, got %q", current.Gateway.TargetDomain)
+	}
+	if current.Gateway.ClusterIssuerMap["auto"] != "letsencrypt" {
+		t.Fatalf("expected the reloadable fields to be applied")
+	}
+}
+
+func TestReloadable_Apply_Sharding(t *testing.T) {
+	initial := NetworkServicesOperator{Discovery: DiscoveryConfig{
+		Mode:     "milo",
+		Sharding: &ClusterShardingConfig{Shards: 2},
+	}}
+	reloadable := NewReloadable(initial)
+
+	next := *initial.DeepCopy()
+	next.Discovery.Sharding.Shards = 3
+	restartRequired, err := reloadable.Apply(next)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if restartRequired {
+		t.Fatalf("expected no restart to be required for discovery.sharding.shards")
+	}
+	if got := reloadable.Load().Discovery.Sharding.Shards; got != 3 {
+		t.Fatalf("expected the number of shards to be reloaded, got %d", got)
+	}
+
+	next.Discovery.Sharding = nil
+	restartRequired, err = reloadable.Apply(next)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if !restartRequired {
+		t.Fatalf("expected a restart to be required to disable sharding")
+	}
+	if reloadable.Load().Discovery.Sharding == nil {
+		t.Fatalf("expected sharding to stay enabled until a restart")
+	}
+}
+
+func TestReloadable_Apply_Invalid(t *testing.T) {
+	cases := map[string]struct {
+		mutate  func(*NetworkServicesOperator)
+		wantErr string
+	}{
+		"zero retry interval": {
+			mutate: func(c *NetworkServicesOperator) {
+				c.DomainVerification.RetryIntervals = []RetryInterval{{}}
+			},
+			wantErr: "domainVerification: retryIntervals[0].interval must be positive",
+		},
+		"negative jitter": {
+			mutate: func(c *NetworkServicesOperator) {
+				c.DomainVerification.RetryJitterMaxFactor = -1
+			},
+			wantErr: "domainVerification: retryJitterMaxFactor must not be negative",
+		},
+		"provider without rate": {
+			mutate: func(c *NetworkServicesOperator) {
+				c.DomainRegistration.RegistryData.RateLimits.Providers = []RegistryDataProviderRateLimitConfig{{Provider: "rdap.verisign.com"}}
+			},
+			wantErr: "domainRegistration.registryData.rateLimits: providers[0].requestsPerMinute must be positive",
+		},
+		"empty cluster issuer": {
+			mutate: func(c *NetworkServicesOperator) {
+				c.Gateway.ClusterIssuerMap = map[string]string{"auto": ""}
+			},
+			wantErr: "gateway.clusterIssuerMap: issuer names must not be empty",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reloadable := NewReloadable(NetworkServicesOperator{
+				Gateway: GatewayConfig{ClusterIssuerMap: map[string]string{"auto": "letsencrypt"}},
+			})
+
+			next := *reloadable.Load().DeepCopy()
+			tc.mutate(&next)
+			_, err := reloadable.Apply(next)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+			if got := reloadable.Load().Gateway.ClusterIssuerMap["auto"]; got != "letsencrypt" {
+				t.Fatalf("expected the previous config to stay in use, got %q", got)
+			}
+		})
+	}
+}
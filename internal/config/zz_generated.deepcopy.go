@@ -14,6 +14,39 @@ import (
 	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendHealthConfig) DeepCopyInto(out *BackendHealthConfig) {
+	*out = *in
+	out.RefreshInterval = in.RefreshInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendHealthConfig.
+func (in *BackendHealthConfig) DeepCopy() *BackendHealthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendHealthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendResolutionConfig) DeepCopyInto(out *BackendResolutionConfig) {
+	*out = *in
+	out.MinRefreshInterval = in.MinRefreshInterval
+	out.MaxRefreshInterval = in.MaxRefreshInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendResolutionConfig.
+func (in *BackendResolutionConfig) DeepCopy() *BackendResolutionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendResolutionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendTrafficPolicyValidationOptions) DeepCopyInto(out *BackendTrafficPolicyValidationOptions) {
 	*out = *in
@@ -46,6 +79,23 @@ func (in *CertificateReissuanceConfig) DeepCopy() *CertificateReissuanceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerConfig) DeepCopyInto(out *CircuitBreakerConfig) {
+	*out = *in
+	out.Window = in.Window
+	out.OpenDuration = in.OpenDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerConfig.
+func (in *CircuitBreakerConfig) DeepCopy() *CircuitBreakerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientConnectionConfig) DeepCopyInto(out *ClientConnectionConfig) {
 	*out = *in
@@ -126,6 +176,26 @@ func (in *ClusterSettingsValidationOptions) DeepCopy() *ClusterSettingsValidatio
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterShardingConfig) DeepCopyInto(out *ClusterShardingConfig) {
+	*out = *in
+	if in.Index != nil {
+		in, out := &in.Index, &out.Index
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterShardingConfig.
+func (in *ClusterShardingConfig) DeepCopy() *ClusterShardingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterShardingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectorConfig) DeepCopyInto(out *ConnectorConfig) {
 	*out = *in
@@ -142,6 +212,26 @@ func (in *ConnectorConfig) DeepCopy() *ConnectorConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
+	*out = *in
+	if in.RateLimiter != nil {
+		in, out := &in.RateLimiter, &out.RateLimiter
+		*out = new(RateLimiterConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfig.
+func (in *ControllerConfig) DeepCopy() *ControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CorazaConfig) DeepCopyInto(out *CorazaConfig) {
 	*out = *in
@@ -155,6 +245,15 @@ func (in *CorazaConfig) DeepCopyInto(out *CorazaConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	out.GeoIP = in.GeoIP
+	in.Events.DeepCopyInto(&out.Events)
+	if in.RuleBundles != nil {
+		in, out := &in.RuleBundles, &out.RuleBundles
+		*out = make([]CorazaRuleBundle, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CorazaConfig.
@@ -167,6 +266,62 @@ func (in *CorazaConfig) DeepCopy() *CorazaConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CorazaRuleBundle) DeepCopyInto(out *CorazaRuleBundle) {
+	*out = *in
+	if in.RouteBaseDirectives != nil {
+		in, out := &in.RouteBaseDirectives, &out.RouteBaseDirectives
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CorazaRuleBundle.
+func (in *CorazaRuleBundle) DeepCopy() *CorazaRuleBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(CorazaRuleBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CryptoPolicyConfig) DeepCopyInto(out *CryptoPolicyConfig) {
+	*out = *in
+	in.TLS.DeepCopyInto(&out.TLS)
+	if in.WebhookServer != nil {
+		in, out := &in.WebhookServer, &out.WebhookServer
+		*out = new(TLSSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricsServer != nil {
+		in, out := &in.MetricsServer, &out.MetricsServer
+		*out = new(TLSSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryDataClient != nil {
+		in, out := &in.RegistryDataClient, &out.RegistryDataClient
+		*out = new(TLSSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DomainVerificationClient != nil {
+		in, out := &in.DomainVerificationClient, &out.DomainVerificationClient
+		*out = new(TLSSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CryptoPolicyConfig.
+func (in *CryptoPolicyConfig) DeepCopy() *CryptoPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CryptoPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomHostnameAllowListEntry) DeepCopyInto(out *CustomHostnameAllowListEntry) {
 	*out = *in
@@ -190,6 +345,16 @@ func (in *CustomHostnameAllowListEntry) DeepCopy() *CustomHostnameAllowListEntry
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiscoveryConfig) DeepCopyInto(out *DiscoveryConfig) {
 	*out = *in
+	if in.ProjectFilter != nil {
+		in, out := &in.ProjectFilter, &out.ProjectFilter
+		*out = new(ProjectFilterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sharding != nil {
+		in, out := &in.Sharding, &out.Sharding
+		*out = new(ClusterShardingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveryConfig.
@@ -243,6 +408,16 @@ func (in *DomainVerificationConfig) DeepCopyInto(out *DomainVerificationConfig)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReverificationInterval != nil {
+		in, out := &in.ReverificationInterval, &out.ReverificationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReverificationRetryInterval != nil {
+		in, out := &in.ReverificationRetryInterval, &out.ReverificationRetryInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainVerificationConfig.
@@ -255,9 +430,77 @@ func (in *DomainVerificationConfig) DeepCopy() *DomainVerificationConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownstreamAuditConfig) DeepCopyInto(out *DownstreamAuditConfig) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownstreamAuditConfig.
+func (in *DownstreamAuditConfig) DeepCopy() *DownstreamAuditConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DownstreamAuditConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownstreamClusterDisengagementConfig) DeepCopyInto(out *DownstreamClusterDisengagementConfig) {
+	*out = *in
+	out.GracePeriod = in.GracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownstreamClusterDisengagementConfig.
+func (in *DownstreamClusterDisengagementConfig) DeepCopy() *DownstreamClusterDisengagementConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DownstreamClusterDisengagementConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownstreamInventoryConfig) DeepCopyInto(out *DownstreamInventoryConfig) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownstreamInventoryConfig.
+func (in *DownstreamInventoryConfig) DeepCopy() *DownstreamInventoryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DownstreamInventoryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownstreamNamespaceGCConfig) DeepCopyInto(out *DownstreamNamespaceGCConfig) {
+	*out = *in
+	out.GracePeriod = in.GracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownstreamNamespaceGCConfig.
+func (in *DownstreamNamespaceGCConfig) DeepCopy() *DownstreamNamespaceGCConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DownstreamNamespaceGCConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DownstreamResourceManagementConfig) DeepCopyInto(out *DownstreamResourceManagementConfig) {
 	*out = *in
+	out.Audit = in.Audit
+	out.NamespaceGC = in.NamespaceGC
+	out.ClusterDisengagement = in.ClusterDisengagement
+	out.Inventory = in.Inventory
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownstreamResourceManagementConfig.
@@ -303,6 +546,66 @@ func (in *ExtensionAPIValidationOptions) DeepCopy() *ExtensionAPIValidationOptio
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayAccessLoggingConfig) DeepCopyInto(out *GatewayAccessLoggingConfig) {
+	*out = *in
+	if in.EnvoyProxy != nil {
+		in, out := &in.EnvoyProxy, &out.EnvoyProxy
+		*out = new(GatewayAccessLoggingEnvoyProxyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAccessLoggingConfig.
+func (in *GatewayAccessLoggingConfig) DeepCopy() *GatewayAccessLoggingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAccessLoggingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayAccessLoggingEnvoyProxyRef) DeepCopyInto(out *GatewayAccessLoggingEnvoyProxyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAccessLoggingEnvoyProxyRef.
+func (in *GatewayAccessLoggingEnvoyProxyRef) DeepCopy() *GatewayAccessLoggingEnvoyProxyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAccessLoggingEnvoyProxyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayCAAConfig) DeepCopyInto(out *GatewayCAAConfig) {
+	*out = *in
+	if in.IssuerDomains != nil {
+		in, out := &in.IssuerDomains, &out.IssuerDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayCAAConfig.
+func (in *GatewayCAAConfig) DeepCopy() *GatewayCAAConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayCAAConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayConfig) DeepCopyInto(out *GatewayConfig) {
 	*out = *in
@@ -311,6 +614,11 @@ func (in *GatewayConfig) DeepCopyInto(out *GatewayConfig) {
 		*out = make([]v1alpha.IPFamily, len(*in))
 		copy(*out, *in)
 	}
+	if in.StaticAddressSubnetClasses != nil {
+		in, out := &in.StaticAddressSubnetClasses, &out.StaticAddressSubnetClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PermittedTLSOptions != nil {
 		in, out := &in.PermittedTLSOptions, &out.PermittedTLSOptions
 		*out = make(map[string][]string, len(*in))
@@ -372,12 +680,37 @@ func (in *GatewayConfig) DeepCopyInto(out *GatewayConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SharedDNSZoneSelector != nil {
+		in, out := &in.SharedDNSZoneSelector, &out.SharedDNSZoneSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EPPEmissionEnabled != nil {
 		in, out := &in.EPPEmissionEnabled, &out.EPPEmissionEnabled
 		*out = new(bool)
 		**out = **in
 	}
 	out.CertificateReissuance = in.CertificateReissuance
+	out.ListenerSharding = in.ListenerSharding
+	in.CAA.DeepCopyInto(&out.CAA)
+	in.TLSPolicy.DeepCopyInto(&out.TLSPolicy)
+	in.AccessLogging.DeepCopyInto(&out.AccessLogging)
+	if in.DataPlaneSizes != nil {
+		in, out := &in.DataPlaneSizes, &out.DataPlaneSizes
+		*out = make(map[string]GatewayDataPlaneSize, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	out.DNSEndpointRegistry = in.DNSEndpointRegistry
+	out.DNSRecords = in.DNSRecords
+	in.DNSVerification.DeepCopyInto(&out.DNSVerification)
+	in.DNSFailover.DeepCopyInto(&out.DNSFailover)
+	in.DomainGC.DeepCopyInto(&out.DomainGC)
+	out.Maintenance = in.Maintenance
+	out.RouteLimits = in.RouteLimits
+	out.DeletionProtection = in.DeletionProtection
+	in.PayloadPolicy.DeepCopyInto(&out.PayloadPolicy)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayConfig.
@@ -391,96 +724,441 @@ func (in *GatewayConfig) DeepCopy() *GatewayConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayResourceReplicatorConfig) DeepCopyInto(out *GatewayResourceReplicatorConfig) {
+func (in *GatewayDNSEndpointRegistryConfig) DeepCopyInto(out *GatewayDNSEndpointRegistryConfig) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]ReplicatedResourceConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayResourceReplicatorConfig.
-func (in *GatewayResourceReplicatorConfig) DeepCopy() *GatewayResourceReplicatorConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayDNSEndpointRegistryConfig.
+func (in *GatewayDNSEndpointRegistryConfig) DeepCopy() *GatewayDNSEndpointRegistryConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayResourceReplicatorConfig)
+	out := new(GatewayDNSEndpointRegistryConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPProxyConfig) DeepCopyInto(out *HTTPProxyConfig) {
+func (in *GatewayDNSFailoverConfig) DeepCopyInto(out *GatewayDNSFailoverConfig) {
 	*out = *in
+	if in.SecondaryClusters != nil {
+		in, out := &in.SecondaryClusters, &out.SecondaryClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecheckInterval != nil {
+		in, out := &in.RecheckInterval, &out.RecheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyConfig.
-func (in *HTTPProxyConfig) DeepCopy() *HTTPProxyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayDNSFailoverConfig.
+func (in *GatewayDNSFailoverConfig) DeepCopy() *GatewayDNSFailoverConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPProxyConfig)
+	out := new(GatewayDNSFailoverConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPRouteValidationOptions) DeepCopyInto(out *HTTPRouteValidationOptions) {
+func (in *GatewayDNSRecordsConfig) DeepCopyInto(out *GatewayDNSRecordsConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRouteValidationOptions.
-func (in *HTTPRouteValidationOptions) DeepCopy() *HTTPRouteValidationOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayDNSRecordsConfig.
+func (in *GatewayDNSRecordsConfig) DeepCopy() *GatewayDNSRecordsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPRouteValidationOptions)
+	out := new(GatewayDNSRecordsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IrohConnectorConfig) DeepCopyInto(out *IrohConnectorConfig) {
+func (in *GatewayDNSVerificationConfig) DeepCopyInto(out *GatewayDNSVerificationConfig) {
 	*out = *in
-	out.DNSZoneRef = in.DNSZoneRef
+	if in.Resolvers != nil {
+		in, out := &in.Resolvers, &out.Resolvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecheckInterval != nil {
+		in, out := &in.RecheckInterval, &out.RecheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IrohConnectorConfig.
-func (in *IrohConnectorConfig) DeepCopy() *IrohConnectorConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayDNSVerificationConfig.
+func (in *GatewayDNSVerificationConfig) DeepCopy() *GatewayDNSVerificationConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(IrohConnectorConfig)
+	out := new(GatewayDNSVerificationConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IrohDNSZoneRef) DeepCopyInto(out *IrohDNSZoneRef) {
+func (in *GatewayDataPlaneAutoscaling) DeepCopyInto(out *GatewayDataPlaneAutoscaling) {
 	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IrohDNSZoneRef.
-func (in *IrohDNSZoneRef) DeepCopy() *IrohDNSZoneRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayDataPlaneAutoscaling.
+func (in *GatewayDataPlaneAutoscaling) DeepCopy() *GatewayDataPlaneAutoscaling {
 	if in == nil {
 		return nil
 	}
-	out := new(IrohDNSZoneRef)
+	out := new(GatewayDataPlaneAutoscaling)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LeaderElectionConfig) DeepCopyInto(out *LeaderElectionConfig) {
+func (in *GatewayDataPlaneSize) DeepCopyInto(out *GatewayDataPlaneSize) {
 	*out = *in
-	out.LeaseDuration = in.LeaseDuration
-	out.RenewDeadline = in.RenewDeadline
-	out.RetryPeriod = in.RetryPeriod
-}
-
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(GatewayDataPlaneAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayDataPlaneSize.
+func (in *GatewayDataPlaneSize) DeepCopy() *GatewayDataPlaneSize {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayDataPlaneSize)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayDeletionProtectionConfig) DeepCopyInto(out *GatewayDeletionProtectionConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayDeletionProtectionConfig.
+func (in *GatewayDeletionProtectionConfig) DeepCopy() *GatewayDeletionProtectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayDeletionProtectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayDomainGCConfig) DeepCopyInto(out *GatewayDomainGCConfig) {
+	*out = *in
+	if in.RetentionPeriod != nil {
+		in, out := &in.RetentionPeriod, &out.RetentionPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayDomainGCConfig.
+func (in *GatewayDomainGCConfig) DeepCopy() *GatewayDomainGCConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayDomainGCConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayMaintenanceConfig) DeepCopyInto(out *GatewayMaintenanceConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayMaintenanceConfig.
+func (in *GatewayMaintenanceConfig) DeepCopy() *GatewayMaintenanceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayMaintenanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayPayloadPolicyConfig) DeepCopyInto(out *GatewayPayloadPolicyConfig) {
+	*out = *in
+	if in.DefaultCompressors != nil {
+		in, out := &in.DefaultCompressors, &out.DefaultCompressors
+		*out = make([]v1alpha.PayloadCompressor, len(*in))
+		copy(*out, *in)
+	}
+	out.DefaultCompressionMinSize = in.DefaultCompressionMinSize.DeepCopy()
+	out.MaxRequestBodyLimit = in.MaxRequestBodyLimit.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayPayloadPolicyConfig.
+func (in *GatewayPayloadPolicyConfig) DeepCopy() *GatewayPayloadPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayPayloadPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayResourceReplicatorConfig) DeepCopyInto(out *GatewayResourceReplicatorConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ReplicatedResourceConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayResourceReplicatorConfig.
+func (in *GatewayResourceReplicatorConfig) DeepCopy() *GatewayResourceReplicatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayResourceReplicatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayRouteLimitsConfig) DeepCopyInto(out *GatewayRouteLimitsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRouteLimitsConfig.
+func (in *GatewayRouteLimitsConfig) DeepCopy() *GatewayRouteLimitsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayRouteLimitsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTLSPolicyConfig) DeepCopyInto(out *GatewayTLSPolicyConfig) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ALPNProtocols != nil {
+		in, out := &in.ALPNProtocols, &out.ALPNProtocols
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTLSPolicyConfig.
+func (in *GatewayTLSPolicyConfig) DeepCopy() *GatewayTLSPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTLSPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeoIPConfig) DeepCopyInto(out *GeoIPConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoIPConfig.
+func (in *GeoIPConfig) DeepCopy() *GeoIPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GeoIPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyConfig) DeepCopyInto(out *HTTPProxyConfig) {
+	*out = *in
+	out.BackendResolution = in.BackendResolution
+	in.RequestPolicy.DeepCopyInto(&out.RequestPolicy)
+	out.BackendHealth = in.BackendHealth
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyConfig.
+func (in *HTTPProxyConfig) DeepCopy() *HTTPProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProxyRequestPolicyConfig) DeepCopyInto(out *HTTPProxyRequestPolicyConfig) {
+	*out = *in
+	if in.DefaultRequestTimeout != nil {
+		in, out := &in.DefaultRequestTimeout, &out.DefaultRequestTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DefaultBackendRequestTimeout != nil {
+		in, out := &in.DefaultBackendRequestTimeout, &out.DefaultBackendRequestTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	out.MaxRequestTimeout = in.MaxRequestTimeout
+	out.DefaultRetryBackoff = in.DefaultRetryBackoff
+	out.MaxRetryBackoff = in.MaxRetryBackoff
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProxyRequestPolicyConfig.
+func (in *HTTPProxyRequestPolicyConfig) DeepCopy() *HTTPProxyRequestPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProxyRequestPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRouteValidationOptions) DeepCopyInto(out *HTTPRouteValidationOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRouteValidationOptions.
+func (in *HTTPRouteValidationOptions) DeepCopy() *HTTPRouteValidationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRouteValidationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMConfig) DeepCopyInto(out *IPAMConfig) {
+	*out = *in
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]IPAMPoolConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReclaimGracePeriod != nil {
+		in, out := &in.ReclaimGracePeriod, &out.ReclaimGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMConfig.
+func (in *IPAMConfig) DeepCopy() *IPAMConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMPoolConfig) DeepCopyInto(out *IPAMPoolConfig) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultPrefixLength != nil {
+		in, out := &in.DefaultPrefixLength, &out.DefaultPrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMPoolConfig.
+func (in *IPAMPoolConfig) DeepCopy() *IPAMPoolConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMPoolConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IrohConnectorConfig) DeepCopyInto(out *IrohConnectorConfig) {
+	*out = *in
+	out.DNSZoneRef = in.DNSZoneRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IrohConnectorConfig.
+func (in *IrohConnectorConfig) DeepCopy() *IrohConnectorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IrohConnectorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IrohDNSZoneRef) DeepCopyInto(out *IrohDNSZoneRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IrohDNSZoneRef.
+func (in *IrohDNSZoneRef) DeepCopy() *IrohDNSZoneRef {
+	if in == nil {
+		return nil
+	}
+	out := new(IrohDNSZoneRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderElectionConfig) DeepCopyInto(out *LeaderElectionConfig) {
+	*out = *in
+	out.LeaseDuration = in.LeaseDuration
+	out.RenewDeadline = in.RenewDeadline
+	out.RetryPeriod = in.RetryPeriod
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderElectionConfig.
 func (in *LeaderElectionConfig) DeepCopy() *LeaderElectionConfig {
 	if in == nil {
@@ -491,6 +1169,21 @@ func (in *LeaderElectionConfig) DeepCopy() *LeaderElectionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerShardingConfig) DeepCopyInto(out *ListenerShardingConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerShardingConfig.
+func (in *ListenerShardingConfig) DeepCopy() *ListenerShardingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerShardingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsServerConfig) DeepCopyInto(out *MetricsServerConfig) {
 	*out = *in
@@ -519,17 +1212,35 @@ func (in *NetworkServicesOperator) DeepCopyInto(out *NetworkServicesOperator) {
 	in.MetricsServer.DeepCopyInto(&out.MetricsServer)
 	in.WebhookServer.DeepCopyInto(&out.WebhookServer)
 	in.Gateway.DeepCopyInto(&out.Gateway)
-	out.HTTPProxy = in.HTTPProxy
+	in.HTTPProxy.DeepCopyInto(&out.HTTPProxy)
 	out.Connector = in.Connector
-	out.Discovery = in.Discovery
+	in.Discovery.DeepCopyInto(&out.Discovery)
+	in.IPAM.DeepCopyInto(&out.IPAM)
 	out.DownstreamResourceManagement = in.DownstreamResourceManagement
 	in.Redis.DeepCopyInto(&out.Redis)
 	out.LeaderElection = in.LeaderElection
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make(map[string]ControllerConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	in.DomainVerification.DeepCopyInto(&out.DomainVerification)
 	in.DomainRegistration.DeepCopyInto(&out.DomainRegistration)
 	out.ControlPlaneClient = in.ControlPlaneClient
 	out.DownstreamClient = in.DownstreamClient
+	out.DownstreamCircuitBreaker = in.DownstreamCircuitBreaker
+	out.UpstreamOutage = in.UpstreamOutage
 	out.ProjectClient = in.ProjectClient
+	in.CryptoPolicy.DeepCopyInto(&out.CryptoPolicy)
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkServicesOperator.
@@ -570,6 +1281,68 @@ func (in *OIDCValidationOptions) DeepCopy() *OIDCValidationOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectFilterConfig) DeepCopyInto(out *ProjectFilterConfig) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeSelector != nil {
+		in, out := &in.ExcludeSelector, &out.ExcludeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExcludeAnnotations != nil {
+		in, out := &in.ExcludeAnnotations, &out.ExcludeAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.RecheckInterval = in.RecheckInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectFilterConfig.
+func (in *ProjectFilterConfig) DeepCopy() *ProjectFilterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectFilterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimiterConfig) DeepCopyInto(out *RateLimiterConfig) {
+	*out = *in
+	out.BaseDelay = in.BaseDelay
+	out.MaxDelay = in.MaxDelay
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimiterConfig.
+func (in *RateLimiterConfig) DeepCopy() *RateLimiterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimiterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisConfig) DeepCopyInto(out *RedisConfig) {
 	*out = *in
@@ -663,6 +1436,21 @@ func (in *RegistryDataConfig) DeepCopy() *RegistryDataConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryDataProviderRateLimitConfig) DeepCopyInto(out *RegistryDataProviderRateLimitConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryDataProviderRateLimitConfig.
+func (in *RegistryDataProviderRateLimitConfig) DeepCopy() *RegistryDataProviderRateLimitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryDataProviderRateLimitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryDataRateLimitsConfig) DeepCopyInto(out *RegistryDataRateLimitsConfig) {
 	*out = *in
@@ -671,6 +1459,11 @@ func (in *RegistryDataRateLimitsConfig) DeepCopyInto(out *RegistryDataRateLimits
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]RegistryDataProviderRateLimitConfig, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryDataRateLimitsConfig.
@@ -765,6 +1558,63 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSSettings) DeepCopyInto(out *TLSSettings) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSettings.
+func (in *TLSSettings) DeepCopy() *TLSSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamOutageConfig) DeepCopyInto(out *UpstreamOutageConfig) {
+	*out = *in
+	out.Threshold = in.Threshold
+	out.RetryInterval = in.RetryInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamOutageConfig.
+func (in *UpstreamOutageConfig) DeepCopy() *UpstreamOutageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamOutageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WAFEventsConfig) DeepCopyInto(out *WAFEventsConfig) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WAFEventsConfig.
+func (in *WAFEventsConfig) DeepCopy() *WAFEventsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WAFEventsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookServerConfig) DeepCopyInto(out *WebhookServerConfig) {
 	*out = *in
@@ -57,6 +57,14 @@ func SetObjectDefaults_NetworkServicesOperator(in *NetworkServicesOperator) {
 			panic(err)
 		}
 	}
+	if in.Gateway.Coraza.Events.MetricName == "" {
+		in.Gateway.Coraza.Events.MetricName = "datum_waf_events_total"
+	}
+	if in.Gateway.Coraza.Events.RefreshInterval == nil {
+		if err := json.Unmarshal([]byte(`"5m"`), &in.Gateway.Coraza.Events.RefreshInterval); err != nil {
+			panic(err)
+		}
+	}
 	if in.Gateway.ErrorPage.MinStatusCode == 0 {
 		in.Gateway.ErrorPage.MinStatusCode = 500
 	}
@@ -230,18 +238,82 @@ func SetObjectDefaults_NetworkServicesOperator(in *NetworkServicesOperator) {
 		in.Gateway.ExtensionAPIValidationOptions.SecurityPolicies.ClusterSettings.HTTP2MaxConcurrentStreams = 1024
 	}
 	SetDefaults_GatewayResourceReplicatorConfig(&in.Gateway.ResourceReplicator)
+	if in.Gateway.DownstreamBackendMode == "" {
+		in.Gateway.DownstreamBackendMode = "Services"
+	}
 	if in.Gateway.MaxConcurrentReconciles == 0 {
 		in.Gateway.MaxConcurrentReconciles = 5
 	}
 	if in.Gateway.CertificateReissuance.MaxRetries == 0 {
 		in.Gateway.CertificateReissuance.MaxRetries = 3
 	}
+	if in.Gateway.ListenerSharding.MaxListenersPerGateway == 0 {
+		in.Gateway.ListenerSharding.MaxListenersPerGateway = 64
+	}
+	if in.Gateway.InvalidListenerPolicy == "" {
+		in.Gateway.InvalidListenerPolicy = "ExcludeListeners"
+	}
+	if in.Gateway.CAA.CacheTTL == nil {
+		if err := json.Unmarshal([]byte(`"5m"`), &in.Gateway.CAA.CacheTTL); err != nil {
+			panic(err)
+		}
+	}
+	if in.Gateway.TLSPolicy.MinVersion == "" {
+		in.Gateway.TLSPolicy.MinVersion = "1.2"
+	}
+	if in.Gateway.HTTP3 == "" {
+		in.Gateway.HTTP3 = "Disabled"
+	}
+	if in.Gateway.DNSRecords.TTL == 0 {
+		in.Gateway.DNSRecords.TTL = 300
+	}
+	if in.Gateway.DNSRecords.MinTTL == 0 {
+		in.Gateway.DNSRecords.MinTTL = 60
+	}
+	if in.Gateway.DNSRecords.MaxTTL == 0 {
+		in.Gateway.DNSRecords.MaxTTL = 86400
+	}
+	if in.Gateway.DNSRecords.Policy == "" {
+		in.Gateway.DNSRecords.Policy = "Standard"
+	}
+	if in.Gateway.DNSVerification.RecheckInterval == nil {
+		if err := json.Unmarshal([]byte(`"30s"`), &in.Gateway.DNSVerification.RecheckInterval); err != nil {
+			panic(err)
+		}
+	}
+	if in.Gateway.DNSVerification.CacheTTL == nil {
+		if err := json.Unmarshal([]byte(`"10m"`), &in.Gateway.DNSVerification.CacheTTL); err != nil {
+			panic(err)
+		}
+	}
+	if in.Gateway.DNSFailover.RecheckInterval == nil {
+		if err := json.Unmarshal([]byte(`"30s"`), &in.Gateway.DNSFailover.RecheckInterval); err != nil {
+			panic(err)
+		}
+	}
+	if in.Gateway.DomainGC.RetentionPeriod == nil {
+		if err := json.Unmarshal([]byte(`"24h"`), &in.Gateway.DomainGC.RetentionPeriod); err != nil {
+			panic(err)
+		}
+	}
+	if in.Gateway.Maintenance.StatusCode == 0 {
+		in.Gateway.Maintenance.StatusCode = 503
+	}
+	if in.Gateway.Maintenance.ContentType == "" {
+		in.Gateway.Maintenance.ContentType = "text/plain"
+	}
+	if in.Gateway.Maintenance.Body == "" {
+		in.Gateway.Maintenance.Body = "Service temporarily unavailable for maintenance"
+	}
+	SetDefaults_GatewayPayloadPolicyConfig(&in.Gateway.PayloadPolicy)
 	if in.HTTPProxy.GatewayClassName == "" {
 		in.HTTPProxy.GatewayClassName = "datum-external-global-proxy"
 	}
 	if in.HTTPProxy.MaxConcurrentReconciles == 0 {
 		in.HTTPProxy.MaxConcurrentReconciles = 5
 	}
+	SetDefaults_BackendResolutionConfig(&in.HTTPProxy.BackendResolution)
+	SetDefaults_HTTPProxyRequestPolicyConfig(&in.HTTPProxy.RequestPolicy)
 	if in.Connector.LeaseDurationSeconds == 0 {
 		in.Connector.LeaseDurationSeconds = 30
 	}
@@ -252,6 +324,14 @@ func SetObjectDefaults_NetworkServicesOperator(in *NetworkServicesOperator) {
 		in.Connector.Iroh.TTLSeconds = 5
 	}
 	SetDefaults_DiscoveryConfig(&in.Discovery)
+	if in.Discovery.ProjectFilter != nil {
+		SetDefaults_ProjectFilterConfig(in.Discovery.ProjectFilter)
+	}
+	SetDefaults_IPAMConfig(&in.IPAM)
+	SetDefaults_DownstreamAuditConfig(&in.DownstreamResourceManagement.Audit)
+	SetDefaults_DownstreamNamespaceGCConfig(&in.DownstreamResourceManagement.NamespaceGC)
+	SetDefaults_DownstreamClusterDisengagementConfig(&in.DownstreamResourceManagement.ClusterDisengagement)
+	SetDefaults_DownstreamInventoryConfig(&in.DownstreamResourceManagement.Inventory)
 	if in.Redis.DialTimeout == nil {
 		if err := json.Unmarshal([]byte(`"5s"`), &in.Redis.DialTimeout); err != nil {
 			panic(err)
@@ -285,6 +365,19 @@ func SetObjectDefaults_NetworkServicesOperator(in *NetworkServicesOperator) {
 	if in.DomainVerification.HTTPVerificationTokenPath == "" {
 		in.DomainVerification.HTTPVerificationTokenPath = ".well-known/datum-custom-hostname-challenge"
 	}
+	if in.DomainVerification.ReverificationInterval == nil {
+		if err := json.Unmarshal([]byte(`"24h"`), &in.DomainVerification.ReverificationInterval); err != nil {
+			panic(err)
+		}
+	}
+	if in.DomainVerification.ReverificationRetryInterval == nil {
+		if err := json.Unmarshal([]byte(`"5m"`), &in.DomainVerification.ReverificationRetryInterval); err != nil {
+			panic(err)
+		}
+	}
+	if in.DomainVerification.ReverificationFailureThreshold == 0 {
+		in.DomainVerification.ReverificationFailureThreshold = 3
+	}
 	if in.DomainRegistration.RefreshInterval == nil {
 		if err := json.Unmarshal([]byte(`"24h"`), &in.DomainRegistration.RefreshInterval); err != nil {
 			panic(err)
@@ -312,6 +405,9 @@ func SetObjectDefaults_NetworkServicesOperator(in *NetworkServicesOperator) {
 	if in.DomainRegistration.RegistryData.Cache.RedisKeyPrefix == "" {
 		in.DomainRegistration.RegistryData.Cache.RedisKeyPrefix = "network-services-operator:"
 	}
+	if in.DomainRegistration.RegistryData.Cache.MaxEntries == 0 {
+		in.DomainRegistration.RegistryData.Cache.MaxEntries = 10000
+	}
 	if in.DomainRegistration.RegistryData.CacheTTLs.Domain == nil {
 		if err := json.Unmarshal([]byte(`"15m"`), &in.DomainRegistration.RegistryData.CacheTTLs.Domain); err != nil {
 			panic(err)
@@ -352,6 +448,14 @@ func SetObjectDefaults_NetworkServicesOperator(in *NetworkServicesOperator) {
 	if in.DownstreamClient.Burst == 0 {
 		in.DownstreamClient.Burst = 100
 	}
+	SetDefaults_CircuitBreakerConfig(&in.DownstreamCircuitBreaker)
+	if in.DownstreamCircuitBreaker.ErrorRateThreshold == 0 {
+		in.DownstreamCircuitBreaker.ErrorRateThreshold = 50
+	}
+	if in.DownstreamCircuitBreaker.MinimumRequests == 0 {
+		in.DownstreamCircuitBreaker.MinimumRequests = 20
+	}
+	SetDefaults_UpstreamOutageConfig(&in.UpstreamOutage)
 	SetDefaults_ClientConnectionConfig(&in.ProjectClient)
 	if in.ProjectClient.QPS == 0 {
 		in.ProjectClient.QPS = 50
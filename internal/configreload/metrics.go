@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package configreload
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Reload results, used as the "result" label of reloadsTotal.
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+var (
+	// reloadsTotal counts reloads of the server config file by result. A
+	// failed reload leaves the previous configuration in use.
+	reloadsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nso_config_reloads_total",
+			Help: "Total reloads of the server config file by result (success | failure).",
+		},
+		[]string{"result"},
+	)
+
+	// lastReloadSuccessful alerts on config files that could not be applied:
+	//   nso_config_last_reload_successful == 0
+	lastReloadSuccessful = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "nso_config_last_reload_successful",
+			Help: "1 if the last reload of the server config file succeeded, 0 otherwise.",
+		},
+	)
+
+	lastReloadSuccessTimestamp = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "nso_config_last_reload_success_timestamp_seconds",
+			Help: "Unix time of the last successful reload of the server config file.",
+		},
+	)
+
+	// restartRequired is set when the server config file changes fields that
+	// are not reloaded, so that the change is not mistaken for being applied.
+	restartRequired = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "nso_config_restart_required",
+			Help: "1 if the server config file changes fields that only take effect after a restart, 0 otherwise.",
+		},
+	)
+)
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package configreload reloads the server config file of the manager when it
+// changes, so that the config.ReloadableFields take effect without a restart.
+package configreload
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// defaultDebounce is how long the watcher waits for further changes before
+// reloading. Updates of mounted ConfigMaps produce a burst of events as the
+// data directory is swapped.
+const defaultDebounce = time.Second
+
+var (
+	_ manager.Runnable               = &Watcher{}
+	_ manager.LeaderElectionRunnable = &Watcher{}
+)
+
+// Watcher watches the server config file and applies its reloadable fields to
+// Config whenever its contents change. Contents that fail to decode or
+// validate are rejected, and the previous configuration stays in use.
+type Watcher struct {
+	// Path of the server config file.
+	Path string
+
+	// Config receives the reloaded configuration.
+	Config *config.Reloadable
+
+	// Decode decodes and defaults the contents of the config file, the same way
+	// the manager does at startup.
+	Decode func(data []byte) (config.NetworkServicesOperator, error)
+
+	// Debounce is how long to wait for further changes to the file before
+	// reloading it. Defaults to one second.
+	Debounce time.Duration
+
+	lastData []byte
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica
+// reloads its own configuration.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start watches the config file until ctx is done.
+func (w *Watcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("config-reload").WithValues("path", w.Path)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed creating config file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch the directory rather than the file, as mounted ConfigMaps replace
+	// the file through a symlink instead of writing to it.
+	if err := watcher.Add(filepath.Dir(w.Path)); err != nil {
+		return fmt.Errorf("failed watching config file directory: %w", err)
+	}
+
+	// Pick up any change made between startup and the watch being added.
+	w.reload(logger)
+
+	debounce := w.Debounce
+	if debounce <= 0 {
+		debounce = defaultDebounce
+	}
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			timer.Reset(debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Error(err, "config file watch error")
+		case <-timer.C:
+			w.reload(logger)
+		}
+	}
+}
+
+// reload applies the config file if its contents changed since the last
+// reload, and records the result.
+func (w *Watcher) reload(logger logr.Logger) {
+	changed, needsRestart, err := w.apply()
+	switch {
+	case err != nil:
+		reloadsTotal.WithLabelValues(resultFailure).Inc()
+		lastReloadSuccessful.Set(0)
+		logger.Error(err, "failed reloading server config, keeping the previous config")
+	case changed:
+		reloadsTotal.WithLabelValues(resultSuccess).Inc()
+		lastReloadSuccessful.Set(1)
+		lastReloadSuccessTimestamp.SetToCurrentTime()
+		if needsRestart {
+			restartRequired.Set(1)
+			logger.Info("reloaded server config; changes to fields other than the reloadable fields take effect after a restart",
+				"reloadableFields", config.ReloadableFields)
+		} else {
+			restartRequired.Set(0)
+			logger.Info("reloaded server config")
+		}
+	}
+}
+
+// apply reads, decodes and applies the config file. It reports whether the
+// contents changed since the last successful apply.
+func (w *Watcher) apply() (changed, restartRequired bool, err error) {
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		return false, false, fmt.Errorf("failed reading server config: %w", err)
+	}
+	if w.lastData != nil && bytes.Equal(data, w.lastData) {
+		return false, false, nil
+	}
+
+	next, err := w.Decode(data)
+	if err != nil {
+		return false, false, fmt.Errorf("failed decoding server config: %w", err)
+	}
+	restartRequired, err = w.Config.Apply(next)
+	if err != nil {
+		return false, false, err
+	}
+	w.lastData = data
+	return true, restartRequired, nil
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package configreload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func decode(data []byte) (config.NetworkServicesOperator, error) {
+	var cfg config.NetworkServicesOperator
+	err := yaml.UnmarshalStrict(data, &cfg)
+	return cfg, err
+}
+
+func writeConfig(t *testing.T, path, contents string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+}
+
+func newWatcher(t *testing.T, contents string) *Watcher {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, contents)
+
+	initial, err := decode([]byte(contents))
+	require.NoError(t, err)
+	return &Watcher{
+		Path:   path,
+		Config: config.NewReloadable(initial),
+		Decode: decode,
+	}
+}
+
+func TestWatcherApply(t *testing.T) {
+	w := newWatcher(t, `
+gateway:
+  clusterIssuerMap:
+    auto: letsencrypt
+`)
+
+	changed, restartRequired, err := w.apply()
+	require.NoError(t, err)
+	assert.True(t, changed, "the first apply records the contents of the file")
+	assert.False(t, restartRequired)
+
+	changed, _, err = w.apply()
+	require.NoError(t, err)
+	assert.False(t, changed, "unchanged contents should not be applied again")
+
+	writeConfig(t, w.Path, `
+gateway:
+  targetDomain: example.net
+  clusterIssuerMap:
+    auto: letsencrypt-staging
+`)
+	changed, restartRequired, err = w.apply()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, restartRequired, "gateway.targetDomain is not reloadable")
+	assert.Equal(t, "letsencrypt-staging", w.Config.Load().Gateway.ClusterIssuerMap["auto"])
+	assert.Empty(t, w.Config.Load().Gateway.TargetDomain)
+}
+
+func TestWatcherApplyRejectsInvalidConfig(t *testing.T) {
+	w := newWatcher(t, `
+gateway:
+  clusterIssuerMap:
+    auto: letsencrypt
+`)
+
+	scenarios := map[string]string{
+		"undecodable": "gateway: [",
+		"unknown field": `
+gateway:
+  clusterIssuerMaps: {}
+`,
+		"invalid": `
+gateway:
+  clusterIssuerMap:
+    auto: ""
+`,
+	}
+	for name, contents := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			writeConfig(t, w.Path, contents)
+			_, _, err := w.apply()
+			assert.Error(t, err)
+			assert.Equal(t, "letsencrypt", w.Config.Load().Gateway.ClusterIssuerMap["auto"])
+		})
+	}
+}
+
+func TestWatcherStart(t *testing.T) {
+	w := newWatcher(t, `
+gateway:
+  clusterIssuerMap:
+    auto: letsencrypt
+`)
+	w.Debounce = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Start(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	// Wait for the initial reload, which follows the watch being added.
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(lastReloadSuccessful) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	writeConfig(t, w.Path, `
+gateway:
+  clusterIssuerMap:
+    auto: ""
+`)
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(lastReloadSuccessful) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "letsencrypt", w.Config.Load().Gateway.ClusterIssuerMap["auto"])
+
+	writeConfig(t, w.Path, `
+gateway:
+  clusterIssuerMap:
+    auto: letsencrypt-staging
+`)
+	require.Eventually(t, func() bool {
+		return w.Config.Load().Gateway.ClusterIssuerMap["auto"] == "letsencrypt-staging"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, float64(1), testutil.ToFloat64(lastReloadSuccessful))
+}
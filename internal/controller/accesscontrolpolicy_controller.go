@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+	mcsource "sigs.k8s.io/multicluster-runtime/pkg/source"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+const accessControlPolicyFinalizer = "networking.datumapis.com/accesscontrolpolicy-cleanup"
+
+// AccessControlPolicyReconciler reconciles an AccessControlPolicy object
+type AccessControlPolicyReconciler struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+
+	DownstreamCluster cluster.Cluster
+
+	// UpstreamOutages, when set, retries reconciles while the API server of
+	// the policy's cluster is unreachable.
+	UpstreamOutages *UpstreamOutageTracker
+}
+
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=accesscontrolpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=accesscontrolpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=accesscontrolpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=securitypolicies,verbs=get;list;watch;create;update;patch;delete
+
+func (r *AccessControlPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	return r.localPolicy().reconciler().reconcile(ctx, r.mgr, r.DownstreamCluster, r.UpstreamOutages, req)
+}
+
+func (r *AccessControlPolicyReconciler) localPolicy() *targetedLocalPolicy[*networkingv1alpha.AccessControlPolicy, *envoygatewayv1alpha1.SecurityPolicy] {
+	return &targetedLocalPolicy[*networkingv1alpha.AccessControlPolicy, *envoygatewayv1alpha1.SecurityPolicy]{
+		name:           "accesscontrolpolicy",
+		kind:           "AccessControlPolicy",
+		finalizer:      accessControlPolicyFinalizer,
+		controllerName: string(r.Config.Gateway.ControllerName),
+		newPolicy:      func() *networkingv1alpha.AccessControlPolicy { return &networkingv1alpha.AccessControlPolicy{} },
+		targetRefs: func(policy *networkingv1alpha.AccessControlPolicy) []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+			return policy.Spec.TargetRefs
+		},
+		policyStatus: func(policy *networkingv1alpha.AccessControlPolicy) *gatewayv1alpha2.PolicyStatus {
+			return &policy.Status.PolicyStatus
+		},
+		listPolicies: listSecurityLocalPolicies,
+		newDownstream: func(policy *networkingv1alpha.AccessControlPolicy) *envoygatewayv1alpha1.SecurityPolicy {
+			return &envoygatewayv1alpha1.SecurityPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: downstreamAccessControlPolicyName(policy)},
+			}
+		},
+		desiredDownstream: desiredAccessControlSecurityPolicy,
+	}
+}
+
+// downstreamAccessControlPolicyName returns the name of the SecurityPolicy
+// that programs an AccessControlPolicy. It is prefixed so that it doesn't
+// collide with SecurityPolicies replicated from the upstream namespace.
+func downstreamAccessControlPolicyName(policy *networkingv1alpha.AccessControlPolicy) string {
+	return resourcename.GetValidDNS1123Name(fmt.Sprintf("access-control-%s", policy.Name))
+}
+
+// desiredAccessControlSecurityPolicy programs the allow and deny lists of the
+// policy on the downstream SecurityPolicy attached to the accepted targets.
+func desiredAccessControlSecurityPolicy(
+	policy *networkingv1alpha.AccessControlPolicy,
+	securityPolicy *envoygatewayv1alpha1.SecurityPolicy,
+	targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+) string {
+	securityPolicy.Spec = envoygatewayv1alpha1.SecurityPolicySpec{
+		PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+			TargetRefs: targetRefs,
+		},
+		MergeType:     routePolicyMergeType(targetRefs),
+		Authorization: desiredAccessControlAuthorization(policy.Spec),
+	}
+	return ""
+}
+
+// desiredAccessControlAuthorization translates the allow and deny lists of an
+// AccessControlPolicy to Envoy Gateway authorization rules. Rules are
+// evaluated in order, so the deny rule comes first. Requests that match
+// neither list are denied when an allow list is set, and allowed otherwise.
+func desiredAccessControlAuthorization(spec networkingv1alpha.AccessControlPolicySpec) *envoygatewayv1alpha1.Authorization {
+	authorization := &envoygatewayv1alpha1.Authorization{
+		DefaultAction: ptr.To(envoygatewayv1alpha1.AuthorizationActionAllow),
+	}
+
+	if len(spec.Deny) > 0 {
+		authorization.Rules = append(authorization.Rules, envoygatewayv1alpha1.AuthorizationRule{
+			Name:      ptr.To("deny"),
+			Action:    envoygatewayv1alpha1.AuthorizationActionDeny,
+			Principal: envoygatewayv1alpha1.Principal{ClientCIDRs: accessControlCIDRs(spec.Deny)},
+		})
+	}
+
+	if len(spec.Allow) > 0 {
+		authorization.Rules = append(authorization.Rules, envoygatewayv1alpha1.AuthorizationRule{
+			Name:      ptr.To("allow"),
+			Action:    envoygatewayv1alpha1.AuthorizationActionAllow,
+			Principal: envoygatewayv1alpha1.Principal{ClientCIDRs: accessControlCIDRs(spec.Allow)},
+		})
+		authorization.DefaultAction = ptr.To(envoygatewayv1alpha1.AuthorizationActionDeny)
+	}
+
+	return authorization
+}
+
+func accessControlCIDRs(cidrs []networkingv1alpha.CIDR) []envoygatewayv1alpha1.CIDR {
+	result := make([]envoygatewayv1alpha1.CIDR, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		result = append(result, envoygatewayv1alpha1.CIDR(cidr))
+	}
+	return result
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AccessControlPolicyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+
+	downstreamSecurityPolicySource := mcsource.TypedKind(
+		&envoygatewayv1alpha1.SecurityPolicy{},
+		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*envoygatewayv1alpha1.SecurityPolicy](&networkingv1alpha.AccessControlPolicy{}),
+	)
+
+	downstreamSecurityPolicyClusterSource, _, _ := downstreamSecurityPolicySource.ForCluster("", r.DownstreamCluster)
+
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&networkingv1alpha.AccessControlPolicy{}).
+		Watches(&gatewayv1.Gateway{}, enqueueLocalPoliciesForTargetFunc(KindGateway, listAccessControlPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&gatewayv1.HTTPRoute{}, enqueueLocalPoliciesForTargetFunc(KindHTTPRoute, listAccessControlPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.HTTPProxy{}, enqueueLocalPoliciesForTargetFunc(KindHTTPProxy, listAccessControlPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.AuthenticationPolicy{}, enqueueLocalPoliciesInNamespaceFunc(listAccessControlPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		WatchesRawSource(downstreamSecurityPolicyClusterSource).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "accesscontrolpolicy", 0)).
+		Named("accesscontrolpolicy").
+		Complete(r)
+}
+
+func listAccessControlPolicies(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error) {
+	var policies networkingv1alpha.AccessControlPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing accesscontrolpolicies: %w", err)
+	}
+
+	localPolicies := make([]localPolicy, 0, len(policies.Items))
+	for i := range policies.Items {
+		localPolicies = append(localPolicies, localPolicy{Object: &policies.Items[i], kind: "AccessControlPolicy", targetRefs: policies.Items[i].Spec.TargetRefs})
+	}
+	return localPolicies, nil
+}
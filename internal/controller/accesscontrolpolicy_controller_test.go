@@ -0,0 +1,241 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestDesiredAccessControlAuthorization(t *testing.T) {
+	tests := []struct {
+		name string
+		spec networkingv1alpha.AccessControlPolicySpec
+		want *envoygatewayv1alpha1.Authorization
+	}{
+		{
+			name: "deny list allows other clients",
+			spec: networkingv1alpha.AccessControlPolicySpec{
+				Deny: []networkingv1alpha.CIDR{"192.0.2.0/24"},
+			},
+			want: &envoygatewayv1alpha1.Authorization{
+				Rules: []envoygatewayv1alpha1.AuthorizationRule{
+					{
+						Name:      ptr.To("deny"),
+						Action:    envoygatewayv1alpha1.AuthorizationActionDeny,
+						Principal: envoygatewayv1alpha1.Principal{ClientCIDRs: []envoygatewayv1alpha1.CIDR{"192.0.2.0/24"}},
+					},
+				},
+				DefaultAction: ptr.To(envoygatewayv1alpha1.AuthorizationActionAllow),
+			},
+		},
+		{
+			name: "allow list denies other clients, deny takes precedence",
+			spec: networkingv1alpha.AccessControlPolicySpec{
+				Allow: []networkingv1alpha.CIDR{"198.51.100.0/24", "2001:db8::/32"},
+				Deny:  []networkingv1alpha.CIDR{"198.51.100.7/32"},
+			},
+			want: &envoygatewayv1alpha1.Authorization{
+				Rules: []envoygatewayv1alpha1.AuthorizationRule{
+					{
+						Name:      ptr.To("deny"),
+						Action:    envoygatewayv1alpha1.AuthorizationActionDeny,
+						Principal: envoygatewayv1alpha1.Principal{ClientCIDRs: []envoygatewayv1alpha1.CIDR{"198.51.100.7/32"}},
+					},
+					{
+						Name:      ptr.To("allow"),
+						Action:    envoygatewayv1alpha1.AuthorizationActionAllow,
+						Principal: envoygatewayv1alpha1.Principal{ClientCIDRs: []envoygatewayv1alpha1.CIDR{"198.51.100.0/24", "2001:db8::/32"}},
+					},
+				},
+				DefaultAction: ptr.To(envoygatewayv1alpha1.AuthorizationActionDeny),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, desiredAccessControlAuthorization(tt.spec))
+		})
+	}
+}
+
+func TestAccessControlPolicyReconcile(t *testing.T) {
+	policy := &networkingv1alpha.AccessControlPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  localPolicyTestNamespace,
+			Name:       "policy",
+			UID:        "policy-uid",
+			Finalizers: []string{accessControlPolicyFinalizer},
+		},
+		Spec: networkingv1alpha.AccessControlPolicySpec{
+			TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+						Group: gatewayv1.GroupName,
+						Kind:  KindGateway,
+						Name:  "gw",
+					},
+				},
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+						Group: gatewayv1.GroupName,
+						Kind:  KindHTTPRoute,
+						Name:  "missing",
+					},
+				},
+			},
+			Deny: []networkingv1alpha.CIDR{"192.0.2.0/24"},
+		},
+	}
+
+	fakeUpstreamClient, fakeDownstreamClient := newLocalPolicyTestClients(
+		t,
+		&networkingv1alpha.AccessControlPolicy{},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "gw"},
+			Spec: gatewayv1.GatewaySpec{
+				Listeners: []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}},
+			},
+		},
+		policy,
+	)
+
+	reconciler := &AccessControlPolicyReconciler{
+		mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+		Config:            localPolicyTestConfig,
+	}
+
+	ctx := context.Background()
+	req := localPolicyTestRequest(policy.Name)
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+
+	var updated networkingv1alpha.AccessControlPolicy
+	assert.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &updated))
+	reasons := map[gatewayv1.ObjectName]string{}
+	for _, ancestor := range updated.Status.Ancestors {
+		if condition := apimeta.FindStatusCondition(ancestor.Conditions, string(gatewayv1.PolicyConditionAccepted)); condition != nil {
+			reasons[ancestor.AncestorRef.Name] = condition.Reason
+		}
+	}
+	assert.Equal(t, map[gatewayv1.ObjectName]string{
+		"gw":      string(gatewayv1.PolicyReasonAccepted),
+		"missing": string(gatewayv1.PolicyReasonTargetNotFound),
+	}, reasons)
+
+	var securityPolicy envoygatewayv1alpha1.SecurityPolicy
+	assert.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "access-control-policy"}, &securityPolicy))
+	assert.Equal(t, []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+		{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  KindGateway,
+				Name:  "gw",
+			},
+		},
+	}, securityPolicy.Spec.TargetRefs)
+	assert.Nil(t, securityPolicy.Spec.MergeType)
+	assert.Equal(t, desiredAccessControlAuthorization(policy.Spec), securityPolicy.Spec.Authorization)
+}
+
+func TestAccessControlPolicyReconcileHTTPProxyAuth(t *testing.T) {
+	httpProxy := &networkingv1alpha.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "proxy", UID: "proxy-uid"},
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Rules: []networkingv1alpha.HTTPProxyRule{{Name: ptr.To(gatewayv1.SectionName("api"))}},
+			Auth: &networkingv1alpha.HTTPProxyAuth{
+				Basic: &networkingv1alpha.HTTPProxyBasicAuth{
+					UsersRef: corev1.LocalObjectReference{Name: "users"},
+				},
+			},
+		},
+	}
+
+	// The policy targets a rule of the proxy, which the auth of the proxy
+	// already protects.
+	policy := &networkingv1alpha.AccessControlPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  localPolicyTestNamespace,
+			Name:       "policy",
+			UID:        "policy-uid",
+			Finalizers: []string{accessControlPolicyFinalizer},
+		},
+		Spec: networkingv1alpha.AccessControlPolicySpec{
+			TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+						Group: gatewayv1.Group(networkingv1alpha.GroupVersion.Group),
+						Kind:  KindHTTPProxy,
+						Name:  "proxy",
+					},
+					SectionName: ptr.To(gatewayv1.SectionName("api")),
+				},
+			},
+			Deny: []networkingv1alpha.CIDR{"192.0.2.0/24"},
+		},
+	}
+
+	fakeUpstreamClient, fakeDownstreamClient := newLocalPolicyTestClients(
+		t,
+		&networkingv1alpha.AccessControlPolicy{},
+		httpProxy,
+		policy,
+	)
+
+	reconciler := &AccessControlPolicyReconciler{
+		mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+		Config:            localPolicyTestConfig,
+	}
+
+	ctx := context.Background()
+	req := localPolicyTestRequest(policy.Name)
+	_, err := reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+
+	var updated networkingv1alpha.AccessControlPolicy
+	assert.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &updated))
+	if assert.Len(t, updated.Status.Ancestors, 1) {
+		condition := apimeta.FindStatusCondition(updated.Status.Ancestors[0].Conditions, string(gatewayv1.PolicyConditionAccepted))
+		if assert.NotNil(t, condition) {
+			assert.Equal(t, string(gatewayv1.PolicyReasonConflicted), condition.Reason)
+			assert.Equal(t, "Unable to target HTTPProxy proxy, the auth configuration of HTTPProxy proxy has already attached to it", condition.Message)
+		}
+	}
+
+	var securityPolicy envoygatewayv1alpha1.SecurityPolicy
+	err = fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "access-control-policy"}, &securityPolicy)
+	assert.True(t, apierrors.IsNotFound(err), "expected no downstream access control policy, got %v", err)
+
+	// Once the proxy no longer configures auth, the policy is accepted.
+	var updatedProxy networkingv1alpha.HTTPProxy
+	assert.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(httpProxy), &updatedProxy))
+	updatedProxy.Spec.Auth = nil
+	assert.NoError(t, fakeUpstreamClient.Update(ctx, &updatedProxy))
+	_, err = reconciler.Reconcile(ctx, req)
+	assert.NoError(t, err)
+
+	assert.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &updated))
+	if assert.Len(t, updated.Status.Ancestors, 1) {
+		condition := apimeta.FindStatusCondition(updated.Status.Ancestors[0].Conditions, string(gatewayv1.PolicyConditionAccepted))
+		if assert.NotNil(t, condition) {
+			assert.Equal(t, string(gatewayv1.PolicyReasonAccepted), condition.Reason)
+		}
+	}
+	if assert.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "access-control-policy"}, &securityPolicy)) {
+		assert.Equal(t, ptr.To(envoygatewayv1alpha1.StrategicMerge), securityPolicy.Spec.MergeType)
+	}
+}
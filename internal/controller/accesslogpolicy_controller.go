@@ -0,0 +1,507 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+	mcsource "sigs.k8s.io/multicluster-runtime/pkg/source"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+const accessLogPolicyFinalizer = "networking.datumapis.com/accesslogpolicy-cleanup"
+
+// managedAccessLogSettingsAnnotation is set on the EnvoyProxy of the
+// downstream GatewayClass to the number of its trailing access log settings
+// that program AccessLogPolicies. Settings of the platform must therefore be
+// placed before them.
+const managedAccessLogSettingsAnnotation = "networking.datumapis.com/managed-access-log-settings"
+
+// maxAccessLogSettings is the maximum number of access log settings of an
+// EnvoyProxy.
+const maxAccessLogSettings = 50
+
+const accessLogStdoutPath = "/dev/stdout"
+
+// Resource attributes added to the access log entries exported to OTLP and S3
+// sinks, which identify the tenant they were logged for.
+const (
+	accessLogClusterNameAttribute   = "datum.cluster.name"
+	accessLogNamespaceNameAttribute = "datum.namespace.name"
+	accessLogPolicyNameAttribute    = "datum.accesslogpolicy.name"
+
+	accessLogS3BucketAttribute   = "datum.s3.bucket"
+	accessLogS3EndpointAttribute = "datum.s3.endpoint"
+	accessLogS3RegionAttribute   = "datum.s3.region"
+	accessLogS3PrefixAttribute   = "datum.s3.prefix"
+)
+
+// AccessLogPolicyReconciler reconciles an AccessLogPolicy object
+type AccessLogPolicyReconciler struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+
+	DownstreamCluster cluster.Cluster
+
+	// UpstreamOutages, when set, retries reconciles while the API server of
+	// the policy's cluster is unreachable.
+	UpstreamOutages *UpstreamOutageTracker
+}
+
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=accesslogpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=accesslogpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=accesslogpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=envoyproxies,verbs=get;list;watch;create;update;patch;delete
+
+func (r *AccessLogPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	return r.reconciler(req.ClusterName).reconcile(ctx, r.mgr, r.DownstreamCluster, r.UpstreamOutages, req)
+}
+
+// reconciler returns the reconciler of the policies of the given cluster,
+// whose name is attached to the access log entries.
+func (r *AccessLogPolicyReconciler) reconciler(clusterName multicluster.ClusterName) *localPolicyReconciler[*networkingv1alpha.AccessLogPolicy] {
+	return &localPolicyReconciler[*networkingv1alpha.AccessLogPolicy]{
+		name:      "accesslogpolicy",
+		finalizer: accessLogPolicyFinalizer,
+		newPolicy: func() *networkingv1alpha.AccessLogPolicy { return &networkingv1alpha.AccessLogPolicy{} },
+		program: func(ctx context.Context, upstreamClient client.Client, policy *networkingv1alpha.AccessLogPolicy, downstreamStrategy downstreamclient.ResourceStrategy) error {
+			return r.program(ctx, clusterName, upstreamClient, policy, downstreamStrategy)
+		},
+		removeDownstream: func(ctx context.Context, policy *networkingv1alpha.AccessLogPolicy, downstreamStrategy downstreamclient.ResourceStrategy) error {
+			_, err := r.ensureDownstreamAccessLogPolicy(ctx, clusterName, policy, nil, downstreamStrategy)
+			return err
+		},
+		// The access log settings of the policy are programmed outside of the
+		// downstream namespace, so they are removed even when the namespace is
+		// terminating.
+		removeWhenTerminating: true,
+	}
+}
+
+// program resolves the targets of the policy, and programs its access log
+// settings for the targets it is accepted for.
+func (r *AccessLogPolicyReconciler) program(
+	ctx context.Context,
+	clusterName multicluster.ClusterName,
+	upstreamClient client.Client,
+	policy *networkingv1alpha.AccessLogPolicy,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) error {
+	controllerName := string(r.Config.Gateway.ControllerName)
+
+	targetRefs, err := resolveLocalPolicyTargets(
+		ctx,
+		upstreamClient,
+		controllerName,
+		localPolicy{Object: policy, kind: "AccessLogPolicy", targetRefs: policy.Spec.TargetRefs},
+		&policy.Status.PolicyStatus,
+		listAccessLogPolicies,
+		localPolicyTargetsEqual,
+	)
+	if err != nil {
+		return err
+	}
+
+	var programmingErr string
+	if len(targetRefs) > 0 && accessLogPolicyHasS3Sink(policy) && r.Config.Gateway.AccessLogging.S3CollectorEndpoint == "" {
+		programmingErr = "S3 access log sinks are not available on this platform"
+		targetRefs = nil
+	}
+
+	programmed, err := r.ensureDownstreamAccessLogPolicy(ctx, clusterName, policy, targetRefs, downstreamStrategy)
+	if err != nil {
+		return err
+	}
+	if len(targetRefs) > 0 && !programmed {
+		programmingErr = fmt.Sprintf("The platform limit of %d access log settings has been reached", maxAccessLogSettings)
+	}
+
+	setLocalPolicyProgrammingStatus(
+		localPolicy{Object: policy, targetRefs: policy.Spec.TargetRefs},
+		&policy.Status.PolicyStatus,
+		controllerName,
+		programmingErr,
+	)
+	return nil
+}
+
+func accessLogPolicyHasS3Sink(policy *networkingv1alpha.AccessLogPolicy) bool {
+	return slices.ContainsFunc(policy.Spec.Sinks, func(sink networkingv1alpha.AccessLogSink) bool {
+		return sink.Type == networkingv1alpha.AccessLogSinkS3
+	})
+}
+
+// downstreamAccessLogPolicyName returns the name of the EnvoyProxy that holds
+// the access log settings of an AccessLogPolicy.
+func downstreamAccessLogPolicyName(policy *networkingv1alpha.AccessLogPolicy) string {
+	return resourcename.GetValidDNS1123Name(fmt.Sprintf("accesslog-%s", policy.Name))
+}
+
+// ensureDownstreamAccessLogPolicy programs the access log settings of a policy
+// for its accepted targets, and returns whether they are programmed on the
+// EnvoyProxy of the downstream GatewayClass.
+//
+// The settings of each policy are kept on an EnvoyProxy in the downstream
+// namespace of the policy, which is not attached to any gateway. The settings
+// of all policies are then copied to the EnvoyProxy of the downstream
+// GatewayClass, as Envoy Gateway ignores the EnvoyProxy of a Gateway when
+// gateways are merged.
+func (r *AccessLogPolicyReconciler) ensureDownstreamAccessLogPolicy(
+	ctx context.Context,
+	clusterName multicluster.ClusterName,
+	policy *networkingv1alpha.AccessLogPolicy,
+	targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	downstreamObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, policy)
+	if err != nil {
+		return false, fmt.Errorf("failed to derive downstream metadata: %w", err)
+	}
+
+	envoyProxy := &envoygatewayv1alpha1.EnvoyProxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: downstreamObjectMeta.Namespace,
+			Name:      downstreamAccessLogPolicyName(policy),
+		},
+	}
+
+	targetRefs, err = downstreamLocalPolicyTargetRefs(ctx, downstreamStrategy.GetClient(), downstreamObjectMeta.Namespace, targetRefs)
+	if err != nil {
+		return false, err
+	}
+
+	if len(targetRefs) == 0 {
+		if err := downstreamStrategy.GetClient().Delete(ctx, envoyProxy); client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("failed deleting downstream access log policy: %w", err)
+		}
+		return r.ensureGatewayClassAccessLogSettings(ctx, client.ObjectKeyFromObject(envoyProxy), nil)
+	}
+
+	setting, err := r.desiredAccessLogSetting(clusterName, policy, downstreamObjectMeta.Namespace, targetRefs)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, downstreamStrategy.GetClient(), envoyProxy, func() error {
+		if err := downstreamStrategy.SetControllerReference(ctx, policy, envoyProxy); err != nil {
+			return fmt.Errorf("failed to set controller reference on downstream access log policy: %w", err)
+		}
+		envoyProxy.Spec = envoygatewayv1alpha1.EnvoyProxySpec{
+			Telemetry: &envoygatewayv1alpha1.ProxyTelemetry{
+				AccessLog: &envoygatewayv1alpha1.ProxyAccessLog{
+					Settings: []envoygatewayv1alpha1.ProxyAccessLogSetting{setting},
+				},
+			},
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed ensuring downstream access log policy: %w", err)
+	}
+
+	logger.Info("downstream access log policy processed", "operation_result", result)
+	return r.ensureGatewayClassAccessLogSettings(ctx, client.ObjectKeyFromObject(envoyProxy), envoyProxy)
+}
+
+// ensureGatewayClassAccessLogSettings programs the access log settings of the
+// EnvoyProxies of all AccessLogPolicies on the EnvoyProxy of the downstream
+// GatewayClass, after the settings of the platform. When the limit of settings
+// is reached, the settings of the oldest policies are programmed.
+//
+// The EnvoyProxy of the policy being reconciled is passed explicitly, as the
+// cache may not yet reflect its latest state. It is nil when the EnvoyProxy
+// was deleted. The returned value reports whether its settings are programmed.
+func (r *AccessLogPolicyReconciler) ensureGatewayClassAccessLogSettings(
+	ctx context.Context,
+	policyKey client.ObjectKey,
+	policyEnvoyProxy *envoygatewayv1alpha1.EnvoyProxy,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+	downstreamClient := r.DownstreamCluster.GetClient()
+
+	var policyEnvoyProxies envoygatewayv1alpha1.EnvoyProxyList
+	if err := downstreamClient.List(ctx, &policyEnvoyProxies, client.MatchingLabels{
+		downstreamclient.UpstreamOwnerGroupLabel: networkingv1alpha.GroupVersion.Group,
+		downstreamclient.UpstreamOwnerKindLabel:  "AccessLogPolicy",
+	}); err != nil {
+		return false, fmt.Errorf("failed listing downstream access log policies: %w", err)
+	}
+
+	envoyProxies := slices.DeleteFunc(policyEnvoyProxies.Items, func(envoyProxy envoygatewayv1alpha1.EnvoyProxy) bool {
+		return client.ObjectKeyFromObject(&envoyProxy) == policyKey
+	})
+	if policyEnvoyProxy != nil {
+		envoyProxies = append(envoyProxies, *policyEnvoyProxy)
+	}
+	slices.SortFunc(envoyProxies, func(a, b envoygatewayv1alpha1.EnvoyProxy) int {
+		return cmp.Or(
+			a.CreationTimestamp.Compare(b.CreationTimestamp.Time),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	gatewayClassEnvoyProxyRef := r.Config.Gateway.AccessLogging.EnvoyProxy
+	var gatewayClassEnvoyProxy envoygatewayv1alpha1.EnvoyProxy
+	if err := downstreamClient.Get(ctx, client.ObjectKey{
+		Namespace: gatewayClassEnvoyProxyRef.Namespace,
+		Name:      gatewayClassEnvoyProxyRef.Name,
+	}, &gatewayClassEnvoyProxy); err != nil {
+		return false, fmt.Errorf("failed getting gatewayclass envoyproxy: %w", err)
+	}
+
+	var settings []envoygatewayv1alpha1.ProxyAccessLogSetting
+	if telemetry := gatewayClassEnvoyProxy.Spec.Telemetry; telemetry != nil && telemetry.AccessLog != nil {
+		settings = telemetry.AccessLog.Settings
+	}
+	managed, _ := strconv.Atoi(gatewayClassEnvoyProxy.Annotations[managedAccessLogSettingsAnnotation])
+	platformSettings := slices.Clone(settings[:len(settings)-min(max(managed, 0), len(settings))])
+
+	programmed := false
+	desiredSettings := platformSettings
+	for _, envoyProxy := range envoyProxies {
+		var policySettings []envoygatewayv1alpha1.ProxyAccessLogSetting
+		if envoyProxy.Spec.Telemetry != nil && envoyProxy.Spec.Telemetry.AccessLog != nil {
+			policySettings = envoyProxy.Spec.Telemetry.AccessLog.Settings
+		}
+		if len(desiredSettings)+len(policySettings) > maxAccessLogSettings {
+			break
+		}
+		desiredSettings = append(desiredSettings, policySettings...)
+		if client.ObjectKeyFromObject(&envoyProxy) == policyKey {
+			programmed = true
+		}
+	}
+	desiredManaged := strconv.Itoa(len(desiredSettings) - len(platformSettings))
+
+	if equality.Semantic.DeepEqual(settings, desiredSettings) &&
+		gatewayClassEnvoyProxy.Annotations[managedAccessLogSettingsAnnotation] == desiredManaged {
+		return programmed, nil
+	}
+
+	if gatewayClassEnvoyProxy.Spec.Telemetry == nil {
+		gatewayClassEnvoyProxy.Spec.Telemetry = &envoygatewayv1alpha1.ProxyTelemetry{}
+	}
+	if gatewayClassEnvoyProxy.Spec.Telemetry.AccessLog == nil {
+		gatewayClassEnvoyProxy.Spec.Telemetry.AccessLog = &envoygatewayv1alpha1.ProxyAccessLog{}
+	}
+	if len(desiredSettings) == 0 {
+		desiredSettings = nil
+	}
+	gatewayClassEnvoyProxy.Spec.Telemetry.AccessLog.Settings = desiredSettings
+	if gatewayClassEnvoyProxy.Annotations == nil {
+		gatewayClassEnvoyProxy.Annotations = map[string]string{}
+	}
+	gatewayClassEnvoyProxy.Annotations[managedAccessLogSettingsAnnotation] = desiredManaged
+
+	if err := downstreamClient.Update(ctx, &gatewayClassEnvoyProxy); err != nil {
+		return false, fmt.Errorf("failed updating access log settings of gatewayclass envoyproxy: %w", err)
+	}
+
+	logger.Info("gatewayclass access log settings updated", "managed_settings", desiredManaged)
+	return programmed, nil
+}
+
+// desiredAccessLogSetting translates an AccessLogPolicy to an Envoy Gateway
+// access log setting.
+//
+// As the setting is shared by every gateway of the downstream GatewayClass, it
+// only matches requests routed through the virtual hosts of targeted Gateway
+// listeners, or the routes of targeted HTTPProxies. Both are matched by the
+// prefix of their xDS names, which Envoy Gateway derives from the names of the
+// Gateway and listener, or of the HTTPRoute.
+func (r *AccessLogPolicyReconciler) desiredAccessLogSetting(
+	clusterName multicluster.ClusterName,
+	policy *networkingv1alpha.AccessLogPolicy,
+	downstreamNamespace string,
+	targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+) (envoygatewayv1alpha1.ProxyAccessLogSetting, error) {
+	targetMatches := make([]string, 0, len(targetRefs))
+	for _, targetRef := range targetRefs {
+		switch targetRef.Kind {
+		case KindGateway:
+			prefix := fmt.Sprintf("%s/%s/", downstreamNamespace, targetRef.Name)
+			if targetRef.SectionName != nil {
+				prefix += string(*targetRef.SectionName) + "/"
+			}
+			targetMatches = append(targetMatches, fmt.Sprintf("xds.virtual_host_name.startsWith('%s')", prefix))
+		case KindHTTPRoute:
+			targetMatches = append(targetMatches, fmt.Sprintf("xds.route_name.startsWith('httproute/%s/%s/')", downstreamNamespace, targetRef.Name))
+		}
+	}
+	match := fmt.Sprintf("(%s)", strings.Join(targetMatches, " || "))
+	if sampleMatch := accessLogSampleMatch(ptr.Deref(policy.Spec.SamplePercent, 100)); sampleMatch != "" {
+		match = fmt.Sprintf("%s && %s", match, sampleMatch)
+	}
+
+	setting := envoygatewayv1alpha1.ProxyAccessLogSetting{
+		Matches: []string{match},
+	}
+
+	if format := policy.Spec.Format; format != nil {
+		setting.Format = &envoygatewayv1alpha1.ProxyAccessLogFormat{
+			Type: ptr.To(envoygatewayv1alpha1.ProxyAccessLogFormatTypeText),
+			Text: format.Text,
+		}
+		if format.Type == networkingv1alpha.AccessLogFormatJSON {
+			setting.Format = &envoygatewayv1alpha1.ProxyAccessLogFormat{
+				Type: ptr.To(envoygatewayv1alpha1.ProxyAccessLogFormatTypeJSON),
+				JSON: format.JSON,
+			}
+		}
+	}
+
+	tenantAttributes := map[string]string{
+		accessLogClusterNameAttribute:   string(clusterName),
+		accessLogNamespaceNameAttribute: policy.Namespace,
+		accessLogPolicyNameAttribute:    policy.Name,
+	}
+
+	for i, sink := range policy.Spec.Sinks {
+		switch sink.Type {
+		case networkingv1alpha.AccessLogSinkStdout:
+			setting.Sinks = append(setting.Sinks, envoygatewayv1alpha1.ProxyAccessLogSink{
+				Type: envoygatewayv1alpha1.ProxyAccessLogSinkTypeFile,
+				File: &envoygatewayv1alpha1.FileEnvoyProxyAccessLog{Path: accessLogStdoutPath},
+			})
+		case networkingv1alpha.AccessLogSinkOTLP:
+			if sink.OTLP == nil {
+				return setting, fmt.Errorf("sink %d of type %s has no otlp settings", i, sink.Type)
+			}
+			resourceAttributes := map[string]string{}
+			for k, v := range sink.OTLP.ResourceAttributes {
+				resourceAttributes[k] = v
+			}
+			for k, v := range tenantAttributes {
+				resourceAttributes[k] = v
+			}
+			otlpSink, err := openTelemetryAccessLogSink(sink.OTLP.Endpoint, sink.OTLP.Headers, resourceAttributes)
+			if err != nil {
+				return setting, fmt.Errorf("failed parsing endpoint of sink %d: %w", i, err)
+			}
+			setting.Sinks = append(setting.Sinks, otlpSink)
+		case networkingv1alpha.AccessLogSinkS3:
+			if sink.S3 == nil {
+				return setting, fmt.Errorf("sink %d of type %s has no s3 settings", i, sink.Type)
+			}
+			resourceAttributes := map[string]string{
+				accessLogS3BucketAttribute: sink.S3.Bucket,
+			}
+			if sink.S3.Endpoint != nil {
+				resourceAttributes[accessLogS3EndpointAttribute] = *sink.S3.Endpoint
+			}
+			if sink.S3.Region != nil {
+				resourceAttributes[accessLogS3RegionAttribute] = *sink.S3.Region
+			}
+			if sink.S3.Prefix != nil {
+				resourceAttributes[accessLogS3PrefixAttribute] = *sink.S3.Prefix
+			}
+			for k, v := range tenantAttributes {
+				resourceAttributes[k] = v
+			}
+			s3Sink, err := openTelemetryAccessLogSink(r.Config.Gateway.AccessLogging.S3CollectorEndpoint, nil, resourceAttributes)
+			if err != nil {
+				return setting, fmt.Errorf("failed parsing s3 collector endpoint: %w", err)
+			}
+			setting.Sinks = append(setting.Sinks, s3Sink)
+		}
+	}
+
+	return setting, nil
+}
+
+// accessLogSampleMatch returns a CEL expression that matches the given
+// percentage of requests, or an empty string when every request is logged.
+//
+// Envoy assigns each request a random UUID as its request ID, so requests are
+// sampled by comparing the first four hex digits of the ID with a threshold.
+func accessLogSampleMatch(percent int32) string {
+	if percent >= 100 {
+		return ""
+	}
+	threshold := int64(max(percent, 0)) * 0x10000 / 100
+	return fmt.Sprintf("request.id < '%04x'", threshold)
+}
+
+func openTelemetryAccessLogSink(endpoint string, headers []gatewayv1.HTTPHeader, resourceAttributes map[string]string) (envoygatewayv1alpha1.ProxyAccessLogSink, error) {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return envoygatewayv1alpha1.ProxyAccessLogSink{}, err
+	}
+	portNumber, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return envoygatewayv1alpha1.ProxyAccessLogSink{}, fmt.Errorf("invalid port %q: %w", port, err)
+	}
+
+	return envoygatewayv1alpha1.ProxyAccessLogSink{
+		Type: envoygatewayv1alpha1.ProxyAccessLogSinkTypeOpenTelemetry,
+		OpenTelemetry: &envoygatewayv1alpha1.OpenTelemetryEnvoyProxyAccessLog{
+			// A Backend reference would have to be granted to every tenant
+			// namespace, so the collector is addressed directly.
+			Host:               ptr.To(host),
+			Port:               int32(portNumber),
+			ResourceAttributes: resourceAttributes,
+			Headers:            headers,
+		},
+	}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AccessLogPolicyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+
+	downstreamEnvoyProxySource := mcsource.TypedKind(
+		&envoygatewayv1alpha1.EnvoyProxy{},
+		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*envoygatewayv1alpha1.EnvoyProxy](&networkingv1alpha.AccessLogPolicy{}),
+	)
+
+	downstreamEnvoyProxyClusterSource, _, _ := downstreamEnvoyProxySource.ForCluster("", r.DownstreamCluster)
+
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&networkingv1alpha.AccessLogPolicy{}).
+		Watches(&gatewayv1.Gateway{}, enqueueLocalPoliciesForTargetFunc(KindGateway, listAccessLogPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.HTTPProxy{}, enqueueLocalPoliciesForTargetFunc(KindHTTPProxy, listAccessLogPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		WatchesRawSource(downstreamEnvoyProxyClusterSource).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "accesslogpolicy", 0)).
+		Named("accesslogpolicy").
+		Complete(r)
+}
+
+func listAccessLogPolicies(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error) {
+	var policies networkingv1alpha.AccessLogPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing accesslogpolicies: %w", err)
+	}
+
+	localPolicies := make([]localPolicy, 0, len(policies.Items))
+	for i := range policies.Items {
+		localPolicies = append(localPolicies, localPolicy{Object: &policies.Items[i], kind: "AccessLogPolicy", targetRefs: policies.Items[i].Spec.TargetRefs})
+	}
+	return localPolicies, nil
+}
@@ -0,0 +1,328 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestAccessLogPolicyReconcile(t *testing.T) {
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "gw"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}},
+		},
+	}
+	httpProxy := &networkingv1alpha.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "proxy"},
+	}
+
+	gatewayTarget := gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Group: gatewayv1.GroupName, Kind: KindGateway, Name: "gw"},
+		SectionName:                ptr.To(gatewayv1.SectionName("http")),
+	}
+	proxyTarget := gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+			Group: gatewayv1.Group(networkingv1alpha.GroupVersion.Group),
+			Kind:  KindHTTPProxy,
+			Name:  "proxy",
+		},
+	}
+
+	platformSetting := envoygatewayv1alpha1.ProxyAccessLogSetting{
+		Sinks: []envoygatewayv1alpha1.ProxyAccessLogSink{
+			{Type: envoygatewayv1alpha1.ProxyAccessLogSinkTypeFile, File: &envoygatewayv1alpha1.FileEnvoyProxyAccessLog{Path: "/dev/stdout"}},
+		},
+	}
+	newGatewayClassEnvoyProxy := func(platformSettings int) *envoygatewayv1alpha1.EnvoyProxy {
+		settings := make([]envoygatewayv1alpha1.ProxyAccessLogSetting, 0, platformSettings)
+		for range platformSettings {
+			settings = append(settings, platformSetting)
+		}
+		return &envoygatewayv1alpha1.EnvoyProxy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "envoy-gateway-system",
+				Name:      "proxy",
+				Annotations: map[string]string{
+					managedAccessLogSettingsAnnotation: "0",
+				},
+			},
+			Spec: envoygatewayv1alpha1.EnvoyProxySpec{
+				Telemetry: &envoygatewayv1alpha1.ProxyTelemetry{
+					AccessLog: &envoygatewayv1alpha1.ProxyAccessLog{Settings: settings},
+				},
+			},
+		}
+	}
+
+	otlpSink := networkingv1alpha.AccessLogSink{
+		Type: networkingv1alpha.AccessLogSinkOTLP,
+		OTLP: &networkingv1alpha.AccessLogOTLPSink{
+			Endpoint:           "otel.example.com:4317",
+			Headers:            []gatewayv1.HTTPHeader{{Name: "authorization", Value: "Bearer token"}},
+			ResourceAttributes: map[string]string{"service.name": "edge", accessLogNamespaceNameAttribute: "spoofed"},
+		},
+	}
+	s3Sink := networkingv1alpha.AccessLogSink{
+		Type: networkingv1alpha.AccessLogSinkS3,
+		S3: &networkingv1alpha.AccessLogS3Sink{
+			Bucket: "access-logs",
+			Region: ptr.To("us-east-1"),
+		},
+	}
+
+	newPolicy := func(samplePercent int32, sinks ...networkingv1alpha.AccessLogSink) *networkingv1alpha.AccessLogPolicy {
+		return &networkingv1alpha.AccessLogPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         localPolicyTestNamespace,
+				Name:              "policy",
+				UID:               types.UID("uid-policy"),
+				CreationTimestamp: metav1.NewTime(time.Now().Truncate(time.Second)),
+				Finalizers:        []string{accessLogPolicyFinalizer},
+			},
+			Spec: networkingv1alpha.AccessLogPolicySpec{
+				TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{gatewayTarget, proxyTarget},
+				Format: &networkingv1alpha.AccessLogFormat{
+					Type: networkingv1alpha.AccessLogFormatJSON,
+					JSON: map[string]string{"status": "%RESPONSE_CODE%"},
+				},
+				SamplePercent: ptr.To(samplePercent),
+				Sinks:         sinks,
+			},
+		}
+	}
+
+	wantMatch := "(xds.route_name.startsWith('httproute/" + localPolicyTestDownstreamNamespace + "/proxy/') || " +
+		"xds.virtual_host_name.startsWith('" + localPolicyTestDownstreamNamespace + "/gw/http/'))"
+
+	tests := []struct {
+		name                string
+		policy              *networkingv1alpha.AccessLogPolicy
+		s3CollectorEndpoint string
+		platformSettings    int
+		wantReason          gatewayv1.PolicyConditionReason
+		wantMatches         []string
+		wantSinks           []envoygatewayv1alpha1.ProxyAccessLogSink
+	}{
+		{
+			name:             "stdout and otlp sinks",
+			policy:           newPolicy(100, networkingv1alpha.AccessLogSink{Type: networkingv1alpha.AccessLogSinkStdout}, otlpSink),
+			platformSettings: 1,
+			wantReason:       gatewayv1.PolicyReasonAccepted,
+			wantMatches:      []string{wantMatch},
+			wantSinks: []envoygatewayv1alpha1.ProxyAccessLogSink{
+				{Type: envoygatewayv1alpha1.ProxyAccessLogSinkTypeFile, File: &envoygatewayv1alpha1.FileEnvoyProxyAccessLog{Path: "/dev/stdout"}},
+				{
+					Type: envoygatewayv1alpha1.ProxyAccessLogSinkTypeOpenTelemetry,
+					OpenTelemetry: &envoygatewayv1alpha1.OpenTelemetryEnvoyProxyAccessLog{
+						Host: ptr.To("otel.example.com"),
+						Port: 4317,
+						ResourceAttributes: map[string]string{
+							"service.name":                  "edge",
+							accessLogClusterNameAttribute:   "test-cluster",
+							accessLogNamespaceNameAttribute: localPolicyTestNamespace,
+							accessLogPolicyNameAttribute:    "policy",
+						},
+						Headers: []gatewayv1.HTTPHeader{{Name: "authorization", Value: "Bearer token"}},
+					},
+				},
+			},
+		},
+		{
+			name:                "sampled s3 sink",
+			policy:              newPolicy(25, s3Sink),
+			s3CollectorEndpoint: "collector.observability:4317",
+			wantReason:          gatewayv1.PolicyReasonAccepted,
+			wantMatches:         []string{wantMatch + " && request.id < '4000'"},
+			wantSinks: []envoygatewayv1alpha1.ProxyAccessLogSink{
+				{
+					Type: envoygatewayv1alpha1.ProxyAccessLogSinkTypeOpenTelemetry,
+					OpenTelemetry: &envoygatewayv1alpha1.OpenTelemetryEnvoyProxyAccessLog{
+						Host: ptr.To("collector.observability"),
+						Port: 4317,
+						ResourceAttributes: map[string]string{
+							accessLogS3BucketAttribute:      "access-logs",
+							accessLogS3RegionAttribute:      "us-east-1",
+							accessLogClusterNameAttribute:   "test-cluster",
+							accessLogNamespaceNameAttribute: localPolicyTestNamespace,
+							accessLogPolicyNameAttribute:    "policy",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:       "s3 sink without collector",
+			policy:     newPolicy(100, s3Sink),
+			wantReason: gatewayv1.PolicyReasonInvalid,
+		},
+		{
+			name:             "settings limit reached",
+			policy:           newPolicy(100, networkingv1alpha.AccessLogSink{Type: networkingv1alpha.AccessLogSinkStdout}),
+			platformSettings: maxAccessLogSettings,
+			wantReason:       gatewayv1.PolicyReasonInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			fakeUpstreamClient, fakeDownstreamClient := newLocalPolicyTestClients(t, &networkingv1alpha.AccessLogPolicy{},
+				gateway.DeepCopy(),
+				httpProxy.DeepCopy(),
+				tt.policy,
+			)
+			require.NoError(t, fakeDownstreamClient.Create(ctx, newGatewayClassEnvoyProxy(tt.platformSettings)))
+
+			operatorConfig := localPolicyTestConfig
+			operatorConfig.Gateway.AccessLogging = config.GatewayAccessLoggingConfig{
+				EnvoyProxy:          &config.GatewayAccessLoggingEnvoyProxyRef{Namespace: "envoy-gateway-system", Name: "proxy"},
+				S3CollectorEndpoint: tt.s3CollectorEndpoint,
+			}
+
+			reconciler := &AccessLogPolicyReconciler{
+				mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+				DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+				Config:            operatorConfig,
+			}
+
+			req := localPolicyTestRequest("policy")
+			_, err := reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+
+			var policy networkingv1alpha.AccessLogPolicy
+			require.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &policy))
+			assert.Len(t, policy.Status.Ancestors, 2)
+			for _, ancestor := range policy.Status.Ancestors {
+				condition := apimeta.FindStatusCondition(ancestor.Conditions, string(gatewayv1.PolicyConditionAccepted))
+				if assert.NotNil(t, condition) {
+					assert.Equal(t, string(tt.wantReason), condition.Reason, "ancestor %s", ancestor.AncestorRef.Name)
+				}
+			}
+
+			var gatewayClassEnvoyProxy envoygatewayv1alpha1.EnvoyProxy
+			require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: "envoy-gateway-system", Name: "proxy"}, &gatewayClassEnvoyProxy))
+			settings := gatewayClassEnvoyProxy.Spec.Telemetry.AccessLog.Settings
+
+			var policyEnvoyProxy envoygatewayv1alpha1.EnvoyProxy
+			err = fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "accesslog-policy"}, &policyEnvoyProxy)
+			if tt.wantSinks == nil {
+				assert.Len(t, settings, tt.platformSettings)
+				assert.Equal(t, "0", gatewayClassEnvoyProxy.Annotations[managedAccessLogSettingsAnnotation])
+				if tt.platformSettings < maxAccessLogSettings {
+					assert.True(t, apierrors.IsNotFound(err), "expected no downstream access log policy, got %v", err)
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			wantSetting := envoygatewayv1alpha1.ProxyAccessLogSetting{
+				Format: &envoygatewayv1alpha1.ProxyAccessLogFormat{
+					Type: ptr.To(envoygatewayv1alpha1.ProxyAccessLogFormatTypeJSON),
+					JSON: map[string]string{"status": "%RESPONSE_CODE%"},
+				},
+				Matches: tt.wantMatches,
+				Sinks:   tt.wantSinks,
+			}
+			assert.Equal(t, []envoygatewayv1alpha1.ProxyAccessLogSetting{wantSetting}, policyEnvoyProxy.Spec.Telemetry.AccessLog.Settings)
+
+			require.Len(t, settings, tt.platformSettings+1)
+			assert.Equal(t, wantSetting, settings[tt.platformSettings])
+			assert.Equal(t, "1", gatewayClassEnvoyProxy.Annotations[managedAccessLogSettingsAnnotation])
+
+			// Deleting the policy removes its settings, and keeps those of the
+			// platform.
+			require.NoError(t, fakeUpstreamClient.Delete(ctx, &policy))
+			_, err = reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+
+			require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: "envoy-gateway-system", Name: "proxy"}, &gatewayClassEnvoyProxy))
+			assert.Len(t, gatewayClassEnvoyProxy.Spec.Telemetry.AccessLog.Settings, tt.platformSettings)
+			assert.Equal(t, "0", gatewayClassEnvoyProxy.Annotations[managedAccessLogSettingsAnnotation])
+			err = fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "accesslog-policy"}, &policyEnvoyProxy)
+			assert.True(t, apierrors.IsNotFound(err), "expected downstream access log policy to be deleted, got %v", err)
+		})
+	}
+
+	t.Run("removes the settings of a policy of a terminating namespace", func(t *testing.T) {
+		ctx := context.Background()
+		namespace := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       localPolicyTestNamespace,
+				UID:        localPolicyTestNamespaceUID,
+				Finalizers: []string{"kubernetes"},
+			},
+		}
+		fakeUpstreamClient, fakeDownstreamClient := newLocalPolicyTestClients(t, &networkingv1alpha.AccessLogPolicy{},
+			namespace,
+			gateway.DeepCopy(),
+			httpProxy.DeepCopy(),
+			newPolicy(100, networkingv1alpha.AccessLogSink{Type: networkingv1alpha.AccessLogSinkStdout}),
+		)
+		require.NoError(t, fakeDownstreamClient.Create(ctx, newGatewayClassEnvoyProxy(1)))
+
+		operatorConfig := localPolicyTestConfig
+		operatorConfig.Gateway.AccessLogging = config.GatewayAccessLoggingConfig{
+			EnvoyProxy: &config.GatewayAccessLoggingEnvoyProxyRef{Namespace: "envoy-gateway-system", Name: "proxy"},
+		}
+		reconciler := &AccessLogPolicyReconciler{
+			mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+			DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+			Config:            operatorConfig,
+		}
+
+		req := localPolicyTestRequest("policy")
+		_, err := reconciler.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		var gatewayClassEnvoyProxy envoygatewayv1alpha1.EnvoyProxy
+		gatewayClassEnvoyProxyKey := client.ObjectKey{Namespace: "envoy-gateway-system", Name: "proxy"}
+		require.NoError(t, fakeDownstreamClient.Get(ctx, gatewayClassEnvoyProxyKey, &gatewayClassEnvoyProxy))
+		require.Len(t, gatewayClassEnvoyProxy.Spec.Telemetry.AccessLog.Settings, 2)
+
+		// The settings are programmed outside of the downstream namespace, so
+		// they are removed although the policy is deleted along with its
+		// namespace.
+		require.NoError(t, fakeUpstreamClient.Delete(ctx, namespace))
+		var policy networkingv1alpha.AccessLogPolicy
+		require.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &policy))
+		require.NoError(t, fakeUpstreamClient.Delete(ctx, &policy))
+		_, err = reconciler.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		require.NoError(t, fakeDownstreamClient.Get(ctx, gatewayClassEnvoyProxyKey, &gatewayClassEnvoyProxy))
+		assert.Len(t, gatewayClassEnvoyProxy.Spec.Telemetry.AccessLog.Settings, 1)
+		assert.Equal(t, "0", gatewayClassEnvoyProxy.Annotations[managedAccessLogSettingsAnnotation])
+		err = fakeUpstreamClient.Get(ctx, req.NamespacedName, &policy)
+		assert.True(t, apierrors.IsNotFound(err), "expected the policy to be deleted, got %v", err)
+	})
+}
+
+func TestAccessLogSampleMatch(t *testing.T) {
+	tests := map[int32]string{
+		100: "",
+		50:  "request.id < '8000'",
+		1:   "request.id < '028f'",
+		0:   "request.id < '0000'",
+	}
+	for percent, want := range tests {
+		assert.Equal(t, want, accessLogSampleMatch(percent), "percent %d", percent)
+	}
+}
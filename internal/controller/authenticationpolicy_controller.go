@@ -0,0 +1,298 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+	mcsource "sigs.k8s.io/multicluster-runtime/pkg/source"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+const authenticationPolicyFinalizer = "networking.datumapis.com/authenticationpolicy-cleanup"
+
+// authenticationPolicyClientSecretLabel marks downstream Secrets that hold a
+// copy of the OIDC client secret of an AuthenticationPolicy.
+const authenticationPolicyClientSecretLabel = "networking.datumapis.com/authenticationpolicy-client-secret"
+
+// AuthenticationPolicyReconciler reconciles an AuthenticationPolicy object
+type AuthenticationPolicyReconciler struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+
+	DownstreamCluster cluster.Cluster
+
+	// UpstreamOutages, when set, retries reconciles while the API server of
+	// the policy's cluster is unreachable.
+	UpstreamOutages *UpstreamOutageTracker
+}
+
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=authenticationpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=authenticationpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=authenticationpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=securitypolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+func (r *AuthenticationPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	return r.localPolicy().reconciler().reconcile(ctx, r.mgr, r.DownstreamCluster, r.UpstreamOutages, req)
+}
+
+func (r *AuthenticationPolicyReconciler) localPolicy() *targetedLocalPolicy[*networkingv1alpha.AuthenticationPolicy, *envoygatewayv1alpha1.SecurityPolicy] {
+	return &targetedLocalPolicy[*networkingv1alpha.AuthenticationPolicy, *envoygatewayv1alpha1.SecurityPolicy]{
+		name:           "authenticationpolicy",
+		kind:           "AuthenticationPolicy",
+		finalizer:      authenticationPolicyFinalizer,
+		controllerName: string(r.Config.Gateway.ControllerName),
+		newPolicy:      func() *networkingv1alpha.AuthenticationPolicy { return &networkingv1alpha.AuthenticationPolicy{} },
+		targetRefs: func(policy *networkingv1alpha.AuthenticationPolicy) []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+			return policy.Spec.TargetRefs
+		},
+		policyStatus: func(policy *networkingv1alpha.AuthenticationPolicy) *gatewayv1alpha2.PolicyStatus {
+			return &policy.Status.PolicyStatus
+		},
+		listPolicies: listSecurityLocalPolicies,
+		newDownstream: func(policy *networkingv1alpha.AuthenticationPolicy) *envoygatewayv1alpha1.SecurityPolicy {
+			return &envoygatewayv1alpha1.SecurityPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: downstreamAuthenticationPolicyName(policy)},
+			}
+		},
+		desiredDownstream: desiredAuthenticationSecurityPolicy,
+		// The copy of the client secret of a deleted policy is removed along
+		// with its anchor.
+		syncDownstream: ensureDownstreamAuthenticationClientSecret,
+	}
+}
+
+// downstreamAuthenticationPolicyName returns the name of the SecurityPolicy
+// that programs an AuthenticationPolicy. It is prefixed so that it doesn't
+// collide with SecurityPolicies replicated from the upstream namespace.
+func downstreamAuthenticationPolicyName(policy *networkingv1alpha.AuthenticationPolicy) string {
+	return resourcename.GetValidDNS1123Name(fmt.Sprintf("authentication-%s", policy.Name))
+}
+
+// downstreamAuthenticationClientSecretName returns the name of the downstream
+// copy of the OIDC client secret of an AuthenticationPolicy.
+func downstreamAuthenticationClientSecretName(policy *networkingv1alpha.AuthenticationPolicy) string {
+	return resourcename.GetValidDNS1123Name(fmt.Sprintf("authentication-%s-client-secret", policy.Name))
+}
+
+// ensureDownstreamAuthenticationClientSecret copies the OIDC client secret of the policy to
+// the downstream namespace while the policy is accepted for any target, and
+// deletes the copy otherwise. It returns a message describing why the client
+// secret is not usable. An existing copy is then kept so that the targets keep
+// requiring a login, unless the Secret has a type that may not be copied.
+func ensureDownstreamAuthenticationClientSecret(
+	ctx context.Context,
+	upstreamClient client.Client,
+	policy *networkingv1alpha.AuthenticationPolicy,
+	targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) (string, error) {
+	secretSync := downstreamclient.NewSecretSync(
+		downstreamStrategy,
+		authenticationPolicyClientSecretLabel,
+		downstreamclient.WithAllowedSecretTypes(corev1.SecretTypeOpaque),
+		downstreamclient.WithSecretKeys(envoygatewayv1alpha1.OIDCClientSecretKey),
+	)
+
+	if policy.Spec.OIDC == nil || len(targetRefs) == 0 {
+		if err := secretSync.Sync(ctx, policy, nil); err != nil {
+			return "", fmt.Errorf("failed deleting downstream client secret: %w", err)
+		}
+		return "", nil
+	}
+
+	var programmingErr string
+	secretName := policy.Spec.OIDC.ClientSecretRef.Name
+	secret := &corev1.Secret{}
+	if err := upstreamClient.Get(ctx, client.ObjectKey{Namespace: policy.Namespace, Name: secretName}, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get client secret %s: %w", secretName, err)
+		}
+		programmingErr = fmt.Sprintf("The OIDC client Secret %q was not found", secretName)
+		secret = nil
+	} else if len(secret.Data[envoygatewayv1alpha1.OIDCClientSecretKey]) == 0 {
+		programmingErr = fmt.Sprintf("The OIDC client Secret %q has no %q key", secretName, envoygatewayv1alpha1.OIDCClientSecretKey)
+		secret = nil
+	}
+
+	err := secretSync.Sync(ctx, policy, []downstreamclient.DownstreamSecret{{
+		Name:   downstreamAuthenticationClientSecretName(policy),
+		Source: secret,
+	}})
+	if errors.Is(err, downstreamclient.ErrSecretTypeNotAllowed) {
+		return fmt.Sprintf("The OIDC client Secret %q must be of type %s", secretName, corev1.SecretTypeOpaque), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed syncing downstream client secret: %w", err)
+	}
+
+	return programmingErr, nil
+}
+
+// desiredAuthenticationSecurityPolicy programs the JWT and OIDC
+// authentication of the policy on the downstream SecurityPolicy attached to
+// the accepted targets.
+func desiredAuthenticationSecurityPolicy(
+	policy *networkingv1alpha.AuthenticationPolicy,
+	securityPolicy *envoygatewayv1alpha1.SecurityPolicy,
+	targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+) string {
+	securityPolicy.Spec = envoygatewayv1alpha1.SecurityPolicySpec{
+		PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+			TargetRefs: targetRefs,
+		},
+		MergeType: routePolicyMergeType(targetRefs),
+		JWT:       desiredAuthenticationJWT(policy.Spec.JWT),
+		OIDC:      desiredAuthenticationOIDC(policy.Spec.OIDC, downstreamAuthenticationClientSecretName(policy)),
+	}
+	return ""
+}
+
+// desiredAuthenticationJWT translates the JWT providers of an
+// AuthenticationPolicy to Envoy Gateway JWT authentication.
+func desiredAuthenticationJWT(jwt *networkingv1alpha.JWTAuthentication) *envoygatewayv1alpha1.JWT {
+	if jwt == nil {
+		return nil
+	}
+
+	result := &envoygatewayv1alpha1.JWT{
+		Optional:  jwt.Optional,
+		Providers: make([]envoygatewayv1alpha1.JWTProvider, 0, len(jwt.Providers)),
+	}
+	for _, provider := range jwt.Providers {
+		jwtProvider := envoygatewayv1alpha1.JWTProvider{
+			Name:       provider.Name,
+			Issuer:     provider.Issuer,
+			Audiences:  provider.Audiences,
+			RemoteJWKS: &envoygatewayv1alpha1.RemoteJWKS{URI: provider.JWKSURI},
+		}
+		for _, claimToHeader := range provider.ClaimToHeaders {
+			jwtProvider.ClaimToHeaders = append(jwtProvider.ClaimToHeaders, envoygatewayv1alpha1.ClaimToHeader{
+				Claim:  claimToHeader.Claim,
+				Header: claimToHeader.Header,
+			})
+		}
+		result.Providers = append(result.Providers, jwtProvider)
+	}
+	return result
+}
+
+// desiredAuthenticationOIDC translates the OIDC login flow of an
+// AuthenticationPolicy to Envoy Gateway OIDC authentication, which reads the
+// client secret from its downstream copy.
+func desiredAuthenticationOIDC(oidc *networkingv1alpha.OIDCAuthentication, clientSecretName string) *envoygatewayv1alpha1.OIDC {
+	if oidc == nil {
+		return nil
+	}
+
+	return &envoygatewayv1alpha1.OIDC{
+		Provider: envoygatewayv1alpha1.OIDCProvider{
+			Issuer: oidc.Issuer,
+		},
+		ClientID: ptr.To(oidc.ClientID),
+		ClientSecret: gatewayv1.SecretObjectReference{
+			Name: gatewayv1.ObjectName(clientSecretName),
+		},
+		Scopes:             oidc.Scopes,
+		RedirectURL:        oidc.RedirectURL,
+		LogoutPath:         oidc.LogoutPath,
+		ForwardAccessToken: oidc.ForwardAccessToken,
+	}
+}
+
+// enqueueAuthenticationPoliciesForSecret enqueues the AuthenticationPolicies
+// whose OIDC client secret is a Secret.
+func enqueueAuthenticationPoliciesForSecret(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		logger := log.FromContext(ctx)
+
+		var policies networkingv1alpha.AuthenticationPolicyList
+		if err := cl.GetClient().List(ctx, &policies, client.InNamespace(obj.GetNamespace())); err != nil {
+			logger.Error(err, "failed to list authenticationpolicies for secret")
+			return nil
+		}
+
+		var requests []mcreconcile.Request
+		for _, policy := range policies.Items {
+			if policy.Spec.OIDC == nil || policy.Spec.OIDC.ClientSecretRef.Name != obj.GetName() {
+				continue
+			}
+			requests = append(requests, mcreconcile.Request{
+				ClusterName: clusterName,
+				Request: reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&policy),
+				},
+			})
+		}
+		return requests
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AuthenticationPolicyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+
+	downstreamSecurityPolicySource := mcsource.TypedKind(
+		&envoygatewayv1alpha1.SecurityPolicy{},
+		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*envoygatewayv1alpha1.SecurityPolicy](&networkingv1alpha.AuthenticationPolicy{}),
+	)
+
+	downstreamSecurityPolicyClusterSource, _, _ := downstreamSecurityPolicySource.ForCluster("", r.DownstreamCluster)
+
+	downstreamSecretSource := mcsource.TypedKind(
+		&corev1.Secret{},
+		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*corev1.Secret](&networkingv1alpha.AuthenticationPolicy{}),
+	)
+
+	downstreamSecretClusterSource, _, _ := downstreamSecretSource.ForCluster("", r.DownstreamCluster)
+
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&networkingv1alpha.AuthenticationPolicy{}).
+		Watches(&gatewayv1.Gateway{}, enqueueLocalPoliciesForTargetFunc(KindGateway, listAuthenticationPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&gatewayv1.HTTPRoute{}, enqueueLocalPoliciesForTargetFunc(KindHTTPRoute, listAuthenticationPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.HTTPProxy{}, enqueueLocalPoliciesForTargetFunc(KindHTTPProxy, listAuthenticationPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.AccessControlPolicy{}, enqueueLocalPoliciesInNamespaceFunc(listAuthenticationPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&corev1.Secret{}, enqueueAuthenticationPoliciesForSecret).
+		WatchesRawSource(downstreamSecurityPolicyClusterSource).
+		WatchesRawSource(downstreamSecretClusterSource).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "authenticationpolicy", 0)).
+		Named("authenticationpolicy").
+		Complete(r)
+}
+
+func listAuthenticationPolicies(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error) {
+	var policies networkingv1alpha.AuthenticationPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing authenticationpolicies: %w", err)
+	}
+
+	localPolicies := make([]localPolicy, 0, len(policies.Items))
+	for i := range policies.Items {
+		localPolicies = append(localPolicies, localPolicy{Object: &policies.Items[i], kind: "AuthenticationPolicy", targetRefs: policies.Items[i].Spec.TargetRefs})
+	}
+	return localPolicies, nil
+}
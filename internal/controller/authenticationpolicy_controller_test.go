@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestDesiredAuthenticationJWT(t *testing.T) {
+	assert.Nil(t, desiredAuthenticationJWT(nil))
+
+	jwt := desiredAuthenticationJWT(&networkingv1alpha.JWTAuthentication{
+		Optional: ptr.To(true),
+		Providers: []networkingv1alpha.JWTProvider{
+			{
+				Name:      "example",
+				Issuer:    "https://auth.example.com",
+				JWKSURI:   "https://auth.example.com/.well-known/jwks.json",
+				Audiences: []string{"api"},
+				ClaimToHeaders: []networkingv1alpha.ClaimToHeader{
+					{Claim: "email", Header: "X-User-Email"},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, &envoygatewayv1alpha1.JWT{
+		Optional: ptr.To(true),
+		Providers: []envoygatewayv1alpha1.JWTProvider{
+			{
+				Name:       "example",
+				Issuer:     "https://auth.example.com",
+				Audiences:  []string{"api"},
+				RemoteJWKS: &envoygatewayv1alpha1.RemoteJWKS{URI: "https://auth.example.com/.well-known/jwks.json"},
+				ClaimToHeaders: []envoygatewayv1alpha1.ClaimToHeader{
+					{Claim: "email", Header: "X-User-Email"},
+				},
+			},
+		},
+	}, jwt)
+}
+
+func TestAuthenticationPolicyReconcile(t *testing.T) {
+	policy := &networkingv1alpha.AuthenticationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         localPolicyTestNamespace,
+			Name:              "policy",
+			UID:               "policy-uid",
+			CreationTimestamp: metav1.NewTime(time.Now().Truncate(time.Second)),
+			Finalizers:        []string{authenticationPolicyFinalizer},
+		},
+		Spec: networkingv1alpha.AuthenticationPolicySpec{
+			TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+						Group: gatewayv1.GroupName,
+						Kind:  KindHTTPRoute,
+						Name:  "route",
+					},
+				},
+			},
+			OIDC: &networkingv1alpha.OIDCAuthentication{
+				Issuer:          "https://auth.example.com",
+				ClientID:        "client",
+				ClientSecretRef: corev1.LocalObjectReference{Name: "oidc"},
+				Scopes:          []string{"email"},
+			},
+		},
+	}
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "oidc"},
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			envoygatewayv1alpha1.OIDCClientSecretKey: []byte("s3cr3t"),
+			"unrelated":                              []byte("value"),
+		},
+	}
+
+	fakeUpstreamClient, fakeDownstreamClient := newLocalPolicyTestClients(t, &networkingv1alpha.AuthenticationPolicy{},
+		newHTTPRoute(localPolicyTestNamespace, "route"),
+		clientSecret,
+		policy,
+	)
+
+	reconciler := &AuthenticationPolicyReconciler{
+		mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+		Config:            localPolicyTestConfig,
+	}
+
+	ctx := context.Background()
+	req := localPolicyTestRequest(policy.Name)
+	reconcileAccepted := func() *metav1.Condition {
+		t.Helper()
+		_, err := reconciler.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		var updated networkingv1alpha.AuthenticationPolicy
+		require.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &updated))
+		require.Len(t, updated.Status.Ancestors, 1)
+		return apimeta.FindStatusCondition(updated.Status.Ancestors[0].Conditions, string(gatewayv1.PolicyConditionAccepted))
+	}
+
+	accepted := reconcileAccepted()
+	require.NotNil(t, accepted)
+	assert.Equal(t, string(gatewayv1.PolicyReasonAccepted), accepted.Reason)
+
+	var securityPolicy envoygatewayv1alpha1.SecurityPolicy
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "authentication-policy"}, &securityPolicy))
+	assert.Equal(t, []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+		{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  KindHTTPRoute,
+				Name:  "route",
+			},
+		},
+	}, securityPolicy.Spec.TargetRefs)
+	assert.Equal(t, ptr.To(envoygatewayv1alpha1.StrategicMerge), securityPolicy.Spec.MergeType)
+	assert.Nil(t, securityPolicy.Spec.JWT)
+	if assert.NotNil(t, securityPolicy.Spec.OIDC) {
+		assert.Equal(t, "https://auth.example.com", securityPolicy.Spec.OIDC.Provider.Issuer)
+		assert.Equal(t, ptr.To("client"), securityPolicy.Spec.OIDC.ClientID)
+		assert.Equal(t, gatewayv1.ObjectName("authentication-policy-client-secret"), securityPolicy.Spec.OIDC.ClientSecret.Name)
+		assert.Equal(t, []string{"email"}, securityPolicy.Spec.OIDC.Scopes)
+	}
+
+	downstreamSecretKey := client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "authentication-policy-client-secret"}
+	var downstreamSecret corev1.Secret
+	require.NoError(t, fakeDownstreamClient.Get(ctx, downstreamSecretKey, &downstreamSecret))
+	assert.Equal(t, map[string][]byte{envoygatewayv1alpha1.OIDCClientSecretKey: []byte("s3cr3t")}, downstreamSecret.Data)
+
+	// The copy of the client secret is kept while the upstream Secret is
+	// missing, so that the target keeps requiring a login.
+	require.NoError(t, fakeUpstreamClient.Delete(ctx, clientSecret))
+	accepted = reconcileAccepted()
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+	assert.Equal(t, string(gatewayv1.PolicyReasonInvalid), accepted.Reason)
+	assert.Equal(t, `The OIDC client Secret "oidc" was not found`, accepted.Message)
+	assert.NoError(t, fakeDownstreamClient.Get(ctx, downstreamSecretKey, &downstreamSecret))
+
+	clientSecret.ResourceVersion = ""
+	require.NoError(t, fakeUpstreamClient.Create(ctx, clientSecret))
+	accepted = reconcileAccepted()
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+
+	// An older AccessControlPolicy attached to the same route is programmed as
+	// a SecurityPolicy too, so the policy conflicts with it.
+	accessControlPolicy := &networkingv1alpha.AccessControlPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         localPolicyTestNamespace,
+			Name:              "older",
+			UID:               "older-uid",
+			CreationTimestamp: metav1.NewTime(policy.CreationTimestamp.Add(-time.Hour)),
+		},
+		Spec: networkingv1alpha.AccessControlPolicySpec{
+			TargetRefs: policy.Spec.TargetRefs,
+			Deny:       []networkingv1alpha.CIDR{"192.0.2.0/24"},
+		},
+	}
+	require.NoError(t, fakeUpstreamClient.Create(ctx, accessControlPolicy))
+	accepted = reconcileAccepted()
+	require.NotNil(t, accepted)
+	assert.Equal(t, string(gatewayv1.PolicyReasonConflicted), accepted.Reason)
+	assert.Equal(t, "Unable to target HTTPRoute route, AccessControlPolicy older has already attached to it", accepted.Message)
+	err := fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "authentication-policy"}, &securityPolicy)
+	assert.True(t, apierrors.IsNotFound(err), "expected the downstream authentication policy to be deleted, got %v", err)
+}
@@ -0,0 +1,417 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/backendhealth"
+)
+
+// BackendRoleAnnotation is set on the upstream EndpointSlice of an HTTPProxy
+// backup backend. The gateway controller programs backup backends as a lower
+// priority level of the rule's cluster, so they only receive traffic when the
+// primary backend is unhealthy.
+const BackendRoleAnnotation = "networking.datumapis.com/backend-role"
+
+// BackendHealthCheckAnnotation is set on the upstream EndpointSlices of every
+// backend in an HTTPProxy rule that has backup backends, and of health checked
+// backends. It carries the JSON encoded HTTPProxyHealthCheck for the rule,
+// which the gateway controller programs as an active health check on the
+// downstream cluster.
+const BackendHealthCheckAnnotation = "networking.datumapis.com/backend-health-check"
+
+// BackendLoadBalancerAnnotation is set on the upstream EndpointSlices of the
+// backends of a health checked HTTPProxy rule that configures load balancing.
+// It carries the JSON encoded HTTPProxyBackendLoadBalancer of the rule, which
+// the gateway controller programs in the same BackendTrafficPolicy as the
+// health check, as Envoy Gateway only applies one BackendTrafficPolicy to a
+// route rule.
+const BackendLoadBalancerAnnotation = "networking.datumapis.com/backend-load-balancer"
+
+func isBackupBackend(backend networkingv1alpha.HTTPProxyRuleBackend) bool {
+	return backend.Role == networkingv1alpha.HTTPProxyBackendRoleBackup
+}
+
+func setBackendFailoverAnnotations(
+	annotations map[string]string,
+	backend networkingv1alpha.HTTPProxyRuleBackend,
+	healthCheck *networkingv1alpha.HTTPProxyHealthCheck,
+) error {
+	if isBackupBackend(backend) {
+		annotations[BackendRoleAnnotation] = string(networkingv1alpha.HTTPProxyBackendRoleBackup)
+	}
+
+	if healthCheck == nil {
+		healthCheck = &networkingv1alpha.HTTPProxyHealthCheck{}
+	}
+	return setBackendHealthCheckAnnotation(annotations, healthCheck)
+}
+
+func setBackendHealthCheckAnnotation(annotations map[string]string, healthCheck *networkingv1alpha.HTTPProxyHealthCheck) error {
+	b, err := json.Marshal(healthCheck)
+	if err != nil {
+		return err
+	}
+	annotations[BackendHealthCheckAnnotation] = string(b)
+	return nil
+}
+
+func setBackendLoadBalancerAnnotation(annotations map[string]string, loadBalancer *networkingv1alpha.HTTPProxyBackendLoadBalancer) error {
+	b, err := json.Marshal(loadBalancer)
+	if err != nil {
+		return err
+	}
+	annotations[BackendLoadBalancerAnnotation] = string(b)
+	return nil
+}
+
+// backendFailoverCondition returns the BackendFailover condition for the
+// HTTPProxy from the backend health in its status, or nil when no rule has
+// backup backends. The traffic of a rule has failed over once no endpoint of
+// its primary backends passes its health checks while an endpoint of its
+// backup backends does.
+func backendFailoverCondition(httpProxy *networkingv1alpha.HTTPProxy) *metav1.Condition {
+	var failedOver, unavailable, serving, unknown []string
+	for ruleIndex, rule := range httpProxy.Spec.Rules {
+		if !slices.ContainsFunc(rule.Backends, isBackupBackend) {
+			continue
+		}
+		label := httpProxyRuleLabel(httpProxy, ruleIndex)
+
+		var ruleHealth *networkingv1alpha.HTTPProxyRuleHealth
+		if health := httpProxy.Status.BackendHealth; health != nil {
+			if i := slices.IndexFunc(health.Rules, func(r networkingv1alpha.HTTPProxyRuleHealth) bool {
+				return int(r.RuleIndex) == ruleIndex
+			}); i >= 0 {
+				ruleHealth = &health.Rules[i]
+			}
+		}
+
+		switch {
+		case ruleHealth == nil || ruleHealth.PrimaryHealthyEndpoints == nil || ruleHealth.BackupHealthyEndpoints == nil:
+			unknown = append(unknown, label)
+		case *ruleHealth.PrimaryHealthyEndpoints > 0:
+			serving = append(serving, label)
+		case *ruleHealth.BackupHealthyEndpoints > 0:
+			failedOver = append(failedOver, label)
+		default:
+			unavailable = append(unavailable, label)
+		}
+	}
+
+	condition := &metav1.Condition{
+		Type:               networkingv1alpha.HTTPProxyConditionBackendFailover,
+		ObservedGeneration: httpProxy.Generation,
+	}
+	switch {
+	case len(failedOver) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = networkingv1alpha.HTTPProxyReasonFailedOver
+		condition.Message = fmt.Sprintf("The traffic of rules [%s] is sent to their backup backends, as no endpoint of their primary backends passes its health checks",
+			strings.Join(failedOver, ", "))
+	case len(unknown) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = networkingv1alpha.HTTPProxyReasonFailoverConfigured
+		condition.Message = fmt.Sprintf("Backup backends receive the traffic of rules [%s] when their primary backends fail health checks, the health of their endpoints has not been reported",
+			strings.Join(unknown, ", "))
+	case len(unavailable) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = networkingv1alpha.HTTPProxyReasonBackendsUnhealthy
+		condition.Message = fmt.Sprintf("No endpoint of the primary or backup backends of rules [%s] passes its health checks",
+			strings.Join(unavailable, ", "))
+	case len(serving) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = networkingv1alpha.HTTPProxyReasonPrimaryBackendsServing
+		condition.Message = fmt.Sprintf("The traffic of rules [%s] is sent to their primary backends",
+			strings.Join(serving, ", "))
+	default:
+		return nil
+	}
+	return condition
+}
+
+// ruleFailoverHealth returns the number of healthy endpoints of the primary
+// and of the backup backends of a rule with backup backends. Endpoints are
+// attributed to a backend by the hostname they were resolved from, or by
+// their address for backends with an IP address. It returns false when the
+// health of no endpoint of the rule's backends is reported.
+func ruleFailoverHealth(rule networkingv1alpha.HTTPProxyRule, endpoints []backendhealth.EndpointHealth) (primary, backup int32, ok bool) {
+	if !slices.ContainsFunc(rule.Backends, isBackupBackend) {
+		return 0, 0, false
+	}
+
+	backupHosts := map[string]bool{}
+	for _, backend := range rule.Backends {
+		u, err := url.Parse(backend.Endpoint)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		// A host that is both a primary and a backup backend is counted as a
+		// primary backend.
+		host := strings.TrimSuffix(u.Hostname(), ".")
+		if _, seen := backupHosts[host]; !seen || !isBackupBackend(backend) {
+			backupHosts[host] = isBackupBackend(backend)
+		}
+	}
+
+	for _, endpoint := range endpoints {
+		host := strings.TrimSuffix(endpoint.Hostname, ".")
+		if host == "" {
+			host, _, _ = net.SplitHostPort(endpoint.Address)
+		}
+		isBackup, known := backupHosts[host]
+		if !known {
+			continue
+		}
+		ok = true
+		if !endpoint.Healthy {
+			continue
+		}
+		if isBackup {
+			backup++
+		} else {
+			primary++
+		}
+	}
+	return primary, backup, ok
+}
+
+func failoverHostRewriteFilterName(httpProxy *networkingv1alpha.HTTPProxy, ruleIndex int) string {
+	return fmt.Sprintf("%s-rule-%d-backend-host", httpProxy.Name, ruleIndex)
+}
+
+// desiredFailoverHostRewriteFilter returns an HTTPRouteFilter that rewrites the
+// Host header to the hostname of the backend selected for the request.
+func desiredFailoverHostRewriteFilter(httpProxy *networkingv1alpha.HTTPProxy, ruleIndex int) *envoygatewayv1alpha1.HTTPRouteFilter {
+	return &envoygatewayv1alpha1.HTTPRouteFilter{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: httpProxy.Namespace,
+			Name:      failoverHostRewriteFilterName(httpProxy, ruleIndex),
+		},
+		Spec: envoygatewayv1alpha1.HTTPRouteFilterSpec{
+			URLRewrite: &envoygatewayv1alpha1.HTTPURLRewriteFilter{
+				Hostname: &envoygatewayv1alpha1.HTTPHostnameModifier{
+					Type: envoygatewayv1alpha1.BackendHTTPHostnameModifier,
+				},
+			},
+		},
+	}
+}
+
+// stripURLRewriteHostname removes the hostname from URLRewrite filters,
+// dropping filters that no longer rewrite anything.
+func stripURLRewriteHostname(filters []gatewayv1.HTTPRouteFilter) []gatewayv1.HTTPRouteFilter {
+	out := make([]gatewayv1.HTTPRouteFilter, 0, len(filters))
+	for _, filter := range filters {
+		if filter.Type == gatewayv1.HTTPRouteFilterURLRewrite && filter.URLRewrite != nil {
+			filter.URLRewrite = filter.URLRewrite.DeepCopy()
+			filter.URLRewrite.Hostname = nil
+			if filter.URLRewrite.Path == nil {
+				continue
+			}
+		}
+		out = append(out, filter)
+	}
+	return out
+}
+
+// backendHealthCheckFromAnnotations returns the health check recorded on an
+// upstream EndpointSlice, or nil if the backend is not part of a failover
+// rule.
+func backendHealthCheckFromAnnotations(annotations map[string]string) (*networkingv1alpha.HTTPProxyHealthCheck, error) {
+	v, ok := annotations[BackendHealthCheckAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var healthCheck networkingv1alpha.HTTPProxyHealthCheck
+	if err := json.Unmarshal([]byte(v), &healthCheck); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", BackendHealthCheckAnnotation, err)
+	}
+	return &healthCheck, nil
+}
+
+// backendLoadBalancerFromAnnotations returns the load balancer recorded on an
+// upstream EndpointSlice, or nil if none is recorded.
+func backendLoadBalancerFromAnnotations(annotations map[string]string) (*networkingv1alpha.HTTPProxyBackendLoadBalancer, error) {
+	v, ok := annotations[BackendLoadBalancerAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var loadBalancer networkingv1alpha.HTTPProxyBackendLoadBalancer
+	if err := json.Unmarshal([]byte(v), &loadBalancer); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", BackendLoadBalancerAnnotation, err)
+	}
+	return &loadBalancer, nil
+}
+
+// desiredDownstreamBackend builds the downstream Backend for the endpoints of
+// an upstream EndpointSlice. Envoy Gateway only supports priority levels
+// through the fallback field of a Backend, so failover backends are not
+// programmed as Services, nor are FQDN endpoints, which Envoy resolves itself.
+//
+// Backends do not carry the conditions of their endpoints, so endpoints that
+// are not ready are left out, unless only serving terminating endpoints
+// remain.
+func desiredDownstreamBackend(
+	namespace, name string,
+	upstreamEndpointSlice *discoveryv1.EndpointSlice,
+	port int32,
+) *envoygatewayv1alpha1.Backend {
+	backend := &envoygatewayv1alpha1.Backend{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: envoygatewayv1alpha1.BackendSpec{
+			Fallback: ptr.To(upstreamEndpointSlice.Annotations[BackendRoleAnnotation] == string(networkingv1alpha.HTTPProxyBackendRoleBackup)),
+		},
+	}
+
+	for _, endpointPort := range upstreamEndpointSlice.Ports {
+		if ptr.Deref(endpointPort.Port, 0) == port && ptr.Deref(endpointPort.AppProtocol, "") == AppProtocolH2C {
+			backend.Spec.AppProtocols = []envoygatewayv1alpha1.AppProtocolType{envoygatewayv1alpha1.AppProtocolTypeH2C}
+		}
+	}
+
+	for _, endpoint := range desiredDownstreamEndpoints(upstreamEndpointSlice.Endpoints, true) {
+		for _, address := range endpoint.Addresses {
+			backendEndpoint := envoygatewayv1alpha1.BackendEndpoint{}
+			if upstreamEndpointSlice.AddressType == discoveryv1.AddressTypeFQDN {
+				backendEndpoint.FQDN = &envoygatewayv1alpha1.FQDNEndpoint{
+					Hostname: strings.TrimSuffix(address, "."),
+					Port:     port,
+				}
+			} else {
+				backendEndpoint.IP = &envoygatewayv1alpha1.IPEndpoint{
+					Address: address,
+					Port:    port,
+				}
+			}
+			backend.Spec.Endpoints = append(backend.Spec.Endpoints, backendEndpoint)
+		}
+	}
+
+	return backend
+}
+
+// isFQDNEndpointSlice returns whether an upstream EndpointSlice holds FQDN
+// endpoints, as generated for HTTPProxy backends whose hostnames are not
+// resolved by the operator. Connector backends use a placeholder FQDN address
+// and are reached through the connector instead.
+func isFQDNEndpointSlice(endpointSlice *discoveryv1.EndpointSlice) bool {
+	return endpointSlice.AddressType == discoveryv1.AddressTypeFQDN && !isConnectorEndpointSlice(endpointSlice)
+}
+
+// isConnectorEndpointSlice returns whether an upstream EndpointSlice is the
+// placeholder of a connector backend.
+func isConnectorEndpointSlice(endpointSlice *discoveryv1.EndpointSlice) bool {
+	for _, endpoint := range endpointSlice.Endpoints {
+		if slices.Contains(endpoint.Addresses, connectorPlaceholderAddress) {
+			return true
+		}
+	}
+	return false
+}
+
+// fqdnEndpointSliceHostname returns the hostname of an FQDN EndpointSlice
+// whose endpoints all share one hostname, to validate the certificates of its
+// endpoints against.
+func fqdnEndpointSliceHostname(endpointSlice *discoveryv1.EndpointSlice) *gatewayv1.PreciseHostname {
+	if !isFQDNEndpointSlice(endpointSlice) {
+		return nil
+	}
+	var hostname string
+	for _, endpoint := range endpointSlice.Endpoints {
+		for _, address := range endpoint.Addresses {
+			address = strings.TrimSuffix(address, ".")
+			if hostname != "" && address != hostname {
+				return nil
+			}
+			hostname = address
+		}
+	}
+	if hostname == "" {
+		return nil
+	}
+	return ptr.To(gatewayv1.PreciseHostname(hostname))
+}
+
+// downstreamHealthCheckPolicyName returns the name of the downstream
+// BackendTrafficPolicy that health checks, and load balances, the backends of a
+// rule of an upstream HTTPRoute.
+func downstreamHealthCheckPolicyName(upstreamRouteUID types.UID, ruleIndex int) string {
+	return fmt.Sprintf("route-%s-rule-%d-health", upstreamRouteUID, ruleIndex)
+}
+
+// desiredDownstreamHealthCheckPolicy builds the BackendTrafficPolicy that
+// actively health checks the backends of a downstream HTTPRoute rule, and
+// programs the load balancer of the rule, if any. The policy is attached to
+// the whole route when the rule is not named. The merge type is set when the
+// policy overlays the policy attached to the Gateway, see
+// gatewayPolicyMergeType.
+func desiredDownstreamHealthCheckPolicy(
+	namespace, name string,
+	downstreamRouteName string,
+	ruleName *gatewayv1.SectionName,
+	healthCheck *networkingv1alpha.HTTPProxyHealthCheck,
+	loadBalancer *networkingv1alpha.HTTPProxyBackendLoadBalancer,
+	mergeType *envoygatewayv1alpha1.MergeType,
+) *envoygatewayv1alpha1.BackendTrafficPolicy {
+	path := healthCheck.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return &envoygatewayv1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: envoygatewayv1alpha1.BackendTrafficPolicySpec{
+			PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Group: gatewayv1.GroupName,
+							Kind:  KindHTTPRoute,
+							Name:  gatewayv1.ObjectName(downstreamRouteName),
+						},
+						SectionName: ruleName,
+					},
+				},
+			},
+			ClusterSettings: envoygatewayv1alpha1.ClusterSettings{
+				LoadBalancer: desiredLoadBalancer(loadBalancer),
+				HealthCheck: &envoygatewayv1alpha1.HealthCheck{
+					Active: &envoygatewayv1alpha1.ActiveHealthCheck{
+						Type:               envoygatewayv1alpha1.ActiveHealthCheckerTypeHTTP,
+						Interval:           ptr.To(ptr.Deref(healthCheck.Interval, "10s")),
+						Timeout:            ptr.To(ptr.Deref(healthCheck.Timeout, "1s")),
+						UnhealthyThreshold: ptr.To(ptr.Deref(healthCheck.UnhealthyThreshold, 3)),
+						HealthyThreshold:   ptr.To(ptr.Deref(healthCheck.HealthyThreshold, 1)),
+						HTTP: &envoygatewayv1alpha1.HTTPActiveHealthChecker{
+							Path: path,
+						},
+					},
+				},
+			},
+			MergeType: mergeType,
+		},
+	}
+}
@@ -0,0 +1,463 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/backendhealth"
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestProcessDownstreamHTTPRouteRulesFailover(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayClassName: "test-suite",
+			TargetDomain:               "test-suite.com",
+		},
+	}
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			UID:  uuid.NewUUID(),
+		},
+	}
+
+	healthCheck := `{"path":"/healthz","interval":"5s"}`
+	newFailoverEndpointSlice := func(name, hostname string, annotations map[string]string) *discoveryv1.EndpointSlice {
+		annotations[BackendHealthCheckAnnotation] = healthCheck
+		annotations[BackendCertHostnameAnnotation] = hostname
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   upstreamNamespace.Name,
+				Name:        name,
+				Annotations: annotations,
+			},
+			AddressType: discoveryv1.AddressTypeFQDN,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{hostname}},
+			},
+			Ports: []discoveryv1.EndpointPort{
+				{
+					Name:        ptr.To(name),
+					AppProtocol: ptr.To(SchemeHTTPS),
+					Port:        ptr.To(int32(DefaultHTTPSPort)),
+				},
+			},
+		}
+	}
+
+	newBackendRef := func(name string) gatewayv1.HTTPBackendRef {
+		return gatewayv1.HTTPBackendRef{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Group: ptr.To(gatewayv1.Group("discovery.k8s.io")),
+					Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+					Name:  gatewayv1.ObjectName(name),
+					Port:  ptr.To(gatewayv1.PortNumber(DefaultHTTPSPort)),
+				},
+			},
+		}
+	}
+
+	upstreamRoute := newHTTPRoute(upstreamNamespace.Name, "route", func(route *gatewayv1.HTTPRoute) {
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{
+			{
+				Name:        ptr.To(gatewayv1.SectionName("api")),
+				BackendRefs: []gatewayv1.HTTPBackendRef{newBackendRef("primary"), newBackendRef("backup")},
+			},
+		}
+	})
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			upstreamNamespace,
+			newFailoverEndpointSlice("primary", "primary.example.com", map[string]string{
+				BackendLoadBalancerAnnotation: `{"type":"LeastRequest"}`,
+			}),
+			newFailoverEndpointSlice("backup", "backup.example.com", map[string]string{
+				BackendRoleAnnotation: string(networkingv1alpha.HTTPProxyBackendRoleBackup),
+			}),
+		).
+		Build()
+	fakeDownstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+	reconciler := &GatewayReconciler{
+		Config:            testConfig,
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+	upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test")
+	downstreamGateway := upstreamGateway.DeepCopy()
+	downstreamGateway.Namespace = fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	rules, downstreamResources, downstreamResourcesToDelete, err := reconciler.processDownstreamHTTPRouteRules(
+		context.Background(),
+		fakeUpstreamClient,
+		upstreamGateway,
+		*upstreamRoute,
+		downstreamGateway,
+		downstreamStrategy,
+	)
+	require.NoError(t, err)
+
+	require.Len(t, rules, 1)
+	require.Len(t, rules[0].BackendRefs, 2)
+	for _, backendRef := range rules[0].BackendRefs {
+		assert.Equal(t, envoygatewayv1alpha1.KindBackend, string(ptr.Deref(backendRef.Kind, "")))
+	}
+
+	backends := map[string]*envoygatewayv1alpha1.Backend{}
+	var healthCheckPolicy *envoygatewayv1alpha1.BackendTrafficPolicy
+	var backendTLSPolicies []*gatewayv1.BackendTLSPolicy
+	for _, obj := range downstreamResources {
+		switch obj := obj.(type) {
+		case *envoygatewayv1alpha1.Backend:
+			backends[obj.Spec.Endpoints[0].FQDN.Hostname] = obj
+		case *envoygatewayv1alpha1.BackendTrafficPolicy:
+			healthCheckPolicy = obj
+		case *gatewayv1.BackendTLSPolicy:
+			backendTLSPolicies = append(backendTLSPolicies, obj)
+		case *corev1.Service, *discoveryv1.EndpointSlice:
+			t.Errorf("unexpected %T %q for failover backend", obj, obj.GetName())
+		}
+	}
+
+	if assert.Contains(t, backends, "primary.example.com") {
+		assert.False(t, ptr.Deref(backends["primary.example.com"].Spec.Fallback, true))
+	}
+	if assert.Contains(t, backends, "backup.example.com") {
+		assert.True(t, ptr.Deref(backends["backup.example.com"].Spec.Fallback, false))
+	}
+
+	if assert.Len(t, backendTLSPolicies, 2) {
+		for _, policy := range backendTLSPolicies {
+			assert.Equal(t, gatewayv1.Kind(envoygatewayv1alpha1.KindBackend), policy.Spec.TargetRefs[0].Kind)
+		}
+	}
+
+	require.NotNil(t, healthCheckPolicy)
+	assert.Equal(t, "api", string(ptr.Deref(healthCheckPolicy.Spec.TargetRefs[0].SectionName, "")))
+	active := healthCheckPolicy.Spec.HealthCheck.Active
+	require.NotNil(t, active)
+	assert.Equal(t, "/healthz", active.HTTP.Path)
+	assert.Equal(t, gatewayv1.Duration("5s"), ptr.Deref(active.Interval, ""))
+	assert.Equal(t, gatewayv1.Duration("1s"), ptr.Deref(active.Timeout, ""))
+	// No policy is attached to the Gateway for the policy to overlay.
+	assert.Nil(t, healthCheckPolicy.Spec.MergeType)
+
+	// The load balancer of the rule is programmed by the same policy, as only
+	// one BackendTrafficPolicy applies to a route rule.
+	if assert.NotNil(t, healthCheckPolicy.Spec.LoadBalancer) {
+		assert.Equal(t, envoygatewayv1alpha1.LeastRequestLoadBalancerType, healthCheckPolicy.Spec.LoadBalancer.Type)
+	}
+
+	// Services left over from before the rule had backup backends are removed.
+	var staleServices int
+	for _, obj := range downstreamResourcesToDelete {
+		if _, ok := obj.(*corev1.Service); ok {
+			staleServices++
+		}
+		_, isPolicy := obj.(*envoygatewayv1alpha1.BackendTrafficPolicy)
+		assert.False(t, isPolicy, "health check policy must not be deleted")
+	}
+	assert.Equal(t, 2, staleServices)
+}
+
+func TestBackendFailoverCondition(t *testing.T) {
+	httpProxy := newHTTPProxy()
+	assert.Nil(t, backendFailoverCondition(httpProxy))
+
+	httpProxy.Spec.Rules[0].Name = ptr.To(gatewayv1.SectionName("api"))
+	httpProxy.Spec.Rules[0].Backends = append(httpProxy.Spec.Rules[0].Backends, networkingv1alpha.HTTPProxyRuleBackend{
+		Endpoint: "https://backup.example.com",
+		Role:     networkingv1alpha.HTTPProxyBackendRoleBackup,
+	})
+
+	// The failover state is unknown until the health of the endpoints is
+	// reported.
+	condition := backendFailoverCondition(httpProxy)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+		assert.Equal(t, networkingv1alpha.HTTPProxyReasonFailoverConfigured, condition.Reason)
+		assert.Contains(t, condition.Message, "[api]")
+	}
+
+	tests := []struct {
+		name           string
+		primary        int32
+		backup         int32
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "primary backends serving",
+			primary:        1,
+			backup:         1,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: networkingv1alpha.HTTPProxyReasonPrimaryBackendsServing,
+		},
+		{
+			name:           "failed over",
+			primary:        0,
+			backup:         1,
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: networkingv1alpha.HTTPProxyReasonFailedOver,
+		},
+		{
+			name:           "no healthy backend",
+			primary:        0,
+			backup:         0,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: networkingv1alpha.HTTPProxyReasonBackendsUnhealthy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpProxy.Status.BackendHealth = &networkingv1alpha.HTTPProxyBackendHealth{
+				Rules: []networkingv1alpha.HTTPProxyRuleHealth{{
+					RuleIndex:               0,
+					PrimaryHealthyEndpoints: ptr.To(tt.primary),
+					BackupHealthyEndpoints:  ptr.To(tt.backup),
+				}},
+			}
+			condition := backendFailoverCondition(httpProxy)
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Contains(t, condition.Message, "[api]")
+		})
+	}
+}
+
+func TestRuleFailoverHealth(t *testing.T) {
+	rule := networkingv1alpha.HTTPProxyRule{
+		Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+			{Endpoint: "https://primary.example.com"},
+			{Endpoint: "http://192.0.2.10:8080", Role: networkingv1alpha.HTTPProxyBackendRoleBackup},
+		},
+	}
+
+	_, _, ok := ruleFailoverHealth(rule, nil)
+	assert.False(t, ok, "no endpoint health is reported")
+
+	_, _, ok = ruleFailoverHealth(rule, []backendhealth.EndpointHealth{
+		{Address: "198.51.100.1:443", Hostname: "other.example.com", Healthy: true},
+	})
+	assert.False(t, ok, "endpoints of other backends are ignored")
+
+	primary, backup, ok := ruleFailoverHealth(rule, []backendhealth.EndpointHealth{
+		{Address: "198.51.100.1:443", Hostname: "primary.example.com", Healthy: false},
+		{Address: "198.51.100.2:443", Hostname: "primary.example.com.", Healthy: true},
+		{Address: "192.0.2.10:8080", Healthy: true},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, int32(1), primary)
+	assert.Equal(t, int32(1), backup)
+
+	_, _, ok = ruleFailoverHealth(networkingv1alpha.HTTPProxyRule{
+		Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://primary.example.com"}},
+	}, []backendhealth.EndpointHealth{{Hostname: "primary.example.com", Healthy: true}})
+	assert.False(t, ok, "rules without backup backends do not fail over")
+}
+
+func TestProcessDownstreamHTTPRouteRulesFQDNBackends(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayClassName: "test-suite",
+			TargetDomain:               "test-suite.com",
+		},
+	}
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			UID:  uuid.NewUUID(),
+		},
+	}
+
+	newEndpointSlice := func(name string, addressType discoveryv1.AddressType, address string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: upstreamNamespace.Name,
+				Name:      name,
+			},
+			AddressType: addressType,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{address}},
+			},
+			Ports: []discoveryv1.EndpointPort{
+				{
+					Name:        ptr.To(name),
+					AppProtocol: ptr.To(SchemeHTTPS),
+					Port:        ptr.To(int32(DefaultHTTPSPort)),
+				},
+			},
+		}
+	}
+
+	newRule := func(name string) gatewayv1.HTTPRouteRule {
+		return gatewayv1.HTTPRouteRule{
+			Filters: []gatewayv1.HTTPRouteFilter{
+				{
+					Type: gatewayv1.HTTPRouteFilterURLRewrite,
+					URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
+						Hostname: ptr.To(gatewayv1.PreciseHostname("override.example.com")),
+					},
+				},
+			},
+			BackendRefs: []gatewayv1.HTTPBackendRef{
+				{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{
+							Group: ptr.To(gatewayv1.Group("discovery.k8s.io")),
+							Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+							Name:  gatewayv1.ObjectName(name),
+							Port:  ptr.To(gatewayv1.PortNumber(DefaultHTTPSPort)),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	upstreamRoute := newHTTPRoute(upstreamNamespace.Name, "route", func(route *gatewayv1.HTTPRoute) {
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{newRule("fqdn"), newRule("ip"), newRule("connector")}
+	})
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			upstreamNamespace,
+			newEndpointSlice("fqdn", discoveryv1.AddressTypeFQDN, "api.example.com."),
+			newEndpointSlice("ip", discoveryv1.AddressTypeIPv4, "203.0.113.10"),
+			newEndpointSlice("connector", discoveryv1.AddressTypeFQDN, connectorPlaceholderAddress),
+		).
+		Build()
+	fakeDownstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+	reconciler := &GatewayReconciler{
+		Config:            testConfig,
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+	upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test")
+	downstreamGateway := upstreamGateway.DeepCopy()
+	downstreamGateway.Namespace = fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	rules, downstreamResources, _, err := reconciler.processDownstreamHTTPRouteRules(
+		context.Background(),
+		fakeUpstreamClient,
+		upstreamGateway,
+		*upstreamRoute,
+		downstreamGateway,
+		downstreamStrategy,
+	)
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+
+	// FQDN endpoints are programmed as a Backend, and their certificates are
+	// validated against their own hostname rather than the Host rewrite.
+	assert.Equal(t, envoygatewayv1alpha1.KindBackend, string(ptr.Deref(rules[0].BackendRefs[0].Kind, "")))
+	assert.Equal(t, KindService, string(ptr.Deref(rules[1].BackendRefs[0].Kind, "")))
+	assert.Equal(t, KindService, string(ptr.Deref(rules[2].BackendRefs[0].Kind, "")))
+
+	fqdnResourceName := fmt.Sprintf("route-%s-rule-0-backendref-0", upstreamRoute.UID)
+	var backend *envoygatewayv1alpha1.Backend
+	var backendTLSPolicy *gatewayv1.BackendTLSPolicy
+	for _, obj := range downstreamResources {
+		if obj.GetName() != fqdnResourceName {
+			continue
+		}
+		switch obj := obj.(type) {
+		case *envoygatewayv1alpha1.Backend:
+			backend = obj
+		case *gatewayv1.BackendTLSPolicy:
+			backendTLSPolicy = obj
+		}
+	}
+	require.NotNil(t, backend)
+	require.Len(t, backend.Spec.Endpoints, 1)
+	assert.Equal(t, "api.example.com", backend.Spec.Endpoints[0].FQDN.Hostname)
+	assert.Equal(t, int32(DefaultHTTPSPort), backend.Spec.Endpoints[0].FQDN.Port)
+	require.NotNil(t, backendTLSPolicy)
+	assert.Equal(t, gatewayv1.PreciseHostname("api.example.com"), backendTLSPolicy.Spec.Validation.Hostname)
+	assert.Equal(t, gatewayv1.Kind(envoygatewayv1alpha1.KindBackend), backendTLSPolicy.Spec.TargetRefs[0].Kind)
+}
+
+func TestProgramsEndpointSliceAsBackend(t *testing.T) {
+	newEndpointSlice := func(addressType discoveryv1.AddressType, address string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			AddressType: addressType,
+			Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{address}}},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		mode          config.DownstreamBackendMode
+		endpointSlice *discoveryv1.EndpointSlice
+		want          bool
+	}{
+		{name: "ip endpoints", endpointSlice: newEndpointSlice(discoveryv1.AddressTypeIPv4, "203.0.113.10")},
+		{name: "fqdn endpoints", endpointSlice: newEndpointSlice(discoveryv1.AddressTypeFQDN, "api.example.com"), want: true},
+		{name: "connector", endpointSlice: newEndpointSlice(discoveryv1.AddressTypeFQDN, connectorPlaceholderAddress)},
+		{name: "ip endpoints in backends mode", mode: config.DownstreamBackendModeBackends, endpointSlice: newEndpointSlice(discoveryv1.AddressTypeIPv4, "203.0.113.10"), want: true},
+		{name: "connector in backends mode", mode: config.DownstreamBackendModeBackends, endpointSlice: newEndpointSlice(discoveryv1.AddressTypeFQDN, connectorPlaceholderAddress)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := &GatewayReconciler{Config: config.NetworkServicesOperator{
+				Gateway: config.GatewayConfig{DownstreamBackendMode: tt.mode},
+			}}
+			assert.Equal(t, tt.want, reconciler.programsEndpointSliceAsBackend(tt.endpointSlice))
+		})
+	}
+}
+
+func TestDesiredDownstreamBackendOmitsNotReadyEndpoints(t *testing.T) {
+	endpointSlice := &discoveryv1.EndpointSlice{
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"203.0.113.10"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}},
+			{Addresses: []string{"203.0.113.11"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+		},
+	}
+
+	backend := desiredDownstreamBackend("ns", "backend", endpointSlice, DefaultHTTPPort)
+	require.Len(t, backend.Spec.Endpoints, 1)
+	assert.Equal(t, "203.0.113.10", backend.Spec.Endpoints[0].IP.Address)
+}
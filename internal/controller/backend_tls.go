@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+// BackendCACertificateRefAnnotation is set on the upstream EndpointSlice of an
+// HTTPS backend whose certificate is issued by a private CA. It holds the
+// `<Kind>/<name>` of the ConfigMap or Secret in the namespace of the
+// EndpointSlice with the CA certificates in the `ca.crt` key, which the
+// gateway controller copies downstream for the BackendTLSPolicy of the
+// backend.
+const BackendCACertificateRefAnnotation = "networking.datumapis.com/backend-ca-certificate-ref"
+
+// BackendSubjectAltNamesAnnotation is set on the upstream EndpointSlice of an
+// HTTPS backend whose certificate is validated against explicit Subject
+// Alternative Names. It carries the JSON encoded list of gateway API
+// SubjectAltNames.
+const BackendSubjectAltNamesAnnotation = "networking.datumapis.com/backend-subject-alt-names"
+
+func setBackendTLSAnnotations(annotations map[string]string, tls *networkingv1alpha.HTTPProxyBackendTLS) error {
+	if tls == nil {
+		return nil
+	}
+
+	if tls.CACertificateRef != nil {
+		kind := tls.CACertificateRef.Kind
+		if kind == "" {
+			kind = "ConfigMap"
+		}
+		annotations[BackendCACertificateRefAnnotation] = fmt.Sprintf("%s/%s", kind, tls.CACertificateRef.Name)
+	}
+
+	if len(tls.SubjectAltNames) > 0 {
+		b, err := json.Marshal(tls.SubjectAltNames)
+		if err != nil {
+			return err
+		}
+		annotations[BackendSubjectAltNamesAnnotation] = string(b)
+	}
+	return nil
+}
+
+// backendCACertificateRef returns the CA certificate reference recorded on an
+// upstream EndpointSlice, or nil when the backend is validated against the
+// system CA certificates.
+func backendCACertificateRef(endpointSlice *discoveryv1.EndpointSlice) (*gatewayv1.ObjectReference, error) {
+	v, ok := endpointSlice.Annotations[BackendCACertificateRefAnnotation]
+	if !ok || v == "" {
+		return nil, nil
+	}
+
+	kind, name, found := strings.Cut(v, "/")
+	if !found || name == "" || (kind != "ConfigMap" && kind != "Secret") {
+		return nil, fmt.Errorf("invalid %s annotation %q on endpointslice %q, expected ConfigMap/<name> or Secret/<name>",
+			BackendCACertificateRefAnnotation, v, endpointSlice.Name)
+	}
+
+	return &gatewayv1.ObjectReference{
+		Kind: gatewayv1.Kind(kind),
+		Name: gatewayv1.ObjectName(name),
+	}, nil
+}
+
+// backendSubjectAltNames returns the Subject Alternative Names recorded on an
+// upstream EndpointSlice.
+func backendSubjectAltNames(endpointSlice *discoveryv1.EndpointSlice) ([]gatewayv1.SubjectAltName, error) {
+	v, ok := endpointSlice.Annotations[BackendSubjectAltNamesAnnotation]
+	if !ok || v == "" {
+		return nil, nil
+	}
+
+	var subjectAltNames []gatewayv1.SubjectAltName
+	if err := json.Unmarshal([]byte(v), &subjectAltNames); err != nil {
+		return nil, fmt.Errorf("failed parsing %s annotation on endpointslice %q: %w", BackendSubjectAltNamesAnnotation, endpointSlice.Name, err)
+	}
+	return subjectAltNames, nil
+}
+
+// backendTLSCACertificateName returns the name of the downstream ConfigMap with
+// the CA certificates of the BackendTLSPolicy with the given name.
+func backendTLSCACertificateName(name string) string {
+	return resourcename.GetValidDNS1123Name(name + "-ca")
+}
+
+// desiredBackendTLSResources returns the downstream BackendTLSPolicy that
+// validates the certificate of the backend of an upstream EndpointSlice, along
+// with a copy of the CA certificates it references when the backend uses a
+// private CA. The CA certificate ConfigMap is returned to be deleted when the
+// backend is validated against the system CA certificates.
+func desiredBackendTLSResources(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamEndpointSlice *discoveryv1.EndpointSlice,
+	namespace string,
+	name string,
+	targetRef gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+	hostname gatewayv1.PreciseHostname,
+) (downstreamResources []client.Object, downstreamResourcesToDelete []client.Object, err error) {
+	subjectAltNames, err := backendSubjectAltNames(upstreamEndpointSlice)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	caCertificateRef, err := backendCACertificateRef(upstreamEndpointSlice)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validation := gatewayv1.BackendTLSPolicyValidation{
+		Hostname:        hostname,
+		SubjectAltNames: subjectAltNames,
+	}
+
+	caConfigMapName := backendTLSCACertificateName(name)
+	if caCertificateRef == nil {
+		validation.WellKnownCACertificates = ptr.To(gatewayv1.WellKnownCACertificatesSystem)
+		downstreamResourcesToDelete = append(downstreamResourcesToDelete, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: caConfigMapName},
+		})
+	} else {
+		caBundle, err := getClientCABundle(ctx, upstreamClient, upstreamEndpointSlice.Namespace, *caCertificateRef)
+		if err != nil {
+			return nil, nil, err
+		}
+		if caBundle == nil || !validCABundle(caBundle) {
+			// TODO(jreese) set the RouteConditionResolvedRefs condition to
+			// False, as the CA certificates can't be used.
+			return nil, nil, fmt.Errorf("the %s %q referenced by endpointslice %q does not contain PEM encoded CA certificates in the %q key",
+				caCertificateRef.Kind, caCertificateRef.Name, upstreamEndpointSlice.Name, clientCACertificateKey)
+		}
+
+		downstreamResources = append(downstreamResources, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: caConfigMapName},
+			Data:       map[string]string{clientCACertificateKey: string(caBundle)},
+		})
+		validation.CACertificateRefs = []gatewayv1.LocalObjectReference{
+			{
+				Group: "",
+				Kind:  "ConfigMap",
+				Name:  gatewayv1.ObjectName(caConfigMapName),
+			},
+		}
+	}
+
+	// BackendTLSPolicy graduated from v1alpha3 to v1 in gateway-api v1.5.
+	downstreamResources = append(downstreamResources, &gatewayv1.BackendTLSPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: gatewayv1.BackendTLSPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				targetRef,
+			},
+			Validation: validation,
+		},
+	})
+
+	return downstreamResources, downstreamResourcesToDelete, nil
+}
+
+// backendTLSResourcesToDelete returns the downstream resources that program
+// certificate validation for a backend that is no longer reached over HTTPS.
+func backendTLSResourcesToDelete(namespace, name string) []client.Object {
+	return []client.Object{
+		&gatewayv1.BackendTLSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: backendTLSCACertificateName(name)},
+		},
+	}
+}
+
+// endpointSliceReferencesBackendCA reports whether the backend of an upstream
+// EndpointSlice is validated against the CA certificates of the given object.
+func endpointSliceReferencesBackendCA(endpointSlice *discoveryv1.EndpointSlice, kind, name string) bool {
+	ref, err := backendCACertificateRef(endpointSlice)
+	return err == nil && ref != nil && string(ref.Kind) == kind && string(ref.Name) == name
+}
+
+// listGatewaysForBackendCACertificate returns reconcile requests for the
+// Gateways of the routes with backends that are validated against the CA
+// certificates of a ConfigMap or Secret.
+func (r *GatewayReconciler) listGatewaysForBackendCACertificate(
+	ctx context.Context,
+	clusterName multicluster.ClusterName,
+	cl cluster.Cluster,
+	kind string,
+	obj client.Object,
+) []mcreconcile.Request {
+	logger := log.FromContext(ctx)
+
+	var endpointSlices discoveryv1.EndpointSliceList
+	if err := cl.GetClient().List(ctx, &endpointSlices, client.InNamespace(obj.GetNamespace())); err != nil {
+		logger.Error(err, "failed to list EndpointSlices")
+		return nil
+	}
+
+	var requests []mcreconcile.Request
+	for i := range endpointSlices.Items {
+		if endpointSliceReferencesBackendCA(&endpointSlices.Items[i], kind, obj.GetName()) {
+			requests = append(requests, r.listGatewaysForEndpointSlice(ctx, clusterName, cl, &endpointSlices.Items[i])...)
+		}
+	}
+	return requests
+}
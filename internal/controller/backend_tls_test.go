@@ -0,0 +1,255 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestSetBackendTLSAnnotations(t *testing.T) {
+	annotations := map[string]string{}
+	require.NoError(t, setBackendTLSAnnotations(annotations, &networkingv1alpha.HTTPProxyBackendTLS{
+		CACertificateRef: &networkingv1alpha.HTTPProxyCACertificateReference{Name: "internal-ca"},
+		SubjectAltNames: []gatewayv1.SubjectAltName{
+			{Type: gatewayv1.URISubjectAltNameType, URI: "spiffe://internal/ns/api/sa/api"},
+		},
+	}))
+
+	endpointSlice := &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+
+	ref, err := backendCACertificateRef(endpointSlice)
+	require.NoError(t, err)
+	assert.Equal(t, &gatewayv1.ObjectReference{Kind: "ConfigMap", Name: "internal-ca"}, ref)
+	assert.True(t, endpointSliceReferencesBackendCA(endpointSlice, "ConfigMap", "internal-ca"))
+	assert.False(t, endpointSliceReferencesBackendCA(endpointSlice, "Secret", "internal-ca"))
+
+	subjectAltNames, err := backendSubjectAltNames(endpointSlice)
+	require.NoError(t, err)
+	assert.Equal(t, []gatewayv1.SubjectAltName{
+		{Type: gatewayv1.URISubjectAltNameType, URI: "spiffe://internal/ns/api/sa/api"},
+	}, subjectAltNames)
+
+	endpointSlice.Annotations[BackendCACertificateRefAnnotation] = "Service/internal-ca"
+	_, err = backendCACertificateRef(endpointSlice)
+	assert.Error(t, err)
+}
+
+func TestProcessDownstreamHTTPRouteRulesBackendTLS(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayClassName: "test-suite",
+			TargetDomain:               "test-suite.com",
+		},
+	}
+
+	caPEM, _ := generateTLSKeyPair(t, "Internal CA", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			UID:  uuid.NewUUID(),
+		},
+	}
+
+	newEndpointSlice := func(annotations map[string]string) *discoveryv1.EndpointSlice {
+		annotations[BackendCertHostnameAnnotation] = "api.internal"
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   upstreamNamespace.Name,
+				Name:        "route-0-0",
+				Annotations: annotations,
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"203.0.113.10"}},
+			},
+			Ports: []discoveryv1.EndpointPort{
+				{
+					Name:        ptr.To("httpproxy-0-0"),
+					AppProtocol: ptr.To(SchemeHTTPS),
+					Port:        ptr.To(int32(DefaultHTTPSPort)),
+				},
+			},
+		}
+	}
+
+	upstreamRoute := newHTTPRoute(upstreamNamespace.Name, "route", func(route *gatewayv1.HTTPRoute) {
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{
+			{
+				BackendRefs: []gatewayv1.HTTPBackendRef{
+					{
+						BackendRef: gatewayv1.BackendRef{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Group: ptr.To(gatewayv1.Group("discovery.k8s.io")),
+								Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+								Name:  "route-0-0",
+								Port:  ptr.To(gatewayv1.PortNumber(DefaultHTTPSPort)),
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	subjectAltNames := `[{"type":"URI","uri":"spiffe://internal/ns/api/sa/api"}]`
+	resourceName := fmt.Sprintf("route-%s-rule-0-backendref-0", upstreamRoute.UID)
+
+	tests := map[string]struct {
+		endpointSlice   *discoveryv1.EndpointSlice
+		objects         []client.Object
+		expectErr       bool
+		expectConfigMap bool
+		expectedSANs    []gatewayv1.SubjectAltName
+	}{
+		"system ca certificates": {
+			endpointSlice: newEndpointSlice(map[string]string{}),
+		},
+		"configmap ca certificates and subject alt names": {
+			endpointSlice: newEndpointSlice(map[string]string{
+				BackendCACertificateRefAnnotation: "ConfigMap/internal-ca",
+				BackendSubjectAltNamesAnnotation:  subjectAltNames,
+			}),
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: upstreamNamespace.Name, Name: "internal-ca"},
+					Data:       map[string]string{clientCACertificateKey: string(caPEM)},
+				},
+			},
+			expectConfigMap: true,
+			expectedSANs: []gatewayv1.SubjectAltName{
+				{Type: gatewayv1.URISubjectAltNameType, URI: "spiffe://internal/ns/api/sa/api"},
+			},
+		},
+		"secret ca certificates": {
+			endpointSlice: newEndpointSlice(map[string]string{
+				BackendCACertificateRefAnnotation: "Secret/internal-ca",
+			}),
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: upstreamNamespace.Name, Name: "internal-ca"},
+					Data:       map[string][]byte{clientCACertificateKey: caPEM},
+				},
+			},
+			expectConfigMap: true,
+		},
+		"missing ca certificates": {
+			endpointSlice: newEndpointSlice(map[string]string{
+				BackendCACertificateRefAnnotation: "ConfigMap/internal-ca",
+			}),
+			expectErr: true,
+		},
+		"invalid ca certificates": {
+			endpointSlice: newEndpointSlice(map[string]string{
+				BackendCACertificateRefAnnotation: "ConfigMap/internal-ca",
+			}),
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: upstreamNamespace.Name, Name: "internal-ca"},
+					Data:       map[string]string{clientCACertificateKey: "not a certificate"},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeUpstreamClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(append(tt.objects, upstreamNamespace, tt.endpointSlice)...).
+				Build()
+			fakeDownstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+			reconciler := &GatewayReconciler{
+				Config:            testConfig,
+				DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+			}
+
+			downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+			upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test")
+			downstreamGateway := upstreamGateway.DeepCopy()
+			downstreamGateway.Namespace = fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+			_, downstreamResources, downstreamResourcesToDelete, err := reconciler.processDownstreamHTTPRouteRules(
+				context.Background(),
+				fakeUpstreamClient,
+				upstreamGateway,
+				*upstreamRoute,
+				downstreamGateway,
+				downstreamStrategy,
+			)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var policy *gatewayv1.BackendTLSPolicy
+			var caConfigMap *corev1.ConfigMap
+			for _, obj := range downstreamResources {
+				switch obj := obj.(type) {
+				case *gatewayv1.BackendTLSPolicy:
+					policy = obj
+				case *corev1.ConfigMap:
+					caConfigMap = obj
+				}
+			}
+			require.NotNil(t, policy)
+			assert.Equal(t, resourceName, policy.Name)
+			assert.Equal(t, gatewayv1.PreciseHostname("api.internal"), policy.Spec.Validation.Hostname)
+			assert.Equal(t, tt.expectedSANs, policy.Spec.Validation.SubjectAltNames)
+
+			caConfigMapName := backendTLSCACertificateName(resourceName)
+			if !tt.expectConfigMap {
+				assert.Nil(t, caConfigMap)
+				assert.Equal(t, ptr.To(gatewayv1.WellKnownCACertificatesSystem), policy.Spec.Validation.WellKnownCACertificates)
+				assert.Empty(t, policy.Spec.Validation.CACertificateRefs)
+				assert.Contains(t, downstreamResourceNames(downstreamResourcesToDelete), caConfigMapName)
+				return
+			}
+
+			require.NotNil(t, caConfigMap)
+			assert.Equal(t, caConfigMapName, caConfigMap.Name)
+			assert.Equal(t, downstreamGateway.Namespace, caConfigMap.Namespace)
+			assert.Equal(t, string(caPEM), caConfigMap.Data[clientCACertificateKey])
+			assert.Nil(t, policy.Spec.Validation.WellKnownCACertificates)
+			assert.Equal(t, []gatewayv1.LocalObjectReference{
+				{Kind: "ConfigMap", Name: gatewayv1.ObjectName(caConfigMapName)},
+			}, policy.Spec.Validation.CACertificateRefs)
+			assert.NotContains(t, downstreamResourceNames(downstreamResourcesToDelete), caConfigMapName)
+		})
+	}
+}
+
+func downstreamResourceNames(objs []client.Object) []string {
+	names := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		names = append(names, obj.GetName())
+	}
+	return names
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"slices"
+
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// BackendTrafficSplitAnnotation is set on the upstream EndpointSlices of the
+// backends of an HTTPProxy rule that splits traffic between weighted, canary
+// or blue/green backends. The gateway controller programs them as Envoy Gateway
+// Backends of a single cluster, so the Host header follows the backend each
+// request is sent to.
+const BackendTrafficSplitAnnotation = "networking.datumapis.com/backend-traffic-split"
+
+// httpProxyRuleSplitsTraffic returns whether the rule splits requests between
+// more than one of its backends.
+func httpProxyRuleSplitsTraffic(rule networkingv1alpha.HTTPProxyRule) bool {
+	if httpProxyRuleCanary(rule) != nil || httpProxyRuleBlueGreen(rule) != nil {
+		return true
+	}
+	primaries := 0
+	for _, backend := range rule.Backends {
+		switch backend.Role {
+		case networkingv1alpha.HTTPProxyBackendRoleCanary:
+			return true
+		case networkingv1alpha.HTTPProxyBackendRoleBackup:
+		default:
+			primaries++
+		}
+	}
+	return primaries > 1
+}
+
+func httpProxyRuleCanary(rule networkingv1alpha.HTTPProxyRule) *networkingv1alpha.HTTPProxyCanary {
+	if rule.TrafficPolicy == nil {
+		return nil
+	}
+	return rule.TrafficPolicy.Canary
+}
+
+// httpProxyBackendWeight returns the weight of the downstream backendRef of the
+// backend. In rules with a canary traffic policy, the canary backend receives
+// the canary percentage of requests and the primary backend the remainder.
+func httpProxyBackendWeight(rule networkingv1alpha.HTTPProxyRule, backend networkingv1alpha.HTTPProxyRuleBackend) *int32 {
+	canary := httpProxyRuleCanary(rule)
+	switch {
+	case canary == nil || isBackupBackend(backend):
+		return backend.Weight
+	case backend.Role == networkingv1alpha.HTTPProxyBackendRoleCanary:
+		return ptr.To(canary.Percentage)
+	default:
+		return ptr.To(100 - canary.Percentage)
+	}
+}
+
+// desiredCanaryHeaderRouteRule returns the route rule that sends requests which
+// match the canary headers of the rule to its canary backend, or nil when the
+// rule does not route by header. The header matches are added to every match
+// of the rule, so the canary rule takes precedence over the rule itself.
+func desiredCanaryHeaderRouteRule(
+	rule networkingv1alpha.HTTPProxyRule,
+	ruleFilters []gatewayv1.HTTPRouteFilter,
+	backendRefs []gatewayv1.HTTPBackendRef,
+) *gatewayv1.HTTPRouteRule {
+	canary := httpProxyRuleCanary(rule)
+	if canary == nil || len(canary.Headers) == 0 {
+		return nil
+	}
+
+	canaryIndex := slices.IndexFunc(rule.Backends, func(backend networkingv1alpha.HTTPProxyRuleBackend) bool {
+		return backend.Role == networkingv1alpha.HTTPProxyBackendRoleCanary
+	})
+	if canaryIndex < 0 || canaryIndex >= len(backendRefs) {
+		return nil
+	}
+	canaryBackendRef := *backendRefs[canaryIndex].DeepCopy()
+	canaryBackendRef.Weight = nil
+
+	matches := rule.Matches
+	if len(matches) == 0 {
+		matches = []gatewayv1.HTTPRouteMatch{{
+			Path: &gatewayv1.HTTPPathMatch{
+				Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+				Value: ptr.To("/"),
+			},
+		}}
+	}
+	canaryMatches := make([]gatewayv1.HTTPRouteMatch, len(matches))
+	for i, match := range matches {
+		canaryMatches[i] = *match.DeepCopy()
+		canaryMatches[i].Headers = append(canaryMatches[i].Headers, canary.Headers...)
+	}
+
+	var name *gatewayv1.SectionName
+	if rule.Name != nil {
+		name = ptr.To(*rule.Name + "-canary")
+	}
+
+	return &gatewayv1.HTTPRouteRule{
+		Name:        name,
+		Matches:     canaryMatches,
+		Filters:     slices.Clone(ruleFilters),
+		BackendRefs: []gatewayv1.HTTPBackendRef{canaryBackendRef},
+	}
+}
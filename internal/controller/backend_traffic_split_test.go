@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestHTTPProxyRuleSplitsTraffic(t *testing.T) {
+	assert.False(t, httpProxyRuleSplitsTraffic(networkingv1alpha.HTTPProxyRule{
+		Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+			{Endpoint: "https://primary.example.com"},
+			{Endpoint: "https://backup.example.com", Role: networkingv1alpha.HTTPProxyBackendRoleBackup},
+		},
+	}))
+	assert.True(t, httpProxyRuleSplitsTraffic(networkingv1alpha.HTTPProxyRule{
+		Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+			{Endpoint: "https://blue.example.com", Weight: ptr.To[int32](1)},
+			{Endpoint: "https://green.example.com", Weight: ptr.To[int32](1)},
+		},
+	}))
+	assert.True(t, httpProxyRuleSplitsTraffic(networkingv1alpha.HTTPProxyRule{
+		TrafficPolicy: &networkingv1alpha.HTTPProxyTrafficPolicy{Canary: &networkingv1alpha.HTTPProxyCanary{}},
+		Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+			{Endpoint: "https://stable.example.com"},
+		},
+	}))
+}
+
+func TestHTTPProxyBackendWeight(t *testing.T) {
+	primary := networkingv1alpha.HTTPProxyRuleBackend{Endpoint: "https://stable.example.com", Weight: ptr.To[int32](3)}
+	canary := networkingv1alpha.HTTPProxyRuleBackend{Endpoint: "https://canary.example.com", Role: networkingv1alpha.HTTPProxyBackendRoleCanary}
+
+	rule := networkingv1alpha.HTTPProxyRule{Backends: []networkingv1alpha.HTTPProxyRuleBackend{primary}}
+	assert.Equal(t, ptr.To[int32](3), httpProxyBackendWeight(rule, primary))
+
+	rule.TrafficPolicy = &networkingv1alpha.HTTPProxyTrafficPolicy{Canary: &networkingv1alpha.HTTPProxyCanary{Percentage: 0}}
+	assert.Equal(t, ptr.To[int32](100), httpProxyBackendWeight(rule, primary))
+	assert.Equal(t, ptr.To[int32](0), httpProxyBackendWeight(rule, canary))
+
+	rule.TrafficPolicy.Canary.Percentage = 100
+	assert.Equal(t, ptr.To[int32](0), httpProxyBackendWeight(rule, primary))
+	assert.Equal(t, ptr.To[int32](100), httpProxyBackendWeight(rule, canary))
+}
+
+func TestDesiredCanaryHeaderRouteRule(t *testing.T) {
+	rule := networkingv1alpha.HTTPProxyRule{
+		Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+			{Endpoint: "https://stable.example.com"},
+			{Endpoint: "https://canary.example.com", Role: networkingv1alpha.HTTPProxyBackendRoleCanary},
+		},
+	}
+	backendRefs := []gatewayv1.HTTPBackendRef{
+		{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "stable"}, Weight: ptr.To[int32](90)}},
+		{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "canary"}, Weight: ptr.To[int32](10)}},
+	}
+
+	// Without header routing, there is no canary rule.
+	assert.Nil(t, desiredCanaryHeaderRouteRule(rule, nil, backendRefs))
+	rule.TrafficPolicy = &networkingv1alpha.HTTPProxyTrafficPolicy{Canary: &networkingv1alpha.HTTPProxyCanary{Percentage: 10}}
+	assert.Nil(t, desiredCanaryHeaderRouteRule(rule, nil, backendRefs))
+
+	// Rules without matches match every path.
+	headers := []gatewayv1.HTTPHeaderMatch{{Name: "x-canary", Value: "always"}}
+	rule.TrafficPolicy.Canary.Headers = headers
+	canaryRule := desiredCanaryHeaderRouteRule(rule, nil, backendRefs)
+	require.NotNil(t, canaryRule)
+	assert.Nil(t, canaryRule.Name)
+	require.Len(t, canaryRule.Matches, 1)
+	assert.Equal(t, gatewayv1.PathMatchPathPrefix, ptr.Deref(canaryRule.Matches[0].Path.Type, ""))
+	assert.Equal(t, "/", ptr.Deref(canaryRule.Matches[0].Path.Value, ""))
+	assert.Equal(t, headers, canaryRule.Matches[0].Headers)
+	require.Len(t, canaryRule.BackendRefs, 1)
+	assert.Equal(t, gatewayv1.ObjectName("canary"), canaryRule.BackendRefs[0].Name)
+	assert.Nil(t, canaryRule.BackendRefs[0].Weight)
+	assert.Equal(t, ptr.To[int32](10), backendRefs[1].Weight, "backendRefs of the rule must not be modified")
+
+	// The headers are added to every match, alongside existing header matches.
+	rule.Name = ptr.To(gatewayv1.SectionName("api"))
+	rule.Matches = []gatewayv1.HTTPRouteMatch{
+		{Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchExact), Value: ptr.To("/a")}},
+		{Headers: []gatewayv1.HTTPHeaderMatch{{Name: "x-tenant", Value: "b"}}},
+	}
+	canaryRule = desiredCanaryHeaderRouteRule(rule, nil, backendRefs)
+	require.NotNil(t, canaryRule)
+	assert.Equal(t, gatewayv1.SectionName("api-canary"), ptr.Deref(canaryRule.Name, ""))
+	require.Len(t, canaryRule.Matches, 2)
+	assert.Equal(t, headers, canaryRule.Matches[0].Headers)
+	assert.Equal(t, []gatewayv1.HTTPHeaderMatch{{Name: "x-tenant", Value: "b"}, headers[0]}, canaryRule.Matches[1].Headers)
+	assert.Len(t, rule.Matches[1].Headers, 1, "matches of the rule must not be modified")
+}
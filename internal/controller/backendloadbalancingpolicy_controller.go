@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+	mcsource "sigs.k8s.io/multicluster-runtime/pkg/source"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+const backendLoadBalancingPolicyFinalizer = "networking.datumapis.com/backendloadbalancingpolicy-cleanup"
+
+// BackendLoadBalancingPolicyReconciler reconciles a BackendLoadBalancingPolicy
+// object
+type BackendLoadBalancingPolicyReconciler struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+
+	DownstreamCluster cluster.Cluster
+
+	// UpstreamOutages, when set, retries reconciles while the API server of
+	// the policy's cluster is unreachable.
+	UpstreamOutages *UpstreamOutageTracker
+}
+
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=backendloadbalancingpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=backendloadbalancingpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=backendloadbalancingpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=backendtrafficpolicies,verbs=get;list;watch;create;update;patch;delete
+
+func (r *BackendLoadBalancingPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	return r.localPolicy().reconciler().reconcile(ctx, r.mgr, r.DownstreamCluster, r.UpstreamOutages, req)
+}
+
+func (r *BackendLoadBalancingPolicyReconciler) localPolicy() *targetedLocalPolicy[*networkingv1alpha.BackendLoadBalancingPolicy, *envoygatewayv1alpha1.BackendTrafficPolicy] {
+	return &targetedLocalPolicy[*networkingv1alpha.BackendLoadBalancingPolicy, *envoygatewayv1alpha1.BackendTrafficPolicy]{
+		name:           "backendloadbalancingpolicy",
+		kind:           "BackendLoadBalancingPolicy",
+		finalizer:      backendLoadBalancingPolicyFinalizer,
+		controllerName: string(r.Config.Gateway.ControllerName),
+		newPolicy: func() *networkingv1alpha.BackendLoadBalancingPolicy {
+			return &networkingv1alpha.BackendLoadBalancingPolicy{}
+		},
+		listTargetRefs: func(ctx context.Context, c client.Client, policy *networkingv1alpha.BackendLoadBalancingPolicy) ([]gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName, error) {
+			var httpRoutes gatewayv1.HTTPRouteList
+			if err := c.List(ctx, &httpRoutes, client.InNamespace(policy.Namespace)); err != nil {
+				return nil, fmt.Errorf("failed listing httproutes: %w", err)
+			}
+			return backendLoadBalancingPolicyRouteTargets(policy, httpRoutes.Items), nil
+		},
+		policyStatus: func(policy *networkingv1alpha.BackendLoadBalancingPolicy) *gatewayv1alpha2.PolicyStatus {
+			return &policy.Status.PolicyStatus
+		},
+		listPolicies: listBackendTrafficLocalPolicies,
+		newDownstream: func(policy *networkingv1alpha.BackendLoadBalancingPolicy) *envoygatewayv1alpha1.BackendTrafficPolicy {
+			return &envoygatewayv1alpha1.BackendTrafficPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: downstreamBackendLoadBalancingPolicyName(policy)},
+			}
+		},
+		desiredDownstream: desiredLoadBalancingBackendTrafficPolicy,
+	}
+}
+
+// downstreamBackendLoadBalancingPolicyName returns the name of the
+// BackendTrafficPolicy that programs a BackendLoadBalancingPolicy. It is
+// prefixed so that it doesn't collide with BackendTrafficPolicies replicated
+// from the upstream namespace.
+func downstreamBackendLoadBalancingPolicyName(policy *networkingv1alpha.BackendLoadBalancingPolicy) string {
+	return resourcename.GetValidDNS1123Name(fmt.Sprintf("loadbalancing-%s", policy.Name))
+}
+
+// backendLoadBalancingPolicyRouteTargets returns the rules of the HTTPRoutes
+// that have a backendRef to a target of the policy. Envoy Gateway programs
+// load balancing per route rule, so the policy attaches to the rules rather
+// than to the backends. An unnamed rule is only targeted when it is the only
+// rule of its route, as the policy would otherwise attach to every rule of the
+// route.
+func backendLoadBalancingPolicyRouteTargets(
+	policy *networkingv1alpha.BackendLoadBalancingPolicy,
+	httpRoutes []gatewayv1.HTTPRoute,
+) []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+	httpRoutes = slices.Clone(httpRoutes)
+	slices.SortFunc(httpRoutes, func(a, b gatewayv1.HTTPRoute) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var targetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName
+	for _, httpRoute := range httpRoutes {
+		for _, rule := range httpRoute.Spec.Rules {
+			if rule.Name == nil && len(httpRoute.Spec.Rules) > 1 {
+				continue
+			}
+			if !slices.ContainsFunc(rule.BackendRefs, func(backendRef gatewayv1.HTTPBackendRef) bool {
+				return backendRefTargetedByPolicy(httpRoute.Namespace, backendRef.BackendObjectReference, policy.Spec.TargetRefs)
+			}) {
+				continue
+			}
+			targetRefs = append(targetRefs, gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+					Group: gatewayv1.GroupName,
+					Kind:  KindHTTPRoute,
+					Name:  gatewayv1.ObjectName(httpRoute.Name),
+				},
+				SectionName: rule.Name,
+			})
+		}
+	}
+	return targetRefs
+}
+
+// backendRefTargetedByPolicy reports whether a backendRef of a route in the
+// namespace references one of the targets of a policy.
+func backendRefTargetedByPolicy(
+	namespace string,
+	backendRef gatewayv1.BackendObjectReference,
+	targetRefs []gatewayv1alpha2.LocalPolicyTargetReference,
+) bool {
+	if string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(namespace))) != namespace {
+		return false
+	}
+	group := ptr.Deref(backendRef.Group, "")
+	kind := ptr.Deref(backendRef.Kind, "Service")
+	return slices.ContainsFunc(targetRefs, func(targetRef gatewayv1alpha2.LocalPolicyTargetReference) bool {
+		return targetRef.Group == group && targetRef.Kind == kind && targetRef.Name == backendRef.Name
+	})
+}
+
+// desiredLoadBalancingBackendTrafficPolicy programs the load balancer of the
+// policy on the downstream BackendTrafficPolicy attached to the accepted route
+// rules.
+func desiredLoadBalancingBackendTrafficPolicy(
+	policy *networkingv1alpha.BackendLoadBalancingPolicy,
+	backendTrafficPolicy *envoygatewayv1alpha1.BackendTrafficPolicy,
+	targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+) string {
+	backendTrafficPolicy.Spec = envoygatewayv1alpha1.BackendTrafficPolicySpec{
+		PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+			TargetRefs: targetRefs,
+		},
+		MergeType: routePolicyMergeType(targetRefs),
+		ClusterSettings: envoygatewayv1alpha1.ClusterSettings{
+			LoadBalancer: desiredLoadBalancer(&policy.Spec.LoadBalancer),
+		},
+	}
+	return ""
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackendLoadBalancingPolicyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+
+	downstreamBackendTrafficPolicySource := mcsource.TypedKind(
+		&envoygatewayv1alpha1.BackendTrafficPolicy{},
+		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*envoygatewayv1alpha1.BackendTrafficPolicy](&networkingv1alpha.BackendLoadBalancingPolicy{}),
+	)
+
+	downstreamBackendTrafficPolicyClusterSource, _, _ := downstreamBackendTrafficPolicySource.ForCluster("", r.DownstreamCluster)
+
+	// The targets of a policy are the rules of the HTTPRoutes that reference
+	// its backends, so every HTTPRoute in the namespace may change them.
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&networkingv1alpha.BackendLoadBalancingPolicy{}).
+		Watches(&gatewayv1.HTTPRoute{}, enqueueLocalPoliciesInNamespaceFunc(listBackendLoadBalancingPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.HTTPProxy{}, enqueueLocalPoliciesInNamespaceFunc(listBackendLoadBalancingPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.RateLimitPolicy{}, enqueueLocalPoliciesInNamespaceFunc(listBackendLoadBalancingPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.PayloadPolicy{}, enqueueLocalPoliciesInNamespaceFunc(listBackendLoadBalancingPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		WatchesRawSource(downstreamBackendTrafficPolicyClusterSource).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "backendloadbalancingpolicy", 0)).
+		Named("backendloadbalancingpolicy").
+		Complete(r)
+}
+
+// listBackendLoadBalancingPolicies lists the BackendLoadBalancingPolicies in a
+// namespace, with the HTTPRoute rules they attach to as their targets.
+func listBackendLoadBalancingPolicies(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error) {
+	var policies networkingv1alpha.BackendLoadBalancingPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing backendloadbalancingpolicies: %w", err)
+	}
+	if len(policies.Items) == 0 {
+		return nil, nil
+	}
+
+	var httpRoutes gatewayv1.HTTPRouteList
+	if err := c.List(ctx, &httpRoutes, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing httproutes: %w", err)
+	}
+
+	localPolicies := make([]localPolicy, 0, len(policies.Items))
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		localPolicies = append(localPolicies, localPolicy{
+			Object:     policy,
+			kind:       "BackendLoadBalancingPolicy",
+			targetRefs: backendLoadBalancingPolicyRouteTargets(policy, httpRoutes.Items),
+		})
+	}
+	return localPolicies, nil
+}
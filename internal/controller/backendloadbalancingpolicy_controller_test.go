@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestBackendLoadBalancingPolicyReconcile(t *testing.T) {
+	backendRef := func(group gatewayv1.Group, kind gatewayv1.Kind, name string) gatewayv1.HTTPBackendRef {
+		return gatewayv1.HTTPBackendRef{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Group: ptr.To(group),
+					Kind:  ptr.To(kind),
+					Name:  gatewayv1.ObjectName(name),
+					Port:  ptr.To(gatewayv1.PortNumber(80)),
+				},
+			},
+		}
+	}
+	newHTTPRoute := func(name string, rules ...gatewayv1.HTTPRouteRule) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: name},
+			Spec:       gatewayv1.HTTPRouteSpec{Rules: rules},
+		}
+	}
+	httpRoutes := []client.Object{
+		newHTTPRoute("api",
+			gatewayv1.HTTPRouteRule{Name: ptr.To(gatewayv1.SectionName("app")), BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("", "Service", "app")}},
+			gatewayv1.HTTPRouteRule{Name: ptr.To(gatewayv1.SectionName("other")), BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("", "Service", "other")}},
+		),
+		newHTTPRoute("single",
+			gatewayv1.HTTPRouteRule{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("discovery.k8s.io", "EndpointSlice", "app")}},
+		),
+		// Unnamed rules of routes with several rules can't be targeted.
+		newHTTPRoute("unnamed",
+			gatewayv1.HTTPRouteRule{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("", "Service", "app")}},
+			gatewayv1.HTTPRouteRule{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("", "Service", "other")}},
+		),
+	}
+
+	now := time.Now().Truncate(time.Second)
+
+	policy := &networkingv1alpha.BackendLoadBalancingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         localPolicyTestNamespace,
+			Name:              "policy",
+			UID:               types.UID("uid-policy"),
+			CreationTimestamp: metav1.NewTime(now),
+			Finalizers:        []string{backendLoadBalancingPolicyFinalizer},
+		},
+		Spec: networkingv1alpha.BackendLoadBalancingPolicySpec{
+			TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReference{
+				{Group: "", Kind: "Service", Name: "app"},
+				{Group: "discovery.k8s.io", Kind: "EndpointSlice", Name: "app"},
+			},
+			LoadBalancer: networkingv1alpha.HTTPProxyBackendLoadBalancer{
+				Type: networkingv1alpha.HTTPProxyBackendLoadBalancerRingHash,
+				HashKey: &networkingv1alpha.HTTPProxyBackendHashKey{
+					Cookie: ptr.To("session"),
+				},
+			},
+		},
+	}
+
+	olderRateLimitPolicy := &networkingv1alpha.RateLimitPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         localPolicyTestNamespace,
+			Name:              "older",
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+		Spec: networkingv1alpha.RateLimitPolicySpec{
+			TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Group: gatewayv1.GroupName, Kind: KindHTTPRoute, Name: "api"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		wantTargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName
+		wantAncestors  map[gatewayv1.ObjectName]gatewayv1.PolicyConditionReason
+	}{
+		{
+			name: "route rules referencing the backends",
+			wantTargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Group: gatewayv1.GroupName, Kind: KindHTTPRoute, Name: "api"},
+					SectionName:                ptr.To(gatewayv1.SectionName("app")),
+				},
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Group: gatewayv1.GroupName, Kind: KindHTTPRoute, Name: "single"},
+				},
+			},
+			wantAncestors: map[gatewayv1.ObjectName]gatewayv1.PolicyConditionReason{
+				"api":    gatewayv1.PolicyReasonAccepted,
+				"single": gatewayv1.PolicyReasonAccepted,
+			},
+		},
+		{
+			name:    "conflict with older policy for the whole route",
+			objects: []client.Object{olderRateLimitPolicy},
+			wantTargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Group: gatewayv1.GroupName, Kind: KindHTTPRoute, Name: "single"},
+				},
+			},
+			wantAncestors: map[gatewayv1.ObjectName]gatewayv1.PolicyConditionReason{
+				"api":    gatewayv1.PolicyReasonConflicted,
+				"single": gatewayv1.PolicyReasonAccepted,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			objects := append([]client.Object{policy.DeepCopy()}, httpRoutes...)
+			objects = append(objects, tt.objects...)
+
+			fakeUpstreamClient, fakeDownstreamClient := newLocalPolicyTestClients(t, &networkingv1alpha.BackendLoadBalancingPolicy{}, objects...)
+
+			reconciler := &BackendLoadBalancingPolicyReconciler{
+				mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+				DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+				Config:            localPolicyTestConfig,
+			}
+
+			req := localPolicyTestRequest("policy")
+			_, err := reconciler.Reconcile(ctx, req)
+			assert.NoError(t, err)
+
+			var updated networkingv1alpha.BackendLoadBalancingPolicy
+			assert.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &updated))
+			assert.Len(t, updated.Status.Ancestors, len(tt.wantAncestors))
+			for _, ancestor := range updated.Status.Ancestors {
+				wantReason, ok := tt.wantAncestors[ancestor.AncestorRef.Name]
+				if assert.True(t, ok, "unexpected ancestor %s", ancestor.AncestorRef.Name) {
+					condition := apimeta.FindStatusCondition(ancestor.Conditions, string(gatewayv1.PolicyConditionAccepted))
+					if assert.NotNil(t, condition) {
+						assert.Equal(t, string(wantReason), condition.Reason)
+					}
+				}
+			}
+
+			backendTrafficPolicy := &envoygatewayv1alpha1.BackendTrafficPolicy{}
+			err = fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "loadbalancing-policy"}, backendTrafficPolicy)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.ElementsMatch(t, tt.wantTargetRefs, backendTrafficPolicy.Spec.TargetRefs)
+			assert.Equal(t, ptr.To(envoygatewayv1alpha1.StrategicMerge), backendTrafficPolicy.Spec.MergeType)
+			assert.Equal(t, &envoygatewayv1alpha1.LoadBalancer{
+				Type: envoygatewayv1alpha1.ConsistentHashLoadBalancerType,
+				ConsistentHash: &envoygatewayv1alpha1.ConsistentHash{
+					Type:   envoygatewayv1alpha1.CookieConsistentHashType,
+					Cookie: &envoygatewayv1alpha1.Cookie{Name: "session"},
+				},
+			}, backendTrafficPolicy.Spec.ClusterSettings.LoadBalancer)
+		})
+	}
+}
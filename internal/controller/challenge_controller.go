@@ -26,6 +26,9 @@ import (
 type ChallengeReconciler struct {
 	Config            config.NetworkServicesOperator
 	DownstreamCluster cluster.Cluster
+	// ReloadableConfig, when set, supplies the config.ReloadableFields that
+	// may change while the manager is running.
+	ReloadableConfig *config.Reloadable
 }
 
 // +kubebuilder:rbac:groups=acme.cert-manager.io,resources=challenges,verbs=get;list;watch;delete
@@ -84,7 +87,7 @@ func (r *ChallengeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 // certificate issuer (ClusterIssuers that are mapped in the ClusterIssuerMap configuration).
 func (r *ChallengeReconciler) isGatewayRelatedIssuer(ref cmmeta.ObjectReference) bool {
 	if ref.Kind == KindClusterIssuer || ref.Kind == "" {
-		for _, mappedIssuer := range r.Config.Gateway.ClusterIssuerMap {
+		for _, mappedIssuer := range reloadableConfig(&r.Config, r.ReloadableConfig).Gateway.ClusterIssuerMap {
 			if mappedIssuer == ref.Name {
 				return true
 			}
@@ -110,6 +113,7 @@ func (r *ChallengeReconciler) SetupWithManager(mgr manager.Manager) error {
 			challenge := object.(*cmacmev1.Challenge)
 			return r.isGatewayRelatedIssuer(challenge.Spec.IssuerRef)
 		})).
+		WithOptions(controllerOptions[ctrl.Request](r.Config, "challenge", 0)).
 		Named("challenge").
 		Complete(r)
 }
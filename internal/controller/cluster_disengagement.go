@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// Outcomes of the disengagement of a cluster, used as Prometheus label values.
+const (
+	clusterDisengagementMoved     = "moved"
+	clusterDisengagementRetained  = "retained"
+	clusterDisengagementFinalized = "finalized"
+	clusterDisengagementFailed    = "failed"
+)
+
+var _ mcmanager.Runnable = &ClusterDisengagementHandler{}
+
+// ClusterDisengagementHandler handles upstream clusters once they are
+// disengaged from this replica.
+//
+// Reconcile requests that are still queued for a disengaged cluster are
+// dropped by the controllers set up with ControllerManager. Once a cluster has
+// stayed disengaged for the grace period and the provider no longer knows it,
+// the downstream resources of the cluster are finalized by deleting their
+// anchors, when enabled. Clusters still known to the provider were moved to
+// another replica, by sharding or project filtering, and keep their resources.
+type ClusterDisengagementHandler struct {
+	Config            config.NetworkServicesOperator
+	DownstreamCluster cluster.Cluster
+
+	mgr   mcmanager.Manager
+	queue workqueue.TypedRateLimitingInterface[multicluster.ClusterName]
+
+	mu sync.Mutex
+	// engaged holds the context of each engaged cluster.
+	engaged map[multicluster.ClusterName]context.Context
+	// disengaged holds the clusters that were engaged before, until they are
+	// engaged again.
+	disengaged map[multicluster.ClusterName]struct{}
+}
+
+// SetupWithManager registers the handler with the manager. It must be set up
+// before the controllers, so that clusters are engaged with it first.
+func (h *ClusterDisengagementHandler) SetupWithManager(mgr mcmanager.Manager) error {
+	h.mgr = mgr
+	h.queue = workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[multicluster.ClusterName](),
+		workqueue.TypedRateLimitingQueueConfig[multicluster.ClusterName]{},
+	)
+	h.engaged = map[multicluster.ClusterName]context.Context{}
+	h.disengaged = map[multicluster.ClusterName]struct{}{}
+	return mgr.Add(h)
+}
+
+// ControllerManager returns the manager to set up controllers with. It reports
+// clusters that were disengaged from this replica as not found, so that the
+// reconcile requests queued for them are dropped instead of retried.
+func (h *ClusterDisengagementHandler) ControllerManager() mcmanager.Manager {
+	return &engagedClusterManager{Manager: h.mgr, handler: h}
+}
+
+// Engage tracks the cluster until ctx is done.
+func (h *ClusterDisengagementHandler) Engage(ctx context.Context, name multicluster.ClusterName, _ cluster.Cluster) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.engaged[name] == ctx {
+		return nil
+	}
+	h.engaged[name] = ctx
+	delete(h.disengaged, name)
+
+	go func() {
+		<-ctx.Done()
+		h.disengage(ctx, name)
+	}()
+	return nil
+}
+
+// disengage records the cluster as disengaged once ctx, the context it was
+// engaged with, is done.
+func (h *ClusterDisengagementHandler) disengage(ctx context.Context, name multicluster.ClusterName) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// The cluster was engaged again in the meantime.
+	if h.engaged[name] != ctx {
+		return
+	}
+	delete(h.engaged, name)
+	h.disengaged[name] = struct{}{}
+
+	clusterDisengagementsTotal.Inc()
+	log.Log.WithName("cluster-disengagement").Info("cluster disengaged", "cluster", name)
+	h.queue.AddAfter(name, h.Config.DownstreamResourceManagement.ClusterDisengagement.GracePeriod.Duration)
+}
+
+// isDisengaged reports whether the cluster was disengaged from this replica.
+func (h *ClusterDisengagementHandler) isDisengaged(name multicluster.ClusterName) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, ok := h.disengaged[name]
+	return ok
+}
+
+// Start handles disengaged clusters once their grace period passed, until ctx
+// is done.
+func (h *ClusterDisengagementHandler) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("cluster-disengagement")
+	ctx = log.IntoContext(ctx, logger)
+
+	go func() {
+		<-ctx.Done()
+		h.queue.ShutDown()
+	}()
+
+	for {
+		name, shutdown := h.queue.Get()
+		if shutdown {
+			return nil
+		}
+		if err := h.handle(ctx, name); err != nil {
+			clusterDisengagementOutcomesTotal.WithLabelValues(clusterDisengagementFailed).Inc()
+			logger.Error(err, "failed finalizing downstream resources of disengaged cluster", "cluster", name)
+			h.queue.AddRateLimited(name)
+		} else {
+			h.queue.Forget(name)
+		}
+		h.queue.Done(name)
+	}
+}
+
+// handle finalizes the downstream resources of a disengaged cluster that the
+// provider removed.
+func (h *ClusterDisengagementHandler) handle(ctx context.Context, name multicluster.ClusterName) error {
+	logger := log.FromContext(ctx).WithValues("cluster", name)
+	if !h.isDisengaged(name) {
+		return nil
+	}
+
+	if _, err := h.mgr.GetProvider().Get(ctx, name); err == nil {
+		logger.Info("disengaged cluster is still known to the provider, keeping its downstream resources")
+		clusterDisengagementOutcomesTotal.WithLabelValues(clusterDisengagementMoved).Inc()
+		return nil
+	} else if !errors.Is(err, multicluster.ErrClusterNotFound) {
+		return fmt.Errorf("failed getting cluster from provider: %w", err)
+	}
+
+	namespaces, err := downstreamNamespacesOfCluster(ctx, h.DownstreamCluster.GetClient(), name)
+	if err != nil {
+		return err
+	}
+
+	recorder := eventRecorderFor(h.DownstreamCluster)
+	if !h.Config.DownstreamResourceManagement.ClusterDisengagement.FinalizeResources {
+		for i := range namespaces {
+			recorder.Eventf(&namespaces[i], nil, corev1.EventTypeWarning, "UpstreamClusterRemoved", "Retain",
+				"Upstream cluster %s was removed, its downstream resources are retained", name)
+		}
+		logger.Info("cluster was removed, retaining its downstream resources", "namespaces", len(namespaces))
+		clusterDisengagementOutcomesTotal.WithLabelValues(clusterDisengagementRetained).Inc()
+		return nil
+	}
+
+	for i := range namespaces {
+		namespace := &namespaces[i]
+		deleted, err := deleteDownstreamAnchors(ctx, h.DownstreamCluster.GetClient(), namespace.Name)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			recorder.Eventf(namespace, nil, corev1.EventTypeNormal, "UpstreamClusterRemoved", "Finalize",
+				"Upstream cluster %s was removed, deleted %d anchors of its downstream resources", name, deleted)
+		}
+	}
+	logger.Info("cluster was removed, finalized its downstream resources", "namespaces", len(namespaces))
+	clusterDisengagementOutcomesTotal.WithLabelValues(clusterDisengagementFinalized).Inc()
+	return nil
+}
+
+// downstreamNamespacesOfCluster returns the downstream namespaces of an
+// upstream cluster, including those labeled with the legacy cluster name
+// format.
+func downstreamNamespacesOfCluster(ctx context.Context, reader client.Reader, name multicluster.ClusterName) ([]corev1.Namespace, error) {
+	clusterName := strings.TrimPrefix(string(name), "/")
+	requirement, err := labels.NewRequirement(downstreamclient.UpstreamOwnerClusterNameLabel, selection.In, []string{
+		downstreamclient.UpstreamClusterNameLabelValue(clusterName),
+		downstreamclient.UpstreamClusterNameLabelValue("/" + clusterName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed building downstream namespace selector: %w", err)
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := reader.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*requirement)}); err != nil {
+		return nil, fmt.Errorf("failed listing downstream namespaces: %w", err)
+	}
+	return namespaces.Items, nil
+}
+
+// deleteDownstreamAnchors deletes the anchors of a downstream namespace, which
+// garbage collects the resources they own. It returns the number of anchors
+// deleted.
+func deleteDownstreamAnchors(ctx context.Context, cl client.Client, namespace string) (int, error) {
+	var configMaps metav1.PartialObjectMetadataList
+	configMaps.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMapList"))
+	if err := cl.List(ctx, &configMaps,
+		client.InNamespace(namespace),
+		client.HasLabels{downstreamclient.UpstreamOwnerKindLabel},
+	); err != nil {
+		return 0, fmt.Errorf("failed listing downstream anchors: %w", err)
+	}
+
+	deleted := 0
+	for i := range configMaps.Items {
+		anchor := &configMaps.Items[i]
+		if !strings.HasPrefix(anchor.Name, anchorNamePrefix) || !anchor.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := cl.Delete(ctx, anchor, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return deleted, fmt.Errorf("failed deleting downstream anchor %s/%s: %w", namespace, anchor.Name, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// engagedClusterManager reports clusters disengaged from this replica as not
+// found.
+type engagedClusterManager struct {
+	mcmanager.Manager
+	handler *ClusterDisengagementHandler
+}
+
+func (m *engagedClusterManager) GetCluster(ctx context.Context, name multicluster.ClusterName) (cluster.Cluster, error) {
+	if m.handler.isDisengaged(name) {
+		return nil, fmt.Errorf("cluster %s was disengaged: %w", name, multicluster.ErrClusterNotFound)
+	}
+	return m.Manager.GetCluster(ctx, name)
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+type disengagementTestManager struct {
+	mcmanager.Manager
+	provider multicluster.Provider
+}
+
+func (m *disengagementTestManager) Add(mcmanager.Runnable) error { return nil }
+
+func (m *disengagementTestManager) GetProvider() multicluster.Provider { return m.provider }
+
+func (m *disengagementTestManager) GetCluster(context.Context, multicluster.ClusterName) (cluster.Cluster, error) {
+	return &fakeCluster{}, nil
+}
+
+// disengagementTestProvider knows the clusters in known.
+type disengagementTestProvider struct {
+	multicluster.Provider
+	known map[multicluster.ClusterName]bool
+}
+
+func (p *disengagementTestProvider) Get(_ context.Context, name multicluster.ClusterName) (cluster.Cluster, error) {
+	if p.known[name] {
+		return &fakeCluster{}, nil
+	}
+	return nil, multicluster.ErrClusterNotFound
+}
+
+func TestClusterDisengagementHandler(t *testing.T) {
+	downstreamNamespace := func(name, clusterLabel string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{downstreamclient.UpstreamOwnerClusterNameLabel: clusterLabel},
+		}}
+	}
+	anchor := func(namespace string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      anchorNamePrefix + "owner-uid",
+			Labels:    map[string]string{downstreamclient.UpstreamOwnerKindLabel: KindGateway},
+		}}
+	}
+
+	tests := map[string]struct {
+		finalize     bool
+		known        bool
+		reengage     bool
+		wantDeleted  []string
+		wantRetained []string
+	}{
+		"removed cluster is finalized": {
+			finalize:     true,
+			wantDeleted:  []string{"ns-a", "ns-legacy"},
+			wantRetained: []string{"ns-b"},
+		},
+		"removed cluster is retained when finalization is disabled": {
+			wantRetained: []string{"ns-a", "ns-legacy", "ns-b"},
+		},
+		"moved cluster keeps its resources": {
+			finalize:     true,
+			known:        true,
+			wantRetained: []string{"ns-a", "ns-legacy", "ns-b"},
+		},
+		"re-engaged cluster keeps its resources": {
+			finalize:     true,
+			reengage:     true,
+			wantRetained: []string{"ns-a", "ns-legacy", "ns-b"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := t.Context()
+			testScheme := runtime.NewScheme()
+			require.NoError(t, scheme.AddToScheme(testScheme))
+
+			downstreamClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(
+					downstreamNamespace("ns-a", downstreamclient.UpstreamClusterNameLabelValue("project-a")),
+					downstreamNamespace("ns-legacy", downstreamclient.UpstreamClusterNameLabelValue("/project-a")),
+					downstreamNamespace("ns-b", downstreamclient.UpstreamClusterNameLabelValue("project-b")),
+					anchor("ns-a"), anchor("ns-legacy"), anchor("ns-b"),
+				).
+				Build()
+
+			operatorConfig := config.NetworkServicesOperator{}
+			config.SetObjectDefaults_NetworkServicesOperator(&operatorConfig)
+			operatorConfig.DownstreamResourceManagement.ClusterDisengagement.FinalizeResources = tt.finalize
+
+			handler := &ClusterDisengagementHandler{
+				Config:            operatorConfig,
+				DownstreamCluster: &fakeCluster{cl: downstreamClient},
+			}
+			provider := &disengagementTestProvider{known: map[multicluster.ClusterName]bool{"project-a": tt.known}}
+			require.NoError(t, handler.SetupWithManager(&disengagementTestManager{provider: provider}))
+
+			clusterCtx, disengage := context.WithCancel(ctx)
+			require.NoError(t, handler.Engage(clusterCtx, "project-a", &fakeCluster{}))
+			_, err := handler.ControllerManager().GetCluster(ctx, "project-a")
+			require.NoError(t, err)
+
+			disengage()
+			require.Eventually(t, func() bool { return handler.isDisengaged("project-a") }, time.Second, 10*time.Millisecond)
+			_, err = handler.ControllerManager().GetCluster(ctx, "project-a")
+			assert.ErrorIs(t, err, multicluster.ErrClusterNotFound, "requests of disengaged clusters are dropped")
+
+			if tt.reengage {
+				require.NoError(t, handler.Engage(ctx, "project-a", &fakeCluster{}))
+				_, err = handler.ControllerManager().GetCluster(ctx, "project-a")
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, handler.handle(ctx, "project-a"))
+
+			for _, namespace := range tt.wantDeleted {
+				err := downstreamClient.Get(ctx, client.ObjectKeyFromObject(anchor(namespace)), &corev1.ConfigMap{})
+				assert.Truef(t, apierrors.IsNotFound(err), "expected the anchor in %s to be deleted, got %v", namespace, err)
+			}
+			for _, namespace := range tt.wantRetained {
+				err := downstreamClient.Get(ctx, client.ObjectKeyFromObject(anchor(namespace)), &corev1.ConfigMap{})
+				assert.NoErrorf(t, err, "expected the anchor in %s to be retained", namespace)
+			}
+		})
+	}
+}
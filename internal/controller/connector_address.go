@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
+)
+
+// connectorAddressWatchLag is the time between a Connector becoming Ready and
+// the HTTPProxy controller observing it. Connectors are read from the informer
+// cache, so a high lag means the addressing routes are programmed with is
+// stale.
+var connectorAddressWatchLag = promauto.NewHistogram(
+	prometheus.HistogramOpts{
+		Name:    "nso_connector_address_watch_lag_seconds",
+		Help:    "Time between a Connector becoming Ready and the HTTPProxy controller observing it.",
+		Buckets: prometheus.DefBuckets,
+	},
+)
+
+// connectorAddress is the addressing of a Connector that downstream routes are
+// programmed with.
+type connectorAddress struct {
+	ready     bool
+	nodeID    string
+	homeRelay string
+	addresses []networkingv1alpha1.PublicKeyConnectorAddress
+	// hasPublicKey is false when the Connector has no public key connection
+	// details.
+	hasPublicKey bool
+}
+
+func connectorAddressFromConnector(connector *networkingv1alpha1.Connector) connectorAddress {
+	address := connectorAddress{
+		ready: apimeta.IsStatusConditionTrue(connector.Status.Conditions, networkingv1alpha1.ConnectorConditionReady),
+	}
+	if details := connector.Status.ConnectionDetails; details != nil &&
+		details.Type == networkingv1alpha1.PublicKeyConnectorConnectionType && details.PublicKey != nil {
+		address.hasPublicKey = true
+		address.nodeID = details.PublicKey.Id
+		address.homeRelay = details.PublicKey.HomeRelay
+		address.addresses = slices.Clone(details.PublicKey.Addresses)
+	}
+	return address
+}
+
+// equal returns whether the addressing of the Connector is unchanged.
+func (a connectorAddress) equal(other connectorAddress) bool {
+	return a.ready == other.ready &&
+		a.hasPublicKey == other.hasPublicKey &&
+		a.nodeID == other.nodeID &&
+		a.homeRelay == other.homeRelay &&
+		slices.Equal(a.addresses, other.addresses)
+}
+
+// patchDetails returns whether the Connector is ready, and if so, the node id
+// its tunnel patches connect to.
+func (a connectorAddress) patchDetails(name string) (bool, string, error) {
+	if !a.ready {
+		return false, "", nil
+	}
+	if !a.hasPublicKey {
+		return false, "", fmt.Errorf("connector %q does not have public key connection details", name)
+	}
+	if a.nodeID == "" {
+		return false, "", fmt.Errorf("connector %q public key id is empty", name)
+	}
+	return true, a.nodeID, nil
+}
+
+// connectorAddressSource resolves the current addressing of a Connector in the
+// namespace.
+type connectorAddressSource func(ctx context.Context, namespace, name string) (connectorAddress, error)
+
+// connectorAddresses returns a connectorAddressSource that reads Connectors
+// with the cluster's cache-backed client.
+func connectorAddresses(cl client.Client) connectorAddressSource {
+	return func(ctx context.Context, namespace, name string) (connectorAddress, error) {
+		var connector networkingv1alpha1.Connector
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &connector); err != nil {
+			return connectorAddress{}, err
+		}
+		return connectorAddressFromConnector(&connector), nil
+	}
+}
+
+// connectorAddressChanged passes Connector updates that change the addressing
+// routes are programmed with, so heartbeats and other status churn don't
+// reconcile the HTTPProxies referencing the Connector.
+func connectorAddressChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldConnector, ok1 := e.ObjectOld.(*networkingv1alpha1.Connector)
+			newConnector, ok2 := e.ObjectNew.(*networkingv1alpha1.Connector)
+			if !ok1 || !ok2 {
+				return true
+			}
+			oldAddress := connectorAddressFromConnector(oldConnector)
+			newAddress := connectorAddressFromConnector(newConnector)
+			if newAddress.ready && !oldAddress.ready {
+				if ready := apimeta.FindStatusCondition(newConnector.Status.Conditions, networkingv1alpha1.ConnectorConditionReady); ready != nil {
+					connectorAddressWatchLag.Observe(max(time.Since(ready.LastTransitionTime.Time), 0).Seconds())
+				}
+			}
+			return !oldAddress.equal(newAddress)
+		},
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
+)
+
+func newAddressedConnector(ready bool, nodeID string) *networkingv1alpha1.Connector {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	return &networkingv1alpha1.Connector{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "connector"},
+		Status: networkingv1alpha1.ConnectorStatus{
+			Conditions: []metav1.Condition{{
+				Type:               networkingv1alpha1.ConnectorConditionReady,
+				Status:             status,
+				Reason:             "Test",
+				LastTransitionTime: metav1.Now(),
+			}},
+			ConnectionDetails: &networkingv1alpha1.ConnectorConnectionDetails{
+				Type: networkingv1alpha1.PublicKeyConnectorConnectionType,
+				PublicKey: &networkingv1alpha1.ConnectorConnectionDetailsPublicKey{
+					Id:        nodeID,
+					HomeRelay: "https://relay.example.com",
+					Addresses: []networkingv1alpha1.PublicKeyConnectorAddress{{Address: "192.0.2.1", Port: 4433}},
+				},
+			},
+		},
+	}
+}
+
+func TestConnectorAddressPatchDetails(t *testing.T) {
+	ready, nodeID, err := connectorAddressFromConnector(newAddressedConnector(true, "node-a")).patchDetails("connector")
+	require.NoError(t, err)
+	assert.True(t, ready)
+	assert.Equal(t, "node-a", nodeID)
+
+	ready, _, err = connectorAddressFromConnector(newAddressedConnector(false, "node-a")).patchDetails("connector")
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	_, _, err = connectorAddressFromConnector(newAddressedConnector(true, "")).patchDetails("connector")
+	assert.ErrorContains(t, err, "public key id is empty")
+
+	connector := newAddressedConnector(true, "node-a")
+	connector.Status.ConnectionDetails = nil
+	_, _, err = connectorAddressFromConnector(connector).patchDetails("connector")
+	assert.ErrorContains(t, err, "does not have public key connection details")
+}
+
+func TestConnectorAddressChanged(t *testing.T) {
+	p := connectorAddressChanged()
+
+	online := newAddressedConnector(true, "node-a")
+	assert.True(t, p.Create(event.CreateEvent{Object: online}))
+
+	// Status churn that doesn't change the addressing is filtered.
+	heartbeat := online.DeepCopy()
+	heartbeat.Status.Conditions[0].Message = "still connected"
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: online, ObjectNew: heartbeat}))
+
+	moved := online.DeepCopy()
+	moved.Status.ConnectionDetails.PublicKey.Addresses[0].Address = "192.0.2.2"
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: heartbeat, ObjectNew: moved}))
+
+	offline := moved.DeepCopy()
+	offline.Status.Conditions[0].Status = metav1.ConditionFalse
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: moved, ObjectNew: offline}))
+
+	assert.True(t, p.Delete(event.DeleteEvent{Object: offline}))
+}
+
+func TestConnectorAddresses(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, networkingv1alpha1.AddToScheme(testScheme))
+
+	upstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newAddressedConnector(true, "node-a")).Build()
+
+	address, err := connectorAddresses(upstreamClient)(ctx, "test", "connector")
+	require.NoError(t, err)
+	assert.True(t, address.ready)
+	assert.Equal(t, "node-a", address.nodeID)
+
+	_, err = connectorAddresses(upstreamClient)(ctx, "test", "missing")
+	assert.True(t, apierrors.IsNotFound(err))
+}
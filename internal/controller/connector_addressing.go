@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// connectorAddressingKey identifies a Connector in an upstream cluster, as
+// referenced by one HTTPProxy. HTTPProxies that share a Connector each sweep it
+// with their own tokens, so the key includes the HTTPProxy.
+type connectorAddressingKey struct {
+	clusterName   string
+	namespace     string
+	name          string
+	httpProxyName string
+}
+
+// connectorAddressingPropagator pushes the current addressing of Connectors to
+// their downstream copies when an HTTPProxy that references them becomes
+// Programmed.
+//
+// The edge only re-programs the routes backed by a Connector when the
+// Connector's liveness changes, so routes of an HTTPProxy that is programmed
+// after its Connector came online would otherwise wait for the next change.
+// A sweep writes the Connector's current status and a new sweep token to the
+// downstream Connector, which makes the edge re-program those routes at once.
+type connectorAddressingPropagator struct {
+	mu sync.Mutex
+	// swept holds the last sweep token pushed for each Connector by each
+	// HTTPProxy, so each time an HTTPProxy becomes Programmed only results in a
+	// single patch.
+	swept map[connectorAddressingKey]string
+}
+
+func newConnectorAddressingPropagator() *connectorAddressingPropagator {
+	return &connectorAddressingPropagator{
+		swept: map[connectorAddressingKey]string{},
+	}
+}
+
+// connectorAddressingSweepToken returns the sweep token for a Programmed
+// HTTPProxy. It changes every time the Programmed condition flips to True.
+func connectorAddressingSweepToken(httpProxy *networkingv1alpha.HTTPProxy, programmed *metav1.Condition) string {
+	return fmt.Sprintf("%s/%d", httpProxy.Name, programmed.LastTransitionTime.Unix())
+}
+
+// httpProxyProgrammedCondition returns the Programmed condition of the status
+// if it is True.
+func httpProxyProgrammedCondition(status *networkingv1alpha.HTTPProxyStatus) *metav1.Condition {
+	programmed := apimeta.FindStatusCondition(status.Conditions, networkingv1alpha.HTTPProxyConditionProgrammed)
+	if programmed == nil || programmed.Status != metav1.ConditionTrue {
+		return nil
+	}
+	return programmed
+}
+
+// httpProxyConnectorNames returns the sorted names of the Connectors referenced
+// by the HTTPProxy's backends.
+func httpProxyConnectorNames(httpProxy *networkingv1alpha.HTTPProxy) []string {
+	var names []string
+	for _, rule := range httpProxy.Spec.Rules {
+		for _, backend := range rule.Backends {
+			if backend.Connector != nil && !slices.Contains(names, backend.Connector.Name) {
+				names = append(names, backend.Connector.Name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// sweep pushes the current addressing of every Connector referenced by the
+// HTTPProxy to the downstream cluster. Connectors that don't exist upstream or
+// haven't been replicated downstream yet are skipped, as the replicator writes
+// their current status when it creates them.
+func (p *connectorAddressingPropagator) sweep(
+	ctx context.Context,
+	clusterName string,
+	upstreamClient client.Client,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	httpProxy *networkingv1alpha.HTTPProxy,
+	token string,
+) error {
+	logger := log.FromContext(ctx)
+
+	var errs []error
+	for _, name := range httpProxyConnectorNames(httpProxy) {
+		key := connectorAddressingKey{clusterName: clusterName, namespace: httpProxy.Namespace, name: name, httpProxyName: httpProxy.Name}
+		if p.lastSweep(key) == token {
+			continue
+		}
+
+		var connector networkingv1alpha1.Connector
+		if err := upstreamClient.Get(ctx, client.ObjectKey{Namespace: httpProxy.Namespace, Name: name}, &connector); err != nil {
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to get connector %q: %w", name, err))
+			}
+			continue
+		}
+
+		pushed, err := pushConnectorAddressing(ctx, downstreamStrategy, &connector, token)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to push addressing of connector %q: %w", name, err))
+			continue
+		}
+		p.recordSweep(key, token)
+		if pushed {
+			logger.Info("pushed connector addressing downstream", "connector", name, "sweep", token)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *connectorAddressingPropagator) lastSweep(key connectorAddressingKey) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.swept[key]
+}
+
+func (p *connectorAddressingPropagator) recordSweep(key connectorAddressingKey, token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.swept[key] = token
+}
+
+// forget drops the sweep state of the Connectors referenced by a deleted
+// HTTPProxy.
+func (p *connectorAddressingPropagator) forget(clusterName string, httpProxy *networkingv1alpha.HTTPProxy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, name := range httpProxyConnectorNames(httpProxy) {
+		delete(p.swept, connectorAddressingKey{clusterName: clusterName, namespace: httpProxy.Namespace, name: name, httpProxyName: httpProxy.Name})
+	}
+}
+
+// pushConnectorAddressing merge patches the upstream status and the sweep token
+// onto the downstream copy of the Connector. It returns false if the
+// downstream Connector doesn't exist.
+func pushConnectorAddressing(
+	ctx context.Context,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	connector *networkingv1alpha1.Connector,
+	token string,
+) (bool, error) {
+	downstreamObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, connector)
+	if err != nil {
+		return false, err
+	}
+
+	status, err := json.Marshal(connector.Status)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal connector status: %w", err)
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				networkingv1alpha1.UpstreamStatusAnnotation:              string(status),
+				networkingv1alpha1.ConnectorAddressingSweepAnnotation:    token,
+				networkingv1alpha1.ConnectorAddressingPushTimeAnnotation: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	downstreamConnector := &networkingv1alpha1.Connector{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: downstreamObjectMeta.Namespace,
+			Name:      downstreamObjectMeta.Name,
+		},
+	}
+	if err := downstreamStrategy.GetClient().Patch(ctx, downstreamConnector, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
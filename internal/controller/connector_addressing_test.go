@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestHTTPProxyConnectorNames(t *testing.T) {
+	httpProxy := &networkingv1alpha.HTTPProxy{
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Rules: []networkingv1alpha.HTTPProxyRule{
+				{Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+					{Connector: &networkingv1alpha.ConnectorReference{Name: "b"}},
+					{Endpoint: "https://example.com"},
+				}},
+				{Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+					{Connector: &networkingv1alpha.ConnectorReference{Name: "a"}},
+					{Connector: &networkingv1alpha.ConnectorReference{Name: "b"}},
+				}},
+			},
+		},
+	}
+	assert.Equal(t, []string{"a", "b"}, httpProxyConnectorNames(httpProxy))
+}
+
+func TestConnectorAddressingSweep(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha1.AddToScheme(testScheme))
+
+	upstreamNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()}}
+	downstreamNamespaceName := fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	connector := &networkingv1alpha1.Connector{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "online"},
+		Status: networkingv1alpha1.ConnectorStatus{
+			Conditions: []metav1.Condition{{
+				Type:   networkingv1alpha1.ConnectorConditionReady,
+				Status: metav1.ConditionTrue,
+				Reason: "Test",
+			}},
+			ConnectionDetails: &networkingv1alpha1.ConnectorConnectionDetails{
+				Type:      networkingv1alpha1.PublicKeyConnectorConnectionType,
+				PublicKey: &networkingv1alpha1.ConnectorConnectionDetailsPublicKey{Id: "node-a"},
+			},
+		},
+	}
+	downstreamConnector := &networkingv1alpha1.Connector{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   downstreamNamespaceName,
+			Name:        "online",
+			Annotations: map[string]string{networkingv1alpha1.UpstreamStatusAnnotation: "{}"},
+		},
+	}
+	httpProxy := &networkingv1alpha.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "proxy"},
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Rules: []networkingv1alpha.HTTPProxyRule{{
+				Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+					{Connector: &networkingv1alpha.ConnectorReference{Name: "online"}},
+					// Not replicated downstream yet.
+					{Connector: &networkingv1alpha.ConnectorReference{Name: "pending"}},
+					// Does not exist upstream.
+					{Connector: &networkingv1alpha.ConnectorReference{Name: "missing"}},
+				},
+			}},
+		},
+	}
+	pendingConnector := connector.DeepCopy()
+	pendingConnector.Name = "pending"
+
+	upstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(upstreamNamespace, connector, pendingConnector).
+		Build()
+	var patches int
+	downstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(downstreamConnector).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patches++
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("cluster", upstreamClient, downstreamClient)
+
+	propagator := newConnectorAddressingPropagator()
+	require.NoError(t, propagator.sweep(ctx, "cluster", upstreamClient, downstreamStrategy, httpProxy, "proxy/1"))
+	assert.Equal(t, 2, patches)
+
+	var updated networkingv1alpha1.Connector
+	require.NoError(t, downstreamClient.Get(ctx, client.ObjectKeyFromObject(downstreamConnector), &updated))
+	assert.Equal(t, "proxy/1", updated.Annotations[networkingv1alpha1.ConnectorAddressingSweepAnnotation])
+	var status networkingv1alpha1.ConnectorStatus
+	require.NoError(t, json.Unmarshal([]byte(updated.Annotations[networkingv1alpha1.UpstreamStatusAnnotation]), &status))
+	assert.Equal(t, "node-a", status.ConnectionDetails.PublicKey.Id)
+
+	// The same sweep is only pushed once.
+	require.NoError(t, propagator.sweep(ctx, "cluster", upstreamClient, downstreamStrategy, httpProxy, "proxy/1"))
+	assert.Equal(t, 2, patches)
+
+	// A new sweep is pushed again, as is the same sweep once forgotten.
+	require.NoError(t, propagator.sweep(ctx, "cluster", upstreamClient, downstreamStrategy, httpProxy, "proxy/2"))
+	assert.Equal(t, 4, patches)
+	propagator.forget("cluster", httpProxy)
+	require.NoError(t, propagator.sweep(ctx, "cluster", upstreamClient, downstreamStrategy, httpProxy, "proxy/2"))
+	assert.Equal(t, 6, patches)
+
+	// HTTPProxies that share a Connector don't undo each other's sweeps.
+	otherProxy := httpProxy.DeepCopy()
+	otherProxy.Name = "other"
+	require.NoError(t, propagator.sweep(ctx, "cluster", upstreamClient, downstreamStrategy, otherProxy, "other/1"))
+	assert.Equal(t, 8, patches)
+	require.NoError(t, propagator.sweep(ctx, "cluster", upstreamClient, downstreamStrategy, httpProxy, "proxy/2"))
+	require.NoError(t, propagator.sweep(ctx, "cluster", upstreamClient, downstreamStrategy, otherProxy, "other/1"))
+	assert.Equal(t, 8, patches)
+}
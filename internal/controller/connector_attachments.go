@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// downstreamConnectorAddressing is the addressing of a Connector as last
+// written to its downstream copy.
+type downstreamConnectorAddressing struct {
+	// current is true when the downstream Connector carries the current
+	// liveness and connection details of the upstream Connector.
+	current bool
+	// pushedAt is when a sweep last pushed the addressing of the Connector.
+	pushedAt *metav1.Time
+}
+
+// downstreamConnectorAddressing reads the addressing mirrored onto the
+// downstream copy of the Connector. The zero value is returned when the
+// Connector hasn't been replicated downstream yet.
+func (r *ConnectorReconciler) downstreamConnectorAddressing(
+	ctx context.Context,
+	clusterName string,
+	upstreamClient client.Client,
+	connector *networkingv1alpha1.Connector,
+) (downstreamConnectorAddressing, error) {
+	var addressing downstreamConnectorAddressing
+	if r.DownstreamCluster == nil {
+		return addressing, nil
+	}
+
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(clusterName, upstreamClient, r.DownstreamCluster.GetClient(), downstreamclient.WithControllerName("connector"))
+	downstreamObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, connector)
+	if err != nil {
+		return addressing, fmt.Errorf("failed to derive downstream connector metadata: %w", err)
+	}
+
+	var downstreamConnector networkingv1alpha1.Connector
+	key := client.ObjectKey{Namespace: downstreamObjectMeta.Namespace, Name: downstreamObjectMeta.Name}
+	if err := downstreamStrategy.GetClient().Get(ctx, key, &downstreamConnector); err != nil {
+		if apierrors.IsNotFound(err) {
+			return addressing, nil
+		}
+		return addressing, fmt.Errorf("failed to get downstream connector: %w", err)
+	}
+
+	annotations := downstreamConnector.GetAnnotations()
+	if value, ok := annotations[networkingv1alpha1.ConnectorAddressingPushTimeAnnotation]; ok {
+		if pushedAt, err := time.Parse(time.RFC3339, value); err == nil {
+			addressing.pushedAt = &metav1.Time{Time: pushedAt}
+		}
+	}
+
+	var mirrored networkingv1alpha1.ConnectorStatus
+	if raw, ok := annotations[networkingv1alpha1.UpstreamStatusAnnotation]; ok && json.Unmarshal([]byte(raw), &mirrored) == nil {
+		addressing.current = connectorAddressingEqual(&mirrored, &connector.Status)
+	}
+	return addressing, nil
+}
+
+// connectorAddressingEqual returns whether two Connector statuses have the same
+// liveness and connection details, which is all the edge programs routes
+// backed by a Connector with.
+func connectorAddressingEqual(a, b *networkingv1alpha1.ConnectorStatus) bool {
+	aReady := apimeta.IsStatusConditionTrue(a.Conditions, networkingv1alpha1.ConnectorConditionReady)
+	bReady := apimeta.IsStatusConditionTrue(b.Conditions, networkingv1alpha1.ConnectorConditionReady)
+	return aReady == bReady && equality.Semantic.DeepEqual(a.ConnectionDetails, b.ConnectionDetails)
+}
+
+// connectorAttachments returns the HTTPProxies that reference the Connector,
+// and whether their downstream routes are programmed with its current
+// addressing.
+func connectorAttachments(
+	ctx context.Context,
+	upstreamClient client.Client,
+	connector *networkingv1alpha1.Connector,
+	addressing downstreamConnectorAddressing,
+) ([]networkingv1alpha1.ConnectorAttachmentStatus, error) {
+	var httpProxies networkingv1alpha.HTTPProxyList
+	if err := upstreamClient.List(ctx, &httpProxies, client.InNamespace(connector.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing httpproxies: %w", err)
+	}
+
+	var attachments []networkingv1alpha1.ConnectorAttachmentStatus
+	for i := range httpProxies.Items {
+		httpProxy := &httpProxies.Items[i]
+		if !httpProxy.DeletionTimestamp.IsZero() || !httpProxyReferencesConnector(httpProxy, connector.Name) {
+			continue
+		}
+		programmed := httpProxyProgrammedCondition(&httpProxy.Status) != nil
+		attachments = append(attachments, networkingv1alpha1.ConnectorAttachmentStatus{
+			Kind:              KindHTTPProxy,
+			Name:              httpProxy.Name,
+			Gateway:           httpProxy.Name,
+			Programmed:        programmed,
+			AddressingCurrent: programmed && addressing.current,
+		})
+	}
+
+	slices.SortFunc(attachments, func(a, b networkingv1alpha1.ConnectorAttachmentStatus) int {
+		if c := strings.Compare(a.Kind, b.Kind); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return attachments, nil
+}
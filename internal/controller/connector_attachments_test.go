@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
+)
+
+func TestConnectorAttachments(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha1.AddToScheme(testScheme))
+
+	upstreamNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()}}
+	downstreamNamespaceName := fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	connector := &networkingv1alpha1.Connector{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "connector"},
+		Status: networkingv1alpha1.ConnectorStatus{
+			Conditions: []metav1.Condition{{
+				Type:   networkingv1alpha1.ConnectorConditionReady,
+				Status: metav1.ConditionTrue,
+				Reason: "Test",
+			}},
+			ConnectionDetails: &networkingv1alpha1.ConnectorConnectionDetails{
+				Type:      networkingv1alpha1.PublicKeyConnectorConnectionType,
+				PublicKey: &networkingv1alpha1.ConnectorConnectionDetailsPublicKey{Id: "node-b"},
+			},
+		},
+	}
+
+	newHTTPProxy := func(name string, programmed metav1.ConditionStatus, connectorName string) *networkingv1alpha.HTTPProxy {
+		return &networkingv1alpha.HTTPProxy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name},
+			Spec: networkingv1alpha.HTTPProxySpec{
+				Rules: []networkingv1alpha.HTTPProxyRule{{
+					Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+						{Connector: &networkingv1alpha.ConnectorReference{Name: connectorName}},
+					},
+				}},
+			},
+			Status: networkingv1alpha.HTTPProxyStatus{
+				Conditions: []metav1.Condition{{
+					Type:   networkingv1alpha.HTTPProxyConditionProgrammed,
+					Status: programmed,
+					Reason: "Test",
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mirroredNodeID  string
+		pushTime        string
+		wantCurrent     bool
+		wantPushTimeSet bool
+	}{
+		{
+			name:            "current addressing pushed",
+			mirroredNodeID:  "node-b",
+			pushTime:        "2026-10-14T12:00:00Z",
+			wantCurrent:     true,
+			wantPushTimeSet: true,
+		},
+		{
+			name:           "stale addressing downstream",
+			mirroredNodeID: "node-a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mirrored := connector.Status.DeepCopy()
+			mirrored.ConnectionDetails.PublicKey.Id = tt.mirroredNodeID
+			raw, err := json.Marshal(mirrored)
+			require.NoError(t, err)
+
+			annotations := map[string]string{networkingv1alpha1.UpstreamStatusAnnotation: string(raw)}
+			if tt.pushTime != "" {
+				annotations[networkingv1alpha1.ConnectorAddressingPushTimeAnnotation] = tt.pushTime
+			}
+			downstreamConnector := &networkingv1alpha1.Connector{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   downstreamNamespaceName,
+					Name:        connector.Name,
+					Annotations: annotations,
+				},
+			}
+
+			upstreamClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(
+					upstreamNamespace,
+					connector,
+					newHTTPProxy("b-programmed", metav1.ConditionTrue, connector.Name),
+					newHTTPProxy("a-pending", metav1.ConditionFalse, connector.Name),
+					newHTTPProxy("other", metav1.ConditionTrue, "other"),
+				).
+				Build()
+			downstreamClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(downstreamConnector).
+				Build()
+
+			reconciler := &ConnectorReconciler{DownstreamCluster: &fakeCluster{cl: downstreamClient}}
+			addressing, err := reconciler.downstreamConnectorAddressing(ctx, "cluster", upstreamClient, connector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCurrent, addressing.current)
+			assert.Equal(t, tt.wantPushTimeSet, addressing.pushedAt != nil)
+
+			attachments, err := connectorAttachments(ctx, upstreamClient, connector, addressing)
+			require.NoError(t, err)
+			assert.Equal(t, []networkingv1alpha1.ConnectorAttachmentStatus{
+				{Kind: KindHTTPProxy, Name: "a-pending", Gateway: "a-pending"},
+				{Kind: KindHTTPProxy, Name: "b-programmed", Gateway: "b-programmed", Programmed: true, AddressingCurrent: tt.wantCurrent},
+			}, attachments)
+		})
+	}
+}
@@ -25,21 +25,29 @@ import (
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+	mcsource "sigs.k8s.io/multicluster-runtime/pkg/source"
 
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
 	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
 )
 
 // ConnectorReconciler reconciles a Connector object
 type ConnectorReconciler struct {
 	mgr    mcmanager.Manager
 	Config config.NetworkServicesOperator
+
+	// DownstreamCluster, when set, is read to report whether the edge has been
+	// pushed the current addressing of each Connector.
+	DownstreamCluster cluster.Cluster
 }
 
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=connectors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=connectors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=connectors/finalizers,verbs=update
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=connectorclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=httpproxies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
 
 func (r *ConnectorReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (_ ctrl.Result, err error) {
@@ -161,6 +169,16 @@ func (r *ConnectorReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 
 	apimeta.SetStatusCondition(&connector.Status.Conditions, *readyCondition)
 
+	addressing, err := r.downstreamConnectorAddressing(ctx, string(req.ClusterName), cl.GetClient(), &connector)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	connector.Status.LastAddressingPushTime = addressing.pushedAt
+	connector.Status.Attachments, err = connectorAttachments(ctx, cl.GetClient(), &connector, addressing)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if !equality.Semantic.DeepEqual(*originalStatus, connector.Status) {
 		if statusErr := cl.GetClient().Status().Update(ctx, &connector); statusErr != nil {
 			return ctrl.Result{}, fmt.Errorf("failed updating connector status: %w", statusErr)
@@ -228,8 +246,32 @@ func leaseJitter(base time.Duration) time.Duration {
 func (r *ConnectorReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	r.mgr = mgr
 
-	return mcbuilder.ControllerManagedBy(mgr).
+	builder := mcbuilder.ControllerManagedBy(mgr).
 		For(&networkingv1alpha1.Connector{}).
+		// Watch HTTPProxies to keep the attachments of the Connectors they
+		// reference up to date.
+		Watches(
+			&networkingv1alpha.HTTPProxy{},
+			func(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+				return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+					httpProxy, ok := obj.(*networkingv1alpha.HTTPProxy)
+					if !ok {
+						return nil
+					}
+
+					var requests []mcreconcile.Request
+					for _, name := range httpProxyConnectorNames(httpProxy) {
+						requests = append(requests, mcreconcile.Request{
+							ClusterName: clusterName,
+							Request: ctrl.Request{
+								NamespacedName: client.ObjectKey{Namespace: httpProxy.Namespace, Name: name},
+							},
+						})
+					}
+					return requests
+				})
+			},
+		).
 		Watches(
 			&networkingv1alpha1.ConnectorClass{},
 			func(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
@@ -270,6 +312,19 @@ func (r *ConnectorReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 			mchandler.EnqueueRequestForOwner(&networkingv1alpha1.Connector{}, handler.OnlyControllerOwner()),
 			onlyClustersServingLeases(),
 		).
-		Named("connector").
-		Complete(r)
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "connector", 0)).
+		Named("connector")
+
+	// Sweeps write the addressing of Connectors to their downstream copies,
+	// which is reflected in the attachments of the Connector.
+	if r.DownstreamCluster != nil {
+		downstreamConnectorSource := mcsource.TypedKind(
+			&networkingv1alpha1.Connector{},
+			downstreamclient.TypedEnqueueRequestForUpstreamOwner[*networkingv1alpha1.Connector](&networkingv1alpha1.Connector{}),
+		)
+		downstreamConnectorClusterSource, _, _ := downstreamConnectorSource.ForCluster("", r.DownstreamCluster)
+		builder = builder.WatchesRawSource(downstreamConnectorClusterSource)
+	}
+
+	return builder.Complete(r)
 }
@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
 )
 
@@ -117,6 +118,7 @@ func TestConnectorReconcile(t *testing.T) {
 			testScheme := runtime.NewScheme()
 			assert.NoError(t, scheme.AddToScheme(testScheme))
 			assert.NoError(t, coordinationv1.AddToScheme(testScheme))
+			assert.NoError(t, networkingv1alpha.AddToScheme(testScheme))
 			assert.NoError(t, networkingv1alpha1.AddToScheme(testScheme))
 
 			builder := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(tt.connector)
@@ -67,6 +67,7 @@ const (
 // Metric label name constants.
 const (
 	metricLabelResourceKind = "resource_kind"
+	metricLabelDrift        = "drift"
 )
 
 // API group constants for networking.datumapis.com resources.
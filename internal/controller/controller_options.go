@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// controllerOptions returns the options of the named controller, with the
+// concurrency and work queue rate limiting configured for it in the operator
+// configuration. defaultMaxConcurrentReconciles is used when the configuration
+// does not set the concurrency of the controller, zero meaning the
+// controller-runtime default.
+func controllerOptions[request comparable](
+	operatorConfig config.NetworkServicesOperator,
+	name string,
+	defaultMaxConcurrentReconciles int,
+) controller.TypedOptions[request] {
+	controllerConfig := operatorConfig.ControllerConfig(name)
+
+	options := controller.TypedOptions[request]{
+		MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+	}
+	if controllerConfig.MaxConcurrentReconciles > 0 {
+		options.MaxConcurrentReconciles = controllerConfig.MaxConcurrentReconciles
+	}
+
+	if rl := controllerConfig.RateLimiter; rl != nil {
+		options.RateLimiter = workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[request](rl.BaseDelay.Duration, rl.MaxDelay.Duration),
+			&workqueue.TypedBucketRateLimiter[request]{Limiter: rate.NewLimiter(rate.Limit(rl.QPS), rl.Burst)},
+		)
+	}
+
+	return options
+}
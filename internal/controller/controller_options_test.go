@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestControllerOptions(t *testing.T) {
+	operatorConfig := config.NetworkServicesOperator{
+		Controllers: map[string]config.ControllerConfig{
+			"domain": {
+				MaxConcurrentReconciles: 25,
+				RateLimiter: &config.RateLimiterConfig{
+					BaseDelay: metav1.Duration{Duration: time.Second},
+					MaxDelay:  metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+	}
+
+	options := controllerOptions[mcreconcile.Request](operatorConfig, "gateway", 5)
+	assert.Equal(t, 5, options.MaxConcurrentReconciles)
+	assert.Nil(t, options.RateLimiter)
+
+	options = controllerOptions[mcreconcile.Request](operatorConfig, "domain", 5)
+	assert.Equal(t, 25, options.MaxConcurrentReconciles)
+	if assert.NotNil(t, options.RateLimiter) {
+		item := mcreconcile.Request{ClusterName: "test"}
+		assert.Equal(t, time.Second, options.RateLimiter.When(item))
+		assert.Equal(t, 2*time.Second, options.RateLimiter.When(item))
+		options.RateLimiter.Forget(item)
+		assert.Equal(t, time.Second, options.RateLimiter.When(item))
+	}
+}
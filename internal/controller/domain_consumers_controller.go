@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// DomainConsumersReconciler lists the Gateways, HTTPProxies and DNSZones that
+// use a Domain in its status.consumers, so the impact of changing or deleting
+// a Domain can be seen without searching the namespace.
+type DomainConsumersReconciler struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+}
+
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=domains,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=domains/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=httpproxies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dns.networking.miloapis.com,resources=dnszones,verbs=get;list;watch
+
+func (r *DomainConsumersReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	cl, err := r.mgr.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var domain networkingv1alpha.Domain
+	if err := cl.GetClient().Get(ctx, req.NamespacedName, &domain); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !domain.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	consumers, err := r.listDomainConsumers(ctx, cl, &domain)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if equality.Semantic.DeepEqual(consumers, domain.Status.Consumers) {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFrom(domain.DeepCopy())
+	domain.Status.Consumers = consumers
+	if err := cl.GetClient().Status().Patch(ctx, &domain, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed updating domain consumers: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// listDomainConsumers returns the consumers of the Domain that are not being
+// deleted, sorted by kind and name and truncated to MaxDomainConsumers.
+func (r *DomainConsumersReconciler) listDomainConsumers(ctx context.Context, cl cluster.Cluster, domain *networkingv1alpha.Domain) ([]networkingv1alpha.DomainConsumer, error) {
+	var consumers []networkingv1alpha.DomainConsumer
+
+	var gateways gatewayv1.GatewayList
+	if err := cl.GetClient().List(ctx, &gateways,
+		client.InNamespace(domain.Namespace),
+		client.MatchingFields{gatewayHostnameDomainIndex: domain.Spec.DomainName},
+	); err != nil {
+		return nil, fmt.Errorf("failed listing gateways: %w", err)
+	}
+	for _, gateway := range gateways.Items {
+		if gateway.DeletionTimestamp.IsZero() {
+			consumers = append(consumers, networkingv1alpha.DomainConsumer{Kind: KindGateway, Name: gateway.Name})
+		}
+	}
+
+	var httpProxies networkingv1alpha.HTTPProxyList
+	if err := cl.GetClient().List(ctx, &httpProxies,
+		client.InNamespace(domain.Namespace),
+		client.MatchingFields{httpProxyHostnameDomainIndex: domain.Spec.DomainName},
+	); err != nil {
+		return nil, fmt.Errorf("failed listing httpproxies: %w", err)
+	}
+	for _, httpProxy := range httpProxies.Items {
+		if httpProxy.DeletionTimestamp.IsZero() {
+			consumers = append(consumers, networkingv1alpha.DomainConsumer{Kind: KindHTTPProxy, Name: httpProxy.Name})
+		}
+	}
+
+	if r.Config.Gateway.EnableDNSIntegration {
+		// DNSZones reference the Domain they are bound to in the selectable field
+		// `status.domainRef.name`, which is evaluated by the API server, as is
+		// done for managed DNS verification.
+		zoneList := &unstructured.UnstructuredList{}
+		zoneList.SetGroupVersionKind(dnsZoneListGVK)
+		if err := cl.GetAPIReader().List(ctx, zoneList,
+			client.InNamespace(domain.Namespace),
+			client.MatchingFields{"status.domainRef.name": domain.Name},
+		); err != nil {
+			return nil, fmt.Errorf("failed listing dnszones: %w", err)
+		}
+		for _, zone := range zoneList.Items {
+			if zone.GetDeletionTimestamp().IsZero() {
+				consumers = append(consumers, networkingv1alpha.DomainConsumer{Kind: KindDNSZone, Name: zone.GetName()})
+			}
+		}
+	}
+
+	slices.SortFunc(consumers, func(a, b networkingv1alpha.DomainConsumer) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+	if len(consumers) > networkingv1alpha.MaxDomainConsumers {
+		consumers = consumers[:networkingv1alpha.MaxDomainConsumers]
+	}
+	return consumers, nil
+}
+
+// listDomainsForHostnamesFunc enqueues the Domains that the hostnames or
+// domain name of a Gateway, HTTPProxy or DNSZone fall under. Updates map both
+// the old and new object, so Domains that are no longer used are enqueued too.
+func (r *DomainConsumersReconciler) listDomainsForHostnamesFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		logger := log.FromContext(ctx)
+
+		var domainNames []string
+		switch obj := obj.(type) {
+		case *gatewayv1.Gateway:
+			domainNames = gatewayHostnameDomainIndexFunc(obj)
+		case *networkingv1alpha.HTTPProxy:
+			domainNames = httpProxyHostnameDomainIndexFunc(obj)
+		case *dnsv1alpha1.DNSZone:
+			domainNames = hostnameDomainCandidates(obj.Spec.DomainName)
+		}
+
+		var requests []mcreconcile.Request
+		for _, domainName := range domainNames {
+			var domains networkingv1alpha.DomainList
+			if err := cl.GetClient().List(ctx, &domains,
+				client.InNamespace(obj.GetNamespace()),
+				client.MatchingFields{domainDomainNameIndex: domainName},
+			); err != nil {
+				logger.Error(err, "failed to list Domains")
+				return nil
+			}
+
+			for _, domain := range domains.Items {
+				requests = append(requests, mcreconcile.Request{
+					ClusterName: clusterName,
+					Request: reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(&domain),
+					},
+				})
+			}
+		}
+		return requests
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DomainConsumersReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+
+	builder := mcbuilder.ControllerManagedBy(mgr).
+		For(&networkingv1alpha.Domain{}, mcbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&gatewayv1.Gateway{}, r.listDomainsForHostnamesFunc, mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.HTTPProxy{}, r.listDomainsForHostnamesFunc, mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()))
+
+	if r.Config.Gateway.EnableDNSIntegration {
+		builder = builder.Watches(&dnsv1alpha1.DNSZone{}, r.listDomainsForHostnamesFunc)
+	}
+
+	return builder.
+		Named("domain-consumers").
+		Complete(r)
+}
@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestDomainConsumersReconciler(t *testing.T) {
+	gatewayWithHostname := func(name, hostname string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: gatewayv1.GatewaySpec{
+				Listeners: []gatewayv1.Listener{{
+					Name:     "http",
+					Protocol: gatewayv1.HTTPProtocolType,
+					Port:     80,
+					Hostname: ptr.To(gatewayv1.Hostname(hostname)),
+				}},
+			},
+		}
+	}
+	httpProxyWithHostname := func(name, hostname string) *networkingv1alpha.HTTPProxy {
+		return &networkingv1alpha.HTTPProxy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       networkingv1alpha.HTTPProxySpec{Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(hostname)}},
+		}
+	}
+	dnsZoneForDomain := func(name, domainName string) *unstructured.Unstructured {
+		zone := newUnstructuredForGVK(dnsZoneGVK)
+		zone.SetNamespace("default")
+		zone.SetName(name)
+		_ = unstructured.SetNestedField(zone.Object, domainName, "status", "domainRef", "name")
+		return zone
+	}
+	manyGateways := func(count int) []client.Object {
+		objects := make([]client.Object, 0, count)
+		for i := range count {
+			objects = append(objects, gatewayWithHostname(fmt.Sprintf("gateway-%03d", i), "example.com"))
+		}
+		return objects
+	}
+
+	tests := map[string]struct {
+		enableDNSIntegration bool
+		consumers            []networkingv1alpha.DomainConsumer
+		objects              []client.Object
+		wantConsumers        []networkingv1alpha.DomainConsumer
+		wantConsumerCount    int
+	}{
+		"no consumers": {},
+		"gateways and httpproxies with hostnames under the domain": {
+			objects: []client.Object{
+				gatewayWithHostname("web", "www.example.com"),
+				gatewayWithHostname("apex", "example.com"),
+				httpProxyWithHostname("proxy", "api.example.com"),
+			},
+			wantConsumers: []networkingv1alpha.DomainConsumer{
+				{Kind: KindGateway, Name: "apex"},
+				{Kind: KindGateway, Name: "web"},
+				{Kind: KindHTTPProxy, Name: "proxy"},
+			},
+		},
+		"hostnames of other domains are not consumers": {
+			objects: []client.Object{
+				gatewayWithHostname("web", "www.notexample.com"),
+				httpProxyWithHostname("proxy", "example.org"),
+			},
+		},
+		"consumers that are gone are removed": {
+			consumers: []networkingv1alpha.DomainConsumer{{Kind: KindGateway, Name: "deleted"}},
+		},
+		"dnszones are consumers with dns integration": {
+			enableDNSIntegration: true,
+			objects: []client.Object{
+				dnsZoneForDomain("example-com", "example.com"),
+				dnsZoneForDomain("other", "other.com"),
+			},
+			wantConsumers: []networkingv1alpha.DomainConsumer{
+				{Kind: KindDNSZone, Name: "example-com"},
+			},
+		},
+		"consumers are capped": {
+			objects:           manyGateways(networkingv1alpha.MaxDomainConsumers + 5),
+			wantConsumerCount: networkingv1alpha.MaxDomainConsumers,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			testScheme := runtime.NewScheme()
+			require.NoError(t, scheme.AddToScheme(testScheme))
+			require.NoError(t, gatewayv1.Install(testScheme))
+			require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+
+			domain := &networkingv1alpha.Domain{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example.com"},
+				Spec:       networkingv1alpha.DomainSpec{DomainName: "example.com"},
+				Status:     networkingv1alpha.DomainStatus{Consumers: tt.consumers},
+			}
+			upstreamClient := withDomainIndexes(fake.NewClientBuilder().WithScheme(testScheme)).
+				WithIndex(newUnstructuredForGVK(dnsZoneGVK), "status.domainRef.name", dnsZoneDomainRefNameIndex).
+				WithObjects(append([]client.Object{domain}, tt.objects...)...).
+				WithStatusSubresource(domain).
+				Build()
+
+			operatorConfig := config.NetworkServicesOperator{}
+			operatorConfig.Gateway.EnableDNSIntegration = tt.enableDNSIntegration
+
+			reconciler := &DomainConsumersReconciler{
+				mgr:    &fakeMockManager{cl: upstreamClient},
+				Config: operatorConfig,
+			}
+
+			_, err := reconciler.Reconcile(ctx, mcreconcile.Request{
+				Request: reconcile.Request{NamespacedName: client.ObjectKeyFromObject(domain)},
+			})
+			require.NoError(t, err)
+
+			var got networkingv1alpha.Domain
+			require.NoError(t, upstreamClient.Get(ctx, client.ObjectKeyFromObject(domain), &got))
+			if tt.wantConsumerCount > 0 {
+				assert.Len(t, got.Status.Consumers, tt.wantConsumerCount)
+				return
+			}
+			assert.Equal(t, tt.wantConsumers, got.Status.Consumers)
+		})
+	}
+}
+
+func TestListGatewaysForDomainFunc(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+
+	newGatewayWithHostname := func(name, hostname string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: gatewayv1.GatewaySpec{
+				Listeners: []gatewayv1.Listener{{
+					Name:     "http",
+					Protocol: gatewayv1.HTTPProtocolType,
+					Port:     80,
+					Hostname: ptr.To(gatewayv1.Hostname(hostname)),
+				}},
+			},
+		}
+	}
+
+	upstreamClient := withDomainIndexes(fake.NewClientBuilder().WithScheme(testScheme)).
+		WithObjects(
+			newGatewayWithHostname("web", "www.example.com"),
+			newGatewayWithHostname("other", "www.notexample.com"),
+		).
+		Build()
+
+	domain := &networkingv1alpha.Domain{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example.com"},
+		Spec:       networkingv1alpha.DomainSpec{DomainName: "example.com"},
+		Status: networkingv1alpha.DomainStatus{Conditions: []metav1.Condition{{
+			Type:   networkingv1alpha.DomainConditionVerified,
+			Status: metav1.ConditionTrue,
+			Reason: networkingv1alpha.DomainReasonVerified,
+		}}},
+	}
+
+	reconciler := &GatewayReconciler{}
+	h := reconciler.listGatewaysForDomainFunc("test", &fakeCluster{cl: upstreamClient})
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+	t.Cleanup(queue.ShutDown)
+
+	h.Create(context.Background(), event.TypedCreateEvent[client.Object]{Object: domain}, queue)
+	require.Equal(t, 1, queue.Len(), "expected a single gateway under the domain to be enqueued")
+
+	item, shutdown := queue.Get()
+	assert.False(t, shutdown, "unexpected queue shutdown")
+	queue.Done(item)
+
+	assert.Equal(t, client.ObjectKey{Namespace: "default", Name: "web"}, item.NamespacedName)
+}
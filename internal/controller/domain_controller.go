@@ -4,6 +4,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -21,9 +22,9 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
@@ -41,11 +42,16 @@ type DomainReconciler struct {
 	mgr    mcmanager.Manager
 	Config config.NetworkServicesOperator
 
+	// ReloadableConfig, when set, supplies the config.ReloadableFields that
+	// may change while the manager is running.
+	ReloadableConfig *config.Reloadable
+
 	timeNow   func() time.Time
 	httpGet   func(ctx context.Context, url string) ([]byte, *http.Response, error)
 	lookupTXT func(ctx context.Context, name string) ([]string, error)
 
 	registryClient registrydata.Client
+	exchangeDNSSEC dnsutil.ExchangeFunc
 }
 
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=domains,verbs=get;list;watch;create;update;patch;delete
@@ -112,6 +118,7 @@ func (r *DomainReconciler) Reconcile(ctx context.Context, req mcreconcile.Reques
 			if err := cl.GetClient().Status().Update(ctx, domain); err != nil {
 				return ctrl.Result{}, fmt.Errorf("failed updating domain status: %w", err)
 			}
+			recordDomainEvents(eventRecorderFor(cl), domain, origStatus)
 		}
 		return ctrl.Result{}, nil
 	}
@@ -122,11 +129,17 @@ func (r *DomainReconciler) Reconcile(ctx context.Context, req mcreconcile.Reques
 	// Delegate all registration work (including timers/backoff)
 	nextRegistration := r.reconcileRegistration(ctx, domain, apex)
 
+	// Validate DNSSEC whenever registration data was refreshed
+	registrationRefreshed := origStatus.Registration == nil ||
+		!origStatus.Registration.LastRefreshAttempt.Equal(&domain.Status.Registration.LastRefreshAttempt)
+	r.reconcileDNSSEC(ctx, domain, apex, registrationRefreshed)
+
 	// Persist status if changed
 	if !equality.Semantic.DeepEqual(*origStatus, domain.Status) {
 		if err := cl.GetClient().Status().Update(ctx, domain); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed updating domain status: %w", err)
 		}
+		recordDomainEvents(eventRecorderFor(cl), domain, origStatus)
 	}
 
 	// Compute earliest independent timer
@@ -219,13 +232,11 @@ func (r *DomainReconciler) reconcileVerification(ctx context.Context, reader cli
 
 			// Clear verification scaffolding and sub-conditions (except VerifiedDNSZone
 			// which is needed by downstream consumers like the Gateway DNS controller).
-			domainStatus.Verification = nil
+			nextAttempt = r.completeVerification(domainStatus)
 			apimeta.RemoveStatusCondition(&domainStatus.Conditions, networkingv1alpha.DomainConditionVerifiedDNS)
 			apimeta.RemoveStatusCondition(&domainStatus.Conditions, networkingv1alpha.DomainConditionVerifiedHTTP)
 			// Keep VerifiedDNSZone=True so the Gateway DNS controller can detect it
 			apimeta.SetStatusCondition(&domainStatus.Conditions, *verifiedDNSZoneCondition)
-			// When verified, no future verification timer is needed
-			nextAttempt = time.Time{}
 		} else if domainStatus.Verification == nil {
 			// Update the domain with content the user can leverage to update DNS or
 			// HTTP endpoints for verification.
@@ -282,9 +293,10 @@ func (r *DomainReconciler) reconcileVerification(ctx context.Context, reader cli
 				elapsed := now.Sub(initialAttempt)
 				logger.Info("time elapsed since last transition time", "duration", elapsed)
 
+				verificationConfig := reloadableConfig(&r.Config, r.ReloadableConfig).DomainVerification
 				requeueAfter := wait.Jitter(
-					r.Config.DomainVerification.GetRetryInterval(elapsed),
-					r.Config.DomainVerification.RetryJitterMaxFactor,
+					verificationConfig.GetRetryInterval(elapsed),
+					verificationConfig.RetryJitterMaxFactor,
 				)
 
 				domainStatus.Verification.LastVerificationAttempt = metav1.NewTime(now)
@@ -308,15 +320,16 @@ func (r *DomainReconciler) reconcileVerification(ctx context.Context, reader cli
 					verifiedCondition.Message = "Domain verification successful"
 
 					// Clear verification scaffolding and sub-conditions
-					domainStatus.Verification = nil
+					nextAttempt = r.completeVerification(domainStatus)
 					apimeta.RemoveStatusCondition(&domainStatus.Conditions, networkingv1alpha.DomainConditionVerifiedDNS)
 					apimeta.RemoveStatusCondition(&domainStatus.Conditions, networkingv1alpha.DomainConditionVerifiedHTTP)
 					apimeta.RemoveStatusCondition(&domainStatus.Conditions, networkingv1alpha.DomainConditionVerifiedDNSZone)
-					// When verified, no future verification timer is needed
-					nextAttempt = time.Time{}
 				}
 			}
 		}
+	} else if r.Config.DomainVerification.ReverificationEnabled() {
+		nextAttempt = r.reconcileReverification(ctx, reader, domain, domainStatus,
+			verifiedCondition, verifiedDNSCondition, verifiedHTTPCondition, verifiedDNSZoneCondition)
 	}
 
 	// Update conditions
@@ -335,6 +348,130 @@ func (r *DomainReconciler) reconcileVerification(ctx context.Context, reader cli
 	return nextAttempt
 }
 
+// completeVerification clears the verification scaffolding of a newly verified
+// Domain. When re-verification is enabled, the scaffolding is kept so the
+// verification token can be checked again, and the first re-verification is
+// scheduled. It returns the time of the next verification attempt, if any.
+func (r *DomainReconciler) completeVerification(domainStatus *networkingv1alpha.DomainStatus) time.Time {
+	cfg := reloadableConfig(&r.Config, r.ReloadableConfig).DomainVerification
+	if !cfg.ReverificationEnabled() || domainStatus.Verification == nil {
+		domainStatus.Verification = nil
+		return time.Time{}
+	}
+
+	domainStatus.Verification.ReverificationFailures = 0
+	domainStatus.Verification.NextVerificationAttempt = metav1.NewTime(
+		r.timeNow().Add(wait.Jitter(cfg.ReverificationInterval.Duration, cfg.RetryJitterMaxFactor)),
+	)
+	return domainStatus.Verification.NextVerificationAttempt.Time
+}
+
+// reconcileReverification re-checks the ownership of a verified Domain once
+// its re-verification is due. A Domain stays verified until
+// ReverificationFailureThreshold consecutive attempts fail, after which it is
+// marked as not verified with the VerificationExpired reason and the regular
+// verification loop takes over. It returns the next re-verification time.
+func (r *DomainReconciler) reconcileReverification(
+	ctx context.Context,
+	reader client.Reader,
+	domain *networkingv1alpha.Domain,
+	domainStatus *networkingv1alpha.DomainStatus,
+	verifiedCondition *metav1.Condition,
+	verifiedDNSCondition *metav1.Condition,
+	verifiedHTTPCondition *metav1.Condition,
+	verifiedDNSZoneCondition *metav1.Condition,
+) time.Time {
+	logger := log.FromContext(ctx)
+	cfg := reloadableConfig(&r.Config, r.ReloadableConfig).DomainVerification
+
+	hasToken := domainStatus.Verification != nil && domainStatus.Verification.DNSRecord.Content != ""
+	dnsZoneVerified := apimeta.IsStatusConditionTrue(domainStatus.Conditions, networkingv1alpha.DomainConditionVerifiedDNSZone)
+	if !hasToken && !dnsZoneVerified {
+		// Domains verified before re-verification was enabled no longer have a
+		// verification token to check.
+		return time.Time{}
+	}
+	if domainStatus.Verification == nil {
+		domainStatus.Verification = &networkingv1alpha.DomainVerificationStatus{}
+	}
+	verification := domainStatus.Verification
+
+	now := r.timeNow()
+	if verification.NextVerificationAttempt.IsZero() {
+		verification.NextVerificationAttempt = metav1.NewTime(now.Add(wait.Jitter(cfg.ReverificationInterval.Duration, cfg.RetryJitterMaxFactor)))
+		return verification.NextVerificationAttempt.Time
+	}
+	if verification.NextVerificationAttempt.After(now) {
+		return verification.NextVerificationAttempt.Time
+	}
+
+	logger.Info("re-verifying domain ownership")
+	verification.LastVerificationAttempt = metav1.NewTime(now)
+
+	attemptCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Attempts start from fresh conditions, as the existing conditions reflect
+	// the successful verification.
+	newAttemptCondition := func(conditionType string) *metav1.Condition {
+		return &metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             networkingv1alpha.DomainReasonPendingVerification,
+			Message:            "The Domain could not be re-verified",
+			ObservedGeneration: domain.Generation,
+			LastTransitionTime: metav1.NewTime(now),
+		}
+	}
+	dnsZoneAttempt := newAttemptCondition(networkingv1alpha.DomainConditionVerifiedDNSZone)
+	dnsAttempt := newAttemptCondition(networkingv1alpha.DomainConditionVerifiedDNS)
+	httpAttempt := newAttemptCondition(networkingv1alpha.DomainConditionVerifiedHTTP)
+
+	if dnsZoneVerified {
+		r.attemptDNSZoneVerification(attemptCtx, reader, domain, domainStatus, dnsZoneAttempt)
+	}
+	if hasToken && dnsZoneAttempt.Status != metav1.ConditionTrue {
+		r.attemptDNSVerification(attemptCtx, domainStatus, dnsAttempt)
+		if dnsAttempt.Status != metav1.ConditionTrue {
+			r.attemptHTTPVerification(attemptCtx, domainStatus, httpAttempt)
+		}
+	}
+
+	if dnsZoneAttempt.Status == metav1.ConditionTrue || dnsAttempt.Status == metav1.ConditionTrue || httpAttempt.Status == metav1.ConditionTrue {
+		verification.ReverificationFailures = 0
+		verification.NextVerificationAttempt = metav1.NewTime(now.Add(wait.Jitter(cfg.ReverificationInterval.Duration, cfg.RetryJitterMaxFactor)))
+		return verification.NextVerificationAttempt.Time
+	}
+
+	verification.ReverificationFailures++
+	logger.Info("domain re-verification failed", "failures", verification.ReverificationFailures)
+	if int(verification.ReverificationFailures) < cfg.ReverificationFailureThreshold {
+		verification.NextVerificationAttempt = metav1.NewTime(now.Add(wait.Jitter(cfg.GetReverificationRetryInterval(), cfg.RetryJitterMaxFactor)))
+		return verification.NextVerificationAttempt.Time
+	}
+
+	logger.Info("revoking domain verification", "failures", verification.ReverificationFailures)
+	// The conditions may be the existing conditions of the status, whose
+	// transition time is not updated when they are mutated in place.
+	verifiedCondition.Status = metav1.ConditionFalse
+	verifiedCondition.LastTransitionTime = metav1.NewTime(now)
+	verifiedCondition.Reason = networkingv1alpha.DomainReasonVerificationExpired
+	verifiedCondition.Message = fmt.Sprintf("Domain ownership could not be re-verified after %d consecutive attempts", verification.ReverificationFailures)
+	*verifiedDNSCondition = *dnsAttempt
+	*verifiedHTTPCondition = *httpAttempt
+	*verifiedDNSZoneCondition = *dnsZoneAttempt
+	verification.ReverificationFailures = 0
+
+	if !hasToken {
+		// Generate verification scaffolding on the next reconcile, so the domain
+		// can be verified with a DNS record or HTTP token as well.
+		domainStatus.Verification = nil
+		return now
+	}
+	verification.NextVerificationAttempt = metav1.NewTime(now.Add(wait.Jitter(cfg.GetRetryInterval(0), cfg.RetryJitterMaxFactor)))
+	return verification.NextVerificationAttempt.Time
+}
+
 var dnsZoneListGVK = schema.GroupVersionKind{
 	Group:   "dns.networking.miloapis.com",
 	Version: versionV1Alpha1,
@@ -538,12 +675,15 @@ func (r *DomainReconciler) attemptHTTPVerification(
 	}
 }
 
-// defaultHTTPGet is the default HTTP GET implementation for verification
-func defaultHTTPGet(ctx context.Context, url string) ([]byte, *http.Response, error) {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+// newHTTPGet returns the default HTTP GET implementation for verification,
+// which issues requests with the given client.
+func newHTTPGet(client *http.Client) func(ctx context.Context, url string) ([]byte, *http.Response, error) {
+	return func(ctx context.Context, url string) ([]byte, *http.Response, error) {
+		return httpGet(ctx, client, url)
 	}
+}
 
+func httpGet(ctx context.Context, client *http.Client, url string) ([]byte, *http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("creating request: %w", err)
@@ -636,6 +776,12 @@ func (r *DomainReconciler) reconcileRegistration(ctx context.Context, d *network
 	// Stamp the time we attempted a refresh now that we've built/updated the snapshot.
 	st.Registration.LastRefreshAttempt = metav1.NewTime(now)
 
+	refreshedCond := metav1.Condition{
+		Type:               networkingv1alpha.DomainConditionRegistrationRefreshed,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: d.Generation,
+	}
+
 	// Schedule next refresh (with jitter)
 	if lookupErr != nil {
 		if rl, ok := lookupErr.(*registrydata.RateLimitedError); ok {
@@ -648,13 +794,27 @@ func (r *DomainReconciler) reconcileRegistration(ctx context.Context, d *network
 			}
 			next := now.Add(wait.Jitter(delay, r.Config.DomainRegistration.JitterMaxFactor))
 			st.Registration.NextRefreshAttempt = metav1.NewTime(next)
+
+			refreshedCond.Reason = networkingv1alpha.DomainReasonRateLimited
+			refreshedCond.Message = fmt.Sprintf("Registry lookups to %q are rate limited, refresh deferred until %s",
+				rl.Provider, next.UTC().Format(time.RFC3339))
+			apimeta.SetStatusCondition(&st.Conditions, refreshedCond)
 			return next
 		}
 		next := now.Add(r.Config.DomainRegistration.RetryBackoff.Duration)
 		st.Registration.NextRefreshAttempt = metav1.NewTime(next)
+
+		refreshedCond.Reason = networkingv1alpha.DomainReasonRegistrationLookupFailed
+		refreshedCond.Message = fmt.Sprintf("Registration lookup failed: %v", lookupErr)
+		apimeta.SetStatusCondition(&st.Conditions, refreshedCond)
 		return next
 	}
 
+	refreshedCond.Status = metav1.ConditionTrue
+	refreshedCond.Reason = networkingv1alpha.DomainReasonRegistrationRefreshed
+	refreshedCond.Message = "Registration data was refreshed"
+	apimeta.SetStatusCondition(&st.Conditions, refreshedCond)
+
 	interval := r.Config.DomainRegistration.RefreshInterval.Duration
 	if res != nil && res.SuggestedDelay > interval {
 		interval = res.SuggestedDelay
@@ -664,6 +824,87 @@ func (r *DomainReconciler) reconcileRegistration(ctx context.Context, d *network
 	return next
 }
 
+// reconcileDNSSEC maintains the DNSSECValid condition. The chain of trust of the
+// registered domain is validated when registration data reports DNSSEC as
+// enabled, each time registration data is refreshed or the Domain changes.
+func (r *DomainReconciler) reconcileDNSSEC(ctx context.Context, d *networkingv1alpha.Domain, apex string, registrationRefreshed bool) {
+	st := &d.Status
+	if st.Registration == nil || st.Registration.DNSSEC == nil ||
+		st.Registration.DNSSEC.Enabled == nil || !*st.Registration.DNSSEC.Enabled {
+		apimeta.RemoveStatusCondition(&st.Conditions, networkingv1alpha.DomainConditionDNSSECValid)
+		return
+	}
+
+	existing := apimeta.FindStatusCondition(st.Conditions, networkingv1alpha.DomainConditionDNSSECValid)
+	if !registrationRefreshed && existing != nil && existing.ObservedGeneration == d.Generation {
+		return
+	}
+
+	ctxLookup, cancel := context.WithTimeout(ctx, r.Config.DomainRegistration.LookupTimeout.Duration)
+	defer cancel()
+
+	cond := metav1.Condition{
+		Type:               networkingv1alpha.DomainConditionDNSSECValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.DomainReasonDNSSECValid,
+		Message:            "DNSSEC chain of trust is valid",
+		ObservedGeneration: d.Generation,
+	}
+	if err := dnsutil.ValidateDNSSECChain(ctxLookup, r.exchangeDNSSEC, apex, r.timeNow()); err != nil {
+		var validationErr *dnsutil.DNSSECValidationError
+		if errors.As(err, &validationErr) {
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = validationErr.Reason
+			cond.Message = validationErr.Message
+		} else {
+			log.FromContext(ctx).Info("failed validating DNSSEC", "apex", apex, "error", err.Error())
+			cond.Status = metav1.ConditionUnknown
+			cond.Reason = networkingv1alpha.DomainReasonDNSSECLookupFailed
+			cond.Message = fmt.Sprintf("DNSSEC records could not be looked up: %v", err)
+		}
+	}
+	apimeta.SetStatusCondition(&st.Conditions, cond)
+}
+
+// registryRateLimits converts the registry data rate limits of the operator
+// config to those of the registrydata client.
+func registryRateLimits(cfg config.RegistryDataRateLimitsConfig) registrydata.RateLimits {
+	limits := registrydata.RateLimits{
+		DefaultRatePerSec: cfg.DefaultRatePerSec,
+		DefaultBurst:      cfg.DefaultBurst,
+		Providers:         registryProviderRateLimits(cfg.Providers),
+	}
+	if cfg.DefaultBlock != nil {
+		limits.DefaultBlock = cfg.DefaultBlock.Duration
+	}
+	return limits
+}
+
+// registryProviderRateLimits converts the per-provider rate limits of the
+// operator config to the token buckets of the registrydata client.
+func registryProviderRateLimits(providers []config.RegistryDataProviderRateLimitConfig) map[string]registrydata.ProviderRateLimit {
+	if len(providers) == 0 {
+		return nil
+	}
+	limits := make(map[string]registrydata.ProviderRateLimit, len(providers))
+	for _, p := range providers {
+		limits[strings.ToLower(p.Provider)] = registrydata.ProviderRateLimit{
+			RatePerSec: p.RequestsPerMinute / 60,
+			Burst:      p.Burst,
+		}
+	}
+	return limits
+}
+
+// recordDomainEvents emits events for the transitions of the validity and
+// verification of a domain.
+func recordDomainEvents(recorder events.EventRecorder, domain *networkingv1alpha.Domain, origStatus *networkingv1alpha.DomainStatus) {
+	recordConditionTransition(recorder, domain, "Validate", origStatus.Conditions, domain.Status.Conditions,
+		networkingv1alpha.DomainConditionValidDomain, networkingv1alpha.DomainReasonValid)
+	recordConditionTransition(recorder, domain, "Verify", origStatus.Conditions, domain.Status.Conditions,
+		networkingv1alpha.DomainConditionVerified, networkingv1alpha.DomainReasonPendingVerification)
+}
+
 func registeredApex(name string) (string, error) {
 	n := strings.TrimSuffix(strings.ToLower(name), ".")
 	return publicsuffix.EffectiveTLDPlusOne(n)
@@ -674,7 +915,7 @@ func (r *DomainReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	r.mgr = mgr
 
 	r.timeNow = time.Now
-	r.httpGet = defaultHTTPGet
+	r.httpGet = newHTTPGet(r.Config.CryptoPolicy.HTTPClient(config.CryptoPolicyDomainVerificationClient, 10*time.Second))
 	r.lookupTXT = net.DefaultResolver.LookupTXT
 
 	registryCfg := r.Config.DomainRegistration.RegistryData
@@ -723,6 +964,7 @@ func (r *DomainReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 		Cache: registrydata.CacheConfig{
 			Backend:        cacheBackend,
 			RedisKeyPrefix: cacheCfg.RedisKeyPrefix,
+			MaxEntries:     cacheCfg.MaxEntries,
 		},
 		RedisClient: redisClient,
 		CacheTTLs: registrydata.CacheTTLs{
@@ -730,24 +972,33 @@ func (r *DomainReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 			Nameserver:   registryCfg.CacheTTLs.Nameserver.Duration,
 			IPRegistrant: registryCfg.CacheTTLs.IPRegistrant.Duration,
 		},
-		RateLimits: registrydata.RateLimits{
-			DefaultRatePerSec: registryCfg.RateLimits.DefaultRatePerSec,
-			DefaultBurst:      registryCfg.RateLimits.DefaultBurst,
-			DefaultBlock:      registryCfg.RateLimits.DefaultBlock.Duration,
-		},
-		HTTPClient:         &http.Client{Timeout: r.Config.DomainRegistration.LookupTimeout.Duration},
+		RateLimits: registryRateLimits(registryCfg.RateLimits),
+		// WHOIS lookups are plain text, so only RDAP is subject to the crypto policy.
+		HTTPClient: r.Config.CryptoPolicy.HTTPClient(
+			config.CryptoPolicyRegistryDataClient,
+			r.Config.DomainRegistration.LookupTimeout.Duration,
+		),
 		WhoisBootstrapHost: r.Config.DomainRegistration.WhoisBootstrapHost,
 	})
 	if err != nil {
 		return err
 	}
 	r.registryClient = regClient
+	if setter, ok := regClient.(registrydata.RateLimitSetter); ok && r.ReloadableConfig != nil {
+		r.ReloadableConfig.Subscribe(func(cfg *config.NetworkServicesOperator) {
+			setter.SetRateLimits(registryRateLimits(cfg.DomainRegistration.RegistryData.RateLimits))
+		})
+	}
+
+	exchangeDNSSEC, err := dnsutil.NewExchange(r.Config.DomainRegistration.DNSSECResolver, r.Config.DomainRegistration.LookupTimeout.Duration)
+	if err != nil {
+		return fmt.Errorf("failed to create DNSSEC resolver: %w", err)
+	}
+	r.exchangeDNSSEC = exchangeDNSSEC
 	return mcbuilder.ControllerManagedBy(mgr).
 		// Watch all Domains so registration continues after verification
 		For(&networkingv1alpha.Domain{}).
-		WithOptions(controller.TypedOptions[mcreconcile.Request]{
-			MaxConcurrentReconciles: r.Config.DomainVerification.MaxConcurrentVerifications,
-		}).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "domain", r.Config.DomainVerification.MaxConcurrentVerifications)).
 		Named("domain").
 		Complete(r)
 }
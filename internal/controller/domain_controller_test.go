@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -17,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -553,8 +555,9 @@ func TestValidDomainGate_InvalidApex_SetsConditionAndSkipsFlows(t *testing.T) {
 		WithStatusSubresource(dom).
 		Build()
 
+	recorder := events.NewFakeRecorder(10)
 	reconciler := &DomainReconciler{
-		mgr:            &fakeMockManager{cl: fakeClient},
+		mgr:            &fakeMockManager{cl: fakeClient, recorder: recorder},
 		Config:         operatorConfig,
 		timeNow:        time.Now,
 		httpGet:        func(ctx context.Context, url string) ([]byte, *http.Response, error) { return nil, nil, nil },
@@ -566,6 +569,15 @@ func TestValidDomainGate_InvalidApex_SetsConditionAndSkipsFlows(t *testing.T) {
 	_, err := reconciler.Reconcile(context.Background(), req)
 	assert.NoError(t, err)
 
+	if assert.Len(t, recorder.Events, 1) {
+		assert.Equal(t, "Warning InvalidApex Domain is not registrable (public-suffix only or invalid)", <-recorder.Events)
+	}
+
+	// A second reconcile does not repeat the event
+	_, err = reconciler.Reconcile(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+
 	// Fetch and assert conditions were set and flows skipped
 	got := &networkingv1alpha.Domain{}
 	assert.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: ns.Name, Name: dom.Name}, got))
@@ -659,6 +671,83 @@ func TestRegistration_Apex_UsesRDAPNameservers(t *testing.T) {
 	}
 	assert.ElementsMatch(t, []string{"ns1.example.net", "ns2.example.net"}, have)
 	assert.True(t, res.RequeueAfter > 0)
+	assert.True(t, apimeta.IsStatusConditionTrue(got.Status.Conditions, networkingv1alpha.DomainConditionRegistrationRefreshed))
+}
+
+func TestRegistration_DNSSECValidation(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = scheme.AddToScheme(s)
+	_ = networkingv1alpha.AddToScheme(s)
+
+	dom := newDomain("default", "dnssec")
+	dom.Spec.DomainName = "www." + exampleDomain
+
+	cl := fake.NewClientBuilder().
+		WithScheme(s).
+		WithIndex(newUnstructuredForGVK(dnsZoneGVK), "status.domainRef.name", dnsZoneDomainRefNameIndex).
+		WithObjects(dom).
+		WithStatusSubresource(dom).
+		Build()
+
+	now := time.Date(2025, 10, 9, 1, 0, 0, 0, time.UTC)
+	dnssecEnabled := true
+	var queried []string
+
+	r := &DomainReconciler{
+		mgr: &fakeMockManager{cl: cl},
+		Config: config.NetworkServicesOperator{DomainRegistration: config.DomainRegistrationConfig{
+			LookupTimeout:   &metav1.Duration{Duration: 3 * time.Second},
+			RefreshInterval: &metav1.Duration{Duration: time.Hour},
+			RetryBackoff:    &metav1.Duration{Duration: time.Minute},
+		}},
+		timeNow: func() time.Time { return now },
+		httpGet: func(ctx context.Context, url string) ([]byte, *http.Response, error) {
+			return nil, nil, fmt.Errorf("not implemented")
+		},
+		lookupTXT: func(ctx context.Context, name string) ([]string, error) { return nil, &net.DNSError{IsNotFound: true} },
+		registryClient: &fakeRegistryClient{
+			lookupDomain: func(ctx context.Context, domain string, opts registrydata.LookupOptions) (*registrydata.DomainResult, error) {
+				return &registrydata.DomainResult{Registration: &networkingv1alpha.Registration{
+					Domain: exampleDomain,
+					DNSSEC: &networkingv1alpha.DNSSECInfo{Enabled: ptrBool(dnssecEnabled)},
+				}}, nil
+			},
+		},
+		exchangeDNSSEC: func(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+			queried = append(queried, name)
+			return new(dns.Msg), nil
+		},
+	}
+	req := mcreconcile.Request{ClusterName: "test", Request: reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dom)}}
+
+	_, err := r.Reconcile(context.Background(), req)
+	assert.NoError(t, err)
+
+	got := &networkingv1alpha.Domain{}
+	_ = cl.Get(context.Background(), client.ObjectKeyFromObject(dom), got)
+
+	// The chain of the registered domain is validated, not the subdomain.
+	assert.Equal(t, []string{exampleDomain + "."}, queried)
+	dnssecCond := apimeta.FindStatusCondition(got.Status.Conditions, networkingv1alpha.DomainConditionDNSSECValid)
+	if assert.NotNil(t, dnssecCond) {
+		assert.Equal(t, metav1.ConditionFalse, dnssecCond.Status)
+		assert.Equal(t, networkingv1alpha.DomainReasonDNSSECDSNotFound, dnssecCond.Reason)
+	}
+
+	// Without a registration refresh, DNSSEC is not validated again.
+	queried = nil
+	_, err = r.Reconcile(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Empty(t, queried)
+
+	// The condition is removed once the registry reports DNSSEC disabled.
+	dnssecEnabled = false
+	now = now.Add(2 * time.Hour)
+	_, err = r.Reconcile(context.Background(), req)
+	assert.NoError(t, err)
+
+	_ = cl.Get(context.Background(), client.ObjectKeyFromObject(dom), got)
+	assert.Nil(t, apimeta.FindStatusCondition(got.Status.Conditions, networkingv1alpha.DomainConditionDNSSECValid))
 }
 
 func TestVerification_RequeueImmediate_WhenWakeDueOrPast(t *testing.T) {
@@ -979,6 +1068,129 @@ func TestVerification_DesiredRefreshAttempt_ExpediteBehavior(t *testing.T) {
 	})
 }
 
+func TestVerification_Reverification(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	assert.NoError(t, scheme.AddToScheme(testScheme))
+	assert.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+
+	operatorConfig := config.NetworkServicesOperator{}
+	config.SetObjectDefaults_NetworkServicesOperator(&operatorConfig)
+	operatorConfig.DomainVerification.RetryJitterMaxFactor = 0.01
+
+	reader := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithIndex(newUnstructuredForGVK(dnsZoneGVK), "status.domainRef.name", dnsZoneDomainRefNameIndex).
+		Build()
+
+	now := time.Date(2025, 8, 4, 17, 0, 0, 0, time.UTC)
+	txtRecordPresent := true
+	reconciler := &DomainReconciler{
+		Config:  operatorConfig,
+		timeNow: func() time.Time { return now },
+		lookupTXT: func(ctx context.Context, name string) ([]string, error) {
+			if txtRecordPresent {
+				return []string{"token"}, nil
+			}
+			return nil, &net.DNSError{IsNotFound: true}
+		},
+		httpGet: func(ctx context.Context, url string) ([]byte, *http.Response, error) {
+			return nil, &http.Response{StatusCode: http.StatusNotFound}, nil
+		},
+	}
+
+	domain := newDomain("test", "test", func(domain *networkingv1alpha.Domain) {
+		domain.Status.Verification = &networkingv1alpha.DomainVerificationStatus{
+			DNSRecord: networkingv1alpha.DNSVerificationRecord{Name: "_verify.example.com", Type: "TXT", Content: "token"},
+			HTTPToken: networkingv1alpha.HTTPVerificationToken{URL: "http://example.com/verify", Body: "token"},
+		}
+	})
+
+	// The verification token is kept for re-verification once verified.
+	ctx := context.Background()
+	next := reconciler.reconcileVerification(ctx, reader, domain)
+	assert.True(t, apimeta.IsStatusConditionTrue(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified))
+	if assert.NotNil(t, domain.Status.Verification) {
+		assert.Equal(t, "token", domain.Status.Verification.DNSRecord.Content)
+	}
+	assert.WithinDuration(t, now.Add(24*time.Hour), next, 15*time.Minute)
+
+	// Nothing is checked until re-verification is due.
+	txtRecordPresent = false
+	assert.Equal(t, next, reconciler.reconcileVerification(ctx, reader, domain))
+	assert.Zero(t, domain.Status.Verification.ReverificationFailures)
+
+	// Failures are retried, and the domain stays verified until the threshold
+	// is reached.
+	for failures := int32(1); failures < 3; failures++ {
+		now = next
+		next = reconciler.reconcileVerification(ctx, reader, domain)
+		assert.True(t, apimeta.IsStatusConditionTrue(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified))
+		assert.Equal(t, failures, domain.Status.Verification.ReverificationFailures)
+		assert.WithinDuration(t, now.Add(5*time.Minute), next, 5*time.Second)
+	}
+
+	// A successful attempt resets the failures.
+	txtRecordPresent = true
+	now = next
+	next = reconciler.reconcileVerification(ctx, reader, domain)
+	assert.Zero(t, domain.Status.Verification.ReverificationFailures)
+	assert.WithinDuration(t, now.Add(24*time.Hour), next, 15*time.Minute)
+
+	txtRecordPresent = false
+	for range 3 {
+		now = next
+		next = reconciler.reconcileVerification(ctx, reader, domain)
+	}
+	verified := apimeta.FindStatusCondition(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified)
+	if assert.NotNil(t, verified) {
+		assert.Equal(t, metav1.ConditionFalse, verified.Status)
+		assert.Equal(t, networkingv1alpha.DomainReasonVerificationExpired, verified.Reason)
+		assert.Equal(t, now, verified.LastTransitionTime.Time)
+	}
+	verifiedDNS := apimeta.FindStatusCondition(domain.Status.Conditions, networkingv1alpha.DomainConditionVerifiedDNS)
+	if assert.NotNil(t, verifiedDNS) {
+		assert.Equal(t, networkingv1alpha.DomainReasonVerificationRecordNotFound, verifiedDNS.Reason)
+	}
+	assert.Zero(t, domain.Status.Verification.ReverificationFailures)
+
+	// The regular verification loop verifies the domain again.
+	txtRecordPresent = true
+	now = next
+	reconciler.reconcileVerification(ctx, reader, domain)
+	assert.True(t, apimeta.IsStatusConditionTrue(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified))
+}
+
+func TestVerification_ReverificationSkipped(t *testing.T) {
+	verifiedDomain := func() *networkingv1alpha.Domain {
+		return newDomain("test", "test", func(domain *networkingv1alpha.Domain) {
+			domain.Status.Conditions = []metav1.Condition{{
+				Type:               networkingv1alpha.DomainConditionVerified,
+				Status:             metav1.ConditionTrue,
+				Reason:             networkingv1alpha.DomainReasonVerified,
+				LastTransitionTime: metav1.Now(),
+			}}
+		})
+	}
+
+	operatorConfig := config.NetworkServicesOperator{}
+	config.SetObjectDefaults_NetworkServicesOperator(&operatorConfig)
+	reconciler := &DomainReconciler{Config: operatorConfig, timeNow: time.Now}
+
+	// Domains verified before re-verification was enabled have no token to check.
+	domain := verifiedDomain()
+	assert.Zero(t, reconciler.reconcileVerification(context.Background(), nil, domain))
+	assert.Nil(t, domain.Status.Verification)
+
+	// Re-verification can be disabled.
+	reconciler.Config.DomainVerification.ReverificationInterval = &metav1.Duration{}
+	domain = verifiedDomain()
+	domain.Status.Verification = &networkingv1alpha.DomainVerificationStatus{
+		DNSRecord: networkingv1alpha.DNSVerificationRecord{Content: "token"},
+	}
+	assert.Zero(t, reconciler.reconcileVerification(context.Background(), nil, domain))
+	assert.True(t, domain.Status.Verification.NextVerificationAttempt.IsZero())
+}
+
 func TestRegistration_Subdomain_DelegationOverridesApexNS(t *testing.T) {
 	s := runtime.NewScheme()
 	_ = scheme.AddToScheme(s)
@@ -1181,6 +1393,10 @@ func TestRegistration_RetryBackoffOnError(t *testing.T) {
 
 	// should schedule retry in 2m
 	assert.Equal(t, 2*time.Minute, got.Status.Registration.NextRefreshAttempt.Sub(now))
+	refreshed := apimeta.FindStatusCondition(got.Status.Conditions, networkingv1alpha.DomainConditionRegistrationRefreshed)
+	if assert.NotNil(t, refreshed) {
+		assert.Equal(t, networkingv1alpha.DomainReasonRegistrationLookupFailed, refreshed.Reason)
+	}
 	assert.True(t, res.RequeueAfter == 0 || res.RequeueAfter > 0) // depending on verification timer
 }
 
@@ -1229,6 +1445,13 @@ func TestRegistration_RDAP429_WithRetryAfter_SchedulesRetryAfter(t *testing.T) {
 	// Should honor Retry-After; allow small slack in case of internal delays
 	assert.GreaterOrEqual(t, gotDelay, retryAfter)
 	assert.LessOrEqual(t, gotDelay, retryAfter+10*time.Second)
+
+	refreshed := apimeta.FindStatusCondition(got.Status.Conditions, networkingv1alpha.DomainConditionRegistrationRefreshed)
+	if assert.NotNil(t, refreshed) {
+		assert.Equal(t, metav1.ConditionFalse, refreshed.Status)
+		assert.Equal(t, networkingv1alpha.DomainReasonRateLimited, refreshed.Reason)
+		assert.Contains(t, refreshed.Message, "rdap.verisign.com")
+	}
 }
 
 func TestRegistration_RDAP429_NoRetryAfter_Uses2xBackoff(t *testing.T) {
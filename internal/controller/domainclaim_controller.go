@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// maxDomainClaimFailures is the maximum number of failed domain names listed
+// in the status of a DomainClaim.
+const maxDomainClaimFailures = 100
+
+// DomainClaimReconciler reconciles a DomainClaim object
+type DomainClaimReconciler struct {
+	mgr mcmanager.Manager
+}
+
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=domainclaims,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=domainclaims/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=domains,verbs=get;list;watch;create;delete
+
+// Reconcile creates a Domain for each domain name of the DomainClaim, deletes
+// Domains whose domain name was removed from the claim, and summarizes the
+// verification state of the Domains in the status of the claim.
+func (r *DomainClaimReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx, "cluster", req.ClusterName)
+	ctx = log.IntoContext(ctx, logger)
+
+	cl, err := r.mgr.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var claim networkingv1alpha.DomainClaim
+	if err := cl.GetClient().Get(ctx, req.NamespacedName, &claim); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !claim.DeletionTimestamp.IsZero() {
+		// Domains created by the claim are garbage collected.
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("reconciling domain claim")
+	defer logger.Info("reconcile complete")
+
+	var domainList networkingv1alpha.DomainList
+	if err := cl.GetClient().List(ctx, &domainList, client.InNamespace(claim.Namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed listing domains: %w", err)
+	}
+
+	domainsByName := map[string]*networkingv1alpha.Domain{}
+	domainsByDomainName := map[string]*networkingv1alpha.Domain{}
+	for i := range domainList.Items {
+		domain := &domainList.Items[i]
+		domainsByName[domain.Name] = domain
+		// Prefer the Domain named after the domain name, as the claim would have
+		// created it.
+		if existing, ok := domainsByDomainName[domain.Spec.DomainName]; !ok || existing.Name != existing.Spec.DomainName {
+			domainsByDomainName[domain.Spec.DomainName] = domain
+		}
+	}
+
+	origStatus := claim.Status.DeepCopy()
+	claim.Status.DomainCount = int32(len(claim.Spec.DomainNames))
+	claim.Status.VerifiedCount = 0
+	claim.Status.PendingCount = 0
+	claim.Status.FailedCount = 0
+
+	var failures []networkingv1alpha.DomainClaimFailure
+	domainNames := slices.Compact(slices.Sorted(slices.Values(claim.Spec.DomainNames)))
+	for _, domainName := range domainNames {
+		domain, ok := domainsByDomainName[domainName]
+		if !ok {
+			failure, err := r.createDomain(ctx, cl, &claim, domainName, domainsByName[domainName])
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if failure != nil {
+				failures = append(failures, *failure)
+			} else {
+				claim.Status.PendingCount++
+			}
+			continue
+		}
+
+		verified, failure := domainClaimDomainState(domain)
+		switch {
+		case verified:
+			claim.Status.VerifiedCount++
+		case failure != nil:
+			failures = append(failures, *failure)
+		default:
+			claim.Status.PendingCount++
+		}
+	}
+
+	// Delete Domains created by the claim for domain names that were removed.
+	for i := range domainList.Items {
+		domain := &domainList.Items[i]
+		if !metav1.IsControlledBy(domain, &claim) || slices.Contains(domainNames, domain.Spec.DomainName) {
+			continue
+		}
+		logger.Info("deleting domain removed from claim", "domain", domain.Name)
+		if err := cl.GetClient().Delete(ctx, domain); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("failed deleting domain %q: %w", domain.Name, err)
+		}
+	}
+
+	claim.Status.FailedCount = int32(len(failures))
+	if len(failures) > maxDomainClaimFailures {
+		failures = failures[:maxDomainClaimFailures]
+	}
+	claim.Status.Failed = failures
+
+	verifiedCondition := metav1.Condition{
+		Type:               networkingv1alpha.DomainClaimConditionVerified,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: claim.Generation,
+	}
+	switch {
+	case claim.Status.FailedCount > 0:
+		verifiedCondition.Reason = networkingv1alpha.DomainClaimReasonFailed
+		verifiedCondition.Message = fmt.Sprintf("%d of %d domains failed, see status.failed", claim.Status.FailedCount, claim.Status.DomainCount)
+	case claim.Status.PendingCount > 0:
+		verifiedCondition.Reason = networkingv1alpha.DomainClaimReasonPending
+		verifiedCondition.Message = fmt.Sprintf("%d of %d domains are waiting to be verified", claim.Status.PendingCount, claim.Status.DomainCount)
+	default:
+		verifiedCondition.Status = metav1.ConditionTrue
+		verifiedCondition.Reason = networkingv1alpha.DomainClaimReasonAllVerified
+		verifiedCondition.Message = fmt.Sprintf("All %d domains have been verified", claim.Status.DomainCount)
+	}
+	apimeta.SetStatusCondition(&claim.Status.Conditions, verifiedCondition)
+
+	if !equality.Semantic.DeepEqual(*origStatus, claim.Status) {
+		if err := cl.GetClient().Status().Update(ctx, &claim); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed updating domain claim status: %w", err)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// createDomain creates the Domain for a domain name of the claim. It returns a
+// failure when the Domain cannot be created, for example because a Domain with
+// the same name exists for a different domain name.
+func (r *DomainClaimReconciler) createDomain(
+	ctx context.Context,
+	cl cluster.Cluster,
+	claim *networkingv1alpha.DomainClaim,
+	domainName string,
+	existing *networkingv1alpha.Domain,
+) (*networkingv1alpha.DomainClaimFailure, error) {
+	if existing != nil {
+		return &networkingv1alpha.DomainClaimFailure{
+			DomainName: domainName,
+			Domain:     existing.Name,
+			Reason:     networkingv1alpha.DomainClaimReasonConflict,
+			Message:    fmt.Sprintf("Domain %q already exists for domain name %q", existing.Name, existing.Spec.DomainName),
+		}, nil
+	}
+
+	domain := &networkingv1alpha.Domain{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: claim.Namespace,
+			Name:      domainName,
+		},
+		Spec: networkingv1alpha.DomainSpec{
+			DomainName: domainName,
+		},
+	}
+	if err := controllerutil.SetControllerReference(claim, domain, cl.GetScheme()); err != nil {
+		return nil, fmt.Errorf("failed to set controller on domain: %w", err)
+	}
+
+	if err := cl.GetClient().Create(ctx, domain); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// The Domain was created since the cache was synced.
+			return nil, nil
+		}
+		if apierrors.IsInvalid(err) || apierrors.IsForbidden(err) {
+			return &networkingv1alpha.DomainClaimFailure{
+				DomainName: domainName,
+				Reason:     string(apierrors.ReasonForError(err)),
+				Message:    err.Error(),
+			}, nil
+		}
+		return nil, fmt.Errorf("failed creating domain %q: %w", domainName, err)
+	}
+
+	log.FromContext(ctx).Info("domain created", "domain", domain.Name)
+	return nil, nil
+}
+
+// domainClaimDomainState returns whether the Domain is verified, or the
+// failure to report when it will not be verified without user action.
+func domainClaimDomainState(domain *networkingv1alpha.Domain) (bool, *networkingv1alpha.DomainClaimFailure) {
+	if apimeta.IsStatusConditionTrue(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified) {
+		return true, nil
+	}
+
+	newFailure := func(condition *metav1.Condition) *networkingv1alpha.DomainClaimFailure {
+		return &networkingv1alpha.DomainClaimFailure{
+			DomainName: domain.Spec.DomainName,
+			Domain:     domain.Name,
+			Reason:     condition.Reason,
+			Message:    condition.Message,
+		}
+	}
+
+	if valid := apimeta.FindStatusCondition(domain.Status.Conditions, networkingv1alpha.DomainConditionValidDomain); valid != nil && valid.Status == metav1.ConditionFalse {
+		return false, newFailure(valid)
+	}
+	if verified := apimeta.FindStatusCondition(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified); verified != nil &&
+		verified.Reason == networkingv1alpha.DomainReasonVerificationExpired {
+		return false, newFailure(verified)
+	}
+	return false, nil
+}
+
+// listDomainClaimsForDomainFunc enqueues the DomainClaims that list the domain
+// name of a Domain, or that created it.
+func (r *DomainClaimReconciler) listDomainClaimsForDomainFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		domain := obj.(*networkingv1alpha.Domain)
+
+		logger := log.FromContext(ctx)
+
+		var claimList networkingv1alpha.DomainClaimList
+		if err := cl.GetClient().List(ctx, &claimList, client.InNamespace(domain.Namespace)); err != nil {
+			logger.Error(err, "failed to list DomainClaims")
+			return nil
+		}
+
+		var requests []mcreconcile.Request
+		for _, claim := range claimList.Items {
+			// Claims also track Domains whose name conflicts with one of their
+			// domain names.
+			if metav1.IsControlledBy(domain, &claim) ||
+				slices.ContainsFunc(claim.Spec.DomainNames, func(domainName string) bool {
+					return domainName == domain.Spec.DomainName || domainName == domain.Name
+				}) {
+				requests = append(requests, mcreconcile.Request{
+					ClusterName: clusterName,
+					Request: reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(&claim),
+					},
+				})
+			}
+		}
+
+		return requests
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DomainClaimReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&networkingv1alpha.DomainClaim{}).
+		Watches(
+			&networkingv1alpha.Domain{},
+			r.listDomainClaimsForDomainFunc,
+		).
+		Named("domainclaim").
+		Complete(r)
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestDomainClaimReconcile(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+
+	claim := &networkingv1alpha.DomainClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "claim", UID: uuid.NewUUID(), Generation: 1},
+		Spec: networkingv1alpha.DomainClaimSpec{
+			DomainNames: []string{"new.example.com", "verified.example.com", "invalid.example.com", "conflict.example.com"},
+		},
+	}
+
+	verifiedDomain := &networkingv1alpha.Domain{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "verified"},
+		Spec:       networkingv1alpha.DomainSpec{DomainName: "verified.example.com"},
+		Status: networkingv1alpha.DomainStatus{Conditions: []metav1.Condition{{
+			Type:   networkingv1alpha.DomainConditionVerified,
+			Status: metav1.ConditionTrue,
+			Reason: networkingv1alpha.DomainReasonVerified,
+		}}},
+	}
+	invalidDomain := &networkingv1alpha.Domain{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "invalid.example.com"},
+		Spec:       networkingv1alpha.DomainSpec{DomainName: "invalid.example.com"},
+		Status: networkingv1alpha.DomainStatus{Conditions: []metav1.Condition{{
+			Type:    networkingv1alpha.DomainConditionValidDomain,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Invalid",
+			Message: "not a registrable domain",
+		}}},
+	}
+	conflictingDomain := &networkingv1alpha.Domain{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "conflict.example.com"},
+		Spec:       networkingv1alpha.DomainSpec{DomainName: "other.example.com"},
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(claim, verifiedDomain, invalidDomain, conflictingDomain).
+		WithStatusSubresource(claim, verifiedDomain, invalidDomain, conflictingDomain).
+		Build()
+	reconciler := &DomainClaimReconciler{mgr: &fakeMockManager{cl: cl}}
+	req := mcreconcile.Request{Request: reconcile.Request{NamespacedName: client.ObjectKeyFromObject(claim)}}
+
+	_, err := reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	// A Domain is only created for the domain name without one.
+	var domainList networkingv1alpha.DomainList
+	require.NoError(t, cl.List(ctx, &domainList, client.InNamespace("test")))
+	assert.Len(t, domainList.Items, 4)
+
+	var created networkingv1alpha.Domain
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "test", Name: "new.example.com"}, &created))
+	assert.Equal(t, "new.example.com", created.Spec.DomainName)
+	assert.True(t, metav1.IsControlledBy(&created, claim))
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(claim), claim))
+	assert.Equal(t, int32(4), claim.Status.DomainCount)
+	assert.Equal(t, int32(1), claim.Status.VerifiedCount)
+	assert.Equal(t, int32(1), claim.Status.PendingCount)
+	assert.Equal(t, int32(2), claim.Status.FailedCount)
+	if assert.Len(t, claim.Status.Failed, 2) {
+		assert.Equal(t, "conflict.example.com", claim.Status.Failed[0].DomainName)
+		assert.Equal(t, networkingv1alpha.DomainClaimReasonConflict, claim.Status.Failed[0].Reason)
+		assert.Equal(t, "invalid.example.com", claim.Status.Failed[1].DomainName)
+		assert.Equal(t, "invalid.example.com", claim.Status.Failed[1].Domain)
+		assert.Equal(t, "not a registrable domain", claim.Status.Failed[1].Message)
+	}
+	condition := apimeta.FindStatusCondition(claim.Status.Conditions, networkingv1alpha.DomainClaimConditionVerified)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, networkingv1alpha.DomainClaimReasonFailed, condition.Reason)
+
+	// Once the remaining domain names are verified, the claim is verified.
+	claim.Spec.DomainNames = []string{"new.example.com", "verified.example.com"}
+	require.NoError(t, cl.Update(ctx, claim))
+	created.Status.Conditions = verifiedDomain.Status.Conditions
+	require.NoError(t, cl.Status().Update(ctx, &created))
+
+	_, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(claim), claim))
+	assert.Equal(t, int32(2), claim.Status.DomainCount)
+	assert.Equal(t, int32(2), claim.Status.VerifiedCount)
+	assert.Zero(t, claim.Status.FailedCount)
+	assert.Empty(t, claim.Status.Failed)
+	assert.True(t, apimeta.IsStatusConditionTrue(claim.Status.Conditions, networkingv1alpha.DomainClaimConditionVerified))
+
+	// Domains the claim created are deleted when their domain name is removed,
+	// while Domains it did not create are left alone.
+	claim.Spec.DomainNames = []string{"verified.example.com"}
+	require.NoError(t, cl.Update(ctx, claim))
+
+	_, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	err = cl.Get(ctx, client.ObjectKeyFromObject(&created), &created)
+	assert.True(t, apierrors.IsNotFound(err), "expected created domain to be deleted, got %v", err)
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(invalidDomain), invalidDomain))
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(conflictingDomain), conflictingDomain))
+}
+
+func TestDomainClaimDomainState(t *testing.T) {
+	domain := &networkingv1alpha.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Spec:       networkingv1alpha.DomainSpec{DomainName: "example.com"},
+	}
+	verified, failure := domainClaimDomainState(domain)
+	assert.False(t, verified)
+	assert.Nil(t, failure)
+
+	domain.Status.Conditions = []metav1.Condition{{
+		Type:    networkingv1alpha.DomainConditionVerified,
+		Status:  metav1.ConditionFalse,
+		Reason:  networkingv1alpha.DomainReasonVerificationExpired,
+		Message: "verification record removed",
+	}}
+	verified, failure = domainClaimDomainState(domain)
+	assert.False(t, verified)
+	require.NotNil(t, failure)
+	assert.Equal(t, "example", failure.Domain)
+	assert.Equal(t, networkingv1alpha.DomainReasonVerificationExpired, failure.Reason)
+}
@@ -0,0 +1,324 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/features"
+)
+
+// Drift values of downstream resources, used as Prometheus label values.
+const (
+	// downstreamDriftOrphaned is the drift of a resource whose upstream owner no
+	// longer exists.
+	downstreamDriftOrphaned = "orphaned"
+	// downstreamDriftOwnerReplaced is the drift of a resource whose upstream
+	// owner was deleted and recreated with the same name, leaving the resource
+	// anchored to the deleted owner.
+	downstreamDriftOwnerReplaced = "owner_replaced"
+	// downstreamDriftSpecModified is the drift of a resource whose spec was
+	// changed since a controller last wrote the desired state of its upstream
+	// owner to it.
+	downstreamDriftSpecModified = "spec_modified"
+)
+
+// anchorNamePrefix is the prefix of the names of the anchor ConfigMaps that
+// downstream resources are owned by, followed by the UID of the upstream owner.
+const anchorNamePrefix = "anchor-"
+
+// downstreamSpecDriftQueueSize is the number of resources with a modified spec
+// the downstream audit can hand over before the controllers of their owners
+// pick them up.
+const downstreamSpecDriftQueueSize = 1024
+
+// downstreamAuditedKinds are the kinds of downstream resources audited. The
+// kinds of routes that are only translated with a feature enabled are added by
+// auditedKinds.
+var downstreamAuditedKinds = []schema.GroupVersionKind{
+	gatewayv1.SchemeGroupVersion.WithKind(KindGateway),
+	gatewayv1.SchemeGroupVersion.WithKind(KindHTTPRoute),
+	corev1.SchemeGroupVersion.WithKind(KindService),
+	discoveryv1.SchemeGroupVersion.WithKind(KindEndpointSlice),
+}
+
+// DownstreamAuditor periodically audits the resources the operator manages in
+// the downstream cluster against their upstream owners, and reports or repairs
+// the resources that drifted from them.
+type DownstreamAuditor struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+
+	DownstreamCluster cluster.Cluster
+
+	// SpecDrift, when set, receives the downstream resources whose spec was
+	// modified when repair is enabled, for the controllers of their owners to
+	// write their desired spec again.
+	SpecDrift chan<- event.TypedGenericEvent[client.Object]
+}
+
+// downstreamDrift is a downstream resource that drifted from its upstream
+// owner.
+type downstreamDrift struct {
+	GVK   schema.GroupVersionKind
+	Key   client.ObjectKey
+	Drift string
+}
+
+// NewDownstreamSpecDriftQueue returns a queue for the DownstreamAuditor to hand
+// the resources with a modified spec over to the controllers of their owners.
+func NewDownstreamSpecDriftQueue() chan event.TypedGenericEvent[client.Object] {
+	return make(chan event.TypedGenericEvent[client.Object], downstreamSpecDriftQueueSize)
+}
+
+// SetupWithManager registers the auditor to run on the leader.
+func (a *DownstreamAuditor) SetupWithManager(mgr mcmanager.Manager) error {
+	a.mgr = mgr
+	return mgr.GetLocalManager().Add(a)
+}
+
+// Start runs the audit every interval until the context is done. The first
+// audit runs after one interval, so that upstream clusters have been engaged.
+func (a *DownstreamAuditor) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("downstream-audit")
+	ctx = log.IntoContext(ctx, logger)
+
+	ticker := time.NewTicker(a.Config.DownstreamResourceManagement.Audit.Interval.Duration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			drifts, err := a.Audit(ctx)
+			if err != nil {
+				logger.Error(err, "downstream audit failed")
+				continue
+			}
+			logger.Info("downstream audit complete", "drifted", len(drifts))
+		}
+	}
+}
+
+// auditedKinds returns the kinds of downstream resources audited, including the
+// kinds of routes translated by enabled features, whose CRDs may not be
+// installed otherwise.
+func (a *DownstreamAuditor) auditedKinds() []schema.GroupVersionKind {
+	kinds := slices.Clone(downstreamAuditedKinds)
+	if a.Config.FeatureEnabled(features.GRPCRoutes) {
+		kinds = append(kinds, gatewayv1.SchemeGroupVersion.WithKind(KindGRPCRoute))
+	}
+	if a.Config.FeatureEnabled(features.L4Routes) {
+		kinds = append(kinds,
+			gatewayv1alpha2.SchemeGroupVersion.WithKind(KindTCPRoute),
+			gatewayv1alpha2.SchemeGroupVersion.WithKind(KindUDPRoute),
+		)
+	}
+	return kinds
+}
+
+// Audit lists the managed downstream resources, returns those that drifted
+// from their upstream owners and, when repair is enabled, deletes them or, if
+// only their spec was modified, has their desired spec written again.
+//
+// Resources of upstream clusters that are not engaged, or of owner kinds that
+// are not known, are skipped as their owners cannot be looked up.
+func (a *DownstreamAuditor) Audit(ctx context.Context) ([]downstreamDrift, error) {
+	logger := log.FromContext(ctx)
+	repair := a.Config.DownstreamResourceManagement.Audit.Repair
+
+	var drifts []downstreamDrift
+	counts := map[schema.GroupVersionKind]map[string]int{}
+	for _, gvk := range a.auditedKinds() {
+		counts[gvk] = map[string]int{downstreamDriftOrphaned: 0, downstreamDriftOwnerReplaced: 0, downstreamDriftSpecModified: 0}
+
+		var list metav1.PartialObjectMetadataList
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := a.DownstreamCluster.GetAPIReader().List(ctx, &list, client.HasLabels{
+			downstreamclient.UpstreamOwnerClusterNameLabel,
+			downstreamclient.UpstreamOwnerKindLabel,
+			downstreamclient.UpstreamOwnerNameLabel,
+		}); err != nil {
+			return nil, fmt.Errorf("failed listing downstream %s resources: %w", gvk.Kind, err)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if !obj.DeletionTimestamp.IsZero() {
+				continue
+			}
+			drift, err := a.driftOf(ctx, obj)
+			if err != nil {
+				return nil, err
+			}
+			if drift == "" {
+				continue
+			}
+
+			counts[gvk][drift]++
+			drifts = append(drifts, downstreamDrift{GVK: gvk, Key: client.ObjectKeyFromObject(obj), Drift: drift})
+			logger.Info("downstream resource drifted from upstream owner",
+				jsonKeyKind, gvk.Kind, jsonKeyNamespace, obj.Namespace, jsonKeyName, obj.Name, "drift", drift, "repair", repair)
+
+			if !repair {
+				continue
+			}
+			if drift == downstreamDriftSpecModified {
+				if err := a.reapplySpec(ctx, gvk, client.ObjectKeyFromObject(obj)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			obj.SetGroupVersionKind(gvk)
+			if err := a.DownstreamCluster.GetClient().Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed deleting drifted downstream %s %s: %w", gvk.Kind, client.ObjectKeyFromObject(obj), err)
+			}
+			downstreamDriftRepairedTotal.WithLabelValues(gvk.Kind, drift).Inc()
+		}
+	}
+
+	for gvk, driftCounts := range counts {
+		for drift, count := range driftCounts {
+			downstreamDriftResources.WithLabelValues(gvk.Kind, drift).Set(float64(count))
+		}
+	}
+
+	return drifts, nil
+}
+
+// reapplySpec hands a downstream resource whose spec was modified to the
+// controller of its owner, which writes its desired spec again. A resource that
+// cannot be handed over is reported again by the next audit.
+func (a *DownstreamAuditor) reapplySpec(ctx context.Context, gvk schema.GroupVersionKind, key client.ObjectKey) error {
+	if a.SpecDrift == nil {
+		return nil
+	}
+
+	newObj, err := a.DownstreamCluster.GetScheme().New(gvk)
+	if err != nil {
+		return fmt.Errorf("failed creating downstream %s: %w", gvk.Kind, err)
+	}
+	obj, ok := newObj.(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", newObj)
+	}
+	if err := a.DownstreamCluster.GetClient().Get(ctx, key, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	select {
+	case a.SpecDrift <- event.TypedGenericEvent[client.Object]{Object: obj}:
+		downstreamDriftRepairedTotal.WithLabelValues(gvk.Kind, downstreamDriftSpecModified).Inc()
+	default:
+		log.FromContext(ctx).Info("downstream spec drift queue is full, deferring repair to the next audit",
+			jsonKeyKind, gvk.Kind, jsonKeyNamespace, key.Namespace, jsonKeyName, key.Name)
+	}
+	return nil
+}
+
+// driftOf returns the drift of a managed downstream resource from its upstream
+// owner, or an empty string if it is in sync or its owner cannot be looked up.
+func (a *DownstreamAuditor) driftOf(ctx context.Context, obj *metav1.PartialObjectMetadata) (string, error) {
+	labels := obj.GetLabels()
+	clusterName := downstreamclient.UpstreamClusterNameFromLabel(labels[downstreamclient.UpstreamOwnerClusterNameLabel])
+	upstreamCluster, err := a.mgr.GetCluster(ctx, multicluster.ClusterName(clusterName))
+	if err != nil {
+		return "", nil
+	}
+
+	ownerGroup := labels[downstreamclient.UpstreamOwnerGroupLabel]
+	ownerKind := labels[downstreamclient.UpstreamOwnerKindLabel]
+	owner, ok := newObjectForGroupKind(upstreamCluster, schema.GroupKind{Group: ownerGroup, Kind: ownerKind})
+	if !ok {
+		return "", nil
+	}
+
+	ownerKey := client.ObjectKey{
+		Namespace: labels[downstreamclient.UpstreamOwnerNamespaceLabel],
+		Name:      labels[downstreamclient.UpstreamOwnerNameLabel],
+	}
+	if err := upstreamCluster.GetClient().Get(ctx, ownerKey, owner); err != nil {
+		if apierrors.IsNotFound(err) {
+			return downstreamDriftOrphaned, nil
+		}
+		return "", fmt.Errorf("failed getting upstream %s %s: %w", ownerKind, ownerKey, err)
+	}
+	if !owner.GetDeletionTimestamp().IsZero() {
+		// The owner's controllers remove the resource while finalizing it.
+		return "", nil
+	}
+
+	anchored, anchoredToOwner := false, false
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind != "ConfigMap" || !strings.HasPrefix(ref.Name, anchorNamePrefix) {
+			continue
+		}
+		anchored = true
+		if ref.Name == anchorNamePrefix+string(owner.GetUID()) {
+			anchoredToOwner = true
+		}
+	}
+	if anchored && !anchoredToOwner {
+		return downstreamDriftOwnerReplaced, nil
+	}
+	if specModified(obj) {
+		return downstreamDriftSpecModified, nil
+	}
+	return "", nil
+}
+
+// specModified reports whether the spec of a downstream resource changed since
+// a controller last wrote its desired state, as recorded in the desired hash
+// and observed generation annotations. Resources written without recording
+// their desired state are never reported.
+func specModified(obj client.Object) bool {
+	annotations := obj.GetAnnotations()
+	if annotations[downstreamclient.DesiredHashAnnotation] == "" {
+		return false
+	}
+	observedGeneration, ok := annotations[downstreamclient.ObservedGenerationAnnotation]
+	if !ok {
+		return false
+	}
+	return observedGeneration != strconv.FormatInt(obj.GetGeneration(), 10)
+}
+
+// newObjectForGroupKind returns a new object of the kind in the preferred
+// version of its group known to the cluster's scheme.
+func newObjectForGroupKind(cl cluster.Cluster, gk schema.GroupKind) (client.Object, bool) {
+	scheme := cl.GetScheme()
+	for _, gv := range scheme.PrioritizedVersionsForGroup(gk.Group) {
+		gvk := gv.WithKind(gk.Kind)
+		if !scheme.Recognizes(gvk) {
+			continue
+		}
+		obj, err := scheme.New(gvk)
+		if err != nil {
+			return nil, false
+		}
+		clientObj, ok := obj.(client.Object)
+		return clientObj, ok
+	}
+	return nil, false
+}
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/features"
+)
+
+func TestDownstreamAuditorAudit(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	upstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway", UID: "gateway-uid"},
+	}
+	upstreamRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "route", UID: "route-uid"},
+	}
+	modifiedRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "modified", UID: "modified-uid"},
+	}
+	modifiedGRPCRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "grpc", UID: "grpc-uid"},
+	}
+	upstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(upstreamGateway, upstreamRoute, modifiedRoute, modifiedGRPCRoute).
+		Build()
+
+	downstreamMeta := func(name, ownerKind, ownerName string, ownerUID types.UID) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace: "ns-test",
+			Name:      name,
+			Labels: map[string]string{
+				downstreamclient.UpstreamOwnerClusterNameLabel: "cluster-test",
+				downstreamclient.UpstreamOwnerGroupLabel:       gatewayv1.GroupName,
+				downstreamclient.UpstreamOwnerKindLabel:        ownerKind,
+				downstreamclient.UpstreamOwnerNameLabel:        ownerName,
+				downstreamclient.UpstreamOwnerNamespaceLabel:   "default",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: anchorNamePrefix + string(ownerUID), UID: "anchor-uid"},
+			},
+		}
+	}
+
+	withDesiredState := func(objectMeta metav1.ObjectMeta, generation int64, observedGeneration string) metav1.ObjectMeta {
+		objectMeta.Generation = generation
+		objectMeta.Annotations = map[string]string{
+			downstreamclient.DesiredHashAnnotation:        "hash",
+			downstreamclient.ObservedGenerationAnnotation: observedGeneration,
+		}
+		return objectMeta
+	}
+
+	downstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			// In sync with its upstream owner.
+			&gatewayv1.Gateway{ObjectMeta: downstreamMeta("gateway", KindGateway, "gateway", "gateway-uid")},
+			// The upstream owner was deleted.
+			&gatewayv1.Gateway{ObjectMeta: downstreamMeta("deleted", KindGateway, "deleted", "deleted-uid")},
+			// The upstream owner was deleted and recreated.
+			&gatewayv1.HTTPRoute{ObjectMeta: downstreamMeta("route", KindHTTPRoute, "route", "previous-route-uid")},
+			// The spec was changed after the desired state was written.
+			&gatewayv1.HTTPRoute{
+				ObjectMeta: withDesiredState(downstreamMeta("modified", KindHTTPRoute, "modified", "modified-uid"), 3, "2"),
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "gateway"}},
+					},
+				},
+			},
+			// The spec is as last written.
+			&gatewayv1.HTTPRoute{ObjectMeta: withDesiredState(downstreamMeta("unmodified", KindHTTPRoute, "route", "route-uid"), 2, "2")},
+			// GRPCRoutes are audited when they are translated.
+			&gatewayv1.GRPCRoute{ObjectMeta: withDesiredState(downstreamMeta("grpc", KindGRPCRoute, "grpc", "grpc-uid"), 2, "1")},
+			// Owners of unknown kinds cannot be looked up.
+			&corev1.Service{ObjectMeta: downstreamMeta("service", "Unknown", "service", "service-uid")},
+			// Resources without upstream owner labels are not managed.
+			&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-test", Name: "unmanaged"}, AddressType: discoveryv1.AddressTypeIPv4},
+		).
+		Build()
+
+	operatorConfig := config.NetworkServicesOperator{}
+	config.SetObjectDefaults_NetworkServicesOperator(&operatorConfig)
+	operatorConfig.FeatureGates = map[string]bool{string(features.GRPCRoutes): true}
+	specDrift := NewDownstreamSpecDriftQueue()
+	auditor := &DownstreamAuditor{
+		mgr:               &fakeMockManager{cl: upstreamClient},
+		Config:            operatorConfig,
+		DownstreamCluster: &fakeCluster{cl: downstreamClient},
+		SpecDrift:         specDrift,
+	}
+
+	wantDrifts := []downstreamDrift{
+		{GVK: gatewayv1.SchemeGroupVersion.WithKind(KindGateway), Key: client.ObjectKey{Namespace: "ns-test", Name: "deleted"}, Drift: downstreamDriftOrphaned},
+		{GVK: gatewayv1.SchemeGroupVersion.WithKind(KindHTTPRoute), Key: client.ObjectKey{Namespace: "ns-test", Name: "modified"}, Drift: downstreamDriftSpecModified},
+		{GVK: gatewayv1.SchemeGroupVersion.WithKind(KindHTTPRoute), Key: client.ObjectKey{Namespace: "ns-test", Name: "route"}, Drift: downstreamDriftOwnerReplaced},
+		{GVK: gatewayv1.SchemeGroupVersion.WithKind(KindGRPCRoute), Key: client.ObjectKey{Namespace: "ns-test", Name: "grpc"}, Drift: downstreamDriftSpecModified},
+	}
+
+	// Drift is only reported by default.
+	drifts, err := auditor.Audit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantDrifts, drifts)
+	assert.NoError(t, downstreamClient.Get(ctx, client.ObjectKey{Namespace: "ns-test", Name: "deleted"}, &gatewayv1.Gateway{}))
+	assert.Empty(t, specDrift)
+
+	// Drifted resources are deleted when repair is enabled.
+	auditor.Config.DownstreamResourceManagement.Audit.Repair = true
+	drifts, err = auditor.Audit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantDrifts, drifts)
+
+	err = downstreamClient.Get(ctx, client.ObjectKey{Namespace: "ns-test", Name: "deleted"}, &gatewayv1.Gateway{})
+	assert.True(t, apierrors.IsNotFound(err), "expected orphaned gateway to be deleted, got %v", err)
+	err = downstreamClient.Get(ctx, client.ObjectKey{Namespace: "ns-test", Name: "route"}, &gatewayv1.HTTPRoute{})
+	assert.True(t, apierrors.IsNotFound(err), "expected stale route to be deleted, got %v", err)
+	assert.NoError(t, downstreamClient.Get(ctx, client.ObjectKey{Namespace: "ns-test", Name: "gateway"}, &gatewayv1.Gateway{}))
+	assert.NoError(t, downstreamClient.Get(ctx, client.ObjectKey{Namespace: "ns-test", Name: "service"}, &corev1.Service{}))
+	// A modified spec is handed to the Gateway controller to write again.
+	assert.NoError(t, downstreamClient.Get(ctx, client.ObjectKey{Namespace: "ns-test", Name: "modified"}, &gatewayv1.HTTPRoute{}))
+	if assert.Len(t, specDrift, 2) {
+		evt := <-specDrift
+		assert.Equal(t, client.ObjectKey{Namespace: "ns-test", Name: "modified"}, client.ObjectKeyFromObject(evt.Object))
+		reconciler := &GatewayReconciler{DownstreamCluster: &fakeCluster{cl: downstreamClient}}
+		assert.Equal(t, []mcreconcile.Request{
+			{
+				Request:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "gateway"}},
+				ClusterName: "test",
+			},
+		}, reconciler.listGatewaysForDownstreamSpecDrift(ctx, evt.Object))
+		evt = <-specDrift
+		assert.Equal(t, client.ObjectKey{Namespace: "ns-test", Name: "grpc"}, client.ObjectKeyFromObject(evt.Object))
+	}
+
+	drifts, err = auditor.Audit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []downstreamDrift{wantDrifts[1], wantDrifts[3]}, drifts)
+}
+
+func TestListGatewaysForDownstreamSpecDrift(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, gatewayv1alpha2.Install(testScheme))
+
+	ownerLabels := map[string]string{
+		downstreamclient.UpstreamOwnerClusterNameLabel: "cluster-test",
+		downstreamclient.UpstreamOwnerGroupLabel:       gatewayv1.GroupName,
+		downstreamclient.UpstreamOwnerNamespaceLabel:   "default",
+	}
+	routeLabels := maps.Clone(ownerLabels)
+	routeLabels[downstreamclient.UpstreamOwnerKindLabel] = KindTCPRoute
+	routeLabels[downstreamclient.UpstreamOwnerNameLabel] = "tcp"
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-test", Name: "tcp", UID: "tcp-uid", Labels: routeLabels},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "gateway"}},
+			},
+		},
+	}
+	downstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(tcpRoute).Build()
+	reconciler := &GatewayReconciler{DownstreamCluster: &fakeCluster{cl: downstreamClient}}
+
+	wantRequests := []mcreconcile.Request{
+		{
+			Request:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "gateway"}},
+			ClusterName: "test",
+		},
+	}
+
+	gatewayLabels := maps.Clone(ownerLabels)
+	gatewayLabels[downstreamclient.UpstreamOwnerKindLabel] = KindGateway
+	gatewayLabels[downstreamclient.UpstreamOwnerNameLabel] = "gateway"
+	shard := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-test", Name: "gateway-shard-1", Labels: gatewayLabels}}
+	assert.Equal(t, wantRequests, reconciler.listGatewaysForDownstreamSpecDrift(ctx, shard))
+
+	assert.Equal(t, wantRequests, reconciler.listGatewaysForDownstreamSpecDrift(ctx, tcpRoute))
+
+	// The resources of the backends of a route are mapped to the Gateways of
+	// the route.
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta:  metav1.ObjectMeta{Namespace: "ns-test", Name: "backend"},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	require.NoError(t, controllerutil.SetControllerReference(tcpRoute, endpointSlice, testScheme))
+	assert.Equal(t, wantRequests, reconciler.listGatewaysForDownstreamSpecDrift(ctx, endpointSlice))
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-test", Name: "unowned"}}
+	assert.Empty(t, reconciler.listGatewaysForDownstreamSpecDrift(ctx, service))
+}
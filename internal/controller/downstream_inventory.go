@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// InventoryEndpointPath is the path the downstream inventory is served on.
+const InventoryEndpointPath = "/debug/inventory"
+
+// inventoryUnknown is reported for resources created before the upstream
+// cluster and controller labels were set on every downstream resource.
+const inventoryUnknown = "unknown"
+
+// downstreamInventoryKinds are the kinds of downstream resources counted by the
+// inventory. Kinds whose API is not served by the downstream cluster are
+// skipped.
+var downstreamInventoryKinds = []schema.GroupVersionKind{
+	corev1.SchemeGroupVersion.WithKind("Namespace"),
+	corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+	corev1.SchemeGroupVersion.WithKind("Secret"),
+	corev1.SchemeGroupVersion.WithKind(KindService),
+	discoveryv1.SchemeGroupVersion.WithKind(KindEndpointSlice),
+	networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"),
+	gatewayv1.SchemeGroupVersion.WithKind(KindGateway),
+	gatewayv1.SchemeGroupVersion.WithKind(KindHTTPRoute),
+	cmv1.SchemeGroupVersion.WithKind(cmv1.CertificateKind),
+	envoygatewayv1alpha1.GroupVersion.WithKind(envoygatewayv1alpha1.KindBackend),
+	envoygatewayv1alpha1.GroupVersion.WithKind(envoygatewayv1alpha1.KindBackendTrafficPolicy),
+	envoygatewayv1alpha1.GroupVersion.WithKind(envoygatewayv1alpha1.KindClientTrafficPolicy),
+	envoygatewayv1alpha1.GroupVersion.WithKind(envoygatewayv1alpha1.KindEnvoyPatchPolicy),
+	envoygatewayv1alpha1.GroupVersion.WithKind(envoygatewayv1alpha1.KindHTTPRouteFilter),
+	envoygatewayv1alpha1.GroupVersion.WithKind(envoygatewayv1alpha1.KindSecurityPolicy),
+}
+
+// DownstreamInventoryReport counts the downstream resources the operator
+// manages.
+type DownstreamInventoryReport struct {
+	// TakenAt is when the inventory was taken.
+	TakenAt metav1.Time `json:"takenAt"`
+
+	// Total is the number of managed downstream resources.
+	Total int `json:"total"`
+
+	// Kinds counts the resources by kind.
+	Kinds map[string]int `json:"kinds"`
+
+	// Clusters counts the resources of each upstream cluster by kind.
+	Clusters map[string]map[string]int `json:"clusters"`
+
+	// Controllers counts the resources created by each controller by kind.
+	Controllers map[string]map[string]int `json:"controllers"`
+}
+
+// DownstreamInventory periodically counts the downstream resources the
+// operator manages, identified by the upstream owner labels the downstream
+// resource strategy sets, and serves the last count on the
+// InventoryEndpointPath.
+type DownstreamInventory struct {
+	Config config.NetworkServicesOperator
+
+	DownstreamCluster cluster.Cluster
+
+	mu     sync.RWMutex
+	report *DownstreamInventoryReport
+}
+
+// SetupWithManager registers the inventory to run on the leader.
+func (i *DownstreamInventory) SetupWithManager(mgr mcmanager.Manager) error {
+	return mgr.GetLocalManager().Add(i)
+}
+
+// Start takes the inventory every interval until the context is done.
+func (i *DownstreamInventory) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("downstream-inventory")
+	ctx = log.IntoContext(ctx, logger)
+
+	ticker := time.NewTicker(i.Config.DownstreamResourceManagement.Inventory.Interval.Duration)
+	defer ticker.Stop()
+	for {
+		report, err := i.Take(ctx)
+		if err != nil {
+			logger.Error(err, "downstream inventory failed")
+		} else {
+			logger.V(1).Info("downstream inventory complete", "total", report.Total)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Take counts the managed downstream resources, records the counts in the
+// nso_downstream_managed_resources metric and keeps the report for the
+// endpoint.
+func (i *DownstreamInventory) Take(ctx context.Context) (*DownstreamInventoryReport, error) {
+	report := &DownstreamInventoryReport{
+		TakenAt:     metav1.Now(),
+		Kinds:       map[string]int{},
+		Clusters:    map[string]map[string]int{},
+		Controllers: map[string]map[string]int{},
+	}
+
+	for _, gvk := range downstreamInventoryKinds {
+		var list metav1.PartialObjectMetadataList
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := i.DownstreamCluster.GetAPIReader().List(ctx, &list, client.HasLabels{
+			downstreamclient.UpstreamOwnerNamespaceLabel,
+		}); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed listing downstream %s resources: %w", gvk.Kind, err)
+		}
+
+		report.Kinds[gvk.Kind] = len(list.Items)
+		for _, obj := range list.Items {
+			labels := obj.GetLabels()
+
+			clusterName := inventoryUnknown
+			if value, ok := labels[downstreamclient.UpstreamOwnerClusterNameLabel]; ok {
+				clusterName = downstreamclient.UpstreamClusterNameFromLabel(value)
+			}
+			controllerName := inventoryUnknown
+			if value, ok := labels[downstreamclient.ManagedByControllerLabel]; ok {
+				controllerName = value
+			}
+
+			incrementInventoryCount(report.Clusters, clusterName, gvk.Kind)
+			incrementInventoryCount(report.Controllers, controllerName, gvk.Kind)
+			report.Total++
+		}
+	}
+
+	for kind, count := range report.Kinds {
+		downstreamManagedResources.WithLabelValues(kind).Set(float64(count))
+	}
+
+	i.mu.Lock()
+	i.report = report
+	i.mu.Unlock()
+
+	return report, nil
+}
+
+func incrementInventoryCount(counts map[string]map[string]int, key, kind string) {
+	if counts[key] == nil {
+		counts[key] = map[string]int{}
+	}
+	counts[key][kind]++
+}
+
+// ServeHTTP serves the last inventory as JSON. Only the replica running the
+// inventory, which is the leader, has a report to serve.
+func (i *DownstreamInventory) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	i.mu.RLock()
+	report := i.report
+	i.mu.RUnlock()
+
+	if report == nil {
+		http.Error(w, "no downstream inventory has been taken by this replica", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, "failed to encode inventory: "+err.Error(), http.StatusInternalServerError)
+	}
+}
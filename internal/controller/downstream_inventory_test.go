@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestDownstreamInventoryTake(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	managedMeta := func(name, clusterLabel, controllerName string) metav1.ObjectMeta {
+		labels := map[string]string{downstreamclient.UpstreamOwnerNamespaceLabel: "default"}
+		if clusterLabel != "" {
+			labels[downstreamclient.UpstreamOwnerClusterNameLabel] = clusterLabel
+		}
+		if controllerName != "" {
+			labels[downstreamclient.ManagedByControllerLabel] = controllerName
+		}
+		return metav1.ObjectMeta{Namespace: "ns-test", Name: name, Labels: labels}
+	}
+
+	downstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			&gatewayv1.Gateway{ObjectMeta: managedMeta("a", "cluster-project-a", "gateway")},
+			&gatewayv1.Gateway{ObjectMeta: managedMeta("b", "cluster-project-b", "gateway")},
+			&gatewayv1.HTTPRoute{ObjectMeta: managedMeta("a", "cluster-project-a", "httpproxy")},
+			// Created before the cluster and controller labels were added.
+			&corev1.Service{ObjectMeta: managedMeta("legacy", "", "")},
+			// Resources without upstream owner labels are not managed.
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-test", Name: "unmanaged"}},
+		).
+		Build()
+
+	inventory := &DownstreamInventory{DownstreamCluster: &fakeCluster{cl: downstreamClient}}
+
+	// No report is served before the first inventory.
+	recorder := httptest.NewRecorder()
+	inventory.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, InventoryEndpointPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	report, err := inventory.Take(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 2, report.Kinds[KindGateway])
+	assert.Equal(t, 1, report.Kinds[KindHTTPRoute])
+	assert.Equal(t, 1, report.Kinds[KindService])
+	assert.Equal(t, 0, report.Kinds["ConfigMap"])
+	assert.Equal(t, map[string]int{KindGateway: 1, KindHTTPRoute: 1}, report.Clusters["project-a"])
+	assert.Equal(t, map[string]int{KindService: 1}, report.Clusters[inventoryUnknown])
+	assert.Equal(t, map[string]int{KindGateway: 2}, report.Controllers["gateway"])
+
+	recorder = httptest.NewRecorder()
+	inventory.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, InventoryEndpointPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var served DownstreamInventoryReport
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &served))
+	assert.Equal(t, report.Total, served.Total)
+	assert.Equal(t, report.Clusters, served.Clusters)
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// downstreamNamespaceEmptySinceAnnotation records when a downstream namespace
+// was first found without anchors, to start its garbage collection grace
+// period.
+const downstreamNamespaceEmptySinceAnnotation = "meta.datumapis.com/empty-since"
+
+// DownstreamNamespaceGCReconciler deletes downstream namespaces that no longer
+// hold resources of upstream owners.
+//
+// Every downstream resource is owned by the anchor ConfigMap of its upstream
+// owner, which is removed when the owner is finalized. A namespace without
+// anchors is deleted once it has stayed empty for the configured grace period.
+type DownstreamNamespaceGCReconciler struct {
+	Config            config.NetworkServicesOperator
+	DownstreamCluster cluster.Cluster
+}
+
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;patch;delete
+
+func (r *DownstreamNamespaceGCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	downstreamClient := r.DownstreamCluster.GetClient()
+
+	var namespace corev1.Namespace
+	if err := downstreamClient.Get(ctx, req.NamespacedName, &namespace); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if _, ok := namespace.Labels[downstreamclient.UpstreamOwnerNamespaceLabel]; !ok {
+		return ctrl.Result{}, nil
+	}
+	if !namespace.DeletionTimestamp.IsZero() || namespace.Annotations[downstreamclient.KeepNamespaceAnnotation] == "true" {
+		return ctrl.Result{}, nil
+	}
+
+	anchored, err := downstreamNamespaceHasAnchors(ctx, downstreamClient, namespace.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	emptySinceValue, emptySinceSet := namespace.Annotations[downstreamNamespaceEmptySinceAnnotation]
+	if anchored {
+		if emptySinceSet {
+			patch := client.MergeFrom(namespace.DeepCopy())
+			delete(namespace.Annotations, downstreamNamespaceEmptySinceAnnotation)
+			if err := downstreamClient.Patch(ctx, &namespace, patch); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed clearing downstream namespace empty-since annotation: %w", err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	gracePeriod := r.Config.DownstreamResourceManagement.NamespaceGC.GracePeriod.Duration
+	emptySince, err := time.Parse(time.RFC3339, emptySinceValue)
+	if !emptySinceSet || err != nil {
+		patch := client.MergeFrom(namespace.DeepCopy())
+		if namespace.Annotations == nil {
+			namespace.Annotations = map[string]string{}
+		}
+		namespace.Annotations[downstreamNamespaceEmptySinceAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		if err := downstreamClient.Patch(ctx, &namespace, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed setting downstream namespace empty-since annotation: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: gracePeriod}, nil
+	}
+
+	if remaining := gracePeriod - time.Since(emptySince); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	// The cache may not have observed anchors created since, so the namespace
+	// is checked once more against the API server before it is deleted.
+	if anchored, err := downstreamNamespaceHasAnchors(ctx, r.DownstreamCluster.GetAPIReader(), namespace.Name); err != nil || anchored {
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("deleting empty downstream namespace", "emptySince", emptySinceValue)
+	if err := downstreamClient.Delete(ctx, &namespace,
+		client.Preconditions{UID: &namespace.UID, ResourceVersion: &namespace.ResourceVersion},
+		client.PropagationPolicy(metav1.DeletePropagationBackground),
+	); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("failed deleting downstream namespace: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// downstreamNamespaceHasAnchors returns whether a downstream namespace holds
+// anchors of upstream owners that are not being deleted.
+func downstreamNamespaceHasAnchors(ctx context.Context, reader client.Reader, namespace string) (bool, error) {
+	var configMaps metav1.PartialObjectMetadataList
+	configMaps.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMapList"))
+	if err := reader.List(ctx, &configMaps,
+		client.InNamespace(namespace),
+		client.HasLabels{downstreamclient.UpstreamOwnerKindLabel},
+	); err != nil {
+		return false, fmt.Errorf("failed listing downstream anchors: %w", err)
+	}
+
+	for _, configMap := range configMaps.Items {
+		if strings.HasPrefix(configMap.Name, anchorNamePrefix) && configMap.DeletionTimestamp.IsZero() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DownstreamNamespaceGCReconciler) SetupWithManager(mgr manager.Manager) error {
+	downstreamNamespaceSource := source.TypedKind(
+		r.DownstreamCluster.GetCache(),
+		&corev1.Namespace{},
+		&handler.TypedEnqueueRequestForObject[*corev1.Namespace]{},
+	)
+
+	// Anchors are watched by metadata only, to enqueue their namespace when the
+	// last of them is removed.
+	anchor := &metav1.PartialObjectMetadata{}
+	anchor.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	downstreamAnchorSource := source.TypedKind(
+		r.DownstreamCluster.GetCache(),
+		anchor,
+		handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, obj *metav1.PartialObjectMetadata) []reconcile.Request {
+			if !strings.HasPrefix(obj.Name, anchorNamePrefix) {
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.Namespace}}}
+		}),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WatchesRawSource(downstreamNamespaceSource).
+		WatchesRawSource(downstreamAnchorSource).
+		WithOptions(controllerOptions[ctrl.Request](r.Config, "downstream-namespace-gc", 0)).
+		Named("downstream-namespace-gc").
+		Complete(r)
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestDownstreamNamespaceGCReconciler(t *testing.T) {
+	gracePeriod := 5 * time.Minute
+
+	managedLabels := map[string]string{downstreamclient.UpstreamOwnerNamespaceLabel: "default"}
+	emptySince := func(ago time.Duration) map[string]string {
+		return map[string]string{
+			downstreamNamespaceEmptySinceAnnotation: time.Now().Add(-ago).UTC().Format(time.RFC3339),
+		}
+	}
+	anchor := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-test",
+			Name:      anchorNamePrefix + "owner-uid",
+			Labels:    map[string]string{downstreamclient.UpstreamOwnerKindLabel: KindGateway},
+		},
+	}
+
+	tests := map[string]struct {
+		labels         map[string]string
+		annotations    map[string]string
+		objects        []client.Object
+		wantDeleted    bool
+		wantEmptySince bool
+		wantRequeue    bool
+	}{
+		"empty namespace starts the grace period": {
+			labels:         managedLabels,
+			wantEmptySince: true,
+			wantRequeue:    true,
+		},
+		"empty namespace within the grace period is kept": {
+			labels:         managedLabels,
+			annotations:    emptySince(time.Minute),
+			wantEmptySince: true,
+			wantRequeue:    true,
+		},
+		"empty namespace past the grace period is deleted": {
+			labels:      managedLabels,
+			annotations: emptySince(gracePeriod + time.Minute),
+			wantDeleted: true,
+		},
+		"anchors clear the grace period": {
+			labels:      managedLabels,
+			annotations: emptySince(gracePeriod + time.Minute),
+			objects:     []client.Object{anchor},
+		},
+		"non-anchor configmaps do not keep the namespace": {
+			labels:      managedLabels,
+			annotations: emptySince(gracePeriod + time.Minute),
+			objects: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-test",
+					Name:      "replicated",
+					Labels:    map[string]string{downstreamclient.UpstreamOwnerKindLabel: KindGateway},
+				},
+			}},
+			wantDeleted: true,
+		},
+		"keep annotation prevents deletion": {
+			labels: managedLabels,
+			annotations: map[string]string{
+				downstreamclient.KeepNamespaceAnnotation: "true",
+				downstreamNamespaceEmptySinceAnnotation:  time.Now().Add(-gracePeriod - time.Minute).UTC().Format(time.RFC3339),
+			},
+			wantEmptySince: true,
+		},
+		"unmanaged namespace is ignored": {},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			testScheme := runtime.NewScheme()
+			require.NoError(t, scheme.AddToScheme(testScheme))
+
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ns-test",
+					Labels:      tt.labels,
+					Annotations: tt.annotations,
+				},
+			}
+			downstreamClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(append([]client.Object{namespace}, tt.objects...)...).
+				Build()
+
+			operatorConfig := config.NetworkServicesOperator{}
+			config.SetObjectDefaults_NetworkServicesOperator(&operatorConfig)
+			operatorConfig.DownstreamResourceManagement.NamespaceGC.GracePeriod = metav1.Duration{Duration: gracePeriod}
+
+			reconciler := &DownstreamNamespaceGCReconciler{
+				Config:            operatorConfig,
+				DownstreamCluster: &fakeCluster{cl: downstreamClient},
+			}
+
+			result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(namespace)})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRequeue, result.RequeueAfter > 0)
+			assert.LessOrEqual(t, result.RequeueAfter, gracePeriod)
+
+			var got corev1.Namespace
+			err = downstreamClient.Get(ctx, client.ObjectKeyFromObject(namespace), &got)
+			if tt.wantDeleted {
+				assert.True(t, apierrors.IsNotFound(err), "expected namespace to be deleted, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			_, hasEmptySince := got.Annotations[downstreamNamespaceEmptySinceAnnotation]
+			assert.Equal(t, tt.wantEmptySince, hasEmptySince)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/features"
+)
+
+// EndpointSliceGCReconciler releases upstream EndpointSlices that carry the
+// gateway finalizer but are no longer referenced by any route in their
+// namespace, for example because the referencing route was deleted while the
+// operator was down. The downstream copies of the EndpointSlice are removed
+// along with its anchor, and the finalizer is removed so that the EndpointSlice
+// can be deleted without the operator.
+//
+// EndpointSlices that are being deleted are left to the
+// GatewayDownstreamGCReconciler.
+type EndpointSliceGCReconciler struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+
+	DownstreamCluster cluster.Cluster
+}
+
+func (r *EndpointSliceGCReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx, "cluster", req.ClusterName)
+
+	cl, err := r.mgr.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	upstreamClient := cl.GetClient()
+
+	var endpointSlice discoveryv1.EndpointSlice
+	if err := upstreamClient.Get(ctx, req.NamespacedName, &endpointSlice); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !controllerutil.ContainsFinalizer(&endpointSlice, gatewayControllerGCFinalizer) || !endpointSlice.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	referenced, err := r.endpointSliceReferenced(ctx, upstreamClient, &endpointSlice)
+	if err != nil || referenced {
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("releasing endpointslice no longer referenced by any route", "namespace", endpointSlice.Namespace, jsonKeyName, endpointSlice.Name)
+
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), upstreamClient, r.DownstreamCluster.GetClient(), downstreamclient.WithControllerName("gateway_endpointslice_gc"))
+	if err := downstreamStrategy.DeleteAnchorForObject(ctx, &endpointSlice); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed deleting anchor: %w", err)
+	}
+
+	if controllerutil.RemoveFinalizer(&endpointSlice, gatewayControllerGCFinalizer) {
+		if err := upstreamClient.Update(ctx, &endpointSlice); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// endpointSliceReferenced returns whether a backendRef of a route in the
+// namespace of the EndpointSlice references it. Routes being deleted still
+// count as references, as their garbage collection relies on the downstream
+// copies of the EndpointSlice.
+func (r *EndpointSliceGCReconciler) endpointSliceReferenced(
+	ctx context.Context,
+	upstreamClient client.Client,
+	endpointSlice *discoveryv1.EndpointSlice,
+) (bool, error) {
+	var routes []client.Object
+
+	var httpRoutes gatewayv1.HTTPRouteList
+	if err := upstreamClient.List(ctx, &httpRoutes, client.InNamespace(endpointSlice.Namespace)); err != nil {
+		return false, fmt.Errorf("failed listing httproutes: %w", err)
+	}
+	for i := range httpRoutes.Items {
+		routes = append(routes, &httpRoutes.Items[i])
+	}
+
+	if r.Config.FeatureEnabled(features.GRPCRoutes) {
+		var grpcRoutes gatewayv1.GRPCRouteList
+		if err := upstreamClient.List(ctx, &grpcRoutes, client.InNamespace(endpointSlice.Namespace)); err != nil {
+			return false, fmt.Errorf("failed listing grpcroutes: %w", err)
+		}
+		for i := range grpcRoutes.Items {
+			routes = append(routes, &grpcRoutes.Items[i])
+		}
+	}
+
+	if r.Config.FeatureEnabled(features.L4Routes) {
+		var tcpRoutes gatewayv1alpha2.TCPRouteList
+		if err := upstreamClient.List(ctx, &tcpRoutes, client.InNamespace(endpointSlice.Namespace)); err != nil {
+			return false, fmt.Errorf("failed listing tcproutes: %w", err)
+		}
+		for i := range tcpRoutes.Items {
+			routes = append(routes, &tcpRoutes.Items[i])
+		}
+
+		var udpRoutes gatewayv1alpha2.UDPRouteList
+		if err := upstreamClient.List(ctx, &udpRoutes, client.InNamespace(endpointSlice.Namespace)); err != nil {
+			return false, fmt.Errorf("failed listing udproutes: %w", err)
+		}
+		for i := range udpRoutes.Items {
+			routes = append(routes, &udpRoutes.Items[i])
+		}
+	}
+
+	for _, route := range routes {
+		for _, key := range routeEndpointSliceKeys(route) {
+			if key == client.ObjectKeyFromObject(endpointSlice) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// routeEndpointSliceKeys returns the keys of the EndpointSlices referenced by
+// the backendRefs of a route, including the backends of RequestMirror filters.
+func routeEndpointSliceKeys(route client.Object) []client.ObjectKey {
+	var ruleBackendRefs [][]gatewayv1.BackendRef
+	var keys []client.ObjectKey
+	switch route := route.(type) {
+	case *gatewayv1.HTTPRoute:
+		ruleBackendRefs = httpRouteBackendRefs(route)
+		keys = httpRouteRequestMirrorEndpointSliceKeys(route)
+	case *gatewayv1.GRPCRoute:
+		ruleBackendRefs = grpcRouteBackendRefs(route)
+	case *gatewayv1alpha2.TCPRoute, *gatewayv1alpha2.UDPRoute:
+		ruleBackendRefs = wrapL4Route(route).backendRefs()
+	}
+
+	for _, backendRefs := range ruleBackendRefs {
+		for _, backendRef := range backendRefs {
+			if ptr.Deref(backendRef.Group, "") != discoveryv1.GroupName ||
+				ptr.Deref(backendRef.Kind, "") != KindEndpointSlice {
+				continue
+			}
+			keys = append(keys, client.ObjectKey{
+				Namespace: string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(route.GetNamespace()))),
+				Name:      string(backendRef.Name),
+			})
+		}
+	}
+	return keys
+}
+
+// httpRouteRequestMirrorEndpointSliceKeys returns the keys of the EndpointSlices
+// mirrored to by the rules of an HTTPRoute. These hold the gateway finalizer
+// just like the EndpointSlices of the backendRefs.
+func httpRouteRequestMirrorEndpointSliceKeys(httpRoute *gatewayv1.HTTPRoute) []client.ObjectKey {
+	var keys []client.ObjectKey
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, filter := range rule.Filters {
+			if filter.Type != gatewayv1.HTTPRouteFilterRequestMirror || filter.RequestMirror == nil ||
+				ptr.Deref(filter.RequestMirror.BackendRef.Kind, "") != KindEndpointSlice {
+				continue
+			}
+			backendRef := filter.RequestMirror.BackendRef
+			keys = append(keys, client.ObjectKey{
+				Namespace: string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(httpRoute.Namespace))),
+				Name:      string(backendRef.Name),
+			})
+		}
+	}
+	return keys
+}
+
+// enqueueRouteEndpointSlices enqueues the EndpointSlices referenced by a
+// route, which may have lost their last reference when the route changed or
+// was deleted.
+func enqueueRouteEndpointSlices(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		keys := routeEndpointSliceKeys(obj)
+		requests := make([]mcreconcile.Request, 0, len(keys))
+		for _, key := range keys {
+			requests = append(requests, mcreconcile.Request{
+				ClusterName: clusterName,
+				Request:     reconcile.Request{NamespacedName: key},
+			})
+		}
+		return requests
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EndpointSliceGCReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+
+	// The EndpointSlices that were stranded while the operator was down are
+	// picked up by the initial list of the informer.
+	finalized := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return controllerutil.ContainsFinalizer(obj, gatewayControllerGCFinalizer)
+	})
+
+	b := mcbuilder.ControllerManagedBy(mgr).
+		For(&discoveryv1.EndpointSlice{}, mcbuilder.WithPredicates(finalized)).
+		Watches(&gatewayv1.HTTPRoute{}, enqueueRouteEndpointSlices, mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()))
+
+	if r.Config.FeatureEnabled(features.GRPCRoutes) {
+		b = b.Watches(&gatewayv1.GRPCRoute{}, enqueueRouteEndpointSlices, mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()))
+	}
+
+	if r.Config.FeatureEnabled(features.L4Routes) {
+		b = b.
+			Watches(&gatewayv1alpha2.TCPRoute{}, enqueueRouteEndpointSlices, mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+			Watches(&gatewayv1alpha2.UDPRoute{}, enqueueRouteEndpointSlices, mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()))
+	}
+
+	return b.
+		Named("gateway_endpointslice_gc").
+		Complete(r)
+}
@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+func TestEndpointSliceGC(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()},
+	}
+	downstreamNamespaceName := fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	newEndpointSlice := func(name string, finalizers ...string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  upstreamNamespace.Name,
+				Name:       name,
+				UID:        uuid.NewUUID(),
+				Finalizers: finalizers,
+			},
+			AddressType: discoveryv1.AddressTypeFQDN,
+		}
+	}
+	anchorFor := func(endpointSlice *discoveryv1.EndpointSlice) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: downstreamNamespaceName,
+				Name:      fmt.Sprintf("anchor-%s", endpointSlice.UID),
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		endpointSlice *discoveryv1.EndpointSlice
+		routed        bool
+		mirrored      bool
+		expectRelease bool
+	}{
+		{
+			name:          "unreferenced endpointslice is released",
+			endpointSlice: newEndpointSlice("orphaned", gatewayControllerGCFinalizer),
+			expectRelease: true,
+		},
+		{
+			name:          "referenced endpointslice is kept",
+			endpointSlice: newEndpointSlice("routed", gatewayControllerGCFinalizer),
+			routed:        true,
+		},
+		{
+			name:          "endpointslice mirrored to is kept",
+			endpointSlice: newEndpointSlice("mirrored", gatewayControllerGCFinalizer),
+			mirrored:      true,
+		},
+		{
+			name:          "endpointslice without the finalizer is ignored",
+			endpointSlice: newEndpointSlice("unfinalized"),
+		},
+		{
+			name: "deleting endpointslice is left to the downstream gc",
+			endpointSlice: func() *discoveryv1.EndpointSlice {
+				endpointSlice := newEndpointSlice("deleting", gatewayControllerGCFinalizer)
+				endpointSlice.DeletionTimestamp = ptr.To(metav1.Now())
+				return endpointSlice
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backendName := "other"
+			if tt.routed {
+				backendName = tt.endpointSlice.Name
+			}
+			route := newHTTPRoute(upstreamNamespace.Name, "route", func(route *gatewayv1.HTTPRoute) {
+				route.Spec.Rules = []gatewayv1.HTTPRouteRule{{
+					BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{
+							Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+							Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+							Name:  gatewayv1.ObjectName(backendName),
+						},
+					}}},
+				}}
+				if tt.mirrored {
+					route.Spec.Rules[0].Filters = []gatewayv1.HTTPRouteFilter{{
+						Type: gatewayv1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+							BackendRef: gatewayv1.BackendObjectReference{
+								Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+								Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+								Name:  gatewayv1.ObjectName(tt.endpointSlice.Name),
+								Port:  ptr.To(gatewayv1.PortNumber(443)),
+							},
+						},
+					}}
+				}
+			})
+			anchor := anchorFor(tt.endpointSlice)
+
+			fakeUpstreamClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(upstreamNamespace, tt.endpointSlice, route).
+				Build()
+			fakeDownstreamClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(anchor).
+				Build()
+
+			ctx := context.Background()
+			reconciler := &EndpointSliceGCReconciler{
+				mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+				DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+			}
+
+			_, err := reconciler.Reconcile(ctx, mcreconcile.Request{
+				ClusterName: "test",
+				Request: reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(tt.endpointSlice),
+				},
+			})
+			require.NoError(t, err, "reconcile failed")
+
+			var endpointSlice discoveryv1.EndpointSlice
+			require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(tt.endpointSlice), &endpointSlice))
+			assert.Equal(t,
+				controllerutil.ContainsFinalizer(tt.endpointSlice, gatewayControllerGCFinalizer) && !tt.expectRelease,
+				controllerutil.ContainsFinalizer(&endpointSlice, gatewayControllerGCFinalizer),
+			)
+
+			err = fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(anchor), &corev1.ConfigMap{})
+			if tt.expectRelease {
+				assert.True(t, apierrors.IsNotFound(err), "anchor of the downstream copies is deleted, got %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRouteEndpointSliceKeys(t *testing.T) {
+	route := newHTTPRoute("test", "route", func(route *gatewayv1.HTTPRoute) {
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{
+			{BackendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+					Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+					Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+					Name:  "a",
+				}}},
+				{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+					Name: "service",
+				}}},
+			}},
+			{BackendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+					Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+					Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+					Name:  "b",
+				}}},
+			}},
+		}
+	})
+
+	assert.Equal(t, []client.ObjectKey{
+		{Namespace: "test", Name: "a"},
+		{Namespace: "test", Name: "b"},
+	}, routeEndpointSliceKeys(route))
+}
+
+func TestRouteEndpointSliceKeysRequestMirror(t *testing.T) {
+	route := newHTTPRoute("test", "route", func(route *gatewayv1.HTTPRoute) {
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{
+			{
+				BackendRefs: []gatewayv1.HTTPBackendRef{
+					{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+						Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+						Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+						Name:  "primary",
+					}}},
+				},
+				Filters: []gatewayv1.HTTPRouteFilter{
+					{
+						Type: gatewayv1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+							BackendRef: gatewayv1.BackendObjectReference{
+								Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+								Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+								Name:  "mirror",
+								Port:  ptr.To(gatewayv1.PortNumber(443)),
+							},
+						},
+					},
+					{
+						Type: gatewayv1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+							BackendRef: gatewayv1.BackendObjectReference{Name: "service"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	assert.ElementsMatch(t, []client.ObjectKey{
+		{Namespace: "test", Name: "primary"},
+		{Namespace: "test", Name: "mirror"},
+	}, routeEndpointSliceKeys(route))
+}
@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+const (
+	// envoyPatchPolicyLastProgrammedAnnotation holds the last spec of a
+	// downstream EnvoyPatchPolicy that the data plane programmed. It is
+	// restored when the data plane rejects a later spec.
+	envoyPatchPolicyLastProgrammedAnnotation = "networking.datumapis.com/last-programmed-spec"
+
+	// envoyPatchPolicyRejectedAnnotation records the desired spec of a
+	// downstream EnvoyPatchPolicy that the data plane rejected, so that it is
+	// not applied again until the desired spec changes.
+	envoyPatchPolicyRejectedAnnotation = "networking.datumapis.com/rejected-spec"
+
+	// maxLastProgrammedSpecSize bounds the size of the spec kept in the
+	// envoyPatchPolicyLastProgrammedAnnotation, well below the limit on the
+	// total size of annotations. Larger specs are rolled back to a spec
+	// without patches instead.
+	maxLastProgrammedSpecSize = 128 << 10
+)
+
+// envoyPatchPolicyRejection describes a desired spec of a downstream
+// EnvoyPatchPolicy that the data plane rejected.
+type envoyPatchPolicyRejection struct {
+	// SpecHash is the hash of the rejected desired spec.
+	SpecHash string `json:"specHash"`
+
+	// Reason and Message are from the Programmed condition the data plane
+	// reported for the rejected spec.
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// String returns a message describing the rejection.
+func (r *envoyPatchPolicyRejection) String() string {
+	if r.Message == "" {
+		return fmt.Sprintf("The data plane rejected the downstream EnvoyPatchPolicy (%s), and it was rolled back", r.Reason)
+	}
+	return fmt.Sprintf("The data plane rejected the downstream EnvoyPatchPolicy (%s), and it was rolled back: %s", r.Reason, r.Message)
+}
+
+// envoyPatchPolicyRejectionOf returns the rejection recorded on a downstream
+// EnvoyPatchPolicy by setEnvoyPatchPolicySpec, which is kept while the desired
+// spec is rejected.
+func envoyPatchPolicyRejectionOf(policy *envoygatewayv1alpha1.EnvoyPatchPolicy) *envoyPatchPolicyRejection {
+	value, ok := policy.GetAnnotations()[envoyPatchPolicyRejectedAnnotation]
+	if !ok {
+		return nil
+	}
+	var rejection envoyPatchPolicyRejection
+	if err := json.Unmarshal([]byte(value), &rejection); err != nil {
+		// A malformed record is dropped, and the desired spec is tried again.
+		return nil
+	}
+	return &rejection
+}
+
+// envoyPatchPolicyProgrammedCondition returns the Programmed condition the data
+// plane reported for the current generation of the policy. A False condition
+// of any ancestor is returned ahead of True conditions. It returns nil while
+// the current generation has not been programmed.
+func envoyPatchPolicyProgrammedCondition(policy *envoygatewayv1alpha1.EnvoyPatchPolicy) *metav1.Condition {
+	var programmed *metav1.Condition
+	for i := range policy.Status.Ancestors {
+		condition := apimeta.FindStatusCondition(policy.Status.Ancestors[i].Conditions, conditionTypeProgrammed)
+		if condition == nil || condition.ObservedGeneration != policy.Generation {
+			continue
+		}
+		if condition.Status == metav1.ConditionFalse {
+			return condition
+		}
+		if condition.Status == metav1.ConditionTrue {
+			programmed = condition
+		}
+	}
+	return programmed
+}
+
+// setEnvoyPatchPolicySpec sets the desired spec of a downstream
+// EnvoyPatchPolicy as fetched by controllerutil.CreateOrUpdate.
+//
+// Generated JSONPatches may stop matching the xDS resources of the data plane,
+// for example after an Envoy Gateway upgrade, which can break listeners. When
+// the data plane rejected the current spec of the policy, the policy is rolled
+// back to the last spec that was programmed, or to a spec without patches, and
+// the rejected desired spec is not applied again until it changes. The returned
+// rejection is non-nil while the desired spec is rejected.
+func setEnvoyPatchPolicySpec(
+	policy *envoygatewayv1alpha1.EnvoyPatchPolicy,
+	desired envoygatewayv1alpha1.EnvoyPatchPolicySpec,
+) (*envoyPatchPolicyRejection, error) {
+	desiredHash, err := downstreamclient.DesiredHash(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := policy.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	rejection := envoyPatchPolicyRejectionOf(policy)
+	if !policy.CreationTimestamp.IsZero() {
+		switch condition := envoyPatchPolicyProgrammedCondition(policy); {
+		case condition == nil:
+		case condition.Status == metav1.ConditionTrue:
+			if lastProgrammed, err := json.Marshal(policy.Spec); err == nil && len(lastProgrammed) <= maxLastProgrammedSpecSize {
+				annotations[envoyPatchPolicyLastProgrammedAnnotation] = string(lastProgrammed)
+			} else {
+				delete(annotations, envoyPatchPolicyLastProgrammedAnnotation)
+			}
+		case len(policy.Spec.JSONPatches) > 0:
+			rejection = &envoyPatchPolicyRejection{
+				SpecHash: annotations[downstreamclient.DesiredHashAnnotation],
+				Reason:   condition.Reason,
+				Message:  condition.Message,
+			}
+			value, err := json.Marshal(rejection)
+			if err != nil {
+				return nil, fmt.Errorf("failed encoding envoypatchpolicy rejection: %w", err)
+			}
+			annotations[envoyPatchPolicyRejectedAnnotation] = string(value)
+
+			restored := envoygatewayv1alpha1.EnvoyPatchPolicySpec{
+				Type:      policy.Spec.Type,
+				TargetRef: policy.Spec.TargetRef,
+				Priority:  policy.Spec.Priority,
+			}
+			if lastProgrammed, ok := annotations[envoyPatchPolicyLastProgrammedAnnotation]; ok {
+				var spec envoygatewayv1alpha1.EnvoyPatchPolicySpec
+				if err := json.Unmarshal([]byte(lastProgrammed), &spec); err == nil && !equality.Semantic.DeepEqual(spec, policy.Spec) {
+					restored = spec
+				}
+			}
+			// The restored spec is only kept while it is programmed.
+			delete(annotations, envoyPatchPolicyLastProgrammedAnnotation)
+
+			restoredHash, err := downstreamclient.DesiredHash(restored)
+			if err != nil {
+				return nil, err
+			}
+			policy.Spec = restored
+			annotations[downstreamclient.DesiredHashAnnotation] = restoredHash
+		}
+	}
+
+	if rejection != nil && rejection.SpecHash == desiredHash {
+		policy.SetAnnotations(annotations)
+		return rejection, nil
+	}
+
+	delete(annotations, envoyPatchPolicyRejectedAnnotation)
+	annotations[downstreamclient.DesiredHashAnnotation] = desiredHash
+	policy.SetAnnotations(annotations)
+	policy.Spec = desired
+	return nil, nil
+}
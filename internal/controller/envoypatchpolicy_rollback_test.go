@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func testEnvoyPatchPolicySpec(jsonPath string) envoygatewayv1alpha1.EnvoyPatchPolicySpec {
+	return envoygatewayv1alpha1.EnvoyPatchPolicySpec{
+		Type: envoygatewayv1alpha1.JSONPatchEnvoyPatchType,
+		TargetRef: gatewayv1.LocalPolicyTargetReference{
+			Group: gatewayv1.GroupName,
+			Kind:  KindGatewayClass,
+			Name:  "datum-downstream-gateway",
+		},
+		JSONPatches: []envoygatewayv1alpha1.EnvoyJSONPatchConfig{
+			{
+				Type: envoygatewayv1alpha1.ListenerEnvoyResourceType,
+				Name: "default/gateway-1/https",
+				Operation: envoygatewayv1alpha1.JSONPatchOperation{
+					Op:       "remove",
+					JSONPath: ptr.To(jsonPath),
+				},
+			},
+		},
+	}
+}
+
+// programEnvoyPatchPolicy simulates the data plane reporting the Programmed
+// condition for the current generation of the policy.
+func programEnvoyPatchPolicy(policy *envoygatewayv1alpha1.EnvoyPatchPolicy, status metav1.ConditionStatus, reason string) {
+	policy.Generation++
+	policy.Status.Ancestors = []gatewayv1.PolicyAncestorStatus{
+		{
+			AncestorRef: gatewayv1.ParentReference{Kind: ptr.To(gatewayv1.Kind(KindGatewayClass)), Name: "datum-downstream-gateway"},
+			Conditions: []metav1.Condition{
+				{Type: conditionTypeProgrammed, Status: status, Reason: reason, Message: "patch message", ObservedGeneration: policy.Generation},
+			},
+		},
+	}
+}
+
+func TestSetEnvoyPatchPolicySpec(t *testing.T) {
+	previous := testEnvoyPatchPolicySpec("$.previous")
+	desired := testEnvoyPatchPolicySpec("$.desired")
+
+	// New policies are created with the desired spec.
+	policy := &envoygatewayv1alpha1.EnvoyPatchPolicy{}
+	rejection, err := setEnvoyPatchPolicySpec(policy, previous)
+	require.NoError(t, err)
+	assert.Nil(t, rejection)
+	assert.Equal(t, previous, policy.Spec)
+	policy.CreationTimestamp = metav1.Now()
+
+	// A programmed spec is kept to be restored.
+	programEnvoyPatchPolicy(policy, metav1.ConditionTrue, "Programmed")
+	rejection, err = setEnvoyPatchPolicySpec(policy, desired)
+	require.NoError(t, err)
+	assert.Nil(t, rejection)
+	assert.Equal(t, desired, policy.Spec)
+	assert.Contains(t, policy.Annotations, envoyPatchPolicyLastProgrammedAnnotation)
+
+	// A rejected spec is rolled back to the last programmed spec, and is not
+	// applied again.
+	programEnvoyPatchPolicy(policy, metav1.ConditionFalse, "ResourceNotFound")
+	rejection, err = setEnvoyPatchPolicySpec(policy, desired)
+	require.NoError(t, err)
+	require.NotNil(t, rejection)
+	assert.Equal(t, "ResourceNotFound", rejection.Reason)
+	assert.Equal(t, "patch message", rejection.Message)
+	assert.Equal(t, previous, policy.Spec)
+	assert.Equal(t, rejection, envoyPatchPolicyRejectionOf(policy))
+
+	programEnvoyPatchPolicy(policy, metav1.ConditionTrue, "Programmed")
+	rejection, err = setEnvoyPatchPolicySpec(policy, desired)
+	require.NoError(t, err)
+	require.NotNil(t, rejection)
+	assert.Equal(t, previous, policy.Spec)
+
+	// A change of the desired spec is applied.
+	changed := testEnvoyPatchPolicySpec("$.changed")
+	rejection, err = setEnvoyPatchPolicySpec(policy, changed)
+	require.NoError(t, err)
+	assert.Nil(t, rejection)
+	assert.Equal(t, changed, policy.Spec)
+	assert.Nil(t, envoyPatchPolicyRejectionOf(policy))
+}
+
+func TestSetEnvoyPatchPolicySpecWithoutProgrammedSpec(t *testing.T) {
+	desired := testEnvoyPatchPolicySpec("$.desired")
+
+	policy := &envoygatewayv1alpha1.EnvoyPatchPolicy{}
+	_, err := setEnvoyPatchPolicySpec(policy, desired)
+	require.NoError(t, err)
+	policy.CreationTimestamp = metav1.Now()
+
+	// Without a programmed spec to restore, the patches are removed.
+	programEnvoyPatchPolicy(policy, metav1.ConditionFalse, "Invalid")
+	rejection, err := setEnvoyPatchPolicySpec(policy, desired)
+	require.NoError(t, err)
+	require.NotNil(t, rejection)
+	assert.Empty(t, policy.Spec.JSONPatches)
+	assert.Equal(t, desired.TargetRef, policy.Spec.TargetRef)
+
+	// Conditions of previous generations are ignored.
+	policy.Generation++
+	rejection, err = setEnvoyPatchPolicySpec(policy, desired)
+	require.NoError(t, err)
+	require.NotNil(t, rejection)
+	assert.Empty(t, policy.Spec.JSONPatches)
+}
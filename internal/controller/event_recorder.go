@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// eventRecorderName is the reporting controller of the events emitted by the
+// operator.
+const eventRecorderName = "network-services-operator"
+
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// eventRecorderFor returns the recorder of events for objects in a cluster.
+func eventRecorderFor(cl cluster.Cluster) events.EventRecorder {
+	if recorder := cl.GetEventRecorder(eventRecorderName); recorder != nil {
+		return recorder
+	}
+	return noopEventRecorder{}
+}
+
+// clusterEventRecorder returns the recorder of events for objects in the
+// named cluster, or a recorder that drops events if the cluster is gone.
+func clusterEventRecorder(ctx context.Context, mgr mcmanager.Manager, clusterName string) events.EventRecorder {
+	cl, err := mgr.GetCluster(ctx, multicluster.ClusterName(clusterName))
+	if err != nil {
+		return noopEventRecorder{}
+	}
+	return eventRecorderFor(cl)
+}
+
+// noopEventRecorder drops all events, for clusters that have no recorder.
+type noopEventRecorder struct{}
+
+func (noopEventRecorder) Eventf(runtime.Object, runtime.Object, string, string, string, string, ...any) {
+}
+
+// recordConditionTransition emits an event for the object when the status or
+// reason of a condition changed. Conditions that are True are reported as
+// Normal events, others as Warnings. Conditions that are Unknown, or have one
+// of the ignored reasons, are not reported.
+func recordConditionTransition(
+	recorder events.EventRecorder,
+	obj runtime.Object,
+	action string,
+	oldConditions, newConditions []metav1.Condition,
+	conditionType string,
+	ignoredReasons ...string,
+) {
+	condition := apimeta.FindStatusCondition(newConditions, conditionType)
+	if condition == nil || condition.Status == metav1.ConditionUnknown || slices.Contains(ignoredReasons, condition.Reason) {
+		return
+	}
+	if old := apimeta.FindStatusCondition(oldConditions, conditionType); old != nil &&
+		old.Status == condition.Status && old.Reason == condition.Reason {
+		return
+	}
+
+	eventType := corev1.EventTypeWarning
+	if condition.Status == metav1.ConditionTrue {
+		eventType = corev1.EventTypeNormal
+	}
+	note := condition.Message
+	if note == "" {
+		note = conditionType + " is " + string(condition.Status)
+	}
+	recorder.Eventf(obj, nil, eventType, condition.Reason, action, "%s", note)
+}
+
+// recordPolicyAncestorConflicts emits a Warning event for each ancestor of a
+// policy that became Conflicted with another policy.
+func recordPolicyAncestorConflicts(recorder events.EventRecorder, policy runtime.Object, oldAncestors, newAncestors []gatewayv1.PolicyAncestorStatus) {
+	for _, ancestor := range newAncestors {
+		condition := apimeta.FindStatusCondition(ancestor.Conditions, string(gatewayv1.PolicyConditionAccepted))
+		if condition == nil || condition.Reason != string(gatewayv1.PolicyReasonConflicted) {
+			continue
+		}
+		var oldConditions []metav1.Condition
+		for _, old := range oldAncestors {
+			if old.ControllerName == ancestor.ControllerName && equality.Semantic.DeepEqual(old.AncestorRef, ancestor.AncestorRef) {
+				oldConditions = old.Conditions
+			}
+		}
+		recordConditionTransition(recorder, policy, "Attach", oldConditions, ancestor.Conditions, string(gatewayv1.PolicyConditionAccepted))
+	}
+}
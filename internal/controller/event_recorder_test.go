@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestRecordConditionTransition(t *testing.T) {
+	condition := func(status metav1.ConditionStatus, reason, message string) []metav1.Condition {
+		return []metav1.Condition{{Type: "Ready", Status: status, Reason: reason, Message: message}}
+	}
+
+	tests := map[string]struct {
+		old, new []metav1.Condition
+		want     []string
+	}{
+		"new true condition is normal": {
+			new:  condition(metav1.ConditionTrue, "Ready", "Subnet is ready to use"),
+			want: []string{"Normal Ready Subnet is ready to use"},
+		},
+		"condition becoming false is a warning": {
+			old:  condition(metav1.ConditionTrue, "Ready", ""),
+			new:  condition(metav1.ConditionFalse, "Broken", ""),
+			want: []string{"Warning Broken Ready is False"},
+		},
+		"changed reason is reported": {
+			old:  condition(metav1.ConditionFalse, "Broken", ""),
+			new:  condition(metav1.ConditionFalse, "StillBroken", "still broken"),
+			want: []string{"Warning StillBroken still broken"},
+		},
+		"unchanged condition is not reported": {
+			old: condition(metav1.ConditionFalse, "Broken", "before"),
+			new: condition(metav1.ConditionFalse, "Broken", "after"),
+		},
+		"unknown condition is not reported": {
+			new: condition(metav1.ConditionUnknown, "Broken", ""),
+		},
+		"ignored reason is not reported": {
+			new: condition(metav1.ConditionFalse, "Pending", ""),
+		},
+		"missing condition is not reported": {},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := events.NewFakeRecorder(10)
+			recordConditionTransition(recorder, &networkingv1alpha.Subnet{}, "Reconcile", tt.old, tt.new, "Ready", "Pending")
+			close(recorder.Events)
+
+			var got []string
+			for event := range recorder.Events {
+				got = append(got, event)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
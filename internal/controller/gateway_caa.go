@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	dnsutil "go.datum.net/network-services-operator/internal/util/dns"
+)
+
+// GatewayConditionCertificateIssuanceAllowed is set on upstream Gateways with
+// listeners that request certificates, and reports whether the CAA records of
+// the listener hostnames authorize the certificate authority to issue them.
+const GatewayConditionCertificateIssuanceAllowed = "CertificateIssuanceAllowed"
+
+const (
+	GatewayReasonCertificateIssuanceAllowed = "Allowed"
+	GatewayReasonBlockedByCAA               = "BlockedByCAA"
+	GatewayReasonCAALookupFailed            = "CAALookupFailed"
+)
+
+const caaLookupTimeout = 5 * time.Second
+
+// caaChecker checks the CAA records of hostnames against the configured issuer
+// domains, and caches the results so that gateways are not blocked on DNS
+// lookups every reconcile.
+type caaChecker struct {
+	exchange      dnsutil.ExchangeFunc
+	issuerDomains []string
+	ttl           time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	results map[string]caaCacheEntry
+}
+
+type caaCacheEntry struct {
+	result  dnsutil.CAAResult
+	expires time.Time
+}
+
+func newCAAChecker(exchange dnsutil.ExchangeFunc, issuerDomains []string, ttl time.Duration) *caaChecker {
+	return &caaChecker{
+		exchange:      exchange,
+		issuerDomains: issuerDomains,
+		ttl:           ttl,
+		now:           time.Now,
+		results:       map[string]caaCacheEntry{},
+	}
+}
+
+// check returns whether the CAA records of hostname authorize one of the
+// issuer domains. Lookup failures are not cached.
+func (c *caaChecker) check(ctx context.Context, hostname string) (dnsutil.CAAResult, error) {
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.results[hostname]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.result, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, caaLookupTimeout)
+	defer cancel()
+	result, err := dnsutil.CheckCAA(ctx, c.exchange, hostname, c.issuerDomains)
+	if err != nil {
+		return dnsutil.CAAResult{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, entry := range c.results {
+		if !now.Before(entry.expires) {
+			delete(c.results, name)
+		}
+	}
+	c.results[hostname] = caaCacheEntry{result: result, expires: now.Add(c.ttl)}
+	return result, nil
+}
+
+// listenerCertificateHostnames returns the hostnames of listeners that
+// ensureListenerCertificates requests a Certificate for.
+func (r *GatewayReconciler) listenerCertificateHostnames(upstreamGateway *gatewayv1.Gateway, claimedHostnames []string) []string {
+	wildcardSuffix := "." + r.Config.Gateway.TargetDomain
+	hasSharedSecret := r.Config.Gateway.HasDefaultListenerTLSSecret()
+	autoResolved := r.resolveAutoIssuer(upstreamGateway)
+
+	var hostnames []string
+	for _, l := range upstreamGateway.Spec.Listeners {
+		if l.TLS == nil || l.TLS.Options[certificateIssuerTLSOption] == "" || l.Hostname == nil {
+			continue
+		}
+		hostname := string(*l.Hostname)
+		if !slices.Contains(claimedHostnames, hostname) {
+			continue
+		}
+		if hasSharedSecret && (strings.HasSuffix(hostname, wildcardSuffix) || hostname == r.Config.Gateway.TargetDomain) {
+			continue
+		}
+
+		clusterIssuerName := string(l.TLS.Options[certificateIssuerTLSOption])
+		if mapped := reloadableConfig(&r.Config, r.ReloadableConfig).Gateway.ClusterIssuerMap[clusterIssuerName]; mapped != "" {
+			clusterIssuerName = mapped
+		}
+		if clusterIssuerName == autoIssuerSentinel && autoResolved == "" {
+			continue
+		}
+
+		if !slices.Contains(hostnames, hostname) {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	return hostnames
+}
+
+// reconcileCertificateIssuanceStatus sets the CertificateIssuanceAllowed
+// condition on the upstream gateway from the CAA records of the hostnames that
+// certificates are requested for. The condition is removed when CAA records
+// are not inspected, or when no certificates are requested.
+func (r *GatewayReconciler) reconcileCertificateIssuanceStatus(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	claimedHostnames []string,
+) (result Result) {
+	var hostnames []string
+	if r.caa != nil {
+		hostnames = r.listenerCertificateHostnames(upstreamGateway, claimedHostnames)
+	}
+	if len(hostnames) == 0 {
+		if apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, GatewayConditionCertificateIssuanceAllowed) == nil {
+			return result
+		}
+		apimeta.RemoveStatusCondition(&upstreamGateway.Status.Conditions, GatewayConditionCertificateIssuanceAllowed)
+		result.AddStatusUpdate(upstreamClient, upstreamGateway)
+		return result
+	}
+
+	logger := log.FromContext(ctx)
+
+	var blocked, failed []string
+	for _, hostname := range hostnames {
+		caaResult, err := r.caa.check(ctx, hostname)
+		if err != nil {
+			logger.Info("failed checking CAA records", "hostname", hostname, "error", err.Error())
+			failed = append(failed, hostname)
+			continue
+		}
+		if !caaResult.Allowed {
+			blocked = append(blocked, fmt.Sprintf("%s (CAA records at %s authorize %s)",
+				hostname, caaResult.RecordName, caaIssuersDescription(caaResult.Issuers)))
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               GatewayConditionCertificateIssuanceAllowed,
+		Status:             metav1.ConditionTrue,
+		Reason:             GatewayReasonCertificateIssuanceAllowed,
+		Message:            "CAA records of all listener hostnames allow certificate issuance",
+		ObservedGeneration: upstreamGateway.Generation,
+	}
+	switch {
+	case len(blocked) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GatewayReasonBlockedByCAA
+		condition.Message = fmt.Sprintf("CAA records do not allow %s to issue certificates for: %s",
+			strings.Join(r.Config.Gateway.CAA.IssuerDomains, ", "), strings.Join(blocked, "; "))
+	case len(failed) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = GatewayReasonCAALookupFailed
+		condition.Message = fmt.Sprintf("Failed looking up CAA records for: %s", strings.Join(failed, ", "))
+	}
+
+	if existing := apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, condition.Type); existing != nil &&
+		existing.Status == condition.Status &&
+		existing.Reason == condition.Reason &&
+		existing.Message == condition.Message &&
+		existing.ObservedGeneration == condition.ObservedGeneration {
+		return result
+	}
+	apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, condition)
+	result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	return result
+}
+
+func caaIssuersDescription(issuers []string) string {
+	if len(issuers) == 0 {
+		return "no certificate authority"
+	}
+	return strings.Join(issuers, ", ")
+}
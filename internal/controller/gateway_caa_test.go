@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestReconcileCertificateIssuanceStatus(t *testing.T) {
+	ctx := context.Background()
+
+	caaValue := "letsencrypt.org"
+	lookups := 0
+	exchange := func(_ context.Context, name string, qtype uint16) (*dns.Msg, error) {
+		lookups++
+		msg := new(dns.Msg)
+		if name == "example.com" && qtype == dns.TypeCAA {
+			msg.Answer = []dns.RR{&dns.CAA{
+				Hdr:   dns.RR_Header{Rrtype: dns.TypeCAA, Class: dns.ClassINET},
+				Tag:   "issue",
+				Value: caaValue,
+			}}
+		}
+		return msg, nil
+	}
+
+	reconciler := &GatewayReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{
+				TargetDomain: "test-suite.com",
+				CAA:          config.GatewayCAAConfig{IssuerDomains: []string{"letsencrypt.org"}},
+			},
+		},
+		caa: newCAAChecker(exchange, []string{"letsencrypt.org"}, time.Minute),
+	}
+	now := time.Now()
+	reconciler.caa.now = func() time.Time { return now }
+
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 2},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{
+					Name:     "https",
+					Protocol: gatewayv1.HTTPSProtocolType,
+					Hostname: ptr.To(gatewayv1.Hostname("www.example.com")),
+					TLS: &gatewayv1.ListenerTLSConfig{
+						Options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+							certificateIssuerTLSOption: "letsencrypt",
+						},
+					},
+				},
+				{
+					// Not claimed, so no certificate is requested.
+					Name:     "https-unclaimed",
+					Protocol: gatewayv1.HTTPSProtocolType,
+					Hostname: ptr.To(gatewayv1.Hostname("other.example.net")),
+					TLS: &gatewayv1.ListenerTLSConfig{
+						Options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+							certificateIssuerTLSOption: "letsencrypt",
+						},
+					},
+				},
+			},
+		},
+	}
+	claimed := []string{"www.example.com"}
+
+	reconciler.reconcileCertificateIssuanceStatus(ctx, nil, gw, claimed)
+	c := apimeta.FindStatusCondition(gw.Status.Conditions, GatewayConditionCertificateIssuanceAllowed)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, int64(2), c.ObservedGeneration)
+
+	// Results are cached until the TTL expires.
+	caaValue = "pki.goog"
+	lookupsBefore := lookups
+	reconciler.reconcileCertificateIssuanceStatus(ctx, nil, gw, claimed)
+	assert.Equal(t, lookupsBefore, lookups)
+	assert.True(t, apimeta.IsStatusConditionTrue(gw.Status.Conditions, GatewayConditionCertificateIssuanceAllowed))
+
+	now = now.Add(2 * time.Minute)
+	reconciler.reconcileCertificateIssuanceStatus(ctx, nil, gw, claimed)
+	c = apimeta.FindStatusCondition(gw.Status.Conditions, GatewayConditionCertificateIssuanceAllowed)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, GatewayReasonBlockedByCAA, c.Reason)
+	assert.Contains(t, c.Message, "www.example.com")
+	assert.Contains(t, c.Message, "pki.goog")
+
+	// The condition is removed when CAA records are no longer inspected.
+	reconciler.caa = nil
+	reconciler.reconcileCertificateIssuanceStatus(ctx, nil, gw, claimed)
+	assert.Nil(t, apimeta.FindStatusCondition(gw.Status.Conditions, GatewayConditionCertificateIssuanceAllowed))
+}
@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+const (
+	// clientCACertificateLabel marks the downstream ClientTrafficPolicies and
+	// ConfigMaps that program client certificate validation on a listener.
+	clientCACertificateLabel = "networking.datumapis.com/client-ca-certificate"
+
+	// clientCACertificateKey is the key of the PEM encoded CA bundle in the
+	// ConfigMaps and Secrets referenced by caCertificateRefs.
+	clientCACertificateKey = "ca.crt"
+)
+
+// listenerClientValidation is the client certificate validation of an HTTPS
+// listener.
+type listenerClientValidation struct {
+	validation *gatewayv1.FrontendTLSValidation
+	// caBundle is the CA bundle of the referenced object, set when it is
+	// usable.
+	caBundle []byte
+	// reason and message explain why the CA certificate can't be used.
+	reason  gatewayv1.ListenerConditionReason
+	message string
+}
+
+func (v listenerClientValidation) valid() bool {
+	return v.reason == ""
+}
+
+// listenerFrontendValidation returns the client certificate validation that
+// applies to an HTTPS listener. Settings for the port of the listener take
+// precedence over the default settings of the gateway.
+func listenerFrontendValidation(gateway *gatewayv1.Gateway, l gatewayv1.Listener) *gatewayv1.FrontendTLSValidation {
+	if l.Protocol != gatewayv1.HTTPSProtocolType || gateway.Spec.TLS == nil || gateway.Spec.TLS.Frontend == nil {
+		return nil
+	}
+	frontend := gateway.Spec.TLS.Frontend
+	for _, perPort := range frontend.PerPort {
+		if perPort.Port == l.Port {
+			return perPort.TLS.Validation
+		}
+	}
+	return frontend.Default.Validation
+}
+
+// listenerClientValidationName returns the name of the downstream
+// ClientTrafficPolicy and CA bundle ConfigMap of a listener.
+func listenerClientValidationName(gatewayName string, listenerName gatewayv1.SectionName) string {
+	return resourcename.GetValidDNS1123Name(fmt.Sprintf("%s-%s-client-ca", gatewayName, listenerName))
+}
+
+// evaluateListenerClientValidation loads and validates the CA certificates
+// referenced by the client certificate validation of each HTTPS listener.
+func (r *GatewayReconciler) evaluateListenerClientValidation(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+) (map[gatewayv1.SectionName]listenerClientValidation, error) {
+	logger := log.FromContext(ctx)
+	validations := map[gatewayv1.SectionName]listenerClientValidation{}
+
+	for _, l := range upstreamGateway.Spec.Listeners {
+		validation := listenerFrontendValidation(upstreamGateway, l)
+		if validation == nil || len(validation.CACertificateRefs) == 0 {
+			continue
+		}
+
+		v := listenerClientValidation{validation: validation}
+		ref := validation.CACertificateRefs[0]
+		caBundle, err := getClientCABundle(ctx, upstreamClient, upstreamGateway.Namespace, ref)
+		switch {
+		case err != nil:
+			return nil, err
+		case ref.Group != "" || (ref.Kind != "ConfigMap" && ref.Kind != "Secret"):
+			v.reason = gatewayv1.ListenerReasonInvalidCACertificateKind
+			v.message = fmt.Sprintf("CA certificates can only be referenced from a ConfigMap or Secret, not a %s.", ref.Kind)
+		case caBundle == nil:
+			v.reason = gatewayv1.ListenerReasonInvalidCACertificateRef
+			v.message = fmt.Sprintf("The %s %q with the CA certificates for client certificate validation was not found.", ref.Kind, ref.Name)
+		case !validCABundle(caBundle):
+			v.reason = gatewayv1.ListenerReasonInvalidCACertificateRef
+			v.message = fmt.Sprintf("The %s %q does not contain PEM encoded CA certificates in the %q key.", ref.Kind, ref.Name, clientCACertificateKey)
+		default:
+			v.caBundle = caBundle
+		}
+		if !v.valid() {
+			logger.Info("listener client certificate validation is not usable", "listener", l.Name, "reason", v.reason, "message", v.message)
+		}
+		validations[l.Name] = v
+	}
+
+	return validations, nil
+}
+
+// getClientCABundle returns the CA bundle of the object referenced by a
+// caCertificateRef, or nil if the object or its CA bundle does not exist.
+func getClientCABundle(ctx context.Context, upstreamClient client.Client, namespace string, ref gatewayv1.ObjectReference) ([]byte, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: string(ref.Name)}
+
+	var caBundle []byte
+	var err error
+	switch {
+	case ref.Group != "":
+		return nil, nil
+	case ref.Kind == "ConfigMap":
+		var configMap corev1.ConfigMap
+		if err = upstreamClient.Get(ctx, key, &configMap); err == nil {
+			if v, ok := configMap.Data[clientCACertificateKey]; ok {
+				caBundle = []byte(v)
+			}
+		}
+	case ref.Kind == "Secret":
+		var secret corev1.Secret
+		if err = upstreamClient.Get(ctx, key, &secret); err == nil {
+			caBundle = secret.Data[clientCACertificateKey]
+		}
+	default:
+		return nil, nil
+	}
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get CA certificate %s %s: %w", ref.Kind, ref.Name, err)
+	}
+	return caBundle, nil
+}
+
+// validCABundle reports whether data contains at least one PEM encoded
+// certificate, and nothing that isn't parsable as one.
+func validCABundle(data []byte) bool {
+	found := false
+	rest := bytes.TrimSpace(data)
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil || block.Type != "CERTIFICATE" {
+			return false
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return false
+		}
+		found = true
+		rest = bytes.TrimSpace(rest)
+	}
+	return found
+}
+
+// ensureDownstreamClientValidation programs the client certificate validation
+// of each listener as a ClientTrafficPolicy attached to the listener on its
+// downstream Gateway shard, with a copy of the CA bundle in a ConfigMap. The
+// TLS policy and HTTP/3 settings of the gateway are included, as the policy
+// attached to a listener takes precedence over the policy attached to the
+// gateway.
+func (r *GatewayReconciler) ensureDownstreamClientValidation(
+	ctx context.Context,
+	upstreamGateway *gatewayv1.Gateway,
+	shardGateways []*gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	validations map[gatewayv1.SectionName]listenerClientValidation,
+	export *manifestExport,
+) (result Result) {
+	logger := log.FromContext(ctx)
+	downstreamClient := downstreamStrategy.GetClient()
+	desiredNames := map[string]bool{}
+
+	var namespace string
+	for _, shardGateway := range shardGateways {
+		namespace = shardGateway.Namespace
+
+		for _, l := range shardGateway.Spec.Listeners {
+			v, ok := validations[l.Name]
+			if !ok || !v.valid() {
+				continue
+			}
+
+			name := listenerClientValidationName(upstreamGateway.Name, l.Name)
+			desiredNames[name] = true
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: shardGateway.Namespace, Name: name},
+			}
+			if _, err := controllerutil.CreateOrUpdate(ctx, downstreamClient, configMap, func() error {
+				if err := downstreamStrategy.SetControllerReference(ctx, upstreamGateway, configMap); err != nil {
+					return fmt.Errorf("failed to set controller reference on CA certificate ConfigMap: %w", err)
+				}
+				metav1.SetMetaDataLabel(&configMap.ObjectMeta, clientCACertificateLabel, "true")
+				configMap.Data = map[string]string{clientCACertificateKey: string(v.caBundle)}
+				return nil
+			}); err != nil {
+				result.Err = fmt.Errorf("failed ensuring CA certificate ConfigMap %s: %w", name, err)
+				return result
+			}
+			export.add(configMap)
+
+			mode := envoygatewayv1alpha1.ClientValidationRequireAndVerify
+			if v.validation.Mode == gatewayv1.AllowInsecureFallback {
+				mode = envoygatewayv1alpha1.ClientValidationRequest
+			}
+
+			policy := &envoygatewayv1alpha1.ClientTrafficPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: shardGateway.Namespace, Name: name},
+			}
+			opResult, err := controllerutil.CreateOrUpdate(ctx, downstreamClient, policy, func() error {
+				if err := downstreamStrategy.SetControllerReference(ctx, upstreamGateway, policy); err != nil {
+					return fmt.Errorf("failed to set controller reference on client traffic policy: %w", err)
+				}
+				metav1.SetMetaDataLabel(&policy.ObjectMeta, clientCACertificateLabel, "true")
+				policy.Spec.TargetRefs = []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Group: gatewayv1.GroupName,
+							Kind:  KindGateway,
+							Name:  gatewayv1.ObjectName(shardGateway.Name),
+						},
+						SectionName: ptr.To(l.Name),
+					},
+				}
+				policy.Spec.TLS = &envoygatewayv1alpha1.ClientTLSSettings{
+					TLSSettings: r.clientTLSSettings(ctx, upstreamGateway),
+					ClientValidation: &envoygatewayv1alpha1.ClientValidationContext{
+						Mode: ptr.To(mode),
+						CACertificateRefs: []gatewayv1.SecretObjectReference{
+							{
+								Group: ptr.To(gatewayv1.Group("")),
+								Kind:  ptr.To(gatewayv1.Kind("ConfigMap")),
+								Name:  gatewayv1.ObjectName(name),
+							},
+						},
+					},
+				}
+				policy.Spec.HTTP3 = r.clientHTTP3Settings(upstreamGateway)
+				return nil
+			})
+			if err != nil {
+				result.Err = fmt.Errorf("failed ensuring client certificate validation policy %s: %w", name, err)
+				return result
+			}
+			export.add(policy)
+			logger.Info("downstream client certificate validation policy processed", "listener", l.Name, "operation_result", opResult)
+		}
+	}
+
+	if namespace == "" {
+		return result
+	}
+
+	// Every policy has a CA bundle ConfigMap of the same name, so the
+	// ConfigMaps are enough to find the policies that are no longer desired.
+	var configMaps corev1.ConfigMapList
+	if err := downstreamClient.List(ctx, &configMaps,
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			clientCACertificateLabel:                     "true",
+			downstreamclient.UpstreamOwnerKindLabel:      KindGateway,
+			downstreamclient.UpstreamOwnerNameLabel:      upstreamGateway.Name,
+			downstreamclient.UpstreamOwnerNamespaceLabel: upstreamGateway.Namespace,
+		},
+	); err != nil {
+		result.Err = fmt.Errorf("failed listing CA certificate ConfigMaps: %w", err)
+		return result
+	}
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		if desiredNames[configMap.Name] {
+			continue
+		}
+		policy := &envoygatewayv1alpha1.ClientTrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: configMap.Namespace, Name: configMap.Name},
+		}
+		if err := downstreamClient.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+			result.Err = fmt.Errorf("failed deleting client certificate validation policy %s: %w", policy.Name, err)
+			return result
+		}
+		if err := downstreamClient.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+			result.Err = fmt.Errorf("failed deleting CA certificate ConfigMap %s: %w", configMap.Name, err)
+			return result
+		}
+		logger.Info("deleted client certificate validation policy", jsonKeyName, configMap.Name)
+	}
+
+	return result
+}
+
+// gatewayReferencesClientCA reports whether the client certificate validation
+// of the gateway references an object.
+func gatewayReferencesClientCA(gateway *gatewayv1.Gateway, kind gatewayv1.Kind, name string) bool {
+	if gateway.Spec.TLS == nil || gateway.Spec.TLS.Frontend == nil {
+		return false
+	}
+	frontend := gateway.Spec.TLS.Frontend
+	validations := []*gatewayv1.FrontendTLSValidation{frontend.Default.Validation}
+	for _, perPort := range frontend.PerPort {
+		validations = append(validations, perPort.TLS.Validation)
+	}
+	for _, validation := range validations {
+		if validation == nil {
+			continue
+		}
+		for _, ref := range validation.CACertificateRefs {
+			if ref.Group == "" && ref.Kind == kind && string(ref.Name) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// listGatewaysForConfigMapFunc enqueues the Gateways whose client certificate
+// validation references a ConfigMap.
+func (r *GatewayReconciler) listGatewaysForConfigMapFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		logger := log.FromContext(ctx)
+
+		var gatewayList gatewayv1.GatewayList
+		if err := cl.GetClient().List(ctx, &gatewayList, client.InNamespace(obj.GetNamespace())); err != nil {
+			logger.Error(err, "failed to list Gateways")
+			return nil
+		}
+
+		var requests []mcreconcile.Request
+		for i := range gatewayList.Items {
+			if gatewayReferencesClientCA(&gatewayList.Items[i], "ConfigMap", obj.GetName()) {
+				requests = append(requests, mcreconcile.Request{
+					ClusterName: clusterName,
+					Request: reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(&gatewayList.Items[i]),
+					},
+				})
+			}
+		}
+
+		return append(requests, r.listGatewaysForBackendCACertificate(ctx, clusterName, cl, "ConfigMap", obj)...)
+	})
+}
@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
+)
+
+func TestValidCABundle(t *testing.T) {
+	now := time.Now()
+	certPEM, keyPEM := generateTLSKeyPair(t, "ca.example.com", now.Add(-time.Hour), now.Add(time.Hour))
+	otherPEM, _ := generateTLSKeyPair(t, "other.example.com", now.Add(-time.Hour), now.Add(time.Hour))
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected bool
+	}{
+		{name: "single certificate", data: certPEM, expected: true},
+		{name: "certificate bundle", data: append(append(certPEM, '\n'), otherPEM...), expected: true},
+		{name: "empty", data: nil},
+		{name: "private key", data: keyPEM},
+		{name: "not PEM", data: []byte("not a certificate")},
+		{name: "trailing garbage", data: append(certPEM, []byte("garbage")...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validCABundle(tt.data))
+		})
+	}
+}
+
+func TestEnsureDownstreamGatewayClientValidation(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, discoveryv1.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+	require.NoError(t, cmv1.AddToScheme(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()},
+	}
+	downstreamNamespaceName := fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayClassName:            "test-suite",
+			DownstreamHostnameAccountingNamespace: "default",
+			TargetDomain:                          "test-suite.com",
+			IPFamilies: []networkingv1alpha.IPFamily{
+				networkingv1alpha.IPv4Protocol,
+			},
+		},
+	}
+
+	now := time.Now()
+	certPEM, keyPEM := generateTLSKeyPair(t, "a.example.com", now.Add(-time.Hour), now.Add(24*time.Hour))
+	caPEM, _ := generateTLSKeyPair(t, "client-ca.example.com", now.Add(-time.Hour), now.Add(24*time.Hour))
+
+	upstreamSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: upstreamNamespace.Name, Name: "custom-cert"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}
+	caConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: upstreamNamespace.Name, Name: "client-ca"},
+		Data:       map[string]string{clientCACertificateKey: string(caPEM)},
+	}
+	domain := newDomain(upstreamNamespace.Name, "a.example.com", func(d *networkingv1alpha.Domain) {
+		d.Spec.DomainName = "a.example.com"
+		apimeta.SetStatusCondition(&d.Status.Conditions, metav1.Condition{
+			Type:   networkingv1alpha.DomainConditionVerified,
+			Status: metav1.ConditionTrue,
+		})
+	})
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: gatewayv1.GatewayController("test")},
+	}
+	upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test-gw", func(g *gatewayv1.Gateway) {
+		g.Spec.Listeners = append(g.Spec.Listeners, gatewayv1.Listener{
+			Name:     "https-custom",
+			Protocol: gatewayv1.HTTPSProtocolType,
+			Port:     DefaultHTTPSPort,
+			Hostname: ptr.To(gatewayv1.Hostname("a.example.com")),
+			AllowedRoutes: &gatewayv1.AllowedRoutes{
+				Namespaces: &gatewayv1.RouteNamespaces{From: ptr.To(gatewayv1.NamespacesFromSame)},
+			},
+			TLS: &gatewayv1.ListenerTLSConfig{
+				Mode:            ptr.To(gatewayv1.TLSModeTerminate),
+				CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "custom-cert"}},
+			},
+		})
+		g.Spec.TLS = &gatewayv1.GatewayTLSConfig{
+			Frontend: &gatewayv1.FrontendTLSConfig{
+				Default: gatewayv1.TLSConfig{
+					Validation: &gatewayv1.FrontendTLSValidation{
+						CACertificateRefs: []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "client-ca"}},
+						Mode:              gatewayv1.AllowInsecureFallback,
+					},
+				},
+			},
+		}
+	})
+	for _, obj := range []client.Object{upstreamSecret, caConfigMap, domain, gatewayClass} {
+		obj.SetUID(uuid.NewUUID())
+		obj.SetCreationTimestamp(metav1.Now())
+	}
+
+	fakeUpstreamClient := withDomainIndexes(fake.NewClientBuilder().WithScheme(testScheme)).
+		WithObjects(upstreamGateway, upstreamNamespace, upstreamSecret, caConfigMap, domain, gatewayClass).
+		WithStatusSubresource(upstreamGateway, domain).
+		Build()
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithStatusSubresource(&gatewayv1.Gateway{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// The fake client does not set creation timestamps, which hostname
+			// claims rely on to be recognized across reconciles.
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				obj.SetCreationTimestamp(metav1.Now())
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	ctx := context.Background()
+	reconciler := &GatewayReconciler{
+		mgr:                    &fakeMockManager{cl: fakeUpstreamClient},
+		Config:                 testConfig,
+		DownstreamCluster:      &fakeCluster{cl: fakeDownstreamClient},
+		customCertificateRoots: trustedCertPool(t, certPEM),
+	}
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+
+	reconcileGateway := func() *gatewayv1.Gateway {
+		t.Helper()
+		require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(upstreamGateway), upstreamGateway))
+		reconciler.prepareUpstreamGateway(upstreamGateway)
+		result, downstreamGateway := reconciler.ensureDownstreamGateway(ctx, "test-suite", fakeUpstreamClient, upstreamGateway, downstreamStrategy)
+		require.NoError(t, result.Err)
+		_, err := result.Complete(ctx)
+		require.NoError(t, err)
+		require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(upstreamGateway), upstreamGateway))
+		return downstreamGateway
+	}
+
+	policyKey := client.ObjectKey{
+		Namespace: downstreamNamespaceName,
+		Name:      listenerClientValidationName(upstreamGateway.Name, "https-custom"),
+	}
+
+	// The CA bundle is copied downstream and referenced by a policy attached to
+	// the listener.
+	downstreamGateway := reconcileGateway()
+	require.NotNil(t, gatewayutil.GetListenerByName(downstreamGateway.Spec.Listeners, "https-custom"))
+
+	var downstreamConfigMap corev1.ConfigMap
+	require.NoError(t, fakeDownstreamClient.Get(ctx, policyKey, &downstreamConfigMap))
+	assert.Equal(t, string(caPEM), downstreamConfigMap.Data[clientCACertificateKey])
+
+	var policy envoygatewayv1alpha1.ClientTrafficPolicy
+	require.NoError(t, fakeDownstreamClient.Get(ctx, policyKey, &policy))
+	if assert.Len(t, policy.Spec.TargetRefs, 1) {
+		assert.Equal(t, gatewayv1.ObjectName(downstreamGateway.Name), policy.Spec.TargetRefs[0].Name)
+		assert.Equal(t, ptr.To(gatewayv1.SectionName("https-custom")), policy.Spec.TargetRefs[0].SectionName)
+	}
+	require.NotNil(t, policy.Spec.TLS)
+	require.NotNil(t, policy.Spec.TLS.ClientValidation)
+	assert.Equal(t, ptr.To(envoygatewayv1alpha1.ClientValidationRequest), policy.Spec.TLS.ClientValidation.Mode)
+	if assert.Len(t, policy.Spec.TLS.ClientValidation.CACertificateRefs, 1) {
+		assert.Equal(t, gatewayv1.ObjectName(policyKey.Name), policy.Spec.TLS.ClientValidation.CACertificateRefs[0].Name)
+	}
+
+	listenerStatus := func(name gatewayv1.SectionName) gatewayv1.ListenerStatus {
+		for _, ls := range upstreamGateway.Status.Listeners {
+			if ls.Name == name {
+				return ls
+			}
+		}
+		t.Fatalf("listener %q missing from status", name)
+		return gatewayv1.ListenerStatus{}
+	}
+	assert.True(t, apimeta.IsStatusConditionTrue(listenerStatus("https-custom").Conditions, string(gatewayv1.ListenerConditionResolvedRefs)))
+
+	// An unusable CA bundle withholds the listener rather than serving it
+	// without validating clients.
+	caConfigMap.Data[clientCACertificateKey] = "not a certificate"
+	require.NoError(t, fakeUpstreamClient.Update(ctx, caConfigMap))
+	downstreamGateway = reconcileGateway()
+	assert.Nil(t, gatewayutil.GetListenerByName(downstreamGateway.Spec.Listeners, "https-custom"))
+
+	conditions := listenerStatus("https-custom").Conditions
+	resolvedRefs := apimeta.FindStatusCondition(conditions, string(gatewayv1.ListenerConditionResolvedRefs))
+	require.NotNil(t, resolvedRefs)
+	assert.Equal(t, metav1.ConditionFalse, resolvedRefs.Status)
+	assert.Equal(t, string(gatewayv1.ListenerReasonInvalidCACertificateRef), resolvedRefs.Reason)
+	assert.Contains(t, resolvedRefs.Message, `"client-ca"`)
+	accepted := apimeta.FindStatusCondition(conditions, string(gatewayv1.ListenerConditionAccepted))
+	require.NotNil(t, accepted)
+	assert.Equal(t, string(gatewayv1.ListenerReasonNoValidCACertificate), accepted.Reason)
+
+	err := fakeDownstreamClient.Get(ctx, policyKey, &policy)
+	assert.True(t, apierrors.IsNotFound(err), "expected client validation policy to be deleted, got %v", err)
+	err = fakeDownstreamClient.Get(ctx, policyKey, &downstreamConfigMap)
+	assert.True(t, apierrors.IsNotFound(err), "expected CA bundle ConfigMap to be deleted, got %v", err)
+}
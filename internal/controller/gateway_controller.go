@@ -5,7 +5,10 @@ package controller
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"maps"
+	"net/netip"
 	"slices"
 	"strconv"
 	"strings"
@@ -30,12 +33,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
 	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
@@ -46,6 +52,8 @@ import (
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	"go.datum.net/network-services-operator/internal/config"
 	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/features"
+	dnsutil "go.datum.net/network-services-operator/internal/util/dns"
 	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 	"go.datum.net/network-services-operator/internal/util/resourcename"
 	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
@@ -64,6 +72,10 @@ const annotationReissuanceCount = "networking.datumapis.com/reissuance-count"
 
 const gatewayClassRequeueInterval = 30 * time.Second
 
+// gatewayStatusFieldManager is the field manager of the status writes of the
+// gateway controller.
+const gatewayStatusFieldManager = "network-services-operator/gateway"
+
 const KindGateway = "Gateway"
 const KindHTTPRoute = "HTTPRoute"
 const KindService = "Service"
@@ -74,7 +86,38 @@ type GatewayReconciler struct {
 	mgr    mcmanager.Manager
 	Config config.NetworkServicesOperator
 
+	// ReloadableConfig, when set, supplies the config.ReloadableFields that
+	// may change while the manager is running. The gateways are requeued
+	// when the fields they are programmed from are reloaded.
+	ReloadableConfig *config.Reloadable
+
 	DownstreamCluster cluster.Cluster
+
+	// DownstreamCircuitBreaker, when set, is the circuit breaker of
+	// DownstreamCluster, and pauses reconciliation of gateways while its API
+	// server is failing.
+	DownstreamCircuitBreaker *downstreamclient.CircuitBreaker
+
+	// UpstreamOutages, when set, defers status updates of gateways while the
+	// API server of their cluster is unreachable.
+	UpstreamOutages *UpstreamOutageTracker
+
+	// DownstreamSpecDrift, when set, delivers the downstream resources the
+	// downstream audit found with a modified spec, so that the gateways they
+	// belong to write their desired spec again.
+	DownstreamSpecDrift <-chan event.TypedGenericEvent[client.Object]
+
+	// caa checks the CAA records of listener hostnames. Nil when CAA records
+	// are not inspected.
+	caa *caaChecker
+
+	// dnsVerifier looks up the hostnames of gateways on public resolvers. Nil
+	// when DNS records are not verified.
+	dnsVerifier *dnsRecordVerifier
+
+	// customCertificateRoots are the roots certificates provided by users must
+	// chain to. Nil uses the system roots.
+	customCertificateRoots *x509.CertPool
 }
 
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
@@ -95,11 +138,17 @@ type GatewayReconciler struct {
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=backendtlspolicies/finalizers,verbs=update
 
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=clienttrafficpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=envoyproxies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=hostnameblocklists,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ipaddressclaims,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=work.karmada.io,resources=resourcebindings,verbs=get;list;watch
+
 func (r *GatewayReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx, "cluster", req.ClusterName, "namespace", req.Namespace, jsonKeyName, req.Name)
 	ctx = log.IntoContext(ctx, logger)
@@ -111,6 +160,9 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req mcreconcile.Reque
 		logger.Error(err, "failed to get cluster")
 		return ctrl.Result{}, err
 	}
+	// Outages are detected from the calls made to the upstream cluster only, so
+	// that failing downstream calls are not taken for an upstream outage.
+	cl, upstreamCalls := recordUpstreamCalls(cl)
 
 	logger.Info("got cluster, fetching gateway")
 
@@ -140,11 +192,15 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req mcreconcile.Reque
 		return ctrl.Result{RequeueAfter: gatewayClassRequeueInterval}, nil
 	}
 
-	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), cl.GetClient(), r.DownstreamCluster.GetClient())
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), cl.GetClient(), r.DownstreamCluster.GetClient(), downstreamclient.WithControllerName("gateway"))
 
 	if !gateway.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(&gateway, gatewayControllerFinalizer) {
+			// Downstream resources of deleted gateways must be removed even when
+			// the downstream cluster is degraded, so they stop serving traffic.
+			ctx := downstreamclient.WithCriticalWrites(ctx)
 			if result := r.finalizeGateway(ctx, string(req.ClusterName), cl.GetClient(), &gateway, downstreamStrategy); result.ShouldReturn() {
+				result.FieldManager = gatewayStatusFieldManager
 				return result.Complete(ctx)
 			}
 
@@ -157,6 +213,15 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req mcreconcile.Reque
 		return ctrl.Result{}, nil
 	}
 
+	terminating, err := upstreamNamespaceTerminating(ctx, cl.GetClient(), gateway.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if terminating {
+		logger.Info("namespace is terminating, waiting for gateway deletion")
+		return ctrl.Result{}, nil
+	}
+
 	if r.prepareUpstreamGateway(&gateway) {
 		logger.Info("preparing upstream gateway (adding finalizer/defaults)")
 		if err := cl.GetClient().Update(ctx, &gateway); err != nil {
@@ -170,12 +235,14 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req mcreconcile.Reque
 	logger.Info("reconciling gateway")
 	defer logger.Info("reconcile complete")
 
-	result, _ := r.ensureDownstreamGateway(ctx, string(req.ClusterName), cl.GetClient(), &gateway, downstreamStrategy)
-	if result.ShouldReturn() {
-		return result.Complete(ctx)
+	degradedResult := r.reconcileDownstreamDegradedStatus(cl.GetClient(), &gateway)
+	if degradedResult.ShouldReturn() {
+		return r.completeWithUpstreamOutage(ctx, string(req.ClusterName), cl.GetClient(), upstreamCalls, &gateway, degradedResult)
 	}
 
-	return result.Complete(ctx)
+	result, _ := r.ensureDownstreamGateway(ctx, string(req.ClusterName), cl.GetClient(), &gateway, downstreamStrategy)
+	result = result.Merge(degradedResult)
+	return r.completeWithUpstreamOutage(ctx, string(req.ClusterName), cl.GetClient(), upstreamCalls, &gateway, result)
 }
 
 // prepareUpstreamGateway adds a finalizer to the upstream gateway and ensures
@@ -224,13 +291,37 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 	}
 	upstreamGatewayClassControllerName := string(upstreamGatewayClass.Spec.ControllerName)
 
+	// Named addresses of the gateway are the addresses allocated to its
+	// IPAddressClaims. The gateway isn't programmed until its claims are
+	// allocated, so that it's never reachable at an address it doesn't keep.
+	staticAddresses, allocated, err := resolveGatewayStaticAddresses(ctx, upstreamClient, upstreamGateway)
+	if err != nil {
+		result.Err = err
+		return result, nil
+	}
+	if !allocated {
+		log.FromContext(ctx).Info("waiting for ip address claims of gateway to be allocated")
+		result.StopProcessing = true
+		return result, nil
+	}
+
+	ipv4Enabled, ipv6Enabled := r.gatewayIPFamilies(staticAddresses)
+
 	var targetDomainHostnames []string
-	// Keep existing addresses in the status if present.
-	if len(upstreamGateway.Status.Addresses) > 0 {
+	// Keep existing addresses in the status if present, dropping the address
+	// specific to an IP family that is no longer enabled.
+	if slices.ContainsFunc(upstreamGateway.Status.Addresses, isHostnameStatusAddress) {
 		for _, addr := range upstreamGateway.Status.Addresses {
-			if ptr.Deref(addr.Type, "") == gatewayv1.HostnameAddressType {
-				targetDomainHostnames = append(targetDomainHostnames, addr.Value)
+			if !isHostnameStatusAddress(addr) {
+				continue
+			}
+			if strings.HasPrefix(addr.Value, "v4.") && !ipv4Enabled {
+				continue
 			}
+			if strings.HasPrefix(addr.Value, "v6.") && !ipv6Enabled {
+				continue
+			}
+			targetDomainHostnames = append(targetDomainHostnames, addr.Value)
 		}
 	} else {
 		gatewayDNSAddress := r.gatewayCanonicalHostname(upstreamGateway)
@@ -240,11 +331,11 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 		// listeners WILL NOT be added to the addresses list in the gateway status.
 		targetDomainHostnames = append(targetDomainHostnames, gatewayDNSAddress)
 
-		if r.Config.Gateway.IPv4Enabled() {
+		if ipv4Enabled {
 			targetDomainHostnames = append(targetDomainHostnames, fmt.Sprintf("v4.%s", gatewayDNSAddress))
 		}
 
-		if r.Config.Gateway.IPv6Enabled() {
+		if ipv6Enabled {
 			targetDomainHostnames = append(targetDomainHostnames, fmt.Sprintf("v6.%s", gatewayDNSAddress))
 		}
 	}
@@ -265,12 +356,19 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 		return result, nil
 	}
 
+	blockedHostnames, err := r.blockedGatewayHostnames(ctx, upstreamGateway)
+	if err != nil {
+		result.Err = err
+		return result, nil
+	}
+
 	verifiedHostnames, claimedHostnames, notClaimedHostnames, err := r.ensureHostnamesClaimed(
 		ctx,
 		upstreamClusterName,
 		upstreamClient,
 		upstreamGateway,
 		downstreamGateway,
+		blockedHostnames,
 	)
 	if err != nil {
 		result.Err = err
@@ -292,6 +390,34 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 		claimedHostnames,
 	)
 
+	// Certificates provided by users are gated the same way.
+	customCerts, err := r.evaluateCustomListenerCertificates(ctx, upstreamClient, upstreamGateway, claimedHostnames)
+	if err != nil {
+		result.Err = err
+		return result, nil
+	}
+	for listenerName, cert := range customCerts {
+		listenerCertHealth[listenerName] = cert.status
+	}
+
+	clientValidations, err := r.evaluateListenerClientValidation(ctx, upstreamClient, upstreamGateway)
+	if err != nil {
+		result.Err = err
+		return result, nil
+	}
+
+	listenerValidation := gatewayListenerValidation{
+		verifiedHostnames:   verifiedHostnames,
+		notClaimedHostnames: notClaimedHostnames,
+		blockedHostnames:    blockedHostnames,
+		certHealth:          listenerCertHealth,
+		clientValidations:   clientValidations,
+	}
+	invalidListeners := listenerValidation.invalidListeners(upstreamGateway)
+	if len(invalidListeners) > 0 && r.Config.Gateway.InvalidListenerPolicy == config.InvalidListenerPolicyRejectGateway {
+		return r.rejectGateway(ctx, upstreamClient, upstreamGateway, listenerValidation, invalidListeners), downstreamGateway
+	}
+
 	desiredDownstreamGateway := r.getDesiredDownstreamGateway(
 		ctx,
 		upstreamGateway,
@@ -299,6 +425,36 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 		listenerCertHealth,
 	)
 
+	// Static addresses are requested from the downstream gateway as is, and
+	// are permitted by the Gateway webhook.
+	for _, ip := range staticAddresses {
+		desiredDownstreamGateway.Spec.Addresses = append(desiredDownstreamGateway.Spec.Addresses, gatewayv1.GatewaySpecAddress{
+			Type:  ptr.To(gatewayv1.IPAddressType),
+			Value: ip.String(),
+		})
+	}
+
+	// Leave out a listener whose client certificate validation can't be
+	// programmed, rather than serving it without validating clients.
+	desiredDownstreamGateway.Spec.Listeners = slices.DeleteFunc(desiredDownstreamGateway.Spec.Listeners, func(l gatewayv1.Listener) bool {
+		v, ok := clientValidations[l.Name]
+		return ok && !v.valid()
+	})
+
+	shards := shardDownstreamGatewayListeners(r.Config.Gateway.ListenerSharding, downstreamGateway.Name, desiredDownstreamGateway.Spec.Listeners)
+	desiredDownstreamGateway.Spec.Listeners = shards[0].listeners
+
+	if err := r.ensureDownstreamDataPlaneEnvoyProxy(ctx, upstreamGateway, downstreamGateway, downstreamStrategy); err != nil {
+		result.Err = err
+		return result, nil
+	}
+
+	desiredDownstreamGateway.Annotations, err = desiredDownstreamGatewayAnnotations(desiredDownstreamGateway.Annotations, downstreamGateway, desiredDownstreamGateway.Spec)
+	if err != nil {
+		result.Err = err
+		return result, nil
+	}
+
 	if downstreamGateway.CreationTimestamp.IsZero() {
 		if err := downstreamStrategy.SetControllerReference(ctx, upstreamGateway, downstreamGateway); err != nil {
 			result.Err = fmt.Errorf("failed to set controller reference on downstream gateway: %w", err)
@@ -312,6 +468,8 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 			result.Err = fmt.Errorf("failed creating downstream gateway: %w", err)
 			return result, nil
 		}
+		clusterEventRecorder(ctx, r.mgr, upstreamClusterName).Eventf(upstreamGateway, nil, corev1.EventTypeNormal,
+			"DownstreamGatewayCreated", "Create", "Created downstream gateway %s/%s", downstreamGateway.Namespace, downstreamGateway.Name)
 	} else {
 		if !equality.Semantic.DeepEqual(downstreamGateway.Annotations, desiredDownstreamGateway.Annotations) ||
 			!equality.Semantic.DeepEqual(downstreamGateway.Spec, desiredDownstreamGateway.Spec) {
@@ -323,38 +481,143 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 			}
 		}
 	}
+	if err := downstreamclient.RecordObservedGeneration(ctx, downstreamClient, downstreamGateway); err != nil {
+		result.Err = err
+		return result, nil
+	}
 
-	certResult := r.ensureListenerCertificates(
+	shardGateways, err := r.ensureDownstreamGatewayShards(
 		ctx,
 		upstreamGateway,
 		downstreamGateway,
-		downstreamClient,
+		desiredDownstreamGateway,
+		shards,
 		downstreamStrategy,
-		claimedHostnames,
 	)
-	if certResult.ShouldReturn() {
-		return certResult.Merge(result), nil
+	if err != nil {
+		result.Err = err
+		return result, nil
+	}
+	downstreamGatewayRollup := rollupDownstreamGatewayShards(shardGateways)
+
+	// The downstream objects applied below are collected for the manifest
+	// export.
+	export := &manifestExport{}
+	for _, shardGateway := range shardGateways {
+		export.add(shardGateway)
 	}
 
-	dnsResult := r.ensureDownstreamGatewayDNSEndpoints(
+	tlsPolicyResult := r.ensureDownstreamClientTrafficPolicy(
 		ctx,
+		upstreamGateway,
 		downstreamGateway,
+		shardGateways,
 		downstreamStrategy,
-		targetDomainHostnames,
+		export,
 	)
-	if dnsResult.Err != nil || dnsResult.StopProcessing {
-		return dnsResult.Merge(result), nil
+	if tlsPolicyResult.ShouldReturn() {
+		return tlsPolicyResult.Merge(result), nil
 	}
-	// Carry RequeueAfter from dnsResult (e.g. IPs not yet available) without
-	// blocking downstream HTTPRoute creation or gateway status updates.
-	result = result.Merge(dnsResult)
 
-	hostnameStatuses, dnsProgramResult := r.ensureDNSRecordSets(
+	clientValidationResult := r.ensureDownstreamClientValidation(
+		ctx,
+		upstreamGateway,
+		shardGateways,
+		downstreamStrategy,
+		clientValidations,
+		export,
+	)
+	if clientValidationResult.ShouldReturn() {
+		return clientValidationResult.Merge(result), nil
+	}
+
+	result = result.Merge(r.reconcileCertificateIssuanceStatus(ctx, upstreamClient, upstreamGateway, claimedHostnames))
+
+	customCertResult := r.ensureCustomCertificateSecrets(
+		ctx,
+		upstreamGateway,
+		downstreamStrategy,
+		customCerts,
+	)
+	if customCertResult.ShouldReturn() {
+		return customCertResult.Merge(result), nil
+	}
+
+	certResult := r.ensureListenerCertificates(
 		ctx,
-		upstreamClient,
 		upstreamGateway,
+		downstreamGateway,
+		downstreamClient,
+		downstreamStrategy,
 		claimedHostnames,
 	)
+	if certResult.Err != nil || certResult.StopProcessing {
+		return certResult.Merge(result), nil
+	}
+	// Carry RequeueAfter from certResult (e.g. waiting to re-issue a failed
+	// certificate) so the listener status still reports the failure.
+	result = result.Merge(certResult)
+
+	dnsRecords := r.gatewayDNSRecords(&upstreamGatewayClass, upstreamGateway)
+	dnsAddresses := r.downstreamGatewayDNSAddresses(downstreamGatewayRollup)
+
+	// Gateways propagated to multiple member clusters only publish the
+	// addresses of their healthy member clusters.
+	dnsTargets, err := r.downstreamGatewayDNSTargets(ctx, downstreamGateway, downstreamStrategy, dnsAddresses)
+	if err != nil {
+		result.Err = err
+		return result, nil
+	}
+	if len(dnsTargets) > 0 {
+		dnsAddresses = failoverDNSAddresses(dnsAddresses, dnsTargets)
+		dnsRecords.failover = &dnsAddresses
+	}
+	result = result.Merge(r.reconcileDNSTargetsStatus(upstreamClient, upstreamGateway, dnsTargets))
+
+	var (
+		hostnameStatuses []networkingv1alpha.HostnameStatus
+		dnsProgramResult Result
+	)
+	if dnsRecords.internal() {
+		// Internal gateways are only resolvable through their internal
+		// DNSZone, so the public records of their canonical hostnames are
+		// removed.
+		if err := deleteDownstreamGatewayDNSEndpoint(ctx, downstreamGatewayRollup, downstreamStrategy); err != nil {
+			result.Err = err
+			return result, nil
+		}
+
+		hostnameStatuses, dnsProgramResult = r.ensureInternalDNSRecordSets(
+			ctx,
+			upstreamClient,
+			upstreamGateway,
+			claimedHostnames,
+			dnsAddresses,
+			dnsRecords,
+		)
+	} else {
+		dnsResult := r.ensureDownstreamGatewayDNSEndpoints(
+			ctx,
+			downstreamGatewayRollup,
+			downstreamStrategy,
+			targetDomainHostnames,
+			dnsRecords,
+		)
+		if dnsResult.Err != nil || dnsResult.StopProcessing {
+			return dnsResult.Merge(result), nil
+		}
+		// Carry RequeueAfter from dnsResult (e.g. IPs not yet available) without
+		// blocking downstream HTTPRoute creation or gateway status updates.
+		result = result.Merge(dnsResult)
+
+		hostnameStatuses, dnsProgramResult = r.ensureDNSRecordSets(
+			ctx,
+			upstreamClient,
+			upstreamGateway,
+			claimedHostnames,
+			dnsRecords,
+		)
+	}
 	if dnsProgramResult.ShouldReturn() {
 		return dnsProgramResult.Merge(result), nil
 	}
@@ -365,11 +628,26 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 		result = result.Merge(dnsStatusResult)
 	}
 
+	// Public resolvers don't answer the records of internal gateways.
+	resolvableHostnames, resolvableHostnameStatuses := targetDomainHostnames, hostnameStatuses
+	if dnsRecords.internal() {
+		resolvableHostnames, resolvableHostnameStatuses = nil, nil
+	}
+	result = result.Merge(r.reconcileDNSResolvableStatus(
+		ctx,
+		upstreamClient,
+		upstreamGateway,
+		dnsAddresses,
+		resolvableHostnames,
+		resolvableHostnameStatuses,
+	))
+
 	gatewayStatusResult := r.reconcileGatewayStatus(
 		ctx,
 		upstreamClient,
 		upstreamGateway,
-		downstreamGateway,
+		downstreamGatewayRollup,
+		invalidListeners,
 	)
 	if gatewayStatusResult.Err != nil || gatewayStatusResult.StopProcessing {
 		return gatewayStatusResult.Merge(result), nil
@@ -387,7 +665,11 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 		downstreamStrategy,
 		verifiedHostnames,
 		notClaimedHostnames,
+		blockedHostnames,
 		listenerCertHealth,
+		clientValidations,
+		downstreamListenerConditions(shardGateways),
+		export,
 	)
 
 	// When a listener is only waiting on a certificate to be issued, check back
@@ -399,6 +681,13 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 		}
 	}
 
+	requestIDConfig, requestIDErr := gatewayutil.GetRequestIDConfig(upstreamGateway)
+	result = result.Merge(r.reconcileRequestIDStatus(upstreamClient, upstreamGateway, requestIDConfig, requestIDErr))
+	result = result.Merge(r.reconcileHTTP3Status(upstreamClient, upstreamGateway))
+	result = result.Merge(r.reconcileDataPlaneSizeStatus(upstreamClient, upstreamGateway))
+	result = result.Merge(r.reconcilePausedStatus(upstreamClient, upstreamGateway))
+	result = result.Merge(r.reconcileDNSRecordsStatus(upstreamClient, upstreamGateway))
+
 	addresses := make([]gatewayv1.GatewayStatusAddress, 0, len(targetDomainHostnames))
 
 	for _, hostname := range targetDomainHostnames {
@@ -408,11 +697,20 @@ func (r *GatewayReconciler) ensureDownstreamGateway(
 		})
 	}
 
+	addresses = append(addresses, boundStaticGatewayAddresses(staticAddresses, downstreamGatewayRollup)...)
+
 	if !equality.Semantic.DeepEqual(upstreamGateway.Status.Addresses, addresses) {
 		upstreamGateway.Status.Addresses = addresses
 		result.AddStatusUpdate(upstreamClient, upstreamGateway)
 	}
 
+	// The export reflects the downstream objects as applied above, rather than
+	// what is observed in the cache. It is left as is until all routes could
+	// be processed.
+	if httpRouteResult.Err == nil {
+		result = result.Merge(r.reconcileManifestExport(ctx, upstreamClient, upstreamGateway, export))
+	}
+
 	return httpRouteResult.Merge(result), downstreamGateway
 }
 
@@ -424,8 +722,25 @@ const listenerCertExpiryMargin = 1 * time.Minute
 // A listener with no entry is never gated: its certificate is managed elsewhere
 // (for example the shared platform certificate) and a customer's certificate
 // failure must never disable it.
+// ListenerConditionCertificateReady is set on listeners that request a
+// certificate, and mirrors the readiness of the downstream certificate.
+const ListenerConditionCertificateReady = "CertificateReady"
+
+const (
+	ListenerReasonCertificateReady   = "Ready"
+	ListenerReasonCertificateIssuing = "Issuing"
+	ListenerReasonCertificateFailed  = "Failed"
+	ListenerReasonCertificateExpired = "Expired"
+)
+
 type listenerCertStatus struct {
 	healthy bool
+	// certificateReason is the reason of the listener CertificateReady
+	// condition.
+	certificateReason string
+	// issuerMessage is the message cert-manager reports for a certificate
+	// that failed to be issued.
+	issuerMessage string
 	// reason is the listener status reason shown when the certificate is unusable.
 	reason gatewayv1.ListenerConditionReason
 	// message is a plain-language explanation shown to the customer.
@@ -557,45 +872,55 @@ func (r *GatewayReconciler) listenerCertHealth(
 	if err := downstreamClient.Get(ctx, client.ObjectKey{Namespace: downstreamNamespace, Name: certName}, &cert); err != nil {
 		if apierrors.IsNotFound(err) {
 			return listenerCertStatus{
-				reason:     gatewayv1.ListenerReasonInvalidCertificateRef,
-				message:    certIssuanceFailingMessage(hostname),
-				pending:    true,
-				secretName: secretName,
+				certificateReason: ListenerReasonCertificateIssuing,
+				reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+				message:           certIssuanceFailingMessage(hostname),
+				pending:           true,
+				secretName:        secretName,
 			}
 		}
 		// On a read error, hold the listener back but allow it to recover later.
 		logger.Error(err, "failed to get listener Certificate", "certificate", certName)
 		return listenerCertStatus{
-			reason:     gatewayv1.ListenerReasonInvalidCertificateRef,
-			message:    certIssuanceFailingMessage(hostname),
-			pending:    true,
-			secretName: secretName,
+			certificateReason: ListenerReasonCertificateIssuing,
+			reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+			message:           certIssuanceFailingMessage(hostname),
+			pending:           true,
+			secretName:        secretName,
 		}
 	}
 
 	if !certIsReady(&cert) {
-		return listenerCertStatus{
-			reason:     gatewayv1.ListenerReasonInvalidCertificateRef,
-			message:    certIssuanceFailingMessage(hostname),
-			pending:    true,
-			secretName: secretName,
+		status := listenerCertStatus{
+			certificateReason: ListenerReasonCertificateIssuing,
+			reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+			message:           certIssuanceFailingMessage(hostname),
+			pending:           true,
+			secretName:        secretName,
 		}
+		if cert.Status.LastFailureTime != nil {
+			status.certificateReason = ListenerReasonCertificateFailed
+			status.issuerMessage = certIssuingConditionMessage(&cert)
+		}
+		return status
 	}
 
 	// The certificate must be within its valid dates.
 	if cert.Status.NotBefore != nil && cert.Status.NotBefore.After(now) {
 		return listenerCertStatus{
-			reason:     gatewayv1.ListenerReasonInvalidCertificateRef,
-			message:    certNotYetValidMessage(hostname),
-			secretName: secretName,
+			certificateReason: ListenerReasonCertificateIssuing,
+			reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+			message:           certNotYetValidMessage(hostname),
+			secretName:        secretName,
 		}
 	}
 	if cert.Status.NotAfter != nil && !cert.Status.NotAfter.After(now.Add(listenerCertExpiryMargin)) {
 		return listenerCertStatus{
-			reason:     gatewayv1.ListenerReasonInvalidCertificateRef,
-			message:    certExpiredMessage(hostname),
-			notAfter:   cert.Status.NotAfter,
-			secretName: secretName,
+			certificateReason: ListenerReasonCertificateExpired,
+			reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+			message:           certExpiredMessage(hostname),
+			notAfter:          cert.Status.NotAfter,
+			secretName:        secretName,
 		}
 	}
 
@@ -606,38 +931,80 @@ func (r *GatewayReconciler) listenerCertHealth(
 	if err := downstreamClient.Get(ctx, client.ObjectKey{Namespace: downstreamNamespace, Name: secretName}, &secret); err != nil {
 		if apierrors.IsNotFound(err) {
 			return listenerCertStatus{
-				reason:     gatewayv1.ListenerReasonInvalidCertificateRef,
-				message:    certMissingMessage(hostname),
-				pending:    true,
-				secretName: secretName,
+				certificateReason: ListenerReasonCertificateIssuing,
+				reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+				message:           certMissingMessage(hostname),
+				pending:           true,
+				secretName:        secretName,
 			}
 		}
 		logger.Error(err, "failed to get listener Secret", "secret", secretName)
 		return listenerCertStatus{
-			reason:     gatewayv1.ListenerReasonInvalidCertificateRef,
-			message:    certMissingMessage(hostname),
-			pending:    true,
-			secretName: secretName,
+			certificateReason: ListenerReasonCertificateIssuing,
+			reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+			message:           certMissingMessage(hostname),
+			pending:           true,
+			secretName:        secretName,
 		}
 	}
 
 	keyPair, err := tls.X509KeyPair(secret.Data["tls.crt"], secret.Data["tls.key"])
 	if err != nil {
 		return listenerCertStatus{
-			reason:     gatewayv1.ListenerReasonInvalidCertificateRef,
-			message:    certMissingMessage(hostname),
-			secretName: secretName,
+			certificateReason: ListenerReasonCertificateFailed,
+			reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+			message:           certMissingMessage(hostname),
+			secretName:        secretName,
 		}
 	}
 	if leaf := keyPair.Leaf; leaf != nil && !leaf.NotAfter.After(now) {
 		return listenerCertStatus{
-			reason:     gatewayv1.ListenerReasonInvalidCertificateRef,
-			message:    certExpiredMessage(hostname),
-			secretName: secretName,
+			certificateReason: ListenerReasonCertificateExpired,
+			reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+			message:           certExpiredMessage(hostname),
+			secretName:        secretName,
+		}
+	}
+
+	return listenerCertStatus{
+		healthy:           true,
+		certificateReason: ListenerReasonCertificateReady,
+		notAfter:          cert.Status.NotAfter,
+		secretName:        secretName,
+	}
+}
+
+// certIssuingConditionMessage returns the message of the condition cert-manager
+// reports the latest issuance failure of a Certificate on.
+func certIssuingConditionMessage(cert *cmv1.Certificate) string {
+	for _, conditionType := range []cmv1.CertificateConditionType{cmv1.CertificateConditionIssuing, cmv1.CertificateConditionReady} {
+		for _, c := range cert.Status.Conditions {
+			if c.Type == conditionType && c.Status == cmmeta.ConditionFalse && c.Message != "" {
+				return c.Message
+			}
 		}
 	}
+	return ""
+}
 
-	return listenerCertStatus{healthy: true, notAfter: cert.Status.NotAfter, secretName: secretName}
+// listenerCertificateReadyCondition returns the CertificateReady condition of
+// a listener that requests a certificate.
+func listenerCertificateReadyCondition(status listenerCertStatus, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               ListenerConditionCertificateReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             status.certificateReason,
+		Message:            "The TLS certificate of the listener is ready",
+		ObservedGeneration: generation,
+	}
+	if !status.healthy {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = status.message
+		if status.issuerMessage != "" {
+			condition.Message = fmt.Sprintf("%s Last issuance error: %s", status.message, status.issuerMessage)
+		}
+	}
+	return condition
 }
 
 // certIsReady reports whether a cert-manager Certificate has Ready=True.
@@ -718,7 +1085,20 @@ func (r *GatewayReconciler) getDesiredDownstreamGateway(
 
 		if l.Hostname != nil {
 			listenerCopy := l.DeepCopy()
-			if l.TLS != nil && l.TLS.Options[certificateIssuerTLSOption] != "" {
+			if hasCustomCertificate(l) {
+				// Secret name must match the copy of the user's Secret created by
+				// ensureCustomCertificateSecrets for this listener.
+				listenerCopy.TLS = &gatewayv1.ListenerTLSConfig{
+					Mode: ptr.To(gatewayv1.TLSModeTerminate),
+					CertificateRefs: []gatewayv1.SecretObjectReference{
+						{
+							Group: ptr.To(gatewayv1.Group("")),
+							Kind:  ptr.To(gatewayv1.Kind("Secret")),
+							Name:  gatewayv1.ObjectName(listenerCertificateSecretName(upstreamGateway.Name, l.Name)),
+						},
+					},
+				}
+			} else if l.TLS != nil && l.TLS.Options[certificateIssuerTLSOption] != "" {
 				delete(listenerCopy.TLS.Options, certificateIssuerTLSOption)
 
 				tlsMode := gatewayv1.TLSModeTerminate
@@ -753,14 +1133,120 @@ func (r *GatewayReconciler) getDesiredDownstreamGateway(
 		}
 	}
 
+	// Carry scheduling hints to the downstream gateway so that location aware
+	// infrastructure can act on them.
+	downstreamGateway.Annotations = gatewayutil.GetSchedulingHints(upstreamGateway).Annotations()
+
 	// TODO(jreese) get from "scheduler"
 	downstreamGateway.Spec.GatewayClassName = gatewayv1.ObjectName(r.Config.Gateway.DownstreamGatewayClassName)
 
 	downstreamGateway.Spec.Listeners = listeners
 
+	downstreamGateway.Spec.Infrastructure = r.downstreamGatewayInfrastructure(upstreamGateway)
+
 	return &downstreamGateway
 }
 
+// desiredDownstreamGatewayAnnotations returns the annotations of a downstream
+// Gateway, or gateway shard, with the hash of its desired spec. The generation
+// recorded on the current Gateway is kept, so that the annotations only differ
+// when the desired state does.
+func desiredDownstreamGatewayAnnotations(annotations map[string]string, current *gatewayv1.Gateway, spec gatewayv1.GatewaySpec) (map[string]string, error) {
+	hash, err := downstreamclient.DesiredHash(spec)
+	if err != nil {
+		return nil, err
+	}
+	annotations = maps.Clone(annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[downstreamclient.DesiredHashAnnotation] = hash
+	delete(annotations, downstreamclient.ObservedGenerationAnnotation)
+	if generation, ok := current.Annotations[downstreamclient.ObservedGenerationAnnotation]; ok {
+		annotations[downstreamclient.ObservedGenerationAnnotation] = generation
+	}
+	return annotations, nil
+}
+
+// staticGatewayAddresses returns the static IP addresses requested by the
+// gateway.
+func staticGatewayAddresses(gateway *gatewayv1.Gateway) []netip.Addr {
+	var addresses []netip.Addr
+	for _, address := range gateway.Spec.Addresses {
+		if ptr.Deref(address.Type, gatewayv1.IPAddressType) != gatewayv1.IPAddressType {
+			continue
+		}
+		if ip, err := netip.ParseAddr(address.Value); err == nil {
+			addresses = append(addresses, ip.Unmap())
+		}
+	}
+	return addresses
+}
+
+// resolveGatewayStaticAddresses returns the static IP addresses of a gateway,
+// resolving its named addresses to the addresses allocated to the
+// IPAddressClaims they name. It returns false while a claim has no address.
+func resolveGatewayStaticAddresses(ctx context.Context, cl client.Client, gateway *gatewayv1.Gateway) ([]netip.Addr, bool, error) {
+	addresses := staticGatewayAddresses(gateway)
+	for _, address := range gateway.Spec.Addresses {
+		if ptr.Deref(address.Type, gatewayv1.IPAddressType) != gatewayv1.NamedAddressType {
+			continue
+		}
+		var claim networkingv1alpha.IPAddressClaim
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: gateway.Namespace, Name: address.Value}, &claim); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, false, nil
+			}
+			return nil, false, fmt.Errorf("failed fetching ip address claim: %w", err)
+		}
+		ip, err := netip.ParseAddr(claim.Status.Address)
+		if err != nil {
+			return nil, false, nil
+		}
+		addresses = append(addresses, ip.Unmap())
+	}
+	return addresses, true, nil
+}
+
+// boundStaticGatewayAddresses returns the status addresses of the static
+// addresses of the upstream gateway that the downstream gateway is bound to.
+func boundStaticGatewayAddresses(staticAddresses []netip.Addr, downstreamGateway *gatewayv1.Gateway) []gatewayv1.GatewayStatusAddress {
+	var addresses []gatewayv1.GatewayStatusAddress
+	for _, ip := range staticAddresses {
+		if slices.ContainsFunc(downstreamGateway.Status.Addresses, func(addr gatewayv1.GatewayStatusAddress) bool {
+			statusIP, err := netip.ParseAddr(addr.Value)
+			return ptr.Deref(addr.Type, gatewayv1.IPAddressType) == gatewayv1.IPAddressType && err == nil && statusIP.Unmap() == ip
+		}) {
+			addresses = append(addresses, gatewayv1.GatewayStatusAddress{
+				Type:  ptr.To(gatewayv1.IPAddressType),
+				Value: ip.String(),
+			})
+		}
+	}
+	return addresses
+}
+
+// gatewayIPFamilies returns whether the gateway is reachable over IPv4 and
+// IPv6. A gateway with static addresses is only reachable over the families
+// of its addresses, and is otherwise given an address of each enabled family.
+func (r *GatewayReconciler) gatewayIPFamilies(staticAddresses []netip.Addr) (ipv4, ipv6 bool) {
+	if len(staticAddresses) == 0 {
+		return r.Config.Gateway.IPv4Enabled(), r.Config.Gateway.IPv6Enabled()
+	}
+	for _, ip := range staticAddresses {
+		if ip.Is4() {
+			ipv4 = true
+		} else {
+			ipv6 = true
+		}
+	}
+	return ipv4, ipv6
+}
+
+func isHostnameStatusAddress(addr gatewayv1.GatewayStatusAddress) bool {
+	return ptr.Deref(addr.Type, "") == gatewayv1.HostnameAddressType
+}
+
 // listenerCertificateSecretName returns the deterministic Secret name that a
 // Certificate resource will populate for a given gateway listener.
 func listenerCertificateSecretName(gatewayName string, listenerName gatewayv1.SectionName) string {
@@ -792,7 +1278,7 @@ func (r *GatewayReconciler) resolveAutoIssuer(gateway *gatewayv1.Gateway) string
 		if issuer == "" || issuer == autoIssuerSentinel {
 			continue
 		}
-		if mapped := r.Config.Gateway.ClusterIssuerMap[issuer]; mapped != "" {
+		if mapped := reloadableConfig(&r.Config, r.ReloadableConfig).Gateway.ClusterIssuerMap[issuer]; mapped != "" {
 			return mapped
 		}
 		return issuer
@@ -856,7 +1342,7 @@ func (r *GatewayReconciler) ensureListenerCertificates(
 		// resolution when the sentinel survives mapping unchanged, i.e. when
 		// the admin hasn't provided a translation.
 		clusterIssuerName := string(l.TLS.Options[certificateIssuerTLSOption])
-		if mapped := r.Config.Gateway.ClusterIssuerMap[clusterIssuerName]; mapped != "" {
+		if mapped := reloadableConfig(&r.Config, r.ReloadableConfig).Gateway.ClusterIssuerMap[clusterIssuerName]; mapped != "" {
 			clusterIssuerName = mapped
 		}
 		if clusterIssuerName == autoIssuerSentinel {
@@ -909,9 +1395,15 @@ func (r *GatewayReconciler) ensureListenerCertificates(
 
 		desiredSpec := cmv1.CertificateSpec{
 			SecretName: secretName,
+			// The upstream owner labels let changes to the Secret be mapped
+			// back to the upstream Gateway.
 			SecretTemplate: &cmv1.CertificateSecretTemplate{
 				Labels: map[string]string{
 					downstreamclient.UpstreamOwnerClusterNameLabel: cert.Labels[downstreamclient.UpstreamOwnerClusterNameLabel],
+					downstreamclient.UpstreamOwnerGroupLabel:       cert.Labels[downstreamclient.UpstreamOwnerGroupLabel],
+					downstreamclient.UpstreamOwnerKindLabel:        cert.Labels[downstreamclient.UpstreamOwnerKindLabel],
+					downstreamclient.UpstreamOwnerNameLabel:        cert.Labels[downstreamclient.UpstreamOwnerNameLabel],
+					downstreamclient.UpstreamOwnerNamespaceLabel:   cert.Labels[downstreamclient.UpstreamOwnerNamespaceLabel],
 				},
 			},
 			DNSNames: []string{hostname},
@@ -1124,6 +1616,7 @@ func (r *GatewayReconciler) reconcileGatewayStatus(
 	upstreamClient client.Client,
 	upstreamGateway *gatewayv1.Gateway,
 	downstreamGateway *gatewayv1.Gateway,
+	invalidListeners []gatewayv1.SectionName,
 ) (result Result) {
 	logger := log.FromContext(ctx)
 
@@ -1132,15 +1625,22 @@ func (r *GatewayReconciler) reconcileGatewayStatus(
 
 	if c := apimeta.FindStatusCondition(downstreamGateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted)); c != nil {
 		message := "The Gateway has not been scheduled by Datum Gateway"
+		reason := c.Reason
 		if c.Status == metav1.ConditionTrue {
 			message = "The Gateway has been scheduled by Datum Gateway"
 			acceptedReady = true
+
+			// Invalid listeners are left out of the downstream gateway.
+			if len(invalidListeners) > 0 {
+				message = "The Gateway has been scheduled by Datum Gateway without its invalid listeners. " + listenersNotValidMessage(invalidListeners)
+				reason = string(gatewayv1.GatewayReasonListenersNotValid)
+			}
 		}
 
 		apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, metav1.Condition{
 			Message:            message,
 			Type:               string(gatewayv1.GatewayConditionAccepted),
-			Reason:             c.Reason,
+			Reason:             reason,
 			Status:             c.Status,
 			ObservedGeneration: upstreamGateway.Generation,
 		})
@@ -1195,8 +1695,11 @@ func (r *GatewayReconciler) ensureHostnamesClaimed(
 	upstreamClient client.Client,
 	upstreamGateway *gatewayv1.Gateway,
 	downstreamGateway *gatewayv1.Gateway,
+	blockedHostnames []string,
 ) (verifiedHostnames, claimedHostnames, notClaimedHostnames []string, err error) {
 
+	logger := log.FromContext(ctx)
+
 	verifiedHostnames, err = r.ensureHostnameVerification(ctx, upstreamClient, upstreamGateway, downstreamGateway)
 	if err != nil {
 		return nil, nil, nil, err
@@ -1217,6 +1720,12 @@ func (r *GatewayReconciler) ensureHostnamesClaimed(
 			continue
 		}
 
+		// Blocked hostnames are never claimed, which also releases any claim
+		// held before the hostname was blocked.
+		if slices.Contains(blockedHostnames, hostname) {
+			continue
+		}
+
 		objectKey := client.ObjectKey{
 			Namespace: r.Config.Gateway.DownstreamHostnameAccountingNamespace,
 			Name:      hostname,
@@ -1244,15 +1753,42 @@ func (r *GatewayReconciler) ensureHostnamesClaimed(
 			}
 
 			if err := downstreamClient.Create(ctx, &hostnameConfigMap); err != nil {
+				// Another gateway claimed the hostname first.
+				if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
+					notClaimedHostnames = append(notClaimedHostnames, hostname)
+					continue
+				}
+				return nil, nil, nil, err
+			}
+		} else if owner := hostnameConfigMap.Data[jsonKeyOwner]; owner != upstreamGatewayReferenceName {
+			stale, err := r.isStaleHostnameClaim(ctx, owner, hostname)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if !stale {
+				logger.Info("hostname is claimed by another gateway", "hostname", hostname, "owner", owner)
+				notClaimedHostnames = append(notClaimedHostnames, hostname)
+				continue
+			}
+
+			// Take over the claim of a gateway that no longer uses the hostname.
+			// The update fails if another gateway takes it over first.
+			logger.Info("taking over stale hostname claim", "hostname", hostname, "owner", owner)
+			hostnameConfigMap.Labels = map[string]string{
+				downstreamclient.UpstreamOwnerClusterNameLabel: fmt.Sprintf("cluster-%s", strings.ReplaceAll(upstreamClusterName, "/", "_")),
+				downstreamclient.UpstreamOwnerNamespaceLabel:   upstreamGateway.Namespace,
+				downstreamclient.UpstreamOwnerNameLabel:        upstreamGateway.Name,
+			}
+			hostnameConfigMap.Data = map[string]string{
+				jsonKeyOwner: upstreamGatewayReferenceName,
+			}
+			if err := downstreamClient.Update(ctx, &hostnameConfigMap); err != nil {
 				if apierrors.IsConflict(err) {
 					notClaimedHostnames = append(notClaimedHostnames, hostname)
 					continue
 				}
 				return nil, nil, nil, err
 			}
-		} else if hostnameConfigMap.Data[jsonKeyOwner] != upstreamGatewayReferenceName {
-			notClaimedHostnames = append(notClaimedHostnames, hostname)
-			continue
 		}
 
 		claimedHostnames = append(claimedHostnames, hostname)
@@ -1292,6 +1828,46 @@ func (r *GatewayReconciler) ensureHostnamesClaimed(
 	return verifiedHostnames, claimedHostnames, notClaimedHostnames, nil
 }
 
+// isStaleHostnameClaim returns whether the gateway that owns a hostname claim
+// no longer exists, or no longer has a listener for the hostname. Claims of
+// gateways in clusters that are not engaged are never considered stale, as the
+// gateway can't be looked up.
+func (r *GatewayReconciler) isStaleHostnameClaim(ctx context.Context, owner, hostname string) (bool, error) {
+	// The owner is formatted as <cluster>/<namespace>/<name>, where the cluster
+	// name may itself contain slashes.
+	i := strings.LastIndex(owner, "/")
+	if i <= 0 {
+		return false, nil
+	}
+	j := strings.LastIndex(owner[:i], "/")
+	if j < 0 {
+		return false, nil
+	}
+	clusterName, key := owner[:j], client.ObjectKey{Namespace: owner[j+1 : i], Name: owner[i+1:]}
+
+	cl, err := r.mgr.GetCluster(ctx, multicluster.ClusterName(clusterName))
+	if err != nil {
+		return false, nil
+	}
+
+	var gateway gatewayv1.Gateway
+	if err := cl.GetClient().Get(ctx, key, &gateway); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get gateway %s owning hostname claim: %w", owner, err)
+	}
+
+	// A deleting gateway releases its claims when it's finalized.
+	if !gateway.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+
+	return !slices.ContainsFunc(gateway.Spec.Listeners, func(l gatewayv1.Listener) bool {
+		return l.Hostname != nil && string(*l.Hostname) == hostname
+	}), nil
+}
+
 func (r *GatewayReconciler) isDatumManagedGatewayHostname(upstreamGateway *gatewayv1.Gateway, hostname string) bool {
 	targetDomain := r.Config.Gateway.TargetDomain
 	gatewayUID := string(upstreamGateway.UID)
@@ -1391,26 +1967,26 @@ func (r *GatewayReconciler) ensureHostnameVerification(
 		return verifiedHostnamesSlice, nil
 	}
 
-	// List all Domains in the same namespace as the upstream gateway. A field
-	// selector will not work here, as set based operations are not supported, and
-	// there's not way to check for a suffix match.
-
-	var domainList networkingv1alpha.DomainList
-	if err := upstreamClient.List(ctx, &domainList, client.InNamespace(upstreamGateway.Namespace)); err != nil {
-		return nil, fmt.Errorf("failed listing domains: %w", err)
-	}
-
-	logger.Info("processing domains in same namespace", "domain_count", len(domainList.Items))
-
 	domainsToCreate := sets.New[string]()
 	for _, hostname := range hostnames.UnsortedList() {
 		// Gateway DNS address hostname is exempt from verification
 		if addressHostnames.Has(hostname) {
 			continue
 		}
+
+		// Look up the Domains in the same namespace as the upstream gateway whose
+		// domain name is the hostname or one of its parent domains.
 		foundMatchingDomain := false
-		for _, domain := range domainList.Items {
-			if hostname == domain.Spec.DomainName || strings.HasSuffix(hostname, "."+domain.Spec.DomainName) {
+		for _, domainName := range hostnameDomainCandidates(hostname) {
+			var domainList networkingv1alpha.DomainList
+			if err := upstreamClient.List(ctx, &domainList,
+				client.InNamespace(upstreamGateway.Namespace),
+				client.MatchingFields{domainDomainNameIndex: domainName},
+			); err != nil {
+				return nil, fmt.Errorf("failed listing domains: %w", err)
+			}
+
+			for _, domain := range domainList.Items {
 				foundMatchingDomain = true
 				if !apimeta.IsStatusConditionTrue(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified) {
 					logger.Info("domain is not verified", "domain", domain.Name)
@@ -1419,6 +1995,9 @@ func (r *GatewayReconciler) ensureHostnameVerification(
 				verifiedHostnames.Insert(hostname)
 				break
 			}
+			if verifiedHostnames.Has(hostname) {
+				break
+			}
 		}
 
 		if !foundMatchingDomain {
@@ -1443,6 +2022,9 @@ func (r *GatewayReconciler) ensureHostnameVerification(
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: upstreamGateway.Namespace,
 					Name:      domainName,
+					Labels: map[string]string{
+						GatewayCreatedDomainLabel: upstreamGateway.Name,
+					},
 				},
 				Spec: networkingv1alpha.DomainSpec{
 					DomainName: domainName,
@@ -1511,42 +2093,39 @@ func (r *GatewayReconciler) ensureDownstreamGatewayDNSEndpoints(
 	downstreamGateway *gatewayv1.Gateway,
 	downstreamStrategy downstreamclient.ResourceStrategy,
 	hostnames []string,
+	dnsRecords gatewayDNSRecords,
 ) (result Result) {
 	logger := log.FromContext(ctx)
 
-	// Extract IP addresses from the downstream gateway's status
-	// Using the `any`` type due to deep copy logic requirements in the unstructured
-	// lib used to set DNSEndpoint values.
-	var v4IPs, v6IPs []any
-	for _, addr := range downstreamGateway.Status.Addresses {
-		if addr.Type == nil {
-			continue
-		}
-		switch *addr.Type {
-		case gatewayv1.IPAddressType:
-			// Check if it's an IPv4 or IPv6 address
-			if strings.Contains(addr.Value, ":") {
-				v6IPs = append(v6IPs, addr.Value)
-			} else {
-				v4IPs = append(v4IPs, addr.Value)
-			}
-		}
+	addresses := r.downstreamGatewayDNSAddresses(downstreamGateway)
+	if dnsRecords.failover != nil {
+		addresses = *dnsRecords.failover
 	}
 
 	// Return early if no IP addresses were found. Requeue after a short delay
 	// so we don't rely solely on the downstream Gateway watch to re-trigger
 	// reconciliation (the watch may fire before the cache reflects the status
 	// update, leaving us with stale data on this cycle).
-	if (r.Config.Gateway.IPv4Enabled() && len(v4IPs) == 0) || (r.Config.Gateway.IPv6Enabled() && len(v6IPs) == 0) {
+	if !addresses.available() {
 		logger.Info(
 			"IP addresses not yet available on downstream gateway",
-			"ipv4", v4IPs, "ipv4_enabled", r.Config.Gateway.IPv4Enabled(),
-			"ipv6", v6IPs, "ipv6_enabled", r.Config.Gateway.IPv6Enabled(),
+			"ipv4", addresses.v4, "ipv4_enabled", addresses.ipv4Enabled,
+			"ipv6", addresses.v6, "ipv6_enabled", addresses.ipv6Enabled,
 		)
 		result.RequeueAfter = 5 * time.Second
 		return result
 	}
 
+	// Using the `any` type due to deep copy logic requirements in the
+	// unstructured lib used to set DNSEndpoint values.
+	var v4IPs, v6IPs []any
+	for _, ip := range addresses.v4 {
+		v4IPs = append(v4IPs, ip)
+	}
+	for _, ip := range addresses.v6 {
+		v6IPs = append(v6IPs, ip)
+	}
+
 	endpoints := []any{}
 	var gatewayDNSEndpoint unstructured.Unstructured
 	gatewayDNSEndpoint.SetGroupVersionKind(schema.GroupVersionKind{
@@ -1557,25 +2136,28 @@ func (r *GatewayReconciler) ensureDownstreamGatewayDNSEndpoints(
 	gatewayDNSEndpoint.SetNamespace(downstreamGateway.Namespace)
 	gatewayDNSEndpoint.SetName(downstreamGateway.Name)
 
-	for _, hostname := range hostnames {
-		if len(v4IPs) > 0 && !strings.HasPrefix(hostname, "v6") {
-			// v4 specific hostname, or hostname that includes both v4 and v6
-			endpoints = append(endpoints, map[string]any{
-				"dnsName":    hostname,
-				"targets":    v4IPs,
-				"recordType": "A",
-				"recordTTL":  int64(300),
-			})
+	registry := r.Config.Gateway.DNSEndpointRegistry
+	addEndpoint := func(hostname, recordType string, targets []any) {
+		endpoints = append(endpoints, map[string]any{
+			"dnsName":    hostname,
+			"targets":    targets,
+			"recordType": recordType,
+			"recordTTL":  dnsRecords.ttl,
+		})
+		if registry.Enabled() {
+			endpoints = append(endpoints, dnsEndpointRegistryRecord(registry, &gatewayDNSEndpoint, hostname, recordType, dnsRecords.ttl))
+		}
+	}
+
+	for _, hostname := range hostnames {
+		if len(v4IPs) > 0 && !strings.HasPrefix(hostname, "v6.") {
+			// v4 specific hostname, or hostname that includes both v4 and v6
+			addEndpoint(hostname, "A", v4IPs)
 		}
 
-		if len(v6IPs) > 0 && !strings.HasPrefix(hostname, "v4") {
+		if len(v6IPs) > 0 && !strings.HasPrefix(hostname, "v4.") {
 			// v6 specific hostname, or hostname that includes both v4 and v6
-			endpoints = append(endpoints, map[string]any{
-				"dnsName":    hostname,
-				"targets":    v6IPs,
-				"recordType": "AAAA",
-				"recordTTL":  int64(300),
-			})
+			addEndpoint(hostname, "AAAA", v6IPs)
 		}
 	}
 
@@ -1592,6 +2174,73 @@ func (r *GatewayReconciler) ensureDownstreamGatewayDNSEndpoints(
 	return result
 }
 
+// gatewayDNSAddresses are the addresses of a downstream gateway that are
+// published in DNS.
+type gatewayDNSAddresses struct {
+	ipv4Enabled, ipv6Enabled bool
+	v4, v6                   []string
+}
+
+// available returns whether the downstream gateway has addresses of every
+// enabled IP family.
+func (a gatewayDNSAddresses) available() bool {
+	return (!a.ipv4Enabled || len(a.v4) > 0) && (!a.ipv6Enabled || len(a.v6) > 0)
+}
+
+// downstreamGatewayDNSAddresses extracts the addresses of a downstream gateway
+// from its status. Addresses of an IP family that is not enabled are never
+// published, so an IPv6 only gateway only receives AAAA records. A gateway
+// with static addresses only publishes those addresses.
+func (r *GatewayReconciler) downstreamGatewayDNSAddresses(downstreamGateway *gatewayv1.Gateway) gatewayDNSAddresses {
+	staticAddresses := staticGatewayAddresses(downstreamGateway)
+	var addresses gatewayDNSAddresses
+	addresses.ipv4Enabled, addresses.ipv6Enabled = r.gatewayIPFamilies(staticAddresses)
+	for _, addr := range downstreamGateway.Status.Addresses {
+		if addr.Type == nil {
+			continue
+		}
+		switch *addr.Type {
+		case gatewayv1.IPAddressType:
+			if len(staticAddresses) > 0 {
+				ip, err := netip.ParseAddr(addr.Value)
+				if err != nil || !slices.Contains(staticAddresses, ip.Unmap()) {
+					continue
+				}
+			}
+			// Check if it's an IPv4 or IPv6 address
+			if strings.Contains(addr.Value, ":") {
+				if addresses.ipv6Enabled {
+					addresses.v6 = append(addresses.v6, addr.Value)
+				}
+			} else if addresses.ipv4Enabled {
+				addresses.v4 = append(addresses.v4, addr.Value)
+			}
+		}
+	}
+	return addresses
+}
+
+// dnsEndpointRegistryRecord returns the TXT registry record that marks a record
+// of a DNSEndpoint as owned by the operator. The record is named and labeled as
+// in the TXT registry of external-dns, so that external-dns instances and
+// operators sharing the zone recognize the owner of the record.
+func dnsEndpointRegistryRecord(
+	registry config.GatewayDNSEndpointRegistryConfig,
+	dnsEndpoint client.Object,
+	hostname string,
+	recordType string,
+	ttl int64,
+) map[string]any {
+	labels := fmt.Sprintf(`"heritage=external-dns,external-dns/owner=%s,external-dns/resource=crd/%s/%s"`,
+		registry.OwnerID, dnsEndpoint.GetNamespace(), dnsEndpoint.GetName())
+	return map[string]any{
+		"dnsName":    registry.Prefix + strings.ToLower(recordType) + "-" + hostname,
+		"targets":    []any{labels},
+		"recordType": "TXT",
+		"recordTTL":  ttl,
+	}
+}
+
 func (r *GatewayReconciler) finalizeGateway(
 	ctx context.Context,
 	upstreamClusterName string,
@@ -1608,6 +2257,15 @@ func (r *GatewayReconciler) finalizeGateway(
 	// Clear this gateway's cert-health series now that it is gone.
 	clearListenerCertMetrics(upstreamGateway.Namespace, upstreamGateway.Name)
 
+	terminating, err := upstreamNamespaceTerminating(ctx, upstreamClient, upstreamGateway.Namespace)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if terminating {
+		return r.teardownGateway(ctx, upstreamClusterName, upstreamClient, upstreamGateway, downstreamStrategy)
+	}
+
 	// Clean up DNS records created by this gateway
 	if r.Config.Gateway.EnableDNSIntegration {
 		if cleanupResult := r.cleanupDNSRecordSets(ctx, upstreamClient, upstreamGateway); cleanupResult.ShouldReturn() {
@@ -1653,12 +2311,116 @@ func (r *GatewayReconciler) finalizeGateway(
 		return detachResult
 	}
 
+	if r.Config.FeatureEnabled(features.L4Routes) {
+		logger.Info("detaching tcproutes and udproutes from gateway")
+		detachResult = r.detachL4Routes(ctx, upstreamClient, upstreamGateway, false)
+		if detachResult.ShouldReturn() {
+			return detachResult
+		}
+		detachResult = r.detachL4Routes(ctx, downstreamClient, downstreamGateway, true)
+		if detachResult.ShouldReturn() {
+			return detachResult
+		}
+	}
+
+	if r.Config.FeatureEnabled(features.GRPCRoutes) {
+		logger.Info("detaching grpcroutes from gateway")
+		detachResult = r.detachGRPCRoutes(ctx, upstreamClient, upstreamGateway, false)
+		if detachResult.ShouldReturn() {
+			return detachResult
+		}
+		detachResult = r.detachGRPCRoutes(ctx, downstreamClient, downstreamGateway, true)
+		if detachResult.ShouldReturn() {
+			return detachResult
+		}
+	}
+
+	shardGateways, err := listDownstreamGatewayShards(ctx, downstreamClient, downstreamGateway.Namespace, upstreamGateway.Name)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	for i := range shardGateways {
+		detachResult = r.detachHTTPRoutes(ctx, downstreamClient, &shardGateways[i], true)
+		if detachResult.ShouldReturn() {
+			return detachResult
+		}
+		if r.Config.FeatureEnabled(features.L4Routes) {
+			detachResult = r.detachL4Routes(ctx, downstreamClient, &shardGateways[i], true)
+			if detachResult.ShouldReturn() {
+				return detachResult
+			}
+		}
+		if r.Config.FeatureEnabled(features.GRPCRoutes) {
+			detachResult = r.detachGRPCRoutes(ctx, downstreamClient, &shardGateways[i], true)
+			if detachResult.ShouldReturn() {
+				return detachResult
+			}
+		}
+	}
+
 	logger.Info("deleting anchor for upstream gateway")
 	if err := downstreamStrategy.DeleteAnchorForObject(ctx, upstreamGateway); err != nil {
 		result.Err = err
 		return result
 	}
 
+	if err := r.deleteHostnameClaims(ctx, upstreamClusterName, upstreamGateway); err != nil {
+		result.Err = err
+	}
+	return result
+}
+
+// teardownGateway finalizes a gateway in a terminating namespace. Downstream
+// resources are removed along with the downstream namespace, so only hostname
+// claims, which live outside of it, are removed individually. DNS records and
+// routes in the namespace are deleted with it and are not detached.
+func (r *GatewayReconciler) teardownGateway(
+	ctx context.Context,
+	upstreamClusterName string,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) (result Result) {
+	logger := log.FromContext(ctx)
+	logger.Info("tearing down gateway in terminating namespace")
+
+	if err := teardownDownstreamNamespace(ctx, downstreamStrategy, upstreamClusterName, upstreamGateway.Namespace); err != nil {
+		result.Err = err
+		return result
+	}
+
+	if err := r.deleteHostnameClaims(ctx, upstreamClusterName, upstreamGateway); err != nil {
+		result.Err = err
+		return result
+	}
+
+	// DNS records in shared DNSZones are not removed with the namespace.
+	if r.Config.Gateway.EnableDNSIntegration && r.Config.Gateway.SharedDNSZoneSelector != nil {
+		if cleanupResult := r.cleanupDNSRecordSets(ctx, upstreamClient, upstreamGateway); cleanupResult.ShouldReturn() {
+			return cleanupResult
+		}
+	}
+
+	pending, err := pendingHTTPRouteFinalizers(ctx, upstreamClient, upstreamGateway.Namespace)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if pending > 0 {
+		logger.Info("waiting for httproutes to be released before the gateway", "pending", pending)
+		result.RequeueAfter = namespaceTeardownRequeueInterval
+	}
+	return result
+}
+
+// deleteHostnameClaims deletes the configmaps that claim hostnames for the
+// gateway in the hostname accounting namespace.
+func (r *GatewayReconciler) deleteHostnameClaims(
+	ctx context.Context,
+	upstreamClusterName string,
+	upstreamGateway *gatewayv1.Gateway,
+) error {
 	// Delete all configmaps that are claiming hostnames
 	downstreamClusterClient := r.DownstreamCluster.GetClient()
 	listOpts := []client.ListOption{
@@ -1672,20 +2434,18 @@ func (r *GatewayReconciler) finalizeGateway(
 
 	var hostnameConfigMapList corev1.ConfigMapList
 	if err := downstreamClusterClient.List(ctx, &hostnameConfigMapList, listOpts...); err != nil {
-		result.Err = err
-		return result
+		return err
 	}
 
 	if len(hostnameConfigMapList.Items) > 0 {
 		for _, configMap := range hostnameConfigMapList.Items {
 			if err := downstreamClusterClient.Delete(ctx, &configMap); err != nil {
-				result.Err = fmt.Errorf("failed to delete hostname claim configmap: %w", err)
-				return result
+				return fmt.Errorf("failed to delete hostname claim configmap: %w", err)
 			}
 		}
 	}
 
-	return result
+	return nil
 }
 
 // cleanupDNSRecordSets deletes all DNSRecordSet resources that were created by
@@ -1701,7 +2461,7 @@ func (r *GatewayReconciler) cleanupDNSRecordSets(
 
 	var recordSetList dnsv1alpha1.DNSRecordSetList
 	if err := upstreamClient.List(ctx, &recordSetList,
-		client.InNamespace(upstreamGateway.Namespace),
+		r.dnsRecordSetListNamespace(upstreamGateway),
 		client.MatchingLabels{
 			labelDNSManaged:    labelValueTrue,
 			labelManagedBy:     labelManagedByValue,
@@ -1800,7 +2560,11 @@ func (r *GatewayReconciler) ensureDownstreamGatewayHTTPRoutes(
 	downstreamStrategy downstreamclient.ResourceStrategy,
 	verifiedHostnames []string,
 	notClaimedHostnames []string,
+	blockedHostnames []string,
 	listenerCertHealth map[gatewayv1.SectionName]listenerCertStatus,
+	clientValidations map[gatewayv1.SectionName]listenerClientValidation,
+	downstreamListeners map[gatewayv1.SectionName][]metav1.Condition,
+	export *manifestExport,
 ) (result Result) {
 	logger := log.FromContext(ctx)
 
@@ -1813,10 +2577,11 @@ func (r *GatewayReconciler) ensureDownstreamGatewayHTTPRoutes(
 
 	upstreamNS := gatewayv1.Namespace(upstreamGateway.Namespace)
 
-	// Collect routes attached to the gateway.
+	// Collect routes attached to the gateway, and the listeners they attach to.
 	attachedRoutes := map[client.ObjectKey]gatewayv1.HTTPRoute{}
-	attachedRouteCount := make(map[gatewayv1.SectionName]int32, len(upstreamGateway.Spec.Listeners))
+	attachedListeners := map[client.ObjectKey][]gatewayv1.SectionName{}
 	for _, route := range httpRoutes.Items {
+		routeKey := client.ObjectKeyFromObject(&route)
 		if parentRefs := route.Spec.ParentRefs; parentRefs != nil {
 			for _, parentRef := range parentRefs {
 				if ptr.Deref(parentRef.Namespace, upstreamNS) != upstreamNS {
@@ -1839,7 +2604,7 @@ func (r *GatewayReconciler) ensureDownstreamGatewayHTTPRoutes(
 					if parentRef.SectionName != nil {
 						foundSectionName := false
 						for _, listener := range upstreamGateway.Spec.Listeners {
-							if listener.Name == *parentRef.SectionName {
+							if listener.Name == *parentRef.SectionName && listenerRouteKind(listener.Protocol) == KindHTTPRoute {
 								foundSectionName = true
 								break
 							}
@@ -1849,15 +2614,17 @@ func (r *GatewayReconciler) ensureDownstreamGatewayHTTPRoutes(
 							continue
 						}
 
-						attachedRouteCount[*parentRef.SectionName]++
+						attachedListeners[routeKey] = append(attachedListeners[routeKey], *parentRef.SectionName)
 					} else {
-						// Attached to all sections, update all counts
+						// Attached to all HTTP sections
 						for _, l := range upstreamGateway.Spec.Listeners {
-							attachedRouteCount[l.Name]++
+							if listenerRouteKind(l.Protocol) == KindHTTPRoute {
+								attachedListeners[routeKey] = append(attachedListeners[routeKey], l.Name)
+							}
 						}
 					}
 
-					attachedRoutes[client.ObjectKeyFromObject(&route)] = route
+					attachedRoutes[routeKey] = route
 				}
 			}
 		}
@@ -1865,7 +2632,27 @@ func (r *GatewayReconciler) ensureDownstreamGatewayHTTPRoutes(
 
 	logger.Info("attached routes", "count", len(attachedRoutes))
 
-	for _, route := range attachedRoutes {
+	// Routes exceeding the route limits are not counted as attached.
+	rejectedRoutes := admitHTTPRoutes(slices.Collect(maps.Values(attachedRoutes)), r.Config.Gateway.RouteLimits)
+	var admittedRoutes int32
+	attachedRouteCount := make(map[gatewayv1.SectionName]int32, len(upstreamGateway.Spec.Listeners))
+	rejectedRouteCount := make(map[gatewayv1.SectionName]int32, len(upstreamGateway.Spec.Listeners))
+	for key, listeners := range attachedListeners {
+		routeCount := attachedRouteCount
+		if _, rejected := rejectedRoutes[key]; rejected {
+			routeCount = rejectedRouteCount
+		} else {
+			admittedRoutes++
+		}
+		for _, listener := range listeners {
+			routeCount[listener]++
+		}
+	}
+	if len(rejectedRoutes) > 0 {
+		logger.Info("httproutes exceed the route limits", "count", len(rejectedRoutes))
+	}
+
+	for key, route := range attachedRoutes {
 		if !route.DeletionTimestamp.IsZero() {
 			logger.Info("skipping httproute due to deletion timestamp", jsonKeyName, route.Name)
 			continue
@@ -1887,6 +2674,8 @@ func (r *GatewayReconciler) ensureDownstreamGatewayHTTPRoutes(
 			downstreamGateway,
 			downstreamStrategy,
 			route,
+			rejectedRoutes[key],
+			export,
 		)
 		if result.Err != nil {
 			return result
@@ -1894,6 +2683,40 @@ func (r *GatewayReconciler) ensureDownstreamGatewayHTTPRoutes(
 		result = result.Merge(httpRouteResult)
 	}
 
+	if r.Config.FeatureEnabled(features.L4Routes) {
+		l4RouteResult := r.ensureDownstreamGatewayL4Routes(
+			ctx,
+			upstreamClient,
+			upstreamGateway,
+			upstreamGatewayClassControllerName,
+			downstreamGateway,
+			downstreamStrategy,
+			attachedRouteCount,
+			export,
+		)
+		result = result.Merge(l4RouteResult)
+		if result.Err != nil {
+			return result
+		}
+	}
+
+	if r.Config.FeatureEnabled(features.GRPCRoutes) {
+		grpcRouteResult := r.ensureDownstreamGatewayGRPCRoutes(
+			ctx,
+			upstreamClient,
+			upstreamGateway,
+			upstreamGatewayClassControllerName,
+			downstreamGateway,
+			downstreamStrategy,
+			attachedRouteCount,
+			export,
+		)
+		result = result.Merge(grpcRouteResult)
+		if result.Err != nil {
+			return result
+		}
+	}
+
 	logger.Info("updating listener status", "verified_hostnames", verifiedHostnames, "not_claimed_hostnames", notClaimedHostnames)
 
 	currentListenerStatus := map[gatewayv1.SectionName]gatewayv1.ListenerStatus{}
@@ -1901,6 +2724,14 @@ func (r *GatewayReconciler) ensureDownstreamGatewayHTTPRoutes(
 		currentListenerStatus[listener.Name] = *listener.DeepCopy()
 	}
 
+	listenerValidation := gatewayListenerValidation{
+		verifiedHostnames:   verifiedHostnames,
+		notClaimedHostnames: notClaimedHostnames,
+		blockedHostnames:    blockedHostnames,
+		certHealth:          listenerCertHealth,
+		clientValidations:   clientValidations,
+	}
+
 	// Update listener status for the upstream gateway
 	listenerStatus := make([]gatewayv1.ListenerStatus, 0, len(upstreamGateway.Spec.Listeners))
 	for _, listener := range upstreamGateway.Spec.Listeners {
@@ -1908,87 +2739,44 @@ func (r *GatewayReconciler) ensureDownstreamGatewayHTTPRoutes(
 		if !ok {
 			status = gatewayv1.ListenerStatus{
 				Name: listener.Name,
-				SupportedKinds: []gatewayv1.RouteGroupKind{
-					{
-						Group: ptr.To(gatewayv1.Group(gatewayv1.GroupName)),
-						Kind:  KindHTTPRoute,
-					},
-				},
 			}
 		}
 
+		status.SupportedKinds = r.listenerSupportedKinds(listener.Protocol)
+
 		status.AttachedRoutes = attachedRouteCount[listener.Name]
 
-		acceptedCondition := metav1.Condition{
-			Type:               string(gatewayv1.ListenerConditionAccepted),
-			Status:             metav1.ConditionTrue,
-			Reason:             string(gatewayv1.ListenerReasonAccepted),
-			Message:            "The listener has been accepted by the Datum Gateway",
-			ObservedGeneration: upstreamGateway.Generation,
-		}
+		conditions := listenerValidation.conditions(listener, upstreamGateway.Generation)
+		acceptedCondition := conditions.accepted
+		programmedCondition := conditions.programmed
+		resolvedRefsCondition := conditions.resolvedRefs
+		conflictedCondition := conditions.conflicted
 
-		programmedCondition := metav1.Condition{
-			Type:               string(gatewayv1.ListenerConditionProgrammed),
-			Status:             metav1.ConditionTrue,
-			Reason:             string(gatewayv1.ListenerReasonProgrammed),
-			Message:            "The listener has been programmed by the Datum Gateway",
-			ObservedGeneration: upstreamGateway.Generation,
-		}
+		setListenerExcludedCondition(&status, programmedCondition)
 
-		resolvedRefsCondition := metav1.Condition{
-			Type:               string(gatewayv1.ListenerConditionResolvedRefs),
-			Status:             metav1.ConditionTrue,
-			Reason:             string(gatewayv1.ListenerReasonResolvedRefs),
-			Message:            "The listener has been resolved by the Datum Gateway",
-			ObservedGeneration: upstreamGateway.Generation,
+		// Listeners without problems of their own are only programmed once the
+		// downstream gateway reports so.
+		if programmedCondition.Status == metav1.ConditionTrue {
+			applyDownstreamListenerConditions(&programmedCondition, &resolvedRefsCondition, downstreamListeners[listener.Name])
 		}
 
-		// A hostname problem is reported instead of a certificate problem, since
-		// the hostname has to be sorted out first.
-		hostnameProblem := false
-
-		if listener.Hostname != nil {
-
-			if !slices.Contains(verifiedHostnames, string(*listener.Hostname)) {
-				hostnameProblem = true
-				acceptedCondition.Status = metav1.ConditionFalse
-				acceptedCondition.Reason = networkingv1alpha.UnverifiedHostnamesPresent
-				acceptedCondition.Message = fmt.Sprintf("The hostname %q has not been verified. Check status of Domains in the same namespace.", *listener.Hostname)
-
-				programmedCondition.Status = metav1.ConditionFalse
-				programmedCondition.Reason = acceptedCondition.Reason
-				programmedCondition.Message = acceptedCondition.Message
-			} else if slices.Contains(notClaimedHostnames, string(*listener.Hostname)) {
-				hostnameProblem = true
-				acceptedCondition.Status = metav1.ConditionFalse
-				acceptedCondition.Reason = networkingv1alpha.HostnameInUseReason
-				acceptedCondition.Message = fmt.Sprintf("The hostname %q is already attached to a resource.", *listener.Hostname)
+		apimeta.SetStatusCondition(&status.Conditions, acceptedCondition)
+		apimeta.SetStatusCondition(&status.Conditions, programmedCondition)
+		apimeta.SetStatusCondition(&status.Conditions, resolvedRefsCondition)
+		apimeta.SetStatusCondition(&status.Conditions, conflictedCondition)
 
-				programmedCondition.Status = metav1.ConditionFalse
-				programmedCondition.Reason = acceptedCondition.Reason
-				programmedCondition.Message = acceptedCondition.Message
-			}
+		if certStatus, gated := listenerCertHealth[listener.Name]; gated {
+			apimeta.SetStatusCondition(&status.Conditions, listenerCertificateReadyCondition(certStatus, upstreamGateway.Generation))
+		} else {
+			apimeta.RemoveStatusCondition(&status.Conditions, ListenerConditionCertificateReady)
 		}
 
-		// Tell the customer when a listener's certificate is the reason HTTPS
-		// isn't running. The listener stays accepted because the configuration
-		// is fine; only the certificate needs attention.
-		if !hostnameProblem {
-			if certStatus, gated := listenerCertHealth[listener.Name]; gated && !certStatus.healthy {
-				resolvedRefsCondition.Status = metav1.ConditionFalse
-				resolvedRefsCondition.Reason = string(gatewayv1.ListenerReasonInvalidCertificateRef)
-				resolvedRefsCondition.Message = certStatus.message
-
-				programmedCondition.Status = metav1.ConditionFalse
-				programmedCondition.Reason = string(gatewayv1.ListenerReasonInvalid)
-				programmedCondition.Message = certStatus.message
-			}
+		if condition := routeLimitListenerCondition(r.Config.Gateway.RouteLimits, admittedRoutes, rejectedRouteCount[listener.Name], upstreamGateway.Generation); condition != nil {
+			apimeta.SetStatusCondition(&status.Conditions, *condition)
+		} else {
+			apimeta.RemoveStatusCondition(&status.Conditions, ListenerConditionRouteLimitExceeded)
 		}
 
-		apimeta.SetStatusCondition(&status.Conditions, acceptedCondition)
-		apimeta.SetStatusCondition(&status.Conditions, programmedCondition)
-		apimeta.SetStatusCondition(&status.Conditions, resolvedRefsCondition)
-
 		listenerStatus = append(listenerStatus, status)
 	}
 
@@ -2011,6 +2799,8 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 	downstreamGateway *gatewayv1.Gateway,
 	downstreamStrategy downstreamclient.ResourceStrategy,
 	upstreamRoute gatewayv1.HTTPRoute,
+	routeLimitMessage string,
+	export *manifestExport,
 ) (result Result) {
 	logger := log.FromContext(ctx)
 	logger.Info("processing httproute", jsonKeyName, upstreamRoute.Name)
@@ -2026,6 +2816,30 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 		ObjectMeta: downstreamRouteObjectMeta,
 	}
 
+	// A route exceeding the route limits of the gateway is programmed for its
+	// other gateways only, and removed when no gateway admits it.
+	admitted := routeLimitMessage == ""
+	if admitted {
+		clearRouteLimitExceededCondition(&upstreamRoute, client.ObjectKeyFromObject(upstreamGateway))
+	} else {
+		setRouteLimitExceededCondition(
+			&upstreamRoute,
+			client.ObjectKeyFromObject(upstreamGateway),
+			upstreamGatewayClassControllerName,
+			routeLimitMessage,
+		)
+	}
+	upstreamParentRefs := admittedParentRefs(&upstreamRoute, client.ObjectKeyFromObject(upstreamGateway), admitted)
+	if len(upstreamParentRefs) == 0 && len(upstreamRoute.Spec.ParentRefs) > 0 {
+		logger.Info("httproute exceeds the route limits, removing downstream httproute", jsonKeyName, upstreamRoute.Name)
+		if err := downstreamClient.Delete(ctx, downstreamRoute); client.IgnoreNotFound(err) != nil {
+			result.Err = fmt.Errorf("failed to delete downstream httproute: %w", err)
+			return result
+		}
+		result.AddStatusUpdate(upstreamClient, &upstreamRoute)
+		return result
+	}
+
 	rules, downstreamResources, downstreamResourcesToDelete, err := r.processDownstreamHTTPRouteRules(
 		ctx,
 		upstreamClient,
@@ -2039,40 +2853,128 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 		return result
 	}
 
+	// The resources of the backends are kept while the gateway is paused, so
+	// that traffic resumes without reprogramming them.
+	maintenanceFilter := desiredDownstreamMaintenanceFilter(
+		downstreamRouteObjectMeta.Namespace,
+		downstreamMaintenanceFilterName(upstreamRoute.UID),
+		r.Config.Gateway.Maintenance,
+	)
+	if gatewayPaused(upstreamGateway) {
+		rules = maintenanceHTTPRouteRules(rules, maintenanceFilter.Name)
+		downstreamResources = append(downstreamResources, maintenanceFilter)
+	}
+
+	parentRefs, err := downstreamHTTPRouteParentRefs(ctx, downstreamClient, downstreamRouteObjectMeta.Namespace, upstreamParentRefs)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	desiredSpec := gatewayv1.HTTPRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			// We currently only support same-namespace references, so just copy over
+			// parentRefs from the upstream route, pointed at gateway shards.
+			ParentRefs: parentRefs,
+		},
+		Hostnames: upstreamRoute.Spec.Hostnames,
+		Rules:     rules,
+	}
+	desiredHash, err := downstreamclient.DesiredHash(desiredSpec)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	// The maintenance filter is removed once the route no longer references it.
+	unpaused := false
 	routeResult, err := controllerutil.CreateOrUpdate(ctx, downstreamClient, downstreamRoute, func() error {
 		if err := downstreamStrategy.SetControllerReference(ctx, &upstreamRoute, downstreamRoute); err != nil {
 			return fmt.Errorf("failed to set controller reference on downstream httproute: %w", err)
 		}
+		unpaused = !gatewayPaused(upstreamGateway) && httpRouteReferencesFilter(downstreamRoute, maintenanceFilter.Name)
 
-		downstreamRoute.Spec = gatewayv1.HTTPRouteSpec{
-			CommonRouteSpec: gatewayv1.CommonRouteSpec{
-				// We currently only support same-namespace references, so just copy over
-				// parentRefs from the upstream route.
-				ParentRefs: upstreamRoute.Spec.ParentRefs,
-			},
-			Hostnames: upstreamRoute.Spec.Hostnames,
-			Rules:     rules,
+		// The spec is left as stored when neither the desired spec nor the
+		// downstream route changed, as the stored spec carries apiserver
+		// defaults and would otherwise be rewritten on every reconcile.
+		if downstreamclient.InSync(downstreamRoute, desiredHash) {
+			return nil
 		}
+		downstreamRoute.Spec = desiredSpec
+		downstreamclient.SetDesiredHash(downstreamRoute, desiredHash)
 
 		return nil
 	})
+	if err == nil {
+		err = downstreamclient.RecordObservedGeneration(ctx, downstreamClient, downstreamRoute)
+	}
 	if err != nil {
 		if apierrors.IsConflict(err) {
 			result.RequeueAfter = 1 * time.Second
+			export.addConflictedRoute(KindHTTPRoute, upstreamRoute.Name)
 			return result
 		}
 		result.Err = err
 		return result
 	}
 
-	// Create required downstream resources. Currently they're all specific to
-	// the HTTPRoute resource, so we set it as the owner and let them get
-	// cleaned up when the HTTPRoute is deleted.
-	for _, resource := range downstreamResources {
-		if err := controllerutil.SetControllerReference(downstreamRoute, resource, downstreamClient.Scheme()); err != nil {
+	if unpaused {
+		downstreamResourcesToDelete = append(downstreamResourcesToDelete, &envoygatewayv1alpha1.HTTPRouteFilter{
+			ObjectMeta: maintenanceFilter.ObjectMeta,
+		})
+	}
+
+	export.add(downstreamRoute)
+	if err := r.applyDownstreamRouteResources(ctx, downstreamClient, downstreamRoute, downstreamResources, downstreamResourcesToDelete, export); err != nil {
+		result.Err = err
+		return result
+	}
+
+	// Update the upstream route's parent status information, unless the
+	// gateway rejected the route for exceeding its route limits.
+	if admitted {
+		if err := mirrorDownstreamRouteParentStatus(
+			ctx,
+			downstreamClient,
+			upstreamGateway,
+			upstreamGatewayClassControllerName,
+			downstreamGateway,
+			&upstreamRoute.Status.Parents,
+			downstreamRoute.Status.Parents,
+			upstreamRoute.Generation,
+		); err != nil {
 			result.Err = err
 			return result
 		}
+	}
+
+	result.AddStatusUpdate(upstreamClient, &upstreamRoute)
+
+	logger.Info("downstream httproute processed", "operation_result", routeResult)
+
+	return result
+}
+
+// applyDownstreamRouteResources creates or updates the downstream resources
+// required by a downstream route, and deletes the ones that are no longer
+// desired. The resources are specific to the route, so the route is set as
+// their owner and they are cleaned up when the route is deleted. The desired
+// resources are exported rather than the applied ones, which carry server
+// assigned fields such as the cluster IPs of Services.
+func (r *GatewayReconciler) applyDownstreamRouteResources(
+	ctx context.Context,
+	downstreamClient client.Client,
+	downstreamRoute client.Object,
+	downstreamResources []client.Object,
+	downstreamResourcesToDelete []client.Object,
+	export *manifestExport,
+) error {
+	logger := log.FromContext(ctx)
+
+	for _, resource := range downstreamResources {
+		if err := controllerutil.SetControllerReference(downstreamRoute, resource, downstreamClient.Scheme()); err != nil {
+			return err
+		}
 
 		desiredDownstreamResource := resource.DeepCopyObject()
 		resourceResult, err := controllerutil.CreateOrUpdate(ctx, downstreamClient, resource, func() error {
@@ -2105,6 +3007,17 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 						obj.Spec.Ports = append(obj.Spec.Ports, dp)
 					}
 				}
+				// Services written before they carried upstream owner labels
+				// are labeled, and the desired spec is recorded for the
+				// downstream audit.
+				for key, value := range desired.Labels {
+					metav1.SetMetaDataLabel(&obj.ObjectMeta, key, value)
+				}
+				desiredHash, err := downstreamclient.DesiredHash(desired.Spec)
+				if err != nil {
+					return err
+				}
+				downstreamclient.SetDesiredHash(obj, desiredHash)
 			case *discoveryv1.EndpointSlice:
 				desiredEndpointSlice := desiredDownstreamResource.(*discoveryv1.EndpointSlice)
 				// Since endpointslices get duplicated for routes, add them as a controller
@@ -2115,20 +3028,42 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 				obj.AddressType = desiredEndpointSlice.AddressType
 				obj.Endpoints = desiredEndpointSlice.Endpoints
 				obj.Ports = desiredEndpointSlice.Ports
+				desiredHash, err := downstreamclient.DesiredHash(struct {
+					AddressType discoveryv1.AddressType
+					Endpoints   []discoveryv1.Endpoint
+					Ports       []discoveryv1.EndpointPort
+				}{obj.AddressType, obj.Endpoints, obj.Ports})
+				if err != nil {
+					return err
+				}
+				downstreamclient.SetDesiredHash(obj, desiredHash)
+			case *corev1.ConfigMap:
+				obj.Data = desiredDownstreamResource.(*corev1.ConfigMap).Data
 			case *gatewayv1.BackendTLSPolicy:
 				obj.Spec = desiredDownstreamResource.(*gatewayv1.BackendTLSPolicy).Spec
+			case *envoygatewayv1alpha1.Backend:
+				obj.Spec = desiredDownstreamResource.(*envoygatewayv1alpha1.Backend).Spec
+			case *envoygatewayv1alpha1.BackendTrafficPolicy:
+				obj.Spec = desiredDownstreamResource.(*envoygatewayv1alpha1.BackendTrafficPolicy).Spec
+			case *envoygatewayv1alpha1.HTTPRouteFilter:
+				obj.Spec = desiredDownstreamResource.(*envoygatewayv1alpha1.HTTPRouteFilter).Spec
 			}
 			return nil
 		})
+		if err == nil {
+			switch resource.(type) {
+			case *corev1.Service, *discoveryv1.EndpointSlice:
+				err = downstreamclient.RecordObservedGeneration(ctx, downstreamClient, resource)
+			}
+		}
 		if err != nil {
-			result.Err = err
-			return result
+			return err
 		}
+		export.add(desiredDownstreamResource.(client.Object))
 
 		gvk, err := apiutil.GVKForObject(resource, downstreamClient.Scheme())
 		if err != nil {
-			result.Err = err
-			return result
+			return err
 		}
 
 		logger.Info("downstream resource processed",
@@ -2142,20 +3077,21 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 	// Delete downstream resources that were previously desired but no longer
 	// are. This catches cases like an HTTPProxy backend flipping from https to
 	// http, which should remove the BackendTLSPolicy that had been created for
-	// the previous state. Resources owned by the downstream HTTPRoute are
+	// the previous state. Resources owned by the downstream route are
 	// garbage collected when the route is deleted, but that does not cover
 	// in-place transitions, so orphans must be cleaned up explicitly here.
 	for _, resource := range downstreamResourcesToDelete {
 		gvk, err := apiutil.GVKForObject(resource, downstreamClient.Scheme())
 		if err != nil {
-			result.Err = err
-			return result
+			return err
 		}
 
-		if err := downstreamClient.Delete(ctx, resource); err != nil && !apierrors.IsNotFound(err) {
-			result.Err = fmt.Errorf("failed deleting stale downstream resource %s/%s: %w",
+		if err := downstreamClient.Delete(ctx, resource); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed deleting stale downstream resource %s/%s: %w",
 				resource.GetNamespace(), resource.GetName(), err)
-			return result
 		}
 
 		logger.Info("stale downstream resource removed",
@@ -2165,13 +3101,30 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 		)
 	}
 
-	// Update the upstream route's parent status information
+	return nil
+}
+
+// mirrorDownstreamRouteParentStatus updates the parent status that an upstream
+// route has for the upstream gateway from the statuses the downstream route
+// has for the shards of the downstream gateway.
+func mirrorDownstreamRouteParentStatus(
+	ctx context.Context,
+	downstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	upstreamGatewayClassControllerName string,
+	downstreamGateway *gatewayv1.Gateway,
+	upstreamParents *[]gatewayv1.RouteParentStatus,
+	downstreamParents []gatewayv1.RouteParentStatus,
+	generation int64,
+) error {
+	logger := log.FromContext(ctx)
+
 	var parentStatus *gatewayv1.RouteParentStatus
-	for i, parent := range upstreamRoute.Status.Parents {
+	for i, parent := range *upstreamParents {
 		if ptr.Deref(parent.ParentRef.Group, gatewayv1.GroupName) == gatewayv1.GroupName &&
 			ptr.Deref(parent.ParentRef.Kind, KindGateway) == KindGateway &&
 			string(parent.ParentRef.Name) == upstreamGateway.Name {
-			parentStatus = &upstreamRoute.Status.Parents[i]
+			parentStatus = &(*upstreamParents)[i]
 			break
 		}
 	}
@@ -2188,13 +3141,15 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 	}
 
 	// Get the status of this parent from the downstream route
-	var downstreamParentStatus *gatewayv1.RouteParentStatus
-	for _, parent := range downstreamRoute.Status.Parents {
-		if string(parent.ParentRef.Name) == downstreamGateway.Name {
-			downstreamParentStatus = &parent
-			break
-		}
+	shardGateways, err := listDownstreamGatewayShards(ctx, downstreamClient, downstreamGateway.Namespace, upstreamGateway.Name)
+	if err != nil {
+		return err
+	}
+	downstreamParentNames := []string{downstreamGateway.Name}
+	for _, shard := range shardGateways {
+		downstreamParentNames = append(downstreamParentNames, shard.Name)
 	}
+	downstreamParentStatus := rollupDownstreamRouteParentStatus(downstreamParents, downstreamParentNames)
 
 	if downstreamParentStatus != nil {
 		if c := apimeta.FindStatusCondition(downstreamParentStatus.Conditions, string(gatewayv1.RouteConditionAccepted)); c != nil {
@@ -2208,7 +3163,7 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 				Type:               string(gatewayv1.RouteConditionAccepted),
 				Reason:             c.Reason,
 				Status:             c.Status,
-				ObservedGeneration: upstreamRoute.Generation,
+				ObservedGeneration: generation,
 			})
 		}
 
@@ -2223,7 +3178,7 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 				Type:               string(gatewayv1.RouteConditionResolvedRefs),
 				Reason:             c.Reason,
 				Status:             c.Status,
-				ObservedGeneration: upstreamRoute.Generation,
+				ObservedGeneration: generation,
 			})
 		}
 	} else {
@@ -2231,14 +3186,10 @@ func (r *GatewayReconciler) ensureDownstreamHTTPRoute(
 	}
 
 	if insertParentStatus {
-		upstreamRoute.Status.Parents = append(upstreamRoute.Status.Parents, *parentStatus)
+		*upstreamParents = append(*upstreamParents, *parentStatus)
 	}
 
-	result.AddStatusUpdate(upstreamClient, &upstreamRoute)
-
-	logger.Info("downstream httproute processed", "operation_result", routeResult)
-
-	return result
+	return nil
 }
 
 // processDownstreamHTTPRouteRules is a helper function that processes the
@@ -2269,8 +3220,19 @@ func (r *GatewayReconciler) processDownstreamHTTPRouteRules(
 
 	logger := log.FromContext(ctx)
 
+	// Invalid configuration is surfaced on the gateway status, and results in
+	// no request ID filters being added.
+	requestIDConfig, _ := gatewayutil.GetRequestIDConfig(upstreamGateway)
+
+	// The health check policies of the rules overlay the policy attached to
+	// the Gateway, which is only looked up for routes that health check.
+	var healthCheckMergeType *envoygatewayv1alpha1.MergeType
+	var healthCheckMergeTypeResolved bool
+
 	for ruleIdx, rule := range upstreamRoute.Spec.Rules {
 		var backendRefs []gatewayv1.HTTPBackendRef
+		var ruleHealthCheck *networkingv1alpha.HTTPProxyHealthCheck
+		var ruleLoadBalancer *networkingv1alpha.HTTPProxyBackendLoadBalancer
 		for backendRefIdx, backendRef := range rule.BackendRefs {
 
 			if backendRef.Kind == nil {
@@ -2303,17 +3265,9 @@ func (r *GatewayReconciler) processDownstreamHTTPRouteRules(
 					}
 				}
 
-				var ports []corev1.ServicePort
 				var appProtocol *string
 				var endpointPort *discoveryv1.EndpointPort
 				for _, port := range upstreamEndpointSlice.Ports {
-					ports = append(ports, corev1.ServicePort{
-						Name:        ptr.Deref(port.Name, ""),
-						Protocol:    ptr.Deref(port.Protocol, corev1.ProtocolTCP),
-						AppProtocol: port.AppProtocol,
-						Port:        *port.Port,
-					})
-
 					if *backendRef.Port == *port.Port {
 						if port.Name == nil {
 							// This should be protected by validation, but check just in case.
@@ -2334,46 +3288,79 @@ func (r *GatewayReconciler) processDownstreamHTTPRouteRules(
 				// downstream backendRef will reference.
 				resourceName := fmt.Sprintf("route-%s-rule-%d-backendref-%d", upstreamRoute.UID, ruleIdx, backendRefIdx)
 
-				downstreamService := &corev1.Service{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: downstreamGateway.Namespace,
-						Name:      resourceName,
-					},
-					Spec: corev1.ServiceSpec{
-						Type:                  corev1.ServiceTypeClusterIP,
-						ClusterIP:             clusterIPNone,
-						Ports:                 ports,
-						InternalTrafficPolicy: ptr.To(corev1.ServiceInternalTrafficPolicyCluster),
-						TrafficDistribution:   ptr.To(corev1.ServiceTrafficDistributionPreferClose),
-					},
+				healthCheck, err := backendHealthCheckFromAnnotations(upstreamEndpointSlice.Annotations)
+				if err != nil {
+					return nil, nil, nil, err
 				}
-				downstreamResources = append(downstreamResources, downstreamService)
-
-				downstreamEndpointSlice := &discoveryv1.EndpointSlice{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: downstreamGateway.Namespace,
-						Name:      resourceName,
-						Labels: map[string]string{
-							downstreamclient.UpstreamOwnerNameLabel: upstreamEndpointSlice.Name,
-							discoveryv1.LabelServiceName:            downstreamService.Name,
-						},
-					},
-					AddressType: upstreamEndpointSlice.AddressType,
-					Endpoints:   upstreamEndpointSlice.Endpoints,
-					Ports:       upstreamEndpointSlice.Ports,
+				loadBalancer, err := backendLoadBalancerFromAnnotations(upstreamEndpointSlice.Annotations)
+				if err != nil {
+					return nil, nil, nil, err
 				}
-
-				if err := downstreamStrategy.SetControllerReference(ctx, &upstreamEndpointSlice, downstreamEndpointSlice); err != nil {
-					return nil, nil, nil, fmt.Errorf("failed to set controller reference on downstream endpointslice: %w", err)
+				if loadBalancer != nil {
+					ruleLoadBalancer = loadBalancer
 				}
 
-				downstreamResources = append(downstreamResources, downstreamEndpointSlice)
-
-				backendObjectReference := gatewayv1.BackendObjectReference{
-					Namespace: ptr.To(gatewayv1.Namespace(downstreamGateway.Namespace)),
-					Kind:      ptr.To(gatewayv1.Kind(KindService)),
-					Name:      gatewayv1.ObjectName(downstreamService.Name),
-					Port:      backendRef.Port,
+				var backendObjectReference gatewayv1.BackendObjectReference
+				var backendTLSPolicyTargetRef gatewayv1.LocalPolicyTargetReferenceWithSectionName
+				if healthCheck != nil || upstreamEndpointSlice.Annotations[BackendTrafficSplitAnnotation] == "true" || r.programsEndpointSliceAsBackend(&upstreamEndpointSlice) {
+					// Backends of a failover or traffic split rule are programmed as
+					// priority levels or weighted endpoints of the same cluster, which
+					// Envoy Gateway only supports for Backends.
+					if healthCheck != nil {
+						ruleHealthCheck = healthCheck
+					}
+					downstreamBackend := desiredDownstreamBackend(downstreamGateway.Namespace, resourceName, &upstreamEndpointSlice, int32(*backendRef.Port))
+					downstreamResources = append(downstreamResources, downstreamBackend)
+					downstreamResourcesToDelete = append(downstreamResourcesToDelete,
+						&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: downstreamGateway.Namespace, Name: resourceName}},
+						&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Namespace: downstreamGateway.Namespace, Name: resourceName}},
+					)
+
+					backendObjectReference = gatewayv1.BackendObjectReference{
+						Group:     ptr.To(gatewayv1.Group(envoygatewayv1alpha1.GroupName)),
+						Kind:      ptr.To(gatewayv1.Kind(envoygatewayv1alpha1.KindBackend)),
+						Namespace: ptr.To(gatewayv1.Namespace(downstreamGateway.Namespace)),
+						Name:      gatewayv1.ObjectName(downstreamBackend.Name),
+						Port:      backendRef.Port,
+					}
+					backendTLSPolicyTargetRef = gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Group: gatewayv1.Group(envoygatewayv1alpha1.GroupName),
+							Kind:  gatewayv1.Kind(envoygatewayv1alpha1.KindBackend),
+							Name:  gatewayv1.ObjectName(downstreamBackend.Name),
+						},
+					}
+				} else {
+					downstreamService, downstreamEndpointSlice, err := r.desiredDownstreamEndpointSliceService(
+						ctx,
+						downstreamStrategy,
+						downstreamGateway.Namespace,
+						resourceName,
+						&upstreamEndpointSlice,
+					)
+					if err != nil {
+						return nil, nil, nil, err
+					}
+					downstreamResources = append(downstreamResources, downstreamService, downstreamEndpointSlice)
+					downstreamResourcesToDelete = append(downstreamResourcesToDelete,
+						&envoygatewayv1alpha1.Backend{ObjectMeta: metav1.ObjectMeta{Namespace: downstreamGateway.Namespace, Name: resourceName}},
+					)
+
+					backendObjectReference = gatewayv1.BackendObjectReference{
+						Namespace: ptr.To(gatewayv1.Namespace(downstreamGateway.Namespace)),
+						Kind:      ptr.To(gatewayv1.Kind(KindService)),
+						Name:      gatewayv1.ObjectName(downstreamService.Name),
+						Port:      backendRef.Port,
+					}
+					// TODO(jreese): We may have multiple ports that we need to set
+					// the policy on.
+					backendTLSPolicyTargetRef = gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Kind: gatewayv1.Kind(KindService),
+							Name: gatewayv1.ObjectName(downstreamService.Name),
+						},
+						SectionName: ptr.To(gatewayv1.SectionName(*endpointPort.Name)),
+					}
 				}
 
 				downstreamHTTPBackendRef := gatewayv1.HTTPBackendRef{
@@ -2398,6 +3385,11 @@ func (r *GatewayReconciler) processDownstreamHTTPRouteRules(
 						hostname = ptr.To(gatewayv1.PreciseHostname(v))
 					}
 
+					// FQDN endpoints are validated against their own hostname.
+					if hostname == nil {
+						hostname = fqdnEndpointSliceHostname(&upstreamEndpointSlice)
+					}
+
 					// Fall back to looking at rule filters for a hostname
 					// (preserves behaviour for EndpointSlices that predate
 					// the annotation).
@@ -2416,44 +3408,28 @@ func (r *GatewayReconciler) processDownstreamHTTPRouteRules(
 						return nil, nil, nil, fmt.Errorf("no hostname found in URLRewrite filters or EndpointSlice annotation on backendRef or Route %q", upstreamRoute.Name)
 					}
 
-					// BackendTLSPolicy graduated from v1alpha3 to v1 in gateway-api v1.5.
-					backendTLSPolicy := &gatewayv1.BackendTLSPolicy{
-						ObjectMeta: metav1.ObjectMeta{
-							Namespace: downstreamGateway.Namespace,
-							Name:      resourceName,
-						},
-						Spec: gatewayv1.BackendTLSPolicySpec{
-							TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
-								// TODO(jreese): We may have multiple ports that we need to set
-								// the policy on.
-								{
-									LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
-										Kind: gatewayv1.Kind(KindService),
-										Name: gatewayv1.ObjectName(downstreamService.Name),
-									},
-									SectionName: ptr.To(gatewayv1.SectionName(*endpointPort.Name)),
-								},
-							},
-							Validation: gatewayv1.BackendTLSPolicyValidation{
-								WellKnownCACertificates: ptr.To(gatewayv1.WellKnownCACertificatesSystem),
-								Hostname:                *hostname,
-							},
-						},
+					backendTLSResources, staleBackendTLSResources, err := desiredBackendTLSResources(
+						ctx,
+						upstreamClient,
+						&upstreamEndpointSlice,
+						downstreamGateway.Namespace,
+						resourceName,
+						backendTLSPolicyTargetRef,
+						*hostname,
+					)
+					if err != nil {
+						return nil, nil, nil, err
 					}
-
-					downstreamResources = append(downstreamResources, backendTLSPolicy)
+					downstreamResources = append(downstreamResources, backendTLSResources...)
+					downstreamResourcesToDelete = append(downstreamResourcesToDelete, staleBackendTLSResources...)
 				} else {
 					// The backend is not https, so any BackendTLSPolicy that may
 					// have been created by a previous reconcile (when the backend
 					// was https) must be removed. The policy name is deterministic
 					// from the route UID and backend indices, so we can target it
 					// directly without listing.
-					downstreamResourcesToDelete = append(downstreamResourcesToDelete, &gatewayv1.BackendTLSPolicy{
-						ObjectMeta: metav1.ObjectMeta{
-							Namespace: downstreamGateway.Namespace,
-							Name:      resourceName,
-						},
-					})
+					downstreamResourcesToDelete = append(downstreamResourcesToDelete,
+						backendTLSResourcesToDelete(downstreamGateway.Namespace, resourceName)...)
 				}
 
 			case "Service":
@@ -2468,9 +3444,56 @@ func (r *GatewayReconciler) processDownstreamHTTPRouteRules(
 			}
 		}
 
+		healthCheckPolicyName := downstreamHealthCheckPolicyName(upstreamRoute.UID, ruleIdx)
+		if ruleHealthCheck != nil {
+			if !healthCheckMergeTypeResolved {
+				mergeType, err := gatewayPolicyMergeType(ctx, upstreamClient, upstreamGateway.Namespace, upstreamGateway.Name)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				healthCheckMergeType = mergeType
+				healthCheckMergeTypeResolved = true
+			}
+			downstreamResources = append(downstreamResources, desiredDownstreamHealthCheckPolicy(
+				downstreamGateway.Namespace,
+				healthCheckPolicyName,
+				upstreamRoute.Name,
+				rule.Name,
+				ruleHealthCheck,
+				ruleLoadBalancer,
+				healthCheckMergeType,
+			))
+		} else {
+			downstreamResourcesToDelete = append(downstreamResourcesToDelete, &envoygatewayv1alpha1.BackendTrafficPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: downstreamGateway.Namespace,
+					Name:      healthCheckPolicyName,
+				},
+			})
+		}
+
+		filters, mirrorResources, mirrorResourcesToDelete, err := r.downstreamRequestMirrorFilters(
+			ctx,
+			upstreamClient,
+			upstreamRoute,
+			ruleIdx,
+			rule.Filters,
+			downstreamGateway.Namespace,
+			downstreamStrategy,
+		)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		downstreamResources = append(downstreamResources, mirrorResources...)
+		downstreamResourcesToDelete = append(downstreamResourcesToDelete, mirrorResourcesToDelete...)
+
+		if requestIDConfig != nil {
+			filters = withRequestIDFilters(filters, requestIDConfig)
+		}
+
 		rules = append(rules, gatewayv1.HTTPRouteRule{
 			Name:               rule.Name,
-			Filters:            rule.Filters,
+			Filters:            filters,
 			Matches:            rule.Matches,
 			BackendRefs:        backendRefs,
 			Timeouts:           rule.Timeouts,
@@ -2482,13 +3505,106 @@ func (r *GatewayReconciler) processDownstreamHTTPRouteRules(
 	return rules, downstreamResources, downstreamResourcesToDelete, nil
 }
 
+// programsEndpointSliceAsBackend returns whether the endpoints of an upstream
+// EndpointSlice are programmed downstream as an Envoy Gateway Backend, instead
+// of a headless Service and EndpointSlice. FQDN endpoints are always
+// programmed as Backends, as Envoy Gateway does not resolve the addresses of
+// FQDN EndpointSlices.
+func (r *GatewayReconciler) programsEndpointSliceAsBackend(endpointSlice *discoveryv1.EndpointSlice) bool {
+	if isFQDNEndpointSlice(endpointSlice) {
+		return true
+	}
+	return r.Config.Gateway.DownstreamBackendMode == config.DownstreamBackendModeBackends && !isConnectorEndpointSlice(endpointSlice)
+}
+
+// desiredDownstreamEndpointSliceService returns the headless Service and the
+// EndpointSlice that a downstream backendRef references to reach the endpoints
+// of an upstream EndpointSlice.
+func (r *GatewayReconciler) desiredDownstreamEndpointSliceService(
+	ctx context.Context,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	namespace string,
+	name string,
+	upstreamEndpointSlice *discoveryv1.EndpointSlice,
+) (*corev1.Service, *discoveryv1.EndpointSlice, error) {
+	var ports []corev1.ServicePort
+	for _, port := range upstreamEndpointSlice.Ports {
+		ports = append(ports, corev1.ServicePort{
+			Name:        ptr.Deref(port.Name, ""),
+			Protocol:    ptr.Deref(port.Protocol, corev1.ProtocolTCP),
+			AppProtocol: port.AppProtocol,
+			Port:        *port.Port,
+		})
+	}
+
+	downstreamService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:                  corev1.ServiceTypeClusterIP,
+			ClusterIP:             clusterIPNone,
+			Ports:                 ports,
+			InternalTrafficPolicy: ptr.To(corev1.ServiceInternalTrafficPolicyCluster),
+			TrafficDistribution:   ptr.To(corev1.ServiceTrafficDistributionPreferClose),
+		},
+	}
+
+	downstreamEndpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				downstreamclient.UpstreamOwnerNameLabel: upstreamEndpointSlice.Name,
+				discoveryv1.LabelServiceName:            downstreamService.Name,
+			},
+		},
+		AddressType: upstreamEndpointSlice.AddressType,
+		Endpoints:   desiredDownstreamEndpoints(upstreamEndpointSlice.Endpoints, r.Config.Gateway.FilterNotReadyEndpoints),
+		Ports:       upstreamEndpointSlice.Ports,
+	}
+
+	if err := downstreamStrategy.SetControllerReference(ctx, upstreamEndpointSlice, downstreamEndpointSlice); err != nil {
+		return nil, nil, fmt.Errorf("failed to set controller reference on downstream endpointslice: %w", err)
+	}
+	// The Service shares the upstream owner of its EndpointSlice, so that the
+	// downstream audit finds it.
+	if err := downstreamStrategy.SetControllerReference(ctx, upstreamEndpointSlice, downstreamService); err != nil {
+		return nil, nil, fmt.Errorf("failed to set controller reference on downstream service: %w", err)
+	}
+
+	return downstreamService, downstreamEndpointSlice, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *GatewayReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	r.mgr = mgr
 
+	if caaConfig := r.Config.Gateway.CAA; len(caaConfig.IssuerDomains) > 0 {
+		exchange, err := dnsutil.NewExchange(caaConfig.Resolver, caaLookupTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to create CAA resolver: %w", err)
+		}
+		r.caa = newCAAChecker(exchange, caaConfig.IssuerDomains, caaConfig.CacheTTL.Duration)
+	}
+
+	if verificationConfig := r.Config.Gateway.DNSVerification; len(verificationConfig.Resolvers) > 0 {
+		resolvers := make([]dnsResolver, 0, len(verificationConfig.Resolvers))
+		for _, server := range verificationConfig.Resolvers {
+			exchange, err := dnsutil.NewExchange(server, dnsVerificationLookupTimeout)
+			if err != nil {
+				return fmt.Errorf("failed to create DNS verification resolver: %w", err)
+			}
+			resolvers = append(resolvers, dnsResolver{name: server, exchange: exchange})
+		}
+		r.dnsVerifier = newDNSRecordVerifier(resolvers, verificationConfig.CacheTTL.Duration, verificationConfig.RecheckInterval.Duration)
+	}
+
 	downstreamGatewaySource := mcsource.TypedKind(
 		&gatewayv1.Gateway{},
 		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*gatewayv1.Gateway](&gatewayv1.Gateway{}),
+		downstreamSpecOrStatusChanged[*gatewayv1.Gateway](),
 	)
 
 	downstreamGatewayClusterSource, _, _ := downstreamGatewaySource.ForCluster("", r.DownstreamCluster)
@@ -2496,6 +3612,7 @@ func (r *GatewayReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	downstreamHTTPRouteSource := mcsource.Kind(
 		&gatewayv1.HTTPRoute{},
 		r.listGatewaysAttachedByDownstreamHTTPRoute,
+		downstreamSpecOrStatusChanged[*gatewayv1.HTTPRoute](),
 	)
 
 	downstreamHTTPRouteClusterSource, _, _ := downstreamHTTPRouteSource.ForCluster("", r.DownstreamCluster)
@@ -2503,31 +3620,70 @@ func (r *GatewayReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	downstreamCertificateSource := mcsource.TypedKind(
 		&cmv1.Certificate{},
 		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*cmv1.Certificate](&gatewayv1.Gateway{}),
+		downstreamSpecOrStatusChanged[*cmv1.Certificate](),
 	)
 
 	downstreamCertificateClusterSource, _, _ := downstreamCertificateSource.ForCluster("", r.DownstreamCluster)
 
+	downstreamSecretSource := mcsource.TypedKind(
+		&corev1.Secret{},
+		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*corev1.Secret](&gatewayv1.Gateway{}),
+	)
+
+	downstreamSecretClusterSource, _, _ := downstreamSecretSource.ForCluster("", r.DownstreamCluster)
+
+	downstreamClientTrafficPolicySource := mcsource.TypedKind(
+		&envoygatewayv1alpha1.ClientTrafficPolicy{},
+		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*envoygatewayv1alpha1.ClientTrafficPolicy](&gatewayv1.Gateway{}),
+		ignoreStatusOnlyUpdates[*envoygatewayv1alpha1.ClientTrafficPolicy](),
+	)
+
+	downstreamClientTrafficPolicyClusterSource, _, _ := downstreamClientTrafficPolicySource.ForCluster("", r.DownstreamCluster)
+
 	builder := mcbuilder.ControllerManagedBy(mgr).
 		For(&gatewayv1.Gateway{}).
 		Watches(
 			&gatewayv1.HTTPRoute{},
 			mchandler.EnqueueRequestsFromMapFunc(r.listGatewaysAttachedByHTTPRoute),
+			mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()),
 		).
 		Watches(
 			&discoveryv1.EndpointSlice{},
 			r.listGatewaysForEndpointSliceFunc,
+			mcbuilder.WithPredicates(endpointSliceChangedPredicate),
 		).
 		Watches(
 			&networkingv1alpha.Domain{},
 			r.listGatewaysForDomainFunc,
+			mcbuilder.WithPredicates(domainVerificationChangedPredicate),
+		).
+		Watches(
+			&networkingv1alpha.IPAddressClaim{},
+			r.listGatewaysForIPAddressClaimFunc,
+		).
+		Watches(
+			&gatewayv1.GatewayClass{},
+			r.listGatewaysForGatewayClassFunc,
+			mcbuilder.WithPredicates(predicate.AnnotationChangedPredicate{}),
 		).
 		Watches(
 			&envoygatewayv1alpha1.HTTPRouteFilter{},
 			r.listGatewaysForHTTPRouteFilterFunc,
+			mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()),
+		).
+		Watches(
+			&corev1.Secret{},
+			r.listGatewaysForSecretFunc,
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			r.listGatewaysForConfigMapFunc,
 		).
 		WatchesRawSource(downstreamGatewayClusterSource).
 		WatchesRawSource(downstreamHTTPRouteClusterSource).
-		WatchesRawSource(downstreamCertificateClusterSource)
+		WatchesRawSource(downstreamCertificateClusterSource).
+		WatchesRawSource(downstreamSecretClusterSource).
+		WatchesRawSource(downstreamClientTrafficPolicyClusterSource)
 
 	if r.Config.Gateway.EnableDNSIntegration {
 		builder = builder.
@@ -2539,13 +3695,86 @@ func (r *GatewayReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 				&dnsv1alpha1.DNSRecordSet{},
 				r.listGatewaysForDNSRecordSetFunc,
 			)
+		if r.Config.Gateway.SharedDNSZoneSelector != nil {
+			builder = builder.Watches(
+				&gatewayv1.ReferenceGrant{},
+				r.listGatewaysForReferenceGrantFunc,
+			)
+		}
+	}
+
+	if r.DownstreamSpecDrift != nil {
+		builder = builder.WatchesRawSource(source.TypedChannel(
+			r.DownstreamSpecDrift,
+			handler.TypedEnqueueRequestsFromMapFunc(r.listGatewaysForDownstreamSpecDrift),
+		))
+	}
+
+	// The issuers of listener Certificates are mapped through the cluster
+	// issuer map, so gateways are reprogrammed when it is reloaded.
+	if r.ReloadableConfig != nil {
+		builder = builder.WatchesRawSource(source.TypedChannel(
+			configReloadEvents(r.ReloadableConfig, func(cfg *config.NetworkServicesOperator) map[string]string {
+				return cfg.Gateway.ClusterIssuerMap
+			}),
+			handler.TypedEnqueueRequestsFromMapFunc(r.listGatewaysForConfigReload),
+		))
+	}
+
+	// The health check policies of routes overlay the policy attached to the
+	// Gateway, if any.
+	for _, policy := range []client.Object{&envoygatewayv1alpha1.BackendTrafficPolicy{}, &networkingv1alpha.RateLimitPolicy{}, &networkingv1alpha.PayloadPolicy{}} {
+		builder = builder.Watches(policy, enqueueGatewayPolicyTargets, mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()))
 	}
 
-	return builder.
-		WithOptions(controller.TypedOptions[mcreconcile.Request]{
-			MaxConcurrentReconciles: r.Config.Gateway.MaxConcurrentReconciles,
-		}).
-		Named("gateway").Complete(r)
+	if r.Config.FeatureEnabled(features.L4Routes) {
+		for _, route := range []client.Object{&gatewayv1alpha2.TCPRoute{}, &gatewayv1alpha2.UDPRoute{}} {
+			downstreamRouteClusterSource, _, _ := mcsource.Kind(
+				route,
+				r.listGatewaysAttachedByDownstreamL4Route,
+				downstreamSpecOrStatusChanged[client.Object](),
+			).ForCluster("", r.DownstreamCluster)
+
+			builder = builder.
+				Watches(
+					route,
+					mchandler.EnqueueRequestsFromMapFunc(r.listGatewaysAttachedByL4Route),
+					mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()),
+				).
+				WatchesRawSource(downstreamRouteClusterSource)
+		}
+	}
+
+	if r.Config.FeatureEnabled(features.GRPCRoutes) {
+		downstreamGRPCRouteClusterSource, _, _ := mcsource.Kind(
+			&gatewayv1.GRPCRoute{},
+			r.listGatewaysAttachedByDownstreamGRPCRoute,
+			downstreamSpecOrStatusChanged[*gatewayv1.GRPCRoute](),
+		).ForCluster("", r.DownstreamCluster)
+
+		builder = builder.
+			Watches(
+				&gatewayv1.GRPCRoute{},
+				mchandler.EnqueueRequestsFromMapFunc(r.listGatewaysAttachedByGRPCRoute),
+				mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()),
+			).
+			WatchesRawSource(downstreamGRPCRouteClusterSource)
+	}
+
+	gatewayController, err := builder.
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "gateway", r.Config.Gateway.MaxConcurrentReconciles)).
+		Named("gateway").Build(r)
+	if err != nil {
+		return err
+	}
+
+	if r.Config.FeatureEnabled(features.HostnameBlocklist) {
+		return gatewayController.MultiClusterWatch(&hostnameBlocklistSource{
+			downstreamCluster: r.DownstreamCluster,
+			handlerFunc:       r.listGatewaysForHostnameBlocklistFunc,
+		})
+	}
+	return nil
 }
 
 // listGatewaysAttachedByHTTPRoute is a watch predicate which finds all Gateways mentioned
@@ -2587,23 +3816,120 @@ func (r *GatewayReconciler) listGatewaysAttachedByDownstreamHTTPRoute(clusterNam
 		logger := log.FromContext(ctx)
 		logger.Info("enqueueing upstream gateway for downstream httproute", jsonKeyName, httpRoute.Name)
 
-		var reqs []mcreconcile.Request
-		if _, ok := httpRoute.Labels[downstreamclient.UpstreamOwnerClusterNameLabel]; ok {
-			for _, parentRef := range httpRoute.Spec.ParentRefs {
+		return downstreamRouteGatewayRequests(httpRoute.Labels, httpRoute.Spec.ParentRefs)
+	})
+}
+
+// listGatewaysForDownstreamSpecDrift returns requests for the upstream Gateways
+// of a downstream resource whose spec was modified. The resource is found out
+// of sync when they are reconciled, and its desired spec is written again.
+//
+// Services and EndpointSlices are written for the backends of a downstream
+// route, which is their controller, and are mapped to the Gateways of the
+// route.
+func (r *GatewayReconciler) listGatewaysForDownstreamSpecDrift(ctx context.Context, obj client.Object) []mcreconcile.Request {
+	switch obj := obj.(type) {
+	case *gatewayv1.Gateway:
+		// Shards of a gateway share the owner labels of the upstream Gateway.
+		if _, ok := obj.Labels[downstreamclient.UpstreamOwnerClusterNameLabel]; !ok {
+			return nil
+		}
+		return []mcreconcile.Request{downstreamGatewayUpstreamRequest(obj.Labels)}
+	case *gatewayv1.HTTPRoute:
+		return downstreamRouteGatewayRequests(obj.Labels, obj.Spec.ParentRefs)
+	case *gatewayv1.GRPCRoute:
+		return downstreamRouteGatewayRequests(obj.Labels, obj.Spec.ParentRefs)
+	case *gatewayv1alpha2.TCPRoute, *gatewayv1alpha2.UDPRoute:
+		route := wrapL4Route(obj)
+		return downstreamRouteGatewayRequests(route.GetLabels(), *route.parentRefs)
+	case *corev1.Service, *discoveryv1.EndpointSlice:
+		ownerRef := metav1.GetControllerOf(obj)
+		if ownerRef == nil {
+			return nil
+		}
+		gv, err := schema.ParseGroupVersion(ownerRef.APIVersion)
+		if err != nil || gv.Group != gatewayv1.GroupName {
+			return nil
+		}
+		route, ok := newObjectForGroupKind(r.DownstreamCluster, gv.WithKind(ownerRef.Kind).GroupKind())
+		if !ok {
+			return nil
+		}
+		if err := r.DownstreamCluster.GetClient().Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: ownerRef.Name}, route); err != nil {
+			log.FromContext(ctx).Error(err, "failed to get downstream route of drifted resource", jsonKeyKind, ownerRef.Kind, jsonKeyName, ownerRef.Name)
+			return nil
+		}
+		switch route.(type) {
+		case *gatewayv1.HTTPRoute, *gatewayv1.GRPCRoute, *gatewayv1alpha2.TCPRoute, *gatewayv1alpha2.UDPRoute:
+			return r.listGatewaysForDownstreamSpecDrift(ctx, route)
+		}
+	}
+	return nil
+}
+
+// listGatewaysForConfigReload returns requests for the upstream Gateways of
+// every downstream Gateway, which are reprogrammed from the reloaded
+// configuration.
+func (r *GatewayReconciler) listGatewaysForConfigReload(ctx context.Context, _ *config.NetworkServicesOperator) []mcreconcile.Request {
+	logger := log.FromContext(ctx)
+
+	var downstreamGateways gatewayv1.GatewayList
+	if err := r.DownstreamCluster.GetClient().List(ctx, &downstreamGateways, client.MatchingLabels{
+		downstreamclient.UpstreamOwnerGroupLabel: gatewayv1.GroupName,
+		downstreamclient.UpstreamOwnerKindLabel:  KindGateway,
+	}); err != nil {
+		logger.Error(err, "failed to list downstream gateways for config reload")
+		return nil
+	}
+
+	// Shards of a gateway share the owner labels of the upstream Gateway.
+	requests := sets.New[mcreconcile.Request]()
+	for _, downstreamGateway := range downstreamGateways.Items {
+		requests.Insert(downstreamGatewayUpstreamRequest(downstreamGateway.Labels))
+	}
+	logger.Info("config reloaded, enqueueing gateways", "gateways", requests.Len())
+	return requests.UnsortedList()
+}
+
+// downstreamGatewayUpstreamRequest returns a request for the upstream Gateway
+// of a downstream Gateway, or gateway shard, from its owner labels.
+func downstreamGatewayUpstreamRequest(labels map[string]string) mcreconcile.Request {
+	return mcreconcile.Request{
+		Request: ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: labels[downstreamclient.UpstreamOwnerNamespaceLabel],
+				Name:      labels[downstreamclient.UpstreamOwnerNameLabel],
+			},
+		},
+		ClusterName: multicluster.ClusterName(downstreamclient.UpstreamClusterNameFromLabel(labels[downstreamclient.UpstreamOwnerClusterNameLabel])),
+	}
+}
+
+// downstreamRouteGatewayRequests returns requests for the upstream Gateways of
+// the downstream Gateways, or gateway shards, that a downstream route
+// references.
+func downstreamRouteGatewayRequests(routeLabels map[string]string, parentRefs []gatewayv1.ParentReference) []mcreconcile.Request {
+	var reqs []mcreconcile.Request
+	if _, ok := routeLabels[downstreamclient.UpstreamOwnerClusterNameLabel]; ok {
+		for _, parentRef := range parentRefs {
+			names := []string{string(parentRef.Name)}
+			if name, ok := upstreamGatewayNameFromShard(string(parentRef.Name)); ok {
+				names = append(names, name)
+			}
+			for _, name := range names {
 				reqs = append(reqs, mcreconcile.Request{
 					Request: ctrl.Request{
 						NamespacedName: types.NamespacedName{
-							Namespace: httpRoute.Labels[downstreamclient.UpstreamOwnerNamespaceLabel],
-							Name:      string(parentRef.Name),
+							Namespace: routeLabels[downstreamclient.UpstreamOwnerNamespaceLabel],
+							Name:      name,
 						},
 					},
-					ClusterName: multicluster.ClusterName(downstreamclient.UpstreamClusterNameFromLabel(httpRoute.Labels[downstreamclient.UpstreamOwnerClusterNameLabel])),
+					ClusterName: multicluster.ClusterName(downstreamclient.UpstreamClusterNameFromLabel(routeLabels[downstreamclient.UpstreamOwnerClusterNameLabel])),
 				})
-
 			}
 		}
-		return reqs
-	})
+	}
+	return reqs
 }
 
 // listGatewaysForEndpointSliceFunc creates an event handler that watches EndpointSlice changes
@@ -2621,45 +3947,129 @@ func (r *GatewayReconciler) listGatewaysAttachedByDownstreamHTTPRoute(clusterNam
 // change, maintaining proper traffic routing and load balancing.
 func (r *GatewayReconciler) listGatewaysForEndpointSliceFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
 	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
-		endpointSlice := obj.(*discoveryv1.EndpointSlice)
-		logger := log.FromContext(ctx)
+		return r.listGatewaysForEndpointSlice(ctx, clusterName, cl, obj.(*discoveryv1.EndpointSlice))
+	})
+}
 
-		var httpRoutes gatewayv1.HTTPRouteList
-		if err := cl.GetClient().List(ctx, &httpRoutes); err != nil {
-			logger.Error(err, "failed to list HTTPRoutes")
-			return nil
-		}
+// listGatewaysForEndpointSlice returns reconcile requests for the Gateways of
+// the routes with an EndpointSlice as a backend.
+func (r *GatewayReconciler) listGatewaysForEndpointSlice(
+	ctx context.Context,
+	clusterName multicluster.ClusterName,
+	cl cluster.Cluster,
+	endpointSlice *discoveryv1.EndpointSlice,
+) []mcreconcile.Request {
+	logger := log.FromContext(ctx)
 
-		var requests []mcreconcile.Request
+	var httpRoutes gatewayv1.HTTPRouteList
+	if err := cl.GetClient().List(ctx, &httpRoutes); err != nil {
+		logger.Error(err, "failed to list HTTPRoutes")
+		return nil
+	}
 
-		for _, route := range httpRoutes.Items {
-			for _, rule := range route.Spec.Rules {
-				for _, backendRef := range rule.BackendRefs {
-					if ptr.Deref(backendRef.Kind, "") == KindEndpointSlice {
-						backendNamespace := string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(route.Namespace)))
+	var requests []mcreconcile.Request
 
-						if backendNamespace == endpointSlice.Namespace && string(backendRef.Name) == endpointSlice.Name {
-							for _, parentRef := range route.Spec.ParentRefs {
-								if ptr.Deref(parentRef.Group, gatewayv1.GroupName) == gatewayv1.GroupName &&
-									ptr.Deref(parentRef.Kind, KindGateway) == KindGateway {
-									gatewayNamespace := string(ptr.Deref(parentRef.Namespace, gatewayv1.Namespace(route.Namespace)))
+	for _, route := range httpRoutes.Items {
+		for _, rule := range route.Spec.Rules {
+			backendRefs := make([]gatewayv1.BackendObjectReference, 0, len(rule.BackendRefs))
+			for _, backendRef := range rule.BackendRefs {
+				backendRefs = append(backendRefs, backendRef.BackendObjectReference)
+			}
+			for _, filter := range rule.Filters {
+				if filter.RequestMirror != nil {
+					backendRefs = append(backendRefs, filter.RequestMirror.BackendRef)
+				}
+			}
 
-									requests = append(requests, mcreconcile.Request{
-										ClusterName: clusterName,
-										Request: reconcile.Request{
-											NamespacedName: types.NamespacedName{
-												Namespace: gatewayNamespace,
-												Name:      string(parentRef.Name),
-											},
+			for _, backendRef := range backendRefs {
+				if ptr.Deref(backendRef.Kind, "") == KindEndpointSlice {
+					backendNamespace := string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(route.Namespace)))
+
+					if backendNamespace == endpointSlice.Namespace && string(backendRef.Name) == endpointSlice.Name {
+						for _, parentRef := range route.Spec.ParentRefs {
+							if ptr.Deref(parentRef.Group, gatewayv1.GroupName) == gatewayv1.GroupName &&
+								ptr.Deref(parentRef.Kind, KindGateway) == KindGateway {
+								gatewayNamespace := string(ptr.Deref(parentRef.Namespace, gatewayv1.Namespace(route.Namespace)))
+
+								requests = append(requests, mcreconcile.Request{
+									ClusterName: clusterName,
+									Request: reconcile.Request{
+										NamespacedName: types.NamespacedName{
+											Namespace: gatewayNamespace,
+											Name:      string(parentRef.Name),
 										},
-									})
-								}
+									},
+								})
 							}
 						}
 					}
 				}
 			}
 		}
+	}
+
+	if r.Config.FeatureEnabled(features.L4Routes) {
+		requests = append(requests, r.listGatewaysForL4RouteEndpointSlice(ctx, clusterName, cl.GetClient(), endpointSlice)...)
+	}
+	if r.Config.FeatureEnabled(features.GRPCRoutes) {
+		requests = append(requests, r.listGatewaysForGRPCRouteEndpointSlice(ctx, clusterName, cl.GetClient(), endpointSlice)...)
+	}
+
+	return requests
+}
+
+// listGatewaysForIPAddressClaimFunc enqueues the Gateways that name an
+// IPAddressClaim as an address.
+func (r *GatewayReconciler) listGatewaysForIPAddressClaimFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		logger := log.FromContext(ctx)
+
+		var gatewayList gatewayv1.GatewayList
+		if err := cl.GetClient().List(ctx, &gatewayList, client.InNamespace(obj.GetNamespace())); err != nil {
+			logger.Error(err, "failed to list Gateways")
+			return nil
+		}
+
+		var requests []mcreconcile.Request
+		for i := range gatewayList.Items {
+			if gatewayNamesIPAddressClaim(&gatewayList.Items[i], obj.GetName()) {
+				requests = append(requests, mcreconcile.Request{
+					ClusterName: clusterName,
+					Request: reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(&gatewayList.Items[i]),
+					},
+				})
+			}
+		}
+
+		return requests
+	})
+}
+
+// listGatewaysForGatewayClassFunc enqueues the gateways of a GatewayClass, so
+// that they pick up the DNS record annotations of the class.
+func (r *GatewayReconciler) listGatewaysForGatewayClassFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		logger := log.FromContext(ctx)
+
+		var gatewayList gatewayv1.GatewayList
+		if err := cl.GetClient().List(ctx, &gatewayList); err != nil {
+			logger.Error(err, "failed to list Gateways")
+			return nil
+		}
+
+		var requests []mcreconcile.Request
+		for i := range gatewayList.Items {
+			if string(gatewayList.Items[i].Spec.GatewayClassName) != obj.GetName() {
+				continue
+			}
+			requests = append(requests, mcreconcile.Request{
+				ClusterName: clusterName,
+				Request: reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&gatewayList.Items[i]),
+				},
+			})
+		}
 
 		return requests
 	})
@@ -2669,38 +4079,32 @@ func (r *GatewayReconciler) listGatewaysForDomainFunc(clusterName multicluster.C
 	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
 		domain := obj.(*networkingv1alpha.Domain)
 
-		// Only enqueue if the domain is verified
-		if !apimeta.IsStatusConditionTrue(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified) {
+		// Only enqueue if the domain is verified, or its verification expired so
+		// its hostnames must be removed from downstream listeners.
+		verified := apimeta.FindStatusCondition(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified)
+		if verified == nil || (verified.Status != metav1.ConditionTrue && verified.Reason != networkingv1alpha.DomainReasonVerificationExpired) {
 			return nil
 		}
 
 		logger := log.FromContext(ctx)
 
 		var gatewayList gatewayv1.GatewayList
-		if err := cl.GetClient().List(ctx, &gatewayList, client.InNamespace(domain.Namespace)); err != nil {
+		if err := cl.GetClient().List(ctx, &gatewayList,
+			client.InNamespace(domain.Namespace),
+			client.MatchingFields{gatewayHostnameDomainIndex: domain.Spec.DomainName},
+		); err != nil {
 			logger.Error(err, "failed to list Gateways")
 			return nil
 		}
 
-		var requests []mcreconcile.Request
-
+		requests := make([]mcreconcile.Request, 0, len(gatewayList.Items))
 		for _, gateway := range gatewayList.Items {
-			for _, l := range gateway.Spec.Listeners {
-				if l.Hostname == nil {
-					continue
-				}
-				hostname := string(*l.Hostname)
-
-				if hostname == domain.Spec.DomainName || strings.HasSuffix(hostname, "."+domain.Spec.DomainName) {
-					requests = append(requests, mcreconcile.Request{
-						ClusterName: clusterName,
-						Request: reconcile.Request{
-							NamespacedName: client.ObjectKeyFromObject(&gateway),
-						},
-					})
-					continue
-				}
-			}
+			requests = append(requests, mcreconcile.Request{
+				ClusterName: clusterName,
+				Request: reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&gateway),
+				},
+			})
 		}
 
 		return requests
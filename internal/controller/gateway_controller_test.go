@@ -24,7 +24,9 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
@@ -74,6 +76,7 @@ func TestEnsureDownstreamGateway(t *testing.T) {
 
 	tests := []struct {
 		name                      string
+		ipFamilies                []networkingv1alpha.IPFamily
 		upstreamGateway           *gatewayv1.Gateway
 		existingUpstreamObjects   []client.Object
 		existingDownstreamObjects []client.Object
@@ -118,6 +121,95 @@ func TestEnsureDownstreamGateway(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:            "ipv6 only addresses",
+			ipFamilies:      []networkingv1alpha.IPFamily{networkingv1alpha.IPv6Protocol},
+			upstreamGateway: newGateway(testConfig, upstreamNamespace.Name, "test"),
+			assert: func(t *testing.T, upstreamGateway, downstreamGateway *gatewayv1.Gateway) {
+				if assert.Len(t, upstreamGateway.Status.Addresses, 2) {
+					assert.Equal(t, "v6."+upstreamGateway.Status.Addresses[0].Value, upstreamGateway.Status.Addresses[1].Value)
+				}
+			},
+		},
+		{
+			name:       "ipv6 only drops existing ipv4 address",
+			ipFamilies: []networkingv1alpha.IPFamily{networkingv1alpha.IPv6Protocol},
+			upstreamGateway: newGateway(testConfig, upstreamNamespace.Name, "test", func(g *gatewayv1.Gateway) {
+				for _, value := range []string{"test.example.com", "v4.test.example.com", "v6.test.example.com"} {
+					g.Status.Addresses = append(g.Status.Addresses, gatewayv1.GatewayStatusAddress{
+						Type:  ptr.To(gatewayv1.HostnameAddressType),
+						Value: value,
+					})
+				}
+			}),
+			assert: func(t *testing.T, upstreamGateway, downstreamGateway *gatewayv1.Gateway) {
+				if assert.Len(t, upstreamGateway.Status.Addresses, 2) {
+					assert.Equal(t, "test.example.com", upstreamGateway.Status.Addresses[0].Value)
+					assert.Equal(t, "v6.test.example.com", upstreamGateway.Status.Addresses[1].Value)
+				}
+			},
+		},
+		{
+			name: "static addresses",
+			upstreamGateway: newGateway(testConfig, upstreamNamespace.Name, "test", func(g *gatewayv1.Gateway) {
+				g.Spec.Addresses = []gatewayv1.GatewaySpecAddress{
+					{Type: ptr.To(gatewayv1.IPAddressType), Value: "2001:db8::10"},
+				}
+			}),
+			assert: func(t *testing.T, upstreamGateway, downstreamGateway *gatewayv1.Gateway) {
+				assert.Equal(t, upstreamGateway.Spec.Addresses, downstreamGateway.Spec.Addresses)
+
+				// Only the address specific to the family of the static address is
+				// added.
+				if assert.Len(t, upstreamGateway.Status.Addresses, 2) {
+					assert.Equal(t, "v6."+upstreamGateway.Status.Addresses[0].Value, upstreamGateway.Status.Addresses[1].Value)
+				}
+			},
+		},
+		{
+			name: "ip address claim",
+			upstreamGateway: newGateway(testConfig, upstreamNamespace.Name, "test", func(g *gatewayv1.Gateway) {
+				g.Spec.Addresses = []gatewayv1.GatewaySpecAddress{
+					{Type: ptr.To(gatewayv1.NamedAddressType), Value: "stable"},
+				}
+			}),
+			existingUpstreamObjects: []client.Object{
+				&networkingv1alpha.IPAddressClaim{
+					ObjectMeta: metav1.ObjectMeta{Namespace: upstreamNamespace.Name, Name: "stable"},
+					Spec:       networkingv1alpha.IPAddressClaimSpec{SubnetClass: "gateway-static", IPFamily: networkingv1alpha.IPv4Protocol},
+					Status:     networkingv1alpha.IPAddressClaimStatus{Address: "192.0.2.1"},
+				},
+			},
+			assert: func(t *testing.T, upstreamGateway, downstreamGateway *gatewayv1.Gateway) {
+				// The downstream gateway requests the address allocated to the claim.
+				assert.Equal(t, []gatewayv1.GatewaySpecAddress{
+					{Type: ptr.To(gatewayv1.IPAddressType), Value: "192.0.2.1"},
+				}, downstreamGateway.Spec.Addresses)
+
+				if assert.Len(t, upstreamGateway.Status.Addresses, 2) {
+					assert.Equal(t, "v4."+upstreamGateway.Status.Addresses[0].Value, upstreamGateway.Status.Addresses[1].Value)
+				}
+			},
+		},
+		{
+			name: "unallocated ip address claim",
+			upstreamGateway: newGateway(testConfig, upstreamNamespace.Name, "test", func(g *gatewayv1.Gateway) {
+				g.Spec.Addresses = []gatewayv1.GatewaySpecAddress{
+					{Type: ptr.To(gatewayv1.NamedAddressType), Value: "stable"},
+				}
+			}),
+			existingUpstreamObjects: []client.Object{
+				&networkingv1alpha.IPAddressClaim{
+					ObjectMeta: metav1.ObjectMeta{Namespace: upstreamNamespace.Name, Name: "stable"},
+					Spec:       networkingv1alpha.IPAddressClaimSpec{SubnetClass: "gateway-static", IPFamily: networkingv1alpha.IPv4Protocol},
+				},
+			},
+			assert: func(t *testing.T, upstreamGateway, downstreamGateway *gatewayv1.Gateway) {
+				// The gateway isn't programmed until the claim is allocated.
+				assert.Nil(t, downstreamGateway)
+				assert.Empty(t, upstreamGateway.Status.Addresses)
+			},
+		},
 		{
 			name: "hostname claimed by different gateway",
 			upstreamGateway: newGateway(testConfig, upstreamNamespace.Name, "test", func(g *gatewayv1.Gateway) {
@@ -143,6 +235,16 @@ func TestEnsureDownstreamGateway(t *testing.T) {
 						Status: metav1.ConditionTrue,
 					})
 				}),
+				newGateway(testConfig, "other", "gateway", func(g *gatewayv1.Gateway) {
+					g.Spec.Listeners = []gatewayv1.Listener{
+						{
+							Name:     "custom-hostname-0",
+							Port:     DefaultHTTPPort,
+							Protocol: gatewayv1.HTTPProtocolType,
+							Hostname: ptr.To(gatewayv1.Hostname("example.com")),
+						},
+					}
+				}),
 			},
 			existingDownstreamObjects: []client.Object{
 				&corev1.ConfigMap{
@@ -181,6 +283,12 @@ func TestEnsureDownstreamGateway(t *testing.T) {
 						assert.Equal(t, metav1.ConditionFalse, programmedCondition.Status)
 						assert.Equal(t, networkingv1alpha.HostnameInUseReason, programmedCondition.Reason)
 					}
+
+					conflictedCondition := apimeta.FindStatusCondition(listenerStatus.Conditions, string(gatewayv1.ListenerConditionConflicted))
+					if assert.NotNil(t, conflictedCondition, "did not find conflicted condition on listener") {
+						assert.Equal(t, metav1.ConditionTrue, conflictedCondition.Status)
+						assert.Equal(t, string(gatewayv1.ListenerReasonHostnameConflict), conflictedCondition.Reason)
+					}
 				}
 			},
 		},
@@ -205,8 +313,7 @@ func TestEnsureDownstreamGateway(t *testing.T) {
 				obj.SetCreationTimestamp(metav1.Now())
 			}
 
-			fakeUpstreamClient := fake.NewClientBuilder().
-				WithScheme(testScheme).
+			fakeUpstreamClient := withDomainIndexes(fake.NewClientBuilder().WithScheme(testScheme)).
 				WithObjects(tt.upstreamGateway, upstreamNamespace).
 				WithObjects(tt.existingUpstreamObjects...).
 				WithStatusSubresource(tt.upstreamGateway).
@@ -225,9 +332,14 @@ func TestEnsureDownstreamGateway(t *testing.T) {
 
 			mgr := &fakeMockManager{cl: fakeUpstreamClient}
 
+			reconcilerConfig := testConfig
+			if tt.ipFamilies != nil {
+				reconcilerConfig.Gateway.IPFamilies = tt.ipFamilies
+			}
+
 			reconciler := &GatewayReconciler{
 				mgr:               mgr,
-				Config:            testConfig,
+				Config:            reconcilerConfig,
 				DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
 			}
 
@@ -246,6 +358,13 @@ func TestEnsureDownstreamGateway(t *testing.T) {
 			_, err := result.Complete(ctx)
 			assert.NoError(t, err, "failed completing result")
 
+			// The desired spec written to the downstream gateway is recorded for
+			// the downstream audit.
+			if downstreamGateway != nil && downstreamGateway.ResourceVersion != "" {
+				assert.NotEmpty(t, downstreamGateway.Annotations[downstreamclient.DesiredHashAnnotation])
+				assert.Contains(t, downstreamGateway.Annotations, downstreamclient.ObservedGenerationAnnotation)
+			}
+
 			if tt.assert != nil {
 				updatedUpstreamGateway := &gatewayv1.Gateway{}
 
@@ -257,6 +376,138 @@ func TestEnsureDownstreamGateway(t *testing.T) {
 	}
 }
 
+func TestEnsureDownstreamGatewayDNSEndpoints(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	downstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-test", Name: "gateway", UID: uuid.NewUUID()},
+		Status: gatewayv1.GatewayStatus{
+			Addresses: []gatewayv1.GatewayStatusAddress{
+				{Type: ptr.To(gatewayv1.IPAddressType), Value: "192.0.2.1"},
+				{Type: ptr.To(gatewayv1.IPAddressType), Value: "2001:db8::1"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		ipFamilies      []networkingv1alpha.IPFamily
+		staticAddresses []string
+		registry        config.GatewayDNSEndpointRegistryConfig
+		hostnames       []string
+		wantRecords     []string
+	}{
+		{
+			name:       "dual stack",
+			ipFamilies: []networkingv1alpha.IPFamily{networkingv1alpha.IPv4Protocol, networkingv1alpha.IPv6Protocol},
+			hostnames:  []string{"gateway.example.com", "v4.gateway.example.com", "v6.gateway.example.com"},
+			wantRecords: []string{
+				"gateway.example.com A 192.0.2.1",
+				"gateway.example.com AAAA 2001:db8::1",
+				"v4.gateway.example.com A 192.0.2.1",
+				"v6.gateway.example.com AAAA 2001:db8::1",
+			},
+		},
+		{
+			name:       "ipv6 only",
+			ipFamilies: []networkingv1alpha.IPFamily{networkingv1alpha.IPv6Protocol},
+			hostnames:  []string{"gateway.example.com", "v6.gateway.example.com"},
+			wantRecords: []string{
+				"gateway.example.com AAAA 2001:db8::1",
+				"v6.gateway.example.com AAAA 2001:db8::1",
+			},
+		},
+		{
+			name:            "static addresses",
+			ipFamilies:      []networkingv1alpha.IPFamily{networkingv1alpha.IPv4Protocol, networkingv1alpha.IPv6Protocol},
+			staticAddresses: []string{"2001:db8::1"},
+			hostnames:       []string{"gateway.example.com", "v6.gateway.example.com"},
+			wantRecords: []string{
+				"gateway.example.com AAAA 2001:db8::1",
+				"v6.gateway.example.com AAAA 2001:db8::1",
+			},
+		},
+		{
+			name:       "txt registry records",
+			ipFamilies: []networkingv1alpha.IPFamily{networkingv1alpha.IPv4Protocol, networkingv1alpha.IPv6Protocol},
+			registry:   config.GatewayDNSEndpointRegistryConfig{OwnerID: "nso", Prefix: "_owner."},
+			hostnames:  []string{"gateway.example.com", "v4.gateway.example.com"},
+			wantRecords: []string{
+				"gateway.example.com A 192.0.2.1",
+				`_owner.a-gateway.example.com TXT "heritage=external-dns,external-dns/owner=nso,external-dns/resource=crd/ns-test/gateway"`,
+				"gateway.example.com AAAA 2001:db8::1",
+				`_owner.aaaa-gateway.example.com TXT "heritage=external-dns,external-dns/owner=nso,external-dns/resource=crd/ns-test/gateway"`,
+				"v4.gateway.example.com A 192.0.2.1",
+				`_owner.a-v4.gateway.example.com TXT "heritage=external-dns,external-dns/owner=nso,external-dns/resource=crd/ns-test/gateway"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeDownstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+			reconciler := &GatewayReconciler{
+				Config: config.NetworkServicesOperator{
+					Gateway: config.GatewayConfig{IPFamilies: tt.ipFamilies, DNSEndpointRegistry: tt.registry},
+				},
+			}
+			downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeDownstreamClient, fakeDownstreamClient)
+
+			downstreamGateway := downstreamGateway.DeepCopy()
+			for _, address := range tt.staticAddresses {
+				downstreamGateway.Spec.Addresses = append(downstreamGateway.Spec.Addresses, gatewayv1.GatewaySpecAddress{Value: address})
+			}
+
+			result := reconciler.ensureDownstreamGatewayDNSEndpoints(ctx, downstreamGateway, downstreamStrategy, tt.hostnames, gatewayDNSRecords{ttl: 300})
+			require.NoError(t, result.Err)
+			assert.Zero(t, result.RequeueAfter)
+
+			var dnsEndpoint unstructured.Unstructured
+			dnsEndpoint.SetGroupVersionKind(schema.GroupVersionKind{Group: "externaldns.k8s.io", Version: versionV1Alpha1, Kind: "DNSEndpoint"})
+			require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(downstreamGateway), &dnsEndpoint))
+
+			endpoints, _, err := unstructured.NestedSlice(dnsEndpoint.Object, "spec", "endpoints")
+			require.NoError(t, err)
+			var records []string
+			for _, endpoint := range endpoints {
+				endpoint := endpoint.(map[string]any)
+				for _, target := range endpoint["targets"].([]any) {
+					records = append(records, fmt.Sprintf("%s %s %s", endpoint["dnsName"], endpoint["recordType"], target))
+				}
+			}
+			assert.Equal(t, tt.wantRecords, records)
+		})
+	}
+}
+
+func TestBoundStaticGatewayAddresses(t *testing.T) {
+	upstreamGateway := &gatewayv1.Gateway{
+		Spec: gatewayv1.GatewaySpec{
+			Addresses: []gatewayv1.GatewaySpecAddress{
+				{Value: "192.0.2.1"},
+				{Type: ptr.To(gatewayv1.IPAddressType), Value: "2001:db8:0::1"},
+			},
+		},
+	}
+	downstreamGateway := &gatewayv1.Gateway{
+		Status: gatewayv1.GatewayStatus{
+			Addresses: []gatewayv1.GatewayStatusAddress{
+				{Type: ptr.To(gatewayv1.IPAddressType), Value: "2001:db8::1"},
+				{Type: ptr.To(gatewayv1.IPAddressType), Value: "198.51.100.1"},
+			},
+		},
+	}
+
+	// The IPv4 address is not yet bound by the downstream gateway.
+	assert.Equal(t, []gatewayv1.GatewayStatusAddress{
+		{Type: ptr.To(gatewayv1.IPAddressType), Value: "2001:db8::1"},
+	}, boundStaticGatewayAddresses(staticGatewayAddresses(upstreamGateway), downstreamGateway))
+}
+
 func TestPrepareUpstreamGateway_UsesExistingCanonicalHostname(t *testing.T) {
 	testConfig := config.NetworkServicesOperator{
 		Gateway: config.GatewayConfig{
@@ -656,8 +907,7 @@ func TestEnsureDownstreamGatewayWildcardCert(t *testing.T) {
 				obj.SetCreationTimestamp(metav1.Now())
 			}
 
-			fakeUpstreamClient := fake.NewClientBuilder().
-				WithScheme(testScheme).
+			fakeUpstreamClient := withDomainIndexes(fake.NewClientBuilder().WithScheme(testScheme)).
 				WithObjects(tt.upstreamGateway, upstreamNamespace).
 				WithObjects(tt.existingUpstreamObjects...).
 				WithStatusSubresource(tt.upstreamGateway).
@@ -776,6 +1026,8 @@ func TestListenerCertificateHealthGating(t *testing.T) {
 		resolvedRefs          metav1.ConditionStatus
 		resolvedRefsReason    string // when False
 		expectMessageContains string // substring of the user-facing message (when unhealthy)
+		certificateReason     string // CertificateReady reason
+		certificateMessage    string // substring of the CertificateReady message, if it differs
 	}
 
 	tests := []struct {
@@ -793,7 +1045,8 @@ func TestListenerCertificateHealthGating(t *testing.T) {
 				{gatewayName: "test-gw", listenerName: "https-0", hostname: "a.example.com", ready: true},
 			},
 			expect: []listenerExpect{
-				{name: "https-0", present: true, programmed: metav1.ConditionTrue, resolvedRefs: metav1.ConditionTrue},
+				{name: "https-0", present: true, programmed: metav1.ConditionTrue, resolvedRefs: metav1.ConditionTrue,
+					certificateReason: ListenerReasonCertificateReady},
 			},
 		},
 		{
@@ -807,7 +1060,7 @@ func TestListenerCertificateHealthGating(t *testing.T) {
 			expect: []listenerExpect{
 				{name: "https-0", present: false, programmed: metav1.ConditionFalse,
 					resolvedRefs: metav1.ConditionFalse, resolvedRefsReason: string(gatewayv1.ListenerReasonInvalidCertificateRef),
-					expectMessageContains: "has expired"},
+					expectMessageContains: "has expired", certificateReason: ListenerReasonCertificateExpired},
 			},
 		},
 		{
@@ -834,7 +1087,22 @@ func TestListenerCertificateHealthGating(t *testing.T) {
 			expect: []listenerExpect{
 				{name: "https-0", present: false, programmed: metav1.ConditionFalse,
 					resolvedRefs: metav1.ConditionFalse, resolvedRefsReason: string(gatewayv1.ListenerReasonInvalidCertificateRef),
-					expectMessageContains: "couldn't issue"},
+					expectMessageContains: "couldn't issue", certificateReason: ListenerReasonCertificateIssuing},
+			},
+		},
+		{
+			name:      "failed cert reports the issuance error",
+			listeners: []gatewayv1.Listener{customHTTPSListener("https-0", "a.example.com")},
+			domains:   []client.Object{verifiedDomain("a.example.com")},
+			certStates: []listenerCertState{
+				{gatewayName: "test-gw", listenerName: "https-0", hostname: "a.example.com", ready: false,
+					failureMessage: "CAA record forbids issuance"},
+			},
+			expect: []listenerExpect{
+				{name: "https-0", present: false, programmed: metav1.ConditionFalse,
+					resolvedRefs: metav1.ConditionFalse, resolvedRefsReason: string(gatewayv1.ListenerReasonInvalidCertificateRef),
+					expectMessageContains: "couldn't issue", certificateReason: ListenerReasonCertificateFailed,
+					certificateMessage: "Last issuance error: CAA record forbids issuance"},
 			},
 		},
 		{
@@ -903,13 +1171,30 @@ func TestListenerCertificateHealthGating(t *testing.T) {
 				downstreamObjects = append(downstreamObjects, newDownstreamListenerCertObjects(t, downstreamNamespaceName, s)...)
 			}
 
+			// The edge reports the listeners that are sent to it as programmed.
+			edgeGateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespaceName, Name: "test-gw"},
+			}
+			for _, exp := range tt.expect {
+				if !exp.present {
+					continue
+				}
+				edgeGateway.Status.Listeners = append(edgeGateway.Status.Listeners, gatewayv1.ListenerStatus{
+					Name: exp.name,
+					Conditions: []metav1.Condition{
+						{Type: string(gatewayv1.ListenerConditionProgrammed), Status: metav1.ConditionTrue, Reason: string(gatewayv1.ListenerReasonProgrammed)},
+						{Type: string(gatewayv1.ListenerConditionResolvedRefs), Status: metav1.ConditionTrue, Reason: string(gatewayv1.ListenerReasonResolvedRefs)},
+					},
+				})
+			}
+			downstreamObjects = append(downstreamObjects, edgeGateway)
+
 			for _, obj := range append(append([]client.Object{}, upstreamObjects...), downstreamObjects...) {
 				obj.SetUID(uuid.NewUUID())
 				obj.SetCreationTimestamp(metav1.Now())
 			}
 
-			fakeUpstreamClient := fake.NewClientBuilder().
-				WithScheme(testScheme).
+			fakeUpstreamClient := withDomainIndexes(fake.NewClientBuilder().WithScheme(testScheme)).
 				WithObjects(upstreamGateway, upstreamNamespace).
 				WithObjects(upstreamObjects...).
 				WithStatusSubresource(upstreamGateway).
@@ -977,6 +1262,19 @@ func TestListenerCertificateHealthGating(t *testing.T) {
 					}
 				}
 
+				if exp.certificateReason != "" {
+					certificateReady := apimeta.FindStatusCondition(ls.Conditions, ListenerConditionCertificateReady)
+					if assert.NotNil(t, certificateReady, "certificateReady condition missing on %q", exp.name) {
+						assert.Equal(t, exp.certificateReason, certificateReady.Reason, "certificateReady reason on %q", exp.name)
+						assert.Equal(t, exp.certificateReason == ListenerReasonCertificateReady, certificateReady.Status == metav1.ConditionTrue)
+						expectedMessage := exp.expectMessageContains
+						if exp.certificateMessage != "" {
+							expectedMessage = exp.certificateMessage
+						}
+						assert.Contains(t, certificateReady.Message, expectedMessage, "certificateReady message on %q", exp.name)
+					}
+				}
+
 				// Accepted must remain True regardless of cert health.
 				accepted := apimeta.FindStatusCondition(ls.Conditions, string(gatewayv1.ListenerConditionAccepted))
 				if assert.NotNil(t, accepted, "accepted condition missing on %q", exp.name) {
@@ -1185,6 +1483,10 @@ func TestEnsureDownstreamGatewayHTTPRoutes(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			assert.NoError(t, result.Err, "failed ensuring downstream gateway HTTPRoutes")
 
@@ -1350,6 +1652,16 @@ func TestEnsureHostnamesClaimed(t *testing.T) {
 						Status: metav1.ConditionTrue,
 					})
 				}),
+				newGateway(testConfig, "other", "gateway", func(g *gatewayv1.Gateway) {
+					g.Spec.Listeners = []gatewayv1.Listener{
+						{
+							Name:     "custom-hostname-0",
+							Port:     DefaultHTTPPort,
+							Protocol: gatewayv1.HTTPProtocolType,
+							Hostname: ptr.To(gatewayv1.Hostname("example.com")),
+						},
+					}
+				}),
 			},
 			existingDownstreamObjects: []client.Object{
 				&corev1.ConfigMap{
@@ -1366,6 +1678,40 @@ func TestEnsureHostnamesClaimed(t *testing.T) {
 			expectedClaimedHostnames:    []string{"test.example.com"},
 			expectedNotClaimedHostnames: []string{"example.com"},
 		},
+		{
+			name: "verified domain exists and hostname claim of a deleted gateway is taken over",
+			upstreamGateway: newGateway(testConfig, upstreamNamespace.Name, "test", func(g *gatewayv1.Gateway) {
+				g.Spec.Listeners = []gatewayv1.Listener{
+					{
+						Name:     "listener-1",
+						Port:     DefaultHTTPPort,
+						Protocol: gatewayv1.HTTPProtocolType,
+						Hostname: ptr.To(gatewayv1.Hostname("example.com")),
+					},
+				}
+			}),
+			existingUpstreamObjects: []client.Object{
+				newDomain(upstreamNamespace.Name, "example.com", func(d *networkingv1alpha.Domain) {
+					apimeta.SetStatusCondition(&d.Status.Conditions, metav1.Condition{
+						Type:   networkingv1alpha.DomainConditionVerified,
+						Status: metav1.ConditionTrue,
+					})
+				}),
+			},
+			existingDownstreamObjects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testConfig.Gateway.DownstreamHostnameAccountingNamespace,
+						Name:      "example.com",
+					},
+					Data: map[string]string{
+						"owner": "some/other/gateway",
+					},
+				},
+			},
+			expectedVerifiedHostnames: []string{"example.com"},
+			expectedClaimedHostnames:  []string{"example.com"},
+		},
 		{
 			name: "hostname verified by being programmed on downstream gateway",
 			upstreamGateway: newGateway(testConfig, upstreamNamespace.Name, "test", func(g *gatewayv1.Gateway) {
@@ -1508,8 +1854,7 @@ func TestEnsureHostnamesClaimed(t *testing.T) {
 				tt.downstreamGateway = &gatewayv1.Gateway{}
 			}
 
-			fakeUpstreamClient := fake.NewClientBuilder().
-				WithScheme(testScheme).
+			fakeUpstreamClient := withDomainIndexes(fake.NewClientBuilder().WithScheme(testScheme)).
 				WithObjects(tt.upstreamGateway, upstreamNamespace).
 				WithObjects(tt.existingUpstreamObjects...).
 				WithStatusSubresource(tt.upstreamGateway).
@@ -1540,6 +1885,7 @@ func TestEnsureHostnamesClaimed(t *testing.T) {
 				fakeUpstreamClient,
 				tt.upstreamGateway,
 				tt.downstreamGateway,
+				nil,
 			)
 
 			if assert.NoError(t, err, "unexpected error calling ensureHostnameVerification") {
@@ -1734,9 +2080,10 @@ type listenerCertState struct {
 	listenerName gatewayv1.SectionName
 	hostname     string
 
-	ready     bool       // Certificate Ready condition
-	notBefore *time.Time // Certificate.status.NotBefore (defaults to past)
-	notAfter  *time.Time // Certificate.status.NotAfter (defaults to far future)
+	ready          bool       // Certificate Ready condition
+	failureMessage string     // seed a failed issuance with this message
+	notBefore      *time.Time // Certificate.status.NotBefore (defaults to past)
+	notAfter       *time.Time // Certificate.status.NotAfter (defaults to far future)
 
 	omitSecret     bool       // do not create the backing Secret
 	mismatchedKey  bool       // seed a Secret whose key does not match the cert
@@ -1775,6 +2122,15 @@ func newDownstreamListenerCertObjects(t *testing.T, namespace string, s listener
 	cert.Status.Conditions = []cmv1.CertificateCondition{
 		{Type: cmv1.CertificateConditionReady, Status: readyStatus},
 	}
+	if s.failureMessage != "" {
+		cert.Status.LastFailureTime = &metav1.Time{Time: now}
+		cert.Status.Conditions = append(cert.Status.Conditions, cmv1.CertificateCondition{
+			Type:    cmv1.CertificateConditionIssuing,
+			Status:  cmmeta.ConditionFalse,
+			Reason:  "Failed",
+			Message: s.failureMessage,
+		})
+	}
 
 	objs := make([]client.Object, 0, 2)
 	objs = append(objs, cert)
@@ -2282,3 +2638,42 @@ func TestReconcileRequeuesWhenGatewayClassUnavailable(t *testing.T) {
 		})
 	}
 }
+
+func TestListGatewaysForConfigReload(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	ownerLabels := func(name string) map[string]string {
+		return map[string]string{
+			downstreamclient.UpstreamOwnerClusterNameLabel: downstreamclient.UpstreamClusterNameLabelValue("test-cluster"),
+			downstreamclient.UpstreamOwnerGroupLabel:       gatewayv1.GroupName,
+			downstreamclient.UpstreamOwnerKindLabel:        KindGateway,
+			downstreamclient.UpstreamOwnerNamespaceLabel:   "default",
+			downstreamclient.UpstreamOwnerNameLabel:        name,
+		}
+	}
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			&gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "gw", Labels: ownerLabels("gw")}},
+			&gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "gw-shard-1", Labels: ownerLabels("gw")}},
+			&gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "other", Labels: ownerLabels("other")}},
+			&gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "unmanaged"}},
+		).
+		Build()
+
+	reconciler := &GatewayReconciler{
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+
+	newRequest := func(name string) mcreconcile.Request {
+		return mcreconcile.Request{
+			Request:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}},
+			ClusterName: "test-cluster",
+		}
+	}
+	assert.ElementsMatch(t,
+		[]mcreconcile.Request{newRequest("gw"), newRequest("other")},
+		reconciler.listGatewaysForConfigReload(context.Background(), &config.NetworkServicesOperator{}),
+	)
+}
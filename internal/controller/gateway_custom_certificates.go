@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+const (
+	// customCertificateSecretLabel marks downstream Secrets that hold a copy of
+	// a certificate provided by the user.
+	customCertificateSecretLabel = "networking.datumapis.com/custom-certificate"
+)
+
+// customListenerCertificate is the certificate provided by the user for a
+// listener through its certificateRefs.
+type customListenerCertificate struct {
+	status listenerCertStatus
+	// secret is the upstream Secret, set when its certificate is usable.
+	secret *corev1.Secret
+}
+
+// hasCustomCertificate reports whether the listener serves a certificate
+// provided by the user.
+func hasCustomCertificate(l gatewayv1.Listener) bool {
+	return l.TLS != nil && len(l.TLS.CertificateRefs) > 0
+}
+
+// evaluateCustomListenerCertificates loads and validates the Secrets referenced
+// by the certificateRefs of listeners with claimed hostnames.
+func (r *GatewayReconciler) evaluateCustomListenerCertificates(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	claimedHostnames []string,
+) (map[gatewayv1.SectionName]customListenerCertificate, error) {
+	logger := log.FromContext(ctx)
+	certs := map[gatewayv1.SectionName]customListenerCertificate{}
+	now := time.Now()
+
+	for _, l := range upstreamGateway.Spec.Listeners {
+		if !hasCustomCertificate(l) || l.Hostname == nil {
+			continue
+		}
+		hostname := string(*l.Hostname)
+		if !slices.Contains(claimedHostnames, hostname) {
+			continue
+		}
+
+		secretName := listenerCertificateSecretName(upstreamGateway.Name, l.Name)
+		ref := l.TLS.CertificateRefs[0]
+
+		var secret corev1.Secret
+		if err := upstreamClient.Get(ctx, client.ObjectKey{Namespace: upstreamGateway.Namespace, Name: string(ref.Name)}, &secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get certificate Secret %s: %w", ref.Name, err)
+			}
+			certs[l.Name] = customListenerCertificate{status: listenerCertStatus{
+				certificateReason: ListenerReasonCertificateFailed,
+				reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+				message:           customCertNotFoundMessage(hostname, string(ref.Name)),
+				secretName:        secretName,
+			}}
+			continue
+		}
+
+		status := customCertificateStatus(&secret, hostname, now, r.customCertificateRoots)
+		status.secretName = secretName
+		cert := customListenerCertificate{status: status}
+		if status.healthy {
+			cert.secret = &secret
+		} else {
+			logger.Info("listener certificate Secret is not usable", "listener", l.Name, "secret", secret.Name, "message", status.message)
+		}
+		certs[l.Name] = cert
+	}
+
+	return certs, nil
+}
+
+// customCertificateStatus validates that the certificate in a Secret matches
+// its key, chains to a trusted root, is currently valid, and covers the
+// hostname of the listener. System roots are used when roots is nil.
+func customCertificateStatus(secret *corev1.Secret, hostname string, now time.Time, roots *x509.CertPool) listenerCertStatus {
+	invalid := func(certificateReason, message string) listenerCertStatus {
+		return listenerCertStatus{
+			certificateReason: certificateReason,
+			reason:            gatewayv1.ListenerReasonInvalidCertificateRef,
+			message:           message,
+		}
+	}
+
+	keyPair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return invalid(ListenerReasonCertificateFailed, customCertInvalidMessage(hostname, secret.Name))
+	}
+	leaf := keyPair.Leaf
+	if leaf == nil {
+		if leaf, err = x509.ParseCertificate(keyPair.Certificate[0]); err != nil {
+			return invalid(ListenerReasonCertificateFailed, customCertInvalidMessage(hostname, secret.Name))
+		}
+	}
+
+	if !leaf.NotAfter.After(now) {
+		return invalid(ListenerReasonCertificateExpired, customCertExpiredMessage(hostname, secret.Name))
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, der := range keyPair.Certificate[1:] {
+		if cert, err := x509.ParseCertificate(der); err == nil {
+			intermediates.AddCert(cert)
+		}
+	}
+
+	// A certificate for a wildcard hostname must itself be a wildcard, so check
+	// coverage of a name that is not expected to be listed explicitly.
+	dnsName := hostname
+	if strings.HasPrefix(hostname, "*.") {
+		dnsName = "datum-wildcard-check" + strings.TrimPrefix(hostname, "*")
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{
+		DNSName:       dnsName,
+		Intermediates: intermediates,
+		Roots:         roots,
+		CurrentTime:   now,
+	})
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	switch {
+	case err == nil:
+	case errors.As(err, &hostnameErr):
+		return invalid(ListenerReasonCertificateFailed, customCertHostnameMessage(hostname, secret.Name))
+	case errors.As(err, &invalidErr) && invalidErr.Reason == x509.Expired:
+		if leaf.NotBefore.After(now) {
+			return invalid(ListenerReasonCertificateFailed, certNotYetValidMessage(hostname))
+		}
+		return invalid(ListenerReasonCertificateExpired, customCertExpiredMessage(hostname, secret.Name))
+	default:
+		return invalid(ListenerReasonCertificateFailed, customCertUntrustedMessage(hostname, secret.Name))
+	}
+
+	return listenerCertStatus{
+		healthy:           true,
+		certificateReason: ListenerReasonCertificateReady,
+		notAfter:          &metav1.Time{Time: leaf.NotAfter},
+	}
+}
+
+// ensureCustomCertificateSecrets copies usable certificates provided by the
+// user into the downstream namespace, updating the copies when the upstream
+// Secret or the copy itself changes, and deletes copies that are no longer
+// referenced by a listener.
+func (r *GatewayReconciler) ensureCustomCertificateSecrets(
+	ctx context.Context,
+	upstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	certs map[gatewayv1.SectionName]customListenerCertificate,
+) (result Result) {
+	secrets := make([]downstreamclient.DownstreamSecret, 0, len(certs))
+	for _, cert := range certs {
+		// Listeners with an unusable certificate are not programmed, keep any
+		// existing copy until the certificate is fixed or removed.
+		secrets = append(secrets, downstreamclient.DownstreamSecret{
+			Name:   cert.status.secretName,
+			Source: cert.secret,
+		})
+	}
+
+	secretSync := downstreamclient.NewSecretSync(
+		downstreamStrategy,
+		customCertificateSecretLabel,
+		downstreamclient.WithSecretKeys(corev1.TLSCertKey, corev1.TLSPrivateKeyKey),
+		downstreamclient.WithDownstreamSecretType(corev1.SecretTypeTLS),
+	)
+	if err := secretSync.Sync(ctx, upstreamGateway, secrets); err != nil {
+		result.Err = fmt.Errorf("failed syncing certificate Secrets: %w", err)
+	}
+	return result
+}
+
+// listGatewaysForSecretFunc enqueues the Gateways with a listener whose
+// certificateRefs reference a Secret.
+func (r *GatewayReconciler) listGatewaysForSecretFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		secret := obj.(*corev1.Secret)
+
+		logger := log.FromContext(ctx)
+
+		var gatewayList gatewayv1.GatewayList
+		if err := cl.GetClient().List(ctx, &gatewayList, client.InNamespace(secret.Namespace)); err != nil {
+			logger.Error(err, "failed to list Gateways")
+			return nil
+		}
+
+		var requests []mcreconcile.Request
+		for _, gateway := range gatewayList.Items {
+			referenced := gatewayReferencesClientCA(&gateway, "Secret", secret.Name)
+			for _, l := range gateway.Spec.Listeners {
+				if hasCustomCertificate(l) && string(l.TLS.CertificateRefs[0].Name) == secret.Name {
+					referenced = true
+					break
+				}
+			}
+			if referenced {
+				requests = append(requests, mcreconcile.Request{
+					ClusterName: clusterName,
+					Request: reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(&gateway),
+					},
+				})
+			}
+		}
+
+		return append(requests, r.listGatewaysForBackendCACertificate(ctx, clusterName, cl, "Secret", secret)...)
+	})
+}
+
+// These messages are shown to customers, so they stay plain and name the
+// hostname and Secret affected.
+
+func customCertNotFoundMessage(hostname, secretName string) string {
+	return fmt.Sprintf("The TLS certificate Secret %q for %s was not found, so HTTPS for this hostname is unavailable.", secretName, hostname)
+}
+
+func customCertInvalidMessage(hostname, secretName string) string {
+	return fmt.Sprintf("The Secret %q for %s does not contain a valid TLS certificate and matching private key, so HTTPS for this hostname is unavailable.", secretName, hostname)
+}
+
+func customCertExpiredMessage(hostname, secretName string) string {
+	return fmt.Sprintf("The TLS certificate in Secret %q for %s has expired, so HTTPS for this hostname is paused until the Secret is updated with a valid certificate.", secretName, hostname)
+}
+
+func customCertHostnameMessage(hostname, secretName string) string {
+	return fmt.Sprintf("The TLS certificate in Secret %q does not cover %s, so HTTPS for this hostname is unavailable.", secretName, hostname)
+}
+
+func customCertUntrustedMessage(hostname, secretName string) string {
+	return fmt.Sprintf("The TLS certificate in Secret %q for %s is not issued by a trusted certificate authority. Include any intermediate certificates in the Secret.", secretName, hostname)
+}
@@ -0,0 +1,296 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
+)
+
+// trustedCertPool returns a pool trusting the self-signed certificates.
+func trustedCertPool(t *testing.T, certPEMs ...[]byte) *x509.CertPool {
+	t.Helper()
+	pool := x509.NewCertPool()
+	for _, certPEM := range certPEMs {
+		block, _ := pem.Decode(certPEM)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+		pool.AddCert(cert)
+	}
+	return pool
+}
+
+func TestCustomCertificateStatus(t *testing.T) {
+	now := time.Now()
+	certPEM, keyPEM := generateTLSKeyPair(t, "a.example.com", now.Add(-time.Hour), now.Add(24*time.Hour))
+	expiredPEM, expiredKeyPEM := generateTLSKeyPair(t, "a.example.com", now.Add(-48*time.Hour), now.Add(-24*time.Hour))
+	_, otherKeyPEM := generateTLSKeyPair(t, "a.example.com", now.Add(-time.Hour), now.Add(24*time.Hour))
+	roots := trustedCertPool(t, certPEM, expiredPEM)
+
+	secret := func(certPEM, keyPEM []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom-cert"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		secret          *corev1.Secret
+		hostname        string
+		roots           *x509.CertPool
+		expectedReason  string
+		messageContains string
+	}{
+		{
+			name:           "valid",
+			secret:         secret(certPEM, keyPEM),
+			hostname:       "a.example.com",
+			roots:          roots,
+			expectedReason: ListenerReasonCertificateReady,
+		},
+		{
+			name:            "mismatched key",
+			secret:          secret(certPEM, otherKeyPEM),
+			hostname:        "a.example.com",
+			roots:           roots,
+			expectedReason:  ListenerReasonCertificateFailed,
+			messageContains: "matching private key",
+		},
+		{
+			name:            "hostname not covered",
+			secret:          secret(certPEM, keyPEM),
+			hostname:        "b.example.com",
+			roots:           roots,
+			expectedReason:  ListenerReasonCertificateFailed,
+			messageContains: "does not cover b.example.com",
+		},
+		{
+			name:            "wildcard hostname not covered by specific certificate",
+			secret:          secret(certPEM, keyPEM),
+			hostname:        "*.example.com",
+			roots:           roots,
+			expectedReason:  ListenerReasonCertificateFailed,
+			messageContains: "does not cover",
+		},
+		{
+			name:            "expired",
+			secret:          secret(expiredPEM, expiredKeyPEM),
+			hostname:        "a.example.com",
+			roots:           roots,
+			expectedReason:  ListenerReasonCertificateExpired,
+			messageContains: "has expired",
+		},
+		{
+			name:            "untrusted",
+			secret:          secret(certPEM, keyPEM),
+			hostname:        "a.example.com",
+			roots:           x509.NewCertPool(),
+			expectedReason:  ListenerReasonCertificateFailed,
+			messageContains: "trusted certificate authority",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := customCertificateStatus(tt.secret, tt.hostname, now, tt.roots)
+			assert.Equal(t, tt.expectedReason, status.certificateReason)
+			assert.Equal(t, tt.expectedReason == ListenerReasonCertificateReady, status.healthy)
+			assert.Contains(t, status.message, tt.messageContains)
+		})
+	}
+}
+
+func TestEnsureDownstreamGatewayCustomCertificate(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, discoveryv1.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+	require.NoError(t, cmv1.AddToScheme(testScheme))
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()},
+	}
+	downstreamNamespaceName := fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayClassName:            "test-suite",
+			DownstreamHostnameAccountingNamespace: "default",
+			TargetDomain:                          "test-suite.com",
+			IPFamilies: []networkingv1alpha.IPFamily{
+				networkingv1alpha.IPv4Protocol,
+			},
+		},
+	}
+
+	now := time.Now()
+	certPEM, keyPEM := generateTLSKeyPair(t, "a.example.com", now.Add(-time.Hour), now.Add(24*time.Hour))
+	renewedPEM, renewedKeyPEM := generateTLSKeyPair(t, "a.example.com", now.Add(-time.Hour), now.Add(48*time.Hour))
+
+	upstreamSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: upstreamNamespace.Name, Name: "custom-cert"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}
+	domain := newDomain(upstreamNamespace.Name, "a.example.com", func(d *networkingv1alpha.Domain) {
+		d.Spec.DomainName = "a.example.com"
+		apimeta.SetStatusCondition(&d.Status.Conditions, metav1.Condition{
+			Type:   networkingv1alpha.DomainConditionVerified,
+			Status: metav1.ConditionTrue,
+		})
+	})
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: gatewayv1.GatewayController("test")},
+	}
+	upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test-gw", func(g *gatewayv1.Gateway) {
+		g.Spec.Listeners = append(g.Spec.Listeners, gatewayv1.Listener{
+			Name:     "https-custom",
+			Protocol: gatewayv1.HTTPSProtocolType,
+			Port:     DefaultHTTPSPort,
+			Hostname: ptr.To(gatewayv1.Hostname("a.example.com")),
+			AllowedRoutes: &gatewayv1.AllowedRoutes{
+				Namespaces: &gatewayv1.RouteNamespaces{From: ptr.To(gatewayv1.NamespacesFromSame)},
+			},
+			TLS: &gatewayv1.ListenerTLSConfig{
+				Mode:            ptr.To(gatewayv1.TLSModeTerminate),
+				CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "custom-cert"}},
+			},
+		})
+	})
+	for _, obj := range []client.Object{upstreamSecret, domain, gatewayClass} {
+		obj.SetUID(uuid.NewUUID())
+		obj.SetCreationTimestamp(metav1.Now())
+	}
+
+	fakeUpstreamClient := withDomainIndexes(fake.NewClientBuilder().WithScheme(testScheme)).
+		WithObjects(upstreamGateway, upstreamNamespace, upstreamSecret, domain, gatewayClass).
+		WithStatusSubresource(upstreamGateway, domain).
+		Build()
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithStatusSubresource(&gatewayv1.Gateway{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// The fake client does not set creation timestamps, which hostname
+			// claims rely on to be recognized across reconciles.
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				obj.SetCreationTimestamp(metav1.Now())
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	ctx := context.Background()
+	reconciler := &GatewayReconciler{
+		mgr:                    &fakeMockManager{cl: fakeUpstreamClient},
+		Config:                 testConfig,
+		DownstreamCluster:      &fakeCluster{cl: fakeDownstreamClient},
+		customCertificateRoots: trustedCertPool(t, certPEM, renewedPEM),
+	}
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+
+	reconcileGateway := func() *gatewayv1.Gateway {
+		t.Helper()
+		require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(upstreamGateway), upstreamGateway))
+		reconciler.prepareUpstreamGateway(upstreamGateway)
+		result, downstreamGateway := reconciler.ensureDownstreamGateway(ctx, "test-suite", fakeUpstreamClient, upstreamGateway, downstreamStrategy)
+		require.NoError(t, result.Err)
+		_, err := result.Complete(ctx)
+		require.NoError(t, err)
+		require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(upstreamGateway), upstreamGateway))
+		return downstreamGateway
+	}
+
+	downstreamSecretKey := client.ObjectKey{
+		Namespace: downstreamNamespaceName,
+		Name:      listenerCertificateSecretName(upstreamGateway.Name, "https-custom"),
+	}
+
+	// The certificate is copied downstream and referenced by the listener.
+	downstreamGateway := reconcileGateway()
+	listener := gatewayutil.GetListenerByName(downstreamGateway.Spec.Listeners, "https-custom")
+	require.NotNil(t, listener)
+	require.Len(t, listener.TLS.CertificateRefs, 1)
+	assert.Equal(t, gatewayv1.ObjectName(downstreamSecretKey.Name), listener.TLS.CertificateRefs[0].Name)
+
+	var downstreamSecret corev1.Secret
+	require.NoError(t, fakeDownstreamClient.Get(ctx, downstreamSecretKey, &downstreamSecret))
+	assert.Equal(t, certPEM, downstreamSecret.Data[corev1.TLSCertKey])
+	assert.Equal(t, downstreamclient.SecretHash(corev1.SecretTypeTLS, upstreamSecret.Data), downstreamSecret.Annotations[downstreamclient.DesiredHashAnnotation])
+
+	var certList cmv1.CertificateList
+	require.NoError(t, fakeDownstreamClient.List(ctx, &certList, client.InNamespace(downstreamNamespaceName)))
+	assert.Empty(t, certList.Items, "no Certificate should be requested for a provided certificate")
+
+	listenerStatus := func(name gatewayv1.SectionName) gatewayv1.ListenerStatus {
+		for _, ls := range upstreamGateway.Status.Listeners {
+			if ls.Name == name {
+				return ls
+			}
+		}
+		t.Fatalf("listener %q missing from status", name)
+		return gatewayv1.ListenerStatus{}
+	}
+	assert.True(t, apimeta.IsStatusConditionTrue(listenerStatus("https-custom").Conditions, ListenerConditionCertificateReady))
+	assert.True(t, apimeta.IsStatusConditionTrue(listenerStatus("https-custom").Conditions, string(gatewayv1.ListenerConditionResolvedRefs)))
+
+	// Changes to the downstream copy are reverted.
+	downstreamSecret.Data[corev1.TLSCertKey] = []byte("tampered")
+	require.NoError(t, fakeDownstreamClient.Update(ctx, &downstreamSecret))
+	reconcileGateway()
+	require.NoError(t, fakeDownstreamClient.Get(ctx, downstreamSecretKey, &downstreamSecret))
+	assert.Equal(t, certPEM, downstreamSecret.Data[corev1.TLSCertKey])
+
+	// Renewing the certificate upstream updates the copy.
+	upstreamSecret.Data = map[string][]byte{corev1.TLSCertKey: renewedPEM, corev1.TLSPrivateKeyKey: renewedKeyPEM}
+	require.NoError(t, fakeUpstreamClient.Update(ctx, upstreamSecret))
+	reconcileGateway()
+	require.NoError(t, fakeDownstreamClient.Get(ctx, downstreamSecretKey, &downstreamSecret))
+	assert.Equal(t, renewedPEM, downstreamSecret.Data[corev1.TLSCertKey])
+
+	// A missing Secret withholds the listener and reports the problem.
+	require.NoError(t, fakeUpstreamClient.Delete(ctx, upstreamSecret))
+	downstreamGateway = reconcileGateway()
+	assert.Nil(t, gatewayutil.GetListenerByName(downstreamGateway.Spec.Listeners, "https-custom"))
+	resolvedRefs := apimeta.FindStatusCondition(listenerStatus("https-custom").Conditions, string(gatewayv1.ListenerConditionResolvedRefs))
+	require.NotNil(t, resolvedRefs)
+	assert.Equal(t, metav1.ConditionFalse, resolvedRefs.Status)
+	assert.Equal(t, string(gatewayv1.ListenerReasonInvalidCertificateRef), resolvedRefs.Reason)
+	assert.Contains(t, resolvedRefs.Message, `"custom-cert" for a.example.com was not found`)
+
+	// The copy is deleted once no listener references a certificate.
+	upstreamGateway.Spec.Listeners = upstreamGateway.Spec.Listeners[:len(upstreamGateway.Spec.Listeners)-1]
+	require.NoError(t, fakeUpstreamClient.Update(ctx, upstreamGateway))
+	reconcileGateway()
+	err := fakeDownstreamClient.Get(ctx, downstreamSecretKey, &downstreamSecret)
+	assert.True(t, apierrors.IsNotFound(err), "expected downstream Secret to be deleted, got %v", err)
+}
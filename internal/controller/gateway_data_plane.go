@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+// GatewayDataPlaneSizeAnnotation selects one of the data plane sizes
+// configured for the platform for the Envoy fleet of an upstream Gateway.
+const GatewayDataPlaneSizeAnnotation = "networking.datumapis.com/data-plane-size"
+
+// GatewayConditionDataPlaneSized is set on upstream Gateways that select a
+// data plane size, and reports whether the size is programmed.
+const GatewayConditionDataPlaneSized = "DataPlaneSized"
+
+const (
+	GatewayReasonDataPlaneSized       = "Sized"
+	GatewayReasonUnknownDataPlaneSize = "UnknownSize"
+)
+
+// gatewayDataPlaneSize returns the data plane size selected by an upstream
+// gateway, or nil when it is served by the fleet of the downstream
+// GatewayClass. Sizes that are no longer configured are ignored.
+func (r *GatewayReconciler) gatewayDataPlaneSize(upstreamGateway *gatewayv1.Gateway) *config.GatewayDataPlaneSize {
+	name, ok := upstreamGateway.Annotations[GatewayDataPlaneSizeAnnotation]
+	if !ok {
+		return nil
+	}
+	size, ok := r.Config.Gateway.DataPlaneSizes[name]
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+// downstreamDataPlaneEnvoyProxyName returns the name of the EnvoyProxy that
+// sizes the Envoy fleet of a downstream gateway.
+func downstreamDataPlaneEnvoyProxyName(gatewayName string) string {
+	return resourcename.GetValidDNS1123Name(fmt.Sprintf("%s-data-plane", gatewayName))
+}
+
+// downstreamGatewayInfrastructure returns the infrastructure of the downstream
+// gateway, which references the EnvoyProxy of its data plane size.
+func (r *GatewayReconciler) downstreamGatewayInfrastructure(upstreamGateway *gatewayv1.Gateway) *gatewayv1.GatewayInfrastructure {
+	if r.gatewayDataPlaneSize(upstreamGateway) == nil {
+		return nil
+	}
+	return &gatewayv1.GatewayInfrastructure{
+		ParametersRef: &gatewayv1.LocalParametersReference{
+			Group: envoygatewayv1alpha1.GroupName,
+			Kind:  envoygatewayv1alpha1.KindEnvoyProxy,
+			Name:  downstreamDataPlaneEnvoyProxyName(upstreamGateway.Name),
+		},
+	}
+}
+
+// desiredDataPlaneEnvoyProxySpec returns the EnvoyProxy spec of a data plane
+// size. It is merged over the EnvoyProxy of the downstream GatewayClass, so it
+// only holds the settings of the size.
+func desiredDataPlaneEnvoyProxySpec(size *config.GatewayDataPlaneSize) envoygatewayv1alpha1.EnvoyProxySpec {
+	kubernetes := &envoygatewayv1alpha1.EnvoyProxyKubernetesProvider{
+		EnvoyDeployment: &envoygatewayv1alpha1.KubernetesDeploymentSpec{
+			Replicas: size.Replicas,
+		},
+	}
+	if size.Resources != nil {
+		kubernetes.EnvoyDeployment.Container = &envoygatewayv1alpha1.KubernetesContainerSpec{
+			Resources: size.Resources.DeepCopy(),
+		}
+	}
+	if size.Autoscaling != nil {
+		kubernetes.EnvoyHpa = &envoygatewayv1alpha1.KubernetesHorizontalPodAutoscalerSpec{
+			MinReplicas: size.Autoscaling.MinReplicas,
+			MaxReplicas: ptr.To(size.Autoscaling.MaxReplicas),
+		}
+	}
+
+	return envoygatewayv1alpha1.EnvoyProxySpec{
+		MergeType: ptr.To(envoygatewayv1alpha1.StrategicMerge),
+		Provider: &envoygatewayv1alpha1.EnvoyProxyProvider{
+			Type:       envoygatewayv1alpha1.EnvoyProxyProviderTypeKubernetes,
+			Kubernetes: kubernetes,
+		},
+	}
+}
+
+// ensureDownstreamDataPlaneEnvoyProxy programs the data plane size selected by
+// an upstream gateway as the EnvoyProxy of its downstream gateway, and removes
+// the EnvoyProxy when no size is selected.
+func (r *GatewayReconciler) ensureDownstreamDataPlaneEnvoyProxy(
+	ctx context.Context,
+	upstreamGateway *gatewayv1.Gateway,
+	downstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) error {
+	if len(r.Config.Gateway.DataPlaneSizes) == 0 {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+
+	envoyProxy := &envoygatewayv1alpha1.EnvoyProxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: downstreamGateway.Namespace,
+			Name:      downstreamDataPlaneEnvoyProxyName(downstreamGateway.Name),
+		},
+	}
+
+	size := r.gatewayDataPlaneSize(upstreamGateway)
+	if size == nil {
+		if err := downstreamStrategy.GetClient().Delete(ctx, envoyProxy); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed deleting downstream data plane envoyproxy: %w", err)
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, downstreamStrategy.GetClient(), envoyProxy, func() error {
+		if err := downstreamStrategy.SetControllerReference(ctx, upstreamGateway, envoyProxy); err != nil {
+			return fmt.Errorf("failed to set controller reference on downstream data plane envoyproxy: %w", err)
+		}
+		envoyProxy.Spec = desiredDataPlaneEnvoyProxySpec(size)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed ensuring downstream data plane envoyproxy: %w", err)
+	}
+
+	logger.Info("downstream data plane envoyproxy processed", "operation_result", result)
+	return nil
+}
+
+// reconcileDataPlaneSizeStatus sets the DataPlaneSized condition on the
+// upstream gateway. The condition is removed when no size is selected.
+func (r *GatewayReconciler) reconcileDataPlaneSizeStatus(upstreamClient client.Client, upstreamGateway *gatewayv1.Gateway) (result Result) {
+	name, ok := upstreamGateway.Annotations[GatewayDataPlaneSizeAnnotation]
+	if !ok {
+		if apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, GatewayConditionDataPlaneSized) == nil {
+			return result
+		}
+		apimeta.RemoveStatusCondition(&upstreamGateway.Status.Conditions, GatewayConditionDataPlaneSized)
+		result.AddStatusUpdate(upstreamClient, upstreamGateway)
+		return result
+	}
+
+	condition := metav1.Condition{
+		Type:               GatewayConditionDataPlaneSized,
+		Status:             metav1.ConditionTrue,
+		Reason:             GatewayReasonDataPlaneSized,
+		Message:            fmt.Sprintf("The gateway is served by a data plane of size %s", name),
+		ObservedGeneration: upstreamGateway.Generation,
+	}
+	if r.gatewayDataPlaneSize(upstreamGateway) == nil {
+		sizes := slices.Sorted(maps.Keys(r.Config.Gateway.DataPlaneSizes))
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GatewayReasonUnknownDataPlaneSize
+		condition.Message = fmt.Sprintf("The data plane size %q is not available, the gateway is served by the shared data plane", name)
+		if len(sizes) > 0 {
+			condition.Message += fmt.Sprintf(". Available sizes: %s", strings.Join(sizes, ", "))
+		}
+	}
+
+	if !apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, condition) {
+		return result
+	}
+	result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	return result
+}
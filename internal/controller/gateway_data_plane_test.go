@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func testDataPlaneSizes() map[string]config.GatewayDataPlaneSize {
+	return map[string]config.GatewayDataPlaneSize{
+		"large": {
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+			Autoscaling: &config.GatewayDataPlaneAutoscaling{MinReplicas: ptr.To[int32](3), MaxReplicas: 12},
+		},
+		"medium": {Replicas: ptr.To[int32](2)},
+	}
+}
+
+func TestDesiredDataPlaneEnvoyProxySpec(t *testing.T) {
+	sizes := testDataPlaneSizes()
+
+	large := sizes["large"]
+	spec := desiredDataPlaneEnvoyProxySpec(&large)
+	assert.Equal(t, envoygatewayv1alpha1.StrategicMerge, ptr.Deref(spec.MergeType, ""))
+	require.NotNil(t, spec.Provider)
+	require.NotNil(t, spec.Provider.Kubernetes)
+	kubernetes := spec.Provider.Kubernetes
+	assert.Nil(t, kubernetes.EnvoyDeployment.Replicas)
+	require.NotNil(t, kubernetes.EnvoyDeployment.Container)
+	assert.True(t, kubernetes.EnvoyDeployment.Container.Resources.Requests.Cpu().Equal(resource.MustParse("2")))
+	require.NotNil(t, kubernetes.EnvoyHpa)
+	assert.Equal(t, int32(3), ptr.Deref(kubernetes.EnvoyHpa.MinReplicas, 0))
+	assert.Equal(t, int32(12), ptr.Deref(kubernetes.EnvoyHpa.MaxReplicas, 0))
+
+	medium := sizes["medium"]
+	spec = desiredDataPlaneEnvoyProxySpec(&medium)
+	assert.Equal(t, int32(2), ptr.Deref(spec.Provider.Kubernetes.EnvoyDeployment.Replicas, 0))
+	assert.Nil(t, spec.Provider.Kubernetes.EnvoyDeployment.Container)
+	assert.Nil(t, spec.Provider.Kubernetes.EnvoyHpa)
+}
+
+func TestReconcileDataPlaneSizeStatus(t *testing.T) {
+	tests := map[string]struct {
+		size                 string
+		expectedReason       string
+		expectInfrastructure bool
+	}{
+		"no size": {},
+		"configured size": {
+			size:                 "large",
+			expectedReason:       GatewayReasonDataPlaneSized,
+			expectInfrastructure: true,
+		},
+		"unknown size": {
+			size:           "huge",
+			expectedReason: GatewayReasonUnknownDataPlaneSize,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test-gw"},
+			}
+			if tt.size != "" {
+				metav1.SetMetaDataAnnotation(&gateway.ObjectMeta, GatewayDataPlaneSizeAnnotation, tt.size)
+			}
+
+			reconciler := &GatewayReconciler{
+				Config: config.NetworkServicesOperator{Gateway: config.GatewayConfig{DataPlaneSizes: testDataPlaneSizes()}},
+			}
+
+			infrastructure := reconciler.downstreamGatewayInfrastructure(gateway)
+			if tt.expectInfrastructure {
+				require.NotNil(t, infrastructure)
+				assert.Equal(t, gatewayv1.Kind(envoygatewayv1alpha1.KindEnvoyProxy), infrastructure.ParametersRef.Kind)
+				assert.Equal(t, "test-gw-data-plane", infrastructure.ParametersRef.Name)
+			} else {
+				assert.Nil(t, infrastructure)
+			}
+
+			reconciler.reconcileDataPlaneSizeStatus(nil, gateway)
+			condition := apimeta.FindStatusCondition(gateway.Status.Conditions, GatewayConditionDataPlaneSized)
+			if tt.expectedReason == "" {
+				assert.Nil(t, condition)
+				return
+			}
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, tt.expectInfrastructure, condition.Status == metav1.ConditionTrue)
+			if !tt.expectInfrastructure {
+				assert.Contains(t, condition.Message, "large, medium")
+			}
+		})
+	}
+}
+
+func TestEnsureDownstreamDataPlaneEnvoyProxy(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()},
+	}
+	upstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "test",
+			Name:        "test-gw",
+			UID:         uuid.NewUUID(),
+			Annotations: map[string]string{GatewayDataPlaneSizeAnnotation: "medium"},
+		},
+	}
+
+	downstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-" + string(upstreamNamespace.UID), Name: "test-gw"},
+	}
+
+	fakeUpstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(upstreamNamespace, upstreamGateway).Build()
+	fakeDownstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+
+	reconciler := &GatewayReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{DataPlaneSizes: testDataPlaneSizes()},
+		},
+	}
+
+	ctx := context.Background()
+	require.NoError(t, reconciler.ensureDownstreamDataPlaneEnvoyProxy(ctx, upstreamGateway, downstreamGateway, downstreamStrategy))
+
+	envoyProxyKey := client.ObjectKey{Namespace: downstreamGateway.Namespace, Name: "test-gw-data-plane"}
+	var envoyProxy envoygatewayv1alpha1.EnvoyProxy
+	require.NoError(t, fakeDownstreamClient.Get(ctx, envoyProxyKey, &envoyProxy))
+	assert.Equal(t, int32(2), ptr.Deref(envoyProxy.Spec.Provider.Kubernetes.EnvoyDeployment.Replicas, 0))
+
+	// Switching sizes updates the EnvoyProxy in place.
+	upstreamGateway.Annotations[GatewayDataPlaneSizeAnnotation] = "large"
+	require.NoError(t, reconciler.ensureDownstreamDataPlaneEnvoyProxy(ctx, upstreamGateway, downstreamGateway, downstreamStrategy))
+	require.NoError(t, fakeDownstreamClient.Get(ctx, envoyProxyKey, &envoyProxy))
+	assert.Nil(t, envoyProxy.Spec.Provider.Kubernetes.EnvoyDeployment.Replicas)
+	assert.NotNil(t, envoyProxy.Spec.Provider.Kubernetes.EnvoyHpa)
+
+	// The EnvoyProxy is removed once the gateway no longer selects a size.
+	delete(upstreamGateway.Annotations, GatewayDataPlaneSizeAnnotation)
+	require.NoError(t, reconciler.ensureDownstreamDataPlaneEnvoyProxy(ctx, upstreamGateway, downstreamGateway, downstreamStrategy))
+	err := fakeDownstreamClient.Get(ctx, envoyProxyKey, &envoyProxy)
+	assert.True(t, apierrors.IsNotFound(err), "expected data plane envoyproxy to be deleted, got %v", err)
+}
@@ -14,6 +14,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
@@ -27,6 +28,7 @@ import (
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	dnsutil "go.datum.net/network-services-operator/internal/util/dns"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
 )
 
@@ -61,6 +63,7 @@ func (r *GatewayReconciler) ensureDNSRecordSets(
 	upstreamClient client.Client,
 	upstreamGateway *gatewayv1.Gateway,
 	claimedHostnames []string,
+	dnsRecords gatewayDNSRecords,
 ) (hostnameStatuses []networkingv1alpha.HostnameStatus, result Result) {
 	if !r.Config.Gateway.EnableDNSIntegration {
 		return nil, result
@@ -78,8 +81,8 @@ func (r *GatewayReconciler) ensureDNSRecordSets(
 		return nil, result
 	}
 
-	// Build a set of desired DNSRecordSet names so we can garbage-collect stale ones.
-	desiredRecordSetNames := map[string]bool{}
+	// Build a set of desired DNSRecordSets so we can garbage-collect stale ones.
+	desiredRecordSets := map[client.ObjectKey]bool{}
 
 	for _, hostname := range claimedHostnames {
 		// Skip the platform-managed canonical hostname – it is handled by external-dns.
@@ -122,30 +125,28 @@ func (r *GatewayReconciler) ensureDNSRecordSets(
 				continue
 			}
 
-			// Look up the DNSZone for this domain.
-			var dnsZoneList dnsv1alpha1.DNSZoneList
-			if err := upstreamClient.List(ctx, &dnsZoneList,
-				client.InNamespace(upstreamGateway.Namespace),
-				client.MatchingFields{dnsZoneDomainNameIndex: zoneName},
-			); err != nil {
+			// Look up the DNSZones for this domain, including shared DNSZones
+			// the gateway has been granted access to.
+			dnsZones, err := r.findDNSZonesForDomain(ctx, upstreamClient, upstreamGateway, zoneName)
+			if err != nil {
 				result.Err = fmt.Errorf("failed listing DNSZones for domain %q: %w", zoneName, err)
 				return nil, result
 			}
 
-			if len(dnsZoneList.Items) == 0 {
-				continue
-			}
-
 			// Check if Datum DNS has authority (DNSZone ready + nameservers match).
-			zone := &dnsZoneList.Items[0]
-			if !dnsutil.HasDNSAuthority(&d, zone) {
+			for i := range dnsZones {
+				if dnsutil.HasDNSAuthority(&d, &dnsZones[i]) {
+					dnsZone = &dnsZones[i]
+					break
+				}
+			}
+			if dnsZone == nil {
 				// Domain verified but Datum DNS doesn't have authority yet.
 				continue
 			}
 
 			// Found a matching Domain + DNSZone where Datum DNS has authority.
 			domain = &d
-			dnsZone = zone
 			matchedZoneName = zoneName
 			break
 		}
@@ -171,20 +172,14 @@ func (r *GatewayReconciler) ensureDNSRecordSets(
 				}
 
 				// Domain is verified; check if there's a DNSZone
-				var dnsZoneList dnsv1alpha1.DNSZoneList
-				if err := upstreamClient.List(ctx, &dnsZoneList,
-					client.InNamespace(upstreamGateway.Namespace),
-					client.MatchingFields{dnsZoneDomainNameIndex: zoneName},
-				); err != nil {
-					continue
-				}
-				if len(dnsZoneList.Items) == 0 {
+				dnsZones, err := r.findDNSZonesForDomain(ctx, upstreamClient, upstreamGateway, zoneName)
+				if err != nil || len(dnsZones) == 0 {
 					continue
 				}
 
 				// DNSZone exists but Datum DNS doesn't have authority
 				noAuthorityDomain = &d
-				noAuthorityZone = &dnsZoneList.Items[0]
+				noAuthorityZone = &dnsZones[0]
 				break
 			}
 
@@ -235,20 +230,42 @@ func (r *GatewayReconciler) ensureDNSRecordSets(
 			"domain", domain.Name,
 		)
 
-		// Determine the record type based on whether this hostname is an apex domain.
-		rrType := dnsv1alpha1.RRTypeCNAME
-		if domain.Status.Apex {
-			rrType = dnsv1alpha1.RRTypeALIAS
+		// Determine the record type based on whether this hostname is an apex
+		// domain and the DNS record policy of the gateway.
+		rrType := dnsRecords.recordType(domain.Status.Apex)
+
+		recordSetKey := dnsRecordSetKey(upstreamGateway, hostname, dnsZone)
+		recordSetName := recordSetKey.Name
+
+		// Gateways with failover targets point the hostname at the addresses of
+		// their healthy targets instead of their canonical hostname.
+		recordSets := []desiredDNSRecordSet{{
+			key:  recordSetKey,
+			spec: buildDesiredDNSRecordSetSpec(hostname, canonicalHostname, *dnsZone, rrType, dnsRecords.ttl),
+		}}
+		if dnsRecords.failover != nil {
+			recordSets = failoverDNSRecordSets(recordSetKey, hostname, *dnsZone, *dnsRecords.failover, dnsRecords.ttl)
+		}
+		for _, recordSet := range recordSets {
+			desiredRecordSets[recordSet.key] = true
+		}
+		if len(recordSets) == 0 {
+			apimeta.SetStatusCondition(&hs.Conditions, metav1.Condition{
+				Type:               networkingv1alpha.HostnameConditionDNSRecordProgrammed,
+				Status:             metav1.ConditionFalse,
+				Reason:             networkingv1alpha.DNSRecordReasonPending,
+				Message:            "Waiting for the targets of the gateway to be assigned addresses",
+				ObservedGeneration: upstreamGateway.Generation,
+			})
+			hostnameStatuses = append(hostnameStatuses, hs)
+			continue
 		}
-
-		recordSetName := dnsRecordSetName(upstreamGateway.Name, hostname)
-		desiredRecordSetNames[recordSetName] = true
 
 		// Conflict detection: list existing DNSRecordSets with the same
-		// hostname annotation in this namespace that reference this zone.
+		// hostname annotation in the zone's namespace that reference this zone.
 		var existingList dnsv1alpha1.DNSRecordSetList
 		if err := upstreamClient.List(ctx, &existingList,
-			client.InNamespace(upstreamGateway.Namespace),
+			client.InNamespace(recordSetKey.Namespace),
 			client.MatchingLabels{labelDNSManaged: labelValueTrue},
 		); err != nil {
 			result.Err = fmt.Errorf("failed listing existing DNSRecordSets: %w", err)
@@ -286,48 +303,22 @@ func (r *GatewayReconciler) ensureDNSRecordSets(
 		}
 
 		{
-			desired := buildDesiredDNSRecordSet(upstreamGateway, recordSetName)
-
-			operationResult, err := controllerutil.CreateOrUpdate(ctx, upstreamClient, desired, func() error {
-				// If the record already exists and is managed by us, update the spec.
-				// If it is managed by someone else, return an error so the caller can
-				// surface a conflict condition.
-				existingManagedBy := desired.Labels[labelManagedBy]
-				if existingManagedBy != "" && existingManagedBy != labelManagedByValue {
-					return fmt.Errorf("conflict: existing DNSRecordSet %q is managed by %q", desired.Name, existingManagedBy)
-				}
-
-				// Set owner reference for garbage collection, but NOT as controller.
-				// The dns-operator's dnsrecordset-replicator expects to be the controller
-				// of DNSRecordSets it manages. Using SetOwnerReference (not SetControllerReference)
-				// allows the Gateway to own the record for GC while letting dns-operator manage it.
-				if err := controllerutil.SetOwnerReference(upstreamGateway, desired, upstreamClient.Scheme()); err != nil {
-					return fmt.Errorf("failed to set owner reference on DNSRecordSet: %w", err)
-				}
-
-				// Ensure labels and annotations are set on both create and update.
-				if desired.Labels == nil {
-					desired.Labels = map[string]string{}
-				}
-				desired.Labels[labelManagedBy] = labelManagedByValue
-				desired.Labels[labelDNSManaged] = labelValueTrue
-				desired.Labels[labelDNSSourceKind] = KindGateway
-				desired.Labels[labelDNSSourceName] = upstreamGateway.Name
-				desired.Labels[labelDNSSourceNS] = upstreamGateway.Namespace
-
-				if desired.Annotations == nil {
-					desired.Annotations = map[string]string{}
+			operationResult := controllerutil.OperationResultNone
+			recordTypes := make([]string, 0, len(recordSets))
+			var err error
+			for _, recordSet := range recordSets {
+				var recordSetResult controllerutil.OperationResult
+				recordSetResult, err = createOrUpdateGatewayDNSRecordSet(ctx, upstreamClient, upstreamGateway, hostname, recordSet)
+				if err != nil {
+					recordSetName = recordSet.key.Name
+					break
 				}
-				desired.Annotations[annotationDNSHostname] = hostname
-
-				// Only write sync-started-at on creation (when creationTimestamp is zero).
-				if desired.CreationTimestamp.IsZero() {
-					desired.Annotations[annotationSyncStart] = metav1.Now().UTC().Format("2006-01-02T15:04:05Z")
+				recordTypes = append(recordTypes, strings.ToLower(string(recordSet.spec.RecordType)))
+				if recordSetResult == controllerutil.OperationResultUpdated ||
+					operationResult == controllerutil.OperationResultNone {
+					operationResult = recordSetResult
 				}
-
-				desired.Spec = buildDesiredDNSRecordSetSpec(hostname, canonicalHostname, *dnsZone, rrType)
-				return nil
-			})
+			}
 			if err != nil {
 				if apierrors.IsConflict(err) {
 					result.RequeueAfter = retryAfterConflict
@@ -356,7 +347,7 @@ func (r *GatewayReconciler) ensureDNSRecordSets(
 			logger.Info("DNS record set processed",
 				"hostname", hostname,
 				"zone", dnsZone.Name,
-				"record_type", string(rrType),
+				"record_type", strings.Join(recordTypes, ","),
 				"canonical_hostname", canonicalHostname,
 				"operation_result", operationResult,
 			)
@@ -366,7 +357,7 @@ func (r *GatewayReconciler) ensureDNSRecordSets(
 				Status: metav1.ConditionTrue,
 				Reason: reason,
 				Message: fmt.Sprintf("%s record %s in DNSZone %q",
-					strings.ToLower(string(rrType)),
+					strings.Join(recordTypes, ", "),
 					operationResultVerb(operationResult),
 					dnsZone.Name,
 				),
@@ -379,7 +370,7 @@ func (r *GatewayReconciler) ensureDNSRecordSets(
 	}
 
 	// Garbage-collect stale DNSRecordSets that are no longer needed.
-	gcResult := r.garbageCollectDNSRecordSets(ctx, upstreamClient, upstreamGateway, desiredRecordSetNames)
+	gcResult := r.garbageCollectDNSRecordSets(ctx, upstreamClient, upstreamGateway, desiredRecordSets)
 	if gcResult.ShouldReturn() {
 		return hostnameStatuses, gcResult.Merge(result)
 	}
@@ -387,6 +378,63 @@ func (r *GatewayReconciler) ensureDNSRecordSets(
 	return hostnameStatuses, result
 }
 
+// createOrUpdateGatewayDNSRecordSet creates or updates a DNSRecordSet of an
+// upstream gateway for hostname.
+func createOrUpdateGatewayDNSRecordSet(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	hostname string,
+	recordSet desiredDNSRecordSet,
+) (controllerutil.OperationResult, error) {
+	desired := buildDesiredDNSRecordSet(recordSet.key)
+	return controllerutil.CreateOrUpdate(ctx, upstreamClient, desired, func() error {
+		// If the record already exists and is managed by us, update the spec.
+		// If it is managed by someone else, return an error so the caller can
+		// surface a conflict condition.
+		existingManagedBy := desired.Labels[labelManagedBy]
+		if existingManagedBy != "" && existingManagedBy != labelManagedByValue {
+			return fmt.Errorf("conflict: existing DNSRecordSet %q is managed by %q", desired.Name, existingManagedBy)
+		}
+
+		// Set owner reference for garbage collection, but NOT as controller.
+		// The dns-operator's dnsrecordset-replicator expects to be the controller
+		// of DNSRecordSets it manages. Using SetOwnerReference (not SetControllerReference)
+		// allows the Gateway to own the record for GC while letting dns-operator manage it.
+		// Records in shared DNSZones cannot be owned across namespaces and are
+		// only removed by the gateway finalizer.
+		if desired.Namespace == upstreamGateway.Namespace {
+			if err := controllerutil.SetOwnerReference(upstreamGateway, desired, upstreamClient.Scheme()); err != nil {
+				return fmt.Errorf("failed to set owner reference on DNSRecordSet: %w", err)
+			}
+		}
+
+		// Ensure labels and annotations are set on both create and update.
+		if desired.Labels == nil {
+			desired.Labels = map[string]string{}
+		}
+		desired.Labels[labelManagedBy] = labelManagedByValue
+		desired.Labels[labelDNSManaged] = labelValueTrue
+		desired.Labels[labelDNSSourceKind] = KindGateway
+		desired.Labels[labelDNSSourceName] = upstreamGateway.Name
+		desired.Labels[labelDNSSourceNS] = upstreamGateway.Namespace
+		gatewayutil.GetSchedulingHints(upstreamGateway).ApplyLabels(desired.Labels)
+
+		if desired.Annotations == nil {
+			desired.Annotations = map[string]string{}
+		}
+		desired.Annotations[annotationDNSHostname] = hostname
+
+		// Only write sync-started-at on creation (when creationTimestamp is zero).
+		if desired.CreationTimestamp.IsZero() {
+			desired.Annotations[annotationSyncStart] = metav1.Now().UTC().Format("2006-01-02T15:04:05Z")
+		}
+
+		desired.Spec = recordSet.spec
+		return nil
+	})
+}
+
 // reconcileDNSStatus computes and applies the aggregate DNSRecordsProgrammed
 // condition on the upstream gateway from the per-hostname statuses produced by
 // ensureDNSRecordSets. Hostnames marked NotApplicable are excluded from the
@@ -440,19 +488,19 @@ func (r *GatewayReconciler) reconcileDNSStatus(
 
 // garbageCollectDNSRecordSets deletes DNSRecordSet resources that were
 // previously created for the gateway but are no longer needed because the
-// corresponding hostname was removed from the gateway listeners. Only records
-// whose names are absent from desiredNames are deleted.
+// corresponding hostname was removed from the gateway listeners, or moved to
+// another DNSZone. Only records absent from desired are deleted.
 func (r *GatewayReconciler) garbageCollectDNSRecordSets(
 	ctx context.Context,
 	upstreamClient client.Client,
 	upstreamGateway *gatewayv1.Gateway,
-	desiredNames map[string]bool,
+	desired map[client.ObjectKey]bool,
 ) (result Result) {
 	logger := log.FromContext(ctx)
 
 	var existingList dnsv1alpha1.DNSRecordSetList
 	if err := upstreamClient.List(ctx, &existingList,
-		client.InNamespace(upstreamGateway.Namespace),
+		r.dnsRecordSetListNamespace(upstreamGateway),
 		client.MatchingLabels{
 			labelDNSManaged:    labelValueTrue,
 			labelManagedBy:     labelManagedByValue,
@@ -465,7 +513,7 @@ func (r *GatewayReconciler) garbageCollectDNSRecordSets(
 	}
 
 	for _, rs := range existingList.Items {
-		if desiredNames[rs.Name] {
+		if desired[client.ObjectKeyFromObject(&rs)] {
 			continue
 		}
 		hostname := rs.Annotations[annotationDNSHostname]
@@ -535,14 +583,11 @@ func findDomainByName(domains []networkingv1alpha.Domain, apexDomain string) (ne
 // Namespace populated. Labels, Annotations, and Spec are intentionally omitted
 // here and are applied inside the CreateOrUpdate mutate function so they are
 // set consistently on both create and update operations.
-func buildDesiredDNSRecordSet(
-	upstreamGateway *gatewayv1.Gateway,
-	name string,
-) *dnsv1alpha1.DNSRecordSet {
+func buildDesiredDNSRecordSet(key client.ObjectKey) *dnsv1alpha1.DNSRecordSet {
 	return &dnsv1alpha1.DNSRecordSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: upstreamGateway.Namespace,
-			Name:      name,
+			Namespace: key.Namespace,
+			Name:      key.Name,
 		},
 	}
 }
@@ -550,12 +595,12 @@ func buildDesiredDNSRecordSet(
 // buildDesiredDNSRecordSetSpec constructs the DNSRecordSetSpec that points
 // hostname at canonicalHostname. Both values are normalized to absolute FQDNs
 // (trailing dot) before being written into the record entry. The record type
-// is CNAME for non-apex hostnames and ALIAS for apex domains, as determined
-// by the caller.
+// and TTL are determined by the caller.
 func buildDesiredDNSRecordSetSpec(
 	hostname, canonicalHostname string,
 	dnsZone dnsv1alpha1.DNSZone,
 	rrType dnsv1alpha1.RRType,
+	ttl int64,
 ) dnsv1alpha1.DNSRecordSetSpec {
 	fqdnHostname := hostname
 	if !strings.HasSuffix(fqdnHostname, ".") {
@@ -569,7 +614,7 @@ func buildDesiredDNSRecordSetSpec(
 
 	var entry dnsv1alpha1.RecordEntry
 	entry.Name = fqdnHostname
-	entry.TTL = ptr.To(int64(300))
+	entry.TTL = ptr.To(ttl)
 
 	switch rrType {
 	case dnsv1alpha1.RRTypeCNAME:
@@ -601,7 +646,8 @@ func operationResultVerb(result controllerutil.OperationResult) string {
 // listGatewaysForDNSZoneFunc returns a TypedEventHandler that enqueues every
 // Gateway in the same namespace whenever a DNSZone changes. This ensures the
 // controller re-evaluates DNS record status when a zone becomes ready or is
-// deleted.
+// deleted. For shared DNSZones, Gateways in the namespaces granted access to
+// DNSZones in the zone's namespace are enqueued as well.
 func (r *GatewayReconciler) listGatewaysForDNSZoneFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
 	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
 		dnsZone := obj.(*dnsv1alpha1.DNSZone)
@@ -622,7 +668,17 @@ func (r *GatewayReconciler) listGatewaysForDNSZoneFunc(clusterName multicluster.
 				},
 			})
 		}
-		return requests
+
+		selector, err := r.sharedDNSZoneSelector()
+		if err != nil || selector == nil || !selector.Matches(labels.Set(dnsZone.Labels)) {
+			return requests
+		}
+		var referenceGrants gatewayv1.ReferenceGrantList
+		if err := cl.GetClient().List(ctx, &referenceGrants, client.InNamespace(dnsZone.Namespace)); err != nil {
+			logger.Error(err, "failed to list ReferenceGrants for shared DNSZone change")
+			return requests
+		}
+		return append(requests, listGatewaysForDNSZoneGrants(ctx, clusterName, cl.GetClient(), referenceGrants.Items)...)
 	})
 }
 
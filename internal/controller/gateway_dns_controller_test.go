@@ -27,6 +27,7 @@ import (
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	"go.datum.net/network-services-operator/internal/config"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
 )
 
@@ -144,8 +145,7 @@ func newDNSZone(namespace, name, domainName string) *dnsv1alpha1.DNSZone {
 // provided objects plus a field index for spec.domainName on DNSZone (the index
 // used by ensureDNSRecordSets when it calls List with MatchingFields).
 func buildFakeUpstreamClientForDNS(s *runtime.Scheme, objects ...client.Object) client.Client {
-	return fake.NewClientBuilder().
-		WithScheme(s).
+	return withDomainIndexes(fake.NewClientBuilder().WithScheme(s)).
 		WithObjects(objects...).
 		WithIndex(&dnsv1alpha1.DNSZone{}, dnsZoneDomainNameIndex, func(o client.Object) []string {
 			zone, ok := o.(*dnsv1alpha1.DNSZone)
@@ -653,7 +653,7 @@ func TestEnsureDNSRecordSets(t *testing.T) {
 				claimed = []string{canonicalHostname}
 			}
 
-			statuses, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, claimed)
+			statuses, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, claimed, gatewayDNSRecords{ttl: 300})
 
 			if tt.wantErr {
 				assert.Error(t, result.Err)
@@ -742,7 +742,7 @@ func TestEnsureDNSRecordSets_UpdateExistingRecord(t *testing.T) {
 	cl := buildFakeUpstreamClientForDNS(s, allObjects...)
 	reconciler := newDNSReconciler(testConfig)
 
-	statuses, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{hostname})
+	statuses, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{hostname}, gatewayDNSRecords{ttl: 300})
 	require.NoError(t, result.Err)
 	require.Len(t, statuses, 1)
 
@@ -764,6 +764,49 @@ func TestEnsureDNSRecordSets_UpdateExistingRecord(t *testing.T) {
 	assert.True(t, len(updatedRS.Spec.Records[0].CNAME.Content) > 0)
 }
 
+func TestEnsureDNSRecordSets_SchedulingHintLabels(t *testing.T) {
+	const ns = "test-ns"
+	ctx := log.IntoContext(context.Background(), zap.New())
+	s := newDNSTestScheme(t)
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			TargetDomain:         "gateways.test.local",
+			EnableDNSIntegration: true,
+		},
+	}
+
+	gw := newTestGatewayForDNS(ns, "my-gw")
+	gw.Annotations = map[string]string{
+		gatewayutil.PreferredLocationsAnnotation: "dfw, ord",
+		gatewayutil.PreferredRegionsAnnotation:   "us-central",
+	}
+	domain := newVerifiedDNSZoneDomain(ns, "example.com", false)
+	zone := newDNSZone(ns, "example-com", "example.com")
+
+	cl := buildFakeUpstreamClientForDNS(s, gw, domain, zone)
+	reconciler := newDNSReconciler(testConfig)
+
+	hostname := "api.example.com"
+	_, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{hostname}, gatewayDNSRecords{ttl: 300})
+	require.NoError(t, result.Err)
+
+	var rs dnsv1alpha1.DNSRecordSet
+	rsKey := client.ObjectKey{Namespace: ns, Name: dnsRecordSetName(gw.Name, hostname)}
+	require.NoError(t, cl.Get(ctx, rsKey, &rs))
+	assert.Equal(t, "dfw", rs.Labels[gatewayutil.PreferredLocationLabel])
+	assert.Equal(t, "us-central", rs.Labels[gatewayutil.PreferredRegionLabel])
+
+	// Removing the hints removes the labels.
+	gw.Annotations = nil
+	_, result = reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{hostname}, gatewayDNSRecords{ttl: 300})
+	require.NoError(t, result.Err)
+
+	require.NoError(t, cl.Get(ctx, rsKey, &rs))
+	assert.NotContains(t, rs.Labels, gatewayutil.PreferredLocationLabel)
+	assert.NotContains(t, rs.Labels, gatewayutil.PreferredRegionLabel)
+}
+
 // ---------------------------------------------------------------------------
 // TestGarbageCollectDNSRecordSets
 // ---------------------------------------------------------------------------
@@ -824,12 +867,12 @@ func TestGarbageCollectDNSRecordSets(t *testing.T) {
 	cl := buildFakeUpstreamClientForDNS(s, allObjects...)
 	reconciler := newDNSReconciler(testConfig)
 
-	// desiredNames contains only the "keep" record; "stale" should be GC'd.
-	desiredNames := map[string]bool{
-		keepRS.Name: true,
+	// desired contains only the "keep" record; "stale" should be GC'd.
+	desired := map[client.ObjectKey]bool{
+		client.ObjectKeyFromObject(keepRS): true,
 	}
 
-	result := reconciler.garbageCollectDNSRecordSets(ctx, cl, gw, desiredNames)
+	result := reconciler.garbageCollectDNSRecordSets(ctx, cl, gw, desired)
 	require.NoError(t, result.Err)
 
 	// Stale record should be gone.
@@ -893,8 +936,8 @@ func TestGarbageCollectDNSRecordSets_DoesNotDeleteInUseRecords(t *testing.T) {
 	reconciler := newDNSReconciler(testConfig)
 
 	// Provide the record name as desired – it must not be deleted.
-	desiredNames := map[string]bool{rsName: true}
-	result := reconciler.garbageCollectDNSRecordSets(ctx, cl, gw, desiredNames)
+	desired := map[client.ObjectKey]bool{{Namespace: ns, Name: rsName}: true}
+	result := reconciler.garbageCollectDNSRecordSets(ctx, cl, gw, desired)
 	require.NoError(t, result.Err)
 
 	var remaining dnsv1alpha1.DNSRecordSet
@@ -1117,7 +1160,7 @@ func TestBuildDesiredDNSRecordSetSpec(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			spec := buildDesiredDNSRecordSetSpec(tt.hostname, tt.canonicalHostname, zone, tt.rrType)
+			spec := buildDesiredDNSRecordSetSpec(tt.hostname, tt.canonicalHostname, zone, tt.rrType, 300)
 
 			assert.Equal(t, tt.wantRecordType, spec.RecordType)
 			assert.Equal(t, "example-com", spec.DNSZoneRef.Name)
@@ -1176,7 +1219,7 @@ func TestEnsureDNSRecordSets_DNSIntegrationDisabled(t *testing.T) {
 	cl := buildFakeUpstreamClientForDNS(s, gw, domain, zone)
 	reconciler := newDNSReconciler(testConfig)
 
-	statuses, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{"api.example.com"})
+	statuses, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{"api.example.com"}, gatewayDNSRecords{ttl: 300})
 
 	require.NoError(t, result.Err)
 	assert.Empty(t, statuses, "no statuses should be returned when DNS integration is disabled")
@@ -0,0 +1,305 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// GatewayConditionDNSTargetsHealthy is set on upstream Gateways that are
+// propagated to multiple member clusters, and reports the health of each
+// member cluster the DNS records of the gateway fail over between.
+const GatewayConditionDNSTargetsHealthy = "DNSTargetsHealthy"
+
+const (
+	GatewayReasonDNSTargetsHealthy    = "Healthy"
+	GatewayReasonDNSTargetsDegraded   = "Degraded"
+	GatewayReasonDNSTargetsFailedOver = "FailedOver"
+	GatewayReasonNoHealthyDNSTargets  = "NoHealthyTargets"
+)
+
+// Health of a target. Healthy and Unknown are reported by Karmada in the
+// aggregated status of the ResourceBinding of the gateway.
+const (
+	dnsTargetHealthy     = "Healthy"
+	dnsTargetUnknown     = "Unknown"
+	dnsTargetNotApplied  = "NotApplied"
+	dnsTargetNoAddresses = "NoAddresses"
+)
+
+// gatewayDNSTarget is a member cluster that a downstream gateway is propagated
+// to.
+type gatewayDNSTarget struct {
+	cluster   string
+	secondary bool
+	health    string
+	addresses gatewayDNSAddresses
+}
+
+func (t gatewayDNSTarget) healthy() bool {
+	return t.health == dnsTargetHealthy
+}
+
+func (t gatewayDNSTarget) String() string {
+	role := "primary"
+	if t.secondary {
+		role = "secondary"
+	}
+	return fmt.Sprintf("%s (%s): %s", t.cluster, role, t.health)
+}
+
+// downstreamGatewayDNSTargets returns the member clusters that Karmada
+// propagated the downstream gateway to, with their health and the addresses
+// of their gateways, from the ResourceBinding of the downstream gateway. Only
+// the families enabled on addresses are kept. Nil is returned when DNS
+// failover is disabled or the gateway is bound to fewer than two clusters.
+func (r *GatewayReconciler) downstreamGatewayDNSTargets(
+	ctx context.Context,
+	downstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	addresses gatewayDNSAddresses,
+) ([]gatewayDNSTarget, error) {
+	cfg := r.Config.Gateway.DNSFailover
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	// Karmada names the binding of a resource template after its name and
+	// kind.
+	var binding unstructured.Unstructured
+	binding.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "work.karmada.io",
+		Version: "v1alpha2",
+		Kind:    "ResourceBinding",
+	})
+	key := client.ObjectKey{
+		Namespace: downstreamGateway.Namespace,
+		Name:      strings.ToLower(downstreamGateway.Name + "-" + KindGateway),
+	}
+	if err := downstreamStrategy.GetClient().Get(ctx, key, &binding); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed getting downstream gateway resourcebinding: %w", err)
+	}
+
+	aggregatedStatus, _, err := unstructured.NestedSlice(binding.Object, "status", "aggregatedStatus")
+	if err != nil {
+		return nil, fmt.Errorf("failed reading aggregated status of downstream gateway resourcebinding: %w", err)
+	}
+
+	var targets []gatewayDNSTarget
+	for _, item := range aggregatedStatus {
+		item, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		cluster, _, _ := unstructured.NestedString(item, "clusterName")
+		if cluster == "" {
+			continue
+		}
+		target := gatewayDNSTarget{
+			cluster:   cluster,
+			secondary: slices.Contains(cfg.SecondaryClusters, cluster),
+			addresses: gatewayDNSAddresses{ipv4Enabled: addresses.ipv4Enabled, ipv6Enabled: addresses.ipv6Enabled},
+		}
+
+		statusAddresses, _, _ := unstructured.NestedSlice(item, "status", "addresses")
+		for _, address := range statusAddresses {
+			address, ok := address.(map[string]any)
+			if !ok {
+				continue
+			}
+			addressType, _, _ := unstructured.NestedString(address, "type")
+			value, _, _ := unstructured.NestedString(address, "value")
+			if addressType != "" && addressType != string(gatewayv1.IPAddressType) {
+				continue
+			}
+			if strings.Contains(value, ":") {
+				if target.addresses.ipv6Enabled {
+					target.addresses.v6 = append(target.addresses.v6, value)
+				}
+			} else if value != "" && target.addresses.ipv4Enabled {
+				target.addresses.v4 = append(target.addresses.v4, value)
+			}
+		}
+
+		applied, _, _ := unstructured.NestedBool(item, "applied")
+		target.health, _, _ = unstructured.NestedString(item, "health")
+		switch {
+		case !applied:
+			target.health = dnsTargetNotApplied
+		case !target.addresses.available():
+			target.health = dnsTargetNoAddresses
+		case target.health == "":
+			target.health = dnsTargetUnknown
+		}
+		targets = append(targets, target)
+	}
+
+	if len(targets) < 2 {
+		return nil, nil
+	}
+	slices.SortFunc(targets, func(a, b gatewayDNSTarget) int {
+		return strings.Compare(a.cluster, b.cluster)
+	})
+	return targets, nil
+}
+
+// selectDNSFailoverTargets returns the targets that the DNS records of a
+// gateway point at: the healthy primary targets, or the healthy secondary
+// targets when no primary target is healthy. All targets are returned when
+// none is healthy, so that the hostnames of the gateway keep resolving.
+func selectDNSFailoverTargets(targets []gatewayDNSTarget) []gatewayDNSTarget {
+	var primaries, secondaries []gatewayDNSTarget
+	for _, target := range targets {
+		switch {
+		case !target.healthy():
+		case target.secondary:
+			secondaries = append(secondaries, target)
+		default:
+			primaries = append(primaries, target)
+		}
+	}
+	switch {
+	case len(primaries) > 0:
+		return primaries
+	case len(secondaries) > 0:
+		return secondaries
+	default:
+		return targets
+	}
+}
+
+// failoverDNSAddresses returns the addresses of the selected targets of a
+// gateway. The addresses of the downstream gateway are returned when no
+// selected target reports addresses.
+func failoverDNSAddresses(addresses gatewayDNSAddresses, targets []gatewayDNSTarget) gatewayDNSAddresses {
+	failover := gatewayDNSAddresses{ipv4Enabled: addresses.ipv4Enabled, ipv6Enabled: addresses.ipv6Enabled}
+	for _, target := range selectDNSFailoverTargets(targets) {
+		for _, v4 := range target.addresses.v4 {
+			if !slices.Contains(failover.v4, v4) {
+				failover.v4 = append(failover.v4, v4)
+			}
+		}
+		for _, v6 := range target.addresses.v6 {
+			if !slices.Contains(failover.v6, v6) {
+				failover.v6 = append(failover.v6, v6)
+			}
+		}
+	}
+	if !failover.available() {
+		return addresses
+	}
+	return failover
+}
+
+// desiredDNSRecordSet is a DNSRecordSet programmed for a hostname.
+type desiredDNSRecordSet struct {
+	key  client.ObjectKey
+	spec dnsv1alpha1.DNSRecordSetSpec
+}
+
+// failoverDNSRecordSets returns the A and AAAA DNSRecordSets that point
+// hostname at the failover addresses of its gateway.
+func failoverDNSRecordSets(
+	key client.ObjectKey,
+	hostname string,
+	dnsZone dnsv1alpha1.DNSZone,
+	addresses gatewayDNSAddresses,
+	ttl int64,
+) []desiredDNSRecordSet {
+	var recordSets []desiredDNSRecordSet
+	for _, family := range []struct {
+		rrType dnsv1alpha1.RRType
+		ips    []string
+	}{
+		{dnsv1alpha1.RRTypeA, addresses.v4},
+		{dnsv1alpha1.RRTypeAAAA, addresses.v6},
+	} {
+		if len(family.ips) == 0 {
+			continue
+		}
+		recordSets = append(recordSets, desiredDNSRecordSet{
+			key: client.ObjectKey{
+				Namespace: key.Namespace,
+				Name:      fmt.Sprintf("%s-%s", key.Name, strings.ToLower(string(family.rrType))),
+			},
+			spec: buildAddressDNSRecordSetSpec(hostname, dnsZone, family.rrType, family.ips, ttl),
+		})
+	}
+	return recordSets
+}
+
+// reconcileDNSTargetsStatus sets the DNSTargetsHealthy condition on the
+// upstream gateway from the health of its targets, and requeues the gateway
+// to check their health again. The condition is removed when the gateway has
+// no failover targets.
+func (r *GatewayReconciler) reconcileDNSTargetsStatus(
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	targets []gatewayDNSTarget,
+) (result Result) {
+	if len(targets) == 0 {
+		if apimeta.RemoveStatusCondition(&upstreamGateway.Status.Conditions, GatewayConditionDNSTargetsHealthy) {
+			result.AddStatusUpdate(upstreamClient, upstreamGateway)
+		}
+		return result
+	}
+
+	if interval := r.Config.Gateway.DNSFailover.RecheckInterval; interval != nil {
+		result.RequeueAfter = interval.Duration
+	}
+
+	healthy := 0
+	descriptions := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if target.healthy() {
+			healthy++
+		}
+		descriptions = append(descriptions, target.String())
+	}
+	selected := selectDNSFailoverTargets(targets)
+	serving := make([]string, 0, len(selected))
+	for _, target := range selected {
+		serving = append(serving, target.cluster)
+	}
+
+	condition := metav1.Condition{
+		Type:               GatewayConditionDNSTargetsHealthy,
+		Status:             metav1.ConditionTrue,
+		Reason:             GatewayReasonDNSTargetsHealthy,
+		ObservedGeneration: upstreamGateway.Generation,
+	}
+	switch {
+	case healthy == 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GatewayReasonNoHealthyDNSTargets
+	case selected[0].secondary:
+		condition.Reason = GatewayReasonDNSTargetsFailedOver
+	case healthy < len(targets):
+		condition.Reason = GatewayReasonDNSTargetsDegraded
+	}
+	condition.Message = fmt.Sprintf("%d/%d targets are healthy, DNS records point at %s: %s",
+		healthy, len(targets), strings.Join(serving, ", "), strings.Join(descriptions, ", "))
+
+	if !apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, condition) {
+		return result
+	}
+	result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	return result
+}
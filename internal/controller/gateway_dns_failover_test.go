@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+)
+
+func newTestGatewayResourceBinding(namespace, gatewayName string, clusters ...map[string]any) *unstructured.Unstructured {
+	aggregatedStatus := make([]any, 0, len(clusters))
+	for _, cluster := range clusters {
+		aggregatedStatus = append(aggregatedStatus, cluster)
+	}
+	binding := &unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{"aggregatedStatus": aggregatedStatus},
+	}}
+	binding.SetAPIVersion("work.karmada.io/v1alpha2")
+	binding.SetKind("ResourceBinding")
+	binding.SetNamespace(namespace)
+	binding.SetName(gatewayName + "-gateway")
+	return binding
+}
+
+func newTestMemberGatewayStatus(cluster, health string, addresses ...string) map[string]any {
+	statusAddresses := make([]any, 0, len(addresses))
+	for _, address := range addresses {
+		statusAddresses = append(statusAddresses, map[string]any{"type": "IPAddress", "value": address})
+	}
+	return map[string]any{
+		"clusterName": cluster,
+		"applied":     true,
+		"health":      health,
+		"status":      map[string]any{"addresses": statusAddresses},
+	}
+}
+
+func TestDownstreamGatewayDNSTargets(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	downstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-test", Name: "gateway"},
+	}
+	addresses := gatewayDNSAddresses{ipv4Enabled: true, v4: []string{"192.0.2.1"}}
+
+	tests := []struct {
+		name          string
+		clusters      []map[string]any
+		wantAddresses []string
+		wantReason    string
+	}{
+		{
+			name: "single cluster",
+			clusters: []map[string]any{
+				newTestMemberGatewayStatus("dfw", dnsTargetHealthy, "198.51.100.1"),
+			},
+			wantAddresses: []string{"192.0.2.1"},
+		},
+		{
+			name: "healthy primaries",
+			clusters: []map[string]any{
+				newTestMemberGatewayStatus("dfw", dnsTargetHealthy, "198.51.100.1"),
+				newTestMemberGatewayStatus("ord", dnsTargetHealthy, "198.51.100.2"),
+				newTestMemberGatewayStatus("sjc", dnsTargetHealthy, "198.51.100.3"),
+			},
+			wantAddresses: []string{"198.51.100.1", "198.51.100.2"},
+			wantReason:    GatewayReasonDNSTargetsHealthy,
+		},
+		{
+			name: "unhealthy primary",
+			clusters: []map[string]any{
+				newTestMemberGatewayStatus("dfw", dnsTargetHealthy, "198.51.100.1"),
+				newTestMemberGatewayStatus("ord", "Unhealthy", "198.51.100.2"),
+				newTestMemberGatewayStatus("sjc", dnsTargetHealthy, "198.51.100.3"),
+			},
+			wantAddresses: []string{"198.51.100.1"},
+			wantReason:    GatewayReasonDNSTargetsDegraded,
+		},
+		{
+			name: "fails over to secondary",
+			clusters: []map[string]any{
+				newTestMemberGatewayStatus("dfw", "Unhealthy", "198.51.100.1"),
+				newTestMemberGatewayStatus("ord", dnsTargetHealthy),
+				newTestMemberGatewayStatus("sjc", dnsTargetHealthy, "198.51.100.3"),
+			},
+			wantAddresses: []string{"198.51.100.3"},
+			wantReason:    GatewayReasonDNSTargetsFailedOver,
+		},
+		{
+			name: "no healthy targets",
+			clusters: []map[string]any{
+				newTestMemberGatewayStatus("dfw", "Unhealthy", "198.51.100.1"),
+				newTestMemberGatewayStatus("sjc", dnsTargetUnknown, "198.51.100.3"),
+			},
+			wantAddresses: []string{"198.51.100.1", "198.51.100.3"},
+			wantReason:    GatewayReasonNoHealthyDNSTargets,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeDownstreamClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(newTestGatewayResourceBinding("ns-test", "gateway", tt.clusters...)).
+				Build()
+			downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeDownstreamClient, fakeDownstreamClient)
+
+			reconciler := &GatewayReconciler{
+				Config: config.NetworkServicesOperator{Gateway: config.GatewayConfig{
+					DNSFailover: config.GatewayDNSFailoverConfig{
+						Enabled:           true,
+						SecondaryClusters: []string{"sjc"},
+						RecheckInterval:   &metav1.Duration{Duration: 30 * time.Second},
+					},
+				}},
+			}
+
+			targets, err := reconciler.downstreamGatewayDNSTargets(ctx, downstreamGateway, downstreamStrategy, addresses)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAddresses, failoverDNSAddresses(addresses, targets).v4)
+
+			upstreamGateway := &gatewayv1.Gateway{}
+			result := reconciler.reconcileDNSTargetsStatus(nil, upstreamGateway, targets)
+			condition := apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, GatewayConditionDNSTargetsHealthy)
+			if tt.wantReason == "" {
+				assert.Nil(t, condition)
+				assert.Zero(t, result.RequeueAfter)
+				return
+			}
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.wantReason, condition.Reason)
+			assert.NotZero(t, result.RequeueAfter)
+		})
+	}
+}
+
+func TestEnsureDNSRecordSetsFailover(t *testing.T) {
+	const ns = "test-ns"
+	ctx := log.IntoContext(context.Background(), zap.New())
+	s := newDNSTestScheme(t)
+
+	gw := newTestGatewayForDNS(ns, "my-gw")
+	domain := newVerifiedDNSZoneDomain(ns, "example.com", false)
+	zone := newDNSZone(ns, "example-com", "example.com")
+	hostname := "api.example.com"
+	cnameKey := dnsRecordSetKey(gw, hostname, zone)
+
+	cl := buildFakeUpstreamClientForDNS(s, gw, domain, zone)
+	reconciler := newDNSReconciler(config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{TargetDomain: "gateways.test.local", EnableDNSIntegration: true},
+	})
+
+	_, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{hostname}, gatewayDNSRecords{ttl: 300})
+	require.NoError(t, result.Err)
+	require.NoError(t, cl.Get(ctx, cnameKey, &dnsv1alpha1.DNSRecordSet{}))
+
+	failover := gatewayDNSAddresses{ipv4Enabled: true, v4: []string{"198.51.100.1", "198.51.100.2"}}
+	statuses, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{hostname}, gatewayDNSRecords{ttl: 300, failover: &failover})
+	require.NoError(t, result.Err)
+	require.Len(t, statuses, 1)
+
+	c := apimeta.FindStatusCondition(statuses[0].Conditions, networkingv1alpha.HostnameConditionDNSRecordProgrammed)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, `a record created in DNSZone "example-com"`, c.Message)
+
+	var a dnsv1alpha1.DNSRecordSet
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: cnameKey.Name + "-a"}, &a))
+	assert.Equal(t, dnsv1alpha1.RRTypeA, a.Spec.RecordType)
+	require.Len(t, a.Spec.Records, 2)
+	assert.Equal(t, "198.51.100.2", a.Spec.Records[1].A.Content)
+
+	err := cl.Get(ctx, cnameKey, &dnsv1alpha1.DNSRecordSet{})
+	assert.True(t, apierrors.IsNotFound(err), "expected the CNAME DNSRecordSet to be replaced, got %v", err)
+}
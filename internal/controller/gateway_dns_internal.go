@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+)
+
+// GatewayDNSVisibilityAnnotation selects whether the DNS records of a gateway
+// are published in public DNS, or only in an internal DNSZone. It can be set
+// on a GatewayClass for all of its gateways, and on a Gateway or HTTPProxy for
+// a single gateway.
+const GatewayDNSVisibilityAnnotation = "networking.datumapis.com/dns-visibility"
+
+// GatewayInternalDNSZoneAnnotation names the DNSZone, in the namespace of the
+// gateway, that the records of an internal gateway are programmed in.
+const GatewayInternalDNSZoneAnnotation = "networking.datumapis.com/internal-dns-zone"
+
+const (
+	// GatewayDNSVisibilityPublic publishes the canonical hostnames of the
+	// gateway with external-dns, and the custom hostnames in the DNSZones of
+	// their verified Domains.
+	GatewayDNSVisibilityPublic = "Public"
+	// GatewayDNSVisibilityInternal programs A and AAAA records of the custom
+	// hostnames of the gateway in its internal DNSZone, and publishes no public
+	// records.
+	GatewayDNSVisibilityInternal = "Internal"
+)
+
+// deleteDownstreamGatewayDNSEndpoint removes the DNSEndpoint that publishes the
+// canonical hostnames of a downstream gateway.
+func deleteDownstreamGatewayDNSEndpoint(
+	ctx context.Context,
+	downstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) error {
+	var gatewayDNSEndpoint unstructured.Unstructured
+	gatewayDNSEndpoint.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "externaldns.k8s.io",
+		Version: versionV1Alpha1,
+		Kind:    "DNSEndpoint",
+	})
+	gatewayDNSEndpoint.SetNamespace(downstreamGateway.Namespace)
+	gatewayDNSEndpoint.SetName(downstreamGateway.Name)
+
+	if err := downstreamStrategy.GetClient().Delete(ctx, &gatewayDNSEndpoint); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed deleting downstream gateway dnsendpoint: %w", err)
+	}
+	return nil
+}
+
+// ensureInternalDNSRecordSets programs A and AAAA DNSRecordSets that point the
+// claimed hostnames of an internal gateway at its addresses, in the internal
+// DNSZone of the gateway. Hostnames outside of the zone are marked
+// NotApplicable. Records of the gateway in other zones are removed.
+func (r *GatewayReconciler) ensureInternalDNSRecordSets(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	claimedHostnames []string,
+	addresses gatewayDNSAddresses,
+	dnsRecords gatewayDNSRecords,
+) (hostnameStatuses []networkingv1alpha.HostnameStatus, result Result) {
+	if !r.Config.Gateway.EnableDNSIntegration {
+		return nil, result
+	}
+
+	logger := log.FromContext(ctx)
+
+	setAll := func(status metav1.ConditionStatus, reason, message string) []networkingv1alpha.HostnameStatus {
+		statuses := make([]networkingv1alpha.HostnameStatus, 0, len(claimedHostnames))
+		for _, hostname := range claimedHostnames {
+			hs := networkingv1alpha.HostnameStatus{Hostname: hostname}
+			apimeta.SetStatusCondition(&hs.Conditions, metav1.Condition{
+				Type:               networkingv1alpha.HostnameConditionDNSRecordProgrammed,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				ObservedGeneration: upstreamGateway.Generation,
+			})
+			statuses = append(statuses, hs)
+		}
+		return statuses
+	}
+
+	if dnsRecords.internalZone == "" {
+		return setAll(metav1.ConditionFalse, networkingv1alpha.DNSRecordReasonZoneNotFound,
+			fmt.Sprintf("The gateway has internal DNS visibility, but the %s annotation is not set", GatewayInternalDNSZoneAnnotation)), result
+	}
+
+	var dnsZone dnsv1alpha1.DNSZone
+	if err := upstreamClient.Get(ctx, client.ObjectKey{Namespace: upstreamGateway.Namespace, Name: dnsRecords.internalZone}, &dnsZone); err != nil {
+		if !apierrors.IsNotFound(err) {
+			result.Err = fmt.Errorf("failed getting internal DNSZone: %w", err)
+			return nil, result
+		}
+		return setAll(metav1.ConditionFalse, networkingv1alpha.DNSRecordReasonZoneNotFound,
+			fmt.Sprintf("Internal DNSZone %q not found", dnsRecords.internalZone)), result
+	}
+	if !apimeta.IsStatusConditionTrue(dnsZone.Status.Conditions, conditionTypeAccepted) ||
+		!apimeta.IsStatusConditionTrue(dnsZone.Status.Conditions, conditionTypeProgrammed) {
+		return setAll(metav1.ConditionFalse, networkingv1alpha.DNSRecordReasonZoneNotReady,
+			fmt.Sprintf("Internal DNSZone %q is not ready (waiting for Accepted and Programmed conditions)", dnsZone.Name)), result
+	}
+	if !addresses.available() {
+		result.RequeueAfter = 5 * time.Second
+		return setAll(metav1.ConditionFalse, networkingv1alpha.DNSRecordReasonPending,
+			"Waiting for the gateway to be assigned addresses"), result
+	}
+
+	zoneDomain := strings.TrimSuffix(dnsZone.Spec.DomainName, ".")
+	desiredRecordSets := map[client.ObjectKey]bool{}
+
+	for _, hostname := range claimedHostnames {
+		hs := networkingv1alpha.HostnameStatus{Hostname: hostname}
+
+		if hostname != zoneDomain && !strings.HasSuffix(hostname, "."+zoneDomain) {
+			apimeta.SetStatusCondition(&hs.Conditions, metav1.Condition{
+				Type:               networkingv1alpha.HostnameConditionDNSRecordProgrammed,
+				Status:             metav1.ConditionTrue,
+				Reason:             networkingv1alpha.DNSRecordReasonNotApplicable,
+				Message:            fmt.Sprintf("Hostname is not in internal DNSZone %q", dnsZone.Name),
+				ObservedGeneration: upstreamGateway.Generation,
+			})
+			hostnameStatuses = append(hostnameStatuses, hs)
+			continue
+		}
+
+		reason := networkingv1alpha.DNSRecordReasonCreated
+		var programmed, failed []string
+		for _, family := range []struct {
+			rrType dnsv1alpha1.RRType
+			ips    []string
+		}{
+			{dnsv1alpha1.RRTypeA, addresses.v4},
+			{dnsv1alpha1.RRTypeAAAA, addresses.v6},
+		} {
+			if len(family.ips) == 0 {
+				continue
+			}
+
+			key := client.ObjectKey{
+				Namespace: upstreamGateway.Namespace,
+				Name:      fmt.Sprintf("%s-%s", dnsRecordSetName(upstreamGateway.Name, hostname), strings.ToLower(string(family.rrType))),
+			}
+			desiredRecordSets[key] = true
+
+			desired := buildDesiredDNSRecordSet(key)
+			operationResult, err := controllerutil.CreateOrUpdate(ctx, upstreamClient, desired, func() error {
+				if managedBy := desired.Labels[labelManagedBy]; managedBy != "" && managedBy != labelManagedByValue {
+					return fmt.Errorf("conflict: existing DNSRecordSet %q is managed by %q", desired.Name, managedBy)
+				}
+				if err := controllerutil.SetOwnerReference(upstreamGateway, desired, upstreamClient.Scheme()); err != nil {
+					return fmt.Errorf("failed to set owner reference on DNSRecordSet: %w", err)
+				}
+
+				if desired.Labels == nil {
+					desired.Labels = map[string]string{}
+				}
+				desired.Labels[labelManagedBy] = labelManagedByValue
+				desired.Labels[labelDNSManaged] = labelValueTrue
+				desired.Labels[labelDNSSourceKind] = KindGateway
+				desired.Labels[labelDNSSourceName] = upstreamGateway.Name
+				desired.Labels[labelDNSSourceNS] = upstreamGateway.Namespace
+				gatewayutil.GetSchedulingHints(upstreamGateway).ApplyLabels(desired.Labels)
+
+				if desired.Annotations == nil {
+					desired.Annotations = map[string]string{}
+				}
+				desired.Annotations[annotationDNSHostname] = hostname
+
+				desired.Spec = buildAddressDNSRecordSetSpec(hostname, dnsZone, family.rrType, family.ips, dnsRecords.ttl)
+				return nil
+			})
+			if err != nil {
+				if apierrors.IsConflict(err) {
+					result.RequeueAfter = retryAfterConflict
+					return hostnameStatuses, result
+				}
+				logger.Error(err, "failed to create or update internal DNSRecordSet",
+					"hostname", hostname,
+					"record_set_name", key.Name,
+				)
+				failed = append(failed, fmt.Sprintf("%s: %v", family.rrType, err))
+				continue
+			}
+
+			programmed = append(programmed, strings.ToLower(string(family.rrType)))
+			if operationResult == controllerutil.OperationResultUpdated {
+				reason = networkingv1alpha.DNSRecordReasonUpdated
+			}
+		}
+
+		condition := metav1.Condition{
+			Type:               networkingv1alpha.HostnameConditionDNSRecordProgrammed,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            fmt.Sprintf("%s records programmed in internal DNSZone %q", strings.Join(programmed, ", "), dnsZone.Name),
+			ObservedGeneration: upstreamGateway.Generation,
+		}
+		if len(failed) > 0 {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = networkingv1alpha.DNSRecordReasonFailed
+			condition.Message = fmt.Sprintf("Failed to create or update DNSRecordSet: %s", strings.Join(failed, "; "))
+		}
+		apimeta.SetStatusCondition(&hs.Conditions, condition)
+		hostnameStatuses = append(hostnameStatuses, hs)
+	}
+
+	gcResult := r.garbageCollectDNSRecordSets(ctx, upstreamClient, upstreamGateway, desiredRecordSets)
+	if gcResult.ShouldReturn() {
+		return hostnameStatuses, gcResult.Merge(result)
+	}
+
+	return hostnameStatuses, result
+}
+
+// buildAddressDNSRecordSetSpec constructs the DNSRecordSetSpec that points
+// hostname at the addresses of its gateway, with one record entry per
+// address.
+func buildAddressDNSRecordSetSpec(
+	hostname string,
+	dnsZone dnsv1alpha1.DNSZone,
+	rrType dnsv1alpha1.RRType,
+	ips []string,
+	ttl int64,
+) dnsv1alpha1.DNSRecordSetSpec {
+	fqdnHostname := hostname
+	if !strings.HasSuffix(fqdnHostname, ".") {
+		fqdnHostname = fqdnHostname + "."
+	}
+
+	records := make([]dnsv1alpha1.RecordEntry, 0, len(ips))
+	for _, ip := range ips {
+		entry := dnsv1alpha1.RecordEntry{Name: fqdnHostname, TTL: ptr.To(ttl)}
+		switch rrType {
+		case dnsv1alpha1.RRTypeA:
+			entry.A = &dnsv1alpha1.ARecordSpec{Content: ip}
+		case dnsv1alpha1.RRTypeAAAA:
+			entry.AAAA = &dnsv1alpha1.AAAARecordSpec{Content: ip}
+		}
+		records = append(records, entry)
+	}
+
+	return dnsv1alpha1.DNSRecordSetSpec{
+		DNSZoneRef: corev1.LocalObjectReference{Name: dnsZone.Name},
+		RecordType: rrType,
+		Records:    records,
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+)
+
+func TestEnsureInternalDNSRecordSets(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), zap.New(zap.UseDevMode(true)))
+	s := newDNSTestScheme(t)
+
+	gw := newTestGatewayForDNS("test-ns", "internal-gw")
+	zone := newDNSZone("test-ns", "corp-zone", "corp.example.com")
+
+	// A public record left over from before the gateway was made internal.
+	stale := buildDesiredDNSRecordSet(client.ObjectKey{Namespace: "test-ns", Name: dnsRecordSetName(gw.Name, "api.example.com")})
+	stale.Labels = map[string]string{
+		labelManagedBy:     labelManagedByValue,
+		labelDNSManaged:    labelValueTrue,
+		labelDNSSourceKind: KindGateway,
+		labelDNSSourceName: gw.Name,
+		labelDNSSourceNS:   gw.Namespace,
+	}
+	stale.Spec = dnsv1alpha1.DNSRecordSetSpec{RecordType: dnsv1alpha1.RRTypeCNAME}
+
+	cl := buildFakeUpstreamClientForDNS(s, gw, zone, stale)
+	reconciler := newDNSReconciler(config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{EnableDNSIntegration: true},
+	})
+
+	addresses := gatewayDNSAddresses{
+		ipv4Enabled: true,
+		ipv6Enabled: true,
+		v4:          []string{"10.0.0.1", "10.0.0.2"},
+		v6:          []string{"fd00::1"},
+	}
+	dnsRecords := gatewayDNSRecords{ttl: 60, visibility: GatewayDNSVisibilityInternal, internalZone: zone.Name}
+
+	statuses, result := reconciler.ensureInternalDNSRecordSets(ctx, cl, gw,
+		[]string{"api.corp.example.com", "api.example.com"}, addresses, dnsRecords)
+	require.NoError(t, result.Err)
+	require.Len(t, statuses, 2)
+
+	programmed := apimeta.FindStatusCondition(statuses[0].Conditions, networkingv1alpha.HostnameConditionDNSRecordProgrammed)
+	require.NotNil(t, programmed)
+	assert.Equal(t, metav1.ConditionTrue, programmed.Status)
+	assert.Equal(t, networkingv1alpha.DNSRecordReasonCreated, programmed.Reason)
+
+	notApplicable := apimeta.FindStatusCondition(statuses[1].Conditions, networkingv1alpha.HostnameConditionDNSRecordProgrammed)
+	require.NotNil(t, notApplicable)
+	assert.Equal(t, networkingv1alpha.DNSRecordReasonNotApplicable, notApplicable.Reason)
+
+	var a dnsv1alpha1.DNSRecordSet
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "test-ns", Name: dnsRecordSetName(gw.Name, "api.corp.example.com") + "-a"}, &a))
+	assert.Equal(t, dnsv1alpha1.RRTypeA, a.Spec.RecordType)
+	assert.Equal(t, zone.Name, a.Spec.DNSZoneRef.Name)
+	require.Len(t, a.Spec.Records, 2)
+	assert.Equal(t, "api.corp.example.com.", a.Spec.Records[0].Name)
+	assert.Equal(t, "10.0.0.1", a.Spec.Records[0].A.Content)
+	assert.Equal(t, int64(60), *a.Spec.Records[0].TTL)
+
+	var aaaa dnsv1alpha1.DNSRecordSet
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "test-ns", Name: dnsRecordSetName(gw.Name, "api.corp.example.com") + "-aaaa"}, &aaaa))
+	require.Len(t, aaaa.Spec.Records, 1)
+	assert.Equal(t, "fd00::1", aaaa.Spec.Records[0].AAAA.Content)
+
+	err := cl.Get(ctx, client.ObjectKeyFromObject(stale), &dnsv1alpha1.DNSRecordSet{})
+	assert.True(t, apierrors.IsNotFound(err), "expected stale public DNSRecordSet to be deleted, got %v", err)
+}
+
+func TestEnsureInternalDNSRecordSetsZoneNotFound(t *testing.T) {
+	ctx := context.Background()
+	s := newDNSTestScheme(t)
+
+	gw := newTestGatewayForDNS("test-ns", "internal-gw")
+	cl := buildFakeUpstreamClientForDNS(s, gw)
+	reconciler := newDNSReconciler(config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{EnableDNSIntegration: true},
+	})
+	addresses := gatewayDNSAddresses{ipv4Enabled: true, v4: []string{"10.0.0.1"}}
+
+	for _, zoneName := range []string{"", "missing"} {
+		dnsRecords := gatewayDNSRecords{ttl: 60, visibility: GatewayDNSVisibilityInternal, internalZone: zoneName}
+		statuses, result := reconciler.ensureInternalDNSRecordSets(ctx, cl, gw, []string{"api.corp.example.com"}, addresses, dnsRecords)
+		require.NoError(t, result.Err)
+		require.Len(t, statuses, 1)
+
+		condition := apimeta.FindStatusCondition(statuses[0].Conditions, networkingv1alpha.HostnameConditionDNSRecordProgrammed)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, networkingv1alpha.DNSRecordReasonZoneNotFound, condition.Reason)
+	}
+}
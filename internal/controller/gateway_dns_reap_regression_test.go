@@ -91,7 +91,7 @@ func TestHostnameRemoval_ReapsRecordInSameReconcile(t *testing.T) {
 	rsName := dnsRecordSetName(gw.Name, "www.ab.dk")
 
 	// --- Reconcile pass 1: hostname present, record gets created. ---
-	_, claimed1, _, err := reconciler.ensureHostnamesClaimed(ctx, upstreamCluster, fakeUpstreamClient, gw, downstreamGateway)
+	_, claimed1, _, err := reconciler.ensureHostnamesClaimed(ctx, upstreamCluster, fakeUpstreamClient, gw, downstreamGateway, nil)
 	require.NoError(t, err)
 	require.Contains(t, claimed1, "www.ab.dk", "pass 1: hostname should be claimed")
 
@@ -100,7 +100,7 @@ func TestHostnameRemoval_ReapsRecordInSameReconcile(t *testing.T) {
 	require.NoError(t, fakeDownstreamClient.Update(ctx, downstreamGateway))
 	require.True(t, hasListenerHostname(downstreamGateway, "www.ab.dk"), "pass 1: downstream should carry the www.ab.dk listener")
 
-	_, dnsResult1 := reconciler.ensureDNSRecordSets(ctx, fakeUpstreamClient, gw, claimed1)
+	_, dnsResult1 := reconciler.ensureDNSRecordSets(ctx, fakeUpstreamClient, gw, claimed1, gatewayDNSRecords{ttl: 300})
 	require.NoError(t, dnsResult1.Err)
 
 	var rs dnsv1alpha1.DNSRecordSet
@@ -124,7 +124,7 @@ func TestHostnameRemoval_ReapsRecordInSameReconcile(t *testing.T) {
 	// downstream snapshot at the top of this reconcile still has the old
 	// listener (it hasn't been updated yet this pass) -- that's exactly the
 	// state that used to keep the hostname claimed for an extra cycle. ---
-	_, claimed2, _, err := reconciler.ensureHostnamesClaimed(ctx, upstreamCluster, fakeUpstreamClient, gw, downstreamGateway)
+	_, claimed2, _, err := reconciler.ensureHostnamesClaimed(ctx, upstreamCluster, fakeUpstreamClient, gw, downstreamGateway, nil)
 	require.NoError(t, err)
 	assert.NotContains(t, claimed2, "www.ab.dk",
 		"pass 2: a hostname removed from the upstream gateway must not be resurrected by the stale downstream listener")
@@ -134,7 +134,7 @@ func TestHostnameRemoval_ReapsRecordInSameReconcile(t *testing.T) {
 	require.NoError(t, fakeDownstreamClient.Update(ctx, downstreamGateway))
 	assert.False(t, hasListenerHostname(downstreamGateway, "www.ab.dk"), "pass 2: downstream listener is dropped")
 
-	_, dnsResult2 := reconciler.ensureDNSRecordSets(ctx, fakeUpstreamClient, gw, claimed2)
+	_, dnsResult2 := reconciler.ensureDNSRecordSets(ctx, fakeUpstreamClient, gw, claimed2, gatewayDNSRecords{ttl: 300})
 	require.NoError(t, dnsResult2.Err)
 
 	err = fakeUpstreamClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: rsName}, &rs)
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
+)
+
+// GatewayDNSRecordTTLAnnotation overrides the TTL, in seconds, of the DNS
+// records of a gateway. It can be set on a GatewayClass for all of its
+// gateways, and on a Gateway or HTTPProxy for a single gateway.
+const GatewayDNSRecordTTLAnnotation = gatewayutil.DNSRecordTTLAnnotation
+
+// GatewayDNSRecordPolicyAnnotation overrides the DNS record policy of a
+// gateway. It can be set on a GatewayClass for all of its gateways, and on a
+// Gateway or HTTPProxy for a single gateway.
+const GatewayDNSRecordPolicyAnnotation = "networking.datumapis.com/dns-record-policy"
+
+// GatewayConditionDNSRecordsConfigured is set on upstream Gateways that
+// override the TTL, policy or visibility of their DNS records, and reports
+// whether the overrides are applied.
+const GatewayConditionDNSRecordsConfigured = "DNSRecordsConfigured"
+
+const (
+	GatewayReasonDNSRecordsConfigured    = "Configured"
+	GatewayReasonInvalidDNSRecordsConfig = "InvalidConfiguration"
+)
+
+// gatewayDNSRecords holds the TTL, policy and visibility of the DNS records
+// of a gateway.
+type gatewayDNSRecords struct {
+	ttl    int64
+	policy config.DNSRecordPolicy
+
+	// visibility is GatewayDNSVisibilityInternal for gateways whose records
+	// are only programmed in internalZone.
+	visibility   string
+	internalZone string
+
+	// failover, when set, holds the addresses of the healthy targets of a
+	// gateway propagated to multiple member clusters. The hostnames of the
+	// gateway are then programmed as A and AAAA records of these addresses.
+	failover *gatewayDNSAddresses
+}
+
+// internal returns whether the records of the gateway are only programmed in
+// an internal DNSZone.
+func (o gatewayDNSRecords) internal() bool {
+	return o.visibility == GatewayDNSVisibilityInternal
+}
+
+// recordType returns the record type that points a custom hostname at the
+// canonical hostname of the gateway.
+func (o gatewayDNSRecords) recordType(apex bool) dnsv1alpha1.RRType {
+	if apex || o.policy == config.DNSRecordPolicyAliasPreferred {
+		return dnsv1alpha1.RRTypeALIAS
+	}
+	return dnsv1alpha1.RRTypeCNAME
+}
+
+// gatewayDNSRecords returns the TTL, policy and visibility of the DNS records
+// of an upstream gateway. Annotations on the gateway take precedence over
+// those on its GatewayClass, which take precedence over the platform
+// configuration. Invalid annotations are ignored.
+func (r *GatewayReconciler) gatewayDNSRecords(gatewayClass *gatewayv1.GatewayClass, upstreamGateway *gatewayv1.Gateway) gatewayDNSRecords {
+	cfg := r.Config.Gateway.DNSRecords
+	records := gatewayDNSRecords{ttl: cfg.TTL, policy: cfg.Policy, visibility: GatewayDNSVisibilityPublic}
+
+	for _, obj := range []client.Object{gatewayClass, upstreamGateway} {
+		if ttl, ok, err := r.annotatedDNSRecordTTL(obj); ok && err == nil {
+			records.ttl = ttl
+		}
+		if policy, ok, err := annotatedDNSRecordPolicy(obj); ok && err == nil {
+			records.policy = policy
+		}
+		if visibility, ok, err := annotatedDNSVisibility(obj); ok && err == nil {
+			records.visibility = visibility
+		}
+		if zone := obj.GetAnnotations()[GatewayInternalDNSZoneAnnotation]; zone != "" {
+			records.internalZone = zone
+		}
+	}
+	return records
+}
+
+// annotatedDNSRecordTTL returns the TTL set by the annotations of obj, and
+// whether one is set.
+func (r *GatewayReconciler) annotatedDNSRecordTTL(obj client.Object) (int64, bool, error) {
+	value, ok := obj.GetAnnotations()[GatewayDNSRecordTTLAnnotation]
+	if !ok {
+		return 0, false, nil
+	}
+	cfg := r.Config.Gateway.DNSRecords
+	ttl, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || !cfg.AllowsTTL(ttl) {
+		bounds := fmt.Sprintf("at least %d", max(cfg.MinTTL, 1))
+		if cfg.MaxTTL > 0 {
+			bounds = fmt.Sprintf("between %d and %d", max(cfg.MinTTL, 1), cfg.MaxTTL)
+		}
+		return 0, true, fmt.Errorf("the DNS record TTL %q must be a number of seconds %s", value, bounds)
+	}
+	return ttl, true, nil
+}
+
+// annotatedDNSRecordPolicy returns the DNS record policy set by the
+// annotations of obj, and whether one is set.
+func annotatedDNSRecordPolicy(obj client.Object) (config.DNSRecordPolicy, bool, error) {
+	value, ok := obj.GetAnnotations()[GatewayDNSRecordPolicyAnnotation]
+	if !ok {
+		return "", false, nil
+	}
+	policy := config.DNSRecordPolicy(value)
+	if err := policy.Validate(); err != nil {
+		return "", true, fmt.Errorf("the DNS record policy %q is not one of %s, %s", value,
+			config.DNSRecordPolicyStandard, config.DNSRecordPolicyAliasPreferred)
+	}
+	return policy, true, nil
+}
+
+// annotatedDNSVisibility returns the DNS visibility set by the annotations of
+// obj, and whether one is set.
+func annotatedDNSVisibility(obj client.Object) (string, bool, error) {
+	value, ok := obj.GetAnnotations()[GatewayDNSVisibilityAnnotation]
+	if !ok {
+		return "", false, nil
+	}
+	switch value {
+	case GatewayDNSVisibilityPublic, GatewayDNSVisibilityInternal:
+		return value, true, nil
+	default:
+		return "", true, fmt.Errorf("the DNS visibility %q is not one of %s, %s", value,
+			GatewayDNSVisibilityPublic, GatewayDNSVisibilityInternal)
+	}
+}
+
+// reconcileDNSRecordsStatus sets the DNSRecordsConfigured condition on the
+// upstream gateway. The condition is removed when the gateway doesn't
+// override the TTL, policy or visibility of its DNS records.
+func (r *GatewayReconciler) reconcileDNSRecordsStatus(upstreamClient client.Client, upstreamGateway *gatewayv1.Gateway) (result Result) {
+	_, ttlSet, ttlErr := r.annotatedDNSRecordTTL(upstreamGateway)
+	_, policySet, policyErr := annotatedDNSRecordPolicy(upstreamGateway)
+	_, visibilitySet, visibilityErr := annotatedDNSVisibility(upstreamGateway)
+	if !ttlSet && !policySet && !visibilitySet {
+		if apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, GatewayConditionDNSRecordsConfigured) == nil {
+			return result
+		}
+		apimeta.RemoveStatusCondition(&upstreamGateway.Status.Conditions, GatewayConditionDNSRecordsConfigured)
+		result.AddStatusUpdate(upstreamClient, upstreamGateway)
+		return result
+	}
+
+	condition := metav1.Condition{
+		Type:               GatewayConditionDNSRecordsConfigured,
+		Status:             metav1.ConditionTrue,
+		Reason:             GatewayReasonDNSRecordsConfigured,
+		Message:            "The DNS records of the gateway are programmed with the requested TTL, policy and visibility",
+		ObservedGeneration: upstreamGateway.Generation,
+	}
+	var messages []string
+	for _, err := range []error{ttlErr, policyErr, visibilityErr} {
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	if len(messages) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GatewayReasonInvalidDNSRecordsConfig
+		condition.Message = fmt.Sprintf("%s, the default is used instead", strings.Join(messages, "; "))
+	}
+
+	if !apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, condition) {
+		return result
+	}
+	result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	return result
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestGatewayDNSRecords(t *testing.T) {
+	tests := map[string]struct {
+		classAnnotations   map[string]string
+		gatewayAnnotations map[string]string
+		expectedTTL        int64
+		expectedPolicy     config.DNSRecordPolicy
+		expectedVisibility string
+		expectedZone       string
+		expectedReason     string
+	}{
+		"platform defaults": {
+			expectedTTL:    300,
+			expectedPolicy: config.DNSRecordPolicyStandard,
+		},
+		"gateway class overrides": {
+			classAnnotations: map[string]string{
+				GatewayDNSRecordTTLAnnotation:    "600",
+				GatewayDNSRecordPolicyAnnotation: string(config.DNSRecordPolicyAliasPreferred),
+			},
+			expectedTTL:    600,
+			expectedPolicy: config.DNSRecordPolicyAliasPreferred,
+		},
+		"gateway overrides gateway class": {
+			classAnnotations:   map[string]string{GatewayDNSRecordTTLAnnotation: "600"},
+			gatewayAnnotations: map[string]string{GatewayDNSRecordTTLAnnotation: "60"},
+			expectedTTL:        60,
+			expectedPolicy:     config.DNSRecordPolicyStandard,
+			expectedReason:     GatewayReasonDNSRecordsConfigured,
+		},
+		"ttl out of bounds": {
+			classAnnotations:   map[string]string{GatewayDNSRecordTTLAnnotation: "600"},
+			gatewayAnnotations: map[string]string{GatewayDNSRecordTTLAnnotation: "5"},
+			expectedTTL:        600,
+			expectedPolicy:     config.DNSRecordPolicyStandard,
+			expectedReason:     GatewayReasonInvalidDNSRecordsConfig,
+		},
+		"unknown policy": {
+			gatewayAnnotations: map[string]string{GatewayDNSRecordPolicyAnnotation: "Proxied"},
+			expectedTTL:        300,
+			expectedPolicy:     config.DNSRecordPolicyStandard,
+			expectedReason:     GatewayReasonInvalidDNSRecordsConfig,
+		},
+		"internal visibility": {
+			classAnnotations: map[string]string{GatewayInternalDNSZoneAnnotation: "corp-zone"},
+			gatewayAnnotations: map[string]string{
+				GatewayDNSVisibilityAnnotation: GatewayDNSVisibilityInternal,
+			},
+			expectedTTL:        300,
+			expectedPolicy:     config.DNSRecordPolicyStandard,
+			expectedVisibility: GatewayDNSVisibilityInternal,
+			expectedZone:       "corp-zone",
+			expectedReason:     GatewayReasonDNSRecordsConfigured,
+		},
+		"unknown visibility": {
+			gatewayAnnotations: map[string]string{GatewayDNSVisibilityAnnotation: "Private"},
+			expectedTTL:        300,
+			expectedPolicy:     config.DNSRecordPolicyStandard,
+			expectedReason:     GatewayReasonInvalidDNSRecordsConfig,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gatewayClass := &gatewayv1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-class", Annotations: tt.classAnnotations},
+			}
+			gateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test-gw", Annotations: tt.gatewayAnnotations},
+			}
+
+			reconciler := &GatewayReconciler{
+				Config: config.NetworkServicesOperator{Gateway: config.GatewayConfig{
+					DNSRecords: config.GatewayDNSRecordsConfig{
+						TTL:    300,
+						MinTTL: 60,
+						MaxTTL: 86400,
+						Policy: config.DNSRecordPolicyStandard,
+					},
+				}},
+			}
+
+			records := reconciler.gatewayDNSRecords(gatewayClass, gateway)
+			assert.Equal(t, tt.expectedTTL, records.ttl)
+			assert.Equal(t, tt.expectedPolicy, records.policy)
+			expectedVisibility := tt.expectedVisibility
+			if expectedVisibility == "" {
+				expectedVisibility = GatewayDNSVisibilityPublic
+			}
+			assert.Equal(t, expectedVisibility, records.visibility)
+			assert.Equal(t, tt.expectedZone, records.internalZone)
+
+			reconciler.reconcileDNSRecordsStatus(nil, gateway)
+			condition := apimeta.FindStatusCondition(gateway.Status.Conditions, GatewayConditionDNSRecordsConfigured)
+			if tt.expectedReason == "" {
+				assert.Nil(t, condition)
+				return
+			}
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func TestGatewayDNSRecordsRecordType(t *testing.T) {
+	standard := gatewayDNSRecords{policy: config.DNSRecordPolicyStandard}
+	assert.Equal(t, dnsv1alpha1.RRTypeCNAME, standard.recordType(false))
+	assert.Equal(t, dnsv1alpha1.RRTypeALIAS, standard.recordType(true))
+
+	aliasPreferred := gatewayDNSRecords{policy: config.DNSRecordPolicyAliasPreferred}
+	assert.Equal(t, dnsv1alpha1.RRTypeALIAS, aliasPreferred.recordType(false))
+	assert.Equal(t, dnsv1alpha1.RRTypeALIAS, aliasPreferred.recordType(true))
+}
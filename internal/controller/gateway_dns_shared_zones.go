@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+)
+
+const KindDNSZone = "DNSZone"
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch
+
+// sharedDNSZoneSelector returns the selector for DNSZones that may be used by
+// Gateways in other namespaces, or nil when shared DNSZones are disabled.
+func (r *GatewayReconciler) sharedDNSZoneSelector() (labels.Selector, error) {
+	if r.Config.Gateway.SharedDNSZoneSelector == nil {
+		return nil, nil
+	}
+	return metav1.LabelSelectorAsSelector(r.Config.Gateway.SharedDNSZoneSelector)
+}
+
+// findDNSZonesForDomain returns the DNSZones for a domain name that the gateway
+// may place DNS records in. DNSZones in the gateway's namespace come first,
+// followed by shared DNSZones whose namespace has a ReferenceGrant that allows
+// the gateway to reference them.
+func (r *GatewayReconciler) findDNSZonesForDomain(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	domainName string,
+) ([]dnsv1alpha1.DNSZone, error) {
+	var dnsZoneList dnsv1alpha1.DNSZoneList
+	if err := upstreamClient.List(ctx, &dnsZoneList,
+		client.InNamespace(upstreamGateway.Namespace),
+		client.MatchingFields{dnsZoneDomainNameIndex: domainName},
+	); err != nil {
+		return nil, err
+	}
+	dnsZones := dnsZoneList.Items
+
+	selector, err := r.sharedDNSZoneSelector()
+	if err != nil || selector == nil {
+		return dnsZones, err
+	}
+
+	var sharedDNSZoneList dnsv1alpha1.DNSZoneList
+	if err := upstreamClient.List(ctx, &sharedDNSZoneList,
+		client.MatchingLabelsSelector{Selector: selector},
+		client.MatchingFields{dnsZoneDomainNameIndex: domainName},
+	); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(sharedDNSZoneList.Items, func(a, b dnsv1alpha1.DNSZone) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+
+	for _, dnsZone := range sharedDNSZoneList.Items {
+		if dnsZone.Namespace == upstreamGateway.Namespace {
+			continue
+		}
+		granted, err := dnsZoneReferenceGranted(ctx, upstreamClient, upstreamGateway.Namespace, &dnsZone)
+		if err != nil {
+			return nil, err
+		}
+		if granted {
+			dnsZones = append(dnsZones, dnsZone)
+		}
+	}
+	return dnsZones, nil
+}
+
+// dnsZoneReferenceGranted returns whether a ReferenceGrant in the DNSZone's
+// namespace allows Gateways in fromNamespace to reference the DNSZone.
+func dnsZoneReferenceGranted(
+	ctx context.Context,
+	upstreamClient client.Client,
+	fromNamespace string,
+	dnsZone *dnsv1alpha1.DNSZone,
+) (bool, error) {
+	var referenceGrants gatewayv1.ReferenceGrantList
+	if err := upstreamClient.List(ctx, &referenceGrants, client.InNamespace(dnsZone.Namespace)); err != nil {
+		return false, fmt.Errorf("failed listing referencegrants: %w", err)
+	}
+
+	for _, referenceGrant := range referenceGrants.Items {
+		if !slices.ContainsFunc(referenceGrant.Spec.From, func(from gatewayv1.ReferenceGrantFrom) bool {
+			return isGatewayReferenceGrantFrom(from) && string(from.Namespace) == fromNamespace
+		}) {
+			continue
+		}
+		if slices.ContainsFunc(referenceGrant.Spec.To, func(to gatewayv1.ReferenceGrantTo) bool {
+			return isDNSZoneReferenceGrantTo(to) && (to.Name == nil || string(*to.Name) == dnsZone.Name)
+		}) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isGatewayReferenceGrantFrom(from gatewayv1.ReferenceGrantFrom) bool {
+	return from.Group == gatewayv1.GroupName && from.Kind == KindGateway
+}
+
+func isDNSZoneReferenceGrantTo(to gatewayv1.ReferenceGrantTo) bool {
+	return string(to.Group) == dnsv1alpha1.GroupVersion.Group && to.Kind == KindDNSZone
+}
+
+// dnsRecordSetKey returns the key of the DNSRecordSet for a gateway hostname.
+// DNSRecordSets are created in the namespace of their DNSZone. Records in a
+// shared DNSZone include the gateway namespace in their name, as gateways from
+// several namespaces place records there.
+func dnsRecordSetKey(upstreamGateway *gatewayv1.Gateway, hostname string, dnsZone *dnsv1alpha1.DNSZone) client.ObjectKey {
+	if dnsZone.Namespace == upstreamGateway.Namespace {
+		return client.ObjectKey{Namespace: dnsZone.Namespace, Name: dnsRecordSetName(upstreamGateway.Name, hostname)}
+	}
+	return client.ObjectKey{
+		Namespace: dnsZone.Namespace,
+		Name:      dnsRecordSetName(upstreamGateway.Namespace+"-"+upstreamGateway.Name, hostname),
+	}
+}
+
+// dnsRecordSetListNamespace returns the namespace to list the DNSRecordSets of
+// a gateway in. All namespaces are listed when shared DNSZones are enabled.
+func (r *GatewayReconciler) dnsRecordSetListNamespace(upstreamGateway *gatewayv1.Gateway) client.InNamespace {
+	if r.Config.Gateway.SharedDNSZoneSelector != nil {
+		return client.InNamespace(metav1.NamespaceAll)
+	}
+	return client.InNamespace(upstreamGateway.Namespace)
+}
+
+// listGatewaysForDNSZoneGrants returns requests for the Gateways in the
+// namespaces that ReferenceGrants in the given namespace allow to reference
+// DNSZones.
+func listGatewaysForDNSZoneGrants(
+	ctx context.Context,
+	clusterName multicluster.ClusterName,
+	cl client.Client,
+	referenceGrants []gatewayv1.ReferenceGrant,
+) []mcreconcile.Request {
+	logger := log.FromContext(ctx)
+
+	namespaces := sets.New[string]()
+	for _, referenceGrant := range referenceGrants {
+		if !slices.ContainsFunc(referenceGrant.Spec.To, isDNSZoneReferenceGrantTo) {
+			continue
+		}
+		for _, from := range referenceGrant.Spec.From {
+			if isGatewayReferenceGrantFrom(from) {
+				namespaces.Insert(string(from.Namespace))
+			}
+		}
+	}
+
+	var requests []mcreconcile.Request
+	for _, namespace := range sets.List(namespaces) {
+		var gatewayList gatewayv1.GatewayList
+		if err := cl.List(ctx, &gatewayList, client.InNamespace(namespace)); err != nil {
+			logger.Error(err, "failed to list Gateways for DNSZone reference grant", "namespace", namespace)
+			continue
+		}
+		for _, gw := range gatewayList.Items {
+			requests = append(requests, mcreconcile.Request{
+				ClusterName: clusterName,
+				Request: reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&gw),
+				},
+			})
+		}
+	}
+	return requests
+}
+
+// listGatewaysForReferenceGrantFunc returns a TypedEventHandler that enqueues
+// the Gateways a ReferenceGrant allows to reference DNSZones, so DNS records
+// are moved when access to a shared DNSZone is granted or revoked.
+func (r *GatewayReconciler) listGatewaysForReferenceGrantFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		referenceGrant := obj.(*gatewayv1.ReferenceGrant)
+		return listGatewaysForDNSZoneGrants(ctx, clusterName, cl.GetClient(), []gatewayv1.ReferenceGrant{*referenceGrant})
+	})
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+)
+
+const sharedDNSZoneLabel = "dns.datumapis.com/shared"
+
+func newDNSZoneReferenceGrant(namespace, fromNamespace string, zoneName *string) *gatewayv1.ReferenceGrant {
+	return &gatewayv1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "allow-" + fromNamespace},
+		Spec: gatewayv1.ReferenceGrantSpec{
+			From: []gatewayv1.ReferenceGrantFrom{
+				{Group: gatewayv1.GroupName, Kind: KindGateway, Namespace: gatewayv1.Namespace(fromNamespace)},
+			},
+			To: []gatewayv1.ReferenceGrantTo{
+				{
+					Group: gatewayv1.Group(dnsv1alpha1.GroupVersion.Group),
+					Kind:  KindDNSZone,
+					Name:  (*gatewayv1.ObjectName)(zoneName),
+				},
+			},
+		},
+	}
+}
+
+func TestDNSZoneReferenceGranted(t *testing.T) {
+	zone := newDNSZone("platform", "example-com", "example.com")
+
+	tests := []struct {
+		name           string
+		referenceGrant *gatewayv1.ReferenceGrant
+		want           bool
+	}{
+		{
+			name: "no reference grant",
+		},
+		{
+			name:           "grant for all DNSZones",
+			referenceGrant: newDNSZoneReferenceGrant("platform", "test-ns", nil),
+			want:           true,
+		},
+		{
+			name:           "grant for the DNSZone",
+			referenceGrant: newDNSZoneReferenceGrant("platform", "test-ns", ptr.To("example-com")),
+			want:           true,
+		},
+		{
+			name:           "grant for another DNSZone",
+			referenceGrant: newDNSZoneReferenceGrant("platform", "test-ns", ptr.To("example-net")),
+		},
+		{
+			name:           "grant for another namespace",
+			referenceGrant: newDNSZoneReferenceGrant("platform", "other-ns", nil),
+		},
+		{
+			name:           "grant in another namespace",
+			referenceGrant: newDNSZoneReferenceGrant("other-platform", "test-ns", nil),
+		},
+		{
+			name: "grant for another kind",
+			referenceGrant: func() *gatewayv1.ReferenceGrant {
+				referenceGrant := newDNSZoneReferenceGrant("platform", "test-ns", nil)
+				referenceGrant.Spec.To[0] = gatewayv1.ReferenceGrantTo{Kind: "Secret"}
+				return referenceGrant
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objects []client.Object
+			if tt.referenceGrant != nil {
+				objects = append(objects, tt.referenceGrant)
+			}
+			cl := buildFakeUpstreamClientForDNS(newDNSTestScheme(t), objects...)
+
+			granted, err := dnsZoneReferenceGranted(context.Background(), cl, "test-ns", zone)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, granted)
+		})
+	}
+}
+
+func TestEnsureDNSRecordSets_SharedDNSZone(t *testing.T) {
+	const ns = "test-ns"
+	const sharedNS = "platform"
+	const hostname = "api.example.com"
+	ctx := log.IntoContext(context.Background(), zap.New())
+	s := newDNSTestScheme(t)
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			TargetDomain:         "gateways.test.local",
+			EnableDNSIntegration: true,
+			SharedDNSZoneSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{sharedDNSZoneLabel: "true"},
+			},
+		},
+	}
+
+	gw := newTestGatewayForDNS(ns, "my-gw")
+	domain := newVerifiedDNSZoneDomain(ns, "example.com", false)
+	sharedZone := newDNSZone(sharedNS, "example-com", "example.com")
+	sharedZone.Labels = map[string]string{sharedDNSZoneLabel: "true"}
+	unlabeledZone := newDNSZone("other-platform", "example-com", "example.com")
+
+	t.Run("shared DNSZone without reference grant is not used", func(t *testing.T) {
+		cl := buildFakeUpstreamClientForDNS(s, gw, domain, sharedZone, unlabeledZone,
+			newDNSZoneReferenceGrant("other-platform", ns, nil))
+		reconciler := newDNSReconciler(testConfig)
+
+		statuses, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{hostname}, gatewayDNSRecords{ttl: 300})
+		require.NoError(t, result.Err)
+		require.Len(t, statuses, 1)
+		c := apimeta.FindStatusCondition(statuses[0].Conditions, networkingv1alpha.HostnameConditionDNSRecordProgrammed)
+		require.NotNil(t, c)
+		assert.Equal(t, networkingv1alpha.DNSRecordReasonNotApplicable, c.Reason)
+
+		var list dnsv1alpha1.DNSRecordSetList
+		require.NoError(t, cl.List(ctx, &list))
+		assert.Empty(t, list.Items)
+	})
+
+	t.Run("granted shared DNSZone holds the record", func(t *testing.T) {
+		cl := buildFakeUpstreamClientForDNS(s, gw, domain, sharedZone,
+			newDNSZoneReferenceGrant(sharedNS, ns, ptr.To(sharedZone.Name)))
+		reconciler := newDNSReconciler(testConfig)
+
+		statuses, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{hostname}, gatewayDNSRecords{ttl: 300})
+		require.NoError(t, result.Err)
+		require.Len(t, statuses, 1)
+		c := apimeta.FindStatusCondition(statuses[0].Conditions, networkingv1alpha.HostnameConditionDNSRecordProgrammed)
+		require.NotNil(t, c)
+		assert.Equal(t, metav1.ConditionTrue, c.Status)
+		assert.Equal(t, networkingv1alpha.DNSRecordReasonCreated, c.Reason)
+
+		key := dnsRecordSetKey(gw, hostname, sharedZone)
+		assert.Equal(t, sharedNS, key.Namespace)
+		var rs dnsv1alpha1.DNSRecordSet
+		require.NoError(t, cl.Get(ctx, key, &rs))
+		assert.Equal(t, sharedZone.Name, rs.Spec.DNSZoneRef.Name)
+		assert.Equal(t, ns, rs.Labels[labelDNSSourceNS])
+		assert.Empty(t, rs.OwnerReferences, "records cannot be owned across namespaces")
+
+		// Removing the hostname garbage collects the record in the shared namespace.
+		_, result = reconciler.ensureDNSRecordSets(ctx, cl, gw, nil, gatewayDNSRecords{ttl: 300})
+		require.NoError(t, result.Err)
+		var list dnsv1alpha1.DNSRecordSetList
+		require.NoError(t, cl.List(ctx, &list))
+		assert.Empty(t, list.Items)
+	})
+
+	t.Run("DNSZone in the gateway namespace is preferred", func(t *testing.T) {
+		localZone := newDNSZone(ns, "example-com", "example.com")
+		cl := buildFakeUpstreamClientForDNS(s, gw, domain, sharedZone, localZone,
+			newDNSZoneReferenceGrant(sharedNS, ns, nil))
+		reconciler := newDNSReconciler(testConfig)
+
+		_, result := reconciler.ensureDNSRecordSets(ctx, cl, gw, []string{hostname}, gatewayDNSRecords{ttl: 300})
+		require.NoError(t, result.Err)
+
+		var rs dnsv1alpha1.DNSRecordSet
+		require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: dnsRecordSetName(gw.Name, hostname)}, &rs))
+		assert.Len(t, rs.OwnerReferences, 1)
+	})
+}
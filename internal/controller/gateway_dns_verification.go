@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	dnsutil "go.datum.net/network-services-operator/internal/util/dns"
+)
+
+const dnsVerificationLookupTimeout = 5 * time.Second
+
+// dnsResolver is a recursive resolver that hostnames are looked up on.
+type dnsResolver struct {
+	name     string
+	exchange dnsutil.ExchangeFunc
+}
+
+// dnsRecordVerifier looks up the hostnames of gateways on public resolvers,
+// and caches the hostnames that resolve to the addresses of their gateway so
+// that gateways are not blocked on DNS lookups every reconcile.
+type dnsRecordVerifier struct {
+	resolvers       []dnsResolver
+	ttl             time.Duration
+	recheckInterval time.Duration
+	now             func() time.Time
+
+	mu       sync.Mutex
+	verified map[string]time.Time
+}
+
+func newDNSRecordVerifier(resolvers []dnsResolver, ttl, recheckInterval time.Duration) *dnsRecordVerifier {
+	return &dnsRecordVerifier{
+		resolvers:       resolvers,
+		ttl:             ttl,
+		recheckInterval: recheckInterval,
+		now:             time.Now,
+		verified:        map[string]time.Time{},
+	}
+}
+
+// dnsRecordExpectation is the answer expected for the A or AAAA records of a
+// hostname.
+type dnsRecordExpectation struct {
+	hostname  string
+	qtype     uint16
+	addresses []netip.Addr
+}
+
+// resolves returns whether resolver answers the hostname of expectation with
+// exactly its addresses. Lookup failures and hostnames that are still
+// propagating are not cached.
+func (v *dnsRecordVerifier) resolves(ctx context.Context, resolver dnsResolver, expectation dnsRecordExpectation) (bool, error) {
+	now := v.now()
+	key := fmt.Sprintf("%s/%s/%s/%v", resolver.name, expectation.hostname, dns.TypeToString[expectation.qtype], expectation.addresses)
+
+	v.mu.Lock()
+	expires, ok := v.verified[key]
+	v.mu.Unlock()
+	if ok && now.Before(expires) {
+		return true, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dnsVerificationLookupTimeout)
+	defer cancel()
+	addresses, err := dnsutil.LookupAddresses(ctx, resolver.exchange, expectation.hostname, expectation.qtype)
+	if err != nil {
+		return false, err
+	}
+	if !slices.Equal(addresses, expectation.addresses) {
+		return false, nil
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for key, expires := range v.verified {
+		if !now.Before(expires) {
+			delete(v.verified, key)
+		}
+	}
+	v.verified[key] = now.Add(v.ttl)
+	return true, nil
+}
+
+// gatewayDNSExpectations returns the answers expected for the hostnames of a
+// gateway: its canonical hostnames, which are published as A and AAAA records,
+// and the custom hostnames with programmed DNS records, which are CNAME or
+// ALIAS records of a canonical hostname. Both resolve to the addresses of the
+// gateway.
+func gatewayDNSExpectations(
+	addresses gatewayDNSAddresses,
+	canonicalHostnames []string,
+	hostnameStatuses []networkingv1alpha.HostnameStatus,
+) []dnsRecordExpectation {
+	v4 := parseSortedAddrs(addresses.v4)
+	v6 := parseSortedAddrs(addresses.v6)
+
+	var expectations []dnsRecordExpectation
+	add := func(hostname string, v4Allowed, v6Allowed bool) {
+		if addresses.ipv4Enabled && v4Allowed {
+			expectations = append(expectations, dnsRecordExpectation{hostname: hostname, qtype: dns.TypeA, addresses: v4})
+		}
+		if addresses.ipv6Enabled && v6Allowed {
+			expectations = append(expectations, dnsRecordExpectation{hostname: hostname, qtype: dns.TypeAAAA, addresses: v6})
+		}
+	}
+
+	for _, hostname := range canonicalHostnames {
+		add(hostname, !strings.HasPrefix(hostname, "v6."), !strings.HasPrefix(hostname, "v4."))
+	}
+	for _, hs := range hostnameStatuses {
+		c := apimeta.FindStatusCondition(hs.Conditions, networkingv1alpha.HostnameConditionDNSRecordProgrammed)
+		if c == nil || c.Status != metav1.ConditionTrue || c.Reason == networkingv1alpha.DNSRecordReasonNotApplicable {
+			continue
+		}
+		add(hs.Hostname, true, true)
+	}
+	return expectations
+}
+
+func parseSortedAddrs(values []string) []netip.Addr {
+	var addrs []netip.Addr
+	for _, value := range values {
+		if ip, err := netip.ParseAddr(value); err == nil && !slices.Contains(addrs, ip.Unmap()) {
+			addrs = append(addrs, ip.Unmap())
+		}
+	}
+	slices.SortFunc(addrs, netip.Addr.Compare)
+	return addrs
+}
+
+// reconcileDNSResolvableStatus sets the DNSRecordsResolvable condition on the
+// upstream gateway from the answers of the resolvers for its hostnames. The
+// propagation latency is measured from when the gateway was first seen with
+// records that didn't resolve. The condition is removed when DNS records are
+// not verified.
+func (r *GatewayReconciler) reconcileDNSResolvableStatus(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	addresses gatewayDNSAddresses,
+	canonicalHostnames []string,
+	hostnameStatuses []networkingv1alpha.HostnameStatus,
+) (result Result) {
+	var expectations []dnsRecordExpectation
+	if r.dnsVerifier != nil {
+		expectations = gatewayDNSExpectations(addresses, canonicalHostnames, hostnameStatuses)
+	}
+	if len(expectations) == 0 {
+		if apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, networkingv1alpha.HTTPProxyConditionDNSRecordsResolvable) == nil {
+			return result
+		}
+		apimeta.RemoveStatusCondition(&upstreamGateway.Status.Conditions, networkingv1alpha.HTTPProxyConditionDNSRecordsResolvable)
+		result.AddStatusUpdate(upstreamClient, upstreamGateway)
+		return result
+	}
+
+	logger := log.FromContext(ctx)
+
+	var pending, failed []string
+	if addresses.available() {
+		for _, expectation := range expectations {
+			for _, resolver := range r.dnsVerifier.resolvers {
+				ok, err := r.dnsVerifier.resolves(ctx, resolver, expectation)
+				description := fmt.Sprintf("%s %s on %s", expectation.hostname, dns.TypeToString[expectation.qtype], resolver.name)
+				switch {
+				case err != nil:
+					logger.Info("failed looking up DNS records", "hostname", expectation.hostname, "resolver", resolver.name, "error", err.Error())
+					failed = append(failed, description)
+				case !ok:
+					pending = append(pending, description)
+				}
+			}
+		}
+	}
+
+	existing := apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, networkingv1alpha.HTTPProxyConditionDNSRecordsResolvable)
+	condition := metav1.Condition{
+		Type:               networkingv1alpha.HTTPProxyConditionDNSRecordsResolvable,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.DNSRecordsResolvableReasonResolvable,
+		Message:            fmt.Sprintf("All hostnames resolve to the gateway addresses on %d resolvers", len(r.dnsVerifier.resolvers)),
+		ObservedGeneration: upstreamGateway.Generation,
+	}
+	switch {
+	case !addresses.available():
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = networkingv1alpha.DNSRecordsResolvableReasonPropagating
+		condition.Message = "Waiting for the gateway to be assigned addresses"
+	case len(pending) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = networkingv1alpha.DNSRecordsResolvableReasonPropagating
+		condition.Message = fmt.Sprintf("Waiting for DNS records to propagate: %s", strings.Join(pending, ", "))
+	case len(failed) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = networkingv1alpha.DNSRecordsResolvableReasonLookupFailed
+		condition.Message = fmt.Sprintf("Failed looking up DNS records: %s", strings.Join(failed, ", "))
+	case existing != nil && existing.Status == metav1.ConditionTrue:
+		condition.Message = existing.Message
+	case existing != nil:
+		latency := r.dnsVerifier.now().Sub(existing.LastTransitionTime.Time).Round(time.Second)
+		condition.Message += fmt.Sprintf(", propagated in %s", latency)
+	}
+
+	if condition.Status != metav1.ConditionTrue {
+		result.RequeueAfter = r.dnsVerifier.recheckInterval
+	}
+
+	if !apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, condition) {
+		return result
+	}
+	result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	return result
+}
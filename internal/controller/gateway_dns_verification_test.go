@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestReconcileDNSResolvableStatus(t *testing.T) {
+	ctx := context.Background()
+
+	// answers holds the A records that the resolver answers, keyed by
+	// hostname.
+	answers := map[string][]string{}
+	lookups := 0
+	exchange := func(_ context.Context, name string, qtype uint16) (*dns.Msg, error) {
+		lookups++
+		msg := new(dns.Msg)
+		if qtype != dns.TypeA {
+			return msg, nil
+		}
+		for _, ip := range answers[name] {
+			msg.Answer = append(msg.Answer, &dns.A{
+				Hdr: dns.RR_Header{Name: dns.Fqdn(name), Rrtype: dns.TypeA, Class: dns.ClassINET},
+				A:   net.ParseIP(ip),
+			})
+		}
+		return msg, nil
+	}
+
+	reconciler := &GatewayReconciler{
+		dnsVerifier: newDNSRecordVerifier([]dnsResolver{{name: "192.0.2.53:53", exchange: exchange}}, time.Minute, 30*time.Second),
+	}
+	now := time.Now()
+	reconciler.dnsVerifier.now = func() time.Time { return now }
+
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 1},
+	}
+	addresses := gatewayDNSAddresses{ipv4Enabled: true, v4: []string{"198.51.100.1"}}
+	canonical := []string{"gw.example.net", "v4.gw.example.net"}
+	hostnameStatuses := []networkingv1alpha.HostnameStatus{
+		{
+			Hostname: "www.example.com",
+			Conditions: []metav1.Condition{{
+				Type:   networkingv1alpha.HostnameConditionDNSRecordProgrammed,
+				Status: metav1.ConditionTrue,
+				Reason: networkingv1alpha.DNSRecordReasonCreated,
+			}},
+		},
+		{
+			Hostname: "other.example.org",
+			Conditions: []metav1.Condition{{
+				Type:   networkingv1alpha.HostnameConditionDNSRecordProgrammed,
+				Status: metav1.ConditionTrue,
+				Reason: networkingv1alpha.DNSRecordReasonNotApplicable,
+			}},
+		},
+	}
+
+	condition := func() *metav1.Condition {
+		return apimeta.FindStatusCondition(gw.Status.Conditions, networkingv1alpha.HTTPProxyConditionDNSRecordsResolvable)
+	}
+
+	// The gateway has no addresses yet.
+	result := reconciler.reconcileDNSResolvableStatus(ctx, nil, gw, gatewayDNSAddresses{ipv4Enabled: true}, canonical, hostnameStatuses)
+	require.NotNil(t, condition())
+	assert.Equal(t, networkingv1alpha.DNSRecordsResolvableReasonPropagating, condition().Reason)
+	assert.Equal(t, 30*time.Second, result.RequeueAfter)
+	assert.Zero(t, lookups)
+
+	// Only the canonical hostnames have propagated.
+	answers["gw.example.net"] = []string{"198.51.100.1"}
+	answers["v4.gw.example.net"] = []string{"198.51.100.1"}
+	result = reconciler.reconcileDNSResolvableStatus(ctx, nil, gw, addresses, canonical, hostnameStatuses)
+	require.NotNil(t, condition())
+	assert.Equal(t, metav1.ConditionFalse, condition().Status)
+	assert.Contains(t, condition().Message, "www.example.com A on 192.0.2.53:53")
+	assert.NotContains(t, condition().Message, "other.example.org")
+	assert.Equal(t, 30*time.Second, result.RequeueAfter)
+
+	// All hostnames have propagated.
+	condition().LastTransitionTime = metav1.NewTime(now.Add(-42 * time.Second))
+	answers["www.example.com"] = []string{"198.51.100.1"}
+	result = reconciler.reconcileDNSResolvableStatus(ctx, nil, gw, addresses, canonical, hostnameStatuses)
+	require.NotNil(t, condition())
+	assert.Equal(t, metav1.ConditionTrue, condition().Status)
+	assert.Equal(t, networkingv1alpha.DNSRecordsResolvableReasonResolvable, condition().Reason)
+	assert.Contains(t, condition().Message, "propagated in 42s")
+	assert.Zero(t, result.RequeueAfter)
+
+	// Hostnames that resolved are not looked up again until the cache expires.
+	lookups = 0
+	reconciler.reconcileDNSResolvableStatus(ctx, nil, gw, addresses, canonical, hostnameStatuses)
+	assert.Zero(t, lookups)
+	assert.Contains(t, condition().Message, "propagated in 42s")
+
+	// An address change is propagated again.
+	changed := gatewayDNSAddresses{ipv4Enabled: true, v4: []string{"198.51.100.2"}}
+	reconciler.reconcileDNSResolvableStatus(ctx, nil, gw, changed, canonical, hostnameStatuses)
+	assert.Equal(t, networkingv1alpha.DNSRecordsResolvableReasonPropagating, condition().Reason)
+
+	// The condition is removed when DNS records are not verified.
+	reconciler.dnsVerifier = nil
+	reconciler.reconcileDNSResolvableStatus(ctx, nil, gw, addresses, canonical, hostnameStatuses)
+	assert.Nil(t, condition())
+}
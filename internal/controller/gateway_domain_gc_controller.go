@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// GatewayCreatedDomainLabel is set on the Domains that gateways create for
+// hostnames without a matching Domain, to the name of the creating gateway.
+const GatewayCreatedDomainLabel = "networking.datumapis.com/created-by-gateway"
+
+// domainUnreferencedSinceAnnotation records when a Domain created by a gateway
+// was first found unreferenced, to start its retention period.
+const domainUnreferencedSinceAnnotation = "networking.datumapis.com/unreferenced-since"
+
+// GatewayDomainGCReconciler deletes the Domains created by gateways that are
+// no longer referenced by the hostnames of any Gateway or HTTPProxy, or by a
+// DNSZone, in their namespace.
+//
+// Domains that were ever verified are kept, as they may be relied on outside
+// of gateways, and so are Domains that were not created by a gateway.
+type GatewayDomainGCReconciler struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+}
+
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=domains,verbs=get;list;watch;patch;delete
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=httpproxies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dns.networking.miloapis.com,resources=dnszones,verbs=get;list;watch
+
+func (r *GatewayDomainGCReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx, "cluster", req.ClusterName)
+
+	cl, err := r.mgr.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	upstreamClient := cl.GetClient()
+
+	var domain networkingv1alpha.Domain
+	if err := upstreamClient.Get(ctx, req.NamespacedName, &domain); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if _, ok := domain.Labels[GatewayCreatedDomainLabel]; !ok || !domain.DeletionTimestamp.IsZero() || domainEverVerified(&domain) {
+		return ctrl.Result{}, nil
+	}
+
+	referenced, err := r.domainReferenced(ctx, upstreamClient, &domain)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	unreferencedSinceValue, unreferencedSinceSet := domain.Annotations[domainUnreferencedSinceAnnotation]
+	if referenced {
+		if unreferencedSinceSet {
+			patch := client.MergeFrom(domain.DeepCopy())
+			delete(domain.Annotations, domainUnreferencedSinceAnnotation)
+			if err := upstreamClient.Patch(ctx, &domain, patch); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed clearing domain unreferenced-since annotation: %w", err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	retentionPeriod := r.Config.Gateway.DomainGC.RetentionPeriod.Duration
+	unreferencedSince, err := time.Parse(time.RFC3339, unreferencedSinceValue)
+	if !unreferencedSinceSet || err != nil {
+		patch := client.MergeFrom(domain.DeepCopy())
+		if domain.Annotations == nil {
+			domain.Annotations = map[string]string{}
+		}
+		domain.Annotations[domainUnreferencedSinceAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		if err := upstreamClient.Patch(ctx, &domain, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed setting domain unreferenced-since annotation: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: retentionPeriod}, nil
+	}
+
+	if remaining := retentionPeriod - time.Since(unreferencedSince); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	logger.Info("deleting unreferenced domain created by gateway",
+		"domain", domain.Name, "gateway", domain.Labels[GatewayCreatedDomainLabel], "unreferencedSince", unreferencedSinceValue)
+	if err := upstreamClient.Delete(ctx, &domain,
+		client.Preconditions{UID: &domain.UID, ResourceVersion: &domain.ResourceVersion},
+	); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("failed deleting domain: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// domainEverVerified returns whether the Domain is verified, or was verified
+// before its verification expired.
+func domainEverVerified(domain *networkingv1alpha.Domain) bool {
+	verified := apimeta.FindStatusCondition(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified)
+	return verified != nil &&
+		(verified.Status == metav1.ConditionTrue || verified.Reason == networkingv1alpha.DomainReasonVerificationExpired)
+}
+
+// domainReferenced returns whether a hostname of a Gateway or HTTPProxy in the
+// namespace of the Domain, that is not being deleted, falls under the domain
+// name of the Domain. With DNS integration enabled, a DNSZone in the namespace
+// for the domain name also references the Domain.
+func (r *GatewayDomainGCReconciler) domainReferenced(ctx context.Context, c client.Client, domain *networkingv1alpha.Domain) (bool, error) {
+	var gateways gatewayv1.GatewayList
+	if err := c.List(ctx, &gateways,
+		client.InNamespace(domain.Namespace),
+		client.MatchingFields{gatewayHostnameDomainIndex: domain.Spec.DomainName},
+	); err != nil {
+		return false, fmt.Errorf("failed listing gateways: %w", err)
+	}
+	for _, gateway := range gateways.Items {
+		if gateway.DeletionTimestamp.IsZero() {
+			return true, nil
+		}
+	}
+
+	var httpProxies networkingv1alpha.HTTPProxyList
+	if err := c.List(ctx, &httpProxies,
+		client.InNamespace(domain.Namespace),
+		client.MatchingFields{httpProxyHostnameDomainIndex: domain.Spec.DomainName},
+	); err != nil {
+		return false, fmt.Errorf("failed listing httpproxies: %w", err)
+	}
+	for _, httpProxy := range httpProxies.Items {
+		if httpProxy.DeletionTimestamp.IsZero() {
+			return true, nil
+		}
+	}
+
+	if r.Config.Gateway.EnableDNSIntegration {
+		var dnsZones dnsv1alpha1.DNSZoneList
+		if err := c.List(ctx, &dnsZones,
+			client.InNamespace(domain.Namespace),
+			client.MatchingFields{dnsZoneDomainNameIndex: domain.Spec.DomainName},
+		); err != nil {
+			return false, fmt.Errorf("failed listing dnszones: %w", err)
+		}
+		for _, dnsZone := range dnsZones.Items {
+			if dnsZone.DeletionTimestamp.IsZero() {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// listGatewayCreatedDomainsFunc enqueues the Domains created by gateways in the
+// namespace of a Gateway, HTTPProxy or DNSZone, which may have been the last
+// to reference them.
+func (r *GatewayDomainGCReconciler) listGatewayCreatedDomainsFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		logger := log.FromContext(ctx)
+
+		var domains networkingv1alpha.DomainList
+		if err := cl.GetClient().List(ctx, &domains,
+			client.InNamespace(obj.GetNamespace()),
+			client.HasLabels{GatewayCreatedDomainLabel},
+		); err != nil {
+			logger.Error(err, "failed to list Domains")
+			return nil
+		}
+
+		requests := make([]mcreconcile.Request, 0, len(domains.Items))
+		for _, domain := range domains.Items {
+			requests = append(requests, mcreconcile.Request{
+				ClusterName: clusterName,
+				Request: reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&domain),
+				},
+			})
+		}
+		return requests
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GatewayDomainGCReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+
+	createdByGateway := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		_, ok := obj.GetLabels()[GatewayCreatedDomainLabel]
+		return ok
+	})
+
+	builder := mcbuilder.ControllerManagedBy(mgr).
+		For(&networkingv1alpha.Domain{}, mcbuilder.WithPredicates(createdByGateway)).
+		Watches(&gatewayv1.Gateway{}, r.listGatewayCreatedDomainsFunc, mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.HTTPProxy{}, r.listGatewayCreatedDomainsFunc, mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()))
+
+	if r.Config.Gateway.EnableDNSIntegration {
+		builder = builder.Watches(&dnsv1alpha1.DNSZone{}, r.listGatewayCreatedDomainsFunc)
+	}
+
+	return builder.
+		Named("gateway-domain-gc").
+		Complete(r)
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestGatewayDomainGCReconciler(t *testing.T) {
+	retentionPeriod := time.Hour
+
+	createdByGateway := map[string]string{GatewayCreatedDomainLabel: "gateway"}
+	unreferencedSince := func(ago time.Duration) map[string]string {
+		return map[string]string{
+			domainUnreferencedSinceAnnotation: time.Now().Add(-ago).UTC().Format(time.RFC3339),
+		}
+	}
+	gatewayWithHostname := func(hostname string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"},
+			Spec: gatewayv1.GatewaySpec{
+				Listeners: []gatewayv1.Listener{{
+					Name:     "http",
+					Protocol: gatewayv1.HTTPProtocolType,
+					Port:     80,
+					Hostname: ptr.To(gatewayv1.Hostname(hostname)),
+				}},
+			},
+		}
+	}
+	dnsZoneForDomain := func(domainName string) *dnsv1alpha1.DNSZone {
+		return &dnsv1alpha1.DNSZone{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "zone"},
+			Spec:       dnsv1alpha1.DNSZoneSpec{DomainName: domainName},
+		}
+	}
+	verified := []metav1.Condition{{
+		Type:   networkingv1alpha.DomainConditionVerified,
+		Status: metav1.ConditionTrue,
+		Reason: networkingv1alpha.DomainReasonVerified,
+	}}
+
+	tests := map[string]struct {
+		labels                map[string]string
+		annotations           map[string]string
+		conditions            []metav1.Condition
+		objects               []client.Object
+		dnsIntegration        bool
+		wantDeleted           bool
+		wantUnreferencedSince bool
+		wantRequeue           bool
+	}{
+		"unreferenced domain starts the retention period": {
+			labels:                createdByGateway,
+			wantUnreferencedSince: true,
+			wantRequeue:           true,
+		},
+		"unreferenced domain within the retention period is kept": {
+			labels:                createdByGateway,
+			annotations:           unreferencedSince(time.Minute),
+			wantUnreferencedSince: true,
+			wantRequeue:           true,
+		},
+		"unreferenced domain past the retention period is deleted": {
+			labels:      createdByGateway,
+			annotations: unreferencedSince(retentionPeriod + time.Minute),
+			wantDeleted: true,
+		},
+		"gateway hostname clears the retention period": {
+			labels:      createdByGateway,
+			annotations: unreferencedSince(retentionPeriod + time.Minute),
+			objects:     []client.Object{gatewayWithHostname("www.example.com")},
+		},
+		"httpproxy hostname keeps the domain": {
+			labels:      createdByGateway,
+			annotations: unreferencedSince(retentionPeriod + time.Minute),
+			objects: []client.Object{&networkingv1alpha.HTTPProxy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "proxy"},
+				Spec:       networkingv1alpha.HTTPProxySpec{Hostnames: []gatewayv1.Hostname{"example.com"}},
+			}},
+		},
+		"dnszone keeps the domain": {
+			labels:         createdByGateway,
+			annotations:    unreferencedSince(retentionPeriod + time.Minute),
+			objects:        []client.Object{dnsZoneForDomain("example.com")},
+			dnsIntegration: true,
+		},
+		"dnszone is ignored without dns integration": {
+			labels:      createdByGateway,
+			annotations: unreferencedSince(retentionPeriod + time.Minute),
+			objects:     []client.Object{dnsZoneForDomain("example.com")},
+			wantDeleted: true,
+		},
+		"hostnames of other domains do not keep the domain": {
+			labels:      createdByGateway,
+			annotations: unreferencedSince(retentionPeriod + time.Minute),
+			objects:     []client.Object{gatewayWithHostname("www.notexample.com")},
+			wantDeleted: true,
+		},
+		"verified domain is kept": {
+			labels:                createdByGateway,
+			annotations:           unreferencedSince(retentionPeriod + time.Minute),
+			conditions:            verified,
+			wantUnreferencedSince: true,
+		},
+		"domain not created by a gateway is ignored": {
+			annotations:           unreferencedSince(retentionPeriod + time.Minute),
+			wantUnreferencedSince: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			testScheme := runtime.NewScheme()
+			require.NoError(t, scheme.AddToScheme(testScheme))
+			require.NoError(t, gatewayv1.Install(testScheme))
+			require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+			require.NoError(t, dnsv1alpha1.AddToScheme(testScheme))
+
+			domain := &networkingv1alpha.Domain{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "example.com",
+					Labels:      tt.labels,
+					Annotations: tt.annotations,
+				},
+				Spec:   networkingv1alpha.DomainSpec{DomainName: "example.com"},
+				Status: networkingv1alpha.DomainStatus{Conditions: tt.conditions},
+			}
+			upstreamClient := buildFakeUpstreamClientForDNS(testScheme, append([]client.Object{domain}, tt.objects...)...)
+
+			operatorConfig := config.NetworkServicesOperator{}
+			config.SetObjectDefaults_NetworkServicesOperator(&operatorConfig)
+			operatorConfig.Gateway.DomainGC.RetentionPeriod = &metav1.Duration{Duration: retentionPeriod}
+			operatorConfig.Gateway.EnableDNSIntegration = tt.dnsIntegration
+
+			reconciler := &GatewayDomainGCReconciler{
+				mgr:    &fakeMockManager{cl: upstreamClient},
+				Config: operatorConfig,
+			}
+
+			result, err := reconciler.Reconcile(ctx, mcreconcile.Request{
+				Request: reconcile.Request{NamespacedName: client.ObjectKeyFromObject(domain)},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRequeue, result.RequeueAfter > 0)
+			assert.LessOrEqual(t, result.RequeueAfter, retentionPeriod)
+
+			var got networkingv1alpha.Domain
+			err = upstreamClient.Get(ctx, client.ObjectKeyFromObject(domain), &got)
+			if tt.wantDeleted {
+				assert.True(t, apierrors.IsNotFound(err), "expected domain to be deleted, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			_, hasUnreferencedSince := got.Annotations[domainUnreferencedSinceAnnotation]
+			assert.Equal(t, tt.wantUnreferencedSince, hasUnreferencedSince)
+		})
+	}
+}
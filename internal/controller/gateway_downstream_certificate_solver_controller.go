@@ -31,6 +31,10 @@ import (
 type GatewayDownstreamCertificateSolverReconciler struct {
 	Config config.NetworkServicesOperator
 
+	// ReloadableConfig, when set, supplies the config.ReloadableFields that
+	// may change while the manager is running.
+	ReloadableConfig *config.Reloadable
+
 	DownstreamCluster cluster.Cluster
 }
 
@@ -110,7 +114,7 @@ func (r *GatewayDownstreamCertificateSolverReconciler) Reconcile(ctx context.Con
 
 	// Check if this issuer is in our configured list
 	foundIssuer := false
-	for _, v := range r.Config.Gateway.ClusterIssuerMap {
+	for _, v := range reloadableConfig(&r.Config, r.ReloadableConfig).Gateway.ClusterIssuerMap {
 		if v == issuerName {
 			foundIssuer = true
 			break
@@ -265,6 +269,7 @@ func (r *GatewayDownstreamCertificateSolverReconciler) SetupWithManager(mgr mana
 
 	return ctrl.NewControllerManagedBy(mgr).
 		WatchesRawSource(downstreamChallengeSource).
+		WithOptions(controllerOptions[ctrl.Request](r.Config, "downstream-certificate-solver", 0)).
 		Named("downstream-certificate-solver").
 		Complete(r)
 }
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// GatewayConditionDownstreamDegraded is set on upstream Gateways while changes
+// to the downstream cluster are paused because its API server is failing.
+const GatewayConditionDownstreamDegraded = "DownstreamDegraded"
+
+const GatewayReasonCircuitOpen = "CircuitOpen"
+
+// reconcileDownstreamDegradedStatus sets the DownstreamDegraded condition on
+// the upstream gateway while the downstream circuit breaker is open, and
+// requeues the gateway for when the breaker lets requests through again. The
+// condition is removed once the breaker is no longer open.
+func (r *GatewayReconciler) reconcileDownstreamDegradedStatus(
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+) (result Result) {
+	if r.DownstreamCircuitBreaker == nil || r.DownstreamCircuitBreaker.State() != downstreamclient.CircuitOpen {
+		if apimeta.RemoveStatusCondition(&upstreamGateway.Status.Conditions, GatewayConditionDownstreamDegraded) {
+			result.AddStatusUpdate(upstreamClient, upstreamGateway)
+		}
+		return result
+	}
+
+	apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, metav1.Condition{
+		Type:               GatewayConditionDownstreamDegraded,
+		Status:             metav1.ConditionTrue,
+		Reason:             GatewayReasonCircuitOpen,
+		Message:            "Changes to the gateway are paused until the edge control plane recovers",
+		ObservedGeneration: upstreamGateway.Generation,
+	})
+	result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	result.RequeueAfter = max(r.DownstreamCircuitBreaker.RetryAfter(), time.Second)
+	return result
+}
+
+// httpProxyDownstreamDegradedCondition returns the DownstreamDegraded
+// condition of an HTTPProxy from the DownstreamDegraded condition of its
+// gateway, or nil when the gateway is not degraded.
+func httpProxyDownstreamDegradedCondition(httpProxy *networkingv1alpha.HTTPProxy, gateway *gatewayv1.Gateway) *metav1.Condition {
+	if gateway == nil || !apimeta.IsStatusConditionTrue(gateway.Status.Conditions, GatewayConditionDownstreamDegraded) {
+		return nil
+	}
+
+	return &metav1.Condition{
+		Type:               networkingv1alpha.HTTPProxyConditionDownstreamDegraded,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.HTTPProxyReasonCircuitOpen,
+		Message:            "Changes to the HTTPProxy are paused until the edge control plane recovers",
+		ObservedGeneration: httpProxy.Generation,
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestReconcileDownstreamDegradedStatus(t *testing.T) {
+	breaker := downstreamclient.NewCircuitBreaker("test", config.CircuitBreakerConfig{
+		ErrorRateThreshold: 50,
+		MinimumRequests:    1,
+		Window:             metav1.Duration{Duration: time.Minute},
+		OpenDuration:       metav1.Duration{Duration: time.Minute},
+	})
+	reconciler := &GatewayReconciler{DownstreamCircuitBreaker: breaker}
+	upstreamClient := fake.NewClientBuilder().Build()
+	gateway := newGateway(config.NetworkServicesOperator{}, "test", "test")
+
+	result := reconciler.reconcileDownstreamDegradedStatus(upstreamClient, gateway)
+	assert.False(t, result.ShouldReturn())
+	assert.Nil(t, apimeta.FindStatusCondition(gateway.Status.Conditions, GatewayConditionDownstreamDegraded))
+
+	rt := breaker.WrapTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+	}))
+	_, err := rt.RoundTrip(httptest.NewRequest(http.MethodPost, "https://downstream/api/v1/namespaces", nil))
+	require.NoError(t, err)
+	require.Equal(t, downstreamclient.CircuitOpen, breaker.State())
+
+	result = reconciler.reconcileDownstreamDegradedStatus(upstreamClient, gateway)
+	assert.True(t, result.ShouldReturn())
+	assert.Greater(t, result.RequeueAfter, 50*time.Second)
+	condition := apimeta.FindStatusCondition(gateway.Status.Conditions, GatewayConditionDownstreamDegraded)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, GatewayReasonCircuitOpen, condition.Reason)
+	}
+
+	httpProxy := &networkingv1alpha.HTTPProxy{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	proxyCondition := httpProxyDownstreamDegradedCondition(httpProxy, gateway)
+	if assert.NotNil(t, proxyCondition) {
+		assert.Equal(t, networkingv1alpha.HTTPProxyConditionDownstreamDegraded, proxyCondition.Type)
+		assert.Equal(t, metav1.ConditionTrue, proxyCondition.Status)
+		assert.Equal(t, networkingv1alpha.HTTPProxyReasonCircuitOpen, proxyCondition.Reason)
+		assert.Equal(t, int64(2), proxyCondition.ObservedGeneration)
+	}
+
+	reconciler.DownstreamCircuitBreaker = nil
+	reconciler.reconcileDownstreamDegradedStatus(upstreamClient, gateway)
+	assert.Nil(t, apimeta.FindStatusCondition(gateway.Status.Conditions, GatewayConditionDownstreamDegraded))
+	assert.Nil(t, httpProxyDownstreamDegradedCondition(httpProxy, gateway))
+}
@@ -3,9 +3,13 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	discoveryv1 "k8s.io/api/discovery/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -20,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
 	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
@@ -28,8 +33,15 @@ import (
 
 	"go.datum.net/network-services-operator/internal/config"
 	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/features"
 )
 
+// detachedHTTPRouteDetachedAtAnnotation records when an HTTPRoute was first
+// seen without a parentRef to a Datum gateway. It is removed once the
+// downstream resources of the route are cleaned up, or when the route is
+// reattached before that.
+const detachedHTTPRouteDetachedAtAnnotation = "meta.datumapis.com/detached-at"
+
 // GatewayDownstreamGCReconciler reconciles a Gateway object
 type GatewayDownstreamGCReconciler struct {
 	mgr    mcmanager.Manager
@@ -79,89 +91,330 @@ func (r *GatewayDownstreamGCReconciler) Reconcile(ctx context.Context, req GVKRe
 		return ctrl.Result{}, err
 	}
 
-	// Only process objects that are being deleted and have the gateway finalizer.
-	if dt := obj.GetDeletionTimestamp(); dt.IsZero() || !controllerutil.ContainsFinalizer(&obj, gatewayControllerGCFinalizer) {
+	if !controllerutil.ContainsFinalizer(&obj, gatewayControllerGCFinalizer) {
 		return ctrl.Result{}, nil
 	}
 
-	logger.Info("garbage collecting downstream resources")
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), cl.GetClient(), r.DownstreamCluster.GetClient(), downstreamclient.WithControllerName("gateway_downstream_resources"))
 
-	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), cl.GetClient(), r.DownstreamCluster.GetClient())
+	isHTTPRoute := req.GVK.Group == gatewayv1.GroupName && req.GVK.Kind == KindHTTPRoute
 
-	if err := downstreamStrategy.DeleteAnchorForObject(ctx, &obj); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed deleting anchor: %w", err)
+	terminating, err := upstreamNamespaceTerminating(ctx, cl.GetClient(), req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Only process objects that are being deleted, or HTTPRoutes that are no
+	// longer attached to a Datum gateway.
+	if dt := obj.GetDeletionTimestamp(); dt.IsZero() {
+		if terminating || !isHTTPRoute || !r.Config.FeatureEnabled(features.DetachedHTTPRouteCleanup) {
+			return ctrl.Result{}, nil
+		}
+		return r.cleanupDetachedHTTPRoute(ctx, cl.GetClient(), downstreamStrategy, &obj)
+	}
+
+	if terminating {
+		// The downstream resources are removed along with the downstream
+		// namespace.
+		logger.Info("releasing object in terminating namespace")
+		if err := teardownDownstreamNamespace(ctx, downstreamStrategy, string(req.ClusterName), req.Namespace); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err := r.garbageCollect(ctx, downstreamStrategy, &obj, req.GVK); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if controllerutil.RemoveFinalizer(&obj, gatewayControllerGCFinalizer) {
+		if err := cl.GetClient().Update(ctx, &obj); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+		}
 	}
 
-	// When an HTTPRoute is deleted, ensure that the downstream EndpointSlices are
-	// deleted as well. They're currently logically owned by the route as a result
-	// of duplicating the upstream EndpointSlice.
+	return ctrl.Result{}, nil
+}
+
+// garbageCollect removes the downstream resources of a deleted object.
+func (r *GatewayDownstreamGCReconciler) garbageCollect(
+	ctx context.Context,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	obj *unstructured.Unstructured,
+	gvk schema.GroupVersionKind,
+) error {
+	log.FromContext(ctx).Info("garbage collecting downstream resources")
+
+	if err := downstreamStrategy.DeleteAnchorForObject(ctx, obj); err != nil {
+		return fmt.Errorf("failed deleting anchor: %w", err)
+	}
 
-	if req.GVK.Group == gatewayv1.GroupName && req.GVK.Kind == KindHTTPRoute {
+	if gvk.Group != gatewayv1.GroupName {
+		return nil
+	}
+
+	switch gvk.Kind {
+	case KindHTTPRoute:
 		httpRoute := &gatewayv1.HTTPRoute{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, httpRoute); err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to convert unstructured httproute: %w", err)
+			return fmt.Errorf("failed to convert unstructured httproute: %w", err)
 		}
+		return r.deleteDownstreamRouteEndpointSlices(ctx, downstreamStrategy, httpRoute, httpRouteBackendRefs(httpRoute))
 
-		downstreamObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, httpRoute)
-		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed getting downstream object metadata: %w", err)
+	case KindGRPCRoute:
+		grpcRoute := &gatewayv1.GRPCRoute{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, grpcRoute); err != nil {
+			return fmt.Errorf("failed to convert unstructured grpcroute: %w", err)
 		}
+		return r.deleteDownstreamRouteEndpointSlices(ctx, downstreamStrategy, grpcRoute, grpcRouteBackendRefs(grpcRoute))
 
-		logger.Info("looking for endpointslices", "downstream_namespace", downstreamObjectMeta.Namespace)
+	case KindTCPRoute, KindUDPRoute:
+		route := newL4Route(gvk.Kind, metav1.ObjectMeta{})
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, route.Object); err != nil {
+			return fmt.Errorf("failed to convert unstructured %s: %w", strings.ToLower(gvk.Kind), err)
+		}
+		route = wrapL4Route(route.Object)
+		return r.deleteDownstreamRouteEndpointSlices(ctx, downstreamStrategy, route.Object, route.backendRefs())
+	}
 
-		for ruleIdx, rule := range httpRoute.Spec.Rules {
-			for backendRefIdx, backendRef := range rule.BackendRefs {
-				if ptr.Deref(backendRef.Group, "") != discoveryv1.GroupName ||
-					ptr.Deref(backendRef.Kind, "") != "EndpointSlice" {
-					continue
-				}
+	return nil
+}
 
-				resourceName := fmt.Sprintf("route-%s-rule-%d-backendref-%d", httpRoute.UID, ruleIdx, backendRefIdx)
-
-				endpointSlice := &discoveryv1.EndpointSlice{}
-				if err := r.DownstreamCluster.GetClient().Get(ctx, client.ObjectKey{
-					Namespace: string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(downstreamObjectMeta.Namespace))),
-					Name:      resourceName,
-				}, endpointSlice); err != nil {
-					if apierrors.IsNotFound(err) {
-						logger.Info("endpointslice not found", "namespace", downstreamObjectMeta.Namespace, jsonKeyName, resourceName)
-						// Nothing to do
-						continue
-					}
-					return ctrl.Result{}, fmt.Errorf("failed fetching endpointslice: %w", err)
-				}
+// cleanupDetachedHTTPRoute removes the downstream resources of an HTTPRoute as
+// soon as it no longer references a Datum gateway, instead of waiting for the
+// route or gateway to be deleted. The finalizer is removed so that the route
+// is picked up again by the gateway controller if it is reattached.
+func (r *GatewayDownstreamGCReconciler) cleanupDetachedHTTPRoute(
+	ctx context.Context,
+	upstreamClient client.Client,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	obj *unstructured.Unstructured,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	httpRoute := &gatewayv1.HTTPRoute{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, httpRoute); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to convert unstructured httproute: %w", err)
+	}
 
-				logger.Info("deleting endpointslice", "namespace", downstreamObjectMeta.Namespace, jsonKeyName, resourceName)
+	attached, err := r.isAttachedToDatumGateway(ctx, upstreamClient, httpRoute)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 
-				if dt := endpointSlice.GetDeletionTimestamp(); dt == nil {
-					if err := r.DownstreamCluster.GetClient().Delete(ctx, endpointSlice); err != nil {
-						return ctrl.Result{}, fmt.Errorf("failed to delete endpointslice: %w", err)
-					}
-				}
+	detachedAtValue, detachedAtSet := httpRoute.Annotations[detachedHTTPRouteDetachedAtAnnotation]
+	if attached {
+		if detachedAtSet {
+			// Reattached before the cleanup completed.
+			delete(httpRoute.Annotations, detachedHTTPRouteDetachedAtAnnotation)
+			if err := upstreamClient.Update(ctx, httpRoute); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed clearing httproute detached-at annotation: %w", err)
 			}
 		}
+		return ctrl.Result{}, nil
 	}
 
-	if controllerutil.RemoveFinalizer(&obj, gatewayControllerGCFinalizer) {
-		if err := cl.GetClient().Update(ctx, &obj); err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+	detachedAt, err := time.Parse(time.RFC3339, detachedAtValue)
+	if !detachedAtSet || err != nil {
+		detachedAt = time.Now().UTC()
+		if httpRoute.Annotations == nil {
+			httpRoute.Annotations = map[string]string{}
+		}
+		httpRoute.Annotations[detachedHTTPRouteDetachedAtAnnotation] = detachedAt.Format(time.RFC3339)
+		if err := upstreamClient.Update(ctx, httpRoute); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed setting httproute detached-at annotation: %w", err)
 		}
 	}
 
+	logger.Info("garbage collecting downstream resources for detached httproute", "detachedAt", detachedAt)
+
+	if err := downstreamStrategy.DeleteAnchorForObject(ctx, httpRoute); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed deleting anchor: %w", err)
+	}
+
+	if err := r.deleteDownstreamRouteEndpointSlices(ctx, downstreamStrategy, httpRoute, httpRouteBackendRefs(httpRoute)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Drop the parent statuses reported for gateways that the route is no
+	// longer attached to.
+	parents := slices.DeleteFunc(slices.Clone(httpRoute.Status.Parents), func(parent gatewayv1.RouteParentStatus) bool {
+		return parent.ControllerName == r.Config.Gateway.ControllerName
+	})
+	if len(parents) != len(httpRoute.Status.Parents) {
+		httpRoute.Status.Parents = parents
+		if err := upstreamClient.Status().Update(ctx, httpRoute); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed updating httproute status: %w", err)
+		}
+	}
+
+	delete(httpRoute.Annotations, detachedHTTPRouteDetachedAtAnnotation)
+	controllerutil.RemoveFinalizer(httpRoute, gatewayControllerGCFinalizer)
+	if err := upstreamClient.Update(ctx, httpRoute); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	detachedHTTPRouteCleanupLatency.Observe(time.Since(detachedAt).Seconds())
+
 	return ctrl.Result{}, nil
 }
 
+// isAttachedToDatumGateway returns true if any parentRef of the HTTPRoute
+// references an existing Gateway whose GatewayClass is managed by this
+// operator.
+func (r *GatewayDownstreamGCReconciler) isAttachedToDatumGateway(
+	ctx context.Context,
+	upstreamClient client.Client,
+	httpRoute *gatewayv1.HTTPRoute,
+) (bool, error) {
+	for _, parentRef := range httpRoute.Spec.ParentRefs {
+		if ptr.Deref(parentRef.Group, gatewayv1.GroupName) != gatewayv1.GroupName ||
+			ptr.Deref(parentRef.Kind, KindGateway) != KindGateway {
+			continue
+		}
+
+		var gateway gatewayv1.Gateway
+		if err := upstreamClient.Get(ctx, client.ObjectKey{
+			Namespace: string(ptr.Deref(parentRef.Namespace, gatewayv1.Namespace(httpRoute.Namespace))),
+			Name:      string(parentRef.Name),
+		}, &gateway); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, fmt.Errorf("failed fetching gateway: %w", err)
+		}
+
+		var gatewayClass gatewayv1.GatewayClass
+		if err := upstreamClient.Get(ctx, client.ObjectKey{Name: string(gateway.Spec.GatewayClassName)}, &gatewayClass); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, fmt.Errorf("failed fetching gatewayclass: %w", err)
+		}
+
+		if gatewayClass.Spec.ControllerName == r.Config.Gateway.ControllerName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// httpRouteBackendRefs returns the backendRefs of each rule of an HTTPRoute.
+func httpRouteBackendRefs(httpRoute *gatewayv1.HTTPRoute) [][]gatewayv1.BackendRef {
+	ruleBackendRefs := make([][]gatewayv1.BackendRef, 0, len(httpRoute.Spec.Rules))
+	for _, rule := range httpRoute.Spec.Rules {
+		backendRefs := make([]gatewayv1.BackendRef, 0, len(rule.BackendRefs))
+		for _, backendRef := range rule.BackendRefs {
+			backendRefs = append(backendRefs, backendRef.BackendRef)
+		}
+		ruleBackendRefs = append(ruleBackendRefs, backendRefs)
+	}
+	return ruleBackendRefs
+}
+
+// deleteDownstreamRouteEndpointSlices deletes the downstream EndpointSlices
+// of a route. They're currently logically owned by the route as a result
+// of duplicating the upstream EndpointSlice.
+func (r *GatewayDownstreamGCReconciler) deleteDownstreamRouteEndpointSlices(
+	ctx context.Context,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	route client.Object,
+	ruleBackendRefs [][]gatewayv1.BackendRef,
+) error {
+	logger := log.FromContext(ctx)
+
+	downstreamObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, route)
+	if err != nil {
+		return fmt.Errorf("failed getting downstream object metadata: %w", err)
+	}
+
+	logger.Info("looking for endpointslices", "downstream_namespace", downstreamObjectMeta.Namespace)
+
+	for ruleIdx, backendRefs := range ruleBackendRefs {
+		for backendRefIdx, backendRef := range backendRefs {
+			if ptr.Deref(backendRef.Group, "") != discoveryv1.GroupName ||
+				ptr.Deref(backendRef.Kind, "") != "EndpointSlice" {
+				continue
+			}
+
+			resourceName := fmt.Sprintf("route-%s-rule-%d-backendref-%d", route.GetUID(), ruleIdx, backendRefIdx)
+
+			endpointSlice := &discoveryv1.EndpointSlice{}
+			if err := r.DownstreamCluster.GetClient().Get(ctx, client.ObjectKey{
+				Namespace: string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(downstreamObjectMeta.Namespace))),
+				Name:      resourceName,
+			}, endpointSlice); err != nil {
+				if apierrors.IsNotFound(err) {
+					logger.Info("endpointslice not found", "namespace", downstreamObjectMeta.Namespace, jsonKeyName, resourceName)
+					// Nothing to do
+					continue
+				}
+				return fmt.Errorf("failed fetching endpointslice: %w", err)
+			}
+
+			logger.Info("deleting endpointslice", "namespace", downstreamObjectMeta.Namespace, jsonKeyName, resourceName)
+
+			if dt := endpointSlice.GetDeletionTimestamp(); dt == nil {
+				if err := r.DownstreamCluster.GetClient().Delete(ctx, endpointSlice); err != nil {
+					return fmt.Errorf("failed to delete endpointslice: %w", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *GatewayDownstreamGCReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	r.mgr = mgr
 
-	return mcbuilder.TypedControllerManagedBy[GVKRequest](mgr).
-		Watches(&gatewayv1.HTTPRoute{}, TypedEnqueueRequestForObjectWithGVK(&gatewayv1.HTTPRoute{})).
-		Watches(&discoveryv1.EndpointSlice{}, TypedEnqueueRequestForObjectWithGVK(&discoveryv1.EndpointSlice{})).
+	b := mcbuilder.TypedControllerManagedBy[GVKRequest](mgr).
+		Watches(&gatewayv1.HTTPRoute{}, typedEnqueueRequestForFinalizedRoute(gatewayv1.SchemeGroupVersion.WithKind(KindHTTPRoute))).
+		Watches(&discoveryv1.EndpointSlice{}, TypedEnqueueRequestForObjectWithGVK(&discoveryv1.EndpointSlice{}))
+
+	if r.Config.FeatureEnabled(features.L4Routes) {
+		b = b.
+			Watches(&gatewayv1alpha2.TCPRoute{}, typedEnqueueRequestForFinalizedRoute(gatewayv1alpha2.SchemeGroupVersion.WithKind(KindTCPRoute))).
+			Watches(&gatewayv1alpha2.UDPRoute{}, typedEnqueueRequestForFinalizedRoute(gatewayv1alpha2.SchemeGroupVersion.WithKind(KindUDPRoute)))
+	}
+
+	if r.Config.FeatureEnabled(features.GRPCRoutes) {
+		b = b.Watches(&gatewayv1.GRPCRoute{}, typedEnqueueRequestForFinalizedRoute(gatewayv1.SchemeGroupVersion.WithKind(KindGRPCRoute)))
+	}
+
+	return b.
+		WithOptions(controllerOptions[GVKRequest](r.Config, "gateway_downstream_resources", 0)).
 		Named("gateway_downstream_resources").
 		Complete(r)
 }
 
+// typedEnqueueRequestForFinalizedRoute enqueues routes that carry the gateway
+// finalizer, so that routes are processed when they're deleted or detached
+// from their gateways.
+func typedEnqueueRequestForFinalizedRoute(gvk schema.GroupVersionKind) mchandler.TypedEventHandlerFunc[client.Object, GVKRequest] {
+	return func(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, GVKRequest] {
+		return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []GVKRequest {
+			if !controllerutil.ContainsFinalizer(obj, gatewayControllerGCFinalizer) {
+				return nil
+			}
+
+			return []GVKRequest{
+				{
+					GVK: gvk,
+					Request: mcreconcile.Request{
+						ClusterName: clusterName,
+						Request: reconcile.Request{
+							NamespacedName: types.NamespacedName{
+								Namespace: obj.GetNamespace(),
+								Name:      obj.GetName(),
+							},
+						},
+					},
+				},
+			}
+		})
+	}
+}
+
 func TypedEnqueueRequestForObjectWithGVK(
 	obj client.Object,
 ) mchandler.TypedEventHandlerFunc[client.Object, GVKRequest] {
@@ -2,8 +2,10 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -17,9 +19,14 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/features"
 )
 
 func TestHTTPRouteGC(t *testing.T) {
@@ -115,3 +122,237 @@ func TestHTTPRouteGC(t *testing.T) {
 	err = fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(downstreamEndpointSlice), &discoveryv1.EndpointSlice{})
 	assert.True(t, apierrors.IsNotFound(err))
 }
+
+func TestHTTPRouteGCDetached(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	assert.NoError(t, scheme.AddToScheme(testScheme))
+	assert.NoError(t, gatewayv1.Install(testScheme))
+	assert.NoError(t, discoveryv1.AddToScheme(testScheme))
+
+	const datumControllerName = gatewayv1.GatewayController("gateway.networking.datumapis.com/test")
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			ControllerName: datumControllerName,
+		},
+		FeatureGates: map[string]bool{string(features.DetachedHTTPRouteCleanup): true},
+	}
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			UID:  uuid.NewUUID(),
+		},
+	}
+	downstreamNamespaceName := fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	gatewayClasses := []client.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "datum"},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: datumControllerName},
+		},
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: "example.com/other"},
+		},
+	}
+	gateways := []client.Object{
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "datum"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "datum"},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "other"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "other"},
+		},
+	}
+
+	detachedAt := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name                string
+		parentRefs          []gatewayv1.ParentReference
+		annotations         map[string]string
+		failStatusUpdate    bool
+		expectCleanup       bool
+		expectParentsStatus []gatewayv1.GatewayController
+	}{
+		{
+			name:          "attached to datum gateway",
+			parentRefs:    []gatewayv1.ParentReference{{Name: "other"}, {Name: "datum"}},
+			expectCleanup: false,
+			expectParentsStatus: []gatewayv1.GatewayController{
+				datumControllerName,
+				"example.com/other",
+			},
+		},
+		{
+			name:       "reattached to datum gateway",
+			parentRefs: []gatewayv1.ParentReference{{Name: "datum"}},
+			annotations: map[string]string{
+				detachedHTTPRouteDetachedAtAnnotation: detachedAt,
+			},
+			expectCleanup: false,
+			expectParentsStatus: []gatewayv1.GatewayController{
+				datumControllerName,
+				"example.com/other",
+			},
+		},
+		{
+			name:                "attached to non datum gateway",
+			parentRefs:          []gatewayv1.ParentReference{{Name: "other"}},
+			expectCleanup:       true,
+			expectParentsStatus: []gatewayv1.GatewayController{"example.com/other"},
+		},
+		{
+			name:                "no parent refs",
+			expectCleanup:       true,
+			expectParentsStatus: []gatewayv1.GatewayController{"example.com/other"},
+		},
+		{
+			name: "detached in a previous run",
+			annotations: map[string]string{
+				detachedHTTPRouteDetachedAtAnnotation: detachedAt,
+			},
+			expectCleanup:       true,
+			expectParentsStatus: []gatewayv1.GatewayController{"example.com/other"},
+		},
+		{
+			name:             "cleanup failed",
+			failStatusUpdate: true,
+			expectCleanup:    false,
+			expectParentsStatus: []gatewayv1.GatewayController{
+				datumControllerName,
+				"example.com/other",
+			},
+		},
+		{
+			name:                "gateway not found",
+			parentRefs:          []gatewayv1.ParentReference{{Name: "missing"}},
+			expectCleanup:       true,
+			expectParentsStatus: []gatewayv1.GatewayController{"example.com/other"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstreamHTTPRouteUID := uuid.NewUUID()
+
+			downstreamEndpointSlice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: downstreamNamespaceName,
+					Name:      fmt.Sprintf("route-%s-rule-%d-backendref-%d", upstreamHTTPRouteUID, 0, 0),
+				},
+			}
+
+			upstreamHTTPRoute := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "test",
+					Name:        "test",
+					UID:         upstreamHTTPRouteUID,
+					Annotations: tt.annotations,
+					Finalizers: []string{
+						gatewayControllerGCFinalizer,
+					},
+				},
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: tt.parentRefs,
+					},
+					Rules: []gatewayv1.HTTPRouteRule{
+						{
+							BackendRefs: []gatewayv1.HTTPBackendRef{
+								{
+									BackendRef: gatewayv1.BackendRef{
+										BackendObjectReference: gatewayv1.BackendObjectReference{
+											Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+											Kind:  ptr.To(gatewayv1.Kind("EndpointSlice")),
+											Name:  "test",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: gatewayv1.HTTPRouteStatus{
+					RouteStatus: gatewayv1.RouteStatus{
+						Parents: []gatewayv1.RouteParentStatus{
+							{ParentRef: gatewayv1.ParentReference{Name: "datum"}, ControllerName: datumControllerName},
+							{ParentRef: gatewayv1.ParentReference{Name: "other"}, ControllerName: "example.com/other"},
+						},
+					},
+				},
+			}
+
+			fakeUpstreamClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(upstreamHTTPRoute, upstreamNamespace).
+				WithObjects(gatewayClasses...).
+				WithObjects(gateways...).
+				WithStatusSubresource(upstreamHTTPRoute).
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						if tt.failStatusUpdate {
+							return errors.New("status update failed")
+						}
+						return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			fakeDownstreamClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(downstreamEndpointSlice).
+				Build()
+
+			ctx := context.Background()
+
+			reconciler := &GatewayDownstreamGCReconciler{
+				mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+				Config:            testConfig,
+				DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+			}
+
+			_, err := reconciler.Reconcile(ctx, GVKRequest{
+				GVK: schema.GroupVersion{Group: gatewayv1.GroupName, Version: "v1"}.WithKind(KindHTTPRoute),
+				Request: mcreconcile.Request{
+					ClusterName: "test",
+					Request: reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(upstreamHTTPRoute),
+					},
+				},
+			})
+			if tt.failStatusUpdate {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err, "reconcile failed")
+			}
+
+			var route gatewayv1.HTTPRoute
+			assert.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(upstreamHTTPRoute), &route))
+			assert.Equal(t, !tt.expectCleanup, controllerutil.ContainsFinalizer(&route, gatewayControllerGCFinalizer))
+			if tt.failStatusUpdate {
+				// The detach time is kept for the retry, so that the latency
+				// is measured from the detach rather than from the last run.
+				assert.Contains(t, route.Annotations, detachedHTTPRouteDetachedAtAnnotation)
+			} else {
+				assert.NotContains(t, route.Annotations, detachedHTTPRouteDetachedAtAnnotation)
+			}
+
+			var controllerNames []gatewayv1.GatewayController
+			for _, parent := range route.Status.Parents {
+				controllerNames = append(controllerNames, parent.ControllerName)
+			}
+			assert.Equal(t, tt.expectParentsStatus, controllerNames)
+
+			// The downstream resources are removed before the status update.
+			err = fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(downstreamEndpointSlice), &discoveryv1.EndpointSlice{})
+			if tt.expectCleanup || tt.failStatusUpdate {
+				assert.True(t, apierrors.IsNotFound(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/utils/ptr"
+)
+
+// desiredDownstreamEndpoints returns the endpoints to program on a downstream
+// EndpointSlice mirrored from an upstream EndpointSlice. Endpoint conditions
+// and topology hints are preserved.
+//
+// A terminating endpoint without an explicit ready condition is marked as not
+// ready, as an unset ready condition is otherwise interpreted as ready and the
+// endpoint would continue to receive new traffic while draining.
+//
+// When filterNotReady is true, endpoints that are not ready are removed. If no
+// ready endpoints remain, terminating endpoints that are still serving are
+// retained so in-flight traffic can drain instead of failing outright.
+func desiredDownstreamEndpoints(endpoints []discoveryv1.Endpoint, filterNotReady bool) []discoveryv1.Endpoint {
+	if endpoints == nil {
+		return nil
+	}
+
+	desired := make([]discoveryv1.Endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		endpoint = *endpoint.DeepCopy()
+		if ptr.Deref(endpoint.Conditions.Terminating, false) && endpoint.Conditions.Ready == nil {
+			endpoint.Conditions.Ready = ptr.To(false)
+		}
+		desired = append(desired, endpoint)
+	}
+
+	if !filterNotReady {
+		return desired
+	}
+
+	ready := make([]discoveryv1.Endpoint, 0, len(desired))
+	serving := make([]discoveryv1.Endpoint, 0, len(desired))
+	for _, endpoint := range desired {
+		switch {
+		case ptr.Deref(endpoint.Conditions.Ready, true):
+			ready = append(ready, endpoint)
+		case ptr.Deref(endpoint.Conditions.Serving, false):
+			serving = append(serving, endpoint)
+		}
+	}
+
+	if len(ready) == 0 {
+		return serving
+	}
+	return ready
+}
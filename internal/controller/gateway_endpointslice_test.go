@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestDesiredDownstreamEndpoints(t *testing.T) {
+	readyEndpoint := discoveryv1.Endpoint{
+		Addresses:  []string{"10.0.0.1"},
+		Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true), Serving: ptr.To(true)},
+		Zone:       ptr.To("us-east-1a"),
+		Hints: &discoveryv1.EndpointHints{
+			ForZones: []discoveryv1.ForZone{{Name: "us-east-1a"}},
+		},
+	}
+	unknownEndpoint := discoveryv1.Endpoint{
+		Addresses: []string{"10.0.0.2"},
+	}
+	notReadyEndpoint := discoveryv1.Endpoint{
+		Addresses:  []string{"10.0.0.3"},
+		Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false), Serving: ptr.To(false)},
+	}
+	drainingEndpoint := discoveryv1.Endpoint{
+		Addresses:  []string{"10.0.0.4"},
+		Conditions: discoveryv1.EndpointConditions{Serving: ptr.To(true), Terminating: ptr.To(true)},
+	}
+	drainedEndpoint := discoveryv1.Endpoint{
+		Addresses:  []string{"10.0.0.4"},
+		Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false), Serving: ptr.To(true), Terminating: ptr.To(true)},
+	}
+
+	tests := []struct {
+		name           string
+		endpoints      []discoveryv1.Endpoint
+		filterNotReady bool
+		expected       []discoveryv1.Endpoint
+	}{
+		{
+			name:     "nil endpoints",
+			expected: nil,
+		},
+		{
+			name:      "conditions and hints preserved",
+			endpoints: []discoveryv1.Endpoint{readyEndpoint, unknownEndpoint, notReadyEndpoint},
+			expected:  []discoveryv1.Endpoint{readyEndpoint, unknownEndpoint, notReadyEndpoint},
+		},
+		{
+			name:      "terminating endpoint marked not ready",
+			endpoints: []discoveryv1.Endpoint{readyEndpoint, drainingEndpoint},
+			expected:  []discoveryv1.Endpoint{readyEndpoint, drainedEndpoint},
+		},
+		{
+			name:           "not ready endpoints filtered",
+			endpoints:      []discoveryv1.Endpoint{readyEndpoint, unknownEndpoint, notReadyEndpoint, drainingEndpoint},
+			filterNotReady: true,
+			expected:       []discoveryv1.Endpoint{readyEndpoint, unknownEndpoint},
+		},
+		{
+			name:           "serving terminating endpoints retained when none are ready",
+			endpoints:      []discoveryv1.Endpoint{notReadyEndpoint, drainingEndpoint},
+			filterNotReady: true,
+			expected:       []discoveryv1.Endpoint{drainedEndpoint},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var original []discoveryv1.Endpoint
+			for _, endpoint := range tt.endpoints {
+				original = append(original, *endpoint.DeepCopy())
+			}
+
+			assert.Equal(t, tt.expected, desiredDownstreamEndpoints(tt.endpoints, tt.filterNotReady))
+			assert.Equal(t, original, tt.endpoints, "upstream endpoints must not be modified")
+		})
+	}
+}
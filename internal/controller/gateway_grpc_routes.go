@@ -0,0 +1,451 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/features"
+)
+
+const KindGRPCRoute = "GRPCRoute"
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes/finalizers,verbs=update
+
+// listenerSupportedKinds returns the route kinds that attach to listeners of a
+// protocol.
+func (r *GatewayReconciler) listenerSupportedKinds(protocol gatewayv1.ProtocolType) []gatewayv1.RouteGroupKind {
+	kind := listenerRouteKind(protocol)
+	supportedKinds := []gatewayv1.RouteGroupKind{
+		{
+			Group: ptr.To(gatewayv1.Group(gatewayv1.GroupName)),
+			Kind:  gatewayv1.Kind(kind),
+		},
+	}
+	if kind == KindHTTPRoute && r.Config.FeatureEnabled(features.GRPCRoutes) {
+		supportedKinds = append(supportedKinds, gatewayv1.RouteGroupKind{
+			Group: ptr.To(gatewayv1.Group(gatewayv1.GroupName)),
+			Kind:  KindGRPCRoute,
+		})
+	}
+	return supportedKinds
+}
+
+// ensureDownstreamGatewayGRPCRoutes programs the GRPCRoutes attached to the
+// upstream gateway into the downstream cluster, and adds them to the attached
+// route counts of the listeners.
+func (r *GatewayReconciler) ensureDownstreamGatewayGRPCRoutes(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	upstreamGatewayClassControllerName string,
+	downstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	attachedRouteCount map[gatewayv1.SectionName]int32,
+	export *manifestExport,
+) (result Result) {
+	logger := log.FromContext(ctx)
+
+	var grpcRoutes gatewayv1.GRPCRouteList
+	if err := upstreamClient.List(ctx, &grpcRoutes, client.InNamespace(upstreamGateway.Namespace)); err != nil {
+		result.Err = fmt.Errorf("failed listing grpcroutes: %w", err)
+		return result
+	}
+
+	for _, route := range grpcRoutes.Items {
+		listeners := routeListeners(upstreamGateway, route.Namespace, route.Spec.ParentRefs, KindGRPCRoute)
+		if len(listeners) == 0 {
+			continue
+		}
+		for _, l := range listeners {
+			attachedRouteCount[l]++
+		}
+
+		if !route.DeletionTimestamp.IsZero() {
+			logger.Info("skipping grpcroute due to deletion timestamp", jsonKeyName, route.Name)
+			continue
+		}
+
+		if !controllerutil.ContainsFinalizer(&route, gatewayControllerGCFinalizer) {
+			controllerutil.AddFinalizer(&route, gatewayControllerGCFinalizer)
+			if err := upstreamClient.Update(ctx, &route); err != nil {
+				result.Err = fmt.Errorf("failed to add finalizer to grpcroute: %w", err)
+				return result
+			}
+		}
+
+		result = result.Merge(r.ensureDownstreamGRPCRoute(
+			ctx,
+			upstreamClient,
+			upstreamGateway,
+			upstreamGatewayClassControllerName,
+			downstreamGateway,
+			downstreamStrategy,
+			route,
+			export,
+		))
+		if result.Err != nil {
+			return result
+		}
+	}
+
+	return result
+}
+
+func (r *GatewayReconciler) ensureDownstreamGRPCRoute(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	upstreamGatewayClassControllerName string,
+	downstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	upstreamRoute gatewayv1.GRPCRoute,
+	export *manifestExport,
+) (result Result) {
+	logger := log.FromContext(ctx)
+	logger.Info("processing grpcroute", jsonKeyName, upstreamRoute.Name)
+
+	downstreamClient := downstreamStrategy.GetClient()
+	downstreamRouteObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, &upstreamRoute)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to get downstream grpcroute object metadata: %w", err)
+		return result
+	}
+
+	downstreamRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: downstreamRouteObjectMeta,
+	}
+
+	rules, downstreamResources, err := r.processDownstreamGRPCRouteRules(
+		ctx,
+		upstreamClient,
+		upstreamGateway,
+		upstreamRoute,
+		downstreamGateway,
+		downstreamStrategy,
+	)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	upstreamParentRefs := make([]gatewayv1.ParentReference, 0, len(upstreamRoute.Spec.ParentRefs))
+	for _, parentRef := range upstreamRoute.Spec.ParentRefs {
+		if parentRef.Namespace != nil {
+			parentRef.Namespace = ptr.To(gatewayv1.Namespace(downstreamGateway.Namespace))
+		}
+		upstreamParentRefs = append(upstreamParentRefs, parentRef)
+	}
+	parentRefs, err := downstreamHTTPRouteParentRefs(ctx, downstreamClient, downstreamRouteObjectMeta.Namespace, upstreamParentRefs)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	desiredSpec := gatewayv1.GRPCRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: parentRefs,
+		},
+		Hostnames: upstreamRoute.Spec.Hostnames,
+		Rules:     rules,
+	}
+	desiredHash, err := downstreamclient.DesiredHash(desiredSpec)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	routeResult, err := controllerutil.CreateOrUpdate(ctx, downstreamClient, downstreamRoute, func() error {
+		if err := downstreamStrategy.SetControllerReference(ctx, &upstreamRoute, downstreamRoute); err != nil {
+			return fmt.Errorf("failed to set controller reference on downstream grpcroute: %w", err)
+		}
+
+		if downstreamclient.InSync(downstreamRoute, desiredHash) {
+			return nil
+		}
+		downstreamRoute.Spec = desiredSpec
+		downstreamclient.SetDesiredHash(downstreamRoute, desiredHash)
+
+		return nil
+	})
+	if err == nil {
+		err = downstreamclient.RecordObservedGeneration(ctx, downstreamClient, downstreamRoute)
+	}
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			result.RequeueAfter = 1 * time.Second
+			export.addConflictedRoute(KindGRPCRoute, upstreamRoute.Name)
+			return result
+		}
+		result.Err = err
+		return result
+	}
+
+	export.add(downstreamRoute)
+	if err := r.applyDownstreamRouteResources(ctx, downstreamClient, downstreamRoute, downstreamResources, nil, export); err != nil {
+		result.Err = err
+		return result
+	}
+
+	if err := mirrorDownstreamRouteParentStatus(
+		ctx,
+		downstreamClient,
+		upstreamGateway,
+		upstreamGatewayClassControllerName,
+		downstreamGateway,
+		&upstreamRoute.Status.Parents,
+		downstreamRoute.Status.Parents,
+		upstreamRoute.Generation,
+	); err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.AddStatusUpdate(upstreamClient, &upstreamRoute)
+
+	logger.Info("downstream grpcroute processed", "operation_result", routeResult)
+
+	return result
+}
+
+// processDownstreamGRPCRouteRules returns the downstream rules of a GRPCRoute,
+// and the downstream resources they reference. EndpointSlice backendRefs are
+// transformed into references to a headless Service, the same way they are
+// for HTTPRoutes.
+func (r *GatewayReconciler) processDownstreamGRPCRouteRules(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	upstreamRoute gatewayv1.GRPCRoute,
+	downstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) (rules []gatewayv1.GRPCRouteRule, downstreamResources []client.Object, err error) {
+	logger := log.FromContext(ctx)
+
+	for ruleIdx, rule := range upstreamRoute.Spec.Rules {
+		var backendRefs []gatewayv1.GRPCBackendRef
+		for backendRefIdx, backendRef := range rule.BackendRefs {
+			switch ptr.Deref(backendRef.Kind, KindService) {
+			case KindEndpointSlice:
+				var upstreamEndpointSlice discoveryv1.EndpointSlice
+				if err := upstreamClient.Get(ctx, types.NamespacedName{
+					Namespace: string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(upstreamGateway.Namespace))),
+					Name:      string(backendRef.Name),
+				}, &upstreamEndpointSlice); err != nil {
+					return nil, nil, err
+				}
+
+				if backendRef.Port == nil {
+					return nil, nil, fmt.Errorf("no port defined in backendRef")
+				}
+
+				if !controllerutil.ContainsFinalizer(&upstreamEndpointSlice, gatewayControllerGCFinalizer) {
+					controllerutil.AddFinalizer(&upstreamEndpointSlice, gatewayControllerGCFinalizer)
+					if err := upstreamClient.Update(ctx, &upstreamEndpointSlice); err != nil {
+						return nil, nil, fmt.Errorf("failed to add finalizer to endpointslice: %w", err)
+					}
+				}
+
+				resourceName := fmt.Sprintf("route-%s-rule-%d-backendref-%d", upstreamRoute.UID, ruleIdx, backendRefIdx)
+				downstreamService, downstreamEndpointSlice, err := r.desiredDownstreamEndpointSliceService(
+					ctx,
+					downstreamStrategy,
+					downstreamGateway.Namespace,
+					resourceName,
+					&upstreamEndpointSlice,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				downstreamResources = append(downstreamResources, downstreamService, downstreamEndpointSlice)
+
+				backendRefs = append(backendRefs, gatewayv1.GRPCBackendRef{
+					BackendRef: gatewayv1.BackendRef{
+						Weight: backendRef.Weight,
+						BackendObjectReference: gatewayv1.BackendObjectReference{
+							Namespace: ptr.To(gatewayv1.Namespace(downstreamGateway.Namespace)),
+							Kind:      ptr.To(gatewayv1.Kind(KindService)),
+							Name:      gatewayv1.ObjectName(downstreamService.Name),
+							Port:      backendRef.Port,
+						},
+					},
+					Filters: backendRef.Filters,
+				})
+
+			case KindService, envoygatewayv1alpha1.KindBackend:
+				backendRefs = append(backendRefs, backendRef)
+
+			default:
+				logger.Info("unknown backend ref kind", jsonKeyKind, *backendRef.Kind)
+				continue
+			}
+		}
+
+		rules = append(rules, gatewayv1.GRPCRouteRule{
+			Name:               rule.Name,
+			Matches:            rule.Matches,
+			Filters:            rule.Filters,
+			BackendRefs:        backendRefs,
+			SessionPersistence: rule.SessionPersistence,
+		})
+	}
+
+	return rules, downstreamResources, nil
+}
+
+// detachGRPCRoutes removes the parent references to the gateway from the
+// GRPCRoutes in its namespace, like detachHTTPRoutes does for HTTPRoutes.
+func (r *GatewayReconciler) detachGRPCRoutes(
+	ctx context.Context,
+	gatewayClient client.Client,
+	gateway *gatewayv1.Gateway,
+	deleteWhenNoParents bool,
+) (result Result) {
+	logger := log.FromContext(ctx)
+
+	var grpcRoutes gatewayv1.GRPCRouteList
+	if err := gatewayClient.List(ctx, &grpcRoutes, client.InNamespace(gateway.Namespace)); err != nil {
+		result.Err = fmt.Errorf("failed listing grpcroutes: %w", err)
+		return result
+	}
+
+	for _, route := range grpcRoutes.Items {
+		if !route.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		var remainingRefs []gatewayv1.ParentReference
+		for _, ref := range route.Spec.ParentRefs {
+			if ptr.Deref(ref.Group, gatewayv1.GroupName) == gatewayv1.GroupName &&
+				ptr.Deref(ref.Kind, KindGateway) == KindGateway &&
+				string(ref.Name) == gateway.Name {
+				continue
+			}
+			remainingRefs = append(remainingRefs, ref)
+		}
+
+		if len(remainingRefs) == len(route.Spec.ParentRefs) {
+			continue
+		}
+
+		if len(remainingRefs) == 0 && deleteWhenNoParents {
+			logger.Info("deleting grpcroute due to no parents", jsonKeyName, route.Name)
+			if err := gatewayClient.Delete(ctx, &route); client.IgnoreNotFound(err) != nil {
+				result.Err = err
+				return result
+			}
+		} else {
+			logger.Info("removing parent ref from grpcroute", jsonKeyName, route.Name, "parent", gateway.Name)
+			route.Spec.ParentRefs = remainingRefs
+			if err := gatewayClient.Update(ctx, &route); err != nil {
+				result.Err = err
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// listGatewaysAttachedByGRPCRoute is a watch predicate which finds all Gateways
+// mentioned in the parentRefs of a GRPCRoute.
+func (r *GatewayReconciler) listGatewaysAttachedByGRPCRoute(ctx context.Context, obj client.Object) []ctrl.Request {
+	grpcRoute, ok := obj.(*gatewayv1.GRPCRoute)
+	if !ok {
+		log.FromContext(ctx).Error(fmt.Errorf("unexpected object type"),
+			"GRPCRoute watch predicate received unexpected object type", "found", fmt.Sprintf("%T", obj))
+		return nil
+	}
+
+	var reqs []ctrl.Request
+	for _, parentRef := range grpcRoute.Spec.ParentRefs {
+		if ptr.Deref(parentRef.Group, gatewayv1.GroupName) == gatewayv1.GroupName &&
+			ptr.Deref(parentRef.Kind, KindGateway) == KindGateway {
+			reqs = append(reqs, ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: string(ptr.Deref(parentRef.Namespace, gatewayv1.Namespace(grpcRoute.Namespace))),
+					Name:      string(parentRef.Name),
+				},
+			})
+		}
+	}
+	return reqs
+}
+
+// listGatewaysAttachedByDownstreamGRPCRoute enqueues reconciliation requests
+// for the upstream Gateways referenced by a downstream GRPCRoute, so that its
+// status is mirrored to the upstream route.
+func (r *GatewayReconciler) listGatewaysAttachedByDownstreamGRPCRoute(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[*gatewayv1.GRPCRoute, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, grpcRoute *gatewayv1.GRPCRoute) []mcreconcile.Request {
+		return downstreamRouteGatewayRequests(grpcRoute.Labels, grpcRoute.Spec.ParentRefs)
+	})
+}
+
+// listGatewaysForGRPCRouteEndpointSlice returns requests for the Gateways of
+// the GRPCRoutes that reference an EndpointSlice as a backend.
+func (r *GatewayReconciler) listGatewaysForGRPCRouteEndpointSlice(
+	ctx context.Context,
+	clusterName multicluster.ClusterName,
+	cl client.Client,
+	endpointSlice *discoveryv1.EndpointSlice,
+) []mcreconcile.Request {
+	var grpcRoutes gatewayv1.GRPCRouteList
+	if err := cl.List(ctx, &grpcRoutes, client.InNamespace(endpointSlice.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list GRPCRoutes")
+		return nil
+	}
+
+	var requests []mcreconcile.Request
+	for i := range grpcRoutes.Items {
+		route := &grpcRoutes.Items[i]
+		referenced := false
+		for _, backendRefs := range grpcRouteBackendRefs(route) {
+			for _, backendRef := range backendRefs {
+				if ptr.Deref(backendRef.Kind, "") == KindEndpointSlice &&
+					string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(route.Namespace))) == endpointSlice.Namespace &&
+					string(backendRef.Name) == endpointSlice.Name {
+					referenced = true
+				}
+			}
+		}
+		if !referenced {
+			continue
+		}
+		for _, req := range r.listGatewaysAttachedByGRPCRoute(ctx, route) {
+			requests = append(requests, mcreconcile.Request{ClusterName: clusterName, Request: req})
+		}
+	}
+	return requests
+}
+
+// grpcRouteBackendRefs returns the backendRefs of each rule of a GRPCRoute.
+func grpcRouteBackendRefs(grpcRoute *gatewayv1.GRPCRoute) [][]gatewayv1.BackendRef {
+	ruleBackendRefs := make([][]gatewayv1.BackendRef, 0, len(grpcRoute.Spec.Rules))
+	for _, rule := range grpcRoute.Spec.Rules {
+		backendRefs := make([]gatewayv1.BackendRef, 0, len(rule.BackendRefs))
+		for _, backendRef := range rule.BackendRefs {
+			backendRefs = append(backendRefs, backendRef.BackendRef)
+		}
+		ruleBackendRefs = append(ruleBackendRefs, backendRefs)
+	}
+	return ruleBackendRefs
+}
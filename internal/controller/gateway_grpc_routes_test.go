@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/features"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
+)
+
+func TestListenerSupportedKinds(t *testing.T) {
+	reconciler := &GatewayReconciler{}
+
+	kinds := func(routeGroupKinds []gatewayv1.RouteGroupKind) []gatewayv1.Kind {
+		var kinds []gatewayv1.Kind
+		for _, k := range routeGroupKinds {
+			kinds = append(kinds, k.Kind)
+		}
+		return kinds
+	}
+
+	assert.Equal(t, []gatewayv1.Kind{KindHTTPRoute}, kinds(reconciler.listenerSupportedKinds(gatewayv1.HTTPSProtocolType)))
+
+	reconciler.Config.FeatureGates = map[string]bool{string(features.GRPCRoutes): true}
+	assert.Equal(t, []gatewayv1.Kind{KindHTTPRoute, KindGRPCRoute}, kinds(reconciler.listenerSupportedKinds(gatewayv1.HTTPSProtocolType)))
+	assert.Equal(t, []gatewayv1.Kind{KindTCPRoute}, kinds(reconciler.listenerSupportedKinds(gatewayv1.TCPProtocolType)))
+}
+
+func TestEnsureDownstreamGatewayGRPCRoutes(t *testing.T) {
+	testScheme := newL4TestScheme(t)
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayClassName: "test-suite",
+			TargetDomain:               "test-suite.com",
+		},
+		FeatureGates: map[string]bool{string(features.GRPCRoutes): true},
+	}
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			UID:  uuid.NewUUID(),
+		},
+	}
+	downstreamNamespaceName := fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test", withL4Listeners)
+
+	upstreamEndpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: upstreamNamespace.Name,
+			Name:      "echo",
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"192.0.2.1"}},
+		},
+		Ports: []discoveryv1.EndpointPort{
+			{Name: ptr.To("grpc"), Port: ptr.To(int32(9000)), AppProtocol: ptr.To("kubernetes.io/h2c")},
+		},
+	}
+
+	grpcRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: upstreamNamespace.Name,
+			Name:      "echo",
+			UID:       uuid.NewUUID(),
+		},
+		Spec: gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "test"}},
+			},
+			Hostnames: []gatewayv1.Hostname{"echo.example.com"},
+			Rules: []gatewayv1.GRPCRouteRule{
+				{
+					Matches: []gatewayv1.GRPCRouteMatch{
+						{Method: &gatewayv1.GRPCMethodMatch{Service: ptr.To("echo.Echo")}},
+					},
+					BackendRefs: []gatewayv1.GRPCBackendRef{
+						{
+							BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+									Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+									Name:  gatewayv1.ObjectName(upstreamEndpointSlice.Name),
+									Port:  ptr.To(gatewayv1.PortNumber(9000)),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(upstreamGateway, upstreamNamespace, upstreamEndpointSlice, grpcRoute).
+		WithStatusSubresource(upstreamGateway, grpcRoute).
+		Build()
+
+	downstreamGateway := newGateway(testConfig, downstreamNamespaceName, upstreamGateway.Name, withL4Listeners)
+
+	acceptedConditions := []metav1.Condition{
+		{
+			Type:               string(gatewayv1.RouteConditionAccepted),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(gatewayv1.RouteReasonAccepted),
+			LastTransitionTime: metav1.Now(),
+		},
+		{
+			Type:               string(gatewayv1.RouteConditionResolvedRefs),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(gatewayv1.RouteReasonBackendNotFound),
+			LastTransitionTime: metav1.Now(),
+		},
+	}
+	existingDownstreamGRPCRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: downstreamNamespaceName,
+			Name:      grpcRoute.Name,
+		},
+		Status: gatewayv1.GRPCRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{
+					{
+						ParentRef:      gatewayv1.ParentReference{Name: gatewayv1.ObjectName(downstreamGateway.Name)},
+						ControllerName: "downstream",
+						Conditions:     acceptedConditions,
+					},
+				},
+			},
+		},
+	}
+
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(downstreamGateway, existingDownstreamGRPCRoute).
+		WithStatusSubresource(downstreamGateway, existingDownstreamGRPCRoute).
+		Build()
+
+	ctx := context.Background()
+
+	reconciler := &GatewayReconciler{
+		mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+		Config:            testConfig,
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+
+	attachedRouteCount := map[gatewayv1.SectionName]int32{}
+	result := reconciler.ensureDownstreamGatewayGRPCRoutes(
+		ctx,
+		fakeUpstreamClient,
+		upstreamGateway,
+		"test",
+		downstreamGateway,
+		downstreamStrategy,
+		attachedRouteCount,
+		nil,
+	)
+	require.NoError(t, result.Err)
+	_, err := result.Complete(ctx)
+	require.NoError(t, err)
+
+	// GRPCRoutes attach to the HTTP and HTTPS listeners only.
+	assert.Equal(t, map[gatewayv1.SectionName]int32{
+		gatewayutil.DefaultHTTPListenerName:  1,
+		gatewayutil.DefaultHTTPSListenerName: 1,
+	}, attachedRouteCount)
+
+	resourceName := fmt.Sprintf("route-%s-rule-0-backendref-0", grpcRoute.UID)
+	var downstreamGRPCRoute gatewayv1.GRPCRoute
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: downstreamNamespaceName, Name: grpcRoute.Name}, &downstreamGRPCRoute))
+	assert.Equal(t, grpcRoute.Spec.Hostnames, downstreamGRPCRoute.Spec.Hostnames)
+	if assert.Len(t, downstreamGRPCRoute.Spec.Rules, 1) {
+		rule := downstreamGRPCRoute.Spec.Rules[0]
+		assert.Equal(t, grpcRoute.Spec.Rules[0].Matches, rule.Matches)
+		if assert.Len(t, rule.BackendRefs, 1) {
+			assert.Equal(t, KindService, string(ptr.Deref(rule.BackendRefs[0].Kind, "")))
+			assert.Equal(t, resourceName, string(rule.BackendRefs[0].Name))
+		}
+	}
+
+	var downstreamService corev1.Service
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: downstreamNamespaceName, Name: resourceName}, &downstreamService))
+	if assert.Len(t, downstreamService.Spec.Ports, 1) {
+		assert.Equal(t, ptr.To("kubernetes.io/h2c"), downstreamService.Spec.Ports[0].AppProtocol)
+	}
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: downstreamNamespaceName, Name: resourceName}, &discoveryv1.EndpointSlice{}))
+
+	var updatedGRPCRoute gatewayv1.GRPCRoute
+	require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(grpcRoute), &updatedGRPCRoute))
+	assert.True(t, controllerutil.ContainsFinalizer(&updatedGRPCRoute, gatewayControllerGCFinalizer))
+	if assert.Len(t, updatedGRPCRoute.Status.Parents, 1) {
+		conditions := updatedGRPCRoute.Status.Parents[0].Conditions
+		assert.True(t, apimeta.IsStatusConditionTrue(conditions, string(gatewayv1.RouteConditionAccepted)))
+		resolvedRefs := apimeta.FindStatusCondition(conditions, string(gatewayv1.RouteConditionResolvedRefs))
+		if assert.NotNil(t, resolvedRefs) {
+			assert.Equal(t, metav1.ConditionFalse, resolvedRefs.Status)
+			assert.Equal(t, string(gatewayv1.RouteReasonBackendNotFound), resolvedRefs.Reason)
+		}
+	}
+}
+
+func TestGRPCRouteGC(t *testing.T) {
+	testScheme := newL4TestScheme(t)
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			UID:  uuid.NewUUID(),
+		},
+	}
+
+	upstreamGRPCRouteUID := uuid.NewUUID()
+
+	downstreamEndpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: fmt.Sprintf("ns-%s", upstreamNamespace.UID),
+			Name:      fmt.Sprintf("route-%s-rule-%d-backendref-%d", upstreamGRPCRouteUID, 0, 0),
+		},
+	}
+
+	upstreamGRPCRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         upstreamNamespace.Name,
+			Name:              "test",
+			UID:               upstreamGRPCRouteUID,
+			Finalizers:        []string{gatewayControllerGCFinalizer},
+			DeletionTimestamp: ptr.To(metav1.Now()),
+		},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{
+				{
+					BackendRefs: []gatewayv1.GRPCBackendRef{
+						{
+							BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+									Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+									Name:  "echo",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(upstreamGRPCRoute, upstreamNamespace).
+		Build()
+
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(downstreamEndpointSlice).
+		Build()
+
+	ctx := context.Background()
+
+	reconciler := &GatewayDownstreamGCReconciler{
+		mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+
+	_, err := reconciler.Reconcile(ctx, GVKRequest{
+		GVK: gatewayv1.SchemeGroupVersion.WithKind(KindGRPCRoute),
+		Request: mcreconcile.Request{
+			ClusterName: "test",
+			Request: reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(upstreamGRPCRoute),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	err = fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(upstreamGRPCRoute), &gatewayv1.GRPCRoute{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	err = fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(downstreamEndpointSlice), &discoveryv1.EndpointSlice{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// GatewayHTTP3Annotation opts an upstream Gateway in to HTTP/3 on its HTTPS
+// listeners when the platform serves HTTP/3 to gateways that opt in.
+// HTTPProxies set it from their protocols.http3 field.
+const GatewayHTTP3Annotation = "networking.datumapis.com/http3"
+
+// AppProtocolH2C is the application protocol of EndpointSlice ports whose
+// backends are reached with HTTP/2 without TLS.
+const AppProtocolH2C = "kubernetes.io/h2c"
+
+// GatewayConditionHTTP3Enabled is set on upstream Gateways that request
+// HTTP/3, and reports whether it is served.
+const GatewayConditionHTTP3Enabled = "HTTP3Enabled"
+
+const (
+	GatewayReasonHTTP3Enabled      = "Enabled"
+	GatewayReasonHTTP3Unavailable  = "Unavailable"
+	GatewayReasonHTTP3PortConflict = "PortConflict"
+)
+
+// gatewayRequestsHTTP3 returns whether HTTP/3 is requested for a gateway,
+// either by the platform or by the gateway itself.
+func gatewayRequestsHTTP3(mode config.GatewayHTTP3Mode, gateway *gatewayv1.Gateway) bool {
+	return mode == config.GatewayHTTP3Enabled || gateway.Annotations[GatewayHTTP3Annotation] == "true"
+}
+
+// http3ConflictingPorts returns the ports of the HTTPS listeners of a gateway
+// that are also used by UDP listeners, as HTTP/3 is served over UDP on the
+// port of the HTTPS listener.
+func http3ConflictingPorts(gateway *gatewayv1.Gateway) []gatewayv1.PortNumber {
+	var httpsPorts, conflicts []gatewayv1.PortNumber
+	for _, l := range gateway.Spec.Listeners {
+		if l.Protocol == gatewayv1.HTTPSProtocolType {
+			httpsPorts = append(httpsPorts, l.Port)
+		}
+	}
+	for _, l := range gateway.Spec.Listeners {
+		if l.Protocol == gatewayv1.UDPProtocolType && slices.Contains(httpsPorts, l.Port) && !slices.Contains(conflicts, l.Port) {
+			conflicts = append(conflicts, l.Port)
+		}
+	}
+	return conflicts
+}
+
+// http3Available returns whether the platform serves HTTP/3 to any gateway.
+func (r *GatewayReconciler) http3Available() bool {
+	mode := r.Config.Gateway.HTTP3
+	return mode == config.GatewayHTTP3OptIn || mode == config.GatewayHTTP3Enabled
+}
+
+// gatewayServesHTTP3 returns whether HTTP/3 is programmed on the HTTPS
+// listeners of a gateway.
+func (r *GatewayReconciler) gatewayServesHTTP3(gateway *gatewayv1.Gateway) bool {
+	return r.http3Available() &&
+		gatewayRequestsHTTP3(r.Config.Gateway.HTTP3, gateway) &&
+		len(http3ConflictingPorts(gateway)) == 0
+}
+
+// clientHTTP3Settings returns the HTTP/3 settings of client connections to the
+// gateway, or nil when HTTP/3 is not served.
+func (r *GatewayReconciler) clientHTTP3Settings(gateway *gatewayv1.Gateway) *envoygatewayv1alpha1.HTTP3Settings {
+	if !r.gatewayServesHTTP3(gateway) {
+		return nil
+	}
+	return &envoygatewayv1alpha1.HTTP3Settings{}
+}
+
+// reconcileHTTP3Status sets the HTTP3Enabled condition on the upstream gateway.
+// The condition is removed when HTTP/3 is not requested.
+func (r *GatewayReconciler) reconcileHTTP3Status(upstreamClient client.Client, upstreamGateway *gatewayv1.Gateway) (result Result) {
+	mode := r.Config.Gateway.HTTP3
+	if !gatewayRequestsHTTP3(mode, upstreamGateway) {
+		if apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, GatewayConditionHTTP3Enabled) == nil {
+			return result
+		}
+		apimeta.RemoveStatusCondition(&upstreamGateway.Status.Conditions, GatewayConditionHTTP3Enabled)
+		result.AddStatusUpdate(upstreamClient, upstreamGateway)
+		return result
+	}
+
+	condition := metav1.Condition{
+		Type:               GatewayConditionHTTP3Enabled,
+		Status:             metav1.ConditionTrue,
+		Reason:             GatewayReasonHTTP3Enabled,
+		Message:            "HTTP/3 is served on the HTTPS listeners of the gateway",
+		ObservedGeneration: upstreamGateway.Generation,
+	}
+	conflicts := http3ConflictingPorts(upstreamGateway)
+	switch {
+	case !r.http3Available():
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GatewayReasonHTTP3Unavailable
+		condition.Message = "HTTP/3 is not available for gateways on this platform"
+	case len(conflicts) > 0:
+		ports := make([]string, 0, len(conflicts))
+		for _, port := range conflicts {
+			ports = append(ports, fmt.Sprintf("%d", port))
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GatewayReasonHTTP3PortConflict
+		condition.Message = fmt.Sprintf("HTTP/3 is not served, as UDP listeners use the ports of HTTPS listeners: %s", strings.Join(ports, ", "))
+	}
+
+	if !apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, condition) {
+		return result
+	}
+	result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	return result
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestReconcileHTTP3Status(t *testing.T) {
+	listeners := []gatewayv1.Listener{
+		{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+		{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443},
+	}
+
+	tests := map[string]struct {
+		mode           config.GatewayHTTP3Mode
+		annotated      bool
+		udpListener    bool
+		expectedReason string
+		expectServed   bool
+	}{
+		"disabled": {
+			mode: config.GatewayHTTP3Disabled,
+		},
+		"opt in without annotation": {
+			mode: config.GatewayHTTP3OptIn,
+		},
+		"opt in with annotation": {
+			mode:           config.GatewayHTTP3OptIn,
+			annotated:      true,
+			expectedReason: GatewayReasonHTTP3Enabled,
+			expectServed:   true,
+		},
+		"enabled": {
+			mode:           config.GatewayHTTP3Enabled,
+			expectedReason: GatewayReasonHTTP3Enabled,
+			expectServed:   true,
+		},
+		"annotation while disabled": {
+			mode:           config.GatewayHTTP3Disabled,
+			annotated:      true,
+			expectedReason: GatewayReasonHTTP3Unavailable,
+		},
+		"udp listener on https port": {
+			mode:           config.GatewayHTTP3Enabled,
+			udpListener:    true,
+			expectedReason: GatewayReasonHTTP3PortConflict,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test-gw"},
+				Spec:       gatewayv1.GatewaySpec{Listeners: append([]gatewayv1.Listener{}, listeners...)},
+			}
+			if tt.annotated {
+				metav1.SetMetaDataAnnotation(&gateway.ObjectMeta, GatewayHTTP3Annotation, "true")
+			}
+			if tt.udpListener {
+				gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayv1.Listener{
+					Name: "quic", Protocol: gatewayv1.UDPProtocolType, Port: 443,
+				})
+			}
+
+			reconciler := &GatewayReconciler{
+				Config: config.NetworkServicesOperator{Gateway: config.GatewayConfig{HTTP3: tt.mode}},
+			}
+			assert.Equal(t, tt.expectServed, reconciler.gatewayServesHTTP3(gateway))
+			assert.Equal(t, tt.expectServed, reconciler.clientHTTP3Settings(gateway) != nil)
+
+			reconciler.reconcileHTTP3Status(nil, gateway)
+			condition := apimeta.FindStatusCondition(gateway.Status.Conditions, GatewayConditionHTTP3Enabled)
+			if tt.expectedReason == "" {
+				assert.Nil(t, condition)
+				return
+			}
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, tt.expectServed, condition.Status == metav1.ConditionTrue)
+		})
+	}
+}
+
+func TestEnsureDownstreamClientTrafficPolicyHTTP3(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()},
+	}
+	upstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "test",
+			Name:        "test-gw",
+			UID:         uuid.NewUUID(),
+			Annotations: map[string]string{GatewayHTTP3Annotation: "true"},
+		},
+	}
+
+	downstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-" + string(upstreamNamespace.UID), Name: "test-gw"},
+	}
+
+	fakeUpstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(upstreamNamespace, upstreamGateway).Build()
+	fakeDownstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+
+	reconciler := &GatewayReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{HTTP3: config.GatewayHTTP3OptIn},
+		},
+	}
+
+	ctx := context.Background()
+	result := reconciler.ensureDownstreamClientTrafficPolicy(ctx, upstreamGateway, downstreamGateway,
+		[]*gatewayv1.Gateway{downstreamGateway}, downstreamStrategy, nil)
+	require.NoError(t, result.Err)
+
+	var policy envoygatewayv1alpha1.ClientTrafficPolicy
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(downstreamGateway), &policy))
+	assert.NotNil(t, policy.Spec.HTTP3)
+	assert.Nil(t, policy.Spec.TLS)
+
+	// The policy is removed once the gateway opts out, as no TLS policy is
+	// configured.
+	delete(upstreamGateway.Annotations, GatewayHTTP3Annotation)
+	result = reconciler.ensureDownstreamClientTrafficPolicy(ctx, upstreamGateway, downstreamGateway,
+		[]*gatewayv1.Gateway{downstreamGateway}, downstreamStrategy, nil)
+	require.NoError(t, result.Err)
+	err := fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(downstreamGateway), &policy)
+	assert.True(t, apierrors.IsNotFound(err), "expected client traffic policy to be deleted, got %v", err)
+}
+
+func TestDesiredDownstreamBackendH2C(t *testing.T) {
+	endpointSlice := &discoveryv1.EndpointSlice{
+		AddressType: discoveryv1.AddressTypeFQDN,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"grpc.example.com"}},
+		},
+		Ports: []discoveryv1.EndpointPort{
+			{Name: ptr.To("grpc"), Port: ptr.To(int32(9000)), AppProtocol: ptr.To(AppProtocolH2C)},
+			{Name: ptr.To("http"), Port: ptr.To(int32(80)), AppProtocol: ptr.To(SchemeHTTP)},
+		},
+	}
+
+	backend := desiredDownstreamBackend("ns", "grpc", endpointSlice, 9000)
+	assert.Equal(t, []envoygatewayv1alpha1.AppProtocolType{envoygatewayv1alpha1.AppProtocolTypeH2C}, backend.Spec.AppProtocols)
+
+	backend = desiredDownstreamBackend("ns", "http", endpointSlice, 80)
+	assert.Empty(t, backend.Spec.AppProtocols)
+}
@@ -0,0 +1,556 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+const (
+	KindTCPRoute = "TCPRoute"
+	KindUDPRoute = "UDPRoute"
+)
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=udproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=udproutes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=udproutes/finalizers,verbs=update
+
+// l4Route gives TCPRoutes and UDPRoutes, which share the same shape, a common
+// view for translation into the downstream cluster.
+type l4Route struct {
+	client.Object
+	kind       string
+	parentRefs *[]gatewayv1.ParentReference
+	status     *gatewayv1.RouteStatus
+	rules      []gatewayv1alpha2.TCPRouteRule
+}
+
+// wrapL4Route returns the l4Route view of a TCPRoute or UDPRoute, or nil for
+// any other object.
+func wrapL4Route(obj client.Object) *l4Route {
+	switch route := obj.(type) {
+	case *gatewayv1alpha2.TCPRoute:
+		return &l4Route{
+			Object:     route,
+			kind:       KindTCPRoute,
+			parentRefs: &route.Spec.ParentRefs,
+			status:     &route.Status.RouteStatus,
+			rules:      route.Spec.Rules,
+		}
+	case *gatewayv1alpha2.UDPRoute:
+		rules := make([]gatewayv1alpha2.TCPRouteRule, 0, len(route.Spec.Rules))
+		for _, rule := range route.Spec.Rules {
+			rules = append(rules, gatewayv1alpha2.TCPRouteRule(rule))
+		}
+		return &l4Route{
+			Object:     route,
+			kind:       KindUDPRoute,
+			parentRefs: &route.Spec.ParentRefs,
+			status:     &route.Status.RouteStatus,
+			rules:      rules,
+		}
+	}
+	return nil
+}
+
+// newL4Route returns an empty route of the given kind.
+func newL4Route(kind string, objectMeta metav1.ObjectMeta) *l4Route {
+	if kind == KindUDPRoute {
+		return wrapL4Route(&gatewayv1alpha2.UDPRoute{ObjectMeta: objectMeta})
+	}
+	return wrapL4Route(&gatewayv1alpha2.TCPRoute{ObjectMeta: objectMeta})
+}
+
+func (r *l4Route) setRules(rules []gatewayv1alpha2.TCPRouteRule) {
+	r.rules = rules
+	switch route := r.Object.(type) {
+	case *gatewayv1alpha2.TCPRoute:
+		route.Spec.Rules = rules
+	case *gatewayv1alpha2.UDPRoute:
+		route.Spec.Rules = make([]gatewayv1alpha2.UDPRouteRule, 0, len(rules))
+		for _, rule := range rules {
+			route.Spec.Rules = append(route.Spec.Rules, gatewayv1alpha2.UDPRouteRule(rule))
+		}
+	}
+}
+
+func (r *l4Route) backendRefs() [][]gatewayv1.BackendRef {
+	backendRefs := make([][]gatewayv1.BackendRef, 0, len(r.rules))
+	for _, rule := range r.rules {
+		backendRefs = append(backendRefs, rule.BackendRefs)
+	}
+	return backendRefs
+}
+
+// listL4Routes returns the TCPRoutes and UDPRoutes in a namespace.
+func listL4Routes(ctx context.Context, cl client.Client, namespace string) ([]*l4Route, error) {
+	var tcpRoutes gatewayv1alpha2.TCPRouteList
+	if err := cl.List(ctx, &tcpRoutes, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing tcproutes: %w", err)
+	}
+	var udpRoutes gatewayv1alpha2.UDPRouteList
+	if err := cl.List(ctx, &udpRoutes, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing udproutes: %w", err)
+	}
+
+	routes := make([]*l4Route, 0, len(tcpRoutes.Items)+len(udpRoutes.Items))
+	for i := range tcpRoutes.Items {
+		routes = append(routes, wrapL4Route(&tcpRoutes.Items[i]))
+	}
+	for i := range udpRoutes.Items {
+		routes = append(routes, wrapL4Route(&udpRoutes.Items[i]))
+	}
+	return routes, nil
+}
+
+// listenerRouteKind returns the kind of route that attaches to listeners of a
+// protocol. GRPCRoutes attach to the same listeners as HTTPRoutes.
+func listenerRouteKind(protocol gatewayv1.ProtocolType) string {
+	switch protocol {
+	case gatewayv1.TCPProtocolType:
+		return KindTCPRoute
+	case gatewayv1.UDPProtocolType:
+		return KindUDPRoute
+	default:
+		return KindHTTPRoute
+	}
+}
+
+// listenerAcceptsRouteKind returns whether routes of a kind attach to
+// listeners of a protocol.
+func listenerAcceptsRouteKind(protocol gatewayv1.ProtocolType, routeKind string) bool {
+	if routeKind == KindGRPCRoute {
+		routeKind = KindHTTPRoute
+	}
+	return listenerRouteKind(protocol) == routeKind
+}
+
+// routeListeners returns the listeners of the gateway that a route attaches
+// to. A parentRef without a section name attaches the route to all listeners
+// with a protocol the route kind supports.
+func routeListeners(
+	upstreamGateway *gatewayv1.Gateway,
+	routeNamespace string,
+	parentRefs []gatewayv1.ParentReference,
+	routeKind string,
+) []gatewayv1.SectionName {
+	var listeners []gatewayv1.SectionName
+	for _, parentRef := range parentRefs {
+		if ptr.Deref(parentRef.Group, gatewayv1.GroupName) != gatewayv1.GroupName ||
+			ptr.Deref(parentRef.Kind, KindGateway) != KindGateway ||
+			string(ptr.Deref(parentRef.Namespace, gatewayv1.Namespace(routeNamespace))) != upstreamGateway.Namespace ||
+			string(parentRef.Name) != upstreamGateway.Name {
+			continue
+		}
+		for _, l := range upstreamGateway.Spec.Listeners {
+			if !listenerAcceptsRouteKind(l.Protocol, routeKind) {
+				continue
+			}
+			if parentRef.SectionName == nil || *parentRef.SectionName == l.Name {
+				listeners = append(listeners, l.Name)
+			}
+		}
+	}
+	return listeners
+}
+
+// ensureDownstreamGatewayL4Routes programs the TCPRoutes and UDPRoutes attached
+// to the upstream gateway into the downstream cluster, and adds them to the
+// attached route counts of the listeners.
+func (r *GatewayReconciler) ensureDownstreamGatewayL4Routes(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	upstreamGatewayClassControllerName string,
+	downstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	attachedRouteCount map[gatewayv1.SectionName]int32,
+	export *manifestExport,
+) (result Result) {
+	logger := log.FromContext(ctx)
+
+	routes, err := listL4Routes(ctx, upstreamClient, upstreamGateway.Namespace)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	for _, route := range routes {
+		listeners := routeListeners(upstreamGateway, route.GetNamespace(), *route.parentRefs, route.kind)
+		if len(listeners) == 0 {
+			continue
+		}
+		for _, l := range listeners {
+			attachedRouteCount[l]++
+		}
+
+		if !route.GetDeletionTimestamp().IsZero() {
+			logger.Info("skipping route due to deletion timestamp", jsonKeyKind, route.kind, jsonKeyName, route.GetName())
+			continue
+		}
+
+		if !controllerutil.ContainsFinalizer(route, gatewayControllerGCFinalizer) {
+			controllerutil.AddFinalizer(route, gatewayControllerGCFinalizer)
+			if err := upstreamClient.Update(ctx, route.Object); err != nil {
+				result.Err = fmt.Errorf("failed to add finalizer to %s: %w", route.kind, err)
+				return result
+			}
+		}
+
+		result = result.Merge(r.ensureDownstreamL4Route(
+			ctx,
+			upstreamClient,
+			upstreamGateway,
+			upstreamGatewayClassControllerName,
+			downstreamGateway,
+			downstreamStrategy,
+			route,
+			export,
+		))
+		if result.Err != nil {
+			return result
+		}
+	}
+
+	return result
+}
+
+func (r *GatewayReconciler) ensureDownstreamL4Route(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	upstreamGatewayClassControllerName string,
+	downstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	upstreamRoute *l4Route,
+	export *manifestExport,
+) (result Result) {
+	logger := log.FromContext(ctx)
+	logger.Info("processing route", jsonKeyKind, upstreamRoute.kind, jsonKeyName, upstreamRoute.GetName())
+
+	downstreamClient := downstreamStrategy.GetClient()
+	downstreamRouteObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, upstreamRoute.Object)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to get downstream %s object metadata: %w", upstreamRoute.kind, err)
+		return result
+	}
+
+	downstreamRoute := newL4Route(upstreamRoute.kind, downstreamRouteObjectMeta)
+
+	rules, downstreamResources, err := r.processDownstreamL4RouteRules(
+		ctx,
+		upstreamClient,
+		upstreamGateway,
+		upstreamRoute,
+		downstreamGateway,
+		downstreamStrategy,
+	)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	upstreamParentRefs := make([]gatewayv1.ParentReference, 0, len(*upstreamRoute.parentRefs))
+	for _, parentRef := range *upstreamRoute.parentRefs {
+		if parentRef.Namespace != nil {
+			parentRef.Namespace = ptr.To(gatewayv1.Namespace(downstreamGateway.Namespace))
+		}
+		upstreamParentRefs = append(upstreamParentRefs, parentRef)
+	}
+	parentRefs, err := downstreamHTTPRouteParentRefs(ctx, downstreamClient, downstreamRouteObjectMeta.Namespace, upstreamParentRefs)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	desiredHash, err := downstreamclient.DesiredHash(struct {
+		ParentRefs []gatewayv1.ParentReference
+		Rules      any
+	}{parentRefs, rules})
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	routeResult, err := controllerutil.CreateOrUpdate(ctx, downstreamClient, downstreamRoute.Object, func() error {
+		if err := downstreamStrategy.SetControllerReference(ctx, upstreamRoute.Object, downstreamRoute.Object); err != nil {
+			return fmt.Errorf("failed to set controller reference on downstream %s: %w", upstreamRoute.kind, err)
+		}
+
+		if downstreamclient.InSync(downstreamRoute.Object, desiredHash) {
+			return nil
+		}
+		*downstreamRoute.parentRefs = parentRefs
+		downstreamRoute.setRules(rules)
+		downstreamclient.SetDesiredHash(downstreamRoute.Object, desiredHash)
+		return nil
+	})
+	if err == nil {
+		err = downstreamclient.RecordObservedGeneration(ctx, downstreamClient, downstreamRoute.Object)
+	}
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			result.RequeueAfter = 1 * time.Second
+			export.addConflictedRoute(upstreamRoute.kind, upstreamRoute.GetName())
+			return result
+		}
+		result.Err = err
+		return result
+	}
+
+	export.add(downstreamRoute.Object)
+	if err := r.applyDownstreamRouteResources(ctx, downstreamClient, downstreamRoute.Object, downstreamResources, nil, export); err != nil {
+		result.Err = err
+		return result
+	}
+
+	if err := mirrorDownstreamRouteParentStatus(
+		ctx,
+		downstreamClient,
+		upstreamGateway,
+		upstreamGatewayClassControllerName,
+		downstreamGateway,
+		&upstreamRoute.status.Parents,
+		downstreamRoute.status.Parents,
+		upstreamRoute.GetGeneration(),
+	); err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.AddStatusUpdate(upstreamClient, upstreamRoute.Object)
+
+	logger.Info("downstream route processed", jsonKeyKind, upstreamRoute.kind, "operation_result", routeResult)
+
+	return result
+}
+
+// processDownstreamL4RouteRules returns the downstream rules of a TCPRoute or
+// UDPRoute, and the downstream resources they reference. EndpointSlice
+// backendRefs are transformed into references to a headless Service, the same
+// way they are for HTTPRoutes.
+func (r *GatewayReconciler) processDownstreamL4RouteRules(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	upstreamRoute *l4Route,
+	downstreamGateway *gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) (rules []gatewayv1alpha2.TCPRouteRule, downstreamResources []client.Object, err error) {
+	logger := log.FromContext(ctx)
+
+	for ruleIdx, rule := range upstreamRoute.rules {
+		var backendRefs []gatewayv1.BackendRef
+		for backendRefIdx, backendRef := range rule.BackendRefs {
+			switch ptr.Deref(backendRef.Kind, KindService) {
+			case KindEndpointSlice:
+				var upstreamEndpointSlice discoveryv1.EndpointSlice
+				if err := upstreamClient.Get(ctx, types.NamespacedName{
+					Namespace: string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(upstreamGateway.Namespace))),
+					Name:      string(backendRef.Name),
+				}, &upstreamEndpointSlice); err != nil {
+					return nil, nil, err
+				}
+
+				if backendRef.Port == nil {
+					return nil, nil, fmt.Errorf("no port defined in backendRef")
+				}
+
+				if !controllerutil.ContainsFinalizer(&upstreamEndpointSlice, gatewayControllerGCFinalizer) {
+					controllerutil.AddFinalizer(&upstreamEndpointSlice, gatewayControllerGCFinalizer)
+					if err := upstreamClient.Update(ctx, &upstreamEndpointSlice); err != nil {
+						return nil, nil, fmt.Errorf("failed to add finalizer to endpointslice: %w", err)
+					}
+				}
+
+				resourceName := fmt.Sprintf("route-%s-rule-%d-backendref-%d", upstreamRoute.GetUID(), ruleIdx, backendRefIdx)
+				downstreamService, downstreamEndpointSlice, err := r.desiredDownstreamEndpointSliceService(
+					ctx,
+					downstreamStrategy,
+					downstreamGateway.Namespace,
+					resourceName,
+					&upstreamEndpointSlice,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				downstreamResources = append(downstreamResources, downstreamService, downstreamEndpointSlice)
+
+				backendRefs = append(backendRefs, gatewayv1.BackendRef{
+					Weight: backendRef.Weight,
+					BackendObjectReference: gatewayv1.BackendObjectReference{
+						Namespace: ptr.To(gatewayv1.Namespace(downstreamGateway.Namespace)),
+						Kind:      ptr.To(gatewayv1.Kind(KindService)),
+						Name:      gatewayv1.ObjectName(downstreamService.Name),
+						Port:      backendRef.Port,
+					},
+				})
+
+			case KindService:
+				backendRefs = append(backendRefs, backendRef)
+
+			default:
+				logger.Info("unknown backend ref kind", jsonKeyKind, *backendRef.Kind)
+				continue
+			}
+		}
+
+		rules = append(rules, gatewayv1alpha2.TCPRouteRule{
+			Name:        rule.Name,
+			BackendRefs: backendRefs,
+		})
+	}
+
+	return rules, downstreamResources, nil
+}
+
+// detachL4Routes removes the parent references to the gateway from the
+// TCPRoutes and UDPRoutes in its namespace, like detachHTTPRoutes does for
+// HTTPRoutes.
+func (r *GatewayReconciler) detachL4Routes(
+	ctx context.Context,
+	gatewayClient client.Client,
+	gateway *gatewayv1.Gateway,
+	deleteWhenNoParents bool,
+) (result Result) {
+	logger := log.FromContext(ctx)
+
+	routes, err := listL4Routes(ctx, gatewayClient, gateway.Namespace)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	for _, route := range routes {
+		if !route.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		var remainingRefs []gatewayv1.ParentReference
+		for _, ref := range *route.parentRefs {
+			if ptr.Deref(ref.Group, gatewayv1.GroupName) == gatewayv1.GroupName &&
+				ptr.Deref(ref.Kind, KindGateway) == KindGateway &&
+				string(ref.Name) == gateway.Name {
+				continue
+			}
+			remainingRefs = append(remainingRefs, ref)
+		}
+
+		if len(remainingRefs) == len(*route.parentRefs) {
+			continue
+		}
+
+		if len(remainingRefs) == 0 && deleteWhenNoParents {
+			logger.Info("deleting route due to no parents", jsonKeyKind, route.kind, jsonKeyName, route.GetName())
+			if err := gatewayClient.Delete(ctx, route.Object); client.IgnoreNotFound(err) != nil {
+				result.Err = err
+				return result
+			}
+		} else {
+			logger.Info("removing parent ref from route", jsonKeyKind, route.kind, jsonKeyName, route.GetName(), "parent", gateway.Name)
+			*route.parentRefs = remainingRefs
+			if err := gatewayClient.Update(ctx, route.Object); err != nil {
+				result.Err = err
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// listGatewaysAttachedByL4Route is a watch predicate which finds all Gateways
+// mentioned in the parentRefs of a TCPRoute or UDPRoute.
+func (r *GatewayReconciler) listGatewaysAttachedByL4Route(ctx context.Context, obj client.Object) []ctrl.Request {
+	route := wrapL4Route(obj)
+	if route == nil {
+		log.FromContext(ctx).Error(fmt.Errorf("unexpected object type"),
+			"route watch predicate received unexpected object type", "found", fmt.Sprintf("%T", obj))
+		return nil
+	}
+
+	var reqs []ctrl.Request
+	for _, parentRef := range *route.parentRefs {
+		if ptr.Deref(parentRef.Group, gatewayv1.GroupName) == gatewayv1.GroupName &&
+			ptr.Deref(parentRef.Kind, KindGateway) == KindGateway {
+			reqs = append(reqs, ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: string(ptr.Deref(parentRef.Namespace, gatewayv1.Namespace(route.GetNamespace()))),
+					Name:      string(parentRef.Name),
+				},
+			})
+		}
+	}
+	return reqs
+}
+
+// listGatewaysAttachedByDownstreamL4Route enqueues reconciliation requests for
+// the upstream Gateways referenced by a downstream TCPRoute or UDPRoute, so
+// that their status is mirrored to the upstream route.
+func (r *GatewayReconciler) listGatewaysAttachedByDownstreamL4Route(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		route := wrapL4Route(obj)
+		if route == nil {
+			return nil
+		}
+		return downstreamRouteGatewayRequests(route.GetLabels(), *route.parentRefs)
+	})
+}
+
+// listGatewaysForL4RouteEndpointSlice returns requests for the Gateways of the
+// TCPRoutes and UDPRoutes that reference an EndpointSlice as a backend.
+func (r *GatewayReconciler) listGatewaysForL4RouteEndpointSlice(
+	ctx context.Context,
+	clusterName multicluster.ClusterName,
+	cl client.Client,
+	endpointSlice *discoveryv1.EndpointSlice,
+) []mcreconcile.Request {
+	routes, err := listL4Routes(ctx, cl, endpointSlice.Namespace)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list routes for endpointslice")
+		return nil
+	}
+
+	var requests []mcreconcile.Request
+	for _, route := range routes {
+		referenced := false
+		for _, backendRefs := range route.backendRefs() {
+			for _, backendRef := range backendRefs {
+				if ptr.Deref(backendRef.Kind, "") == KindEndpointSlice &&
+					string(ptr.Deref(backendRef.Namespace, gatewayv1.Namespace(route.GetNamespace()))) == endpointSlice.Namespace &&
+					string(backendRef.Name) == endpointSlice.Name {
+					referenced = true
+				}
+			}
+		}
+		if !referenced {
+			continue
+		}
+		for _, req := range r.listGatewaysAttachedByL4Route(ctx, route.Object) {
+			requests = append(requests, mcreconcile.Request{ClusterName: clusterName, Request: req})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,399 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/features"
+)
+
+func newL4TestScheme(t *testing.T) *runtime.Scheme {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, gatewayv1alpha2.Install(testScheme))
+	require.NoError(t, discoveryv1.AddToScheme(testScheme))
+	return testScheme
+}
+
+func withL4Listeners(g *gatewayv1.Gateway) {
+	g.Spec.Listeners = append(g.Spec.Listeners,
+		gatewayv1.Listener{Name: "postgres", Protocol: gatewayv1.TCPProtocolType, Port: 5432},
+		gatewayv1.Listener{Name: "redis", Protocol: gatewayv1.TCPProtocolType, Port: 6379},
+		gatewayv1.Listener{Name: "dns", Protocol: gatewayv1.UDPProtocolType, Port: 53},
+	)
+}
+
+func TestRouteListeners(t *testing.T) {
+	testConfig := config.NetworkServicesOperator{Gateway: config.GatewayConfig{TargetDomain: "test-suite.com"}}
+	gateway := newGateway(testConfig, "test", "test", withL4Listeners)
+
+	tests := []struct {
+		name  string
+		route client.Object
+		want  []gatewayv1.SectionName
+	}{
+		{
+			name: "tcp route, all listeners",
+			route: &gatewayv1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+				Spec: gatewayv1alpha2.TCPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "test"}}},
+				},
+			},
+			want: []gatewayv1.SectionName{"postgres", "redis"},
+		},
+		{
+			name: "tcp route, section name",
+			route: &gatewayv1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+				Spec: gatewayv1alpha2.TCPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{
+						{Name: "test", SectionName: ptr.To(gatewayv1.SectionName("redis"))},
+					}},
+				},
+			},
+			want: []gatewayv1.SectionName{"redis"},
+		},
+		{
+			name: "tcp route, udp listener",
+			route: &gatewayv1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+				Spec: gatewayv1alpha2.TCPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{
+						{Name: "test", SectionName: ptr.To(gatewayv1.SectionName("dns"))},
+					}},
+				},
+			},
+		},
+		{
+			name: "udp route, all listeners",
+			route: &gatewayv1alpha2.UDPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+				Spec: gatewayv1alpha2.UDPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "test"}}},
+				},
+			},
+			want: []gatewayv1.SectionName{"dns"},
+		},
+		{
+			name: "other gateway",
+			route: &gatewayv1alpha2.UDPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+				Spec: gatewayv1alpha2.UDPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "other"}}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := wrapL4Route(tt.route)
+			assert.Equal(t, tt.want, routeListeners(gateway, route.GetNamespace(), *route.parentRefs, route.kind))
+		})
+	}
+}
+
+func TestEnsureDownstreamGatewayL4Routes(t *testing.T) {
+	testScheme := newL4TestScheme(t)
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayClassName: "test-suite",
+			TargetDomain:               "test-suite.com",
+		},
+		FeatureGates: map[string]bool{string(features.L4Routes): true},
+	}
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			UID:  uuid.NewUUID(),
+		},
+	}
+	downstreamNamespaceName := fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test", withL4Listeners)
+
+	upstreamEndpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: upstreamNamespace.Name,
+			Name:      "postgres",
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"192.0.2.1"}},
+		},
+		Ports: []discoveryv1.EndpointPort{
+			{Name: ptr.To("postgres"), Port: ptr.To(int32(5432))},
+		},
+	}
+
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: upstreamNamespace.Name,
+			Name:      "postgres",
+			UID:       uuid.NewUUID(),
+		},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{
+					{Name: "test", SectionName: ptr.To(gatewayv1.SectionName("postgres"))},
+				},
+			},
+			Rules: []gatewayv1alpha2.TCPRouteRule{
+				{
+					BackendRefs: []gatewayv1.BackendRef{
+						{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+								Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+								Name:  gatewayv1.ObjectName(upstreamEndpointSlice.Name),
+								Port:  ptr.To(gatewayv1.PortNumber(5432)),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	udpRoute := &gatewayv1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: upstreamNamespace.Name,
+			Name:      "dns",
+			UID:       uuid.NewUUID(),
+		},
+		Spec: gatewayv1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "test"}},
+			},
+			Rules: []gatewayv1alpha2.UDPRouteRule{
+				{
+					BackendRefs: []gatewayv1.BackendRef{
+						{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Name: "coredns",
+								Port: ptr.To(gatewayv1.PortNumber(53)),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(upstreamGateway, upstreamNamespace, upstreamEndpointSlice, tcpRoute, udpRoute).
+		WithStatusSubresource(upstreamGateway, tcpRoute, udpRoute).
+		Build()
+
+	downstreamGateway := newGateway(testConfig, downstreamNamespaceName, upstreamGateway.Name, withL4Listeners)
+
+	// The downstream route has been accepted by the downstream gateway.
+	existingDownstreamTCPRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: downstreamNamespaceName,
+			Name:      tcpRoute.Name,
+		},
+		Status: gatewayv1alpha2.TCPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{
+					{
+						ParentRef:      gatewayv1.ParentReference{Name: gatewayv1.ObjectName(downstreamGateway.Name)},
+						ControllerName: "downstream",
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(gatewayv1.RouteConditionAccepted),
+								Status:             metav1.ConditionTrue,
+								Reason:             string(gatewayv1.RouteReasonAccepted),
+								LastTransitionTime: metav1.Now(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(downstreamGateway, existingDownstreamTCPRoute).
+		WithStatusSubresource(downstreamGateway, existingDownstreamTCPRoute).
+		Build()
+
+	ctx := context.Background()
+
+	reconciler := &GatewayReconciler{
+		mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+		Config:            testConfig,
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+
+	attachedRouteCount := map[gatewayv1.SectionName]int32{}
+	result := reconciler.ensureDownstreamGatewayL4Routes(
+		ctx,
+		fakeUpstreamClient,
+		upstreamGateway,
+		"test",
+		downstreamGateway,
+		downstreamStrategy,
+		attachedRouteCount,
+		nil,
+	)
+	require.NoError(t, result.Err)
+	_, err := result.Complete(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[gatewayv1.SectionName]int32{"postgres": 1, "dns": 1}, attachedRouteCount)
+
+	// EndpointSlice backends are translated into Service backends.
+	resourceName := fmt.Sprintf("route-%s-rule-0-backendref-0", tcpRoute.UID)
+	var downstreamTCPRoute gatewayv1alpha2.TCPRoute
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: downstreamNamespaceName, Name: tcpRoute.Name}, &downstreamTCPRoute))
+	if assert.Len(t, downstreamTCPRoute.Spec.Rules, 1) && assert.Len(t, downstreamTCPRoute.Spec.Rules[0].BackendRefs, 1) {
+		backendRef := downstreamTCPRoute.Spec.Rules[0].BackendRefs[0]
+		assert.Equal(t, KindService, string(ptr.Deref(backendRef.Kind, "")))
+		assert.Equal(t, resourceName, string(backendRef.Name))
+		assert.Equal(t, downstreamNamespaceName, string(ptr.Deref(backendRef.Namespace, "")))
+	}
+
+	var downstreamService corev1.Service
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: downstreamNamespaceName, Name: resourceName}, &downstreamService))
+	if assert.Len(t, downstreamService.Spec.Ports, 1) {
+		assert.EqualValues(t, 5432, downstreamService.Spec.Ports[0].Port)
+	}
+	var downstreamEndpointSlice discoveryv1.EndpointSlice
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: downstreamNamespaceName, Name: resourceName}, &downstreamEndpointSlice))
+	assert.Equal(t, []string{"192.0.2.1"}, downstreamEndpointSlice.Endpoints[0].Addresses)
+
+	var updatedEndpointSlice discoveryv1.EndpointSlice
+	require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(upstreamEndpointSlice), &updatedEndpointSlice))
+	assert.True(t, controllerutil.ContainsFinalizer(&updatedEndpointSlice, gatewayControllerGCFinalizer))
+
+	var downstreamUDPRoute gatewayv1alpha2.UDPRoute
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: downstreamNamespaceName, Name: udpRoute.Name}, &downstreamUDPRoute))
+	if assert.Len(t, downstreamUDPRoute.Spec.Rules, 1) {
+		assert.Equal(t, udpRoute.Spec.Rules[0].BackendRefs, downstreamUDPRoute.Spec.Rules[0].BackendRefs)
+	}
+	if assert.Len(t, downstreamUDPRoute.Spec.ParentRefs, 1) {
+		assert.Equal(t, gatewayv1.ObjectName(downstreamGateway.Name), downstreamUDPRoute.Spec.ParentRefs[0].Name)
+	}
+
+	// The downstream parent status is mirrored to the upstream route.
+	var updatedTCPRoute gatewayv1alpha2.TCPRoute
+	require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(tcpRoute), &updatedTCPRoute))
+	assert.True(t, controllerutil.ContainsFinalizer(&updatedTCPRoute, gatewayControllerGCFinalizer))
+	if assert.Len(t, updatedTCPRoute.Status.Parents, 1) {
+		parent := updatedTCPRoute.Status.Parents[0]
+		assert.Equal(t, gatewayv1.ObjectName(upstreamGateway.Name), parent.ParentRef.Name)
+		assert.True(t, apimeta.IsStatusConditionTrue(parent.Conditions, string(gatewayv1.RouteConditionAccepted)))
+	}
+}
+
+func TestL4RouteGC(t *testing.T) {
+	testScheme := newL4TestScheme(t)
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			UID:  uuid.NewUUID(),
+		},
+	}
+
+	upstreamTCPRouteUID := uuid.NewUUID()
+
+	downstreamEndpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: fmt.Sprintf("ns-%s", upstreamNamespace.UID),
+			Name:      fmt.Sprintf("route-%s-rule-%d-backendref-%d", upstreamTCPRouteUID, 0, 0),
+		},
+	}
+
+	upstreamTCPRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         upstreamNamespace.Name,
+			Name:              "test",
+			UID:               upstreamTCPRouteUID,
+			Finalizers:        []string{gatewayControllerGCFinalizer},
+			DeletionTimestamp: ptr.To(metav1.Now()),
+		},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			Rules: []gatewayv1alpha2.TCPRouteRule{
+				{
+					BackendRefs: []gatewayv1.BackendRef{
+						{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+								Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+								Name:  "postgres",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(upstreamTCPRoute, upstreamNamespace).
+		Build()
+
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(downstreamEndpointSlice).
+		Build()
+
+	ctx := context.Background()
+
+	reconciler := &GatewayDownstreamGCReconciler{
+		mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+
+	_, err := reconciler.Reconcile(ctx, GVKRequest{
+		GVK: gatewayv1alpha2.SchemeGroupVersion.WithKind(KindTCPRoute),
+		Request: mcreconcile.Request{
+			ClusterName: "test",
+			Request: reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(upstreamTCPRoute),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	err = fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(upstreamTCPRoute), &gatewayv1alpha2.TCPRoute{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	err = fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(downstreamEndpointSlice), &discoveryv1.EndpointSlice{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
@@ -0,0 +1,329 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+// GatewayShardLabel is set on the downstream Gateways that hold the listeners of
+// an upstream Gateway beyond the first shard. The value is the name of the
+// upstream Gateway.
+const GatewayShardLabel = "networking.datumapis.com/gateway-shard-of"
+
+const gatewayShardNameInfix = "-shard-"
+
+// gatewayListenerShard is a set of listeners programmed on one downstream
+// Gateway.
+type gatewayListenerShard struct {
+	name      string
+	listeners []gatewayv1.Listener
+}
+
+// shardDownstreamGatewayListeners splits the listeners of a downstream Gateway
+// according to the configured sharding mode. The first shard always keeps the
+// gateway name and is returned even when there are no listeners.
+func shardDownstreamGatewayListeners(
+	cfg config.ListenerShardingConfig,
+	gatewayName string,
+	listeners []gatewayv1.Listener,
+) []gatewayListenerShard {
+	shards := []gatewayListenerShard{{name: gatewayName}}
+
+	switch cfg.Mode {
+	case config.ListenerShardingProtocol:
+		// The first shard holds the protocol of the first listener, which is the
+		// default HTTP listener for most gateways.
+		shardIndex := map[gatewayv1.ProtocolType]int{}
+		for _, l := range listeners {
+			i, ok := shardIndex[l.Protocol]
+			if !ok {
+				i = len(shardIndex)
+				shardIndex[l.Protocol] = i
+				if i > 0 {
+					shards = append(shards, gatewayListenerShard{
+						name: gatewayShardName(gatewayName, strings.ToLower(string(l.Protocol))),
+					})
+				}
+			}
+			shards[i].listeners = append(shards[i].listeners, l)
+		}
+	case config.ListenerShardingCount:
+		for i := 0; i*cfg.MaxListenersPerGateway < len(listeners); i++ {
+			chunk := listeners[i*cfg.MaxListenersPerGateway : min((i+1)*cfg.MaxListenersPerGateway, len(listeners))]
+			if i > 0 {
+				shards = append(shards, gatewayListenerShard{name: gatewayShardName(gatewayName, strconv.Itoa(i))})
+			}
+			shards[i].listeners = chunk
+		}
+	default:
+		shards[0].listeners = listeners
+	}
+
+	return shards
+}
+
+func gatewayShardName(gatewayName, suffix string) string {
+	return resourcename.GetValidDNS1123Name(gatewayName + gatewayShardNameInfix + suffix)
+}
+
+// upstreamGatewayNameFromShard returns the name of the upstream Gateway that a
+// downstream Gateway shard was created for, if the name is a shard name.
+func upstreamGatewayNameFromShard(name string) (string, bool) {
+	i := strings.LastIndex(name, gatewayShardNameInfix)
+	if i <= 0 {
+		return "", false
+	}
+	return name[:i], true
+}
+
+// ensureDownstreamGatewayShards creates or updates the downstream Gateways for
+// all shards except the first, and removes shards that are no longer needed.
+// The returned Gateways include the first shard.
+func (r *GatewayReconciler) ensureDownstreamGatewayShards(
+	ctx context.Context,
+	upstreamGateway *gatewayv1.Gateway,
+	downstreamGateway *gatewayv1.Gateway,
+	desiredDownstreamGateway *gatewayv1.Gateway,
+	shards []gatewayListenerShard,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) ([]*gatewayv1.Gateway, error) {
+	logger := log.FromContext(ctx)
+	downstreamClient := downstreamStrategy.GetClient()
+
+	shardGateways := []*gatewayv1.Gateway{downstreamGateway}
+	desiredNames := sets.New[string]()
+	for _, shard := range shards[1:] {
+		desiredNames.Insert(shard.name)
+
+		shardGateway := &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: downstreamGateway.Namespace,
+				Name:      shard.name,
+			},
+		}
+		_, err := controllerutil.CreateOrUpdate(ctx, downstreamClient, shardGateway, func() error {
+			if err := downstreamStrategy.SetControllerReference(ctx, upstreamGateway, shardGateway); err != nil {
+				return fmt.Errorf("failed to set controller reference on downstream gateway shard: %w", err)
+			}
+			if shardGateway.Labels == nil {
+				shardGateway.Labels = map[string]string{}
+			}
+			shardGateway.Labels[GatewayShardLabel] = upstreamGateway.Name
+			spec := *desiredDownstreamGateway.Spec.DeepCopy()
+			spec.Listeners = shard.listeners
+			annotations, err := desiredDownstreamGatewayAnnotations(desiredDownstreamGateway.Annotations, shardGateway, spec)
+			if err != nil {
+				return err
+			}
+			shardGateway.Annotations = annotations
+			shardGateway.Spec = spec
+			return nil
+		})
+		if err == nil {
+			err = downstreamclient.RecordObservedGeneration(ctx, downstreamClient, shardGateway)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed ensuring downstream gateway shard %q: %w", shard.name, err)
+		}
+		shardGateways = append(shardGateways, shardGateway)
+	}
+
+	existing, err := listDownstreamGatewayShards(ctx, downstreamClient, downstreamGateway.Namespace, upstreamGateway.Name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range existing {
+		if desiredNames.Has(existing[i].Name) {
+			continue
+		}
+		logger.Info("deleting downstream gateway shard", jsonKeyName, existing[i].Name)
+		if err := downstreamClient.Delete(ctx, &existing[i]); client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("failed deleting downstream gateway shard %q: %w", existing[i].Name, err)
+		}
+	}
+
+	return shardGateways, nil
+}
+
+// listDownstreamGatewayShards returns the downstream Gateway shards of an
+// upstream Gateway, excluding the first shard. All shards in the namespace are
+// returned when gatewayName is empty.
+func listDownstreamGatewayShards(
+	ctx context.Context,
+	downstreamClient client.Client,
+	namespace string,
+	gatewayName string,
+) ([]gatewayv1.Gateway, error) {
+	listOpts := []client.ListOption{client.InNamespace(namespace)}
+	if gatewayName != "" {
+		listOpts = append(listOpts, client.MatchingLabels{GatewayShardLabel: gatewayName})
+	} else {
+		listOpts = append(listOpts, client.HasLabels{GatewayShardLabel})
+	}
+
+	var gateways gatewayv1.GatewayList
+	if err := downstreamClient.List(ctx, &gateways, listOpts...); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed listing downstream gateway shards: %w", err)
+	}
+	return gateways.Items, nil
+}
+
+// downstreamHTTPRouteParentRefs maps the parent references of an upstream
+// HTTPRoute onto the downstream Gateway shards. A reference to a listener is
+// pointed at the shard that holds the listener, and a reference to a whole
+// gateway is repeated for every shard of the gateway.
+func downstreamHTTPRouteParentRefs(
+	ctx context.Context,
+	downstreamClient client.Client,
+	downstreamNamespace string,
+	parentRefs []gatewayv1.ParentReference,
+) ([]gatewayv1.ParentReference, error) {
+	shards, err := listDownstreamGatewayShards(ctx, downstreamClient, downstreamNamespace, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(shards) == 0 {
+		return parentRefs, nil
+	}
+
+	downstreamParentRefs := make([]gatewayv1.ParentReference, 0, len(parentRefs))
+	for _, parentRef := range parentRefs {
+		if ptr.Deref(parentRef.Group, gatewayv1.GroupName) != gatewayv1.GroupName ||
+			ptr.Deref(parentRef.Kind, KindGateway) != KindGateway {
+			downstreamParentRefs = append(downstreamParentRefs, parentRef)
+			continue
+		}
+
+		if parentRef.SectionName != nil {
+			for _, shard := range shards {
+				if shard.Labels[GatewayShardLabel] != string(parentRef.Name) {
+					continue
+				}
+				if slices.ContainsFunc(shard.Spec.Listeners, func(l gatewayv1.Listener) bool {
+					return l.Name == *parentRef.SectionName
+				}) {
+					parentRef.Name = gatewayv1.ObjectName(shard.Name)
+					break
+				}
+			}
+			downstreamParentRefs = append(downstreamParentRefs, parentRef)
+			continue
+		}
+
+		downstreamParentRefs = append(downstreamParentRefs, parentRef)
+		for _, shard := range shards {
+			if shard.Labels[GatewayShardLabel] != string(parentRef.Name) {
+				continue
+			}
+			shardParentRef := *parentRef.DeepCopy()
+			shardParentRef.Name = gatewayv1.ObjectName(shard.Name)
+			downstreamParentRefs = append(downstreamParentRefs, shardParentRef)
+		}
+	}
+
+	return downstreamParentRefs, nil
+}
+
+// rollupDownstreamGatewayShards returns a copy of the first shard whose status
+// represents all shards. The Accepted and Programmed conditions are taken from
+// the first shard that is not ready, and only addresses that every shard
+// reports are kept, so DNS never targets an address that is missing some of
+// the gateway's listeners.
+func rollupDownstreamGatewayShards(shardGateways []*gatewayv1.Gateway) *gatewayv1.Gateway {
+	rollup := shardGateways[0].DeepCopy()
+	if len(shardGateways) == 1 {
+		return rollup
+	}
+
+	for _, conditionType := range []gatewayv1.GatewayConditionType{gatewayv1.GatewayConditionAccepted, gatewayv1.GatewayConditionProgrammed} {
+		for _, shard := range shardGateways {
+			c := apimeta.FindStatusCondition(shard.Status.Conditions, string(conditionType))
+			if c == nil {
+				apimeta.RemoveStatusCondition(&rollup.Status.Conditions, string(conditionType))
+				break
+			}
+			if c.Status != metav1.ConditionTrue {
+				apimeta.SetStatusCondition(&rollup.Status.Conditions, *c)
+				break
+			}
+		}
+	}
+
+	var addresses []gatewayv1.GatewayStatusAddress
+	for _, address := range rollup.Status.Addresses {
+		shared := true
+		for _, shard := range shardGateways[1:] {
+			if !slices.ContainsFunc(shard.Status.Addresses, func(a gatewayv1.GatewayStatusAddress) bool {
+				return a.Value == address.Value && ptr.Deref(a.Type, gatewayv1.IPAddressType) == ptr.Deref(address.Type, gatewayv1.IPAddressType)
+			}) {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			addresses = append(addresses, address)
+		}
+	}
+	rollup.Status.Addresses = addresses
+
+	return rollup
+}
+
+// rollupDownstreamRouteParentStatus combines the parent statuses a downstream
+// HTTPRoute has for the shards of a gateway. The route is served by every
+// shard that accepts it, so Accepted and ResolvedRefs are true when any shard
+// reports them as true, and are taken from the first shard otherwise. Other
+// conditions are taken from the first shard that reports them as not true. It
+// returns nil when no shard has reported a status yet.
+func rollupDownstreamRouteParentStatus(parents []gatewayv1.RouteParentStatus, shardNames []string) *gatewayv1.RouteParentStatus {
+	var rollup *gatewayv1.RouteParentStatus
+	for _, parent := range parents {
+		if !slices.Contains(shardNames, string(parent.ParentRef.Name)) {
+			continue
+		}
+		if rollup == nil {
+			rollup = parent.DeepCopy()
+			continue
+		}
+		for _, c := range parent.Conditions {
+			existing := apimeta.FindStatusCondition(rollup.Conditions, c.Type)
+			if existing == nil {
+				apimeta.SetStatusCondition(&rollup.Conditions, c)
+				continue
+			}
+			switch gatewayv1.RouteConditionType(c.Type) {
+			case gatewayv1.RouteConditionAccepted, gatewayv1.RouteConditionResolvedRefs:
+				if existing.Status != metav1.ConditionTrue && c.Status == metav1.ConditionTrue {
+					apimeta.SetStatusCondition(&rollup.Conditions, c)
+				}
+			default:
+				if existing.Status == metav1.ConditionTrue && c.Status != metav1.ConditionTrue {
+					apimeta.SetStatusCondition(&rollup.Conditions, c)
+				}
+			}
+		}
+	}
+	return rollup
+}
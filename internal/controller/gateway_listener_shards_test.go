@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestShardDownstreamGatewayListeners(t *testing.T) {
+	listeners := []gatewayv1.Listener{
+		{Name: "default-http", Protocol: gatewayv1.HTTPProtocolType},
+		{Name: "default-https", Protocol: gatewayv1.HTTPSProtocolType},
+		{Name: "a-http", Protocol: gatewayv1.HTTPProtocolType},
+		{Name: "a-https", Protocol: gatewayv1.HTTPSProtocolType},
+		{Name: "b-http", Protocol: gatewayv1.HTTPProtocolType},
+	}
+
+	shardListenerNames := func(shards []gatewayListenerShard) map[string][]gatewayv1.SectionName {
+		names := map[string][]gatewayv1.SectionName{}
+		for _, shard := range shards {
+			names[shard.name] = []gatewayv1.SectionName{}
+			for _, l := range shard.listeners {
+				names[shard.name] = append(names[shard.name], l.Name)
+			}
+		}
+		return names
+	}
+
+	tests := []struct {
+		name     string
+		cfg      config.ListenerShardingConfig
+		expected map[string][]gatewayv1.SectionName
+	}{
+		{
+			name: "disabled",
+			expected: map[string][]gatewayv1.SectionName{
+				"gw": {"default-http", "default-https", "a-http", "a-https", "b-http"},
+			},
+		},
+		{
+			name: "protocol",
+			cfg:  config.ListenerShardingConfig{Mode: config.ListenerShardingProtocol},
+			expected: map[string][]gatewayv1.SectionName{
+				"gw":             {"default-http", "a-http", "b-http"},
+				"gw-shard-https": {"default-https", "a-https"},
+			},
+		},
+		{
+			name: "count",
+			cfg:  config.ListenerShardingConfig{Mode: config.ListenerShardingCount, MaxListenersPerGateway: 2},
+			expected: map[string][]gatewayv1.SectionName{
+				"gw":         {"default-http", "default-https"},
+				"gw-shard-1": {"a-http", "a-https"},
+				"gw-shard-2": {"b-http"},
+			},
+		},
+		{
+			name: "count fits in one shard",
+			cfg:  config.ListenerShardingConfig{Mode: config.ListenerShardingCount, MaxListenersPerGateway: 10},
+			expected: map[string][]gatewayv1.SectionName{
+				"gw": {"default-http", "default-https", "a-http", "a-https", "b-http"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shards := shardDownstreamGatewayListeners(tt.cfg, "gw", listeners)
+			assert.Equal(t, "gw", shards[0].name)
+			assert.Equal(t, tt.expected, shardListenerNames(shards))
+		})
+	}
+
+	shards := shardDownstreamGatewayListeners(config.ListenerShardingConfig{Mode: config.ListenerShardingProtocol}, "gw", nil)
+	assert.Len(t, shards, 1)
+}
+
+func TestUpstreamGatewayNameFromShard(t *testing.T) {
+	name, ok := upstreamGatewayNameFromShard("my-gateway-shard-https")
+	assert.True(t, ok)
+	assert.Equal(t, "my-gateway", name)
+
+	_, ok = upstreamGatewayNameFromShard("my-gateway")
+	assert.False(t, ok)
+}
+
+func TestDownstreamHTTPRouteParentRefs(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	shard := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-test",
+			Name:      "gw-shard-https",
+			Labels:    map[string]string{GatewayShardLabel: "gw"},
+		},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{{Name: "default-https", Protocol: gatewayv1.HTTPSProtocolType}},
+		},
+	}
+	downstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(shard).Build()
+
+	parentRefs, err := downstreamHTTPRouteParentRefs(context.Background(), downstreamClient, "ns-test", []gatewayv1.ParentReference{
+		{Name: "gw"},
+		{Name: "gw", SectionName: ptr.To(gatewayv1.SectionName("default-https"))},
+		{Name: "gw", SectionName: ptr.To(gatewayv1.SectionName("default-http"))},
+		{Name: "other"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []gatewayv1.ParentReference{
+		{Name: "gw"},
+		{Name: "gw-shard-https"},
+		{Name: "gw-shard-https", SectionName: ptr.To(gatewayv1.SectionName("default-https"))},
+		{Name: "gw", SectionName: ptr.To(gatewayv1.SectionName("default-http"))},
+		{Name: "other"},
+	}, parentRefs)
+}
+
+func TestRollupDownstreamGatewayShards(t *testing.T) {
+	newShard := func(name string, programmed metav1.ConditionStatus, addresses ...string) *gatewayv1.Gateway {
+		gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		gateway.Status.Conditions = []metav1.Condition{
+			{Type: string(gatewayv1.GatewayConditionAccepted), Status: metav1.ConditionTrue, Reason: "Accepted"},
+			{Type: string(gatewayv1.GatewayConditionProgrammed), Status: programmed, Reason: "Programmed"},
+		}
+		for _, address := range addresses {
+			gateway.Status.Addresses = append(gateway.Status.Addresses, gatewayv1.GatewayStatusAddress{
+				Type:  ptr.To(gatewayv1.IPAddressType),
+				Value: address,
+			})
+		}
+		return gateway
+	}
+
+	rollup := rollupDownstreamGatewayShards([]*gatewayv1.Gateway{
+		newShard("gw", metav1.ConditionTrue, "192.0.2.1", "192.0.2.2"),
+		newShard("gw-shard-https", metav1.ConditionFalse, "192.0.2.2"),
+	})
+
+	assert.Equal(t, "gw", rollup.Name)
+	assert.Equal(t, []gatewayv1.GatewayStatusAddress{{Type: ptr.To(gatewayv1.IPAddressType), Value: "192.0.2.2"}}, rollup.Status.Addresses)
+	for _, c := range rollup.Status.Conditions {
+		if c.Type == string(gatewayv1.GatewayConditionProgrammed) {
+			assert.Equal(t, metav1.ConditionFalse, c.Status)
+		} else {
+			assert.Equal(t, metav1.ConditionTrue, c.Status)
+		}
+	}
+}
+
+func TestRollupDownstreamRouteParentStatus(t *testing.T) {
+	parents := []gatewayv1.RouteParentStatus{
+		{
+			ParentRef:  gatewayv1.ParentReference{Name: "gw"},
+			Conditions: []metav1.Condition{{Type: string(gatewayv1.RouteConditionAccepted), Status: metav1.ConditionTrue}},
+		},
+		{
+			ParentRef:  gatewayv1.ParentReference{Name: "other"},
+			Conditions: []metav1.Condition{{Type: string(gatewayv1.RouteConditionAccepted), Status: metav1.ConditionFalse}},
+		},
+		{
+			ParentRef:  gatewayv1.ParentReference{Name: "gw-shard-https"},
+			Conditions: []metav1.Condition{{Type: string(gatewayv1.RouteConditionAccepted), Status: metav1.ConditionFalse, Reason: "NotAllowedByListeners"}},
+		},
+	}
+
+	assert.Nil(t, rollupDownstreamRouteParentStatus(parents, []string{"missing"}))
+
+	rollup := rollupDownstreamRouteParentStatus(parents, []string{"gw"})
+	require.NotNil(t, rollup)
+	assert.Equal(t, metav1.ConditionTrue, rollup.Conditions[0].Status)
+
+	// The route is accepted by the gw shard, so it is served even though the
+	// gw-shard-https shard rejects it.
+	rollup = rollupDownstreamRouteParentStatus(parents, []string{"gw", "gw-shard-https"})
+	require.NotNil(t, rollup)
+	assert.Equal(t, metav1.ConditionTrue, rollup.Conditions[0].Status)
+
+	rollup = rollupDownstreamRouteParentStatus(parents, []string{"other", "gw-shard-https"})
+	require.NotNil(t, rollup)
+	assert.Equal(t, metav1.ConditionFalse, rollup.Conditions[0].Status)
+	assert.Empty(t, rollup.Conditions[0].Reason)
+}
@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// ListenerConditionExcluded is set on listeners that are left out of the
+// downstream gateway, so they are not served.
+const ListenerConditionExcluded = "Excluded"
+
+// ListenerReasonGatewayRejected is the reason of the Excluded condition of
+// valid listeners of a gateway rejected because of its invalid listeners.
+const ListenerReasonGatewayRejected = "GatewayRejected"
+
+// gatewayListenerValidation holds the results of the checks of a gateway's
+// listeners that run before the downstream gateway is programmed.
+type gatewayListenerValidation struct {
+	verifiedHostnames   []string
+	notClaimedHostnames []string
+	blockedHostnames    []string
+	certHealth          map[gatewayv1.SectionName]listenerCertStatus
+	clientValidations   map[gatewayv1.SectionName]listenerClientValidation
+}
+
+// listenerConditions are the status conditions of a listener.
+type listenerConditions struct {
+	accepted     metav1.Condition
+	programmed   metav1.Condition
+	resolvedRefs metav1.Condition
+	conflicted   metav1.Condition
+
+	// invalid is set when the listener cannot be programmed because of its
+	// configuration, rather than because it is waiting on a certificate to be
+	// issued.
+	invalid bool
+}
+
+// conditions returns the conditions of a listener from the checks of the
+// gateway's listeners. A listener that is not Programmed is left out of the
+// downstream gateway.
+func (lv gatewayListenerValidation) conditions(listener gatewayv1.Listener, generation int64) listenerConditions {
+	acceptedCondition := metav1.Condition{
+		Type:               string(gatewayv1.ListenerConditionAccepted),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(gatewayv1.ListenerReasonAccepted),
+		Message:            "The listener has been accepted by the Datum Gateway",
+		ObservedGeneration: generation,
+	}
+
+	programmedCondition := metav1.Condition{
+		Type:               string(gatewayv1.ListenerConditionProgrammed),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(gatewayv1.ListenerReasonProgrammed),
+		Message:            "The listener has been programmed by the Datum Gateway",
+		ObservedGeneration: generation,
+	}
+
+	resolvedRefsCondition := metav1.Condition{
+		Type:               string(gatewayv1.ListenerConditionResolvedRefs),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(gatewayv1.ListenerReasonResolvedRefs),
+		Message:            "The listener has been resolved by the Datum Gateway",
+		ObservedGeneration: generation,
+	}
+
+	conflictedCondition := metav1.Condition{
+		Type:               string(gatewayv1.ListenerConditionConflicted),
+		Status:             metav1.ConditionFalse,
+		Reason:             string(gatewayv1.ListenerReasonNoConflicts),
+		Message:            "The listener does not conflict with other listeners",
+		ObservedGeneration: generation,
+	}
+
+	// A hostname problem is reported instead of a certificate problem, since
+	// the hostname has to be sorted out first.
+	hostnameProblem := false
+	waitingOnCertificate := false
+
+	if listener.Hostname != nil {
+
+		if slices.Contains(lv.blockedHostnames, string(*listener.Hostname)) {
+			hostnameProblem = true
+			acceptedCondition.Status = metav1.ConditionFalse
+			acceptedCondition.Reason = networkingv1alpha.HostnamePolicyViolationReason
+			acceptedCondition.Message = fmt.Sprintf("The hostname %q is not permitted by platform policy.", *listener.Hostname)
+
+			programmedCondition.Status = metav1.ConditionFalse
+			programmedCondition.Reason = acceptedCondition.Reason
+			programmedCondition.Message = acceptedCondition.Message
+		} else if !slices.Contains(lv.verifiedHostnames, string(*listener.Hostname)) {
+			hostnameProblem = true
+			acceptedCondition.Status = metav1.ConditionFalse
+			acceptedCondition.Reason = networkingv1alpha.UnverifiedHostnamesPresent
+			acceptedCondition.Message = fmt.Sprintf("The hostname %q has not been verified. Check status of Domains in the same namespace.", *listener.Hostname)
+
+			programmedCondition.Status = metav1.ConditionFalse
+			programmedCondition.Reason = acceptedCondition.Reason
+			programmedCondition.Message = acceptedCondition.Message
+		} else if slices.Contains(lv.notClaimedHostnames, string(*listener.Hostname)) {
+			hostnameProblem = true
+			acceptedCondition.Status = metav1.ConditionFalse
+			acceptedCondition.Reason = networkingv1alpha.HostnameInUseReason
+			acceptedCondition.Message = fmt.Sprintf("The hostname %q is already attached to a resource.", *listener.Hostname)
+
+			programmedCondition.Status = metav1.ConditionFalse
+			programmedCondition.Reason = acceptedCondition.Reason
+			programmedCondition.Message = acceptedCondition.Message
+
+			// The gateway that claimed the hostname first keeps it, so that
+			// requests for the hostname are routed deterministically.
+			conflictedCondition.Status = metav1.ConditionTrue
+			conflictedCondition.Reason = string(gatewayv1.ListenerReasonHostnameConflict)
+			conflictedCondition.Message = fmt.Sprintf("The hostname %q is claimed by a listener of another Gateway or HTTPProxy.", *listener.Hostname)
+		}
+	}
+
+	// Tell the customer when a listener's certificate is the reason HTTPS
+	// isn't running. The listener stays accepted because the configuration
+	// is fine; only the certificate needs attention.
+	if !hostnameProblem {
+		if certStatus, gated := lv.certHealth[listener.Name]; gated && !certStatus.healthy {
+			waitingOnCertificate = certStatus.pending
+
+			resolvedRefsCondition.Status = metav1.ConditionFalse
+			resolvedRefsCondition.Reason = string(gatewayv1.ListenerReasonInvalidCertificateRef)
+			resolvedRefsCondition.Message = certStatus.message
+
+			programmedCondition.Status = metav1.ConditionFalse
+			programmedCondition.Reason = string(gatewayv1.ListenerReasonInvalid)
+			programmedCondition.Message = certStatus.message
+		}
+
+		if v, ok := lv.clientValidations[listener.Name]; ok && !v.valid() {
+			waitingOnCertificate = false
+
+			resolvedRefsCondition.Status = metav1.ConditionFalse
+			resolvedRefsCondition.Reason = string(v.reason)
+			resolvedRefsCondition.Message = v.message
+
+			acceptedCondition.Status = metav1.ConditionFalse
+			acceptedCondition.Reason = string(gatewayv1.ListenerReasonNoValidCACertificate)
+			acceptedCondition.Message = v.message
+
+			programmedCondition.Status = metav1.ConditionFalse
+			programmedCondition.Reason = string(gatewayv1.ListenerReasonInvalid)
+			programmedCondition.Message = v.message
+		}
+	}
+
+	return listenerConditions{
+		accepted:     acceptedCondition,
+		programmed:   programmedCondition,
+		resolvedRefs: resolvedRefsCondition,
+		conflicted:   conflictedCondition,
+		invalid:      programmedCondition.Status == metav1.ConditionFalse && !waitingOnCertificate,
+	}
+}
+
+// invalidListeners returns the names of the listeners of the gateway that
+// cannot be programmed because of their configuration.
+func (lv gatewayListenerValidation) invalidListeners(gateway *gatewayv1.Gateway) []gatewayv1.SectionName {
+	var invalid []gatewayv1.SectionName
+	for _, listener := range gateway.Spec.Listeners {
+		if lv.conditions(listener, gateway.Generation).invalid {
+			invalid = append(invalid, listener.Name)
+		}
+	}
+	return invalid
+}
+
+// listenersNotValidMessage describes the invalid listeners of a gateway.
+func listenersNotValidMessage(invalidListeners []gatewayv1.SectionName) string {
+	names := make([]string, 0, len(invalidListeners))
+	for _, name := range invalidListeners {
+		names = append(names, string(name))
+	}
+	return fmt.Sprintf("%d listener(s) are not valid: %s", len(names), strings.Join(names, ", "))
+}
+
+// setListenerExcludedCondition sets the Excluded condition of a listener that
+// is left out of the downstream gateway, as its Programmed condition is not
+// true, and removes it from listeners that are programmed downstream.
+func setListenerExcludedCondition(status *gatewayv1.ListenerStatus, programmedCondition metav1.Condition) {
+	if programmedCondition.Status == metav1.ConditionTrue {
+		apimeta.RemoveStatusCondition(&status.Conditions, ListenerConditionExcluded)
+		return
+	}
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ListenerConditionExcluded,
+		Status:             metav1.ConditionTrue,
+		Reason:             programmedCondition.Reason,
+		Message:            programmedCondition.Message,
+		ObservedGeneration: programmedCondition.ObservedGeneration,
+	})
+}
+
+// downstreamListenerConditions returns the conditions the downstream gateway
+// shards report for each of their listeners. Conditions observed for an
+// earlier generation of a shard are left out, as they describe listeners that
+// have since been changed.
+func downstreamListenerConditions(shardGateways []*gatewayv1.Gateway) map[gatewayv1.SectionName][]metav1.Condition {
+	conditions := map[gatewayv1.SectionName][]metav1.Condition{}
+	for _, shard := range shardGateways {
+		for _, listener := range shard.Status.Listeners {
+			current := make([]metav1.Condition, 0, len(listener.Conditions))
+			for _, c := range listener.Conditions {
+				if c.ObservedGeneration >= shard.Generation {
+					current = append(current, c)
+				}
+			}
+			conditions[listener.Name] = current
+		}
+	}
+	return conditions
+}
+
+// applyDownstreamListenerConditions sets the Programmed and ResolvedRefs
+// conditions of an upstream listener from the conditions its downstream
+// listener reports. A listener the downstream gateway has not reported as
+// programmed yet is pending.
+//
+// Reasons are taken from the downstream listener, while messages are not, as
+// those refer to downstream resources the user does not know about.
+func applyDownstreamListenerConditions(
+	programmedCondition *metav1.Condition,
+	resolvedRefsCondition *metav1.Condition,
+	downstreamConditions []metav1.Condition,
+) {
+	if c := apimeta.FindStatusCondition(downstreamConditions, string(gatewayv1.ListenerConditionResolvedRefs)); c != nil && c.Status == metav1.ConditionFalse {
+		resolvedRefsCondition.Status = metav1.ConditionFalse
+		resolvedRefsCondition.Reason = c.Reason
+		resolvedRefsCondition.Message = "The listener references could not be resolved by the Datum Gateway"
+	}
+
+	c := apimeta.FindStatusCondition(downstreamConditions, string(gatewayv1.ListenerConditionProgrammed))
+	switch {
+	case c == nil || c.Status == metav1.ConditionUnknown:
+		programmedCondition.Status = metav1.ConditionFalse
+		programmedCondition.Reason = string(gatewayv1.ListenerReasonPending)
+		programmedCondition.Message = "The listener is waiting to be programmed by the Datum Gateway"
+	case c.Status == metav1.ConditionFalse:
+		programmedCondition.Status = metav1.ConditionFalse
+		programmedCondition.Reason = c.Reason
+		programmedCondition.Message = "The listener could not be programmed by the Datum Gateway"
+	}
+}
+
+// rejectGateway reports a gateway as not accepted because of its invalid
+// listeners, without programming the downstream gateway. Invalid listeners
+// report their problems, and valid listeners that have not been reported yet
+// are reported as not programmed. Valid listeners keep their status otherwise,
+// as the downstream gateway keeps serving them as last programmed.
+func (r *GatewayReconciler) rejectGateway(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	listenerValidation gatewayListenerValidation,
+	invalidListeners []gatewayv1.SectionName,
+) (result Result) {
+	log.FromContext(ctx).Info("rejecting gateway with invalid listeners", "listeners", invalidListeners)
+
+	currentListenerStatus := map[gatewayv1.SectionName]gatewayv1.ListenerStatus{}
+	for _, listener := range upstreamGateway.Status.Listeners {
+		currentListenerStatus[listener.Name] = *listener.DeepCopy()
+	}
+
+	listenerStatus := make([]gatewayv1.ListenerStatus, 0, len(upstreamGateway.Spec.Listeners))
+	for _, listener := range upstreamGateway.Spec.Listeners {
+		status, reported := currentListenerStatus[listener.Name]
+		if !reported {
+			status = gatewayv1.ListenerStatus{Name: listener.Name}
+		}
+		status.SupportedKinds = r.listenerSupportedKinds(listener.Protocol)
+
+		conditions := listenerValidation.conditions(listener, upstreamGateway.Generation)
+		if !conditions.invalid && reported {
+			listenerStatus = append(listenerStatus, status)
+			continue
+		}
+		if !conditions.invalid {
+			conditions.programmed.Status = metav1.ConditionFalse
+			conditions.programmed.Reason = ListenerReasonGatewayRejected
+			conditions.programmed.Message = "The listener is not programmed while the Gateway has invalid listeners"
+		}
+
+		apimeta.SetStatusCondition(&status.Conditions, conditions.accepted)
+		apimeta.SetStatusCondition(&status.Conditions, conditions.programmed)
+		apimeta.SetStatusCondition(&status.Conditions, conditions.resolvedRefs)
+		apimeta.SetStatusCondition(&status.Conditions, conditions.conflicted)
+		setListenerExcludedCondition(&status, conditions.programmed)
+		listenerStatus = append(listenerStatus, status)
+	}
+	upstreamGateway.Status.Listeners = listenerStatus
+
+	apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, metav1.Condition{
+		Type:               string(gatewayv1.GatewayConditionAccepted),
+		Status:             metav1.ConditionFalse,
+		Reason:             string(gatewayv1.GatewayReasonListenersNotValid),
+		Message:            "The Gateway has been rejected because of its invalid listeners. " + listenersNotValidMessage(invalidListeners),
+		ObservedGeneration: upstreamGateway.Generation,
+	})
+	result.AddStatusUpdate(upstreamClient, upstreamGateway)
+
+	return result
+}
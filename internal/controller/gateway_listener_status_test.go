@@ -0,0 +1,246 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestDownstreamListenerConditions(t *testing.T) {
+	programmed := func(generation int64) metav1.Condition {
+		return metav1.Condition{
+			Type:               string(gatewayv1.ListenerConditionProgrammed),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+		}
+	}
+
+	conditions := downstreamListenerConditions([]*gatewayv1.Gateway{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw", Generation: 2},
+			Status: gatewayv1.GatewayStatus{Listeners: []gatewayv1.ListenerStatus{
+				{Name: "http", Conditions: []metav1.Condition{programmed(2)}},
+				{Name: "stale", Conditions: []metav1.Condition{programmed(1)}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw-shard-https", Generation: 1},
+			Status: gatewayv1.GatewayStatus{Listeners: []gatewayv1.ListenerStatus{
+				{Name: "https", Conditions: []metav1.Condition{programmed(1)}},
+			}},
+		},
+	})
+
+	assert.Equal(t, map[gatewayv1.SectionName][]metav1.Condition{
+		"http":  {programmed(2)},
+		"stale": {},
+		"https": {programmed(1)},
+	}, conditions)
+}
+
+func TestApplyDownstreamListenerConditions(t *testing.T) {
+	tests := map[string]struct {
+		downstreamConditions []metav1.Condition
+		wantProgrammed       metav1.ConditionStatus
+		wantProgrammedReason string
+		wantResolvedRefs     metav1.ConditionStatus
+		wantResolvedReason   string
+	}{
+		"not reported": {
+			wantProgrammed:       metav1.ConditionFalse,
+			wantProgrammedReason: string(gatewayv1.ListenerReasonPending),
+			wantResolvedRefs:     metav1.ConditionTrue,
+			wantResolvedReason:   string(gatewayv1.ListenerReasonResolvedRefs),
+		},
+		"programmed": {
+			downstreamConditions: []metav1.Condition{
+				{Type: string(gatewayv1.ListenerConditionProgrammed), Status: metav1.ConditionTrue, Reason: string(gatewayv1.ListenerReasonProgrammed)},
+				{Type: string(gatewayv1.ListenerConditionResolvedRefs), Status: metav1.ConditionTrue, Reason: string(gatewayv1.ListenerReasonResolvedRefs)},
+			},
+			wantProgrammed:       metav1.ConditionTrue,
+			wantProgrammedReason: string(gatewayv1.ListenerReasonProgrammed),
+			wantResolvedRefs:     metav1.ConditionTrue,
+			wantResolvedReason:   string(gatewayv1.ListenerReasonResolvedRefs),
+		},
+		"certificate ref not resolved": {
+			downstreamConditions: []metav1.Condition{
+				{Type: string(gatewayv1.ListenerConditionProgrammed), Status: metav1.ConditionFalse, Reason: string(gatewayv1.ListenerReasonInvalid)},
+				{Type: string(gatewayv1.ListenerConditionResolvedRefs), Status: metav1.ConditionFalse, Reason: string(gatewayv1.ListenerReasonInvalidCertificateRef)},
+			},
+			wantProgrammed:       metav1.ConditionFalse,
+			wantProgrammedReason: string(gatewayv1.ListenerReasonInvalid),
+			wantResolvedRefs:     metav1.ConditionFalse,
+			wantResolvedReason:   string(gatewayv1.ListenerReasonInvalidCertificateRef),
+		},
+		"programming unknown": {
+			downstreamConditions: []metav1.Condition{
+				{Type: string(gatewayv1.ListenerConditionProgrammed), Status: metav1.ConditionUnknown, Reason: string(gatewayv1.ListenerReasonPending)},
+			},
+			wantProgrammed:       metav1.ConditionFalse,
+			wantProgrammedReason: string(gatewayv1.ListenerReasonPending),
+			wantResolvedRefs:     metav1.ConditionTrue,
+			wantResolvedReason:   string(gatewayv1.ListenerReasonResolvedRefs),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			programmedCondition := metav1.Condition{
+				Type:   string(gatewayv1.ListenerConditionProgrammed),
+				Status: metav1.ConditionTrue,
+				Reason: string(gatewayv1.ListenerReasonProgrammed),
+			}
+			resolvedRefsCondition := metav1.Condition{
+				Type:   string(gatewayv1.ListenerConditionResolvedRefs),
+				Status: metav1.ConditionTrue,
+				Reason: string(gatewayv1.ListenerReasonResolvedRefs),
+			}
+
+			applyDownstreamListenerConditions(&programmedCondition, &resolvedRefsCondition, tt.downstreamConditions)
+
+			assert.Equal(t, tt.wantProgrammed, programmedCondition.Status)
+			assert.Equal(t, tt.wantProgrammedReason, programmedCondition.Reason)
+			assert.Equal(t, tt.wantResolvedRefs, resolvedRefsCondition.Status)
+			assert.Equal(t, tt.wantResolvedReason, resolvedRefsCondition.Reason)
+		})
+	}
+}
+
+func TestGatewayListenerValidationInvalidListeners(t *testing.T) {
+	listener := func(name, hostname string) gatewayv1.Listener {
+		return gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(name),
+			Protocol: gatewayv1.HTTPSProtocolType,
+			Hostname: ptr.To(gatewayv1.Hostname(hostname)),
+		}
+	}
+	gateway := &gatewayv1.Gateway{Spec: gatewayv1.GatewaySpec{Listeners: []gatewayv1.Listener{
+		listener("valid", "valid.example.com"),
+		listener("unverified", "unverified.example.com"),
+		listener("issuing", "issuing.example.com"),
+		listener("expired", "expired.example.com"),
+	}}}
+
+	validation := gatewayListenerValidation{
+		verifiedHostnames: []string{"valid.example.com", "issuing.example.com", "expired.example.com"},
+		certHealth: map[gatewayv1.SectionName]listenerCertStatus{
+			"valid":   {healthy: true},
+			"issuing": {pending: true, message: "issuing"},
+			"expired": {message: "expired"},
+		},
+	}
+
+	assert.Equal(t, []gatewayv1.SectionName{"unverified", "expired"}, validation.invalidListeners(gateway))
+
+	conditions := validation.conditions(gateway.Spec.Listeners[1], gateway.Generation)
+	assert.Equal(t, metav1.ConditionFalse, conditions.accepted.Status)
+	assert.Equal(t, networkingv1alpha.UnverifiedHostnamesPresent, conditions.programmed.Reason)
+}
+
+func TestRejectGateway(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	programmed := metav1.Condition{
+		Type:               string(gatewayv1.ListenerConditionProgrammed),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(gatewayv1.ListenerReasonProgrammed),
+		LastTransitionTime: metav1.Now(),
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+		Spec: gatewayv1.GatewaySpec{Listeners: []gatewayv1.Listener{
+			{Name: "served", Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("served.example.com"))},
+			{Name: "added", Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("added.example.com"))},
+			{Name: "unverified", Protocol: gatewayv1.HTTPProtocolType, Hostname: ptr.To(gatewayv1.Hostname("unverified.example.com"))},
+		}},
+		Status: gatewayv1.GatewayStatus{Listeners: []gatewayv1.ListenerStatus{
+			{Name: "served", Conditions: []metav1.Condition{programmed}},
+		}},
+	}
+	upstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(gateway).
+		WithStatusSubresource(gateway).
+		Build()
+
+	reconciler := &GatewayReconciler{}
+	validation := gatewayListenerValidation{
+		verifiedHostnames: []string{"served.example.com", "added.example.com"},
+	}
+	invalidListeners := validation.invalidListeners(gateway)
+	require.Equal(t, []gatewayv1.SectionName{"unverified"}, invalidListeners)
+
+	result := reconciler.rejectGateway(ctx, upstreamClient, gateway, validation, invalidListeners)
+	_, err := result.Complete(ctx)
+	require.NoError(t, err)
+
+	var got gatewayv1.Gateway
+	require.NoError(t, upstreamClient.Get(ctx, client.ObjectKeyFromObject(gateway), &got))
+
+	accepted := apimeta.FindStatusCondition(got.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+	assert.Equal(t, string(gatewayv1.GatewayReasonListenersNotValid), accepted.Reason)
+	assert.Contains(t, accepted.Message, "unverified")
+
+	listeners := map[gatewayv1.SectionName]gatewayv1.ListenerStatus{}
+	for _, listener := range got.Status.Listeners {
+		listeners[listener.Name] = listener
+	}
+	require.Len(t, listeners, 3)
+
+	// The downstream gateway keeps serving listeners as last programmed.
+	assert.True(t, apimeta.IsStatusConditionTrue(listeners["served"].Conditions, string(gatewayv1.ListenerConditionProgrammed)))
+	assert.Nil(t, apimeta.FindStatusCondition(listeners["served"].Conditions, ListenerConditionExcluded))
+
+	added := apimeta.FindStatusCondition(listeners["added"].Conditions, ListenerConditionExcluded)
+	require.NotNil(t, added)
+	assert.Equal(t, ListenerReasonGatewayRejected, added.Reason)
+
+	unverified := apimeta.FindStatusCondition(listeners["unverified"].Conditions, ListenerConditionExcluded)
+	require.NotNil(t, unverified)
+	assert.Equal(t, networkingv1alpha.UnverifiedHostnamesPresent, unverified.Reason)
+}
+
+func TestReconcileGatewayStatusListenersNotValid(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"}}
+	upstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(gateway).
+		WithStatusSubresource(gateway).
+		Build()
+
+	downstreamGateway := &gatewayv1.Gateway{Status: gatewayv1.GatewayStatus{Conditions: []metav1.Condition{
+		{Type: string(gatewayv1.GatewayConditionAccepted), Status: metav1.ConditionTrue, Reason: string(gatewayv1.GatewayReasonAccepted)},
+		{Type: string(gatewayv1.GatewayConditionProgrammed), Status: metav1.ConditionTrue, Reason: string(gatewayv1.GatewayReasonProgrammed)},
+	}}}
+
+	reconciler := &GatewayReconciler{}
+	result := reconciler.reconcileGatewayStatus(ctx, upstreamClient, gateway, downstreamGateway, []gatewayv1.SectionName{"unverified"})
+	require.NoError(t, result.Err)
+
+	accepted := apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+	assert.Equal(t, string(gatewayv1.GatewayReasonListenersNotValid), accepted.Reason)
+	assert.Contains(t, accepted.Message, "unverified")
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"fmt"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// GatewayPausedAnnotation pauses an upstream Gateway when set to "true". The
+// rules of the downstream HTTPRoutes attached to a paused gateway are swapped
+// to a direct response with the maintenance response of the platform, while
+// the resources of their backends are kept programmed. HTTPProxies set it from
+// their spec.paused field.
+const GatewayPausedAnnotation = "networking.datumapis.com/paused"
+
+// GatewayConditionPaused is set on paused upstream Gateways.
+const GatewayConditionPaused = "Paused"
+
+const GatewayReasonPaused = "Paused"
+
+// gatewayPaused returns whether an upstream gateway is paused.
+func gatewayPaused(gateway *gatewayv1.Gateway) bool {
+	return gateway.Annotations[GatewayPausedAnnotation] == "true"
+}
+
+// downstreamMaintenanceFilterName returns the name of the HTTPRouteFilter that
+// serves the maintenance response for a downstream HTTPRoute.
+func downstreamMaintenanceFilterName(upstreamRouteUID types.UID) string {
+	return fmt.Sprintf("route-%s-maintenance", upstreamRouteUID)
+}
+
+// desiredDownstreamMaintenanceFilter returns the HTTPRouteFilter that answers
+// requests with the maintenance response configured for the platform.
+func desiredDownstreamMaintenanceFilter(namespace, name string, maintenance config.GatewayMaintenanceConfig) *envoygatewayv1alpha1.HTTPRouteFilter {
+	return &envoygatewayv1alpha1.HTTPRouteFilter{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: envoygatewayv1alpha1.HTTPRouteFilterSpec{
+			DirectResponse: &envoygatewayv1alpha1.HTTPDirectResponseFilter{
+				ContentType: ptr.To(maintenance.ContentType),
+				StatusCode:  ptr.To(maintenance.StatusCode),
+				Body: &envoygatewayv1alpha1.CustomResponseBody{
+					Type:   ptr.To(envoygatewayv1alpha1.ResponseValueTypeInline),
+					Inline: ptr.To(maintenance.Body),
+				},
+			},
+		},
+	}
+}
+
+// maintenanceHTTPRouteRules returns the rules of a downstream HTTPRoute with
+// their filters and backends replaced by the maintenance filter. The matches
+// of the rules are kept, so the route keeps matching the same requests.
+func maintenanceHTTPRouteRules(rules []gatewayv1.HTTPRouteRule, filterName string) []gatewayv1.HTTPRouteRule {
+	maintenanceRules := make([]gatewayv1.HTTPRouteRule, 0, len(rules))
+	for _, rule := range rules {
+		maintenanceRules = append(maintenanceRules, gatewayv1.HTTPRouteRule{
+			Name:    rule.Name,
+			Matches: rule.Matches,
+			Filters: []gatewayv1.HTTPRouteFilter{
+				{
+					Type: gatewayv1.HTTPRouteFilterExtensionRef,
+					ExtensionRef: &gatewayv1.LocalObjectReference{
+						Group: envoygatewayv1alpha1.GroupName,
+						Kind:  envoygatewayv1alpha1.KindHTTPRouteFilter,
+						Name:  gatewayv1.ObjectName(filterName),
+					},
+				},
+			},
+		})
+	}
+	return maintenanceRules
+}
+
+// httpRouteReferencesFilter returns whether a rule of an HTTPRoute references
+// the named HTTPRouteFilter.
+func httpRouteReferencesFilter(route *gatewayv1.HTTPRoute, filterName string) bool {
+	for _, rule := range route.Spec.Rules {
+		for _, filter := range rule.Filters {
+			if filter.ExtensionRef != nil &&
+				filter.ExtensionRef.Kind == envoygatewayv1alpha1.KindHTTPRouteFilter &&
+				string(filter.ExtensionRef.Name) == filterName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reconcilePausedStatus sets the Paused condition on the upstream gateway. The
+// condition is removed when the gateway is not paused.
+func (r *GatewayReconciler) reconcilePausedStatus(upstreamClient client.Client, upstreamGateway *gatewayv1.Gateway) (result Result) {
+	if !gatewayPaused(upstreamGateway) {
+		if apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, GatewayConditionPaused) == nil {
+			return result
+		}
+		apimeta.RemoveStatusCondition(&upstreamGateway.Status.Conditions, GatewayConditionPaused)
+		result.AddStatusUpdate(upstreamClient, upstreamGateway)
+		return result
+	}
+
+	condition := metav1.Condition{
+		Type:               GatewayConditionPaused,
+		Status:             metav1.ConditionTrue,
+		Reason:             GatewayReasonPaused,
+		Message:            fmt.Sprintf("HTTP requests to the gateway are answered with a %d maintenance response", r.Config.Gateway.Maintenance.StatusCode),
+		ObservedGeneration: upstreamGateway.Generation,
+	}
+	if !apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, condition) {
+		return result
+	}
+	result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	return result
+}
+
+// httpProxyPausedCondition returns the Paused condition of an HTTPProxy from
+// the Paused condition of its gateway, or nil when the proxy is not paused.
+func httpProxyPausedCondition(httpProxy *networkingv1alpha.HTTPProxy, gateway *gatewayv1.Gateway) *metav1.Condition {
+	if !httpProxy.Spec.Paused {
+		return nil
+	}
+
+	condition := &metav1.Condition{
+		Type:               networkingv1alpha.HTTPProxyConditionPaused,
+		Status:             metav1.ConditionFalse,
+		Reason:             networkingv1alpha.HTTPProxyReasonPending,
+		Message:            "Waiting for the gateway to be paused",
+		ObservedGeneration: httpProxy.Generation,
+	}
+	if gateway != nil && gatewayPaused(gateway) &&
+		apimeta.IsStatusConditionTrue(gateway.Status.Conditions, GatewayConditionPaused) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = networkingv1alpha.HTTPProxyReasonPaused
+		condition.Message = "Requests are answered with the maintenance response"
+	}
+	return condition
+}
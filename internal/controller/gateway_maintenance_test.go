@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestEnsureDownstreamHTTPRoutePaused(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayClassName: "test-suite",
+			TargetDomain:               "test-suite.com",
+			Maintenance: config.GatewayMaintenanceConfig{
+				StatusCode:  503,
+				ContentType: "text/plain",
+				Body:        "down for maintenance",
+			},
+		},
+	}
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()},
+	}
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   upstreamNamespace.Name,
+			Name:        "route-0-0",
+			Annotations: map[string]string{BackendCertHostnameAnnotation: "api.example.com"},
+		},
+		AddressType: discoveryv1.AddressTypeFQDN,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"api.example.com"}}},
+		Ports: []discoveryv1.EndpointPort{{
+			Name:        ptr.To("route-0-0"),
+			AppProtocol: ptr.To(SchemeHTTP),
+			Port:        ptr.To(int32(DefaultHTTPPort)),
+		}},
+	}
+	upstreamRoute := newHTTPRoute(upstreamNamespace.Name, "route", func(route *gatewayv1.HTTPRoute) {
+		route.UID = uuid.NewUUID()
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{{
+			Matches: []gatewayv1.HTTPRouteMatch{{
+				Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/")},
+			}},
+			BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Group: ptr.To(gatewayv1.Group("discovery.k8s.io")),
+					Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+					Name:  gatewayv1.ObjectName(endpointSlice.Name),
+					Port:  ptr.To(gatewayv1.PortNumber(DefaultHTTPPort)),
+				},
+			}}},
+		}}
+	})
+
+	upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test", func(gateway *gatewayv1.Gateway) {
+		gateway.Annotations = map[string]string{GatewayPausedAnnotation: "true"}
+	})
+	downstreamGateway := newGateway(testConfig, fmt.Sprintf("ns-%s", upstreamNamespace.UID), "test")
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(upstreamNamespace, endpointSlice, upstreamRoute, upstreamGateway).
+		WithStatusSubresource(upstreamRoute).
+		Build()
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(downstreamGateway).
+		Build()
+
+	reconciler := &GatewayReconciler{
+		Config:            testConfig,
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+
+	ensureRoute := func() *gatewayv1.HTTPRoute {
+		t.Helper()
+		result := reconciler.ensureDownstreamHTTPRoute(
+			context.Background(),
+			fakeUpstreamClient,
+			upstreamGateway,
+			"test-suite",
+			downstreamGateway,
+			downstreamStrategy,
+			*upstreamRoute,
+			"",
+			nil,
+		)
+		require.NoError(t, result.Err)
+
+		var downstreamRoute gatewayv1.HTTPRoute
+		require.NoError(t, fakeDownstreamClient.Get(context.Background(), client.ObjectKey{
+			Namespace: downstreamGateway.Namespace,
+			Name:      upstreamRoute.Name,
+		}, &downstreamRoute))
+		return &downstreamRoute
+	}
+
+	filterKey := client.ObjectKey{
+		Namespace: downstreamGateway.Namespace,
+		Name:      downstreamMaintenanceFilterName(upstreamRoute.UID),
+	}
+
+	downstreamRoute := ensureRoute()
+	require.Len(t, downstreamRoute.Spec.Rules, 1)
+	assert.Empty(t, downstreamRoute.Spec.Rules[0].BackendRefs, "paused rules have no backends")
+	assert.Equal(t, upstreamRoute.Spec.Rules[0].Matches, downstreamRoute.Spec.Rules[0].Matches)
+	assert.True(t, httpRouteReferencesFilter(downstreamRoute, filterKey.Name))
+
+	var filter envoygatewayv1alpha1.HTTPRouteFilter
+	require.NoError(t, fakeDownstreamClient.Get(context.Background(), filterKey, &filter))
+	if assert.NotNil(t, filter.Spec.DirectResponse) {
+		assert.Equal(t, ptr.To(503), filter.Spec.DirectResponse.StatusCode)
+		assert.Equal(t, ptr.To("down for maintenance"), filter.Spec.DirectResponse.Body.Inline)
+	}
+
+	var backends envoygatewayv1alpha1.BackendList
+	require.NoError(t, fakeDownstreamClient.List(context.Background(), &backends, client.InNamespace(downstreamGateway.Namespace)))
+	assert.Len(t, backends.Items, 1, "backend resources are kept while paused")
+
+	delete(upstreamGateway.Annotations, GatewayPausedAnnotation)
+	downstreamRoute = ensureRoute()
+	require.Len(t, downstreamRoute.Spec.Rules, 1)
+	assert.Len(t, downstreamRoute.Spec.Rules[0].BackendRefs, 1, "backends are restored when unpaused")
+	assert.False(t, httpRouteReferencesFilter(downstreamRoute, filterKey.Name))
+
+	err := fakeDownstreamClient.Get(context.Background(), filterKey, &filter)
+	assert.True(t, apierrors.IsNotFound(err), "maintenance filter is removed when unpaused, got %v", err)
+}
+
+func TestReconcilePausedStatus(t *testing.T) {
+	gateway := newGateway(config.NetworkServicesOperator{}, "test", "test", func(gateway *gatewayv1.Gateway) {
+		gateway.Annotations = map[string]string{GatewayPausedAnnotation: "true"}
+	})
+
+	reconciler := &GatewayReconciler{}
+	reconciler.Config.Gateway.Maintenance.StatusCode = 503
+
+	result := reconciler.reconcilePausedStatus(nil, gateway)
+	assert.Len(t, result.syncStatus, 1)
+	assert.True(t, apimeta.IsStatusConditionTrue(gateway.Status.Conditions, GatewayConditionPaused))
+
+	delete(gateway.Annotations, GatewayPausedAnnotation)
+	result = reconciler.reconcilePausedStatus(nil, gateway)
+	assert.Len(t, result.syncStatus, 1)
+	assert.Nil(t, apimeta.FindStatusCondition(gateway.Status.Conditions, GatewayConditionPaused))
+
+	result = reconciler.reconcilePausedStatus(nil, gateway)
+	assert.Empty(t, result.syncStatus, "no update when the condition is already removed")
+}
+
+func TestHTTPProxyPausedCondition(t *testing.T) {
+	httpProxy := &networkingv1alpha.HTTPProxy{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	gateway := &gatewayv1.Gateway{}
+
+	assert.Nil(t, httpProxyPausedCondition(httpProxy, gateway), "no condition when not paused")
+
+	httpProxy.Spec.Paused = true
+	condition := httpProxyPausedCondition(httpProxy, gateway)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, networkingv1alpha.HTTPProxyReasonPending, condition.Reason)
+
+	gateway.Annotations = map[string]string{GatewayPausedAnnotation: "true"}
+	gateway.Status.Conditions = []metav1.Condition{{Type: GatewayConditionPaused, Status: metav1.ConditionTrue, Reason: GatewayReasonPaused}}
+	condition = httpProxyPausedCondition(httpProxy, gateway)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, networkingv1alpha.HTTPProxyReasonPaused, condition.Reason)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+}
+
+func TestHTTPProxyCollectDesiredResourcesPaused(t *testing.T) {
+	reconciler := &HTTPProxyReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway:   config.GatewayConfig{TargetDomain: "example.com"},
+			HTTPProxy: config.HTTPProxyConfig{GatewayClassName: "test"},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+	desiredResources, err := reconciler.collectDesiredResources(context.Background(), "", cl, newHTTPProxy())
+	require.NoError(t, err)
+	assert.NotContains(t, desiredResources.gateway.Annotations, GatewayPausedAnnotation)
+
+	httpProxy := newHTTPProxy(func(p *networkingv1alpha.HTTPProxy) { p.Spec.Paused = true })
+	desiredResources, err = reconciler.collectDesiredResources(context.Background(), "", cl, httpProxy)
+	require.NoError(t, err)
+	assert.Equal(t, "true", desiredResources.gateway.Annotations[GatewayPausedAnnotation])
+}
@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/yaml"
+
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
+)
+
+// manifestExportConfigMapKey is the ConfigMap data key holding the exported
+// manifests.
+const manifestExportConfigMapKey = "manifests.yaml"
+
+// GatewayConditionManifestExported is set on upstream Gateways that request a
+// manifest export, and reports whether the export is up to date.
+const GatewayConditionManifestExported = "ManifestExported"
+
+const (
+	GatewayReasonManifestExported = "Exported"
+	GatewayReasonRouteConflict    = "RouteConflict"
+)
+
+func manifestExportConfigMapName(upstreamGateway *gatewayv1.Gateway) string {
+	return fmt.Sprintf("%s-rendered-manifests", upstreamGateway.Name)
+}
+
+// manifestExport collects the downstream objects applied by a reconcile of a
+// gateway, and the routes that could not be applied due to a conflict. A nil
+// manifestExport collects nothing.
+type manifestExport struct {
+	objects          []client.Object
+	conflictedRoutes []string
+}
+
+// add records an object as applied to the downstream cluster.
+func (e *manifestExport) add(obj client.Object) {
+	if e == nil {
+		return
+	}
+	e.objects = append(e.objects, obj.DeepCopyObject().(client.Object))
+}
+
+// addConflictedRoute records a route that could not be applied due to a
+// conflict with a concurrent change.
+func (e *manifestExport) addConflictedRoute(kind, name string) {
+	if e == nil {
+		return
+	}
+	e.conflictedRoutes = append(e.conflictedRoutes, fmt.Sprintf("%s %s", kind, name))
+}
+
+// reconcileManifestExport writes the downstream Gateways, the routes attached
+// to them, the Services and EndpointSlices of their backends and the policies
+// attached to the gateway and routes, as applied by the current reconcile, to
+// a ConfigMap next to the upstream gateway when the gateway has the manifest
+// export annotation.
+//
+// Manifests are sanitized so they can be vendored and diffed: status, server
+// populated metadata and operator labels and annotations are removed, and
+// downstream namespaces are replaced with the upstream namespace. The export
+// is left as is while any route could not be applied due to a conflict, and
+// the ManifestExported condition reports the routes it is waiting on. The
+// ConfigMap and the condition are removed when the annotation is removed.
+func (r *GatewayReconciler) reconcileManifestExport(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	export *manifestExport,
+) (result Result) {
+	logger := log.FromContext(ctx)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: upstreamGateway.Namespace,
+			Name:      manifestExportConfigMapName(upstreamGateway),
+		},
+	}
+
+	// ConfigMaps are watched by the controller, so this is served by the cache.
+	exists := true
+	if err := upstreamClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			result.Err = fmt.Errorf("failed fetching manifest export configmap: %w", err)
+			return result
+		}
+		exists = false
+	}
+
+	if exists && !metav1.IsControlledBy(configMap, upstreamGateway) {
+		logger.Info("manifest export configmap is not owned by the gateway, skipping export", jsonKeyName, configMap.Name)
+		return result
+	}
+
+	if upstreamGateway.Annotations[gatewayutil.ManifestExportAnnotation] != "true" {
+		if exists {
+			if err := upstreamClient.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+				result.Err = fmt.Errorf("failed deleting manifest export configmap: %w", err)
+			}
+		}
+		if apimeta.RemoveStatusCondition(&upstreamGateway.Status.Conditions, GatewayConditionManifestExported) {
+			result.AddStatusUpdate(upstreamClient, upstreamGateway)
+		}
+		return result
+	}
+
+	if len(export.conflictedRoutes) > 0 {
+		setManifestExportedCondition(upstreamClient, upstreamGateway, &result, metav1.Condition{
+			Status:  metav1.ConditionFalse,
+			Reason:  GatewayReasonRouteConflict,
+			Message: fmt.Sprintf("The export is not updated until routes %s, which conflicted with a concurrent change, are applied", strings.Join(export.conflictedRoutes, ", ")),
+		})
+		return result
+	}
+
+	manifests, err := renderExportedManifests(r.DownstreamCluster.GetScheme(), upstreamGateway.Namespace, export.objects)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	setManifestExportedCondition(upstreamClient, upstreamGateway, &result, metav1.Condition{
+		Status:  metav1.ConditionTrue,
+		Reason:  GatewayReasonManifestExported,
+		Message: fmt.Sprintf("Manifests are exported to ConfigMap %s", configMap.Name),
+	})
+
+	if exists && configMap.Data[manifestExportConfigMapKey] == manifests {
+		return result
+	}
+
+	configMap.Data = map[string]string{
+		manifestExportConfigMapKey: manifests,
+	}
+
+	if exists {
+		if err := upstreamClient.Update(ctx, configMap); err != nil {
+			result.Err = fmt.Errorf("failed updating manifest export configmap: %w", err)
+		}
+		return result
+	}
+
+	if err := controllerutil.SetControllerReference(upstreamGateway, configMap, upstreamClient.Scheme()); err != nil {
+		result.Err = fmt.Errorf("failed to set controller on manifest export configmap: %w", err)
+		return result
+	}
+
+	if err := upstreamClient.Create(ctx, configMap); err != nil && !apierrors.IsAlreadyExists(err) {
+		result.Err = fmt.Errorf("failed creating manifest export configmap: %w", err)
+	}
+	return result
+}
+
+func setManifestExportedCondition(
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	result *Result,
+	condition metav1.Condition,
+) {
+	condition.Type = GatewayConditionManifestExported
+	condition.ObservedGeneration = upstreamGateway.Generation
+	if apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, condition) {
+		result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	}
+}
+
+// renderExportedManifests returns a multi-document YAML stream of the exported
+// downstream objects. Gateways come first, followed by the other objects
+// ordered by kind and name, so that the export only changes along with the
+// objects.
+func renderExportedManifests(scheme *runtime.Scheme, upstreamNamespace string, objects []client.Object) (string, error) {
+	type exportedObject struct {
+		gvk schema.GroupVersionKind
+		obj client.Object
+	}
+
+	// An object applied more than once during the reconcile is exported as
+	// last applied.
+	exported := map[string]exportedObject{}
+	for _, obj := range objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return "", fmt.Errorf("failed to get kind of exported manifest: %w", err)
+		}
+		exported[gvk.Kind+"/"+obj.GetName()] = exportedObject{gvk: gvk, obj: obj}
+	}
+
+	sorted := slices.SortedFunc(maps.Values(exported), func(a, b exportedObject) int {
+		return cmp.Or(
+			cmpBool(b.gvk.Kind == KindGateway, a.gvk.Kind == KindGateway),
+			strings.Compare(a.gvk.Kind, b.gvk.Kind),
+			strings.Compare(a.obj.GetName(), b.obj.GetName()),
+		)
+	})
+
+	var buf bytes.Buffer
+	for i, e := range sorted {
+		obj := e.obj.DeepCopyObject().(client.Object)
+		rewriteExportedNamespaces(obj, upstreamNamespace)
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return "", fmt.Errorf("failed converting exported manifest: %w", err)
+		}
+		metadata, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ptr.To(sanitizedExportObjectMeta(obj, upstreamNamespace)))
+		if err != nil {
+			return "", fmt.Errorf("failed converting exported manifest metadata: %w", err)
+		}
+		delete(content, "status")
+		content["apiVersion"] = e.gvk.GroupVersion().String()
+		content["kind"] = e.gvk.Kind
+		content["metadata"] = metadata
+
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return "", fmt.Errorf("failed marshaling exported manifest: %w", err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.String(), nil
+}
+
+// cmpBool orders false before true.
+func cmpBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
+
+// rewriteExportedNamespaces replaces references to the downstream namespace of
+// a route with references to the upstream namespace.
+func rewriteExportedNamespaces(obj client.Object, upstreamNamespace string) {
+	downstreamNamespace := obj.GetNamespace()
+	rewrite := func(ns *gatewayv1.Namespace) *gatewayv1.Namespace {
+		if ns != nil && string(*ns) == downstreamNamespace {
+			return ptr.To(gatewayv1.Namespace(upstreamNamespace))
+		}
+		return ns
+	}
+	rewriteParentRefs := func(parentRefs []gatewayv1.ParentReference) {
+		for i := range parentRefs {
+			parentRefs[i].Namespace = rewrite(parentRefs[i].Namespace)
+		}
+	}
+
+	switch route := obj.(type) {
+	case *gatewayv1.HTTPRoute:
+		rewriteParentRefs(route.Spec.ParentRefs)
+		for i := range route.Spec.Rules {
+			for j := range route.Spec.Rules[i].BackendRefs {
+				backendRef := &route.Spec.Rules[i].BackendRefs[j]
+				backendRef.Namespace = rewrite(backendRef.Namespace)
+			}
+		}
+	case *gatewayv1.GRPCRoute:
+		rewriteParentRefs(route.Spec.ParentRefs)
+		for i := range route.Spec.Rules {
+			for j := range route.Spec.Rules[i].BackendRefs {
+				backendRef := &route.Spec.Rules[i].BackendRefs[j]
+				backendRef.Namespace = rewrite(backendRef.Namespace)
+			}
+		}
+	case *gatewayv1alpha2.TCPRoute, *gatewayv1alpha2.UDPRoute:
+		l4 := wrapL4Route(route)
+		rewriteParentRefs(*l4.parentRefs)
+		for _, backendRefs := range l4.backendRefs() {
+			for i := range backendRefs {
+				backendRefs[i].Namespace = rewrite(backendRefs[i].Namespace)
+			}
+		}
+	}
+}
+
+// sanitizedExportObjectMeta returns the metadata of an exported object, without
+// the labels and annotations the operator sets on downstream resources.
+func sanitizedExportObjectMeta(obj client.Object, namespace string) metav1.ObjectMeta {
+	sanitized := metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      obj.GetName(),
+	}
+	for k, v := range obj.GetLabels() {
+		if isOperatorMetadataKey(k) || (k == downstreamclient.ManagedByLabel && v == downstreamclient.ManagedByLabelValue) {
+			continue
+		}
+		if sanitized.Labels == nil {
+			sanitized.Labels = map[string]string{}
+		}
+		sanitized.Labels[k] = v
+	}
+	for k, v := range obj.GetAnnotations() {
+		if isOperatorMetadataKey(k) {
+			continue
+		}
+		if sanitized.Annotations == nil {
+			sanitized.Annotations = map[string]string{}
+		}
+		sanitized.Annotations[k] = v
+	}
+	return sanitized
+}
+
+func isOperatorMetadataKey(key string) bool {
+	return strings.HasPrefix(key, "meta.datumapis.com/")
+}
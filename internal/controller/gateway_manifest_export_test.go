@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/yaml"
+
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
+)
+
+func TestReconcileManifestExport(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	assert.NoError(t, scheme.AddToScheme(testScheme))
+	assert.NoError(t, gatewayv1.Install(testScheme))
+	assert.NoError(t, gatewayv1alpha2.Install(testScheme))
+	assert.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	const downstreamNamespace = "ns-1234"
+
+	upstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test",
+			UID:       uuid.NewUUID(),
+			Annotations: map[string]string{
+				gatewayutil.ManifestExportAnnotation: "true",
+			},
+		},
+	}
+
+	downstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       downstreamNamespace,
+			Name:            "test",
+			UID:             uuid.NewUUID(),
+			ResourceVersion: "10",
+			Labels: map[string]string{
+				downstreamclient.UpstreamOwnerNamespaceLabel: "test",
+				downstreamclient.ManagedByLabel:              downstreamclient.ManagedByLabelValue,
+				"app":                                        "web",
+			},
+			Annotations: map[string]string{
+				downstreamclient.DesiredHashAnnotation:        "abc",
+				downstreamclient.ObservedGenerationAnnotation: "1",
+				"example.com/note":                            "kept",
+			},
+		},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "downstream",
+		},
+		Status: gatewayv1.GatewayStatus{
+			Addresses: []gatewayv1.GatewayStatusAddress{{Value: "10.0.0.1"}},
+		},
+	}
+
+	attachedRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: downstreamNamespace,
+			Name:      "attached",
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{
+					{Name: "test", Namespace: ptr.To(gatewayv1.Namespace(downstreamNamespace))},
+				},
+			},
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						{
+							BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Name:      "backend",
+									Namespace: ptr.To(gatewayv1.Namespace(downstreamNamespace)),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	grpcRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespace, Name: "grpc"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "test"}},
+			},
+		},
+	}
+
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespace, Name: "tcp"},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{
+					{Name: "test", Namespace: ptr.To(gatewayv1.Namespace(downstreamNamespace))},
+				},
+			},
+			Rules: []gatewayv1alpha2.TCPRouteRule{
+				{
+					BackendRefs: []gatewayv1.BackendRef{
+						{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Name:      "backend",
+								Namespace: ptr.To(gatewayv1.Namespace(downstreamNamespace)),
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: gatewayv1alpha2.TCPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{{ControllerName: "example.com/status"}},
+			},
+		},
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespace, Name: "backend"},
+		Spec:       corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone},
+	}
+
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta:  metav1.ObjectMeta{Namespace: downstreamNamespace, Name: "backend"},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"192.0.2.1"}}},
+	}
+
+	backendTrafficPolicy := &envoygatewayv1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespace, Name: "attached"},
+	}
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(upstreamGateway).
+		Build()
+
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(downstreamGateway).
+		Build()
+
+	reconciler := &GatewayReconciler{
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+
+	ctx := context.Background()
+	export := &manifestExport{}
+	for _, obj := range []client.Object{
+		tcpRoute,
+		attachedRoute,
+		service,
+		downstreamGateway,
+		endpointSlice,
+		grpcRoute,
+		backendTrafficPolicy,
+		// Objects applied more than once are exported once.
+		attachedRoute,
+	} {
+		export.add(obj)
+	}
+
+	result := reconciler.reconcileManifestExport(ctx, fakeUpstreamClient, upstreamGateway, export)
+	require.NoError(t, result.Err)
+
+	condition := apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, GatewayConditionManifestExported)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, GatewayReasonManifestExported, condition.Reason)
+
+	var configMap corev1.ConfigMap
+	require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKey{Namespace: "test", Name: "test-rendered-manifests"}, &configMap))
+	assert.True(t, metav1.IsControlledBy(&configMap, upstreamGateway))
+
+	manifests := configMap.Data[manifestExportConfigMapKey]
+	assert.NotContains(t, manifests, downstreamNamespace)
+	assert.NotContains(t, manifests, "example.com/status")
+	assert.NotContains(t, manifests, string(downstreamGateway.UID))
+	assert.NotContains(t, manifests, "10.0.0.1")
+	assert.NotContains(t, manifests, downstreamclient.UpstreamOwnerNamespaceLabel)
+
+	documents := strings.Split(manifests, "---\n")
+	require.Len(t, documents, 7)
+
+	// The gateway comes first, followed by the other objects ordered by kind.
+	var kinds []string
+	for _, document := range documents {
+		var obj metav1.PartialObjectMetadata
+		require.NoError(t, yaml.Unmarshal([]byte(document), &obj))
+		kinds = append(kinds, obj.Kind)
+	}
+	assert.Equal(t, []string{
+		KindGateway,
+		"BackendTrafficPolicy",
+		"EndpointSlice",
+		KindGRPCRoute,
+		KindHTTPRoute,
+		"Service",
+		KindTCPRoute,
+	}, kinds)
+
+	var exportedGateway gatewayv1.Gateway
+	require.NoError(t, yaml.Unmarshal([]byte(documents[0]), &exportedGateway))
+	assert.Equal(t, KindGateway, exportedGateway.Kind)
+	assert.Equal(t, "test", exportedGateway.Namespace)
+	assert.Equal(t, map[string]string{"app": "web"}, exportedGateway.Labels)
+	assert.Equal(t, map[string]string{"example.com/note": "kept"}, exportedGateway.Annotations)
+	assert.Equal(t, gatewayv1.ObjectName("downstream"), exportedGateway.Spec.GatewayClassName)
+
+	var exportedRoute gatewayv1.HTTPRoute
+	require.NoError(t, yaml.Unmarshal([]byte(documents[4]), &exportedRoute))
+	assert.Equal(t, "attached", exportedRoute.Name)
+	assert.Equal(t, ptr.To(gatewayv1.Namespace("test")), exportedRoute.Spec.ParentRefs[0].Namespace)
+	assert.Equal(t, ptr.To(gatewayv1.Namespace("test")), exportedRoute.Spec.Rules[0].BackendRefs[0].Namespace)
+
+	var exportedTCPRoute gatewayv1alpha2.TCPRoute
+	require.NoError(t, yaml.Unmarshal([]byte(documents[6]), &exportedTCPRoute))
+	assert.Equal(t, ptr.To(gatewayv1.Namespace("test")), exportedTCPRoute.Spec.ParentRefs[0].Namespace)
+	assert.Equal(t, ptr.To(gatewayv1.Namespace("test")), exportedTCPRoute.Spec.Rules[0].BackendRefs[0].Namespace)
+
+	var exportedEndpointSlice discoveryv1.EndpointSlice
+	require.NoError(t, yaml.Unmarshal([]byte(documents[2]), &exportedEndpointSlice))
+	assert.Equal(t, "test", exportedEndpointSlice.Namespace)
+	assert.Equal(t, endpointSlice.Endpoints, exportedEndpointSlice.Endpoints)
+
+	// Bookkeeping of the operator on the downstream gateway does not change
+	// the export.
+	downstreamGateway.Annotations[downstreamclient.DesiredHashAnnotation] = "def"
+	downstreamGateway.Annotations[downstreamclient.ObservedGenerationAnnotation] = "2"
+	export.add(downstreamGateway)
+	result = reconciler.reconcileManifestExport(ctx, fakeUpstreamClient, upstreamGateway, export)
+	require.NoError(t, result.Err)
+	require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(&configMap), &configMap))
+	assert.Equal(t, manifests, configMap.Data[manifestExportConfigMapKey])
+
+	// A route that could not be applied due to a conflict holds back the
+	// export, rather than exporting a spec that was never applied.
+	require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(&configMap), &configMap))
+	conflicted := &manifestExport{}
+	conflicted.add(downstreamGateway)
+	conflicted.addConflictedRoute(KindHTTPRoute, "attached")
+	result = reconciler.reconcileManifestExport(ctx, fakeUpstreamClient, upstreamGateway, conflicted)
+	require.NoError(t, result.Err)
+
+	var heldConfigMap corev1.ConfigMap
+	require.NoError(t, fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(&configMap), &heldConfigMap))
+	assert.Equal(t, manifests, heldConfigMap.Data[manifestExportConfigMapKey])
+
+	condition = apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, GatewayConditionManifestExported)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, GatewayReasonRouteConflict, condition.Reason)
+	assert.Contains(t, condition.Message, "attached")
+
+	// Removing the annotation removes the export.
+	delete(upstreamGateway.Annotations, gatewayutil.ManifestExportAnnotation)
+	result = reconciler.reconcileManifestExport(ctx, fakeUpstreamClient, upstreamGateway, export)
+	require.NoError(t, result.Err)
+
+	err := fakeUpstreamClient.Get(ctx, client.ObjectKeyFromObject(&configMap), &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Nil(t, apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, GatewayConditionManifestExported))
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
+)
+
+// GatewayConditionRequestIDPropagated is set on upstream Gateways that enable
+// request ID injection, and documents the header that carries the request ID.
+const GatewayConditionRequestIDPropagated = "RequestIDPropagated"
+
+const (
+	GatewayReasonRequestIDEnabled       = "Enabled"
+	GatewayReasonInvalidRequestIDConfig = "InvalidConfiguration"
+)
+
+// Envoy command operators used as header values. The edge always maintains the
+// x-request-id header, which is copied when a different header name is
+// requested.
+const (
+	envoyRequestIDValue = "%REQ(x-request-id)%"
+	envoyTraceIDValue   = "%TRACE_ID%"
+)
+
+// requestIDHeaderValue returns the downstream header value used to populate the
+// request ID header, or an empty string when the edge already maintains the
+// header on requests.
+func requestIDHeaderValue(cfg *gatewayutil.RequestIDConfig) string {
+	if cfg.Format == gatewayutil.RequestIDFormatTraceContext {
+		return envoyTraceIDValue
+	}
+	if cfg.Header == gatewayutil.DefaultRequestIDHeader {
+		return ""
+	}
+	return envoyRequestIDValue
+}
+
+// withRequestIDFilters returns a copy of filters that sets the request ID header
+// on requests forwarded to backends and on responses returned to clients.
+// Existing header modifier filters are extended, as a rule may only contain one
+// filter of each type.
+func withRequestIDFilters(filters []gatewayv1.HTTPRouteFilter, cfg *gatewayutil.RequestIDConfig) []gatewayv1.HTTPRouteFilter {
+	result := make([]gatewayv1.HTTPRouteFilter, 0, len(filters)+2)
+	for _, f := range filters {
+		result = append(result, *f.DeepCopy())
+	}
+
+	requestHeader := gatewayv1.HTTPHeader{
+		Name:  gatewayv1.HTTPHeaderName(cfg.Header),
+		Value: requestIDHeaderValue(cfg),
+	}
+	responseHeader := gatewayv1.HTTPHeader{
+		Name:  gatewayv1.HTTPHeaderName(cfg.Header),
+		Value: "%REQ(" + cfg.Header + ")%",
+	}
+	if requestHeader.Value != "" {
+		result = setHeaderModifierHeader(result, gatewayv1.HTTPRouteFilterRequestHeaderModifier, requestHeader)
+	}
+	result = setHeaderModifierHeader(result, gatewayv1.HTTPRouteFilterResponseHeaderModifier, responseHeader)
+
+	return result
+}
+
+func setHeaderModifierHeader(
+	filters []gatewayv1.HTTPRouteFilter,
+	filterType gatewayv1.HTTPRouteFilterType,
+	header gatewayv1.HTTPHeader,
+) []gatewayv1.HTTPRouteFilter {
+	var modifier *gatewayv1.HTTPHeaderFilter
+	for i := range filters {
+		if filters[i].Type != filterType {
+			continue
+		}
+		switch filterType {
+		case gatewayv1.HTTPRouteFilterRequestHeaderModifier:
+			modifier = filters[i].RequestHeaderModifier
+		case gatewayv1.HTTPRouteFilterResponseHeaderModifier:
+			modifier = filters[i].ResponseHeaderModifier
+		}
+		break
+	}
+
+	if modifier == nil {
+		modifier = &gatewayv1.HTTPHeaderFilter{}
+		filter := gatewayv1.HTTPRouteFilter{Type: filterType}
+		if filterType == gatewayv1.HTTPRouteFilterRequestHeaderModifier {
+			filter.RequestHeaderModifier = modifier
+		} else {
+			filter.ResponseHeaderModifier = modifier
+		}
+		filters = append(filters, filter)
+	}
+
+	// The request ID takes precedence over any user supplied value for the
+	// same header. Envoy Gateway rejects modifiers that set, add or remove the
+	// same header more than once, so conflicting user entries are dropped.
+	sameHeader := func(h gatewayv1.HTTPHeader) bool {
+		return strings.EqualFold(string(h.Name), string(header.Name))
+	}
+	modifier.Set = slices.DeleteFunc(modifier.Set, sameHeader)
+	modifier.Add = slices.DeleteFunc(modifier.Add, sameHeader)
+	modifier.Remove = slices.DeleteFunc(modifier.Remove, func(name string) bool {
+		return strings.EqualFold(name, string(header.Name))
+	})
+	modifier.Set = append(modifier.Set, header)
+	return filters
+}
+
+// reconcileRequestIDStatus sets the RequestIDPropagated condition on the
+// upstream gateway. The condition is removed when request ID injection is not
+// enabled.
+func (r *GatewayReconciler) reconcileRequestIDStatus(
+	upstreamClient client.Client,
+	upstreamGateway *gatewayv1.Gateway,
+	cfg *gatewayutil.RequestIDConfig,
+	cfgErr error,
+) (result Result) {
+	switch {
+	case cfgErr != nil:
+		apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, metav1.Condition{
+			Type:               GatewayConditionRequestIDPropagated,
+			Status:             metav1.ConditionFalse,
+			Reason:             GatewayReasonInvalidRequestIDConfig,
+			Message:            cfgErr.Error(),
+			ObservedGeneration: upstreamGateway.Generation,
+		})
+	case cfg == nil:
+		if apimeta.FindStatusCondition(upstreamGateway.Status.Conditions, GatewayConditionRequestIDPropagated) == nil {
+			return result
+		}
+		apimeta.RemoveStatusCondition(&upstreamGateway.Status.Conditions, GatewayConditionRequestIDPropagated)
+	default:
+		apimeta.SetStatusCondition(&upstreamGateway.Status.Conditions, metav1.Condition{
+			Type:               GatewayConditionRequestIDPropagated,
+			Status:             metav1.ConditionTrue,
+			Reason:             GatewayReasonRequestIDEnabled,
+			Message:            fmt.Sprintf("Requests and responses carry a %s request ID in the %q header", cfg.Format, cfg.Header),
+			ObservedGeneration: upstreamGateway.Generation,
+		})
+	}
+
+	result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	return result
+}
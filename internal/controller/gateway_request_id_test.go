@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
+)
+
+func TestWithRequestIDFilters(t *testing.T) {
+	tests := []struct {
+		name             string
+		filters          []gatewayv1.HTTPRouteFilter
+		cfg              *gatewayutil.RequestIDConfig
+		expectedRequest  []gatewayv1.HTTPHeader
+		expectedResponse []gatewayv1.HTTPHeader
+		expectedAdd      []gatewayv1.HTTPHeader
+		expectedRemove   []string
+		expectedFilters  int
+	}{
+		{
+			name: "default header only echoed on response",
+			cfg:  &gatewayutil.RequestIDConfig{Header: gatewayutil.DefaultRequestIDHeader, Format: gatewayutil.RequestIDFormatUUID},
+			expectedResponse: []gatewayv1.HTTPHeader{
+				{Name: "x-request-id", Value: "%REQ(x-request-id)%"},
+			},
+			expectedFilters: 1,
+		},
+		{
+			name: "custom header copies edge request id",
+			cfg:  &gatewayutil.RequestIDConfig{Header: "x-correlation-id", Format: gatewayutil.RequestIDFormatUUID},
+			expectedRequest: []gatewayv1.HTTPHeader{
+				{Name: "x-correlation-id", Value: envoyRequestIDValue},
+			},
+			expectedResponse: []gatewayv1.HTTPHeader{
+				{Name: "x-correlation-id", Value: "%REQ(x-correlation-id)%"},
+			},
+			expectedFilters: 2,
+		},
+		{
+			name: "trace context merged into existing modifier",
+			filters: []gatewayv1.HTTPRouteFilter{
+				{
+					Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+					RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+						Set: []gatewayv1.HTTPHeader{
+							{Name: "x-custom", Value: "a"},
+							{Name: "X-Request-ID", Value: "user"},
+						},
+					},
+				},
+			},
+			cfg: &gatewayutil.RequestIDConfig{Header: gatewayutil.DefaultRequestIDHeader, Format: gatewayutil.RequestIDFormatTraceContext},
+			expectedRequest: []gatewayv1.HTTPHeader{
+				{Name: "x-custom", Value: "a"},
+				{Name: "x-request-id", Value: envoyTraceIDValue},
+			},
+			expectedResponse: []gatewayv1.HTTPHeader{
+				{Name: "x-request-id", Value: "%REQ(x-request-id)%"},
+			},
+			expectedFilters: 2,
+		},
+		{
+			name: "conflicting add and remove entries dropped",
+			filters: []gatewayv1.HTTPRouteFilter{
+				{
+					Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+					ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+						Add: []gatewayv1.HTTPHeader{
+							{Name: "X-Correlation-ID", Value: "user"},
+							{Name: "x-custom", Value: "a"},
+						},
+						Remove: []string{"x-correlation-id", "x-internal"},
+					},
+				},
+			},
+			cfg: &gatewayutil.RequestIDConfig{Header: "x-correlation-id", Format: gatewayutil.RequestIDFormatUUID},
+			expectedRequest: []gatewayv1.HTTPHeader{
+				{Name: "x-correlation-id", Value: envoyRequestIDValue},
+			},
+			expectedResponse: []gatewayv1.HTTPHeader{
+				{Name: "x-correlation-id", Value: "%REQ(x-correlation-id)%"},
+			},
+			expectedAdd: []gatewayv1.HTTPHeader{
+				{Name: "x-custom", Value: "a"},
+			},
+			expectedRemove:  []string{"x-internal"},
+			expectedFilters: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var original []gatewayv1.HTTPRouteFilter
+			for _, f := range tt.filters {
+				original = append(original, *f.DeepCopy())
+			}
+
+			filters := withRequestIDFilters(tt.filters, tt.cfg)
+			assert.Len(t, filters, tt.expectedFilters)
+			assert.Equal(t, original, tt.filters, "input filters must not be modified")
+
+			var request, response, add []gatewayv1.HTTPHeader
+			var remove []string
+			for _, f := range filters {
+				switch f.Type {
+				case gatewayv1.HTTPRouteFilterRequestHeaderModifier:
+					request = f.RequestHeaderModifier.Set
+					add = append(add, f.RequestHeaderModifier.Add...)
+					remove = append(remove, f.RequestHeaderModifier.Remove...)
+				case gatewayv1.HTTPRouteFilterResponseHeaderModifier:
+					response = f.ResponseHeaderModifier.Set
+					add = append(add, f.ResponseHeaderModifier.Add...)
+					remove = append(remove, f.ResponseHeaderModifier.Remove...)
+				}
+			}
+			assert.Equal(t, tt.expectedRequest, request)
+			assert.Equal(t, tt.expectedResponse, response)
+			assert.Equal(t, tt.expectedAdd, add)
+			assert.Equal(t, tt.expectedRemove, remove)
+		})
+	}
+}
+
+func TestReconcileRequestIDStatus(t *testing.T) {
+	reconciler := &GatewayReconciler{}
+
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test",
+			Namespace:  "default",
+			Generation: 3,
+			Annotations: map[string]string{
+				gatewayutil.RequestIDAnnotation:       "uuid",
+				gatewayutil.RequestIDHeaderAnnotation: "X-Correlation-ID",
+			},
+		},
+	}
+
+	cfg, err := gatewayutil.GetRequestIDConfig(gw)
+	require.NoError(t, err)
+	reconciler.reconcileRequestIDStatus(nil, gw, cfg, err)
+
+	c := apimeta.FindStatusCondition(gw.Status.Conditions, GatewayConditionRequestIDPropagated)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Contains(t, c.Message, `"x-correlation-id"`)
+	assert.Equal(t, int64(3), c.ObservedGeneration)
+
+	gw.Annotations[gatewayutil.RequestIDAnnotation] = "snowflake"
+	cfg, err = gatewayutil.GetRequestIDConfig(gw)
+	require.Error(t, err)
+	reconciler.reconcileRequestIDStatus(nil, gw, cfg, err)
+
+	c = apimeta.FindStatusCondition(gw.Status.Conditions, GatewayConditionRequestIDPropagated)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, GatewayReasonInvalidRequestIDConfig, c.Reason)
+
+	gw.Annotations = nil
+	cfg, err = gatewayutil.GetRequestIDConfig(gw)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+	reconciler.reconcileRequestIDStatus(nil, gw, cfg, err)
+	assert.Nil(t, apimeta.FindStatusCondition(gw.Status.Conditions, GatewayConditionRequestIDPropagated))
+}
@@ -226,7 +226,7 @@ func (r *GatewayResourceReplicatorReconciler) Reconcile(ctx context.Context, req
 	logger.Info("reconciling resource")
 	defer logger.Info("reconcile complete")
 
-	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), upstreamClient, r.DownstreamCluster.GetClient())
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), upstreamClient, r.DownstreamCluster.GetClient(), downstreamclient.WithControllerName("gateway_resource_replicator"))
 
 	if !upstreamObj.GetDeletionTimestamp().IsZero() {
 		return r.finalizeResource(ctx, resourceCfg, upstreamClient, upstreamObj, downstreamStrategy)
@@ -781,7 +781,10 @@ func (r *GatewayResourceReplicatorReconciler) SetupWithManager(mgr mcmanager.Man
 
 	r.resources = resources
 
-	return builder.Named("gateway_resource_replicator").Complete(r)
+	return builder.
+		WithOptions(controllerOptions[GVKRequest](r.Config, "gateway_resource_replicator", 0)).
+		Named("gateway_resource_replicator").
+		Complete(r)
 }
 
 func newUnstructuredForGVK(gvk schema.GroupVersionKind) *unstructured.Unstructured {
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// RouteReasonLimitExceeded is the reason of the Accepted=False condition set
+// on the parent status of HTTPRoutes that exceed the route limits of a gateway.
+const RouteReasonLimitExceeded gatewayv1.RouteConditionReason = "RouteLimitExceeded"
+
+// ListenerConditionRouteLimitExceeded is set on the listeners of gateways when
+// route limits are configured. It reports the routes attached to the gateway
+// against the limit, and is true when routes attached to the listener were
+// rejected.
+const ListenerConditionRouteLimitExceeded = "RouteLimitExceeded"
+
+const ListenerReasonWithinRouteLimit = "WithinRouteLimit"
+
+// admitHTTPRoutes applies the route limits of a gateway to the HTTPRoutes
+// attached to it, and returns the message of each rejected route. Routes are
+// admitted oldest first, as with the conflict resolution of Gateway API, so
+// that adding routes never rejects routes that are already programmed. Routes
+// being deleted don't count against the limits.
+func admitHTTPRoutes(routes []gatewayv1.HTTPRoute, limits config.GatewayRouteLimitsConfig) map[client.ObjectKey]string {
+	rejected := map[client.ObjectKey]string{}
+	if !limits.Enabled() {
+		return rejected
+	}
+
+	sorted := slices.Clone(routes)
+	slices.SortFunc(sorted, func(a, b gatewayv1.HTTPRoute) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var admitted int32
+	for _, route := range sorted {
+		if !route.DeletionTimestamp.IsZero() {
+			continue
+		}
+		key := client.ObjectKeyFromObject(&route)
+		if limits.MaxRulesPerRoute > 0 && int32(len(route.Spec.Rules)) > limits.MaxRulesPerRoute {
+			rejected[key] = fmt.Sprintf("Route has %d rules, more than the limit of %d rules per route", len(route.Spec.Rules), limits.MaxRulesPerRoute)
+			continue
+		}
+		if limits.MaxAttachedRoutes > 0 && admitted >= limits.MaxAttachedRoutes {
+			rejected[key] = fmt.Sprintf("Gateway has reached the limit of %d attached routes", limits.MaxAttachedRoutes)
+			continue
+		}
+		admitted++
+	}
+	return rejected
+}
+
+// isGatewayParentRef returns whether a parentRef of a route in the namespace
+// references the gateway. A parentRef without a namespace references a gateway
+// in the namespace of the route.
+func isGatewayParentRef(parentRef gatewayv1.ParentReference, routeNamespace string, gateway client.ObjectKey) bool {
+	return ptr.Deref(parentRef.Group, gatewayv1.GroupName) == gatewayv1.GroupName &&
+		ptr.Deref(parentRef.Kind, KindGateway) == KindGateway &&
+		string(ptr.Deref(parentRef.Namespace, gatewayv1.Namespace(routeNamespace))) == gateway.Namespace &&
+		string(parentRef.Name) == gateway.Name
+}
+
+// parentRefGateway returns the gateway referenced by a parentRef of a route in
+// the namespace.
+func parentRefGateway(parentRef gatewayv1.ParentReference, routeNamespace string) client.ObjectKey {
+	return client.ObjectKey{
+		Namespace: string(ptr.Deref(parentRef.Namespace, gatewayv1.Namespace(routeNamespace))),
+		Name:      string(parentRef.Name),
+	}
+}
+
+// routeLimitExceeded returns whether the parent status of a route for the
+// gateway reports that the route exceeds the route limits of the gateway.
+func routeLimitExceeded(parents []gatewayv1.RouteParentStatus, routeNamespace string, gateway client.ObjectKey) bool {
+	for _, parent := range parents {
+		if !isGatewayParentRef(parent.ParentRef, routeNamespace, gateway) {
+			continue
+		}
+		c := apimeta.FindStatusCondition(parent.Conditions, string(gatewayv1.RouteConditionAccepted))
+		if c != nil && c.Status == metav1.ConditionFalse && c.Reason == string(RouteReasonLimitExceeded) {
+			return true
+		}
+	}
+	return false
+}
+
+// admittedParentRefs returns the parentRefs of an upstream route that its
+// downstream route is programmed for. The parentRefs of the reconciled gateway
+// are kept when it admitted the route, and the parentRefs of other gateways
+// unless they reported the route exceeding their limits.
+func admittedParentRefs(route *gatewayv1.HTTPRoute, gateway client.ObjectKey, admitted bool) []gatewayv1.ParentReference {
+	parentRefs := make([]gatewayv1.ParentReference, 0, len(route.Spec.ParentRefs))
+	for _, parentRef := range route.Spec.ParentRefs {
+		if isGatewayParentRef(parentRef, route.Namespace, gateway) {
+			if !admitted {
+				continue
+			}
+		} else if ptr.Deref(parentRef.Kind, KindGateway) == KindGateway &&
+			routeLimitExceeded(route.Status.Parents, route.Namespace, parentRefGateway(parentRef, route.Namespace)) {
+			continue
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+	return parentRefs
+}
+
+// setRouteLimitExceededCondition sets the Accepted=False condition on the
+// parent status of a route for a gateway whose route limits it exceeds. A
+// parent status is kept for each parentRef of the route to the gateway, so
+// that routes attached to a listener through their sectionName are reported
+// against that listener.
+func setRouteLimitExceededCondition(
+	route *gatewayv1.HTTPRoute,
+	gateway client.ObjectKey,
+	controllerName string,
+	message string,
+) {
+	for _, parentRef := range route.Spec.ParentRefs {
+		if !isGatewayParentRef(parentRef, route.Namespace, gateway) {
+			continue
+		}
+		if !slices.ContainsFunc(route.Status.Parents, func(parent gatewayv1.RouteParentStatus) bool {
+			return isGatewayParentRef(parent.ParentRef, route.Namespace, gateway) &&
+				ptr.Equal(parent.ParentRef.SectionName, parentRef.SectionName)
+		}) {
+			route.Status.Parents = append(route.Status.Parents, gatewayv1.RouteParentStatus{
+				ControllerName: gatewayv1.GatewayController(controllerName),
+				ParentRef:      parentRef,
+			})
+		}
+	}
+
+	for i := range route.Status.Parents {
+		if !isGatewayParentRef(route.Status.Parents[i].ParentRef, route.Namespace, gateway) {
+			continue
+		}
+		apimeta.SetStatusCondition(&route.Status.Parents[i].Conditions, metav1.Condition{
+			Type:               string(gatewayv1.RouteConditionAccepted),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(RouteReasonLimitExceeded),
+			Message:            message,
+			ObservedGeneration: route.Generation,
+		})
+	}
+}
+
+// clearRouteLimitExceededCondition removes the condition set by
+// setRouteLimitExceededCondition once a gateway admits the route again, until
+// the status of the downstream route is mirrored.
+func clearRouteLimitExceededCondition(route *gatewayv1.HTTPRoute, gateway client.ObjectKey) {
+	for i := range route.Status.Parents {
+		if !isGatewayParentRef(route.Status.Parents[i].ParentRef, route.Namespace, gateway) {
+			continue
+		}
+		c := apimeta.FindStatusCondition(route.Status.Parents[i].Conditions, string(gatewayv1.RouteConditionAccepted))
+		if c != nil && c.Reason == string(RouteReasonLimitExceeded) {
+			apimeta.RemoveStatusCondition(&route.Status.Parents[i].Conditions, string(gatewayv1.RouteConditionAccepted))
+		}
+	}
+}
+
+// routeLimitListenerCondition returns the RouteLimitExceeded condition of a
+// listener, given the number of routes admitted by the gateway and the number
+// of routes rejected from the listener. It returns nil when no route limits
+// are configured.
+func routeLimitListenerCondition(
+	limits config.GatewayRouteLimitsConfig,
+	admittedRoutes int32,
+	rejectedRoutes int32,
+	generation int64,
+) *metav1.Condition {
+	if !limits.Enabled() {
+		return nil
+	}
+
+	message := fmt.Sprintf("%d routes are attached to the gateway", admittedRoutes)
+	if limits.MaxAttachedRoutes > 0 {
+		message = fmt.Sprintf("%d of %d routes are attached to the gateway", admittedRoutes, limits.MaxAttachedRoutes)
+	}
+
+	condition := &metav1.Condition{
+		Type:               ListenerConditionRouteLimitExceeded,
+		Status:             metav1.ConditionFalse,
+		Reason:             ListenerReasonWithinRouteLimit,
+		Message:            message,
+		ObservedGeneration: generation,
+	}
+	if rejectedRoutes > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = string(RouteReasonLimitExceeded)
+		condition.Message = fmt.Sprintf("%s, %d routes of the listener were rejected for exceeding the route limits", message, rejectedRoutes)
+	}
+	return condition
+}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestAdmitHTTPRoutes(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	route := func(name string, age time.Duration, rules int) gatewayv1.HTTPRoute {
+		return *newHTTPRoute("test", name, func(route *gatewayv1.HTTPRoute) {
+			route.CreationTimestamp = metav1.NewTime(created.Add(-age))
+			route.Spec.Rules = make([]gatewayv1.HTTPRouteRule, rules)
+		})
+	}
+	routes := []gatewayv1.HTTPRoute{
+		route("newest", 0, 1),
+		route("large", 3*time.Hour, 4),
+		route("oldest", 2*time.Hour, 1),
+		route("b", time.Hour, 1),
+		route("a", time.Hour, 1),
+	}
+
+	assert.Empty(t, admitHTTPRoutes(routes, config.GatewayRouteLimitsConfig{}))
+
+	rejected := admitHTTPRoutes(routes, config.GatewayRouteLimitsConfig{MaxAttachedRoutes: 2, MaxRulesPerRoute: 2})
+	assert.Equal(t, map[client.ObjectKey]string{
+		{Namespace: "test", Name: "large"}:  "Route has 4 rules, more than the limit of 2 rules per route",
+		{Namespace: "test", Name: "b"}:      "Gateway has reached the limit of 2 attached routes",
+		{Namespace: "test", Name: "newest"}: "Gateway has reached the limit of 2 attached routes",
+	}, rejected)
+}
+
+func TestAdmittedParentRefs(t *testing.T) {
+	route := newHTTPRoute("test", "route", func(route *gatewayv1.HTTPRoute) {
+		route.Spec.ParentRefs = []gatewayv1.ParentReference{
+			{Name: "gateway-a"},
+			{Name: "gateway-b"},
+			{Name: "gateway-c"},
+		}
+	})
+	gateway := func(name string) client.ObjectKey {
+		return client.ObjectKey{Namespace: "test", Name: name}
+	}
+	setRouteLimitExceededCondition(route, gateway("gateway-b"), "test-suite", "limit")
+
+	parentNames := func(parentRefs []gatewayv1.ParentReference) []gatewayv1.ObjectName {
+		var names []gatewayv1.ObjectName
+		for _, parentRef := range parentRefs {
+			names = append(names, parentRef.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []gatewayv1.ObjectName{"gateway-a", "gateway-c"}, parentNames(admittedParentRefs(route, gateway("gateway-a"), true)))
+	assert.Equal(t, []gatewayv1.ObjectName{"gateway-c"}, parentNames(admittedParentRefs(route, gateway("gateway-a"), false)))
+
+	// The gateway that rejected the route keeps its parentRef once it admits
+	// the route again.
+	assert.Equal(t, []gatewayv1.ObjectName{"gateway-a", "gateway-b", "gateway-c"}, parentNames(admittedParentRefs(route, gateway("gateway-b"), true)))
+
+	// A gateway with the same name in another namespace is a different parent.
+	assert.False(t, routeLimitExceeded(route.Status.Parents, route.Namespace, client.ObjectKey{Namespace: "other", Name: "gateway-b"}))
+
+	clearRouteLimitExceededCondition(route, gateway("gateway-b"))
+	assert.False(t, routeLimitExceeded(route.Status.Parents, route.Namespace, gateway("gateway-b")))
+	assert.Equal(t, []gatewayv1.ObjectName{"gateway-a", "gateway-b", "gateway-c"}, parentNames(admittedParentRefs(route, gateway("gateway-a"), true)))
+}
+
+func TestSetRouteLimitExceededCondition(t *testing.T) {
+	gateway := client.ObjectKey{Namespace: "test", Name: "gateway"}
+	route := newHTTPRoute("test", "route", func(route *gatewayv1.HTTPRoute) {
+		route.Spec.ParentRefs = []gatewayv1.ParentReference{
+			{Name: "gateway", SectionName: ptr.To(gatewayv1.SectionName("http"))},
+			{Name: "gateway", SectionName: ptr.To(gatewayv1.SectionName("https"))},
+			{Name: "gateway", Namespace: ptr.To(gatewayv1.Namespace("other"))},
+		}
+	})
+
+	setRouteLimitExceededCondition(route, gateway, "test-suite", "limit")
+	require.Len(t, route.Status.Parents, 2)
+	for i, sectionName := range []gatewayv1.SectionName{"http", "https"} {
+		parent := route.Status.Parents[i]
+		assert.Equal(t, route.Spec.ParentRefs[i], parent.ParentRef)
+		accepted := apimeta.FindStatusCondition(parent.Conditions, string(gatewayv1.RouteConditionAccepted))
+		if assert.NotNil(t, accepted, "listener %s", sectionName) {
+			assert.Equal(t, string(RouteReasonLimitExceeded), accepted.Reason)
+		}
+	}
+	assert.True(t, routeLimitExceeded(route.Status.Parents, route.Namespace, gateway))
+	assert.False(t, routeLimitExceeded(route.Status.Parents, route.Namespace, client.ObjectKey{Namespace: "other", Name: "gateway"}))
+
+	// The gateway in the other namespace still programs the route.
+	assert.Equal(t, route.Spec.ParentRefs[2:], admittedParentRefs(route, client.ObjectKey{Namespace: "other", Name: "gateway"}, true))
+	assert.Equal(t, route.Spec.ParentRefs[2:], admittedParentRefs(route, gateway, false))
+
+	// Setting the condition again doesn't duplicate the parent statuses.
+	setRouteLimitExceededCondition(route, gateway, "test-suite", "limit")
+	assert.Len(t, route.Status.Parents, 2)
+}
+
+func TestRouteLimitListenerCondition(t *testing.T) {
+	assert.Nil(t, routeLimitListenerCondition(config.GatewayRouteLimitsConfig{}, 3, 0, 1))
+
+	limits := config.GatewayRouteLimitsConfig{MaxAttachedRoutes: 10}
+	condition := routeLimitListenerCondition(limits, 3, 0, 1)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ListenerReasonWithinRouteLimit, condition.Reason)
+	assert.Equal(t, "3 of 10 routes are attached to the gateway", condition.Message)
+
+	condition = routeLimitListenerCondition(limits, 10, 2, 1)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, string(RouteReasonLimitExceeded), condition.Reason)
+	assert.Equal(t, "10 of 10 routes are attached to the gateway, 2 routes of the listener were rejected for exceeding the route limits", condition.Message)
+}
+
+func TestEnsureDownstreamHTTPRouteLimitExceeded(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayClassName: "test-suite",
+			TargetDomain:               "test-suite.com",
+		},
+	}
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()},
+	}
+	upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test")
+	downstreamGateway := newGateway(testConfig, fmt.Sprintf("ns-%s", upstreamNamespace.UID), "test")
+	upstreamRoute := newHTTPRoute(upstreamNamespace.Name, "route", func(route *gatewayv1.HTTPRoute) {
+		route.Spec.ParentRefs = []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(upstreamGateway.Name)}}
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{{
+			Matches: []gatewayv1.HTTPRouteMatch{{
+				Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/")},
+			}},
+		}}
+	})
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(upstreamNamespace, upstreamRoute, upstreamGateway).
+		WithStatusSubresource(upstreamRoute).
+		Build()
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(downstreamGateway).
+		Build()
+
+	reconciler := &GatewayReconciler{
+		Config:            testConfig,
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+	downstreamRouteKey := client.ObjectKey{Namespace: downstreamGateway.Namespace, Name: upstreamRoute.Name}
+
+	ensureRoute := func(routeLimitMessage string) Result {
+		t.Helper()
+		result := reconciler.ensureDownstreamHTTPRoute(
+			context.Background(),
+			fakeUpstreamClient,
+			upstreamGateway,
+			"test-suite",
+			downstreamGateway,
+			downstreamStrategy,
+			*upstreamRoute,
+			routeLimitMessage,
+			nil,
+		)
+		require.NoError(t, result.Err)
+		return result
+	}
+
+	ensureRoute("")
+	var downstreamRoute gatewayv1.HTTPRoute
+	require.NoError(t, fakeDownstreamClient.Get(context.Background(), downstreamRouteKey, &downstreamRoute))
+
+	result := ensureRoute("Gateway has reached the limit of 1 attached routes")
+	err := fakeDownstreamClient.Get(context.Background(), downstreamRouteKey, &downstreamRoute)
+	assert.True(t, apierrors.IsNotFound(err), "downstream route is removed when rejected, got %v", err)
+
+	_, err = result.Complete(context.Background())
+	require.NoError(t, err)
+
+	var rejectedRoute gatewayv1.HTTPRoute
+	require.NoError(t, fakeUpstreamClient.Get(context.Background(), client.ObjectKeyFromObject(upstreamRoute), &rejectedRoute))
+	require.Len(t, rejectedRoute.Status.Parents, 1)
+	accepted := apimeta.FindStatusCondition(rejectedRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+	if assert.NotNil(t, accepted) {
+		assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+		assert.Equal(t, string(RouteReasonLimitExceeded), accepted.Reason)
+		assert.Equal(t, "Gateway has reached the limit of 1 attached routes", accepted.Message)
+	}
+}
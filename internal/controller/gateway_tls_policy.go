@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// GatewayTLSPolicyAnnotation is set on upstream Gateways that select a TLS
+// policy stricter than the platform policy. It carries the JSON encoded
+// TLSPolicy, which the gateway controller merges with the platform policy and
+// programs as a ClientTrafficPolicy on the downstream cluster. HTTPProxies set
+// it from their tlsPolicy field.
+const GatewayTLSPolicyAnnotation = "networking.datumapis.com/tls-policy"
+
+func setGatewayTLSPolicyAnnotation(annotations map[string]string, policy *networkingv1alpha.TLSPolicy) error {
+	b, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	annotations[GatewayTLSPolicyAnnotation] = string(b)
+	return nil
+}
+
+// gatewayTLSPolicyFromAnnotations returns the TLS policy selected by an
+// upstream Gateway, or nil if the gateway uses the platform policy.
+func gatewayTLSPolicyFromAnnotations(annotations map[string]string) (*networkingv1alpha.TLSPolicy, error) {
+	v, ok := annotations[GatewayTLSPolicyAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var policy networkingv1alpha.TLSPolicy
+	if err := json.Unmarshal([]byte(v), &policy); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", GatewayTLSPolicyAnnotation, err)
+	}
+	return &policy, nil
+}
+
+// desiredClientTLSSettings merges the TLS policy of a gateway with the platform
+// policy. The gateway may require a later TLS version and select a subset of
+// the permitted cipher suites, but settings weaker than the platform policy
+// are ignored.
+func desiredClientTLSSettings(platform config.GatewayTLSPolicyConfig, policy *networkingv1alpha.TLSPolicy) envoygatewayv1alpha1.TLSSettings {
+	settings := envoygatewayv1alpha1.TLSSettings{
+		Ciphers: slices.Clone(platform.CipherSuites),
+	}
+	if platform.MinVersion != "" {
+		settings.MinVersion = ptr.To(envoygatewayv1alpha1.TLSVersion(platform.MinVersion))
+	}
+	for _, protocol := range platform.ALPNProtocols {
+		settings.ALPNProtocols = append(settings.ALPNProtocols, envoygatewayv1alpha1.ALPNProtocol(protocol))
+	}
+
+	if policy == nil {
+		return settings
+	}
+
+	// TLS versions are of the form "1.x", so they order lexically.
+	if policy.MinVersion != nil && string(*policy.MinVersion) > platform.MinVersion {
+		settings.MinVersion = ptr.To(envoygatewayv1alpha1.TLSVersion(*policy.MinVersion))
+	}
+
+	var ciphers []string
+	for _, cipher := range policy.CipherSuites {
+		if len(platform.CipherSuites) == 0 || slices.Contains(platform.CipherSuites, cipher) {
+			ciphers = append(ciphers, cipher)
+		}
+	}
+	if len(ciphers) > 0 {
+		settings.Ciphers = ciphers
+	}
+
+	if len(policy.ALPNProtocols) > 0 {
+		settings.ALPNProtocols = nil
+		for _, protocol := range policy.ALPNProtocols {
+			settings.ALPNProtocols = append(settings.ALPNProtocols, envoygatewayv1alpha1.ALPNProtocol(protocol))
+		}
+	}
+
+	return settings
+}
+
+// clientTLSSettings returns the TLS settings of client connections to the
+// gateway. The settings are empty when no TLS policy is configured.
+func (r *GatewayReconciler) clientTLSSettings(ctx context.Context, upstreamGateway *gatewayv1.Gateway) envoygatewayv1alpha1.TLSSettings {
+	if !r.Config.Gateway.TLSPolicy.Enabled() {
+		return envoygatewayv1alpha1.TLSSettings{}
+	}
+
+	policy, err := gatewayTLSPolicyFromAnnotations(upstreamGateway.Annotations)
+	if err != nil {
+		// The platform policy is still enforced.
+		log.FromContext(ctx).Info("ignoring gateway TLS policy", "error", err.Error())
+	}
+	return desiredClientTLSSettings(r.Config.Gateway.TLSPolicy, policy)
+}
+
+// ensureDownstreamClientTrafficPolicy programs the TLS policy and HTTP/3
+// settings of the gateway as a ClientTrafficPolicy attached to all of its
+// downstream Gateway shards. The policy is removed when neither applies.
+func (r *GatewayReconciler) ensureDownstreamClientTrafficPolicy(
+	ctx context.Context,
+	upstreamGateway *gatewayv1.Gateway,
+	downstreamGateway *gatewayv1.Gateway,
+	shardGateways []*gatewayv1.Gateway,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	export *manifestExport,
+) (result Result) {
+	if !r.Config.Gateway.TLSPolicy.Enabled() && !r.http3Available() {
+		return result
+	}
+
+	logger := log.FromContext(ctx)
+
+	clientTrafficPolicy := &envoygatewayv1alpha1.ClientTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: downstreamGateway.Namespace,
+			Name:      downstreamGateway.Name,
+		},
+	}
+
+	http3 := r.clientHTTP3Settings(upstreamGateway)
+	if !r.Config.Gateway.TLSPolicy.Enabled() && http3 == nil {
+		// The policy only exists when the gateway opted out of HTTP/3 since it
+		// was programmed.
+		downstreamClient := downstreamStrategy.GetClient()
+		if err := downstreamClient.Get(ctx, client.ObjectKeyFromObject(clientTrafficPolicy), clientTrafficPolicy); err != nil {
+			result.Err = client.IgnoreNotFound(err)
+			return result
+		}
+		if err := downstreamClient.Delete(ctx, clientTrafficPolicy); client.IgnoreNotFound(err) != nil {
+			result.Err = fmt.Errorf("failed deleting downstream client traffic policy: %w", err)
+		}
+		return result
+	}
+
+	targetRefs := make([]gatewayv1.LocalPolicyTargetReferenceWithSectionName, 0, len(shardGateways))
+	for _, shardGateway := range shardGateways {
+		targetRefs = append(targetRefs, gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  KindGateway,
+				Name:  gatewayv1.ObjectName(shardGateway.Name),
+			},
+		})
+	}
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, downstreamStrategy.GetClient(), clientTrafficPolicy, func() error {
+		if err := downstreamStrategy.SetControllerReference(ctx, upstreamGateway, clientTrafficPolicy); err != nil {
+			return fmt.Errorf("failed to set controller reference on client traffic policy: %w", err)
+		}
+		clientTrafficPolicy.Spec.TargetRefs = targetRefs
+		clientTrafficPolicy.Spec.TLS = nil
+		if r.Config.Gateway.TLSPolicy.Enabled() {
+			clientTrafficPolicy.Spec.TLS = &envoygatewayv1alpha1.ClientTLSSettings{TLSSettings: r.clientTLSSettings(ctx, upstreamGateway)}
+		}
+		clientTrafficPolicy.Spec.HTTP3 = http3
+		return nil
+	})
+	if err != nil {
+		result.Err = fmt.Errorf("failed ensuring downstream client traffic policy: %w", err)
+		return result
+	}
+	export.add(clientTrafficPolicy)
+
+	logger.Info("downstream client traffic policy processed", "operation_result", opResult)
+
+	return result
+}
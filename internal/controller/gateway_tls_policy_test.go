@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestDesiredClientTLSSettings(t *testing.T) {
+	platform := config.GatewayTLSPolicyConfig{
+		MinVersion:    "1.2",
+		CipherSuites:  []string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256"},
+		ALPNProtocols: []string{"h2", "http/1.1"},
+	}
+
+	tests := []struct {
+		name     string
+		platform config.GatewayTLSPolicyConfig
+		policy   *networkingv1alpha.TLSPolicy
+		expected envoygatewayv1alpha1.TLSSettings
+	}{
+		{
+			name:     "platform policy",
+			platform: platform,
+			expected: envoygatewayv1alpha1.TLSSettings{
+				MinVersion:    ptr.To(envoygatewayv1alpha1.TLSv12),
+				Ciphers:       []string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256"},
+				ALPNProtocols: []envoygatewayv1alpha1.ALPNProtocol{"h2", "http/1.1"},
+			},
+		},
+		{
+			name:     "stricter gateway policy",
+			platform: platform,
+			policy: &networkingv1alpha.TLSPolicy{
+				MinVersion:    ptr.To(networkingv1alpha.TLSVersion13),
+				CipherSuites:  []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+				ALPNProtocols: []networkingv1alpha.ALPNProtocol{networkingv1alpha.ALPNProtocolHTTP11},
+			},
+			expected: envoygatewayv1alpha1.TLSSettings{
+				MinVersion:    ptr.To(envoygatewayv1alpha1.TLSv13),
+				Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+				ALPNProtocols: []envoygatewayv1alpha1.ALPNProtocol{"http/1.1"},
+			},
+		},
+		{
+			name:     "weaker gateway policy is not applied",
+			platform: config.GatewayTLSPolicyConfig{MinVersion: "1.3", CipherSuites: platform.CipherSuites},
+			policy: &networkingv1alpha.TLSPolicy{
+				MinVersion:   ptr.To(networkingv1alpha.TLSVersion12),
+				CipherSuites: []string{"AES128-SHA"},
+			},
+			expected: envoygatewayv1alpha1.TLSSettings{
+				MinVersion: ptr.To(envoygatewayv1alpha1.TLSv13),
+				Ciphers:    []string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256"},
+			},
+		},
+		{
+			name:     "any cipher suite when platform does not restrict them",
+			platform: config.GatewayTLSPolicyConfig{MinVersion: "1.2"},
+			policy: &networkingv1alpha.TLSPolicy{
+				CipherSuites: []string{"AES128-SHA"},
+			},
+			expected: envoygatewayv1alpha1.TLSSettings{
+				MinVersion: ptr.To(envoygatewayv1alpha1.TLSv12),
+				Ciphers:    []string{"AES128-SHA"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, desiredClientTLSSettings(tt.platform, tt.policy))
+		})
+	}
+}
+
+func TestEnsureDownstreamClientTrafficPolicy(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()},
+	}
+	upstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-gw",
+			UID:       uuid.NewUUID(),
+		},
+	}
+	upstreamGateway.Annotations = map[string]string{}
+	require.NoError(t, setGatewayTLSPolicyAnnotation(upstreamGateway.Annotations, &networkingv1alpha.TLSPolicy{
+		MinVersion: ptr.To(networkingv1alpha.TLSVersion13),
+	}))
+
+	downstreamNamespace := "ns-" + string(upstreamNamespace.UID)
+	downstreamGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespace, Name: "test-gw"},
+	}
+	shardGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespace, Name: "test-gw-shard-1"},
+	}
+
+	fakeUpstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(upstreamNamespace, upstreamGateway).Build()
+	fakeDownstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+
+	reconciler := &GatewayReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{
+				TLSPolicy: config.GatewayTLSPolicyConfig{MinVersion: "1.2"},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	result := reconciler.ensureDownstreamClientTrafficPolicy(ctx, upstreamGateway, downstreamGateway,
+		[]*gatewayv1.Gateway{downstreamGateway, shardGateway}, downstreamStrategy, nil)
+	require.NoError(t, result.Err)
+
+	var policy envoygatewayv1alpha1.ClientTrafficPolicy
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(downstreamGateway), &policy))
+	assert.Equal(t, upstreamGateway.Name, policy.Labels[downstreamclient.UpstreamOwnerNameLabel])
+	if assert.Len(t, policy.Spec.TargetRefs, 2) {
+		assert.Equal(t, gatewayv1.ObjectName("test-gw"), policy.Spec.TargetRefs[0].Name)
+		assert.Equal(t, gatewayv1.ObjectName("test-gw-shard-1"), policy.Spec.TargetRefs[1].Name)
+		assert.Equal(t, gatewayv1.Kind(KindGateway), policy.Spec.TargetRefs[0].Kind)
+	}
+	require.NotNil(t, policy.Spec.TLS)
+	assert.Equal(t, ptr.To(envoygatewayv1alpha1.TLSv13), policy.Spec.TLS.MinVersion)
+
+	// An invalid gateway policy falls back to the platform policy.
+	upstreamGateway.Annotations[GatewayTLSPolicyAnnotation] = "{"
+	result = reconciler.ensureDownstreamClientTrafficPolicy(ctx, upstreamGateway, downstreamGateway,
+		[]*gatewayv1.Gateway{downstreamGateway}, downstreamStrategy, nil)
+	require.NoError(t, result.Err)
+	require.NoError(t, fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(downstreamGateway), &policy))
+	assert.Len(t, policy.Spec.TargetRefs, 1)
+	assert.Equal(t, ptr.To(envoygatewayv1alpha1.TLSv12), policy.Spec.TLS.MinVersion)
+}
@@ -0,0 +1,339 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// ConditionUpstreamUnreachable is set on upstream Gateways and HTTPProxies once
+// the API server of their cluster has been unreachable for longer than the
+// configured threshold. The rest of the status may be stale while it is set.
+const ConditionUpstreamUnreachable = "UpstreamUnreachable"
+
+const ReasonAPIServerUnreachable = "APIServerUnreachable"
+
+// GatewayConditionUpstreamUnreachable is the ConditionUpstreamUnreachable of
+// Gateways.
+const GatewayConditionUpstreamUnreachable = ConditionUpstreamUnreachable
+
+const GatewayReasonAPIServerUnreachable = ReasonAPIServerUnreachable
+
+// UpstreamOutageTracker records when reconciles of each upstream cluster
+// started failing because its API server could not be reached.
+type UpstreamOutageTracker struct {
+	config config.UpstreamOutageConfig
+	now    func() time.Time
+
+	mu    sync.Mutex
+	since map[string]time.Time
+}
+
+// NewUpstreamOutageTracker returns a tracker with no outages.
+func NewUpstreamOutageTracker(cfg config.UpstreamOutageConfig) *UpstreamOutageTracker {
+	return &UpstreamOutageTracker{
+		config: cfg,
+		now:    time.Now,
+		since:  map[string]time.Time{},
+	}
+}
+
+// observe records whether the API server of the cluster was reachable, and
+// returns how long the current outage has lasted. The outage ends the first
+// time the API server is reachable again.
+func (t *UpstreamOutageTracker) observe(clusterName string, unreachable bool) (outage time.Duration, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !unreachable {
+		delete(t.since, clusterName)
+		return 0, false
+	}
+
+	since, ok := t.since[clusterName]
+	if !ok {
+		since = t.now()
+		t.since[clusterName] = since
+	}
+	return t.now().Sub(since), true
+}
+
+// isUpstreamUnreachable returns whether the error was caused by the API server
+// being unreachable or unavailable, rather than by the request itself.
+func isUpstreamUnreachable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if apierrors.IsServiceUnavailable(err) || apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) {
+		return true
+	}
+	if utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// upstreamCalls records whether the calls a reconcile made to the API server
+// of its upstream cluster failed because it was unreachable. Only calls made
+// through the upstream cluster are recorded, so that failures reaching the
+// downstream cluster are not counted as outages of the upstream cluster.
+type upstreamCalls struct {
+	unreachable atomic.Bool
+}
+
+// recordUpstreamCalls returns a cluster whose client records the outcome of
+// its calls in the returned upstreamCalls.
+func recordUpstreamCalls(cl cluster.Cluster) (cluster.Cluster, *upstreamCalls) {
+	calls := &upstreamCalls{}
+	return &upstreamCallsCluster{
+		Cluster:   cl,
+		client:    &upstreamCallsClient{Client: cl.GetClient(), calls: calls},
+		apiReader: &upstreamCallsReader{Reader: cl.GetAPIReader(), calls: calls},
+	}, calls
+}
+
+// wasUnreachable returns whether any call failed because the API server was
+// unreachable.
+func (c *upstreamCalls) wasUnreachable() bool {
+	return c != nil && c.unreachable.Load()
+}
+
+func (c *upstreamCalls) record(err error) error {
+	if isUpstreamUnreachable(err) {
+		c.unreachable.Store(true)
+	}
+	return err
+}
+
+type upstreamCallsCluster struct {
+	cluster.Cluster
+	client    client.Client
+	apiReader client.Reader
+}
+
+func (c *upstreamCallsCluster) GetClient() client.Client {
+	return c.client
+}
+
+func (c *upstreamCallsCluster) GetAPIReader() client.Reader {
+	return c.apiReader
+}
+
+type upstreamCallsReader struct {
+	client.Reader
+	calls *upstreamCalls
+}
+
+func (r *upstreamCallsReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return r.calls.record(r.Reader.Get(ctx, key, obj, opts...))
+}
+
+func (r *upstreamCallsReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return r.calls.record(r.Reader.List(ctx, list, opts...))
+}
+
+type upstreamCallsClient struct {
+	client.Client
+	calls *upstreamCalls
+}
+
+func (c *upstreamCallsClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.calls.record(c.Client.Get(ctx, key, obj, opts...))
+}
+
+func (c *upstreamCallsClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.calls.record(c.Client.List(ctx, list, opts...))
+}
+
+func (c *upstreamCallsClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.calls.record(c.Client.Create(ctx, obj, opts...))
+}
+
+func (c *upstreamCallsClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.calls.record(c.Client.Delete(ctx, obj, opts...))
+}
+
+func (c *upstreamCallsClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return c.calls.record(c.Client.DeleteAllOf(ctx, obj, opts...))
+}
+
+func (c *upstreamCallsClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.calls.record(c.Client.Update(ctx, obj, opts...))
+}
+
+func (c *upstreamCallsClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.calls.record(c.Client.Patch(ctx, obj, patch, opts...))
+}
+
+func (c *upstreamCallsClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	return c.calls.record(c.Client.Apply(ctx, obj, opts...))
+}
+
+func (c *upstreamCallsClient) Status() client.SubResourceWriter {
+	return upstreamCallsSubResourceWriter{SubResourceWriter: c.Client.Status(), calls: c.calls}
+}
+
+func (c *upstreamCallsClient) SubResource(subResource string) client.SubResourceClient {
+	subResourceClient := c.Client.SubResource(subResource)
+	return upstreamCallsSubResourceClient{
+		upstreamCallsSubResourceWriter: upstreamCallsSubResourceWriter{SubResourceWriter: subResourceClient, calls: c.calls},
+		reader:                         subResourceClient,
+	}
+}
+
+type upstreamCallsSubResourceWriter struct {
+	client.SubResourceWriter
+	calls *upstreamCalls
+}
+
+func (w upstreamCallsSubResourceWriter) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return w.calls.record(w.SubResourceWriter.Create(ctx, obj, subResource, opts...))
+}
+
+func (w upstreamCallsSubResourceWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return w.calls.record(w.SubResourceWriter.Update(ctx, obj, opts...))
+}
+
+func (w upstreamCallsSubResourceWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return w.calls.record(w.SubResourceWriter.Patch(ctx, obj, patch, opts...))
+}
+
+func (w upstreamCallsSubResourceWriter) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.SubResourceApplyOption) error {
+	return w.calls.record(w.SubResourceWriter.Apply(ctx, obj, opts...))
+}
+
+type upstreamCallsSubResourceClient struct {
+	upstreamCallsSubResourceWriter
+	reader client.SubResourceReader
+}
+
+func (c upstreamCallsSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	return c.calls.record(c.reader.Get(ctx, obj, subResource, opts...))
+}
+
+// deferStatus observes the upstream calls of a reconcile, and returns whether
+// the status updates of the reconciled object should be deferred because the
+// outage is shorter than the threshold. Otherwise, the UpstreamUnreachable
+// condition is set in conditions once the threshold is exceeded, and removed
+// once the API server is reachable again. It returns whether the conditions
+// were changed.
+func (t *UpstreamOutageTracker) deferStatus(
+	ctx context.Context,
+	clusterName string,
+	calls *upstreamCalls,
+	conditions *[]metav1.Condition,
+	generation int64,
+) (deferred, changed bool) {
+	if t == nil {
+		return false, false
+	}
+
+	outage, ok := t.observe(clusterName, calls.wasUnreachable())
+	switch {
+	case !ok:
+		return false, apimeta.RemoveStatusCondition(conditions, ConditionUpstreamUnreachable)
+	case outage < t.config.Threshold.Duration:
+		log.FromContext(ctx).Info("upstream cluster API server unreachable, deferring status updates", "outage", outage)
+		return true, false
+	default:
+		return false, apimeta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               ConditionUpstreamUnreachable,
+			Status:             metav1.ConditionTrue,
+			Reason:             ReasonAPIServerUnreachable,
+			Message:            fmt.Sprintf("The cluster API server has been unreachable for %s, status may be stale", outage.Round(time.Second)),
+			ObservedGeneration: generation,
+		})
+	}
+}
+
+// complete returns the result of a reconcile of an object that has no
+// UpstreamUnreachable condition.
+//
+// While an outage of the upstream API server is shorter than the threshold,
+// the reconcile is retried without an error instead of backing off, and the
+// status updates it did not get to make are made by the retry. Once the
+// threshold is exceeded, the errors are returned.
+func (t *UpstreamOutageTracker) complete(
+	ctx context.Context,
+	clusterName string,
+	calls *upstreamCalls,
+	res ctrl.Result,
+	err error,
+) (ctrl.Result, error) {
+	if t == nil {
+		return res, err
+	}
+	if outage, ok := t.observe(clusterName, calls.wasUnreachable()); ok && outage < t.config.Threshold.Duration {
+		log.FromContext(ctx).Info("upstream cluster API server unreachable, retrying", "outage", outage, "error", err)
+		return ctrl.Result{RequeueAfter: t.config.RetryInterval.Duration}, nil
+	}
+	return res, err
+}
+
+// completeWithUpstreamOutage completes a gateway reconcile, tolerating outages
+// of the upstream API server.
+//
+// While an outage is shorter than the threshold, the status updates of the
+// result are deferred and the gateway is retried, as they were computed while
+// requests to the API server were failing. Once the threshold is exceeded, the
+// status updates are written with the UpstreamUnreachable condition set. The
+// condition is removed once a reconcile reaches the API server again.
+//
+// Outages are detected from the calls recorded in calls, which must be made
+// through the upstream cluster of the gateway, status writes of the result
+// included.
+func (r *GatewayReconciler) completeWithUpstreamOutage(
+	ctx context.Context,
+	clusterName string,
+	upstreamClient client.Client,
+	calls *upstreamCalls,
+	upstreamGateway *gatewayv1.Gateway,
+	result Result,
+) (ctrl.Result, error) {
+	result.FieldManager = gatewayStatusFieldManager
+	if r.UpstreamOutages == nil {
+		return result.Complete(ctx)
+	}
+
+	wasUnreachable := calls.wasUnreachable()
+	deferred, changed := r.UpstreamOutages.deferStatus(ctx, clusterName, calls, &upstreamGateway.Status.Conditions, upstreamGateway.Generation)
+	if deferred {
+		return ctrl.Result{RequeueAfter: r.UpstreamOutages.config.RetryInterval.Duration}, nil
+	}
+	if changed {
+		result.AddStatusUpdate(upstreamClient, upstreamGateway)
+	}
+
+	res, err := result.Complete(ctx)
+	if !wasUnreachable && calls.wasUnreachable() {
+		// The outage started with the status updates.
+		r.UpstreamOutages.observe(clusterName, true)
+		log.FromContext(ctx).Info("upstream cluster API server unreachable, deferring status updates", "error", err)
+		return ctrl.Result{RequeueAfter: r.UpstreamOutages.config.RetryInterval.Duration}, nil
+	}
+	return res, err
+}
@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestIsUpstreamUnreachable(t *testing.T) {
+	gr := schema.GroupResource{Group: gatewayv1.GroupName, Resource: "gateways"}
+
+	assert.False(t, isUpstreamUnreachable(nil))
+	assert.False(t, isUpstreamUnreachable(context.Canceled))
+	assert.False(t, isUpstreamUnreachable(apierrors.NewConflict(gr, "test", errors.New("conflict"))))
+	assert.False(t, isUpstreamUnreachable(apierrors.NewNotFound(gr, "test")))
+
+	assert.True(t, isUpstreamUnreachable(apierrors.NewServiceUnavailable("unavailable")))
+	assert.True(t, isUpstreamUnreachable(apierrors.NewTimeoutError("timeout", 1)))
+	assert.True(t, isUpstreamUnreachable(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}))
+	assert.True(t, isUpstreamUnreachable(errors.Join(errors.New("other"), context.DeadlineExceeded)))
+}
+
+func TestRecordUpstreamCalls(t *testing.T) {
+	ctx := context.Background()
+	s := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(s))
+
+	gateway := newGateway(config.NetworkServicesOperator{}, "test", "test")
+	var upstreamErr error
+	upstreamClient := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(gateway).
+		WithStatusSubresource(gateway).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if upstreamErr != nil {
+					return upstreamErr
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				return &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}
+			},
+		}).
+		Build()
+
+	cl, calls := recordUpstreamCalls(&fakeCluster{cl: upstreamClient})
+	require.NoError(t, cl.GetClient().Get(ctx, client.ObjectKeyFromObject(gateway), &gatewayv1.Gateway{}))
+	assert.False(t, calls.wasUnreachable())
+
+	upstreamErr = apierrors.NewNotFound(gatewayv1.Resource("gateways"), "test")
+	require.Error(t, cl.GetClient().Get(ctx, client.ObjectKeyFromObject(gateway), &gatewayv1.Gateway{}))
+	assert.False(t, calls.wasUnreachable(), "errors of the request itself are not an outage")
+
+	upstreamErr = apierrors.NewServiceUnavailable("unavailable")
+	require.Error(t, cl.GetClient().Get(ctx, client.ObjectKeyFromObject(gateway), &gatewayv1.Gateway{}))
+	assert.True(t, calls.wasUnreachable())
+
+	// Status writes are recorded too.
+	cl, calls = recordUpstreamCalls(&fakeCluster{cl: upstreamClient})
+	require.Error(t, cl.GetClient().Status().Patch(ctx, gateway, client.MergeFrom(gateway)))
+	assert.True(t, calls.wasUnreachable())
+}
+
+func TestCompleteWithUpstreamOutage(t *testing.T) {
+	ctx := context.Background()
+	s := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(s))
+
+	gateway := newGateway(config.NetworkServicesOperator{}, "test", "test")
+	var failStatusUpdates bool
+	upstreamClient := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(gateway).
+		WithStatusSubresource(gateway).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				if failStatusUpdates {
+					return &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}
+				}
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	now := time.Now()
+	tracker := NewUpstreamOutageTracker(config.UpstreamOutageConfig{
+		Threshold:     metav1.Duration{Duration: time.Minute},
+		RetryInterval: metav1.Duration{Duration: 10 * time.Second},
+	})
+	tracker.now = func() time.Time { return now }
+	reconciler := &GatewayReconciler{UpstreamOutages: tracker}
+
+	programmedReason := "Pending"
+	// reconcile completes a reconcile that failed with err. Only errors of
+	// upstream calls are recorded as an outage.
+	reconcile := func(err error, upstream bool) (time.Duration, error) {
+		t.Helper()
+		cl, calls := recordUpstreamCalls(&fakeCluster{cl: upstreamClient})
+		var gw gatewayv1.Gateway
+		require.NoError(t, cl.GetClient().Get(ctx, client.ObjectKeyFromObject(gateway), &gw))
+		apimeta.SetStatusCondition(&gw.Status.Conditions, metav1.Condition{
+			Type:   string(gatewayv1.GatewayConditionProgrammed),
+			Status: metav1.ConditionFalse,
+			Reason: programmedReason,
+		})
+		if upstream {
+			_ = calls.record(err)
+		}
+		result := Result{Err: err}
+		result.AddStatusUpdate(cl.GetClient(), &gw)
+		res, err := reconciler.completeWithUpstreamOutage(ctx, "cluster", cl.GetClient(), calls, &gw, result)
+		return res.RequeueAfter, err
+	}
+	storedConditions := func() []metav1.Condition {
+		t.Helper()
+		var gw gatewayv1.Gateway
+		require.NoError(t, upstreamClient.Get(ctx, client.ObjectKeyFromObject(gateway), &gw))
+		return gw.Status.Conditions
+	}
+	outageErr := apierrors.NewServiceUnavailable("unavailable")
+
+	// Outages of the downstream cluster are not an upstream outage.
+	_, err := reconcile(outageErr, false)
+	require.Error(t, err)
+	assert.NotNil(t, apimeta.FindStatusCondition(storedConditions(), string(gatewayv1.GatewayConditionProgrammed)))
+	assert.Nil(t, apimeta.FindStatusCondition(storedConditions(), GatewayConditionUpstreamUnreachable))
+	programmedReason = "Retrying"
+
+	// Status updates are deferred while the outage is below the threshold.
+	requeueAfter, err := reconcile(outageErr, true)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, requeueAfter)
+	assert.Equal(t, "Pending", apimeta.FindStatusCondition(storedConditions(), string(gatewayv1.GatewayConditionProgrammed)).Reason)
+
+	// Once the threshold is exceeded, status is written with the condition.
+	now = now.Add(2 * time.Minute)
+	_, err = reconcile(outageErr, true)
+	require.Error(t, err)
+	condition := apimeta.FindStatusCondition(storedConditions(), GatewayConditionUpstreamUnreachable)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, GatewayReasonAPIServerUnreachable, condition.Reason)
+	}
+
+	// The condition is removed once the API server is reachable.
+	_, err = reconcile(nil, false)
+	require.NoError(t, err)
+	assert.Nil(t, apimeta.FindStatusCondition(storedConditions(), GatewayConditionUpstreamUnreachable))
+	assert.NotNil(t, apimeta.FindStatusCondition(storedConditions(), string(gatewayv1.GatewayConditionProgrammed)))
+
+	// Failing status updates start a new outage and are deferred.
+	failStatusUpdates = true
+	programmedReason = "Invalid"
+	requeueAfter, err = reconcile(nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, requeueAfter)
+	_, ok := tracker.observe("cluster", true)
+	assert.True(t, ok)
+
+	// Reconciles of gateways are unaffected when tracking is disabled.
+	reconciler.UpstreamOutages = nil
+	_, err = reconcile(outageErr, true)
+	require.Error(t, err)
+}
+
+func TestUpstreamOutageTrackerComplete(t *testing.T) {
+	ctx := context.Background()
+	outageErr := apierrors.NewServiceUnavailable("unavailable")
+
+	var tracker *UpstreamOutageTracker
+	_, err := tracker.complete(ctx, "cluster", &upstreamCalls{}, ctrl.Result{}, outageErr)
+	require.Error(t, err, "reconciles are unaffected when tracking is disabled")
+
+	now := time.Now()
+	tracker = NewUpstreamOutageTracker(config.UpstreamOutageConfig{
+		Threshold:     metav1.Duration{Duration: time.Minute},
+		RetryInterval: metav1.Duration{Duration: 10 * time.Second},
+	})
+	tracker.now = func() time.Time { return now }
+
+	// Errors of downstream calls are returned.
+	_, err = tracker.complete(ctx, "cluster", &upstreamCalls{}, ctrl.Result{}, outageErr)
+	require.Error(t, err)
+
+	unreachable := &upstreamCalls{}
+	_ = unreachable.record(outageErr)
+
+	// Reconciles are retried while the outage is below the threshold.
+	res, err := tracker.complete(ctx, "cluster", unreachable, ctrl.Result{}, outageErr)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, res.RequeueAfter)
+
+	// Once the threshold is exceeded, the error is returned.
+	now = now.Add(2 * time.Minute)
+	_, err = tracker.complete(ctx, "cluster", unreachable, ctrl.Result{}, outageErr)
+	require.Error(t, err)
+}
@@ -92,6 +92,7 @@ func (r *GatewayClassReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	r.mgr = mgr
 	return mcbuilder.ControllerManagedBy(mgr).
 		For(&gatewayv1.GatewayClass{}, mcbuilder.WithEngageWithLocalCluster(false)).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "gatewayclass", 0)).
 		Named("gatewayclass").
 		Complete(r)
 }
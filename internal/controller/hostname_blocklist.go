@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+	mcsource "sigs.k8s.io/multicluster-runtime/pkg/source"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/features"
+	"go.datum.net/network-services-operator/internal/validation"
+)
+
+// hostnameBlocklist matches hostnames against the combined entries of all
+// HostnameBlocklists.
+type hostnameBlocklist struct {
+	hostnames sets.Set[string]
+	suffixes  []string
+	patterns  []*regexp.Regexp
+}
+
+func newHostnameBlocklist(ctx context.Context, blocklists []networkingv1alpha.HostnameBlocklist) *hostnameBlocklist {
+	logger := log.FromContext(ctx)
+
+	b := &hostnameBlocklist{
+		hostnames: sets.New[string](),
+	}
+	for _, blocklist := range blocklists {
+		for _, hostname := range blocklist.Spec.Hostnames {
+			b.hostnames.Insert(strings.ToLower(hostname))
+		}
+		for _, suffix := range blocklist.Spec.Suffixes {
+			if suffix = strings.Trim(strings.ToLower(suffix), "."); suffix != "" {
+				b.suffixes = append(b.suffixes, suffix)
+			}
+		}
+		for _, pattern := range blocklist.Spec.Patterns {
+			// Invalid patterns are rejected by the HostnameBlocklist webhook, and
+			// skipped here for blocklists admitted before it was installed.
+			re, err := validation.CompileHostnameBlocklistPattern(pattern)
+			if err != nil {
+				logger.Info("ignoring invalid hostname blocklist pattern", "blocklist", blocklist.Name, "pattern", pattern, "error", err.Error())
+				continue
+			}
+			b.patterns = append(b.patterns, re)
+		}
+	}
+	return b
+}
+
+// Blocks returns true if the hostname matches any blocklist entry.
+func (b *hostnameBlocklist) Blocks(hostname string) bool {
+	hostname = strings.ToLower(hostname)
+	if b.hostnames.Has(hostname) {
+		return true
+	}
+	for _, suffix := range b.suffixes {
+		if hostname == suffix || strings.HasSuffix(hostname, "."+suffix) {
+			return true
+		}
+	}
+	for _, re := range b.patterns {
+		if re.MatchString(hostname) {
+			return true
+		}
+	}
+	return false
+}
+
+// getHostnameBlocklist reads all HostnameBlocklists from the downstream control
+// plane.
+func getHostnameBlocklist(ctx context.Context, downstreamClient client.Client) (*hostnameBlocklist, error) {
+	var blocklists networkingv1alpha.HostnameBlocklistList
+	if err := downstreamClient.List(ctx, &blocklists); err != nil {
+		return nil, fmt.Errorf("failed listing hostname blocklists: %w", err)
+	}
+	return newHostnameBlocklist(ctx, blocklists.Items), nil
+}
+
+// blockedGatewayHostnames returns the listener hostnames on the upstream gateway
+// that match a HostnameBlocklist. Platform managed hostnames are never blocked.
+// Nothing is blocked when the HostnameBlocklist feature is disabled.
+func (r *GatewayReconciler) blockedGatewayHostnames(ctx context.Context, upstreamGateway *gatewayv1.Gateway) ([]string, error) {
+	if !r.Config.FeatureEnabled(features.HostnameBlocklist) {
+		return nil, nil
+	}
+
+	blocklist, err := getHostnameBlocklist(ctx, r.DownstreamCluster.GetClient())
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := sets.New[string]()
+	for _, l := range upstreamGateway.Spec.Listeners {
+		if l.Hostname == nil {
+			continue
+		}
+		hostname := string(*l.Hostname)
+		if r.isDatumManagedGatewayHostname(upstreamGateway, hostname) {
+			continue
+		}
+		if blocklist.Blocks(hostname) {
+			blocked.Insert(hostname)
+		}
+	}
+	return sets.List(blocked), nil
+}
+
+// hostnameBlocklistSource watches the HostnameBlocklists of the downstream
+// control plane on behalf of every engaged upstream cluster, so that the
+// Gateways of each cluster affected by a change are reconciled.
+type hostnameBlocklistSource struct {
+	downstreamCluster cluster.Cluster
+	handlerFunc       func(multicluster.ClusterName, cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request]
+}
+
+var _ mcsource.Source = &hostnameBlocklistSource{}
+
+func (s *hostnameBlocklistSource) ForCluster(clusterName multicluster.ClusterName, cl cluster.Cluster) (source.TypedSource[mcreconcile.Request], bool, error) {
+	// The informer is the one of the downstream cluster, while the handler
+	// enqueues the Gateways of the upstream cluster being engaged.
+	return mcsource.TypedKind[client.Object, mcreconcile.Request](
+		&networkingv1alpha.HostnameBlocklist{},
+		func(multicluster.ClusterName, cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+			return s.handlerFunc(clusterName, cl)
+		},
+	).ForCluster(clusterName, s.downstreamCluster)
+}
+
+// listGatewaysForHostnameBlocklistFunc enqueues the Gateways of an upstream
+// cluster with a listener hostname matched by a HostnameBlocklist, before or
+// after it changed. Gateways matched only before the change have hostnames
+// to unblock.
+func (r *GatewayReconciler) listGatewaysForHostnameBlocklistFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	enqueue := func(ctx context.Context, q workqueue.TypedRateLimitingInterface[mcreconcile.Request], objs ...client.Object) {
+		logger := log.FromContext(ctx)
+
+		blocklists := make([]networkingv1alpha.HostnameBlocklist, 0, len(objs))
+		for _, obj := range objs {
+			if blocklist, ok := obj.(*networkingv1alpha.HostnameBlocklist); ok {
+				blocklists = append(blocklists, *blocklist)
+			}
+		}
+		blocklist := newHostnameBlocklist(ctx, blocklists)
+
+		var gatewayList gatewayv1.GatewayList
+		if err := cl.GetClient().List(ctx, &gatewayList); err != nil {
+			logger.Error(err, "failed to list Gateways")
+			return
+		}
+
+		for i := range gatewayList.Items {
+			gateway := &gatewayList.Items[i]
+			if !slices.ContainsFunc(gateway.Spec.Listeners, func(l gatewayv1.Listener) bool {
+				return l.Hostname != nil && blocklist.Blocks(string(*l.Hostname))
+			}) {
+				continue
+			}
+			q.Add(mcreconcile.Request{
+				ClusterName: clusterName,
+				Request:     reconcile.Request{NamespacedName: client.ObjectKeyFromObject(gateway)},
+			})
+		}
+	}
+
+	return handler.TypedFuncs[client.Object, mcreconcile.Request]{
+		CreateFunc: func(ctx context.Context, e event.TypedCreateEvent[client.Object], q workqueue.TypedRateLimitingInterface[mcreconcile.Request]) {
+			enqueue(ctx, q, e.Object)
+		},
+		UpdateFunc: func(ctx context.Context, e event.TypedUpdateEvent[client.Object], q workqueue.TypedRateLimitingInterface[mcreconcile.Request]) {
+			enqueue(ctx, q, e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(ctx context.Context, e event.TypedDeleteEvent[client.Object], q workqueue.TypedRateLimitingInterface[mcreconcile.Request]) {
+			enqueue(ctx, q, e.Object)
+		},
+		GenericFunc: func(ctx context.Context, e event.TypedGenericEvent[client.Object], q workqueue.TypedRateLimitingInterface[mcreconcile.Request]) {
+			enqueue(ctx, q, e.Object)
+		},
+	}
+}
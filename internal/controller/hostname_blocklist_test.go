@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/features"
+)
+
+func TestHostnameBlocklistBlocks(t *testing.T) {
+	blocklist := newHostnameBlocklist(context.Background(), []networkingv1alpha.HostnameBlocklist{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "abuse"},
+			Spec: networkingv1alpha.HostnameBlocklistSpec{
+				Hostnames: []string{"Login.Example.com"},
+				Suffixes:  []string{".phish.test"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "patterns"},
+			Spec: networkingv1alpha.HostnameBlocklistSpec{
+				Patterns: []string{`paypa[l1]-.*\.com`, `(invalid`},
+			},
+		},
+	})
+
+	tests := []struct {
+		hostname string
+		blocked  bool
+	}{
+		{hostname: "login.example.com", blocked: true},
+		{hostname: "www.login.example.com", blocked: false},
+		{hostname: "example.com", blocked: false},
+		{hostname: "phish.test", blocked: true},
+		{hostname: "a.b.phish.test", blocked: true},
+		{hostname: "*.phish.test", blocked: true},
+		{hostname: "notphish.test", blocked: false},
+		{hostname: "paypa1-secure.com", blocked: true},
+		{hostname: "www.paypal-secure.com.example.org", blocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			assert.Equal(t, tt.blocked, blocklist.Blocks(tt.hostname))
+		})
+	}
+}
+
+func TestBlockedGatewayHostnames(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	assert.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			TargetDomain: "test-suite.com",
+		},
+		FeatureGates: map[string]bool{string(features.HostnameBlocklist): true},
+	}
+
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(&networkingv1alpha.HostnameBlocklist{
+			ObjectMeta: metav1.ObjectMeta{Name: "abuse"},
+			Spec: networkingv1alpha.HostnameBlocklistSpec{
+				Suffixes: []string{"test-suite.com", "evil.test"},
+			},
+		}).
+		Build()
+
+	reconciler := &GatewayReconciler{
+		Config:            testConfig,
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+
+	gateway := newGateway(testConfig, "test", "test", func(g *gatewayv1.Gateway) {
+		g.Spec.Listeners = append(g.Spec.Listeners,
+			gatewayv1.Listener{
+				Name:     "blocked",
+				Port:     DefaultHTTPPort,
+				Protocol: gatewayv1.HTTPProtocolType,
+				Hostname: ptr.To(gatewayv1.Hostname("www.evil.test")),
+			},
+			gatewayv1.Listener{
+				Name:     "allowed",
+				Port:     DefaultHTTPPort,
+				Protocol: gatewayv1.HTTPProtocolType,
+				Hostname: ptr.To(gatewayv1.Hostname("www.example.com")),
+			},
+		)
+	})
+	gateway.Spec.Listeners[0].Hostname = ptr.To(gatewayv1.Hostname(reconciler.gatewayCanonicalHostname(gateway)))
+
+	blocked, err := reconciler.blockedGatewayHostnames(context.Background(), gateway)
+	if assert.NoError(t, err) {
+		// The platform managed hostname is never blocked, even though it matches
+		// a blocked suffix.
+		assert.Equal(t, []string{"www.evil.test"}, blocked)
+	}
+}
+
+func TestListGatewaysForHostnameBlocklist(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	testConfig := config.NetworkServicesOperator{}
+	gatewayWithHostname := func(name, hostname string) *gatewayv1.Gateway {
+		return newGateway(testConfig, "test", name, func(g *gatewayv1.Gateway) {
+			g.Spec.Listeners = append(g.Spec.Listeners, gatewayv1.Listener{
+				Name:     "custom",
+				Port:     DefaultHTTPPort,
+				Protocol: gatewayv1.HTTPProtocolType,
+				Hostname: ptr.To(gatewayv1.Hostname(hostname)),
+			})
+		})
+	}
+
+	upstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			gatewayWithHostname("unblocked", "www.old.test"),
+			gatewayWithHostname("blocked", "www.new.test"),
+			gatewayWithHostname("unaffected", "www.example.com"),
+		).
+		Build()
+
+	oldBlocklist := &networkingv1alpha.HostnameBlocklist{
+		ObjectMeta: metav1.ObjectMeta{Name: "abuse"},
+		Spec:       networkingv1alpha.HostnameBlocklistSpec{Suffixes: []string{"old.test"}},
+	}
+	newBlocklist := oldBlocklist.DeepCopy()
+	newBlocklist.Spec.Suffixes = []string{"new.test"}
+
+	reconciler := &GatewayReconciler{Config: testConfig}
+	h := reconciler.listGatewaysForHostnameBlocklistFunc("test", &fakeCluster{cl: upstreamClient})
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+	t.Cleanup(queue.ShutDown)
+
+	// Gateways matched before or after the change are both affected.
+	h.Update(context.Background(), event.TypedUpdateEvent[client.Object]{ObjectOld: oldBlocklist, ObjectNew: newBlocklist}, queue)
+	require.Equal(t, 2, queue.Len())
+
+	var enqueued []string
+	for queue.Len() > 0 {
+		item, _ := queue.Get()
+		queue.Done(item)
+		assert.Equal(t, "test", string(item.ClusterName))
+		enqueued = append(enqueued, item.Name)
+	}
+	assert.ElementsMatch(t, []string{"unblocked", "blocked"}, enqueued)
+}
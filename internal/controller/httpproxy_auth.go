@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// authSecurityPolicyName returns the name of the SecurityPolicy that programs
+// the auth of an HTTPProxy.
+func authSecurityPolicyName(httpProxy *networkingv1alpha.HTTPProxy) string {
+	return fmt.Sprintf("%s-auth", httpProxy.Name)
+}
+
+// desiredAuthSecurityPolicy translates the auth of an HTTPProxy into a
+// SecurityPolicy attached to its HTTPRoute. The SecurityPolicy and the Secrets
+// it references are replicated downstream along with the other Envoy Gateway
+// resources in the namespace. It is merged into the SecurityPolicy attached to
+// the Gateway, so that the access control of the Gateway still applies to the
+// proxy.
+func desiredAuthSecurityPolicy(httpProxy *networkingv1alpha.HTTPProxy, httpRouteName string) *envoygatewayv1alpha1.SecurityPolicy {
+	auth := httpProxy.Spec.Auth
+	if auth == nil {
+		return nil
+	}
+
+	securityPolicy := &envoygatewayv1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: httpProxy.Namespace,
+			Name:      authSecurityPolicyName(httpProxy),
+		},
+		Spec: envoygatewayv1alpha1.SecurityPolicySpec{
+			PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Group: gatewayv1.GroupName,
+							Kind:  KindHTTPRoute,
+							Name:  gatewayv1.ObjectName(httpRouteName),
+						},
+					},
+				},
+			},
+			MergeType: ptr.To(envoygatewayv1alpha1.StrategicMerge),
+		},
+	}
+
+	if auth.Basic != nil {
+		securityPolicy.Spec.BasicAuth = &envoygatewayv1alpha1.BasicAuth{
+			Users: gatewayv1.SecretObjectReference{
+				Name: gatewayv1.ObjectName(auth.Basic.UsersRef.Name),
+			},
+			ForwardUsernameHeader: auth.Basic.ForwardUsernameHeader,
+		}
+	}
+
+	return securityPolicy
+}
+
+// reconcileAuthSecurityPolicy maintains the SecurityPolicy that programs the
+// auth of an HTTPProxy, removing it when auth is no longer configured.
+func reconcileAuthSecurityPolicy(
+	ctx context.Context,
+	cl client.Client,
+	scheme *runtime.Scheme,
+	httpProxy *networkingv1alpha.HTTPProxy,
+	desiredPolicy *envoygatewayv1alpha1.SecurityPolicy,
+) error {
+	logger := log.FromContext(ctx)
+
+	if desiredPolicy == nil {
+		var policy envoygatewayv1alpha1.SecurityPolicy
+		policyKey := client.ObjectKey{Namespace: httpProxy.Namespace, Name: authSecurityPolicyName(httpProxy)}
+		if err := cl.Get(ctx, policyKey, &policy); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(&policy, httpProxy) {
+			return nil
+		}
+		if err := cl.Delete(ctx, &policy); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed deleting securitypolicy: %w", err)
+		}
+		logger.Info("deleted securitypolicy", jsonKeyName, policy.Name)
+		return nil
+	}
+
+	policy := desiredPolicy.DeepCopy()
+	result, err := controllerutil.CreateOrUpdate(ctx, cl, policy, func() error {
+		if err := controllerutil.SetControllerReference(httpProxy, policy, scheme); err != nil {
+			return fmt.Errorf("failed to set controller on SecurityPolicy: %w", err)
+		}
+		policy.Spec = desiredPolicy.Spec
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed updating securitypolicy resource: %w", err)
+	}
+	logger.Info("processed securitypolicy", jsonKeyName, policy.Name, "result", result)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestDesiredAuthSecurityPolicy(t *testing.T) {
+	assert.Nil(t, desiredAuthSecurityPolicy(newHTTPProxy(), "test"))
+
+	basic := desiredAuthSecurityPolicy(newHTTPProxy(func(p *networkingv1alpha.HTTPProxy) {
+		p.Spec.Auth = &networkingv1alpha.HTTPProxyAuth{
+			Basic: &networkingv1alpha.HTTPProxyBasicAuth{
+				UsersRef:              corev1.LocalObjectReference{Name: "users"},
+				ForwardUsernameHeader: ptr.To("X-User"),
+			},
+		}
+	}), "test")
+	require.NotNil(t, basic)
+	assert.Equal(t, "test-auth", basic.Name)
+	assert.Equal(t, []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+		{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  KindHTTPRoute,
+				Name:  "test",
+			},
+		},
+	}, basic.Spec.TargetRefs)
+	assert.Equal(t, ptr.To(envoygatewayv1alpha1.StrategicMerge), basic.Spec.MergeType)
+	assert.Equal(t, &envoygatewayv1alpha1.BasicAuth{
+		Users:                 gatewayv1.SecretObjectReference{Name: "users"},
+		ForwardUsernameHeader: ptr.To("X-User"),
+	}, basic.Spec.BasicAuth)
+}
+
+func TestReconcileAuthSecurityPolicy(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	httpProxy := newHTTPProxy(func(p *networkingv1alpha.HTTPProxy) {
+		p.Spec.Auth = &networkingv1alpha.HTTPProxyAuth{
+			Basic: &networkingv1alpha.HTTPProxyBasicAuth{
+				UsersRef: corev1.LocalObjectReference{Name: "users"},
+			},
+		}
+	})
+	cl := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(httpProxy).Build()
+	ctx := context.Background()
+
+	require.NoError(t, reconcileAuthSecurityPolicy(ctx, cl, testScheme, httpProxy, desiredAuthSecurityPolicy(httpProxy, httpProxy.Name)))
+
+	policyKey := client.ObjectKey{Namespace: httpProxy.Namespace, Name: authSecurityPolicyName(httpProxy)}
+	var policy envoygatewayv1alpha1.SecurityPolicy
+	require.NoError(t, cl.Get(ctx, policyKey, &policy))
+	assert.True(t, metav1.IsControlledBy(&policy, httpProxy))
+	if assert.NotNil(t, policy.Spec.BasicAuth) {
+		assert.Equal(t, gatewayv1.ObjectName("users"), policy.Spec.BasicAuth.Users.Name)
+	}
+
+	// Changing the users Secret updates the policy.
+	httpProxy.Spec.Auth.Basic.UsersRef.Name = "other-users"
+	require.NoError(t, reconcileAuthSecurityPolicy(ctx, cl, testScheme, httpProxy, desiredAuthSecurityPolicy(httpProxy, httpProxy.Name)))
+	require.NoError(t, cl.Get(ctx, policyKey, &policy))
+	if assert.NotNil(t, policy.Spec.BasicAuth) {
+		assert.Equal(t, gatewayv1.ObjectName("other-users"), policy.Spec.BasicAuth.Users.Name)
+	}
+
+	// Removing auth removes the policy.
+	httpProxy.Spec.Auth = nil
+	require.NoError(t, reconcileAuthSecurityPolicy(ctx, cl, testScheme, httpProxy, desiredAuthSecurityPolicy(httpProxy, httpProxy.Name)))
+	err := cl.Get(ctx, policyKey, &policy)
+	assert.True(t, apierrors.IsNotFound(err), "expected the auth SecurityPolicy to be deleted, got %v", err)
+}
@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/backendhealth"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+const defaultBackendHealthRefreshInterval = time.Minute
+
+// httpProxyHealthCheckedRules returns the indexes of the rules of the HTTPProxy
+// whose backends are actively health checked.
+func httpProxyHealthCheckedRules(httpProxy *networkingv1alpha.HTTPProxy) []int {
+	var ruleIndexes []int
+	for ruleIndex, rule := range httpProxy.Spec.Rules {
+		if httpProxyRuleHealthChecked(rule) {
+			ruleIndexes = append(ruleIndexes, ruleIndex)
+		}
+	}
+	return ruleIndexes
+}
+
+// httpProxyRuleHealthChecked returns whether the backends of the rule are
+// actively health checked. Rules with backup backends are always health
+// checked.
+func httpProxyRuleHealthChecked(rule networkingv1alpha.HTTPProxyRule) bool {
+	return slices.ContainsFunc(rule.Backends, func(backend networkingv1alpha.HTTPProxyRuleBackend) bool {
+		return isBackupBackend(backend) || backend.HealthCheck != nil
+	})
+}
+
+// httpProxyRuleLoadBalancer returns the load balancer of the rule. Backups and
+// split traffic share a single cluster, so the load balancer of the first
+// backend that sets one applies to the whole rule.
+func httpProxyRuleLoadBalancer(rule networkingv1alpha.HTTPProxyRule) *networkingv1alpha.HTTPProxyBackendLoadBalancer {
+	for _, backend := range rule.Backends {
+		if backend.LoadBalancer != nil && !isBackupBackend(backend) {
+			return backend.LoadBalancer
+		}
+	}
+	return nil
+}
+
+// httpProxyRuleLabel returns the name of the rule, or its index when the rule
+// is not named.
+func httpProxyRuleLabel(httpProxy *networkingv1alpha.HTTPProxy, ruleIndex int) string {
+	if name := httpProxy.Spec.Rules[ruleIndex].Name; name != nil {
+		return string(*name)
+	}
+	return strconv.Itoa(ruleIndex)
+}
+
+// healthChecksProgrammedCondition returns the HealthChecksProgrammed condition
+// for the HTTPProxy, derived from whether the gateway accepted the downstream
+// BackendTrafficPolicies that carry the health checks of its rules. The health
+// of the backends is reported by refreshBackendHealth. It returns nil when no
+// rule is health checked.
+func (r *HTTPProxyReconciler) healthChecksProgrammedCondition(
+	ctx context.Context,
+	clusterName string,
+	upstreamClient client.Client,
+	httpProxy *networkingv1alpha.HTTPProxy,
+	httpRoute *gatewayv1.HTTPRoute,
+) (*metav1.Condition, error) {
+	ruleIndexes := httpProxyHealthCheckedRules(httpProxy)
+	if len(ruleIndexes) == 0 || r.DownstreamCluster == nil {
+		return nil, nil
+	}
+
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(
+		clusterName,
+		upstreamClient,
+		r.DownstreamCluster.GetClient(),
+		downstreamclient.WithControllerName("httpproxy"),
+	)
+	downstreamNamespaceName, err := downstreamStrategy.GetDownstreamNamespaceNameForUpstreamNamespace(ctx, httpProxy.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get downstream namespace: %w", err)
+	}
+
+	var programmed, pending, rejected []string
+	var rejectedMessage string
+	for _, ruleIndex := range ruleIndexes {
+		label := httpProxyRuleLabel(httpProxy, ruleIndex)
+
+		var policy envoygatewayv1alpha1.BackendTrafficPolicy
+		if err := downstreamStrategy.GetClient().Get(ctx, client.ObjectKey{
+			Namespace: downstreamNamespaceName,
+			Name:      downstreamHealthCheckPolicyName(httpRoute.UID, ruleIndex),
+		}, &policy); err != nil {
+			if apierrors.IsNotFound(err) {
+				pending = append(pending, label)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get downstream health check policy: %w", err)
+		}
+
+		switch status, message := downstreamHealthCheckPolicyStatus(&policy); status {
+		case metav1.ConditionTrue:
+			programmed = append(programmed, label)
+		case metav1.ConditionFalse:
+			rejected = append(rejected, label)
+			if rejectedMessage == "" {
+				rejectedMessage = message
+			}
+		default:
+			pending = append(pending, label)
+		}
+	}
+
+	condition := &metav1.Condition{
+		Type:               networkingv1alpha.HTTPProxyConditionHealthChecksProgrammed,
+		ObservedGeneration: httpProxy.Generation,
+	}
+	switch {
+	case len(rejected) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = networkingv1alpha.HTTPProxyReasonHealthChecksRejected
+		condition.Message = fmt.Sprintf("Health checks for rules [%s] were not accepted by the gateway: %s",
+			strings.Join(rejected, ", "), rejectedMessage)
+	case len(pending) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = networkingv1alpha.HTTPProxyReasonHealthChecksPending
+		condition.Message = fmt.Sprintf("Waiting for the gateway to accept health checks for rules [%s]", strings.Join(pending, ", "))
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = networkingv1alpha.HTTPProxyReasonHealthChecksProgrammed
+		condition.Message = fmt.Sprintf("Health checks for rules [%s] were accepted by the gateway, backends only receive traffic while they pass",
+			strings.Join(programmed, ", "))
+	}
+	return condition, nil
+}
+
+// refreshBackendHealth updates the backend health in the status of the
+// HTTPProxy when it is due for a refresh, and returns when the next refresh is
+// due. It returns 0 when backend health is not reported, in which case the
+// backend health is removed from the status.
+func (r *HTTPProxyReconciler) refreshBackendHealth(
+	ctx context.Context,
+	clusterName string,
+	upstreamClient client.Client,
+	httpProxyCopy *networkingv1alpha.HTTPProxy,
+	httpRoute *gatewayv1.HTTPRoute,
+) time.Duration {
+	ruleIndexes := httpProxyHealthCheckedRules(httpProxyCopy)
+	if r.BackendHealth == nil || r.DownstreamCluster == nil || httpRoute == nil || len(ruleIndexes) == 0 {
+		httpProxyCopy.Status.BackendHealth = nil
+		return 0
+	}
+	logger := log.FromContext(ctx)
+
+	refreshInterval := defaultBackendHealthRefreshInterval
+	if interval := r.Config.HTTPProxy.BackendHealth.RefreshInterval.Duration; interval > 0 {
+		refreshInterval = interval
+	}
+
+	// The health last reported is kept until it is due for a refresh, unless
+	// the rules that are health checked changed.
+	now := time.Now()
+	if health := httpProxyCopy.Status.BackendHealth; health != nil && backendHealthRulesMatch(health, ruleIndexes) {
+		if age := now.Sub(health.ObservedTime.Time); age >= 0 && age < refreshInterval {
+			return refreshInterval - age
+		}
+	}
+
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(
+		clusterName,
+		upstreamClient,
+		r.DownstreamCluster.GetClient(),
+		downstreamclient.WithControllerName("httpproxy"),
+	)
+	downstreamNamespaceName, err := downstreamStrategy.GetDownstreamNamespaceNameForUpstreamNamespace(ctx, httpProxyCopy.Namespace)
+	if err != nil {
+		logger.Error(err, "failed to get downstream namespace for backend health")
+		return refreshInterval
+	}
+
+	routeHealth, err := r.BackendHealth.RouteHealth(ctx, backendhealth.RouteRef{
+		Namespace: downstreamNamespaceName,
+		Name:      httpRoute.Name,
+	})
+	if err != nil {
+		// The health last reported is kept until the next refresh succeeds.
+		logger.Error(err, "failed to collect backend health")
+		return refreshInterval
+	}
+
+	health := &networkingv1alpha.HTTPProxyBackendHealth{
+		ObservedTime: metav1.NewTime(now.Truncate(time.Second)),
+	}
+	for _, ruleIndex := range ruleIndexes {
+		ruleHealth := routeHealth[ruleIndex]
+		status := networkingv1alpha.HTTPProxyRuleHealth{
+			RuleIndex:        int32(ruleIndex),
+			HealthyEndpoints: int32(ruleHealth.Healthy),
+			TotalEndpoints:   int32(ruleHealth.Total),
+		}
+		if primary, backup, ok := ruleFailoverHealth(httpProxyCopy.Spec.Rules[ruleIndex], ruleHealth.Endpoints); ok {
+			status.PrimaryHealthyEndpoints = ptr.To(primary)
+			status.BackupHealthyEndpoints = ptr.To(backup)
+		}
+		health.Rules = append(health.Rules, status)
+	}
+	httpProxyCopy.Status.BackendHealth = health
+	return refreshInterval
+}
+
+// backendHealthRulesMatch returns whether the backend health covers exactly the
+// rules with the given indexes.
+func backendHealthRulesMatch(health *networkingv1alpha.HTTPProxyBackendHealth, ruleIndexes []int) bool {
+	if len(health.Rules) != len(ruleIndexes) {
+		return false
+	}
+	for i, rule := range health.Rules {
+		if int(rule.RuleIndex) != ruleIndexes[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// backendsHealthyCondition returns the BackendsHealthy condition for the
+// HTTPProxy from the backend health in its status, or nil when backend health
+// is not reported.
+func backendsHealthyCondition(httpProxy *networkingv1alpha.HTTPProxy) *metav1.Condition {
+	health := httpProxy.Status.BackendHealth
+	if health == nil {
+		return nil
+	}
+
+	var healthy, degraded, unhealthy, unknown []string
+	for _, rule := range health.Rules {
+		label := httpProxyRuleLabel(httpProxy, int(rule.RuleIndex))
+		switch {
+		case rule.TotalEndpoints == 0:
+			unknown = append(unknown, label)
+		case rule.HealthyEndpoints == 0:
+			unhealthy = append(unhealthy, label)
+		case rule.HealthyEndpoints < rule.TotalEndpoints:
+			degraded = append(degraded, label)
+		default:
+			healthy = append(healthy, label)
+		}
+	}
+
+	condition := &metav1.Condition{
+		Type:               networkingv1alpha.HTTPProxyConditionBackendsHealthy,
+		ObservedGeneration: httpProxy.Generation,
+	}
+	switch {
+	case len(unhealthy) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = networkingv1alpha.HTTPProxyReasonBackendsUnhealthy
+		condition.Message = fmt.Sprintf("No endpoint of the backends of rules [%s] passes its health checks", strings.Join(unhealthy, ", "))
+	case len(unknown) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = networkingv1alpha.HTTPProxyReasonBackendHealthUnknown
+		condition.Message = fmt.Sprintf("Waiting for the gateway to report the health of the backends of rules [%s]", strings.Join(unknown, ", "))
+	case len(degraded) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = networkingv1alpha.HTTPProxyReasonBackendsHealthy
+		condition.Message = fmt.Sprintf("Every health checked rule has a healthy endpoint, some endpoints of rules [%s] fail their health checks", strings.Join(degraded, ", "))
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = networkingv1alpha.HTTPProxyReasonBackendsHealthy
+		condition.Message = fmt.Sprintf("Every endpoint of the backends of rules [%s] passes its health checks", strings.Join(healthy, ", "))
+	}
+	return condition
+}
+
+// downstreamHealthCheckPolicyStatus returns whether every gateway the policy is
+// attached to has accepted it, along with the message of a rejection.
+func downstreamHealthCheckPolicyStatus(policy *envoygatewayv1alpha1.BackendTrafficPolicy) (metav1.ConditionStatus, string) {
+	if len(policy.Status.Ancestors) == 0 {
+		return metav1.ConditionUnknown, ""
+	}
+
+	status := metav1.ConditionTrue
+	for _, ancestor := range policy.Status.Ancestors {
+		accepted := apimeta.FindStatusCondition(ancestor.Conditions, conditionTypeAccepted)
+		switch {
+		case accepted == nil || accepted.Status == metav1.ConditionUnknown:
+			status = metav1.ConditionUnknown
+		case accepted.Status == metav1.ConditionFalse:
+			if accepted.Message != "" {
+				return metav1.ConditionFalse, accepted.Message
+			}
+			return metav1.ConditionFalse, accepted.Reason
+		}
+	}
+	return status, ""
+}
+
+// enqueueHTTPProxyForDownstreamRoutePolicy returns a watch handler that
+// enqueues the HTTPProxy (same name/namespace as the upstream HTTPRoute) when a
+// BackendTrafficPolicy owned by a downstream HTTPRoute changes, so the
+// HealthChecksProgrammed condition is updated.
+func (r *HTTPProxyReconciler) enqueueHTTPProxyForDownstreamRoutePolicy() func(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[*envoygatewayv1alpha1.BackendTrafficPolicy, mcreconcile.Request] {
+	return func(_ multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[*envoygatewayv1alpha1.BackendTrafficPolicy, mcreconcile.Request] {
+		return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, policy *envoygatewayv1alpha1.BackendTrafficPolicy) []mcreconcile.Request {
+			logger := log.FromContext(ctx)
+			ownerRef := metav1.GetControllerOf(policy)
+			if ownerRef == nil || ownerRef.Kind != KindHTTPRoute {
+				return nil
+			}
+			routeKey := client.ObjectKey{Namespace: policy.Namespace, Name: ownerRef.Name}
+			var route gatewayv1.HTTPRoute
+			if err := cl.GetClient().Get(ctx, routeKey, &route); err != nil {
+				if apierrors.IsNotFound(err) {
+					return nil
+				}
+				logger.Error(err, "failed to get HTTPRoute owner of BackendTrafficPolicy", "backendtrafficpolicy", policy.Name, "httproute", routeKey)
+				return nil
+			}
+			labels := route.GetLabels()
+			upstreamNs := labels[downstreamclient.UpstreamOwnerNamespaceLabel]
+			upstreamName := labels[downstreamclient.UpstreamOwnerNameLabel]
+			upstreamCluster := labels[downstreamclient.UpstreamOwnerClusterNameLabel]
+			if upstreamNs == "" || upstreamName == "" || upstreamCluster == "" {
+				return nil
+			}
+			clusterName := multicluster.ClusterName(downstreamclient.UpstreamClusterNameFromLabel(upstreamCluster))
+			return []mcreconcile.Request{{
+				ClusterName: clusterName,
+				Request:     ctrl.Request{NamespacedName: types.NamespacedName{Namespace: upstreamNs, Name: upstreamName}},
+			}}
+		})
+	}
+}
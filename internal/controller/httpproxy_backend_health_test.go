@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/backendhealth"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestHTTPProxyHealthCheckedRules(t *testing.T) {
+	httpProxy := &networkingv1alpha.HTTPProxy{
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Rules: []networkingv1alpha.HTTPProxyRule{
+				{Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://www.example.com"}}},
+				{Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+					{Endpoint: "https://api.example.com", HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{}},
+				}},
+				{Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+					{Endpoint: "https://primary.example.com"},
+					{Endpoint: "https://backup.example.com", Role: networkingv1alpha.HTTPProxyBackendRoleBackup},
+				}},
+			},
+		},
+	}
+	assert.Equal(t, []int{1, 2}, httpProxyHealthCheckedRules(httpProxy))
+}
+
+func TestHealthChecksProgrammedCondition(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	upstreamNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()}}
+	downstreamNamespaceName := fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+	httpRoute := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test", UID: uuid.NewUUID()}}
+
+	httpProxy := &networkingv1alpha.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test", Generation: 2},
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Rules: []networkingv1alpha.HTTPProxyRule{
+				{
+					Name: ptr.To(gatewayv1.SectionName("api")),
+					Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+						{Endpoint: "https://api.example.com", HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{}},
+					},
+				},
+				{
+					Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+						{Endpoint: "https://www.example.com", HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{}},
+					},
+				},
+			},
+		},
+	}
+
+	upstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(upstreamNamespace).Build()
+	downstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	reconciler := &HTTPProxyReconciler{DownstreamCluster: &fakeCluster{cl: downstreamClient}}
+
+	setPolicyAccepted := func(ruleIndex int, status metav1.ConditionStatus, message string) {
+		t.Helper()
+		policy := &envoygatewayv1alpha1.BackendTrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: downstreamNamespaceName,
+				Name:      downstreamHealthCheckPolicyName(httpRoute.UID, ruleIndex),
+			},
+		}
+		_ = downstreamClient.Delete(ctx, policy)
+		policy.Status.Ancestors = []gatewayv1.PolicyAncestorStatus{{
+			AncestorRef: gatewayv1.ParentReference{Name: "test"},
+			Conditions: []metav1.Condition{{
+				Type:    conditionTypeAccepted,
+				Status:  status,
+				Reason:  "Test",
+				Message: message,
+			}},
+		}}
+		require.NoError(t, downstreamClient.Create(ctx, policy))
+	}
+
+	condition, err := reconciler.healthChecksProgrammedCondition(ctx, "cluster", upstreamClient, httpProxy, httpRoute)
+	require.NoError(t, err)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, networkingv1alpha.HTTPProxyReasonHealthChecksPending, condition.Reason)
+	assert.Contains(t, condition.Message, "[api, 1]")
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+
+	setPolicyAccepted(0, metav1.ConditionTrue, "")
+	setPolicyAccepted(1, metav1.ConditionTrue, "")
+	condition, err = reconciler.healthChecksProgrammedCondition(ctx, "cluster", upstreamClient, httpProxy, httpRoute)
+	require.NoError(t, err)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, networkingv1alpha.HTTPProxyReasonHealthChecksProgrammed, condition.Reason)
+
+	setPolicyAccepted(1, metav1.ConditionFalse, "policy conflicts with another policy")
+	condition, err = reconciler.healthChecksProgrammedCondition(ctx, "cluster", upstreamClient, httpProxy, httpRoute)
+	require.NoError(t, err)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, networkingv1alpha.HTTPProxyReasonHealthChecksRejected, condition.Reason)
+	assert.Contains(t, condition.Message, "[1]")
+	assert.Contains(t, condition.Message, "policy conflicts with another policy")
+
+	// The condition is omitted once no backend is health checked.
+	for i := range httpProxy.Spec.Rules {
+		httpProxy.Spec.Rules[i].Backends[0].HealthCheck = nil
+	}
+	condition, err = reconciler.healthChecksProgrammedCondition(ctx, "cluster", upstreamClient, httpProxy, httpRoute)
+	require.NoError(t, err)
+	assert.Nil(t, condition)
+}
+
+type fakeBackendHealthSource struct {
+	health  map[int]backendhealth.RuleHealth
+	err     error
+	queries []backendhealth.RouteRef
+}
+
+func (s *fakeBackendHealthSource) RouteHealth(_ context.Context, route backendhealth.RouteRef) (map[int]backendhealth.RuleHealth, error) {
+	s.queries = append(s.queries, route)
+	return s.health, s.err
+}
+
+func TestRefreshBackendHealth(t *testing.T) {
+	ctx := context.Background()
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+
+	upstreamNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()}}
+	downstreamNamespaceName := fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+	httpRoute := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test", UID: uuid.NewUUID()}}
+
+	httpProxy := &networkingv1alpha.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test", Generation: 3},
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Rules: []networkingv1alpha.HTTPProxyRule{
+				{Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://www.example.com"}}},
+				{
+					Name: ptr.To(gatewayv1.SectionName("api")),
+					Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+						{Endpoint: "https://api.example.com", HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{}},
+					},
+				},
+				{
+					Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+						{Endpoint: "https://primary.example.com", HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{}},
+						{Endpoint: "https://backup.example.com", Role: networkingv1alpha.HTTPProxyBackendRoleBackup},
+					},
+				},
+			},
+		},
+	}
+
+	upstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(upstreamNamespace).Build()
+	downstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	source := &fakeBackendHealthSource{
+		health: map[int]backendhealth.RuleHealth{
+			1: {Healthy: 2, Total: 2},
+		},
+	}
+	reconciler := &HTTPProxyReconciler{
+		Config:            config.NetworkServicesOperator{},
+		DownstreamCluster: &fakeCluster{cl: downstreamClient},
+		BackendHealth:     source,
+	}
+
+	// Rules without data are reported with no endpoints until the gateway
+	// reports them.
+	refreshAfter := reconciler.refreshBackendHealth(ctx, "cluster", upstreamClient, httpProxy, httpRoute)
+	assert.Equal(t, defaultBackendHealthRefreshInterval, refreshAfter)
+	assert.Equal(t, []backendhealth.RouteRef{{Namespace: downstreamNamespaceName, Name: "test"}}, source.queries)
+	require.NotNil(t, httpProxy.Status.BackendHealth)
+	assert.Equal(t, []networkingv1alpha.HTTPProxyRuleHealth{
+		{RuleIndex: 1, HealthyEndpoints: 2, TotalEndpoints: 2},
+		{RuleIndex: 2, HealthyEndpoints: 0, TotalEndpoints: 0},
+	}, httpProxy.Status.BackendHealth.Rules)
+
+	condition := backendsHealthyCondition(httpProxy)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, networkingv1alpha.HTTPProxyReasonBackendHealthUnknown, condition.Reason)
+	assert.Contains(t, condition.Message, "[2]")
+	assert.Equal(t, int64(3), condition.ObservedGeneration)
+
+	// The health is not collected again until it is due for a refresh.
+	source.health = map[int]backendhealth.RuleHealth{
+		1: {Healthy: 1, Total: 2},
+		2: {Healthy: 0, Total: 1},
+	}
+	refreshAfter = reconciler.refreshBackendHealth(ctx, "cluster", upstreamClient, httpProxy, httpRoute)
+	assert.Positive(t, refreshAfter)
+	assert.LessOrEqual(t, refreshAfter, defaultBackendHealthRefreshInterval)
+	assert.Len(t, source.queries, 1)
+
+	httpProxy.Status.BackendHealth.ObservedTime = metav1.NewTime(time.Now().Add(-2 * defaultBackendHealthRefreshInterval))
+	reconciler.refreshBackendHealth(ctx, "cluster", upstreamClient, httpProxy, httpRoute)
+	assert.Len(t, source.queries, 2)
+	condition = backendsHealthyCondition(httpProxy)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, networkingv1alpha.HTTPProxyReasonBackendsUnhealthy, condition.Reason)
+	assert.Contains(t, condition.Message, "[2]")
+
+	source.health[2] = backendhealth.RuleHealth{Healthy: 1, Total: 1}
+	httpProxy.Status.BackendHealth.ObservedTime = metav1.NewTime(time.Now().Add(-2 * defaultBackendHealthRefreshInterval))
+	reconciler.refreshBackendHealth(ctx, "cluster", upstreamClient, httpProxy, httpRoute)
+	condition = backendsHealthyCondition(httpProxy)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, networkingv1alpha.HTTPProxyReasonBackendsHealthy, condition.Reason)
+	assert.Contains(t, condition.Message, "[api]")
+
+	// The health last reported is kept when it cannot be collected.
+	lastHealth := httpProxy.Status.BackendHealth.DeepCopy()
+	lastHealth.ObservedTime = metav1.NewTime(time.Now().Add(-2 * defaultBackendHealthRefreshInterval))
+	httpProxy.Status.BackendHealth = lastHealth.DeepCopy()
+	source.err = errors.New("prometheus unavailable")
+	reconciler.refreshBackendHealth(ctx, "cluster", upstreamClient, httpProxy, httpRoute)
+	assert.Equal(t, lastHealth, httpProxy.Status.BackendHealth)
+	source.err = nil
+
+	// The health is collected again as soon as the health checked rules
+	// change.
+	httpProxy.Status.BackendHealth.ObservedTime = metav1.Now()
+	httpProxy.Spec.Rules[2].Backends = []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://primary.example.com"}}
+	reconciler.refreshBackendHealth(ctx, "cluster", upstreamClient, httpProxy, httpRoute)
+	assert.Len(t, source.queries, 5)
+	assert.Equal(t, []networkingv1alpha.HTTPProxyRuleHealth{
+		{RuleIndex: 1, HealthyEndpoints: 1, TotalEndpoints: 2},
+	}, httpProxy.Status.BackendHealth.Rules)
+
+	// The health is removed once no backend is health checked.
+	httpProxy.Spec.Rules[1].Backends[0].HealthCheck = nil
+	assert.Zero(t, reconciler.refreshBackendHealth(ctx, "cluster", upstreamClient, httpProxy, httpRoute))
+	assert.Nil(t, httpProxy.Status.BackendHealth)
+	assert.Nil(t, backendsHealthyCondition(httpProxy))
+}
+
+func TestDownstreamHealthCheckPolicyStatus(t *testing.T) {
+	policy := &envoygatewayv1alpha1.BackendTrafficPolicy{}
+	status, _ := downstreamHealthCheckPolicyStatus(policy)
+	assert.Equal(t, metav1.ConditionUnknown, status)
+
+	policy.Status.Ancestors = []gatewayv1.PolicyAncestorStatus{
+		{Conditions: []metav1.Condition{{Type: conditionTypeAccepted, Status: metav1.ConditionTrue}}},
+		{},
+	}
+	status, _ = downstreamHealthCheckPolicyStatus(policy)
+	assert.Equal(t, metav1.ConditionUnknown, status)
+
+	policy.Status.Ancestors[1].Conditions = []metav1.Condition{{Type: conditionTypeAccepted, Status: metav1.ConditionFalse, Reason: "Invalid"}}
+	status, message := downstreamHealthCheckPolicyStatus(policy)
+	assert.Equal(t, metav1.ConditionFalse, status)
+	assert.Equal(t, "Invalid", message)
+}
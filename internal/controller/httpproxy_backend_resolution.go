@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	dnsutil "go.datum.net/network-services-operator/internal/util/dns"
+)
+
+const defaultDNSQueryTimeout = 5 * time.Second
+
+// lookupHostFunc resolves a hostname into its IP addresses and returns the
+// lowest TTL of the records in the answers.
+type lookupHostFunc func(ctx context.Context, host string) ([]netip.Addr, time.Duration, error)
+
+// resolvedBackendHost is the result of resolving a backend hostname.
+type resolvedBackendHost struct {
+	addresses  []netip.Addr
+	resolvedAt time.Time
+	expiresAt  time.Time
+}
+
+// backendHostResolver resolves the hostnames of HTTPProxy backends and caches
+// the results until their TTL expires, clamped to the configured refresh
+// intervals. The previous result is served when a lookup fails.
+type backendHostResolver struct {
+	lookup             lookupHostFunc
+	minRefreshInterval time.Duration
+	maxRefreshInterval time.Duration
+	now                func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]resolvedBackendHost
+}
+
+func newBackendHostResolver(cfg config.BackendResolutionConfig) (*backendHostResolver, error) {
+	exchange, err := dnsutil.NewExchange(cfg.Nameserver, defaultDNSQueryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backend resolver: %w", err)
+	}
+	return &backendHostResolver{
+		lookup:             dnsLookupHost(exchange),
+		minRefreshInterval: cfg.MinRefreshInterval.Duration,
+		maxRefreshInterval: cfg.MaxRefreshInterval.Duration,
+		now:                time.Now,
+		cache:              map[string]resolvedBackendHost{},
+	}, nil
+}
+
+func (r *backendHostResolver) resolve(ctx context.Context, host string) (resolvedBackendHost, error) {
+	host = strings.ToLower(host)
+	now := r.now()
+
+	r.mu.Lock()
+	cached, ok := r.cache[host]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached, nil
+	}
+
+	addresses, ttl, err := r.lookup(ctx, host)
+	if err == nil && len(addresses) == 0 {
+		err = fmt.Errorf("no addresses found for %q", host)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		if !ok {
+			return resolvedBackendHost{}, err
+		}
+		log.FromContext(ctx).Error(err, "failed resolving backend hostname, using previous addresses", "hostname", host)
+		cached.expiresAt = now.Add(r.minRefreshInterval)
+		r.cache[host] = cached
+		return cached, nil
+	}
+
+	// Remove hostnames that are no longer looked up by any backend.
+	for h, entry := range r.cache {
+		if now.Sub(entry.expiresAt) > r.maxRefreshInterval {
+			delete(r.cache, h)
+		}
+	}
+
+	slices.SortFunc(addresses, func(a, b netip.Addr) int { return a.Compare(b) })
+	resolved := resolvedBackendHost{
+		addresses:  slices.Compact(addresses),
+		resolvedAt: now,
+		expiresAt:  now.Add(min(max(ttl, r.minRefreshInterval), r.maxRefreshInterval)),
+	}
+	r.cache[host] = resolved
+	return resolved, nil
+}
+
+// backendsResolvedCondition returns the BackendsResolved condition for the
+// HTTPProxy, listing the backend hostnames that could not be resolved.
+func backendsResolvedCondition(httpProxy *networkingv1alpha.HTTPProxy, unresolvedHosts []string) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               networkingv1alpha.HTTPProxyConditionBackendsResolved,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.HTTPProxyReasonBackendsResolved,
+		Message:            "The hostnames of all backends are resolved",
+		ObservedGeneration: httpProxy.Generation,
+	}
+	if len(unresolvedHosts) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = networkingv1alpha.HTTPProxyReasonResolutionFailed
+		condition.Message = fmt.Sprintf("The hostnames [%s] could not be resolved, their backends are resolved by the gateway until they can be",
+			strings.Join(unresolvedHosts, ", "))
+	}
+	return condition
+}
+
+// endpointsForAddresses returns the address type and endpoints for an
+// EndpointSlice of resolved backend addresses. An EndpointSlice holds a single
+// address family, so IPv4 addresses are preferred when both are present and
+// IPv4 is enabled on gateways. IPv6 only gateways only use IPv6 addresses.
+func endpointsForAddresses(addresses []netip.Addr, ipv4Enabled bool) (discoveryv1.AddressType, []discoveryv1.Endpoint) {
+	addressType := discoveryv1.AddressTypeIPv6
+	if ipv4Enabled && slices.ContainsFunc(addresses, netip.Addr.Is4) {
+		addressType = discoveryv1.AddressTypeIPv4
+	}
+
+	var endpoints []discoveryv1.Endpoint
+	for _, addr := range addresses {
+		if addr.Is4() != (addressType == discoveryv1.AddressTypeIPv4) {
+			continue
+		}
+		endpoints = append(endpoints, discoveryv1.Endpoint{
+			Addresses: []string{addr.String()},
+			Conditions: discoveryv1.EndpointConditions{
+				Ready:       ptr.To(true),
+				Serving:     ptr.To(true),
+				Terminating: ptr.To(false),
+			},
+		})
+	}
+	return addressType, endpoints
+}
+
+// dnsLookupHost returns a lookupHostFunc that looks up the A and AAAA records
+// of a hostname. Unlike net.Resolver, it reports the TTL of the answers.
+func dnsLookupHost(exchange dnsutil.ExchangeFunc) lookupHostFunc {
+	return func(ctx context.Context, host string) ([]netip.Addr, time.Duration, error) {
+		var addresses []netip.Addr
+		var ttl time.Duration
+		for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+			answers, answerTTL, err := dnsutil.LookupAddressesWithTTL(ctx, exchange, host, qtype)
+			if err != nil {
+				return nil, 0, err
+			}
+			addresses = append(addresses, answers...)
+			if len(answers) > 0 && (ttl == 0 || answerTTL < ttl) {
+				ttl = answerTTL
+			}
+		}
+		return addresses, ttl, nil
+	}
+}
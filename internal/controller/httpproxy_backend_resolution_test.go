@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func newTestBackendHostResolver(now *time.Time, lookup lookupHostFunc) *backendHostResolver {
+	return &backendHostResolver{
+		lookup:             lookup,
+		minRefreshInterval: 30 * time.Second,
+		maxRefreshInterval: time.Hour,
+		now:                func() time.Time { return *now },
+		cache:              map[string]resolvedBackendHost{},
+	}
+}
+
+func TestBackendHostResolver(t *testing.T) {
+	now := time.Now()
+	addresses := []netip.Addr{netip.MustParseAddr("192.0.2.2"), netip.MustParseAddr("192.0.2.1")}
+	ttl := 5 * time.Minute
+	var lookupErr error
+	var lookups int
+	resolver := newTestBackendHostResolver(&now, func(ctx context.Context, host string) ([]netip.Addr, time.Duration, error) {
+		lookups++
+		return addresses, ttl, lookupErr
+	})
+
+	resolved, err := resolver.resolve(context.Background(), "www.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("192.0.2.1"), netip.MustParseAddr("192.0.2.2")}, resolved.addresses)
+	assert.Equal(t, now, resolved.resolvedAt)
+	assert.Equal(t, now.Add(5*time.Minute), resolved.expiresAt)
+
+	// Results are cached until the TTL expires.
+	now = now.Add(time.Minute)
+	_, err = resolver.resolve(context.Background(), "WWW.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 1, lookups)
+
+	now = now.Add(5 * time.Minute)
+	addresses = []netip.Addr{netip.MustParseAddr("192.0.2.3")}
+	ttl = time.Second
+	resolved, err = resolver.resolve(context.Background(), "www.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 2, lookups)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("192.0.2.3")}, resolved.addresses)
+	assert.Equal(t, now.Add(30*time.Second), resolved.expiresAt, "TTL should be clamped to the minimum refresh interval")
+
+	// Previous addresses are served when a lookup fails.
+	now = now.Add(time.Minute)
+	lookupErr = errors.New("timeout")
+	resolved, err = resolver.resolve(context.Background(), "www.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("192.0.2.3")}, resolved.addresses)
+	assert.Equal(t, now.Add(30*time.Second), resolved.expiresAt)
+
+	_, err = resolver.resolve(context.Background(), "api.example.com")
+	assert.ErrorContains(t, err, "timeout")
+
+	lookupErr = nil
+	addresses = nil
+	_, err = resolver.resolve(context.Background(), "api.example.com")
+	assert.ErrorContains(t, err, "no addresses found")
+}
+
+func TestEndpointsForAddresses(t *testing.T) {
+	dualStack := []netip.Addr{
+		netip.MustParseAddr("192.0.2.1"),
+		netip.MustParseAddr("2001:db8::1"),
+	}
+	addressType, endpoints := endpointsForAddresses(dualStack, true)
+	assert.Equal(t, discoveryv1.AddressTypeIPv4, addressType)
+	if assert.Len(t, endpoints, 1) {
+		assert.Equal(t, []string{"192.0.2.1"}, endpoints[0].Addresses)
+	}
+
+	addressType, endpoints = endpointsForAddresses([]netip.Addr{netip.MustParseAddr("2001:db8::1")}, true)
+	assert.Equal(t, discoveryv1.AddressTypeIPv6, addressType)
+	assert.Len(t, endpoints, 1)
+
+	// IPv6 only gateways never use IPv4 addresses.
+	addressType, endpoints = endpointsForAddresses(dualStack, false)
+	assert.Equal(t, discoveryv1.AddressTypeIPv6, addressType)
+	if assert.Len(t, endpoints, 1) {
+		assert.Equal(t, []string{"2001:db8::1"}, endpoints[0].Addresses)
+	}
+
+	addressType, endpoints = endpointsForAddresses([]netip.Addr{netip.MustParseAddr("192.0.2.1")}, false)
+	assert.Equal(t, discoveryv1.AddressTypeIPv6, addressType)
+	assert.Empty(t, endpoints)
+}
+
+func TestDNSLookupHost(t *testing.T) {
+	exchange := func(_ context.Context, name string, qtype uint16) (*dns.Msg, error) {
+		msg := new(dns.Msg)
+		msg.Answer = []dns.RR{
+			&dns.CNAME{Hdr: dns.RR_Header{Name: dns.Fqdn(name), Rrtype: dns.TypeCNAME, Class: dns.ClassINET, Ttl: 3600}, Target: "backend.example.net."},
+		}
+		header := dns.RR_Header{Name: "backend.example.net.", Rrtype: qtype, Class: dns.ClassINET, Ttl: 60}
+		if qtype == dns.TypeA {
+			msg.Answer = append(msg.Answer, &dns.A{Hdr: header, A: net.ParseIP("192.0.2.1")})
+		} else {
+			header.Ttl = 120
+			msg.Answer = append(msg.Answer, &dns.AAAA{Hdr: header, AAAA: net.ParseIP("2001:db8::1")})
+		}
+		return msg, nil
+	}
+
+	addresses, ttl, err := dnsLookupHost(exchange)(context.Background(), "www.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("192.0.2.1"), netip.MustParseAddr("2001:db8::1")}, addresses)
+	assert.Equal(t, time.Minute, ttl)
+
+	_, _, err = dnsLookupHost(func(context.Context, string, uint16) (*dns.Msg, error) {
+		return nil, errors.New("timeout")
+	})(context.Background(), "www.example.com")
+	assert.ErrorContains(t, err, "timeout")
+}
+
+func TestHTTPProxyCollectDesiredResourcesResolvesBackends(t *testing.T) {
+	now := time.Now()
+	reconciler := &HTTPProxyReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway:   config.GatewayConfig{TargetDomain: "example.com"},
+			HTTPProxy: config.HTTPProxyConfig{GatewayClassName: "test"},
+		},
+		backendResolver: newTestBackendHostResolver(&now, func(ctx context.Context, host string) ([]netip.Addr, time.Duration, error) {
+			assert.Equal(t, "www.example.com", host)
+			return []netip.Addr{netip.MustParseAddr("192.0.2.1")}, 5 * time.Minute, nil
+		}),
+	}
+
+	httpProxy := newHTTPProxy(func(p *networkingv1alpha.HTTPProxy) {
+		p.Spec.Rules = append(p.Spec.Rules, networkingv1alpha.HTTPProxyRule{
+			Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "http://192.0.2.10"}},
+		})
+	})
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	desiredResources, err := reconciler.collectDesiredResources(context.Background(), "", cl, httpProxy)
+	require.NoError(t, err)
+
+	require.Len(t, desiredResources.endpointSlices, 2)
+	endpointSlice := desiredResources.endpointSlices[0]
+	assert.Equal(t, discoveryv1.AddressTypeIPv4, endpointSlice.AddressType)
+	if assert.Len(t, endpointSlice.Endpoints, 1) {
+		assert.Equal(t, []string{"192.0.2.1"}, endpointSlice.Endpoints[0].Addresses)
+	}
+	assert.Equal(t, "www.example.com", endpointSlice.Annotations[BackendCertHostnameAnnotation])
+
+	if assert.Len(t, desiredResources.backendStatuses, 1, "IP address backends are not resolved") {
+		status := desiredResources.backendStatuses[0]
+		assert.Equal(t, int32(0), status.RuleIndex)
+		assert.Equal(t, "http://www.example.com", status.Endpoint)
+		assert.Equal(t, []string{"192.0.2.1"}, status.Addresses)
+		assert.True(t, status.LastResolvedTime.Time.Equal(now))
+	}
+	assert.Equal(t, now.Add(5*time.Minute), desiredResources.backendsExpireAt)
+}
+
+func TestHTTPProxyCollectDesiredResourcesUnresolvableBackend(t *testing.T) {
+	now := time.Now()
+	reconciler := &HTTPProxyReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway:   config.GatewayConfig{TargetDomain: "example.com"},
+			HTTPProxy: config.HTTPProxyConfig{GatewayClassName: "test"},
+		},
+		backendResolver: newTestBackendHostResolver(&now, func(ctx context.Context, host string) ([]netip.Addr, time.Duration, error) {
+			if host == "missing.example.com" {
+				return nil, 0, nil
+			}
+			return []netip.Addr{netip.MustParseAddr("192.0.2.1")}, 5 * time.Minute, nil
+		}),
+	}
+
+	httpProxy := newHTTPProxy(func(p *networkingv1alpha.HTTPProxy) {
+		p.Spec.Rules = append(p.Spec.Rules, networkingv1alpha.HTTPProxyRule{
+			Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "http://missing.example.com"}},
+		})
+	})
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	desiredResources, err := reconciler.collectDesiredResources(context.Background(), "", cl, httpProxy)
+	require.NoError(t, err, "an unresolvable backend must not fail the other backends")
+
+	// The unresolvable backend keeps its hostname, the others are resolved.
+	require.Len(t, desiredResources.endpointSlices, 2)
+	assert.Equal(t, discoveryv1.AddressTypeIPv4, desiredResources.endpointSlices[0].AddressType)
+	unresolved := desiredResources.endpointSlices[1]
+	assert.Equal(t, discoveryv1.AddressTypeFQDN, unresolved.AddressType)
+	if assert.Len(t, unresolved.Endpoints, 1) {
+		assert.Equal(t, []string{"missing.example.com"}, unresolved.Endpoints[0].Addresses)
+	}
+
+	assert.Len(t, desiredResources.backendStatuses, 1)
+	assert.Equal(t, []string{"missing.example.com"}, desiredResources.unresolvedBackendHosts)
+	assert.Equal(t, now.Add(30*time.Second), desiredResources.backendsExpireAt, "unresolvable hostnames are retried")
+
+	condition := backendsResolvedCondition(httpProxy, desiredResources.unresolvedBackendHosts)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, networkingv1alpha.HTTPProxyReasonResolutionFailed, condition.Reason)
+	assert.Contains(t, condition.Message, "[missing.example.com]")
+
+	condition = backendsResolvedCondition(httpProxy, nil)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// defaultBlueGreenDrainTimeout is the drain timeout of blue/green traffic
+// policies that do not set one.
+const defaultBlueGreenDrainTimeout = 30 * time.Second
+
+func httpProxyRuleBlueGreen(rule networkingv1alpha.HTTPProxyRule) *networkingv1alpha.HTTPProxyBlueGreen {
+	if rule.TrafficPolicy == nil {
+		return nil
+	}
+	return rule.TrafficPolicy.BlueGreen
+}
+
+// blueGreenDrainTimeout returns how long the previously active backends of a
+// blue/green traffic policy are kept after a swap.
+func blueGreenDrainTimeout(blueGreen *networkingv1alpha.HTTPProxyBlueGreen) time.Duration {
+	drainTimeout, ok := parseRouteDuration(blueGreen.DrainTimeout)
+	if !ok || drainTimeout <= 0 {
+		// Invalid drain timeouts are rejected by validation.
+		return defaultBlueGreenDrainTimeout
+	}
+	return drainTimeout
+}
+
+// desiredBlueGreenStatus returns the status of the blue/green traffic policy of
+// the rule at ruleIndex. A change of the active set since the previous status
+// is recorded as a swap, which drains the previously active set until the
+// drain timeout has passed. The returned time is when the draining set is to be
+// removed, and is zero when no set is draining.
+func desiredBlueGreenStatus(
+	httpProxy *networkingv1alpha.HTTPProxy,
+	ruleIndex int,
+	blueGreen *networkingv1alpha.HTTPProxyBlueGreen,
+	now time.Time,
+) (networkingv1alpha.HTTPProxyBlueGreenStatus, time.Time) {
+	status := networkingv1alpha.HTTPProxyBlueGreenStatus{
+		RuleIndex: int32(ruleIndex),
+		Active:    blueGreen.Active,
+	}
+
+	var previous *networkingv1alpha.HTTPProxyBlueGreenStatus
+	for i := range httpProxy.Status.BlueGreen {
+		if httpProxy.Status.BlueGreen[i].RuleIndex == int32(ruleIndex) {
+			previous = &httpProxy.Status.BlueGreen[i]
+			break
+		}
+	}
+
+	switch {
+	case previous == nil:
+		// The first set to be active has nothing to drain.
+		return status, time.Time{}
+	case previous.Active != blueGreen.Active:
+		status.Draining = previous.Active
+		status.SwapTime = ptr.To(metav1.NewTime(now))
+	default:
+		status.Draining = previous.Draining
+		status.SwapTime = previous.SwapTime
+	}
+
+	if status.Draining == "" || status.SwapTime == nil {
+		status.Draining = ""
+		return status, time.Time{}
+	}
+
+	drainEndsAt := status.SwapTime.Add(blueGreenDrainTimeout(blueGreen))
+	if !now.Before(drainEndsAt) {
+		status.Draining = ""
+		return status, time.Time{}
+	}
+	return status, drainEndsAt
+}
+
+// blueGreenBackendRefs returns the backendRefs of the backends of a rule with a
+// blue/green traffic policy that are programmed downstream. Backends of the
+// active set keep their weights, while backends of the draining set are kept
+// with a weight of 0, so they receive no new requests while the requests in
+// flight complete. Backends of the inactive set are left out.
+func blueGreenBackendRefs(
+	rule networkingv1alpha.HTTPProxyRule,
+	backendRefs []gatewayv1.HTTPBackendRef,
+	status networkingv1alpha.HTTPProxyBlueGreenStatus,
+) []gatewayv1.HTTPBackendRef {
+	var desired []gatewayv1.HTTPBackendRef
+	for i, backend := range rule.Backends {
+		if i >= len(backendRefs) {
+			break
+		}
+		switch {
+		case backend.Role == status.Active:
+			desired = append(desired, backendRefs[i])
+		case status.Draining != "" && backend.Role == status.Draining:
+			backendRef := *backendRefs[i].DeepCopy()
+			backendRef.Weight = ptr.To[int32](0)
+			desired = append(desired, backendRef)
+		}
+	}
+	return desired
+}
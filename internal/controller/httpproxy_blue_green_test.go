@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestDesiredBlueGreenStatus(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	httpProxy := &networkingv1alpha.HTTPProxy{}
+	blueGreen := &networkingv1alpha.HTTPProxyBlueGreen{
+		Active:       networkingv1alpha.HTTPProxyBackendRoleBlue,
+		DrainTimeout: ptr.To(gatewayv1.Duration("1m")),
+	}
+
+	// The first active set has nothing to drain.
+	status, drainEndsAt := desiredBlueGreenStatus(httpProxy, 1, blueGreen, now)
+	assert.Equal(t, networkingv1alpha.HTTPProxyBlueGreenStatus{RuleIndex: 1, Active: networkingv1alpha.HTTPProxyBackendRoleBlue}, status)
+	assert.True(t, drainEndsAt.IsZero())
+	httpProxy.Status.BlueGreen = []networkingv1alpha.HTTPProxyBlueGreenStatus{status}
+
+	// Swapping drains the previously active set.
+	blueGreen.Active = networkingv1alpha.HTTPProxyBackendRoleGreen
+	status, drainEndsAt = desiredBlueGreenStatus(httpProxy, 1, blueGreen, now)
+	assert.Equal(t, networkingv1alpha.HTTPProxyBackendRoleGreen, status.Active)
+	assert.Equal(t, networkingv1alpha.HTTPProxyBackendRoleBlue, status.Draining)
+	require.NotNil(t, status.SwapTime)
+	assert.True(t, status.SwapTime.Time.Equal(now))
+	assert.Equal(t, now.Add(time.Minute), drainEndsAt)
+	httpProxy.Status.BlueGreen = []networkingv1alpha.HTTPProxyBlueGreenStatus{status}
+
+	// The draining set is kept until the drain timeout has passed.
+	status, drainEndsAt = desiredBlueGreenStatus(httpProxy, 1, blueGreen, now.Add(30*time.Second))
+	assert.Equal(t, networkingv1alpha.HTTPProxyBackendRoleBlue, status.Draining)
+	assert.Equal(t, now.Add(time.Minute), drainEndsAt)
+
+	status, drainEndsAt = desiredBlueGreenStatus(httpProxy, 1, blueGreen, now.Add(time.Minute))
+	assert.Empty(t, status.Draining)
+	require.NotNil(t, status.SwapTime)
+	assert.True(t, status.SwapTime.Time.Equal(now))
+	assert.True(t, drainEndsAt.IsZero())
+
+	// Statuses of other rules are not considered.
+	status, drainEndsAt = desiredBlueGreenStatus(httpProxy, 0, blueGreen, now)
+	assert.Empty(t, status.Draining)
+	assert.Nil(t, status.SwapTime)
+	assert.True(t, drainEndsAt.IsZero())
+}
+
+func TestBlueGreenDrainTimeout(t *testing.T) {
+	assert.Equal(t, defaultBlueGreenDrainTimeout, blueGreenDrainTimeout(&networkingv1alpha.HTTPProxyBlueGreen{}))
+	assert.Equal(t, 5*time.Minute, blueGreenDrainTimeout(&networkingv1alpha.HTTPProxyBlueGreen{DrainTimeout: ptr.To(gatewayv1.Duration("5m"))}))
+}
+
+func TestBlueGreenBackendRefs(t *testing.T) {
+	rule := networkingv1alpha.HTTPProxyRule{
+		Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+			{Endpoint: "https://blue.example.com", Role: networkingv1alpha.HTTPProxyBackendRoleBlue},
+			{Endpoint: "https://green-a.example.com", Role: networkingv1alpha.HTTPProxyBackendRoleGreen, Weight: ptr.To[int32](3)},
+			{Endpoint: "https://green-b.example.com", Role: networkingv1alpha.HTTPProxyBackendRoleGreen, Weight: ptr.To[int32](1)},
+		},
+	}
+	backendRefs := []gatewayv1.HTTPBackendRef{
+		{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "blue"}}},
+		{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "green-a"}, Weight: ptr.To[int32](3)}},
+		{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "green-b"}, Weight: ptr.To[int32](1)}},
+	}
+
+	// Only the active set is programmed when nothing is draining.
+	desired := blueGreenBackendRefs(rule, backendRefs, networkingv1alpha.HTTPProxyBlueGreenStatus{
+		Active: networkingv1alpha.HTTPProxyBackendRoleBlue,
+	})
+	require.Len(t, desired, 1)
+	assert.Equal(t, gatewayv1.ObjectName("blue"), desired[0].Name)
+
+	// The draining set is kept without receiving new requests.
+	desired = blueGreenBackendRefs(rule, backendRefs, networkingv1alpha.HTTPProxyBlueGreenStatus{
+		Active:   networkingv1alpha.HTTPProxyBackendRoleGreen,
+		Draining: networkingv1alpha.HTTPProxyBackendRoleBlue,
+	})
+	require.Len(t, desired, 3)
+	assert.Equal(t, gatewayv1.ObjectName("blue"), desired[0].Name)
+	assert.Equal(t, ptr.To[int32](0), desired[0].Weight)
+	assert.Equal(t, ptr.To[int32](3), desired[1].Weight)
+	assert.Equal(t, ptr.To[int32](1), desired[2].Weight)
+	assert.Nil(t, backendRefs[0].Weight, "backendRefs of the rule must not be modified")
+}
@@ -11,6 +11,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	v1 "k8s.io/api/core/v1"
@@ -20,13 +21,14 @@ import (
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -39,11 +41,13 @@ import (
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
+	"go.datum.net/network-services-operator/internal/backendhealth"
 	"go.datum.net/network-services-operator/internal/config"
 	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
 	conditionutil "go.datum.net/network-services-operator/internal/util/condition"
 	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 	"go.datum.net/network-services-operator/internal/util/resourcename"
+	"go.datum.net/network-services-operator/internal/validation"
 	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
 )
 
@@ -53,6 +57,17 @@ type HTTPProxyReconciler struct {
 	Config config.NetworkServicesOperator
 
 	DownstreamCluster cluster.Cluster
+
+	// BackendHealth, when set, reports the health of the endpoints of health
+	// checked rules in the status of HTTPProxies.
+	BackendHealth backendhealth.Source
+
+	// UpstreamOutages, when set, defers status updates of HTTPProxies while the
+	// API server of their cluster is unreachable.
+	UpstreamOutages *UpstreamOutageTracker
+
+	backendResolver     *backendHostResolver
+	connectorAddressing *connectorAddressingPropagator
 }
 
 type desiredHTTPProxyResources struct {
@@ -60,11 +75,38 @@ type desiredHTTPProxyResources struct {
 	httpRoute        *gatewayv1.HTTPRoute
 	endpointSlices   []*discoveryv1.EndpointSlice
 	httpRouteFilters []*envoygatewayv1alpha1.HTTPRouteFilter
+
+	backendTrafficPolicies []*envoygatewayv1alpha1.BackendTrafficPolicy
+
+	// authSecurityPolicy programs the auth of the HTTPProxy, and is nil when
+	// auth is not configured.
+	authSecurityPolicy *envoygatewayv1alpha1.SecurityPolicy
+
+	// backendStatuses and backendsExpireAt are set when backend hostnames are
+	// resolved into IP addresses. backendsExpireAt is when the first resolved
+	// hostname needs to be resolved again.
+	backendStatuses  []networkingv1alpha.HTTPProxyBackendStatus
+	backendsExpireAt time.Time
+	// unresolvedBackendHosts are the backend hostnames that could not be
+	// resolved. Their EndpointSlices keep the hostname, and they are resolved
+	// again with the other hostnames at backendsExpireAt.
+	unresolvedBackendHosts []string
+
+	// blueGreenStatuses are the statuses of the rules with a blue/green traffic
+	// policy. blueGreenDrainEndsAt is when the first draining backend set is to
+	// be removed.
+	blueGreenStatuses    []networkingv1alpha.HTTPProxyBlueGreenStatus
+	blueGreenDrainEndsAt time.Time
 }
 
 const httpProxyFinalizer = "networking.datumapis.com/httpproxy-cleanup"
 const connectorOfflineFilterPrefix = "connector-offline"
 
+// connectorPlaceholderAddress is the address of the upstream EndpointSlices of
+// connector backends, which are reached through the connector's tunnel instead
+// of their addresses.
+const connectorPlaceholderAddress = "connector.local"
+
 // BackendCertHostnameAnnotation is set on the upstream EndpointSlice by the
 // HTTPProxy controller to record the hostname expected on the backend's TLS
 // certificate. The gateway controller reads it when building a
@@ -86,9 +128,11 @@ const (
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=httpproxies/finalizers,verbs=update
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=connectors,verbs=get;list;watch
 // +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=httproutefilters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=backendtrafficpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=securitypolicies,verbs=get;list;watch;create;update;patch;delete
 // HTTPProxy controller reads cert-manager Certificate resources in the downstream cluster for status; ensure downstream role has cert-manager.io/certificates get;list;watch.
 
-func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (_ ctrl.Result, err error) {
+func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (res ctrl.Result, err error) {
 	logger := log.FromContext(ctx, "cluster", req.ClusterName)
 	ctx = log.IntoContext(ctx, logger)
 
@@ -96,6 +140,10 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	cl, upstreamCalls := recordUpstreamCalls(cl)
+	defer func() {
+		res, err = r.UpstreamOutages.complete(ctx, string(req.ClusterName), upstreamCalls, res, err)
+	}()
 
 	var httpProxy networkingv1alpha.HTTPProxy
 	if err := cl.GetClient().Get(ctx, req.NamespacedName, &httpProxy); err != nil {
@@ -105,19 +153,37 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 		return ctrl.Result{}, err
 	}
 
+	terminating, err := upstreamNamespaceTerminating(ctx, cl.GetClient(), httpProxy.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if !httpProxy.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(&httpProxy, httpProxyFinalizer) {
-			if err := r.cleanupConnectorEnvoyPatchPolicy(ctx, cl.GetClient(), string(req.ClusterName), &httpProxy); err != nil {
+			if terminating && r.DownstreamCluster != nil {
+				downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), cl.GetClient(), r.DownstreamCluster.GetClient(), downstreamclient.WithControllerName("httpproxy"))
+				if err := teardownDownstreamNamespace(ctx, downstreamStrategy, string(req.ClusterName), httpProxy.Namespace); err != nil {
+					return ctrl.Result{}, err
+				}
+			} else if err := r.cleanupConnectorEnvoyPatchPolicy(ctx, cl.GetClient(), string(req.ClusterName), &httpProxy); err != nil {
 				return ctrl.Result{}, err
 			}
 			controllerutil.RemoveFinalizer(&httpProxy, httpProxyFinalizer)
 			if err := cl.GetClient().Update(ctx, &httpProxy); err != nil {
 				return ctrl.Result{}, err
 			}
+			if r.connectorAddressing != nil {
+				r.connectorAddressing.forget(string(req.ClusterName), &httpProxy)
+			}
 		}
 		return ctrl.Result{}, nil
 	}
 
+	if terminating {
+		logger.Info("namespace is terminating, waiting for httpproxy deletion")
+		return ctrl.Result{}, nil
+	}
+
 	logger.Info("reconciling httpproxy")
 	defer logger.Info("reconcile complete")
 
@@ -158,6 +224,9 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 	}
 	setTunnelMetadataCondition := false
 
+	var gateway *gatewayv1.Gateway
+	var httpRoute *gatewayv1.HTTPRoute
+
 	defer func() {
 		apimeta.SetStatusCondition(&httpProxyCopy.Status.Conditions, *acceptedCondition)
 		apimeta.SetStatusCondition(&httpProxyCopy.Status.Conditions, *programmedCondition)
@@ -166,17 +235,40 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 		} else {
 			apimeta.RemoveStatusCondition(&httpProxyCopy.Status.Conditions, networkingv1alpha.HTTPProxyConditionConnectorMetadataProgrammed)
 		}
+		r.setHTTPProxyReadiness(httpProxyCopy, programmedCondition, gateway, httpRoute)
+		httpProxyCopy.Status.Warnings = validation.HTTPProxyWarnings(&httpProxy)
+
+		// Status computed while the API server is unreachable is not written
+		// until the outage exceeds the threshold.
+		if deferred, _ := r.UpstreamOutages.deferStatus(ctx, string(req.ClusterName), upstreamCalls, &httpProxyCopy.Status.Conditions, httpProxy.Generation); deferred {
+			return
+		}
 
 		if !equality.Semantic.DeepEqual(httpProxy.Status, httpProxyCopy.Status) {
+			origStatus := httpProxy.Status
 			httpProxy.Status = httpProxyCopy.Status
 			if statusErr := cl.GetClient().Status().Update(ctx, &httpProxy); statusErr != nil {
 				err = errors.Join(err, fmt.Errorf("failed updating httpproxy status: %w", statusErr))
+				return
 			}
 			logger.Info("httpproxy status updated")
+			recordHTTPProxyEvents(eventRecorderFor(cl), &httpProxy, &origStatus)
+		}
+
+		// Routes backed by connectors are pushed the current connector addressing
+		// as soon as the HTTPProxy is programmed. Each time the HTTPProxy becomes
+		// Programmed is swept once.
+		if programmed := httpProxyProgrammedCondition(&httpProxy.Status); programmed != nil &&
+			r.connectorAddressing != nil && r.DownstreamCluster != nil && httpProxyHasConnectorBackends(&httpProxy) {
+			downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), cl.GetClient(), r.DownstreamCluster.GetClient(), downstreamclient.WithControllerName("httpproxy"))
+			token := connectorAddressingSweepToken(&httpProxy, programmed)
+			if sweepErr := r.connectorAddressing.sweep(ctx, string(req.ClusterName), cl.GetClient(), downstreamStrategy, &httpProxy, token); sweepErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed sweeping connector addressing: %w", sweepErr))
+			}
 		}
 	}()
 
-	desiredResources, err := r.collectDesiredResources(ctx, cl.GetClient(), &httpProxy)
+	desiredResources, err := r.collectDesiredResources(ctx, string(req.ClusterName), cl.GetClient(), &httpProxy)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to collect desired resources: %w", err)
 	}
@@ -184,7 +276,7 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 	// Maintain a Gateway for the HTTPProxy, handle conflicts in names by updating the
 	// Programmed condition with info about the conflict.
 
-	gateway := desiredResources.gateway.DeepCopy()
+	gateway = desiredResources.gateway.DeepCopy()
 
 	result, err := controllerutil.CreateOrUpdate(ctx, cl.GetClient(), gateway, func() error {
 		if hasControllerConflict(gateway, &httpProxy) {
@@ -213,6 +305,43 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 
 		gateway.Spec = desiredResources.gateway.Spec
 
+		if v, ok := httpProxy.Annotations[gatewayutil.ManifestExportAnnotation]; ok {
+			metav1.SetMetaDataAnnotation(&gateway.ObjectMeta, gatewayutil.ManifestExportAnnotation, v)
+		} else {
+			delete(gateway.Annotations, gatewayutil.ManifestExportAnnotation)
+		}
+
+		if v, ok := desiredResources.gateway.Annotations[GatewayTLSPolicyAnnotation]; ok {
+			metav1.SetMetaDataAnnotation(&gateway.ObjectMeta, GatewayTLSPolicyAnnotation, v)
+		} else {
+			delete(gateway.Annotations, GatewayTLSPolicyAnnotation)
+		}
+
+		if v, ok := desiredResources.gateway.Annotations[GatewayHTTP3Annotation]; ok {
+			metav1.SetMetaDataAnnotation(&gateway.ObjectMeta, GatewayHTTP3Annotation, v)
+		} else {
+			delete(gateway.Annotations, GatewayHTTP3Annotation)
+		}
+
+		if v, ok := desiredResources.gateway.Annotations[GatewayPausedAnnotation]; ok {
+			metav1.SetMetaDataAnnotation(&gateway.ObjectMeta, GatewayPausedAnnotation, v)
+		} else {
+			delete(gateway.Annotations, GatewayPausedAnnotation)
+		}
+
+		for _, annotation := range []string{
+			GatewayDNSRecordTTLAnnotation,
+			GatewayDNSRecordPolicyAnnotation,
+			GatewayDNSVisibilityAnnotation,
+			GatewayInternalDNSZoneAnnotation,
+		} {
+			if v, ok := httpProxy.Annotations[annotation]; ok {
+				metav1.SetMetaDataAnnotation(&gateway.ObjectMeta, annotation, v)
+			} else {
+				delete(gateway.Annotations, annotation)
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -229,34 +358,35 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 	}
 
 	logger.Info("processed gateway", jsonKeyName, gateway.Name, "result", result)
+	if result == controllerutil.OperationResultCreated {
+		eventRecorderFor(cl).Eventf(&httpProxy, gateway, v1.EventTypeNormal, "GatewayCreated", "Create", "Created gateway %s", gateway.Name)
+	}
 
 	// Maintain an HTTPRoute for all rules in the HTTPProxy
 
-	if len(desiredResources.httpRouteFilters) == 0 {
-		if err := cleanupConnectorOfflineHTTPRouteFilter(ctx, cl.GetClient(), &httpProxy); err != nil {
-			return ctrl.Result{}, err
-		}
-	} else {
-		for _, desiredFilter := range desiredResources.httpRouteFilters {
-			httpRouteFilter := desiredFilter.DeepCopy()
-			result, err := controllerutil.CreateOrUpdate(ctx, cl.GetClient(), httpRouteFilter, func() error {
-				if err := controllerutil.SetControllerReference(&httpProxy, httpRouteFilter, cl.GetScheme()); err != nil {
-					return fmt.Errorf("failed to set controller on HTTPRouteFilter: %w", err)
-				}
-				httpRouteFilter.Spec = desiredFilter.Spec
-				return nil
-			})
-			if err != nil {
-				if apierrors.IsConflict(err) {
-					return ctrl.Result{RequeueAfter: retryAfterConflict}, nil
-				}
-				return ctrl.Result{}, fmt.Errorf("failed updating httproutefilter resource: %w", err)
+	for _, desiredFilter := range desiredResources.httpRouteFilters {
+		httpRouteFilter := desiredFilter.DeepCopy()
+		result, err := controllerutil.CreateOrUpdate(ctx, cl.GetClient(), httpRouteFilter, func() error {
+			if err := controllerutil.SetControllerReference(&httpProxy, httpRouteFilter, cl.GetScheme()); err != nil {
+				return fmt.Errorf("failed to set controller on HTTPRouteFilter: %w", err)
 			}
-			logger.Info("processed httproutefilter", jsonKeyName, httpRouteFilter.Name, "result", result)
+			httpRouteFilter.Spec = desiredFilter.Spec
+			return nil
+		})
+		if err != nil {
+			if apierrors.IsConflict(err) {
+				return ctrl.Result{RequeueAfter: retryAfterConflict}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("failed updating httproutefilter resource: %w", err)
 		}
+		logger.Info("processed httproutefilter", jsonKeyName, httpRouteFilter.Name, "result", result)
+	}
+
+	if err := cleanupStaleHTTPRouteFilters(ctx, cl.GetClient(), &httpProxy, desiredResources.httpRouteFilters); err != nil {
+		return ctrl.Result{}, err
 	}
 
-	httpRoute := desiredResources.httpRoute.DeepCopy()
+	httpRoute = desiredResources.httpRoute.DeepCopy()
 
 	result, err = controllerutil.CreateOrUpdate(ctx, cl.GetClient(), httpRoute, func() error {
 		if hasControllerConflict(httpRoute, &httpProxy) {
@@ -288,6 +418,20 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 
 	logger.Info("processed httproute", jsonKeyName, httpRoute.Name, "result", result)
 
+	if err := reconcileLoadBalancerPolicies(ctx, cl.GetClient(), cl.GetScheme(), &httpProxy, desiredResources.backendTrafficPolicies); err != nil {
+		if apierrors.IsConflict(err) {
+			return ctrl.Result{RequeueAfter: retryAfterConflict}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err := reconcileAuthSecurityPolicy(ctx, cl.GetClient(), cl.GetScheme(), &httpProxy, desiredResources.authSecurityPolicy); err != nil {
+		if apierrors.IsConflict(err) {
+			return ctrl.Result{RequeueAfter: retryAfterConflict}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
 	for _, desiredEndpointSlice := range desiredResources.endpointSlices {
 		endpointSlice := desiredEndpointSlice.DeepCopy()
 
@@ -322,17 +466,30 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 			endpointSlice.Endpoints = desiredEndpointSlice.Endpoints
 			endpointSlice.Ports = desiredEndpointSlice.Ports
 
-			// Keep the backend cert hostname annotation in sync. The gateway
-			// controller reads this to build the BackendTLSPolicy when the
-			// URLRewrite filter carries a user Host override instead of the
-			// real backend FQDN.
-			if v, ok := desiredEndpointSlice.Annotations[BackendCertHostnameAnnotation]; ok {
-				if endpointSlice.Annotations == nil {
-					endpointSlice.Annotations = map[string]string{}
+			// Keep the annotations read by the gateway controller in sync. The
+			// backend cert hostname is used to build the BackendTLSPolicy when the
+			// URLRewrite filter carries a user Host override instead of the real
+			// backend FQDN, and the role, health check and load balancer program
+			// failover, health checking and load balancing of health checked
+			// rules. The CA certificate ref and subject alt names
+			// program certificate validation of backends with a private PKI.
+			for _, annotation := range []string{
+				BackendCertHostnameAnnotation,
+				BackendRoleAnnotation,
+				BackendHealthCheckAnnotation,
+				BackendLoadBalancerAnnotation,
+				BackendTrafficSplitAnnotation,
+				BackendCACertificateRefAnnotation,
+				BackendSubjectAltNamesAnnotation,
+			} {
+				if v, ok := desiredEndpointSlice.Annotations[annotation]; ok {
+					if endpointSlice.Annotations == nil {
+						endpointSlice.Annotations = map[string]string{}
+					}
+					endpointSlice.Annotations[annotation] = v
+				} else {
+					delete(endpointSlice.Annotations, annotation)
 				}
-				endpointSlice.Annotations[BackendCertHostnameAnnotation] = v
-			} else {
-				delete(endpointSlice.Annotations, BackendCertHostnameAnnotation)
 			}
 			return nil
 		})
@@ -402,11 +559,34 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 		}
 	}
 
+	var patchPolicyRejection *envoyPatchPolicyRejection
+	if hasConnectorBackends && patchPolicy != nil {
+		patchPolicyRejection = envoyPatchPolicyRejectionOf(patchPolicy)
+	}
+
+	if patchPolicyRejection != nil {
+		apimeta.SetStatusCondition(&httpProxyCopy.Status.Conditions, metav1.Condition{
+			Type:               networkingv1alpha.HTTPProxyConditionPolicyProgrammingFailed,
+			Status:             metav1.ConditionTrue,
+			Reason:             patchPolicyRejection.Reason,
+			ObservedGeneration: httpProxy.Generation,
+			Message:            patchPolicyRejection.String(),
+		})
+	} else {
+		apimeta.RemoveStatusCondition(&httpProxyCopy.Status.Conditions, networkingv1alpha.HTTPProxyConditionPolicyProgrammingFailed)
+	}
+
 	if hasConnectorBackends {
 		connectorPolicyReady, connectorPolicyMessage := downstreamPatchPolicyReady(
 			patchPolicy,
 			r.Config.Gateway.DownstreamGatewayClassName,
 		)
+		if patchPolicyRejection != nil {
+			// The rolled back policy may be programmed, but it does not hold the
+			// desired connector metadata.
+			connectorPolicyReady = false
+			connectorPolicyMessage = patchPolicyRejection.String()
+		}
 		if !connectorPolicyReady {
 			programmedCondition.Status = metav1.ConditionFalse
 			programmedCondition.Reason = networkingv1alpha.HTTPProxyReasonPending
@@ -428,9 +608,63 @@ func (r *HTTPProxyReconciler) Reconcile(ctx context.Context, req mcreconcile.Req
 		apimeta.RemoveStatusCondition(&httpProxyCopy.Status.Conditions, networkingv1alpha.HTTPProxyConditionConnectorMetadataProgrammed)
 	}
 
+	if pausedCondition := httpProxyPausedCondition(&httpProxy, gateway); pausedCondition != nil {
+		apimeta.SetStatusCondition(&httpProxyCopy.Status.Conditions, *pausedCondition)
+	} else {
+		apimeta.RemoveStatusCondition(&httpProxyCopy.Status.Conditions, networkingv1alpha.HTTPProxyConditionPaused)
+	}
+
+	if degradedCondition := httpProxyDownstreamDegradedCondition(&httpProxy, gateway); degradedCondition != nil {
+		apimeta.SetStatusCondition(&httpProxyCopy.Status.Conditions, *degradedCondition)
+	} else {
+		apimeta.RemoveStatusCondition(&httpProxyCopy.Status.Conditions, networkingv1alpha.HTTPProxyConditionDownstreamDegraded)
+	}
+
+	healthChecksProgrammedCondition, err := r.healthChecksProgrammedCondition(ctx, string(req.ClusterName), cl.GetClient(), &httpProxy, httpRoute)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if healthChecksProgrammedCondition != nil {
+		apimeta.SetStatusCondition(&httpProxyCopy.Status.Conditions, *healthChecksProgrammedCondition)
+	} else {
+		apimeta.RemoveStatusCondition(&httpProxyCopy.Status.Conditions, networkingv1alpha.HTTPProxyConditionHealthChecksProgrammed)
+	}
+
+	backendHealthRefreshAfter := r.refreshBackendHealth(ctx, string(req.ClusterName), cl.GetClient(), httpProxyCopy, httpRoute)
+	if healthyCondition := backendsHealthyCondition(httpProxyCopy); healthyCondition != nil {
+		apimeta.SetStatusCondition(&httpProxyCopy.Status.Conditions, *healthyCondition)
+	} else {
+		apimeta.RemoveStatusCondition(&httpProxyCopy.Status.Conditions, networkingv1alpha.HTTPProxyConditionBackendsHealthy)
+	}
+	if failoverCondition := backendFailoverCondition(httpProxyCopy); failoverCondition != nil {
+		apimeta.SetStatusCondition(&httpProxyCopy.Status.Conditions, *failoverCondition)
+	} else {
+		apimeta.RemoveStatusCondition(&httpProxyCopy.Status.Conditions, networkingv1alpha.HTTPProxyConditionBackendFailover)
+	}
+
 	r.reconcileHTTPProxyHostnameStatus(ctx, cl.GetClient(), gateway, httpProxyCopy, string(req.ClusterName))
 
-	return ctrl.Result{}, nil
+	httpProxyCopy.Status.Backends = desiredResources.backendStatuses
+	if len(desiredResources.backendStatuses) > 0 || len(desiredResources.unresolvedBackendHosts) > 0 {
+		apimeta.SetStatusCondition(&httpProxyCopy.Status.Conditions, backendsResolvedCondition(httpProxyCopy, desiredResources.unresolvedBackendHosts))
+	} else {
+		apimeta.RemoveStatusCondition(&httpProxyCopy.Status.Conditions, networkingv1alpha.HTTPProxyConditionBackendsResolved)
+	}
+	httpProxyCopy.Status.BlueGreen = desiredResources.blueGreenStatuses
+
+	requeueAt := desiredResources.backendsExpireAt
+	if drainEndsAt := desiredResources.blueGreenDrainEndsAt; !drainEndsAt.IsZero() && (requeueAt.IsZero() || drainEndsAt.Before(requeueAt)) {
+		requeueAt = drainEndsAt
+	}
+	requeueAfter := backendHealthRefreshAfter
+	if !requeueAt.IsZero() {
+		untilRequeue := max(time.Until(requeueAt), time.Second)
+		if requeueAfter == 0 || untilRequeue < requeueAfter {
+			requeueAfter = untilRequeue
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *HTTPProxyReconciler) reconcileHTTPProxyHostnameStatus(
@@ -468,6 +702,7 @@ func (r *HTTPProxyReconciler) reconcileHTTPProxyHostnameStatus(
 	acceptedHostnames := sets.New[gatewayv1.Hostname]()
 	nonAcceptedHostnames := sets.New[string]()
 	inUseHostnames := sets.New[string]()
+	blockedHostnames := sets.New[string]()
 	for _, listener := range gateway.Spec.Listeners {
 		if listener.Hostname == nil {
 			// Should only happen shortly after creation, before the default hostnames
@@ -487,6 +722,8 @@ func (r *HTTPProxyReconciler) reconcileHTTPProxyHostnameStatus(
 				acceptedHostnames.Insert(*listener.Hostname)
 			} else if listenerAcceptedCondition.Reason == networkingv1alpha.HostnameInUseReason {
 				inUseHostnames.Insert(string(*listener.Hostname))
+			} else if listenerAcceptedCondition.Reason == networkingv1alpha.HostnamePolicyViolationReason {
+				blockedHostnames.Insert(string(*listener.Hostname))
 			} else {
 				nonAcceptedHostnames.Insert(string(*listener.Hostname))
 			}
@@ -508,7 +745,11 @@ func (r *HTTPProxyReconciler) reconcileHTTPProxyHostnameStatus(
 		})
 		hostnamesVerifiedCondition.ObservedGeneration = httpProxyCopy.Generation
 
-		if nonAcceptedHostnames.Len() > 0 {
+		if blockedHostnames.Len() > 0 {
+			hostnamesVerifiedCondition.Status = metav1.ConditionFalse
+			hostnamesVerifiedCondition.Reason = networkingv1alpha.HostnamePolicyViolationReason
+			hostnamesVerifiedCondition.Message = fmt.Sprintf("hostnames are not permitted by platform policy: %s", strings.Join(sets.List(blockedHostnames), ","))
+		} else if nonAcceptedHostnames.Len() > 0 {
 			nonAcceptedHostnamesSlice := nonAcceptedHostnames.UnsortedList()
 			slices.Sort(nonAcceptedHostnamesSlice)
 			hostnamesVerifiedCondition.Status = metav1.ConditionFalse
@@ -547,12 +788,21 @@ func (r *HTTPProxyReconciler) reconcileHTTPProxyHostnameStatus(
 	}
 
 	// Build per-hostname statuses
-	availabilityStatuses := buildAvailabilityStatuses(acceptedHostnames, inUseHostnames, httpProxyCopy.Generation)
+	availabilityStatuses := buildAvailabilityStatuses(acceptedHostnames, inUseHostnames, blockedHostnames, httpProxyCopy.Generation)
+	verificationStatuses := buildVerificationStatuses(acceptedHostnames, nonAcceptedHostnames, httpProxyCopy.Generation)
 	dnsStatuses := r.buildDNSStatuses(ctx, cl, gateway, httpProxyCopy.Generation)
 	certificateStatuses := r.buildCertificateStatuses(ctx, cl, clusterName, gateway, httpProxyCopy)
+	routeStatuses := buildRouteStatuses(gateway, httpProxyCopy.Generation)
 	previousHostnameStatuses := httpProxyCopy.Status.HostnameStatuses
-	httpProxyCopy.Status.HostnameStatuses = mergeHostnameStatuses(availabilityStatuses, dnsStatuses, certificateStatuses)
+	httpProxyCopy.Status.HostnameStatuses = mergeHostnameStatuses(
+		availabilityStatuses,
+		verificationStatuses,
+		dnsStatuses,
+		certificateStatuses,
+		routeStatuses,
+	)
 	preserveHostnameConditionTransitions(httpProxyCopy.Status.HostnameStatuses, previousHostnameStatuses)
+	httpProxyCopy.Status.HostnameCount, httpProxyCopy.Status.VerifiedHostnameCount = countHostnameStatuses(httpProxyCopy.Status.HostnameStatuses)
 
 	r.setCertificatesReadyCondition(httpProxyCopy, certificateStatuses, gateway)
 }
@@ -560,6 +810,15 @@ func (r *HTTPProxyReconciler) reconcileHTTPProxyHostnameStatus(
 // SetupWithManager sets up the controller with the Manager.
 func (r *HTTPProxyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	r.mgr = mgr
+	r.connectorAddressing = newConnectorAddressingPropagator()
+
+	if r.Config.HTTPProxy.BackendResolution.Enabled {
+		backendResolver, err := newBackendHostResolver(r.Config.HTTPProxy.BackendResolution)
+		if err != nil {
+			return err
+		}
+		r.backendResolver = backendResolver
+	}
 
 	builder := mcbuilder.ControllerManagedBy(mgr).
 		For(&networkingv1alpha.HTTPProxy{}).
@@ -568,7 +827,9 @@ func (r *HTTPProxyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 		Owns(&discoveryv1.EndpointSlice{}).
 		// Watch Connectors and reconcile HTTPProxies that reference them.
 		// This ensures EnvoyPatchPolicy headers are updated when a Connector's
-		// publicKey.id changes (e.g., after connector restart/reconnect).
+		// publicKey.id changes (e.g., after connector restart/reconnect). Only
+		// updates that change the addressing of the Connector reconcile
+		// HTTPProxies.
 		Watches(
 			&networkingv1alpha1.Connector{},
 			func(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
@@ -610,8 +871,15 @@ func (r *HTTPProxyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 					return requests
 				})
 			},
+			mcbuilder.WithPredicates(connectorAddressChanged()),
 		)
 
+	// The policies of load balanced rules overlay the policy attached to the
+	// Gateway of the HTTPProxy, if any.
+	for _, policy := range []client.Object{&envoygatewayv1alpha1.BackendTrafficPolicy{}, &networkingv1alpha.RateLimitPolicy{}, &networkingv1alpha.PayloadPolicy{}} {
+		builder = builder.Watches(policy, enqueueGatewayPolicyTargets, mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]()))
+	}
+
 	if r.DownstreamCluster != nil {
 		downstreamPolicySource := mcsource.TypedKind(
 			&envoygatewayv1alpha1.EnvoyPatchPolicy{},
@@ -621,6 +889,15 @@ func (r *HTTPProxyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 		downstreamPolicyClusterSource, _, _ := downstreamPolicySource.ForCluster("", r.DownstreamCluster)
 		builder = builder.WatchesRawSource(downstreamPolicyClusterSource)
 
+		// Watch downstream BackendTrafficPolicies so the HealthChecksProgrammed
+		// condition is updated when the gateway accepts or rejects health checks.
+		downstreamRoutePolicySource := mcsource.TypedKind(
+			&envoygatewayv1alpha1.BackendTrafficPolicy{},
+			r.enqueueHTTPProxyForDownstreamRoutePolicy(),
+		)
+		downstreamRoutePolicyClusterSource, _, _ := downstreamRoutePolicySource.ForCluster("", r.DownstreamCluster)
+		builder = builder.WatchesRawSource(downstreamRoutePolicyClusterSource)
+
 		// Watch downstream cert-manager Certificates so HTTPProxy certificate status
 		// is updated when certificates become ready or fail.
 		downstreamCertificateSource := mcsource.TypedKind(
@@ -632,9 +909,7 @@ func (r *HTTPProxyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	}
 
 	return builder.
-		WithOptions(controller.TypedOptions[mcreconcile.Request]{
-			MaxConcurrentReconciles: r.Config.HTTPProxy.MaxConcurrentReconciles,
-		}).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "httpproxy", r.Config.HTTPProxy.MaxConcurrentReconciles)).
 		Named("httpproxy").Complete(r)
 }
 
@@ -749,8 +1024,79 @@ func stripHostFromRequestHeaderModifier(filters []gatewayv1.HTTPRouteFilter) []g
 	return out
 }
 
+// applyResponseHeaderPolicy folds the HTTPProxy and rule level response header
+// policies into the rule's ResponseHeaderModifier filter. Rule level entries
+// override proxy level entries for the same header, and entries in an existing
+// ResponseHeaderModifier filter override both.
+func applyResponseHeaderPolicy(
+	filters []gatewayv1.HTTPRouteFilter,
+	proxyHeaders *gatewayv1.HTTPHeaderFilter,
+	ruleHeaders *gatewayv1.HTTPHeaderFilter,
+) []gatewayv1.HTTPRouteFilter {
+	if proxyHeaders == nil && ruleHeaders == nil {
+		return filters
+	}
+
+	modifier := mergeHeaderFilters(proxyHeaders, ruleHeaders)
+	for i, filter := range filters {
+		if filter.Type != gatewayv1.HTTPRouteFilterResponseHeaderModifier || filter.ResponseHeaderModifier == nil {
+			continue
+		}
+		filters[i].ResponseHeaderModifier = mergeHeaderFilters(modifier, filter.ResponseHeaderModifier)
+		return filters
+	}
+
+	return append(filters, gatewayv1.HTTPRouteFilter{
+		Type:                   gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+		ResponseHeaderModifier: modifier,
+	})
+}
+
+// mergeHeaderFilters returns a new filter containing the entries of base and
+// override. Any header named in override replaces entries for the same header
+// in base.
+func mergeHeaderFilters(base, override *gatewayv1.HTTPHeaderFilter) *gatewayv1.HTTPHeaderFilter {
+	merged := &gatewayv1.HTTPHeaderFilter{}
+	if override == nil {
+		override = &gatewayv1.HTTPHeaderFilter{}
+	}
+
+	overridden := sets.New[string]()
+	for _, h := range slices.Concat(override.Set, override.Add) {
+		overridden.Insert(strings.ToLower(string(h.Name)))
+	}
+	for _, name := range override.Remove {
+		overridden.Insert(strings.ToLower(name))
+	}
+
+	if base != nil {
+		for _, h := range base.Set {
+			if !overridden.Has(strings.ToLower(string(h.Name))) {
+				merged.Set = append(merged.Set, h)
+			}
+		}
+		for _, h := range base.Add {
+			if !overridden.Has(strings.ToLower(string(h.Name))) {
+				merged.Add = append(merged.Add, h)
+			}
+		}
+		for _, name := range base.Remove {
+			if !overridden.Has(strings.ToLower(name)) {
+				merged.Remove = append(merged.Remove, name)
+			}
+		}
+	}
+
+	merged.Set = append(merged.Set, override.Set...)
+	merged.Add = append(merged.Add, override.Add...)
+	merged.Remove = append(merged.Remove, override.Remove...)
+
+	return merged
+}
+
 func (r *HTTPProxyReconciler) collectDesiredResources(
 	ctx context.Context,
+	clusterName string,
 	cl client.Client,
 	httpProxy *networkingv1alpha.HTTPProxy,
 ) (*desiredHTTPProxyResources, error) {
@@ -770,6 +1116,48 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 	// CreateOrUpdate logic for maintaining the gateway.
 	gatewayutil.SetDefaultListeners(gateway, r.Config.Gateway)
 
+	if httpProxy.Spec.TLSPolicy != nil {
+		gateway.Annotations = map[string]string{}
+		if err := setGatewayTLSPolicyAnnotation(gateway.Annotations, httpProxy.Spec.TLSPolicy); err != nil {
+			return nil, fmt.Errorf("failed to set tls policy annotation: %w", err)
+		}
+	}
+
+	if httpProxy.Spec.Protocols != nil && httpProxy.Spec.Protocols.HTTP3 {
+		metav1.SetMetaDataAnnotation(&gateway.ObjectMeta, GatewayHTTP3Annotation, "true")
+	}
+
+	if httpProxy.Spec.Paused {
+		metav1.SetMetaDataAnnotation(&gateway.ObjectMeta, GatewayPausedAnnotation, "true")
+	}
+
+	if v := httpProxy.Spec.ClientValidation; v != nil {
+		gateway.Spec.TLS = &gatewayv1.GatewayTLSConfig{
+			Frontend: &gatewayv1.FrontendTLSConfig{
+				Default: gatewayv1.TLSConfig{
+					Validation: &gatewayv1.FrontendTLSValidation{
+						CACertificateRefs: []gatewayv1.ObjectReference{
+							{Kind: "ConfigMap", Name: gatewayv1.ObjectName(v.CACertificateRef.Name)},
+						},
+						Mode: v.Mode,
+					},
+				},
+			},
+		}
+	}
+
+	// Hostname listeners serve the certificate provided by the user, or request
+	// a certificate to be issued for them through the listener defaults.
+	var listenerTLS *gatewayv1.ListenerTLSConfig
+	if httpProxy.Spec.TLS != nil {
+		listenerTLS = &gatewayv1.ListenerTLSConfig{
+			Mode: ptr.To(gatewayv1.TLSModeTerminate),
+			CertificateRefs: []gatewayv1.SecretObjectReference{
+				{Name: gatewayv1.ObjectName(httpProxy.Spec.TLS.CertificateRef.Name)},
+			},
+		}
+	}
+
 	// Add listeners for each hostname
 	for i, hostname := range httpProxy.Spec.Hostnames {
 		gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayv1.Listener{
@@ -777,11 +1165,6 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 			Protocol: gatewayv1.HTTPProtocolType,
 			Port:     DefaultHTTPPort,
 			Hostname: ptr.To(hostname),
-			AllowedRoutes: &gatewayv1.AllowedRoutes{
-				Namespaces: &gatewayv1.RouteNamespaces{
-					From: ptr.To(gatewayv1.NamespacesFromSame),
-				},
-			},
 		})
 
 		gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayv1.Listener{
@@ -789,18 +1172,14 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 			Protocol: gatewayv1.HTTPSProtocolType,
 			Port:     DefaultHTTPSPort,
 			Hostname: ptr.To(hostname),
-			AllowedRoutes: &gatewayv1.AllowedRoutes{
-				Namespaces: &gatewayv1.RouteNamespaces{
-					From: ptr.To(gatewayv1.NamespacesFromSame),
-				},
-			},
-			TLS: &gatewayv1.ListenerTLSConfig{
-				Mode:    ptr.To(gatewayv1.TLSModeTerminate),
-				Options: r.Config.Gateway.ListenerTLSOptions,
-			},
+			TLS:      listenerTLS.DeepCopy(),
 		})
 	}
 
+	// Apply the same defaults as the Gateway defaulting webhook, so that the
+	// desired gateway doesn't drift from the admitted one.
+	gatewayutil.SetListenerDefaults(gateway, r.Config.Gateway)
+
 	httpRoute := &gatewayv1.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: httpProxy.Namespace,
@@ -819,20 +1198,35 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 
 	var desiredEndpointSlices []*discoveryv1.EndpointSlice
 	var desiredRouteFilters []*envoygatewayv1alpha1.HTTPRouteFilter
+	var desiredBackendTrafficPolicies []*envoygatewayv1alpha1.BackendTrafficPolicy
+	var loadBalancerMergeType *envoygatewayv1alpha1.MergeType
+	var loadBalancerMergeTypeResolved bool
+	var backendStatuses []networkingv1alpha.HTTPProxyBackendStatus
+	var backendsExpireAt time.Time
+	var unresolvedBackendHosts []string
+	var blueGreenStatuses []networkingv1alpha.HTTPProxyBlueGreenStatus
+	var blueGreenDrainEndsAt time.Time
+	now := time.Now()
 
 	desiredRouteRules := make([]gatewayv1.HTTPRouteRule, len(httpProxy.Spec.Rules))
+	// Header based canary routing is programmed as additional route rules after
+	// the rules of the proxy, so the index of a proxy rule is also its index in
+	// the route.
+	var canaryRouteRules []gatewayv1.HTTPRouteRule
 	for ruleIndex, rule := range httpProxy.Spec.Rules {
-		ruleFilters := slices.Clone(rule.Filters)
+		ruleFilters := applyResponseHeaderPolicy(slices.Clone(rule.Filters), httpProxy.Spec.ResponseHeaders, rule.ResponseHeaders)
 		backendRefs := make([]gatewayv1.HTTPBackendRef, len(rule.Backends))
 		offlineRuleSet := false
 
-		// Validation will prevent this from occurring, unless the maximum items for
-		// backends is adjusted. The following error has been placed here so that
-		// if/when that occurs, we're sure to address obvious programming changes
-		// required (which should happen anyways, but just to be safe...).
-		if len(rule.Backends) > 1 {
+		// Validation will prevent this from occurring. Additional backends are
+		// only permitted as failover targets or to split traffic, as the rule
+		// level Host rewrite is otherwise derived from the single backend.
+		hasBackups := slices.ContainsFunc(rule.Backends, isBackupBackend)
+		splitsTraffic := httpProxyRuleSplitsTraffic(rule)
+		if len(rule.Backends) > 1 && !hasBackups && !splitsTraffic {
 			return nil, fmt.Errorf("invalid number of backends for rule - expected 1 got %d", len(rule.Backends))
 		}
+		ruleHasUserHost := false
 
 		for backendIndex, backend := range rule.Backends {
 			// Offline-connector handling differs by emission mode:
@@ -852,11 +1246,11 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 			//     route, which it renders as a bare direct_response 500 (no offline
 			//     page) — the regression this guards against.
 			if backend.Connector != nil && r.Config.Gateway.IsEPPEmissionEnabled() {
-				ready, err := connectorReady(ctx, cl, httpProxy.Namespace, backend.Connector.Name)
+				address, err := connectorAddresses(cl)(ctx, httpProxy.Namespace, backend.Connector.Name)
 				if err != nil {
 					return nil, err
 				}
-				if !ready {
+				if !address.ready {
 					// Connector is offline: keep the route rule with no backends so EG
 					// can translate it (creating virtual_hosts). The connector EPP
 					// (buildConnectorOfflineEnvoyPatches) inserts a direct_response CONNECT
@@ -886,6 +1280,8 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 			if u.Scheme == SchemeHTTPS {
 				backendPort = DefaultHTTPSPort
 				appProtocol = SchemeHTTPS
+			} else if backend.Protocol == networkingv1alpha.HTTPProxyBackendProtocolH2C {
+				appProtocol = AppProtocolH2C
 			}
 
 			if endpointPort := u.Port(); endpointPort != "" {
@@ -907,7 +1303,7 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 			isIPAddress := false
 			if backend.Connector != nil {
 				// Connector backends don't rely on EndpointSlice addresses; use a safe placeholder.
-				endpointHost = "connector.local"
+				endpointHost = connectorPlaceholderAddress
 				addressType = discoveryv1.AddressTypeFQDN
 			} else if ip := net.ParseIP(host); ip != nil {
 				isIPAddress = true
@@ -933,6 +1329,7 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 			if hasUserHost {
 				ruleFilters = stripHostFromRequestHeaderModifier(ruleFilters)
 				backend.Filters = stripHostFromRequestHeaderModifier(backend.Filters)
+				ruleHasUserHost = true
 			}
 
 			// Track the backend cert hostname separately from the Host
@@ -945,7 +1342,12 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 
 			// For HTTPS endpoints with IP addresses, require tls.hostname for certificate validation
 			// and use it as the Host header for the upstream request.
-			if u.Scheme == SchemeHTTPS && isIPAddress {
+			//
+			// Backup backends share the rule level Host rewrite of the primary
+			// backend, which is replaced below when no Host override is set.
+			if isBackupBackend(backend) {
+				certHostname = host
+			} else if u.Scheme == SchemeHTTPS && isIPAddress {
 				if backend.TLS == nil || backend.TLS.Hostname == nil || *backend.TLS.Hostname == "" {
 					return nil, fmt.Errorf("HTTPS endpoint with IP address requires tls.hostname for backend %d in rule %d", backendIndex, ruleIndex)
 				}
@@ -1007,6 +1409,28 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 				// override instead of the real backend FQDN).
 				epAnnotations[BackendCertHostnameAnnotation] = certHostname
 			}
+			if hasBackups {
+				if err := setBackendFailoverAnnotations(epAnnotations, backend, rule.HealthCheck); err != nil {
+					return nil, fmt.Errorf("failed building failover annotations for backend %d in rule %d: %w", backendIndex, ruleIndex, err)
+				}
+			} else if backend.HealthCheck != nil {
+				if err := setBackendHealthCheckAnnotation(epAnnotations, backend.HealthCheck); err != nil {
+					return nil, fmt.Errorf("failed building health check annotation for backend %d in rule %d: %w", backendIndex, ruleIndex, err)
+				}
+			}
+			if loadBalancer := httpProxyRuleLoadBalancer(rule); loadBalancer != nil && httpProxyRuleHealthChecked(rule) {
+				if err := setBackendLoadBalancerAnnotation(epAnnotations, loadBalancer); err != nil {
+					return nil, fmt.Errorf("failed building load balancer annotation for backend %d in rule %d: %w", backendIndex, ruleIndex, err)
+				}
+			}
+			if splitsTraffic {
+				epAnnotations[BackendTrafficSplitAnnotation] = "true"
+			}
+			if u.Scheme == SchemeHTTPS {
+				if err := setBackendTLSAnnotations(epAnnotations, backend.TLS); err != nil {
+					return nil, fmt.Errorf("failed building tls annotations for backend %d in rule %d: %w", backendIndex, ruleIndex, err)
+				}
+			}
 			endpointSlice := &discoveryv1.EndpointSlice{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace:   httpProxy.Namespace,
@@ -1036,6 +1460,40 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 				},
 			}
 
+			// Failover, traffic split and health checked backends are programmed
+			// downstream as Envoy Gateway Backends with FQDN endpoints, which Envoy
+			// resolves itself and sends as the Host header of health checks.
+			if r.backendResolver != nil && !isIPAddress && backend.Connector == nil && !hasBackups && !splitsTraffic && backend.HealthCheck == nil {
+				if resolved, err := r.backendResolver.resolve(ctx, host); err != nil {
+					// The EndpointSlice keeps the hostname, which the gateway
+					// resolves, until the hostname can be resolved again.
+					log.FromContext(ctx).Error(err, "failed resolving backend hostname", "hostname", host, "rule", ruleIndex, "backend", backendIndex)
+					if !slices.Contains(unresolvedBackendHosts, host) {
+						unresolvedBackendHosts = append(unresolvedBackendHosts, host)
+					}
+					if retryAt := r.backendResolver.now().Add(r.backendResolver.minRefreshInterval); backendsExpireAt.IsZero() || retryAt.Before(backendsExpireAt) {
+						backendsExpireAt = retryAt
+					}
+				} else {
+					endpointSlice.AddressType, endpointSlice.Endpoints = endpointsForAddresses(resolved.addresses, !r.Config.Gateway.IPv6Only())
+
+					backendStatus := networkingv1alpha.HTTPProxyBackendStatus{
+						RuleIndex:        int32(ruleIndex),
+						BackendIndex:     int32(backendIndex),
+						Endpoint:         backend.Endpoint,
+						LastResolvedTime: ptr.To(metav1.NewTime(resolved.resolvedAt)),
+					}
+					for _, endpoint := range endpointSlice.Endpoints {
+						backendStatus.Addresses = append(backendStatus.Addresses, endpoint.Addresses...)
+					}
+					backendStatuses = append(backendStatuses, backendStatus)
+
+					if backendsExpireAt.IsZero() || resolved.expiresAt.Before(backendsExpireAt) {
+						backendsExpireAt = resolved.expiresAt
+					}
+				}
+			}
+
 			desiredEndpointSlices = append(desiredEndpointSlices, endpointSlice)
 
 			backendRefs[backendIndex] = gatewayv1.HTTPBackendRef{
@@ -1046,33 +1504,203 @@ func (r *HTTPProxyReconciler) collectDesiredResources(
 						Name:  gatewayv1.ObjectName(endpointSlice.Name),
 						Port:  ptr.To(gatewayv1.PortNumber(backendPort)),
 					},
+					Weight: httpProxyBackendWeight(rule, backend),
 				},
 				Filters: backend.Filters,
 			}
 		}
 
+		if blueGreen := httpProxyRuleBlueGreen(rule); blueGreen != nil {
+			// Every backend keeps its EndpointSlice, so that a swap only changes
+			// the backendRefs of the route rule.
+			blueGreenStatus, drainEndsAt := desiredBlueGreenStatus(httpProxy, ruleIndex, blueGreen, now)
+			blueGreenStatuses = append(blueGreenStatuses, blueGreenStatus)
+			if !drainEndsAt.IsZero() && (blueGreenDrainEndsAt.IsZero() || drainEndsAt.Before(blueGreenDrainEndsAt)) {
+				blueGreenDrainEndsAt = drainEndsAt
+			}
+			backendRefs = blueGreenBackendRefs(rule, backendRefs, blueGreenStatus)
+		}
+
+		if (hasBackups || splitsTraffic) && !ruleHasUserHost {
+			// Each backend is programmed as a priority level or weighted endpoint
+			// of the same cluster, so the Host header must follow whichever backend
+			// is serving the request.
+			hostRewriteFilter := desiredFailoverHostRewriteFilter(httpProxy, ruleIndex)
+			desiredRouteFilters = append(desiredRouteFilters, hostRewriteFilter)
+			ruleFilters = append(stripURLRewriteHostname(ruleFilters), gatewayv1.HTTPRouteFilter{
+				Type: gatewayv1.HTTPRouteFilterExtensionRef,
+				ExtensionRef: &gatewayv1.LocalObjectReference{
+					Group: envoygatewayv1alpha1.GroupName,
+					Kind:  envoygatewayv1alpha1.KindHTTPRouteFilter,
+					Name:  gatewayv1.ObjectName(hostRewriteFilter.Name),
+				},
+			})
+		}
+
+		// The load balancer of a health checked rule is programmed by the
+		// gateway controller, along with the health check, through the
+		// annotations of the EndpointSlices of the rule.
+		if loadBalancer := httpProxyRuleLoadBalancer(rule); loadBalancer != nil && !httpProxyRuleHealthChecked(rule) {
+			if !loadBalancerMergeTypeResolved {
+				mergeType, err := gatewayPolicyMergeType(ctx, cl, httpProxy.Namespace, gateway.Name)
+				if err != nil {
+					return nil, err
+				}
+				loadBalancerMergeType = mergeType
+				loadBalancerMergeTypeResolved = true
+			}
+			desiredBackendTrafficPolicies = append(desiredBackendTrafficPolicies,
+				desiredLoadBalancerPolicy(httpProxy, httpRoute.Name, ruleIndex, rule.Name, loadBalancer, loadBalancerMergeType))
+		}
+
 		if offlineRuleSet {
 			continue
 		}
 
+		if rule.Mirror != nil && len(backendRefs) > 0 {
+			mirrorEndpointSlice, mirrorFilter, err := desiredRequestMirror(httpProxy, ruleIndex, rule.Mirror)
+			if err != nil {
+				return nil, err
+			}
+			desiredEndpointSlices = append(desiredEndpointSlices, mirrorEndpointSlice)
+			ruleFilters = append(ruleFilters, mirrorFilter)
+		}
+
 		desiredRouteRules[ruleIndex] = gatewayv1.HTTPRouteRule{
 			Name:        rule.Name,
 			Matches:     rule.Matches,
 			Filters:     ruleFilters,
 			BackendRefs: backendRefs,
+			Timeouts:    desiredRouteRuleTimeouts(rule, r.Config.HTTPProxy.RequestPolicy),
+			Retry:       desiredRouteRuleRetry(rule, r.Config.HTTPProxy.RequestPolicy),
+		}
+
+		if canaryRule := desiredCanaryHeaderRouteRule(rule, ruleFilters, backendRefs); canaryRule != nil {
+			canaryRule.Timeouts = desiredRouteRuleTimeouts(rule, r.Config.HTTPProxy.RequestPolicy)
+			canaryRule.Retry = desiredRouteRuleRetry(rule, r.Config.HTTPProxy.RequestPolicy)
+			canaryRouteRules = append(canaryRouteRules, *canaryRule)
 		}
 	}
 
-	httpRoute.Spec.Rules = desiredRouteRules
+	httpRoute.Spec.Rules = append(desiredRouteRules, canaryRouteRules...)
 
 	return &desiredHTTPProxyResources{
 		gateway:          gateway,
 		httpRoute:        httpRoute,
 		endpointSlices:   desiredEndpointSlices,
 		httpRouteFilters: desiredRouteFilters,
+
+		backendTrafficPolicies: desiredBackendTrafficPolicies,
+
+		authSecurityPolicy: desiredAuthSecurityPolicy(httpProxy, httpRoute.Name),
+
+		backendStatuses:        backendStatuses,
+		backendsExpireAt:       backendsExpireAt,
+		unresolvedBackendHosts: unresolvedBackendHosts,
+
+		blueGreenStatuses:    blueGreenStatuses,
+		blueGreenDrainEndsAt: blueGreenDrainEndsAt,
 	}, nil
 }
 
+// loadBalancerPolicyName returns the name of the BackendTrafficPolicy that
+// programs load balancing for a rule in an HTTPProxy.
+func loadBalancerPolicyName(httpProxy *networkingv1alpha.HTTPProxy, ruleIndex int) string {
+	return fmt.Sprintf("%s-rule-%d-lb", httpProxy.Name, ruleIndex)
+}
+
+// isLoadBalancerPolicyName returns whether name is that of a load balancing
+// BackendTrafficPolicy of the HTTPProxy, as returned by loadBalancerPolicyName.
+func isLoadBalancerPolicyName(httpProxy *networkingv1alpha.HTTPProxy, name string) bool {
+	ruleIndex, ok := strings.CutPrefix(name, httpProxy.Name+"-rule-")
+	if !ok {
+		return false
+	}
+	ruleIndex, ok = strings.CutSuffix(ruleIndex, "-lb")
+	if !ok {
+		return false
+	}
+	_, err := strconv.Atoi(ruleIndex)
+	return err == nil
+}
+
+// desiredLoadBalancerPolicy translates a backend load balancer configuration
+// into a BackendTrafficPolicy attached to the HTTPRoute rule. When the rule is
+// not named, the policy attaches to the whole HTTPRoute, which validation only
+// permits when the HTTPProxy has a single rule. The merge type is set when the
+// policy overlays the policy attached to the Gateway, see
+// gatewayPolicyMergeType.
+func desiredLoadBalancerPolicy(
+	httpProxy *networkingv1alpha.HTTPProxy,
+	httpRouteName string,
+	ruleIndex int,
+	ruleName *gatewayv1.SectionName,
+	loadBalancer *networkingv1alpha.HTTPProxyBackendLoadBalancer,
+	mergeType *envoygatewayv1alpha1.MergeType,
+) *envoygatewayv1alpha1.BackendTrafficPolicy {
+	return &envoygatewayv1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: httpProxy.Namespace,
+			Name:      loadBalancerPolicyName(httpProxy, ruleIndex),
+		},
+		Spec: envoygatewayv1alpha1.BackendTrafficPolicySpec{
+			PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Group: gatewayv1.GroupName,
+							Kind:  KindHTTPRoute,
+							Name:  gatewayv1.ObjectName(httpRouteName),
+						},
+						SectionName: ruleName,
+					},
+				},
+			},
+			ClusterSettings: envoygatewayv1alpha1.ClusterSettings{
+				LoadBalancer: desiredLoadBalancer(loadBalancer),
+			},
+			MergeType: mergeType,
+		},
+	}
+}
+
+// desiredLoadBalancer translates a backend load balancer configuration into an
+// Envoy Gateway load balancer, or nil when no configuration is given.
+func desiredLoadBalancer(loadBalancer *networkingv1alpha.HTTPProxyBackendLoadBalancer) *envoygatewayv1alpha1.LoadBalancer {
+	if loadBalancer == nil {
+		return nil
+	}
+
+	egLoadBalancer := &envoygatewayv1alpha1.LoadBalancer{}
+	switch loadBalancer.Type {
+	case networkingv1alpha.HTTPProxyBackendLoadBalancerLeastRequest:
+		egLoadBalancer.Type = envoygatewayv1alpha1.LeastRequestLoadBalancerType
+	case networkingv1alpha.HTTPProxyBackendLoadBalancerRingHash:
+		egLoadBalancer.Type = envoygatewayv1alpha1.ConsistentHashLoadBalancerType
+		consistentHash := &envoygatewayv1alpha1.ConsistentHash{
+			Type: envoygatewayv1alpha1.SourceIPConsistentHashType,
+		}
+		if hashKey := loadBalancer.HashKey; hashKey != nil {
+			switch {
+			case hashKey.Header != nil:
+				consistentHash.Type = envoygatewayv1alpha1.HeadersConsistentHashType
+				consistentHash.Headers = []*envoygatewayv1alpha1.Header{
+					{Name: string(*hashKey.Header)},
+				}
+			case hashKey.Cookie != nil:
+				consistentHash.Type = envoygatewayv1alpha1.CookieConsistentHashType
+				consistentHash.Cookie = &envoygatewayv1alpha1.Cookie{
+					Name: *hashKey.Cookie,
+				}
+			}
+		}
+		egLoadBalancer.ConsistentHash = consistentHash
+	default:
+		egLoadBalancer.Type = envoygatewayv1alpha1.RoundRobinLoadBalancerType
+	}
+	return egLoadBalancer
+}
+
 func hasControllerConflict(obj, owner metav1.Object) bool {
 	if t := obj.GetCreationTimestamp(); t.IsZero() {
 		return false
@@ -1086,9 +1714,10 @@ func hasControllerConflict(obj, owner metav1.Object) bool {
 func buildAvailabilityStatuses(
 	acceptedHostnames sets.Set[gatewayv1.Hostname],
 	inUseHostnames sets.Set[string],
+	blockedHostnames sets.Set[string],
 	generation int64,
 ) []networkingv1alpha.HostnameStatus {
-	statuses := make([]networkingv1alpha.HostnameStatus, 0, acceptedHostnames.Len()+inUseHostnames.Len())
+	statuses := make([]networkingv1alpha.HostnameStatus, 0, acceptedHostnames.Len()+inUseHostnames.Len()+blockedHostnames.Len())
 
 	for hostname := range acceptedHostnames {
 		hs := networkingv1alpha.HostnameStatus{Hostname: string(hostname)}
@@ -1114,6 +1743,115 @@ func buildAvailabilityStatuses(
 		statuses = append(statuses, hs)
 	}
 
+	for hostname := range blockedHostnames {
+		hs := networkingv1alpha.HostnameStatus{Hostname: hostname}
+		apimeta.SetStatusCondition(&hs.Conditions, metav1.Condition{
+			Type:               networkingv1alpha.HostnameConditionAvailable,
+			Status:             metav1.ConditionFalse,
+			Reason:             networkingv1alpha.HostnameAvailableReasonBlocked,
+			Message:            "Hostname is not permitted by platform policy",
+			ObservedGeneration: generation,
+		})
+		statuses = append(statuses, hs)
+	}
+
+	return statuses
+}
+
+// buildVerificationStatuses builds HostnameStatus entries with the Verified
+// condition based on which hostnames were accepted vs not verified. Hostnames
+// that are in use or blocked are left out, as their verification is not
+// evaluated.
+func buildVerificationStatuses(
+	acceptedHostnames sets.Set[gatewayv1.Hostname],
+	unverifiedHostnames sets.Set[string],
+	generation int64,
+) []networkingv1alpha.HostnameStatus {
+	statuses := make([]networkingv1alpha.HostnameStatus, 0, acceptedHostnames.Len()+unverifiedHostnames.Len())
+
+	for hostname := range acceptedHostnames {
+		hs := networkingv1alpha.HostnameStatus{Hostname: string(hostname)}
+		apimeta.SetStatusCondition(&hs.Conditions, metav1.Condition{
+			Type:               networkingv1alpha.HostnameConditionVerified,
+			Status:             metav1.ConditionTrue,
+			Reason:             networkingv1alpha.HostnameVerifiedReasonVerified,
+			Message:            "Hostname ownership has been verified",
+			ObservedGeneration: generation,
+		})
+		statuses = append(statuses, hs)
+	}
+
+	for hostname := range unverifiedHostnames {
+		hs := networkingv1alpha.HostnameStatus{Hostname: hostname}
+		apimeta.SetStatusCondition(&hs.Conditions, metav1.Condition{
+			Type:               networkingv1alpha.HostnameConditionVerified,
+			Status:             metav1.ConditionFalse,
+			Reason:             networkingv1alpha.HostnameVerifiedReasonUnverified,
+			Message:            "Hostname ownership has not been verified, check status of Domains in the same namespace",
+			ObservedGeneration: generation,
+		})
+		statuses = append(statuses, hs)
+	}
+
+	return statuses
+}
+
+// buildRouteStatuses builds HostnameStatus entries with the RouteProgrammed
+// condition from the status of the Gateway listeners of each hostname. A
+// hostname served by several listeners is only programmed once all of them
+// are.
+func buildRouteStatuses(gateway *gatewayv1.Gateway, generation int64) []networkingv1alpha.HostnameStatus {
+	listenerStatuses := make(map[gatewayv1.SectionName]gatewayv1.ListenerStatus, len(gateway.Status.Listeners))
+	for _, ls := range gateway.Status.Listeners {
+		listenerStatuses[ls.Name] = ls
+	}
+
+	var statuses []networkingv1alpha.HostnameStatus
+	indexes := map[string]int{}
+	for _, l := range gateway.Spec.Listeners {
+		if l.Hostname == nil {
+			continue
+		}
+		hostname := string(*l.Hostname)
+
+		condition := metav1.Condition{
+			Type:               networkingv1alpha.HostnameConditionRouteProgrammed,
+			Status:             metav1.ConditionTrue,
+			Reason:             networkingv1alpha.RouteProgrammedReasonProgrammed,
+			Message:            "Routes are programmed on the Gateway listeners",
+			ObservedGeneration: generation,
+		}
+		ls, ok := listenerStatuses[l.Name]
+		programmed := apimeta.FindStatusCondition(ls.Conditions, string(gatewayv1.ListenerConditionProgrammed))
+		switch {
+		case !ok || programmed == nil:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = networkingv1alpha.RouteProgrammedReasonPending
+			condition.Message = fmt.Sprintf("Waiting for listener %q to be programmed", l.Name)
+		case programmed.Status != metav1.ConditionTrue:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = programmed.Reason
+			condition.Message = fmt.Sprintf("Listener %q is not programmed: %s", l.Name, programmed.Message)
+		case ls.AttachedRoutes == 0:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = networkingv1alpha.RouteProgrammedReasonNoAttachedRoutes
+			condition.Message = fmt.Sprintf("No routes are attached to listener %q", l.Name)
+		}
+
+		i, seen := indexes[hostname]
+		if !seen {
+			hs := networkingv1alpha.HostnameStatus{Hostname: hostname}
+			apimeta.SetStatusCondition(&hs.Conditions, condition)
+			indexes[hostname] = len(statuses)
+			statuses = append(statuses, hs)
+			continue
+		}
+		if condition.Status != metav1.ConditionTrue &&
+			apimeta.IsStatusConditionTrue(statuses[i].Conditions, networkingv1alpha.HostnameConditionRouteProgrammed) {
+			apimeta.SetStatusCondition(&statuses[i].Conditions, condition)
+		}
+	}
+
 	return statuses
 }
 
@@ -1205,6 +1943,7 @@ func (r *HTTPProxyReconciler) buildCertificateStatuses(
 		clusterName,
 		upstreamClient,
 		r.DownstreamCluster.GetClient(),
+		downstreamclient.WithControllerName("httpproxy"),
 	)
 	downstreamNamespaceName, err := downstreamStrategy.GetDownstreamNamespaceNameForUpstreamNamespace(ctx, httpProxy.Namespace)
 	if err != nil {
@@ -1246,6 +1985,14 @@ func (r *HTTPProxyReconciler) buildCertificateStatuses(
 			continue
 		}
 
+		// A certificate provided by the user is validated by the gateway
+		// controller, which reports problems on the listener.
+		if l.TLS != nil && len(l.TLS.CertificateRefs) > 0 {
+			apimeta.SetStatusCondition(&hs.Conditions, customCertificateReadyCondition(gateway, l.Name, httpProxy.Generation))
+			statuses = append(statuses, hs)
+			continue
+		}
+
 		certName := resourcename.GetValidDNS1123Name(fmt.Sprintf("%s-%s", gateway.Name, l.Name))
 		certificate := newUnstructuredForGVK(certificateGVK)
 		certKey := client.ObjectKey{Namespace: downstreamNamespaceName, Name: certName}
@@ -1309,6 +2056,38 @@ func (r *HTTPProxyReconciler) buildCertificateStatuses(
 	return statuses
 }
 
+// customCertificateReadyCondition returns the CertificateReady condition of a
+// hostname served with a certificate provided by the user, from the
+// CertificateReady condition of its Gateway listener.
+func customCertificateReadyCondition(gateway *gatewayv1.Gateway, listenerName gatewayv1.SectionName, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               networkingv1alpha.HostnameConditionCertificateReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             networkingv1alpha.CertificateReadyReasonPending,
+		Message:            "Waiting for the provided TLS certificate to be validated",
+		ObservedGeneration: generation,
+	}
+
+	var listenerCondition *metav1.Condition
+	for _, ls := range gateway.Status.Listeners {
+		if ls.Name == listenerName {
+			listenerCondition = apimeta.FindStatusCondition(ls.Conditions, ListenerConditionCertificateReady)
+			break
+		}
+	}
+	switch {
+	case listenerCondition == nil:
+	case listenerCondition.Status == metav1.ConditionTrue:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = networkingv1alpha.CertificateReadyReasonCertificateIssued
+		condition.Message = "The provided TLS certificate is ready"
+	default:
+		condition.Reason = networkingv1alpha.CertificateReadyReasonProvisioningFailed
+		condition.Message = listenerCondition.Message
+	}
+	return condition
+}
+
 // getCertificateReadyConditionReason returns the reason and message for the
 // CertificateReady condition based on the cert-manager Certificate's Ready condition.
 func getCertificateReadyConditionReason(certificate *unstructured.Unstructured) (string, string) {
@@ -1447,6 +2226,29 @@ func (r *HTTPProxyReconciler) setCertificatesReadyCondition(
 	}
 }
 
+// recordHTTPProxyEvents emits events for the transitions of the Programmed
+// condition of an HTTPProxy, and for hostnames whose DNS records started to
+// conflict with records the operator does not manage.
+func recordHTTPProxyEvents(recorder events.EventRecorder, httpProxy *networkingv1alpha.HTTPProxy, origStatus *networkingv1alpha.HTTPProxyStatus) {
+	recordConditionTransition(recorder, httpProxy, "Program", origStatus.Conditions, httpProxy.Status.Conditions,
+		networkingv1alpha.HTTPProxyConditionProgrammed, networkingv1alpha.HTTPProxyReasonPending)
+
+	for _, hs := range httpProxy.Status.HostnameStatuses {
+		condition := apimeta.FindStatusCondition(hs.Conditions, networkingv1alpha.HostnameConditionDNSRecordProgrammed)
+		if condition == nil || condition.Reason != networkingv1alpha.DNSRecordReasonConflict {
+			continue
+		}
+		var origConditions []metav1.Condition
+		for _, orig := range origStatus.HostnameStatuses {
+			if orig.Hostname == hs.Hostname {
+				origConditions = orig.Conditions
+			}
+		}
+		recordConditionTransition(recorder, httpProxy, "ProgramDNS", origConditions, hs.Conditions,
+			networkingv1alpha.HostnameConditionDNSRecordProgrammed)
+	}
+}
+
 // mergeHostnameStatuses merges multiple slices of HostnameStatus, combining
 // conditions for the same hostname. Returns a sorted slice for deterministic output.
 func mergeHostnameStatuses(statusSets ...[]networkingv1alpha.HostnameStatus) []networkingv1alpha.HostnameStatus {
@@ -1478,6 +2280,18 @@ func mergeHostnameStatuses(statusSets ...[]networkingv1alpha.HostnameStatus) []n
 	return result
 }
 
+// countHostnameStatuses returns the number of hostnames, and the number of
+// hostnames whose ownership has been verified.
+func countHostnameStatuses(statuses []networkingv1alpha.HostnameStatus) (total, verified int32) {
+	for _, hs := range statuses {
+		total++
+		if apimeta.IsStatusConditionTrue(hs.Conditions, networkingv1alpha.HostnameConditionVerified) {
+			verified++
+		}
+	}
+	return total, verified
+}
+
 // preserveHostnameConditionTransitions retains the original LastTransitionTime
 // for hostname conditions whose Status has not changed. Without this, freshly-
 // built HostnameStatus objects always get LastTransitionTime=now, which causes
@@ -1519,6 +2333,7 @@ func (r *HTTPProxyReconciler) reconcileConnectorEnvoyPatchPolicy(
 		clusterName,
 		upstreamClient,
 		r.DownstreamCluster.GetClient(),
+		downstreamclient.WithControllerName("httpproxy"),
 	)
 	downstreamNamespaceName, err := downstreamStrategy.GetDownstreamNamespaceNameForUpstreamNamespace(ctx, httpProxy.Namespace)
 	if err != nil {
@@ -1576,7 +2391,7 @@ func (r *HTTPProxyReconciler) reconcileConnectorEnvoyPatchPolicy(
 	// connector is offline and we keep the EPP alive with a direct_response route
 	// so EG never hits a delete+create cycle (which causes the watchable
 	// deduplication race that leaves EPP status permanently null).
-	connectorBackends, err := collectConnectorBackends(ctx, upstreamClient, httpProxy)
+	connectorBackends, err := collectConnectorBackends(ctx, connectorAddresses(upstreamClient), httpProxy)
 	if err != nil {
 		return nil, false, err
 	}
@@ -1611,7 +2426,9 @@ func (r *HTTPProxyReconciler) reconcileConnectorEnvoyPatchPolicy(
 		if err := downstreamStrategy.SetControllerReference(ctx, httpProxy, &policy); err != nil {
 			return err
 		}
-		policy.Spec = envoygatewayv1alpha1.EnvoyPatchPolicySpec{
+		// A spec rejected by the data plane is rolled back, and reported by
+		// the PolicyProgrammingFailed condition of the HTTPProxy.
+		_, err := setEnvoyPatchPolicySpec(&policy, envoygatewayv1alpha1.EnvoyPatchPolicySpec{
 			TargetRef: gatewayv1.LocalPolicyTargetReference{
 				Group: gatewayv1.GroupName,
 				Kind:  KindGatewayClass,
@@ -1619,8 +2436,8 @@ func (r *HTTPProxyReconciler) reconcileConnectorEnvoyPatchPolicy(
 			},
 			Type:        envoygatewayv1alpha1.JSONPatchEnvoyPatchType,
 			JSONPatches: jsonPatches,
-		}
-		return nil
+		})
+		return err
 	})
 	if err != nil {
 		return nil, connectorOnline, err
@@ -1660,6 +2477,7 @@ func (r *HTTPProxyReconciler) cleanupConnectorEnvoyPatchPolicy(
 		clusterName,
 		upstreamClient,
 		r.DownstreamCluster.GetClient(),
+		downstreamclient.WithControllerName("httpproxy"),
 	)
 	downstreamNamespaceName, err := downstreamStrategy.GetDownstreamNamespaceNameForUpstreamNamespace(ctx, httpProxy.Namespace)
 	if err != nil {
@@ -1691,6 +2509,94 @@ func (r *HTTPProxyReconciler) cleanupConnectorEnvoyPatchPolicy(
 	return downstreamStrategy.DeleteAnchorForObject(ctx, httpProxy)
 }
 
+// reconcileLoadBalancerPolicies maintains the BackendTrafficPolicies that
+// program backend load balancing for an HTTPProxy, removing any that are no
+// longer desired. Only policies named as load balancing policies of the
+// HTTPProxy are removed, so that other BackendTrafficPolicies it controls are
+// left to the code that programs them.
+func reconcileLoadBalancerPolicies(
+	ctx context.Context,
+	cl client.Client,
+	scheme *runtime.Scheme,
+	httpProxy *networkingv1alpha.HTTPProxy,
+	desiredPolicies []*envoygatewayv1alpha1.BackendTrafficPolicy,
+) error {
+	logger := log.FromContext(ctx)
+
+	desiredNames := sets.New[string]()
+	for _, desiredPolicy := range desiredPolicies {
+		desiredNames.Insert(desiredPolicy.Name)
+
+		policy := desiredPolicy.DeepCopy()
+		result, err := controllerutil.CreateOrUpdate(ctx, cl, policy, func() error {
+			if err := controllerutil.SetControllerReference(httpProxy, policy, scheme); err != nil {
+				return fmt.Errorf("failed to set controller on BackendTrafficPolicy: %w", err)
+			}
+			policy.Spec = desiredPolicy.Spec
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed updating backendtrafficpolicy resource: %w", err)
+		}
+		logger.Info("processed backendtrafficpolicy", jsonKeyName, policy.Name, "result", result)
+	}
+
+	var policies envoygatewayv1alpha1.BackendTrafficPolicyList
+	if err := cl.List(ctx, &policies, client.InNamespace(httpProxy.Namespace)); err != nil {
+		return fmt.Errorf("failed listing backendtrafficpolicies: %w", err)
+	}
+
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if desiredNames.Has(policy.Name) || !metav1.IsControlledBy(policy, httpProxy) || !isLoadBalancerPolicyName(httpProxy, policy.Name) {
+			continue
+		}
+		if err := cl.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed deleting backendtrafficpolicy: %w", err)
+		}
+		logger.Info("deleted backendtrafficpolicy", jsonKeyName, policy.Name)
+	}
+
+	return nil
+}
+
+// cleanupStaleHTTPRouteFilters removes HTTPRouteFilters controlled by the
+// HTTPProxy that are no longer desired.
+func cleanupStaleHTTPRouteFilters(
+	ctx context.Context,
+	cl client.Client,
+	httpProxy *networkingv1alpha.HTTPProxy,
+	desiredFilters []*envoygatewayv1alpha1.HTTPRouteFilter,
+) error {
+	desiredNames := sets.New[string]()
+	for _, desiredFilter := range desiredFilters {
+		desiredNames.Insert(desiredFilter.Name)
+	}
+
+	if !desiredNames.Has(connectorOfflineFilterName(httpProxy)) {
+		if err := cleanupConnectorOfflineHTTPRouteFilter(ctx, cl, httpProxy); err != nil {
+			return err
+		}
+	}
+
+	var filters envoygatewayv1alpha1.HTTPRouteFilterList
+	if err := cl.List(ctx, &filters, client.InNamespace(httpProxy.Namespace)); err != nil {
+		return fmt.Errorf("failed listing httproutefilters: %w", err)
+	}
+
+	for i := range filters.Items {
+		filter := &filters.Items[i]
+		if desiredNames.Has(filter.Name) || !metav1.IsControlledBy(filter, httpProxy) {
+			continue
+		}
+		if err := cl.Delete(ctx, filter); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed deleting httproutefilter: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func cleanupConnectorOfflineHTTPRouteFilter(ctx context.Context, cl client.Client, httpProxy *networkingv1alpha.HTTPProxy) error {
 	filterKey := client.ObjectKey{Namespace: httpProxy.Namespace, Name: connectorOfflineFilterName(httpProxy)}
 	var filter envoygatewayv1alpha1.HTTPRouteFilter
@@ -1756,7 +2662,7 @@ func formatPolicyConditionMessage(conditionType string, condition *metav1.Condit
 
 func collectConnectorBackends(
 	ctx context.Context,
-	cl client.Client,
+	addresses connectorAddressSource,
 	httpProxy *networkingv1alpha.HTTPProxy,
 ) ([]connectorBackendPatch, error) {
 	connectorBackends := make([]connectorBackendPatch, 0)
@@ -1776,7 +2682,11 @@ func collectConnectorBackends(
 					return nil, err
 				}
 
-				connectorReady, nodeID, err := connectorPatchDetails(ctx, cl, httpProxy.Namespace, backend.Connector.Name)
+				address, err := addresses(ctx, httpProxy.Namespace, backend.Connector.Name)
+				if err != nil {
+					return nil, err
+				}
+				connectorReady, nodeID, err := address.patchDetails(backend.Connector.Name)
 				if err != nil {
 					return nil, err
 				}
@@ -1841,32 +2751,3 @@ func httpProxyHasConnectorBackends(httpProxy *networkingv1alpha.HTTPProxy) bool
 	}
 	return false
 }
-
-func connectorReady(ctx context.Context, cl client.Client, namespace, name string) (bool, error) {
-	var connector networkingv1alpha1.Connector
-	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &connector); err != nil {
-		return false, err
-	}
-	return apimeta.IsStatusConditionTrue(connector.Status.Conditions, networkingv1alpha1.ConnectorConditionReady), nil
-}
-
-func connectorPatchDetails(ctx context.Context, cl client.Client, namespace, name string) (bool, string, error) {
-	var connector networkingv1alpha1.Connector
-	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &connector); err != nil {
-		return false, "", err
-	}
-
-	ready := apimeta.IsStatusConditionTrue(connector.Status.Conditions, networkingv1alpha1.ConnectorConditionReady)
-	if !ready {
-		return false, "", nil
-	}
-
-	details := connector.Status.ConnectionDetails
-	if details == nil || details.Type != networkingv1alpha1.PublicKeyConnectorConnectionType || details.PublicKey == nil {
-		return false, "", fmt.Errorf("connector %q does not have public key connection details", name)
-	}
-	if details.PublicKey.Id == "" {
-		return false, "", fmt.Errorf("connector %q public key id is empty", name)
-	}
-	return true, details.PublicKey.Id, nil
-}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"testing"
 	"time"
 
@@ -52,6 +53,10 @@ const certManagerConditionStatusFalse = "False"
 
 //nolint:gocyclo
 func TestHTTPProxyCollectDesiredResources(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
 
 	operatorConfig := config.NetworkServicesOperator{
 		Gateway: config.GatewayConfig{
@@ -102,6 +107,201 @@ func TestHTTPProxyCollectDesiredResources(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "response header policy",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.ResponseHeaders = &gatewayv1.HTTPHeaderFilter{
+					Set: []gatewayv1.HTTPHeader{
+						{Name: "Cache-Control", Value: "public, max-age=300"},
+						{Name: "Surrogate-Control", Value: "max-age=3600"},
+						{Name: "X-Frame-Options", Value: "DENY"},
+					},
+				}
+				h.Spec.Rules[0].ResponseHeaders = &gatewayv1.HTTPHeaderFilter{
+					Set: []gatewayv1.HTTPHeader{
+						{Name: "cache-control", Value: "no-store"},
+					},
+					Remove: []string{"Surrogate-Control"},
+				}
+				h.Spec.Rules[0].Filters = append(h.Spec.Rules[0].Filters, gatewayv1.HTTPRouteFilter{
+					Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+					ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+						Set: []gatewayv1.HTTPHeader{
+							{Name: "X-Frame-Options", Value: "SAMEORIGIN"},
+						},
+					},
+				})
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				routeRule := desiredResources.httpRoute.Spec.Rules[0]
+
+				var modifiers []*gatewayv1.HTTPHeaderFilter
+				for _, filter := range routeRule.Filters {
+					if filter.Type == gatewayv1.HTTPRouteFilterResponseHeaderModifier {
+						modifiers = append(modifiers, filter.ResponseHeaderModifier)
+					}
+				}
+				require.Len(t, modifiers, 1)
+				assert.Equal(t, []gatewayv1.HTTPHeader{
+					{Name: "cache-control", Value: "no-store"},
+					{Name: "X-Frame-Options", Value: "SAMEORIGIN"},
+				}, modifiers[0].Set)
+				assert.Equal(t, []string{"Surrogate-Control"}, modifiers[0].Remove)
+
+				// The HTTPProxy spec must not be modified.
+				assert.Len(t, httpProxy.Spec.Rules[0].Filters[1].ResponseHeaderModifier.Set, 1)
+			},
+		},
+		{
+			name: "backend load balancer",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.Rules[0].Name = ptr.To(gatewayv1.SectionName("api"))
+				h.Spec.Rules[0].Backends[0].LoadBalancer = &networkingv1alpha.HTTPProxyBackendLoadBalancer{
+					Type: networkingv1alpha.HTTPProxyBackendLoadBalancerRingHash,
+					HashKey: &networkingv1alpha.HTTPProxyBackendHashKey{
+						Cookie: ptr.To("session"),
+					},
+				}
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				require.Len(t, desiredResources.backendTrafficPolicies, 1)
+				policy := desiredResources.backendTrafficPolicies[0]
+				assert.Equal(t, loadBalancerPolicyName(httpProxy, 0), policy.Name)
+				assert.Equal(t, httpProxy.Namespace, policy.Namespace)
+				assert.NotContains(t, desiredResources.endpointSlices[0].Annotations, BackendLoadBalancerAnnotation)
+
+				if assert.Len(t, policy.Spec.TargetRefs, 1) {
+					targetRef := policy.Spec.TargetRefs[0]
+					assert.Equal(t, gatewayv1.Kind(KindHTTPRoute), targetRef.Kind)
+					assert.Equal(t, gatewayv1.ObjectName(desiredResources.httpRoute.Name), targetRef.Name)
+					assert.Equal(t, "api", string(ptr.Deref(targetRef.SectionName, "")))
+				}
+				// No policy is attached to the Gateway for the policy to overlay.
+				assert.Nil(t, policy.Spec.MergeType)
+
+				loadBalancer := policy.Spec.LoadBalancer
+				require.NotNil(t, loadBalancer)
+				assert.Equal(t, envoygatewayv1alpha1.ConsistentHashLoadBalancerType, loadBalancer.Type)
+				if assert.NotNil(t, loadBalancer.ConsistentHash) {
+					assert.Equal(t, envoygatewayv1alpha1.CookieConsistentHashType, loadBalancer.ConsistentHash.Type)
+					assert.Equal(t, "session", loadBalancer.ConsistentHash.Cookie.Name)
+				}
+			},
+		},
+		{
+			name: "backup backend",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.Rules[0].Name = ptr.To(gatewayv1.SectionName("api"))
+				h.Spec.Rules[0].HealthCheck = &networkingv1alpha.HTTPProxyHealthCheck{
+					Path: "/healthz",
+				}
+				h.Spec.Rules[0].Backends[0].Filters = nil
+				h.Spec.Rules[0].Backends = append(h.Spec.Rules[0].Backends, networkingv1alpha.HTTPProxyRuleBackend{
+					Endpoint: "http://backup.example.com",
+					Role:     networkingv1alpha.HTTPProxyBackendRoleBackup,
+				})
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				routeRule := desiredResources.httpRoute.Spec.Rules[0]
+				assert.Len(t, routeRule.BackendRefs, 2)
+
+				// The path rewrite is kept, but the Host header follows the backend
+				// selected for the request.
+				var extensionRef *gatewayv1.LocalObjectReference
+				for _, filter := range routeRule.Filters {
+					switch filter.Type {
+					case gatewayv1.HTTPRouteFilterURLRewrite:
+						assert.Nil(t, filter.URLRewrite.Hostname)
+						assert.NotNil(t, filter.URLRewrite.Path)
+					case gatewayv1.HTTPRouteFilterExtensionRef:
+						extensionRef = filter.ExtensionRef
+					}
+				}
+				require.NotNil(t, extensionRef)
+				require.Len(t, desiredResources.httpRouteFilters, 1)
+				hostRewriteFilter := desiredResources.httpRouteFilters[0]
+				assert.Equal(t, string(extensionRef.Name), hostRewriteFilter.Name)
+				assert.Equal(t, envoygatewayv1alpha1.BackendHTTPHostnameModifier, hostRewriteFilter.Spec.URLRewrite.Hostname.Type)
+
+				require.Len(t, desiredResources.endpointSlices, 2)
+				primary, backup := desiredResources.endpointSlices[0], desiredResources.endpointSlices[1]
+				assert.NotContains(t, primary.Annotations, BackendRoleAnnotation)
+				assert.Equal(t, string(networkingv1alpha.HTTPProxyBackendRoleBackup), backup.Annotations[BackendRoleAnnotation])
+				assert.Equal(t, "backup.example.com", backup.Annotations[BackendCertHostnameAnnotation])
+				for _, endpointSlice := range desiredResources.endpointSlices {
+					healthCheck, err := backendHealthCheckFromAnnotations(endpointSlice.Annotations)
+					if assert.NoError(t, err) && assert.NotNil(t, healthCheck) {
+						assert.Equal(t, "/healthz", healthCheck.Path)
+					}
+				}
+			},
+		},
+		{
+			name: "backend health check",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.Rules[0].Backends[0].HealthCheck = &networkingv1alpha.HTTPProxyHealthCheck{Path: "/ready"}
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				require.Len(t, desiredResources.endpointSlices, 1)
+				endpointSlice := desiredResources.endpointSlices[0]
+				assert.NotContains(t, endpointSlice.Annotations, BackendRoleAnnotation)
+				healthCheck, err := backendHealthCheckFromAnnotations(endpointSlice.Annotations)
+				if assert.NoError(t, err) && assert.NotNil(t, healthCheck) {
+					assert.Equal(t, "/ready", healthCheck.Path)
+				}
+				assert.Empty(t, desiredResources.httpRouteFilters)
+			},
+		},
+		{
+			name: "health checked backend load balancer",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.Rules[0].Backends[0].HealthCheck = &networkingv1alpha.HTTPProxyHealthCheck{Path: "/ready"}
+				h.Spec.Rules[0].Backends[0].LoadBalancer = &networkingv1alpha.HTTPProxyBackendLoadBalancer{
+					Type: networkingv1alpha.HTTPProxyBackendLoadBalancerLeastRequest,
+				}
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				// The load balancer is programmed along with the health check by the
+				// gateway controller, so that the rule has a single
+				// BackendTrafficPolicy.
+				assert.Empty(t, desiredResources.backendTrafficPolicies)
+				require.Len(t, desiredResources.endpointSlices, 1)
+				loadBalancer, err := backendLoadBalancerFromAnnotations(desiredResources.endpointSlices[0].Annotations)
+				if assert.NoError(t, err) && assert.NotNil(t, loadBalancer) {
+					assert.Equal(t, networkingv1alpha.HTTPProxyBackendLoadBalancerLeastRequest, loadBalancer.Type)
+				}
+			},
+		},
+		{
+			name: "canary traffic policy",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.Rules[0].TrafficPolicy = &networkingv1alpha.HTTPProxyTrafficPolicy{
+					Canary: &networkingv1alpha.HTTPProxyCanary{Percentage: 20},
+				}
+				h.Spec.Rules[0].Backends = []networkingv1alpha.HTTPProxyRuleBackend{
+					{Endpoint: "https://stable.example.com"},
+					{Endpoint: "https://canary.example.com", Role: networkingv1alpha.HTTPProxyBackendRoleCanary},
+				}
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				routeRule := desiredResources.httpRoute.Spec.Rules[0]
+				require.Len(t, routeRule.BackendRefs, 2)
+				assert.Equal(t, int32(80), ptr.Deref(routeRule.BackendRefs[0].Weight, 0))
+				assert.Equal(t, int32(20), ptr.Deref(routeRule.BackendRefs[1].Weight, 0))
+
+				for _, endpointSlice := range desiredResources.endpointSlices {
+					assert.Equal(t, "true", endpointSlice.Annotations[BackendTrafficSplitAnnotation])
+				}
+
+				// The Host header follows the backend each request is sent to.
+				require.Len(t, desiredResources.httpRouteFilters, 1)
+				for _, filter := range routeRule.Filters {
+					if filter.URLRewrite != nil {
+						assert.Nil(t, filter.URLRewrite.Hostname)
+					}
+				}
+			},
+		},
 		{
 			name: "https scheme",
 			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
@@ -447,13 +647,110 @@ func TestHTTPProxyCollectDesiredResources(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "tls policy",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.TLSPolicy = &networkingv1alpha.TLSPolicy{
+					MinVersion: ptr.To(networkingv1alpha.TLSVersion13),
+				}
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				policy, err := gatewayTLSPolicyFromAnnotations(desiredResources.gateway.Annotations)
+				assert.NoError(t, err)
+				assert.Equal(t, httpProxy.Spec.TLSPolicy, policy)
+			},
+		},
+		{
+			name: "http3 and h2c backend",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.Protocols = &networkingv1alpha.HTTPProxyProtocols{HTTP3: true}
+				h.Spec.Rules[0].Backends[0].Endpoint = "http://grpc.example.com:9000"
+				h.Spec.Rules[0].Backends[0].Protocol = networkingv1alpha.HTTPProxyBackendProtocolH2C
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				assert.Equal(t, "true", desiredResources.gateway.Annotations[GatewayHTTP3Annotation])
+				if assert.NotEmpty(t, desiredResources.endpointSlices) && assert.Len(t, desiredResources.endpointSlices[0].Ports, 1) {
+					assert.Equal(t, ptr.To(AppProtocolH2C), desiredResources.endpointSlices[0].Ports[0].AppProtocol)
+				}
+			},
+		},
+		{
+			name: "custom TLS certificate",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.Hostnames = []gatewayv1.Hostname{
+					gatewayv1.Hostname("test.example.com"),
+				}
+				h.Spec.TLS = &networkingv1alpha.HTTPProxyTLS{
+					CertificateRef: corev1.LocalObjectReference{Name: "test-cert"},
+				}
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				listener := gatewayutil.GetListenerByName(desiredResources.gateway.Spec.Listeners, "https-hostname-0")
+				if assert.NotNil(t, listener) && assert.NotNil(t, listener.TLS) {
+					assert.Empty(t, listener.TLS.Options)
+					assert.Equal(t, []gatewayv1.SecretObjectReference{{Name: "test-cert"}}, listener.TLS.CertificateRefs)
+				}
+			},
+		},
+		{
+			name: "request mirror",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.Rules[0].Mirror = &networkingv1alpha.HTTPProxyRequestMirror{
+					Endpoint: "https://shadow.example.com:8443",
+					Percent:  ptr.To[int32](25),
+				}
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				routeRule := desiredResources.httpRoute.Spec.Rules[0]
+				mirrorIndex := slices.IndexFunc(routeRule.Filters, func(f gatewayv1.HTTPRouteFilter) bool {
+					return f.Type == gatewayv1.HTTPRouteFilterRequestMirror
+				})
+				require.GreaterOrEqual(t, mirrorIndex, 0)
+				mirror := routeRule.Filters[mirrorIndex].RequestMirror
+				require.NotNil(t, mirror)
+				assert.Equal(t, gatewayv1.BackendObjectReference{
+					Group: ptr.To(gatewayv1.Group("discovery.k8s.io")),
+					Kind:  ptr.To(gatewayv1.Kind("EndpointSlice")),
+					Name:  gatewayv1.ObjectName(httpProxy.Name + "-0-mirror"),
+					Port:  ptr.To(gatewayv1.PortNumber(8443)),
+				}, mirror.BackendRef)
+				assert.Equal(t, ptr.To[int32](25), mirror.Percent)
+
+				endpointSliceIndex := slices.IndexFunc(desiredResources.endpointSlices, func(e *discoveryv1.EndpointSlice) bool {
+					return e.Name == httpProxy.Name+"-0-mirror"
+				})
+				require.GreaterOrEqual(t, endpointSliceIndex, 0)
+				endpointSlice := desiredResources.endpointSlices[endpointSliceIndex]
+				assert.Equal(t, discoveryv1.AddressTypeFQDN, endpointSlice.AddressType)
+				assert.Equal(t, []string{"shadow.example.com"}, endpointSlice.Endpoints[0].Addresses)
+				assert.Equal(t, SchemeHTTPS, ptr.Deref(endpointSlice.Ports[0].AppProtocol, ""))
+				assert.Equal(t, "shadow.example.com", endpointSlice.Annotations[BackendCertHostnameAnnotation])
+			},
+		},
+		{
+			name: "client certificate validation",
+			httpProxy: newHTTPProxy(func(h *networkingv1alpha.HTTPProxy) {
+				h.Spec.ClientValidation = &networkingv1alpha.HTTPProxyClientValidation{
+					CACertificateRef: corev1.LocalObjectReference{Name: "client-ca"},
+					Mode:             gatewayv1.AllowValidOnly,
+				}
+			}),
+			assert: func(t *testing.T, httpProxy *networkingv1alpha.HTTPProxy, desiredResources *desiredHTTPProxyResources) {
+				tls := desiredResources.gateway.Spec.TLS
+				if assert.NotNil(t, tls) && assert.NotNil(t, tls.Frontend) && assert.NotNil(t, tls.Frontend.Default.Validation) {
+					validation := tls.Frontend.Default.Validation
+					assert.Equal(t, gatewayv1.AllowValidOnly, validation.Mode)
+					assert.Equal(t, []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "client-ca"}}, validation.CACertificateRefs)
+				}
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
 			reconciler := &HTTPProxyReconciler{Config: operatorConfig}
-			cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
-			desiredResources, err := reconciler.collectDesiredResources(context.Background(), cl, tt.httpProxy)
+			cl := fake.NewClientBuilder().WithScheme(testScheme).Build()
+			desiredResources, err := reconciler.collectDesiredResources(context.Background(), "", cl, tt.httpProxy)
 
 			if tt.expectError != "" {
 				assert.Error(t, err)
@@ -468,7 +765,14 @@ func TestHTTPProxyCollectDesiredResources(t *testing.T) {
 
 			assert.NotNil(t, gateway)
 			assert.NotNil(t, httpRoute)
-			assert.Len(t, endpointSlices, 1)
+			backendCount := 0
+			for _, rule := range tt.httpProxy.Spec.Rules {
+				backendCount += len(rule.Backends)
+				if rule.Mirror != nil {
+					backendCount++
+				}
+			}
+			assert.Len(t, endpointSlices, backendCount)
 
 			// Gateway assertions on items that are not hard coded
 			assert.Equal(t, tt.httpProxy.Namespace, gateway.Namespace)
@@ -1944,6 +2248,55 @@ func TestHTTPProxyFinalizerCleanup(t *testing.T) {
 	}
 }
 
+func TestReconcileLoadBalancerPolicies(t *testing.T) {
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+
+	httpProxy := newHTTPProxy()
+	ownedPolicy := func(name string) *envoygatewayv1alpha1.BackendTrafficPolicy {
+		policy := &envoygatewayv1alpha1.BackendTrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: httpProxy.Namespace, Name: name},
+		}
+		require.NoError(t, controllerutil.SetControllerReference(httpProxy, policy, testScheme))
+		return policy
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			httpProxy,
+			ownedPolicy(loadBalancerPolicyName(httpProxy, 1)),
+			ownedPolicy(httpProxy.Name+"-other"),
+			&envoygatewayv1alpha1.BackendTrafficPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: httpProxy.Namespace, Name: loadBalancerPolicyName(httpProxy, 2)},
+			},
+		).
+		Build()
+
+	desiredPolicy := desiredLoadBalancerPolicy(httpProxy, httpProxy.Name, 0, nil, &networkingv1alpha.HTTPProxyBackendLoadBalancer{
+		Type: networkingv1alpha.HTTPProxyBackendLoadBalancerLeastRequest,
+	}, nil)
+	require.NoError(t, reconcileLoadBalancerPolicies(ctx, fakeClient, testScheme, httpProxy, []*envoygatewayv1alpha1.BackendTrafficPolicy{desiredPolicy}))
+
+	var policies envoygatewayv1alpha1.BackendTrafficPolicyList
+	require.NoError(t, fakeClient.List(ctx, &policies, client.InNamespace(httpProxy.Namespace)))
+	var names []string
+	for _, policy := range policies.Items {
+		names = append(names, policy.Name)
+	}
+	// The stale load balancing policy is removed, while policies that are not
+	// load balancing policies of the HTTPProxy are kept.
+	assert.ElementsMatch(t, []string{
+		loadBalancerPolicyName(httpProxy, 0),
+		loadBalancerPolicyName(httpProxy, 2),
+		httpProxy.Name + "-other",
+	}, names)
+}
+
 // TestHTTPProxyReconcileConnectorEPPEmissionDisabled verifies that when
 // gateway.eppEmissionEnabled is false, the HTTPProxy reconciler does NOT create
 // a connector EnvoyPatchPolicy in the downstream cluster even when all
@@ -2501,16 +2854,25 @@ func newHTTPProxy(opts ...func(*networkingv1alpha.HTTPProxy)) *networkingv1alpha
 
 type fakeMockManager struct {
 	mcmanager.Manager
-	cl client.Client
+	cl       client.Client
+	recorder events.EventRecorder
 }
 
 func (m *fakeMockManager) GetCluster(ctx context.Context, clusterName multicluster.ClusterName) (cluster.Cluster, error) {
-	return &fakeCluster{cl: m.cl}, nil
+	return &fakeCluster{cl: m.cl, recorder: m.recorder}, nil
 }
 
 type fakeCluster struct {
 	cluster.Cluster
-	cl client.Client
+	cl       client.Client
+	recorder events.EventRecorder
+}
+
+func (c *fakeCluster) GetEventRecorder(string) events.EventRecorder {
+	if c.recorder == nil {
+		return &events.FakeRecorder{}
+	}
+	return c.recorder
 }
 
 func (c *fakeCluster) GetClient() client.Client {
@@ -2535,6 +2897,7 @@ func TestBuildAvailabilityStatuses(t *testing.T) {
 		name              string
 		acceptedHostnames sets.Set[gatewayv1.Hostname]
 		inUseHostnames    sets.Set[string]
+		blockedHostnames  sets.Set[string]
 		wantHostnames     []string
 		wantConditions    map[string]metav1.Condition
 	}{
@@ -2624,6 +2987,31 @@ func TestBuildAvailabilityStatuses(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "blocked hostname gets Available=False with reason Blocked",
+			acceptedHostnames: sets.New[gatewayv1.Hostname](
+				gatewayv1.Hostname("accepted.example.com"),
+			),
+			inUseHostnames: sets.New[string](),
+			blockedHostnames: sets.New[string](
+				"phish.example.com",
+			),
+			wantHostnames: []string{"accepted.example.com", "phish.example.com"},
+			wantConditions: map[string]metav1.Condition{
+				"accepted.example.com": {
+					Type:               networkingv1alpha.HostnameConditionAvailable,
+					Status:             metav1.ConditionTrue,
+					Reason:             networkingv1alpha.HostnameAvailableReasonClaimed,
+					ObservedGeneration: generation,
+				},
+				"phish.example.com": {
+					Type:               networkingv1alpha.HostnameConditionAvailable,
+					Status:             metav1.ConditionFalse,
+					Reason:             networkingv1alpha.HostnameAvailableReasonBlocked,
+					ObservedGeneration: generation,
+				},
+			},
+		},
 		{
 			name: "ObservedGeneration is set from the generation argument",
 			acceptedHostnames: sets.New[gatewayv1.Hostname](
@@ -2646,7 +3034,7 @@ func TestBuildAvailabilityStatuses(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			statuses := buildAvailabilityStatuses(tt.acceptedHostnames, tt.inUseHostnames, generation)
+			statuses := buildAvailabilityStatuses(tt.acceptedHostnames, tt.inUseHostnames, tt.blockedHostnames, generation)
 
 			// Verify each expected hostname appears exactly once.
 			gotHostnames := make([]string, 0, len(statuses))
@@ -2674,6 +3062,128 @@ func TestBuildAvailabilityStatuses(t *testing.T) {
 	}
 }
 
+func TestBuildVerificationStatuses(t *testing.T) {
+	t.Parallel()
+
+	statuses := buildVerificationStatuses(
+		sets.New[gatewayv1.Hostname]("verified.example.com"),
+		sets.New[string]("unverified.example.com"),
+		3,
+	)
+	statuses = mergeHostnameStatuses(statuses)
+	require.Len(t, statuses, 2)
+
+	unverified := apimeta.FindStatusCondition(statuses[0].Conditions, networkingv1alpha.HostnameConditionVerified)
+	require.NotNil(t, unverified)
+	assert.Equal(t, "unverified.example.com", statuses[0].Hostname)
+	assert.Equal(t, metav1.ConditionFalse, unverified.Status)
+	assert.Equal(t, networkingv1alpha.HostnameVerifiedReasonUnverified, unverified.Reason)
+
+	verified := apimeta.FindStatusCondition(statuses[1].Conditions, networkingv1alpha.HostnameConditionVerified)
+	require.NotNil(t, verified)
+	assert.Equal(t, metav1.ConditionTrue, verified.Status)
+	assert.Equal(t, int64(3), verified.ObservedGeneration)
+}
+
+func TestBuildRouteStatuses(t *testing.T) {
+	t.Parallel()
+
+	programmed := func(status metav1.ConditionStatus, reason string) []metav1.Condition {
+		return []metav1.Condition{{
+			Type:   string(gatewayv1.ListenerConditionProgrammed),
+			Status: status,
+			Reason: reason,
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		listeners  []gatewayv1.ListenerStatus
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name: "all listeners programmed",
+			listeners: []gatewayv1.ListenerStatus{
+				{Name: "http", AttachedRoutes: 1, Conditions: programmed(metav1.ConditionTrue, "Programmed")},
+				{Name: "https", AttachedRoutes: 1, Conditions: programmed(metav1.ConditionTrue, "Programmed")},
+			},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: networkingv1alpha.RouteProgrammedReasonProgrammed,
+		},
+		{
+			name: "listener status missing",
+			listeners: []gatewayv1.ListenerStatus{
+				{Name: "http", AttachedRoutes: 1, Conditions: programmed(metav1.ConditionTrue, "Programmed")},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: networkingv1alpha.RouteProgrammedReasonPending,
+		},
+		{
+			name: "listener not programmed",
+			listeners: []gatewayv1.ListenerStatus{
+				{Name: "http", AttachedRoutes: 1, Conditions: programmed(metav1.ConditionTrue, "Programmed")},
+				{Name: "https", AttachedRoutes: 1, Conditions: programmed(metav1.ConditionFalse, "Invalid")},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "Invalid",
+		},
+		{
+			name: "no attached routes",
+			listeners: []gatewayv1.ListenerStatus{
+				{Name: "http", Conditions: programmed(metav1.ConditionTrue, "Programmed")},
+				{Name: "https", AttachedRoutes: 1, Conditions: programmed(metav1.ConditionTrue, "Programmed")},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: networkingv1alpha.RouteProgrammedReasonNoAttachedRoutes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gateway := &gatewayv1.Gateway{
+				Spec: gatewayv1.GatewaySpec{
+					Listeners: []gatewayv1.Listener{
+						{Name: "http", Hostname: ptr.To(gatewayv1.Hostname("www.example.com"))},
+						{Name: "https", Hostname: ptr.To(gatewayv1.Hostname("www.example.com"))},
+					},
+				},
+				Status: gatewayv1.GatewayStatus{Listeners: tt.listeners},
+			}
+
+			statuses := buildRouteStatuses(gateway, 1)
+			require.Len(t, statuses, 1)
+			cond := apimeta.FindStatusCondition(statuses[0].Conditions, networkingv1alpha.HostnameConditionRouteProgrammed)
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.wantStatus, cond.Status)
+			assert.Equal(t, tt.wantReason, cond.Reason)
+		})
+	}
+}
+
+func TestCountHostnameStatuses(t *testing.T) {
+	t.Parallel()
+
+	statuses := mergeHostnameStatuses(
+		buildVerificationStatuses(
+			sets.New[gatewayv1.Hostname]("a.example.com", "b.example.com"),
+			sets.New("c.example.com"),
+			1,
+		),
+		[]networkingv1alpha.HostnameStatus{{Hostname: "d.example.com"}},
+	)
+
+	total, verified := countHostnameStatuses(statuses)
+	assert.Equal(t, int32(4), total)
+	assert.Equal(t, int32(2), verified)
+
+	total, verified = countHostnameStatuses(nil)
+	assert.Zero(t, total)
+	assert.Zero(t, verified)
+}
+
 func TestMergeHostnameStatuses(t *testing.T) {
 	t.Parallel()
 
@@ -2953,6 +3463,22 @@ func TestBuildCertificateStatuses(t *testing.T) {
 		},
 	}
 
+	gatewayWithCustomCertificate := func(status metav1.ConditionStatus, message string) *gatewayv1.Gateway {
+		gw := gatewayWithHTTPS.DeepCopy()
+		gw.Spec.Listeners[0].TLS = &gatewayv1.ListenerTLSConfig{
+			CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "custom-cert"}},
+		}
+		gw.Status.Listeners = []gatewayv1.ListenerStatus{
+			{
+				Name: "https-hostname-0",
+				Conditions: []metav1.Condition{
+					{Type: ListenerConditionCertificateReady, Status: status, Reason: "Test", Message: message},
+				},
+			},
+		}
+		return gw
+	}
+
 	tests := []struct {
 		name              string
 		config            *config.NetworkServicesOperator
@@ -3027,6 +3553,23 @@ func TestBuildCertificateStatuses(t *testing.T) {
 			wantReason:        networkingv1alpha.CertificateReadyReasonPending,
 			wantStatus:        metav1.ConditionFalse,
 		},
+		{
+			name:              "valid custom certificate returns CertificateIssued",
+			gateway:           gatewayWithCustomCertificate(metav1.ConditionTrue, "ok"),
+			downstreamCluster: true,
+			wantLen:           1,
+			wantReason:        networkingv1alpha.CertificateReadyReasonCertificateIssued,
+			wantStatus:        metav1.ConditionTrue,
+		},
+		{
+			name:              "invalid custom certificate returns ProvisioningFailed",
+			gateway:           gatewayWithCustomCertificate(metav1.ConditionFalse, "The certificate has expired"),
+			downstreamCluster: true,
+			wantLen:           1,
+			wantReason:        networkingv1alpha.CertificateReadyReasonProvisioningFailed,
+			wantStatus:        metav1.ConditionFalse,
+			wantMessage:       "The certificate has expired",
+		},
 	}
 
 	for _, tt := range tests {
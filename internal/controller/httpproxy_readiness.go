@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// setHTTPProxyReadiness evaluates the readiness gates of an HTTPProxy, records
+// the breakdown in status.readiness, and sets the composite Ready condition.
+//
+// programmedCondition is the Programmed condition computed during the current
+// reconcile. gateway and httpRoute may be nil when reconciliation stopped
+// before they were processed.
+func (r *HTTPProxyReconciler) setHTTPProxyReadiness(
+	httpProxy *networkingv1alpha.HTTPProxy,
+	programmedCondition *metav1.Condition,
+	gateway *gatewayv1.Gateway,
+	httpRoute *gatewayv1.HTTPRoute,
+) {
+	gates := []networkingv1alpha.HTTPProxyReadinessGate{
+		gatewayProgrammedReadinessGate(programmedCondition),
+		certificatesReadinessGate(httpProxy),
+		r.dnsRecordsReadinessGate(httpProxy, gateway),
+		routesAcceptedReadinessGate(httpRoute, r.Config.Gateway.ControllerName),
+	}
+
+	httpProxy.Status.Readiness = &networkingv1alpha.HTTPProxyReadiness{Gates: gates}
+
+	var pendingGates []string
+	for _, gate := range gates {
+		if gate.Status != metav1.ConditionTrue {
+			pendingGates = append(pendingGates, string(gate.Name))
+		}
+	}
+
+	readyCondition := metav1.Condition{
+		Type:               networkingv1alpha.HTTPProxyConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.HTTPProxyReasonReady,
+		Message:            "All readiness gates are satisfied",
+		ObservedGeneration: httpProxy.Generation,
+	}
+	if len(pendingGates) > 0 {
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = networkingv1alpha.HTTPProxyReasonReadinessGatesPending
+		readyCondition.Message = fmt.Sprintf("Waiting on readiness gates: %s", strings.Join(pendingGates, ", "))
+	}
+
+	apimeta.SetStatusCondition(&httpProxy.Status.Conditions, readyCondition)
+}
+
+func gatewayProgrammedReadinessGate(programmedCondition *metav1.Condition) networkingv1alpha.HTTPProxyReadinessGate {
+	gate := networkingv1alpha.HTTPProxyReadinessGate{
+		Name:    networkingv1alpha.HTTPProxyReadinessGateGatewayProgrammed,
+		Status:  metav1.ConditionFalse,
+		Message: "The Gateway has not been programmed",
+	}
+	if programmedCondition == nil {
+		return gate
+	}
+
+	if programmedCondition.Status == metav1.ConditionTrue {
+		gate.Status = metav1.ConditionTrue
+		gate.Message = "The Gateway has been programmed"
+	} else if programmedCondition.Message != "" {
+		gate.Message = programmedCondition.Message
+	}
+
+	return gate
+}
+
+// certificatesReadinessGate is satisfied when every hostname tracked with a
+// CertificateReady condition has an issued certificate.
+func certificatesReadinessGate(httpProxy *networkingv1alpha.HTTPProxy) networkingv1alpha.HTTPProxyReadinessGate {
+	gate := networkingv1alpha.HTTPProxyReadinessGate{
+		Name: networkingv1alpha.HTTPProxyReadinessGateCertificatesReady,
+	}
+
+	gate.Pending = pendingHostnamesForCondition(httpProxy.Status.HostnameStatuses, networkingv1alpha.HostnameConditionCertificateReady)
+
+	aggregate := apimeta.FindStatusCondition(httpProxy.Status.Conditions, networkingv1alpha.HTTPProxyConditionCertificatesReady)
+	switch {
+	case len(gate.Pending) > 0:
+		gate.Status = metav1.ConditionFalse
+		gate.Message = "Certificates are pending for one or more hostnames"
+	case aggregate != nil && aggregate.Status != metav1.ConditionTrue:
+		gate.Status = aggregate.Status
+		gate.Message = aggregate.Message
+	case aggregate == nil && len(httpProxy.Status.HostnameStatuses) == 0:
+		gate.Status = metav1.ConditionUnknown
+		gate.Message = "Waiting for hostname status"
+	default:
+		gate.Status = metav1.ConditionTrue
+		gate.Message = "Certificates have been issued for all hostnames"
+	}
+
+	return gate
+}
+
+// dnsRecordsReadinessGate is satisfied when DNS records are programmed for
+// every hostname that uses Datum-managed DNS, and resolve when DNS records are
+// verified. The gate is always satisfied when DNS integration is disabled.
+func (r *HTTPProxyReconciler) dnsRecordsReadinessGate(
+	httpProxy *networkingv1alpha.HTTPProxy,
+	gateway *gatewayv1.Gateway,
+) networkingv1alpha.HTTPProxyReadinessGate {
+	gate := networkingv1alpha.HTTPProxyReadinessGate{
+		Name:    networkingv1alpha.HTTPProxyReadinessGateDNSRecordsProgrammed,
+		Status:  metav1.ConditionTrue,
+		Message: "DNS records have been programmed for all hostnames",
+	}
+
+	if !r.Config.Gateway.EnableDNSIntegration {
+		gate.Message = "Datum-managed DNS is not enabled"
+		return gate
+	}
+
+	gate.Pending = pendingHostnamesForCondition(httpProxy.Status.HostnameStatuses, networkingv1alpha.HostnameConditionDNSRecordProgrammed)
+	if len(gate.Pending) > 0 {
+		gate.Status = metav1.ConditionFalse
+		gate.Message = "DNS records are pending for one or more hostnames"
+		return gate
+	}
+
+	if gateway != nil {
+		if c := apimeta.FindStatusCondition(gateway.Status.Conditions, networkingv1alpha.HTTPProxyConditionDNSRecordsProgrammed); c != nil && c.Status != metav1.ConditionTrue {
+			gate.Status = c.Status
+			gate.Message = c.Message
+			return gate
+		}
+		// Programmed records only satisfy the gate once they resolve, when DNS
+		// records are verified.
+		if c := apimeta.FindStatusCondition(gateway.Status.Conditions, networkingv1alpha.HTTPProxyConditionDNSRecordsResolvable); c != nil && c.Status != metav1.ConditionTrue {
+			gate.Status = c.Status
+			gate.Message = c.Message
+		}
+	}
+
+	return gate
+}
+
+// routesAcceptedReadinessGate is satisfied when every parent referenced by the
+// HTTPRoute reports the route as Accepted for the current generation. Only the
+// parent statuses written by the given controller are considered.
+func routesAcceptedReadinessGate(httpRoute *gatewayv1.HTTPRoute, controllerName gatewayv1.GatewayController) networkingv1alpha.HTTPProxyReadinessGate {
+	gate := networkingv1alpha.HTTPProxyReadinessGate{
+		Name:    networkingv1alpha.HTTPProxyReadinessGateRoutesAccepted,
+		Status:  metav1.ConditionFalse,
+		Message: "The HTTPRoute has not been processed",
+	}
+	if httpRoute == nil {
+		return gate
+	}
+
+	for _, parentRef := range httpRoute.Spec.ParentRefs {
+		accepted := false
+		for _, parentStatus := range httpRoute.Status.Parents {
+			if parentStatus.ControllerName != controllerName || !parentRefsEqual(httpRoute.Namespace, parentStatus.ParentRef, parentRef) {
+				continue
+			}
+			c := apimeta.FindStatusCondition(parentStatus.Conditions, string(gatewayv1.RouteConditionAccepted))
+			accepted = c != nil && c.Status == metav1.ConditionTrue && c.ObservedGeneration == httpRoute.Generation
+			break
+		}
+		if !accepted {
+			gate.Pending = append(gate.Pending, string(parentRef.Name))
+		}
+	}
+
+	if len(gate.Pending) > 0 {
+		gate.Message = "The HTTPRoute has not been accepted by all parents"
+		return gate
+	}
+
+	gate.Status = metav1.ConditionTrue
+	gate.Message = "The HTTPRoute has been accepted by all parents"
+	return gate
+}
+
+// parentRefsEqual returns whether two parentRefs of a route in the given
+// namespace reference the same parent, after defaulting their group, kind and
+// namespace.
+func parentRefsEqual(namespace string, a, b gatewayv1.ParentReference) bool {
+	return ptr.Deref(a.Group, gatewayv1.GroupName) == ptr.Deref(b.Group, gatewayv1.GroupName) &&
+		ptr.Deref(a.Kind, KindGateway) == ptr.Deref(b.Kind, KindGateway) &&
+		ptr.Deref(a.Namespace, gatewayv1.Namespace(namespace)) == ptr.Deref(b.Namespace, gatewayv1.Namespace(namespace)) &&
+		a.Name == b.Name &&
+		ptr.Equal(a.SectionName, b.SectionName) &&
+		ptr.Equal(a.Port, b.Port)
+}
+
+// pendingHostnamesForCondition returns the sorted hostnames that carry the
+// given condition type with a status other than True.
+func pendingHostnamesForCondition(statuses []networkingv1alpha.HostnameStatus, conditionType string) []string {
+	var pending []string
+	for _, hs := range statuses {
+		c := apimeta.FindStatusCondition(hs.Conditions, conditionType)
+		if c != nil && c.Status != metav1.ConditionTrue {
+			pending = append(pending, hs.Hostname)
+		}
+	}
+	slices.Sort(pending)
+	return pending
+}
@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestSetHTTPProxyReadiness(t *testing.T) {
+	const controllerName = gatewayv1.GatewayController("gateway.networking.datumapis.com/external-global-proxy-controller")
+
+	programmed := &metav1.Condition{
+		Type:   networkingv1alpha.HTTPProxyConditionProgrammed,
+		Status: metav1.ConditionTrue,
+	}
+
+	acceptedRoute := func() *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test", Generation: 2},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "test"}},
+				},
+			},
+			Status: gatewayv1.HTTPRouteStatus{
+				RouteStatus: gatewayv1.RouteStatus{
+					Parents: []gatewayv1.RouteParentStatus{
+						{
+							ParentRef:      gatewayv1.ParentReference{Namespace: ptr.To(gatewayv1.Namespace("default")), Name: "test"},
+							ControllerName: controllerName,
+							Conditions: []metav1.Condition{
+								{
+									Type:               string(gatewayv1.RouteConditionAccepted),
+									Status:             metav1.ConditionTrue,
+									ObservedGeneration: 2,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	hostnameStatus := func(hostname, conditionType string, status metav1.ConditionStatus) networkingv1alpha.HostnameStatus {
+		return networkingv1alpha.HostnameStatus{
+			Hostname:   hostname,
+			Conditions: []metav1.Condition{{Type: conditionType, Status: status}},
+		}
+	}
+
+	readyProxy := func() *networkingv1alpha.HTTPProxy {
+		p := newHTTPProxy()
+		p.Status.HostnameStatuses = []networkingv1alpha.HostnameStatus{
+			hostnameStatus("a.example.com", networkingv1alpha.HostnameConditionCertificateReady, metav1.ConditionTrue),
+		}
+		apimeta.SetStatusCondition(&p.Status.Conditions, metav1.Condition{
+			Type:   networkingv1alpha.HTTPProxyConditionCertificatesReady,
+			Status: metav1.ConditionTrue,
+		})
+		return p
+	}
+
+	tests := []struct {
+		name                string
+		enableDNS           bool
+		httpProxy           *networkingv1alpha.HTTPProxy
+		programmedCondition *metav1.Condition
+		gateway             *gatewayv1.Gateway
+		httpRoute           *gatewayv1.HTTPRoute
+		expectedReady       metav1.ConditionStatus
+		expectedGates       map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus
+		expectedPending     map[networkingv1alpha.HTTPProxyReadinessGateName][]string
+	}{
+		{
+			name:                "all gates satisfied",
+			httpProxy:           readyProxy(),
+			programmedCondition: programmed,
+			httpRoute:           acceptedRoute(),
+			expectedReady:       metav1.ConditionTrue,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateGatewayProgrammed:    metav1.ConditionTrue,
+				networkingv1alpha.HTTPProxyReadinessGateCertificatesReady:    metav1.ConditionTrue,
+				networkingv1alpha.HTTPProxyReadinessGateDNSRecordsProgrammed: metav1.ConditionTrue,
+				networkingv1alpha.HTTPProxyReadinessGateRoutesAccepted:       metav1.ConditionTrue,
+			},
+		},
+		{
+			name: "pending certificate",
+			httpProxy: func() *networkingv1alpha.HTTPProxy {
+				p := readyProxy()
+				p.Status.HostnameStatuses = append(p.Status.HostnameStatuses,
+					hostnameStatus("b.example.com", networkingv1alpha.HostnameConditionCertificateReady, metav1.ConditionFalse))
+				return p
+			}(),
+			programmedCondition: programmed,
+			httpRoute:           acceptedRoute(),
+			expectedReady:       metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateCertificatesReady: metav1.ConditionFalse,
+			},
+			expectedPending: map[networkingv1alpha.HTTPProxyReadinessGateName][]string{
+				networkingv1alpha.HTTPProxyReadinessGateCertificatesReady: {"b.example.com"},
+			},
+		},
+		{
+			name:      "pending dns record",
+			enableDNS: true,
+			httpProxy: func() *networkingv1alpha.HTTPProxy {
+				p := readyProxy()
+				p.Status.HostnameStatuses = append(p.Status.HostnameStatuses,
+					hostnameStatus("c.example.com", networkingv1alpha.HostnameConditionDNSRecordProgrammed, metav1.ConditionFalse))
+				return p
+			}(),
+			programmedCondition: programmed,
+			httpRoute:           acceptedRoute(),
+			expectedReady:       metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateDNSRecordsProgrammed: metav1.ConditionFalse,
+			},
+			expectedPending: map[networkingv1alpha.HTTPProxyReadinessGateName][]string{
+				networkingv1alpha.HTTPProxyReadinessGateDNSRecordsProgrammed: {"c.example.com"},
+			},
+		},
+		{
+			name:                "gateway dns aggregate failing",
+			enableDNS:           true,
+			httpProxy:           readyProxy(),
+			programmedCondition: programmed,
+			gateway: &gatewayv1.Gateway{
+				Status: gatewayv1.GatewayStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   networkingv1alpha.HTTPProxyConditionDNSRecordsProgrammed,
+							Status: metav1.ConditionFalse,
+						},
+					},
+				},
+			},
+			httpRoute:     acceptedRoute(),
+			expectedReady: metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateDNSRecordsProgrammed: metav1.ConditionFalse,
+			},
+		},
+		{
+			name:                "gateway dns records propagating",
+			enableDNS:           true,
+			httpProxy:           readyProxy(),
+			programmedCondition: programmed,
+			gateway: &gatewayv1.Gateway{
+				Status: gatewayv1.GatewayStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   networkingv1alpha.HTTPProxyConditionDNSRecordsProgrammed,
+							Status: metav1.ConditionTrue,
+						},
+						{
+							Type:   networkingv1alpha.HTTPProxyConditionDNSRecordsResolvable,
+							Status: metav1.ConditionFalse,
+							Reason: networkingv1alpha.DNSRecordsResolvableReasonPropagating,
+						},
+					},
+				},
+			},
+			httpRoute:     acceptedRoute(),
+			expectedReady: metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateDNSRecordsProgrammed: metav1.ConditionFalse,
+			},
+		},
+		{
+			name:      "gateway not programmed",
+			httpProxy: readyProxy(),
+			programmedCondition: &metav1.Condition{
+				Type:    networkingv1alpha.HTTPProxyConditionProgrammed,
+				Status:  metav1.ConditionFalse,
+				Message: "waiting",
+			},
+			httpRoute:     acceptedRoute(),
+			expectedReady: metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateGatewayProgrammed: metav1.ConditionFalse,
+			},
+		},
+		{
+			name:                "route accepted for stale generation",
+			httpProxy:           readyProxy(),
+			programmedCondition: programmed,
+			httpRoute: func() *gatewayv1.HTTPRoute {
+				r := acceptedRoute()
+				r.Generation = 3
+				return r
+			}(),
+			expectedReady: metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateRoutesAccepted: metav1.ConditionFalse,
+			},
+			expectedPending: map[networkingv1alpha.HTTPProxyReadinessGateName][]string{
+				networkingv1alpha.HTTPProxyReadinessGateRoutesAccepted: {"test"},
+			},
+		},
+		{
+			name:                "route accepted by another controller",
+			httpProxy:           readyProxy(),
+			programmedCondition: programmed,
+			httpRoute: func() *gatewayv1.HTTPRoute {
+				r := acceptedRoute()
+				r.Status.Parents[0].ControllerName = "example.com/other-controller"
+				return r
+			}(),
+			expectedReady: metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateRoutesAccepted: metav1.ConditionFalse,
+			},
+		},
+		{
+			name:                "route accepted for another listener",
+			httpProxy:           readyProxy(),
+			programmedCondition: programmed,
+			httpRoute: func() *gatewayv1.HTTPRoute {
+				r := acceptedRoute()
+				r.Spec.ParentRefs[0].SectionName = ptr.To(gatewayv1.SectionName("https"))
+				return r
+			}(),
+			expectedReady: metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateRoutesAccepted: metav1.ConditionFalse,
+			},
+		},
+		{
+			name:                "route accepted for a gateway in another namespace",
+			httpProxy:           readyProxy(),
+			programmedCondition: programmed,
+			httpRoute: func() *gatewayv1.HTTPRoute {
+				r := acceptedRoute()
+				r.Status.Parents[0].ParentRef.Namespace = ptr.To(gatewayv1.Namespace("other"))
+				return r
+			}(),
+			expectedReady: metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateRoutesAccepted: metav1.ConditionFalse,
+			},
+		},
+		{
+			name:                "route not yet processed",
+			httpProxy:           readyProxy(),
+			programmedCondition: programmed,
+			expectedReady:       metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateRoutesAccepted: metav1.ConditionFalse,
+			},
+		},
+		{
+			name:                "no hostname status yet",
+			httpProxy:           newHTTPProxy(),
+			programmedCondition: programmed,
+			httpRoute:           acceptedRoute(),
+			expectedReady:       metav1.ConditionFalse,
+			expectedGates: map[networkingv1alpha.HTTPProxyReadinessGateName]metav1.ConditionStatus{
+				networkingv1alpha.HTTPProxyReadinessGateCertificatesReady: metav1.ConditionUnknown,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := &HTTPProxyReconciler{
+				Config: config.NetworkServicesOperator{
+					Gateway: config.GatewayConfig{
+						ControllerName:       controllerName,
+						EnableDNSIntegration: tt.enableDNS,
+					},
+				},
+			}
+
+			reconciler.setHTTPProxyReadiness(tt.httpProxy, tt.programmedCondition, tt.gateway, tt.httpRoute)
+
+			readyCondition := apimeta.FindStatusCondition(tt.httpProxy.Status.Conditions, networkingv1alpha.HTTPProxyConditionReady)
+			require.NotNil(t, readyCondition)
+			assert.Equal(t, tt.expectedReady, readyCondition.Status)
+
+			require.NotNil(t, tt.httpProxy.Status.Readiness)
+			gates := map[networkingv1alpha.HTTPProxyReadinessGateName]networkingv1alpha.HTTPProxyReadinessGate{}
+			for _, gate := range tt.httpProxy.Status.Readiness.Gates {
+				gates[gate.Name] = gate
+			}
+			assert.Len(t, gates, 4)
+
+			for name, status := range tt.expectedGates {
+				assert.Equal(t, status, gates[name].Status, "gate %s status mismatch", name)
+			}
+			for name, pending := range tt.expectedPending {
+				assert.Equal(t, pending, gates[name].Pending, "gate %s pending mismatch", name)
+			}
+		})
+	}
+}
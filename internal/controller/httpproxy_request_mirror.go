@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// desiredRequestMirror returns the EndpointSlice of the mirror backend of a
+// rule in an HTTPProxy, and the RequestMirror filter that references it.
+func desiredRequestMirror(
+	httpProxy *networkingv1alpha.HTTPProxy,
+	ruleIndex int,
+	mirror *networkingv1alpha.HTTPProxyRequestMirror,
+) (*discoveryv1.EndpointSlice, gatewayv1.HTTPRouteFilter, error) {
+	u, err := url.Parse(mirror.Endpoint)
+	if err != nil {
+		return nil, gatewayv1.HTTPRouteFilter{}, fmt.Errorf("failed parsing mirror endpoint in rule %d: %w", ruleIndex, err)
+	}
+
+	appProtocol := SchemeHTTP
+	port := DefaultHTTPPort
+	if u.Scheme == SchemeHTTPS {
+		appProtocol = SchemeHTTPS
+		port = DefaultHTTPSPort
+	}
+	if endpointPort := u.Port(); endpointPort != "" {
+		port, err = strconv.Atoi(endpointPort)
+		if err != nil {
+			return nil, gatewayv1.HTTPRouteFilter{}, fmt.Errorf("failed parsing mirror endpoint port in rule %d: %w", ruleIndex, err)
+		}
+	}
+
+	host := u.Hostname()
+	addressType := discoveryv1.AddressTypeFQDN
+	annotations := map[string]string{}
+	if ip := net.ParseIP(host); ip != nil {
+		addressType = discoveryv1.AddressTypeIPv6
+		if ip.To4() != nil {
+			addressType = discoveryv1.AddressTypeIPv4
+		}
+	} else {
+		// Mirrored requests keep the Host header of the original request, so the
+		// certificate of the mirror backend is validated against its hostname.
+		annotations[BackendCertHostnameAnnotation] = host
+	}
+
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   httpProxy.Namespace,
+			Name:        fmt.Sprintf("%s-%d-mirror", httpProxy.Name, ruleIndex),
+			Annotations: annotations,
+		},
+		AddressType: addressType,
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				Addresses: []string{host},
+				Conditions: discoveryv1.EndpointConditions{
+					Ready:       ptr.To(true),
+					Serving:     ptr.To(true),
+					Terminating: ptr.To(false),
+				},
+			},
+		},
+		Ports: []discoveryv1.EndpointPort{
+			{
+				Name:        ptr.To(fmt.Sprintf("httpproxy-%d-mirror", ruleIndex)),
+				Protocol:    ptr.To(corev1.ProtocolTCP),
+				AppProtocol: ptr.To(appProtocol),
+				Port:        ptr.To(int32(port)),
+			},
+		},
+	}
+
+	filter := gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+			BackendRef: gatewayv1.BackendObjectReference{
+				Group: ptr.To(gatewayv1.Group(discoveryv1.GroupName)),
+				Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+				Name:  gatewayv1.ObjectName(endpointSlice.Name),
+				Port:  ptr.To(gatewayv1.PortNumber(port)),
+			},
+			Percent: mirror.Percent,
+		},
+	}
+
+	return endpointSlice, filter, nil
+}
+
+// downstreamRequestMirrorFilters returns the filters of an upstream route rule
+// with the EndpointSlice backends of RequestMirror filters replaced by the
+// downstream Services that reach their endpoints, along with the downstream
+// resources that they need.
+func (r *GatewayReconciler) downstreamRequestMirrorFilters(
+	ctx context.Context,
+	upstreamClient client.Client,
+	upstreamRoute gatewayv1.HTTPRoute,
+	ruleIdx int,
+	filters []gatewayv1.HTTPRouteFilter,
+	downstreamNamespace string,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) (downstreamFilters []gatewayv1.HTTPRouteFilter, downstreamResources []client.Object, downstreamResourcesToDelete []client.Object, err error) {
+	downstreamFilters = make([]gatewayv1.HTTPRouteFilter, 0, len(filters))
+	for filterIdx, filter := range filters {
+		if filter.Type != gatewayv1.HTTPRouteFilterRequestMirror || filter.RequestMirror == nil ||
+			ptr.Deref(filter.RequestMirror.BackendRef.Kind, "") != KindEndpointSlice {
+			downstreamFilters = append(downstreamFilters, filter)
+			continue
+		}
+
+		mirror := filter.RequestMirror.DeepCopy()
+		if mirror.BackendRef.Port == nil {
+			return nil, nil, nil, fmt.Errorf("no port defined in request mirror backendRef")
+		}
+
+		var upstreamEndpointSlice discoveryv1.EndpointSlice
+		if err := upstreamClient.Get(ctx, types.NamespacedName{
+			Namespace: string(ptr.Deref(mirror.BackendRef.Namespace, gatewayv1.Namespace(upstreamRoute.Namespace))),
+			Name:      string(mirror.BackendRef.Name),
+		}, &upstreamEndpointSlice); err != nil {
+			return nil, nil, nil, err
+		}
+
+		if !controllerutil.ContainsFinalizer(&upstreamEndpointSlice, gatewayControllerGCFinalizer) {
+			controllerutil.AddFinalizer(&upstreamEndpointSlice, gatewayControllerGCFinalizer)
+			if err := upstreamClient.Update(ctx, &upstreamEndpointSlice); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to add finalizer to endpointslice: %w", err)
+			}
+		}
+
+		var endpointPort *discoveryv1.EndpointPort
+		for _, port := range upstreamEndpointSlice.Ports {
+			if port.Port != nil && *port.Port == *mirror.BackendRef.Port {
+				endpointPort = ptr.To(port)
+			}
+		}
+		if endpointPort == nil || endpointPort.Name == nil {
+			return nil, nil, nil, fmt.Errorf("port not found in upstream endpointslice %q", upstreamEndpointSlice.Name)
+		}
+
+		resourceName := fmt.Sprintf("route-%s-rule-%d-mirror-%d", upstreamRoute.UID, ruleIdx, filterIdx)
+		var backendRef gatewayv1.BackendObjectReference
+		var backendTLSPolicyTargetRef gatewayv1.LocalPolicyTargetReferenceWithSectionName
+		if r.programsEndpointSliceAsBackend(&upstreamEndpointSlice) {
+			downstreamBackend := desiredDownstreamBackend(downstreamNamespace, resourceName, &upstreamEndpointSlice, int32(*mirror.BackendRef.Port))
+			downstreamResources = append(downstreamResources, downstreamBackend)
+			downstreamResourcesToDelete = append(downstreamResourcesToDelete,
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespace, Name: resourceName}},
+				&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespace, Name: resourceName}},
+			)
+
+			backendRef = gatewayv1.BackendObjectReference{
+				Group:     ptr.To(gatewayv1.Group(envoygatewayv1alpha1.GroupName)),
+				Kind:      ptr.To(gatewayv1.Kind(envoygatewayv1alpha1.KindBackend)),
+				Namespace: ptr.To(gatewayv1.Namespace(downstreamNamespace)),
+				Name:      gatewayv1.ObjectName(downstreamBackend.Name),
+				Port:      mirror.BackendRef.Port,
+			}
+			backendTLSPolicyTargetRef = gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+					Group: gatewayv1.Group(envoygatewayv1alpha1.GroupName),
+					Kind:  gatewayv1.Kind(envoygatewayv1alpha1.KindBackend),
+					Name:  gatewayv1.ObjectName(downstreamBackend.Name),
+				},
+			}
+		} else {
+			downstreamService, downstreamEndpointSlice, err := r.desiredDownstreamEndpointSliceService(
+				ctx,
+				downstreamStrategy,
+				downstreamNamespace,
+				resourceName,
+				&upstreamEndpointSlice,
+			)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			downstreamResources = append(downstreamResources, downstreamService, downstreamEndpointSlice)
+			downstreamResourcesToDelete = append(downstreamResourcesToDelete,
+				&envoygatewayv1alpha1.Backend{ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespace, Name: resourceName}},
+			)
+
+			backendRef = gatewayv1.BackendObjectReference{
+				Namespace: ptr.To(gatewayv1.Namespace(downstreamNamespace)),
+				Kind:      ptr.To(gatewayv1.Kind(KindService)),
+				Name:      gatewayv1.ObjectName(downstreamService.Name),
+				Port:      mirror.BackendRef.Port,
+			}
+			backendTLSPolicyTargetRef = gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+					Kind: gatewayv1.Kind(KindService),
+					Name: gatewayv1.ObjectName(downstreamService.Name),
+				},
+				SectionName: ptr.To(gatewayv1.SectionName(*endpointPort.Name)),
+			}
+		}
+
+		hostname := upstreamEndpointSlice.Annotations[BackendCertHostnameAnnotation]
+		if hostname == "" {
+			hostname = string(ptr.Deref(fqdnEndpointSliceHostname(&upstreamEndpointSlice), ""))
+		}
+		if ptr.Deref(endpointPort.AppProtocol, "") == SchemeHTTPS && hostname != "" {
+			backendTLSResources, staleBackendTLSResources, err := desiredBackendTLSResources(
+				ctx,
+				upstreamClient,
+				&upstreamEndpointSlice,
+				downstreamNamespace,
+				resourceName,
+				backendTLSPolicyTargetRef,
+				gatewayv1.PreciseHostname(hostname),
+			)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			downstreamResources = append(downstreamResources, backendTLSResources...)
+			downstreamResourcesToDelete = append(downstreamResourcesToDelete, staleBackendTLSResources...)
+		} else {
+			downstreamResourcesToDelete = append(downstreamResourcesToDelete, backendTLSResourcesToDelete(downstreamNamespace, resourceName)...)
+		}
+
+		mirror.BackendRef = backendRef
+		downstreamFilters = append(downstreamFilters, gatewayv1.HTTPRouteFilter{
+			Type:          gatewayv1.HTTPRouteFilterRequestMirror,
+			RequestMirror: mirror,
+		})
+	}
+
+	return downstreamFilters, downstreamResources, downstreamResourcesToDelete, nil
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestProcessDownstreamHTTPRouteRulesRequestMirror(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayClassName: "test-suite",
+			TargetDomain:               "test-suite.com",
+		},
+	}
+
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			UID:  uuid.NewUUID(),
+		},
+	}
+
+	newEndpointSlice := func(name, hostname string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   upstreamNamespace.Name,
+				Name:        name,
+				Annotations: map[string]string{BackendCertHostnameAnnotation: hostname},
+			},
+			AddressType: discoveryv1.AddressTypeFQDN,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{hostname}},
+			},
+			Ports: []discoveryv1.EndpointPort{
+				{
+					Name:        ptr.To(name),
+					AppProtocol: ptr.To(SchemeHTTPS),
+					Port:        ptr.To(int32(DefaultHTTPSPort)),
+				},
+			},
+		}
+	}
+
+	endpointSliceRef := func(name string) gatewayv1.BackendObjectReference {
+		return gatewayv1.BackendObjectReference{
+			Group: ptr.To(gatewayv1.Group("discovery.k8s.io")),
+			Kind:  ptr.To(gatewayv1.Kind(KindEndpointSlice)),
+			Name:  gatewayv1.ObjectName(name),
+			Port:  ptr.To(gatewayv1.PortNumber(DefaultHTTPSPort)),
+		}
+	}
+
+	upstreamRoute := newHTTPRoute(upstreamNamespace.Name, "route", func(route *gatewayv1.HTTPRoute) {
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{
+			{
+				Filters: []gatewayv1.HTTPRouteFilter{
+					{
+						Type: gatewayv1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+							BackendRef: endpointSliceRef("route-0-mirror"),
+							Percent:    ptr.To[int32](50),
+						},
+					},
+				},
+				BackendRefs: []gatewayv1.HTTPBackendRef{
+					{BackendRef: gatewayv1.BackendRef{BackendObjectReference: endpointSliceRef("route-0-0")}},
+				},
+			},
+		}
+	})
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			upstreamNamespace,
+			newEndpointSlice("route-0-0", "api.example.com"),
+			newEndpointSlice("route-0-mirror", "shadow.example.com"),
+		).
+		Build()
+	fakeDownstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+	reconciler := &GatewayReconciler{
+		Config:            testConfig,
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", fakeUpstreamClient, fakeDownstreamClient)
+	upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test")
+	downstreamGateway := upstreamGateway.DeepCopy()
+	downstreamGateway.Namespace = fmt.Sprintf("ns-%s", upstreamNamespace.UID)
+
+	rules, downstreamResources, _, err := reconciler.processDownstreamHTTPRouteRules(
+		context.Background(),
+		fakeUpstreamClient,
+		upstreamGateway,
+		*upstreamRoute,
+		downstreamGateway,
+		downstreamStrategy,
+	)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Len(t, rules[0].Filters, 1)
+
+	mirrorResourceName := fmt.Sprintf("route-%s-rule-0-mirror-0", upstreamRoute.UID)
+	mirror := rules[0].Filters[0].RequestMirror
+	require.NotNil(t, mirror)
+	assert.Equal(t, gatewayv1.BackendObjectReference{
+		Group:     ptr.To(gatewayv1.Group(envoygatewayv1alpha1.GroupName)),
+		Kind:      ptr.To(gatewayv1.Kind(envoygatewayv1alpha1.KindBackend)),
+		Namespace: ptr.To(gatewayv1.Namespace(downstreamGateway.Namespace)),
+		Name:      gatewayv1.ObjectName(mirrorResourceName),
+		Port:      ptr.To(gatewayv1.PortNumber(DefaultHTTPSPort)),
+	}, mirror.BackendRef)
+	assert.Equal(t, ptr.To[int32](50), mirror.Percent)
+
+	var mirrorBackend *envoygatewayv1alpha1.Backend
+	var mirrorTLSPolicy *gatewayv1.BackendTLSPolicy
+	for _, obj := range downstreamResources {
+		if obj.GetName() != mirrorResourceName {
+			continue
+		}
+		switch obj := obj.(type) {
+		case *envoygatewayv1alpha1.Backend:
+			mirrorBackend = obj
+		case *gatewayv1.BackendTLSPolicy:
+			mirrorTLSPolicy = obj
+		case *corev1.Service, *discoveryv1.EndpointSlice:
+			t.Errorf("unexpected %T %q for FQDN mirror backend", obj, obj.GetName())
+		}
+	}
+
+	require.NotNil(t, mirrorBackend)
+	require.Len(t, mirrorBackend.Spec.Endpoints, 1)
+	assert.Equal(t, "shadow.example.com", mirrorBackend.Spec.Endpoints[0].FQDN.Hostname)
+	require.NotNil(t, mirrorTLSPolicy)
+	assert.Equal(t, gatewayv1.PreciseHostname("shadow.example.com"), mirrorTLSPolicy.Spec.Validation.Hostname)
+	assert.Equal(t, gatewayv1.ObjectName(mirrorBackend.Name), mirrorTLSPolicy.Spec.TargetRefs[0].Name)
+}
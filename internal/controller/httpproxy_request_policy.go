@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// desiredRouteRuleTimeouts returns the timeouts of the HTTPRoute rule of a rule
+// in an HTTPProxy. Timeouts the rule does not set are defaulted from the
+// operator configuration, unless the default would conflict with the other
+// timeout of the rule, as the backend request timeout may not exceed the
+// request timeout.
+func desiredRouteRuleTimeouts(rule networkingv1alpha.HTTPProxyRule, requestPolicy config.HTTPProxyRequestPolicyConfig) *gatewayv1.HTTPRouteTimeouts {
+	timeouts := &gatewayv1.HTTPRouteTimeouts{}
+	if rule.Timeouts != nil {
+		timeouts = rule.Timeouts.DeepCopy()
+	}
+
+	request, requestSet := parseRouteDuration(timeouts.Request)
+	backendRequest, backendRequestSet := parseRouteDuration(timeouts.BackendRequest)
+
+	if timeouts.Request == nil && requestPolicy.DefaultRequestTimeout != nil &&
+		(!backendRequestSet || backendRequest <= requestPolicy.DefaultRequestTimeout.Duration) {
+		timeouts.Request = formatRouteDuration(requestPolicy.DefaultRequestTimeout.Duration)
+		request, requestSet = requestPolicy.DefaultRequestTimeout.Duration, timeouts.Request != nil
+	}
+	if timeouts.BackendRequest == nil && requestPolicy.DefaultBackendRequestTimeout != nil &&
+		(!requestSet || requestPolicy.DefaultBackendRequestTimeout.Duration <= request) {
+		timeouts.BackendRequest = formatRouteDuration(requestPolicy.DefaultBackendRequestTimeout.Duration)
+	}
+
+	if timeouts.Request == nil && timeouts.BackendRequest == nil {
+		return nil
+	}
+	return timeouts
+}
+
+// desiredRouteRuleRetry returns the retry policy of the HTTPRoute rule of a
+// rule in an HTTPProxy. Requests are only retried when the rule configures
+// retries, with the attempts and backoff it does not set defaulted from the
+// operator configuration.
+func desiredRouteRuleRetry(rule networkingv1alpha.HTTPProxyRule, requestPolicy config.HTTPProxyRequestPolicyConfig) *gatewayv1.HTTPRouteRetry {
+	if rule.Retry == nil {
+		return nil
+	}
+
+	retry := rule.Retry.DeepCopy()
+	if retry.Attempts == nil {
+		retry.Attempts = ptr.To(requestPolicy.DefaultRetryAttempts)
+	}
+	if retry.Backoff == nil {
+		retry.Backoff = formatRouteDuration(requestPolicy.DefaultRetryBackoff.Duration)
+	}
+	return retry
+}
+
+// parseRouteDuration returns the value of a Gateway API duration, and whether
+// it is set to a valid duration.
+func parseRouteDuration(duration *gatewayv1.Duration) (time.Duration, bool) {
+	if duration == nil {
+		return 0, false
+	}
+	d, err := time.ParseDuration(string(*duration))
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+// formatRouteDuration returns a duration in the Gateway API duration format,
+// which only has hour, minute, second and millisecond units, truncated to the
+// millisecond. Durations shorter than a millisecond are returned as nil.
+func formatRouteDuration(d time.Duration) *gatewayv1.Duration {
+	d = d.Truncate(time.Millisecond)
+	if d <= 0 {
+		return nil
+	}
+
+	var formatted strings.Builder
+	for _, unit := range []struct {
+		duration time.Duration
+		suffix   string
+	}{
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+	} {
+		if count := d / unit.duration; count > 0 {
+			fmt.Fprintf(&formatted, "%d%s", count, unit.suffix)
+			d -= count * unit.duration
+		}
+	}
+	return ptr.To(gatewayv1.Duration(formatted.String()))
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestDesiredRouteRuleTimeouts(t *testing.T) {
+	requestPolicy := config.HTTPProxyRequestPolicyConfig{
+		DefaultRequestTimeout:        &metav1.Duration{Duration: 30 * time.Second},
+		DefaultBackendRequestTimeout: &metav1.Duration{Duration: 10 * time.Second},
+	}
+
+	tests := map[string]struct {
+		requestPolicy config.HTTPProxyRequestPolicyConfig
+		timeouts      *gatewayv1.HTTPRouteTimeouts
+		want          *gatewayv1.HTTPRouteTimeouts
+	}{
+		"no timeouts or defaults": {},
+		"defaults": {
+			requestPolicy: requestPolicy,
+			want: &gatewayv1.HTTPRouteTimeouts{
+				Request:        ptr.To(gatewayv1.Duration("30s")),
+				BackendRequest: ptr.To(gatewayv1.Duration("10s")),
+			},
+		},
+		"rule timeouts take precedence": {
+			requestPolicy: requestPolicy,
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        ptr.To(gatewayv1.Duration("1m")),
+				BackendRequest: ptr.To(gatewayv1.Duration("20s")),
+			},
+			want: &gatewayv1.HTTPRouteTimeouts{
+				Request:        ptr.To(gatewayv1.Duration("1m")),
+				BackendRequest: ptr.To(gatewayv1.Duration("20s")),
+			},
+		},
+		"default backend request timeout longer than rule request timeout": {
+			requestPolicy: requestPolicy,
+			timeouts:      &gatewayv1.HTTPRouteTimeouts{Request: ptr.To(gatewayv1.Duration("5s"))},
+			want:          &gatewayv1.HTTPRouteTimeouts{Request: ptr.To(gatewayv1.Duration("5s"))},
+		},
+		"rule backend request timeout longer than default request timeout": {
+			requestPolicy: requestPolicy,
+			timeouts:      &gatewayv1.HTTPRouteTimeouts{BackendRequest: ptr.To(gatewayv1.Duration("1m"))},
+			want:          &gatewayv1.HTTPRouteTimeouts{BackendRequest: ptr.To(gatewayv1.Duration("1m"))},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := desiredRouteRuleTimeouts(networkingv1alpha.HTTPProxyRule{Timeouts: tt.timeouts}, tt.requestPolicy)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDesiredRouteRuleRetry(t *testing.T) {
+	requestPolicy := config.HTTPProxyRequestPolicyConfig{
+		DefaultRetryAttempts: 2,
+		DefaultRetryBackoff:  metav1.Duration{Duration: 25 * time.Millisecond},
+	}
+
+	assert.Nil(t, desiredRouteRuleRetry(networkingv1alpha.HTTPProxyRule{}, requestPolicy), "rules without retry are not retried")
+
+	got := desiredRouteRuleRetry(networkingv1alpha.HTTPProxyRule{
+		Retry: &gatewayv1.HTTPRouteRetry{Codes: []gatewayv1.HTTPRouteRetryStatusCode{503}},
+	}, requestPolicy)
+	assert.Equal(t, &gatewayv1.HTTPRouteRetry{
+		Codes:    []gatewayv1.HTTPRouteRetryStatusCode{503},
+		Attempts: ptr.To(2),
+		Backoff:  ptr.To(gatewayv1.Duration("25ms")),
+	}, got)
+
+	got = desiredRouteRuleRetry(networkingv1alpha.HTTPProxyRule{
+		Retry: &gatewayv1.HTTPRouteRetry{Attempts: ptr.To(0), Backoff: ptr.To(gatewayv1.Duration("1s"))},
+	}, requestPolicy)
+	assert.Equal(t, &gatewayv1.HTTPRouteRetry{Attempts: ptr.To(0), Backoff: ptr.To(gatewayv1.Duration("1s"))}, got)
+}
+
+func TestFormatRouteDuration(t *testing.T) {
+	tests := map[time.Duration]*gatewayv1.Duration{
+		0:                                    nil,
+		time.Microsecond:                     nil,
+		25 * time.Millisecond:                ptr.To(gatewayv1.Duration("25ms")),
+		90 * time.Second:                     ptr.To(gatewayv1.Duration("1m30s")),
+		time.Hour + 1500*time.Millisecond:    ptr.To(gatewayv1.Duration("1h1s500ms")),
+		2*time.Second + 500*time.Microsecond: ptr.To(gatewayv1.Duration("2s")),
+	}
+
+	for d, want := range tests {
+		assert.Equal(t, want, formatRouteDuration(d), d.String())
+	}
+}
+
+func TestHTTPProxyCollectDesiredResourcesRequestPolicy(t *testing.T) {
+	reconciler := &HTTPProxyReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{TargetDomain: "example.com"},
+			HTTPProxy: config.HTTPProxyConfig{
+				GatewayClassName: "test",
+				RequestPolicy: config.HTTPProxyRequestPolicyConfig{
+					DefaultRequestTimeout: &metav1.Duration{Duration: 30 * time.Second},
+					DefaultRetryAttempts:  2,
+					DefaultRetryBackoff:   metav1.Duration{Duration: 25 * time.Millisecond},
+				},
+			},
+		},
+	}
+
+	httpProxy := newHTTPProxy(func(p *networkingv1alpha.HTTPProxy) {
+		p.Spec.Rules[0].Retry = &gatewayv1.HTTPRouteRetry{Codes: []gatewayv1.HTTPRouteRetryStatusCode{503}}
+	})
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	desiredResources, err := reconciler.collectDesiredResources(context.Background(), "", cl, httpProxy)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, desiredResources.httpRoute.Spec.Rules)
+	rule := desiredResources.httpRoute.Spec.Rules[0]
+	assert.Equal(t, &gatewayv1.HTTPRouteTimeouts{Request: ptr.To(gatewayv1.Duration("30s"))}, rule.Timeouts)
+	assert.Equal(t, &gatewayv1.HTTPRouteRetry{
+		Codes:    []gatewayv1.HTTPRouteRetryStatusCode{503},
+		Attempts: ptr.To(2),
+		Backoff:  ptr.To(gatewayv1.Duration("25ms")),
+	}, rule.Retry)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
@@ -19,11 +22,23 @@ const (
 
 	// dnsZoneDomainNameIndex is the field index name for DNSZone.spec.domainName.
 	dnsZoneDomainNameIndex = "spec.domainName"
+
+	// domainDomainNameIndex is the field index name for Domain.spec.domainName.
+	domainDomainNameIndex = "spec.domainName"
+
+	// gatewayHostnameDomainIndex indexes Gateways by the domain names their
+	// listener hostnames fall under.
+	gatewayHostnameDomainIndex = "gatewayHostnameDomainIndex"
+
+	// httpProxyHostnameDomainIndex indexes HTTPProxies by the domain names their
+	// hostnames fall under.
+	httpProxyHostnameDomainIndex = "httpProxyHostnameDomainIndex"
 )
 
 func AddIndexers(ctx context.Context, mgr mcmanager.Manager) error {
 	return errors.Join(
 		addNetworkContextControllerIndexers(ctx, mgr),
+		addDomainIndexers(ctx, mgr),
 	)
 }
 
@@ -46,6 +61,68 @@ func networkContextControllerNetworkUIDIndexFunc(o client.Object) []string {
 	return nil
 }
 
+func addDomainIndexers(ctx context.Context, mgr mcmanager.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &networkingv1alpha.Domain{}, domainDomainNameIndex, domainDomainNameIndexFunc); err != nil {
+		return fmt.Errorf("failed to add Domain domain name indexer %q: %w", domainDomainNameIndex, err)
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &gatewayv1.Gateway{}, gatewayHostnameDomainIndex, gatewayHostnameDomainIndexFunc); err != nil {
+		return fmt.Errorf("failed to add gateway hostname domain indexer %q: %w", gatewayHostnameDomainIndex, err)
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &networkingv1alpha.HTTPProxy{}, httpProxyHostnameDomainIndex, httpProxyHostnameDomainIndexFunc); err != nil {
+		return fmt.Errorf("failed to add httpproxy hostname domain indexer %q: %w", httpProxyHostnameDomainIndex, err)
+	}
+
+	return nil
+}
+
+func domainDomainNameIndexFunc(o client.Object) []string {
+	domain := o.(*networkingv1alpha.Domain)
+	if domain.Spec.DomainName == "" {
+		return nil
+	}
+	return []string{domain.Spec.DomainName}
+}
+
+func gatewayHostnameDomainIndexFunc(o client.Object) []string {
+	gateway := o.(*gatewayv1.Gateway)
+	domainNames := sets.New[string]()
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.Hostname == nil {
+			continue
+		}
+		domainNames.Insert(hostnameDomainCandidates(string(*listener.Hostname))...)
+	}
+	return domainNames.UnsortedList()
+}
+
+func httpProxyHostnameDomainIndexFunc(o client.Object) []string {
+	httpProxy := o.(*networkingv1alpha.HTTPProxy)
+	domainNames := sets.New[string]()
+	for _, hostname := range httpProxy.Spec.Hostnames {
+		domainNames.Insert(hostnameDomainCandidates(string(hostname))...)
+	}
+	return domainNames.UnsortedList()
+}
+
+// hostnameDomainCandidates returns the domain names a Domain may have for the
+// hostname to fall under it, which are the hostname itself and each of its
+// parent domains. Looking these up replaces a suffix match against every
+// Domain in the namespace.
+func hostnameDomainCandidates(hostname string) []string {
+	var candidates []string
+	for hostname != "" {
+		candidates = append(candidates, hostname)
+		i := strings.IndexByte(hostname, '.')
+		if i < 0 {
+			break
+		}
+		hostname = hostname[i+1:]
+	}
+	return candidates
+}
+
 // TODO(jreese): I can't seem to get these indexers to function on the downstream
 // cluster. From tracing the code, the indexers get invoked, but I still get
 // an error that the index does not exist when trying to list resources.
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// withDomainIndexes registers the indexes added by addDomainIndexers on a fake
+// client.
+func withDomainIndexes(b *fake.ClientBuilder) *fake.ClientBuilder {
+	return b.
+		WithIndex(&networkingv1alpha.Domain{}, domainDomainNameIndex, domainDomainNameIndexFunc).
+		WithIndex(&gatewayv1.Gateway{}, gatewayHostnameDomainIndex, gatewayHostnameDomainIndexFunc).
+		WithIndex(&networkingv1alpha.HTTPProxy{}, httpProxyHostnameDomainIndex, httpProxyHostnameDomainIndexFunc)
+}
+
+func TestHostnameDomainCandidates(t *testing.T) {
+	tests := []struct {
+		hostname string
+		expected []string
+	}{
+		{hostname: "", expected: nil},
+		{hostname: "example.com", expected: []string{"example.com", "com"}},
+		{hostname: "a.b.example.com", expected: []string{"a.b.example.com", "b.example.com", "example.com", "com"}},
+		{hostname: "*.example.com", expected: []string{"*.example.com", "example.com", "com"}},
+		{hostname: "example.com.", expected: []string{"example.com.", "com."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hostnameDomainCandidates(tt.hostname))
+		})
+	}
+}
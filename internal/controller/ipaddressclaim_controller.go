@@ -0,0 +1,440 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/util/ipam"
+)
+
+// ipAddressClaimReleaseFinalizer returns the address of an IP address claim
+// to its IP pool once no gateway references the claim.
+const ipAddressClaimReleaseFinalizer = "networking.datumapis.com/ipaddressclaim-release"
+
+// IPAddressClaimReconciler reconciles an IPAddressClaim object
+type IPAddressClaimReconciler struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+}
+
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ipaddressclaims/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ipaddressclaims/finalizers,verbs=update
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ippools,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ippools/status,verbs=get;update;patch
+
+// Reconcile allocates an address to an IP address claim, and releases it once
+// the claim is deleted and no gateway names the claim.
+func (r *IPAddressClaimReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx, "cluster", req.ClusterName)
+
+	cl, err := r.mgr.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var claim networkingv1alpha.IPAddressClaim
+	if err := cl.GetClient().Get(ctx, req.NamespacedName, &claim); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !claim.DeletionTimestamp.IsZero() {
+		return r.releaseIPAddressClaim(ctx, string(req.ClusterName), cl.GetClient(), &claim)
+	}
+
+	logger.Info("reconciling ip address claim")
+	defer logger.Info("reconcile complete")
+
+	if !controllerutil.ContainsFinalizer(&claim, ipAddressClaimReleaseFinalizer) {
+		controllerutil.AddFinalizer(&claim, ipAddressClaimReleaseFinalizer)
+		if err := cl.GetClient().Update(ctx, &claim); err != nil {
+			if apierrors.IsConflict(err) {
+				return ctrl.Result{RequeueAfter: retryAfterConflict}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("failed adding ip address claim finalizer: %w", err)
+		}
+	}
+
+	origStatus := claim.Status.DeepCopy()
+	var result ctrl.Result
+	allocatedCondition := metav1.Condition{
+		Type:               networkingv1alpha.IPAddressClaimAllocated,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.IPAddressClaimAllocatedReasonAllocated,
+		ObservedGeneration: claim.Generation,
+		Message:            "IP address claim has been allocated an address",
+	}
+	if claim.Status.Address == "" {
+		addr, pool, err := r.allocateIPAddressClaim(ctx, string(req.ClusterName), cl.GetClient(), &claim)
+		var allocationErr *subnetAllocationError
+		switch {
+		case errors.As(err, &allocationErr):
+			allocatedCondition.Status = metav1.ConditionFalse
+			allocatedCondition.Reason = allocationErr.reason
+			allocatedCondition.Message = allocationErr.message
+			result.RequeueAfter = allocationErr.retryAfter
+		case err != nil:
+			return ctrl.Result{}, err
+		default:
+			claim.Status.Address = addr.String()
+			claim.Status.IPPool = pool
+		}
+	}
+
+	apimeta.SetStatusCondition(&claim.Status.Conditions, allocatedCondition)
+	if !equality.Semantic.DeepEqual(origStatus, &claim.Status) {
+		if err := cl.GetClient().Status().Update(ctx, &claim); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed updating ip address claim status: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// allocateIPAddressClaim allocates an address to an IP address claim from the
+// IPPool of its subnet class and IP family, and returns the address and the
+// name of the pool.
+func (r *IPAddressClaimReconciler) allocateIPAddressClaim(
+	ctx context.Context,
+	clusterName string,
+	cl client.Client,
+	claim *networkingv1alpha.IPAddressClaim,
+) (netip.Addr, string, error) {
+	if !slices.Contains(r.Config.Gateway.StaticAddressSubnetClasses, claim.Spec.SubnetClass) {
+		return netip.Addr{}, "", &subnetAllocationError{
+			reason:  networkingv1alpha.IPAddressClaimAllocatedReasonUnsupportedSubnetClass,
+			message: fmt.Sprintf("Addresses of subnet class %q may not be claimed", claim.Spec.SubnetClass),
+		}
+	}
+
+	pool, err := namespaceIPPool(ctx, cl, r.Config.IPAM, claim.Namespace, claim.Spec.SubnetClass, claim.Spec.IPFamily)
+	if err != nil {
+		return netip.Addr{}, "", err
+	}
+	if pool == nil {
+		return netip.Addr{}, "", &subnetAllocationError{
+			reason:  networkingv1alpha.IPAddressClaimAllocatedReasonNoIPPool,
+			message: fmt.Sprintf("There is no %s IP pool for subnet class %q", claim.Spec.IPFamily, claim.Spec.SubnetClass),
+		}
+	}
+
+	addr, err := allocateIPPoolAddress(ctx, clusterName, cl, pool, claim)
+	if err != nil {
+		return netip.Addr{}, "", err
+	}
+	return addr, pool.Name, nil
+}
+
+// allocateIPPoolAddress allocates an address to an IP address claim from an
+// IPPool, and persists the allocation in the status of the pool. Addresses are
+// allocated in blocks of the pool, so only pools with blocks of a single
+// address allocate addresses to claims. A claim that holds an address of the
+// pool is returned that address.
+func allocateIPPoolAddress(
+	ctx context.Context,
+	clusterName string,
+	cl client.Client,
+	pool *networkingv1alpha.IPPool,
+	claim *networkingv1alpha.IPAddressClaim,
+) (netip.Addr, error) {
+	for _, allocation := range pool.Status.Allocations {
+		if isIPAddressClaimIPPoolAllocation(allocation, claim) {
+			prefix, err := netip.ParsePrefix(allocation.Prefix)
+			if err != nil {
+				return netip.Addr{}, fmt.Errorf("invalid allocated prefix of ip pool %s: %w", pool.Name, err)
+			}
+			return prefix.Addr(), nil
+		}
+	}
+
+	cidrs, err := restoreIPPoolCIDRs(pool)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	now := time.Now()
+	reclaimed, err := reclaimIPPoolPrefixes(pool, cidrs, func(reclaim networkingv1alpha.IPPoolReclaim) bool {
+		return !reclaim.ReclaimAfter.After(now)
+	})
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	cidrs, allocatable, specErr := ipPoolAllocatableCIDRs(pool, cidrs)
+	if specErr != nil {
+		if setIPPoolReadyCondition(pool, specErr) {
+			if err := cl.Status().Update(ctx, pool); err != nil {
+				return netip.Addr{}, fmt.Errorf("failed updating ip pool status: %w", err)
+			}
+		}
+		return netip.Addr{}, &subnetAllocationError{
+			reason:  networkingv1alpha.IPAddressClaimAllocatedReasonNoIPPool,
+			message: fmt.Sprintf("IP pool %s is not ready: %v", pool.Name, specErr),
+		}
+	}
+
+	addressLength := int32(32)
+	if pool.Spec.IPFamily == networkingv1alpha.IPv6Protocol {
+		addressLength = 128
+	}
+	if pool.Spec.BlockPrefixLength != addressLength {
+		return netip.Addr{}, &subnetAllocationError{
+			reason:  networkingv1alpha.IPAddressClaimAllocatedReasonNoIPPool,
+			message: fmt.Sprintf("IP pool %s allocates /%d blocks rather than single addresses", pool.Name, pool.Spec.BlockPrefixLength),
+		}
+	}
+
+	var prefix netip.Prefix
+	if claim.Spec.Address != nil {
+		prefix, err = reserveSubnetPrefix(allocatable, *claim.Spec.Address, addressLength)
+		if err != nil {
+			return netip.Addr{}, &subnetAllocationError{
+				reason:  networkingv1alpha.IPAddressClaimAllocatedReasonInvalidAddress,
+				message: fmt.Sprintf("The requested address can't be allocated from IP pool %s: %v", pool.Name, err),
+			}
+		}
+	} else {
+		err = ipam.ErrExhausted
+		for _, cidr := range allocatable {
+			if prefix, err = cidr.Allocate(int(addressLength)); !errors.Is(err, ipam.ErrExhausted) {
+				break
+			}
+		}
+		if err != nil {
+			ipPoolExhaustedTotal.WithLabelValues(clusterName, pool.Namespace, pool.Name).Inc()
+			exhaustedChanged := apimeta.SetStatusCondition(&pool.Status.Conditions, metav1.Condition{
+				Type:               networkingv1alpha.IPPoolExhausted,
+				Status:             metav1.ConditionTrue,
+				Reason:             networkingv1alpha.IPPoolExhaustedReasonExhausted,
+				ObservedGeneration: pool.Generation,
+				Message:            fmt.Sprintf("No free address for ip address claim %s", claim.Name),
+			})
+			if exhaustedChanged || reclaimed {
+				setIPPoolCIDRStatus(pool, cidrs, allocatable)
+				if err := cl.Status().Update(ctx, pool); err != nil {
+					return netip.Addr{}, fmt.Errorf("failed updating ip pool status: %w", err)
+				}
+				recordIPPoolMetrics(clusterName, pool)
+			}
+			allocationErr := &subnetAllocationError{
+				reason:  networkingv1alpha.IPAddressClaimAllocatedReasonPoolExhausted,
+				message: fmt.Sprintf("IP pool %s has no free address", pool.Name),
+			}
+			for _, reclaim := range pool.Status.Reclaiming {
+				retryAfter := reclaim.ReclaimAfter.Sub(now)
+				if allocationErr.retryAfter == 0 || retryAfter < allocationErr.retryAfter {
+					allocationErr.retryAfter = retryAfter
+				}
+			}
+			return netip.Addr{}, allocationErr
+		}
+	}
+
+	pool.Status.Allocations = append(pool.Status.Allocations, networkingv1alpha.IPPoolAllocation{
+		Prefix:            prefix.String(),
+		IPAddressClaimRef: &networkingv1alpha.LocalIPAddressClaimReference{Name: claim.Name},
+	})
+	setIPPoolCIDRStatus(pool, cidrs, allocatable)
+	setIPPoolReadyCondition(pool, nil)
+	setIPPoolAvailableCondition(pool)
+	if err := cl.Status().Update(ctx, pool); err != nil {
+		return netip.Addr{}, fmt.Errorf("failed updating ip pool status: %w", err)
+	}
+	recordIPPoolMetrics(clusterName, pool)
+
+	return prefix.Addr(), nil
+}
+
+// isIPAddressClaimIPPoolAllocation returns whether an address of an IPPool is
+// allocated to an IP address claim.
+func isIPAddressClaimIPPoolAllocation(allocation networkingv1alpha.IPPoolAllocation, claim *networkingv1alpha.IPAddressClaim) bool {
+	return allocation.IPAddressClaimRef != nil && allocation.IPAddressClaimRef.Name == claim.Name
+}
+
+// releaseIPAddressClaim returns the address of a deleted IP address claim to
+// its IP pool, and removes the finalizer of the claim. The address is kept
+// while gateways still name the claim, so that a gateway never loses its
+// address to another claim.
+func (r *IPAddressClaimReconciler) releaseIPAddressClaim(
+	ctx context.Context,
+	clusterName string,
+	cl client.Client,
+	claim *networkingv1alpha.IPAddressClaim,
+) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(claim, ipAddressClaimReleaseFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	var gatewayList gatewayv1.GatewayList
+	if err := cl.List(ctx, &gatewayList, client.InNamespace(claim.Namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed listing gateways: %w", err)
+	}
+	var gatewayNames []string
+	for i := range gatewayList.Items {
+		if gatewayNamesIPAddressClaim(&gatewayList.Items[i], claim.Name) {
+			gatewayNames = append(gatewayNames, gatewayList.Items[i].Name)
+		}
+	}
+	if len(gatewayNames) > 0 {
+		if apimeta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
+			Type:               networkingv1alpha.IPAddressClaimAllocated,
+			Status:             metav1.ConditionTrue,
+			Reason:             networkingv1alpha.IPAddressClaimAllocatedReasonInUse,
+			ObservedGeneration: claim.Generation,
+			Message:            fmt.Sprintf("The address is released once it's no longer used by gateways %s", strings.Join(gatewayNames, ", ")),
+		}) {
+			if err := cl.Status().Update(ctx, claim); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed updating ip address claim status: %w", err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if err := releaseIPPoolAddresses(ctx, clusterName, cl, claim); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	controllerutil.RemoveFinalizer(claim, ipAddressClaimReleaseFinalizer)
+	if err := cl.Update(ctx, claim); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed removing ip address claim finalizer: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// releaseIPPoolAddresses releases the addresses allocated to an IP address
+// claim from the IPPools of its namespace. Unlike the prefixes of subnets, the
+// addresses of claims are free as soon as they're released, since no gateway
+// uses them anymore.
+func releaseIPPoolAddresses(
+	ctx context.Context,
+	clusterName string,
+	cl client.Client,
+	claim *networkingv1alpha.IPAddressClaim,
+) error {
+	var pools networkingv1alpha.IPPoolList
+	if err := cl.List(ctx, &pools, client.InNamespace(claim.Namespace)); err != nil {
+		return fmt.Errorf("failed listing ip pools: %w", err)
+	}
+
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		isClaimAllocation := func(allocation networkingv1alpha.IPPoolAllocation) bool {
+			return isIPAddressClaimIPPoolAllocation(allocation, claim)
+		}
+		if !slices.ContainsFunc(pool.Status.Allocations, isClaimAllocation) {
+			continue
+		}
+
+		cidrs, err := restoreIPPoolCIDRs(pool)
+		if err != nil {
+			return err
+		}
+		for _, allocation := range pool.Status.Allocations {
+			if !isClaimAllocation(allocation) {
+				continue
+			}
+			prefix, err := netip.ParsePrefix(allocation.Prefix)
+			if err != nil {
+				return fmt.Errorf("invalid allocated prefix of ip pool %s: %w", pool.Name, err)
+			}
+			for _, cidr := range cidrs {
+				if cidr.Contains(prefix) {
+					if err := cidr.Release(prefix); err != nil {
+						return fmt.Errorf("failed releasing %s from ip pool %s: %w", prefix, pool.Name, err)
+					}
+				}
+			}
+		}
+		pool.Status.Allocations = slices.DeleteFunc(pool.Status.Allocations, isClaimAllocation)
+
+		cidrs, allocatable, specErr := ipPoolAllocatableCIDRs(pool, cidrs)
+		setIPPoolCIDRStatus(pool, cidrs, allocatable)
+		setIPPoolReadyCondition(pool, specErr)
+		setIPPoolAvailableCondition(pool)
+		if err := cl.Status().Update(ctx, pool); err != nil {
+			return fmt.Errorf("failed updating ip pool status: %w", err)
+		}
+		recordIPPoolMetrics(clusterName, pool)
+	}
+	return nil
+}
+
+// gatewayNamesIPAddressClaim returns whether a gateway requests the address of
+// an IP address claim as a named address.
+func gatewayNamesIPAddressClaim(gateway *gatewayv1.Gateway, claimName string) bool {
+	return slices.ContainsFunc(gateway.Spec.Addresses, func(address gatewayv1.GatewaySpecAddress) bool {
+		return ptr.Deref(address.Type, gatewayv1.IPAddressType) == gatewayv1.NamedAddressType && address.Value == claimName
+	})
+}
+
+// listIPAddressClaimsForGatewayFunc enqueues the deleted IP address claims
+// named by a Gateway, which release their addresses once no gateway names
+// them.
+func (r *IPAddressClaimReconciler) listIPAddressClaimsForGatewayFunc(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		gateway := obj.(*gatewayv1.Gateway)
+
+		logger := log.FromContext(ctx)
+
+		var claimList networkingv1alpha.IPAddressClaimList
+		if err := cl.GetClient().List(ctx, &claimList, client.InNamespace(gateway.Namespace)); err != nil {
+			logger.Error(err, "failed to list IPAddressClaims")
+			return nil
+		}
+
+		var requests []mcreconcile.Request
+		for _, claim := range claimList.Items {
+			if !claim.DeletionTimestamp.IsZero() && gatewayNamesIPAddressClaim(gateway, claim.Name) {
+				requests = append(requests, mcreconcile.Request{
+					ClusterName: clusterName,
+					Request: reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(&claim),
+					},
+				})
+			}
+		}
+
+		return requests
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IPAddressClaimReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&networkingv1alpha.IPAddressClaim{}).
+		Watches(
+			&gatewayv1.Gateway{},
+			r.listIPAddressClaimsForGatewayFunc,
+		).
+		Named("ipaddressclaim").
+		Complete(r)
+}
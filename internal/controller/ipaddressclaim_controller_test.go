@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func newIPAddressClaimTestClient(t *testing.T, objs ...client.Object) client.Client {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	return fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&networkingv1alpha.IPAddressClaim{}, &networkingv1alpha.IPPool{}).
+		Build()
+}
+
+func newTestIPAddressPool(name string, family networkingv1alpha.IPFamily, blockPrefixLength int32, cidrs ...string) *networkingv1alpha.IPPool {
+	return &networkingv1alpha.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: networkingv1alpha.IPPoolSpec{
+			SubnetClass:       "gateway-static",
+			IPFamily:          family,
+			CIDRs:             cidrs,
+			BlockPrefixLength: blockPrefixLength,
+		},
+	}
+}
+
+func TestIPAddressClaimReconcile(t *testing.T) {
+	tests := []struct {
+		name        string
+		subnetClass string
+		ipFamily    networkingv1alpha.IPFamily
+		address     *string
+		pools       []client.Object
+		wantReason  string
+		wantAddress string
+	}{
+		{
+			name:        "allocates the first free address",
+			pools:       []client.Object{newTestIPAddressPool("pool", networkingv1alpha.IPv4Protocol, 32, "192.0.2.0/30")},
+			wantReason:  networkingv1alpha.IPAddressClaimAllocatedReasonAllocated,
+			wantAddress: "192.0.2.0",
+		},
+		{
+			name:        "allocates the requested address",
+			ipFamily:    networkingv1alpha.IPv6Protocol,
+			address:     ptr.To("2001:db8::2"),
+			pools:       []client.Object{newTestIPAddressPool("pool", networkingv1alpha.IPv6Protocol, 128, "2001:db8::/126")},
+			wantReason:  networkingv1alpha.IPAddressClaimAllocatedReasonAllocated,
+			wantAddress: "2001:db8::2",
+		},
+		{
+			name:       "requested address outside of the pool",
+			address:    ptr.To("198.51.100.1"),
+			pools:      []client.Object{newTestIPAddressPool("pool", networkingv1alpha.IPv4Protocol, 32, "192.0.2.0/30")},
+			wantReason: networkingv1alpha.IPAddressClaimAllocatedReasonInvalidAddress,
+		},
+		{
+			name:        "unsupported subnet class",
+			subnetClass: "private",
+			pools:       []client.Object{newTestIPAddressPool("pool", networkingv1alpha.IPv4Protocol, 32, "192.0.2.0/30")},
+			wantReason:  networkingv1alpha.IPAddressClaimAllocatedReasonUnsupportedSubnetClass,
+		},
+		{
+			name:       "no pool of the ip family",
+			ipFamily:   networkingv1alpha.IPv6Protocol,
+			pools:      []client.Object{newTestIPAddressPool("pool", networkingv1alpha.IPv4Protocol, 32, "192.0.2.0/30")},
+			wantReason: networkingv1alpha.IPAddressClaimAllocatedReasonNoIPPool,
+		},
+		{
+			name:       "pool of prefixes",
+			pools:      []client.Object{newTestIPAddressPool("pool", networkingv1alpha.IPv4Protocol, 28, "192.0.2.0/24")},
+			wantReason: networkingv1alpha.IPAddressClaimAllocatedReasonNoIPPool,
+		},
+		{
+			name:       "exhausted pool",
+			pools:      []client.Object{newTestIPAddressPool("pool", networkingv1alpha.IPv4Protocol, 32)},
+			wantReason: networkingv1alpha.IPAddressClaimAllocatedReasonPoolExhausted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			claim := &networkingv1alpha.IPAddressClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "claim"},
+				Spec: networkingv1alpha.IPAddressClaimSpec{
+					SubnetClass: "gateway-static",
+					IPFamily:    networkingv1alpha.IPv4Protocol,
+					Address:     tt.address,
+				},
+			}
+			if tt.subnetClass != "" {
+				claim.Spec.SubnetClass = tt.subnetClass
+			}
+			if tt.ipFamily != "" {
+				claim.Spec.IPFamily = tt.ipFamily
+			}
+			cl := newIPAddressClaimTestClient(t, append(tt.pools, claim)...)
+			reconciler := &IPAddressClaimReconciler{
+				mgr: &fakeMockManager{cl: cl},
+				Config: config.NetworkServicesOperator{
+					Gateway: config.GatewayConfig{StaticAddressSubnetClasses: []string{"gateway-static"}},
+				},
+			}
+
+			_, err := reconciler.Reconcile(ctx, mcreconcile.Request{
+				Request:     reconcile.Request{NamespacedName: client.ObjectKeyFromObject(claim)},
+				ClusterName: "single",
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(claim), claim))
+			assert.Contains(t, claim.Finalizers, ipAddressClaimReleaseFinalizer)
+			allocated := apimeta.FindStatusCondition(claim.Status.Conditions, networkingv1alpha.IPAddressClaimAllocated)
+			require.NotNil(t, allocated)
+			assert.Equal(t, tt.wantReason, allocated.Reason)
+			assert.Equal(t, tt.wantAddress, claim.Status.Address)
+
+			if tt.wantAddress != "" {
+				var pool networkingv1alpha.IPPool
+				require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "pool"}, &pool))
+				assert.Equal(t, "pool", claim.Status.IPPool)
+				require.Len(t, pool.Status.Allocations, 1)
+				assert.Equal(t, "claim", pool.Status.Allocations[0].IPAddressClaimRef.Name)
+				assert.Nil(t, pool.Status.Allocations[0].SubnetRef)
+			}
+		})
+	}
+}
+
+func TestIPAddressClaimReconcileRelease(t *testing.T) {
+	ctx := context.Background()
+
+	claim := &networkingv1alpha.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "claim"},
+		Spec: networkingv1alpha.IPAddressClaimSpec{
+			SubnetClass: "gateway-static",
+			IPFamily:    networkingv1alpha.IPv4Protocol,
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "blue"},
+		Spec: gatewayv1.GatewaySpec{
+			Addresses: []gatewayv1.GatewaySpecAddress{{Type: ptr.To(gatewayv1.NamedAddressType), Value: "claim"}},
+		},
+	}
+	cl := newIPAddressClaimTestClient(t,
+		newTestIPAddressPool("pool", networkingv1alpha.IPv4Protocol, 32, "192.0.2.0/30"),
+		claim,
+		gateway,
+	)
+	reconciler := &IPAddressClaimReconciler{
+		mgr: &fakeMockManager{cl: cl},
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{StaticAddressSubnetClasses: []string{"gateway-static"}},
+		},
+	}
+
+	reconcileClaim := func() {
+		_, err := reconciler.Reconcile(ctx, mcreconcile.Request{
+			Request:     reconcile.Request{NamespacedName: client.ObjectKeyFromObject(claim)},
+			ClusterName: "single",
+		})
+		require.NoError(t, err)
+	}
+
+	reconcileClaim()
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(claim), claim))
+	require.Equal(t, "192.0.2.0", claim.Status.Address)
+
+	// The address is kept while a gateway names the claim.
+	require.NoError(t, cl.Delete(ctx, claim))
+	reconcileClaim()
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(claim), claim))
+	allocated := apimeta.FindStatusCondition(claim.Status.Conditions, networkingv1alpha.IPAddressClaimAllocated)
+	require.NotNil(t, allocated)
+	assert.Equal(t, networkingv1alpha.IPAddressClaimAllocatedReasonInUse, allocated.Reason)
+
+	var pool networkingv1alpha.IPPool
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "pool"}, &pool))
+	assert.Len(t, pool.Status.Allocations, 1)
+
+	// The address returns to the pool once no gateway names the claim.
+	require.NoError(t, cl.Delete(ctx, gateway))
+	reconcileClaim()
+	err := cl.Get(ctx, client.ObjectKeyFromObject(claim), claim)
+	assert.True(t, apierrors.IsNotFound(err), "expected ip address claim to be deleted, got %v", err)
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "pool"}, &pool))
+	assert.Empty(t, pool.Status.Allocations)
+	require.Len(t, pool.Status.CIDRs, 1)
+	assert.Zero(t, pool.Status.CIDRs[0].AllocatedBlocks)
+}
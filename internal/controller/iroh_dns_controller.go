@@ -469,6 +469,7 @@ func (r *IrohDNSReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 			onlyClustersServingLeases(),
 		).
 		WatchesRawSource(downstreamClusterSource).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "iroh-dns", 0)).
 		Named("iroh-dns").
 		Complete(r)
 }
@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// localPolicyReconciler reconciles a policy that is programmed as resources in
+// the downstream namespace of its own namespace. It holds a finalizer on the
+// policy until its downstream resources are removed, and writes the status the
+// policy is programmed with.
+type localPolicyReconciler[P client.Object] struct {
+	// name is the name of the controller, such as "ratelimitpolicy".
+	name      string
+	finalizer string
+	newPolicy func() P
+
+	// program programs the policy downstream and reports the result in its
+	// status.
+	program func(ctx context.Context, upstreamClient client.Client, policy P, downstreamStrategy downstreamclient.ResourceStrategy) error
+	// removeDownstream removes the downstream resources of a deleted policy.
+	removeDownstream func(ctx context.Context, policy P, downstreamStrategy downstreamclient.ResourceStrategy) error
+	// removeWhenTerminating removes the downstream resources of a policy that
+	// is deleted along with its namespace, for policies that are programmed
+	// outside of the downstream namespace.
+	removeWhenTerminating bool
+}
+
+// reconcile reconciles the policy of the request. Reconciles failing while the
+// API server of the upstream cluster is unreachable are retried as tracked by
+// upstreamOutages, which may be nil.
+func (r *localPolicyReconciler[P]) reconcile(
+	ctx context.Context,
+	mgr mcmanager.Manager,
+	downstreamCluster cluster.Cluster,
+	upstreamOutages *UpstreamOutageTracker,
+	req mcreconcile.Request,
+) (res ctrl.Result, err error) {
+	logger := log.FromContext(ctx, "cluster", req.ClusterName)
+	ctx = log.IntoContext(ctx, logger)
+
+	cl, err := mgr.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	cl, upstreamCalls := recordUpstreamCalls(cl)
+	defer func() {
+		res, err = upstreamOutages.complete(ctx, string(req.ClusterName), upstreamCalls, res, err)
+	}()
+	upstreamClient := cl.GetClient()
+
+	policy := r.newPolicy()
+	if err := upstreamClient.Get(ctx, req.NamespacedName, policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	terminating, err := upstreamNamespaceTerminating(ctx, upstreamClient, policy.GetNamespace())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), upstreamClient, downstreamCluster.GetClient(), downstreamclient.WithControllerName(r.name))
+
+	if !policy.GetDeletionTimestamp().IsZero() {
+		if controllerutil.ContainsFinalizer(policy, r.finalizer) {
+			// Policies are removed downstream along with the downstream namespace.
+			if !terminating {
+				if err := r.finalize(ctx, policy, downstreamStrategy); err != nil {
+					return ctrl.Result{}, err
+				}
+			} else if r.removeWhenTerminating {
+				if err := r.removeDownstream(ctx, policy, downstreamStrategy); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			controllerutil.RemoveFinalizer(policy, r.finalizer)
+			if err := upstreamClient.Update(ctx, policy); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if terminating {
+		logger.Info("namespace is terminating, waiting for " + r.name + " deletion")
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("reconciling " + r.name)
+	defer logger.Info("reconcile complete")
+
+	if !controllerutil.ContainsFinalizer(policy, r.finalizer) {
+		controllerutil.AddFinalizer(policy, r.finalizer)
+		if err := upstreamClient.Update(ctx, policy); err != nil {
+			if apierrors.IsConflict(err) {
+				return ctrl.Result{RequeueAfter: retryAfterConflict}, nil
+			}
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Programming the policy only changes its status.
+	original := policy.DeepCopyObject()
+
+	if err := r.program(ctx, upstreamClient, policy, downstreamStrategy); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !equality.Semantic.DeepEqual(original, runtime.Object(policy)) {
+		if err := upstreamClient.Status().Update(ctx, policy); err != nil {
+			if apierrors.IsConflict(err) {
+				return ctrl.Result{RequeueAfter: retryAfterConflict}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to update status for %s %s/%s: %w", r.name, policy.GetNamespace(), policy.GetName(), err)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *localPolicyReconciler[P]) finalize(ctx context.Context, policy P, downstreamStrategy downstreamclient.ResourceStrategy) error {
+	if err := r.removeDownstream(ctx, policy, downstreamStrategy); err != nil {
+		return err
+	}
+
+	if err := downstreamStrategy.DeleteAnchorForObject(ctx, policy); err != nil {
+		return fmt.Errorf("failed to delete downstream anchor for %s %s/%s: %w", r.name, policy.GetNamespace(), policy.GetName(), err)
+	}
+
+	return nil
+}
+
+// targetedLocalPolicy describes a policy that targets Gateways, HTTPRoutes and
+// HTTPProxies in its own namespace, and is programmed as a downstream Envoy
+// Gateway policy of type D attached to the targets it is accepted for.
+type targetedLocalPolicy[P, D client.Object] struct {
+	// name is the name of the controller, such as "ratelimitpolicy".
+	name string
+	// kind is the kind of the policy, such as "RateLimitPolicy".
+	kind           string
+	finalizer      string
+	controllerName string
+	newPolicy      func() P
+
+	targetRefs   func(policy P) []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName
+	policyStatus func(policy P) *gatewayv1alpha2.PolicyStatus
+	// listTargetRefs, when set, lists the targets of the policy in place of
+	// targetRefs, for policies that attach to the routes referencing their own
+	// targets.
+	listTargetRefs func(ctx context.Context, c client.Client, policy P) ([]gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName, error)
+	// listPolicies lists the policies the policy conflicts with when their
+	// targets overlap, which are programmed as the same kind of Envoy Gateway
+	// policy.
+	listPolicies listLocalPoliciesFunc
+
+	// newDownstream returns the downstream policy of the policy, with only its
+	// name set.
+	newDownstream func(policy P) D
+	// desiredDownstream sets the spec of the downstream policy, attached to
+	// the given targets. It returns an error that prevents the policy from
+	// being programmed as specified, which is reported on the ancestors of its
+	// accepted targets.
+	desiredDownstream func(policy P, downstream D, targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName) string
+	// syncDownstream, when set, programs the downstream resources the
+	// downstream policy depends on, such as the Secrets it references. It
+	// returns a programming error, like desiredDownstream.
+	syncDownstream func(
+		ctx context.Context,
+		upstreamClient client.Client,
+		policy P,
+		targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+		downstreamStrategy downstreamclient.ResourceStrategy,
+	) (string, error)
+}
+
+// reconciler returns the reconciler of the policy.
+func (p *targetedLocalPolicy[P, D]) reconciler() *localPolicyReconciler[P] {
+	return &localPolicyReconciler[P]{
+		name:      p.name,
+		finalizer: p.finalizer,
+		newPolicy: p.newPolicy,
+		program:   p.program,
+		removeDownstream: func(ctx context.Context, policy P, downstreamStrategy downstreamclient.ResourceStrategy) error {
+			_, err := p.ensureDownstream(ctx, policy, nil, downstreamStrategy)
+			return err
+		},
+	}
+}
+
+// program resolves the targets of the policy, and programs it downstream
+// attached to the targets it is accepted for.
+func (p *targetedLocalPolicy[P, D]) program(
+	ctx context.Context,
+	upstreamClient client.Client,
+	policy P,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) error {
+	policyTargetRefs, err := p.policyTargetRefs(ctx, upstreamClient, policy)
+	if err != nil {
+		return err
+	}
+
+	targetRefs, err := resolveLocalPolicyTargets(
+		ctx,
+		upstreamClient,
+		p.controllerName,
+		localPolicy{Object: policy, kind: p.kind, targetRefs: policyTargetRefs},
+		p.policyStatus(policy),
+		p.listPolicies,
+		localPolicyTargetsOverlap,
+	)
+	if err != nil {
+		return err
+	}
+
+	var programmingErr string
+	if p.syncDownstream != nil {
+		programmingErr, err = p.syncDownstream(ctx, upstreamClient, policy, targetRefs, downstreamStrategy)
+		if err != nil {
+			return err
+		}
+	}
+
+	downstreamErr, err := p.ensureDownstream(ctx, policy, targetRefs, downstreamStrategy)
+	if err != nil {
+		return err
+	}
+	if programmingErr == "" {
+		programmingErr = downstreamErr
+	}
+
+	setLocalPolicyProgrammingStatus(
+		localPolicy{Object: policy, targetRefs: policyTargetRefs},
+		p.policyStatus(policy),
+		p.controllerName,
+		programmingErr,
+	)
+	return nil
+}
+
+// policyTargetRefs returns the targets of the policy.
+func (p *targetedLocalPolicy[P, D]) policyTargetRefs(
+	ctx context.Context,
+	upstreamClient client.Client,
+	policy P,
+) ([]gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName, error) {
+	if p.listTargetRefs != nil {
+		return p.listTargetRefs(ctx, upstreamClient, policy)
+	}
+	return p.targetRefs(policy), nil
+}
+
+// ensureDownstream programs the policy as a downstream policy attached to the
+// given targets. The downstream policy is removed when there is no target.
+func (p *targetedLocalPolicy[P, D]) ensureDownstream(
+	ctx context.Context,
+	policy P,
+	targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) (string, error) {
+	logger := log.FromContext(ctx)
+
+	downstreamObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, policy)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive downstream metadata: %w", err)
+	}
+
+	downstream := p.newDownstream(policy)
+	downstream.SetNamespace(downstreamObjectMeta.Namespace)
+
+	targetRefs, err = downstreamLocalPolicyTargetRefs(ctx, downstreamStrategy.GetClient(), downstreamObjectMeta.Namespace, targetRefs)
+	if err != nil {
+		return "", err
+	}
+
+	if len(targetRefs) == 0 {
+		if err := downstreamStrategy.GetClient().Delete(ctx, downstream); client.IgnoreNotFound(err) != nil {
+			return "", fmt.Errorf("failed deleting downstream policy for %s: %w", p.name, err)
+		}
+		return "", nil
+	}
+
+	var programmingErr string
+	result, err := controllerutil.CreateOrUpdate(ctx, downstreamStrategy.GetClient(), downstream, func() error {
+		if err := downstreamStrategy.SetControllerReference(ctx, policy, downstream); err != nil {
+			return fmt.Errorf("failed to set controller reference on downstream policy for %s: %w", p.name, err)
+		}
+		programmingErr = p.desiredDownstream(policy, downstream, targetRefs)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed ensuring downstream policy for %s: %w", p.name, err)
+	}
+
+	logger.Info("downstream policy processed", "policy", metav1.Object(downstream).GetName(), "operation_result", result)
+	return programmingErr, nil
+}
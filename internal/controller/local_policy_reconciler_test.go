@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// The upstream namespace of the policies in the tests of local policy
+// reconcilers, and the downstream namespace it is mapped to.
+const (
+	localPolicyTestNamespace           = "default"
+	localPolicyTestNamespaceUID        = "test-ns-uid"
+	localPolicyTestDownstreamNamespace = "ns-" + localPolicyTestNamespaceUID
+)
+
+// localPolicyTestConfig is the operator configuration the local policy
+// reconcilers are tested with.
+var localPolicyTestConfig = config.NetworkServicesOperator{
+	Gateway: config.GatewayConfig{
+		ControllerName: gatewayv1.GatewayController("gateway.networking.datumapis.com/external-global-proxy-controller"),
+	},
+}
+
+// newLocalPolicyTestClients returns the upstream and downstream clients the
+// local policy reconcilers are tested with. The upstream client holds the
+// upstream namespace and the given objects, and serves the status of objects
+// of the same type as policy as a subresource. A Namespace among the objects
+// replaces the upstream namespace.
+func newLocalPolicyTestClients(t *testing.T, policy client.Object, objects ...client.Object) (client.Client, client.Client) {
+	t.Helper()
+
+	upstreamScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(upstreamScheme))
+	require.NoError(t, gatewayv1.Install(upstreamScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(upstreamScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(upstreamScheme))
+
+	downstreamScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(downstreamScheme))
+	require.NoError(t, gatewayv1.Install(downstreamScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(downstreamScheme))
+
+	upstreamObjects := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: localPolicyTestNamespace, UID: localPolicyTestNamespaceUID}},
+	}
+	for _, obj := range objects {
+		if _, ok := obj.(*corev1.Namespace); ok {
+			upstreamObjects[0] = obj
+			continue
+		}
+		upstreamObjects = append(upstreamObjects, obj)
+	}
+
+	upstreamClient := fake.NewClientBuilder().
+		WithScheme(upstreamScheme).
+		WithObjects(upstreamObjects...).
+		WithStatusSubresource(policy).
+		Build()
+	downstreamClient := fake.NewClientBuilder().
+		WithScheme(downstreamScheme).
+		Build()
+
+	return upstreamClient, downstreamClient
+}
+
+// localPolicyTestRequest returns the request to reconcile the named policy in
+// the upstream namespace.
+func localPolicyTestRequest(name string) mcreconcile.Request {
+	return mcreconcile.Request{
+		Request: reconcile.Request{
+			NamespacedName: client.ObjectKey{Namespace: localPolicyTestNamespace, Name: name},
+		},
+		ClusterName: "test-cluster",
+	}
+}
+
+func TestLocalPolicyReconcilerLifecycle(t *testing.T) {
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "gw"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}},
+		},
+	}
+	newPolicy := func() *networkingv1alpha.RateLimitPolicy {
+		return &networkingv1alpha.RateLimitPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "policy"},
+			Spec: networkingv1alpha.RateLimitPolicySpec{
+				TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Group: gatewayv1.GroupName,
+							Kind:  KindGateway,
+							Name:  "gw",
+						},
+					},
+				},
+				Rules: []networkingv1alpha.RateLimitRule{
+					{Limit: networkingv1alpha.RateLimitValue{Requests: 10, Unit: networkingv1alpha.RateLimitUnitMinute}},
+				},
+			},
+		}
+	}
+	downstreamKey := client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "ratelimit-policy"}
+	req := localPolicyTestRequest("policy")
+
+	t.Run("programs the policy and cleans up on deletion", func(t *testing.T) {
+		ctx := context.Background()
+		upstreamClient, downstreamClient := newLocalPolicyTestClients(t, &networkingv1alpha.RateLimitPolicy{}, gateway.DeepCopy(), newPolicy())
+		reconciler := &RateLimitPolicyReconciler{
+			mgr:               &fakeMockManager{cl: upstreamClient},
+			DownstreamCluster: &fakeCluster{cl: downstreamClient},
+			Config:            localPolicyTestConfig,
+		}
+
+		_, err := reconciler.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		var policy networkingv1alpha.RateLimitPolicy
+		require.NoError(t, upstreamClient.Get(ctx, req.NamespacedName, &policy))
+		assert.True(t, controllerutil.ContainsFinalizer(&policy, rateLimitPolicyFinalizer))
+		assert.Len(t, policy.Status.Ancestors, 1)
+		require.NoError(t, downstreamClient.Get(ctx, downstreamKey, &envoygatewayv1alpha1.BackendTrafficPolicy{}))
+
+		require.NoError(t, upstreamClient.Delete(ctx, &policy))
+		_, err = reconciler.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		err = upstreamClient.Get(ctx, req.NamespacedName, &policy)
+		assert.True(t, apierrors.IsNotFound(err), "expected the policy to be deleted, got %v", err)
+		err = downstreamClient.Get(ctx, downstreamKey, &envoygatewayv1alpha1.BackendTrafficPolicy{})
+		assert.True(t, apierrors.IsNotFound(err), "expected no downstream policy, got %v", err)
+	})
+
+	t.Run("releases the policy of a terminating namespace", func(t *testing.T) {
+		ctx := context.Background()
+		namespace := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              localPolicyTestNamespace,
+				UID:               localPolicyTestNamespaceUID,
+				DeletionTimestamp: ptr.To(metav1.Now()),
+				Finalizers:        []string{"kubernetes"},
+			},
+		}
+		policy := newPolicy()
+		policy.Finalizers = []string{rateLimitPolicyFinalizer}
+		policy.DeletionTimestamp = ptr.To(metav1.Now())
+
+		upstreamClient, downstreamClient := newLocalPolicyTestClients(t, &networkingv1alpha.RateLimitPolicy{}, namespace, gateway.DeepCopy(), policy)
+		reconciler := &RateLimitPolicyReconciler{
+			mgr:               &fakeMockManager{cl: upstreamClient},
+			DownstreamCluster: &fakeCluster{cl: downstreamClient},
+			Config:            localPolicyTestConfig,
+		}
+
+		_, err := reconciler.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		err = upstreamClient.Get(ctx, req.NamespacedName, &networkingv1alpha.RateLimitPolicy{})
+		assert.True(t, apierrors.IsNotFound(err), "expected the policy to be deleted, got %v", err)
+	})
+}
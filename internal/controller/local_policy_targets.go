@@ -0,0 +1,606 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	gatewaystatus "go.datum.net/network-services-operator/internal/gatewayapi/status"
+)
+
+// localPolicy is a policy that targets Gateways, HTTPRoutes and HTTPProxies in
+// its own namespace, such as a RateLimitPolicy.
+type localPolicy struct {
+	metav1.Object
+	// kind is the kind of the policy. For a generated policy it names the
+	// configuration of the HTTPProxy that the policy programs.
+	kind       string
+	targetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName
+	// generated is set for the policies an HTTPProxy generates from its own
+	// configuration, which take precedence over the policies that target it.
+	generated bool
+}
+
+// listLocalPoliciesFunc lists the policies in a namespace that are programmed
+// as the same kind of Envoy Gateway policy.
+type listLocalPoliciesFunc func(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error)
+
+// resolveLocalPolicyTargets reports the status of each target of the policy
+// as an ancestor, and returns the downstream targets of the targets the policy
+// is accepted for.
+//
+// A target may only be attached to one of the listed policies, and targets
+// conflict when targetsOverlap reports so. When several policies target it,
+// the policies generated by an HTTPProxy are accepted first and then the
+// oldest policy, and the others are reported as conflicted rather than
+// programmed.
+func resolveLocalPolicyTargets(
+	ctx context.Context,
+	upstreamClient client.Client,
+	controllerName string,
+	policy localPolicy,
+	policyStatus *gatewayv1alpha2.PolicyStatus,
+	listPolicies listLocalPoliciesFunc,
+	targetsOverlap func(a, b gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName) bool,
+) ([]gatewayv1.LocalPolicyTargetReferenceWithSectionName, error) {
+	policies, err := listPolicies(ctx, upstreamClient, policy.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	var ancestorRefs []gatewayv1alpha2.ParentReference
+	var downstreamTargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName
+
+	for _, targetRef := range policy.targetRefs {
+		ancestorRef := getAncestorRefForTarget(policy.GetNamespace(), targetRef)
+		ancestorRefs = append(ancestorRefs, *ancestorRef)
+
+		if other := precedingLocalPolicyForTarget(policies, policy, targetRef, targetsOverlap); other != nil {
+			gatewaystatus.SetResolveErrorForPolicyAncestor(policyStatus, ancestorRef, controllerName, policy.GetGeneration(),
+				&gatewaystatus.PolicyResolveError{
+					Reason:  gatewayv1.PolicyReasonConflicted,
+					Message: localPolicyConflictMessage(targetRef, other),
+				},
+			)
+			continue
+		}
+
+		downstreamTargetRef, resolveErr, err := resolveLocalPolicyTarget(ctx, upstreamClient, policy.GetNamespace(), targetRef)
+		if err != nil {
+			return nil, err
+		}
+		if resolveErr != nil {
+			gatewaystatus.SetResolveErrorForPolicyAncestor(policyStatus, ancestorRef, controllerName, policy.GetGeneration(), resolveErr)
+			continue
+		}
+
+		acceptLocalPolicyTarget(policyStatus, ancestorRef, controllerName, policy.GetGeneration())
+		downstreamTargetRefs = append(downstreamTargetRefs, *downstreamTargetRef)
+	}
+
+	// Remove the ancestors of targets that are no longer targeted.
+	policyStatus.Ancestors = slices.DeleteFunc(policyStatus.Ancestors, func(ancestor gatewayv1.PolicyAncestorStatus) bool {
+		if string(ancestor.ControllerName) != controllerName {
+			return false
+		}
+		return !slices.ContainsFunc(ancestorRefs, func(ref gatewayv1alpha2.ParentReference) bool {
+			return equality.Semantic.DeepEqual(ancestor.AncestorRef, ref)
+		})
+	})
+
+	return downstreamTargetRefs, nil
+}
+
+// acceptLocalPolicyTarget accepts the ancestor of a target. Unlike
+// gatewaystatus.SetAcceptedForPolicyAncestor, it replaces a conflict or
+// resolve error reported by an earlier reconcile, as the status of a policy is
+// kept between reconciles. An error reported by setLocalPolicyProgrammingStatus
+// is left for it to resolve.
+func acceptLocalPolicyTarget(
+	policyStatus *gatewayv1alpha2.PolicyStatus,
+	ancestorRef *gatewayv1alpha2.ParentReference,
+	controllerName string,
+	generation int64,
+) {
+	for _, ancestor := range policyStatus.Ancestors {
+		if string(ancestor.ControllerName) != controllerName || !equality.Semantic.DeepEqual(ancestor.AncestorRef, *ancestorRef) {
+			continue
+		}
+		condition := apimeta.FindStatusCondition(ancestor.Conditions, string(gatewayv1.PolicyConditionAccepted))
+		if condition != nil && (condition.Status == metav1.ConditionTrue || condition.Reason == string(gatewayv1.PolicyReasonInvalid)) {
+			return
+		}
+	}
+	gatewaystatus.SetConditionForPolicyAncestor(policyStatus, ancestorRef, controllerName,
+		gatewayv1.PolicyConditionAccepted, metav1.ConditionTrue, gatewayv1.PolicyReasonAccepted, "Policy has been accepted.", generation)
+}
+
+// setLocalPolicyProgrammingStatus reports an error that prevents a policy from
+// being programmed on the ancestors of the targets it was accepted for, or
+// accepts them again once the error is resolved. Targets that
+// resolveLocalPolicyTargets did not accept keep their status.
+func setLocalPolicyProgrammingStatus(
+	policy localPolicy,
+	policyStatus *gatewayv1alpha2.PolicyStatus,
+	controllerName string,
+	programmingErr string,
+) {
+	for _, targetRef := range policy.targetRefs {
+		ancestorRef := getAncestorRefForTarget(policy.GetNamespace(), targetRef)
+		for _, ancestor := range policyStatus.Ancestors {
+			if string(ancestor.ControllerName) != controllerName || !equality.Semantic.DeepEqual(ancestor.AncestorRef, *ancestorRef) {
+				continue
+			}
+			condition := apimeta.FindStatusCondition(ancestor.Conditions, string(gatewayv1.PolicyConditionAccepted))
+			if condition == nil || (condition.Status != metav1.ConditionTrue && condition.Reason != string(gatewayv1.PolicyReasonInvalid)) {
+				continue
+			}
+			if programmingErr != "" {
+				gatewaystatus.SetTranslationErrorForPolicyAncestor(policyStatus, ancestorRef, controllerName, policy.GetGeneration(), programmingErr)
+			} else if condition.Status != metav1.ConditionTrue {
+				gatewaystatus.SetConditionForPolicyAncestor(policyStatus, ancestorRef, controllerName,
+					gatewayv1.PolicyConditionAccepted, metav1.ConditionTrue, gatewayv1.PolicyReasonAccepted, "Policy has been accepted.", policy.GetGeneration())
+			}
+		}
+	}
+}
+
+// precedingLocalPolicyForTarget returns the policy that takes precedence over
+// the given policy for a target, if any.
+func precedingLocalPolicyForTarget(
+	policies []localPolicy,
+	policy localPolicy,
+	targetRef gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName,
+	targetsOverlap func(a, b gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName) bool,
+) *localPolicy {
+	for i := range policies {
+		other := &policies[i]
+		if other.GetUID() == policy.GetUID() || other.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if localPolicyPrecedes(other, &policy) && slices.ContainsFunc(other.targetRefs, func(ref gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName) bool {
+			return targetsOverlap(ref, targetRef)
+		}) {
+			return other
+		}
+	}
+	return nil
+}
+
+// localPolicyPrecedes reports whether a policy takes precedence over another.
+// Generated policies precede the others, and older policies precede newer
+// ones.
+func localPolicyPrecedes(policy, other *localPolicy) bool {
+	if policy.generated != other.generated {
+		return policy.generated
+	}
+	creationTimestamp := policy.GetCreationTimestamp()
+	otherCreationTimestamp := other.GetCreationTimestamp()
+	if !creationTimestamp.Equal(&otherCreationTimestamp) {
+		return creationTimestamp.Before(&otherCreationTimestamp)
+	}
+	if policy.kind != other.kind {
+		return policy.kind < other.kind
+	}
+	return policy.GetName() < other.GetName()
+}
+
+// localPolicyTargetsEqual reports whether two targets are the same.
+func localPolicyTargetsEqual(a, b gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName) bool {
+	return equality.Semantic.DeepEqual(a, b)
+}
+
+// localPolicyTargetsOverlap reports whether two targets attach to a common
+// section of the same downstream resource. An HTTPProxy is programmed as the
+// HTTPRoute of the same name. Envoy Gateway only applies the most specific of
+// the policies of a kind attached to a section, and the oldest of those
+// attached to the same section, so overlapping policies conflict.
+func localPolicyTargetsOverlap(a, b gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName) bool {
+	return downstreamLocalPolicyTargetKind(a.Kind) == downstreamLocalPolicyTargetKind(b.Kind) &&
+		a.Name == b.Name &&
+		(a.SectionName == nil || b.SectionName == nil || *a.SectionName == *b.SectionName)
+}
+
+// downstreamLocalPolicyTargetKind returns the kind of the downstream resource
+// that a target of the given kind is programmed as.
+func downstreamLocalPolicyTargetKind(kind gatewayv1.Kind) gatewayv1.Kind {
+	if kind == KindHTTPProxy {
+		return KindHTTPRoute
+	}
+	return kind
+}
+
+// localPolicyConflictMessage reports that a target is already attached to
+// another policy.
+func localPolicyConflictMessage(targetRef gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName, other *localPolicy) string {
+	if other.generated {
+		return fmt.Sprintf("Unable to target %s %s, the %s of HTTPProxy %s has already attached to it", targetRef.Kind, targetRef.Name, other.kind, other.GetName())
+	}
+	return fmt.Sprintf("Unable to target %s %s, %s %s has already attached to it", targetRef.Kind, targetRef.Name, other.kind, other.GetName())
+}
+
+// routePolicyMergeType returns the merge type of a downstream policy with the
+// given targets. A policy attached to a route is merged into the policy
+// attached to the Gateway of the route, rather than overriding it.
+func routePolicyMergeType(targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName) *envoygatewayv1alpha1.MergeType {
+	if slices.ContainsFunc(targetRefs, func(ref gatewayv1.LocalPolicyTargetReferenceWithSectionName) bool {
+		return ref.Kind == KindHTTPRoute
+	}) {
+		return ptr.To(envoygatewayv1alpha1.StrategicMerge)
+	}
+	return nil
+}
+
+// gatewayPolicyMergeType returns the merge type of a BackendTrafficPolicy
+// attached to the routes of a Gateway. The policy overlays the policy attached
+// to the Gateway when there is one, so that the settings of the Gateway, such
+// as its rate limits, keep applying to the routes. It is left unset otherwise,
+// as there is no policy to overlay.
+func gatewayPolicyMergeType(ctx context.Context, c client.Client, namespace, gatewayName string) (*envoygatewayv1alpha1.MergeType, error) {
+	var backendTrafficPolicies envoygatewayv1alpha1.BackendTrafficPolicyList
+	if err := c.List(ctx, &backendTrafficPolicies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing backendtrafficpolicies: %w", err)
+	}
+	for _, policy := range backendTrafficPolicies.Items {
+		if slices.Contains(targetedGatewayNames(backendTrafficPolicyTargetRefs(&policy)), gatewayName) {
+			return ptr.To(envoygatewayv1alpha1.StrategicMerge), nil
+		}
+	}
+
+	localPolicies, err := listBackendTrafficLocalPolicies(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range localPolicies {
+		if slices.Contains(targetedGatewayNames(policy.targetRefs), gatewayName) {
+			return ptr.To(envoygatewayv1alpha1.StrategicMerge), nil
+		}
+	}
+	return nil, nil
+}
+
+// targetedGatewayNames returns the names of the Gateways among the targets of
+// a policy.
+func targetedGatewayNames(targetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName) []string {
+	var names []string
+	for _, ref := range targetRefs {
+		if ref.Kind == KindGateway {
+			names = append(names, string(ref.Name))
+		}
+	}
+	return names
+}
+
+// backendTrafficPolicyTargetRefs returns the targets of a BackendTrafficPolicy
+// as the targets of a local policy.
+func backendTrafficPolicyTargetRefs(policy *envoygatewayv1alpha1.BackendTrafficPolicy) []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+	targetRefs := make([]gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName, 0, len(policy.Spec.TargetRefs))
+	for _, ref := range policy.Spec.TargetRefs {
+		targetRefs = append(targetRefs, gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName(ref))
+	}
+	return targetRefs
+}
+
+// enqueueGatewayPolicyTargets enqueues the Gateways a BackendTrafficPolicy, or
+// a policy programmed as one, is attached to, along with the HTTPProxies that
+// share their names, as the policies generated for their routes overlay it.
+func enqueueGatewayPolicyTargets(clusterName multicluster.ClusterName, _ cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		var targetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName
+		switch policy := obj.(type) {
+		case *envoygatewayv1alpha1.BackendTrafficPolicy:
+			targetRefs = backendTrafficPolicyTargetRefs(policy)
+		case *networkingv1alpha.RateLimitPolicy:
+			targetRefs = policy.Spec.TargetRefs
+		case *networkingv1alpha.PayloadPolicy:
+			targetRefs = policy.Spec.TargetRefs
+		}
+
+		var requests []mcreconcile.Request
+		for _, name := range targetedGatewayNames(targetRefs) {
+			requests = append(requests, mcreconcile.Request{
+				ClusterName: clusterName,
+				Request: reconcile.Request{
+					NamespacedName: client.ObjectKey{Namespace: obj.GetNamespace(), Name: name},
+				},
+			})
+		}
+		return requests
+	})
+}
+
+// listBackendTrafficLocalPolicies lists the policies in a namespace that are
+// programmed as BackendTrafficPolicies, along with the policies HTTPProxies
+// generate for the backends of their rules.
+func listBackendTrafficLocalPolicies(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error) {
+	var localPolicies []localPolicy
+	for _, listPolicies := range []listLocalPoliciesFunc{listRateLimitPolicies, listPayloadPolicies, listBackendLoadBalancingPolicies, listHTTPProxyBackendPolicies} {
+		policies, err := listPolicies(ctx, c, namespace)
+		if err != nil {
+			return nil, err
+		}
+		localPolicies = append(localPolicies, policies...)
+	}
+	return localPolicies, nil
+}
+
+// listSecurityLocalPolicies lists the policies in a namespace that are
+// programmed as SecurityPolicies, along with the policies HTTPProxies generate
+// for their auth.
+func listSecurityLocalPolicies(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error) {
+	var localPolicies []localPolicy
+	for _, listPolicies := range []listLocalPoliciesFunc{listAccessControlPolicies, listAuthenticationPolicies, listHTTPProxyAuthPolicies} {
+		policies, err := listPolicies(ctx, c, namespace)
+		if err != nil {
+			return nil, err
+		}
+		localPolicies = append(localPolicies, policies...)
+	}
+	return localPolicies, nil
+}
+
+// listHTTPProxyBackendPolicies lists the BackendTrafficPolicies HTTPProxies
+// generate for the rules that health check or load balance their backends.
+func listHTTPProxyBackendPolicies(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error) {
+	var httpProxies networkingv1alpha.HTTPProxyList
+	if err := c.List(ctx, &httpProxies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing httpproxies: %w", err)
+	}
+
+	var localPolicies []localPolicy
+	for i := range httpProxies.Items {
+		httpProxy := &httpProxies.Items[i]
+		policy := localPolicy{Object: httpProxy, kind: "backend configuration", generated: true}
+		for _, rule := range httpProxy.Spec.Rules {
+			if !httpProxyRuleHealthChecked(rule) && httpProxyRuleLoadBalancer(rule) == nil {
+				continue
+			}
+			policy.targetRefs = append(policy.targetRefs, gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+					Group: gatewayv1.Group(networkingv1alpha.GroupVersion.Group),
+					Kind:  KindHTTPProxy,
+					Name:  gatewayv1.ObjectName(httpProxy.Name),
+				},
+				SectionName: rule.Name,
+			})
+		}
+		if len(policy.targetRefs) > 0 {
+			localPolicies = append(localPolicies, policy)
+		}
+	}
+	return localPolicies, nil
+}
+
+// resolveLocalPolicyTarget returns the downstream target of a policy target.
+// An HTTPProxy is targeted through the HTTPRoute it programs, which has the
+// same name and rule names.
+func resolveLocalPolicyTarget(
+	ctx context.Context,
+	upstreamClient client.Client,
+	namespace string,
+	targetRef gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName,
+) (*gatewayv1.LocalPolicyTargetReferenceWithSectionName, *gatewaystatus.PolicyResolveError, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: string(targetRef.Name)}
+
+	var obj client.Object
+	downstreamKind := gatewayv1.Kind(KindHTTPRoute)
+	switch targetRef.Kind {
+	case KindGateway:
+		obj = &gatewayv1.Gateway{}
+		downstreamKind = KindGateway
+	case KindHTTPRoute:
+		obj = &gatewayv1.HTTPRoute{}
+	case KindHTTPProxy:
+		obj = &networkingv1alpha.HTTPProxy{}
+	default:
+		return nil, &gatewaystatus.PolicyResolveError{
+			Reason:  gatewayv1.PolicyReasonInvalid,
+			Message: fmt.Sprintf("Unsupported target kind %s", targetRef.Kind),
+		}, nil
+	}
+
+	if err := upstreamClient.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &gatewaystatus.PolicyResolveError{
+				Reason:  gatewayv1.PolicyReasonTargetNotFound,
+				Message: fmt.Sprintf("%s %s/%s not found", targetRef.Kind, namespace, targetRef.Name),
+			}, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get %s %s: %w", targetRef.Kind, key, err)
+	}
+
+	var sectionNames []gatewayv1.SectionName
+	switch o := obj.(type) {
+	case *gatewayv1.Gateway:
+		for _, l := range o.Spec.Listeners {
+			sectionNames = append(sectionNames, l.Name)
+		}
+	case *gatewayv1.HTTPRoute:
+		for _, rule := range o.Spec.Rules {
+			if rule.Name != nil {
+				sectionNames = append(sectionNames, *rule.Name)
+			}
+		}
+	case *networkingv1alpha.HTTPProxy:
+		for _, rule := range o.Spec.Rules {
+			if rule.Name != nil {
+				sectionNames = append(sectionNames, *rule.Name)
+			}
+		}
+	}
+
+	if targetRef.SectionName != nil && !slices.Contains(sectionNames, *targetRef.SectionName) {
+		return nil, &gatewaystatus.PolicyResolveError{
+			Reason:  gatewayv1.PolicyReasonTargetNotFound,
+			Message: fmt.Sprintf("No section name %s found for %s %s/%s", *targetRef.SectionName, targetRef.Kind, namespace, targetRef.Name),
+		}, nil
+	}
+
+	return &gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+			Group: gatewayv1.GroupName,
+			Kind:  downstreamKind,
+			Name:  targetRef.Name,
+		},
+		SectionName: targetRef.SectionName,
+	}, nil, nil
+}
+
+// downstreamLocalPolicyTargetRefs points Gateway targets at the downstream
+// Gateway shards that hold the targeted listeners.
+func downstreamLocalPolicyTargetRefs(
+	ctx context.Context,
+	downstreamClient client.Client,
+	downstreamNamespace string,
+	targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+) ([]gatewayv1.LocalPolicyTargetReferenceWithSectionName, error) {
+	var parentRefs []gatewayv1.ParentReference
+	var downstreamTargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName
+	for _, targetRef := range targetRefs {
+		if targetRef.Kind != KindGateway {
+			downstreamTargetRefs = append(downstreamTargetRefs, targetRef)
+			continue
+		}
+		parentRefs = append(parentRefs, gatewayv1.ParentReference{
+			Group:       ptr.To(targetRef.Group),
+			Kind:        ptr.To(targetRef.Kind),
+			Name:        targetRef.Name,
+			SectionName: targetRef.SectionName,
+		})
+	}
+	if len(parentRefs) == 0 {
+		return downstreamTargetRefs, nil
+	}
+
+	parentRefs, err := downstreamHTTPRouteParentRefs(ctx, downstreamClient, downstreamNamespace, parentRefs)
+	if err != nil {
+		return nil, err
+	}
+	for _, parentRef := range parentRefs {
+		downstreamTargetRefs = append(downstreamTargetRefs, gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  KindGateway,
+				Name:  parentRef.Name,
+			},
+			SectionName: parentRef.SectionName,
+		})
+	}
+	return downstreamTargetRefs, nil
+}
+
+// listHTTPProxyAuthPolicies lists the SecurityPolicies HTTPProxies generate
+// for their auth, which are attached to all of their rules.
+func listHTTPProxyAuthPolicies(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error) {
+	var httpProxies networkingv1alpha.HTTPProxyList
+	if err := c.List(ctx, &httpProxies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing httpproxies: %w", err)
+	}
+
+	var localPolicies []localPolicy
+	for i := range httpProxies.Items {
+		httpProxy := &httpProxies.Items[i]
+		if httpProxy.Spec.Auth == nil {
+			continue
+		}
+		localPolicies = append(localPolicies, localPolicy{
+			Object: httpProxy,
+			kind:   "auth configuration",
+			targetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+						Group: gatewayv1.Group(networkingv1alpha.GroupVersion.Group),
+						Kind:  KindHTTPProxy,
+						Name:  gatewayv1.ObjectName(httpProxy.Name),
+					},
+				},
+			},
+			generated: true,
+		})
+	}
+	return localPolicies, nil
+}
+
+// enqueueLocalPoliciesForTargetFunc enqueues the policies that target an
+// object of the given kind, or the downstream resource it is programmed as.
+func enqueueLocalPoliciesForTargetFunc(
+	kind gatewayv1.Kind,
+	listPolicies listLocalPoliciesFunc,
+) func(multicluster.ClusterName, cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return func(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+		return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+			logger := log.FromContext(ctx)
+
+			policies, err := listPolicies(ctx, cl.GetClient(), obj.GetNamespace())
+			if err != nil {
+				logger.Error(err, "failed to list policies for target", "kind", kind)
+				return nil
+			}
+
+			var requests []mcreconcile.Request
+			for _, policy := range policies {
+				if slices.ContainsFunc(policy.targetRefs, func(ref gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName) bool {
+					return downstreamLocalPolicyTargetKind(ref.Kind) == downstreamLocalPolicyTargetKind(kind) && string(ref.Name) == obj.GetName()
+				}) {
+					requests = append(requests, mcreconcile.Request{
+						ClusterName: clusterName,
+						Request: reconcile.Request{
+							NamespacedName: client.ObjectKey{Namespace: policy.GetNamespace(), Name: policy.GetName()},
+						},
+					})
+				}
+			}
+
+			return requests
+		})
+	}
+}
+
+// enqueueLocalPoliciesInNamespaceFunc enqueues the policies in the namespace
+// of an object, such as a policy they may conflict with.
+func enqueueLocalPoliciesInNamespaceFunc(
+	listPolicies listLocalPoliciesFunc,
+) func(multicluster.ClusterName, cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return func(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+		return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+			logger := log.FromContext(ctx)
+
+			policies, err := listPolicies(ctx, cl.GetClient(), obj.GetNamespace())
+			if err != nil {
+				logger.Error(err, "failed to list policies in namespace", "namespace", obj.GetNamespace())
+				return nil
+			}
+
+			requests := make([]mcreconcile.Request, 0, len(policies))
+			for _, policy := range policies {
+				requests = append(requests, mcreconcile.Request{
+					ClusterName: clusterName,
+					Request: reconcile.Request{
+						NamespacedName: client.ObjectKey{Namespace: policy.GetNamespace(), Name: policy.GetName()},
+					},
+				})
+			}
+
+			return requests
+		})
+	}
+}
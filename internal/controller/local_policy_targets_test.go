@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestGatewayPolicyMergeType(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+
+	targetRef := func(kind gatewayv1.Kind, name string) gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+		return gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  kind,
+				Name:  gatewayv1.ObjectName(name),
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    *envoygatewayv1alpha1.MergeType
+	}{
+		{
+			name: "no gateway policy",
+			objects: []client.Object{
+				&envoygatewayv1alpha1.BackendTrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route"},
+					Spec: envoygatewayv1alpha1.BackendTrafficPolicySpec{
+						PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+							TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{gatewayv1.LocalPolicyTargetReferenceWithSectionName(targetRef(KindHTTPRoute, "gw"))},
+						},
+					},
+				},
+				&envoygatewayv1alpha1.BackendTrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "other-gateway"},
+					Spec: envoygatewayv1alpha1.BackendTrafficPolicySpec{
+						PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+							TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{gatewayv1.LocalPolicyTargetReferenceWithSectionName(targetRef(KindGateway, "other"))},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "backend traffic policy attached to the gateway",
+			objects: []client.Object{
+				&envoygatewayv1alpha1.BackendTrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"},
+					Spec: envoygatewayv1alpha1.BackendTrafficPolicySpec{
+						PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+							TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{gatewayv1.LocalPolicyTargetReferenceWithSectionName(targetRef(KindGateway, "gw"))},
+						},
+					},
+				},
+			},
+			want: ptr.To(envoygatewayv1alpha1.StrategicMerge),
+		},
+		{
+			name: "rate limit policy attached to the gateway",
+			objects: []client.Object{
+				&networkingv1alpha.RateLimitPolicy{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"},
+					Spec: networkingv1alpha.RateLimitPolicySpec{
+						TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{targetRef(KindGateway, "gw")},
+					},
+				},
+			},
+			want: ptr.To(envoygatewayv1alpha1.StrategicMerge),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(tt.objects...).Build()
+			mergeType, err := gatewayPolicyMergeType(context.Background(), fakeClient, "test", "gw")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, mergeType)
+		})
+	}
+}
@@ -17,6 +17,7 @@ const (
 	metricLabelHostname = "hostname"
 	metricLabelSecret   = "secret"
 	metricLabelReason   = "reason"
+	metricLabelCluster  = "cluster"
 )
 
 var (
@@ -115,4 +116,97 @@ var (
 		},
 		[]string{jsonKeyNamespace, jsonKeyName, metricLabelListener, metricLabelHostname},
 	)
+
+	// detachedHTTPRouteCleanupLatency is a histogram of the time between an
+	// HTTPRoute being first seen without a Datum gateway parentRef and the
+	// completion of the cleanup of its downstream resources. The detach time is
+	// recorded on the route in the detachedHTTPRouteDetachedAtAnnotation.
+	detachedHTTPRouteCleanupLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "nso_httproute_detached_cleanup_latency_seconds",
+			Help:    "Time between an HTTPRoute being detached from all Datum gateways and the cleanup of its downstream resources.",
+			Buckets: prometheus.ExponentialBuckets(0.5, 2, 14),
+		},
+	)
+
+	// downstreamDriftResources is the number of managed downstream resources
+	// found drifted by the last downstream audit, by resource kind and drift
+	// (orphaned | owner_replaced | spec_modified). Drifted resources that were
+	// repaired are still counted, so a non-zero value that persists across
+	// audits while repair is enabled indicates resources that cannot be removed
+	// or a spec that is repeatedly changed behind the operator's back.
+	downstreamDriftResources = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nso_downstream_drift_resources",
+			Help: "Number of managed downstream resources found drifted from their upstream owners by the last audit, by resource kind and drift.",
+		},
+		[]string{metricLabelResourceKind, metricLabelDrift},
+	)
+
+	// downstreamDriftRepairedTotal counts the drifted downstream resources
+	// repaired by the downstream audit, by resource kind and drift. Resources
+	// with a modified spec are repaired by having their desired spec written
+	// again, others are deleted.
+	downstreamDriftRepairedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nso_downstream_drift_repaired_total",
+			Help: "Total drifted downstream resources repaired by the downstream audit, by resource kind and drift.",
+		},
+		[]string{metricLabelResourceKind, metricLabelDrift},
+	)
+
+	// downstreamManagedResources is the number of downstream resources the
+	// operator manages by resource kind, as counted by the last downstream
+	// inventory.
+	downstreamManagedResources = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nso_downstream_managed_resources",
+			Help: "Number of downstream resources managed by the operator as counted by the last inventory, by resource kind.",
+		},
+		[]string{metricLabelResourceKind},
+	)
+
+	// ipPoolBlocks is the number of blocks of an IPPool by state (allocated |
+	// free). Alert on the fraction of free blocks to grow a pool before subnets
+	// fail to allocate:
+	//   nso_ippool_blocks{state="free"} / ignoring(state) sum without(state) (nso_ippool_blocks)
+	ipPoolBlocks = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nso_ippool_blocks",
+			Help: "Number of blocks of an IPPool by state (allocated | free).",
+		},
+		[]string{metricLabelCluster, jsonKeyNamespace, jsonKeyName, "state"},
+	)
+
+	// ipPoolExhaustedTotal counts subnet allocations that failed because the
+	// IPPool had no free prefix of the requested length.
+	ipPoolExhaustedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nso_ippool_exhausted_total",
+			Help: "Total subnet allocations that failed because the IPPool had no free prefix of the requested length.",
+		},
+		[]string{metricLabelCluster, jsonKeyNamespace, jsonKeyName},
+	)
+
+	// clusterDisengagementsTotal counts the upstream clusters disengaged from
+	// this replica, whether they were removed by the provider or moved to
+	// another replica.
+	clusterDisengagementsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "nso_cluster_disengagements_total",
+			Help: "Total upstream clusters disengaged from this replica.",
+		},
+	)
+
+	// clusterDisengagementOutcomesTotal counts disengaged clusters once their
+	// grace period passed, by outcome (moved | retained | finalized | failed).
+	// Retained clusters are removed clusters whose downstream resources were
+	// left in place because finalization is disabled.
+	clusterDisengagementOutcomesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nso_cluster_disengagement_outcomes_total",
+			Help: "Total disengaged upstream clusters by outcome (moved | retained | finalized | failed).",
+		},
+		[]string{"outcome"},
+	)
 )
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// namespaceTeardownRequeueInterval is how often finalization is retried while
+// waiting for dependent resources in a terminating namespace to be released.
+const namespaceTeardownRequeueInterval = 2 * time.Second
+
+// upstreamNamespaceTerminating returns whether an upstream namespace is being
+// deleted.
+//
+// Controllers switch to teardown-only mode for resources in a terminating
+// namespace. Nothing is created or updated, and finalizers skip the removal of
+// individual downstream resources, which are removed along with the downstream
+// namespace instead.
+func upstreamNamespaceTerminating(ctx context.Context, upstreamClient client.Client, name string) (bool, error) {
+	var namespace corev1.Namespace
+	if err := upstreamClient.Get(ctx, client.ObjectKey{Name: name}, &namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get upstream namespace: %w", err)
+	}
+	return !namespace.DeletionTimestamp.IsZero(), nil
+}
+
+// teardownDownstreamNamespace deletes the downstream namespace of a terminating
+// upstream namespace. This removes all downstream resources of the namespace at
+// once, rather than waiting on the finalizers of each upstream resource.
+//
+// An upstream namespace that no longer exists is torn down as well. Its UID,
+// and with it the name of the downstream namespace, can no longer be derived,
+// so the downstream namespace is found by the labels stamped on it instead.
+func teardownDownstreamNamespace(
+	ctx context.Context,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+	upstreamClusterName string,
+	upstreamNamespace string,
+) error {
+	downstreamClient := downstreamStrategy.GetClient()
+
+	var downstreamNamespaces []corev1.Namespace
+	downstreamNamespaceName, err := downstreamStrategy.GetDownstreamNamespaceNameForUpstreamNamespace(ctx, upstreamNamespace)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		labels := client.MatchingLabels{
+			downstreamclient.ManagedByLabel:              downstreamclient.ManagedByLabelValue,
+			downstreamclient.UpstreamOwnerNamespaceLabel: upstreamNamespace,
+		}
+		if upstreamClusterName != "" {
+			labels[downstreamclient.UpstreamOwnerClusterNameLabel] = downstreamclient.UpstreamClusterNameLabelValue(upstreamClusterName)
+		}
+		var namespaceList corev1.NamespaceList
+		if err := downstreamClient.List(ctx, &namespaceList, labels); err != nil {
+			return fmt.Errorf("failed listing downstream namespaces: %w", err)
+		}
+		downstreamNamespaces = namespaceList.Items
+	} else {
+		var downstreamNamespace corev1.Namespace
+		if err := downstreamClient.Get(ctx, client.ObjectKey{Name: downstreamNamespaceName}, &downstreamNamespace); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		downstreamNamespaces = append(downstreamNamespaces, downstreamNamespace)
+	}
+
+	for i := range downstreamNamespaces {
+		downstreamNamespace := &downstreamNamespaces[i]
+		if !downstreamNamespace.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		log.FromContext(ctx).Info("deleting downstream namespace of terminating namespace", "downstreamNamespace", downstreamNamespace.Name)
+		if err := downstreamClient.Delete(ctx, downstreamNamespace, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed deleting downstream namespace: %w", err)
+		}
+	}
+	return nil
+}
+
+// pendingHTTPRouteFinalizers returns the number of HTTPRoutes in the namespace
+// that still hold the downstream garbage collection finalizer. Gateways in a
+// terminating namespace are only released once all routes are, so routes are
+// never left waiting on a gateway that no longer exists.
+func pendingHTTPRouteFinalizers(ctx context.Context, upstreamClient client.Client, namespace string) (int, error) {
+	var httpRoutes gatewayv1.HTTPRouteList
+	if err := upstreamClient.List(ctx, &httpRoutes, client.InNamespace(namespace)); err != nil {
+		return 0, fmt.Errorf("failed listing httproutes: %w", err)
+	}
+
+	pending := 0
+	for i := range httpRoutes.Items {
+		if controllerutil.ContainsFinalizer(&httpRoutes.Items[i], gatewayControllerGCFinalizer) {
+			pending++
+		}
+	}
+	return pending, nil
+}
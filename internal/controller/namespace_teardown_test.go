@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func newTerminatingNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			UID:               uuid.NewUUID(),
+			DeletionTimestamp: ptr.To(metav1.Now()),
+			Finalizers:        []string{"kubernetes"},
+		},
+	}
+}
+
+func TestUpstreamNamespaceTerminating(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "active"}},
+		newTerminatingNamespace("terminating"),
+	).Build()
+
+	for name, expected := range map[string]bool{"active": false, "terminating": true, "missing": true} {
+		terminating, err := upstreamNamespaceTerminating(context.Background(), cl, name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, terminating, name)
+	}
+}
+
+func TestGatewayTeardown(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, gatewayv1.Install(testScheme))
+
+	upstreamNamespace := newTerminatingNamespace("test")
+	downstreamNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("ns-%s", upstreamNamespace.UID)}}
+
+	testConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{DownstreamHostnameAccountingNamespace: "hostname-accounting"},
+	}
+	upstreamGateway := newGateway(testConfig, upstreamNamespace.Name, "test")
+
+	hostnameClaim := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "hostname-accounting",
+			Name:      "claim",
+			Labels: map[string]string{
+				downstreamclient.UpstreamOwnerClusterNameLabel: "cluster-test",
+				downstreamclient.UpstreamOwnerNamespaceLabel:   upstreamGateway.Namespace,
+				downstreamclient.UpstreamOwnerNameLabel:        upstreamGateway.Name,
+			},
+		},
+	}
+
+	httpRoute := newHTTPRoute(upstreamNamespace.Name, "route", func(route *gatewayv1.HTTPRoute) {
+		route.Finalizers = []string{gatewayControllerGCFinalizer}
+	})
+
+	upstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(upstreamNamespace, httpRoute).Build()
+	downstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(downstreamNamespace, hostnameClaim).Build()
+
+	reconciler := &GatewayReconciler{
+		Config:            testConfig,
+		DownstreamCluster: &fakeCluster{cl: downstreamClient},
+	}
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", upstreamClient, downstreamClient)
+
+	// The gateway is held until the routes in the namespace are released.
+	result := reconciler.finalizeGateway(context.Background(), "test", upstreamClient, upstreamGateway, downstreamStrategy)
+	require.NoError(t, result.Err)
+	assert.Equal(t, namespaceTeardownRequeueInterval, result.RequeueAfter)
+
+	err := downstreamClient.Get(context.Background(), client.ObjectKeyFromObject(downstreamNamespace), &corev1.Namespace{})
+	assert.True(t, apierrors.IsNotFound(err), "downstream namespace should be deleted")
+	err = downstreamClient.Get(context.Background(), client.ObjectKeyFromObject(hostnameClaim), &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err), "hostname claims should be deleted")
+
+	httpRoute.Finalizers = nil
+	require.NoError(t, upstreamClient.Update(context.Background(), httpRoute))
+
+	result = reconciler.finalizeGateway(context.Background(), "test", upstreamClient, upstreamGateway, downstreamStrategy)
+	require.NoError(t, result.Err)
+	assert.False(t, result.ShouldReturn())
+}
+
+func TestTeardownDownstreamNamespaceOfDeletedNamespace(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+
+	managedLabels := func(upstreamNamespace string) map[string]string {
+		return map[string]string{
+			downstreamclient.ManagedByLabel:                downstreamclient.ManagedByLabelValue,
+			downstreamclient.UpstreamOwnerClusterNameLabel: downstreamclient.UpstreamClusterNameLabelValue("test"),
+			downstreamclient.UpstreamOwnerNamespaceLabel:   upstreamNamespace,
+		}
+	}
+	orphaned := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   fmt.Sprintf("ns-%s", uuid.NewUUID()),
+		Labels: managedLabels("deleted"),
+	}}
+	other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   fmt.Sprintf("ns-%s", uuid.NewUUID()),
+		Labels: managedLabels("other"),
+	}}
+
+	// The upstream namespace is already gone.
+	upstreamClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	downstreamClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(orphaned, other).Build()
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy("test", upstreamClient, downstreamClient)
+
+	require.NoError(t, teardownDownstreamNamespace(context.Background(), downstreamStrategy, "test", "deleted"))
+
+	err := downstreamClient.Get(context.Background(), client.ObjectKeyFromObject(orphaned), &corev1.Namespace{})
+	assert.True(t, apierrors.IsNotFound(err), "downstream namespace of the deleted namespace should be deleted")
+	assert.NoError(t, downstreamClient.Get(context.Background(), client.ObjectKeyFromObject(other), &corev1.Namespace{}))
+}
@@ -7,7 +7,10 @@ import (
 	"errors"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -76,9 +79,54 @@ func (r *NetworkReconciler) Reconcile(ctx context.Context, req mcreconcile.Reque
 		return ctrl.Result{}, nil
 	}
 
+	listOpts := client.MatchingFields{
+		networkContextControllerNetworkUIDIndex: string(network.UID),
+	}
+	var networkContexts networkingv1alpha.NetworkContextList
+	if err := cl.GetClient().List(ctx, &networkContexts, listOpts); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed listing network contexts: %w", err)
+	}
+
+	if setNetworkStatus(&network, networkContexts.Items) {
+		if err := cl.GetClient().Status().Update(ctx, &network); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed updating network status: %w", err)
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// setNetworkStatus summarizes the NetworkContexts of a network in its status,
+// and returns whether the status changed.
+func setNetworkStatus(network *networkingv1alpha.Network, networkContexts []networkingv1alpha.NetworkContext) bool {
+	originalStatus := network.Status.DeepCopy()
+
+	network.Status.ContextCount = int32(len(networkContexts))
+	network.Status.ReadyContextCount = 0
+	for _, networkContext := range networkContexts {
+		if apimeta.IsStatusConditionTrue(networkContext.Status.Conditions, networkingv1alpha.NetworkContextReady) {
+			network.Status.ReadyContextCount++
+		}
+	}
+
+	readyCondition := metav1.Condition{
+		Type:               networkingv1alpha.NetworkReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.NetworkReadyReasonReady,
+		Message:            "All network contexts are ready",
+		ObservedGeneration: network.Generation,
+	}
+	if network.Status.ReadyContextCount < network.Status.ContextCount {
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = networkingv1alpha.NetworkReadyReasonContextsNotReady
+		readyCondition.Message = fmt.Sprintf("%d of %d network contexts are ready",
+			network.Status.ReadyContextCount, network.Status.ContextCount)
+	}
+	apimeta.SetStatusCondition(&network.Status.Conditions, readyCondition)
+
+	return !equality.Semantic.DeepEqual(*originalStatus, network.Status)
+}
+
 var errNetworkContextsExist = errors.New("network contexts exist")
 
 func (r *NetworkReconciler) Finalize(ctx context.Context, obj client.Object) (finalizer.Result, error) {
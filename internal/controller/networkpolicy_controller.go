@@ -4,47 +4,377 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
 
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+	mcsource "sigs.k8s.io/multicluster-runtime/pkg/source"
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/validation"
 )
 
+const networkPolicyFinalizer = "networking.datumapis.com/networkpolicy-cleanup"
+
 // NetworkPolicyReconciler reconciles a NetworkPolicy object
 type NetworkPolicyReconciler struct {
-	mgr mcmanager.Manager
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+
+	DownstreamCluster cluster.Cluster
+
+	// UpstreamOutages, when set, retries reconciles while the API server of
+	// the policy's cluster is unreachable.
+	UpstreamOutages *UpstreamOutageTracker
 }
 
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=networkpolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=networkpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the NetworkPolicy object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.1/pkg/reconcile
 func (r *NetworkPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx, "cluster", req.ClusterName)
+	return r.reconciler().reconcile(ctx, r.mgr, r.DownstreamCluster, r.UpstreamOutages, req)
+}
+
+func (r *NetworkPolicyReconciler) reconciler() *localPolicyReconciler[*networkingv1alpha.NetworkPolicy] {
+	return &localPolicyReconciler[*networkingv1alpha.NetworkPolicy]{
+		name:             "networkpolicy",
+		finalizer:        networkPolicyFinalizer,
+		newPolicy:        func() *networkingv1alpha.NetworkPolicy { return &networkingv1alpha.NetworkPolicy{} },
+		program:          r.program,
+		removeDownstream: r.deleteDownstreamNetworkPolicy,
+	}
+}
+
+// program programs the valid ingress rules of the policy downstream if its
+// network exists, and reports the rules left out and the result in its status.
+func (r *NetworkPolicyReconciler) program(
+	ctx context.Context,
+	upstreamClient client.Client,
+	policy *networkingv1alpha.NetworkPolicy,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) error {
+	ingressRules, ruleErrors := desiredNetworkPolicyIngressRules(policy.Spec.Ingress)
+	policy.Status.RuleErrors = ruleErrors
+
+	acceptedCondition := metav1.Condition{
+		Type:               networkingv1alpha.NetworkPolicyAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.NetworkPolicyAcceptedReasonAccepted,
+		ObservedGeneration: policy.Generation,
+		Message:            "All rules of the network policy are valid",
+	}
+	if len(ruleErrors) > 0 {
+		acceptedCondition.Status = metav1.ConditionFalse
+		acceptedCondition.Reason = networkingv1alpha.NetworkPolicyAcceptedReasonInvalidRules
+		acceptedCondition.Message = fmt.Sprintf("%d of %d ingress rules are invalid and are not enforced", len(policy.Spec.Ingress)-len(ingressRules), len(policy.Spec.Ingress))
+	}
+	apimeta.SetStatusCondition(&policy.Status.Conditions, acceptedCondition)
+
+	programmedCondition := metav1.Condition{
+		Type:               networkingv1alpha.NetworkPolicyProgrammed,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.NetworkPolicyProgrammedReasonProgrammed,
+		ObservedGeneration: policy.Generation,
+		Message:            "The network policy is enforced on the instances of the network",
+	}
+
+	var network networkingv1alpha.Network
+	networkErr := upstreamClient.Get(ctx, client.ObjectKey{Namespace: policy.Namespace, Name: policy.Spec.NetworkRef.Name}, &network)
+	if client.IgnoreNotFound(networkErr) != nil {
+		return fmt.Errorf("failed fetching network: %w", networkErr)
+	}
+
+	if apierrors.IsNotFound(networkErr) {
+		programmedCondition.Status = metav1.ConditionFalse
+		programmedCondition.Reason = networkingv1alpha.NetworkPolicyProgrammedReasonNetworkNotFound
+		programmedCondition.Message = fmt.Sprintf("Network %q not found", policy.Spec.NetworkRef.Name)
+		if err := r.deleteDownstreamNetworkPolicy(ctx, policy, downstreamStrategy); err != nil {
+			return err
+		}
+	} else {
+		if err := r.ensureDownstreamNetworkPolicy(ctx, policy, ingressRules, downstreamStrategy); err != nil {
+			return err
+		}
+
+		// The policy only selects the pods the workload controllers labeled
+		// with the network, so it is only reported as enforced once it does.
+		selectedPods, err := r.countSelectedPods(ctx, policy, downstreamStrategy)
+		if err != nil {
+			return err
+		}
+		if selectedPods == 0 {
+			programmedCondition.Status = metav1.ConditionFalse
+			programmedCondition.Reason = networkingv1alpha.NetworkPolicyProgrammedReasonNoInstances
+			programmedCondition.Message = fmt.Sprintf("The network policy is programmed, but selects no pods labeled %s=%s", networkingv1alpha.NetworkPolicyNetworkLabel, policy.Spec.NetworkRef.Name)
+		} else {
+			programmedCondition.Message = fmt.Sprintf("The network policy is enforced on the %d instance pods of the network", selectedPods)
+		}
+	}
+	apimeta.SetStatusCondition(&policy.Status.Conditions, programmedCondition)
+
+	return nil
+}
+
+// desiredNetworkPolicyIngressRules translates the valid ingress rules of a
+// NetworkPolicy to Kubernetes NetworkPolicy ingress rules, and returns the
+// validation errors of the rules that are left out. Leaving out a rule only
+// denies the traffic it would have allowed.
+func desiredNetworkPolicyIngressRules(rules []networkingv1alpha.NetworkPolicyIngressRule) ([]networkingv1.NetworkPolicyIngressRule, []networkingv1alpha.NetworkPolicyRuleError) {
+	ingressPath := field.NewPath("spec", "ingress")
+
+	var desiredRules []networkingv1.NetworkPolicyIngressRule
+	var ruleErrors []networkingv1alpha.NetworkPolicyRuleError
+	for i, rule := range rules {
+		if errs := validation.ValidateNetworkPolicyIngressRule(rule, ingressPath.Index(i)); len(errs) > 0 {
+			for _, err := range errs {
+				ruleErrors = append(ruleErrors, networkingv1alpha.NetworkPolicyRuleError{
+					Index:   int32(i),
+					Field:   err.Field,
+					Message: err.ErrorBody(),
+				})
+			}
+			continue
+		}
+
+		var desiredRule networkingv1.NetworkPolicyIngressRule
+		for _, port := range rule.Ports {
+			desiredRule.Ports = append(desiredRule.Ports, networkingv1.NetworkPolicyPort{
+				Protocol: port.Protocol,
+				Port:     port.Port,
+				EndPort:  port.EndPort,
+			})
+		}
+		for _, peer := range rule.From {
+			// Validation ensures the CIDRs parse, and masks them as the CIDRs of
+			// Kubernetes NetworkPolicies must be.
+			cidr := netip.MustParsePrefix(peer.IPBlock.CIDR).Masked()
+			ipBlock := &networkingv1.IPBlock{CIDR: cidr.String()}
+			for _, except := range peer.IPBlock.Except {
+				ipBlock.Except = append(ipBlock.Except, netip.MustParsePrefix(except).Masked().String())
+			}
+			desiredRule.From = append(desiredRule.From, networkingv1.NetworkPolicyPeer{IPBlock: ipBlock})
+		}
+		desiredRules = append(desiredRules, desiredRule)
+	}
+	return desiredRules, ruleErrors
+}
+
+// ensureDownstreamNetworkPolicy programs a NetworkPolicy as a downstream
+// Kubernetes NetworkPolicy that selects the pods of the instances of its
+// network.
+func (r *NetworkPolicyReconciler) ensureDownstreamNetworkPolicy(
+	ctx context.Context,
+	policy *networkingv1alpha.NetworkPolicy,
+	ingressRules []networkingv1.NetworkPolicyIngressRule,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) error {
+	logger := log.FromContext(ctx)
+
+	downstreamObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, policy)
+	if err != nil {
+		return fmt.Errorf("failed to derive downstream metadata: %w", err)
+	}
 
-	// TODO(user): your logic here
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: downstreamObjectMeta.Namespace,
+			Name:      downstreamObjectMeta.Name,
+		},
+	}
 
-	return ctrl.Result{}, nil
+	result, err := controllerutil.CreateOrUpdate(ctx, downstreamStrategy.GetClient(), networkPolicy, func() error {
+		if err := downstreamStrategy.SetControllerReference(ctx, policy, networkPolicy); err != nil {
+			return fmt.Errorf("failed to set controller reference on downstream network policy: %w", err)
+		}
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					networkingv1alpha.NetworkPolicyNetworkLabel: policy.Spec.NetworkRef.Name,
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     ingressRules,
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed ensuring downstream network policy: %w", err)
+	}
+
+	logger.Info("downstream network policy processed", "operation_result", result)
+	return nil
+}
+
+// countSelectedPods returns the number of downstream pods selected by the
+// downstream Kubernetes NetworkPolicy of a NetworkPolicy. Only the metadata of
+// the pods is read.
+func (r *NetworkPolicyReconciler) countSelectedPods(
+	ctx context.Context,
+	policy *networkingv1alpha.NetworkPolicy,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) (int, error) {
+	downstreamObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, policy)
+	if err != nil {
+		return 0, fmt.Errorf("failed to derive downstream metadata: %w", err)
+	}
+
+	pods := &metav1.PartialObjectMetadataList{}
+	pods.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PodList"))
+	if err := downstreamStrategy.GetClient().List(ctx, pods,
+		client.InNamespace(downstreamObjectMeta.Namespace),
+		client.MatchingLabels{networkingv1alpha.NetworkPolicyNetworkLabel: policy.Spec.NetworkRef.Name},
+	); err != nil {
+		return 0, fmt.Errorf("failed listing downstream pods: %w", err)
+	}
+	return len(pods.Items), nil
+}
+
+// deleteDownstreamNetworkPolicy removes the downstream Kubernetes NetworkPolicy
+// of a NetworkPolicy.
+func (r *NetworkPolicyReconciler) deleteDownstreamNetworkPolicy(
+	ctx context.Context,
+	policy *networkingv1alpha.NetworkPolicy,
+	downstreamStrategy downstreamclient.ResourceStrategy,
+) error {
+	downstreamObjectMeta, err := downstreamStrategy.ObjectMetaFromUpstreamObject(ctx, policy)
+	if err != nil {
+		return fmt.Errorf("failed to derive downstream metadata: %w", err)
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: downstreamObjectMeta.Namespace,
+			Name:      downstreamObjectMeta.Name,
+		},
+	}
+	if err := downstreamStrategy.GetClient().Delete(ctx, networkPolicy); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed deleting downstream network policy: %w", err)
+	}
+	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *NetworkPolicyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	r.mgr = mgr
+
+	downstreamNetworkPolicySource := mcsource.TypedKind(
+		&networkingv1.NetworkPolicy{},
+		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*networkingv1.NetworkPolicy](&networkingv1alpha.NetworkPolicy{}),
+	)
+
+	downstreamNetworkPolicyClusterSource, _, _ := downstreamNetworkPolicySource.ForCluster("", r.DownstreamCluster)
+
+	// Only the metadata of pods is watched, as only their labels select them.
+	downstreamPod := &metav1.PartialObjectMetadata{}
+	downstreamPod.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+	downstreamPodSource := mcsource.TypedKind(
+		downstreamPod,
+		r.enqueueNetworkPoliciesForDownstreamPod,
+		predicate.NewTypedPredicateFuncs(func(pod *metav1.PartialObjectMetadata) bool {
+			_, ok := pod.GetLabels()[networkingv1alpha.NetworkPolicyNetworkLabel]
+			return ok
+		}),
+	)
+
+	downstreamPodClusterSource, _, _ := downstreamPodSource.ForCluster("", r.DownstreamCluster)
+
 	return mcbuilder.ControllerManagedBy(mgr).
 		For(&networkingv1alpha.NetworkPolicy{}, mcbuilder.WithEngageWithLocalCluster(false)).
+		Watches(&networkingv1alpha.Network{}, r.enqueueNetworkPoliciesForNetwork).
+		WatchesRawSource(downstreamNetworkPolicyClusterSource).
+		WatchesRawSource(downstreamPodClusterSource).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "networkpolicy", 0)).
 		Named("networkpolicy").
 		Complete(r)
 }
+
+// enqueueNetworkPoliciesForNetwork enqueues the NetworkPolicies of a network,
+// so that they are programmed once the network is created.
+func (r *NetworkPolicyReconciler) enqueueNetworkPoliciesForNetwork(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+		logger := log.FromContext(ctx)
+
+		var policies networkingv1alpha.NetworkPolicyList
+		if err := cl.GetClient().List(ctx, &policies, client.InNamespace(obj.GetNamespace())); err != nil {
+			logger.Error(err, "failed to list networkpolicies", "namespace", obj.GetNamespace())
+			return nil
+		}
+
+		var requests []mcreconcile.Request
+		for _, policy := range policies.Items {
+			if policy.Spec.NetworkRef.Name != obj.GetName() {
+				continue
+			}
+			requests = append(requests, mcreconcile.Request{
+				Request: reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&policy),
+				},
+				ClusterName: clusterName,
+			})
+		}
+		return requests
+	})
+}
+
+// enqueueNetworkPoliciesForDownstreamPod enqueues the upstream NetworkPolicies
+// whose downstream Kubernetes NetworkPolicies select a downstream pod, so that
+// they report whether they select any pods as pods come and go.
+func (r *NetworkPolicyReconciler) enqueueNetworkPoliciesForDownstreamPod(_ multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[*metav1.PartialObjectMetadata, mcreconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, pod *metav1.PartialObjectMetadata) []mcreconcile.Request {
+		logger := log.FromContext(ctx)
+
+		var networkPolicies networkingv1.NetworkPolicyList
+		if err := cl.GetClient().List(ctx, &networkPolicies,
+			client.InNamespace(pod.GetNamespace()),
+			client.MatchingLabels{downstreamclient.UpstreamOwnerKindLabel: "NetworkPolicy"},
+		); err != nil {
+			logger.Error(err, "failed to list downstream networkpolicies", "namespace", pod.GetNamespace())
+			return nil
+		}
+
+		network := pod.GetLabels()[networkingv1alpha.NetworkPolicyNetworkLabel]
+		var requests []mcreconcile.Request
+		for _, networkPolicy := range networkPolicies.Items {
+			if networkPolicy.Spec.PodSelector.MatchLabels[networkingv1alpha.NetworkPolicyNetworkLabel] != network {
+				continue
+			}
+			labels := networkPolicy.GetLabels()
+			if labels[downstreamclient.UpstreamOwnerGroupLabel] != networkingv1alpha.GroupVersion.Group {
+				continue
+			}
+			requests = append(requests, mcreconcile.Request{
+				Request: reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: labels[downstreamclient.UpstreamOwnerNamespaceLabel],
+						Name:      labels[downstreamclient.UpstreamOwnerNameLabel],
+					},
+				},
+				ClusterName: multicluster.ClusterName(downstreamclient.UpstreamClusterNameFromLabel(labels[downstreamclient.UpstreamOwnerClusterNameLabel])),
+			})
+		}
+		return requests
+	})
+}
@@ -0,0 +1,225 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestNetworkPolicyReconcile(t *testing.T) {
+	network := &networkingv1alpha.Network{
+		ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "network"},
+	}
+
+	validRule := networkingv1alpha.NetworkPolicyIngressRule{
+		Ports: []networkingv1alpha.NetworkPolicyPort{
+			{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(8000)), EndPort: ptr.To[int32](8080)},
+		},
+		From: []networkingv1alpha.NetworkPolicyPeer{
+			{IPBlock: &networkingv1alpha.IPBlock{CIDR: "10.0.0.1/8", Except: []string{"10.1.0.0/16"}}},
+		},
+	}
+	invalidRule := networkingv1alpha.NetworkPolicyIngressRule{
+		From: []networkingv1alpha.NetworkPolicyPeer{
+			{IPBlock: &networkingv1alpha.IPBlock{CIDR: "10.0.0.0/16", Except: []string{"192.168.0.0/24"}}},
+		},
+	}
+
+	wantValidRule := networkingv1.NetworkPolicyIngressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(8000)), EndPort: ptr.To[int32](8080)},
+		},
+		From: []networkingv1.NetworkPolicyPeer{
+			{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		ingress          []networkingv1alpha.NetworkPolicyIngressRule
+		noNetwork        bool
+		noPods           bool
+		wantAccepted     string
+		wantProgrammed   string
+		wantRuleErrors   []networkingv1alpha.NetworkPolicyRuleError
+		wantIngress      []networkingv1.NetworkPolicyIngressRule
+		wantNoDownstream bool
+	}{
+		{
+			name:           "valid rules",
+			ingress:        []networkingv1alpha.NetworkPolicyIngressRule{validRule},
+			wantAccepted:   networkingv1alpha.NetworkPolicyAcceptedReasonAccepted,
+			wantProgrammed: networkingv1alpha.NetworkPolicyProgrammedReasonProgrammed,
+			wantIngress:    []networkingv1.NetworkPolicyIngressRule{wantValidRule},
+		},
+		{
+			name:           "invalid rules are not enforced",
+			ingress:        []networkingv1alpha.NetworkPolicyIngressRule{invalidRule, validRule},
+			wantAccepted:   networkingv1alpha.NetworkPolicyAcceptedReasonInvalidRules,
+			wantProgrammed: networkingv1alpha.NetworkPolicyProgrammedReasonProgrammed,
+			wantRuleErrors: []networkingv1alpha.NetworkPolicyRuleError{
+				{Index: 0, Field: "spec.ingress[0].from[0].ipBlock.except[0]", Message: `Invalid value: "192.168.0.0/24": must be a strict subset of cidr`},
+			},
+			wantIngress: []networkingv1.NetworkPolicyIngressRule{wantValidRule},
+		},
+		{
+			name:           "no rules deny all ingress",
+			wantAccepted:   networkingv1alpha.NetworkPolicyAcceptedReasonAccepted,
+			wantProgrammed: networkingv1alpha.NetworkPolicyProgrammedReasonProgrammed,
+		},
+		{
+			name:           "no selected pods",
+			ingress:        []networkingv1alpha.NetworkPolicyIngressRule{validRule},
+			noPods:         true,
+			wantAccepted:   networkingv1alpha.NetworkPolicyAcceptedReasonAccepted,
+			wantProgrammed: networkingv1alpha.NetworkPolicyProgrammedReasonNoInstances,
+			wantIngress:    []networkingv1.NetworkPolicyIngressRule{wantValidRule},
+		},
+		{
+			name:             "missing network",
+			ingress:          []networkingv1alpha.NetworkPolicyIngressRule{validRule},
+			noNetwork:        true,
+			wantAccepted:     networkingv1alpha.NetworkPolicyAcceptedReasonAccepted,
+			wantProgrammed:   networkingv1alpha.NetworkPolicyProgrammedReasonNetworkNotFound,
+			wantNoDownstream: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			policy := &networkingv1alpha.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "policy", UID: "uid-policy"},
+				Spec: networkingv1alpha.NetworkPolicySpec{
+					NetworkRef: networkingv1alpha.LocalNetworkRef{Name: "network"},
+					Ingress:    tt.ingress,
+				},
+			}
+			objects := []client.Object{policy}
+			if !tt.noNetwork {
+				objects = append(objects, network.DeepCopy())
+			}
+
+			fakeUpstreamClient, fakeDownstreamClient := newLocalPolicyTestClients(t, &networkingv1alpha.NetworkPolicy{}, objects...)
+
+			if !tt.noPods {
+				require.NoError(t, fakeDownstreamClient.Create(ctx, &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: localPolicyTestDownstreamNamespace,
+						Name:      "instance",
+						Labels:    map[string]string{networkingv1alpha.NetworkPolicyNetworkLabel: "network"},
+					},
+				}))
+			}
+
+			reconciler := &NetworkPolicyReconciler{
+				mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+				DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+			}
+
+			req := localPolicyTestRequest(policy.Name)
+			_, err := reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+
+			var updated networkingv1alpha.NetworkPolicy
+			require.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &updated))
+			assert.Contains(t, updated.Finalizers, networkPolicyFinalizer)
+			assert.Equal(t, tt.wantRuleErrors, updated.Status.RuleErrors)
+
+			accepted := apimeta.FindStatusCondition(updated.Status.Conditions, networkingv1alpha.NetworkPolicyAccepted)
+			require.NotNil(t, accepted)
+			assert.Equal(t, tt.wantAccepted, accepted.Reason)
+
+			programmed := apimeta.FindStatusCondition(updated.Status.Conditions, networkingv1alpha.NetworkPolicyProgrammed)
+			require.NotNil(t, programmed)
+			assert.Equal(t, tt.wantProgrammed, programmed.Reason)
+
+			var networkPolicy networkingv1.NetworkPolicy
+			err = fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "policy"}, &networkPolicy)
+			if tt.wantNoDownstream {
+				assert.True(t, apierrors.IsNotFound(err), "expected no downstream network policy, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string]string{networkingv1alpha.NetworkPolicyNetworkLabel: "network"}, networkPolicy.Spec.PodSelector.MatchLabels)
+			assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, networkPolicy.Spec.PolicyTypes)
+			assert.Equal(t, tt.wantIngress, networkPolicy.Spec.Ingress)
+
+			// Deleting the policy removes the downstream network policy.
+			require.NoError(t, fakeUpstreamClient.Delete(ctx, &updated))
+			_, err = reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+
+			err = fakeDownstreamClient.Get(ctx, client.ObjectKeyFromObject(&networkPolicy), &networkingv1.NetworkPolicy{})
+			assert.True(t, apierrors.IsNotFound(err), "expected downstream network policy to be deleted, got %v", err)
+			err = fakeUpstreamClient.Get(ctx, req.NamespacedName, &networkingv1alpha.NetworkPolicy{})
+			assert.True(t, apierrors.IsNotFound(err), "expected network policy to be deleted, got %v", err)
+		})
+	}
+}
+
+func TestEnqueueNetworkPoliciesForDownstreamPod(t *testing.T) {
+	ctx := context.Background()
+
+	policy := &networkingv1alpha.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "policy", UID: "uid-policy"},
+		Spec: networkingv1alpha.NetworkPolicySpec{
+			NetworkRef: networkingv1alpha.LocalNetworkRef{Name: "network"},
+		},
+	}
+	fakeUpstreamClient, fakeDownstreamClient := newLocalPolicyTestClients(t, &networkingv1alpha.NetworkPolicy{},
+		policy,
+		&networkingv1alpha.Network{ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "network"}},
+	)
+
+	reconciler := &NetworkPolicyReconciler{
+		mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+	}
+	req := localPolicyTestRequest(policy.Name)
+	_, err := reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	h := reconciler.enqueueNetworkPoliciesForDownstreamPod("", &fakeCluster{cl: fakeDownstreamClient})
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+	t.Cleanup(queue.ShutDown)
+
+	newPod := func(network string) *metav1.PartialObjectMetadata {
+		pod := &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: localPolicyTestDownstreamNamespace,
+				Name:      "instance",
+				Labels:    map[string]string{networkingv1alpha.NetworkPolicyNetworkLabel: network},
+			},
+		}
+		pod.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+		return pod
+	}
+
+	// Pods of other networks are not selected by the policy.
+	h.Create(ctx, event.TypedCreateEvent[*metav1.PartialObjectMetadata]{Object: newPod("other")}, queue)
+	assert.Equal(t, 0, queue.Len())
+
+	h.Create(ctx, event.TypedCreateEvent[*metav1.PartialObjectMetadata]{Object: newPod("network")}, queue)
+	require.Equal(t, 1, queue.Len())
+	item, _ := queue.Get()
+	queue.Done(item)
+	assert.Equal(t, req, item)
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+	mcsource "sigs.k8s.io/multicluster-runtime/pkg/source"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+const payloadPolicyFinalizer = "networking.datumapis.com/payloadpolicy-cleanup"
+
+// PayloadPolicyReconciler reconciles a PayloadPolicy object
+type PayloadPolicyReconciler struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+
+	DownstreamCluster cluster.Cluster
+
+	// UpstreamOutages, when set, retries reconciles while the API server of
+	// the policy's cluster is unreachable.
+	UpstreamOutages *UpstreamOutageTracker
+}
+
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=payloadpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=payloadpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=payloadpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=backendtrafficpolicies,verbs=get;list;watch;create;update;patch;delete
+
+func (r *PayloadPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	return r.localPolicy().reconciler().reconcile(ctx, r.mgr, r.DownstreamCluster, r.UpstreamOutages, req)
+}
+
+func (r *PayloadPolicyReconciler) localPolicy() *targetedLocalPolicy[*networkingv1alpha.PayloadPolicy, *envoygatewayv1alpha1.BackendTrafficPolicy] {
+	return &targetedLocalPolicy[*networkingv1alpha.PayloadPolicy, *envoygatewayv1alpha1.BackendTrafficPolicy]{
+		name:           "payloadpolicy",
+		kind:           "PayloadPolicy",
+		finalizer:      payloadPolicyFinalizer,
+		controllerName: string(r.Config.Gateway.ControllerName),
+		newPolicy:      func() *networkingv1alpha.PayloadPolicy { return &networkingv1alpha.PayloadPolicy{} },
+		targetRefs: func(policy *networkingv1alpha.PayloadPolicy) []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+			return policy.Spec.TargetRefs
+		},
+		policyStatus: func(policy *networkingv1alpha.PayloadPolicy) *gatewayv1alpha2.PolicyStatus {
+			return &policy.Status.PolicyStatus
+		},
+		listPolicies: listBackendTrafficLocalPolicies,
+		newDownstream: func(policy *networkingv1alpha.PayloadPolicy) *envoygatewayv1alpha1.BackendTrafficPolicy {
+			return &envoygatewayv1alpha1.BackendTrafficPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: downstreamPayloadPolicyName(policy)},
+			}
+		},
+		desiredDownstream: r.desiredPayloadBackendTrafficPolicy,
+	}
+}
+
+// downstreamPayloadPolicyName returns the name of the BackendTrafficPolicy
+// that programs a PayloadPolicy. It is prefixed so that it doesn't collide
+// with BackendTrafficPolicies replicated from the upstream namespace.
+func downstreamPayloadPolicyName(policy *networkingv1alpha.PayloadPolicy) string {
+	return resourcename.GetValidDNS1123Name(fmt.Sprintf("payload-%s", policy.Name))
+}
+
+// desiredPayloadBackendTrafficPolicy programs the compression and request
+// body limit of the policy on the downstream BackendTrafficPolicy attached to
+// the accepted targets. The returned programming error reports a request body
+// limit that exceeds the maximum of the platform, which is programmed instead.
+func (r *PayloadPolicyReconciler) desiredPayloadBackendTrafficPolicy(
+	policy *networkingv1alpha.PayloadPolicy,
+	backendTrafficPolicy *envoygatewayv1alpha1.BackendTrafficPolicy,
+	targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+) string {
+	var programmingErr string
+	var requestBuffer *envoygatewayv1alpha1.RequestBuffer
+	if limit := policy.Spec.RequestBodyLimit; limit != nil {
+		requestBuffer = &envoygatewayv1alpha1.RequestBuffer{Limit: limit.DeepCopy()}
+		// The limit was admitted against the maximum at the time, which may have
+		// been lowered since.
+		maxLimit := r.Config.Gateway.PayloadPolicy.MaxRequestBodyLimit
+		if !maxLimit.IsZero() && limit.Cmp(maxLimit) > 0 {
+			programmingErr = fmt.Sprintf("The request body limit %s exceeds the maximum of %s, which is enforced instead", limit.String(), maxLimit.String())
+			requestBuffer.Limit = maxLimit.DeepCopy()
+		}
+	}
+
+	backendTrafficPolicy.Spec = envoygatewayv1alpha1.BackendTrafficPolicySpec{
+		PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+			TargetRefs: targetRefs,
+		},
+		MergeType:     routePolicyMergeType(targetRefs),
+		Compressor:    desiredCompressors(policy.Spec.Compression, r.Config.Gateway.PayloadPolicy),
+		RequestBuffer: requestBuffer,
+	}
+	return programmingErr
+}
+
+// desiredCompressors translates the compression of a PayloadPolicy to Envoy
+// Gateway compressors, falling back to the defaults of the platform for the
+// encodings and minimum size the policy does not set.
+func desiredCompressors(compression *networkingv1alpha.PayloadCompression, payloadPolicy config.GatewayPayloadPolicyConfig) []*envoygatewayv1alpha1.Compression {
+	if compression == nil {
+		return nil
+	}
+
+	compressors := compression.Compressors
+	if len(compressors) == 0 {
+		compressors = payloadPolicy.DefaultCompressors
+	}
+	minSize := payloadPolicy.DefaultCompressionMinSize
+	if compression.MinSize != nil {
+		minSize = *compression.MinSize
+	}
+
+	desiredCompressors := make([]*envoygatewayv1alpha1.Compression, 0, len(compressors))
+	for _, compressor := range compressors {
+		desiredCompressor := &envoygatewayv1alpha1.Compression{
+			Type: envoygatewayv1alpha1.CompressorType(compressor),
+		}
+		if !minSize.IsZero() {
+			desiredCompressor.MinContentLength = ptr.To(minSize.DeepCopy())
+		}
+		switch compressor {
+		case networkingv1alpha.PayloadCompressorBrotli:
+			desiredCompressor.Brotli = &envoygatewayv1alpha1.BrotliCompressor{}
+		case networkingv1alpha.PayloadCompressorGzip:
+			desiredCompressor.Gzip = &envoygatewayv1alpha1.GzipCompressor{}
+		case networkingv1alpha.PayloadCompressorZstd:
+			desiredCompressor.Zstd = &envoygatewayv1alpha1.ZstdCompressor{}
+		}
+		desiredCompressors = append(desiredCompressors, desiredCompressor)
+	}
+	return desiredCompressors
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PayloadPolicyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+
+	downstreamBackendTrafficPolicySource := mcsource.TypedKind(
+		&envoygatewayv1alpha1.BackendTrafficPolicy{},
+		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*envoygatewayv1alpha1.BackendTrafficPolicy](&networkingv1alpha.PayloadPolicy{}),
+	)
+
+	downstreamBackendTrafficPolicyClusterSource, _, _ := downstreamBackendTrafficPolicySource.ForCluster("", r.DownstreamCluster)
+
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&networkingv1alpha.PayloadPolicy{}).
+		Watches(&gatewayv1.Gateway{}, enqueueLocalPoliciesForTargetFunc(KindGateway, listPayloadPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&gatewayv1.HTTPRoute{}, enqueueLocalPoliciesForTargetFunc(KindHTTPRoute, listPayloadPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.HTTPProxy{}, enqueueLocalPoliciesForTargetFunc(KindHTTPProxy, listPayloadPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.RateLimitPolicy{}, enqueueLocalPoliciesInNamespaceFunc(listPayloadPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		// The targets of BackendLoadBalancingPolicies follow the HTTPRoutes that
+		// reference their backends, which is reflected in their status.
+		Watches(&networkingv1alpha.BackendLoadBalancingPolicy{}, enqueueLocalPoliciesInNamespaceFunc(listPayloadPolicies)).
+		WatchesRawSource(downstreamBackendTrafficPolicyClusterSource).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "payloadpolicy", 0)).
+		Named("payloadpolicy").
+		Complete(r)
+}
+
+func listPayloadPolicies(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error) {
+	var policies networkingv1alpha.PayloadPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing payloadpolicies: %w", err)
+	}
+
+	localPolicies := make([]localPolicy, 0, len(policies.Items))
+	for i := range policies.Items {
+		localPolicies = append(localPolicies, localPolicy{Object: &policies.Items[i], kind: "PayloadPolicy", targetRefs: policies.Items[i].Spec.TargetRefs})
+	}
+	return localPolicies, nil
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestDesiredCompressors(t *testing.T) {
+	payloadPolicy := config.GatewayPayloadPolicyConfig{}
+	config.SetDefaults_GatewayPayloadPolicyConfig(&payloadPolicy)
+
+	assert.Nil(t, desiredCompressors(nil, payloadPolicy))
+
+	assert.Equal(t, []*envoygatewayv1alpha1.Compression{
+		{
+			Type:             envoygatewayv1alpha1.BrotliCompressorType,
+			Brotli:           &envoygatewayv1alpha1.BrotliCompressor{},
+			MinContentLength: ptr.To(resource.MustParse("1Ki")),
+		},
+		{
+			Type:             envoygatewayv1alpha1.GzipCompressorType,
+			Gzip:             &envoygatewayv1alpha1.GzipCompressor{},
+			MinContentLength: ptr.To(resource.MustParse("1Ki")),
+		},
+	}, desiredCompressors(&networkingv1alpha.PayloadCompression{}, payloadPolicy))
+
+	assert.Equal(t, []*envoygatewayv1alpha1.Compression{
+		{
+			Type:             envoygatewayv1alpha1.ZstdCompressorType,
+			Zstd:             &envoygatewayv1alpha1.ZstdCompressor{},
+			MinContentLength: ptr.To(resource.MustParse("256")),
+		},
+	}, desiredCompressors(&networkingv1alpha.PayloadCompression{
+		Compressors: []networkingv1alpha.PayloadCompressor{networkingv1alpha.PayloadCompressorZstd},
+		MinSize:     ptr.To(resource.MustParse("256")),
+	}, payloadPolicy))
+}
+
+func TestPayloadPolicyReconcile(t *testing.T) {
+	policy := &networkingv1alpha.PayloadPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         localPolicyTestNamespace,
+			Name:              "policy",
+			UID:               "policy-uid",
+			CreationTimestamp: metav1.NewTime(time.Now().Truncate(time.Second)),
+			Finalizers:        []string{payloadPolicyFinalizer},
+		},
+		Spec: networkingv1alpha.PayloadPolicySpec{
+			TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+						Group: gatewayv1.GroupName,
+						Kind:  KindHTTPRoute,
+						Name:  "route",
+					},
+				},
+			},
+			Compression:      &networkingv1alpha.PayloadCompression{},
+			RequestBodyLimit: ptr.To(resource.MustParse("8Mi")),
+		},
+	}
+
+	fakeUpstreamClient, fakeDownstreamClient := newLocalPolicyTestClients(t, &networkingv1alpha.PayloadPolicy{},
+		newHTTPRoute(localPolicyTestNamespace, "route"),
+		policy,
+	)
+
+	operatorConfig := localPolicyTestConfig
+	config.SetDefaults_GatewayPayloadPolicyConfig(&operatorConfig.Gateway.PayloadPolicy)
+
+	reconciler := &PayloadPolicyReconciler{
+		mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+		Config:            operatorConfig,
+	}
+
+	ctx := context.Background()
+	req := localPolicyTestRequest(policy.Name)
+	reconcileAccepted := func() *metav1.Condition {
+		t.Helper()
+		_, err := reconciler.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		var updated networkingv1alpha.PayloadPolicy
+		require.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &updated))
+		require.Len(t, updated.Status.Ancestors, 1)
+		return apimeta.FindStatusCondition(updated.Status.Ancestors[0].Conditions, string(gatewayv1.PolicyConditionAccepted))
+	}
+
+	accepted := reconcileAccepted()
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+
+	backendTrafficPolicyKey := client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "payload-policy"}
+	var backendTrafficPolicy envoygatewayv1alpha1.BackendTrafficPolicy
+	require.NoError(t, fakeDownstreamClient.Get(ctx, backendTrafficPolicyKey, &backendTrafficPolicy))
+	assert.Equal(t, []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+		{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  KindHTTPRoute,
+				Name:  "route",
+			},
+		},
+	}, backendTrafficPolicy.Spec.TargetRefs)
+	assert.Equal(t, ptr.To(envoygatewayv1alpha1.StrategicMerge), backendTrafficPolicy.Spec.MergeType)
+	if assert.Len(t, backendTrafficPolicy.Spec.Compressor, 2) {
+		assert.Equal(t, envoygatewayv1alpha1.BrotliCompressorType, backendTrafficPolicy.Spec.Compressor[0].Type)
+		assert.Equal(t, envoygatewayv1alpha1.GzipCompressorType, backendTrafficPolicy.Spec.Compressor[1].Type)
+	}
+	if assert.NotNil(t, backendTrafficPolicy.Spec.RequestBuffer) {
+		assert.Equal(t, "8Mi", backendTrafficPolicy.Spec.RequestBuffer.Limit.String())
+	}
+
+	// A limit above a lowered platform maximum is reported, and the maximum is
+	// enforced instead.
+	reconciler.Config.Gateway.PayloadPolicy.MaxRequestBodyLimit = resource.MustParse("1Mi")
+	accepted = reconcileAccepted()
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+	assert.Equal(t, string(gatewayv1.PolicyReasonInvalid), accepted.Reason)
+	assert.Equal(t, "The request body limit 8Mi exceeds the maximum of 1Mi, which is enforced instead", accepted.Message)
+	require.NoError(t, fakeDownstreamClient.Get(ctx, backendTrafficPolicyKey, &backendTrafficPolicy))
+	if assert.NotNil(t, backendTrafficPolicy.Spec.RequestBuffer) {
+		assert.Equal(t, "1Mi", backendTrafficPolicy.Spec.RequestBuffer.Limit.String())
+	}
+
+	reconciler.Config.Gateway.PayloadPolicy.MaxRequestBodyLimit = resource.MustParse("10Mi")
+	accepted = reconcileAccepted()
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+
+	// An older RateLimitPolicy attached to the same route is programmed as a
+	// BackendTrafficPolicy too, so the policy conflicts with it.
+	rateLimitPolicy := &networkingv1alpha.RateLimitPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         localPolicyTestNamespace,
+			Name:              "older",
+			UID:               "older-uid",
+			CreationTimestamp: metav1.NewTime(policy.CreationTimestamp.Add(-time.Hour)),
+		},
+		Spec: networkingv1alpha.RateLimitPolicySpec{
+			TargetRefs: policy.Spec.TargetRefs,
+		},
+	}
+	require.NoError(t, fakeUpstreamClient.Create(ctx, rateLimitPolicy))
+	accepted = reconcileAccepted()
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+	assert.Equal(t, string(gatewayv1.PolicyReasonConflicted), accepted.Reason)
+	assert.Equal(t, "Unable to target HTTPRoute route, RateLimitPolicy older has already attached to it", accepted.Message)
+	err := fakeDownstreamClient.Get(ctx, backendTrafficPolicyKey, &backendTrafficPolicy)
+	assert.True(t, apierrors.IsNotFound(err), "expected the downstream payload policy to be deleted, got %v", err)
+
+	require.NoError(t, fakeUpstreamClient.Delete(ctx, rateLimitPolicy))
+	accepted = reconcileAccepted()
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+
+	// Removing the targets removes the downstream policy.
+	var updated networkingv1alpha.PayloadPolicy
+	require.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &updated))
+	updated.Spec.TargetRefs[0].Name = "missing"
+	require.NoError(t, fakeUpstreamClient.Update(ctx, &updated))
+	_, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+	err = fakeDownstreamClient.Get(ctx, backendTrafficPolicyKey, &backendTrafficPolicy)
+	assert.True(t, apierrors.IsNotFound(err), "expected the downstream payload policy to be deleted, got %v", err)
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// ignoreStatusOnlyUpdates passes updates that change the generation, labels or
+// annotations of an object, and drops updates that only change its status. It
+// is meant for watches whose map functions and reconcilers only read the spec
+// and metadata of the watched objects.
+func ignoreStatusOnlyUpdates[T client.Object]() predicate.TypedPredicate[T] {
+	return predicate.Or[T](
+		predicate.TypedGenerationChangedPredicate[T]{},
+		predicate.TypedLabelChangedPredicate[T]{},
+		predicate.TypedAnnotationChangedPredicate[T]{},
+	)
+}
+
+// downstreamSpecOrStatusChanged passes updates of downstream resources that
+// change their generation, labels or status. The status of downstream
+// resources is mirrored upstream, so it must be watched, while their
+// annotations are written by the operator itself, for instance the
+// downstreamclient.ObservedGenerationAnnotation recorded after every write, and
+// updates that only change them would reconcile the upstream resource again
+// for nothing.
+func downstreamSpecOrStatusChanged[T client.Object]() predicate.TypedPredicate[T] {
+	return predicate.Or[T](
+		predicate.TypedGenerationChangedPredicate[T]{},
+		predicate.TypedLabelChangedPredicate[T]{},
+		predicate.TypedFuncs[T]{
+			UpdateFunc: func(e event.TypedUpdateEvent[T]) bool {
+				return !equality.Semantic.DeepEqual(objectStatus(e.ObjectOld), objectStatus(e.ObjectNew))
+			},
+		},
+	)
+}
+
+// objectStatus returns the status of the downstream resources watched with
+// downstreamSpecOrStatusChanged. Other objects are returned whole, so every
+// update of them is passed.
+func objectStatus(obj client.Object) any {
+	switch o := obj.(type) {
+	case *gatewayv1.Gateway:
+		return o.Status
+	case *gatewayv1.HTTPRoute:
+		return o.Status
+	case *gatewayv1.GRPCRoute:
+		return o.Status
+	case *gatewayv1alpha2.TCPRoute:
+		return o.Status
+	case *gatewayv1alpha2.UDPRoute:
+		return o.Status
+	case *cmv1.Certificate:
+		return o.Status
+	default:
+		return obj
+	}
+}
+
+// endpointSliceChangedPredicate drops EndpointSlice updates that do not change
+// its address type, endpoints or ports, such as the updates of its managed
+// fields and labels that do not change what traffic is routed to.
+var endpointSliceChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldSlice, oldOK := e.ObjectOld.(*discoveryv1.EndpointSlice)
+		newSlice, newOK := e.ObjectNew.(*discoveryv1.EndpointSlice)
+		if !oldOK || !newOK {
+			return true
+		}
+		return oldSlice.AddressType != newSlice.AddressType ||
+			!equality.Semantic.DeepEqual(oldSlice.Endpoints, newSlice.Endpoints) ||
+			!equality.Semantic.DeepEqual(oldSlice.Ports, newSlice.Ports)
+	},
+}
+
+// domainVerificationChangedPredicate drops Domain updates that change neither
+// the status nor reason of its Verified condition, nor whether it is an apex
+// domain, which is all gateways read from a Domain. The status of a Domain is
+// otherwise updated on every verification attempt and registration refresh.
+var domainVerificationChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldDomain, oldOK := e.ObjectOld.(*networkingv1alpha.Domain)
+		newDomain, newOK := e.ObjectNew.(*networkingv1alpha.Domain)
+		if !oldOK || !newOK {
+			return true
+		}
+		if oldDomain.Status.Apex != newDomain.Status.Apex {
+			return true
+		}
+
+		oldVerified := apimeta.FindStatusCondition(oldDomain.Status.Conditions, networkingv1alpha.DomainConditionVerified)
+		newVerified := apimeta.FindStatusCondition(newDomain.Status.Conditions, networkingv1alpha.DomainConditionVerified)
+		if oldVerified == nil || newVerified == nil {
+			return oldVerified != newVerified
+		}
+		return oldVerified.Status != newVerified.Status || oldVerified.Reason != newVerified.Reason
+	},
+}
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+func TestIgnoreStatusOnlyUpdates(t *testing.T) {
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Generation: 1}}
+
+	tests := map[string]struct {
+		mutate func(*gatewayv1.HTTPRoute)
+		want   bool
+	}{
+		"status only": {
+			mutate: func(r *gatewayv1.HTTPRoute) {
+				r.Status.Parents = []gatewayv1.RouteParentStatus{{ControllerName: "example.com/controller"}}
+			},
+		},
+		"generation": {
+			mutate: func(r *gatewayv1.HTTPRoute) { r.Generation++ },
+			want:   true,
+		},
+		"labels": {
+			mutate: func(r *gatewayv1.HTTPRoute) { r.Labels = map[string]string{"a": "b"} },
+			want:   true,
+		},
+		"annotations": {
+			mutate: func(r *gatewayv1.HTTPRoute) { r.Annotations = map[string]string{"a": "b"} },
+			want:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			updated := route.DeepCopy()
+			tt.mutate(updated)
+			got := ignoreStatusOnlyUpdates[client.Object]().Update(event.UpdateEvent{ObjectOld: route, ObjectNew: updated})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDownstreamSpecOrStatusChanged(t *testing.T) {
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Generation: 1}}
+
+	tests := map[string]struct {
+		mutate func(*gatewayv1.HTTPRoute)
+		want   bool
+	}{
+		"operator annotations only": {
+			mutate: func(r *gatewayv1.HTTPRoute) {
+				r.Annotations = map[string]string{downstreamclient.ObservedGenerationAnnotation: "1"}
+			},
+		},
+		"resource version only": {
+			mutate: func(r *gatewayv1.HTTPRoute) { r.ResourceVersion = "2" },
+		},
+		"status": {
+			mutate: func(r *gatewayv1.HTTPRoute) {
+				r.Status.Parents = []gatewayv1.RouteParentStatus{{ControllerName: "example.com/controller"}}
+			},
+			want: true,
+		},
+		"generation": {
+			mutate: func(r *gatewayv1.HTTPRoute) { r.Generation++ },
+			want:   true,
+		},
+		"labels": {
+			mutate: func(r *gatewayv1.HTTPRoute) { r.Labels = map[string]string{"a": "b"} },
+			want:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			updated := route.DeepCopy()
+			tt.mutate(updated)
+			got := downstreamSpecOrStatusChanged[*gatewayv1.HTTPRoute]().Update(event.TypedUpdateEvent[*gatewayv1.HTTPRoute]{ObjectOld: route, ObjectNew: updated})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEndpointSliceChangedPredicate(t *testing.T) {
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta:  metav1.ObjectMeta{Name: "backend"},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}}},
+		Ports:       []discoveryv1.EndpointPort{{Port: ptr.To[int32](8080)}},
+	}
+
+	tests := map[string]struct {
+		mutate func(*discoveryv1.EndpointSlice)
+		want   bool
+	}{
+		"metadata only": {
+			mutate: func(s *discoveryv1.EndpointSlice) {
+				s.ResourceVersion = "2"
+				s.Labels = map[string]string{"a": "b"}
+			},
+		},
+		"endpoints": {
+			mutate: func(s *discoveryv1.EndpointSlice) { s.Endpoints[0].Addresses = []string{"10.0.0.2"} },
+			want:   true,
+		},
+		"endpoint readiness": {
+			mutate: func(s *discoveryv1.EndpointSlice) {
+				s.Endpoints[0].Conditions.Ready = ptr.To(false)
+			},
+			want: true,
+		},
+		"ports": {
+			mutate: func(s *discoveryv1.EndpointSlice) { s.Ports[0].Port = ptr.To[int32](9090) },
+			want:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			updated := endpointSlice.DeepCopy()
+			tt.mutate(updated)
+			assert.Equal(t, tt.want, endpointSliceChangedPredicate.Update(event.UpdateEvent{ObjectOld: endpointSlice, ObjectNew: updated}))
+		})
+	}
+}
+
+func TestDomainVerificationChangedPredicate(t *testing.T) {
+	domain := &networkingv1alpha.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+		Status: networkingv1alpha.DomainStatus{
+			Conditions: []metav1.Condition{{
+				Type:   networkingv1alpha.DomainConditionVerified,
+				Status: metav1.ConditionFalse,
+				Reason: networkingv1alpha.DomainReasonPendingVerification,
+			}},
+		},
+	}
+
+	tests := map[string]struct {
+		mutate func(*networkingv1alpha.Domain)
+		want   bool
+	}{
+		"verification attempt": {
+			mutate: func(d *networkingv1alpha.Domain) {
+				d.Status.Verification = &networkingv1alpha.DomainVerificationStatus{NextVerificationAttempt: metav1.Now()}
+				d.Status.Conditions[0].Message = "still waiting"
+			},
+		},
+		"consumers": {
+			mutate: func(d *networkingv1alpha.Domain) {
+				d.Status.Consumers = []networkingv1alpha.DomainConsumer{{Kind: KindGateway, Name: "gateway"}}
+			},
+		},
+		"verified": {
+			mutate: func(d *networkingv1alpha.Domain) {
+				d.Status.Conditions[0].Status = metav1.ConditionTrue
+				d.Status.Conditions[0].Reason = networkingv1alpha.DomainReasonVerified
+			},
+			want: true,
+		},
+		"verification expired": {
+			mutate: func(d *networkingv1alpha.Domain) {
+				d.Status.Conditions[0].Reason = networkingv1alpha.DomainReasonVerificationExpired
+			},
+			want: true,
+		},
+		"verified condition removed": {
+			mutate: func(d *networkingv1alpha.Domain) { d.Status.Conditions = nil },
+			want:   true,
+		},
+		"apex": {
+			mutate: func(d *networkingv1alpha.Domain) { d.Status.Apex = true },
+			want:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			updated := domain.DeepCopy()
+			tt.mutate(updated)
+			assert.Equal(t, tt.want, domainVerificationChangedPredicate.Update(event.UpdateEvent{ObjectOld: domain, ObjectNew: updated}))
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+	mcsource "sigs.k8s.io/multicluster-runtime/pkg/source"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	downstreamclient "go.datum.net/network-services-operator/internal/downstreamclient"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+const rateLimitPolicyFinalizer = "networking.datumapis.com/ratelimitpolicy-cleanup"
+
+// RateLimitPolicyReconciler reconciles a RateLimitPolicy object
+type RateLimitPolicyReconciler struct {
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
+
+	DownstreamCluster cluster.Cluster
+
+	// UpstreamOutages, when set, retries reconciles while the API server of
+	// the policy's cluster is unreachable.
+	UpstreamOutages *UpstreamOutageTracker
+}
+
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ratelimitpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ratelimitpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ratelimitpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=backendtrafficpolicies,verbs=get;list;watch;create;update;patch;delete
+
+func (r *RateLimitPolicyReconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	return r.localPolicy().reconciler().reconcile(ctx, r.mgr, r.DownstreamCluster, r.UpstreamOutages, req)
+}
+
+func (r *RateLimitPolicyReconciler) localPolicy() *targetedLocalPolicy[*networkingv1alpha.RateLimitPolicy, *envoygatewayv1alpha1.BackendTrafficPolicy] {
+	return &targetedLocalPolicy[*networkingv1alpha.RateLimitPolicy, *envoygatewayv1alpha1.BackendTrafficPolicy]{
+		name:           "ratelimitpolicy",
+		kind:           "RateLimitPolicy",
+		finalizer:      rateLimitPolicyFinalizer,
+		controllerName: string(r.Config.Gateway.ControllerName),
+		newPolicy:      func() *networkingv1alpha.RateLimitPolicy { return &networkingv1alpha.RateLimitPolicy{} },
+		targetRefs: func(policy *networkingv1alpha.RateLimitPolicy) []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+			return policy.Spec.TargetRefs
+		},
+		policyStatus: func(policy *networkingv1alpha.RateLimitPolicy) *gatewayv1alpha2.PolicyStatus {
+			return &policy.Status.PolicyStatus
+		},
+		listPolicies: listBackendTrafficLocalPolicies,
+		newDownstream: func(policy *networkingv1alpha.RateLimitPolicy) *envoygatewayv1alpha1.BackendTrafficPolicy {
+			return &envoygatewayv1alpha1.BackendTrafficPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: downstreamRateLimitPolicyName(policy)},
+			}
+		},
+		desiredDownstream: desiredRateLimitBackendTrafficPolicy,
+	}
+}
+
+// downstreamRateLimitPolicyName returns the name of the BackendTrafficPolicy
+// that programs a RateLimitPolicy. It is prefixed so that it doesn't collide
+// with BackendTrafficPolicies replicated from the upstream namespace.
+func downstreamRateLimitPolicyName(policy *networkingv1alpha.RateLimitPolicy) string {
+	return resourcename.GetValidDNS1123Name(fmt.Sprintf("ratelimit-%s", policy.Name))
+}
+
+// desiredRateLimitBackendTrafficPolicy programs the rate limits of the policy
+// on the downstream BackendTrafficPolicy attached to the accepted targets.
+func desiredRateLimitBackendTrafficPolicy(
+	policy *networkingv1alpha.RateLimitPolicy,
+	backendTrafficPolicy *envoygatewayv1alpha1.BackendTrafficPolicy,
+	targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+) string {
+	backendTrafficPolicy.Spec = envoygatewayv1alpha1.BackendTrafficPolicySpec{
+		PolicyTargetReferences: envoygatewayv1alpha1.PolicyTargetReferences{
+			TargetRefs: targetRefs,
+		},
+		MergeType: routePolicyMergeType(targetRefs),
+		RateLimit: &envoygatewayv1alpha1.RateLimitSpec{
+			Type: ptr.To(envoygatewayv1alpha1.GlobalRateLimitType),
+			Global: &envoygatewayv1alpha1.GlobalRateLimit{
+				Rules: desiredRateLimitRules(policy.Spec.Rules),
+			},
+		},
+	}
+	return ""
+}
+
+// desiredRateLimitRules translates the rules of a RateLimitPolicy to Envoy
+// Gateway rate limit rules.
+func desiredRateLimitRules(rules []networkingv1alpha.RateLimitRule) []envoygatewayv1alpha1.RateLimitRule {
+	desiredRules := make([]envoygatewayv1alpha1.RateLimitRule, 0, len(rules))
+	for _, rule := range rules {
+		desiredRule := envoygatewayv1alpha1.RateLimitRule{
+			Limit: envoygatewayv1alpha1.RateLimitValue{
+				Requests: rule.Limit.Requests,
+				Unit:     envoygatewayv1alpha1.RateLimitUnit(rule.Limit.Unit),
+			},
+		}
+
+		for _, selector := range rule.ClientSelectors {
+			var condition envoygatewayv1alpha1.RateLimitSelectCondition
+			for _, header := range selector.Headers {
+				matchType := envoygatewayv1alpha1.HeaderMatchExact
+				if header.Type == networkingv1alpha.RateLimitMatchDistinct {
+					matchType = envoygatewayv1alpha1.HeaderMatchDistinct
+				}
+				condition.Headers = append(condition.Headers, envoygatewayv1alpha1.HeaderMatch{
+					Type:  ptr.To(matchType),
+					Name:  header.Name,
+					Value: header.Value,
+				})
+			}
+			if sourceCIDR := selector.SourceCIDR; sourceCIDR != nil {
+				matchType := envoygatewayv1alpha1.SourceMatchExact
+				if sourceCIDR.Type == networkingv1alpha.RateLimitMatchDistinct {
+					matchType = envoygatewayv1alpha1.SourceMatchDistinct
+				}
+				condition.SourceCIDR = &envoygatewayv1alpha1.SourceMatch{
+					Type:  ptr.To(matchType),
+					Value: sourceCIDR.Value,
+				}
+			}
+			desiredRule.ClientSelectors = append(desiredRule.ClientSelectors, condition)
+		}
+
+		desiredRules = append(desiredRules, desiredRule)
+	}
+	return desiredRules
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RateLimitPolicyReconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	r.mgr = mgr
+
+	downstreamBackendTrafficPolicySource := mcsource.TypedKind(
+		&envoygatewayv1alpha1.BackendTrafficPolicy{},
+		downstreamclient.TypedEnqueueRequestForUpstreamOwner[*envoygatewayv1alpha1.BackendTrafficPolicy](&networkingv1alpha.RateLimitPolicy{}),
+	)
+
+	downstreamBackendTrafficPolicyClusterSource, _, _ := downstreamBackendTrafficPolicySource.ForCluster("", r.DownstreamCluster)
+
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&networkingv1alpha.RateLimitPolicy{}).
+		Watches(&gatewayv1.Gateway{}, enqueueLocalPoliciesForTargetFunc(KindGateway, listRateLimitPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&gatewayv1.HTTPRoute{}, enqueueLocalPoliciesForTargetFunc(KindHTTPRoute, listRateLimitPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.HTTPProxy{}, enqueueLocalPoliciesForTargetFunc(KindHTTPProxy, listRateLimitPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		Watches(&networkingv1alpha.PayloadPolicy{}, enqueueLocalPoliciesInNamespaceFunc(listRateLimitPolicies), mcbuilder.WithPredicates(ignoreStatusOnlyUpdates[client.Object]())).
+		// The targets of BackendLoadBalancingPolicies follow the HTTPRoutes that
+		// reference their backends, which is reflected in their status.
+		Watches(&networkingv1alpha.BackendLoadBalancingPolicy{}, enqueueLocalPoliciesInNamespaceFunc(listRateLimitPolicies)).
+		WatchesRawSource(downstreamBackendTrafficPolicyClusterSource).
+		WithOptions(controllerOptions[mcreconcile.Request](r.Config, "ratelimitpolicy", 0)).
+		Named("ratelimitpolicy").
+		Complete(r)
+}
+
+func listRateLimitPolicies(ctx context.Context, c client.Client, namespace string) ([]localPolicy, error) {
+	var policies networkingv1alpha.RateLimitPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing ratelimitpolicies: %w", err)
+	}
+
+	localPolicies := make([]localPolicy, 0, len(policies.Items))
+	for i := range policies.Items {
+		localPolicies = append(localPolicies, localPolicy{Object: &policies.Items[i], kind: "RateLimitPolicy", targetRefs: policies.Items[i].Spec.TargetRefs})
+	}
+	return localPolicies, nil
+}
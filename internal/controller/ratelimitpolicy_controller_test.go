@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestRateLimitPolicyReconcile(t *testing.T) {
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "gw"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}},
+		},
+	}
+	httpProxy := &networkingv1alpha.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: localPolicyTestNamespace, Name: "proxy"},
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Rules: []networkingv1alpha.HTTPProxyRule{{Name: ptr.To(gatewayv1.SectionName("api"))}},
+		},
+	}
+	loadBalancedHTTPProxy := httpProxy.DeepCopy()
+	loadBalancedHTTPProxy.Spec.Rules[0].Backends = []networkingv1alpha.HTTPProxyRuleBackend{
+		{
+			Endpoint:     "https://example.com",
+			LoadBalancer: &networkingv1alpha.HTTPProxyBackendLoadBalancer{Type: networkingv1alpha.HTTPProxyBackendLoadBalancerLeastRequest},
+		},
+	}
+
+	targetRef := func(group gatewayv1.Group, kind gatewayv1.Kind, name string, sectionName *gatewayv1.SectionName) gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+		return gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: group,
+				Kind:  kind,
+				Name:  gatewayv1.ObjectName(name),
+			},
+			SectionName: sectionName,
+		}
+	}
+	gatewayTarget := targetRef(gatewayv1.GroupName, KindGateway, "gw", ptr.To(gatewayv1.SectionName("http")))
+	proxyTarget := targetRef(gatewayv1.Group(networkingv1alpha.GroupVersion.Group), KindHTTPProxy, "proxy", ptr.To(gatewayv1.SectionName("api")))
+	missingTarget := targetRef(gatewayv1.GroupName, KindHTTPRoute, "missing", nil)
+
+	rules := []networkingv1alpha.RateLimitRule{
+		{
+			ClientSelectors: []networkingv1alpha.RateLimitClientSelector{
+				{
+					Headers: []networkingv1alpha.RateLimitHeaderMatch{
+						{Type: networkingv1alpha.RateLimitMatchDistinct, Name: "x-user-id"},
+					},
+					SourceCIDR: &networkingv1alpha.RateLimitSourceCIDRMatch{
+						Type:  networkingv1alpha.RateLimitMatchExact,
+						Value: "0.0.0.0/0",
+					},
+				},
+			},
+			Limit: networkingv1alpha.RateLimitValue{Requests: 10, Unit: networkingv1alpha.RateLimitUnitMinute},
+		},
+	}
+
+	newPolicy := func(name string, created time.Time, targetRefs ...gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName) *networkingv1alpha.RateLimitPolicy {
+		return &networkingv1alpha.RateLimitPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         localPolicyTestNamespace,
+				Name:              name,
+				UID:               types.UID("uid-" + name),
+				CreationTimestamp: metav1.NewTime(created),
+				Finalizers:        []string{rateLimitPolicyFinalizer},
+			},
+			Spec: networkingv1alpha.RateLimitPolicySpec{
+				TargetRefs: targetRefs,
+				Rules:      rules,
+			},
+		}
+	}
+
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name             string
+		httpProxy        *networkingv1alpha.HTTPProxy
+		policies         []*networkingv1alpha.RateLimitPolicy
+		wantTargetRefs   []gatewayv1.LocalPolicyTargetReferenceWithSectionName
+		wantMergeType    *envoygatewayv1alpha1.MergeType
+		wantAncestors    map[gatewayv1.ObjectName]gatewayv1.PolicyConditionReason
+		wantNoDownstream bool
+	}{
+		{
+			name: "gateway listener and httpproxy rule",
+			policies: []*networkingv1alpha.RateLimitPolicy{
+				newPolicy("policy", now, gatewayTarget, proxyTarget),
+			},
+			wantTargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Group: gatewayv1.GroupName, Kind: KindHTTPRoute, Name: "proxy"},
+					SectionName:                ptr.To(gatewayv1.SectionName("api")),
+				},
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Group: gatewayv1.GroupName, Kind: KindGateway, Name: "gw"},
+					SectionName:                ptr.To(gatewayv1.SectionName("http")),
+				},
+			},
+			wantMergeType: ptr.To(envoygatewayv1alpha1.StrategicMerge),
+			wantAncestors: map[gatewayv1.ObjectName]gatewayv1.PolicyConditionReason{
+				"gw":    gatewayv1.PolicyReasonAccepted,
+				"proxy": gatewayv1.PolicyReasonAccepted,
+			},
+		},
+		{
+			name: "missing target",
+			policies: []*networkingv1alpha.RateLimitPolicy{
+				newPolicy("policy", now, gatewayTarget, missingTarget),
+			},
+			wantTargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Group: gatewayv1.GroupName, Kind: KindGateway, Name: "gw"},
+					SectionName:                ptr.To(gatewayv1.SectionName("http")),
+				},
+			},
+			wantAncestors: map[gatewayv1.ObjectName]gatewayv1.PolicyConditionReason{
+				"gw":      gatewayv1.PolicyReasonAccepted,
+				"missing": gatewayv1.PolicyReasonTargetNotFound,
+			},
+		},
+		{
+			name: "missing section name",
+			policies: []*networkingv1alpha.RateLimitPolicy{
+				newPolicy("policy", now, targetRef(gatewayv1.GroupName, KindGateway, "gw", ptr.To(gatewayv1.SectionName("https")))),
+			},
+			wantAncestors: map[gatewayv1.ObjectName]gatewayv1.PolicyConditionReason{
+				"gw": gatewayv1.PolicyReasonTargetNotFound,
+			},
+			wantNoDownstream: true,
+		},
+		{
+			name: "conflict with older policy",
+			policies: []*networkingv1alpha.RateLimitPolicy{
+				newPolicy("policy", now, gatewayTarget),
+				newPolicy("older", now.Add(-time.Hour), gatewayTarget),
+			},
+			wantAncestors: map[gatewayv1.ObjectName]gatewayv1.PolicyConditionReason{
+				"gw": gatewayv1.PolicyReasonConflicted,
+			},
+			wantNoDownstream: true,
+		},
+		{
+			name: "conflict with older policy for the whole gateway",
+			policies: []*networkingv1alpha.RateLimitPolicy{
+				newPolicy("policy", now, gatewayTarget),
+				newPolicy("older", now.Add(-time.Hour), targetRef(gatewayv1.GroupName, KindGateway, "gw", nil)),
+			},
+			wantAncestors: map[gatewayv1.ObjectName]gatewayv1.PolicyConditionReason{
+				"gw": gatewayv1.PolicyReasonConflicted,
+			},
+			wantNoDownstream: true,
+		},
+		{
+			name:      "conflict with load balanced httpproxy rule",
+			httpProxy: loadBalancedHTTPProxy,
+			policies: []*networkingv1alpha.RateLimitPolicy{
+				newPolicy("policy", now, gatewayTarget, targetRef(gatewayv1.GroupName, KindHTTPRoute, "proxy", nil)),
+			},
+			wantTargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Group: gatewayv1.GroupName, Kind: KindGateway, Name: "gw"},
+					SectionName:                ptr.To(gatewayv1.SectionName("http")),
+				},
+			},
+			wantAncestors: map[gatewayv1.ObjectName]gatewayv1.PolicyConditionReason{
+				"gw":    gatewayv1.PolicyReasonAccepted,
+				"proxy": gatewayv1.PolicyReasonConflicted,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			objects := []client.Object{gateway.DeepCopy()}
+			if tt.httpProxy != nil {
+				objects = append(objects, tt.httpProxy.DeepCopy())
+			} else {
+				objects = append(objects, httpProxy.DeepCopy())
+			}
+			for _, policy := range tt.policies {
+				objects = append(objects, policy)
+			}
+
+			fakeUpstreamClient, fakeDownstreamClient := newLocalPolicyTestClients(t, &networkingv1alpha.RateLimitPolicy{}, objects...)
+
+			reconciler := &RateLimitPolicyReconciler{
+				mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+				DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+				Config:            localPolicyTestConfig,
+			}
+
+			req := localPolicyTestRequest("policy")
+			_, err := reconciler.Reconcile(ctx, req)
+			assert.NoError(t, err)
+
+			var policy networkingv1alpha.RateLimitPolicy
+			assert.NoError(t, fakeUpstreamClient.Get(ctx, req.NamespacedName, &policy))
+			assert.Len(t, policy.Status.Ancestors, len(tt.wantAncestors))
+			for _, ancestor := range policy.Status.Ancestors {
+				wantReason, ok := tt.wantAncestors[ancestor.AncestorRef.Name]
+				if assert.True(t, ok, "unexpected ancestor %s", ancestor.AncestorRef.Name) {
+					condition := apimeta.FindStatusCondition(ancestor.Conditions, string(gatewayv1.PolicyConditionAccepted))
+					if assert.NotNil(t, condition) {
+						assert.Equal(t, string(wantReason), condition.Reason)
+					}
+				}
+			}
+
+			backendTrafficPolicy := &envoygatewayv1alpha1.BackendTrafficPolicy{}
+			err = fakeDownstreamClient.Get(ctx, client.ObjectKey{Namespace: localPolicyTestDownstreamNamespace, Name: "ratelimit-policy"}, backendTrafficPolicy)
+			if tt.wantNoDownstream {
+				assert.True(t, apierrors.IsNotFound(err), "expected no downstream policy, got %v", err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.ElementsMatch(t, tt.wantTargetRefs, backendTrafficPolicy.Spec.TargetRefs)
+			assert.Equal(t, tt.wantMergeType, backendTrafficPolicy.Spec.MergeType)
+			if assert.NotNil(t, backendTrafficPolicy.Spec.RateLimit) && assert.NotNil(t, backendTrafficPolicy.Spec.RateLimit.Global) {
+				assert.Equal(t, []envoygatewayv1alpha1.RateLimitRule{
+					{
+						ClientSelectors: []envoygatewayv1alpha1.RateLimitSelectCondition{
+							{
+								Headers: []envoygatewayv1alpha1.HeaderMatch{
+									{Type: ptr.To(envoygatewayv1alpha1.HeaderMatchDistinct), Name: "x-user-id"},
+								},
+								SourceCIDR: &envoygatewayv1alpha1.SourceMatch{
+									Type:  ptr.To(envoygatewayv1alpha1.SourceMatchExact),
+									Value: "0.0.0.0/0",
+								},
+							},
+						},
+						Limit: envoygatewayv1alpha1.RateLimitValue{Requests: 10, Unit: envoygatewayv1alpha1.RateLimitUnitMinute},
+					},
+				}, backendTrafficPolicy.Spec.RateLimit.Global.Rules)
+			}
+		})
+	}
+}
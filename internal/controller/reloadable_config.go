@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// reloadableConfig returns the configuration to read config.ReloadableFields
+// from: the live configuration when hot reload is enabled, otherwise the
+// configuration the reconciler was created with.
+func reloadableConfig(static *config.NetworkServicesOperator, live *config.Reloadable) *config.NetworkServicesOperator {
+	if live == nil {
+		return static
+	}
+	return live.Load()
+}
+
+// configReloadEvents returns a channel that receives the live configuration
+// after each reload that changes the fields returned by fields, so that
+// controllers can requeue the objects programmed from them. Reloads are
+// coalesced while an event is pending, as reconcilers read the latest
+// configuration.
+func configReloadEvents[T any](live *config.Reloadable, fields func(*config.NetworkServicesOperator) T) <-chan event.TypedGenericEvent[*config.NetworkServicesOperator] {
+	events := make(chan event.TypedGenericEvent[*config.NetworkServicesOperator], 1)
+
+	// Subscribers are called one at a time.
+	current := fields(live.Load())
+	live.Subscribe(func(cfg *config.NetworkServicesOperator) {
+		next := fields(cfg)
+		if equality.Semantic.DeepEqual(current, next) {
+			return
+		}
+		current = next
+
+		select {
+		case events <- event.TypedGenericEvent[*config.NetworkServicesOperator]{Object: cfg}:
+		default:
+		}
+	})
+	return events
+}
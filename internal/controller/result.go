@@ -3,13 +3,19 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
+// defaultStatusFieldManager is the field manager of status writes of results
+// that do not set one.
+const defaultStatusFieldManager = "network-services-operator"
+
 type Result struct {
 	// Result contains the result of a Reconciler invocation.
 	ctrl.Result
@@ -22,7 +28,23 @@ type Result struct {
 	// Watch to trigger a future reconciliation call.
 	StopProcessing bool
 
-	syncStatus map[client.Object]client.Client
+	// FieldManager is the field manager status writes are made as. Defaults to
+	// the operator name.
+	FieldManager string
+
+	syncStatus map[statusUpdateKey]statusUpdate
+}
+
+// statusUpdateKey identifies an object whose status is written, so that status
+// updates of the same object are coalesced into a single write.
+type statusUpdateKey struct {
+	kind string
+	key  client.ObjectKey
+}
+
+type statusUpdate struct {
+	client client.Client
+	obj    client.Object
 }
 
 func (r *Result) Merge(other Result) Result {
@@ -35,9 +57,12 @@ func (r *Result) Merge(other Result) Result {
 	if other.StopProcessing {
 		r.StopProcessing = true
 	}
+	if r.FieldManager == "" {
+		r.FieldManager = other.FieldManager
+	}
 	if other.syncStatus != nil {
 		if r.syncStatus == nil {
-			r.syncStatus = make(map[client.Object]client.Client)
+			r.syncStatus = make(map[statusUpdateKey]statusUpdate)
 		}
 		for k, v := range other.syncStatus {
 			r.syncStatus[k] = v
@@ -47,22 +72,42 @@ func (r *Result) Merge(other Result) Result {
 	return *r
 }
 
+// AddStatusUpdate schedules the status of the object to be written when the
+// result is completed. Adding the same object several times results in a
+// single write of its latest status.
 func (r *Result) AddStatusUpdate(c client.Client, obj client.Object) {
 	if r.syncStatus == nil {
-		r.syncStatus = make(map[client.Object]client.Client)
+		r.syncStatus = make(map[statusUpdateKey]statusUpdate)
 	}
-	r.syncStatus[obj] = c
+	kind := fmt.Sprintf("%T", obj)
+	if c != nil {
+		if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+			kind = gvk.String()
+		}
+	}
+	r.syncStatus[statusUpdateKey{kind: kind, key: client.ObjectKeyFromObject(obj)}] = statusUpdate{client: c, obj: obj}
 }
 
 func (r Result) ShouldReturn() bool {
 	return r.Err != nil || !r.IsZero() || r.StopProcessing
 }
 
+// Complete writes the scheduled status updates and returns the result of the
+// reconcile.
+//
+// Statuses are written as merge patches against the stored object, and objects
+// whose status is unchanged are not written at all. A status computed from a
+// stale object is not written, and the reconcile is requeued instead.
 func (r Result) Complete(ctx context.Context) (ctrl.Result, error) {
 	if r.syncStatus != nil {
+		fieldManager := r.FieldManager
+		if fieldManager == "" {
+			fieldManager = defaultStatusFieldManager
+		}
+
 		var errs []error
-		for obj, client := range r.syncStatus {
-			if err := client.Status().Update(ctx, obj); err != nil {
+		for _, update := range r.syncStatus {
+			if err := patchStatus(ctx, update.client, update.obj, fieldManager); err != nil {
 				if r.Err == nil && apierrors.IsConflict(err) {
 					r.RequeueAfter = 1 * time.Second
 				} else {
@@ -78,3 +123,32 @@ func (r Result) Complete(ctx context.Context) (ctrl.Result, error) {
 
 	return r.Result, r.Err
 }
+
+// patchStatus writes the status of the object as a merge patch against the
+// stored object. Nothing is written if the status is unchanged or the object
+// no longer exists.
+//
+// The patch is locked to the resource version the status was computed from,
+// so a status computed from a stale object is rejected with a conflict rather
+// than overwriting the changes made since.
+func patchStatus(ctx context.Context, c client.Client, obj client.Object, fieldManager string) error {
+	current, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	current.SetResourceVersion(obj.GetResourceVersion())
+	data, err := client.MergeFrom(current).Data(obj)
+	if err != nil {
+		return fmt.Errorf("failed computing status patch: %w", err)
+	}
+	if string(data) == "{}" {
+		return nil
+	}
+
+	patch := client.MergeFromWithOptions(current, client.MergeFromWithOptimisticLock{})
+	return c.Status().Patch(ctx, obj, patch, client.FieldOwner(fieldManager))
+}
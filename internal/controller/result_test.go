@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestResultCompleteCoalescesStatusUpdates(t *testing.T) {
+	ctx := context.Background()
+	s := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(s))
+
+	gateway := newGateway(config.NetworkServicesOperator{}, "test", "test")
+	var fieldManagers []string
+	upstreamClient := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(gateway).
+		WithStatusSubresource(gateway).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				patchOptions := &client.SubResourcePatchOptions{}
+				patchOptions.ApplyOptions(opts)
+				fieldManagers = append(fieldManagers, patchOptions.FieldManager)
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	getGateway := func() *gatewayv1.Gateway {
+		t.Helper()
+		var gw gatewayv1.Gateway
+		require.NoError(t, upstreamClient.Get(ctx, client.ObjectKeyFromObject(gateway), &gw))
+		return &gw
+	}
+	setProgrammed := func(gw *gatewayv1.Gateway, reason string) {
+		apimeta.SetStatusCondition(&gw.Status.Conditions, metav1.Condition{
+			Type:   string(gatewayv1.GatewayConditionProgrammed),
+			Status: metav1.ConditionFalse,
+			Reason: reason,
+		})
+	}
+
+	// Updates of the same object are written once, with the latest status.
+	first, second := getGateway(), getGateway()
+	setProgrammed(first, "Pending")
+	setProgrammed(second, "Invalid")
+	var result Result
+	result.AddStatusUpdate(upstreamClient, first)
+	other := Result{FieldManager: gatewayStatusFieldManager}
+	other.AddStatusUpdate(upstreamClient, second)
+	result.Merge(other)
+
+	_, err := result.Complete(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{gatewayStatusFieldManager}, fieldManagers)
+	condition := apimeta.FindStatusCondition(getGateway().Status.Conditions, string(gatewayv1.GatewayConditionProgrammed))
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, "Invalid", condition.Reason)
+	}
+
+	// Unchanged statuses are not written, even from a stale object.
+	stale := first
+	setProgrammed(stale, "Invalid")
+	result = Result{}
+	result.AddStatusUpdate(upstreamClient, stale)
+	_, err = result.Complete(ctx)
+	require.NoError(t, err)
+	assert.Len(t, fieldManagers, 1)
+
+	// Statuses computed from a stale object are not written, and the reconcile
+	// is requeued.
+	setProgrammed(stale, "Programmed")
+	result = Result{}
+	result.AddStatusUpdate(upstreamClient, stale)
+	res, err := result.Complete(ctx)
+	require.NoError(t, err)
+	assert.NotZero(t, res.RequeueAfter)
+	condition = apimeta.FindStatusCondition(getGateway().Status.Conditions, string(gatewayv1.GatewayConditionProgrammed))
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, "Invalid", condition.Reason)
+	}
+
+	// Statuses computed from the stored object are written.
+	current := getGateway()
+	setProgrammed(current, "Programmed")
+	result = Result{}
+	result.AddStatusUpdate(upstreamClient, current)
+	res, err = result.Complete(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, res.RequeueAfter)
+	assert.Equal(t, []string{gatewayStatusFieldManager, defaultStatusFieldManager, defaultStatusFieldManager}, fieldManagers)
+	condition = apimeta.FindStatusCondition(getGateway().Status.Conditions, string(gatewayv1.GatewayConditionProgrammed))
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, "Programmed", condition.Reason)
+	}
+}
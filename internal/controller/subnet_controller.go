@@ -4,31 +4,42 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
-	"google.golang.org/protobuf/proto"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
 )
 
 // SubnetReconciler reconciles a Subnet object
 type SubnetReconciler struct {
-	mgr mcmanager.Manager
+	mgr    mcmanager.Manager
+	Config config.NetworkServicesOperator
 }
 
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=subnets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=subnets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=subnets/finalizers,verbs=update
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ippools,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=networking.datumapis.com,resources=ippools/status,verbs=get;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -56,17 +67,46 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req mcreconcile.Reques
 	}
 
 	if !subnet.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&subnet, subnetIPAMFinalizer) {
+			var gracePeriod time.Duration
+			if r.Config.IPAM.ReclaimGracePeriod != nil {
+				gracePeriod = r.Config.IPAM.ReclaimGracePeriod.Duration
+			}
+			if err := releaseSubnetPrefixes(ctx, string(req.ClusterName), cl.GetClient(), &subnet, gracePeriod); err != nil {
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(&subnet, subnetIPAMFinalizer)
+			if err := cl.GetClient().Update(ctx, &subnet); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed removing subnet finalizer: %w", err)
+			}
+		}
 		return ctrl.Result{}, nil
 	}
 
 	logger.Info("reconciling subnet")
 	defer logger.Info("reconcile complete")
 
-	// TODO(jreese) finalizer work
+	if !controllerutil.ContainsFinalizer(&subnet, subnetIPAMFinalizer) {
+		controllerutil.AddFinalizer(&subnet, subnetIPAMFinalizer)
+		if err := cl.GetClient().Update(ctx, &subnet); err != nil {
+			if apierrors.IsConflict(err) {
+				return ctrl.Result{RequeueAfter: retryAfterConflict}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("failed adding subnet finalizer: %w", err)
+		}
+	}
 
+	origConditions := slices.Clone(subnet.Status.Conditions)
+	var result ctrl.Result
 	needsStatusUpdate := false
+	allocatedCondition := metav1.Condition{
+		Type:               networkingv1alpha.SubnetAllocated,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.SubnetAllocatedReasonPrefixAllocated,
+		ObservedGeneration: subnet.Generation,
+		Message:            "Subnet has been allocated a prefix",
+	}
 	if subnet.Status.StartAddress == nil {
-		needsStatusUpdate = true
 		var networkContext networkingv1alpha.NetworkContext
 		networkContextObjectKey := client.ObjectKey{
 			Namespace: subnet.Namespace,
@@ -80,58 +120,25 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req mcreconcile.Reques
 			return ctrl.Result{}, fmt.Errorf("network context is not ready")
 		}
 
-		var location networkingv1alpha.Location
-		locationObjectKey := client.ObjectKey{
-			Namespace: networkContext.Spec.Location.Namespace,
-			Name:      networkContext.Spec.Location.Name,
-		}
-		if err := cl.GetClient().Get(ctx, locationObjectKey, &location); err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed fetching network context location: %w", err)
-		}
-
-		// TODO(jreese) get topology key from well known package
-		cityCode, ok := location.Spec.Topology["topology.datum.net/city-code"]
-		if !ok {
-			return ctrl.Result{}, fmt.Errorf("unable to find topology key: topology.datum.net/city-code")
+		prefixes, err := r.allocateSubnet(ctx, string(req.ClusterName), cl.GetClient(), &subnet)
+		var allocationErr *subnetAllocationError
+		switch {
+		case errors.As(err, &allocationErr):
+			allocatedCondition.Status = metav1.ConditionFalse
+			allocatedCondition.Reason = allocationErr.reason
+			allocatedCondition.Message = allocationErr.message
+			result.RequeueAfter = allocationErr.retryAfter
+		case err != nil:
+			return ctrl.Result{}, err
+		default:
+			needsStatusUpdate = true
+			subnet.Status.Prefixes = prefixes
+			subnet.Status.StartAddress = ptr.To(prefixes[0].StartAddress)
+			subnet.Status.PrefixLength = ptr.To(prefixes[0].PrefixLength)
 		}
-
-		// TODO(jreese) move to proper higher level subnet allocation logic, this is
-		// for the rough POC! Pay attention to the subnet class, etc.
-		//
-		// GCP allocates a /20 per region. Distribution seems to be as new regions
-		// come online, a /20 is allocated, but there appears to be at least a /15
-		// between each region's /20. For example:
-		//
-		// 	europe-west9      10.200.0.0/20
-		// 	us-east5          10.202.0.0/20
-		// 	europe-southwest1 10.204.0.0/20
-		// 	us-south1         10.206.0.0/20
-		// 	me-west1          10.208.0.0/20
-		//
-		// There's a few scenarios where this isn't the case.
-
-		var startAddress string
-		switch cityCode {
-		case "DFW":
-			startAddress = "10.128.0.0"
-		case "DLS":
-			startAddress = "10.130.0.0"
-		case "LHR":
-			startAddress = "10.132.0.0"
-		}
-
-		subnet.Status.StartAddress = proto.String(startAddress)
-		subnet.Status.PrefixLength = proto.Int32(20)
-
 	}
 
-	if apimeta.SetStatusCondition(&subnet.Status.Conditions, metav1.Condition{
-		Type:               networkingv1alpha.SubnetAllocated,
-		Status:             metav1.ConditionTrue,
-		Reason:             "PrefixAllocated",
-		ObservedGeneration: subnet.Generation,
-		Message:            "Subnet has been allocated a prefix",
-	}) {
+	if apimeta.SetStatusCondition(&subnet.Status.Conditions, allocatedCondition) {
 		needsStatusUpdate = true
 	}
 
@@ -160,9 +167,14 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req mcreconcile.Reques
 		if err := cl.GetClient().Status().Update(ctx, &subnet); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed updating subnet status")
 		}
+
+		recorder := eventRecorderFor(cl)
+		recordConditionTransition(recorder, &subnet, "Allocate", origConditions, subnet.Status.Conditions, networkingv1alpha.SubnetAllocated)
+		recordConditionTransition(recorder, &subnet, "Reconcile", origConditions, subnet.Status.Conditions, networkingv1alpha.SubnetReady,
+			networkingv1alpha.SubnetProgrammedReasonNotProgrammed)
 	}
 
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -172,13 +184,46 @@ func (r *SubnetReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 		For(&networkingv1alpha.Subnet{},
 			mcbuilder.WithPredicates(
 				predicate.NewPredicateFuncs(func(object client.Object) bool {
-					// Don't bother processing subnets that are ready
+					// Don't bother processing subnets that are ready, unless their
+					// prefixes need to be released
 					o := object.(*networkingv1alpha.Subnet)
-					return !apimeta.IsStatusConditionTrue(o.Status.Conditions, networkingv1alpha.SubnetReady)
+					return !o.DeletionTimestamp.IsZero() || !apimeta.IsStatusConditionTrue(o.Status.Conditions, networkingv1alpha.SubnetReady)
 				}),
 			),
 			mcbuilder.WithEngageWithLocalCluster(false),
 		).
+		Watches(
+			&networkingv1alpha.IPPool{},
+			func(clusterName multicluster.ClusterName, cl cluster.Cluster) handler.TypedEventHandler[client.Object, mcreconcile.Request] {
+				return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+					logger := log.FromContext(ctx)
+
+					// Subnets that failed to allocate are retried when an IP pool of
+					// their namespace changes, for example after a prefix is released.
+					var subnets networkingv1alpha.SubnetList
+					if err := cl.GetClient().List(ctx, &subnets, client.InNamespace(obj.GetNamespace())); err != nil {
+						logger.Error(err, "failed to list Subnets for IPPool watch", "ipPool", obj.GetName())
+						return nil
+					}
+
+					var requests []mcreconcile.Request
+					for i := range subnets.Items {
+						subnet := &subnets.Items[i]
+						if subnet.Status.StartAddress != nil {
+							continue
+						}
+						requests = append(requests, mcreconcile.Request{
+							ClusterName: clusterName,
+							Request: ctrl.Request{
+								NamespacedName: client.ObjectKeyFromObject(subnet),
+							},
+						})
+					}
+					return requests
+				})
+			},
+			mcbuilder.WithEngageWithLocalCluster(false),
+		).
 		Named("subnet").
 		Complete(r)
 }
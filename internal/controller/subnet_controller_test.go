@@ -0,0 +1,332 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func newTestSubnet(name string, family networkingv1alpha.IPFamily) *networkingv1alpha.Subnet {
+	return &networkingv1alpha.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: networkingv1alpha.SubnetSpec{
+			SubnetClass:    "private",
+			NetworkContext: networkingv1alpha.LocalNetworkContextRef{Name: "context"},
+			IPFamily:       family,
+		},
+	}
+}
+
+func newTestIPPool(family networkingv1alpha.IPFamily, cidrs []string, blockPrefixLength int32) *networkingv1alpha.IPPool {
+	return &networkingv1alpha.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: ipPoolName("private", family)},
+		Spec: networkingv1alpha.IPPoolSpec{
+			SubnetClass:       "private",
+			IPFamily:          family,
+			CIDRs:             cidrs,
+			BlockPrefixLength: blockPrefixLength,
+		},
+	}
+}
+
+func newSubnetTestClient(t *testing.T, objs ...client.Object) client.Client {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(testScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(testScheme))
+
+	networkContext := &networkingv1alpha.NetworkContext{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "context"},
+		Status: networkingv1alpha.NetworkContextStatus{
+			Conditions: []metav1.Condition{{
+				Type:               networkingv1alpha.NetworkContextReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(append(objs, networkContext)...).
+		WithStatusSubresource(&networkingv1alpha.Subnet{}, &networkingv1alpha.SubnetClaim{}, &networkingv1alpha.IPPool{}).
+		Build()
+}
+
+func reconcileTestSubnet(t *testing.T, reconciler *SubnetReconciler, subnet *networkingv1alpha.Subnet) *networkingv1alpha.Subnet {
+	ctx := context.Background()
+	_, err := reconciler.Reconcile(ctx, mcreconcile.Request{
+		Request:     reconcile.Request{NamespacedName: client.ObjectKeyFromObject(subnet)},
+		ClusterName: "single",
+	})
+	require.NoError(t, err)
+
+	cl := reconciler.mgr.(*fakeMockManager).cl
+	var updated networkingv1alpha.Subnet
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(subnet), &updated))
+	return &updated
+}
+
+func TestSubnetReconcileAllocation(t *testing.T) {
+	ipamConfig := config.IPAMConfig{
+		Pools: []config.IPAMPoolConfig{
+			{
+				SubnetClass:         "private",
+				IPFamily:            networkingv1alpha.IPv4Protocol,
+				CIDRs:               []string{"10.128.0.0/16"},
+				BlockPrefixLength:   24,
+				DefaultPrefixLength: ptr.To[int32](20),
+			},
+			{
+				SubnetClass:       "private",
+				IPFamily:          networkingv1alpha.IPv6Protocol,
+				CIDRs:             []string{"fd20::/48"},
+				BlockPrefixLength: 64,
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		subnet       func() *networkingv1alpha.Subnet
+		objects      []client.Object
+		wantReason   string
+		wantPrefixes []string
+	}{
+		{
+			name: "allocates from the platform pool",
+			subnet: func() *networkingv1alpha.Subnet {
+				return newTestSubnet("subnet", networkingv1alpha.IPv4Protocol)
+			},
+			wantReason:   networkingv1alpha.SubnetAllocatedReasonPrefixAllocated,
+			wantPrefixes: []string{"10.128.0.0/20"},
+		},
+		{
+			name: "dual stack",
+			subnet: func() *networkingv1alpha.Subnet {
+				subnet := newTestSubnet("subnet", networkingv1alpha.IPv6Protocol)
+				subnet.Spec.IPFamilyPolicy = networkingv1alpha.IPFamilyPolicyDualStack
+				return subnet
+			},
+			wantReason:   networkingv1alpha.SubnetAllocatedReasonPrefixAllocated,
+			wantPrefixes: []string{"fd20::/64", "10.128.0.0/20"},
+		},
+		{
+			name: "requested prefix",
+			subnet: func() *networkingv1alpha.Subnet {
+				subnet := newTestSubnet("subnet", networkingv1alpha.IPv4Protocol)
+				subnet.Spec.StartAddress = "10.128.64.0"
+				subnet.Spec.PrefixLength = 22
+				return subnet
+			},
+			wantReason:   networkingv1alpha.SubnetAllocatedReasonPrefixAllocated,
+			wantPrefixes: []string{"10.128.64.0/22"},
+		},
+		{
+			name: "requested prefix outside of the pool",
+			subnet: func() *networkingv1alpha.Subnet {
+				subnet := newTestSubnet("subnet", networkingv1alpha.IPv4Protocol)
+				subnet.Spec.StartAddress = "10.129.0.0"
+				return subnet
+			},
+			wantReason: networkingv1alpha.SubnetAllocatedReasonInvalidPrefix,
+		},
+		{
+			name: "tenant pool takes precedence",
+			subnet: func() *networkingv1alpha.Subnet {
+				return newTestSubnet("subnet", networkingv1alpha.IPv4Protocol)
+			},
+			objects: []client.Object{
+				newTestIPPool(networkingv1alpha.IPv4Protocol, []string{"192.168.0.0/24"}, 28),
+			},
+			wantReason:   networkingv1alpha.SubnetAllocatedReasonPrefixAllocated,
+			wantPrefixes: []string{"192.168.0.0/28"},
+		},
+		{
+			name: "no pool for the subnet class",
+			subnet: func() *networkingv1alpha.Subnet {
+				subnet := newTestSubnet("subnet", networkingv1alpha.IPv4Protocol)
+				subnet.Spec.SubnetClass = "public"
+				return subnet
+			},
+			wantReason: networkingv1alpha.SubnetAllocatedReasonNoIPPool,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subnet := tt.subnet()
+			cl := newSubnetTestClient(t, append(tt.objects, subnet)...)
+			reconciler := &SubnetReconciler{
+				mgr:    &fakeMockManager{cl: cl},
+				Config: config.NetworkServicesOperator{IPAM: ipamConfig},
+			}
+
+			updated := reconcileTestSubnet(t, reconciler, subnet)
+			assert.Contains(t, updated.Finalizers, subnetIPAMFinalizer)
+
+			condition := apimeta.FindStatusCondition(updated.Status.Conditions, networkingv1alpha.SubnetAllocated)
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.wantReason, condition.Reason)
+
+			var prefixes []string
+			for _, prefix := range updated.Status.Prefixes {
+				prefixes = append(prefixes, fmt.Sprintf("%s/%d", prefix.StartAddress, prefix.PrefixLength))
+			}
+			assert.Equal(t, tt.wantPrefixes, prefixes)
+			if len(tt.wantPrefixes) == 0 {
+				assert.Nil(t, updated.Status.StartAddress)
+				return
+			}
+			assert.Equal(t, updated.Status.Prefixes[0].StartAddress, ptr.Deref(updated.Status.StartAddress, ""))
+
+			// Each prefix is recorded as an allocation of its pool.
+			for _, prefix := range updated.Status.Prefixes {
+				var pool networkingv1alpha.IPPool
+				require.NoError(t, cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: prefix.IPPool}, &pool))
+				assert.Contains(t, pool.Status.Allocations, networkingv1alpha.IPPoolAllocation{
+					Prefix:    fmt.Sprintf("%s/%d", prefix.StartAddress, prefix.PrefixLength),
+					SubnetRef: &networkingv1alpha.LocalSubnetReference{Name: subnet.Name},
+				})
+				assert.True(t, apimeta.IsStatusConditionTrue(pool.Status.Conditions, networkingv1alpha.IPPoolReady))
+			}
+		})
+	}
+}
+
+func TestSubnetReconcileExhaustionAndRelease(t *testing.T) {
+	ctx := context.Background()
+
+	pool := newTestIPPool(networkingv1alpha.IPv4Protocol, []string{"10.0.0.0/24"}, 25)
+	first := newTestSubnet("first", networkingv1alpha.IPv4Protocol)
+	second := newTestSubnet("second", networkingv1alpha.IPv4Protocol)
+	third := newTestSubnet("third", networkingv1alpha.IPv4Protocol)
+
+	cl := newSubnetTestClient(t, pool, first, second, third)
+	reconciler := &SubnetReconciler{mgr: &fakeMockManager{cl: cl}}
+
+	assert.Equal(t, "10.0.0.0", ptr.Deref(reconcileTestSubnet(t, reconciler, first).Status.StartAddress, ""))
+	assert.Equal(t, "10.0.0.128", ptr.Deref(reconcileTestSubnet(t, reconciler, second).Status.StartAddress, ""))
+
+	updated := reconcileTestSubnet(t, reconciler, third)
+	assert.Nil(t, updated.Status.StartAddress)
+	condition := apimeta.FindStatusCondition(updated.Status.Conditions, networkingv1alpha.SubnetAllocated)
+	require.NotNil(t, condition)
+	assert.Equal(t, networkingv1alpha.SubnetAllocatedReasonPoolExhausted, condition.Reason)
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(pool), pool))
+	assert.True(t, apimeta.IsStatusConditionTrue(pool.Status.Conditions, networkingv1alpha.IPPoolExhausted))
+	require.Len(t, pool.Status.CIDRs, 1)
+	assert.Equal(t, int32(2), pool.Status.CIDRs[0].AllocatedBlocks)
+
+	// Deleting a subnet releases its prefix, which is allocated to the subnet
+	// waiting on the pool.
+	require.NoError(t, cl.Delete(ctx, first))
+	_, err := reconciler.Reconcile(ctx, mcreconcile.Request{
+		Request:     reconcile.Request{NamespacedName: client.ObjectKeyFromObject(first)},
+		ClusterName: "single",
+	})
+	require.NoError(t, err)
+	err = cl.Get(ctx, client.ObjectKeyFromObject(first), &networkingv1alpha.Subnet{})
+	assert.True(t, apierrors.IsNotFound(err), "expected subnet to be deleted, got %v", err)
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(pool), pool))
+	assert.False(t, apimeta.IsStatusConditionTrue(pool.Status.Conditions, networkingv1alpha.IPPoolExhausted))
+	assert.Equal(t, int32(1), pool.Status.CIDRs[0].AllocatedBlocks)
+
+	assert.Equal(t, "10.0.0.0", ptr.Deref(reconcileTestSubnet(t, reconciler, third).Status.StartAddress, ""))
+}
+
+func TestSubnetReconcileReclaimGracePeriod(t *testing.T) {
+	ctx := context.Background()
+
+	pool := newTestIPPool(networkingv1alpha.IPv4Protocol, []string{"10.0.0.0/24"}, 25)
+	first := newTestSubnet("first", networkingv1alpha.IPv4Protocol)
+	second := newTestSubnet("second", networkingv1alpha.IPv4Protocol)
+	third := newTestSubnet("third", networkingv1alpha.IPv4Protocol)
+
+	cl := newSubnetTestClient(t, pool, first, second, third)
+	reconciler := &SubnetReconciler{
+		mgr: &fakeMockManager{cl: cl},
+		Config: config.NetworkServicesOperator{
+			IPAM: config.IPAMConfig{ReclaimGracePeriod: &metav1.Duration{Duration: 10 * time.Minute}},
+		},
+	}
+
+	assert.Equal(t, "10.0.0.0", ptr.Deref(reconcileTestSubnet(t, reconciler, first).Status.StartAddress, ""))
+	assert.Equal(t, "10.0.0.128", ptr.Deref(reconcileTestSubnet(t, reconciler, second).Status.StartAddress, ""))
+
+	// The prefix of a deleted subnet is kept from other subnets during the
+	// grace period, and the waiting subnet is retried once it ends.
+	require.NoError(t, cl.Delete(ctx, first))
+	reconcileTestSubnetDeletion(t, reconciler, first)
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(pool), pool))
+	require.Len(t, pool.Status.Reclaiming, 1)
+	assert.Equal(t, "10.0.0.0/25", pool.Status.Reclaiming[0].Prefix)
+	require.Len(t, pool.Status.Allocations, 1)
+	assert.Equal(t, "second", pool.Status.Allocations[0].SubnetRef.Name)
+	assert.Equal(t, int32(2), pool.Status.CIDRs[0].AllocatedBlocks)
+
+	result, err := reconciler.Reconcile(ctx, mcreconcile.Request{
+		Request:     reconcile.Request{NamespacedName: client.ObjectKeyFromObject(third)},
+		ClusterName: "single",
+	})
+	require.NoError(t, err)
+	assert.Greater(t, result.RequeueAfter, time.Duration(0))
+	assert.LessOrEqual(t, result.RequeueAfter, 10*time.Minute)
+
+	// A subnet recreated with the same name during the grace period is
+	// allocated its prefix again.
+	recreated := newTestSubnet("first", networkingv1alpha.IPv4Protocol)
+	require.NoError(t, cl.Create(ctx, recreated))
+	assert.Equal(t, "10.0.0.0", ptr.Deref(reconcileTestSubnet(t, reconciler, recreated).Status.StartAddress, ""))
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(pool), pool))
+	assert.Empty(t, pool.Status.Reclaiming)
+
+	// Once the grace period ends, the prefix is allocated to other subnets.
+	require.NoError(t, cl.Delete(ctx, recreated))
+	reconcileTestSubnetDeletion(t, reconciler, recreated)
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(pool), pool))
+	require.Len(t, pool.Status.Reclaiming, 1)
+	pool.Status.Reclaiming[0].ReclaimAfter = metav1.NewTime(time.Now().Add(-time.Second))
+	require.NoError(t, cl.Status().Update(ctx, pool))
+
+	assert.Equal(t, "10.0.0.0", ptr.Deref(reconcileTestSubnet(t, reconciler, third).Status.StartAddress, ""))
+
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(pool), pool))
+	assert.Empty(t, pool.Status.Reclaiming)
+	assert.Equal(t, int32(2), pool.Status.CIDRs[0].AllocatedBlocks)
+}
+
+func reconcileTestSubnetDeletion(t *testing.T, reconciler *SubnetReconciler, subnet *networkingv1alpha.Subnet) {
+	ctx := context.Background()
+	_, err := reconciler.Reconcile(ctx, mcreconcile.Request{
+		Request:     reconcile.Request{NamespacedName: client.ObjectKeyFromObject(subnet)},
+		ClusterName: "single",
+	})
+	require.NoError(t, err)
+
+	cl := reconciler.mgr.(*fakeMockManager).cl
+	err = cl.Get(ctx, client.ObjectKeyFromObject(subnet), &networkingv1alpha.Subnet{})
+	assert.True(t, apierrors.IsNotFound(err), "expected subnet to be deleted, got %v", err)
+}
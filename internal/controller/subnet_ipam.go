@@ -0,0 +1,552 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/util/ipam"
+	"go.datum.net/network-services-operator/internal/util/resourcename"
+)
+
+// subnetIPAMFinalizer releases the prefixes of a subnet from its IPPools
+// before the subnet is deleted.
+const subnetIPAMFinalizer = "networking.datumapis.com/subnet-ipam"
+
+// subnetAllocationError is returned when a subnet can't be allocated a
+// prefix, and sets the reason of the Allocated condition of the subnet.
+type subnetAllocationError struct {
+	reason  string
+	message string
+
+	// retryAfter is set when a prefix becomes available once a released prefix
+	// is reclaimed.
+	retryAfter time.Duration
+}
+
+func (e *subnetAllocationError) Error() string {
+	return e.message
+}
+
+// subnetIPFamilies returns the IP families a subnet is allocated prefixes of,
+// starting with the IP family of the subnet.
+func subnetIPFamilies(subnet *networkingv1alpha.Subnet) []networkingv1alpha.IPFamily {
+	families := []networkingv1alpha.IPFamily{subnet.Spec.IPFamily}
+	if subnet.Spec.IPFamilyPolicy == networkingv1alpha.IPFamilyPolicyDualStack {
+		if subnet.Spec.IPFamily == networkingv1alpha.IPv4Protocol {
+			families = append(families, networkingv1alpha.IPv6Protocol)
+		} else {
+			families = append(families, networkingv1alpha.IPv4Protocol)
+		}
+	}
+	return families
+}
+
+// ipPoolName returns the name of the IPPool created for a pool of the
+// platform.
+func ipPoolName(subnetClass string, ipFamily networkingv1alpha.IPFamily) string {
+	return resourcename.GetValidDNS1123Name(strings.ToLower(fmt.Sprintf("%s-%s", subnetClass, ipFamily)))
+}
+
+// allocateSubnet allocates the prefixes of a subnet, one for each of its IP
+// families. The start address and prefix length of the subnet apply to the
+// prefix of its IP family.
+func (r *SubnetReconciler) allocateSubnet(
+	ctx context.Context,
+	clusterName string,
+	cl client.Client,
+	subnet *networkingv1alpha.Subnet,
+) ([]networkingv1alpha.SubnetPrefix, error) {
+	var prefixes []networkingv1alpha.SubnetPrefix
+	for i, family := range subnetIPFamilies(subnet) {
+		pool, err := r.subnetIPPool(ctx, cl, subnet, family)
+		if err != nil {
+			return nil, err
+		}
+
+		var startAddress string
+		var prefixLength int32
+		if i == 0 {
+			startAddress = subnet.Spec.StartAddress
+			prefixLength = subnet.Spec.PrefixLength
+		}
+		prefix, err := allocateSubnetPrefix(ctx, clusterName, cl, pool, subnet, startAddress, prefixLength)
+		if err != nil {
+			return nil, err
+		}
+
+		prefixes = append(prefixes, networkingv1alpha.SubnetPrefix{
+			IPFamily:     family,
+			StartAddress: prefix.Addr().String(),
+			PrefixLength: int32(prefix.Bits()),
+			IPPool:       pool.Name,
+		})
+	}
+	return prefixes, nil
+}
+
+// subnetIPPool returns the IPPool a subnet is allocated a prefix of an IP
+// family from. When the namespace of the subnet has no IPPool of its subnet
+// class and the IP family, the IPPool is created from the pools of the
+// platform.
+func (r *SubnetReconciler) subnetIPPool(
+	ctx context.Context,
+	cl client.Client,
+	subnet *networkingv1alpha.Subnet,
+	family networkingv1alpha.IPFamily,
+) (*networkingv1alpha.IPPool, error) {
+	pool, err := namespaceIPPool(ctx, cl, r.Config.IPAM, subnet.Namespace, subnet.Spec.SubnetClass, family)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, &subnetAllocationError{
+			reason:  networkingv1alpha.SubnetAllocatedReasonNoIPPool,
+			message: fmt.Sprintf("There is no %s IP pool for subnet class %q", family, subnet.Spec.SubnetClass),
+		}
+	}
+	return pool, nil
+}
+
+// namespaceIPPool returns the IPPool of a subnet class and IP family in a
+// namespace, creating it from the pools of the platform when the namespace
+// has none. A nil pool is returned when neither exists.
+func namespaceIPPool(
+	ctx context.Context,
+	cl client.Client,
+	ipamConfig config.IPAMConfig,
+	namespace string,
+	subnetClass string,
+	family networkingv1alpha.IPFamily,
+) (*networkingv1alpha.IPPool, error) {
+	var pools networkingv1alpha.IPPoolList
+	if err := cl.List(ctx, &pools, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing ip pools: %w", err)
+	}
+
+	slices.SortFunc(pools.Items, func(a, b networkingv1alpha.IPPool) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		if pool.Spec.SubnetClass == subnetClass && pool.Spec.IPFamily == family && pool.DeletionTimestamp.IsZero() {
+			return pool, nil
+		}
+	}
+
+	poolConfig := ipamConfig.Pool(subnetClass, family)
+	if poolConfig == nil {
+		return nil, nil
+	}
+
+	pool := &networkingv1alpha.IPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      ipPoolName(poolConfig.SubnetClass, poolConfig.IPFamily),
+		},
+		Spec: networkingv1alpha.IPPoolSpec{
+			SubnetClass:         poolConfig.SubnetClass,
+			IPFamily:            poolConfig.IPFamily,
+			CIDRs:               slices.Clone(poolConfig.CIDRs),
+			BlockPrefixLength:   poolConfig.BlockPrefixLength,
+			DefaultPrefixLength: poolConfig.DefaultPrefixLength,
+		},
+	}
+	if err := cl.Create(ctx, pool); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed creating ip pool: %w", err)
+		}
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(pool), pool); err != nil {
+			return nil, fmt.Errorf("failed fetching ip pool: %w", err)
+		}
+	}
+	return pool, nil
+}
+
+// allocateSubnetPrefix allocates a prefix to a subnet from an IPPool, and
+// persists the allocation in the status of the pool. The status update fails
+// with a conflict when the pool was updated concurrently, so a prefix is never
+// allocated twice. A subnet that holds a prefix of the pool, or released one
+// that is still reclaiming, is returned that prefix.
+func allocateSubnetPrefix(
+	ctx context.Context,
+	clusterName string,
+	cl client.Client,
+	pool *networkingv1alpha.IPPool,
+	subnet *networkingv1alpha.Subnet,
+	startAddress string,
+	prefixLength int32,
+) (netip.Prefix, error) {
+	for _, allocation := range pool.Status.Allocations {
+		if isSubnetIPPoolAllocation(allocation, subnet) {
+			return netip.ParsePrefix(allocation.Prefix)
+		}
+	}
+
+	cidrs, err := restoreIPPoolCIDRs(pool)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	now := time.Now()
+	reclaimed, err := reclaimIPPoolPrefixes(pool, cidrs, func(reclaim networkingv1alpha.IPPoolReclaim) bool {
+		return !reclaim.ReclaimAfter.After(now)
+	})
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	cidrs, allocatable, specErr := ipPoolAllocatableCIDRs(pool, cidrs)
+	if specErr != nil {
+		if setIPPoolReadyCondition(pool, specErr) {
+			if err := cl.Status().Update(ctx, pool); err != nil {
+				return netip.Prefix{}, fmt.Errorf("failed updating ip pool status: %w", err)
+			}
+		}
+		return netip.Prefix{}, &subnetAllocationError{
+			reason:  networkingv1alpha.SubnetAllocatedReasonNoIPPool,
+			message: fmt.Sprintf("IP pool %s is not ready: %v", pool.Name, specErr),
+		}
+	}
+
+	if prefixLength == 0 {
+		prefixLength = ptr.Deref(pool.Spec.DefaultPrefixLength, pool.Spec.BlockPrefixLength)
+	}
+
+	// The prefix released by the subnet is allocated again when it matches the
+	// request of the subnet, and reclaimed otherwise.
+	var prefix netip.Prefix
+	if idx := slices.IndexFunc(pool.Status.Reclaiming, func(reclaim networkingv1alpha.IPPoolReclaim) bool {
+		return reclaim.SubnetRef.Name == subnet.Name
+	}); idx >= 0 {
+		released, err := netip.ParsePrefix(pool.Status.Reclaiming[idx].Prefix)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid reclaiming prefix of ip pool %s: %w", pool.Name, err)
+		}
+		if (startAddress == "" || startAddress == released.Addr().String()) && int(prefixLength) == released.Bits() {
+			prefix = released
+			pool.Status.Reclaiming = slices.Delete(pool.Status.Reclaiming, idx, idx+1)
+		} else {
+			if _, err := reclaimIPPoolPrefixes(pool, cidrs, func(reclaim networkingv1alpha.IPPoolReclaim) bool {
+				return reclaim.SubnetRef.Name == subnet.Name
+			}); err != nil {
+				return netip.Prefix{}, err
+			}
+			reclaimed = true
+		}
+	}
+
+	switch {
+	case prefix.IsValid():
+	case startAddress != "":
+		prefix, err = reserveSubnetPrefix(allocatable, startAddress, prefixLength)
+		if err != nil {
+			return netip.Prefix{}, &subnetAllocationError{
+				reason:  networkingv1alpha.SubnetAllocatedReasonInvalidPrefix,
+				message: fmt.Sprintf("The requested prefix can't be allocated from IP pool %s: %v", pool.Name, err),
+			}
+		}
+	default:
+		err = ipam.ErrExhausted
+		for _, cidr := range allocatable {
+			if prefix, err = cidr.Allocate(int(prefixLength)); !errors.Is(err, ipam.ErrExhausted) {
+				break
+			}
+		}
+		switch {
+		case errors.Is(err, ipam.ErrExhausted):
+			ipPoolExhaustedTotal.WithLabelValues(clusterName, pool.Namespace, pool.Name).Inc()
+			exhaustedChanged := apimeta.SetStatusCondition(&pool.Status.Conditions, metav1.Condition{
+				Type:               networkingv1alpha.IPPoolExhausted,
+				Status:             metav1.ConditionTrue,
+				Reason:             networkingv1alpha.IPPoolExhaustedReasonExhausted,
+				ObservedGeneration: pool.Generation,
+				Message:            fmt.Sprintf("No free /%d prefix for subnet %s", prefixLength, subnet.Name),
+			})
+			if exhaustedChanged || reclaimed {
+				setIPPoolCIDRStatus(pool, cidrs, allocatable)
+				if err := cl.Status().Update(ctx, pool); err != nil {
+					return netip.Prefix{}, fmt.Errorf("failed updating ip pool status: %w", err)
+				}
+				recordIPPoolMetrics(clusterName, pool)
+			}
+			allocationErr := &subnetAllocationError{
+				reason:  networkingv1alpha.SubnetAllocatedReasonPoolExhausted,
+				message: fmt.Sprintf("IP pool %s has no free /%d prefix", pool.Name, prefixLength),
+			}
+			for _, reclaim := range pool.Status.Reclaiming {
+				retryAfter := reclaim.ReclaimAfter.Sub(now)
+				if allocationErr.retryAfter == 0 || retryAfter < allocationErr.retryAfter {
+					allocationErr.retryAfter = retryAfter
+				}
+			}
+			return netip.Prefix{}, allocationErr
+		case err != nil:
+			return netip.Prefix{}, &subnetAllocationError{
+				reason:  networkingv1alpha.SubnetAllocatedReasonInvalidPrefix,
+				message: fmt.Sprintf("The requested prefix can't be allocated from IP pool %s: %v", pool.Name, err),
+			}
+		}
+	}
+
+	pool.Status.Allocations = append(pool.Status.Allocations, networkingv1alpha.IPPoolAllocation{
+		Prefix:    prefix.String(),
+		SubnetRef: &networkingv1alpha.LocalSubnetReference{Name: subnet.Name},
+	})
+	setIPPoolCIDRStatus(pool, cidrs, allocatable)
+	setIPPoolReadyCondition(pool, nil)
+	setIPPoolAvailableCondition(pool)
+	if err := cl.Status().Update(ctx, pool); err != nil {
+		return netip.Prefix{}, fmt.Errorf("failed updating ip pool status: %w", err)
+	}
+	recordIPPoolMetrics(clusterName, pool)
+
+	return prefix, nil
+}
+
+// isSubnetIPPoolAllocation returns whether a prefix of an IPPool is allocated
+// to a subnet.
+func isSubnetIPPoolAllocation(allocation networkingv1alpha.IPPoolAllocation, subnet *networkingv1alpha.Subnet) bool {
+	return allocation.SubnetRef != nil && allocation.SubnetRef.Name == subnet.Name
+}
+
+// reserveSubnetPrefix reserves the prefix of a start address and prefix length
+// from the CIDR that contains it.
+func reserveSubnetPrefix(cidrs []*ipam.Pool, startAddress string, prefixLength int32) (netip.Prefix, error) {
+	addr, err := netip.ParseAddr(startAddress)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid start address: %w", err)
+	}
+	prefix := netip.PrefixFrom(addr, int(prefixLength))
+	if !prefix.IsValid() || prefix != prefix.Masked() {
+		return netip.Prefix{}, fmt.Errorf("%s/%d is not a valid prefix", startAddress, prefixLength)
+	}
+	idx := slices.IndexFunc(cidrs, func(cidr *ipam.Pool) bool {
+		return cidr.Contains(prefix)
+	})
+	if idx < 0 {
+		return netip.Prefix{}, fmt.Errorf("%s is not within the CIDRs of the pool", prefix)
+	}
+	if err := cidrs[idx].Reserve(prefix); err != nil {
+		return netip.Prefix{}, fmt.Errorf("%s: %w", prefix, err)
+	}
+	return prefix, nil
+}
+
+// releaseSubnetPrefixes releases the prefixes allocated to a subnet from the
+// IPPools of its namespace. Released prefixes are reclaimed once the grace
+// period ends.
+func releaseSubnetPrefixes(
+	ctx context.Context,
+	clusterName string,
+	cl client.Client,
+	subnet *networkingv1alpha.Subnet,
+	gracePeriod time.Duration,
+) error {
+	var pools networkingv1alpha.IPPoolList
+	if err := cl.List(ctx, &pools, client.InNamespace(subnet.Namespace)); err != nil {
+		return fmt.Errorf("failed listing ip pools: %w", err)
+	}
+
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		isSubnetAllocation := func(allocation networkingv1alpha.IPPoolAllocation) bool {
+			return isSubnetIPPoolAllocation(allocation, subnet)
+		}
+		if !slices.ContainsFunc(pool.Status.Allocations, isSubnetAllocation) {
+			continue
+		}
+
+		cidrs, err := restoreIPPoolCIDRs(pool)
+		if err != nil {
+			return err
+		}
+		reclaimAfter := metav1.NewTime(time.Now().Add(gracePeriod))
+		for _, allocation := range pool.Status.Allocations {
+			if isSubnetAllocation(allocation) {
+				pool.Status.Reclaiming = append(pool.Status.Reclaiming, networkingv1alpha.IPPoolReclaim{
+					Prefix:       allocation.Prefix,
+					SubnetRef:    *allocation.SubnetRef,
+					ReclaimAfter: reclaimAfter,
+				})
+			}
+		}
+		pool.Status.Allocations = slices.DeleteFunc(pool.Status.Allocations, isSubnetAllocation)
+		reclaimed, err := reclaimIPPoolPrefixes(pool, cidrs, func(reclaim networkingv1alpha.IPPoolReclaim) bool {
+			return !reclaim.ReclaimAfter.After(time.Now())
+		})
+		if err != nil {
+			return err
+		}
+
+		// Prefixes are released from CIDRs removed from the pool even when the
+		// spec of the pool is invalid.
+		cidrs, allocatable, specErr := ipPoolAllocatableCIDRs(pool, cidrs)
+		setIPPoolCIDRStatus(pool, cidrs, allocatable)
+		setIPPoolReadyCondition(pool, specErr)
+		if reclaimed {
+			setIPPoolAvailableCondition(pool)
+		}
+		if err := cl.Status().Update(ctx, pool); err != nil {
+			return fmt.Errorf("failed updating ip pool status: %w", err)
+		}
+		recordIPPoolMetrics(clusterName, pool)
+	}
+	return nil
+}
+
+// reclaimIPPoolPrefixes frees the blocks of the reclaiming prefixes of an
+// IPPool that match, and returns whether any prefix was reclaimed.
+func reclaimIPPoolPrefixes(
+	pool *networkingv1alpha.IPPool,
+	cidrs []*ipam.Pool,
+	match func(networkingv1alpha.IPPoolReclaim) bool,
+) (bool, error) {
+	reclaimed := false
+	for _, reclaim := range pool.Status.Reclaiming {
+		if !match(reclaim) {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(reclaim.Prefix)
+		if err != nil {
+			return false, fmt.Errorf("invalid reclaiming prefix of ip pool %s: %w", pool.Name, err)
+		}
+		for _, cidr := range cidrs {
+			if cidr.Contains(prefix) {
+				if err := cidr.Release(prefix); err != nil {
+					return false, fmt.Errorf("failed releasing %s from ip pool %s: %w", prefix, pool.Name, err)
+				}
+			}
+		}
+		reclaimed = true
+	}
+	pool.Status.Reclaiming = slices.DeleteFunc(pool.Status.Reclaiming, match)
+	return reclaimed, nil
+}
+
+// restoreIPPoolCIDRs restores the CIDRs of an IPPool from the bitmaps in its
+// status.
+func restoreIPPoolCIDRs(pool *networkingv1alpha.IPPool) ([]*ipam.Pool, error) {
+	var cidrs []*ipam.Pool
+	for _, status := range pool.Status.CIDRs {
+		prefix, err := netip.ParsePrefix(status.CIDR)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR in status of ip pool %s: %w", pool.Name, err)
+		}
+		cidr, err := ipam.NewPool(prefix, int(pool.Spec.BlockPrefixLength), status.Bitmap)
+		if err != nil {
+			return nil, fmt.Errorf("failed restoring CIDR %s of ip pool %s: %w", prefix, pool.Name, err)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, nil
+}
+
+// ipPoolAllocatableCIDRs adds the CIDRs of the spec of an IPPool that aren't
+// restored from its status, and returns the CIDRs prefixes are allocated from
+// in the order of the spec. CIDRs removed from the spec are kept while they
+// hold allocations, but nothing is allocated from them.
+func ipPoolAllocatableCIDRs(pool *networkingv1alpha.IPPool, cidrs []*ipam.Pool) ([]*ipam.Pool, []*ipam.Pool, error) {
+	var allocatable []*ipam.Pool
+	for _, c := range pool.Spec.CIDRs {
+		prefix, err := netip.ParsePrefix(c)
+		if err != nil {
+			return cidrs, nil, fmt.Errorf("invalid CIDR %q: %w", c, err)
+		}
+		if prefix.Addr().Is4() != (pool.Spec.IPFamily == networkingv1alpha.IPv4Protocol) {
+			return cidrs, nil, fmt.Errorf("CIDR %s is not an %s CIDR", prefix, pool.Spec.IPFamily)
+		}
+
+		idx := slices.IndexFunc(cidrs, func(cidr *ipam.Pool) bool {
+			return cidr.CIDR() == prefix
+		})
+		if idx >= 0 {
+			allocatable = append(allocatable, cidrs[idx])
+			continue
+		}
+		for _, cidr := range cidrs {
+			if cidr.CIDR().Overlaps(prefix) {
+				return cidrs, nil, fmt.Errorf("CIDR %s overlaps CIDR %s", prefix, cidr.CIDR())
+			}
+		}
+		cidr, err := ipam.NewPool(prefix, int(pool.Spec.BlockPrefixLength), nil)
+		if err != nil {
+			return cidrs, nil, fmt.Errorf("CIDR %s: %w", prefix, err)
+		}
+		cidrs = append(cidrs, cidr)
+		allocatable = append(allocatable, cidr)
+	}
+	return cidrs, allocatable, nil
+}
+
+// setIPPoolCIDRStatus stores the bitmaps of the CIDRs of an IPPool in its
+// status. CIDRs that are no longer allocatable are dropped once they hold no
+// allocations.
+func setIPPoolCIDRStatus(pool *networkingv1alpha.IPPool, cidrs, allocatable []*ipam.Pool) {
+	pool.Status.CIDRs = nil
+	for _, cidr := range cidrs {
+		if cidr.Allocated() == 0 && !slices.Contains(allocatable, cidr) {
+			continue
+		}
+		pool.Status.CIDRs = append(pool.Status.CIDRs, networkingv1alpha.IPPoolCIDRStatus{
+			CIDR:            cidr.CIDR().String(),
+			Bitmap:          cidr.Bitmap(),
+			Blocks:          int32(cidr.Size()),
+			AllocatedBlocks: int32(cidr.Allocated()),
+		})
+	}
+}
+
+// setIPPoolReadyCondition sets the Ready condition of an IPPool from the error
+// of its spec, and returns whether the condition changed.
+func setIPPoolReadyCondition(pool *networkingv1alpha.IPPool, specErr error) bool {
+	condition := metav1.Condition{
+		Type:               networkingv1alpha.IPPoolReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             networkingv1alpha.IPPoolReadyReasonReady,
+		ObservedGeneration: pool.Generation,
+		Message:            "IP pool is ready for allocations",
+	}
+	if specErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = networkingv1alpha.IPPoolReadyReasonInvalidCIDRs
+		condition.Message = specErr.Error()
+	}
+	return apimeta.SetStatusCondition(&pool.Status.Conditions, condition)
+}
+
+// setIPPoolAvailableCondition clears the Exhausted condition of an IPPool
+// after a prefix is allocated or released.
+func setIPPoolAvailableCondition(pool *networkingv1alpha.IPPool) {
+	apimeta.SetStatusCondition(&pool.Status.Conditions, metav1.Condition{
+		Type:               networkingv1alpha.IPPoolExhausted,
+		Status:             metav1.ConditionFalse,
+		Reason:             networkingv1alpha.IPPoolExhaustedReasonAvailable,
+		ObservedGeneration: pool.Generation,
+		Message:            "IP pool has free prefixes",
+	})
+}
+
+// recordIPPoolMetrics records the number of allocated and free blocks of an
+// IPPool.
+func recordIPPoolMetrics(clusterName string, pool *networkingv1alpha.IPPool) {
+	var blocks, allocated int32
+	for _, cidr := range pool.Status.CIDRs {
+		blocks += cidr.Blocks
+		allocated += cidr.AllocatedBlocks
+	}
+	ipPoolBlocks.WithLabelValues(clusterName, pool.Namespace, pool.Name, "allocated").Set(float64(allocated))
+	ipPoolBlocks.WithLabelValues(clusterName, pool.Namespace, pool.Name, "free").Set(float64(blocks - allocated))
+}
@@ -5,10 +5,12 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -22,6 +24,14 @@ import (
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 )
 
+// subnetClaimReleaseFinalizer deletes the subnet of a subnet claim, which
+// returns its prefixes to their IP pools, before the claim is deleted.
+const subnetClaimReleaseFinalizer = "networking.datumapis.com/subnetclaim-release"
+
+// maxSubnetClaimAllocationHistory is the number of allocations kept in the
+// history of a subnet claim.
+const maxSubnetClaimAllocationHistory = 16
+
 // SubnetClaimReconciler reconciles a SubnetClaim object
 type SubnetClaimReconciler struct {
 	mgr mcmanager.Manager
@@ -57,21 +67,32 @@ func (r *SubnetClaimReconciler) Reconcile(ctx context.Context, req mcreconcile.R
 	}
 
 	if !claim.DeletionTimestamp.IsZero() {
-		return ctrl.Result{}, nil
+		return r.releaseSubnetClaim(ctx, cl.GetClient(), &claim)
 	}
 
 	logger.Info("reconciling subnet claim")
 	defer logger.Info("reconcile complete")
 
+	if !controllerutil.ContainsFinalizer(&claim, subnetClaimReleaseFinalizer) {
+		controllerutil.AddFinalizer(&claim, subnetClaimReleaseFinalizer)
+		if err := cl.GetClient().Update(ctx, &claim); err != nil {
+			if apierrors.IsConflict(err) {
+				return ctrl.Result{RequeueAfter: retryAfterConflict}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("failed adding subnet claim finalizer: %w", err)
+		}
+	}
+
 	// TODO(jreese) move to a network context level subnet allocator, instead of
 	// the 1:1 SubnetClaim:Subnet that's here right now.
 
 	var subnet networkingv1alpha.Subnet
-	if err := cl.GetClient().Get(ctx, client.ObjectKeyFromObject(&claim), &subnet); client.IgnoreNotFound(err) != nil {
+	err = cl.GetClient().Get(ctx, client.ObjectKeyFromObject(&claim), &subnet)
+	if client.IgnoreNotFound(err) != nil {
 		return ctrl.Result{}, fmt.Errorf("failed fetching subnet: %w", err)
 	}
 
-	if subnet.CreationTimestamp.IsZero() {
+	if apierrors.IsNotFound(err) {
 		var networkContext networkingv1alpha.NetworkContext
 		networkContextObjectKey := client.ObjectKey{
 			Namespace: claim.Namespace,
@@ -88,9 +109,12 @@ func (r *SubnetClaimReconciler) Reconcile(ctx context.Context, req mcreconcile.R
 			},
 			Spec: networkingv1alpha.SubnetSpec{
 				IPFamily:       claim.Spec.IPFamily,
+				IPFamilyPolicy: claim.Spec.IPFamilyPolicy,
 				SubnetClass:    claim.Spec.SubnetClass,
 				NetworkContext: claim.Spec.NetworkContext,
 				Location:       claim.Spec.Location,
+				StartAddress:   ptr.Deref(claim.Spec.StartAddress, ""),
+				PrefixLength:   ptr.Deref(claim.Spec.PrefixLength, 0),
 			},
 		}
 
@@ -110,6 +134,9 @@ func (r *SubnetClaimReconciler) Reconcile(ctx context.Context, req mcreconcile.R
 			Message:            "Subnet is not ready",
 		})
 
+		// The prefixes of a deleted subnet are released.
+		recordSubnetClaimAllocations(&claim, nil, metav1.Now())
+
 		if err := cl.GetClient().Status().Update(ctx, &claim); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed updating claim status")
 		}
@@ -117,14 +144,36 @@ func (r *SubnetClaimReconciler) Reconcile(ctx context.Context, req mcreconcile.R
 		return ctrl.Result{}, nil
 	}
 
+	// Surface allocation failures of the subnet, such as an exhausted IP pool,
+	// on the claim.
+	allocatedChanged := false
+	if allocated := apimeta.FindStatusCondition(subnet.Status.Conditions, networkingv1alpha.SubnetAllocated); allocated != nil {
+		allocatedChanged = apimeta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
+			Type:               networkingv1alpha.SubnetAllocated,
+			Status:             allocated.Status,
+			Reason:             allocated.Reason,
+			ObservedGeneration: claim.Generation,
+			Message:            allocated.Message,
+		})
+	}
+
 	if !apimeta.IsStatusConditionTrue(subnet.Status.Conditions, "Ready") {
 		logger.Info("subnet is not ready")
+		if allocatedChanged {
+			if err := cl.GetClient().Status().Update(ctx, &claim); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed updating claim status: %w", err)
+			}
+		}
 		return ctrl.Result{}, nil
 	}
 
 	claim.Status.SubnetRef = &networkingv1alpha.LocalSubnetReference{
 		Name: subnet.Name,
 	}
+	claim.Status.StartAddress = subnet.Status.StartAddress
+	claim.Status.PrefixLength = subnet.Status.PrefixLength
+	claim.Status.Prefixes = subnet.Status.Prefixes
+	recordSubnetClaimAllocations(&claim, subnet.Status.Prefixes, metav1.Now())
 
 	apimeta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
 		Type:               certManagerConditionTypeReady,
@@ -141,6 +190,82 @@ func (r *SubnetClaimReconciler) Reconcile(ctx context.Context, req mcreconcile.R
 	return ctrl.Result{}, nil
 }
 
+// releaseSubnetClaim deletes the subnet of a deleted subnet claim, and removes
+// the finalizer of the claim once the subnet is gone. The subnet releases its
+// prefixes before it's deleted, and its deletion enqueues the claim.
+func (r *SubnetClaimReconciler) releaseSubnetClaim(
+	ctx context.Context,
+	cl client.Client,
+	claim *networkingv1alpha.SubnetClaim,
+) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(claim, subnetClaimReleaseFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	var subnet networkingv1alpha.Subnet
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(claim), &subnet); err == nil {
+		if subnet.DeletionTimestamp.IsZero() {
+			if err := cl.Delete(ctx, &subnet); client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, fmt.Errorf("failed deleting subnet: %w", err)
+			}
+		}
+		return ctrl.Result{}, nil
+	} else if !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed fetching subnet: %w", err)
+	}
+
+	controllerutil.RemoveFinalizer(claim, subnetClaimReleaseFinalizer)
+	if err := cl.Update(ctx, claim); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed removing subnet claim finalizer: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// recordSubnetClaimAllocations records the prefixes allocated to a subnet
+// claim in its allocation history, and marks prefixes the claim no longer
+// holds as released. Released allocations are dropped, oldest first, to keep
+// the history within its limit.
+func recordSubnetClaimAllocations(claim *networkingv1alpha.SubnetClaim, prefixes []networkingv1alpha.SubnetPrefix, now metav1.Time) {
+	held := map[string]bool{}
+	for _, prefix := range prefixes {
+		held[fmt.Sprintf("%s/%d", prefix.StartAddress, prefix.PrefixLength)] = true
+	}
+
+	recorded := map[string]bool{}
+	for i := range claim.Status.AllocationHistory {
+		allocation := &claim.Status.AllocationHistory[i]
+		if allocation.ReleasedAt != nil {
+			continue
+		}
+		if held[allocation.Prefix] {
+			recorded[allocation.Prefix] = true
+		} else {
+			allocation.ReleasedAt = ptr.To(now)
+		}
+	}
+
+	for _, prefix := range prefixes {
+		cidr := fmt.Sprintf("%s/%d", prefix.StartAddress, prefix.PrefixLength)
+		if !recorded[cidr] {
+			claim.Status.AllocationHistory = append(claim.Status.AllocationHistory, networkingv1alpha.SubnetClaimAllocation{
+				IPFamily:    prefix.IPFamily,
+				Prefix:      cidr,
+				AllocatedAt: now,
+			})
+		}
+	}
+
+	for len(claim.Status.AllocationHistory) > maxSubnetClaimAllocationHistory {
+		idx := slices.IndexFunc(claim.Status.AllocationHistory, func(allocation networkingv1alpha.SubnetClaimAllocation) bool {
+			return allocation.ReleasedAt != nil
+		})
+		if idx < 0 {
+			idx = 0
+		}
+		claim.Status.AllocationHistory = slices.Delete(claim.Status.AllocationHistory, idx, idx+1)
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SubnetClaimReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 	r.mgr = mgr
@@ -150,7 +275,7 @@ func (r *SubnetClaimReconciler) SetupWithManager(mgr mcmanager.Manager) error {
 				predicate.NewPredicateFuncs(func(object client.Object) bool {
 					// Don't bother processing deployments that have been scheduled
 					o := object.(*networkingv1alpha.SubnetClaim)
-					return o.Status.SubnetRef == nil
+					return o.Status.SubnetRef == nil || !o.DeletionTimestamp.IsZero()
 				}),
 			),
 			mcbuilder.WithEngageWithLocalCluster(false),
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestSubnetClaimReconcileRelease(t *testing.T) {
+	ctx := context.Background()
+
+	claim := &networkingv1alpha.SubnetClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "claim"},
+		Spec: networkingv1alpha.SubnetClaimSpec{
+			SubnetClass:    "private",
+			NetworkContext: networkingv1alpha.LocalNetworkContextRef{Name: "context"},
+			IPFamily:       networkingv1alpha.IPv4Protocol,
+		},
+	}
+	cl := newSubnetTestClient(t, claim)
+	reconciler := &SubnetClaimReconciler{mgr: &fakeMockManager{cl: cl}}
+
+	reconcileClaim := func() {
+		_, err := reconciler.Reconcile(ctx, mcreconcile.Request{
+			Request:     reconcile.Request{NamespacedName: client.ObjectKeyFromObject(claim)},
+			ClusterName: "single",
+		})
+		require.NoError(t, err)
+	}
+
+	// The claim is given a finalizer and a subnet of the same name.
+	reconcileClaim()
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(claim), claim))
+	assert.Contains(t, claim.Finalizers, subnetClaimReleaseFinalizer)
+
+	var subnet networkingv1alpha.Subnet
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(claim), &subnet))
+	subnet.Status.StartAddress = ptr.To("10.0.0.0")
+	subnet.Status.PrefixLength = ptr.To[int32](24)
+	subnet.Status.Prefixes = []networkingv1alpha.SubnetPrefix{{
+		IPFamily:     networkingv1alpha.IPv4Protocol,
+		StartAddress: "10.0.0.0",
+		PrefixLength: 24,
+		IPPool:       "pool",
+	}}
+	apimeta.SetStatusCondition(&subnet.Status.Conditions, metav1.Condition{
+		Type:   networkingv1alpha.SubnetReady,
+		Status: metav1.ConditionTrue,
+		Reason: networkingv1alpha.SubnetReadyReasonReady,
+	})
+	require.NoError(t, cl.Status().Update(ctx, &subnet))
+
+	// The prefix of the ready subnet is recorded in the allocation history.
+	reconcileClaim()
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(claim), claim))
+	require.Len(t, claim.Status.AllocationHistory, 1)
+	assert.Equal(t, "10.0.0.0/24", claim.Status.AllocationHistory[0].Prefix)
+	assert.Nil(t, claim.Status.AllocationHistory[0].ReleasedAt)
+
+	// Deleting the claim deletes its subnet, and the claim is deleted once the
+	// subnet is gone.
+	require.NoError(t, cl.Delete(ctx, claim))
+	reconcileClaim()
+	err := cl.Get(ctx, client.ObjectKeyFromObject(claim), &networkingv1alpha.Subnet{})
+	assert.True(t, apierrors.IsNotFound(err), "expected subnet to be deleted, got %v", err)
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(claim), claim))
+
+	reconcileClaim()
+	err = cl.Get(ctx, client.ObjectKeyFromObject(claim), claim)
+	assert.True(t, apierrors.IsNotFound(err), "expected subnet claim to be deleted, got %v", err)
+}
+
+func TestRecordSubnetClaimAllocations(t *testing.T) {
+	allocatedAt := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(allocatedAt.Add(time.Hour))
+
+	prefix := func(cidr string) networkingv1alpha.SubnetPrefix {
+		p := netip.MustParsePrefix(cidr)
+		return networkingv1alpha.SubnetPrefix{
+			IPFamily:     networkingv1alpha.IPv4Protocol,
+			StartAddress: p.Addr().String(),
+			PrefixLength: int32(p.Bits()),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		history  []networkingv1alpha.SubnetClaimAllocation
+		prefixes []networkingv1alpha.SubnetPrefix
+		want     []networkingv1alpha.SubnetClaimAllocation
+	}{
+		{
+			name:     "records a new allocation",
+			prefixes: []networkingv1alpha.SubnetPrefix{prefix("10.0.0.0/24")},
+			want: []networkingv1alpha.SubnetClaimAllocation{
+				{IPFamily: networkingv1alpha.IPv4Protocol, Prefix: "10.0.0.0/24", AllocatedAt: now},
+			},
+		},
+		{
+			name: "keeps a held allocation",
+			history: []networkingv1alpha.SubnetClaimAllocation{
+				{IPFamily: networkingv1alpha.IPv4Protocol, Prefix: "10.0.0.0/24", AllocatedAt: allocatedAt},
+			},
+			prefixes: []networkingv1alpha.SubnetPrefix{prefix("10.0.0.0/24")},
+			want: []networkingv1alpha.SubnetClaimAllocation{
+				{IPFamily: networkingv1alpha.IPv4Protocol, Prefix: "10.0.0.0/24", AllocatedAt: allocatedAt},
+			},
+		},
+		{
+			name: "releases an allocation of a deleted subnet",
+			history: []networkingv1alpha.SubnetClaimAllocation{
+				{IPFamily: networkingv1alpha.IPv4Protocol, Prefix: "10.0.0.0/24", AllocatedAt: allocatedAt},
+			},
+			want: []networkingv1alpha.SubnetClaimAllocation{
+				{IPFamily: networkingv1alpha.IPv4Protocol, Prefix: "10.0.0.0/24", AllocatedAt: allocatedAt, ReleasedAt: &now},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &networkingv1alpha.SubnetClaim{
+				Status: networkingv1alpha.SubnetClaimStatus{AllocationHistory: tt.history},
+			}
+			recordSubnetClaimAllocations(claim, tt.prefixes, now)
+			assert.Equal(t, tt.want, claim.Status.AllocationHistory)
+		})
+	}
+
+	t.Run("drops the oldest released allocations", func(t *testing.T) {
+		claim := &networkingv1alpha.SubnetClaim{}
+		for i := range maxSubnetClaimAllocationHistory {
+			claim.Status.AllocationHistory = append(claim.Status.AllocationHistory, networkingv1alpha.SubnetClaimAllocation{
+				IPFamily:    networkingv1alpha.IPv4Protocol,
+				Prefix:      fmt.Sprintf("10.0.%d.0/24", i),
+				AllocatedAt: allocatedAt,
+				ReleasedAt:  &allocatedAt,
+			})
+		}
+		recordSubnetClaimAllocations(claim, []networkingv1alpha.SubnetPrefix{prefix("10.1.0.0/24")}, now)
+
+		require.Len(t, claim.Status.AllocationHistory, maxSubnetClaimAllocationHistory)
+		assert.Equal(t, "10.0.1.0/24", claim.Status.AllocationHistory[0].Prefix)
+		assert.Equal(t, "10.1.0.0/24", claim.Status.AllocationHistory[maxSubnetClaimAllocationHistory-1].Prefix)
+	})
+}
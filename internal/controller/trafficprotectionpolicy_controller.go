@@ -16,6 +16,7 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
@@ -42,6 +43,8 @@ import (
 	gatewaystatus "go.datum.net/network-services-operator/internal/gatewayapi/status"
 	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 	"go.datum.net/network-services-operator/internal/util/resourcename"
+	"go.datum.net/network-services-operator/internal/validation"
+	"go.datum.net/network-services-operator/internal/wafevents"
 )
 
 // TrafficProtectionPolicyReconciler reconciles a TrafficProtectionPolicy object
@@ -49,7 +52,20 @@ type TrafficProtectionPolicyReconciler struct {
 	mgr    mcmanager.Manager
 	Config config.NetworkServicesOperator
 
+	// ReloadableConfig, when set, supplies the config.ReloadableFields that
+	// may change while the manager is running. The namespaces with policies are requeued
+	// when the fields they are programmed from are reloaded.
+	ReloadableConfig *config.Reloadable
+
 	DownstreamCluster cluster.Cluster
+
+	// WAFEvents reports the events of policies into their status. Reporting is
+	// disabled when nil.
+	WAFEvents wafevents.Source
+
+	// UpstreamOutages, when set, retries reconciles while the API server of
+	// the policies' cluster is unreachable.
+	UpstreamOutages *UpstreamOutageTracker
 }
 
 const (
@@ -60,6 +76,9 @@ const (
 	// PolicyReasonWaitingForListenersProgrammed indicates that the policy is waiting
 	// for HTTPS listeners to be Programmed=True before EnvoyPatchPolicies can be created.
 	PolicyReasonWaitingForListenersProgrammed gatewayv1.PolicyConditionReason = "WaitingForListenersProgrammed"
+	// PolicyReasonUnsupportedVersion indicates that the OWASP Core Rule Set
+	// release pinned by the policy's crsVersion is not available.
+	PolicyReasonUnsupportedVersion gatewayv1.PolicyConditionReason = "UnsupportedVersion"
 
 	// tppEnvoyPatchPolicyPrefix is the name prefix for all EnvoyPatchPolicies
 	// written by the TrafficProtectionPolicy controller ("tpp-<gateway-name>").
@@ -67,6 +86,11 @@ const (
 	// controllers (e.g. the HTTPProxy connector controller uses "connector-<name>").
 	tppEnvoyPatchPolicyPrefix = "tpp-"
 
+	// PolicyConditionProgrammingFailed is set on the ancestors of a policy
+	// whose downstream EnvoyPatchPolicy was rejected by the data plane and
+	// rolled back. It is removed once the data plane programs the policy.
+	PolicyConditionProgrammingFailed gatewayv1.PolicyConditionType = "PolicyProgrammingFailed"
+
 	// tppManagedLabel is stamped onto every EnvoyPatchPolicy created or updated
 	// by this controller. Once all existing EPPs have been reconciled and carry
 	// this label, the stale-cleanup loop can switch to a label-selector List
@@ -89,7 +113,7 @@ type certificateReadinessResult struct {
 // +kubebuilder:rbac:groups=networking.datumapis.com,resources=trafficprotectionpolicies/finalizers,verbs=update
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch
 
-func (r *TrafficProtectionPolicyReconciler) Reconcile(ctx context.Context, req NamespaceReconcileRequest) (ctrl.Result, error) {
+func (r *TrafficProtectionPolicyReconciler) Reconcile(ctx context.Context, req NamespaceReconcileRequest) (res ctrl.Result, err error) {
 	logger := log.FromContext(ctx, "cluster", req.ClusterName)
 	ctx = log.IntoContext(ctx, logger)
 
@@ -107,11 +131,25 @@ func (r *TrafficProtectionPolicyReconciler) Reconcile(ctx context.Context, req N
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	cl, upstreamCalls := recordUpstreamCalls(cl)
+	defer func() {
+		res, err = r.UpstreamOutages.complete(ctx, string(req.ClusterName), upstreamCalls, res, err)
+	}()
+
+	terminating, err := upstreamNamespaceTerminating(ctx, cl.GetClient(), req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if terminating {
+		// Policies are removed downstream along with the downstream namespace.
+		logger.Info("namespace is terminating, skipping trafficprotectionpolicies")
+		return ctrl.Result{}, nil
+	}
 
 	logger.Info("reconciling trafficprotectionpolicies")
 	defer logger.Info("reconcile complete")
 
-	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), cl.GetClient(), r.DownstreamCluster.GetClient())
+	downstreamStrategy := downstreamclient.NewMappedNamespaceResourceStrategy(string(req.ClusterName), cl.GetClient(), r.DownstreamCluster.GetClient(), downstreamclient.WithControllerName("trafficprotectionpolicy"))
 
 	downstreamNamespaceName, err := downstreamStrategy.GetDownstreamNamespaceNameForUpstreamNamespace(ctx, req.Namespace)
 	if err != nil {
@@ -131,6 +169,7 @@ func (r *TrafficProtectionPolicyReconciler) Reconcile(ctx context.Context, req N
 	}
 
 	trafficProtectionPolicies := r.getTrafficProtectionPolicyContexts(trafficProtectionPolicyList.Items)
+	eventsRequeueAfter := r.refreshTrafficProtectionPolicyEvents(ctx, string(req.ClusterName), trafficProtectionPolicies)
 
 	var upstreamGateways gatewayv1.GatewayList
 	if err := cl.GetClient().List(ctx, &upstreamGateways, client.InNamespace(req.Namespace)); err != nil {
@@ -161,12 +200,12 @@ func (r *TrafficProtectionPolicyReconciler) Reconcile(ctx context.Context, req N
 			logger.Info("waiting for TLS certificates to become ready", "pendingListeners", certReadiness.PendingListeners)
 			r.setWaitingForCertificatesConditions(trafficProtectionPolicies, certReadiness.PendingListeners)
 
-			if err := r.updateTPPAncestorsStatus(ctx, cl.GetClient(), trafficProtectionPolicies, originalTrafficProtectionPolicies); err != nil {
+			if err := r.updateTPPAncestorsStatus(ctx, cl, trafficProtectionPolicies, originalTrafficProtectionPolicies); err != nil {
 				return ctrl.Result{}, err
 			}
 
 			// Certificate watch will trigger reconciliation when certificates become ready
-			return ctrl.Result{}, nil
+			return ctrl.Result{RequeueAfter: eventsRequeueAfter}, nil
 		}
 
 		listenerReadiness := r.checkHTTPSListenersProgrammed(attachments)
@@ -174,12 +213,12 @@ func (r *TrafficProtectionPolicyReconciler) Reconcile(ctx context.Context, req N
 			logger.Info("waiting for HTTPS listeners to become programmed", "pendingListeners", listenerReadiness.PendingListeners)
 			r.setWaitingForListenersProgrammedConditions(trafficProtectionPolicies, listenerReadiness.PendingListeners)
 
-			if err := r.updateTPPAncestorsStatus(ctx, cl.GetClient(), trafficProtectionPolicies, originalTrafficProtectionPolicies); err != nil {
+			if err := r.updateTPPAncestorsStatus(ctx, cl, trafficProtectionPolicies, originalTrafficProtectionPolicies); err != nil {
 				return ctrl.Result{}, err
 			}
 
 			// Gateway/HTTPRoute watches will trigger reconciliation when listener status changes.
-			return ctrl.Result{}, nil
+			return ctrl.Result{RequeueAfter: eventsRequeueAfter}, nil
 		}
 
 		desiredPolicies, err := r.getDesiredEnvoyPatchPolicies(downstreamNamespaceName, attachments)
@@ -188,6 +227,7 @@ func (r *TrafficProtectionPolicyReconciler) Reconcile(ctx context.Context, req N
 		}
 
 		desiredPolicyNames := make(map[string]struct{}, len(desiredPolicies))
+		rejections := make(map[string]*envoyPatchPolicyRejection)
 		for _, desiredPolicy := range desiredPolicies {
 			desiredPolicyNames[desiredPolicy.Name] = struct{}{}
 
@@ -196,19 +236,26 @@ func (r *TrafficProtectionPolicyReconciler) Reconcile(ctx context.Context, req N
 				Name:      desiredPolicy.Name,
 			}}
 
+			var rejection *envoyPatchPolicyRejection
 			result, err := controllerutil.CreateOrUpdate(ctx, downstreamStrategy.GetClient(), &policy, func() error {
 				if policy.Labels == nil {
 					policy.Labels = make(map[string]string)
 				}
 				policy.Labels[tppManagedLabel] = labelValueTrue
-				policy.Spec = desiredPolicy.Spec
-				return nil
+				var err error
+				rejection, err = setEnvoyPatchPolicySpec(&policy, desiredPolicy.Spec)
+				return err
 			})
 			if err != nil {
 				return ctrl.Result{}, fmt.Errorf("failed to create or update envoypatchpolicy %s/%s: %w", policy.Namespace, policy.Name, err)
 			}
+			if rejection != nil {
+				logger.Info("data plane rejected envoypatchpolicy, rolled back", jsonKeyNamespace, policy.Namespace, jsonKeyName, policy.Name, "reason", rejection.Reason, "message", rejection.Message)
+				rejections[desiredPolicy.Name] = rejection
+			}
 			logger.Info("applied envoypatchpolicy to downstream cluster", jsonKeyNamespace, policy.Namespace, jsonKeyName, policy.Name, "result", result)
 		}
+		r.setPolicyProgrammingFailedConditions(trafficProtectionPolicies, attachments, rejections)
 
 		// Clean up stale EPPs. All EPPs written by this controller are named
 		// "tpp-<gateway-name>"; other controllers use different prefixes (e.g.
@@ -242,11 +289,11 @@ func (r *TrafficProtectionPolicyReconciler) Reconcile(ctx context.Context, req N
 		}
 	}
 
-	if err := r.updateTPPAncestorsStatus(ctx, cl.GetClient(), trafficProtectionPolicies, originalTrafficProtectionPolicies); err != nil {
+	if err := r.updateTPPAncestorsStatus(ctx, cl, trafficProtectionPolicies, originalTrafficProtectionPolicies); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: eventsRequeueAfter}, nil
 }
 
 func (r *TrafficProtectionPolicyReconciler) getTrafficProtectionPolicyContexts(
@@ -257,8 +304,10 @@ func (r *TrafficProtectionPolicyReconciler) getTrafficProtectionPolicyContexts(
 		if dt := tpp.DeletionTimestamp; dt != nil {
 			continue
 		}
+		policy := policies[i].DeepCopy()
+		policy.Status.Warnings = validation.TrafficProtectionPolicyWarnings(policy)
 		trafficProtectionPolicies = append(trafficProtectionPolicies, &policyContext{
-			TrafficProtectionPolicy: policies[i].DeepCopy(),
+			TrafficProtectionPolicy: policy,
 		})
 	}
 
@@ -279,7 +328,7 @@ func (r *TrafficProtectionPolicyReconciler) getTrafficProtectionPolicyContexts(
 
 func (r *TrafficProtectionPolicyReconciler) updateTPPAncestorsStatus(
 	ctx context.Context,
-	upstreamClient client.Client,
+	upstreamCluster cluster.Cluster,
 	processedTrafficProtectionPolicies []*policyContext,
 	trafficProtectionPolicies map[string]networkingv1alpha.TrafficProtectionPolicy,
 ) error {
@@ -311,12 +360,15 @@ func (r *TrafficProtectionPolicyReconciler) updateTPPAncestorsStatus(
 				policy.Status.Ancestors = append(policy.Status.Ancestors[:i], policy.Status.Ancestors[i+1:]...)
 			}
 		}
+		policy.Status.TargetCount, policy.Status.AcceptedCount = r.countTPPAncestors(policy.TrafficProtectionPolicy)
 
 		if !equality.Semantic.DeepEqual(originalPolicy.Status, policy.Status) {
+			origAncestors := originalPolicy.Status.Ancestors
 			originalPolicy.Status = policy.Status
-			if err := upstreamClient.Status().Update(ctx, &originalPolicy); err != nil {
+			if err := upstreamCluster.GetClient().Status().Update(ctx, &originalPolicy); err != nil {
 				return fmt.Errorf("failed to update status for trafficprotectionpolicy %s/%s: %w", policy.Namespace, policy.Name, err)
 			}
+			recordPolicyAncestorConflicts(eventRecorderFor(upstreamCluster), &originalPolicy, origAncestors, originalPolicy.Status.Ancestors)
 		} else {
 			logger.Info("status unchanged, skipping update", "trafficprotectionpolicy", fmt.Sprintf("%s/%s", policy.Namespace, policy.Name))
 		}
@@ -327,6 +379,21 @@ func (r *TrafficProtectionPolicyReconciler) updateTPPAncestorsStatus(
 
 }
 
+// countTPPAncestors returns the number of ancestors owned by this controller
+// that the policy is attached to, and the number of them that accepted it.
+func (r *TrafficProtectionPolicyReconciler) countTPPAncestors(policy *networkingv1alpha.TrafficProtectionPolicy) (targets, accepted int32) {
+	for _, ancestor := range policy.Status.Ancestors {
+		if ancestor.ControllerName != r.Config.Gateway.ControllerName {
+			continue
+		}
+		targets++
+		if apimeta.IsStatusConditionTrue(ancestor.Conditions, string(gatewayv1.PolicyConditionAccepted)) {
+			accepted++
+		}
+	}
+	return targets, accepted
+}
+
 // checkHTTPSListenerCertificatesReady checks if all TLS certificates for HTTPS listeners
 // referenced by the policy attachments are ready. This ensures that EnvoyPatchPolicies
 // are not created until the filter_chains are materialized by Envoy Gateway.
@@ -498,6 +565,67 @@ func (r *TrafficProtectionPolicyReconciler) setWaitingForListenersProgrammedCond
 	}
 }
 
+// setPolicyProgrammingFailedConditions sets the PolicyProgrammingFailed
+// condition on the ancestors of policies attached to a gateway whose
+// EnvoyPatchPolicy the data plane rejected, keyed by the name of the
+// EnvoyPatchPolicy, and removes it from every other ancestor.
+func (r *TrafficProtectionPolicyReconciler) setPolicyProgrammingFailedConditions(
+	policies []*policyContext,
+	attachments []policyAttachment,
+	rejections map[string]*envoyPatchPolicyRejection,
+) {
+	failedAncestors := make(map[*policyContext][]*gatewayv1alpha2.ParentReference)
+	failedRejections := make(map[*policyContext][]*envoyPatchPolicyRejection)
+	for _, attachment := range attachments {
+		rejection, ok := rejections[tppEnvoyPatchPolicyPrefix+attachment.Gateway.Name]
+		if !ok {
+			continue
+		}
+		for _, targetRef := range attachment.Policy.Spec.TargetRefs {
+			switch {
+			case attachment.Route != nil:
+				if targetRef.Kind != KindHTTPRoute || string(targetRef.Name) != attachment.Route.Name {
+					continue
+				}
+			case targetRef.Kind != KindGateway || string(targetRef.Name) != attachment.Gateway.Name:
+				continue
+			}
+			failedAncestors[attachment.Policy] = append(failedAncestors[attachment.Policy], getAncestorRefForTarget(attachment.Policy.Namespace, targetRef))
+			failedRejections[attachment.Policy] = append(failedRejections[attachment.Policy], rejection)
+		}
+	}
+
+	for _, policy := range policies {
+		ancestorRefs := failedAncestors[policy]
+		for i, ancestorRef := range ancestorRefs {
+			rejection := failedRejections[policy][i]
+			gatewaystatus.SetConditionForPolicyAncestor(
+				&policy.Status.PolicyStatus,
+				ancestorRef,
+				string(r.Config.Gateway.ControllerName),
+				PolicyConditionProgrammingFailed,
+				metav1.ConditionTrue,
+				gatewayv1.PolicyConditionReason(rejection.Reason),
+				rejection.String(),
+				policy.Generation,
+			)
+		}
+
+		for i := range policy.Status.Ancestors {
+			ancestor := &policy.Status.Ancestors[i]
+			if ancestor.ControllerName != r.Config.Gateway.ControllerName {
+				continue
+			}
+			if slices.ContainsFunc(ancestorRefs, func(ancestorRef *gatewayv1alpha2.ParentReference) bool {
+				return equality.Semantic.DeepEqual(ancestor.AncestorRef, *ancestorRef)
+			}) {
+				continue
+			}
+			apimeta.RemoveStatusCondition(&ancestor.Conditions, string(PolicyConditionProgrammingFailed))
+		}
+	}
+}
+
 func (r *TrafficProtectionPolicyReconciler) ensureHTTPCorazaListenerFilter(ctx context.Context) error {
 	envoyPatchPolicy := &envoygatewayv1alpha1.EnvoyPatchPolicy{
 		ObjectMeta: metav1.ObjectMeta{
@@ -506,32 +634,37 @@ func (r *TrafficProtectionPolicyReconciler) ensureHTTPCorazaListenerFilter(ctx c
 		},
 	}
 
-	corazaConfigBytes, err := r.getCorazaListenerFilterConfig()
+	listenerFilterConfigs, err := r.getListenerFilterConfigs()
 	if err != nil {
 		return err
 	}
 
+	var jsonPatches []envoygatewayv1alpha1.EnvoyJSONPatchConfig
+	for _, filterConfigBytes := range listenerFilterConfigs {
+		jsonPatches = append(jsonPatches, envoygatewayv1alpha1.EnvoyJSONPatchConfig{
+			Type: "type.googleapis.com/envoy.config.listener.v3.Listener",
+			Name: fmt.Sprintf("tcp-%d", DefaultHTTPPort),
+			Operation: envoygatewayv1alpha1.JSONPatchOperation{
+				Op:    jsonPatchOpAdd,
+				Path:  ptr.To("/default_filter_chain/filters/0/typed_config/http_filters/0"),
+				Value: &apiextensionsv1.JSON{Raw: filterConfigBytes},
+			},
+		})
+	}
+
+	var rejection *envoyPatchPolicyRejection
 	result, err := controllerutil.CreateOrUpdate(ctx, r.DownstreamCluster.GetClient(), envoyPatchPolicy, func() error {
-		envoyPatchPolicy.Spec = envoygatewayv1alpha1.EnvoyPatchPolicySpec{
+		var err error
+		rejection, err = setEnvoyPatchPolicySpec(envoyPatchPolicy, envoygatewayv1alpha1.EnvoyPatchPolicySpec{
 			TargetRef: gatewayv1.LocalPolicyTargetReference{
 				Group: gatewayv1.GroupName,
 				Kind:  "GatewayClass",
 				Name:  gatewayv1.ObjectName(r.Config.Gateway.DownstreamGatewayClassName),
 			},
-			Type: envoygatewayv1alpha1.JSONPatchEnvoyPatchType,
-			JSONPatches: []envoygatewayv1alpha1.EnvoyJSONPatchConfig{
-				{
-					Type: "type.googleapis.com/envoy.config.listener.v3.Listener",
-					Name: fmt.Sprintf("tcp-%d", DefaultHTTPPort),
-					Operation: envoygatewayv1alpha1.JSONPatchOperation{
-						Op:    jsonPatchOpAdd,
-						Path:  ptr.To("/default_filter_chain/filters/0/typed_config/http_filters/0"),
-						Value: &apiextensionsv1.JSON{Raw: corazaConfigBytes},
-					},
-				},
-			},
-		}
-		return nil
+			Type:        envoygatewayv1alpha1.JSONPatchEnvoyPatchType,
+			JSONPatches: jsonPatches,
+		})
+		return err
 	})
 
 	if err != nil {
@@ -539,25 +672,94 @@ func (r *TrafficProtectionPolicyReconciler) ensureHTTPCorazaListenerFilter(ctx c
 	}
 
 	logger := log.FromContext(ctx)
+	if rejection != nil {
+		// The listener filter is shared by every policy, so the rejection is
+		// only logged.
+		logger.Info("data plane rejected envoypatchpolicy for http listener, rolled back", jsonKeyNamespace, envoyPatchPolicy.Namespace, jsonKeyName, envoyPatchPolicy.Name, "reason", rejection.Reason, "message", rejection.Message)
+	}
 	logger.Info("ensured envoypatchpolicy for http listener", jsonKeyNamespace, envoyPatchPolicy.Namespace, jsonKeyName, envoyPatchPolicy.Name, "result", result)
 
 	return nil
 }
 
-func (r TrafficProtectionPolicyReconciler) getCorazaListenerFilterConfig() ([]byte, error) {
-	directiveBytes, err := json.Marshal(r.Config.Gateway.Coraza.ListenerDirectives)
+// getListenerFilterConfigs returns the HTTP filters to insert at the start of
+// the filter chain of listeners, in insertion order. A Coraza filter is
+// inserted for each rule bundle, and is enabled by the routes of the policies
+// using the bundle. Each filter is inserted at index 0, so the GeoIP filters
+// end up ahead of the Coraza filters and the country header is set before the
+// WAF evaluates requests.
+func (r TrafficProtectionPolicyReconciler) getListenerFilterConfigs() ([][]byte, error) {
+	var filterConfigs [][]byte
+	for _, bundle := range reloadableConfig(&r.Config, r.ReloadableConfig).Gateway.Coraza.Bundles() {
+		corazaConfigBytes, err := r.getCorazaListenerFilterConfig(bundle)
+		if err != nil {
+			return nil, err
+		}
+		filterConfigs = append(filterConfigs, corazaConfigBytes)
+	}
+
+	if !r.Config.Gateway.Coraza.GeoIP.Enabled() {
+		return filterConfigs, nil
+	}
+
+	geoIPConfig := map[string]any{
+		jsonKeyName: config.GeoIPFilterName,
+		jsonKeyTypedConfig: map[string]any{
+			jsonKeyAtType: "type.googleapis.com/envoy.extensions.filters.http.geoip.v3.Geoip",
+			"provider": map[string]any{
+				jsonKeyName: "envoy.geoip_providers.maxmind",
+				jsonKeyTypedConfig: map[string]any{
+					jsonKeyAtType:     "type.googleapis.com/envoy.extensions.geoip_providers.maxmind.v3.MaxMindConfig",
+					"country_db_path": r.Config.Gateway.Coraza.GeoIP.CountryDatabasePath,
+					"common_provider_config": map[string]any{
+						"geo_headers_to_add": map[string]any{
+							"country": config.GeoIPCountryHeader,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The geoip filter doesn't set the header when the lookup fails, so a
+	// country sent by the client must be removed first.
+	headerStripConfig := map[string]any{
+		jsonKeyName: config.GeoIPHeaderStripFilterName,
+		jsonKeyTypedConfig: map[string]any{
+			jsonKeyAtType: "type.googleapis.com/envoy.extensions.filters.http.header_mutation.v3.HeaderMutation",
+			"mutations": map[string]any{
+				"request_mutations": []map[string]any{
+					{"remove": config.GeoIPCountryHeader},
+				},
+			},
+		},
+	}
+
+	for _, filterConfig := range []map[string]any{geoIPConfig, headerStripConfig} {
+		filterConfigBytes, err := json.Marshal(filterConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal geoip filter config: %w", err)
+		}
+		filterConfigs = append(filterConfigs, filterConfigBytes)
+	}
+
+	return filterConfigs, nil
+}
+
+func (r TrafficProtectionPolicyReconciler) getCorazaListenerFilterConfig(bundle config.CorazaRuleBundle) ([]byte, error) {
+	directiveBytes, err := json.Marshal(reloadableConfig(&r.Config, r.ReloadableConfig).Gateway.Coraza.ListenerDirectives)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal coraza directives: %w", err)
 	}
 
 	corazaConfig := map[string]any{
-		jsonKeyName: r.Config.Gateway.Coraza.FilterName,
+		jsonKeyName: bundle.FilterName,
 		"disabled":  true,
 		jsonKeyTypedConfig: map[string]any{
 			jsonKeyAtType:  "type.googleapis.com/envoy.extensions.filters.http.golang.v3alpha.Config",
-			"library_id":   r.Config.Gateway.Coraza.LibraryID,
-			"library_path": r.Config.Gateway.Coraza.LibraryPath,
-			"plugin_name":  r.Config.Gateway.Coraza.PluginName,
+			"library_id":   bundle.LibraryID,
+			"library_path": bundle.LibraryPath,
+			"plugin_name":  bundle.PluginName,
 			"plugin_config": map[string]any{
 				jsonKeyAtType: "type.googleapis.com/xds.type.v3.TypedStruct",
 				"value": map[string]any{
@@ -796,7 +998,7 @@ func (r *TrafficProtectionPolicyReconciler) processTrafficProtectionPolicyForHTT
 		route.attachedToRouteRules.Insert(routeRuleName)
 	}
 
-	if resolveErr := paranoiaLevelsResolveError(policy); resolveErr != nil {
+	if resolveErr := r.policyResolveError(policy); resolveErr != nil {
 		gatewaystatus.SetResolveErrorForPolicyAncestor(
 			&policy.Status.PolicyStatus,
 			ancestorRef,
@@ -944,7 +1146,7 @@ func (r *TrafficProtectionPolicyReconciler) processTrafficProtectionPolicyForGat
 		gateway.attachedToListeners.Insert(listenerName)
 	}
 
-	if resolveErr := paranoiaLevelsResolveError(policy); resolveErr != nil {
+	if resolveErr := r.policyResolveError(policy); resolveErr != nil {
 		gatewaystatus.SetResolveErrorForPolicyAncestor(
 			&policy.Status.PolicyStatus,
 			ancestorRef,
@@ -1011,6 +1213,11 @@ func (r *TrafficProtectionPolicyReconciler) getDesiredEnvoyPatchPolicies(
 				// Shouldn't happen until other types of rulesets are added
 				continue
 			}
+			// Policies with an unavailable crsVersion aren't attached.
+			bundle, ok := r.corazaRuleBundle(policyAttachment.Policy)
+			if !ok {
+				continue
+			}
 			vhostConstraints := getVHostConstraintForGateway(downstreamNamespaceName, policyAttachment.Gateway)
 
 			if policyAttachment.Listener != nil {
@@ -1077,7 +1284,7 @@ func (r *TrafficProtectionPolicyReconciler) getDesiredEnvoyPatchPolicies(
 			corazaConfig := map[string]any{
 				jsonKeyAtType: "type.googleapis.com/envoy.extensions.filters.http.golang.v3alpha.ConfigsPerRoute",
 				"plugins_config": map[string]any{
-					r.Config.Gateway.Coraza.PluginName: map[string]any{
+					bundle.PluginName: map[string]any{
 						"config": map[string]any{
 							jsonKeyAtType: "type.googleapis.com/xds.type.v3.TypedStruct",
 							"value": map[string]any{
@@ -1108,7 +1315,7 @@ func (r *TrafficProtectionPolicyReconciler) getDesiredEnvoyPatchPolicies(
 					Operation: envoygatewayv1alpha1.JSONPatchOperation{
 						Op:       jsonPatchOpAdd,
 						JSONPath: ptr.To(httpRoutesJSONPath),
-						Path:     ptr.To(fmt.Sprintf("/typed_per_filter_config/%s", r.Config.Gateway.Coraza.FilterName)),
+						Path:     ptr.To(fmt.Sprintf("/typed_per_filter_config/%s", bundle.FilterName)),
 						Value:    &apiextensionsv1.JSON{Raw: corazaConfigBytes},
 					},
 				})
@@ -1139,7 +1346,7 @@ func (r *TrafficProtectionPolicyReconciler) getDesiredEnvoyPatchPolicies(
 						Operation: envoygatewayv1alpha1.JSONPatchOperation{
 							Op:       jsonPatchOpAdd,
 							JSONPath: ptr.To(httpRoutesJSONPath),
-							Path:     ptr.To(fmt.Sprintf("/typed_per_filter_config/%s", r.Config.Gateway.Coraza.FilterName)),
+							Path:     ptr.To(fmt.Sprintf("/typed_per_filter_config/%s", bundle.FilterName)),
 							Value:    &apiextensionsv1.JSON{Raw: corazaConfigBytes},
 						},
 					})
@@ -1168,7 +1375,7 @@ func (r *TrafficProtectionPolicyReconciler) getDesiredEnvoyPatchPolicies(
 					Operation: envoygatewayv1alpha1.JSONPatchOperation{
 						Op:       jsonPatchOpAdd,
 						JSONPath: ptr.To(httpRoutesJSONPath),
-						Path:     ptr.To(fmt.Sprintf("/typed_per_filter_config/%s", r.Config.Gateway.Coraza.FilterName)),
+						Path:     ptr.To(fmt.Sprintf("/typed_per_filter_config/%s", bundle.FilterName)),
 						Value:    &apiextensionsv1.JSON{Raw: corazaConfigBytes},
 					},
 				})
@@ -1178,22 +1385,24 @@ func (r *TrafficProtectionPolicyReconciler) getDesiredEnvoyPatchPolicies(
 
 		// Process TLS filter chains with attachments
 
-		corazaConfigBytes, err := r.getCorazaListenerFilterConfig()
+		listenerFilterConfigs, err := r.getListenerFilterConfigs()
 		if err != nil {
 			return nil, err
 		}
 
 		for _, filterChainName := range sets.List(tlsFilterChainsWithAttachments) {
-			jsonPatches = append(jsonPatches, envoygatewayv1alpha1.EnvoyJSONPatchConfig{
-				Type: "type.googleapis.com/envoy.config.listener.v3.Listener",
-				Name: fmt.Sprintf("tcp-%d", DefaultHTTPSPort),
-				Operation: envoygatewayv1alpha1.JSONPatchOperation{
-					Op:       jsonPatchOpAdd,
-					JSONPath: ptr.To(fmt.Sprintf(`..filter_chains[?(@.name=="%s")]`, filterChainName)),
-					Path:     ptr.To("/filters/0/typed_config/http_filters/0"),
-					Value:    &apiextensionsv1.JSON{Raw: corazaConfigBytes},
-				},
-			})
+			for _, filterConfigBytes := range listenerFilterConfigs {
+				jsonPatches = append(jsonPatches, envoygatewayv1alpha1.EnvoyJSONPatchConfig{
+					Type: "type.googleapis.com/envoy.config.listener.v3.Listener",
+					Name: fmt.Sprintf("tcp-%d", DefaultHTTPSPort),
+					Operation: envoygatewayv1alpha1.JSONPatchOperation{
+						Op:       jsonPatchOpAdd,
+						JSONPath: ptr.To(fmt.Sprintf(`..filter_chains[?(@.name=="%s")]`, filterChainName)),
+						Path:     ptr.To("/filters/0/typed_config/http_filters/0"),
+						Value:    &apiextensionsv1.JSON{Raw: filterConfigBytes},
+					},
+				})
+			}
 		}
 
 		if len(jsonPatches) == 0 {
@@ -1247,6 +1456,34 @@ func paranoiaLevelsResolveError(policy *policyContext) *gatewaystatus.PolicyReso
 	}
 }
 
+// policyResolveError returns a resolve error when a policy can't be
+// programmed as configured.
+func (r *TrafficProtectionPolicyReconciler) policyResolveError(policy *policyContext) *gatewaystatus.PolicyResolveError {
+	if resolveErr := paranoiaLevelsResolveError(policy); resolveErr != nil {
+		return resolveErr
+	}
+	if policy.Spec.GeoRuleSet() != nil && !r.Config.Gateway.Coraza.GeoIP.Enabled() {
+		return &gatewaystatus.PolicyResolveError{
+			Reason:  gatewayv1.PolicyReasonInvalid,
+			Message: "Geo rulesets are not supported, no GeoIP database is configured",
+		}
+	}
+	if _, ok := r.corazaRuleBundle(policy); !ok {
+		return &gatewaystatus.PolicyResolveError{
+			Reason:  PolicyReasonUnsupportedVersion,
+			Message: fmt.Sprintf("OWASP Core Rule Set version %q is not available", policy.Spec.CRSVersion),
+		}
+	}
+	return nil
+}
+
+// corazaRuleBundle returns the Coraza rule bundle embedding the OWASP Core
+// Rule Set release pinned by the policy, or the default bundle. It returns
+// false when the release isn't available.
+func (r *TrafficProtectionPolicyReconciler) corazaRuleBundle(policy *policyContext) (config.CorazaRuleBundle, bool) {
+	return reloadableConfig(&r.Config, r.ReloadableConfig).Gateway.Coraza.RuleBundle(policy.Spec.CRSVersion)
+}
+
 func (r *TrafficProtectionPolicyReconciler) getCorazaDirectivesForTrafficProtectionPolicy(
 	policy *policyContext,
 ) []string {
@@ -1257,7 +1494,18 @@ func (r *TrafficProtectionPolicyReconciler) getCorazaDirectivesForTrafficProtect
 			break
 		}
 	}
-	if owaspCRS == nil {
+
+	var geo *networkingv1alpha.GeoRuleSet
+	if r.Config.Gateway.Coraza.GeoIP.Enabled() {
+		geo = policy.Spec.GeoRuleSet()
+	}
+
+	var customRules []string
+	if customRuleSet := policy.Spec.CustomRuleSet(); customRuleSet != nil {
+		customRules = customRuleSet.Directives
+	}
+
+	if owaspCRS == nil && geo == nil && len(customRules) == 0 {
 		return nil
 	}
 
@@ -1269,10 +1517,21 @@ func (r *TrafficProtectionPolicyReconciler) getCorazaDirectivesForTrafficProtect
 		secRuleEngine = "Off"
 	}
 
-	directives := slices.Clone(r.Config.Gateway.Coraza.RouteBaseDirectives)
+	bundle, ok := r.corazaRuleBundle(policy)
+	if !ok {
+		return nil
+	}
+	directives := slices.Clone(bundle.RouteBaseDirectives)
 
 	directives = append(directives, fmt.Sprintf("SecRuleEngine %s", secRuleEngine))
 
+	// Geo rules deny in phase 1, before the anomaly scoring of the CRS.
+	directives = append(directives, geoCorazaDirectives(geo)...)
+
+	if owaspCRS == nil {
+		return append(directives, customRules...)
+	}
+
 	directives = append(directives,
 		fmt.Sprintf(
 			`SecAction "id:900110,phase:1,nolog,pass,t:none,setvar:tx.inbound_anomaly_score_threshold=%d,setvar:tx.outbound_anomaly_score_threshold=%d"`,
@@ -1319,7 +1578,47 @@ func (r *TrafficProtectionPolicyReconciler) getCorazaDirectivesForTrafficProtect
 		}
 	}
 
-	return directives
+	// Custom rules are evaluated after the CRS, so they may update or remove
+	// CRS rules.
+	return append(directives, customRules...)
+}
+
+// geoCorazaDirectives returns the rules that deny requests by the country set
+// in the GeoIPCountryHeader by the geoip filter.
+func geoCorazaDirectives(geo *networkingv1alpha.GeoRuleSet) []string {
+	if geo == nil {
+		return nil
+	}
+
+	if len(geo.DenyCountries) > 0 {
+		return []string{
+			fmt.Sprintf(
+				`SecRule REQUEST_HEADERS:%s "@rx ^(?:%s)$" "id:100010,phase:1,deny,status:403,log,msg:'Request denied by geo ruleset',tag:'datum/geo'"`,
+				config.GeoIPCountryHeader,
+				joinCountryCodes(geo.DenyCountries),
+			),
+		}
+	}
+
+	return []string{
+		fmt.Sprintf(
+			`SecRule &REQUEST_HEADERS:%s "@eq 0" "id:100010,phase:1,deny,status:403,log,msg:'Request country could not be determined',tag:'datum/geo'"`,
+			config.GeoIPCountryHeader,
+		),
+		fmt.Sprintf(
+			`SecRule REQUEST_HEADERS:%s "!@rx ^(?:%s)$" "id:100011,phase:1,deny,status:403,log,msg:'Request denied by geo ruleset',tag:'datum/geo'"`,
+			config.GeoIPCountryHeader,
+			joinCountryCodes(geo.AllowCountries),
+		),
+	}
+}
+
+func joinCountryCodes(codes []networkingv1alpha.CountryCode) string {
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, string(code))
+	}
+	return strings.Join(parts, "|")
 }
 
 func sanitizeJSONPath(jsonPath string) string {
@@ -1338,15 +1637,70 @@ func (r *TrafficProtectionPolicyReconciler) SetupWithManager(mgr mcmanager.Manag
 		r.enqueuePoliciesForCertificate(),
 	)
 
-	return mcbuilder.TypedControllerManagedBy[NamespaceReconcileRequest](mgr).
+	// Watch downstream EnvoyPatchPolicies for the data plane's Programmed
+	// condition.
+	downstreamPolicySource := source.TypedKind(
+		r.DownstreamCluster.GetCache(),
+		&envoygatewayv1alpha1.EnvoyPatchPolicy{},
+		r.enqueuePoliciesForEnvoyPatchPolicy(),
+	)
+
+	builder := mcbuilder.TypedControllerManagedBy[NamespaceReconcileRequest](mgr).
 		Watches(&networkingv1alpha.TrafficProtectionPolicy{}, EnqueueRequestForObjectNamespace).
 		Watches(&gatewayv1.Gateway{}, EnqueueRequestForObjectNamespace).
 		Watches(&gatewayv1.HTTPRoute{}, EnqueueRequestForObjectNamespace).
 		WatchesRawSource(downstreamCertificateSource).
+		WatchesRawSource(downstreamPolicySource)
+
+	// The Coraza directives are programmed on the EnvoyPatchPolicies of every
+	// namespace with policies, so they are reprogrammed when reloaded.
+	if r.ReloadableConfig != nil {
+		builder = builder.WatchesRawSource(source.TypedChannel(
+			configReloadEvents(r.ReloadableConfig, func(cfg *config.NetworkServicesOperator) config.CorazaConfig {
+				return cfg.Gateway.Coraza
+			}),
+			r.enqueuePoliciesForConfigReload(),
+		))
+	}
+
+	return builder.
+		WithOptions(controllerOptions[NamespaceReconcileRequest](r.Config, "trafficprotectionpolicy", 0)).
 		Named("trafficprotectionpolicy").
 		Complete(r)
 }
 
+// enqueuePoliciesForConfigReload returns an event handler that enqueues a
+// reconcile request for every upstream namespace with EnvoyPatchPolicies
+// written by this controller when the configuration is reloaded.
+func (r *TrafficProtectionPolicyReconciler) enqueuePoliciesForConfigReload() handler.TypedEventHandler[*config.NetworkServicesOperator, NamespaceReconcileRequest] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, _ *config.NetworkServicesOperator) []NamespaceReconcileRequest {
+		logger := log.FromContext(ctx)
+
+		var policies envoygatewayv1alpha1.EnvoyPatchPolicyList
+		if err := r.DownstreamCluster.GetClient().List(ctx, &policies, client.MatchingLabels{tppManagedLabel: labelValueTrue}); err != nil {
+			logger.Error(err, "failed to list envoypatchpolicies for config reload")
+			return nil
+		}
+
+		downstreamNamespaces := sets.New[string]()
+		for _, policy := range policies.Items {
+			downstreamNamespaces.Insert(policy.Namespace)
+		}
+
+		var requests []NamespaceReconcileRequest
+		for _, downstreamNamespace := range sets.List(downstreamNamespaces) {
+			namespaceRequests, err := r.upstreamNamespaceRequests(ctx, downstreamNamespace)
+			if err != nil {
+				logger.Error(err, "failed to get upstream namespace for config reload", jsonKeyNamespace, downstreamNamespace)
+				continue
+			}
+			requests = append(requests, namespaceRequests...)
+		}
+		logger.Info("config reloaded, enqueueing trafficprotectionpolicies", "namespaces", len(requests))
+		return requests
+	})
+}
+
 // enqueuePoliciesForCertificate returns an event handler that enqueues a reconcile
 // request for the upstream namespace when a certificate becomes ready.
 func (r *TrafficProtectionPolicyReconciler) enqueuePoliciesForCertificate() handler.TypedEventHandler[*unstructured.Unstructured, NamespaceReconcileRequest] {
@@ -1359,33 +1713,62 @@ func (r *TrafficProtectionPolicyReconciler) enqueuePoliciesForCertificate() hand
 			return nil
 		}
 
-		// Get the downstream namespace to find upstream owner labels
-		downstreamNamespaceName := cert.GetNamespace()
-		var downstreamNamespace corev1.Namespace
-		if err := r.DownstreamCluster.GetClient().Get(ctx, client.ObjectKey{Name: downstreamNamespaceName}, &downstreamNamespace); err != nil {
-			logger.Error(err, "failed to get downstream namespace for certificate", "certificate", cert.GetName(), jsonKeyNamespace, downstreamNamespaceName)
+		requests, err := r.upstreamNamespaceRequests(ctx, cert.GetNamespace())
+		if err != nil {
+			logger.Error(err, "failed to get downstream namespace for certificate", "certificate", cert.GetName(), jsonKeyNamespace, cert.GetNamespace())
+			return nil
+		}
+		if len(requests) > 0 {
+			logger.Info("certificate became ready, enqueueing reconcile", "certificate", cert.GetName(), "upstreamNamespace", requests[0].Namespace)
+		}
+		return requests
+	})
+}
+
+// enqueuePoliciesForEnvoyPatchPolicy returns an event handler that enqueues a
+// reconcile request for the upstream namespace of an EnvoyPatchPolicy written by
+// this controller, so that the policy is rolled back as soon as the data plane
+// rejects it.
+func (r *TrafficProtectionPolicyReconciler) enqueuePoliciesForEnvoyPatchPolicy() handler.TypedEventHandler[*envoygatewayv1alpha1.EnvoyPatchPolicy, NamespaceReconcileRequest] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, policy *envoygatewayv1alpha1.EnvoyPatchPolicy) []NamespaceReconcileRequest {
+		if policy.Labels[tppManagedLabel] != labelValueTrue {
 			return nil
 		}
 
-		// Extract upstream namespace from labels
-		upstreamNamespace := downstreamNamespace.Labels[downstreamclient.UpstreamOwnerNamespaceLabel]
-		if upstreamNamespace == "" {
+		requests, err := r.upstreamNamespaceRequests(ctx, policy.Namespace)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "failed to get downstream namespace for envoypatchpolicy", jsonKeyName, policy.Name, jsonKeyNamespace, policy.Namespace)
 			return nil
 		}
+		return requests
+	})
+}
 
-		// Extract the upstream cluster name so the reconciler can look up the
-		// cluster via mcsingle.Get (which requires clusterName == "single").
-		// The label value is "cluster-<name>" with "/" replaced by "_".
-		clusterLabel := downstreamNamespace.Labels[downstreamclient.UpstreamOwnerClusterNameLabel]
-		upstreamClusterName := multicluster.ClusterName(downstreamclient.UpstreamClusterNameFromLabel(clusterLabel))
+// upstreamNamespaceRequests returns the reconcile request for the upstream
+// namespace that a downstream namespace is mapped from, if any.
+func (r *TrafficProtectionPolicyReconciler) upstreamNamespaceRequests(ctx context.Context, downstreamNamespaceName string) ([]NamespaceReconcileRequest, error) {
+	// Get the downstream namespace to find upstream owner labels
+	var downstreamNamespace corev1.Namespace
+	if err := r.DownstreamCluster.GetClient().Get(ctx, client.ObjectKey{Name: downstreamNamespaceName}, &downstreamNamespace); err != nil {
+		return nil, err
+	}
 
-		logger.Info("certificate became ready, enqueueing reconcile", "certificate", cert.GetName(), "upstreamNamespace", upstreamNamespace)
+	// Extract upstream namespace from labels
+	upstreamNamespace := downstreamNamespace.Labels[downstreamclient.UpstreamOwnerNamespaceLabel]
+	if upstreamNamespace == "" {
+		return nil, nil
+	}
 
-		return []NamespaceReconcileRequest{{
-			Namespace:   upstreamNamespace,
-			ClusterName: upstreamClusterName,
-		}}
-	})
+	// Extract the upstream cluster name so the reconciler can look up the
+	// cluster via mcsingle.Get (which requires clusterName == "single").
+	// The label value is "cluster-<name>" with "/" replaced by "_".
+	clusterLabel := downstreamNamespace.Labels[downstreamclient.UpstreamOwnerClusterNameLabel]
+	upstreamClusterName := multicluster.ClusterName(downstreamclient.UpstreamClusterNameFromLabel(clusterLabel))
+
+	return []NamespaceReconcileRequest{{
+		Namespace:   upstreamNamespace,
+		ClusterName: upstreamClusterName,
+	}}, nil
 }
 
 var EnqueueRequestForObjectNamespace = mchandler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []NamespaceReconcileRequest {
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	envoygatewayv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -25,6 +28,7 @@ import (
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
 	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 )
 
@@ -780,6 +784,192 @@ func TestParanoiaLevelsResolveError(t *testing.T) {
 	}
 }
 
+func TestGeoRuleSetResolveError(t *testing.T) {
+	withGeo := func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+		tpp.Spec.RuleSets = append(tpp.Spec.RuleSets, networkingv1alpha.TrafficProtectionPolicyRuleSet{
+			Type: networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+			Geo:  &networkingv1alpha.GeoRuleSet{DenyCountries: []networkingv1alpha.CountryCode{"XX"}},
+		})
+	}
+
+	tests := []struct {
+		name         string
+		databasePath string
+		mutate       func(*networkingv1alpha.TrafficProtectionPolicy)
+		wantError    bool
+	}{
+		{name: "no geo ruleset", mutate: func(*networkingv1alpha.TrafficProtectionPolicy) {}},
+		{name: "geo ruleset without database", mutate: withGeo, wantError: true},
+		{name: "geo ruleset with database", databasePath: "/geoip/country.mmdb", mutate: withGeo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := &TrafficProtectionPolicyReconciler{
+				Config: config.NetworkServicesOperator{
+					Gateway: config.GatewayConfig{
+						Coraza: config.CorazaConfig{GeoIP: config.GeoIPConfig{CountryDatabasePath: tt.databasePath}},
+					},
+				},
+			}
+			policy := &policyContext{
+				TrafficProtectionPolicy: ptr.To(newTrafficProtectionPolicy("default", "tpp-1", tt.mutate)),
+			}
+
+			resolveErr := reconciler.policyResolveError(policy)
+			if tt.wantError {
+				if assert.NotNil(t, resolveErr) {
+					assert.Equal(t, gatewayv1.PolicyReasonInvalid, resolveErr.Reason)
+				}
+			} else {
+				assert.Nil(t, resolveErr)
+			}
+		})
+	}
+}
+
+func TestPolicyResolveErrorCRSVersion(t *testing.T) {
+	reconciler := &TrafficProtectionPolicyReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{
+				Coraza: config.CorazaConfig{
+					CRSVersion:  "4.7.0",
+					RuleBundles: []config.CorazaRuleBundle{{CRSVersion: "4.10.0", LibraryPath: "/opt/coraza-waf/coraza-waf-4.10.0.so"}},
+				},
+			},
+		},
+	}
+
+	for _, version := range []string{"", "4.7.0", "4.10.0"} {
+		policy := &policyContext{
+			TrafficProtectionPolicy: ptr.To(newTrafficProtectionPolicy("default", "tpp-1", func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+				tpp.Spec.CRSVersion = version
+			})),
+		}
+		assert.Nilf(t, reconciler.policyResolveError(policy), "crsVersion %q", version)
+	}
+
+	policy := &policyContext{
+		TrafficProtectionPolicy: ptr.To(newTrafficProtectionPolicy("default", "tpp-1", func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+			tpp.Spec.CRSVersion = "3.3.0"
+		})),
+	}
+	resolveErr := reconciler.policyResolveError(policy)
+	if assert.NotNil(t, resolveErr) {
+		assert.Equal(t, PolicyReasonUnsupportedVersion, resolveErr.Reason)
+	}
+	assert.Nil(t, reconciler.getCorazaDirectivesForTrafficProtectionPolicy(policy))
+}
+
+func TestGetListenerFilterConfigs(t *testing.T) {
+	filterNames := func(databasePath string) []string {
+		reconciler := &TrafficProtectionPolicyReconciler{
+			Config: config.NetworkServicesOperator{
+				Gateway: config.GatewayConfig{
+					Coraza: config.CorazaConfig{
+						FilterName: "coraza-waf",
+						GeoIP:      config.GeoIPConfig{CountryDatabasePath: databasePath},
+					},
+				},
+			},
+		}
+		filterConfigs, err := reconciler.getListenerFilterConfigs()
+		assert.NoError(t, err)
+
+		var names []string
+		for _, filterConfig := range filterConfigs {
+			var filter map[string]any
+			assert.NoError(t, json.Unmarshal(filterConfig, &filter))
+			names = append(names, filter[jsonKeyName].(string))
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"coraza-waf"}, filterNames(""))
+	// Each filter is inserted at the start of the chain, so the header strip
+	// filter runs first, followed by the geoip filter and Coraza.
+	assert.Equal(t, []string{"coraza-waf", config.GeoIPFilterName, config.GeoIPHeaderStripFilterName}, filterNames("/geoip/country.mmdb"))
+}
+
+func TestGetListenerFilterConfigsWithRuleBundles(t *testing.T) {
+	reconciler := &TrafficProtectionPolicyReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{
+				Coraza: config.CorazaConfig{
+					LibraryID:   "coraza-waf",
+					LibraryPath: "/opt/coraza-waf/coraza-waf.so",
+					FilterName:  "coraza-waf",
+					PluginName:  "coraza-waf",
+					RuleBundles: []config.CorazaRuleBundle{{CRSVersion: "4.10.0", LibraryPath: "/opt/coraza-waf/coraza-waf-4.10.0.so"}},
+				},
+			},
+		},
+	}
+	filterConfigs, err := reconciler.getListenerFilterConfigs()
+	require.NoError(t, err)
+	require.Len(t, filterConfigs, 2)
+
+	libraryPaths := map[string]string{}
+	for _, filterConfig := range filterConfigs {
+		var filter struct {
+			Name        string `json:"name"`
+			Disabled    bool   `json:"disabled"`
+			TypedConfig struct {
+				LibraryID   string `json:"library_id"`
+				LibraryPath string `json:"library_path"`
+			} `json:"typed_config"`
+		}
+		require.NoError(t, json.Unmarshal(filterConfig, &filter))
+		assert.True(t, filter.Disabled)
+		libraryPaths[filter.Name] = filter.TypedConfig.LibraryPath
+	}
+	assert.Equal(t, map[string]string{
+		"coraza-waf":        "/opt/coraza-waf/coraza-waf.so",
+		"coraza-waf-4.10.0": "/opt/coraza-waf/coraza-waf-4.10.0.so",
+	}, libraryPaths)
+}
+
+func TestGetDesiredEnvoyPatchPoliciesWithCRSVersion(t *testing.T) {
+	operatorConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			TargetDomain:               "example.com",
+			DownstreamGatewayClassName: "test-gateway-class",
+			Coraza: config.CorazaConfig{
+				FilterName:  "coraza-waf",
+				PluginName:  "coraza-waf",
+				RuleBundles: []config.CorazaRuleBundle{{CRSVersion: "4.10.0", LibraryPath: "/opt/coraza-waf/coraza-waf-4.10.0.so"}},
+			},
+		},
+	}
+	reconciler := &TrafficProtectionPolicyReconciler{Config: operatorConfig}
+
+	policy := &policyContext{
+		TrafficProtectionPolicy: ptr.To(newTrafficProtectionPolicy("default", "tpp-1", func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+			tpp.Spec.CRSVersion = "4.10.0"
+		})),
+	}
+	patchPolicies, err := reconciler.getDesiredEnvoyPatchPolicies("test-namespace", []policyAttachment{
+		{
+			Policy:           policy,
+			Gateway:          newGateway(operatorConfig, "default", "gateway-1"),
+			CorazaDirectives: []string{"SecRuleEngine On"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, patchPolicies, 1)
+
+	var corazaPaths []string
+	for _, patch := range patchPolicies[0].Spec.JSONPatches {
+		if path := ptr.Deref(patch.Operation.Path, ""); strings.HasPrefix(path, "/typed_per_filter_config") {
+			corazaPaths = append(corazaPaths, path)
+		}
+	}
+	require.NotEmpty(t, corazaPaths)
+	for _, path := range corazaPaths {
+		assert.Equal(t, "/typed_per_filter_config/coraza-waf-4.10.0", path)
+	}
+}
+
 func TestGetDesiredEnvoyPatchPolicies(t *testing.T) {
 
 	operatorConfig := config.NetworkServicesOperator{
@@ -1085,6 +1275,47 @@ func TestGetCorazaDirectivesForTrafficProtectionPolicy(t *testing.T) {
 				"SecRuleRemoveById \"1000-2000\"",
 			},
 		},
+		{
+			name: "custom rules after OWASP CRS",
+			policy: newTrafficProtectionPolicy("default", "tpp-1", func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+				tpp.Spec.RuleSets = append(tpp.Spec.RuleSets, networkingv1alpha.TrafficProtectionPolicyRuleSet{
+					Type: networkingv1alpha.TrafficProtectionPolicyCustomRuleSet,
+					CustomRules: &networkingv1alpha.CustomRuleSet{
+						Directives: []string{`SecRule REQUEST_URI "@beginsWith /admin" "id:1000,phase:1,deny,status:403"`},
+					},
+				})
+			}),
+			expectedCorazaDirectives: []string{
+				"Include @crs-setup-conf",
+				"Include @recommended-conf",
+				"SecRuleEngine DetectionOnly",
+				"SecAction \"id:900110,phase:1,nolog,pass,t:none,setvar:tx.inbound_anomaly_score_threshold=5,setvar:tx.outbound_anomaly_score_threshold=4\"",
+				"SecAction \"id:900000,phase:1,pass,t:none,nolog,tag:'OWASP_CRS',setvar:tx.blocking_paranoia_level=1\"",
+				"SecAction \"id:900001,phase:1,pass,t:none,nolog,tag:'OWASP_CRS',setvar:tx.detection_paranoia_level=1\"",
+				"Include @owasp_crs/*.conf",
+				`SecRule REQUEST_URI "@beginsWith /admin" "id:1000,phase:1,deny,status:403"`,
+			},
+		},
+		{
+			name: "custom rules only",
+			policy: newTrafficProtectionPolicy("default", "tpp-1", func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+				tpp.Spec.Mode = networkingv1alpha.TrafficProtectionPolicyEnforce
+				tpp.Spec.RuleSets = []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+					{
+						Type: networkingv1alpha.TrafficProtectionPolicyCustomRuleSet,
+						CustomRules: &networkingv1alpha.CustomRuleSet{
+							Directives: []string{`SecRule REQUEST_URI "@beginsWith /admin" "id:1000,phase:1,deny,status:403"`},
+						},
+					},
+				}
+			}),
+			expectedCorazaDirectives: []string{
+				"Include @crs-setup-conf",
+				"Include @recommended-conf",
+				"SecRuleEngine On",
+				`SecRule REQUEST_URI "@beginsWith /admin" "id:1000,phase:1,deny,status:403"`,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1097,6 +1328,72 @@ func TestGetCorazaDirectivesForTrafficProtectionPolicy(t *testing.T) {
 	}
 }
 
+func TestGetCorazaDirectivesForGeoRuleSet(t *testing.T) {
+	tests := []struct {
+		name                     string
+		databasePath             string
+		ruleSets                 []networkingv1alpha.TrafficProtectionPolicyRuleSet
+		expectedCorazaDirectives []string
+	}{
+		{
+			name:         "deny countries",
+			databasePath: "/geoip/country.mmdb",
+			ruleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+				{
+					Type: networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+					Geo:  &networkingv1alpha.GeoRuleSet{DenyCountries: []networkingv1alpha.CountryCode{"XX", "YY"}},
+				},
+			},
+			expectedCorazaDirectives: []string{
+				"SecRuleEngine On",
+				`SecRule REQUEST_HEADERS:x-datum-geo-country "@rx ^(?:XX|YY)$" "id:100010,phase:1,deny,status:403,log,msg:'Request denied by geo ruleset',tag:'datum/geo'"`,
+			},
+		},
+		{
+			name:         "allow countries",
+			databasePath: "/geoip/country.mmdb",
+			ruleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+				{
+					Type: networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+					Geo:  &networkingv1alpha.GeoRuleSet{AllowCountries: []networkingv1alpha.CountryCode{"XX"}},
+				},
+			},
+			expectedCorazaDirectives: []string{
+				"SecRuleEngine On",
+				`SecRule &REQUEST_HEADERS:x-datum-geo-country "@eq 0" "id:100010,phase:1,deny,status:403,log,msg:'Request country could not be determined',tag:'datum/geo'"`,
+				`SecRule REQUEST_HEADERS:x-datum-geo-country "!@rx ^(?:XX)$" "id:100011,phase:1,deny,status:403,log,msg:'Request denied by geo ruleset',tag:'datum/geo'"`,
+			},
+		},
+		{
+			name: "geo ruleset without database",
+			ruleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+				{
+					Type: networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+					Geo:  &networkingv1alpha.GeoRuleSet{DenyCountries: []networkingv1alpha.CountryCode{"XX"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := &TrafficProtectionPolicyReconciler{
+				Config: config.NetworkServicesOperator{
+					Gateway: config.GatewayConfig{
+						Coraza: config.CorazaConfig{GeoIP: config.GeoIPConfig{CountryDatabasePath: tt.databasePath}},
+					},
+				},
+			}
+			policy := newTrafficProtectionPolicy("default", "tpp-1", func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+				tpp.Spec.Mode = networkingv1alpha.TrafficProtectionPolicyEnforce
+				tpp.Spec.RuleSets = tt.ruleSets
+			})
+			corazaDirectives := reconciler.getCorazaDirectivesForTrafficProtectionPolicy(&policyContext{ptr.To(policy)})
+			assert.EqualValues(t, tt.expectedCorazaDirectives, corazaDirectives)
+		})
+	}
+}
+
 func TestGetCorazaDirectivesDoesNotAliasRouteBaseDirectives(t *testing.T) {
 	base := make([]string, 2, 8)
 	base[0] = "Include @crs-setup-conf"
@@ -1399,6 +1696,36 @@ func TestSetWaitingForCertificatesConditions(t *testing.T) {
 	}
 }
 
+func TestCountTPPAncestors(t *testing.T) {
+	controllerName := gatewayv1.GatewayController("datumapis.com/network-services-gateway")
+	reconciler := &TrafficProtectionPolicyReconciler{Config: config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{ControllerName: controllerName},
+	}}
+
+	ancestor := func(name string, controller gatewayv1.GatewayController, accepted metav1.ConditionStatus) gatewayv1.PolicyAncestorStatus {
+		return gatewayv1.PolicyAncestorStatus{
+			AncestorRef:    gatewayv1.ParentReference{Name: gatewayv1.ObjectName(name)},
+			ControllerName: controller,
+			Conditions: []metav1.Condition{{
+				Type:   string(gatewayv1.PolicyConditionAccepted),
+				Status: accepted,
+			}},
+		}
+	}
+
+	policy := newTrafficProtectionPolicy("default", "tpp-1", func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+		tpp.Status.Ancestors = []gatewayv1.PolicyAncestorStatus{
+			ancestor("gateway-1", controllerName, metav1.ConditionTrue),
+			ancestor("gateway-2", controllerName, metav1.ConditionFalse),
+			ancestor("gateway-3", "example.com/other-controller", metav1.ConditionTrue),
+		}
+	})
+
+	targets, accepted := reconciler.countTPPAncestors(&policy)
+	assert.Equal(t, int32(2), targets)
+	assert.Equal(t, int32(1), accepted)
+}
+
 func TestCheckHTTPSListenersProgrammed(t *testing.T) {
 	operatorConfig := config.NetworkServicesOperator{
 		Gateway: config.GatewayConfig{
@@ -1596,6 +1923,56 @@ func TestSetWaitingForListenersProgrammedConditions(t *testing.T) {
 	}
 }
 
+func TestSetPolicyProgrammingFailedConditions(t *testing.T) {
+	operatorConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			ControllerName: gatewayv1.GatewayController("datumapis.com/network-services-gateway"),
+		},
+	}
+
+	reconciler := &TrafficProtectionPolicyReconciler{Config: operatorConfig}
+
+	policy := &policyContext{
+		TrafficProtectionPolicy: ptr.To(newTrafficProtectionPolicy("default", "tpp-1", func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+			tpp.Spec.TargetRefs = []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+						Kind: "Gateway",
+						Name: "gateway-1",
+					},
+				},
+			}
+		})),
+	}
+	attachments := []policyAttachment{
+		{
+			Policy:  policy,
+			Gateway: &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway-1"}},
+		},
+	}
+
+	reconciler.setPolicyProgrammingFailedConditions([]*policyContext{policy}, attachments, map[string]*envoyPatchPolicyRejection{
+		"tpp-gateway-1": {Reason: "ResourceNotFound", Message: "unable to match JSONPath"},
+	})
+
+	if assert.Len(t, policy.Status.Ancestors, 1) {
+		ancestor := policy.Status.Ancestors[0]
+		if assert.Len(t, ancestor.Conditions, 1) {
+			cond := ancestor.Conditions[0]
+			assert.Equal(t, string(PolicyConditionProgrammingFailed), cond.Type)
+			assert.Equal(t, metav1.ConditionTrue, cond.Status)
+			assert.Equal(t, "ResourceNotFound", cond.Reason)
+			assert.Contains(t, cond.Message, "unable to match JSONPath")
+		}
+	}
+
+	// The condition is removed once the policy is no longer rejected.
+	reconciler.setPolicyProgrammingFailedConditions([]*policyContext{policy}, attachments, nil)
+	if assert.Len(t, policy.Status.Ancestors, 1) {
+		assert.Empty(t, policy.Status.Ancestors[0].Conditions)
+	}
+}
+
 func newCertificateUnstructured(namespace, name string, isReady bool) *unstructured.Unstructured {
 	cert := &unstructured.Unstructured{}
 	cert.SetGroupVersionKind(certificateGVK)
@@ -1782,3 +2159,102 @@ func TestTPPReconcileStaleCleanupPreservesConnectorEPPs(t *testing.T) {
 	err = fakeDownstreamClient.Get(ctx, client.ObjectKey{Name: "tpp-stale-gw", Namespace: downstreamNS}, stale)
 	assert.True(t, apierrors.IsNotFound(err), "stale tpp EPP must be deleted by stale cleanup")
 }
+
+func TestTPPReconcileConfigReload(t *testing.T) {
+	upstreamScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(upstreamScheme))
+	require.NoError(t, gatewayv1.Install(upstreamScheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(upstreamScheme))
+
+	downstreamScheme := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(downstreamScheme))
+	require.NoError(t, envoygatewayv1alpha1.AddToScheme(downstreamScheme))
+
+	const (
+		upstreamNS   = "default"
+		nsUID        = "test-ns-uid"
+		downstreamNS = "ns-" + nsUID
+	)
+
+	fakeUpstreamClient := fake.NewClientBuilder().
+		WithScheme(upstreamScheme).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: upstreamNS, UID: nsUID}}).
+		Build()
+	fakeDownstreamClient := fake.NewClientBuilder().
+		WithScheme(downstreamScheme).
+		WithObjects(
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: downstreamNS,
+					Labels: map[string]string{
+						downstreamclient.UpstreamOwnerNamespaceLabel:   upstreamNS,
+						downstreamclient.UpstreamOwnerClusterNameLabel: downstreamclient.UpstreamClusterNameLabelValue("test-cluster"),
+					},
+				},
+			},
+			&envoygatewayv1alpha1.EnvoyPatchPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: downstreamNS,
+					Name:      "tpp-gw",
+					Labels:    map[string]string{tppManagedLabel: labelValueTrue},
+				},
+			},
+		).
+		Build()
+
+	operatorConfig := config.NetworkServicesOperator{
+		Gateway: config.GatewayConfig{
+			DownstreamGatewayNamespace: "envoy-gateway-system",
+			Coraza: config.CorazaConfig{
+				ListenerDirectives: []string{"SecRuleEngine DetectionOnly"},
+			},
+		},
+	}
+	live := config.NewReloadable(operatorConfig)
+	reloads := configReloadEvents(live, func(cfg *config.NetworkServicesOperator) config.CorazaConfig {
+		return cfg.Gateway.Coraza
+	})
+
+	reconciler := &TrafficProtectionPolicyReconciler{
+		mgr:               &fakeMockManager{cl: fakeUpstreamClient},
+		DownstreamCluster: &fakeCluster{cl: fakeDownstreamClient},
+		Config:            operatorConfig,
+		ReloadableConfig:  live,
+	}
+
+	ctx := context.Background()
+	listenerPolicyKey := client.ObjectKey{Namespace: "envoy-gateway-system", Name: "coraza-tcp-80"}
+	listenerDirectives := func() string {
+		t.Helper()
+		var policy envoygatewayv1alpha1.EnvoyPatchPolicy
+		require.NoError(t, fakeDownstreamClient.Get(ctx, listenerPolicyKey, &policy))
+		require.NotEmpty(t, policy.Spec.JSONPatches)
+		return string(policy.Spec.JSONPatches[0].Operation.Value.Raw)
+	}
+
+	require.NoError(t, reconciler.ensureHTTPCorazaListenerFilter(ctx))
+	assert.Contains(t, listenerDirectives(), "SecRuleEngine DetectionOnly")
+
+	// A reload of other fields does not requeue the policies.
+	next := *operatorConfig.DeepCopy()
+	next.Gateway.ClusterIssuerMap = map[string]string{"auto": "letsencrypt"}
+	_, err := live.Apply(next)
+	require.NoError(t, err)
+	assert.Empty(t, reloads)
+
+	next.Gateway.Coraza.ListenerDirectives = []string{"SecRuleEngine On"}
+	_, err = live.Apply(next)
+	require.NoError(t, err)
+	require.Len(t, reloads, 1)
+
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[NamespaceReconcileRequest]())
+	defer queue.ShutDown()
+	reconciler.enqueuePoliciesForConfigReload().Generic(ctx, <-reloads, queue)
+	require.Equal(t, 1, queue.Len())
+	req, _ := queue.Get()
+	assert.Equal(t, NamespaceReconcileRequest{Namespace: upstreamNS, ClusterName: "test-cluster"}, req)
+
+	_, err = reconciler.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Contains(t, listenerDirectives(), "SecRuleEngine On")
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/wafevents"
+)
+
+const (
+	// trafficProtectionPolicyEventsWindow is the period covered by the events
+	// in the status of a TrafficProtectionPolicy.
+	trafficProtectionPolicyEventsWindow = time.Hour
+
+	defaultTrafficProtectionPolicyEventsRefreshInterval = 5 * time.Minute
+)
+
+// refreshTrafficProtectionPolicyEvents updates the events in the status of the
+// policies that are due for a refresh, and returns when the next refresh is
+// due. It returns 0 when WAF event reporting is disabled.
+func (r *TrafficProtectionPolicyReconciler) refreshTrafficProtectionPolicyEvents(
+	ctx context.Context,
+	clusterName string,
+	policies []*policyContext,
+) time.Duration {
+	if r.WAFEvents == nil || len(policies) == 0 {
+		return 0
+	}
+	logger := log.FromContext(ctx)
+
+	refreshInterval := defaultTrafficProtectionPolicyEventsRefreshInterval
+	if interval := r.Config.Gateway.Coraza.Events.RefreshInterval; interval != nil && interval.Duration > 0 {
+		refreshInterval = interval.Duration
+	}
+
+	now := time.Now()
+	requeueAfter := refreshInterval
+	for _, policy := range policies {
+		if events := policy.Status.Events; events != nil {
+			if age := now.Sub(events.ObservedTime.Time); age >= 0 && age < refreshInterval {
+				requeueAfter = min(requeueAfter, refreshInterval-age)
+				continue
+			}
+		}
+
+		summary, err := r.WAFEvents.Summarize(ctx, wafevents.PolicyRef{
+			Project:   wafevents.ProjectForClusterName(clusterName),
+			Namespace: policy.Namespace,
+			Name:      policy.Name,
+		}, trafficProtectionPolicyEventsWindow)
+		if err != nil {
+			// The events last reported are kept until the next refresh succeeds.
+			logger.Error(err, "failed to summarize WAF events", "trafficprotectionpolicy", policy.Name)
+			continue
+		}
+
+		policy.Status.Events = trafficProtectionPolicyEvents(summary, now)
+	}
+
+	return requeueAfter
+}
+
+func trafficProtectionPolicyEvents(summary *wafevents.Summary, observedTime time.Time) *networkingv1alpha.TrafficProtectionPolicyEvents {
+	events := &networkingv1alpha.TrafficProtectionPolicyEvents{
+		Window:       metav1.Duration{Duration: trafficProtectionPolicyEventsWindow},
+		ObservedTime: metav1.NewTime(observedTime.Truncate(time.Second)),
+		Blocked:      summary.Blocked,
+		Detected:     summary.Detected,
+	}
+	for _, rule := range summary.TopRules {
+		events.TopRules = append(events.TopRules, networkingv1alpha.TrafficProtectionPolicyRuleEvents{
+			RuleID: rule.RuleID,
+			Count:  rule.Count,
+		})
+	}
+	if summary.LastEventTime != nil {
+		events.LastEventTime = ptr.To(metav1.NewTime(*summary.LastEventTime))
+	}
+	return events
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/wafevents"
+)
+
+type fakeWAFEventSource struct {
+	summaries map[string]*wafevents.Summary
+	refs      []wafevents.PolicyRef
+}
+
+func (s *fakeWAFEventSource) Summarize(_ context.Context, policy wafevents.PolicyRef, _ time.Duration) (*wafevents.Summary, error) {
+	s.refs = append(s.refs, policy)
+	summary, ok := s.summaries[policy.Name]
+	if !ok {
+		return nil, errors.New("query failed")
+	}
+	return summary, nil
+}
+
+func TestRefreshTrafficProtectionPolicyEvents(t *testing.T) {
+	lastEventTime := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	source := &fakeWAFEventSource{
+		summaries: map[string]*wafevents.Summary{
+			"stale": {
+				Blocked:       12,
+				Detected:      3,
+				TopRules:      []wafevents.RuleCount{{RuleID: "942100", Count: 11}},
+				LastEventTime: &lastEventTime,
+			},
+		},
+	}
+	reconciler := &TrafficProtectionPolicyReconciler{
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{
+				Coraza: config.CorazaConfig{
+					Events: config.WAFEventsConfig{RefreshInterval: &metav1.Duration{Duration: 5 * time.Minute}},
+				},
+			},
+		},
+		WAFEvents: source,
+	}
+
+	previousEvents := func(age time.Duration) *networkingv1alpha.TrafficProtectionPolicyEvents {
+		return &networkingv1alpha.TrafficProtectionPolicyEvents{
+			Window:       metav1.Duration{Duration: time.Hour},
+			ObservedTime: metav1.NewTime(time.Now().Add(-age)),
+			Blocked:      1,
+		}
+	}
+	newPolicy := func(name string, events *networkingv1alpha.TrafficProtectionPolicyEvents) *policyContext {
+		policy := newTrafficProtectionPolicy("default", name)
+		policy.Status.Events = events
+		return &policyContext{TrafficProtectionPolicy: ptr.To(policy)}
+	}
+
+	fresh := newPolicy("fresh", previousEvents(time.Minute))
+	stale := newPolicy("stale", previousEvents(10*time.Minute))
+	failing := newPolicy("failing", previousEvents(10*time.Minute))
+
+	requeueAfter := reconciler.refreshTrafficProtectionPolicyEvents(context.Background(), "/project", []*policyContext{fresh, stale, failing})
+
+	assert.InDelta(t, 4*time.Minute, requeueAfter, float64(time.Second), "requeue when the fresh events are due")
+	assert.Equal(t, []wafevents.PolicyRef{
+		{Project: "_project", Namespace: "default", Name: "stale"},
+		{Project: "_project", Namespace: "default", Name: "failing"},
+	}, source.refs)
+
+	assert.Equal(t, int64(1), fresh.Status.Events.Blocked, "fresh events are not refreshed")
+	assert.Equal(t, int64(1), failing.Status.Events.Blocked, "events are kept when the refresh fails")
+
+	if assert.NotNil(t, stale.Status.Events) {
+		assert.Equal(t, int64(12), stale.Status.Events.Blocked)
+		assert.Equal(t, int64(3), stale.Status.Events.Detected)
+		assert.Equal(t, []networkingv1alpha.TrafficProtectionPolicyRuleEvents{{RuleID: "942100", Count: 11}}, stale.Status.Events.TopRules)
+		assert.Equal(t, ptr.To(metav1.NewTime(lastEventTime)), stale.Status.Events.LastEventTime)
+		assert.WithinDuration(t, time.Now(), stale.Status.Events.ObservedTime.Time, 2*time.Second)
+	}
+}
+
+func TestRefreshTrafficProtectionPolicyEventsDisabled(t *testing.T) {
+	reconciler := &TrafficProtectionPolicyReconciler{}
+	policy := &policyContext{TrafficProtectionPolicy: ptr.To(newTrafficProtectionPolicy("default", "tpp"))}
+
+	assert.Zero(t, reconciler.refreshTrafficProtectionPolicyEvents(context.Background(), "/project", []*policyContext{policy}))
+	assert.Nil(t, policy.Status.Events)
+}
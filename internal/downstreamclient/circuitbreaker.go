@@ -0,0 +1,305 @@
+package downstreamclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"k8s.io/client-go/rest"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// ErrCircuitOpen is returned for requests that are rejected because the
+// circuit breaker for the cluster is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState string
+
+const (
+	// CircuitClosed lets all requests through.
+	CircuitClosed CircuitState = "Closed"
+	// CircuitOpen rejects non-critical writes.
+	CircuitOpen CircuitState = "Open"
+	// CircuitHalfOpen lets a single probe request through to decide whether
+	// the breaker should close or open again.
+	CircuitHalfOpen CircuitState = "HalfOpen"
+)
+
+var circuitStates = []CircuitState{CircuitClosed, CircuitOpen, CircuitHalfOpen}
+
+var (
+	circuitBreakerState = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nso_downstream_circuit_breaker_state",
+			Help: "1 for the current state of the downstream cluster circuit breaker, 0 otherwise.",
+		},
+		[]string{"cluster", "state"},
+	)
+
+	circuitBreakerRejectedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nso_downstream_circuit_breaker_rejected_requests_total",
+			Help: "Total requests to the downstream cluster rejected by an open circuit breaker.",
+		},
+		[]string{"cluster"},
+	)
+)
+
+type criticalWriteKey struct{}
+
+// WithCriticalWrites returns a context whose requests are let through while the
+// circuit breaker is open. It should only be used for writes that must not be
+// delayed, such as removing downstream resources of deleted upstream objects.
+func WithCriticalWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, criticalWriteKey{}, true)
+}
+
+func isCriticalWrite(ctx context.Context) bool {
+	v, _ := ctx.Value(criticalWriteKey{}).(bool)
+	return v
+}
+
+// CircuitBreaker tracks the error rate of requests to a cluster's API server
+// and stops non-critical writes while the error rate is above the configured
+// threshold.
+//
+// Reads are always let through, as they are needed by informers to recover
+// and are mostly served from cache.
+type CircuitBreaker struct {
+	cluster string
+	config  config.CircuitBreakerConfig
+	now     func() time.Time
+
+	mu          sync.Mutex
+	state       CircuitState
+	windowStart time.Time
+	requests    int32
+	failures    int32
+	openedAt    time.Time
+	probing     bool
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker for the named cluster.
+func NewCircuitBreaker(cluster string, cfg config.CircuitBreakerConfig) *CircuitBreaker {
+	b := &CircuitBreaker{
+		cluster: cluster,
+		config:  cfg,
+		now:     time.Now,
+		state:   CircuitClosed,
+	}
+	b.windowStart = b.now()
+	b.setStateMetric()
+	return b
+}
+
+// CircuitBreakers holds a CircuitBreaker for each downstream cluster, so that
+// a failing API server only pauses writes to its own cluster.
+type CircuitBreakers struct {
+	config config.CircuitBreakerConfig
+
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+// NewCircuitBreakers returns an empty set of circuit breakers that share cfg.
+func NewCircuitBreakers(cfg config.CircuitBreakerConfig) *CircuitBreakers {
+	return &CircuitBreakers{
+		config:   cfg,
+		breakers: map[string]*CircuitBreaker{},
+	}
+}
+
+// For returns the breaker of the named cluster, creating it on first use. A
+// nil set returns a nil breaker.
+func (c *CircuitBreakers) For(cluster string) *CircuitBreaker {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.breakers[cluster]
+	if !ok {
+		b = NewCircuitBreaker(cluster, c.config)
+		c.breakers[cluster] = b
+	}
+	return b
+}
+
+// Wrap wraps the transport of restConfig with the breaker of the named
+// cluster, and returns the breaker. A nil set leaves restConfig as is.
+func (c *CircuitBreakers) Wrap(cluster string, restConfig *rest.Config) *CircuitBreaker {
+	b := c.For(cluster)
+	if b != nil {
+		restConfig.Wrap(b.WrapTransport)
+	}
+	return b
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advanceLocked()
+	return b.state
+}
+
+// RetryAfter returns how long until an open breaker lets a probe request
+// through. It returns zero when the breaker is not open.
+func (b *CircuitBreaker) RetryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advanceLocked()
+	if b.state != CircuitOpen {
+		return 0
+	}
+	return b.openedAt.Add(b.config.OpenDuration.Duration).Sub(b.now())
+}
+
+// WrapTransport wraps a rest.Config transport with the circuit breaker.
+func (b *CircuitBreaker) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		probe, err := b.before(req)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := rt.RoundTrip(req)
+		b.after(req, probe, resp, err)
+		return resp, err
+	})
+}
+
+func (b *CircuitBreaker) before(req *http.Request) (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advanceLocked()
+
+	switch b.state {
+	case CircuitHalfOpen:
+		if !b.probing {
+			b.probing = true
+			return true, nil
+		}
+	case CircuitClosed:
+		return false, nil
+	}
+
+	if !isWrite(req) || isCriticalWrite(req.Context()) {
+		return false, nil
+	}
+
+	circuitBreakerRejectedTotal.WithLabelValues(b.cluster).Inc()
+	return false, fmt.Errorf("%s %s on cluster %q: %w", req.Method, req.URL.Path, b.cluster, ErrCircuitOpen)
+}
+
+func (b *CircuitBreaker) after(req *http.Request, probe bool, resp *http.Response, err error) {
+	// A request the caller gave up on says nothing about the health of the API
+	// server.
+	canceled := err != nil && req.Context().Err() != nil
+	failed := isFailure(resp, err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+		if canceled {
+			return
+		}
+		if failed {
+			b.openLocked()
+		} else {
+			b.closeLocked()
+		}
+		return
+	}
+
+	if canceled || b.state != CircuitClosed {
+		return
+	}
+
+	b.requests++
+	if failed {
+		b.failures++
+	}
+	if b.requests >= b.config.MinimumRequests && b.failures*100 >= b.requests*b.config.ErrorRateThreshold {
+		b.openLocked()
+	}
+}
+
+// advanceLocked moves an open breaker to half-open once OpenDuration has
+// elapsed, and starts a new window for a closed breaker once Window has
+// elapsed.
+func (b *CircuitBreaker) advanceLocked() {
+	now := b.now()
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) >= b.config.OpenDuration.Duration {
+			b.state = CircuitHalfOpen
+			b.setStateMetric()
+		}
+	case CircuitClosed:
+		if now.Sub(b.windowStart) >= b.config.Window.Duration {
+			b.resetWindowLocked(now)
+		}
+	}
+}
+
+func (b *CircuitBreaker) openLocked() {
+	b.state = CircuitOpen
+	b.openedAt = b.now()
+	b.setStateMetric()
+}
+
+func (b *CircuitBreaker) closeLocked() {
+	b.state = CircuitClosed
+	b.resetWindowLocked(b.now())
+	b.setStateMetric()
+}
+
+func (b *CircuitBreaker) resetWindowLocked(now time.Time) {
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
+
+func (b *CircuitBreaker) setStateMetric() {
+	for _, state := range circuitStates {
+		v := 0.0
+		if state == b.state {
+			v = 1
+		}
+		circuitBreakerState.WithLabelValues(b.cluster, string(state)).Set(v)
+	}
+}
+
+func isWrite(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// isFailure returns whether the request failed because the API server is
+// unavailable or overloaded. Client errors such as conflicts and validation
+// failures are not counted.
+func isFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,117 @@
+package downstreamclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker("test", config.CircuitBreakerConfig{
+		ErrorRateThreshold: 50,
+		MinimumRequests:    4,
+		Window:             metav1.Duration{Duration: time.Minute},
+		OpenDuration:       metav1.Duration{Duration: 10 * time.Second},
+	})
+	breaker.now = func() time.Time { return now }
+
+	status := http.StatusServiceUnavailable
+	var calls int
+	rt := breaker.WrapTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: status}, nil
+	}))
+
+	do := func(ctx context.Context, method string) error {
+		req := httptest.NewRequest(method, "https://downstream/api/v1/namespaces", nil).WithContext(ctx)
+		_, err := rt.RoundTrip(req)
+		return err
+	}
+
+	// Client errors do not count towards the error rate.
+	status = http.StatusConflict
+	for range 4 {
+		require.NoError(t, do(context.Background(), http.MethodPut))
+	}
+	assert.Equal(t, CircuitClosed, breaker.State())
+
+	status = http.StatusServiceUnavailable
+	for range 4 {
+		require.NoError(t, do(context.Background(), http.MethodPut))
+	}
+	assert.Equal(t, CircuitOpen, breaker.State())
+	assert.Equal(t, 10*time.Second, breaker.RetryAfter())
+
+	calls = 0
+	assert.ErrorIs(t, do(context.Background(), http.MethodPatch), ErrCircuitOpen)
+	assert.NoError(t, do(context.Background(), http.MethodGet))
+	assert.NoError(t, do(WithCriticalWrites(context.Background()), http.MethodDelete))
+	assert.Equal(t, 2, calls, "only reads and critical writes should reach the API server")
+
+	// A failed probe opens the breaker again.
+	now = now.Add(10 * time.Second)
+	assert.Equal(t, CircuitHalfOpen, breaker.State())
+	require.NoError(t, do(context.Background(), http.MethodPut))
+	assert.Equal(t, CircuitOpen, breaker.State())
+
+	// A successful probe closes it.
+	now = now.Add(10 * time.Second)
+	status = http.StatusOK
+	require.NoError(t, do(context.Background(), http.MethodPut))
+	assert.Equal(t, CircuitClosed, breaker.State())
+	assert.Zero(t, breaker.RetryAfter())
+}
+
+func TestCircuitBreakerIgnoresCanceledRequests(t *testing.T) {
+	breaker := NewCircuitBreaker("test", config.CircuitBreakerConfig{
+		ErrorRateThreshold: 50,
+		MinimumRequests:    1,
+		Window:             metav1.Duration{Duration: time.Minute},
+		OpenDuration:       metav1.Duration{Duration: time.Minute},
+	})
+
+	rt := breaker.WrapTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, req.Context().Err()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "https://downstream/api/v1/namespaces", nil).WithContext(ctx)
+	_, err := rt.RoundTrip(req)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakersArePerCluster(t *testing.T) {
+	breakers := NewCircuitBreakers(config.CircuitBreakerConfig{
+		ErrorRateThreshold: 50,
+		MinimumRequests:    1,
+		Window:             metav1.Duration{Duration: time.Minute},
+		OpenDuration:       metav1.Duration{Duration: time.Minute},
+	})
+
+	failing := breakers.For("failing")
+	assert.Same(t, failing, breakers.For("failing"))
+
+	rt := failing.WrapTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+	}))
+	_, err := rt.RoundTrip(httptest.NewRequest(http.MethodPut, "https://failing/api/v1/namespaces", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, CircuitOpen, failing.State())
+	assert.Equal(t, CircuitClosed, breakers.For("healthy").State())
+
+	var disabled *CircuitBreakers
+	assert.Nil(t, disabled.For("failing"))
+}
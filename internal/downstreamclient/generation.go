@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package downstreamclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DesiredHashAnnotation holds the hash of the desired state a controller
+	// last wrote to a downstream resource.
+	DesiredHashAnnotation = "meta.datumapis.com/desired-hash"
+
+	// ObservedGenerationAnnotation holds the generation of a downstream
+	// resource right after a controller last wrote its desired state. A
+	// generation that no longer matches means the resource was changed
+	// since.
+	ObservedGenerationAnnotation = "meta.datumapis.com/observed-generation"
+)
+
+// DesiredHash returns the hash of the JSON encoding of desired, which is
+// usually the spec a controller programs on a downstream resource.
+func DesiredHash(desired any) (string, error) {
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return "", fmt.Errorf("failed encoding desired state: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// InSync reports whether the desired state with the hash was last written to
+// obj and obj has not been changed since, in which case writing the desired
+// state again can be skipped.
+//
+// Desired state is built without the defaults the apiserver fills in, so it
+// never equals the stored resource and comparing the two would rewrite the
+// resource on every reconcile.
+func InSync(obj client.Object, hash string) bool {
+	annotations := obj.GetAnnotations()
+	return obj.GetGeneration() != 0 &&
+		annotations[DesiredHashAnnotation] == hash &&
+		annotations[ObservedGenerationAnnotation] == strconv.FormatInt(obj.GetGeneration(), 10)
+}
+
+// SetDesiredHash records the hash of the desired state being written to obj.
+func SetDesiredHash(obj client.Object, hash string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[DesiredHashAnnotation] = hash
+	obj.SetAnnotations(annotations)
+}
+
+// RecordObservedGeneration records the current generation of obj, after its
+// desired state was written, in the ObservedGenerationAnnotation. Metadata
+// changes do not change the generation, so the recorded generation stays
+// current until the spec is changed.
+func RecordObservedGeneration(ctx context.Context, c client.Client, obj client.Object) error {
+	generation := strconv.FormatInt(obj.GetGeneration(), 10)
+	if obj.GetAnnotations()[ObservedGenerationAnnotation] == generation {
+		return nil
+	}
+
+	patch := client.MergeFromWithOptions(obj.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ObservedGenerationAnnotation] = generation
+	obj.SetAnnotations(annotations)
+
+	if err := c.Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("failed recording observed generation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package downstreamclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDesiredHash(t *testing.T) {
+	a, err := DesiredHash(corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}})
+	require.NoError(t, err)
+	b, err := DesiredHash(corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}})
+	require.NoError(t, err)
+	c, err := DesiredHash(corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}})
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestInSync(t *testing.T) {
+	scenarios := map[string]struct {
+		generation  int64
+		annotations map[string]string
+		want        bool
+	}{
+		"written and unchanged": {
+			generation:  2,
+			annotations: map[string]string{DesiredHashAnnotation: "hash", ObservedGenerationAnnotation: "2"},
+			want:        true,
+		},
+		"desired state changed": {
+			generation:  2,
+			annotations: map[string]string{DesiredHashAnnotation: "previous", ObservedGenerationAnnotation: "2"},
+		},
+		"changed since written": {
+			generation:  3,
+			annotations: map[string]string{DesiredHashAnnotation: "hash", ObservedGenerationAnnotation: "2"},
+		},
+		"never written": {
+			generation: 1,
+		},
+		"not created": {
+			annotations: map[string]string{DesiredHashAnnotation: "hash", ObservedGenerationAnnotation: "0"},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			obj := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Generation: scenario.generation, Annotations: scenario.annotations}}
+			assert.Equal(t, scenario.want, InSync(obj, "hash"))
+		})
+	}
+}
+
+func TestRecordObservedGeneration(t *testing.T) {
+	ctx := context.Background()
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc"}}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(service).Build()
+
+	var stored corev1.Service
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(service), &stored))
+	stored.Generation = 4
+	SetDesiredHash(&stored, "hash")
+	require.NoError(t, cl.Update(ctx, &stored))
+
+	require.NoError(t, RecordObservedGeneration(ctx, cl, &stored))
+
+	var recorded corev1.Service
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(service), &recorded))
+	assert.Equal(t, "hash", recorded.Annotations[DesiredHashAnnotation])
+	assert.Equal(t, "4", recorded.Annotations[ObservedGenerationAnnotation])
+
+	// Nothing is written when the generation is already recorded.
+	resourceVersion := recorded.ResourceVersion
+	require.NoError(t, RecordObservedGeneration(ctx, cl, &recorded))
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(service), &recorded))
+	assert.Equal(t, resourceVersion, recorded.ResourceVersion)
+}
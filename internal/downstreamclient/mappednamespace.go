@@ -2,7 +2,9 @@ package downstreamclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -24,18 +26,35 @@ type mappedNamespaceResourceStrategy struct {
 	upstreamClusterName string
 	upstreamClient      client.Client
 	downstreamClient    client.Client
+	controllerName      string
+}
+
+// MappedNamespaceOption configures a mapped namespace resource strategy.
+type MappedNamespaceOption func(*mappedNamespaceResourceStrategy)
+
+// WithControllerName records the name of the controller creating downstream
+// resources in their ManagedByControllerLabel.
+func WithControllerName(name string) MappedNamespaceOption {
+	return func(c *mappedNamespaceResourceStrategy) {
+		c.controllerName = name
+	}
 }
 
 func NewMappedNamespaceResourceStrategy(
 	upstreamClusterName string,
 	upstreamClient client.Client,
 	downstreamClient client.Client,
+	opts ...MappedNamespaceOption,
 ) ResourceStrategy {
-	return &mappedNamespaceResourceStrategy{
+	c := &mappedNamespaceResourceStrategy{
 		upstreamClusterName: upstreamClusterName,
 		upstreamClient:      upstreamClient,
 		downstreamClient:    downstreamClient,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *mappedNamespaceResourceStrategy) GetClient() client.Client {
@@ -51,15 +70,47 @@ func (c *mappedNamespaceResourceStrategy) ObjectMetaFromUpstreamObject(ctx conte
 		return metav1.ObjectMeta{}, fmt.Errorf("failed to get downstream namespace name: %w", err)
 	}
 
+	labels := c.managedLabels()
+	labels[UpstreamOwnerNamespaceLabel] = obj.GetNamespace()
+
 	return metav1.ObjectMeta{
 		Name:      obj.GetName(),
 		Namespace: downstreamNamespaceName,
-		Labels: map[string]string{
-			UpstreamOwnerNamespaceLabel: obj.GetNamespace(),
-		},
+		Labels:    labels,
 	}, nil
 }
 
+// managedLabels returns the labels stamped on every downstream resource
+// created through the strategy, identifying the operator, the controller and
+// the upstream cluster the resource was created for.
+func (c *mappedNamespaceResourceStrategy) managedLabels() map[string]string {
+	labels := map[string]string{
+		ManagedByLabel: ManagedByLabelValue,
+	}
+	if c.controllerName != "" {
+		labels[ManagedByControllerLabel] = c.controllerName
+	}
+	if c.upstreamClusterName != "" {
+		labels[UpstreamOwnerClusterNameLabel] = UpstreamClusterNameLabelValue(c.upstreamClusterName)
+	}
+	return labels
+}
+
+// stampManagedLabels adds the managedLabels of the strategy to obj, keeping
+// any values already set.
+func (c *mappedNamespaceResourceStrategy) stampManagedLabels(obj metav1.Object) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for k, v := range c.managedLabels() {
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+	obj.SetLabels(labels)
+}
+
 func (c *mappedNamespaceResourceStrategy) getUpstreamNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
 	namespace := &corev1.Namespace{}
 
@@ -82,7 +133,23 @@ func (c *mappedNamespaceResourceStrategy) GetDownstreamNamespaceNameForUpstreamN
 	return fmt.Sprintf("ns-%s", namespace.UID), nil
 }
 
+// ErrUpstreamNamespaceTerminating is returned for attempts to create
+// downstream resources for an upstream namespace that is being deleted.
+var ErrUpstreamNamespaceTerminating = errors.New("upstream namespace is terminating")
+
 func (c *mappedNamespaceResourceStrategy) ensureDownstreamNamespace(ctx context.Context, obj metav1.Object) (*corev1.Namespace, error) {
+	// Creating resources would recreate the downstream namespace after it has
+	// been removed along with a terminating upstream namespace.
+	if upstreamNamespaceName, ok := obj.GetLabels()[UpstreamOwnerNamespaceLabel]; ok {
+		upstreamNamespace, err := c.getUpstreamNamespace(ctx, upstreamNamespaceName)
+		if err != nil {
+			return nil, err
+		}
+		if !upstreamNamespace.DeletionTimestamp.IsZero() {
+			return nil, fmt.Errorf("%w: %s", ErrUpstreamNamespaceTerminating, upstreamNamespaceName)
+		}
+	}
+
 	downstreamNamespace := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: obj.GetNamespace(),
@@ -103,8 +170,9 @@ func (c *mappedNamespaceResourceStrategy) ensureDownstreamNamespace(ctx context.
 		// Band-aid: only stamp the label when we actually have a cluster name; the
 		// proper fix is to propagate ClusterName into NamespaceReconcileRequest.
 		if c.upstreamClusterName != "" {
-			downstreamNamespace.Labels[UpstreamOwnerClusterNameLabel] = fmt.Sprintf("cluster-%s", strings.ReplaceAll(c.upstreamClusterName, "/", "_"))
+			downstreamNamespace.Labels[UpstreamOwnerClusterNameLabel] = UpstreamClusterNameLabelValue(c.upstreamClusterName)
 		}
+		downstreamNamespace.Labels[ManagedByLabel] = ManagedByLabelValue
 
 		labels := obj.GetLabels()
 		if v, ok := labels[UpstreamOwnerNamespaceLabel]; ok {
@@ -126,8 +194,29 @@ const (
 	UpstreamOwnerKindLabel        = "meta.datumapis.com/upstream-kind"
 	UpstreamOwnerNameLabel        = "meta.datumapis.com/upstream-name"
 	UpstreamOwnerNamespaceLabel   = "meta.datumapis.com/upstream-namespace"
+	UpstreamOwnerUIDLabel         = "meta.datumapis.com/upstream-uid"
+
+	// ManagedByControllerLabel names the controller that created a downstream
+	// resource.
+	ManagedByControllerLabel = "meta.datumapis.com/managed-by-controller"
+
+	// ManagedByLabel is the well-known label set to ManagedByLabelValue on
+	// every downstream resource the operator creates.
+	ManagedByLabel      = "app.kubernetes.io/managed-by"
+	ManagedByLabelValue = "network-services-operator"
 )
 
+// KeepNamespaceAnnotation prevents the garbage collection of a downstream
+// namespace when set to "true", even once it no longer holds resources of
+// upstream owners.
+const KeepNamespaceAnnotation = "meta.datumapis.com/keep-namespace"
+
+// UpstreamClusterNameLabelValue encodes an upstream cluster name as the value
+// of UpstreamOwnerClusterNameLabel.
+func UpstreamClusterNameLabelValue(clusterName string) string {
+	return fmt.Sprintf("cluster-%s", strings.ReplaceAll(clusterName, "/", "_"))
+}
+
 // UpstreamClusterNameFromLabel decodes the upstream cluster name from the value
 // of UpstreamOwnerClusterNameLabel, reversing the encoding applied when the
 // label is written ("cluster-" prefix, slashes encoded as underscores).
@@ -159,13 +248,17 @@ func (c *mappedNamespaceResourceStrategy) SetControllerReference(ctx context.Con
 
 	anchorName := fmt.Sprintf("anchor-%s", owner.GetUID())
 
-	anchorLabels := map[string]string{
-		UpstreamOwnerClusterNameLabel: fmt.Sprintf("cluster-%s", strings.ReplaceAll(c.upstreamClusterName, "/", "_")),
-		UpstreamOwnerGroupLabel:       gvk.Group,
-		UpstreamOwnerKindLabel:        gvk.Kind,
-		UpstreamOwnerNameLabel:        owner.GetName(),
-		UpstreamOwnerNamespaceLabel:   owner.GetNamespace(),
-	}
+	ownerLabels := c.managedLabels()
+	ownerLabels[UpstreamOwnerGroupLabel] = gvk.Group
+	ownerLabels[UpstreamOwnerKindLabel] = gvk.Kind
+	ownerLabels[UpstreamOwnerNameLabel] = owner.GetName()
+	ownerLabels[UpstreamOwnerNamespaceLabel] = owner.GetNamespace()
+	ownerLabels[UpstreamOwnerUIDLabel] = string(owner.GetUID())
+
+	// Anchors are shared by the controllers of an owner, so they do not record
+	// the controller that created them.
+	anchorLabels := maps.Clone(ownerLabels)
+	delete(anchorLabels, ManagedByControllerLabel)
 
 	downstreamClient := c.GetClient()
 
@@ -179,7 +272,12 @@ func (c *mappedNamespaceResourceStrategy) SetControllerReference(ctx context.Con
 				Labels:    anchorLabels,
 			},
 		}
-		if err := downstreamClient.Create(ctx, &anchorConfigMap); err != nil {
+		if _, err := c.ensureDownstreamNamespace(ctx, &anchorConfigMap); err != nil {
+			return fmt.Errorf("failed to ensure downstream namespace: %w", err)
+		}
+		// Created with the downstream client directly, which does not add the
+		// controller label of the strategy.
+		if err := c.downstreamClient.Create(ctx, &anchorConfigMap); err != nil {
 			return fmt.Errorf("failed creating anchor configmap: %w", err)
 		}
 	} else if err != nil {
@@ -195,11 +293,7 @@ func (c *mappedNamespaceResourceStrategy) SetControllerReference(ctx context.Con
 		labels = map[string]string{}
 	}
 
-	labels[UpstreamOwnerClusterNameLabel] = anchorLabels[UpstreamOwnerClusterNameLabel]
-	labels[UpstreamOwnerGroupLabel] = anchorLabels[UpstreamOwnerGroupLabel]
-	labels[UpstreamOwnerKindLabel] = anchorLabels[UpstreamOwnerKindLabel]
-	labels[UpstreamOwnerNameLabel] = anchorLabels[UpstreamOwnerNameLabel]
-	labels[UpstreamOwnerNamespaceLabel] = anchorLabels[UpstreamOwnerNamespaceLabel]
+	maps.Copy(labels, ownerLabels)
 	controlled.SetLabels(labels)
 
 	return nil
@@ -253,6 +347,7 @@ func (c *mappedNamespaceClient) Create(ctx context.Context, obj client.Object, o
 		return fmt.Errorf("failed to ensure downstream namespace: %w", err)
 	}
 
+	c.resourceStrategy.stampManagedLabels(obj)
 	return c.client.Create(ctx, obj, opts...)
 }
 
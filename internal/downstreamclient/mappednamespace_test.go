@@ -0,0 +1,92 @@
+package downstreamclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMappedNamespaceClientCreateInTerminatingNamespace(t *testing.T) {
+	upstreamNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test",
+			UID:        uuid.NewUUID(),
+			Finalizers: []string{"kubernetes"},
+		},
+	}
+	upstreamClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(upstreamNamespace).Build()
+	downstreamClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	strategy := NewMappedNamespaceResourceStrategy("test", upstreamClient, downstreamClient)
+
+	newConfigMap := func() *corev1.ConfigMap {
+		objectMeta, err := strategy.ObjectMetaFromUpstreamObject(context.Background(), &metav1.ObjectMeta{Namespace: "test", Name: "cm"})
+		require.NoError(t, err)
+		return &corev1.ConfigMap{ObjectMeta: objectMeta}
+	}
+
+	require.NoError(t, strategy.GetClient().Create(context.Background(), newConfigMap()))
+
+	upstreamNamespace.DeletionTimestamp = ptr.To(metav1.Now())
+	upstreamClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(upstreamNamespace).Build()
+	strategy = NewMappedNamespaceResourceStrategy("test", upstreamClient, downstreamClient)
+
+	err := strategy.GetClient().Create(context.Background(), newConfigMap())
+	assert.ErrorIs(t, err, ErrUpstreamNamespaceTerminating)
+}
+
+func TestMappedNamespaceStrategyLabels(t *testing.T) {
+	ctx := context.Background()
+	upstreamNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()}}
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "owner", UID: uuid.NewUUID()}}
+	upstreamClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(upstreamNamespace, owner).Build()
+	downstreamClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	strategy := NewMappedNamespaceResourceStrategy("org/project", upstreamClient, downstreamClient, WithControllerName("test"))
+
+	objectMeta, err := strategy.ObjectMetaFromUpstreamObject(ctx, owner)
+	require.NoError(t, err)
+	controlled := &corev1.ConfigMap{ObjectMeta: objectMeta}
+	require.NoError(t, strategy.SetControllerReference(ctx, owner, controlled))
+	require.NoError(t, strategy.GetClient().Create(ctx, controlled))
+
+	assert.Equal(t, map[string]string{
+		ManagedByLabel:                ManagedByLabelValue,
+		ManagedByControllerLabel:      "test",
+		UpstreamOwnerClusterNameLabel: "cluster-org_project",
+		UpstreamOwnerGroupLabel:       "",
+		UpstreamOwnerKindLabel:        "ConfigMap",
+		UpstreamOwnerNameLabel:        "owner",
+		UpstreamOwnerNamespaceLabel:   "test",
+		UpstreamOwnerUIDLabel:         string(owner.UID),
+	}, controlled.Labels)
+
+	var anchor corev1.ConfigMap
+	require.NoError(t, downstreamClient.Get(ctx, client.ObjectKey{Namespace: objectMeta.Namespace, Name: "anchor-" + string(owner.UID)}, &anchor))
+	assert.NotContains(t, anchor.Labels, ManagedByControllerLabel, "anchors are shared by the controllers of an owner")
+	assert.Equal(t, string(owner.UID), anchor.Labels[UpstreamOwnerUIDLabel])
+
+	var downstreamNamespace corev1.Namespace
+	require.NoError(t, downstreamClient.Get(ctx, client.ObjectKey{Name: objectMeta.Namespace}, &downstreamNamespace))
+	assert.Equal(t, ManagedByLabelValue, downstreamNamespace.Labels[ManagedByLabel])
+	assert.Equal(t, "cluster-org_project", downstreamNamespace.Labels[UpstreamOwnerClusterNameLabel])
+}
+
+func TestMappedNamespaceStrategyLabelsWithoutClusterName(t *testing.T) {
+	upstreamNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()}}
+	upstreamClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(upstreamNamespace).Build()
+	strategy := NewMappedNamespaceResourceStrategy("", upstreamClient, fake.NewClientBuilder().WithScheme(scheme.Scheme).Build())
+
+	objectMeta, err := strategy.ObjectMetaFromUpstreamObject(context.Background(), &metav1.ObjectMeta{Namespace: "test", Name: "cm"})
+	require.NoError(t, err)
+	assert.NotContains(t, objectMeta.Labels, UpstreamOwnerClusterNameLabel)
+	assert.NotContains(t, objectMeta.Labels, ManagedByControllerLabel)
+	assert.Equal(t, ManagedByLabelValue, objectMeta.Labels[ManagedByLabel])
+}
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package downstreamclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ErrSecretTypeNotAllowed is returned by SecretSync.Sync for upstream Secrets
+// whose type is not allowed to be copied downstream.
+var ErrSecretTypeNotAllowed = errors.New("secret type is not allowed")
+
+// SecretSync copies upstream Secrets into the downstream namespace of an
+// upstream owner, such as the certificates, CA bundles and client secrets that
+// gateways, HTTPProxies and policies reference.
+//
+// Copies are controlled by the owner through the ResourceStrategy, so that
+// they are garbage collected along with it, and are marked with the label of
+// the SecretSync, so that each sync removes the copies of the owner that are
+// no longer desired. A copy is only written when its upstream Secret or the
+// copy itself changed, as detected by the hash in the DesiredHashAnnotation.
+type SecretSync struct {
+	strategy     ResourceStrategy
+	label        string
+	allowedTypes []corev1.SecretType
+	keys         []string
+	secretType   corev1.SecretType
+}
+
+// SecretSyncOption configures a SecretSync.
+type SecretSyncOption func(*SecretSync)
+
+// WithAllowedSecretTypes restricts the types of the upstream Secrets that are
+// copied. All types are allowed by default.
+func WithAllowedSecretTypes(types ...corev1.SecretType) SecretSyncOption {
+	return func(s *SecretSync) {
+		s.allowedTypes = types
+	}
+}
+
+// WithSecretKeys only copies the listed keys of the data of upstream Secrets.
+// All keys are copied by default.
+func WithSecretKeys(keys ...string) SecretSyncOption {
+	return func(s *SecretSync) {
+		s.keys = keys
+	}
+}
+
+// WithDownstreamSecretType sets the type of the copies. Copies have the type
+// of their upstream Secret by default.
+func WithDownstreamSecretType(secretType corev1.SecretType) SecretSyncOption {
+	return func(s *SecretSync) {
+		s.secretType = secretType
+	}
+}
+
+// NewSecretSync returns a SecretSync writing through the strategy, which marks
+// the copies it manages with the label.
+func NewSecretSync(strategy ResourceStrategy, label string, opts ...SecretSyncOption) *SecretSync {
+	s := &SecretSync{
+		strategy: strategy,
+		label:    label,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DownstreamSecret is a desired downstream copy of an upstream Secret.
+type DownstreamSecret struct {
+	// Name is the name of the copy in the downstream namespace.
+	Name string
+
+	// Source is the upstream Secret to copy. An existing copy is kept unchanged
+	// when Source is nil, for example while the upstream Secret is unusable.
+	Source *corev1.Secret
+}
+
+// AllowsType returns whether upstream Secrets of the type are copied.
+func (s *SecretSync) AllowsType(secretType corev1.SecretType) bool {
+	return len(s.allowedTypes) == 0 || slices.Contains(s.allowedTypes, secretType)
+}
+
+// Sync ensures the desired copies of upstream Secrets for the owner, and
+// deletes the other copies of the owner managed by the SecretSync. Passing no
+// secrets deletes all copies of the owner.
+//
+// Secrets of types that are not allowed are not copied, and any existing copy
+// is deleted. The returned error then wraps ErrSecretTypeNotAllowed once all
+// other secrets were synced.
+func (s *SecretSync) Sync(ctx context.Context, owner client.Object, secrets []DownstreamSecret) error {
+	logger := log.FromContext(ctx)
+
+	namespace, err := s.strategy.GetDownstreamNamespaceNameForUpstreamNamespace(ctx, owner.GetNamespace())
+	if err != nil {
+		return err
+	}
+	downstreamClient := s.strategy.GetClient()
+
+	var errs []error
+	desired := map[string]bool{}
+	for _, desiredSecret := range secrets {
+		if source := desiredSecret.Source; source != nil && !s.AllowsType(source.Type) {
+			errs = append(errs, fmt.Errorf("%w: Secret %q has type %q", ErrSecretTypeNotAllowed, source.Name, source.Type))
+			continue
+		}
+		desired[desiredSecret.Name] = true
+		if desiredSecret.Source == nil {
+			continue
+		}
+
+		if err := s.ensureSecret(ctx, downstreamClient, owner, namespace, desiredSecret); err != nil {
+			return err
+		}
+	}
+
+	var secretList corev1.SecretList
+	if err := downstreamClient.List(ctx, &secretList,
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			s.label:               "true",
+			UpstreamOwnerUIDLabel: string(owner.GetUID()),
+		},
+	); err != nil {
+		return fmt.Errorf("failed listing Secrets: %w", err)
+	}
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		if desired[secret.Name] {
+			continue
+		}
+		if err := downstreamClient.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed deleting Secret %s: %w", secret.Name, err)
+		}
+		logger.Info("deleted Secret", "secret", secret.Name)
+	}
+
+	return errors.Join(errs...)
+}
+
+// ensureSecret creates or updates a copy of an upstream Secret.
+func (s *SecretSync) ensureSecret(
+	ctx context.Context,
+	downstreamClient client.Client,
+	owner client.Object,
+	namespace string,
+	desiredSecret DownstreamSecret,
+) error {
+	secretType := s.secretType
+	if secretType == "" {
+		secretType = desiredSecret.Source.Type
+	}
+	data := desiredSecret.Source.Data
+	if len(s.keys) > 0 {
+		data = make(map[string][]byte, len(s.keys))
+		for _, key := range s.keys {
+			if value, ok := desiredSecret.Source.Data[key]; ok {
+				data[key] = value
+			}
+		}
+	}
+	hash := SecretHash(secretType, data)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      desiredSecret.Name,
+		},
+	}
+	err := downstreamClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get Secret %s: %w", secret.Name, err)
+	}
+
+	isNew := apierrors.IsNotFound(err)
+	if !isNew &&
+		secret.Labels[s.label] == "true" &&
+		secret.Annotations[DesiredHashAnnotation] == hash &&
+		SecretHash(secret.Type, secret.Data) == hash {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	if !isNew && secret.Type != secretType {
+		// The type of a Secret is immutable, so the copy is replaced.
+		if err := downstreamClient.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed deleting Secret %s: %w", secret.Name, err)
+		}
+		secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: desiredSecret.Name}}
+		isNew = true
+	}
+
+	if isNew {
+		if err := s.strategy.SetControllerReference(ctx, owner, secret); err != nil {
+			return fmt.Errorf("failed to set strategy reference on Secret %s: %w", secret.Name, err)
+		}
+	}
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[s.label] = "true"
+	SetDesiredHash(secret, hash)
+	secret.Type = secretType
+	secret.Data = maps.Clone(data)
+
+	if isNew {
+		if err := downstreamClient.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed creating Secret %s: %w", secret.Name, err)
+		}
+		logger.Info("created Secret", "secret", secret.Name)
+		return nil
+	}
+
+	if err := downstreamClient.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed updating Secret %s: %w", secret.Name, err)
+	}
+	logger.Info("updated Secret", "secret", secret.Name)
+	return nil
+}
+
+// SecretHash returns the hash of the type and data of a Secret.
+func SecretHash(secretType corev1.SecretType, data map[string][]byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d:%s", len(secretType), secretType)
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		fmt.Fprintf(h, "%d:%s%d:", len(key), key, len(data[key]))
+		h.Write(data[key])
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package downstreamclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSecretSync(t *testing.T) {
+	ctx := context.Background()
+	upstreamNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: uuid.NewUUID()}}
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "owner", UID: uuid.NewUUID()}}
+	caBundle := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "ca-bundle"},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"ca.crt": []byte("ca"), "unrelated": []byte("value")},
+	}
+	token := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "token"},
+		Type:       corev1.SecretTypeServiceAccountToken,
+		Data:       map[string][]byte{"ca.crt": []byte("token")},
+	}
+	upstreamClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(upstreamNamespace, owner).Build()
+	downstreamClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	strategy := NewMappedNamespaceResourceStrategy("test", upstreamClient, downstreamClient)
+	downstreamNamespace, err := strategy.GetDownstreamNamespaceNameForUpstreamNamespace(ctx, "test")
+	require.NoError(t, err)
+
+	secretSync := NewSecretSync(strategy, "example.com/ca-bundle",
+		WithAllowedSecretTypes(corev1.SecretTypeOpaque),
+		WithSecretKeys("ca.crt"),
+	)
+	copyKey := client.ObjectKey{Namespace: downstreamNamespace, Name: "owner-ca-bundle"}
+
+	// Copies only hold the selected keys.
+	require.NoError(t, secretSync.Sync(ctx, owner, []DownstreamSecret{{Name: copyKey.Name, Source: caBundle}}))
+	var secret corev1.Secret
+	require.NoError(t, downstreamClient.Get(ctx, copyKey, &secret))
+	assert.Equal(t, map[string][]byte{"ca.crt": []byte("ca")}, secret.Data)
+	assert.Equal(t, corev1.SecretTypeOpaque, secret.Type)
+	assert.Equal(t, "true", secret.Labels["example.com/ca-bundle"])
+	assert.Equal(t, string(owner.UID), secret.Labels[UpstreamOwnerUIDLabel])
+	assert.Equal(t, SecretHash(corev1.SecretTypeOpaque, secret.Data), secret.Annotations[DesiredHashAnnotation])
+
+	// Unchanged copies are not written again.
+	resourceVersion := secret.ResourceVersion
+	require.NoError(t, secretSync.Sync(ctx, owner, []DownstreamSecret{{Name: copyKey.Name, Source: caBundle}}))
+	require.NoError(t, downstreamClient.Get(ctx, copyKey, &secret))
+	assert.Equal(t, resourceVersion, secret.ResourceVersion)
+
+	// Changes to the copy are reverted.
+	secret.Data["ca.crt"] = []byte("tampered")
+	require.NoError(t, downstreamClient.Update(ctx, &secret))
+	require.NoError(t, secretSync.Sync(ctx, owner, []DownstreamSecret{{Name: copyKey.Name, Source: caBundle}}))
+	require.NoError(t, downstreamClient.Get(ctx, copyKey, &secret))
+	assert.Equal(t, []byte("ca"), secret.Data["ca.crt"])
+
+	// A nil source keeps the existing copy.
+	require.NoError(t, secretSync.Sync(ctx, owner, []DownstreamSecret{{Name: copyKey.Name}}))
+	require.NoError(t, downstreamClient.Get(ctx, copyKey, &secret))
+
+	// Secrets of types that are not allowed are not copied, and replace the
+	// existing copy.
+	err = secretSync.Sync(ctx, owner, []DownstreamSecret{{Name: copyKey.Name, Source: token}})
+	assert.ErrorIs(t, err, ErrSecretTypeNotAllowed)
+	err = downstreamClient.Get(ctx, copyKey, &secret)
+	assert.True(t, apierrors.IsNotFound(err), "expected copy to be deleted, got %v", err)
+
+	// Copies that are no longer desired are deleted, while other Secrets of the
+	// namespace are kept.
+	unmanaged := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: downstreamNamespace, Name: "unmanaged"}}
+	require.NoError(t, downstreamClient.Create(ctx, unmanaged))
+	require.NoError(t, secretSync.Sync(ctx, owner, []DownstreamSecret{{Name: copyKey.Name, Source: caBundle}}))
+	require.NoError(t, secretSync.Sync(ctx, owner, nil))
+	err = downstreamClient.Get(ctx, copyKey, &secret)
+	assert.True(t, apierrors.IsNotFound(err), "expected copy to be deleted, got %v", err)
+	require.NoError(t, downstreamClient.Get(ctx, client.ObjectKeyFromObject(unmanaged), &secret))
+}
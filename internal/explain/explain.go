@@ -0,0 +1,432 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package explain walks the dependency graph of Gateways, HTTPProxies and
+// Domains and produces a tree that explains why a top level condition is not
+// True. It only reads objects, and is intended for troubleshooting.
+package explain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+)
+
+const (
+	KindGateway   = "Gateway"
+	KindHTTPProxy = "HTTPProxy"
+	KindDomain    = "Domain"
+
+	kindHTTPRoute   = "HTTPRoute"
+	kindHostname    = "Hostname"
+	kindDNSZone     = "DNSZone"
+	kindCertificate = "Certificate"
+	kindListener    = "Listener"
+)
+
+// ErrUnsupportedKind is returned when an explanation is requested for a kind
+// that is not supported.
+var ErrUnsupportedKind = errors.New("unsupported kind")
+
+// Node is an entry in an explanation tree.
+type Node struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Condition is the condition that best describes the state of the object.
+	// A nil condition means the state of the object could not be determined.
+	Condition *metav1.Condition `json:"condition,omitempty"`
+
+	// Note carries additional context, such as the object not being found.
+	Note string `json:"note,omitempty"`
+
+	Children []*Node `json:"children,omitempty"`
+}
+
+// Healthy returns true when the node's condition is True.
+func (n *Node) Healthy() bool {
+	return n.Condition != nil && n.Condition.Status == metav1.ConditionTrue
+}
+
+// Render writes a human readable representation of the tree to w.
+func (n *Node) Render(w io.Writer) error {
+	return n.render(w, 0)
+}
+
+func (n *Node) render(w io.Writer, depth int) error {
+	marker := "?"
+	switch {
+	case n.Healthy():
+		marker = "✓"
+	case n.Condition != nil && n.Condition.Status == metav1.ConditionFalse:
+		marker = "✗"
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat("  ", depth))
+	fmt.Fprintf(&b, "%s %s ", marker, n.Kind)
+	if n.Namespace != "" {
+		fmt.Fprintf(&b, "%s/", n.Namespace)
+	}
+	b.WriteString(n.Name)
+	if c := n.Condition; c != nil {
+		fmt.Fprintf(&b, ": %s=%s", c.Type, c.Status)
+		if c.Reason != "" {
+			fmt.Fprintf(&b, " (%s)", c.Reason)
+		}
+		if c.Message != "" {
+			fmt.Fprintf(&b, " %s", c.Message)
+		}
+	}
+	if n.Note != "" {
+		fmt.Fprintf(&b, " [%s]", n.Note)
+	}
+	b.WriteString("\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return err
+	}
+
+	for _, child := range n.Children {
+		if err := child.render(w, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Explainer builds explanation trees from the state of a project control
+// plane, and optionally the downstream cluster the project is programmed into.
+type Explainer struct {
+	Client client.Client
+
+	// Downstream, when set, is used to include downstream resources such as
+	// the Gateway and its certificates.
+	Downstream downstreamclient.ResourceStrategy
+
+	// DNSIntegration includes DNSZones in the tree.
+	DNSIntegration bool
+}
+
+// Explain returns the explanation tree for the object of the given kind.
+func (e *Explainer) Explain(ctx context.Context, kind string, key types.NamespacedName) (*Node, error) {
+	switch {
+	case strings.EqualFold(kind, KindHTTPProxy):
+		return e.explainHTTPProxy(ctx, key)
+	case strings.EqualFold(kind, KindGateway):
+		return e.explainGateway(ctx, key)
+	case strings.EqualFold(kind, KindDomain):
+		var domain networkingv1alpha.Domain
+		if err := e.Client.Get(ctx, key, &domain); err != nil {
+			return nil, err
+		}
+		return e.explainDomain(ctx, &domain), nil
+	default:
+		return nil, fmt.Errorf("%w %q, must be one of %s, %s or %s", ErrUnsupportedKind, kind, KindHTTPProxy, KindGateway, KindDomain)
+	}
+}
+
+func (e *Explainer) explainHTTPProxy(ctx context.Context, key types.NamespacedName) (*Node, error) {
+	var httpProxy networkingv1alpha.HTTPProxy
+	if err := e.Client.Get(ctx, key, &httpProxy); err != nil {
+		return nil, err
+	}
+
+	node := &Node{
+		Kind:      KindHTTPProxy,
+		Namespace: httpProxy.Namespace,
+		Name:      httpProxy.Name,
+		Condition: firstCondition(httpProxy.Status.Conditions,
+			networkingv1alpha.HTTPProxyConditionReady,
+			networkingv1alpha.HTTPProxyConditionProgrammed,
+			networkingv1alpha.HTTPProxyConditionAccepted,
+		),
+	}
+
+	gatewayNode, err := e.explainGateway(ctx, key)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		gatewayNode = notFoundNode(KindGateway, key)
+	}
+	node.Children = append(node.Children, gatewayNode)
+
+	var httpRoute gatewayv1.HTTPRoute
+	if err := e.Client.Get(ctx, key, &httpRoute); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		node.Children = append(node.Children, notFoundNode(kindHTTPRoute, key))
+	} else {
+		node.Children = append(node.Children, explainHTTPRoute(&httpRoute))
+	}
+
+	for _, hs := range httpProxy.Status.HostnameStatuses {
+		node.Children = append(node.Children, &Node{
+			Kind:      kindHostname,
+			Name:      hs.Hostname,
+			Condition: worstCondition(hs.Conditions),
+		})
+	}
+
+	return node, nil
+}
+
+func explainHTTPRoute(httpRoute *gatewayv1.HTTPRoute) *Node {
+	node := &Node{
+		Kind:      kindHTTPRoute,
+		Namespace: httpRoute.Namespace,
+		Name:      httpRoute.Name,
+	}
+
+	var conditions []metav1.Condition
+	for _, parent := range httpRoute.Status.Parents {
+		conditions = append(conditions, parent.Conditions...)
+	}
+	node.Condition = worstCondition(conditions)
+	if node.Condition == nil {
+		node.Note = "not yet processed by any parent"
+	} else if node.Condition.ObservedGeneration != httpRoute.Generation {
+		node.Note = fmt.Sprintf("status is for generation %d, current generation is %d", node.Condition.ObservedGeneration, httpRoute.Generation)
+	}
+
+	return node
+}
+
+func (e *Explainer) explainGateway(ctx context.Context, key types.NamespacedName) (*Node, error) {
+	var gateway gatewayv1.Gateway
+	if err := e.Client.Get(ctx, key, &gateway); err != nil {
+		return nil, err
+	}
+
+	node := &Node{
+		Kind:      KindGateway,
+		Namespace: gateway.Namespace,
+		Name:      gateway.Name,
+		Condition: worstCondition(gateway.Status.Conditions),
+	}
+
+	var domains networkingv1alpha.DomainList
+	if err := e.Client.List(ctx, &domains, client.InNamespace(gateway.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing domains: %w", err)
+	}
+
+	// Hostnames in the gateway's addresses are provided by Datum and are not
+	// backed by a Domain.
+	var seenHostnames, seenDomains []string
+	for _, addr := range gateway.Status.Addresses {
+		seenHostnames = append(seenHostnames, addr.Value)
+	}
+
+	for _, l := range gateway.Spec.Listeners {
+		if l.Hostname == nil || slices.Contains(seenHostnames, string(*l.Hostname)) {
+			continue
+		}
+		hostname := string(*l.Hostname)
+		seenHostnames = append(seenHostnames, hostname)
+
+		domain := domainForHostname(domains.Items, hostname)
+		if domain == nil {
+			node.Children = append(node.Children, &Node{
+				Kind: kindHostname,
+				Name: hostname,
+				Note: "no Domain matches this hostname",
+			})
+			continue
+		}
+		if slices.Contains(seenDomains, domain.Name) {
+			continue
+		}
+		seenDomains = append(seenDomains, domain.Name)
+		node.Children = append(node.Children, e.explainDomain(ctx, domain))
+	}
+
+	if e.Downstream != nil {
+		downstreamNode, err := e.explainDownstreamGateway(ctx, &gateway)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, downstreamNode)
+	}
+
+	return node, nil
+}
+
+func (e *Explainer) explainDownstreamGateway(ctx context.Context, upstreamGateway *gatewayv1.Gateway) (*Node, error) {
+	objectMeta, err := e.Downstream.ObjectMetaFromUpstreamObject(ctx, upstreamGateway)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get downstream gateway object metadata: %w", err)
+	}
+
+	key := types.NamespacedName{Namespace: objectMeta.Namespace, Name: objectMeta.Name}
+	downstreamClient := e.Downstream.GetClient()
+
+	var gateway gatewayv1.Gateway
+	if err := downstreamClient.Get(ctx, key, &gateway); err != nil {
+		if apierrors.IsNotFound(err) {
+			node := notFoundNode(KindGateway, key)
+			node.Note = "downstream " + node.Note
+			return node, nil
+		}
+		return nil, fmt.Errorf("failed to get downstream gateway: %w", err)
+	}
+
+	node := &Node{
+		Kind:      KindGateway,
+		Namespace: gateway.Namespace,
+		Name:      gateway.Name,
+		Condition: worstCondition(gateway.Status.Conditions),
+		Note:      "downstream",
+	}
+
+	for _, listener := range gateway.Status.Listeners {
+		if c := worstCondition(listener.Conditions); c != nil && c.Status != metav1.ConditionTrue {
+			node.Children = append(node.Children, &Node{
+				Kind:      kindListener,
+				Name:      string(listener.Name),
+				Condition: c,
+			})
+		}
+	}
+
+	var certificates cmv1.CertificateList
+	if err := downstreamClient.List(ctx, &certificates, client.InNamespace(gateway.Namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return node, nil
+		}
+		return nil, fmt.Errorf("failed listing downstream certificates: %w", err)
+	}
+
+	for i := range certificates.Items {
+		cert := &certificates.Items[i]
+		if !metav1.IsControlledBy(cert, &gateway) {
+			continue
+		}
+
+		certNode := &Node{
+			Kind:      kindCertificate,
+			Namespace: cert.Namespace,
+			Name:      cert.Name,
+		}
+		for _, c := range cert.Status.Conditions {
+			if c.Type == cmv1.CertificateConditionReady {
+				certNode.Condition = &metav1.Condition{
+					Type:    string(c.Type),
+					Status:  metav1.ConditionStatus(c.Status),
+					Reason:  c.Reason,
+					Message: c.Message,
+				}
+				break
+			}
+		}
+		node.Children = append(node.Children, certNode)
+	}
+
+	return node, nil
+}
+
+func (e *Explainer) explainDomain(ctx context.Context, domain *networkingv1alpha.Domain) *Node {
+	node := &Node{
+		Kind:      KindDomain,
+		Namespace: domain.Namespace,
+		Name:      domain.Name,
+		Condition: firstCondition(domain.Status.Conditions,
+			networkingv1alpha.DomainConditionVerified,
+			networkingv1alpha.DomainConditionValidDomain,
+		),
+	}
+
+	if !e.DNSIntegration {
+		return node
+	}
+
+	var zones dnsv1alpha1.DNSZoneList
+	if err := e.Client.List(ctx, &zones, client.InNamespace(domain.Namespace)); err != nil {
+		node.Note = fmt.Sprintf("failed listing DNSZones: %v", err)
+		return node
+	}
+
+	for _, zone := range zones.Items {
+		if zone.Spec.DomainName != domain.Spec.DomainName {
+			continue
+		}
+		node.Children = append(node.Children, &Node{
+			Kind:      kindDNSZone,
+			Namespace: zone.Namespace,
+			Name:      zone.Name,
+			Condition: worstCondition(zone.Status.Conditions),
+		})
+	}
+
+	return node
+}
+
+// domainForHostname returns the Domain with the longest domain name that the
+// hostname falls under.
+func domainForHostname(domains []networkingv1alpha.Domain, hostname string) *networkingv1alpha.Domain {
+	hostname = strings.TrimPrefix(hostname, "*.")
+
+	var match *networkingv1alpha.Domain
+	for i := range domains {
+		d := &domains[i]
+		if hostname != d.Spec.DomainName && !strings.HasSuffix(hostname, "."+d.Spec.DomainName) {
+			continue
+		}
+		if match == nil || len(d.Spec.DomainName) > len(match.Spec.DomainName) {
+			match = d
+		}
+	}
+	return match
+}
+
+// firstCondition returns the first condition found of the given types.
+func firstCondition(conditions []metav1.Condition, conditionTypes ...string) *metav1.Condition {
+	for _, t := range conditionTypes {
+		if c := apimeta.FindStatusCondition(conditions, t); c != nil {
+			return c
+		}
+	}
+	return worstCondition(conditions)
+}
+
+// worstCondition returns the first False condition, followed by the first
+// Unknown condition, followed by the first condition.
+func worstCondition(conditions []metav1.Condition) *metav1.Condition {
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	for _, status := range []metav1.ConditionStatus{metav1.ConditionFalse, metav1.ConditionUnknown} {
+		for i := range conditions {
+			if conditions[i].Status == status {
+				return &conditions[i]
+			}
+		}
+	}
+	return &conditions[0]
+}
+
+func notFoundNode(kind string, key types.NamespacedName) *Node {
+	return &Node{
+		Kind:      kind,
+		Namespace: key.Namespace,
+		Name:      key.Name,
+		Note:      "not found",
+	}
+}
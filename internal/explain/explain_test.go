@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package explain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+)
+
+func newTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(s))
+	require.NoError(t, gatewayv1.Install(s))
+	require.NoError(t, networkingv1alpha.AddToScheme(s))
+	require.NoError(t, dnsv1alpha1.AddToScheme(s))
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+}
+
+func testObjects() []client.Object {
+	return []client.Object{
+		&networkingv1alpha.HTTPProxy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "proxy"},
+			Status: networkingv1alpha.HTTPProxyStatus{
+				Conditions: []metav1.Condition{
+					{Type: networkingv1alpha.HTTPProxyConditionProgrammed, Status: metav1.ConditionFalse, Reason: "Pending"},
+				},
+				HostnameStatuses: []networkingv1alpha.HostnameStatus{
+					{
+						Hostname: "www.example.com",
+						Conditions: []metav1.Condition{
+							{Type: networkingv1alpha.HostnameConditionCertificateReady, Status: metav1.ConditionFalse, Reason: "Issuing"},
+						},
+					},
+				},
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "proxy"},
+			Spec: gatewayv1.GatewaySpec{
+				Listeners: []gatewayv1.Listener{
+					{Name: "default-https", Hostname: ptr.To(gatewayv1.Hostname("abc.gateways.test"))},
+					{Name: "https-0", Hostname: ptr.To(gatewayv1.Hostname("www.example.com"))},
+					{Name: "http-0", Hostname: ptr.To(gatewayv1.Hostname("www.example.com"))},
+					{Name: "https-1", Hostname: ptr.To(gatewayv1.Hostname("api.unknown.com"))},
+				},
+			},
+			Status: gatewayv1.GatewayStatus{
+				Addresses: []gatewayv1.GatewayStatusAddress{
+					{Type: ptr.To(gatewayv1.HostnameAddressType), Value: "abc.gateways.test"},
+				},
+				Conditions: []metav1.Condition{
+					{Type: string(gatewayv1.GatewayConditionAccepted), Status: metav1.ConditionTrue},
+					{Type: string(gatewayv1.GatewayConditionProgrammed), Status: metav1.ConditionFalse, Reason: "Pending"},
+				},
+			},
+		},
+		&networkingv1alpha.Domain{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example-com"},
+			Spec:       networkingv1alpha.DomainSpec{DomainName: "example.com"},
+			Status: networkingv1alpha.DomainStatus{
+				Conditions: []metav1.Condition{
+					{Type: networkingv1alpha.DomainConditionVerified, Status: metav1.ConditionFalse, Reason: "Pending", Message: "TXT record not found"},
+				},
+			},
+		},
+		&dnsv1alpha1.DNSZone{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example-com"},
+			Spec:       dnsv1alpha1.DNSZoneSpec{DomainName: "example.com"},
+		},
+	}
+}
+
+func TestExplainHTTPProxy(t *testing.T) {
+	explainer := &Explainer{
+		Client:         newTestClient(t, testObjects()...),
+		DNSIntegration: true,
+	}
+
+	node, err := explainer.Explain(context.Background(), "httpproxy", types.NamespacedName{Namespace: "default", Name: "proxy"})
+	require.NoError(t, err)
+
+	assert.Equal(t, KindHTTPProxy, node.Kind)
+	assert.False(t, node.Healthy())
+	require.Len(t, node.Children, 3)
+
+	gatewayNode := node.Children[0]
+	assert.Equal(t, KindGateway, gatewayNode.Kind)
+	require.NotNil(t, gatewayNode.Condition)
+	assert.Equal(t, string(gatewayv1.GatewayConditionProgrammed), gatewayNode.Condition.Type)
+
+	// The Datum provided hostname is skipped, duplicate hostnames are collapsed.
+	require.Len(t, gatewayNode.Children, 2)
+	domainNode := gatewayNode.Children[0]
+	assert.Equal(t, KindDomain, domainNode.Kind)
+	assert.Equal(t, "example-com", domainNode.Name)
+	require.Len(t, domainNode.Children, 1)
+	assert.Equal(t, kindDNSZone, domainNode.Children[0].Kind)
+	assert.Equal(t, "no Domain matches this hostname", gatewayNode.Children[1].Note)
+
+	assert.Equal(t, kindHTTPRoute, node.Children[1].Kind)
+	assert.Equal(t, "not found", node.Children[1].Note)
+
+	assert.Equal(t, kindHostname, node.Children[2].Kind)
+	assert.Equal(t, networkingv1alpha.HostnameConditionCertificateReady, node.Children[2].Condition.Type)
+
+	var b strings.Builder
+	require.NoError(t, node.Render(&b))
+	assert.Contains(t, b.String(), "✗ HTTPProxy default/proxy: Programmed=False (Pending)")
+	assert.Contains(t, b.String(), "    ✗ Domain default/example-com: Verified=False (Pending) TXT record not found")
+}
+
+func TestExplainUnsupportedKind(t *testing.T) {
+	explainer := &Explainer{Client: newTestClient(t)}
+	_, err := explainer.Explain(context.Background(), "Service", types.NamespacedName{Namespace: "default", Name: "test"})
+	assert.ErrorIs(t, err, ErrUnsupportedKind)
+}
+
+type testClusterGetter struct {
+	cluster.Cluster
+	client client.Client
+}
+
+func (g *testClusterGetter) GetCluster(context.Context, multicluster.ClusterName) (cluster.Cluster, error) {
+	return g, nil
+}
+
+func (g *testClusterGetter) GetClient() client.Client {
+	return g.client
+}
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler(&testClusterGetter{client: newTestClient(t, testObjects()...)}, nil, false)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "text",
+			query:          "kind=Domain&namespace=default&name=example-com",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "✗ Domain default/example-com",
+		},
+		{
+			name:           "json",
+			query:          "kind=Domain&namespace=default&name=example-com&format=json",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"kind":"Domain"`,
+		},
+		{
+			name:           "missing parameters",
+			query:          "kind=Domain",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not found",
+			query:          "kind=Gateway&namespace=default&name=missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unsupported kind",
+			query:          "kind=Service&namespace=default&name=test",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, EndpointPath+"?"+tt.query, nil))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.expectedBody)
+		})
+	}
+}
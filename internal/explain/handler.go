@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package explain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+
+	"go.datum.net/network-services-operator/internal/downstreamclient"
+)
+
+// EndpointPath is the path the explain handler is served on.
+const EndpointPath = "/debug/explain"
+
+// ClusterGetter returns a project control plane by name.
+type ClusterGetter interface {
+	GetCluster(ctx context.Context, clusterName multicluster.ClusterName) (cluster.Cluster, error)
+}
+
+// NewHandler returns a handler that serves explanation trees. The object is
+// selected with the cluster, kind, namespace and name query parameters. Output
+// is plain text unless format=json is provided.
+func NewHandler(clusters ClusterGetter, downstreamCluster cluster.Cluster, dnsIntegration bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		logger := log.FromContext(ctx).WithName("explain")
+
+		query := req.URL.Query()
+		clusterName := query.Get("cluster")
+		kind := query.Get("kind")
+		key := types.NamespacedName{Namespace: query.Get("namespace"), Name: query.Get("name")}
+
+		if kind == "" || key.Namespace == "" || key.Name == "" {
+			http.Error(w, "kind, namespace and name query parameters are required", http.StatusBadRequest)
+			return
+		}
+
+		cl, err := clusters.GetCluster(ctx, multicluster.ClusterName(clusterName))
+		if err != nil {
+			http.Error(w, "failed to get cluster: "+err.Error(), http.StatusNotFound)
+			return
+		}
+
+		explainer := &Explainer{
+			Client:         cl.GetClient(),
+			DNSIntegration: dnsIntegration,
+		}
+		if downstreamCluster != nil {
+			explainer.Downstream = downstreamclient.NewMappedNamespaceResourceStrategy(clusterName, cl.GetClient(), downstreamCluster.GetClient())
+		}
+
+		node, err := explainer.Explain(ctx, kind, key)
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case apierrors.IsNotFound(err):
+				status = http.StatusNotFound
+			case errors.Is(err, ErrUnsupportedKind):
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		if query.Get("format") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(node); err != nil {
+				logger.Error(err, "failed writing explanation")
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := node.Render(w); err != nil {
+			logger.Error(err, "failed writing explanation")
+		}
+	})
+}
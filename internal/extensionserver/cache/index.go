@@ -15,6 +15,7 @@ import (
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	networkingv1alpha1 "go.datum.net/network-services-operator/api/v1alpha1"
+	"go.datum.net/network-services-operator/internal/config"
 	"go.datum.net/network-services-operator/internal/downstreamclient"
 )
 
@@ -28,15 +29,17 @@ import (
 // everything needed — no upstream control-plane connectivity is required.
 //
 // baseDirectives are the RouteBaseDirectives from the operator config;
-// they are prepended to every policy's per-rule directive list.
-func BuildPolicyIndexFromClient(ctx context.Context, cl client.Client, baseDirectives []string) (*PolicyIndex, error) {
+// they are prepended to every policy's per-rule directive list. geoIPEnabled
+// reports whether a GeoIP database is configured, without which Geo rulesets
+// are ignored.
+func BuildPolicyIndexFromClient(ctx context.Context, cl client.Client, baseDirectives []string, geoIPEnabled bool) (*PolicyIndex, error) {
 	idx := &PolicyIndex{
 		DStoUS:       make(map[string]string),
 		ProjectNames: make(map[string]string),
 		TPPs:         make(map[string][]TPPInfo),
 		Connectors:   make(map[ConnectorKey]ConnectorInfo),
 	}
-	if err := populateFromClient(ctx, cl, idx, baseDirectives); err != nil {
+	if err := populateFromClient(ctx, cl, idx, baseDirectives, geoIPEnabled); err != nil {
 		return nil, err
 	}
 	return idx, nil
@@ -49,7 +52,7 @@ func BuildPolicyIndexFromClient(ctx context.Context, cl client.Client, baseDirec
 //
 // Three list operations + per-proxy Connector Get calls. All reads are served
 // from the informer cache when the client is cache-backed.
-func populateFromClient(ctx context.Context, cl client.Client, idx *PolicyIndex, baseDirectives []string) error {
+func populateFromClient(ctx context.Context, cl client.Client, idx *PolicyIndex, baseDirectives []string, geoIPEnabled bool) error {
 	// --- Downstream → upstream namespace map ---
 	var nsList corev1.NamespaceList
 	if err := cl.List(ctx, &nsList); err != nil {
@@ -110,7 +113,7 @@ func populateFromClient(ctx context.Context, cl client.Client, idx *PolicyIndex,
 			Name:       tpp.Name,
 			Mode:       tpp.Spec.Mode,
 			TargetRefs: tpp.Spec.TargetRefs,
-			Directives: computeCorazaDirectives(tpp, baseDirectives),
+			Directives: computeCorazaDirectives(tpp, baseDirectives, geoIPEnabled),
 		}
 		idx.TPPs[effectiveNS] = append(idx.TPPs[effectiveNS], info)
 	}
@@ -234,6 +237,7 @@ func connectorStatusLiveness(status *networkingv1alpha1.ConnectorStatus) (online
 func computeCorazaDirectives(
 	tpp *networkingv1alpha.TrafficProtectionPolicy,
 	baseDirectives []string,
+	geoIPEnabled bool,
 ) []string {
 	if tpp.Spec.InvertedParanoiaLevels() != nil {
 		return nil
@@ -247,7 +251,18 @@ func computeCorazaDirectives(
 			break
 		}
 	}
-	if owaspCRS == nil {
+
+	var geo *networkingv1alpha.GeoRuleSet
+	if geoIPEnabled {
+		geo = tpp.Spec.GeoRuleSet()
+	}
+
+	var customRules []string
+	if customRuleSet := tpp.Spec.CustomRuleSet(); customRuleSet != nil {
+		customRules = customRuleSet.Directives
+	}
+
+	if owaspCRS == nil && geo == nil && len(customRules) == 0 {
 		return nil
 	}
 
@@ -262,8 +277,14 @@ func computeCorazaDirectives(
 	directives := make([]string, len(baseDirectives))
 	copy(directives, baseDirectives)
 
+	directives = append(directives, fmt.Sprintf("SecRuleEngine %s", secRuleEngine))
+	directives = append(directives, geoCorazaDirectives(geo)...)
+
+	if owaspCRS == nil {
+		return append(directives, customRules...)
+	}
+
 	directives = append(directives,
-		fmt.Sprintf("SecRuleEngine %s", secRuleEngine),
 		fmt.Sprintf(
 			`SecAction "id:900110,phase:1,nolog,pass,t:none,setvar:tx.inbound_anomaly_score_threshold=%d,setvar:tx.outbound_anomaly_score_threshold=%d"`,
 			owaspCRS.ScoreThresholds.Inbound,
@@ -302,7 +323,7 @@ func computeCorazaDirectives(
 		}
 	}
 
-	return directives
+	return append(directives, customRules...)
 }
 
 // parseEndpoint extracts the hostname and port from a backend endpoint URL,
@@ -328,3 +349,41 @@ func parseEndpoint(endpoint string) (string, int, error) {
 	}
 	return host, port, nil
 }
+
+// geoCorazaDirectives mirrors geoCorazaDirectives in
+// internal/controller/trafficprotectionpolicy_controller.go.
+func geoCorazaDirectives(geo *networkingv1alpha.GeoRuleSet) []string {
+	if geo == nil {
+		return nil
+	}
+
+	if len(geo.DenyCountries) > 0 {
+		return []string{
+			fmt.Sprintf(
+				`SecRule REQUEST_HEADERS:%s "@rx ^(?:%s)$" "id:100010,phase:1,deny,status:403,log,msg:'Request denied by geo ruleset',tag:'datum/geo'"`,
+				config.GeoIPCountryHeader,
+				joinCountryCodes(geo.DenyCountries),
+			),
+		}
+	}
+
+	return []string{
+		fmt.Sprintf(
+			`SecRule &REQUEST_HEADERS:%s "@eq 0" "id:100010,phase:1,deny,status:403,log,msg:'Request country could not be determined',tag:'datum/geo'"`,
+			config.GeoIPCountryHeader,
+		),
+		fmt.Sprintf(
+			`SecRule REQUEST_HEADERS:%s "!@rx ^(?:%s)$" "id:100011,phase:1,deny,status:403,log,msg:'Request denied by geo ruleset',tag:'datum/geo'"`,
+			config.GeoIPCountryHeader,
+			joinCountryCodes(geo.AllowCountries),
+		),
+	}
+}
+
+func joinCountryCodes(codes []networkingv1alpha.CountryCode) string {
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, string(code))
+	}
+	return strings.Join(parts, "|")
+}
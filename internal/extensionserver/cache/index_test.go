@@ -161,7 +161,7 @@ func TestBuildPolicyIndexFromClient_NSReverseMap_MultipleNamespaces(t *testing.T
 		WithObjects(ns1, ns2, ns3).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	// Unlabeled namespaces use the identity path: one DStoUS entry per namespace
@@ -177,7 +177,7 @@ func TestBuildPolicyIndexFromClient_NSReverseMap_NoNamespaces(t *testing.T) {
 	scheme := indexTestScheme(t)
 	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 	assert.Empty(t, idx.DStoUS, "empty cluster must produce empty reverse map")
 }
@@ -193,7 +193,7 @@ func TestBuildPolicyIndexFromClient_NSReverseMap_UnlabeledUsesIdentity(t *testin
 		WithObjects(newNS("my-project", "any-uid-value")).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	assert.Equal(t, "my-project", idx.DStoUS["my-project"],
@@ -234,7 +234,7 @@ func TestBuildPolicyIndexFromClient_NSReverseMap_ReplicaNamespaceDistinctUID(t *
 		WithObjects(replicaNS).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	// The critical lookup: dsNS from EG VH metadata is the replica namespace NAME.
@@ -325,7 +325,7 @@ func TestBuildPolicyIndexFromClient_LabelBasedTPPAndConnectorResolution(t *testi
 		WithObjects(connector).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	// DStoUS: dsNS (replica namespace name) → upstream namespace name (from label).
@@ -383,7 +383,7 @@ func TestBuildPolicyIndexFromClient_TPPPrecedence_OlderTimestampFirst(t *testing
 		WithObjects(tppNew, tppMid, tppOld).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	tpps := idx.TPPs["test-ns"]
@@ -417,7 +417,7 @@ func TestBuildPolicyIndexFromClient_TPPPrecedence_NameTiebreakerAlphabetical(t *
 		WithObjects(tppZ, tppM, tppA).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	tpps := idx.TPPs["test-ns"]
@@ -441,7 +441,7 @@ func TestBuildPolicyIndexFromClient_TPPPrecedence_CrossNamespaceBucketing(t *tes
 		WithObjects(tppA1, tppA2, tppB1).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	assert.Len(t, idx.TPPs["ns-alpha"], 2, "ns-alpha must have 2 TPPs")
@@ -472,7 +472,7 @@ func TestBuildPolicyIndexFromClient_TPPFieldsPreserved(t *testing.T) {
 
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tpp).Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	tpps := idx.TPPs["test-ns"]
@@ -496,7 +496,7 @@ func TestBuildPolicyIndexFromClient_InvertedParanoia_NoDirectivesEmitted(t *test
 
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(inverted, valid).Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	byName := map[string]TPPInfo{}
@@ -521,22 +521,56 @@ func TestBuildPolicyIndexFromClient_InvertedParanoia_NoDirectivesEmitted(t *test
 
 func TestComputeCorazaDirectives_NoOWASPRuleSet_ReturnsNil(t *testing.T) {
 	tpp := newTPP("ns", "tpp") // no RuleSets
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	assert.Nil(t, result, "no OWASP ruleset → nil directives (TPP skipped by mutation layer)")
 }
 
 func TestComputeCorazaDirectives_NoOWASPRuleSet_WithBaseDirectives_ReturnsNil(t *testing.T) {
 	tpp := newTPP("ns", "tpp") // no RuleSets
-	result := computeCorazaDirectives(tpp, []string{"SecRuleEngine On"})
+	result := computeCorazaDirectives(tpp, []string{"SecRuleEngine On"}, false)
 	assert.Nil(t, result,
 		"no OWASP CRS ruleset → nil regardless of baseDirectives (TPP has no directives to compute)")
 }
 
+func TestComputeCorazaDirectives_GeoRuleSet(t *testing.T) {
+	tpp := newTPP("ns", "tpp", func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+		tpp.Spec.Mode = networkingv1alpha.TrafficProtectionPolicyEnforce
+		tpp.Spec.RuleSets = []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+			{
+				Type: networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+				Geo:  &networkingv1alpha.GeoRuleSet{DenyCountries: []networkingv1alpha.CountryCode{"XX", "YY"}},
+			},
+		}
+	})
+
+	assert.Equal(t, []string{
+		"SecRuleEngine On",
+		`SecRule REQUEST_HEADERS:x-datum-geo-country "@rx ^(?:XX|YY)$" "id:100010,phase:1,deny,status:403,log,msg:'Request denied by geo ruleset',tag:'datum/geo'"`,
+	}, computeCorazaDirectives(tpp, nil, true))
+	assert.Nil(t, computeCorazaDirectives(tpp, nil, false),
+		"Geo rulesets are ignored when no GeoIP database is configured")
+}
+
+func TestComputeCorazaDirectives_CustomRulesAfterCRS(t *testing.T) {
+	rule := `SecRule REQUEST_URI "@beginsWith /admin" "id:1000,phase:1,deny,status:403"`
+	tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, 1, 1), func(tpp *networkingv1alpha.TrafficProtectionPolicy) {
+		tpp.Spec.RuleSets = append(tpp.Spec.RuleSets, networkingv1alpha.TrafficProtectionPolicyRuleSet{
+			Type:        networkingv1alpha.TrafficProtectionPolicyCustomRuleSet,
+			CustomRules: &networkingv1alpha.CustomRuleSet{Directives: []string{rule}},
+		})
+	})
+
+	result := computeCorazaDirectives(tpp, nil, false)
+	require.NotEmpty(t, result)
+	assert.Equal(t, rule, result[len(result)-1], "custom rules must follow the CRS directives")
+	assert.Contains(t, result, "Include @owasp_crs/*.conf")
+}
+
 func TestComputeCorazaDirectives_ObserveMode_SecRuleEngineDetectionOnly(t *testing.T) {
 	tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, 1, 1))
 	tpp.Spec.Mode = networkingv1alpha.TrafficProtectionPolicyObserve
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	assert.Contains(t, result, "SecRuleEngine DetectionOnly",
@@ -547,7 +581,7 @@ func TestComputeCorazaDirectives_EnforceMode_SecRuleEngineOn(t *testing.T) {
 	tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, 1, 1))
 	tpp.Spec.Mode = networkingv1alpha.TrafficProtectionPolicyEnforce
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	assert.Contains(t, result, "SecRuleEngine On",
@@ -558,7 +592,7 @@ func TestComputeCorazaDirectives_DisabledMode_SecRuleEngineOff(t *testing.T) {
 	tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, 1, 1))
 	tpp.Spec.Mode = networkingv1alpha.TrafficProtectionPolicyDisabled
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	assert.Contains(t, result, "SecRuleEngine Off",
@@ -569,7 +603,7 @@ func TestComputeCorazaDirectives_BaseDirectivesPrependedBeforePolicyDirectives(t
 	tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, 1, 1))
 	baseDirectives := []string{"Include /etc/modsecurity/*.conf", "SecRequestBodyLimit 1000000"}
 
-	result := computeCorazaDirectives(tpp, baseDirectives)
+	result := computeCorazaDirectives(tpp, baseDirectives, false)
 	require.NotNil(t, result)
 	require.GreaterOrEqual(t, len(result), len(baseDirectives)+1)
 
@@ -587,7 +621,7 @@ func TestComputeCorazaDirectives_BaseDirectivesPrependedBeforePolicyDirectives(t
 func TestComputeCorazaDirectives_AnomalyScoreThresholds(t *testing.T) {
 	tpp := newTPP("ns", "tpp", withOWASPCRS(7, 3, 2, 2))
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	var found bool
@@ -605,7 +639,7 @@ func TestComputeCorazaDirectives_AnomalyScoreThresholds(t *testing.T) {
 func TestComputeCorazaDirectives_ParanoiaLevels(t *testing.T) {
 	tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, 3, 4))
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	var foundBlocking, foundDetection bool
@@ -639,7 +673,7 @@ func TestComputeCorazaDirectives_InvertedParanoiaLevels(t *testing.T) {
 			tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, tt.blocking, tt.detection))
 			tpp.Spec.Mode = networkingv1alpha.TrafficProtectionPolicyEnforce
 
-			result := computeCorazaDirectives(tpp, nil)
+			result := computeCorazaDirectives(tpp, nil, false)
 
 			if tt.wantEmitted {
 				assert.NotEmpty(t, result, "valid paranoia levels must emit directives")
@@ -654,7 +688,7 @@ func TestComputeCorazaDirectives_InvertedParanoiaLevels(t *testing.T) {
 func TestComputeCorazaDirectives_IncludeOWASPCRSAppendedAfterActions(t *testing.T) {
 	tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, 1, 1))
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	// "Include @owasp_crs/*.conf" must be present.
@@ -681,7 +715,7 @@ func TestComputeCorazaDirectives_SamplingPercentage_AddedWhenBetween0And100(t *t
 	tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, 1, 1))
 	tpp.Spec.SamplingPercentage = 50
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	var found bool
@@ -698,7 +732,7 @@ func TestComputeCorazaDirectives_SamplingPercentage_ZeroNotAdded(t *testing.T) {
 	tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, 1, 1))
 	tpp.Spec.SamplingPercentage = 0
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	for _, d := range result {
@@ -711,7 +745,7 @@ func TestComputeCorazaDirectives_SamplingPercentage_100NotAdded(t *testing.T) {
 	tpp := newTPP("ns", "tpp", withOWASPCRS(5, 4, 1, 1))
 	tpp.Spec.SamplingPercentage = 100
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	for _, d := range result {
@@ -726,7 +760,7 @@ func TestComputeCorazaDirectives_RuleExclusions_Tags(t *testing.T) {
 		Tags: []networkingv1alpha.OWASPTag{"attack-injection-php", "OWASP_CRS"},
 	}
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	assert.Contains(t, result, `SecRuleRemoveByTag "attack-injection-php"`)
@@ -739,7 +773,7 @@ func TestComputeCorazaDirectives_RuleExclusions_IDs(t *testing.T) {
 		IDs: []int{941100, 942200},
 	}
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	assert.Contains(t, result, "SecRuleRemoveById 941100")
@@ -752,7 +786,7 @@ func TestComputeCorazaDirectives_RuleExclusions_IDRanges(t *testing.T) {
 		IDRanges: []networkingv1alpha.OWASPIDRange{"941100-941200"},
 	}
 
-	result := computeCorazaDirectives(tpp, nil)
+	result := computeCorazaDirectives(tpp, nil, false)
 	require.NotNil(t, result)
 
 	assert.Contains(t, result, `SecRuleRemoveById "941100-941200"`)
@@ -781,7 +815,7 @@ func TestBuildPolicyIndexFromClient_ConnectorResolution_Online(t *testing.T) {
 		WithObjects(connector).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	key := ConnectorKey{
@@ -819,7 +853,7 @@ func TestBuildPolicyIndexFromClient_ConnectorResolution_OfflineConditionFalse(t
 		WithObjects(connector).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	key := ConnectorKey{UpstreamNS: upstreamNS, HTTPProxyName: proxyName, RuleIndex: 0}
@@ -850,7 +884,7 @@ func TestBuildPolicyIndexFromClient_ConnectorResolution_MissingConnector_Treated
 		WithObjects(proxy).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	key := ConnectorKey{UpstreamNS: upstreamNS, HTTPProxyName: proxyName, RuleIndex: 0}
@@ -905,7 +939,7 @@ func TestBuildPolicyIndexFromClient_ConnectorResolution_MultipleRulesCorrectInde
 		WithObjects(connector0, connector1).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	key0 := ConnectorKey{UpstreamNS: upstreamNS, HTTPProxyName: proxyName, RuleIndex: 0}
@@ -950,7 +984,7 @@ func TestBuildPolicyIndexFromClient_ConnectorResolution_NonConnectorBackend_Skip
 
 	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(proxy).Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	assert.Empty(t, idx.Connectors,
@@ -1111,7 +1145,7 @@ func TestBuildPolicyIndexFromClient_ConnectorResolution_AnnotationDrivesOnline(t
 		WithObjects(proxy, connector).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil)
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, nil, false)
 	require.NoError(t, err)
 
 	key := ConnectorKey{UpstreamNS: upstreamNS, HTTPProxyName: proxyName, RuleIndex: 0}
@@ -1215,8 +1249,8 @@ func TestPopulateFromClient_NamespaceNameCollision_LatentRisk(t *testing.T) {
 		TPPs:         make(map[string][]TPPInfo),
 		Connectors:   make(map[ConnectorKey]ConnectorInfo),
 	}
-	require.NoError(t, populateFromClient(context.Background(), clA, idx, nil))
-	require.NoError(t, populateFromClient(context.Background(), clB, idx, nil))
+	require.NoError(t, populateFromClient(context.Background(), clA, idx, nil, false))
+	require.NoError(t, populateFromClient(context.Background(), clB, idx, nil, false))
 
 	// LATENT RISK DOCUMENTED HERE: both TPPs end up in the same namespace key.
 	// In production (Milo architecture) this is safe because namespace names are
@@ -1264,7 +1298,7 @@ func TestBuildPolicyIndexFromClient_FullIndex(t *testing.T) {
 		WithObjects(connector).
 		Build()
 
-	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, []string{"SecRequestBodyLimit 1048576"})
+	idx, err := BuildPolicyIndexFromClient(context.Background(), cl, []string{"SecRequestBodyLimit 1048576"}, false)
 	require.NoError(t, err)
 
 	// NS reverse map: unlabeled namespace produces an identity entry.
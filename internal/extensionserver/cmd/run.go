@@ -284,6 +284,7 @@ func run(o options) {
 			PluginName:                  coraza.PluginName,
 			ListenerDirectives:          coraza.ListenerDirectives,
 			TraceRouteMetadataExtractor: coraza.TraceRouteMetadataExtractor,
+			GeoIPCountryDatabasePath:    coraza.GeoIP.CountryDatabasePath,
 		},
 		ConnectorInternalListener: serverConfig.Gateway.ConnectorTunnelListenerName(),
 		CorazaRouteBaseDirectives: coraza.RouteBaseDirectives,
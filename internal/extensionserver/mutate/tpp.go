@@ -7,13 +7,19 @@ import (
 
 	xdstypev3 "github.com/cncf/xds/go/xds/type/v3"
 	golangv3alpha "github.com/envoyproxy/go-control-plane/contrib/envoy/extensions/filters/http/golang/v3alpha"
+	mutationrulesv3 "github.com/envoyproxy/go-control-plane/envoy/config/common/mutation_rules/v3"
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	geoipv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/geoip/v3"
+	headermutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	geoipcommonv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/geoip_providers/common/v3"
+	maxmindv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/geoip_providers/maxmind/v3"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"go.datum.net/network-services-operator/internal/config"
 	extcache "go.datum.net/network-services-operator/internal/extensionserver/cache"
 )
 
@@ -70,6 +76,9 @@ type CorazaConfig struct {
 	// TraceRouteMetadataExtractor is a CEL expression for trace span attribute
 	// extraction from route metadata. May be empty.
 	TraceRouteMetadataExtractor string
+	// GeoIPCountryDatabasePath is the path to the MaxMind country database in
+	// the Envoy proxies. When empty, no GeoIP filters are injected.
+	GeoIPCountryDatabasePath string
 }
 
 // InjectCorazaListenerFilters prepends the Coraza golang HTTP filter
@@ -151,6 +160,94 @@ func InjectCorazaListenerFilters(l *listenerv3.Listener, cfg *CorazaConfig) (int
 	return mutated, nil
 }
 
+// InjectGeoIPListenerFilters prepends the GeoIP filter, and ahead of it a
+// filter removing the country header sent by the client, to every RDS-based
+// HttpConnectionManager that has the Coraza filter. It must run after
+// InjectCorazaListenerFilters, so the country header is set before Coraza
+// evaluates Geo rulesets. Mirrors getListenerFilterConfigs in
+// internal/controller/trafficprotectionpolicy_controller.go.
+//
+// Returns the number of HCMs mutated.
+func InjectGeoIPListenerFilters(l *listenerv3.Listener, cfg *CorazaConfig) (int, error) {
+	if cfg.Disabled || cfg.FilterName == "" || cfg.GeoIPCountryDatabasePath == "" {
+		return 0, nil
+	}
+
+	providerAny, err := anypb.New(&maxmindv3.MaxMindConfig{
+		CountryDbPath: cfg.GeoIPCountryDatabasePath,
+		CommonProviderConfig: &geoipcommonv3.CommonGeoipProviderConfig{
+			GeoHeadersToAdd: &geoipcommonv3.CommonGeoipProviderConfig_GeolocationHeadersToAdd{
+				Country: config.GeoIPCountryHeader,
+			},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("build geoip provider config: %w", err)
+	}
+	geoIPAny, err := anypb.New(&geoipv3.Geoip{
+		Provider: &corev3.TypedExtensionConfig{
+			Name:        "envoy.geoip_providers.maxmind",
+			TypedConfig: providerAny,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("build geoip listener filter: %w", err)
+	}
+	headerStripAny, err := anypb.New(&headermutationv3.HeaderMutation{
+		Mutations: &headermutationv3.Mutations{
+			RequestMutations: []*mutationrulesv3.HeaderMutation{
+				{Action: &mutationrulesv3.HeaderMutation_Remove{Remove: config.GeoIPCountryHeader}},
+			},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("build geoip header strip filter: %w", err)
+	}
+
+	chains := make([]*listenerv3.FilterChain, 0, len(l.GetFilterChains())+1)
+	chains = append(chains, l.GetFilterChains()...)
+	if dfc := l.GetDefaultFilterChain(); dfc != nil {
+		chains = append(chains, dfc)
+	}
+
+	mutated := 0
+	for _, fc := range chains {
+		for _, f := range fc.GetFilters() {
+			if f.GetName() != hcmFilterName {
+				continue
+			}
+			tc := f.GetTypedConfig()
+			if tc == nil {
+				continue
+			}
+			hcm := &hcmv3.HttpConnectionManager{}
+			if err := tc.UnmarshalTo(hcm); err != nil {
+				return mutated, fmt.Errorf("unmarshal HCM in filter chain %q: %w", fc.GetName(), err)
+			}
+			if hcm.GetRds() == nil || !hcmHasFilter(hcm, cfg.FilterName) || hcmHasFilter(hcm, config.GeoIPFilterName) {
+				continue
+			}
+			hcm.HttpFilters = append([]*hcmv3.HttpFilter{
+				{
+					Name:       config.GeoIPHeaderStripFilterName,
+					ConfigType: &hcmv3.HttpFilter_TypedConfig{TypedConfig: headerStripAny},
+				},
+				{
+					Name:       config.GeoIPFilterName,
+					ConfigType: &hcmv3.HttpFilter_TypedConfig{TypedConfig: geoIPAny},
+				},
+			}, hcm.HttpFilters...)
+			newTC, err := anypb.New(hcm)
+			if err != nil {
+				return mutated, fmt.Errorf("marshal HCM in filter chain %q: %w", fc.GetName(), err)
+			}
+			f.ConfigType = &listenerv3.Filter_TypedConfig{TypedConfig: newTC}
+			mutated++
+		}
+	}
+	return mutated, nil
+}
+
 // ApplyTPPRouteConfig applies per-route WAF config for routes governed by a
 // TrafficProtectionPolicy. For each VirtualHost it:
 //  1. Extracts the EG filter_metadata["envoy-gateway"] gateway resource ref.
@@ -17,6 +17,7 @@ import (
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
 	extcache "go.datum.net/network-services-operator/internal/extensionserver/cache"
 )
 
@@ -185,6 +186,47 @@ func TestInjectCorazaListenerFilters_InjectsAtPositionZeroDisabled(t *testing.T)
 		"router filter must stay last")
 }
 
+func TestInjectGeoIPListenerFilters_InjectsAheadOfCoraza(t *testing.T) {
+	cfg := testCorazaConfig()
+	cfg.GeoIPCountryDatabasePath = "/geoip/country.mmdb"
+	l := listenerWithHCM(t, "consumer-gw/smoke-gw/https")
+
+	_, err := InjectCorazaListenerFilters(l, cfg)
+	require.NoError(t, err)
+	n, err := InjectGeoIPListenerFilters(l, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "expected 1 HCM mutated")
+
+	// Re-injection must be a no-op.
+	n, err = InjectGeoIPListenerFilters(l, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n, "re-injection must be a no-op")
+
+	hcm := &hcmv3.HttpConnectionManager{}
+	require.NoError(t, l.FilterChains[0].Filters[0].GetTypedConfig().UnmarshalTo(hcm))
+	var names []string
+	for _, f := range hcm.HttpFilters {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{
+		config.GeoIPHeaderStripFilterName,
+		config.GeoIPFilterName,
+		cfg.FilterName,
+		"envoy.filters.http.router",
+	}, names)
+}
+
+func TestInjectGeoIPListenerFilters_NoDatabase_NoOp(t *testing.T) {
+	cfg := testCorazaConfig()
+	l := listenerWithHCM(t, "consumer-gw/smoke-gw/https")
+
+	_, err := InjectCorazaListenerFilters(l, cfg)
+	require.NoError(t, err)
+	n, err := InjectGeoIPListenerFilters(l, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n, "no GeoIP database → no HCMs mutated")
+}
+
 func TestInjectCorazaListenerFilters_Idempotent(t *testing.T) {
 	cfg := testCorazaConfig()
 	l := listenerWithHCM(t, "consumer-gw/smoke-gw/https")
@@ -17,6 +17,11 @@
 // Gateway does re-translate on Gateway annotation changes, and because the touch
 // happens after the new liveness is already in the shared cache, the hook
 // re-runs against fresh data.
+//
+// NSO also stamps a sweep token onto the Connector when an HTTPProxy that
+// references it becomes Programmed. A new token touches the Gateway as well, so
+// routes programmed after their connector came online don't have to wait for
+// the next liveness change.
 package retrigger
 
 import (
@@ -62,7 +67,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	online, nodeID := extcache.ConnectorLiveness(&connector)
-	value := livenessValue(online, nodeID)
+	value := livenessValue(online, nodeID, connectorSweep(&connector))
 
 	// The Connector, HTTPProxy, and Gateway share a namespace, and the Gateway is
 	// named after the HTTPProxy, so the connector→Gateway mapping is local.
@@ -111,14 +116,26 @@ func (r *Reconciler) touchGateway(ctx context.Context, key client.ObjectKey, val
 
 // livenessValue is the trigger annotation value. It includes the node id so a
 // change in connectionDetails (e.g. the tunnel endpoint moves) re-translates
-// too, not only Ready flips.
-func livenessValue(online bool, nodeID string) string {
-	return fmt.Sprintf("%t/%s", online, nodeID)
+// too, not only Ready flips, and the addressing sweep token, if any, so a sweep
+// re-translates even when the liveness is unchanged.
+func livenessValue(online bool, nodeID, sweep string) string {
+	value := fmt.Sprintf("%t/%s", online, nodeID)
+	if sweep != "" {
+		value += "/" + sweep
+	}
+	return value
+}
+
+// connectorSweep returns the addressing sweep token NSO last stamped onto the
+// Connector.
+func connectorSweep(connector *networkingv1alpha1.Connector) string {
+	return connector.Annotations[networkingv1alpha1.ConnectorAddressingSweepAnnotation]
 }
 
 // SetupWithManager registers the controller. It reconciles a Connector only when
-// its liveness — the (online, nodeID) the extension server keys on — actually
-// changes, so heartbeat status churn that does not affect routing is ignored.
+// its liveness — the (online, nodeID) the extension server keys on — or its
+// addressing sweep token actually changes, so heartbeat status churn that does
+// not affect routing is ignored.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&networkingv1alpha1.Connector{}, builder.WithPredicates(livenessChangedPredicate())).
@@ -128,7 +145,7 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // livenessChangedPredicate admits creates (so connectors already online when the
 // controller starts get their Gateways stamped) and updates that change the
-// (online, nodeID) classification. Deletes are ignored: removing a Connector
+// (online, nodeID) classification or the addressing sweep token. Deletes are ignored: removing a Connector
 // tears down its HTTPProxy/Gateway/HTTPRoute, which EG re-translates on its own.
 func livenessChangedPredicate() predicate.Predicate {
 	return predicate.Funcs{
@@ -142,7 +159,7 @@ func livenessChangedPredicate() predicate.Predicate {
 			}
 			oOnline, oNode := extcache.ConnectorLiveness(oldC)
 			nOnline, nNode := extcache.ConnectorLiveness(newC)
-			return oOnline != nOnline || oNode != nNode
+			return oOnline != nOnline || oNode != nNode || connectorSweep(oldC) != connectorSweep(newC)
 		},
 	}
 }
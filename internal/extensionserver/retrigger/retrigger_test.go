@@ -194,4 +194,25 @@ func TestLivenessChangedPredicate(t *testing.T) {
 		"nodeID change must reconcile")
 	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: onlineA, ObjectNew: onlineA.DeepCopy()}),
 		"no liveness change must NOT reconcile")
+
+	swept := onlineA.DeepCopy()
+	swept.Annotations[networkingv1alpha1.ConnectorAddressingSweepAnnotation] = "proxy-1/1700000000"
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: onlineA, ObjectNew: swept}),
+		"addressing sweep must reconcile")
+}
+
+// TestReconcile_AddressingSweep_StampsSweepToken verifies an addressing sweep
+// changes the trigger value even though the liveness is unchanged, so EG
+// re-translates routes programmed after the connector came online.
+func TestReconcile_AddressingSweep_StampsSweepToken(t *testing.T) {
+	scheme := testScheme(t)
+	connector := connectorWithUpstreamStatus(t, true, "node-abc")
+	connector.Annotations[networkingv1alpha1.ConnectorAddressingSweepAnnotation] = "proxy-1/1700000000"
+	cl := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(connector, proxyRefersConnector(), gateway()).
+		Build()
+
+	reconcileConnector(t, cl)
+
+	assert.Equal(t, "true/node-abc/proxy-1/1700000000", gatewayLiveness(t, cl))
 }
@@ -8,6 +8,8 @@ import (
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+
+	"go.datum.net/network-services-operator/internal/config"
 )
 
 // These read-only scanners extract an identity for each change the build made.
@@ -135,9 +137,13 @@ func eachHCM(l *listenerv3.Listener, fn func(chainName string, hcm *hcmv3.HttpCo
 
 // hcmHasFilterAtZero reports whether the first filter is named filterName. The
 // firewall is always inserted first, so checking the first position is the
-// precise signal that it was injected.
+// precise signal that it was injected. The GeoIP filters, which are inserted
+// ahead of the firewall to feed it the client country, are skipped.
 func hcmHasFilterAtZero(hcm *hcmv3.HttpConnectionManager, filterName string) bool {
 	fs := hcm.GetHttpFilters()
+	for len(fs) > 0 && (fs[0].GetName() == config.GeoIPHeaderStripFilterName || fs[0].GetName() == config.GeoIPFilterName) {
+		fs = fs[1:]
+	}
 	return len(fs) > 0 && fs[0].GetName() == filterName
 }
 
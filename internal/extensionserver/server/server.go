@@ -141,7 +141,7 @@ func (s *Server) PostTranslateModify(
 	// protobuf template construction is attributable independently.
 	ibStart := time.Now()
 	ctx, ibspan := tr.Start(ctx, "index_build")
-	idx, err := extcache.BuildPolicyIndexFromClient(ctx, s.client, s.cfg.CorazaRouteBaseDirectives)
+	idx, err := extcache.BuildPolicyIndexFromClient(ctx, s.client, s.cfg.CorazaRouteBaseDirectives, s.cfg.Coraza.GeoIPCountryDatabasePath != "")
 	ibspan.End()
 	extmetrics.PhaseDuration.WithLabelValues("index_build").Observe(time.Since(ibStart).Seconds())
 	if err != nil {
@@ -191,6 +191,19 @@ func (s *Server) PostTranslateModify(
 		}
 		hcmCount += n
 
+		// Insert the GeoIP filters ahead of the Coraza filter injected above.
+		if _, geoErr := mutate.InjectGeoIPListenerFilters(l, &s.cfg.Coraza); geoErr != nil {
+			s.log.Error("inject geoip listener filters", "listener", l.GetName(), "err", geoErr)
+			tppListenersSpan.RecordError(geoErr)
+			tppListenersSpan.End()
+			mspan.RecordError(geoErr)
+			mspan.End()
+			extmetrics.PhaseDuration.WithLabelValues("mutate").Observe(time.Since(mutStart).Seconds())
+			hspan.RecordError(geoErr)
+			outcome = outcomeError
+			return nil, geoErr
+		}
+
 		// Attach the branded error page (local_reply_config) to the same
 		// RDS-based HCMs. No-op when disabled or no body is configured. Like the
 		// Coraza injector, this only errors on a genuinely malformed HCM — with
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package features defines the feature gates that control optional operator
+// subsystems. Gates are set in the server config:
+//
+//	featureGates:
+//	  HostnameBlocklist: false
+//
+// New gates must be registered in knownFeatures, which is the single source of
+// truth for their defaults and maturity. New subsystems start as Alpha gates
+// and are promoted once they have been exercised in production. Gates are
+// checked when the manager sets up the controllers and webhooks of a
+// subsystem, and by reconcilers that translate optional resources.
+package features
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Feature is the name of a feature gate.
+type Feature string
+
+// Stage is the maturity of a feature.
+type Stage string
+
+const (
+	// Alpha features are disabled by default and may change or be removed
+	// without notice.
+	Alpha Stage = "Alpha"
+	// Beta features are enabled by default, but may be disabled if problems
+	// are encountered.
+	Beta Stage = "Beta"
+	// GA features are always enabled and the gate will be removed.
+	GA Stage = "GA"
+)
+
+// FeatureSpec describes the default state and maturity of a feature.
+type FeatureSpec struct {
+	Default bool
+	Stage   Stage
+}
+
+const (
+	// HostnameBlocklist rejects Gateway and HTTPProxy hostnames that match a
+	// HostnameBlocklist in the downstream control plane.
+	HostnameBlocklist Feature = "HostnameBlocklist"
+
+	// DetachedHTTPRouteCleanup removes the downstream resources of an HTTPRoute
+	// as soon as it no longer references a Datum gateway.
+	DetachedHTTPRouteCleanup Feature = "DetachedHTTPRouteCleanup"
+
+	// AccessControlPolicy programs AccessControlPolicies as downstream
+	// SecurityPolicies.
+	AccessControlPolicy Feature = "AccessControlPolicy"
+
+	// AuthenticationPolicy programs AuthenticationPolicies as downstream
+	// SecurityPolicies.
+	AuthenticationPolicy Feature = "AuthenticationPolicy"
+
+	// PayloadPolicy programs PayloadPolicies as downstream
+	// BackendTrafficPolicies, and validates them in a webhook.
+	PayloadPolicy Feature = "PayloadPolicy"
+
+	// EndpointSliceGC removes the gateway finalizer and downstream copies of
+	// EndpointSlices that are no longer referenced by any route.
+	EndpointSliceGC Feature = "EndpointSliceGC"
+
+	// DomainConsumers records the Gateways, HTTPProxies and DNSZones that use
+	// a Domain in its status.
+	DomainConsumers Feature = "DomainConsumers"
+
+	// RateLimitPolicy programs RateLimitPolicies as global rate limits of
+	// downstream BackendTrafficPolicies. The downstream Envoy Gateway must be
+	// configured with a rate limit service.
+	RateLimitPolicy Feature = "RateLimitPolicy"
+
+	// BackendLoadBalancingPolicy programs BackendLoadBalancingPolicies as
+	// downstream BackendTrafficPolicies attached to the HTTPRoute rules that
+	// reference their backends.
+	BackendLoadBalancingPolicy Feature = "BackendLoadBalancingPolicy"
+
+	// L4Routes translates TCPRoutes and UDPRoutes attached to TCP and UDP
+	// listeners into the downstream cluster. The experimental Gateway API CRDs
+	// must be installed in both the upstream and downstream clusters.
+	L4Routes Feature = "L4Routes"
+
+	// GRPCRoutes translates GRPCRoutes attached to HTTP and HTTPS listeners
+	// into the downstream cluster. The GRPCRoute CRD must be installed in both
+	// the upstream and downstream clusters.
+	GRPCRoutes Feature = "GRPCRoutes"
+)
+
+var knownFeatures = map[Feature]FeatureSpec{
+	HostnameBlocklist:          {Default: false, Stage: Alpha},
+	DetachedHTTPRouteCleanup:   {Default: false, Stage: Alpha},
+	AccessControlPolicy:        {Default: false, Stage: Alpha},
+	AuthenticationPolicy:       {Default: false, Stage: Alpha},
+	PayloadPolicy:              {Default: false, Stage: Alpha},
+	EndpointSliceGC:            {Default: false, Stage: Alpha},
+	DomainConsumers:            {Default: false, Stage: Alpha},
+	RateLimitPolicy:            {Default: false, Stage: Alpha},
+	BackendLoadBalancingPolicy: {Default: false, Stage: Alpha},
+	L4Routes:                   {Default: false, Stage: Alpha},
+	GRPCRoutes:                 {Default: false, Stage: Alpha},
+}
+
+var featureEnabled = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "nso_feature_enabled",
+		Help: "1 if the feature gate is enabled, 0 otherwise.",
+	},
+	[]string{"name", "stage"},
+)
+
+// Known returns the names of all registered features, sorted.
+func Known() []Feature {
+	names := make([]Feature, 0, len(knownFeatures))
+	for name := range knownFeatures {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Enabled returns whether the feature is enabled given the configured gates.
+// Features that are not set in gates use their default.
+func Enabled(gates map[string]bool, feature Feature) bool {
+	spec, ok := knownFeatures[feature]
+	if !ok {
+		return false
+	}
+	if spec.Stage == GA {
+		return true
+	}
+	if enabled, ok := gates[string(feature)]; ok {
+		return enabled
+	}
+	return spec.Default
+}
+
+// Validate returns an error if gates sets an unknown feature, or attempts to
+// disable a GA feature.
+func Validate(gates map[string]bool) error {
+	var errs []string
+	for name, enabled := range gates {
+		spec, ok := knownFeatures[Feature(name)]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Sprintf("unknown feature gate %q", name))
+		case spec.Stage == GA && !enabled:
+			errs = append(errs, fmt.Sprintf("feature gate %q is GA and cannot be disabled", name))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	slices.Sort(errs)
+	return errors.New(strings.Join(errs, "; "))
+}
+
+// RecordMetrics publishes the state of every registered feature.
+func RecordMetrics(gates map[string]bool) {
+	for _, name := range Known() {
+		value := 0.0
+		if Enabled(gates, name) {
+			value = 1
+		}
+		featureEnabled.WithLabelValues(string(name), string(knownFeatures[name].Stage)).Set(value)
+	}
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package features
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnabled(t *testing.T) {
+	t.Cleanup(func() { delete(knownFeatures, "TestBeta"); delete(knownFeatures, "TestGA") })
+	knownFeatures["TestBeta"] = FeatureSpec{Default: true, Stage: Beta}
+	knownFeatures["TestGA"] = FeatureSpec{Default: true, Stage: GA}
+
+	tests := []struct {
+		name     string
+		gates    map[string]bool
+		feature  Feature
+		expected bool
+	}{
+		{name: "alpha default", feature: HostnameBlocklist, expected: false},
+		{name: "alpha enabled", gates: map[string]bool{string(HostnameBlocklist): true}, feature: HostnameBlocklist, expected: true},
+		{name: "beta default", feature: "TestBeta", expected: true},
+		{name: "beta disabled", gates: map[string]bool{"TestBeta": false}, feature: "TestBeta", expected: false},
+		{name: "ga cannot be disabled", gates: map[string]bool{"TestGA": false}, feature: "TestGA", expected: true},
+		{name: "unknown feature", gates: map[string]bool{"Unknown": true}, feature: "Unknown", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Enabled(tt.gates, tt.feature))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Cleanup(func() { delete(knownFeatures, "TestGA") })
+	knownFeatures["TestGA"] = FeatureSpec{Default: true, Stage: GA}
+
+	assert.NoError(t, Validate(nil))
+	assert.NoError(t, Validate(map[string]bool{string(HostnameBlocklist): false, "TestGA": true}))
+	assert.EqualError(t,
+		Validate(map[string]bool{"Unknown": true, "TestGA": false}),
+		`feature gate "TestGA" is GA and cannot be disabled; unknown feature gate "Unknown"`,
+	)
+}
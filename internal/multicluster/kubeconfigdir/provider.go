@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package kubeconfigdir implements a multicluster provider that engages one
+// cluster per kubeconfig file in a directory. It allows the operator to be run
+// locally against several kind or envtest clusters without the Datum
+// discovery control plane:
+//
+//	kind get kubeconfig --name project-a > clusters/project-a.kubeconfig
+//	kind get kubeconfig --name project-b > clusters/project-b.kubeconfig
+//
+// Each file is engaged as a cluster named after the file without its
+// extension, using the current context of the kubeconfig. Files that are
+// added, changed or removed while the operator runs engage, replace or
+// disengage their cluster.
+package kubeconfigdir
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/multicluster-runtime/pkg/clusters"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var (
+	_ multicluster.Provider         = &Provider{}
+	_ multicluster.ProviderRunnable = &Provider{}
+)
+
+// defaultDebounce is how long the provider waits for further changes to the
+// directory before syncing clusters, so that files being written are read
+// once complete.
+const defaultDebounce = 500 * time.Millisecond
+
+// Options configures a Provider.
+type Options struct {
+	// Directory holds one kubeconfig file per cluster.
+	Directory string
+
+	// ClusterOptions are applied to every cluster that is created.
+	ClusterOptions []cluster.Option
+
+	// Debounce is how long to wait for further changes to the directory before
+	// syncing clusters. Defaults to 500ms.
+	Debounce time.Duration
+}
+
+// Provider engages a cluster for every kubeconfig file in a directory.
+type Provider struct {
+	clusters.Clusters[cluster.Cluster]
+
+	opts Options
+	log  logr.Logger
+
+	newCluster func(config *rest.Config, opts ...cluster.Option) (cluster.Cluster, error)
+
+	// kubeconfigs holds the contents of the kubeconfig files of engaged
+	// clusters, to skip files that did not change.
+	kubeconfigs map[multicluster.ClusterName][]byte
+}
+
+// New returns a provider for the kubeconfig files in opts.Directory.
+func New(opts Options) *Provider {
+	if opts.Debounce <= 0 {
+		opts.Debounce = defaultDebounce
+	}
+	p := &Provider{
+		Clusters:    clusters.New[cluster.Cluster](),
+		opts:        opts,
+		log:         log.Log.WithName("kubeconfig-directory-provider").WithValues("directory", opts.Directory),
+		newCluster:  cluster.New,
+		kubeconfigs: map[multicluster.ClusterName][]byte{},
+	}
+	p.Clusters.ErrorHandler = p.log.Error
+	return p
+}
+
+// Start engages the clusters of the directory and keeps them in sync with its
+// files until ctx is done.
+func (p *Provider) Start(ctx context.Context, aware multicluster.Aware) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed creating kubeconfig directory watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(p.opts.Directory); err != nil {
+		return fmt.Errorf("failed watching kubeconfig directory: %w", err)
+	}
+
+	if err := p.sync(ctx, aware); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(p.opts.Debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			timer.Reset(p.opts.Debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			p.log.Error(err, "kubeconfig directory watch error")
+		case <-timer.C:
+			if err := p.sync(ctx, aware); err != nil {
+				p.log.Error(err, "failed syncing clusters")
+			}
+		}
+	}
+}
+
+// sync engages the clusters of new and changed kubeconfig files, and
+// disengages the clusters of removed files. A kubeconfig that can't be loaded
+// is logged and skipped, leaving the other clusters engaged.
+func (p *Provider) sync(ctx context.Context, aware multicluster.Aware) error {
+	kubeconfigs, err := readKubeconfigs(p.opts.Directory)
+	if err != nil {
+		return err
+	}
+
+	for name := range p.kubeconfigs {
+		if _, ok := kubeconfigs[name]; !ok {
+			p.log.Info("disengaging cluster of removed kubeconfig", "cluster", name)
+			p.Remove(name)
+			delete(p.kubeconfigs, name)
+		}
+	}
+
+	for name, data := range kubeconfigs {
+		// Clusters are also removed when they stop, in which case they are
+		// engaged again.
+		if _, err := p.Get(ctx, name); err == nil && bytes.Equal(p.kubeconfigs[name], data) {
+			continue
+		}
+
+		logger := p.log.WithValues("cluster", name)
+		config, err := clientcmd.RESTConfigFromKubeConfig(data)
+		if err != nil {
+			logger.Error(err, "failed loading kubeconfig")
+			continue
+		}
+		cl, err := p.newCluster(config, p.opts.ClusterOptions...)
+		if err != nil {
+			logger.Error(err, "failed creating cluster")
+			continue
+		}
+
+		logger.Info("engaging cluster")
+		if err := p.AddOrReplace(ctx, name, cl, aware); err != nil {
+			logger.Error(err, "failed engaging cluster")
+			continue
+		}
+		p.kubeconfigs[name] = data
+	}
+	return nil
+}
+
+// readKubeconfigs returns the contents of the kubeconfig files of dir by
+// cluster name. Hidden files, such as the data links of mounted ConfigMaps and
+// Secrets, and directories are ignored.
+func readKubeconfigs(dir string) (map[multicluster.ClusterName][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading kubeconfig directory: %w", err)
+	}
+
+	kubeconfigs := map[multicluster.ClusterName][]byte{}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") || entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			// The file was removed while the directory was read.
+			continue
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading kubeconfig %s: %w", path, err)
+		}
+		kubeconfigs[clusterName(entry.Name())] = data
+	}
+	return kubeconfigs, nil
+}
+
+// clusterName returns the name of the cluster of a kubeconfig file.
+func clusterName(fileName string) multicluster.ClusterName {
+	return multicluster.ClusterName(strings.TrimSuffix(fileName, filepath.Ext(fileName)))
+}
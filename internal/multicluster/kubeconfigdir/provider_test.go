@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package kubeconfigdir
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- name: kind
+  cluster:
+    server: %s
+contexts:
+- name: kind
+  context:
+    cluster: kind
+    user: kind
+current-context: kind
+users:
+- name: kind
+  user:
+    token: test
+`
+
+type fakeCache struct {
+	cache.Cache
+}
+
+func (fakeCache) WaitForCacheSync(context.Context) bool { return true }
+
+type fakeCluster struct {
+	cluster.Cluster
+	config *rest.Config
+}
+
+func (c *fakeCluster) GetConfig() *rest.Config { return c.config }
+func (c *fakeCluster) GetCache() cache.Cache   { return fakeCache{} }
+func (c *fakeCluster) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+type fakeAware struct {
+	mu      sync.Mutex
+	engaged map[multicluster.ClusterName]context.Context
+}
+
+func (a *fakeAware) Engage(ctx context.Context, name multicluster.ClusterName, _ cluster.Cluster) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.engaged[name] = ctx
+	return nil
+}
+
+func newTestProvider(t *testing.T) (*Provider, *fakeAware) {
+	t.Helper()
+	p := New(Options{Directory: t.TempDir()})
+	p.newCluster = func(config *rest.Config, _ ...cluster.Option) (cluster.Cluster, error) {
+		return &fakeCluster{config: config}, nil
+	}
+	return p, &fakeAware{engaged: map[multicluster.ClusterName]context.Context{}}
+}
+
+func writeKubeconfig(t *testing.T, dir, name, server string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), fmt.Appendf(nil, kubeconfigTemplate, server), 0o600))
+}
+
+func server(t *testing.T, p *Provider, name multicluster.ClusterName) string {
+	t.Helper()
+	cl, err := p.Get(context.Background(), name)
+	require.NoError(t, err)
+	return cl.GetConfig().Host
+}
+
+func TestProviderSync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p, aware := newTestProvider(t)
+	dir := p.opts.Directory
+
+	writeKubeconfig(t, dir, "project-a.kubeconfig", "https://127.0.0.1:6443")
+	writeKubeconfig(t, dir, "project-b.yaml", "https://127.0.0.1:7443")
+	writeKubeconfig(t, dir, ".hidden", "https://127.0.0.1:8443")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.kubeconfig"), []byte("clusters: ["), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0o700))
+
+	require.NoError(t, p.sync(ctx, aware))
+	assert.Equal(t, []multicluster.ClusterName{"project-a", "project-b"}, p.ClusterNames(),
+		"hidden files, directories and invalid kubeconfigs should not be engaged")
+	assert.Len(t, aware.engaged, 2)
+	assert.Equal(t, "https://127.0.0.1:6443", server(t, p, "project-a"))
+
+	// Unchanged files are not engaged again.
+	engagedA := aware.engaged["project-a"]
+	require.NoError(t, p.sync(ctx, aware))
+	assert.Equal(t, engagedA, aware.engaged["project-a"])
+
+	// Changed files replace their cluster, and removed files disengage it.
+	writeKubeconfig(t, dir, "project-a.kubeconfig", "https://127.0.0.1:9443")
+	require.NoError(t, os.Remove(filepath.Join(dir, "project-b.yaml")))
+	engagedB := aware.engaged["project-b"]
+
+	require.NoError(t, p.sync(ctx, aware))
+	assert.Equal(t, []multicluster.ClusterName{"project-a"}, p.ClusterNames())
+	assert.Equal(t, "https://127.0.0.1:9443", server(t, p, "project-a"))
+	assert.Error(t, engagedA.Err(), "the replaced cluster should be disengaged")
+	assert.Error(t, engagedB.Err(), "the removed cluster should be disengaged")
+}
+
+func TestProviderSyncMissingDirectory(t *testing.T) {
+	p, aware := newTestProvider(t)
+	p.opts.Directory = filepath.Join(p.opts.Directory, "missing")
+	assert.Error(t, p.sync(context.Background(), aware))
+}
+
+func TestClusterName(t *testing.T) {
+	assert.Equal(t, multicluster.ClusterName("project-a"), clusterName("project-a.kubeconfig"))
+	assert.Equal(t, multicluster.ClusterName("project-a"), clusterName("project-a"))
+	assert.Equal(t, multicluster.ClusterName("kind.project-a"), clusterName("kind.project-a.yaml"))
+}
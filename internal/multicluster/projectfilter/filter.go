@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package projectfilter limits the clusters that the Datum discovery provider
+// engages to the projects matching a config.ProjectFilterConfig, so that the
+// projects of a platform can be sharded across several operator deployments.
+//
+// The filter is evaluated against the Project of each cluster in the discovery
+// control plane when the provider engages the cluster, and again periodically
+// to follow changes to the labels, annotations and phase of the project.
+package projectfilter
+
+import (
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// ProjectGVK is the kind of the projects in the discovery control plane. Each
+// engaged cluster is the control plane of the project of the same name.
+var ProjectGVK = schema.GroupVersionKind{
+	Group:   "resourcemanager.miloapis.com",
+	Version: "v1alpha1",
+	Kind:    "Project",
+}
+
+// Filter decides whether a project is engaged.
+type Filter struct {
+	selector           labels.Selector
+	excludeSelector    labels.Selector
+	annotations        map[string]string
+	excludeAnnotations map[string]string
+	phases             []string
+}
+
+// NewFilter returns the filter described by cfg.
+func NewFilter(cfg config.ProjectFilterConfig) (*Filter, error) {
+	f := &Filter{
+		annotations:        cfg.Annotations,
+		excludeAnnotations: cfg.ExcludeAnnotations,
+		phases:             cfg.Phases,
+	}
+	if cfg.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(cfg.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing project selector: %w", err)
+		}
+		f.selector = selector
+	}
+	if cfg.ExcludeSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(cfg.ExcludeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing project exclude selector: %w", err)
+		}
+		f.excludeSelector = selector
+	}
+	return f, nil
+}
+
+// Match reports whether project is engaged. When it isn't, reason describes
+// the rule that excluded it.
+func (f *Filter) Match(project *unstructured.Unstructured) (match bool, reason string) {
+	projectLabels := labels.Set(project.GetLabels())
+	if f.selector != nil && !f.selector.Matches(projectLabels) {
+		return false, "labels do not match the project selector"
+	}
+	if f.excludeSelector != nil && f.excludeSelector.Matches(projectLabels) {
+		return false, "labels match the project exclude selector"
+	}
+
+	annotations := project.GetAnnotations()
+	for key, value := range f.annotations {
+		if !annotationMatches(annotations, key, value) {
+			return false, fmt.Sprintf("missing annotation %s", key)
+		}
+	}
+	for key, value := range f.excludeAnnotations {
+		if annotationMatches(annotations, key, value) {
+			return false, fmt.Sprintf("excluded by annotation %s", key)
+		}
+	}
+
+	if len(f.phases) > 0 {
+		phase, _, _ := unstructured.NestedString(project.Object, "status", "phase")
+		if !slices.Contains(f.phases, phase) {
+			return false, fmt.Sprintf("phase %q is not one of %q", phase, f.phases)
+		}
+	}
+	return true, ""
+}
+
+// annotationMatches reports whether annotations holds key, with value unless
+// value is empty.
+func annotationMatches(annotations map[string]string, key, value string) bool {
+	actual, ok := annotations[key]
+	return ok && (value == "" || actual == value)
+}
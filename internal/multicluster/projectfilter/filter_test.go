@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package projectfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func newProject(name string, labels, annotations map[string]string, phase string) *unstructured.Unstructured {
+	project := &unstructured.Unstructured{}
+	project.SetGroupVersionKind(ProjectGVK)
+	project.SetName(name)
+	project.SetLabels(labels)
+	project.SetAnnotations(annotations)
+	if phase != "" {
+		_ = unstructured.SetNestedField(project.Object, phase, "status", "phase")
+	}
+	return project
+}
+
+func TestFilterMatch(t *testing.T) {
+	scenarios := map[string]struct {
+		cfg     config.ProjectFilterConfig
+		project *unstructured.Unstructured
+		match   bool
+	}{
+		"empty filter": {
+			project: newProject("p", nil, nil, ""),
+			match:   true,
+		},
+		"selector matches": {
+			cfg:     config.ProjectFilterConfig{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"shard": "a"}}},
+			project: newProject("p", map[string]string{"shard": "a"}, nil, ""),
+			match:   true,
+		},
+		"selector does not match": {
+			cfg:     config.ProjectFilterConfig{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"shard": "a"}}},
+			project: newProject("p", map[string]string{"shard": "b"}, nil, ""),
+		},
+		"exclude selector matches": {
+			cfg: config.ProjectFilterConfig{
+				Selector:        &metav1.LabelSelector{MatchLabels: map[string]string{"shard": "a"}},
+				ExcludeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "internal"}},
+			},
+			project: newProject("p", map[string]string{"shard": "a", "tier": "internal"}, nil, ""),
+		},
+		"annotation with any value": {
+			cfg:     config.ProjectFilterConfig{Annotations: map[string]string{"example.com/shard": ""}},
+			project: newProject("p", nil, map[string]string{"example.com/shard": "a"}, ""),
+			match:   true,
+		},
+		"annotation with another value": {
+			cfg:     config.ProjectFilterConfig{Annotations: map[string]string{"example.com/shard": "b"}},
+			project: newProject("p", nil, map[string]string{"example.com/shard": "a"}, ""),
+		},
+		"missing annotation": {
+			cfg:     config.ProjectFilterConfig{Annotations: map[string]string{"example.com/shard": ""}},
+			project: newProject("p", nil, nil, ""),
+		},
+		"exclude annotation": {
+			cfg:     config.ProjectFilterConfig{ExcludeAnnotations: map[string]string{"example.com/paused": "true"}},
+			project: newProject("p", nil, map[string]string{"example.com/paused": "true"}, ""),
+		},
+		"exclude annotation with another value": {
+			cfg:     config.ProjectFilterConfig{ExcludeAnnotations: map[string]string{"example.com/paused": "true"}},
+			project: newProject("p", nil, map[string]string{"example.com/paused": "false"}, ""),
+			match:   true,
+		},
+		"phase matches": {
+			cfg:     config.ProjectFilterConfig{Phases: []string{"Ready"}},
+			project: newProject("p", nil, nil, "Ready"),
+			match:   true,
+		},
+		"phase does not match": {
+			cfg:     config.ProjectFilterConfig{Phases: []string{"Ready"}},
+			project: newProject("p", nil, nil, "Provisioning"),
+		},
+		"project without a phase": {
+			cfg:     config.ProjectFilterConfig{Phases: []string{"Ready"}},
+			project: newProject("p", nil, nil, ""),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			filter, err := NewFilter(scenario.cfg)
+			require.NoError(t, err)
+
+			match, reason := filter.Match(scenario.project)
+			assert.Equal(t, scenario.match, match, reason)
+			if !match {
+				assert.NotEmpty(t, reason)
+			}
+		})
+	}
+}
+
+func TestNewFilterInvalidSelector(t *testing.T) {
+	_, err := NewFilter(config.ProjectFilterConfig{Selector: &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "shard", Operator: "Like"}},
+	}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package projectfilter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// Options configures the filtering of a provider.
+type Options struct {
+	// Filter selects the projects whose clusters are engaged.
+	Filter *Filter
+
+	// Reader reads the projects from the discovery control plane.
+	Reader client.Reader
+
+	// RecheckInterval is how often the projects of the clusters of the provider
+	// are evaluated again.
+	RecheckInterval time.Duration
+}
+
+var (
+	_ multicluster.ProviderRunnable = &runnableProvider{}
+	_ legacyRunnable                = &legacyProvider{}
+)
+
+// legacyRunnable matches providers that engage their clusters through the
+// manager passed to Run rather than through multicluster.ProviderRunnable,
+// such as the Milo provider.
+type legacyRunnable interface {
+	Run(context.Context, mcmanager.Manager) error
+}
+
+// Wrap returns a provider that only engages the clusters of provider whose
+// project matches opts.Filter. Get is not filtered, so that webhooks keep
+// serving the requests of every project.
+func Wrap(provider multicluster.Provider, opts Options) (multicluster.Provider, error) {
+	switch p := provider.(type) {
+	case multicluster.ProviderRunnable:
+		return &runnableProvider{Provider: provider, runnable: p, opts: opts}, nil
+	case legacyRunnable:
+		return &legacyProvider{Provider: provider, runnable: p, opts: opts}, nil
+	default:
+		return nil, fmt.Errorf("cluster provider %T does not support project filtering", provider)
+	}
+}
+
+type runnableProvider struct {
+	multicluster.Provider
+	runnable multicluster.ProviderRunnable
+	opts     Options
+}
+
+// Start starts the wrapped provider, engaging the clusters of matching
+// projects with aware.
+func (p *runnableProvider) Start(ctx context.Context, aware multicluster.Aware) error {
+	filtering := newFilteringAware(aware, p.opts)
+	go filtering.recheck(ctx)
+	return p.runnable.Start(ctx, filtering)
+}
+
+type legacyProvider struct {
+	multicluster.Provider
+	runnable legacyRunnable
+	opts     Options
+}
+
+// Run runs the wrapped provider, engaging the clusters of matching projects
+// with mgr.
+func (p *legacyProvider) Run(ctx context.Context, mgr mcmanager.Manager) error {
+	filtering := newFilteringAware(mgr, p.opts)
+	go filtering.recheck(ctx)
+	return p.runnable.Run(ctx, &filteringManager{Manager: mgr, aware: filtering})
+}
+
+// filteringManager engages clusters through a filteringAware.
+type filteringManager struct {
+	mcmanager.Manager
+	aware *filteringAware
+}
+
+func (m *filteringManager) Engage(ctx context.Context, name multicluster.ClusterName, cl cluster.Cluster) error {
+	return m.aware.Engage(ctx, name, cl)
+}
+
+// engagement is a cluster offered by the provider.
+type engagement struct {
+	// ctx is the context the provider engaged the cluster with.
+	ctx     context.Context
+	cluster cluster.Cluster
+
+	// cancel disengages the cluster. It is nil while the cluster is skipped.
+	cancel context.CancelFunc
+}
+
+// filteringAware passes the clusters of matching projects on to aware, and
+// tracks the clusters of the provider to engage or disengage them when their
+// project changes.
+type filteringAware struct {
+	aware multicluster.Aware
+	opts  Options
+	log   logr.Logger
+
+	mu       sync.Mutex
+	clusters map[multicluster.ClusterName]*engagement
+}
+
+func newFilteringAware(aware multicluster.Aware, opts Options) *filteringAware {
+	return &filteringAware{
+		aware:    aware,
+		opts:     opts,
+		log:      log.Log.WithName("project-filter"),
+		clusters: map[multicluster.ClusterName]*engagement{},
+	}
+}
+
+// Engage engages cl if its project matches the filter. Clusters that are
+// skipped are evaluated again on every recheck until ctx is done.
+func (a *filteringAware) Engage(ctx context.Context, name multicluster.ClusterName, cl cluster.Cluster) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if old, ok := a.clusters[name]; ok {
+		if old.ctx == ctx && old.cluster == cl && old.cancel != nil {
+			return nil
+		}
+		if old.cancel != nil {
+			old.cancel()
+		}
+	}
+
+	e := &engagement{ctx: ctx, cluster: cl}
+	a.clusters[name] = e
+	go func() {
+		<-ctx.Done()
+		a.forget(name, e)
+	}()
+
+	return a.evaluate(name, e)
+}
+
+// forget stops tracking the cluster of e once the provider disengaged it.
+func (a *filteringAware) forget(name multicluster.ClusterName, e *engagement) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.clusters[name] == e {
+		delete(a.clusters, name)
+	}
+	if e.cancel != nil {
+		e.cancel()
+		e.cancel = nil
+	}
+}
+
+// evaluate engages or disengages the cluster of e according to its project.
+// It must be called with mu held.
+func (a *filteringAware) evaluate(name multicluster.ClusterName, e *engagement) error {
+	logger := a.log.WithValues("cluster", name)
+
+	match, reason, err := a.match(e.ctx, name)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case match && e.cancel == nil:
+		ctx, cancel := context.WithCancel(e.ctx)
+		if err := a.aware.Engage(ctx, name, e.cluster); err != nil {
+			cancel()
+			return err
+		}
+		e.cancel = cancel
+		logger.Info("engaged cluster of matching project")
+	case !match && e.cancel != nil:
+		e.cancel()
+		e.cancel = nil
+		logger.Info("disengaged cluster of project that no longer matches", "reason", reason)
+	case !match:
+		logger.V(1).Info("skipping cluster of project that does not match", "reason", reason)
+	}
+	return nil
+}
+
+// match reports whether the project of the cluster matches the filter.
+// Projects that can't be found do not match.
+func (a *filteringAware) match(ctx context.Context, name multicluster.ClusterName) (match bool, reason string, err error) {
+	project := &unstructured.Unstructured{}
+	project.SetGroupVersionKind(ProjectGVK)
+	// Cluster names used to carry a leading slash.
+	key := client.ObjectKey{Name: strings.TrimPrefix(string(name), "/")}
+	if err := a.opts.Reader.Get(ctx, key, project); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, "project not found", nil
+		}
+		return false, "", fmt.Errorf("failed getting project of cluster %q: %w", name, err)
+	}
+
+	match, reason = a.opts.Filter.Match(project)
+	return match, reason, nil
+}
+
+// recheck evaluates the projects of all clusters every RecheckInterval until
+// ctx is done.
+func (a *filteringAware) recheck(ctx context.Context) {
+	if a.opts.RecheckInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(a.opts.RecheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.recheckAll()
+		}
+	}
+}
+
+func (a *filteringAware) recheckAll() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for name, e := range a.clusters {
+		if err := a.evaluate(name, e); err != nil && !errors.Is(err, context.Canceled) {
+			a.log.Error(err, "failed evaluating project filter", "cluster", name)
+		}
+	}
+}
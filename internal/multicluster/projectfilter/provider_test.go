@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package projectfilter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+type fakeCluster struct {
+	cluster.Cluster
+}
+
+type fakeAware struct {
+	mu      sync.Mutex
+	engaged map[multicluster.ClusterName]context.Context
+}
+
+func (a *fakeAware) Engage(ctx context.Context, name multicluster.ClusterName, _ cluster.Cluster) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.engaged[name] = ctx
+	return nil
+}
+
+// active returns the names of the clusters whose engagement is live.
+func (a *fakeAware) active() []multicluster.ClusterName {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var names []multicluster.ClusterName
+	for name, ctx := range a.engaged {
+		if ctx.Err() == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+type fakeManager struct {
+	mcmanager.Manager
+	*fakeAware
+}
+
+func (m *fakeManager) Engage(ctx context.Context, name multicluster.ClusterName, cl cluster.Cluster) error {
+	return m.fakeAware.Engage(ctx, name, cl)
+}
+
+// fakeProvider engages its clusters with the Aware it is started with.
+type fakeProvider struct {
+	multicluster.Provider
+	clusters []multicluster.ClusterName
+}
+
+func (p *fakeProvider) Start(ctx context.Context, aware multicluster.Aware) error {
+	for _, name := range p.clusters {
+		if err := aware.Engage(ctx, name, &fakeCluster{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeLegacyProvider engages its clusters with the manager it is run with.
+type fakeLegacyProvider struct {
+	multicluster.Provider
+	clusters []multicluster.ClusterName
+}
+
+func (p *fakeLegacyProvider) Run(ctx context.Context, mgr mcmanager.Manager) error {
+	for _, name := range p.clusters {
+		if err := mgr.Engage(ctx, name, &fakeCluster{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestOptions(t *testing.T, cl client.Client) Options {
+	t.Helper()
+	filter, err := NewFilter(config.ProjectFilterConfig{
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"shard": "a"}},
+	})
+	require.NoError(t, err)
+	return Options{Filter: filter, Reader: cl}
+}
+
+func TestWrapRunnableProvider(t *testing.T) {
+	ctx := t.Context()
+
+	cl := fake.NewClientBuilder().WithObjects(
+		newProject("project-a", map[string]string{"shard": "a"}, nil, ""),
+		newProject("project-b", map[string]string{"shard": "b"}, nil, ""),
+	).Build()
+
+	provider, err := Wrap(&fakeProvider{clusters: []multicluster.ClusterName{"project-a", "project-b", "missing"}}, newTestOptions(t, cl))
+	require.NoError(t, err)
+
+	aware := &fakeAware{engaged: map[multicluster.ClusterName]context.Context{}}
+	require.NoError(t, provider.(multicluster.ProviderRunnable).Start(ctx, aware))
+	assert.ElementsMatch(t, []multicluster.ClusterName{"project-a"}, aware.active())
+}
+
+func TestWrapLegacyProvider(t *testing.T) {
+	ctx := t.Context()
+
+	cl := fake.NewClientBuilder().WithObjects(
+		newProject("project-a", map[string]string{"shard": "a"}, nil, ""),
+		newProject("project-b", map[string]string{"shard": "b"}, nil, ""),
+	).Build()
+
+	provider, err := Wrap(&fakeLegacyProvider{clusters: []multicluster.ClusterName{"project-a", "project-b"}}, newTestOptions(t, cl))
+	require.NoError(t, err)
+	_, isRunnable := provider.(multicluster.ProviderRunnable)
+	assert.False(t, isRunnable, "legacy providers must keep being run by the caller")
+
+	aware := &fakeAware{engaged: map[multicluster.ClusterName]context.Context{}}
+	require.NoError(t, provider.(legacyRunnable).Run(ctx, &fakeManager{fakeAware: aware}))
+	assert.ElementsMatch(t, []multicluster.ClusterName{"project-a"}, aware.active())
+}
+
+func TestWrapUnsupportedProvider(t *testing.T) {
+	_, err := Wrap(&struct{ multicluster.Provider }{}, Options{})
+	assert.Error(t, err)
+}
+
+func TestFilteringAwareRecheck(t *testing.T) {
+	ctx := t.Context()
+
+	project := newProject("project-a", map[string]string{"shard": "b"}, nil, "")
+	cl := fake.NewClientBuilder().WithObjects(project).Build()
+
+	aware := &fakeAware{engaged: map[multicluster.ClusterName]context.Context{}}
+	filtering := newFilteringAware(aware, newTestOptions(t, cl))
+
+	clusterCtx, disengage := context.WithCancel(ctx)
+	require.NoError(t, filtering.Engage(clusterCtx, "project-a", &fakeCluster{}))
+	assert.Empty(t, aware.active(), "projects that don't match are skipped")
+
+	project.SetLabels(map[string]string{"shard": "a"})
+	require.NoError(t, cl.Update(ctx, project))
+	filtering.recheckAll()
+	assert.ElementsMatch(t, []multicluster.ClusterName{"project-a"}, aware.active(), "projects that start matching are engaged")
+
+	project.SetLabels(map[string]string{"shard": "b"})
+	require.NoError(t, cl.Update(ctx, project))
+	filtering.recheckAll()
+	assert.Empty(t, aware.active(), "projects that stop matching are disengaged")
+
+	disengage()
+	assert.Eventually(t, func() bool {
+		filtering.mu.Lock()
+		defer filtering.mu.Unlock()
+		return len(filtering.clusters) == 0
+	}, time.Second, 10*time.Millisecond, "clusters disengaged by the provider are forgotten")
+}
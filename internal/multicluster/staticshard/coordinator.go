@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package staticshard implements a multicluster coordinator that splits the
+// discovered clusters across a fixed number of operator replicas. Each replica
+// is configured with its shard index and the number of shards, and engages the
+// clusters that consistent hashing of the cluster name assigns to its shard.
+//
+// Unlike the lease-based coordinator enabled by --cluster-sharding-enabled,
+// replicas do not coordinate with each other: the assignment only depends on
+// the cluster name and the number of shards.
+package staticshard
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ mcmanager.Coordinator = &Coordinator{}
+
+// engagement is a cluster offered by the provider.
+type engagement struct {
+	// ctx is the context the provider engaged the cluster with.
+	ctx     context.Context
+	cluster cluster.Cluster
+
+	// cancel disengages the cluster. It is nil while the cluster belongs to
+	// another shard.
+	cancel context.CancelFunc
+}
+
+// Coordinator engages the clusters of one shard with the multicluster-aware
+// components of the manager.
+type Coordinator struct {
+	index int32
+	log   logr.Logger
+
+	mu       sync.Mutex
+	shards   int32
+	awares   []multicluster.Aware
+	clusters map[multicluster.ClusterName]*engagement
+}
+
+// New returns a coordinator for shard index out of shards.
+func New(index, shards int32) *Coordinator {
+	c := &Coordinator{
+		index:    index,
+		shards:   shards,
+		log:      log.Log.WithName("static-shard-coordinator").WithValues("shard", index),
+		clusters: map[multicluster.ClusterName]*engagement{},
+	}
+	if index >= shards {
+		c.log.Info("shard index is not below the number of shards, no clusters will be engaged", "shards", shards)
+	}
+	c.updateMetrics()
+	return c
+}
+
+// AddAware implements mcmanager.Coordinator.
+func (c *Coordinator) AddAware(aware multicluster.Aware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.awares = append(c.awares, aware)
+}
+
+// Engage implements mcmanager.Coordinator. The cluster is only engaged if it
+// belongs to the shard of the coordinator, but it is tracked until ctx is done
+// so that it can be engaged when the number of shards changes.
+func (c *Coordinator) Engage(ctx context.Context, name multicluster.ClusterName, cl cluster.Cluster) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if old, ok := c.clusters[name]; ok {
+		if old.ctx == ctx && old.cluster == cl && old.cancel != nil {
+			return nil
+		}
+		if old.cancel != nil {
+			old.cancel()
+		}
+	}
+
+	e := &engagement{ctx: ctx, cluster: cl}
+	c.clusters[name] = e
+	go func() {
+		<-ctx.Done()
+		c.forget(name, e)
+	}()
+
+	defer c.updateMetrics()
+	if !c.owns(name) {
+		c.log.V(1).Info("skipping cluster of another shard", "cluster", name)
+		return nil
+	}
+	return c.engage(name, e)
+}
+
+// Runnable implements mcmanager.Coordinator. The coordinator has no
+// background work.
+func (c *Coordinator) Runnable() manager.Runnable {
+	return nil
+}
+
+// SetShards changes the number of shards, engaging the clusters that moved
+// to the shard of the coordinator and disengaging the clusters that moved to
+// other shards.
+func (c *Coordinator) SetShards(shards int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if shards == c.shards {
+		return
+	}
+	c.log.Info("rebalancing clusters", "previousShards", c.shards, "shards", shards)
+	c.shards = shards
+	rebalancesTotal.Inc()
+	defer c.updateMetrics()
+
+	for name, e := range c.clusters {
+		owned := c.owns(name)
+		switch {
+		case owned && e.cancel == nil:
+			movedClustersTotal.Inc()
+			if err := c.engage(name, e); err != nil {
+				c.log.Error(err, "failed engaging cluster", "cluster", name)
+			}
+		case !owned && e.cancel != nil:
+			movedClustersTotal.Inc()
+			c.log.Info("disengaging cluster moved to another shard", "cluster", name)
+			e.cancel()
+			e.cancel = nil
+		}
+	}
+}
+
+// owns reports whether the cluster belongs to the shard of the coordinator.
+// It must be called with mu held.
+func (c *Coordinator) owns(name multicluster.ClusterName) bool {
+	return ShardFor(name, c.shards) == c.index
+}
+
+// engage engages the cluster of e with every aware. It must be called with mu
+// held.
+func (c *Coordinator) engage(name multicluster.ClusterName, e *engagement) error {
+	ctx, cancel := context.WithCancel(e.ctx)
+	for _, aware := range c.awares {
+		if err := aware.Engage(ctx, name, e.cluster); err != nil {
+			cancel()
+			return err
+		}
+	}
+	e.cancel = cancel
+	c.log.Info("engaged cluster", "cluster", name)
+	return nil
+}
+
+// forget stops tracking the cluster of e once the provider disengaged it.
+func (c *Coordinator) forget(name multicluster.ClusterName, e *engagement) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.clusters[name] == e {
+		delete(c.clusters, name)
+	}
+	if e.cancel != nil {
+		e.cancel()
+		e.cancel = nil
+	}
+	c.updateMetrics()
+}
+
+// updateMetrics must be called with mu held.
+func (c *Coordinator) updateMetrics() {
+	var engaged, skipped int
+	for _, e := range c.clusters {
+		if e.cancel != nil {
+			engaged++
+		} else {
+			skipped++
+		}
+	}
+	shard := strconv.Itoa(int(c.index))
+	shardClusters.WithLabelValues(shard, stateEngaged).Set(float64(engaged))
+	shardClusters.WithLabelValues(shard, stateSkipped).Set(float64(skipped))
+	shards.Set(float64(c.shards))
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package staticshard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+type fakeCluster struct {
+	cluster.Cluster
+}
+
+type fakeAware struct {
+	mu      sync.Mutex
+	engaged map[multicluster.ClusterName]context.Context
+}
+
+func (a *fakeAware) Engage(ctx context.Context, name multicluster.ClusterName, _ cluster.Cluster) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.engaged[name] = ctx
+	return nil
+}
+
+// active returns the names of the clusters whose engagement is live.
+func (a *fakeAware) active() map[multicluster.ClusterName]bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	names := map[multicluster.ClusterName]bool{}
+	for name, ctx := range a.engaged {
+		if ctx.Err() == nil {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+func clusterNames(n int) []multicluster.ClusterName {
+	names := make([]multicluster.ClusterName, n)
+	for i := range names {
+		names[i] = multicluster.ClusterName(fmt.Sprintf("project-%d", i))
+	}
+	return names
+}
+
+func TestShardFor(t *testing.T) {
+	names := clusterNames(1000)
+
+	counts := make([]int, 4)
+	for _, name := range names {
+		shard := ShardFor(name, 4)
+		require.GreaterOrEqual(t, shard, int32(0))
+		require.Less(t, shard, int32(4))
+		assert.Equal(t, shard, ShardFor(name, 4), "assignment must be deterministic")
+		counts[shard]++
+	}
+	for shard, count := range counts {
+		assert.InDeltaf(t, 250, count, 75, "shard %d has %d clusters", shard, count)
+	}
+
+	// Adding a shard only moves clusters to the new shard.
+	moved := 0
+	for _, name := range names {
+		before, after := ShardFor(name, 4), ShardFor(name, 5)
+		if before != after {
+			assert.Equal(t, int32(4), after)
+			moved++
+		}
+	}
+	assert.InDelta(t, 200, moved, 75)
+
+	assert.Equal(t, int32(0), ShardFor("project", 1))
+	assert.Equal(t, int32(0), ShardFor("project", 0))
+}
+
+func TestCoordinator(t *testing.T) {
+	ctx := t.Context()
+	names := clusterNames(50)
+
+	aware := &fakeAware{engaged: map[multicluster.ClusterName]context.Context{}}
+	coordinator := New(1, 2)
+	coordinator.AddAware(aware)
+
+	for _, name := range names {
+		require.NoError(t, coordinator.Engage(ctx, name, &fakeCluster{}))
+	}
+
+	assertShard := func(shards int32) {
+		t.Helper()
+		active := aware.active()
+		for _, name := range names {
+			assert.Equalf(t, ShardFor(name, shards) == 1, active[name], "cluster %s with %d shards", name, shards)
+		}
+	}
+	assertShard(2)
+
+	coordinator.SetShards(3)
+	assertShard(3)
+
+	coordinator.SetShards(2)
+	assertShard(2)
+}
+
+func TestCoordinatorForgetsDisengagedClusters(t *testing.T) {
+	aware := &fakeAware{engaged: map[multicluster.ClusterName]context.Context{}}
+	coordinator := New(0, 1)
+	coordinator.AddAware(aware)
+
+	clusterCtx, disengage := context.WithCancel(t.Context())
+	require.NoError(t, coordinator.Engage(clusterCtx, "project", &fakeCluster{}))
+	assert.True(t, aware.active()["project"])
+
+	disengage()
+	assert.Eventually(t, func() bool {
+		coordinator.mu.Lock()
+		defer coordinator.mu.Unlock()
+		return len(coordinator.clusters) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, aware.active()["project"])
+}
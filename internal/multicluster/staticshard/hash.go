@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package staticshard
+
+import (
+	"hash/fnv"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ShardFor returns the shard of the cluster, from 0 to shards-1.
+//
+// Clusters are assigned with jump consistent hashing, so that changing the
+// number of shards from n to n+1 moves only about 1/(n+1) of the clusters, all
+// of them to the new shard.
+func ShardFor(name multicluster.ClusterName, shards int32) int32 {
+	if shards <= 1 {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return jumpHash(h.Sum64(), shards)
+}
+
+// jumpHash implements "A Fast, Minimal Memory, Consistent Hash Algorithm" by
+// Lamping and Veach.
+func jumpHash(key uint64, buckets int32) int32 {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int32(b)
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package staticshard
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Cluster states, used as the "state" label of shardClusters.
+const (
+	stateEngaged = "engaged"
+	stateSkipped = "skipped"
+)
+
+var (
+	// shardClusters counts the discovered clusters of this replica by whether
+	// its shard owns them. Summing the engaged clusters of every replica:
+	//   sum by (shard) (nso_cluster_shard_clusters{state="engaged"})
+	// shows how evenly clusters are spread across shards.
+	shardClusters = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nso_cluster_shard_clusters",
+			Help: "Discovered clusters by shard and state (engaged | skipped).",
+		},
+		[]string{"shard", "state"},
+	)
+
+	shards = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "nso_cluster_shards",
+			Help: "Number of shards the discovered clusters are assigned to.",
+		},
+	)
+
+	// rebalancesTotal counts changes to the number of shards, and
+	// movedClustersTotal the clusters this replica engaged or disengaged
+	// because of them.
+	rebalancesTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "nso_cluster_shard_rebalances_total",
+			Help: "Total changes to the number of shards.",
+		},
+	)
+
+	movedClustersTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "nso_cluster_shard_moved_clusters_total",
+			Help: "Total clusters engaged or disengaged by this replica after a change to the number of shards.",
+		},
+	)
+)
@@ -0,0 +1,365 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package preflight evaluates a proposed Gateway or HTTPProxy against the
+// current state of a project, and reports the hostnames that would not be
+// programmed, without creating or changing any resources.
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	dnsutil "go.datum.net/network-services-operator/internal/util/dns"
+	"go.datum.net/network-services-operator/internal/validation"
+)
+
+// certificateIssuerTLSOption is the listener TLS option that requests a
+// certificate for the listener hostname.
+const certificateIssuerTLSOption = "gateway.networking.datumapis.com/certificate-issuer"
+
+// CAALookupTimeout bounds the CAA lookup of each hostname.
+const CAALookupTimeout = 5 * time.Second
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	// StatusPass checks found nothing that prevents programming.
+	StatusPass Status = "Pass"
+
+	// StatusWarning checks found a configuration that is likely to be
+	// unintended, or could not be completed.
+	StatusWarning Status = "Warning"
+
+	// StatusFail checks found a configuration that prevents programming.
+	StatusFail Status = "Fail"
+)
+
+// Checks run by the Checker.
+const (
+	CheckSpec         = "Spec"
+	CheckVerification = "Verification"
+	CheckDNS          = "DNS"
+	CheckAvailability = "Availability"
+	CheckCAA          = "CAA"
+)
+
+// Result is the outcome of a single check.
+type Result struct {
+	// Check is the name of the check.
+	Check string `json:"check"`
+
+	// Hostname is the hostname the check was run for, or empty for checks of
+	// the whole resource.
+	Hostname string `json:"hostname,omitempty"`
+
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+}
+
+// Report holds the results of the checks run for a resource.
+type Report struct {
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Results   []Result `json:"results"`
+}
+
+// Passed returns whether none of the checks failed.
+func (r *Report) Passed() bool {
+	return !slices.ContainsFunc(r.Results, func(result Result) bool {
+		return result.Status == StatusFail
+	})
+}
+
+// ConfigMap returns a ConfigMap that holds the report, so that the result of
+// a preflight can be inspected by other tools.
+func (r *Report) ConfigMap(key client.ObjectKey) (*corev1.ConfigMap, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling preflight report: %w", err)
+	}
+
+	status := StatusPass
+	if !r.Passed() {
+		status = StatusFail
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+		},
+		Data: map[string]string{
+			"status":      string(status),
+			"report.json": string(data),
+		},
+	}, nil
+}
+
+// Checker runs the preflight checks. It only reads from the cluster.
+type Checker struct {
+	// Client reads the Domains, DNSZones and Gateways of the project.
+	Client client.Client
+
+	Config config.NetworkServicesOperator
+
+	// Exchange looks up CAA records. CAA records are not inspected when nil,
+	// or when no issuer domains are configured.
+	Exchange dnsutil.ExchangeFunc
+}
+
+// Check runs the preflight checks for a Gateway or HTTPProxy.
+func (c *Checker) Check(ctx context.Context, obj client.Object) (*Report, error) {
+	var (
+		kind                 string
+		gatewayName          string
+		hostnames            []string
+		certificateHostnames []string
+		specResults          []Result
+	)
+
+	switch o := obj.(type) {
+	case *networkingv1alpha.HTTPProxy:
+		kind = "HTTPProxy"
+		// HTTPProxies are programmed through a Gateway of the same name, with
+		// a certificate for each hostname.
+		gatewayName = o.Name
+		for _, hostname := range o.Spec.Hostnames {
+			hostnames = append(hostnames, string(hostname))
+		}
+		certificateHostnames = hostnames
+		specResults = specErrorResults(append(
+			validation.ValidateHTTPProxy(o),
+			validation.ValidateHTTPProxyRequestPolicies(o, c.Config.HTTPProxy.RequestPolicy)...,
+		))
+	case *gatewayv1.Gateway:
+		kind = "Gateway"
+		gatewayName = o.Name
+		for _, l := range o.Spec.Listeners {
+			if l.Hostname == nil {
+				continue
+			}
+			hostname := string(*l.Hostname)
+			if !slices.Contains(hostnames, hostname) {
+				hostnames = append(hostnames, hostname)
+			}
+			if l.TLS != nil && l.TLS.Options[certificateIssuerTLSOption] != "" && !slices.Contains(certificateHostnames, hostname) {
+				certificateHostnames = append(certificateHostnames, hostname)
+			}
+		}
+		specResults = specErrorResults(validation.ValidateTenantListenerHostnames(o))
+	default:
+		return nil, fmt.Errorf("unsupported preflight resource %T", obj)
+	}
+
+	report := &Report{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Results:   specResults,
+	}
+	if len(specResults) == 0 {
+		report.Results = append(report.Results, Result{
+			Check:   CheckSpec,
+			Status:  StatusPass,
+			Message: "the spec is valid",
+		})
+	}
+
+	var domains networkingv1alpha.DomainList
+	if err := c.Client.List(ctx, &domains, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed listing domains: %w", err)
+	}
+
+	var dnsZones dnsv1alpha1.DNSZoneList
+	if err := c.Client.List(ctx, &dnsZones, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed listing dnszones: %w", err)
+	}
+
+	var gateways gatewayv1.GatewayList
+	if err := c.Client.List(ctx, &gateways, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed listing gateways: %w", err)
+	}
+
+	for _, hostname := range hostnames {
+		if c.isPlatformHostname(hostname) {
+			report.Results = append(report.Results, Result{
+				Check:    CheckVerification,
+				Hostname: hostname,
+				Status:   StatusPass,
+				Message:  "the hostname is managed by the platform",
+			})
+			continue
+		}
+
+		domain, result := c.checkVerification(hostname, domains.Items)
+		report.Results = append(report.Results, result)
+		if domain != nil {
+			report.Results = append(report.Results, checkDNS(hostname, domain, dnsZones.Items))
+		}
+		report.Results = append(report.Results, checkAvailability(hostname, gatewayName, gateways.Items))
+
+		if slices.Contains(certificateHostnames, hostname) {
+			if result, ok := c.checkCAA(ctx, hostname); ok {
+				report.Results = append(report.Results, result)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func specErrorResults(errs []*field.Error) []Result {
+	var results []Result
+	for _, err := range errs {
+		results = append(results, Result{
+			Check:   CheckSpec,
+			Status:  StatusFail,
+			Message: err.Error(),
+		})
+	}
+	return results
+}
+
+// isPlatformHostname returns whether hostname is in the platform's target
+// domain, which is exempt from verification.
+func (c *Checker) isPlatformHostname(hostname string) bool {
+	targetDomain := c.Config.Gateway.TargetDomain
+	return targetDomain != "" && (hostname == targetDomain || strings.HasSuffix(hostname, "."+targetDomain))
+}
+
+// checkVerification mirrors the Domain matching of the gateway controller: a
+// hostname is verified when a verified Domain in the same namespace matches the
+// hostname exactly, or the hostname is a sub domain of it. The matching Domain
+// is returned, preferring verified Domains.
+func (c *Checker) checkVerification(hostname string, domains []networkingv1alpha.Domain) (*networkingv1alpha.Domain, Result) {
+	result := Result{Check: CheckVerification, Hostname: hostname}
+
+	var matched *networkingv1alpha.Domain
+	var unverified []string
+	for i, domain := range domains {
+		if hostname != domain.Spec.DomainName && !strings.HasSuffix(hostname, "."+domain.Spec.DomainName) {
+			continue
+		}
+		if apimeta.IsStatusConditionTrue(domain.Status.Conditions, networkingv1alpha.DomainConditionVerified) {
+			result.Status = StatusPass
+			result.Message = fmt.Sprintf("Domain %s is verified", domain.Name)
+			return &domains[i], result
+		}
+		if matched == nil {
+			matched = &domains[i]
+		}
+		unverified = append(unverified, domain.Name)
+	}
+
+	switch {
+	case c.Config.Gateway.DisableHostnameVerification:
+		result.Status = StatusPass
+		result.Message = "hostname verification is disabled"
+	case matched != nil:
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("Domain %s is not verified", strings.Join(unverified, ", "))
+	default:
+		result.Status = StatusFail
+		result.Message = "no Domain matches the hostname; a Domain will be created, and must be verified before the hostname is programmed"
+	}
+	return matched, result
+}
+
+// checkDNS reports whether DNS records for hostname will be managed in a
+// DNSZone, or must be created by the user.
+func checkDNS(hostname string, domain *networkingv1alpha.Domain, dnsZones []dnsv1alpha1.DNSZone) Result {
+	result := Result{Check: CheckDNS, Hostname: hostname}
+
+	for _, dnsZone := range dnsZones {
+		if dnsZone.Spec.DomainName != domain.Spec.DomainName {
+			continue
+		}
+		if dnsutil.HasDNSAuthority(domain, &dnsZone) {
+			result.Status = StatusPass
+			result.Message = fmt.Sprintf("DNS records will be programmed in DNSZone %s", dnsZone.Name)
+			return result
+		}
+	}
+
+	result.Status = StatusWarning
+	result.Message = fmt.Sprintf("no DNSZone in the namespace has authority over %s; a CNAME record for the hostname must be created with the DNS provider of the domain", domain.Spec.DomainName)
+	return result
+}
+
+// checkAvailability reports whether another Gateway in the namespace already
+// has a listener for hostname. Hostnames claimed by gateways of other projects
+// are only detected once the resource is programmed.
+func checkAvailability(hostname, gatewayName string, gateways []gatewayv1.Gateway) Result {
+	result := Result{Check: CheckAvailability, Hostname: hostname}
+
+	inUse := sets.New[string]()
+	for _, gateway := range gateways {
+		if gateway.Name == gatewayName {
+			continue
+		}
+		for _, l := range gateway.Spec.Listeners {
+			if l.Hostname != nil && string(*l.Hostname) == hostname {
+				inUse.Insert(gateway.Name)
+			}
+		}
+	}
+
+	if inUse.Len() > 0 {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("the hostname is already used by Gateway %s", strings.Join(sets.List(inUse), ", "))
+		return result
+	}
+	result.Status = StatusPass
+	result.Message = "the hostname is not used by another Gateway in the namespace"
+	return result
+}
+
+// checkCAA reports whether the CAA records of hostname authorize the issuer
+// domains to issue certificates. It returns false when CAA records are not
+// inspected.
+func (c *Checker) checkCAA(ctx context.Context, hostname string) (Result, bool) {
+	issuerDomains := c.Config.Gateway.CAA.IssuerDomains
+	if c.Exchange == nil || len(issuerDomains) == 0 {
+		return Result{}, false
+	}
+
+	result := Result{Check: CheckCAA, Hostname: hostname}
+
+	ctx, cancel := context.WithTimeout(ctx, CAALookupTimeout)
+	defer cancel()
+	caaResult, err := dnsutil.CheckCAA(ctx, c.Exchange, hostname, issuerDomains)
+	switch {
+	case err != nil:
+		result.Status = StatusWarning
+		result.Message = fmt.Sprintf("failed looking up CAA records: %s", err)
+	case !caaResult.Allowed:
+		issuers := "no certificate authority"
+		if len(caaResult.Issuers) > 0 {
+			issuers = strings.Join(caaResult.Issuers, ", ")
+		}
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("CAA records at %s authorize %s, and do not allow %s to issue certificates",
+			caaResult.RecordName, issuers, strings.Join(issuerDomains, ", "))
+	default:
+		result.Status = StatusPass
+		result.Message = "CAA records allow certificate issuance"
+	}
+	return result, true
+}
@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dnsv1alpha1 "go.miloapis.com/dns-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, networkingv1alpha.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, dnsv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func newDomain(name string, verified bool, nameservers ...string) *networkingv1alpha.Domain {
+	domain := &networkingv1alpha.Domain{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       networkingv1alpha.DomainSpec{DomainName: name},
+	}
+	if verified {
+		domain.Status.Conditions = []metav1.Condition{{
+			Type:   networkingv1alpha.DomainConditionVerified,
+			Status: metav1.ConditionTrue,
+		}}
+	}
+	for _, ns := range nameservers {
+		domain.Status.Nameservers = append(domain.Status.Nameservers, networkingv1alpha.Nameserver{Hostname: ns})
+	}
+	return domain
+}
+
+func resultFor(report *Report, check, hostname string) *Result {
+	for i, result := range report.Results {
+		if result.Check == check && result.Hostname == hostname {
+			return &report.Results[i]
+		}
+	}
+	return nil
+}
+
+func TestCheckHTTPProxy(t *testing.T) {
+	ctx := context.Background()
+
+	dnsZone := &dnsv1alpha1.DNSZone{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example-com"},
+		Spec:       dnsv1alpha1.DNSZoneSpec{DomainName: "example.com"},
+		Status: dnsv1alpha1.DNSZoneStatus{
+			Nameservers: []string{"ns1.datumdomains.net"},
+			Conditions: []metav1.Condition{
+				{Type: "Accepted", Status: metav1.ConditionTrue},
+				{Type: "Programmed", Status: metav1.ConditionTrue},
+			},
+		},
+	}
+	otherGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{{
+				Name:     "https",
+				Protocol: gatewayv1.HTTPSProtocolType,
+				Port:     443,
+				Hostname: ptr.To(gatewayv1.Hostname("used.example.com")),
+			}},
+		},
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(
+			newDomain("example.com", true, "ns1.datumdomains.net"),
+			newDomain("example.org", false),
+			newDomain("example.io", true),
+			dnsZone,
+			otherGateway,
+		).
+		Build()
+
+	exchange := func(_ context.Context, name string, qtype uint16) (*dns.Msg, error) {
+		msg := new(dns.Msg)
+		if name == "example.io" && qtype == dns.TypeCAA {
+			msg.Answer = []dns.RR{&dns.CAA{
+				Hdr:   dns.RR_Header{Rrtype: dns.TypeCAA, Class: dns.ClassINET},
+				Tag:   "issue",
+				Value: "pki.goog",
+			}}
+		}
+		return msg, nil
+	}
+
+	checker := &Checker{
+		Client: cl,
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{
+				TargetDomain: "datumproxy.net",
+				CAA:          config.GatewayCAAConfig{IssuerDomains: []string{"letsencrypt.org"}},
+			},
+		},
+		Exchange: exchange,
+	}
+
+	proxy := &networkingv1alpha.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "proxy"},
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Hostnames: []gatewayv1.Hostname{
+				"www.example.com",
+				"www.example.org",
+				"www.example.net",
+				"used.example.com",
+				"www.example.io",
+				"abc.datumproxy.net",
+			},
+			Rules: []networkingv1alpha.HTTPProxyRule{{
+				Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://backend.example.com"}},
+			}},
+		},
+	}
+
+	report, err := checker.Check(ctx, proxy)
+	require.NoError(t, err)
+
+	assert.Equal(t, "HTTPProxy", report.Kind)
+	assert.False(t, report.Passed())
+
+	scenarios := []struct {
+		check    string
+		hostname string
+		status   Status
+	}{
+		{CheckSpec, "", StatusPass},
+		{CheckVerification, "www.example.com", StatusPass},
+		{CheckDNS, "www.example.com", StatusPass},
+		{CheckAvailability, "www.example.com", StatusPass},
+		{CheckCAA, "www.example.com", StatusPass},
+		{CheckVerification, "www.example.org", StatusFail},
+		{CheckDNS, "www.example.org", StatusWarning},
+		{CheckVerification, "www.example.net", StatusFail},
+		{CheckAvailability, "used.example.com", StatusFail},
+		{CheckDNS, "www.example.io", StatusWarning},
+		{CheckCAA, "www.example.io", StatusFail},
+		{CheckVerification, "abc.datumproxy.net", StatusPass},
+	}
+	for _, scenario := range scenarios {
+		result := resultFor(report, scenario.check, scenario.hostname)
+		if assert.NotNilf(t, result, "expected a %s result for %q", scenario.check, scenario.hostname) {
+			assert.Equalf(t, scenario.status, result.Status, "%s of %q: %s", scenario.check, scenario.hostname, result.Message)
+		}
+	}
+
+	assert.Nil(t, resultFor(report, CheckDNS, "www.example.net"), "hostnames without a Domain have no DNS result")
+	assert.Nil(t, resultFor(report, CheckAvailability, "abc.datumproxy.net"), "platform hostnames are not checked further")
+}
+
+func TestCheckGateway(t *testing.T) {
+	ctx := context.Background()
+
+	cl := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(newDomain("example.com", true)).
+		Build()
+
+	lookups := 0
+	exchange := func(_ context.Context, _ string, _ uint16) (*dns.Msg, error) {
+		lookups++
+		return new(dns.Msg), nil
+	}
+
+	checker := &Checker{
+		Client: cl,
+		Config: config.NetworkServicesOperator{
+			Gateway: config.GatewayConfig{
+				CAA: config.GatewayCAAConfig{IssuerDomains: []string{"letsencrypt.org"}},
+			},
+		},
+		Exchange: exchange,
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{
+					Name:     "http",
+					Protocol: gatewayv1.HTTPProtocolType,
+					Port:     80,
+					Hostname: ptr.To(gatewayv1.Hostname("www.example.com")),
+				},
+				{
+					Name:     "https",
+					Protocol: gatewayv1.HTTPSProtocolType,
+					Port:     443,
+					Hostname: ptr.To(gatewayv1.Hostname("www.example.com")),
+					TLS: &gatewayv1.ListenerTLSConfig{
+						Options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+							certificateIssuerTLSOption: "auto",
+						},
+					},
+				},
+				{
+					Name:     "plain",
+					Protocol: gatewayv1.HTTPProtocolType,
+					Port:     80,
+					Hostname: ptr.To(gatewayv1.Hostname("plain.example.com")),
+				},
+			},
+		},
+	}
+
+	report, err := checker.Check(ctx, gateway)
+	require.NoError(t, err)
+	assert.True(t, report.Passed(), "unexpected results: %+v", report.Results)
+
+	// Listeners of the same hostname are checked once.
+	verification := 0
+	for _, result := range report.Results {
+		if result.Check == CheckVerification && result.Hostname == "www.example.com" {
+			verification++
+		}
+	}
+	assert.Equal(t, 1, verification)
+
+	// CAA records are only inspected for listeners that request certificates.
+	assert.NotNil(t, resultFor(report, CheckCAA, "www.example.com"))
+	assert.Nil(t, resultFor(report, CheckCAA, "plain.example.com"))
+	assert.Positive(t, lookups)
+}
+
+func TestCheckUnsupportedResource(t *testing.T) {
+	checker := &Checker{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()}
+	_, err := checker.Check(context.Background(), &networkingv1alpha.Domain{})
+	assert.Error(t, err)
+}
+
+func TestReportConfigMap(t *testing.T) {
+	report := &Report{
+		Kind:      "HTTPProxy",
+		Namespace: "default",
+		Name:      "proxy",
+		Results: []Result{
+			{Check: CheckSpec, Status: StatusPass, Message: "the spec is valid"},
+			{Check: CheckVerification, Hostname: "www.example.com", Status: StatusFail, Message: "Domain example.com is not verified"},
+		},
+	}
+
+	configMap, err := report.ConfigMap(client.ObjectKey{Namespace: "default", Name: "proxy-preflight"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "proxy-preflight", configMap.Name)
+	assert.Equal(t, string(StatusFail), configMap.Data["status"])
+
+	var decoded Report
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data["report.json"]), &decoded))
+	assert.Equal(t, *report, decoded)
+}
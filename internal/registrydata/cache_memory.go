@@ -10,6 +10,9 @@ type memoryCache struct {
 	mu      sync.RWMutex
 	entries map[string]memEntry
 	now     func() time.Time
+
+	// maxEntries bounds the number of entries; zero or less means unbounded.
+	maxEntries int
 }
 
 type memEntry struct {
@@ -17,10 +20,11 @@ type memEntry struct {
 	expiresAt time.Time
 }
 
-func newMemoryCache() *memoryCache {
+func newMemoryCache(maxEntries int) *memoryCache {
 	return &memoryCache{
-		entries: make(map[string]memEntry),
-		now:     time.Now,
+		entries:    make(map[string]memEntry),
+		now:        time.Now,
+		maxEntries: maxEntries,
 	}
 }
 
@@ -62,7 +66,45 @@ func (c *memoryCache) Set(key string, value any, ttl time.Duration) error {
 		expiresAt = c.now().Add(ttl)
 	}
 	c.mu.Lock()
+	if _, ok := c.entries[key]; !ok && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evictLocked()
+	}
 	c.entries[key] = memEntry{b: b, expiresAt: expiresAt}
 	c.mu.Unlock()
 	return nil
 }
+
+// evictLocked makes room for one entry. Expired entries are dropped first; if
+// none have expired, the entry closest to expiry is evicted, since it would be
+// the first to be refetched anyway. Must be called with mu held.
+func (c *memoryCache) evictLocked() {
+	now := c.now()
+	var victim string
+	var victimExpiresAt time.Time
+	expired := 0
+	for k, e := range c.entries {
+		if e.expiresAt.IsZero() {
+			continue
+		}
+		if now.After(e.expiresAt) {
+			delete(c.entries, k)
+			expired++
+			continue
+		}
+		if victim == "" || e.expiresAt.Before(victimExpiresAt) {
+			victim, victimExpiresAt = k, e.expiresAt
+		}
+	}
+	if expired > 0 {
+		return
+	}
+	if victim == "" {
+		// every entry is permanent; evict an arbitrary one
+		for k := range c.entries {
+			victim = k
+			break
+		}
+	}
+	delete(c.entries, victim)
+	cacheEvictionsTotal.Inc()
+}
@@ -10,7 +10,7 @@ import (
 func TestMemoryCache_SetGetAndExpire(t *testing.T) {
 	t.Parallel()
 
-	c := newMemoryCache()
+	c := newMemoryCache(0)
 	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	c.now = func() time.Time { return now }
 
@@ -37,7 +37,7 @@ func TestMemoryCache_SetGetAndExpire(t *testing.T) {
 func TestMemoryCache_BadJSONTreatedAsMissAndDeleted(t *testing.T) {
 	t.Parallel()
 
-	c := newMemoryCache()
+	c := newMemoryCache(0)
 	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	c.now = func() time.Time { return now }
 
@@ -55,3 +55,39 @@ func TestMemoryCache_BadJSONTreatedAsMissAndDeleted(t *testing.T) {
 	c.mu.RUnlock()
 	require.False(t, ok)
 }
+
+func TestMemoryCache_MaxEntriesEvictsExpiredThenClosestToExpiry(t *testing.T) {
+	t.Parallel()
+
+	c := newMemoryCache(2)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Set("short", 1, 10*time.Second))
+	require.NoError(t, c.Set("long", 2, time.Hour))
+
+	// Full: the entry closest to expiry is evicted.
+	require.NoError(t, c.Set("new", 3, 30*time.Minute))
+	var got int
+	found, err := c.Get("short", &got)
+	require.NoError(t, err)
+	require.False(t, found)
+	found, err = c.Get("long", &got)
+	require.NoError(t, err)
+	require.True(t, found)
+
+	// Overwriting an existing key does not evict.
+	require.NoError(t, c.Set("new", 4, 30*time.Minute))
+	require.Len(t, c.entries, 2)
+
+	// Expired entries are dropped before unexpired ones are evicted.
+	now = now.Add(45 * time.Minute)
+	require.NoError(t, c.Set("later", 5, time.Hour))
+	found, err = c.Get("long", &got)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, 2, got)
+	found, err = c.Get("new", &got)
+	require.NoError(t, err)
+	require.False(t, found)
+}
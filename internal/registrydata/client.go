@@ -52,15 +52,7 @@ func NewClient(cfg Config) (Client, error) {
 	if cfg.CacheTTLs.IPRegistrant <= 0 {
 		cfg.CacheTTLs.IPRegistrant = 6 * time.Hour
 	}
-	if cfg.RateLimits.DefaultRatePerSec <= 0 {
-		cfg.RateLimits.DefaultRatePerSec = 1.0
-	}
-	if cfg.RateLimits.DefaultBurst <= 0 {
-		cfg.RateLimits.DefaultBurst = 5
-	}
-	if cfg.RateLimits.DefaultBlock <= 0 {
-		cfg.RateLimits.DefaultBlock = 2 * time.Second
-	}
+	cfg.RateLimits = cfg.RateLimits.withDefaults()
 	if cfg.WhoisBootstrapHost == "" {
 		cfg.WhoisBootstrapHost = "whois.iana.org"
 	}
@@ -82,7 +74,7 @@ func NewClient(cfg Config) (Client, error) {
 	var cache Cache
 	switch cfg.Cache.Backend {
 	case CacheBackendMemory:
-		cache = newMemoryCache()
+		cache = newMemoryCache(cfg.Cache.MaxEntries)
 	case CacheBackendRedis:
 		if cfg.RedisClient == nil {
 			return nil, fmt.Errorf("redis cache backend requires RedisClient")
@@ -118,6 +110,14 @@ func NewClient(cfg Config) (Client, error) {
 	return c, nil
 }
 
+// SetRateLimits replaces the provider rate limits. Buckets keep their tokens
+// and are refilled at the new rates from then on.
+func (c *client) SetRateLimits(limits RateLimits) {
+	if setter, ok := c.limiter.(RateLimitSetter); ok {
+		setter.SetRateLimits(limits.withDefaults())
+	}
+}
+
 func (c *client) LookupDomain(ctx context.Context, domain string, opts LookupOptions) (*DomainResult, error) {
 	domainNorm := normalizeDomain(domain)
 	apex, err := publicsuffix.EffectiveTLDPlusOne(domainNorm)
@@ -128,7 +128,7 @@ func (c *client) LookupDomain(ctx context.Context, domain string, opts LookupOpt
 
 	if !opts.ForceRefresh {
 		var cached DomainResult
-		if found, err := c.cache.Get(cacheKey, &cached); err != nil {
+		if found, err := c.cacheGet(cacheKindDomain, cacheKey, &cached); err != nil {
 			return nil, err
 		} else if found {
 			return &cached, nil
@@ -168,7 +168,7 @@ func (c *client) LookupNameserver(ctx context.Context, hostname string, opts Loo
 
 	if !opts.ForceRefresh {
 		var cached nameserverCacheValue
-		if found, err := c.cache.Get(cacheKey, &cached); err != nil {
+		if found, err := c.cacheGet(cacheKindNameserver, cacheKey, &cached); err != nil {
 			return nil, err
 		} else if found {
 			return cached.toResult(c.cfg.CacheTTLs.Nameserver), nil
@@ -212,7 +212,7 @@ func (c *client) LookupIPRegistrant(ctx context.Context, ip net.IP, opts LookupO
 
 	if !opts.ForceRefresh {
 		var cached IPRegistrantResult
-		if found, err := c.cache.Get(cacheKey, &cached); err != nil {
+		if found, err := c.cacheGet(cacheKindIPRegistrant, cacheKey, &cached); err != nil {
 			return nil, err
 		} else if found {
 			return &cached, nil
@@ -247,6 +247,22 @@ func (c *client) LookupIPRegistrant(ctx context.Context, ip net.IP, opts LookupO
 	return v.(*IPRegistrantResult), nil
 }
 
+// cacheGet looks up a cached value and records the result of the lookup. Only
+// the first lookup of a request is recorded; the re-check inside singleflight
+// would otherwise count every miss twice.
+func (c *client) cacheGet(kind, key string, dst any) (bool, error) {
+	found, err := c.cache.Get(key, dst)
+	result := cacheResultMiss
+	switch {
+	case err != nil:
+		result = cacheResultError
+	case found:
+		result = cacheResultHit
+	}
+	cacheLookupsTotal.WithLabelValues(string(c.cfg.Cache.Backend), kind, result).Inc()
+	return found, err
+}
+
 // --- domain lookup implementation ---
 
 type nameserverCacheValue struct {
@@ -32,6 +32,12 @@ func newMemoryProviderLimiter(limits RateLimits) *memoryProviderLimiter {
 	}
 }
 
+func (l *memoryProviderLimiter) SetRateLimits(limits RateLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits = limits
+}
+
 func (l *memoryProviderLimiter) Acquire(_ context.Context, provider string) (bool, time.Duration, error) {
 	if provider == "" {
 		provider = defaultProvider
@@ -40,9 +46,10 @@ func (l *memoryProviderLimiter) Acquire(_ context.Context, provider string) (boo
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	ratePerSec, burst := l.limits.forProvider(provider)
 	b := l.buckets[provider]
 	if b == nil {
-		b = &memBucket{tokens: l.limits.DefaultBurst, lastRefill: now}
+		b = &memBucket{tokens: burst, lastRefill: now}
 		l.buckets[provider] = b
 	}
 	b.lastTouched = now
@@ -63,7 +70,7 @@ func (l *memoryProviderLimiter) Acquire(_ context.Context, provider string) (boo
 	if delta < 0 {
 		delta = 0
 	}
-	b.tokens = minF(l.limits.DefaultBurst, b.tokens+(delta.Seconds()*l.limits.DefaultRatePerSec))
+	b.tokens = minF(burst, b.tokens+(delta.Seconds()*ratePerSec))
 	b.lastRefill = now
 
 	if b.tokens >= 1 {
@@ -88,7 +95,8 @@ func (l *memoryProviderLimiter) BlockUntil(_ context.Context, provider string, u
 	now := l.now()
 	b := l.buckets[provider]
 	if b == nil {
-		b = &memBucket{tokens: l.limits.DefaultBurst, lastRefill: now}
+		_, burst := l.limits.forProvider(provider)
+		b = &memBucket{tokens: burst, lastRefill: now}
 		l.buckets[provider] = b
 	}
 	b.lastTouched = now
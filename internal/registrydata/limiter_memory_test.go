@@ -152,3 +152,88 @@ func TestMemoryProviderLimiter_ConcurrentAcquire_SingleWinner(t *testing.T) {
 	}
 	require.Equal(t, 1, okCount, "expected exactly one successful Acquire")
 }
+
+func TestMemoryProviderLimiter_ProviderOverrides(t *testing.T) {
+	t.Parallel()
+
+	limits := RateLimits{
+		DefaultRatePerSec: 1.0,
+		DefaultBurst:      1,
+		DefaultBlock:      2 * time.Second,
+		Providers: map[string]ProviderRateLimit{
+			"rdap.slow.example": {RatePerSec: 0.1},
+			"rdap.fast.example": {RatePerSec: 10, Burst: 3},
+		},
+	}
+	l := newMemoryProviderLimiter(limits)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// The fast provider has its own burst.
+	for i := 0; i < 3; i++ {
+		ok, _, err := l.Acquire(ctx, "rdap.fast.example")
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	ok, _, err := l.Acquire(ctx, "rdap.fast.example")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// The slow provider falls back to the default burst, but refills slower.
+	ok, _, err = l.Acquire(ctx, "rdap.slow.example")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	now = now.Add(3 * time.Second)
+	ok, _, err = l.Acquire(ctx, "rdap.slow.example")
+	require.NoError(t, err)
+	require.False(t, ok, "expected slow provider to still be limited after the default refill time")
+
+	ok, _, err = l.Acquire(ctx, "rdap.other.example")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestMemoryProviderLimiter_SetRateLimits(t *testing.T) {
+	t.Parallel()
+
+	l := newMemoryProviderLimiter(RateLimits{
+		DefaultRatePerSec: 1.0,
+		DefaultBurst:      1,
+		DefaultBlock:      2 * time.Second,
+	})
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	ok, _, err := l.Acquire(ctx, "rdap.example")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	l.SetRateLimits(RateLimits{
+		DefaultRatePerSec: 1.0,
+		DefaultBurst:      1,
+		DefaultBlock:      5 * time.Second,
+		Providers: map[string]ProviderRateLimit{
+			"rdap.example": {RatePerSec: 10, Burst: 3},
+		},
+	})
+
+	// The bucket keeps its tokens, and is refilled at the new rate.
+	ok, retry, err := l.Acquire(ctx, "rdap.example")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, 5*time.Second, retry)
+
+	now = now.Add(6 * time.Second)
+	for range 3 {
+		ok, _, err = l.Acquire(ctx, "rdap.example")
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+}
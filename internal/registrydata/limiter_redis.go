@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	redis "github.com/go-redis/redis/v7"
@@ -12,9 +13,11 @@ import (
 type redisProviderLimiter struct {
 	client   redis.UniversalClient
 	prefix   string
-	limits   RateLimits
 	stateTTL time.Duration
 
+	mu     sync.RWMutex
+	limits RateLimits
+
 	acquireScript *redis.Script
 	blockScript   *redis.Script
 }
@@ -78,6 +81,18 @@ return 1
 	}
 }
 
+func (l *redisProviderLimiter) SetRateLimits(limits RateLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits = limits
+}
+
+func (l *redisProviderLimiter) rateLimits() RateLimits {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.limits
+}
+
 func (l *redisProviderLimiter) key(provider string) string {
 	if provider == "" {
 		provider = "default"
@@ -97,12 +112,14 @@ func (l *redisProviderLimiter) Acquire(ctx context.Context, provider string) (bo
 	if stateTTLms <= 0 {
 		stateTTLms = (30 * time.Minute).Milliseconds()
 	}
-	defaultBlock := l.limits.DefaultBlock
+	limits := l.rateLimits()
+	defaultBlock := limits.DefaultBlock
 	if defaultBlock <= 0 {
 		defaultBlock = 2 * time.Second
 	}
+	ratePerSec, burst := limits.forProvider(provider)
 	res, err := l.acquireScript.Run(l.client, []string{l.key(provider)}, nowMs,
-		l.limits.DefaultRatePerSec, l.limits.DefaultBurst, defaultBlock.Milliseconds(), stateTTLms).Result()
+		ratePerSec, burst, defaultBlock.Milliseconds(), stateTTLms).Result()
 	if err != nil {
 		return false, 0, err
 	}
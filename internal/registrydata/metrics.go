@@ -0,0 +1,45 @@
+package registrydata
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Cache kinds, used as the "kind" label of cache metrics.
+const (
+	cacheKindDomain       = "domain"
+	cacheKindNameserver   = "nameserver"
+	cacheKindIPRegistrant = "ip_registrant"
+)
+
+// Cache lookup results, used as the "result" label of cache metrics.
+const (
+	cacheResultHit   = "hit"
+	cacheResultMiss  = "miss"
+	cacheResultError = "error"
+)
+
+var (
+	// cacheLookupsTotal counts cache lookups made by the client before it falls
+	// back to upstream providers. The miss rate approximates the rate of RDAP,
+	// WHOIS and DNS queries made on behalf of Domains:
+	//   sum(rate(nso_registrydata_cache_lookups_total{result="miss"}[5m]))
+	cacheLookupsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nso_registrydata_cache_lookups_total",
+			Help: "Total registry data cache lookups by backend, kind and result (hit | miss | error).",
+		},
+		[]string{"backend", "kind", "result"},
+	)
+
+	// cacheEvictionsTotal counts entries evicted from the memory cache before
+	// they expired because the cache reached its maximum number of entries. A
+	// rising rate means the cache is too small for the number of apexes looked
+	// up within the domain TTL.
+	cacheEvictionsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "nso_registrydata_cache_evictions_total",
+			Help: "Total entries evicted from the registry data memory cache because it was full.",
+		},
+	)
+)
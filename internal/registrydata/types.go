@@ -4,6 +4,7 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	redis "github.com/go-redis/redis/v7"
@@ -24,6 +25,12 @@ type Client interface {
 	LookupIPRegistrant(ctx context.Context, ip net.IP, opts LookupOptions) (*IPRegistrantResult, error)
 }
 
+// RateLimitSetter is implemented by clients whose provider rate limits can be
+// changed while they are in use.
+type RateLimitSetter interface {
+	SetRateLimits(limits RateLimits)
+}
+
 type LookupOptions struct {
 	// ForceRefresh bypasses cache and always fetches fresh data from upstream.
 	ForceRefresh bool
@@ -81,6 +88,10 @@ type CacheConfig struct {
 	Backend CacheBackend
 	// RedisKeyPrefix is used for Redis keys when Backend == redis.
 	RedisKeyPrefix string
+	// MaxEntries bounds the number of entries of the memory backend. Zero or a
+	// negative value means unbounded. The Redis backend relies on key expiry
+	// instead.
+	MaxEntries int
 }
 
 type CacheTTLs struct {
@@ -93,6 +104,45 @@ type RateLimits struct {
 	DefaultRatePerSec float64
 	DefaultBurst      float64
 	DefaultBlock      time.Duration
+
+	// Providers overrides the default rate and burst for specific provider keys
+	// (RDAP base URL hosts and WHOIS hosts).
+	Providers map[string]ProviderRateLimit
+}
+
+// ProviderRateLimit is the token bucket of a single provider. Zero fields fall
+// back to the defaults of RateLimits.
+type ProviderRateLimit struct {
+	RatePerSec float64
+	Burst      float64
+}
+
+// withDefaults returns the limits with unset defaults filled in.
+func (l RateLimits) withDefaults() RateLimits {
+	if l.DefaultRatePerSec <= 0 {
+		l.DefaultRatePerSec = 1.0
+	}
+	if l.DefaultBurst <= 0 {
+		l.DefaultBurst = 5
+	}
+	if l.DefaultBlock <= 0 {
+		l.DefaultBlock = 2 * time.Second
+	}
+	return l
+}
+
+// forProvider returns the token rate and burst size for the provider.
+func (l RateLimits) forProvider(provider string) (ratePerSec, burst float64) {
+	ratePerSec, burst = l.DefaultRatePerSec, l.DefaultBurst
+	if p, ok := l.Providers[strings.ToLower(provider)]; ok {
+		if p.RatePerSec > 0 {
+			ratePerSec = p.RatePerSec
+		}
+		if p.Burst > 0 {
+			burst = p.Burst
+		}
+	}
+	return ratePerSec, burst
 }
 
 type Config struct {
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// LookupAddresses returns the addresses that a recursive resolver answers for
+// the A or AAAA records (qtype) of hostname, sorted. The answer of a hostname
+// that is a CNAME of another hostname, or an ALIAS flattened by the DNS
+// provider, holds the addresses of the target. A hostname that doesn't exist
+// has no addresses.
+func LookupAddresses(ctx context.Context, exchange ExchangeFunc, hostname string, qtype uint16) ([]netip.Addr, error) {
+	addresses, _, err := LookupAddressesWithTTL(ctx, exchange, hostname, qtype)
+	return addresses, err
+}
+
+// LookupAddressesWithTTL is LookupAddresses that also returns the lowest TTL of
+// the address records in the answer, or zero if there are none.
+func LookupAddressesWithTTL(ctx context.Context, exchange ExchangeFunc, hostname string, qtype uint16) ([]netip.Addr, time.Duration, error) {
+	if qtype != dns.TypeA && qtype != dns.TypeAAAA {
+		return nil, 0, fmt.Errorf("unsupported address record type %s", dns.TypeToString[qtype])
+	}
+
+	resp, err := exchange(ctx, hostname, qtype)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed looking up %s records for %s: %w", dns.TypeToString[qtype], hostname, err)
+	}
+
+	var addresses []netip.Addr
+	var ttl time.Duration
+	for _, rr := range resp.Answer {
+		var ip netip.Addr
+		switch rr := rr.(type) {
+		case *dns.A:
+			ip, _ = netip.AddrFromSlice(rr.A.To4())
+		case *dns.AAAA:
+			ip, _ = netip.AddrFromSlice(rr.AAAA.To16())
+		}
+		if !ip.IsValid() {
+			continue
+		}
+		if rrTTL := time.Duration(rr.Header().Ttl) * time.Second; ttl == 0 || rrTTL < ttl {
+			ttl = rrTTL
+		}
+		if !slices.Contains(addresses, ip) {
+			addresses = append(addresses, ip)
+		}
+	}
+	slices.SortFunc(addresses, netip.Addr.Compare)
+	return addresses, ttl, nil
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package dns
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupAddresses(t *testing.T) {
+	t.Parallel()
+
+	exchange := func(_ context.Context, name string, qtype uint16) (*dns.Msg, error) {
+		msg := new(dns.Msg)
+		hdr := func(rrtype uint16) dns.RR_Header {
+			return dns.RR_Header{Name: dns.Fqdn(name), Rrtype: rrtype, Class: dns.ClassINET, Ttl: 300}
+		}
+		switch {
+		case name == "www.example.com" && qtype == dns.TypeA:
+			msg.Answer = []dns.RR{
+				&dns.CNAME{Hdr: hdr(dns.TypeCNAME), Target: "gw.example.net."},
+				&dns.A{Hdr: hdr(dns.TypeA), A: net.ParseIP("192.0.2.2")},
+				&dns.A{Hdr: hdr(dns.TypeA), A: net.ParseIP("192.0.2.1")},
+			}
+		case name == "www.example.com" && qtype == dns.TypeAAAA:
+			msg.Answer = []dns.RR{&dns.AAAA{Hdr: hdr(dns.TypeAAAA), AAAA: net.ParseIP("2001:db8::1")}}
+		default:
+			msg.Rcode = dns.RcodeNameError
+		}
+		return msg, nil
+	}
+
+	tests := []struct {
+		name     string
+		hostname string
+		qtype    uint16
+		expected []netip.Addr
+	}{
+		{
+			name:     "addresses of cname target",
+			hostname: "www.example.com",
+			qtype:    dns.TypeA,
+			expected: []netip.Addr{netip.MustParseAddr("192.0.2.1"), netip.MustParseAddr("192.0.2.2")},
+		},
+		{
+			name:     "ipv6 addresses",
+			hostname: "www.example.com",
+			qtype:    dns.TypeAAAA,
+			expected: []netip.Addr{netip.MustParseAddr("2001:db8::1")},
+		},
+		{
+			name:     "nonexistent hostname",
+			hostname: "missing.example.com",
+			qtype:    dns.TypeA,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			addresses, err := LookupAddresses(context.Background(), exchange, tt.hostname, tt.qtype)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, addresses)
+		})
+	}
+
+	_, err := LookupAddresses(context.Background(), exchange, "www.example.com", dns.TypeCNAME)
+	assert.Error(t, err)
+
+	// The TTL is the lowest of the address records, the CNAME is ignored.
+	_, ttl, err := LookupAddressesWithTTL(context.Background(), func(_ context.Context, name string, qtype uint16) (*dns.Msg, error) {
+		msg := new(dns.Msg)
+		msg.Answer = []dns.RR{
+			&dns.CNAME{Hdr: dns.RR_Header{Name: dns.Fqdn(name), Rrtype: dns.TypeCNAME, Class: dns.ClassINET, Ttl: 30}, Target: "gw.example.net."},
+			&dns.A{Hdr: dns.RR_Header{Name: "gw.example.net.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 120}, A: net.ParseIP("192.0.2.1")},
+			&dns.A{Hdr: dns.RR_Header{Name: "gw.example.net.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60}, A: net.ParseIP("192.0.2.2")},
+		}
+		return msg, nil
+	}, "www.example.com", dns.TypeA)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, ttl)
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/miekg/dns"
+	"golang.org/x/net/publicsuffix"
+)
+
+// CAAResult describes whether the CAA records of a hostname authorize a
+// certificate authority to issue certificates for it.
+type CAAResult struct {
+	// Allowed is true when any of the issuer domains may issue certificates
+	// for the hostname.
+	Allowed bool
+
+	// RecordName is the name at which the relevant CAA record set was found,
+	// or empty when no CAA records apply to the hostname.
+	RecordName string
+
+	// Issuers are the issuer domains authorized by the relevant CAA record
+	// set.
+	Issuers []string
+}
+
+// CheckCAA looks up the relevant CAA record set of hostname (RFC 8659), climbing
+// from the hostname towards its registered domain, and reports whether it
+// authorizes any of issuerDomains (e.g. "letsencrypt.org") to issue a
+// certificate for the hostname. Wildcard hostnames are checked against
+// "issuewild" properties when present.
+func CheckCAA(ctx context.Context, exchange ExchangeFunc, hostname string, issuerDomains []string) (CAAResult, error) {
+	name := strings.ToLower(strings.TrimSuffix(hostname, "."))
+	wildcard := strings.HasPrefix(name, "*.")
+	name = strings.TrimPrefix(name, "*.")
+
+	apex, err := publicsuffix.EffectiveTLDPlusOne(name)
+	if err != nil {
+		return CAAResult{}, fmt.Errorf("failed to determine registered domain of %q: %w", hostname, err)
+	}
+
+	for {
+		resp, err := exchange(ctx, name, dns.TypeCAA)
+		if err != nil {
+			return CAAResult{}, fmt.Errorf("failed looking up CAA records for %s: %w", name, err)
+		}
+
+		var records []*dns.CAA
+		for _, rr := range resp.Answer {
+			if caa, ok := rr.(*dns.CAA); ok {
+				records = append(records, caa)
+			}
+		}
+		if len(records) > 0 {
+			result := evaluateCAA(records, wildcard, issuerDomains)
+			result.RecordName = name
+			return result, nil
+		}
+
+		if name == apex {
+			// No CAA records apply, so any certificate authority may issue.
+			return CAAResult{Allowed: true}, nil
+		}
+		_, name, _ = strings.Cut(name, ".")
+	}
+}
+
+// evaluateCAA evaluates a relevant CAA record set.
+func evaluateCAA(records []*dns.CAA, wildcard bool, issuerDomains []string) CAAResult {
+	tag := "issue"
+	if wildcard && slices.ContainsFunc(records, func(r *dns.CAA) bool { return strings.EqualFold(r.Tag, "issuewild") }) {
+		tag = "issuewild"
+	}
+
+	var result CAAResult
+	restricted := false
+	for _, record := range records {
+		switch strings.ToLower(record.Tag) {
+		case tag:
+			restricted = true
+			issuer, _, _ := strings.Cut(record.Value, ";")
+			issuer = strings.ToLower(strings.TrimSpace(issuer))
+			if issuer == "" {
+				// An empty issuer forbids issuance by any authority.
+				continue
+			}
+			result.Issuers = append(result.Issuers, issuer)
+			if slices.ContainsFunc(issuerDomains, func(d string) bool { return strings.EqualFold(d, issuer) }) {
+				result.Allowed = true
+			}
+		case "issue", "issuewild", "iodef", "issuemail", "issuevmc", "contactemail", "contactphone":
+		default:
+			// Authorities must not issue when a critical property is not
+			// understood.
+			if record.Flag&128 != 0 {
+				return CAAResult{Issuers: result.Issuers}
+			}
+		}
+	}
+
+	if !restricted {
+		// Only properties that do not restrict issuance are present.
+		result.Allowed = true
+	}
+	return result
+}
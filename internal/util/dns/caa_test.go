@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func caaExchange(records map[string][]*dns.CAA) ExchangeFunc {
+	return func(_ context.Context, name string, qtype uint16) (*dns.Msg, error) {
+		msg := new(dns.Msg)
+		if qtype != dns.TypeCAA {
+			return msg, nil
+		}
+		for _, caa := range records[name] {
+			msg.Answer = append(msg.Answer, caa)
+		}
+		return msg, nil
+	}
+}
+
+func caaRecord(flag uint8, tag, value string) *dns.CAA {
+	return &dns.CAA{
+		Hdr:   dns.RR_Header{Rrtype: dns.TypeCAA, Class: dns.ClassINET, Ttl: 3600},
+		Flag:  flag,
+		Tag:   tag,
+		Value: value,
+	}
+}
+
+func TestCheckCAA(t *testing.T) {
+	t.Parallel()
+
+	issuerDomains := []string{"letsencrypt.org"}
+
+	tests := []struct {
+		name            string
+		hostname        string
+		records         map[string][]*dns.CAA
+		expectedAllowed bool
+		expectedName    string
+	}{
+		{
+			name:            "no records",
+			hostname:        "www.example.com",
+			expectedAllowed: true,
+		},
+		{
+			name:     "issuer authorized at apex",
+			hostname: "www.example.com",
+			records: map[string][]*dns.CAA{
+				"example.com": {caaRecord(0, "issue", "letsencrypt.org; validationmethods=http-01")},
+			},
+			expectedAllowed: true,
+			expectedName:    "example.com",
+		},
+		{
+			name:     "other issuer at apex",
+			hostname: "www.example.com",
+			records: map[string][]*dns.CAA{
+				"example.com": {caaRecord(0, "issue", "pki.goog")},
+			},
+			expectedName: "example.com",
+		},
+		{
+			name:     "closest record set is relevant",
+			hostname: "a.b.example.com",
+			records: map[string][]*dns.CAA{
+				"b.example.com": {caaRecord(0, "issue", "LetsEncrypt.org")},
+				"example.com":   {caaRecord(0, "issue", "pki.goog")},
+			},
+			expectedAllowed: true,
+			expectedName:    "b.example.com",
+		},
+		{
+			name:     "issuance forbidden",
+			hostname: "example.com",
+			records: map[string][]*dns.CAA{
+				"example.com": {caaRecord(0, "issue", ";")},
+			},
+			expectedName: "example.com",
+		},
+		{
+			name:     "only reporting properties",
+			hostname: "example.com",
+			records: map[string][]*dns.CAA{
+				"example.com": {caaRecord(0, "iodef", "mailto:security@example.com")},
+			},
+			expectedAllowed: true,
+			expectedName:    "example.com",
+		},
+		{
+			name:     "unknown critical property",
+			hostname: "example.com",
+			records: map[string][]*dns.CAA{
+				"example.com": {
+					caaRecord(0, "issue", "letsencrypt.org"),
+					caaRecord(128, "tbs", "unknown"),
+				},
+			},
+			expectedName: "example.com",
+		},
+		{
+			name:     "unknown non-critical property",
+			hostname: "example.com",
+			records: map[string][]*dns.CAA{
+				"example.com": {
+					caaRecord(0, "issue", "letsencrypt.org"),
+					caaRecord(0, "tbs", "unknown"),
+				},
+			},
+			expectedAllowed: true,
+			expectedName:    "example.com",
+		},
+		{
+			name:     "wildcard uses issuewild",
+			hostname: "*.example.com",
+			records: map[string][]*dns.CAA{
+				"example.com": {
+					caaRecord(0, "issue", "letsencrypt.org"),
+					caaRecord(0, "issuewild", "pki.goog"),
+				},
+			},
+			expectedName: "example.com",
+		},
+		{
+			name:     "wildcard falls back to issue",
+			hostname: "*.example.com",
+			records: map[string][]*dns.CAA{
+				"example.com": {caaRecord(0, "issue", "letsencrypt.org")},
+			},
+			expectedAllowed: true,
+			expectedName:    "example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CheckCAA(context.Background(), caaExchange(tt.records), tt.hostname, issuerDomains)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAllowed, result.Allowed)
+			assert.Equal(t, tt.expectedName, result.RecordName)
+		})
+	}
+}
+
+func TestCheckCAALookupFailure(t *testing.T) {
+	t.Parallel()
+
+	exchange := func(context.Context, string, uint16) (*dns.Msg, error) {
+		return nil, errors.New("timeout")
+	}
+	_, err := CheckCAA(context.Background(), exchange, "www.example.com", []string{"letsencrypt.org"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// DNSSECValidationError is returned by ValidateDNSSECChain when the chain of
+// trust of a zone is broken. Reason is one of the DomainReasonDNSSEC*
+// condition reasons.
+type DNSSECValidationError struct {
+	Reason  string
+	Message string
+}
+
+func (e *DNSSECValidationError) Error() string {
+	return e.Message
+}
+
+// ValidateDNSSECChain validates the link between a zone and its parent: a DS
+// record is published at the parent, it matches a DNSKEY of the zone, and that
+// key has a currently valid signature over the DNSKEY RRset of the zone.
+//
+// A *DNSSECValidationError is returned when the chain is broken. Any other
+// error means the records could not be looked up.
+func ValidateDNSSECChain(ctx context.Context, exchange ExchangeFunc, zone string, now time.Time) error {
+	zone = dns.Fqdn(strings.ToLower(zone))
+
+	dsResp, err := exchange(ctx, zone, dns.TypeDS)
+	if err != nil {
+		return fmt.Errorf("failed looking up DS records: %w", err)
+	}
+	var dsRecords []*dns.DS
+	for _, rr := range dsResp.Answer {
+		if ds, ok := rr.(*dns.DS); ok {
+			dsRecords = append(dsRecords, ds)
+		}
+	}
+	if len(dsRecords) == 0 {
+		return &DNSSECValidationError{
+			Reason:  networkingv1alpha.DomainReasonDNSSECDSNotFound,
+			Message: fmt.Sprintf("DNSSEC is enabled at the registry, but no DS record for %s is published in the parent zone", zone),
+		}
+	}
+
+	keyResp, err := exchange(ctx, zone, dns.TypeDNSKEY)
+	if err != nil {
+		return fmt.Errorf("failed looking up DNSKEY records: %w", err)
+	}
+	var keys []dns.RR
+	var sigs []*dns.RRSIG
+	for _, rr := range keyResp.Answer {
+		switch rr := rr.(type) {
+		case *dns.DNSKEY:
+			keys = append(keys, rr)
+		case *dns.RRSIG:
+			if rr.TypeCovered == dns.TypeDNSKEY {
+				sigs = append(sigs, rr)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return &DNSSECValidationError{
+			Reason:  networkingv1alpha.DomainReasonDNSSECDNSKEYNotFound,
+			Message: fmt.Sprintf("DS records for %s are published, but the zone has no DNSKEY records", zone),
+		}
+	}
+
+	// Keys of the zone that are referenced by a DS record of the parent.
+	var trusted []*dns.DNSKEY
+	for _, rr := range keys {
+		key := rr.(*dns.DNSKEY)
+		for _, ds := range dsRecords {
+			if key.KeyTag() != ds.KeyTag || key.Algorithm != ds.Algorithm {
+				continue
+			}
+			if keyDS := key.ToDS(ds.DigestType); keyDS != nil && strings.EqualFold(keyDS.Digest, ds.Digest) {
+				trusted = append(trusted, key)
+				break
+			}
+		}
+	}
+	if len(trusted) == 0 {
+		return &DNSSECValidationError{
+			Reason:  networkingv1alpha.DomainReasonDNSSECDSMismatch,
+			Message: fmt.Sprintf("None of the DS records for %s match a DNSKEY of the zone", zone),
+		}
+	}
+
+	var expired *dns.RRSIG
+	for _, key := range trusted {
+		for _, sig := range sigs {
+			if sig.KeyTag != key.KeyTag() || sig.Algorithm != key.Algorithm {
+				continue
+			}
+			if !sig.ValidityPeriod(now) {
+				expired = sig
+				continue
+			}
+			if sig.Verify(key, keys) == nil {
+				return nil
+			}
+		}
+	}
+	if expired != nil {
+		return &DNSSECValidationError{
+			Reason: networkingv1alpha.DomainReasonDNSSECSignatureExpired,
+			Message: fmt.Sprintf("The DNSKEY signature of %s is outside its validity period (%s to %s)", zone,
+				dns.TimeToString(expired.Inception), dns.TimeToString(expired.Expiration)),
+		}
+	}
+	return &DNSSECValidationError{
+		Reason:  networkingv1alpha.DomainReasonDNSSECSignatureInvalid,
+		Message: fmt.Sprintf("The DNSKEY records of %s are not validly signed by a key referenced from the parent zone", zone),
+	}
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package dns
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+type testZone struct {
+	key    *dns.DNSKEY
+	signer crypto.Signer
+	ds     *dns.DS
+	sig    *dns.RRSIG
+}
+
+func newTestZone(t *testing.T, zone string, now time.Time) *testZone {
+	t.Helper()
+
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: zone, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+		Flags:     257,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+	priv, err := key.Generate(256)
+	require.NoError(t, err)
+
+	z := &testZone{key: key, signer: priv.(crypto.Signer), ds: key.ToDS(dns.SHA256)}
+	z.sig = z.sign(t, now.Add(-time.Hour), now.Add(time.Hour))
+	return z
+}
+
+func (z *testZone) sign(t *testing.T, inception, expiration time.Time) *dns.RRSIG {
+	t.Helper()
+	sig := &dns.RRSIG{
+		Hdr:         dns.RR_Header{Name: z.key.Hdr.Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 3600},
+		TypeCovered: dns.TypeDNSKEY,
+		Algorithm:   z.key.Algorithm,
+		Labels:      uint8(dns.CountLabel(z.key.Hdr.Name)),
+		OrigTtl:     3600,
+		Inception:   uint32(inception.Unix()),
+		Expiration:  uint32(expiration.Unix()),
+		KeyTag:      z.key.KeyTag(),
+		SignerName:  z.key.Hdr.Name,
+	}
+	require.NoError(t, sig.Sign(z.signer, []dns.RR{z.key}))
+	return sig
+}
+
+func (z *testZone) exchange(ds []dns.RR, dnskey []dns.RR) ExchangeFunc {
+	return func(_ context.Context, name string, qtype uint16) (*dns.Msg, error) {
+		msg := new(dns.Msg)
+		switch qtype {
+		case dns.TypeDS:
+			msg.Answer = ds
+		case dns.TypeDNSKEY:
+			msg.Answer = dnskey
+		}
+		return msg, nil
+	}
+}
+
+func TestValidateDNSSECChain(t *testing.T) {
+	t.Parallel()
+
+	const zone = "example.com."
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	z := newTestZone(t, zone, now)
+	other := newTestZone(t, zone, now)
+
+	tests := []struct {
+		name           string
+		exchange       ExchangeFunc
+		expectedReason string
+		expectLookup   bool
+	}{
+		{
+			name:     "valid chain",
+			exchange: z.exchange([]dns.RR{z.ds}, []dns.RR{z.key, z.sig}),
+		},
+		{
+			name:           "no DS at parent",
+			exchange:       z.exchange(nil, []dns.RR{z.key, z.sig}),
+			expectedReason: networkingv1alpha.DomainReasonDNSSECDSNotFound,
+		},
+		{
+			name:           "no DNSKEY in zone",
+			exchange:       z.exchange([]dns.RR{z.ds}, nil),
+			expectedReason: networkingv1alpha.DomainReasonDNSSECDNSKEYNotFound,
+		},
+		{
+			name:           "DS does not match DNSKEY",
+			exchange:       z.exchange([]dns.RR{other.ds}, []dns.RR{z.key, z.sig}),
+			expectedReason: networkingv1alpha.DomainReasonDNSSECDSMismatch,
+		},
+		{
+			name:           "signature expired",
+			exchange:       z.exchange([]dns.RR{z.ds}, []dns.RR{z.key, z.sign(t, now.Add(-48*time.Hour), now.Add(-24*time.Hour))}),
+			expectedReason: networkingv1alpha.DomainReasonDNSSECSignatureExpired,
+		},
+		{
+			name:           "missing signature",
+			exchange:       z.exchange([]dns.RR{z.ds}, []dns.RR{z.key}),
+			expectedReason: networkingv1alpha.DomainReasonDNSSECSignatureInvalid,
+		},
+		{
+			name: "lookup failure",
+			exchange: func(context.Context, string, uint16) (*dns.Msg, error) {
+				return nil, errors.New("timeout")
+			},
+			expectLookup: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDNSSECChain(context.Background(), tt.exchange, "Example.com", now)
+			switch {
+			case tt.expectLookup:
+				require.Error(t, err)
+				var validationErr *DNSSECValidationError
+				assert.False(t, errors.As(err, &validationErr))
+			case tt.expectedReason == "":
+				assert.NoError(t, err)
+			default:
+				var validationErr *DNSSECValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tt.expectedReason, validationErr.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// ExchangeFunc sends a query for name and qtype to a recursive resolver and
+// returns the response.
+type ExchangeFunc func(ctx context.Context, name string, qtype uint16) (*dns.Msg, error)
+
+// NewExchange returns an ExchangeFunc that queries the recursive resolver at
+// server ("host:port"). When server is empty, the first nameserver of
+// /etc/resolv.conf is used.
+//
+// Queries set the DO bit so that DNSSEC records are returned, and the CD bit so
+// that a validating resolver returns the records of a zone whose DNSSEC is
+// broken, instead of SERVFAIL.
+func NewExchange(server string, timeout time.Duration) (ExchangeFunc, error) {
+	if server == "" {
+		conf, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+		if err != nil {
+			return nil, fmt.Errorf("failed reading resolver config: %w", err)
+		}
+		if len(conf.Servers) == 0 {
+			return nil, errors.New("no nameservers found in resolver config")
+		}
+		server = net.JoinHostPort(conf.Servers[0], conf.Port)
+	}
+
+	client := &dns.Client{Timeout: timeout}
+	return func(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(name), qtype)
+		msg.SetEdns0(4096, true)
+		msg.CheckingDisabled = true
+
+		resp, _, err := client.ExchangeContext(ctx, msg, server)
+		if err == nil && resp.Truncated {
+			tcpClient := &dns.Client{Net: "tcp", Timeout: timeout}
+			resp, _, err = tcpClient.ExchangeContext(ctx, msg, server)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+			return nil, fmt.Errorf("query for %s %s failed: %s", name, dns.TypeToString[qtype], dns.RcodeToString[resp.Rcode])
+		}
+		return resp, nil
+	}, nil
+}
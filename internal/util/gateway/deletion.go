@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package gateway
+
+// ForceDeleteAnnotation may be set to "true" on an upstream Gateway to allow
+// its deletion while HTTPRoutes or an HTTPProxy still reference it, when
+// deletion protection is enabled.
+const ForceDeleteAnnotation = "networking.datumapis.com/force-delete"
@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package gateway
+
+// DNSRecordTTLAnnotation overrides the TTL, in seconds, of the DNS records of
+// a gateway. It can be set on a GatewayClass for all of its gateways, and on a
+// Gateway or HTTPProxy for a single gateway.
+const DNSRecordTTLAnnotation = "networking.datumapis.com/dns-record-ttl"
@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package gateway
+
+// ManifestExportAnnotation may be set to "true" on an upstream Gateway or
+// HTTPProxy to have the rendered downstream manifests written to a ConfigMap
+// in the same namespace.
+const ManifestExportAnnotation = "networking.datumapis.com/export-manifests"
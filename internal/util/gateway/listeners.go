@@ -1,6 +1,8 @@
 package gateway
 
 import (
+	"maps"
+
 	"k8s.io/utils/ptr"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -68,3 +70,51 @@ func SetDefaultListeners(gateway *gatewayv1.Gateway, gatewayConfig config.Gatewa
 		},
 	})
 }
+
+// SetDefaultListenersIfMissing inserts the default listeners that are missing
+// from the gateway, leaving existing default listeners untouched as their
+// hostnames are managed by the gateway controller.
+func SetDefaultListenersIfMissing(gateway *gatewayv1.Gateway, gatewayConfig config.GatewayConfig) {
+	defaults := &gatewayv1.Gateway{}
+	SetDefaultListeners(defaults, gatewayConfig)
+	for _, l := range defaults.Spec.Listeners {
+		if GetListenerByName(gateway.Spec.Listeners, l.Name) == nil {
+			SetListener(gateway, l)
+		}
+	}
+}
+
+// SetListenerDefaults fills in the operator configured defaults of each
+// listener in the gateway:
+//
+//   - Routes are only allowed from the namespace of the gateway.
+//   - HTTPS listeners terminate TLS, and request a certificate with the
+//     configured listener TLS options unless a certificate is provided.
+func SetListenerDefaults(gateway *gatewayv1.Gateway, gatewayConfig config.GatewayConfig) {
+	for i := range gateway.Spec.Listeners {
+		l := &gateway.Spec.Listeners[i]
+
+		if l.AllowedRoutes == nil {
+			l.AllowedRoutes = &gatewayv1.AllowedRoutes{}
+		}
+		if l.AllowedRoutes.Namespaces == nil {
+			l.AllowedRoutes.Namespaces = &gatewayv1.RouteNamespaces{}
+		}
+		if l.AllowedRoutes.Namespaces.From == nil {
+			l.AllowedRoutes.Namespaces.From = ptr.To(gatewayv1.NamespacesFromSame)
+		}
+
+		if l.Protocol != gatewayv1.HTTPSProtocolType {
+			continue
+		}
+		if l.TLS == nil {
+			l.TLS = &gatewayv1.ListenerTLSConfig{}
+		}
+		if l.TLS.Mode == nil {
+			l.TLS.Mode = ptr.To(gatewayv1.TLSModeTerminate)
+		}
+		if *l.TLS.Mode == gatewayv1.TLSModeTerminate && len(l.TLS.CertificateRefs) == 0 && len(l.TLS.Options) == 0 {
+			l.TLS.Options = maps.Clone(gatewayConfig.ListenerTLSOptions)
+		}
+	}
+}
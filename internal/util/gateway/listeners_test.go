@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestSetListenerDefaults(t *testing.T) {
+	gatewayConfig := config.GatewayConfig{
+		ListenerTLSOptions: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+			"gateway.networking.datumapis.com/certificate-issuer": "auto",
+		},
+	}
+	sameNamespace := &gatewayv1.AllowedRoutes{
+		Namespaces: &gatewayv1.RouteNamespaces{From: ptr.To(gatewayv1.NamespacesFromSame)},
+	}
+	allNamespaces := &gatewayv1.AllowedRoutes{
+		Namespaces: &gatewayv1.RouteNamespaces{From: ptr.To(gatewayv1.NamespacesFromAll)},
+	}
+	certificateRefs := []gatewayv1.SecretObjectReference{{Name: "cert"}}
+
+	gateway := &gatewayv1.Gateway{
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+				{Name: "http-all", Protocol: gatewayv1.HTTPProtocolType, Port: 8080, AllowedRoutes: allNamespaces},
+				{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443},
+				{
+					Name:     "https-cert",
+					Protocol: gatewayv1.HTTPSProtocolType,
+					Port:     8443,
+					TLS:      &gatewayv1.ListenerTLSConfig{CertificateRefs: certificateRefs},
+				},
+			},
+		},
+	}
+
+	SetListenerDefaults(gateway, gatewayConfig)
+
+	assert.Equal(t, []gatewayv1.Listener{
+		{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80, AllowedRoutes: sameNamespace},
+		{Name: "http-all", Protocol: gatewayv1.HTTPProtocolType, Port: 8080, AllowedRoutes: allNamespaces},
+		{
+			Name:          "https",
+			Protocol:      gatewayv1.HTTPSProtocolType,
+			Port:          443,
+			AllowedRoutes: sameNamespace,
+			TLS: &gatewayv1.ListenerTLSConfig{
+				Mode:    ptr.To(gatewayv1.TLSModeTerminate),
+				Options: gatewayConfig.ListenerTLSOptions,
+			},
+		},
+		{
+			Name:          "https-cert",
+			Protocol:      gatewayv1.HTTPSProtocolType,
+			Port:          8443,
+			AllowedRoutes: sameNamespace,
+			TLS: &gatewayv1.ListenerTLSConfig{
+				Mode:            ptr.To(gatewayv1.TLSModeTerminate),
+				CertificateRefs: certificateRefs,
+			},
+		},
+	}, gateway.Spec.Listeners)
+}
+
+func TestSetDefaultListenersIfMissing(t *testing.T) {
+	gateway := &gatewayv1.Gateway{
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{
+					Name:     DefaultHTTPListenerName,
+					Protocol: gatewayv1.HTTPProtocolType,
+					Port:     DefaultHTTPPort,
+					Hostname: ptr.To(gatewayv1.Hostname("abc.example.com")),
+				},
+			},
+		},
+	}
+
+	SetDefaultListenersIfMissing(gateway, config.GatewayConfig{})
+
+	if assert.Len(t, gateway.Spec.Listeners, 2) {
+		assert.Equal(t, ptr.To(gatewayv1.Hostname("abc.example.com")), gateway.Spec.Listeners[0].Hostname)
+		assert.Equal(t, gatewayv1.SectionName(DefaultHTTPSListenerName), gateway.Spec.Listeners[1].Name)
+	}
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Request ID annotations may be set on upstream Gateways to have a request ID
+// header injected on, and returned from, all routes attached to the Gateway.
+const (
+	RequestIDAnnotation       = "networking.datumapis.com/request-id"
+	RequestIDHeaderAnnotation = "networking.datumapis.com/request-id-header"
+
+	DefaultRequestIDHeader = "x-request-id"
+)
+
+type RequestIDFormat string
+
+const (
+	// RequestIDFormatUUID uses the UUID request ID generated by the edge, or
+	// the one provided by the client.
+	RequestIDFormatUUID RequestIDFormat = "UUID"
+
+	// RequestIDFormatTraceContext uses the W3C trace-context trace ID of the
+	// request.
+	RequestIDFormatTraceContext RequestIDFormat = "TraceContext"
+)
+
+// RequestIDConfig describes how request IDs are propagated for a Gateway.
+type RequestIDConfig struct {
+	Header string
+	Format RequestIDFormat
+}
+
+// GetRequestIDConfig returns the request ID configuration of a Gateway, or nil
+// if request ID injection is not enabled.
+func GetRequestIDConfig(gateway *gatewayv1.Gateway) (*RequestIDConfig, error) {
+	format, ok := gateway.Annotations[RequestIDAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	cfg := &RequestIDConfig{
+		Header: DefaultRequestIDHeader,
+	}
+
+	switch {
+	case strings.EqualFold(format, string(RequestIDFormatUUID)):
+		cfg.Format = RequestIDFormatUUID
+	case strings.EqualFold(format, string(RequestIDFormatTraceContext)):
+		cfg.Format = RequestIDFormatTraceContext
+	default:
+		return nil, fmt.Errorf("unsupported request ID format %q, must be one of %s or %s", format, RequestIDFormatUUID, RequestIDFormatTraceContext)
+	}
+
+	if header := strings.TrimSpace(gateway.Annotations[RequestIDHeaderAnnotation]); header != "" {
+		if errs := validation.IsHTTPHeaderName(header); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid request ID header name %q: %s", header, strings.Join(errs, "; "))
+		}
+		cfg.Header = strings.ToLower(header)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package gateway
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Scheduling hint annotations may be set on upstream Gateways to express
+// location preferences. Values are comma separated and listed in order of
+// preference.
+const (
+	PreferredLocationsAnnotation = "networking.datumapis.com/preferred-locations"
+	PreferredRegionsAnnotation   = "networking.datumapis.com/preferred-regions"
+)
+
+// Labels derived from scheduling hints. They carry the most preferred location
+// and region so that downstream consumers, such as DNS providers performing
+// geo routing, can select on them.
+const (
+	PreferredLocationLabel = "topology.datum.net/preferred-location"
+	PreferredRegionLabel   = "topology.datum.net/preferred-region"
+)
+
+// SchedulingHints are the location preferences expressed on a Gateway.
+type SchedulingHints struct {
+	Locations []string
+	Regions   []string
+}
+
+// GetSchedulingHints parses the scheduling hint annotations on a Gateway.
+// Entries that are not valid label values are ignored.
+func GetSchedulingHints(gateway *gatewayv1.Gateway) SchedulingHints {
+	return SchedulingHints{
+		Locations: parseHintList(gateway.Annotations[PreferredLocationsAnnotation]),
+		Regions:   parseHintList(gateway.Annotations[PreferredRegionsAnnotation]),
+	}
+}
+
+// IsEmpty returns true when no hints are present.
+func (h SchedulingHints) IsEmpty() bool {
+	return len(h.Locations) == 0 && len(h.Regions) == 0
+}
+
+// Annotations returns the normalized hint annotations to propagate to
+// downstream resources, or nil if there are no hints.
+func (h SchedulingHints) Annotations() map[string]string {
+	if h.IsEmpty() {
+		return nil
+	}
+
+	annotations := map[string]string{}
+	if len(h.Locations) > 0 {
+		annotations[PreferredLocationsAnnotation] = strings.Join(h.Locations, ",")
+	}
+	if len(h.Regions) > 0 {
+		annotations[PreferredRegionsAnnotation] = strings.Join(h.Regions, ",")
+	}
+	return annotations
+}
+
+// ApplyLabels sets the location labels derived from the hints on the provided
+// labels, removing any that no longer apply.
+func (h SchedulingHints) ApplyLabels(labels map[string]string) {
+	setOrDelete := func(key string, values []string) {
+		if len(values) > 0 {
+			labels[key] = values[0]
+		} else {
+			delete(labels, key)
+		}
+	}
+
+	setOrDelete(PreferredLocationLabel, h.Locations)
+	setOrDelete(PreferredRegionLabel, h.Regions)
+}
+
+func parseHintList(value string) []string {
+	var hints []string
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" || slices.Contains(hints, v) || len(validation.IsValidLabelValue(v)) > 0 {
+			continue
+		}
+		hints = append(hints, v)
+	}
+	return hints
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestGetSchedulingHints(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    SchedulingHints
+		labels      map[string]string
+	}{
+		{
+			name:     "no hints",
+			expected: SchedulingHints{},
+			labels:   map[string]string{},
+		},
+		{
+			name: "locations and regions",
+			annotations: map[string]string{
+				PreferredLocationsAnnotation: "dfw, ord,,dfw",
+				PreferredRegionsAnnotation:   "us-central",
+			},
+			expected: SchedulingHints{
+				Locations: []string{"dfw", "ord"},
+				Regions:   []string{"us-central"},
+			},
+			labels: map[string]string{
+				PreferredLocationLabel: "dfw",
+				PreferredRegionLabel:   "us-central",
+			},
+		},
+		{
+			name: "invalid entries ignored",
+			annotations: map[string]string{
+				PreferredLocationsAnnotation: "not a location,sjc",
+			},
+			expected: SchedulingHints{
+				Locations: []string{"sjc"},
+			},
+			labels: map[string]string{
+				PreferredLocationLabel: "sjc",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gw := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+			}
+
+			hints := GetSchedulingHints(gw)
+			assert.Equal(t, tt.expected, hints)
+
+			labels := map[string]string{
+				PreferredLocationLabel: "stale",
+				PreferredRegionLabel:   "stale",
+			}
+			hints.ApplyLabels(labels)
+			assert.Equal(t, tt.labels, labels)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package ipam allocates non-overlapping prefixes from CIDR pools.
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/netip"
+)
+
+// MaxBlockBits limits the number of blocks of a pool to 2^MaxBlockBits, which
+// keeps the bitmap of a pool within 8KiB.
+const MaxBlockBits = 16
+
+var (
+	// ErrExhausted is returned when a pool has no free range of the requested
+	// size.
+	ErrExhausted = errors.New("no free prefix of the requested size")
+
+	// ErrInUse is returned when a prefix overlaps a prefix that is already
+	// allocated.
+	ErrInUse = errors.New("prefix overlaps an allocated prefix")
+)
+
+// Pool allocates prefixes of a CIDR in blocks of a fixed prefix length, and
+// tracks the blocks in use in a bitmap. A prefix of length l spans
+// 2^(blockPrefixLength-l) blocks, and is aligned to its own size.
+//
+// Pool is not safe for concurrent use. Callers persist the bitmap and rely on
+// optimistic concurrency of its storage to allocate atomically.
+type Pool struct {
+	cidr              netip.Prefix
+	blockPrefixLength int
+	bitmap            []byte
+}
+
+// NewPool returns a pool of a CIDR with the blocks marked in bitmap allocated.
+// A nil bitmap returns an empty pool.
+func NewPool(cidr netip.Prefix, blockPrefixLength int, bitmap []byte) (*Pool, error) {
+	if !cidr.IsValid() || cidr != cidr.Masked() {
+		return nil, fmt.Errorf("invalid CIDR %q", cidr)
+	}
+	if blockPrefixLength < cidr.Bits() || blockPrefixLength > cidr.Addr().BitLen() {
+		return nil, fmt.Errorf("block prefix length %d must be between %d and %d", blockPrefixLength, cidr.Bits(), cidr.Addr().BitLen())
+	}
+	if blockPrefixLength-cidr.Bits() > MaxBlockBits {
+		return nil, fmt.Errorf("block prefix length %d splits %s in more than 2^%d blocks", blockPrefixLength, cidr, MaxBlockBits)
+	}
+
+	p := &Pool{cidr: cidr, blockPrefixLength: blockPrefixLength}
+	size := (p.Size() + 7) / 8
+	switch len(bitmap) {
+	case 0:
+		p.bitmap = make([]byte, size)
+	case size:
+		p.bitmap = append([]byte(nil), bitmap...)
+	default:
+		return nil, fmt.Errorf("bitmap of %d bytes does not match the %d blocks of %s", len(bitmap), p.Size(), cidr)
+	}
+	return p, nil
+}
+
+// CIDR returns the CIDR of the pool.
+func (p *Pool) CIDR() netip.Prefix {
+	return p.cidr
+}
+
+// Bitmap returns a copy of the bitmap of the pool, where bit i of byte i/8 is
+// set when block i is allocated.
+func (p *Pool) Bitmap() []byte {
+	return append([]byte(nil), p.bitmap...)
+}
+
+// Size returns the number of blocks of the pool.
+func (p *Pool) Size() int {
+	return 1 << (p.blockPrefixLength - p.cidr.Bits())
+}
+
+// Allocated returns the number of allocated blocks of the pool.
+func (p *Pool) Allocated() int {
+	n := 0
+	for i := range p.Size() {
+		if p.isSet(i) {
+			n++
+		}
+	}
+	return n
+}
+
+// Allocate allocates the first free prefix of a prefix length.
+func (p *Pool) Allocate(prefixLength int) (netip.Prefix, error) {
+	if err := p.validPrefixLength(prefixLength); err != nil {
+		return netip.Prefix{}, err
+	}
+	n := 1 << (p.blockPrefixLength - prefixLength)
+	for start := 0; start < p.Size(); start += n {
+		if p.rangeFree(start, n) {
+			p.setRange(start, n, true)
+			return netip.PrefixFrom(p.blockAddr(start), prefixLength), nil
+		}
+	}
+	return netip.Prefix{}, ErrExhausted
+}
+
+// Reserve allocates a prefix.
+func (p *Pool) Reserve(prefix netip.Prefix) error {
+	start, n, err := p.blocks(prefix)
+	if err != nil {
+		return err
+	}
+	if !p.rangeFree(start, n) {
+		return ErrInUse
+	}
+	p.setRange(start, n, true)
+	return nil
+}
+
+// Release frees a prefix. Releasing a prefix that isn't allocated is a no-op.
+func (p *Pool) Release(prefix netip.Prefix) error {
+	start, n, err := p.blocks(prefix)
+	if err != nil {
+		return err
+	}
+	p.setRange(start, n, false)
+	return nil
+}
+
+// Contains returns whether a prefix can be allocated from the pool.
+func (p *Pool) Contains(prefix netip.Prefix) bool {
+	_, _, err := p.blocks(prefix)
+	return err == nil
+}
+
+func (p *Pool) validPrefixLength(prefixLength int) error {
+	if prefixLength < p.cidr.Bits() || prefixLength > p.blockPrefixLength {
+		return fmt.Errorf("prefix length %d must be between %d and %d", prefixLength, p.cidr.Bits(), p.blockPrefixLength)
+	}
+	return nil
+}
+
+// blocks returns the first block and number of blocks of a prefix.
+func (p *Pool) blocks(prefix netip.Prefix) (int, int, error) {
+	if !prefix.IsValid() || prefix != prefix.Masked() {
+		return 0, 0, fmt.Errorf("invalid prefix %q", prefix)
+	}
+	if prefix.Addr().BitLen() != p.cidr.Addr().BitLen() || !p.cidr.Contains(prefix.Addr()) {
+		return 0, 0, fmt.Errorf("prefix %s is not within %s", prefix, p.cidr)
+	}
+	if err := p.validPrefixLength(prefix.Bits()); err != nil {
+		return 0, 0, err
+	}
+
+	offset := new(big.Int).Sub(addrInt(prefix.Addr()), addrInt(p.cidr.Addr()))
+	offset.Rsh(offset, uint(p.cidr.Addr().BitLen()-p.blockPrefixLength))
+	return int(offset.Int64()), 1 << (p.blockPrefixLength - prefix.Bits()), nil
+}
+
+// blockAddr returns the first address of a block.
+func (p *Pool) blockAddr(block int) netip.Addr {
+	offset := new(big.Int).Lsh(big.NewInt(int64(block)), uint(p.cidr.Addr().BitLen()-p.blockPrefixLength))
+	b := new(big.Int).Add(addrInt(p.cidr.Addr()), offset).FillBytes(make([]byte, p.cidr.Addr().BitLen()/8))
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+func (p *Pool) isSet(block int) bool {
+	return p.bitmap[block/8]&(1<<(block%8)) != 0
+}
+
+func (p *Pool) rangeFree(start, n int) bool {
+	for i := start; i < start+n; i++ {
+		if p.isSet(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Pool) setRange(start, n int, allocated bool) {
+	for i := start; i < start+n; i++ {
+		if allocated {
+			p.bitmap[i/8] |= 1 << (i % 8)
+		} else {
+			p.bitmap[i/8] &^= 1 << (i % 8)
+		}
+	}
+}
+
+func addrInt(addr netip.Addr) *big.Int {
+	return new(big.Int).SetBytes(addr.AsSlice())
+}
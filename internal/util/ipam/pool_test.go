@@ -0,0 +1,136 @@
+package ipam
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestNewPool(t *testing.T) {
+	tests := []struct {
+		name              string
+		cidr              string
+		blockPrefixLength int
+		bitmap            []byte
+		wantErr           bool
+	}{
+		{name: "ipv4", cidr: "10.128.0.0/9", blockPrefixLength: 24},
+		{name: "ipv6", cidr: "fd20::/48", blockPrefixLength: 64},
+		{name: "single block", cidr: "10.0.0.0/24", blockPrefixLength: 24},
+		{name: "unmasked cidr", cidr: "10.0.0.1/24", blockPrefixLength: 28, wantErr: true},
+		{name: "block shorter than cidr", cidr: "10.0.0.0/24", blockPrefixLength: 20, wantErr: true},
+		{name: "too many blocks", cidr: "10.0.0.0/8", blockPrefixLength: 28, wantErr: true},
+		{name: "bitmap size mismatch", cidr: "10.0.0.0/24", blockPrefixLength: 28, bitmap: []byte{0, 0, 0}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPool(netip.MustParsePrefix(tt.cidr), tt.blockPrefixLength, tt.bitmap)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPool() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPoolAllocate(t *testing.T) {
+	pool, err := NewPool(netip.MustParsePrefix("10.0.0.0/24"), 28, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Allocations are aligned to their own size, so the /26 skips the blocks
+	// of the first /28.
+	for _, step := range []struct {
+		prefixLength int
+		want         string
+	}{
+		{prefixLength: 28, want: "10.0.0.0/28"},
+		{prefixLength: 26, want: "10.0.0.64/26"},
+		{prefixLength: 28, want: "10.0.0.16/28"},
+		{prefixLength: 25, want: "10.0.0.128/25"},
+	} {
+		got, err := pool.Allocate(step.prefixLength)
+		if err != nil {
+			t.Fatalf("Allocate(%d) error = %v", step.prefixLength, err)
+		}
+		if got.String() != step.want {
+			t.Fatalf("Allocate(%d) = %s, want %s", step.prefixLength, got, step.want)
+		}
+	}
+	if got := pool.Allocated(); got != 14 {
+		t.Fatalf("Allocated() = %d, want 14", got)
+	}
+
+	if _, err := pool.Allocate(26); !errors.Is(err, ErrExhausted) {
+		t.Fatalf("Allocate(26) error = %v, want ErrExhausted", err)
+	}
+	if _, err := pool.Allocate(29); err == nil {
+		t.Fatal("Allocate(29) expected error for prefix length longer than the block")
+	}
+
+	// The bitmap restores the allocations of the pool, and released blocks are
+	// allocated again.
+	restored, err := NewPool(pool.CIDR(), 28, pool.Bitmap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := restored.Release(netip.MustParsePrefix("10.0.0.64/26")); err != nil {
+		t.Fatal(err)
+	}
+	got, err := restored.Allocate(26)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "10.0.0.64/26" {
+		t.Fatalf("Allocate(26) = %s, want 10.0.0.64/26", got)
+	}
+}
+
+func TestPoolAllocateIPv6(t *testing.T) {
+	pool, err := NewPool(netip.MustParsePrefix("fd20:0:0:ff00::/56"), 64, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Allocate(64); err != nil {
+		t.Fatal(err)
+	}
+	got, err := pool.Allocate(60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "fd20:0:0:ff10::/60" {
+		t.Fatalf("Allocate(60) = %s, want fd20:0:0:ff10::/60", got)
+	}
+}
+
+func TestPoolReserve(t *testing.T) {
+	pool, err := NewPool(netip.MustParsePrefix("10.0.0.0/24"), 28, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pool.Reserve(netip.MustParsePrefix("10.0.0.32/27")); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Reserve(netip.MustParsePrefix("10.0.0.48/28")); !errors.Is(err, ErrInUse) {
+		t.Fatalf("Reserve() error = %v, want ErrInUse", err)
+	}
+	if err := pool.Reserve(netip.MustParsePrefix("10.0.1.0/28")); err == nil {
+		t.Fatal("Reserve() expected error for prefix outside of the pool")
+	}
+	if err := pool.Reserve(netip.MustParsePrefix("fd20::/64")); err == nil {
+		t.Fatal("Reserve() expected error for prefix of another family")
+	}
+	if pool.Contains(netip.MustParsePrefix("10.0.0.0/30")) {
+		t.Fatal("Contains() expected false for prefix longer than the block")
+	}
+
+	got, err := pool.Allocate(27)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "10.0.0.0/27" {
+		t.Fatalf("Allocate(27) = %s, want 10.0.0.0/27", got)
+	}
+}
@@ -2,13 +2,16 @@ package validation
 
 import (
 	"fmt"
+	"net/netip"
 	"slices"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 )
 
@@ -22,7 +25,11 @@ func ValidateGateway(gateway *gatewayv1.Gateway, opts GatewayValidationOptions)
 	allErrs = append(allErrs, validateListeners(gateway, field.NewPath("spec", "listeners"), opts)...)
 
 	if len(gateway.Spec.Addresses) > 0 {
-		allErrs = append(allErrs, field.TooMany(field.NewPath("spec", "addresses"), len(gateway.Spec.Addresses), 0))
+		if opts.StaticAddressesEnabled {
+			allErrs = append(allErrs, validateGatewayAddresses(gateway.Spec.Addresses, field.NewPath("spec", "addresses"), opts)...)
+		} else {
+			allErrs = append(allErrs, field.TooMany(field.NewPath("spec", "addresses"), len(gateway.Spec.Addresses), 0))
+		}
 	}
 
 	if gateway.Spec.Infrastructure != nil {
@@ -31,11 +38,97 @@ func ValidateGateway(gateway *gatewayv1.Gateway, opts GatewayValidationOptions)
 
 	// Gateway-level TLS (spec.tls) was introduced in Gateway API v1.5 and replaces
 	// the removed listener-level FrontendValidation and top-level BackendTLS fields.
-	// It allows configuring frontend client-cert validation and a backend
-	// clientCertificateRef, both of which can reference Secrets. TLS configuration
-	// is operator-controlled (see SetDefaultListeners), so tenants must not set it.
+	// Frontend client certificate validation is permitted, and is programmed on
+	// each HTTPS listener it applies to. The backend clientCertificateRef is
+	// operator-controlled, so tenants must not set it.
 	if gateway.Spec.TLS != nil {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "tls"), "tls is not permitted"))
+		tlsPath := field.NewPath("spec", "tls")
+		if gateway.Spec.TLS.Backend != nil {
+			allErrs = append(allErrs, field.Forbidden(tlsPath.Child("backend"), "backend is not permitted"))
+		}
+		if frontend := gateway.Spec.TLS.Frontend; frontend != nil {
+			frontendPath := tlsPath.Child("frontend")
+			allErrs = append(allErrs, validateFrontendTLSValidation(frontend.Default.Validation, frontendPath.Child("default", "validation"))...)
+			for i, perPort := range frontend.PerPort {
+				allErrs = append(allErrs, validateFrontendTLSValidation(perPort.TLS.Validation, frontendPath.Child("perPort").Index(i).Child("tls", "validation"))...)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateGatewayAddresses permits a static IP address of each IP family
+// enabled on gateways. Named addresses name an IPAddressClaim, whose IP family
+// is validated by the Gateway webhook.
+func validateGatewayAddresses(addresses []gatewayv1.GatewaySpecAddress, fldPath *field.Path, opts GatewayValidationOptions) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	families := sets.New[networkingv1alpha.IPFamily]()
+	for i, address := range addresses {
+		addressPath := fldPath.Index(i)
+
+		switch addressType := ptr.Deref(address.Type, gatewayv1.IPAddressType); addressType {
+		case gatewayv1.IPAddressType:
+		case gatewayv1.NamedAddressType:
+			for _, msg := range validation.IsDNS1123Subdomain(address.Value) {
+				allErrs = append(allErrs, field.Invalid(addressPath.Child("value"), address.Value, msg))
+			}
+			continue
+		default:
+			allErrs = append(allErrs, field.NotSupported(addressPath.Child("type"), addressType, []gatewayv1.AddressType{gatewayv1.IPAddressType, gatewayv1.NamedAddressType}))
+			continue
+		}
+
+		ip, err := netip.ParseAddr(address.Value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(addressPath.Child("value"), address.Value, "must be a valid IP address"))
+			continue
+		}
+
+		family := networkingv1alpha.IPv6Protocol
+		if ip.Unmap().Is4() {
+			family = networkingv1alpha.IPv4Protocol
+		}
+		if !slices.Contains(opts.IPFamilies, family) {
+			allErrs = append(allErrs, field.Invalid(addressPath.Child("value"), address.Value, fmt.Sprintf("%s addresses are not supported by gateways", family)))
+			continue
+		}
+		if families.Has(family) {
+			allErrs = append(allErrs, field.Invalid(addressPath.Child("value"), address.Value, fmt.Sprintf("only one %s address may be requested", family)))
+			continue
+		}
+		families.Insert(family)
+	}
+
+	return allErrs
+}
+
+// validateFrontendTLSValidation permits a single reference to a ConfigMap or
+// Secret in the namespace of the Gateway.
+func validateFrontendTLSValidation(validation *gatewayv1.FrontendTLSValidation, fldPath *field.Path) field.ErrorList {
+	if validation == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	refsPath := fldPath.Child("caCertificateRefs")
+
+	if len(validation.CACertificateRefs) > 1 {
+		allErrs = append(allErrs, field.TooMany(refsPath, len(validation.CACertificateRefs), 1))
+	}
+
+	for i, ref := range validation.CACertificateRefs {
+		refPath := refsPath.Index(i)
+		if ref.Group != "" {
+			allErrs = append(allErrs, field.NotSupported(refPath.Child("group"), ref.Group, []string{""}))
+		}
+		if supportedKinds := []string{"ConfigMap", "Secret"}; !slices.Contains(supportedKinds, string(ref.Kind)) {
+			allErrs = append(allErrs, field.NotSupported(refPath.Child("kind"), ref.Kind, supportedKinds))
+		}
+		if ref.Namespace != nil {
+			allErrs = append(allErrs, field.Forbidden(refPath.Child("namespace"), "references to CA certificates in other namespaces are not permitted"))
+		}
 	}
 
 	return allErrs
@@ -147,10 +240,10 @@ func validateGatewayTLSConfig(tls *gatewayv1.ListenerTLSConfig, fldPath *field.P
 
 	optionsFieldPath := fldPath.Child("options")
 
-	if tls == nil || len(tls.Options) == 0 {
-		// Require the TLS option for cert issuance until there's support for
-		// providing certs.
-		allErrs = append(allErrs, field.Required(optionsFieldPath, "must provide TLS options"))
+	if tls == nil || (len(tls.Options) == 0 && len(tls.CertificateRefs) == 0) {
+		// Certificates are either issued using the TLS options, or provided by
+		// the user in a Secret.
+		allErrs = append(allErrs, field.Required(optionsFieldPath, "must provide TLS options or certificateRefs"))
 		if tls == nil {
 			return allErrs
 		}
@@ -161,7 +254,10 @@ func validateGatewayTLSConfig(tls *gatewayv1.ListenerTLSConfig, fldPath *field.P
 	}
 
 	if len(tls.CertificateRefs) > 0 {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("certificateRefs"), "certificateRefs are not permitted"))
+		if len(tls.Options) > 0 {
+			allErrs = append(allErrs, field.Forbidden(optionsFieldPath, "options may not be set together with certificateRefs"))
+		}
+		allErrs = append(allErrs, validateCertificateRefs(tls.CertificateRefs, fldPath.Child("certificateRefs"))...)
 	}
 
 	for k, v := range tls.Options {
@@ -179,6 +275,31 @@ func validateGatewayTLSConfig(tls *gatewayv1.ListenerTLSConfig, fldPath *field.P
 	return allErrs
 }
 
+// validateCertificateRefs permits a single reference to a Secret in the
+// namespace of the Gateway.
+func validateCertificateRefs(refs []gatewayv1.SecretObjectReference, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(refs) > 1 {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(refs), 1))
+	}
+
+	for i, ref := range refs {
+		refPath := fldPath.Index(i)
+		if group := ptr.Deref(ref.Group, ""); group != "" {
+			allErrs = append(allErrs, field.NotSupported(refPath.Child("group"), group, []string{""}))
+		}
+		if kind := ptr.Deref(ref.Kind, "Secret"); kind != "Secret" {
+			allErrs = append(allErrs, field.NotSupported(refPath.Child("kind"), kind, []string{"Secret"}))
+		}
+		if ref.Namespace != nil {
+			allErrs = append(allErrs, field.Forbidden(refPath.Child("namespace"), "references to Secrets in other namespaces are not permitted"))
+		}
+	}
+
+	return allErrs
+}
+
 type GatewayValidationOptions struct {
 	ControllerName             gatewayv1.GatewayController
 	PermittedTLSOptions        map[string][]string
@@ -187,6 +308,8 @@ type GatewayValidationOptions struct {
 	GatewayDNSAddressFunc      func(gateway *gatewayv1.Gateway) string
 	ClusterName                string
 	SkipHostnameFQDNValidation bool
+	StaticAddressesEnabled     bool
+	IPFamilies                 []networkingv1alpha.IPFamily
 }
 
 type validPortNumbers []int
@@ -13,6 +13,7 @@ import (
 	"k8s.io/utils/ptr"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	"go.datum.net/network-services-operator/internal/config"
 	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 )
@@ -114,7 +115,7 @@ func TestValidateGateway(t *testing.T) {
 				field.Invalid(field.NewPath("spec", "listeners").Index(0).Child("tls", "mode"), gatewayv1.TLSModePassthrough, "mode must be set to Terminate"),
 			},
 		},
-		"certificate refs not permitted": {
+		"certificate refs permitted": {
 			gateway: &gatewayv1.Gateway{
 				Spec: gatewayv1.GatewaySpec{
 					GatewayClassName: "test-gateway-class",
@@ -139,9 +140,49 @@ func TestValidateGateway(t *testing.T) {
 				ValidPortNumbers:   []int{80, 443},
 				ValidProtocolTypes: defaultValidProtocolTypes,
 			},
+			expectedErrors: field.ErrorList{},
+		},
+		"certificate refs to other namespaces and with options not permitted": {
+			gateway: &gatewayv1.Gateway{
+				Spec: gatewayv1.GatewaySpec{
+					GatewayClassName: "test-gateway-class",
+					Listeners: []gatewayv1.Listener{
+						{
+							Name:     "https",
+							Protocol: gatewayv1.HTTPSProtocolType,
+							Port:     443,
+							TLS: &gatewayv1.ListenerTLSConfig{
+								Mode: ptr.To(gatewayv1.TLSModeTerminate),
+								CertificateRefs: []gatewayv1.SecretObjectReference{
+									{
+										Name:      "test-cert",
+										Namespace: ptr.To(gatewayv1.Namespace("other")),
+									},
+									{
+										Kind: ptr.To(gatewayv1.Kind("ConfigMap")),
+										Name: "test-cert",
+									},
+								},
+								Options: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+									"gateway.networking.datumapis.com/certificate-issuer": "test",
+								},
+							},
+						},
+					},
+				},
+			},
+			opts: GatewayValidationOptions{
+				ValidPortNumbers:   []int{80, 443},
+				ValidProtocolTypes: defaultValidProtocolTypes,
+				PermittedTLSOptions: map[string][]string{
+					"gateway.networking.datumapis.com/certificate-issuer": {},
+				},
+			},
 			expectedErrors: field.ErrorList{
-				field.Required(field.NewPath("spec", "listeners").Index(0).Child("tls", "options"), ""),
-				field.Forbidden(field.NewPath("spec", "listeners").Index(0).Child("tls", "certificateRefs"), ""),
+				field.Forbidden(field.NewPath("spec", "listeners").Index(0).Child("tls", "options"), ""),
+				field.TooMany(field.NewPath("spec", "listeners").Index(0).Child("tls", "certificateRefs"), 2, 1),
+				field.Forbidden(field.NewPath("spec", "listeners").Index(0).Child("tls", "certificateRefs").Index(0).Child("namespace"), ""),
+				field.NotSupported(field.NewPath("spec", "listeners").Index(0).Child("tls", "certificateRefs").Index(1).Child("kind"), nil, []string{"Secret"}),
 			},
 		},
 		"routes from all namespaces not permitted": {
@@ -225,6 +266,67 @@ func TestValidateGateway(t *testing.T) {
 				field.TooMany(field.NewPath("spec", "addresses"), 1, 0),
 			},
 		},
+		"static addresses": {
+			gateway: &gatewayv1.Gateway{
+				Spec: gatewayv1.GatewaySpec{
+					GatewayClassName: "test-gateway-class",
+					Listeners: []gatewayv1.Listener{
+						{
+							Name:     "http",
+							Protocol: gatewayv1.HTTPProtocolType,
+							Port:     80,
+						},
+					},
+					Addresses: []gatewayv1.GatewaySpecAddress{
+						{Value: "192.0.2.1"},
+						{Type: ptr.To(gatewayv1.IPAddressType), Value: "2001:db8::1"},
+						{Type: ptr.To(gatewayv1.NamedAddressType), Value: "egress"},
+					},
+				},
+			},
+			opts: GatewayValidationOptions{
+				ValidPortNumbers:       []int{80, 443},
+				ValidProtocolTypes:     defaultValidProtocolTypes,
+				StaticAddressesEnabled: true,
+				IPFamilies:             []networkingv1alpha.IPFamily{networkingv1alpha.IPv4Protocol, networkingv1alpha.IPv6Protocol},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"invalid static addresses": {
+			gateway: &gatewayv1.Gateway{
+				Spec: gatewayv1.GatewaySpec{
+					GatewayClassName: "test-gateway-class",
+					Listeners: []gatewayv1.Listener{
+						{
+							Name:     "http",
+							Protocol: gatewayv1.HTTPProtocolType,
+							Port:     80,
+						},
+					},
+					Addresses: []gatewayv1.GatewaySpecAddress{
+						{Type: ptr.To(gatewayv1.HostnameAddressType), Value: "gateway.example.com"},
+						{Value: "not-an-ip"},
+						{Value: "2001:db8::1"},
+						{Value: "2001:db8::2"},
+						{Value: "192.0.2.1"},
+						{Type: ptr.To(gatewayv1.NamedAddressType), Value: "Not_A_Claim"},
+					},
+				},
+			},
+			opts: GatewayValidationOptions{
+				ValidPortNumbers:       []int{80, 443},
+				ValidProtocolTypes:     defaultValidProtocolTypes,
+				StaticAddressesEnabled: true,
+				IPFamilies:             []networkingv1alpha.IPFamily{networkingv1alpha.IPv6Protocol},
+			},
+			expectedErrors: field.ErrorList{
+				field.NotSupported[gatewayv1.AddressType](field.NewPath("spec", "addresses").Index(0).Child("type"), "", nil),
+				field.Invalid(field.NewPath("spec", "addresses").Index(1).Child("value"), "", ""),
+				field.Invalid(field.NewPath("spec", "addresses").Index(3).Child("value"), "", ""),
+				field.Invalid(field.NewPath("spec", "addresses").Index(4).Child("value"), "", ""),
+				field.Invalid(field.NewPath("spec", "addresses").Index(5).Child("value"), "", ""),
+			},
+		},
 		"infrastructure not permitted": {
 			gateway: &gatewayv1.Gateway{
 				Spec: gatewayv1.GatewaySpec{
@@ -251,7 +353,7 @@ func TestValidateGateway(t *testing.T) {
 				field.Forbidden(field.NewPath("spec", "infrastructure"), "infrastructure is not permitted"),
 			},
 		},
-		"gateway-level tls not permitted": {
+		"gateway-level backend tls not permitted": {
 			gateway: &gatewayv1.Gateway{
 				Spec: gatewayv1.GatewaySpec{
 					GatewayClassName: "test-gateway-class",
@@ -276,7 +378,86 @@ func TestValidateGateway(t *testing.T) {
 				ValidProtocolTypes: defaultValidProtocolTypes,
 			},
 			expectedErrors: field.ErrorList{
-				field.Forbidden(field.NewPath("spec", "tls"), "tls is not permitted"),
+				field.Forbidden(field.NewPath("spec", "tls", "backend"), "backend is not permitted"),
+			},
+		},
+		"frontend tls validation permitted": {
+			gateway: &gatewayv1.Gateway{
+				Spec: gatewayv1.GatewaySpec{
+					GatewayClassName: "test-gateway-class",
+					Listeners: []gatewayv1.Listener{
+						{
+							Name:     "http",
+							Protocol: gatewayv1.HTTPProtocolType,
+							Port:     80,
+						},
+					},
+					TLS: &gatewayv1.GatewayTLSConfig{
+						Frontend: &gatewayv1.FrontendTLSConfig{
+							Default: gatewayv1.TLSConfig{
+								Validation: &gatewayv1.FrontendTLSValidation{
+									CACertificateRefs: []gatewayv1.ObjectReference{
+										{Group: "", Kind: "ConfigMap", Name: "client-ca"},
+									},
+								},
+							},
+							PerPort: []gatewayv1.TLSPortConfig{
+								{
+									Port: 443,
+									TLS: gatewayv1.TLSConfig{
+										Validation: &gatewayv1.FrontendTLSValidation{
+											CACertificateRefs: []gatewayv1.ObjectReference{
+												{Group: "", Kind: "Secret", Name: "client-ca"},
+											},
+											Mode: gatewayv1.AllowInsecureFallback,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			opts: GatewayValidationOptions{
+				ValidPortNumbers:   []int{80, 443},
+				ValidProtocolTypes: defaultValidProtocolTypes,
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"invalid frontend tls validation": {
+			gateway: &gatewayv1.Gateway{
+				Spec: gatewayv1.GatewaySpec{
+					GatewayClassName: "test-gateway-class",
+					Listeners: []gatewayv1.Listener{
+						{
+							Name:     "http",
+							Protocol: gatewayv1.HTTPProtocolType,
+							Port:     80,
+						},
+					},
+					TLS: &gatewayv1.GatewayTLSConfig{
+						Frontend: &gatewayv1.FrontendTLSConfig{
+							Default: gatewayv1.TLSConfig{
+								Validation: &gatewayv1.FrontendTLSValidation{
+									CACertificateRefs: []gatewayv1.ObjectReference{
+										{Group: "", Kind: "ConfigMap", Name: "client-ca", Namespace: ptr.To(gatewayv1.Namespace("other"))},
+										{Group: "example.com", Kind: "Bundle", Name: "client-ca"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			opts: GatewayValidationOptions{
+				ValidPortNumbers:   []int{80, 443},
+				ValidProtocolTypes: defaultValidProtocolTypes,
+			},
+			expectedErrors: field.ErrorList{
+				field.TooMany(field.NewPath("spec", "tls", "frontend", "default", "validation", "caCertificateRefs"), 2, 1),
+				field.Forbidden(field.NewPath("spec", "tls", "frontend", "default", "validation", "caCertificateRefs").Index(0).Child("namespace"), ""),
+				field.NotSupported(field.NewPath("spec", "tls", "frontend", "default", "validation", "caCertificateRefs").Index(1).Child("group"), nil, []string{""}),
+				field.NotSupported(field.NewPath("spec", "tls", "frontend", "default", "validation", "caCertificateRefs").Index(1).Child("kind"), nil, []string{"ConfigMap", "Secret"}),
 			},
 		},
 		"invalid tls settings": {
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package validation
+
+import (
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// CompileHostnameBlocklistPattern compiles a HostnameBlocklist pattern into a
+// case insensitive regular expression that matches hostnames in full.
+func CompileHostnameBlocklistPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)^(?:" + pattern + ")$")
+}
+
+func ValidateHostnameBlocklist(blocklist *networkingv1alpha.HostnameBlocklist) field.ErrorList {
+	var allErrs field.ErrorList
+
+	patternsPath := field.NewPath("spec", "patterns")
+	for i, pattern := range blocklist.Spec.Patterns {
+		if _, err := CompileHostnameBlocklistPattern(pattern); err != nil {
+			allErrs = append(allErrs, field.Invalid(patternsPath.Index(i), pattern, err.Error()))
+		}
+	}
+
+	return allErrs
+}
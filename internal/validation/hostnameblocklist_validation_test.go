@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestValidateHostnameBlocklist(t *testing.T) {
+	scenarios := map[string]struct {
+		patterns       []string
+		expectedErrors field.ErrorList
+	}{
+		"valid patterns": {
+			patterns: []string{`.*\.phish\.example`, `login-[0-9]+\.example\.com`},
+		},
+		"invalid pattern": {
+			patterns: []string{`.*\.phish\.example`, `login-[0-9+\.example\.com`},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "patterns").Index(1), "", ""),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			blocklist := &networkingv1alpha.HostnameBlocklist{
+				Spec: networkingv1alpha.HostnameBlocklistSpec{Patterns: scenario.patterns},
+			}
+			errs := ValidateHostnameBlocklist(blocklist)
+			if diff := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -4,13 +4,22 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 )
 
 func ValidateHTTPProxy(httpProxy *networkingv1alpha.HTTPProxy) field.ErrorList {
@@ -20,8 +29,12 @@ func ValidateHTTPProxy(httpProxy *networkingv1alpha.HTTPProxy) field.ErrorList {
 	hostnamesPath := field.NewPath("spec", "hostnames")
 	hostnames := sets.New[gatewayv1.Hostname]()
 	for i, hostname := range httpProxy.Spec.Hostnames {
-		hostnamePath := hostnamesPath.Index(i).Child("hostname")
-		allErrs = append(allErrs, validation.IsFullyQualifiedDomainName(hostnamePath, string(hostname))...)
+		hostnamePath := hostnamesPath.Index(i)
+		if net.ParseIP(string(hostname)) != nil {
+			allErrs = append(allErrs, field.Invalid(hostnamePath, hostname, "must be a DNS name, not an IP address"))
+		} else {
+			allErrs = append(allErrs, validation.IsFullyQualifiedDomainName(hostnamePath, string(hostname))...)
+		}
 		if hostnames.Has(hostname) {
 			allErrs = append(allErrs, field.Duplicate(hostnamePath, hostname))
 		} else {
@@ -34,6 +47,96 @@ func ValidateHTTPProxy(httpProxy *networkingv1alpha.HTTPProxy) field.ErrorList {
 	}
 
 	allErrs = append(allErrs, validateHTTPProxyRules(httpProxy, field.NewPath("spec", "rules"))...)
+	allErrs = append(allErrs, validateResponseHeaders(httpProxy.Spec.ResponseHeaders, field.NewPath("spec", "responseHeaders"))...)
+
+	return allErrs
+}
+
+// ValidateHTTPProxyBackendIPFamilies returns an error for each backend or
+// mirror endpoint of the HTTPProxy with an IP address of a family that is not
+// enabled on gateways, as the gateway would be unable to reach it.
+func ValidateHTTPProxyBackendIPFamilies(httpProxy *networkingv1alpha.HTTPProxy, ipFamilies []networkingv1alpha.IPFamily) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	validateEndpoint := func(endpoint string, fldPath *field.Path) {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return
+		}
+		ip := net.ParseIP(u.Hostname())
+		if ip == nil {
+			return
+		}
+		family := networkingv1alpha.IPv6Protocol
+		if ip.To4() != nil {
+			family = networkingv1alpha.IPv4Protocol
+		}
+		if !slices.Contains(ipFamilies, family) {
+			allErrs = append(allErrs, field.Invalid(fldPath, endpoint, fmt.Sprintf("%s addresses are not supported by gateways", family)))
+		}
+	}
+
+	rulesPath := field.NewPath("spec", "rules")
+	for i, rule := range httpProxy.Spec.Rules {
+		rulePath := rulesPath.Index(i)
+		for j, backend := range rule.Backends {
+			if backend.Connector != nil {
+				continue
+			}
+			validateEndpoint(backend.Endpoint, rulePath.Child("backends").Index(j).Child("endpoint"))
+		}
+		if rule.Mirror != nil {
+			validateEndpoint(rule.Mirror.Endpoint, rulePath.Child("mirror", "endpoint"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateHTTPProxyRequestPolicies returns an error for each timeout and retry
+// setting of the rules of the HTTPProxy that is outside the limits configured
+// for the operator.
+func ValidateHTTPProxyRequestPolicies(httpProxy *networkingv1alpha.HTTPProxy, requestPolicy config.HTTPProxyRequestPolicyConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	validateDuration := func(duration *gatewayv1.Duration, max time.Duration, fldPath *field.Path) {
+		if duration == nil {
+			return
+		}
+		d, err := time.ParseDuration(string(*duration))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, *duration, err.Error()))
+		} else if d <= 0 || d > max {
+			allErrs = append(allErrs, field.Invalid(fldPath, *duration, fmt.Sprintf("must be greater than 0s and at most %s", max)))
+		}
+	}
+
+	rulesPath := field.NewPath("spec", "rules")
+	for i, rule := range httpProxy.Spec.Rules {
+		rulePath := rulesPath.Index(i)
+		if rule.Timeouts != nil {
+			timeoutsPath := rulePath.Child("timeouts")
+			validateDuration(rule.Timeouts.Request, requestPolicy.MaxRequestTimeout.Duration, timeoutsPath.Child("request"))
+			validateDuration(rule.Timeouts.BackendRequest, requestPolicy.MaxRequestTimeout.Duration, timeoutsPath.Child("backendRequest"))
+		}
+
+		if rule.Retry == nil {
+			continue
+		}
+		retryPath := rulePath.Child("retry")
+		if attempts := rule.Retry.Attempts; attempts != nil && (*attempts < 0 || *attempts > requestPolicy.MaxRetryAttempts) {
+			allErrs = append(allErrs, field.Invalid(retryPath.Child("attempts"), *attempts, fmt.Sprintf("must be between 0 and %d", requestPolicy.MaxRetryAttempts)))
+		}
+		validateDuration(rule.Retry.Backoff, requestPolicy.MaxRetryBackoff.Duration, retryPath.Child("backoff"))
+
+		codes := sets.New[gatewayv1.HTTPRouteRetryStatusCode]()
+		for j, code := range rule.Retry.Codes {
+			if codes.Has(code) {
+				allErrs = append(allErrs, field.Duplicate(retryPath.Child("codes").Index(j), code))
+			}
+			codes.Insert(code)
+		}
+	}
 
 	return allErrs
 }
@@ -41,8 +144,63 @@ func ValidateHTTPProxy(httpProxy *networkingv1alpha.HTTPProxy) field.ErrorList {
 func validateHTTPProxyRules(httpProxy *networkingv1alpha.HTTPProxy, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	ruleNames := sets.New[gatewayv1.SectionName]()
+	for _, rule := range httpProxy.Spec.Rules {
+		if rule.Name != nil {
+			ruleNames.Insert(*rule.Name)
+		}
+	}
+
+	// Rules that route requests to a canary backend by header are programmed as
+	// an additional route rule, which counts towards the rule and match limits
+	// of the route.
+	routeRules, routeMatches := len(httpProxy.Spec.Rules), 0
+	for i, rule := range httpProxy.Spec.Rules {
+		routeMatches += len(rule.Matches)
+		if rule.TrafficPolicy == nil || rule.TrafficPolicy.Canary == nil || len(rule.TrafficPolicy.Canary.Headers) == 0 {
+			continue
+		}
+		routeRules++
+		routeMatches += max(len(rule.Matches), 1)
+		if rule.Name != nil && ruleNames.Has(*rule.Name+"-canary") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), *rule.Name, fmt.Sprintf("conflicts with the name of rule %q, which is used for the rule's canary header routing", *rule.Name+"-canary")))
+		}
+	}
+	if routeRules > 16 {
+		allErrs = append(allErrs, field.TooMany(fldPath, routeRules, 16))
+	}
+	if routeMatches > 128 {
+		allErrs = append(allErrs, field.Invalid(fldPath, routeMatches, "the total number of matches across all rules, including canary header routing, must be less than 128"))
+	}
+
+	// A match that is repeated in a later rule can never be selected for that
+	// rule, as the earliest rule takes precedence.
+	var matches []gatewayv1.HTTPRouteMatch
+	for i, rule := range httpProxy.Spec.Rules {
+		for j, match := range rule.Matches {
+			if slices.ContainsFunc(matches, func(m gatewayv1.HTTPRouteMatch) bool {
+				return equality.Semantic.DeepEqual(m, match)
+			}) {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("matches").Index(j), match))
+				continue
+			}
+			matches = append(matches, match)
+		}
+	}
+
 	for i, rule := range httpProxy.Spec.Rules {
 		allErrs = append(allErrs, validateHTTPProxyRule(rule, fldPath.Index(i))...)
+
+		// Load balancing and health check policies attach to the rule by name, and
+		// would otherwise apply to every rule in the proxy.
+		if rule.Name == nil && len(httpProxy.Spec.Rules) > 1 {
+			for _, backend := range rule.Backends {
+				if backend.LoadBalancer != nil || backend.HealthCheck != nil || backend.Role == networkingv1alpha.HTTPProxyBackendRoleBackup {
+					allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "a rule name is required when a backend sets loadBalancer, healthCheck or the backup role"))
+					break
+				}
+			}
+		}
 	}
 
 	return allErrs
@@ -51,12 +209,60 @@ func validateHTTPProxyRules(httpProxy *networkingv1alpha.HTTPProxy, fldPath *fie
 func validateHTTPProxyRule(rule networkingv1alpha.HTTPProxyRule, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	allErrs = append(allErrs, validateFilters(rule.Filters, supportedHTTPRouteRuleFilters, fldPath.Child("filters"))...)
+	allErrs = append(allErrs, validateHTTPProxyRuleMatches(rule.Matches, fldPath.Child("matches"))...)
+	allErrs = append(allErrs, validateFilters(rule.Filters, supportedHTTPProxyRuleFilters, fldPath.Child("filters"))...)
+	allErrs = append(allErrs, validateHeaderModifierFilters(rule.Filters, fldPath.Child("filters"))...)
+	allErrs = append(allErrs, validateCORSFilters(rule.Filters, fldPath.Child("filters"))...)
+	allErrs = append(allErrs, validateHTTPProxyRequestMirror(rule, fldPath.Child("mirror"))...)
 	allErrs = append(allErrs, validateHTTPProxyRuleBackends(rule, fldPath.Child("backends"))...)
+	allErrs = append(allErrs, validateHTTPProxyRuleFailover(rule, fldPath)...)
+	allErrs = append(allErrs, validateHTTPProxyRuleTrafficSplit(rule, fldPath)...)
+	allErrs = append(allErrs, validateHTTPProxyRuleBlueGreen(rule, fldPath)...)
+	allErrs = append(allErrs, validateResponseHeaders(rule.ResponseHeaders, fldPath.Child("responseHeaders"))...)
 
 	return allErrs
 }
 
+// supportedHTTPProxyRuleFilters are the filters permitted on HTTPProxy rules.
+// Requests are mirrored with the rule's mirror field, which programs the
+// RequestMirror filter and the backend that it references.
+var supportedHTTPProxyRuleFilters = supportedHTTPRouteRuleFilters.Clone().Delete(gatewayv1.HTTPRouteFilterRequestMirror)
+
+// validateHTTPProxyRuleMatches validates the regular expressions of rule
+// matches, which Envoy requires to be valid RE2 syntax.
+func validateHTTPProxyRuleMatches(matches []gatewayv1.HTTPRouteMatch, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, match := range matches {
+		matchPath := fldPath.Index(i)
+
+		if match.Path != nil && match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchRegularExpression && match.Path.Value != nil {
+			allErrs = append(allErrs, validateRegularExpression(matchPath.Child("path", "value"), *match.Path.Value)...)
+		}
+
+		for j, header := range match.Headers {
+			if header.Type != nil && *header.Type == gatewayv1.HeaderMatchRegularExpression {
+				allErrs = append(allErrs, validateRegularExpression(matchPath.Child("headers").Index(j).Child("value"), header.Value)...)
+			}
+		}
+
+		for j, queryParam := range match.QueryParams {
+			if queryParam.Type != nil && *queryParam.Type == gatewayv1.QueryParamMatchRegularExpression {
+				allErrs = append(allErrs, validateRegularExpression(matchPath.Child("queryParams").Index(j).Child("value"), queryParam.Value)...)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateRegularExpression(fldPath *field.Path, expr string) field.ErrorList {
+	if _, err := regexp.Compile(expr); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, expr, fmt.Sprintf("invalid regular expression: %s", err))}
+	}
+	return nil
+}
+
 func validateHTTPProxyRuleBackends(rule networkingv1alpha.HTTPProxyRule, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -82,69 +288,310 @@ func validateHTTPProxyRuleBackends(rule networkingv1alpha.HTTPProxyRule, fldPath
 	return allErrs
 }
 
-func validateHTTPProxyRuleBackend(backend networkingv1alpha.HTTPProxyRuleBackend, fldPath *field.Path) field.ErrorList {
+// validateHTTPProxyRuleFailover validates rules with backup backends. All
+// backends of such a rule are programmed as priority levels of a single
+// cluster, which requires them to share a scheme and to not define anything that
+// would split them into separate clusters.
+func validateHTTPProxyRuleFailover(rule networkingv1alpha.HTTPProxyRule, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	endpointFieldPath := fldPath.Child("endpoint")
-	u, err := url.Parse(backend.Endpoint)
-	if err != nil {
-		allErrs = append(allErrs, field.Invalid(endpointFieldPath, backend.Endpoint, fmt.Sprintf("invalid endpoint: %s", err)))
-	} else {
-		if u.Scheme != schemeHTTP && u.Scheme != schemeHTTPS {
-			allErrs = append(allErrs, field.NotSupported(endpointFieldPath.Key("scheme"), u.Scheme, []string{schemeHTTP, schemeHTTPS}))
+	backendsPath := fldPath.Child("backends")
+
+	primaries := 0
+	hasBackups := false
+	for _, backend := range rule.Backends {
+		switch backend.Role {
+		case networkingv1alpha.HTTPProxyBackendRoleBackup:
+			hasBackups = true
+		case networkingv1alpha.HTTPProxyBackendRoleCanary, networkingv1alpha.HTTPProxyBackendRoleBlue, networkingv1alpha.HTTPProxyBackendRoleGreen:
+			// Reported by traffic split and blue/green validation.
+		default:
+			primaries++
 		}
+	}
+
+	allErrs = append(allErrs, validateHTTPProxyHealthCheck(rule.HealthCheck, fldPath.Child("healthCheck"))...)
 
-		if u.User != nil {
-			allErrs = append(allErrs, field.Invalid(endpointFieldPath.Key("userinfo"), fmt.Sprintf("%s:redacted", u.User.Username()), "endpoint must not have a userinfo component"))
+	if !hasBackups {
+		return allErrs
+	}
+
+	if primaries != 1 {
+		allErrs = append(allErrs, field.Invalid(backendsPath, primaries, "exactly one primary backend is required"))
+	}
+
+	if rule.HealthCheck == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("healthCheck"), "a health check is required when the rule has backup backends"))
+	}
+
+	for i, backend := range rule.Backends {
+		if backend.HealthCheck != nil {
+			allErrs = append(allErrs, field.Forbidden(backendsPath.Index(i).Child("healthCheck"), "backends in rules with backup backends are health checked with the rule's healthCheck"))
 		}
+	}
 
-		// Align with EndpointSlice validation of addresses.
-		// See: https://github.com/kubernetes/kubernetes/blob/d21da29c9ec486956b204050cdfaa46c686e29cc/pkg/apis/discovery/validation/validation.go#L115
-		hostFieldPath := endpointFieldPath.Key("host")
-		host := u.Hostname()
-		hasConnector := backend.Connector != nil
-		isIPAddress := false
-		if ip := net.ParseIP(host); ip != nil {
-			isIPAddress = true
-			// Adapted from https://github.com/kubernetes/kubernetes/blob/d21da29c9ec486956b204050cdfaa46c686e29cc/pkg/apis/core/validation/validation.go#L7797
-			if ip.IsUnspecified() {
-				allErrs = append(allErrs, field.Invalid(hostFieldPath, host, fmt.Sprintf("may not be unspecified (%v)", host)))
-			}
-			if ip.IsLoopback() && !hasConnector {
-				allErrs = append(allErrs, field.Invalid(hostFieldPath, host, "may not be in the loopback range (127.0.0.0/8, ::1/128)"))
-			}
-			if ip.IsLinkLocalUnicast() {
-				allErrs = append(allErrs, field.Invalid(hostFieldPath, host, "may not be in the link-local range (169.254.0.0/16, fe80::/10)"))
-			}
-			if ip.IsLinkLocalMulticast() {
-				allErrs = append(allErrs, field.Invalid(hostFieldPath, host, "may not be in the link-local multicast range (224.0.0.0/24, ff02::/10)"))
+	allErrs = append(allErrs, validateHTTPProxySharedClusterBackends(rule, backendsPath, "rules with backup backends")...)
+
+	return allErrs
+}
+
+// validateHTTPProxyRuleTrafficSplit validates rules that split requests between
+// weighted primary backends, between a primary and a canary backend, or between
+// blue/green backend sets. Like failover, the backends share a single cluster so
+// that the Host header of each request follows the backend it is sent to.
+func validateHTTPProxyRuleTrafficSplit(rule networkingv1alpha.HTTPProxyRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	backendsPath := fldPath.Child("backends")
+	canaryPath := fldPath.Child("trafficPolicy", "canary")
+
+	var canary *networkingv1alpha.HTTPProxyCanary
+	var blueGreen *networkingv1alpha.HTTPProxyBlueGreen
+	if rule.TrafficPolicy != nil {
+		canary = rule.TrafficPolicy.Canary
+		blueGreen = rule.TrafficPolicy.BlueGreen
+	}
+
+	primaries, canaries, blueGreens, weighted := 0, 0, 0, 0
+	for _, backend := range rule.Backends {
+		switch backend.Role {
+		case networkingv1alpha.HTTPProxyBackendRoleBackup:
+		case networkingv1alpha.HTTPProxyBackendRoleCanary:
+			canaries++
+		case networkingv1alpha.HTTPProxyBackendRoleBlue, networkingv1alpha.HTTPProxyBackendRoleGreen:
+			blueGreens++
+		default:
+			primaries++
+			if backend.Weight != nil {
+				weighted++
 			}
-		} else {
-			if !hasConnector || host != "localhost" {
-				allErrs = append(allErrs, validation.IsFullyQualifiedDomainName(hostFieldPath, host)...)
+		}
+	}
+
+	for i, backend := range rule.Backends {
+		backendPath := backendsPath.Index(i)
+		switch {
+		case backend.Weight == nil:
+			// Multiple primary backends without any weights are rejected by the
+			// schema.
+			if primaries > 1 && weighted > 0 && canary == nil && (backend.Role == "" || backend.Role == networkingv1alpha.HTTPProxyBackendRolePrimary) {
+				allErrs = append(allErrs, field.Required(backendPath.Child("weight"), "a weight is required when a rule has multiple primary backends"))
 			}
+		case backend.Role == networkingv1alpha.HTTPProxyBackendRoleBackup:
+			allErrs = append(allErrs, field.Forbidden(backendPath.Child("weight"), "backup backends may not set a weight"))
+		case canary != nil || canaries > 0:
+			allErrs = append(allErrs, field.Forbidden(backendPath.Child("weight"), "weights may not be set in rules with a canary backend, use trafficPolicy.canary.percentage"))
 		}
+	}
 
-		// HTTPS endpoints with IP addresses require tls.hostname for certificate validation
-		if u.Scheme == schemeHTTPS && isIPAddress {
-			if backend.TLS == nil || backend.TLS.Hostname == nil || *backend.TLS.Hostname == "" {
-				allErrs = append(allErrs, field.Required(fldPath.Child("tls", "hostname"), "tls.hostname is required for HTTPS endpoints with IP addresses"))
+	if canaries > 1 {
+		allErrs = append(allErrs, field.Invalid(backendsPath, canaries, "at most one canary backend is permitted"))
+	}
+	if canaries > 0 && canary == nil {
+		allErrs = append(allErrs, field.Required(canaryPath, "a canary traffic policy is required when a backend has the Canary role"))
+	}
+	if canary != nil {
+		if canaries == 0 {
+			allErrs = append(allErrs, field.Required(backendsPath, "a backend with the Canary role is required when the rule has a canary traffic policy"))
+		}
+		if primaries != 1 {
+			allErrs = append(allErrs, field.Invalid(backendsPath, primaries, "exactly one primary backend is required when the rule has a canary traffic policy"))
+		}
+		if canary.Percentage < 0 || canary.Percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(canaryPath.Child("percentage"), canary.Percentage, "must be between 0 and 100"))
+		}
+	}
+
+	if canary == nil && canaries == 0 && blueGreen == nil && blueGreens == 0 && (primaries < 2 || weighted == 0) {
+		return allErrs
+	}
+
+	hasLoadBalancer := false
+	for i, backend := range rule.Backends {
+		backendPath := backendsPath.Index(i)
+		if backend.LoadBalancer != nil {
+			if hasLoadBalancer {
+				allErrs = append(allErrs, field.Forbidden(backendPath.Child("loadBalancer"), "only one backend may set loadBalancer in rules that split traffic, as it applies to every backend of the rule"))
 			}
+			hasLoadBalancer = true
+		}
+		if backend.Role == networkingv1alpha.HTTPProxyBackendRoleBackup {
+			allErrs = append(allErrs, field.Forbidden(backendPath.Child("role"), "backup backends may not be used in rules that split traffic"))
+		}
+		if backend.HealthCheck != nil {
+			allErrs = append(allErrs, field.Forbidden(backendPath.Child("healthCheck"), "health checks may not be used in rules that split traffic"))
+		}
+	}
+
+	allErrs = append(allErrs, validateHTTPProxySharedClusterBackends(rule, backendsPath, "rules that split traffic")...)
+
+	return allErrs
+}
+
+// validateHTTPProxyRuleBlueGreen validates rules with a blue/green traffic
+// policy, whose backends must all belong to either the blue or the green set.
+func validateHTTPProxyRuleBlueGreen(rule networkingv1alpha.HTTPProxyRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	backendsPath := fldPath.Child("backends")
+	blueGreenPath := fldPath.Child("trafficPolicy", "blueGreen")
+
+	var blueGreen *networkingv1alpha.HTTPProxyBlueGreen
+	if rule.TrafficPolicy != nil {
+		blueGreen = rule.TrafficPolicy.BlueGreen
+	}
+
+	blues, greens := 0, 0
+	for _, backend := range rule.Backends {
+		switch backend.Role {
+		case networkingv1alpha.HTTPProxyBackendRoleBlue:
+			blues++
+		case networkingv1alpha.HTTPProxyBackendRoleGreen:
+			greens++
 		}
+	}
 
-		if u.Path != "" {
-			allErrs = append(allErrs, field.Invalid(endpointFieldPath.Key("path"), u.Path, "endpoint must not have a path component"))
+	if blueGreen == nil {
+		if blues > 0 || greens > 0 {
+			allErrs = append(allErrs, field.Required(blueGreenPath, "a blue/green traffic policy is required when a backend has the Blue or Green role"))
 		}
+		return allErrs
+	}
+
+	if rule.TrafficPolicy.Canary != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("trafficPolicy", "canary"), "a canary traffic policy may not be combined with a blue/green traffic policy"))
+	}
 
-		if u.RawQuery != "" {
-			allErrs = append(allErrs, field.Invalid(endpointFieldPath.Key("query"), u.RawQuery, "endpoint must not have a query component"))
+	switch blueGreen.Active {
+	case networkingv1alpha.HTTPProxyBackendRoleBlue, networkingv1alpha.HTTPProxyBackendRoleGreen:
+	default:
+		allErrs = append(allErrs, field.NotSupported(blueGreenPath.Child("active"), blueGreen.Active, []networkingv1alpha.HTTPProxyBackendRole{
+			networkingv1alpha.HTTPProxyBackendRoleBlue,
+			networkingv1alpha.HTTPProxyBackendRoleGreen,
+		}))
+	}
+
+	if blues == 0 {
+		allErrs = append(allErrs, field.Required(backendsPath, "a backend with the Blue role is required when the rule has a blue/green traffic policy"))
+	}
+	if greens == 0 {
+		allErrs = append(allErrs, field.Required(backendsPath, "a backend with the Green role is required when the rule has a blue/green traffic policy"))
+	}
+	for i, backend := range rule.Backends {
+		if backend.Role != networkingv1alpha.HTTPProxyBackendRoleBlue && backend.Role != networkingv1alpha.HTTPProxyBackendRoleGreen {
+			allErrs = append(allErrs, field.Invalid(backendsPath.Index(i).Child("role"), backend.Role, "backends of rules with a blue/green traffic policy must have the Blue or Green role"))
 		}
+	}
 
-		if u.Fragment != "" {
-			allErrs = append(allErrs, field.Invalid(endpointFieldPath.Key("fragment"), u.Fragment, "endpoint must not have a fragment component"))
+	if blueGreen.DrainTimeout != nil {
+		d, err := time.ParseDuration(string(*blueGreen.DrainTimeout))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(blueGreenPath.Child("drainTimeout"), *blueGreen.DrainTimeout, err.Error()))
+		} else if d <= 0 {
+			allErrs = append(allErrs, field.Invalid(blueGreenPath.Child("drainTimeout"), *blueGreen.DrainTimeout, "must be greater than 0"))
 		}
 	}
 
+	return allErrs
+}
+
+// validateHTTPProxySharedClusterBackends validates the backends of a rule that
+// are programmed as a single cluster. Such backends must share a scheme, use DNS
+// hostnames, and not define anything that would split them into separate
+// clusters.
+func validateHTTPProxySharedClusterBackends(rule networkingv1alpha.HTTPProxyRule, fldPath *field.Path, rules string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var scheme string
+	for i, backend := range rule.Backends {
+		backendPath := fldPath.Index(i)
+
+		if backend.Connector != nil {
+			allErrs = append(allErrs, field.Forbidden(backendPath.Child("connector"), fmt.Sprintf("connectors may not be used in %s", rules)))
+		}
+
+		if len(backend.Filters) > 0 {
+			allErrs = append(allErrs, field.Forbidden(backendPath.Child("filters"), fmt.Sprintf("backend filters may not be used in %s", rules)))
+		}
+
+		u, err := url.Parse(backend.Endpoint)
+		if err != nil {
+			// Reported by backend validation.
+			continue
+		}
+
+		if net.ParseIP(u.Hostname()) != nil {
+			allErrs = append(allErrs, field.Invalid(backendPath.Child("endpoint").Key("host"), u.Hostname(), fmt.Sprintf("backends in %s must use a DNS hostname", rules)))
+		}
+
+		if scheme == "" {
+			scheme = u.Scheme
+		} else if u.Scheme != scheme {
+			allErrs = append(allErrs, field.Invalid(backendPath.Child("endpoint").Key("scheme"), u.Scheme, fmt.Sprintf("all backends in %s must use the same scheme", rules)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateHTTPProxyHealthCheck(healthCheck *networkingv1alpha.HTTPProxyHealthCheck, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if healthCheck == nil {
+		return allErrs
+	}
+
+	if healthCheck.Path != "" && !strings.HasPrefix(healthCheck.Path, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), healthCheck.Path, "must begin with '/'"))
+	}
+
+	var interval, timeout time.Duration
+	if healthCheck.Interval != nil {
+		d, err := time.ParseDuration(string(*healthCheck.Interval))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("interval"), *healthCheck.Interval, err.Error()))
+		} else if d < minHealthCheckInterval {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("interval"), *healthCheck.Interval, fmt.Sprintf("must be at least %s", minHealthCheckInterval)))
+		}
+		interval = d
+	}
+
+	if healthCheck.Timeout != nil {
+		d, err := time.ParseDuration(string(*healthCheck.Timeout))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), *healthCheck.Timeout, err.Error()))
+		}
+		timeout = d
+	}
+
+	if interval > 0 && timeout > interval {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), *healthCheck.Timeout, "must not be greater than interval"))
+	}
+
+	return allErrs
+}
+
+// minHealthCheckInterval limits how frequently backends are health checked
+// from each gateway.
+const minHealthCheckInterval = time.Second
+
+func validateHTTPProxyRuleBackend(backend networkingv1alpha.HTTPProxyRuleBackend, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	u, errs := validateHTTPProxyEndpoint(backend.Endpoint, fldPath.Child("endpoint"), backend.Connector != nil)
+	allErrs = append(allErrs, errs...)
+
+	// HTTPS endpoints with IP addresses require tls.hostname for certificate validation
+	if u != nil && u.Scheme == schemeHTTPS && net.ParseIP(u.Hostname()) != nil {
+		if backend.TLS == nil || backend.TLS.Hostname == nil || *backend.TLS.Hostname == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("tls", "hostname"), "tls.hostname is required for HTTPS endpoints with IP addresses"))
+		}
+	}
+	allErrs = append(allErrs, validateHTTPProxyBackendTLS(backend.TLS, u, fldPath.Child("tls"))...)
+
+	if backend.Protocol == networkingv1alpha.HTTPProxyBackendProtocolH2C && u != nil && u.Scheme != schemeHTTP {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("protocol"), backend.Protocol, "H2C may only be used with http endpoints"))
+	}
+
 	if backend.Connector != nil {
 		connectorFieldPath := fldPath.Child("connector", "name")
 		if backend.Connector.Name == "" {
@@ -156,6 +603,389 @@ func validateHTTPProxyRuleBackend(backend networkingv1alpha.HTTPProxyRuleBackend
 		}
 	}
 
+	if backend.HealthCheck != nil && backend.Connector != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("healthCheck"), "connector backends may not be health checked"))
+	}
+	allErrs = append(allErrs, validateHTTPProxyHealthCheck(backend.HealthCheck, fldPath.Child("healthCheck"))...)
+
 	allErrs = append(allErrs, validateFilters(backend.Filters, supportedHTTPBackendRefFilters, fldPath.Child("filters"))...)
+	allErrs = append(allErrs, validateHeaderModifierFilters(backend.Filters, fldPath.Child("filters"))...)
+	return allErrs
+}
+
+// validateHTTPProxyBackendTLS validates the certificate validation settings
+// of a backend, which only apply to https endpoints.
+func validateHTTPProxyBackendTLS(tls *networkingv1alpha.HTTPProxyBackendTLS, u *url.URL, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if tls == nil {
+		return allErrs
+	}
+
+	if u != nil && u.Scheme != schemeHTTPS {
+		if tls.CACertificateRef != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("caCertificateRef"), "may only be set for https endpoints"))
+		}
+		if len(tls.SubjectAltNames) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("subjectAltNames"), "may only be set for https endpoints"))
+		}
+	}
+
+	if ref := tls.CACertificateRef; ref != nil {
+		refPath := fldPath.Child("caCertificateRef")
+		if ref.Kind != "" && ref.Kind != "ConfigMap" && ref.Kind != "Secret" {
+			allErrs = append(allErrs, field.NotSupported(refPath.Child("kind"), ref.Kind, []string{"ConfigMap", "Secret"}))
+		}
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+				allErrs = append(allErrs, field.Invalid(refPath.Child("name"), ref.Name, msg))
+			}
+		}
+	}
+
+	seen := sets.New[gatewayv1.SubjectAltName]()
+	for i, san := range tls.SubjectAltNames {
+		sanPath := fldPath.Child("subjectAltNames").Index(i)
+		switch san.Type {
+		case gatewayv1.HostnameSubjectAltNameType:
+			if san.Hostname == "" {
+				allErrs = append(allErrs, field.Required(sanPath.Child("hostname"), "hostname is required when type is Hostname"))
+			}
+		case gatewayv1.URISubjectAltNameType:
+			if san.URI == "" {
+				allErrs = append(allErrs, field.Required(sanPath.Child("uri"), "uri is required when type is URI"))
+			} else if u, err := url.Parse(string(san.URI)); err != nil || u.Scheme == "" {
+				allErrs = append(allErrs, field.Invalid(sanPath.Child("uri"), san.URI, "must be an absolute URI"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(sanPath.Child("type"), san.Type,
+				[]gatewayv1.SubjectAltNameType{gatewayv1.HostnameSubjectAltNameType, gatewayv1.URISubjectAltNameType}))
+		}
+
+		if seen.Has(san) {
+			allErrs = append(allErrs, field.Duplicate(sanPath, san))
+		}
+		seen.Insert(san)
+	}
+
+	return allErrs
+}
+
+// maxHTTPProxyEndpointLength mirrors the maximum length of endpoints in the
+// HTTPProxy schema.
+const maxHTTPProxyEndpointLength = 2048
+
+// validateHTTPProxyEndpoint validates the URL of a backend endpoint, and
+// returns it when it could be parsed. Loopback addresses and localhost are
+// only permitted for endpoints reached through a connector.
+func validateHTTPProxyEndpoint(endpoint string, endpointFieldPath *field.Path, hasConnector bool) (*url.URL, field.ErrorList) {
+	allErrs := field.ErrorList{}
+
+	if len(endpoint) > maxHTTPProxyEndpointLength {
+		return nil, append(allErrs, field.TooLong(endpointFieldPath, len(endpoint), maxHTTPProxyEndpointLength))
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, append(allErrs, field.Invalid(endpointFieldPath, endpoint, fmt.Sprintf("invalid endpoint: %s", err)))
+	}
+
+	if u.Scheme != schemeHTTP && u.Scheme != schemeHTTPS {
+		allErrs = append(allErrs, field.NotSupported(endpointFieldPath.Key("scheme"), u.Scheme, []string{schemeHTTP, schemeHTTPS}))
+	}
+
+	if u.User != nil {
+		allErrs = append(allErrs, field.Invalid(endpointFieldPath.Key("userinfo"), fmt.Sprintf("%s:redacted", u.User.Username()), "endpoint must not have a userinfo component"))
+	}
+
+	// Align with EndpointSlice validation of addresses.
+	// See: https://github.com/kubernetes/kubernetes/blob/d21da29c9ec486956b204050cdfaa46c686e29cc/pkg/apis/discovery/validation/validation.go#L115
+	hostFieldPath := endpointFieldPath.Key("host")
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		// Adapted from https://github.com/kubernetes/kubernetes/blob/d21da29c9ec486956b204050cdfaa46c686e29cc/pkg/apis/core/validation/validation.go#L7797
+		if ip.IsUnspecified() {
+			allErrs = append(allErrs, field.Invalid(hostFieldPath, host, fmt.Sprintf("may not be unspecified (%v)", host)))
+		}
+		if ip.IsLoopback() && !hasConnector {
+			allErrs = append(allErrs, field.Invalid(hostFieldPath, host, "may not be in the loopback range (127.0.0.0/8, ::1/128)"))
+		}
+		if ip.IsLinkLocalUnicast() {
+			allErrs = append(allErrs, field.Invalid(hostFieldPath, host, "may not be in the link-local range (169.254.0.0/16, fe80::/10)"))
+		}
+		if ip.IsLinkLocalMulticast() {
+			allErrs = append(allErrs, field.Invalid(hostFieldPath, host, "may not be in the link-local multicast range (224.0.0.0/24, ff02::/10)"))
+		}
+	} else {
+		if !hasConnector || host != "localhost" {
+			allErrs = append(allErrs, validation.IsFullyQualifiedDomainName(hostFieldPath, host)...)
+		}
+	}
+
+	if port := u.Port(); port != "" {
+		if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+			allErrs = append(allErrs, field.Invalid(endpointFieldPath.Key("port"), port, "must be between 1 and 65535"))
+		}
+	}
+
+	if u.Path != "" {
+		allErrs = append(allErrs, field.Invalid(endpointFieldPath.Key("path"), u.Path, "endpoint must not have a path component"))
+	}
+
+	if u.RawQuery != "" {
+		allErrs = append(allErrs, field.Invalid(endpointFieldPath.Key("query"), u.RawQuery, "endpoint must not have a query component"))
+	}
+
+	if u.Fragment != "" {
+		allErrs = append(allErrs, field.Invalid(endpointFieldPath.Key("fragment"), u.Fragment, "endpoint must not have a fragment component"))
+	}
+
+	return u, allErrs
+}
+
+// validateHTTPProxyRequestMirror validates the mirror backend of a rule.
+// Mirrored requests are copies of the requests forwarded to the rule's
+// backends, and keep their Host header, so HTTPS mirror backends must be
+// addressed by a DNS name to validate their certificate.
+func validateHTTPProxyRequestMirror(rule networkingv1alpha.HTTPProxyRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if rule.Mirror == nil {
+		return allErrs
+	}
+
+	if len(rule.Backends) == 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "mirror requires the rule to have backends"))
+	}
+
+	endpointFieldPath := fldPath.Child("endpoint")
+	u, errs := validateHTTPProxyEndpoint(rule.Mirror.Endpoint, endpointFieldPath, false)
+	allErrs = append(allErrs, errs...)
+	if u != nil && u.Scheme == schemeHTTPS && net.ParseIP(u.Hostname()) != nil {
+		allErrs = append(allErrs, field.Invalid(endpointFieldPath.Key("host"), u.Hostname(), "HTTPS mirror endpoints must use a DNS name"))
+	}
+
+	if rule.Mirror.Percent != nil && (*rule.Mirror.Percent < 0 || *rule.Mirror.Percent > 100) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("percent"), *rule.Mirror.Percent, "must be between 0 and 100"))
+	}
+
 	return allErrs
 }
+
+// validateCORSFilters validates the CORS filters of a rule. Browsers reject
+// credentialed responses that allow any origin, so credentials may only be
+// allowed together with a list of origins.
+func validateCORSFilters(filters []gatewayv1.HTTPRouteFilter, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, filter := range filters {
+		if filter.Type != gatewayv1.HTTPRouteFilterCORS {
+			continue
+		}
+		corsPath := fldPath.Index(i).Child("cors")
+		if filter.CORS == nil {
+			allErrs = append(allErrs, field.Required(corsPath, "cors is required for the CORS filter"))
+			continue
+		}
+		if ptr.Deref(filter.CORS.AllowCredentials, false) && slices.Contains(filter.CORS.AllowOrigins, "*") {
+			allErrs = append(allErrs, field.Invalid(corsPath.Child("allowOrigins"), filter.CORS.AllowOrigins, "may not allow any origin when allowCredentials is true"))
+		}
+	}
+
+	return allErrs
+}
+
+// protectedResponseHeaders are managed by the platform and may not be modified
+// by response header policies or ResponseHeaderModifier filters.
+var protectedResponseHeaders = sets.New(
+	"alt-svc",
+	"connection",
+	"content-length",
+	"date",
+	"keep-alive",
+	"proxy-connection",
+	"server",
+	"strict-transport-security",
+	"transfer-encoding",
+	"upgrade",
+	"x-request-id",
+)
+
+var protectedResponseHeaderPrefixes = []string{
+	"x-envoy-",
+	"x-datum-",
+}
+
+func isProtectedResponseHeader(name string) bool {
+	name = strings.ToLower(name)
+	if protectedResponseHeaders.Has(name) {
+		return true
+	}
+	for _, prefix := range protectedResponseHeaderPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateResponseHeaders(headers *gatewayv1.HTTPHeaderFilter, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if headers == nil {
+		return allErrs
+	}
+
+	if len(headers.Set) == 0 && len(headers.Add) == 0 && len(headers.Remove) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of set, add or remove must be specified"))
+	}
+
+	allErrs = append(allErrs, validateHeaderNames(headers, fldPath, validateResponseHeaderName)...)
+
+	return allErrs
+}
+
+// validateHeaderModifierFilters validates the header names of the
+// RequestHeaderModifier and ResponseHeaderModifier filters of a rule or
+// backend.
+func validateHeaderModifierFilters(filters []gatewayv1.HTTPRouteFilter, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, filter := range filters {
+		if modifier := filter.RequestHeaderModifier; modifier != nil {
+			modifierPath := fldPath.Index(i).Child("requestHeaderModifier")
+			allErrs = append(allErrs, validateHeaderNames(modifier, modifierPath, validateHTTPHeaderName)...)
+
+			// A Host set by the modifier is translated to a hostname rewrite, which
+			// can't express adding or removing the header.
+			for j, h := range modifier.Add {
+				if strings.EqualFold(string(h.Name), "Host") {
+					allErrs = append(allErrs, field.Forbidden(modifierPath.Child("add").Index(j).Child("name"), "the Host header may only be overridden with set"))
+				}
+			}
+			for j, name := range modifier.Remove {
+				if strings.EqualFold(name, "Host") {
+					allErrs = append(allErrs, field.Forbidden(modifierPath.Child("remove").Index(j), "the Host header may only be overridden with set"))
+				}
+			}
+		}
+
+		if modifier := filter.ResponseHeaderModifier; modifier != nil {
+			modifierPath := fldPath.Index(i).Child("responseHeaderModifier")
+			allErrs = append(allErrs, validateHeaderNames(modifier, modifierPath, validateResponseHeaderName)...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateHeaderNames validates the name of each header set, added or removed
+// by a header filter.
+func validateHeaderNames(headers *gatewayv1.HTTPHeaderFilter, fldPath *field.Path, validateName func(*field.Path, string) field.ErrorList) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, h := range headers.Set {
+		allErrs = append(allErrs, validateName(fldPath.Child("set").Index(i).Child("name"), string(h.Name))...)
+	}
+	for i, h := range headers.Add {
+		allErrs = append(allErrs, validateName(fldPath.Child("add").Index(i).Child("name"), string(h.Name))...)
+	}
+	for i, name := range headers.Remove {
+		allErrs = append(allErrs, validateName(fldPath.Child("remove").Index(i), name)...)
+	}
+
+	return allErrs
+}
+
+func validateHTTPHeaderName(namePath *field.Path, name string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsHTTPHeaderName(name) {
+		allErrs = append(allErrs, field.Invalid(namePath, name, msg))
+	}
+	return allErrs
+}
+
+func validateResponseHeaderName(namePath *field.Path, name string) field.ErrorList {
+	allErrs := validateHTTPHeaderName(namePath, name)
+	if isProtectedResponseHeader(name) {
+		allErrs = append(allErrs, field.Forbidden(namePath, fmt.Sprintf("header %q is managed by the platform and may not be modified", name)))
+	}
+	return allErrs
+}
+
+// recommendedMinHealthCheckInterval is the interval below which health checks
+// are reported as a warning. Each gateway health checks every backend, so
+// short intervals multiply into significant load on backends.
+const recommendedMinHealthCheckInterval = 5 * time.Second
+
+// recommendedMinDNSRecordTTL is the TTL, in seconds, below which DNS record
+// TTLs are reported as a warning. Many resolvers raise lower TTLs, and the
+// records are queried more often by those that don't.
+const recommendedMinDNSRecordTTL = 60
+
+// HTTPProxyWarnings returns the configurations in an HTTPProxy that are valid,
+// but likely to be unintended.
+func HTTPProxyWarnings(httpProxy *networkingv1alpha.HTTPProxy) []networkingv1alpha.Warning {
+	var warnings []networkingv1alpha.Warning
+
+	hostnamesPath := field.NewPath("spec", "hostnames")
+	for i, hostname := range httpProxy.Spec.Hostnames {
+		for _, wildcard := range httpProxy.Spec.Hostnames {
+			if strings.HasPrefix(string(wildcard), "*.") && !strings.HasPrefix(string(hostname), "*.") &&
+				strings.HasSuffix(string(hostname), string(wildcard)[1:]) {
+				warnings = append(warnings, networkingv1alpha.Warning{
+					Code:    networkingv1alpha.WarningCodeHostnameCoveredByWildcard,
+					Field:   hostnamesPath.Index(i).String(),
+					Message: fmt.Sprintf("hostname %q is already matched by wildcard hostname %q", hostname, wildcard),
+				})
+				break
+			}
+		}
+	}
+
+	// TTLs that can't be parsed are reported on the gateway of the proxy.
+	if value, ok := httpProxy.Annotations[gatewayutil.DNSRecordTTLAnnotation]; ok {
+		if ttl, err := strconv.ParseInt(value, 10, 64); err == nil && ttl > 0 && ttl < recommendedMinDNSRecordTTL {
+			warnings = append(warnings, networkingv1alpha.Warning{
+				Code:    networkingv1alpha.WarningCodeDNSRecordTTLShort,
+				Field:   field.NewPath("metadata", "annotations").Key(gatewayutil.DNSRecordTTLAnnotation).String(),
+				Message: fmt.Sprintf("DNS record TTLs below %d seconds are raised by many resolvers, and increase the queries of the others", recommendedMinDNSRecordTTL),
+			})
+		}
+	}
+
+	rulesPath := field.NewPath("spec", "rules")
+	warnShortInterval := func(healthCheck *networkingv1alpha.HTTPProxyHealthCheck, fldPath *field.Path) {
+		if healthCheck == nil || healthCheck.Interval == nil {
+			return
+		}
+		interval, err := time.ParseDuration(string(*healthCheck.Interval))
+		if err == nil && interval < recommendedMinHealthCheckInterval {
+			warnings = append(warnings, networkingv1alpha.Warning{
+				Code:    networkingv1alpha.WarningCodeHealthCheckIntervalShort,
+				Field:   fldPath.Child("interval").String(),
+				Message: fmt.Sprintf("health checks more frequent than every %s increase load on backends, as each gateway checks every backend", recommendedMinHealthCheckInterval),
+			})
+		}
+	}
+	for i, rule := range httpProxy.Spec.Rules {
+		warnShortInterval(rule.HealthCheck, rulesPath.Index(i).Child("healthCheck"))
+		for j, backend := range rule.Backends {
+			warnShortInterval(backend.HealthCheck, rulesPath.Index(i).Child("backends").Index(j).Child("healthCheck"))
+		}
+	}
+
+	return warnings
+}
+
+// WarningMessages formats warnings for admission responses.
+func WarningMessages(warnings []networkingv1alpha.Warning) []string {
+	var messages []string
+	for _, w := range warnings {
+		if w.Field != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s (%s)", w.Field, w.Message, w.Code))
+		} else {
+			messages = append(messages, fmt.Sprintf("%s (%s)", w.Message, w.Code))
+		}
+	}
+	return messages
+}
@@ -3,6 +3,7 @@ package validation
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -12,6 +13,8 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 )
 
 func TestValidateHTTPProxy(t *testing.T) {
@@ -242,6 +245,24 @@ func TestValidateHTTPProxy(t *testing.T) {
 				}
 			}(),
 		},
+		"endpoint too long": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://" + strings.Repeat("a", 2048) + ".example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.TooLong(field.NewPath("spec", "rules").Index(0).Child("backends").Index(0).Child("endpoint"), 0, 0),
+			},
+		},
 		"backend required when RequestRedirect filter not present": {
 			proxy: &networkingv1alpha.HTTPProxy{
 				Spec: networkingv1alpha.HTTPProxySpec{
@@ -366,18 +387,1057 @@ func TestValidateHTTPProxy(t *testing.T) {
 			},
 			expectedErrors: field.ErrorList{},
 		},
-	}
-
-	for name, scenario := range scenarios {
-		t.Run(name, func(t *testing.T) {
-			if scenario.proxy.Name == "" {
-				scenario.proxy.Name = "test"
-			}
-			errs := ValidateHTTPProxy(scenario.proxy)
-			delta := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail"))
-			if delta != "" {
-				t.Errorf("Testcase %s - expected errors '%v', got '%v', diff: '%v'", name, scenario.expectedErrors, errs, delta)
-			}
-		})
+		"response headers for caching are valid": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					ResponseHeaders: &gatewayv1.HTTPHeaderFilter{
+						Set: []gatewayv1.HTTPHeader{
+							{Name: "Cache-Control", Value: "public, max-age=300"},
+						},
+						Remove: []string{"Surrogate-Control"},
+					},
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://api.example.com",
+								},
+							},
+							ResponseHeaders: &gatewayv1.HTTPHeaderFilter{
+								Set: []gatewayv1.HTTPHeader{
+									{Name: "Cache-Control", Value: "no-store"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"protected response headers are forbidden": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					ResponseHeaders: &gatewayv1.HTTPHeaderFilter{
+						Set: []gatewayv1.HTTPHeader{
+							{Name: "Server", Value: "custom"},
+						},
+						Remove: []string{"x-envoy-upstream-service-time"},
+					},
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://api.example.com",
+								},
+							},
+							ResponseHeaders: &gatewayv1.HTTPHeaderFilter{
+								Add: []gatewayv1.HTTPHeader{
+									{Name: "Content-Length", Value: "0"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("responseHeaders", "add").Index(0).Child("name"), ""),
+				field.Forbidden(field.NewPath("spec", "responseHeaders", "set").Index(0).Child("name"), ""),
+				field.Forbidden(field.NewPath("spec", "responseHeaders", "remove").Index(0), ""),
+			},
+		},
+		"empty response headers policy invalid": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					ResponseHeaders: &gatewayv1.HTTPHeaderFilter{},
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://api.example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Required(field.NewPath("spec", "responseHeaders"), ""),
+			},
+		},
+		"header modifier filters valid": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Filters: []gatewayv1.HTTPRouteFilter{
+								{
+									Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+									RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+										Set:    []gatewayv1.HTTPHeader{{Name: "Host", Value: "api.example.com"}},
+										Add:    []gatewayv1.HTTPHeader{{Name: "X-Tenant", Value: "a"}},
+										Remove: []string{"Cookie"},
+									},
+								},
+								{
+									Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+									ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+										Set: []gatewayv1.HTTPHeader{{Name: "Cache-Control", Value: "no-store"}},
+									},
+								},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://api.example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"invalid header modifier filters": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Filters: []gatewayv1.HTTPRouteFilter{
+								{
+									Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+									RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+										Set:    []gatewayv1.HTTPHeader{{Name: "X Tenant", Value: "a"}},
+										Remove: []string{"host"},
+									},
+								},
+								{
+									Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+									ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+										Add: []gatewayv1.HTTPHeader{{Name: "X-Envoy-Upstream-Service-Time", Value: "0"}},
+									},
+								},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://api.example.com",
+									Filters: []gatewayv1.HTTPRouteFilter{
+										{
+											Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+											RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+												Add: []gatewayv1.HTTPHeader{{Name: "Host", Value: "api.example.com"}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("filters").Index(0).Child("requestHeaderModifier", "set").Index(0).Child("name"), "X Tenant", ""),
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("filters").Index(0).Child("requestHeaderModifier", "remove").Index(0), ""),
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("filters").Index(1).Child("responseHeaderModifier", "add").Index(0).Child("name"), ""),
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("backends").Index(0).Child("filters").Index(0).Child("requestHeaderModifier", "add").Index(0).Child("name"), ""),
+			},
+		},
+		"protected headers in response header modifier filters are forbidden": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Filters: []gatewayv1.HTTPRouteFilter{
+								{
+									Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+									ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+										Set:    []gatewayv1.HTTPHeader{{Name: "Strict-Transport-Security", Value: "max-age=0"}},
+										Remove: []string{"X-Datum-Request-Region", "Cache-Control"},
+									},
+								},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://api.example.com",
+									Filters: []gatewayv1.HTTPRouteFilter{
+										{
+											Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+											ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+												Set: []gatewayv1.HTTPHeader{{Name: "server", Value: "custom"}},
+												Add: []gatewayv1.HTTPHeader{{Name: "X-Backend", Value: "a"}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("filters").Index(0).Child("responseHeaderModifier", "set").Index(0).Child("name"), ""),
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("filters").Index(0).Child("responseHeaderModifier", "remove").Index(0), ""),
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("backends").Index(0).Child("filters").Index(0).Child("responseHeaderModifier", "set").Index(0).Child("name"), ""),
+			},
+		},
+		"ring hash load balancer valid": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://api.example.com",
+									LoadBalancer: &networkingv1alpha.HTTPProxyBackendLoadBalancer{
+										Type: networkingv1alpha.HTTPProxyBackendLoadBalancerRingHash,
+										HashKey: &networkingv1alpha.HTTPProxyBackendHashKey{
+											Header: ptr.To(gatewayv1.HTTPHeaderName("x-user-id")),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"load balancer requires rule name": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Name: ptr.To(gatewayv1.SectionName("api")),
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://api.example.com",
+									LoadBalancer: &networkingv1alpha.HTTPProxyBackendLoadBalancer{
+										Type: networkingv1alpha.HTTPProxyBackendLoadBalancerLeastRequest,
+									},
+								},
+							},
+						},
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://www.example.com",
+									LoadBalancer: &networkingv1alpha.HTTPProxyBackendLoadBalancer{
+										Type: networkingv1alpha.HTTPProxyBackendLoadBalancerRoundRobin,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Required(field.NewPath("spec", "rules").Index(1).Child("name"), ""),
+			},
+		},
+		"backup backend valid": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{
+								Path:     "/healthz",
+								Interval: ptr.To(gatewayv1.Duration("5s")),
+								Timeout:  ptr.To(gatewayv1.Duration("1s")),
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://primary.example.com",
+								},
+								{
+									Endpoint: "https://backup.example.com",
+									Role:     networkingv1alpha.HTTPProxyBackendRoleBackup,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"invalid backup backends": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://primary.example.com",
+									Connector: &networkingv1alpha.ConnectorReference{
+										Name: "connector-1",
+									},
+								},
+								{
+									Endpoint: "http://192.168.1.1",
+									Role:     networkingv1alpha.HTTPProxyBackendRoleBackup,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Required(field.NewPath("spec", "rules").Index(0).Child("healthCheck"), ""),
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("backends").Index(0).Child("connector"), ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("endpoint").Key("host"), "", ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("endpoint").Key("scheme"), "", ""),
+			},
+		},
+		"invalid health check": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{
+								Interval: ptr.To(gatewayv1.Duration("100ms")),
+								Timeout:  ptr.To(gatewayv1.Duration("2s")),
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://primary.example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("healthCheck", "interval"), "", ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("healthCheck", "timeout"), "", ""),
+			},
+		},
+		"backend health check valid": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://api.example.com",
+									HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{
+										Path:     "/healthz",
+										Interval: ptr.To(gatewayv1.Duration("10s")),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"invalid backend health checks": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Name: ptr.To(gatewayv1.SectionName("connector")),
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint:    "http://localhost:8080",
+									Connector:   &networkingv1alpha.ConnectorReference{Name: "connector-1"},
+									HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{Interval: ptr.To(gatewayv1.Duration("100ms"))},
+								},
+							},
+						},
+						{
+							Name:        ptr.To(gatewayv1.SectionName("failover")),
+							HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint:    "https://primary.example.com",
+									HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{},
+								},
+								{
+									Endpoint: "https://backup.example.com",
+									Role:     networkingv1alpha.HTTPProxyBackendRoleBackup,
+								},
+							},
+						},
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint:    "https://www.example.com",
+									HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("backends").Index(0).Child("healthCheck"), ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(0).Child("healthCheck", "interval"), "", ""),
+				field.Forbidden(field.NewPath("spec", "rules").Index(1).Child("backends").Index(0).Child("healthCheck"), ""),
+				field.Required(field.NewPath("spec", "rules").Index(2).Child("name"), ""),
+			},
+		},
+		"weighted backends valid": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://blue.example.com",
+									Weight:   ptr.To[int32](90),
+								},
+								{
+									Endpoint: "https://green.example.com",
+									Weight:   ptr.To[int32](10),
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"canary valid": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Name: ptr.To(gatewayv1.SectionName("api")),
+							TrafficPolicy: &networkingv1alpha.HTTPProxyTrafficPolicy{
+								Canary: &networkingv1alpha.HTTPProxyCanary{
+									Percentage: 5,
+									Headers: []gatewayv1.HTTPHeaderMatch{
+										{Name: "x-canary", Value: "always"},
+									},
+								},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://stable.example.com",
+								},
+								{
+									Endpoint: "https://canary.example.com",
+									Role:     networkingv1alpha.HTTPProxyBackendRoleCanary,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"invalid weighted backends": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://blue.example.com",
+									Weight:   ptr.To[int32](1),
+								},
+								{
+									Endpoint: "http://192.168.1.1",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Required(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("weight"), ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("endpoint").Key("host"), "", ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("endpoint").Key("scheme"), "", ""),
+			},
+		},
+		"invalid canary": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Name: ptr.To(gatewayv1.SectionName("api")),
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://stable.example.com",
+									Weight:   ptr.To[int32](2),
+								},
+								{
+									Endpoint: "https://canary.example.com",
+									Role:     networkingv1alpha.HTTPProxyBackendRoleCanary,
+								},
+							},
+						},
+						{
+							Name: ptr.To(gatewayv1.SectionName("www")),
+							TrafficPolicy: &networkingv1alpha.HTTPProxyTrafficPolicy{
+								Canary: &networkingv1alpha.HTTPProxyCanary{
+									Percentage: 10,
+									Headers: []gatewayv1.HTTPHeaderMatch{
+										{Name: "x-canary", Value: "always"},
+									},
+								},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://www.example.com",
+								},
+							},
+						},
+						{
+							Name: ptr.To(gatewayv1.SectionName("www-canary")),
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://beta.example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "rules").Index(1).Child("name"), "", ""),
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("backends").Index(0).Child("weight"), ""),
+				field.Required(field.NewPath("spec", "rules").Index(0).Child("trafficPolicy", "canary"), ""),
+				field.Required(field.NewPath("spec", "rules").Index(1).Child("backends"), ""),
+			},
+		},
+		"blue/green valid": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							TrafficPolicy: &networkingv1alpha.HTTPProxyTrafficPolicy{
+								BlueGreen: &networkingv1alpha.HTTPProxyBlueGreen{
+									Active:       networkingv1alpha.HTTPProxyBackendRoleGreen,
+									DrainTimeout: ptr.To(gatewayv1.Duration("2m")),
+								},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://blue.example.com",
+									Role:     networkingv1alpha.HTTPProxyBackendRoleBlue,
+								},
+								{
+									Endpoint: "https://green-a.example.com",
+									Role:     networkingv1alpha.HTTPProxyBackendRoleGreen,
+									Weight:   ptr.To[int32](3),
+								},
+								{
+									Endpoint: "https://green-b.example.com",
+									Role:     networkingv1alpha.HTTPProxyBackendRoleGreen,
+									Weight:   ptr.To[int32](1),
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"invalid blue/green": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							TrafficPolicy: &networkingv1alpha.HTTPProxyTrafficPolicy{
+								BlueGreen: &networkingv1alpha.HTTPProxyBlueGreen{
+									Active:       networkingv1alpha.HTTPProxyBackendRoleBlue,
+									DrainTimeout: ptr.To(gatewayv1.Duration("0s")),
+								},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://blue.example.com",
+									Role:     networkingv1alpha.HTTPProxyBackendRoleBlue,
+								},
+								{
+									Endpoint: "https://stable.example.com",
+								},
+							},
+						},
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://green.example.com",
+									Role:     networkingv1alpha.HTTPProxyBackendRoleGreen,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Required(field.NewPath("spec", "rules").Index(0).Child("backends"), ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("role"), "", ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("trafficPolicy", "blueGreen", "drainTimeout"), "", ""),
+				field.Required(field.NewPath("spec", "rules").Index(1).Child("trafficPolicy", "blueGreen"), ""),
+			},
+		},
+		"IP address hostname invalid": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Hostnames: []gatewayv1.Hostname{"www.example.com", "192.0.2.10"},
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://api.example.com"}},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "hostnames").Index(1), "", ""),
+			},
+		},
+		"endpoint port out of range": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{Endpoint: "https://api.example.com:8443"},
+								{Endpoint: "https://api.example.com:70000"},
+								{Endpoint: "http://api.example.com:0"},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("endpoint").Key("port"), "", ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(2).Child("endpoint").Key("port"), "", ""),
+			},
+		},
+		"invalid match regular expressions": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Matches: []gatewayv1.HTTPRouteMatch{
+								{
+									Path: &gatewayv1.HTTPPathMatch{
+										Type:  ptr.To(gatewayv1.PathMatchRegularExpression),
+										Value: ptr.To("/api/(v1|v2"),
+									},
+									Headers: []gatewayv1.HTTPHeaderMatch{
+										{Type: ptr.To(gatewayv1.HeaderMatchRegularExpression), Name: "x-version", Value: "^v[0-9]+$"},
+										{Type: ptr.To(gatewayv1.HeaderMatchRegularExpression), Name: "x-tenant", Value: "*tenant"},
+									},
+									QueryParams: []gatewayv1.HTTPQueryParamMatch{
+										{Type: ptr.To(gatewayv1.QueryParamMatchRegularExpression), Name: "q", Value: "a{2,1}"},
+									},
+								},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://api.example.com"}},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("matches").Index(0).Child("path", "value"), "", ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("matches").Index(0).Child("headers").Index(1).Child("value"), "", ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("matches").Index(0).Child("queryParams").Index(0).Child("value"), "", ""),
+			},
+		},
+		"match repeated in a later rule": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Name: ptr.To[gatewayv1.SectionName]("api"),
+							Matches: []gatewayv1.HTTPRouteMatch{
+								{Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/api")}},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://api.example.com"}},
+						},
+						{
+							Name: ptr.To[gatewayv1.SectionName]("api-v2"),
+							Matches: []gatewayv1.HTTPRouteMatch{
+								{Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/api/v2")}},
+								{Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/api")}},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://api-v2.example.com"}},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Duplicate(field.NewPath("spec", "rules").Index(1).Child("matches").Index(1), ""),
+			},
+		},
+		"request mirror": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Mirror: &networkingv1alpha.HTTPProxyRequestMirror{
+								Endpoint: "https://shadow.example.com",
+								Percent:  ptr.To[int32](10),
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://api.example.com"}},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"invalid request mirror": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Name: ptr.To[gatewayv1.SectionName]("no-backends"),
+							Matches: []gatewayv1.HTTPRouteMatch{
+								{Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/a")}},
+							},
+							Filters: []gatewayv1.HTTPRouteFilter{
+								{
+									Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+									RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+										Hostname: ptr.To[gatewayv1.PreciseHostname]("www.example.com"),
+									},
+								},
+							},
+							Mirror: &networkingv1alpha.HTTPProxyRequestMirror{Endpoint: "http://shadow.example.com/path"},
+						},
+						{
+							Name: ptr.To[gatewayv1.SectionName]("https-ip"),
+							Matches: []gatewayv1.HTTPRouteMatch{
+								{Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/b")}},
+							},
+							Mirror:   &networkingv1alpha.HTTPProxyRequestMirror{Endpoint: "https://203.0.113.10"},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://api.example.com"}},
+						},
+						{
+							Name: ptr.To[gatewayv1.SectionName]("mirror-filter"),
+							Matches: []gatewayv1.HTTPRouteMatch{
+								{Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/c")}},
+							},
+							Filters: []gatewayv1.HTTPRouteFilter{
+								{
+									Type: gatewayv1.HTTPRouteFilterRequestMirror,
+									RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+										BackendRef: gatewayv1.BackendObjectReference{Name: "shadow"},
+									},
+								},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://api.example.com"}},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("mirror"), ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("mirror", "endpoint").Key("path"), "", ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(1).Child("mirror", "endpoint").Key("host"), "", ""),
+				field.NotSupported(field.NewPath("spec", "rules").Index(2).Child("filters").Index(0).Child("type"), "RequestMirror", []string{}),
+			},
+		},
+		"backend tls": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "https://203.0.113.10",
+									TLS: &networkingv1alpha.HTTPProxyBackendTLS{
+										Hostname:         ptr.To("api.internal"),
+										CACertificateRef: &networkingv1alpha.HTTPProxyCACertificateReference{Kind: "Secret", Name: "internal-ca"},
+										SubjectAltNames: []gatewayv1.SubjectAltName{
+											{Type: gatewayv1.HostnameSubjectAltNameType, Hostname: "*.api.internal"},
+											{Type: gatewayv1.URISubjectAltNameType, URI: "spiffe://internal/ns/api/sa/api"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"invalid backend tls": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{
+									Endpoint: "http://api.example.com",
+									TLS: &networkingv1alpha.HTTPProxyBackendTLS{
+										CACertificateRef: &networkingv1alpha.HTTPProxyCACertificateReference{Name: "internal-ca"},
+									},
+								},
+								{
+									Endpoint: "https://api.example.com",
+									TLS: &networkingv1alpha.HTTPProxyBackendTLS{
+										CACertificateRef: &networkingv1alpha.HTTPProxyCACertificateReference{Kind: "Service", Name: "Internal_CA"},
+										SubjectAltNames: []gatewayv1.SubjectAltName{
+											{Type: gatewayv1.HostnameSubjectAltNameType},
+											{Type: gatewayv1.URISubjectAltNameType, URI: "api"},
+											{Type: gatewayv1.HostnameSubjectAltNameType, Hostname: "api.example.com"},
+											{Type: gatewayv1.HostnameSubjectAltNameType, Hostname: "api.example.com"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "rules").Index(0).Child("backends").Index(0).Child("tls", "caCertificateRef"), ""),
+				field.NotSupported(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("tls", "caCertificateRef", "kind"), "Service", []string{}),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("tls", "caCertificateRef", "name"), "", ""),
+				field.Required(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("tls", "subjectAltNames").Index(0).Child("hostname"), ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("tls", "subjectAltNames").Index(1).Child("uri"), "", ""),
+				field.Duplicate(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("tls", "subjectAltNames").Index(3), ""),
+			},
+		},
+		"h2c backend": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+								{Endpoint: "http://grpc.example.com:9000", Protocol: networkingv1alpha.HTTPProxyBackendProtocolH2C},
+								{Endpoint: "https://grpc.example.com", Protocol: networkingv1alpha.HTTPProxyBackendProtocolH2C},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("protocol"), "", ""),
+			},
+		},
+		"cors": {
+			proxy: &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{
+						{
+							Name: ptr.To[gatewayv1.SectionName]("credentials"),
+							Matches: []gatewayv1.HTTPRouteMatch{
+								{Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/a")}},
+							},
+							Filters: []gatewayv1.HTTPRouteFilter{
+								{
+									Type: gatewayv1.HTTPRouteFilterCORS,
+									CORS: &gatewayv1.HTTPCORSFilter{
+										AllowOrigins:     []gatewayv1.CORSOrigin{"https://app.example.com"},
+										AllowCredentials: ptr.To(true),
+									},
+								},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://api.example.com"}},
+						},
+						{
+							Name: ptr.To[gatewayv1.SectionName]("wildcard-credentials"),
+							Matches: []gatewayv1.HTTPRouteMatch{
+								{Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/b")}},
+							},
+							Filters: []gatewayv1.HTTPRouteFilter{
+								{
+									Type: gatewayv1.HTTPRouteFilterCORS,
+									CORS: &gatewayv1.HTTPCORSFilter{
+										AllowOrigins:     []gatewayv1.CORSOrigin{"*"},
+										AllowCredentials: ptr.To(true),
+									},
+								},
+								{Type: gatewayv1.HTTPRouteFilterCORS},
+							},
+							Backends: []networkingv1alpha.HTTPProxyRuleBackend{{Endpoint: "https://api.example.com"}},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "rules").Index(1).Child("filters").Index(0).Child("cors", "allowOrigins"), "", ""),
+				field.Required(field.NewPath("spec", "rules").Index(1).Child("filters").Index(1).Child("cors"), ""),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			if scenario.proxy.Name == "" {
+				scenario.proxy.Name = "test"
+			}
+			errs := ValidateHTTPProxy(scenario.proxy)
+			delta := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail"))
+			if delta != "" {
+				t.Errorf("Testcase %s - expected errors '%v', got '%v', diff: '%v'", name, scenario.expectedErrors, errs, delta)
+			}
+		})
+	}
+}
+
+func TestValidateHTTPProxyBackendIPFamilies(t *testing.T) {
+	proxy := &networkingv1alpha.HTTPProxy{
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Rules: []networkingv1alpha.HTTPProxyRule{
+				{
+					Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+						{Endpoint: "http://192.0.2.1:8080"},
+						{Endpoint: "http://[2001:db8::1]:8080"},
+						{Endpoint: "https://api.example.com"},
+					},
+					Mirror: &networkingv1alpha.HTTPProxyRequestMirror{Endpoint: "http://192.0.2.2"},
+				},
+			},
+		},
+	}
+
+	scenarios := map[string]struct {
+		ipFamilies     []networkingv1alpha.IPFamily
+		expectedErrors field.ErrorList
+	}{
+		"dual stack": {
+			ipFamilies:     []networkingv1alpha.IPFamily{networkingv1alpha.IPv4Protocol, networkingv1alpha.IPv6Protocol},
+			expectedErrors: field.ErrorList{},
+		},
+		"ipv6 only": {
+			ipFamilies: []networkingv1alpha.IPFamily{networkingv1alpha.IPv6Protocol},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(0).Child("endpoint"), "", ""),
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("mirror", "endpoint"), "", ""),
+			},
+		},
+		"ipv4 only": {
+			ipFamilies: []networkingv1alpha.IPFamily{networkingv1alpha.IPv4Protocol},
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "rules").Index(0).Child("backends").Index(1).Child("endpoint"), "", ""),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			errs := ValidateHTTPProxyBackendIPFamilies(proxy, scenario.ipFamilies)
+			if diff := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateHTTPProxyRequestPolicies(t *testing.T) {
+	requestPolicy := config.HTTPProxyRequestPolicyConfig{
+		MaxRequestTimeout: metav1.Duration{Duration: time.Minute},
+		MaxRetryAttempts:  3,
+		MaxRetryBackoff:   metav1.Duration{Duration: time.Second},
+	}
+	rulePath := field.NewPath("spec", "rules").Index(0)
+
+	scenarios := map[string]struct {
+		rule           networkingv1alpha.HTTPProxyRule
+		expectedErrors field.ErrorList
+	}{
+		"within limits": {
+			rule: networkingv1alpha.HTTPProxyRule{
+				Timeouts: &gatewayv1.HTTPRouteTimeouts{
+					Request:        ptr.To(gatewayv1.Duration("1m")),
+					BackendRequest: ptr.To(gatewayv1.Duration("10s")),
+				},
+				Retry: &gatewayv1.HTTPRouteRetry{
+					Codes:    []gatewayv1.HTTPRouteRetryStatusCode{502, 503},
+					Attempts: ptr.To(3),
+					Backoff:  ptr.To(gatewayv1.Duration("100ms")),
+				},
+			},
+			expectedErrors: field.ErrorList{},
+		},
+		"timeouts above limit": {
+			rule: networkingv1alpha.HTTPProxyRule{
+				Timeouts: &gatewayv1.HTTPRouteTimeouts{
+					Request:        ptr.To(gatewayv1.Duration("2m")),
+					BackendRequest: ptr.To(gatewayv1.Duration("1h")),
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(rulePath.Child("timeouts", "request"), "", ""),
+				field.Invalid(rulePath.Child("timeouts", "backendRequest"), "", ""),
+			},
+		},
+		"disabled timeout": {
+			rule: networkingv1alpha.HTTPProxyRule{
+				Timeouts: &gatewayv1.HTTPRouteTimeouts{Request: ptr.To(gatewayv1.Duration("0s"))},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(rulePath.Child("timeouts", "request"), "", ""),
+			},
+		},
+		"retry above limits": {
+			rule: networkingv1alpha.HTTPProxyRule{
+				Retry: &gatewayv1.HTTPRouteRetry{
+					Attempts: ptr.To(4),
+					Backoff:  ptr.To(gatewayv1.Duration("5s")),
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(rulePath.Child("retry", "attempts"), "", ""),
+				field.Invalid(rulePath.Child("retry", "backoff"), "", ""),
+			},
+		},
+		"duplicate retry codes": {
+			rule: networkingv1alpha.HTTPProxyRule{
+				Retry: &gatewayv1.HTTPRouteRetry{Codes: []gatewayv1.HTTPRouteRetryStatusCode{503, 503}},
+			},
+			expectedErrors: field.ErrorList{
+				field.Duplicate(rulePath.Child("retry", "codes").Index(1), ""),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			proxy := &networkingv1alpha.HTTPProxy{
+				Spec: networkingv1alpha.HTTPProxySpec{Rules: []networkingv1alpha.HTTPProxyRule{scenario.rule}},
+			}
+			errs := ValidateHTTPProxyRequestPolicies(proxy, requestPolicy)
+			if diff := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHTTPProxyWarnings(t *testing.T) {
+	proxy := &networkingv1alpha.HTTPProxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{gatewayutil.DNSRecordTTLAnnotation: "30"},
+		},
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Hostnames: []gatewayv1.Hostname{"*.example.com", "www.example.com", "example.com", "www.example.org"},
+			Rules: []networkingv1alpha.HTTPProxyRule{
+				{HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{Interval: ptr.To(gatewayv1.Duration("10s"))}},
+				{HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{Interval: ptr.To(gatewayv1.Duration("2s"))}},
+				{Backends: []networkingv1alpha.HTTPProxyRuleBackend{
+					{HealthCheck: &networkingv1alpha.HTTPProxyHealthCheck{Interval: ptr.To(gatewayv1.Duration("1s"))}},
+				}},
+			},
+		},
+	}
+
+	warnings := HTTPProxyWarnings(proxy)
+	expected := []networkingv1alpha.Warning{
+		{Code: networkingv1alpha.WarningCodeHostnameCoveredByWildcard, Field: "spec.hostnames[1]"},
+		{Code: networkingv1alpha.WarningCodeDNSRecordTTLShort, Field: "metadata.annotations[networking.datumapis.com/dns-record-ttl]"},
+		{Code: networkingv1alpha.WarningCodeHealthCheckIntervalShort, Field: "spec.rules[1].healthCheck.interval"},
+		{Code: networkingv1alpha.WarningCodeHealthCheckIntervalShort, Field: "spec.rules[2].backends[0].healthCheck.interval"},
+	}
+	if diff := cmp.Diff(expected, warnings, cmpopts.IgnoreFields(networkingv1alpha.Warning{}, "Message")); diff != "" {
+		t.Errorf("unexpected warnings (-want +got):\n%s", diff)
+	}
+
+	// TTLs at or above the recommended minimum are not reported.
+	proxy.Annotations[gatewayutil.DNSRecordTTLAnnotation] = "300"
+	for _, warning := range HTTPProxyWarnings(proxy) {
+		if warning.Code == networkingv1alpha.WarningCodeDNSRecordTTLShort {
+			t.Errorf("unexpected warning: %v", warning)
+		}
+	}
+
+	messages := WarningMessages(warnings)
+	if len(messages) != 4 || !strings.HasPrefix(messages[0], "spec.hostnames[1]: ") || !strings.HasSuffix(messages[0], "(HostnameCoveredByWildcard)") {
+		t.Errorf("unexpected warning messages: %v", messages)
 	}
 }
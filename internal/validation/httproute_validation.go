@@ -83,6 +83,11 @@ func validateHTTPRouteRule(route *gatewayv1.HTTPRoute, rule gatewayv1.HTTPRouteR
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateFilters(rule.Filters, supportedHTTPRouteRuleFilters, fldPath.Child("filters"))...)
+	for i, filter := range rule.Filters {
+		if filter.RequestMirror != nil {
+			allErrs = append(allErrs, validateBackendObjectReference(route, filter.RequestMirror.BackendRef, fldPath.Child("filters").Index(i).Child("requestMirror", "backendRef"), opts)...)
+		}
+	}
 	allErrs = append(allErrs, validateHTTPRouteRuleBackendRefs(route, rule, fldPath.Child("backendRefs"), opts)...)
 
 	return allErrs
@@ -93,6 +98,7 @@ var supportedHTTPRouteRuleFilters = sets.New(
 	gatewayv1.HTTPRouteFilterResponseHeaderModifier,
 	gatewayv1.HTTPRouteFilterRequestRedirect,
 	gatewayv1.HTTPRouteFilterURLRewrite,
+	gatewayv1.HTTPRouteFilterRequestMirror,
 	gatewayv1.HTTPRouteFilterCORS,
 	gatewayv1.HTTPRouteFilterExtensionRef,
 )
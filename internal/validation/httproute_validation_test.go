@@ -301,7 +301,7 @@ func TestValidateHTTPRoute(t *testing.T) {
 							},
 							Filters: []gatewayv1.HTTPRouteFilter{
 								{
-									Type: gatewayv1.HTTPRouteFilterRequestMirror,
+									Type: gatewayv1.HTTPRouteFilterExternalAuth,
 								},
 							},
 						},
@@ -309,10 +309,35 @@ func TestValidateHTTPRoute(t *testing.T) {
 				},
 			},
 			expectedErrors: field.ErrorList{
-				field.NotSupported(field.NewPath("spec", "rules").Index(0).Child("filters").Index(0).Child("type"), "RequestMirror", []string{}),
+				field.NotSupported(field.NewPath("spec", "rules").Index(0).Child("filters").Index(0).Child("type"), "ExternalAuth", []string{}),
 				field.NotSupported(field.NewPath("spec", "rules").Index(0).Child("backendRefs").Index(0).Child("filters").Index(0).Child("type"), "RequestMirror", []string{}),
 			},
 		},
+		"request mirror backend must be supported": {
+			route: &gatewayv1.HTTPRoute{
+				Spec: gatewayv1.HTTPRouteSpec{
+					Rules: []gatewayv1.HTTPRouteRule{
+						{
+							Filters: []gatewayv1.HTTPRouteFilter{
+								{
+									Type: gatewayv1.HTTPRouteFilterRequestMirror,
+									RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+										BackendRef: gatewayv1.BackendObjectReference{
+											Group: ptr.To(gatewayv1.Group("discovery.k8s.io")),
+											Kind:  ptr.To(gatewayv1.Kind("EndpointSlice")),
+											Name:  "test-mirror",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Required(field.NewPath("spec", "rules").Index(0).Child("filters").Index(0).Child("requestMirror", "backendRef", "port"), ""),
+			},
+		},
 		"service backend requires opt-in": {
 			route: &gatewayv1.HTTPRoute{
 				Spec: gatewayv1.HTTPRouteSpec{
@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"net/netip"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+var supportedNetworkPolicyProtocols = []string{
+	string(corev1.ProtocolTCP),
+	string(corev1.ProtocolUDP),
+	string(corev1.ProtocolSCTP),
+}
+
+// ValidateNetworkPolicyIngressRule validates an ingress rule of a
+// NetworkPolicy beyond the constraints of its schema.
+func ValidateNetworkPolicyIngressRule(rule networkingv1alpha.NetworkPolicyIngressRule, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, port := range rule.Ports {
+		allErrs = append(allErrs, validateNetworkPolicyPort(port, fldPath.Child("ports").Index(i))...)
+	}
+
+	for i, peer := range rule.From {
+		peerPath := fldPath.Child("from").Index(i)
+		if peer.IPBlock == nil {
+			allErrs = append(allErrs, field.Required(peerPath.Child("ipBlock"), "must specify a peer"))
+			continue
+		}
+		allErrs = append(allErrs, validateIPBlock(*peer.IPBlock, peerPath.Child("ipBlock"))...)
+	}
+
+	return allErrs
+}
+
+func validateNetworkPolicyPort(port networkingv1alpha.NetworkPolicyPort, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if port.Protocol != nil {
+		protocol := string(*port.Protocol)
+		if !slices.Contains(supportedNetworkPolicyProtocols, protocol) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("protocol"), protocol, supportedNetworkPolicyProtocols))
+		}
+	}
+
+	if port.Port != nil {
+		if port.Port.Type == intstr.Int {
+			for _, msg := range validation.IsValidPortNum(port.Port.IntValue()) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), port.Port.IntValue(), msg))
+			}
+		} else {
+			for _, msg := range validation.IsValidPortName(port.Port.StrVal) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), port.Port.StrVal, msg))
+			}
+		}
+	}
+
+	if port.EndPort != nil {
+		endPortPath := fldPath.Child("endPort")
+		switch {
+		case port.Port == nil:
+			allErrs = append(allErrs, field.Required(fldPath.Child("port"), "must be specified when endPort is specified"))
+		case port.Port.Type == intstr.String:
+			allErrs = append(allErrs, field.Invalid(endPortPath, *port.EndPort, "may not be specified when port is a named port"))
+		case *port.EndPort < port.Port.IntVal:
+			allErrs = append(allErrs, field.Invalid(endPortPath, *port.EndPort, "must be greater than or equal to port"))
+		}
+		for _, msg := range validation.IsValidPortNum(int(*port.EndPort)) {
+			allErrs = append(allErrs, field.Invalid(endPortPath, *port.EndPort, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func validateIPBlock(ipBlock networkingv1alpha.IPBlock, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	cidr, err := netip.ParsePrefix(ipBlock.CIDR)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath.Child("cidr"), ipBlock.CIDR, "must be a valid CIDR"))
+	}
+	cidr = cidr.Masked()
+
+	for i, except := range ipBlock.Except {
+		exceptPath := fldPath.Child("except").Index(i)
+		exceptCIDR, err := netip.ParsePrefix(except)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(exceptPath, except, "must be a valid CIDR"))
+			continue
+		}
+		if exceptCIDR.Addr().BitLen() != cidr.Addr().BitLen() || exceptCIDR.Bits() <= cidr.Bits() || !cidr.Contains(exceptCIDR.Addr()) {
+			allErrs = append(allErrs, field.Invalid(exceptPath, except, "must be a strict subset of cidr"))
+		}
+	}
+
+	return allErrs
+}
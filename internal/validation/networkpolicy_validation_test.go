@@ -0,0 +1,121 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestValidateNetworkPolicyIngressRule(t *testing.T) {
+	rulePath := field.NewPath("spec", "ingress").Index(0)
+
+	ipBlock := func(cidr string, except ...string) networkingv1alpha.NetworkPolicyPeer {
+		return networkingv1alpha.NetworkPolicyPeer{IPBlock: &networkingv1alpha.IPBlock{CIDR: cidr, Except: except}}
+	}
+
+	scenarios := map[string]struct {
+		rule           networkingv1alpha.NetworkPolicyIngressRule
+		expectedErrors field.ErrorList
+	}{
+		"ports and peers": {
+			rule: networkingv1alpha.NetworkPolicyIngressRule{
+				Ports: []networkingv1alpha.NetworkPolicyPort{
+					{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(8000)), EndPort: ptr.To[int32](8080)},
+					{Protocol: ptr.To(corev1.ProtocolUDP), Port: ptr.To(intstr.FromString("dns"))},
+				},
+				From: []networkingv1alpha.NetworkPolicyPeer{
+					ipBlock("10.0.0.0/8", "10.1.0.0/16"),
+					ipBlock("2001:db8::/32"),
+				},
+			},
+		},
+		"empty rule": {},
+		"unsupported protocol": {
+			rule: networkingv1alpha.NetworkPolicyIngressRule{
+				Ports: []networkingv1alpha.NetworkPolicyPort{{Protocol: ptr.To(corev1.Protocol("ICMP"))}},
+			},
+			expectedErrors: field.ErrorList{
+				field.NotSupported[string](rulePath.Child("ports").Index(0).Child("protocol"), "", nil),
+			},
+		},
+		"invalid port": {
+			rule: networkingv1alpha.NetworkPolicyIngressRule{
+				Ports: []networkingv1alpha.NetworkPolicyPort{
+					{Port: ptr.To(intstr.FromInt32(70000))},
+					{Port: ptr.To(intstr.FromString("not_a_port"))},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(rulePath.Child("ports").Index(0).Child("port"), "", ""),
+				field.Invalid(rulePath.Child("ports").Index(1).Child("port"), "", ""),
+			},
+		},
+		"end port without port": {
+			rule: networkingv1alpha.NetworkPolicyIngressRule{
+				Ports: []networkingv1alpha.NetworkPolicyPort{{EndPort: ptr.To[int32](8080)}},
+			},
+			expectedErrors: field.ErrorList{
+				field.Required(rulePath.Child("ports").Index(0).Child("port"), ""),
+			},
+		},
+		"end port with named port": {
+			rule: networkingv1alpha.NetworkPolicyIngressRule{
+				Ports: []networkingv1alpha.NetworkPolicyPort{{Port: ptr.To(intstr.FromString("http")), EndPort: ptr.To[int32](8080)}},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(rulePath.Child("ports").Index(0).Child("endPort"), "", ""),
+			},
+		},
+		"end port before port": {
+			rule: networkingv1alpha.NetworkPolicyIngressRule{
+				Ports: []networkingv1alpha.NetworkPolicyPort{{Port: ptr.To(intstr.FromInt32(8080)), EndPort: ptr.To[int32](8000)}},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(rulePath.Child("ports").Index(0).Child("endPort"), "", ""),
+			},
+		},
+		"missing peer": {
+			rule: networkingv1alpha.NetworkPolicyIngressRule{
+				From: []networkingv1alpha.NetworkPolicyPeer{{}},
+			},
+			expectedErrors: field.ErrorList{
+				field.Required(rulePath.Child("from").Index(0).Child("ipBlock"), ""),
+			},
+		},
+		"invalid cidr": {
+			rule: networkingv1alpha.NetworkPolicyIngressRule{
+				From: []networkingv1alpha.NetworkPolicyPeer{ipBlock("10.0.0.0/33")},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(rulePath.Child("from").Index(0).Child("ipBlock", "cidr"), "", ""),
+			},
+		},
+		"except outside of cidr": {
+			rule: networkingv1alpha.NetworkPolicyIngressRule{
+				From: []networkingv1alpha.NetworkPolicyPeer{ipBlock("10.0.0.0/16", "10.1.0.0/24", "10.0.0.0/16", "fd00::/64", "invalid")},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(rulePath.Child("from").Index(0).Child("ipBlock", "except").Index(0), "", ""),
+				field.Invalid(rulePath.Child("from").Index(0).Child("ipBlock", "except").Index(1), "", ""),
+				field.Invalid(rulePath.Child("from").Index(0).Child("ipBlock", "except").Index(2), "", ""),
+				field.Invalid(rulePath.Child("from").Index(0).Child("ipBlock", "except").Index(3), "", ""),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			errs := ValidateNetworkPolicyIngressRule(scenario.rule, rulePath)
+			if diff := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
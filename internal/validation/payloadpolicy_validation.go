@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+// minCompressionMinSize is the smallest response size a PayloadPolicy may
+// compress. Smaller responses grow once the headers of the encoding are added.
+const minCompressionMinSize = 30
+
+// ValidatePayloadPolicy validates a PayloadPolicy beyond the constraints of its
+// schema, including the request body limit configured for the operator.
+func ValidatePayloadPolicy(policy *networkingv1alpha.PayloadPolicy, payloadPolicy config.GatewayPayloadPolicyConfig) field.ErrorList {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	if compression := policy.Spec.Compression; compression != nil {
+		compressionPath := specPath.Child("compression")
+		compressors := sets.New[networkingv1alpha.PayloadCompressor]()
+		for i, compressor := range compression.Compressors {
+			if compressors.Has(compressor) {
+				allErrs = append(allErrs, field.Duplicate(compressionPath.Child("compressors").Index(i), compressor))
+			}
+			compressors.Insert(compressor)
+		}
+		if minSize := compression.MinSize; minSize != nil && minSize.Value() < minCompressionMinSize {
+			allErrs = append(allErrs, field.Invalid(compressionPath.Child("minSize"), minSize.String(), fmt.Sprintf("must be at least %d", minCompressionMinSize)))
+		}
+	}
+
+	if limit := policy.Spec.RequestBodyLimit; limit != nil {
+		maxLimit := payloadPolicy.MaxRequestBodyLimit
+		if limit.Sign() <= 0 || (!maxLimit.IsZero() && limit.Cmp(maxLimit) > 0) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("requestBodyLimit"), limit.String(), fmt.Sprintf("must be greater than 0 and at most %s", maxLimit.String())))
+		}
+	}
+
+	return allErrs
+}
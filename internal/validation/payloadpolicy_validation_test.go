@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	"go.datum.net/network-services-operator/internal/config"
+)
+
+func TestValidatePayloadPolicy(t *testing.T) {
+	payloadPolicy := config.GatewayPayloadPolicyConfig{MaxRequestBodyLimit: resource.MustParse("10Mi")}
+	specPath := field.NewPath("spec")
+
+	scenarios := map[string]struct {
+		spec           networkingv1alpha.PayloadPolicySpec
+		expectedErrors field.ErrorList
+	}{
+		"valid": {
+			spec: networkingv1alpha.PayloadPolicySpec{
+				Compression: &networkingv1alpha.PayloadCompression{
+					Compressors: []networkingv1alpha.PayloadCompressor{networkingv1alpha.PayloadCompressorZstd, networkingv1alpha.PayloadCompressorGzip},
+					MinSize:     ptr.To(resource.MustParse("1Ki")),
+				},
+				RequestBodyLimit: ptr.To(resource.MustParse("10Mi")),
+			},
+		},
+		"duplicate compressor": {
+			spec: networkingv1alpha.PayloadPolicySpec{
+				Compression: &networkingv1alpha.PayloadCompression{
+					Compressors: []networkingv1alpha.PayloadCompressor{networkingv1alpha.PayloadCompressorGzip, networkingv1alpha.PayloadCompressorGzip},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Duplicate(specPath.Child("compression", "compressors").Index(1), nil),
+			},
+		},
+		"small compression min size": {
+			spec: networkingv1alpha.PayloadPolicySpec{
+				Compression: &networkingv1alpha.PayloadCompression{MinSize: ptr.To(resource.MustParse("10"))},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(specPath.Child("compression", "minSize"), nil, ""),
+			},
+		},
+		"zero request body limit": {
+			spec: networkingv1alpha.PayloadPolicySpec{RequestBodyLimit: ptr.To(resource.MustParse("0"))},
+			expectedErrors: field.ErrorList{
+				field.Invalid(specPath.Child("requestBodyLimit"), nil, ""),
+			},
+		},
+		"request body limit above the platform limit": {
+			spec: networkingv1alpha.PayloadPolicySpec{RequestBodyLimit: ptr.To(resource.MustParse("1Gi"))},
+			expectedErrors: field.ErrorList{
+				field.Invalid(specPath.Child("requestBodyLimit"), nil, ""),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			policy := &networkingv1alpha.PayloadPolicy{Spec: scenario.spec}
+			errs := ValidatePayloadPolicy(policy, payloadPolicy)
+			if diff := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+const (
+	// maxCustomRulesLength is the maximum combined length of the directives of
+	// a CustomRules ruleset.
+	maxCustomRulesLength = 32 * 1024
+
+	// maxCustomRuleID is the highest rule ID custom rules may use. IDs from 1
+	// to 99,999 are reserved for local use by the ModSecurity rule ID
+	// namespace, so they never collide with the OWASP CRS or platform rules.
+	maxCustomRuleID = 99999
+)
+
+// customRuleDirectives are the SecLang directives allowed in custom rules,
+// mapped to whether the directive defines a rule that requires an ID.
+// Engine-wide settings, such as SecRuleEngine or Include, are left out.
+var customRuleDirectives = map[string]bool{
+	"secrule":                  true,
+	"secaction":                true,
+	"secmarker":                false,
+	"secruleremovebyid":        false,
+	"secruleremovebytag":       false,
+	"secruleremovebymsg":       false,
+	"secruleupdatetargetbyid":  false,
+	"secruleupdatetargetbytag": false,
+	"secruleupdateactionbyid":  false,
+}
+
+var (
+	customRuleIDPattern    = regexp.MustCompile(`(?:^|[",\s])id\s*:\s*'?(\d+)`)
+	customRuleChainPattern = regexp.MustCompile(`(?:^|[",\s])chain(?:$|[",\s])`)
+)
+
+// ValidateTrafficProtectionPolicy validates a TrafficProtectionPolicy beyond
+// the constraints of its schema.
+func ValidateTrafficProtectionPolicy(policy *networkingv1alpha.TrafficProtectionPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateTrafficProtectionPolicyTargetRefs(policy.Spec.TargetRefs, specPath.Child("targetRefs"))...)
+
+	// An empty mode or sampling percentage is defaulted by the schema.
+	switch policy.Spec.Mode {
+	case "",
+		networkingv1alpha.TrafficProtectionPolicyObserve,
+		networkingv1alpha.TrafficProtectionPolicyEnforce,
+		networkingv1alpha.TrafficProtectionPolicyDisabled:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("mode"), policy.Spec.Mode, []networkingv1alpha.TrafficProtectionPolicyMode{
+			networkingv1alpha.TrafficProtectionPolicyObserve,
+			networkingv1alpha.TrafficProtectionPolicyEnforce,
+			networkingv1alpha.TrafficProtectionPolicyDisabled,
+		}))
+	}
+	if policy.Spec.SamplingPercentage < 0 || policy.Spec.SamplingPercentage > 100 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("samplingPercentage"), policy.Spec.SamplingPercentage, "must be between 0 and 100"))
+	}
+
+	ruleSetsPath := specPath.Child("ruleSets")
+	for i, ruleSet := range policy.Spec.RuleSets {
+		ruleSetPath := ruleSetsPath.Index(i)
+		allErrs = append(allErrs, validateTrafficProtectionPolicyRuleSetType(ruleSet, ruleSetPath)...)
+		if ruleSet.Type == networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet {
+			allErrs = append(allErrs, validateOWASPCoreRuleSet(ruleSet.OWASPCoreRuleSet, ruleSetPath.Child("owaspCoreRuleSet"))...)
+		}
+		if ruleSet.Geo != nil {
+			allErrs = append(allErrs, validateGeoRuleSet(ruleSet.Geo, ruleSetPath.Child("geo"))...)
+		}
+		if ruleSet.CustomRules != nil {
+			allErrs = append(allErrs, validateCustomRuleSet(ruleSet.CustomRules, ruleSetPath.Child("customRules"))...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateTrafficProtectionPolicyRuleSetType mirrors the schema rules that
+// tie the configuration of a ruleset to its type.
+func validateTrafficProtectionPolicyRuleSetType(ruleSet networkingv1alpha.TrafficProtectionPolicyRuleSet, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch ruleSet.Type {
+	case networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet,
+		networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+		networkingv1alpha.TrafficProtectionPolicyCustomRuleSet:
+	default:
+		return append(allErrs, field.NotSupported(fldPath.Child("type"), ruleSet.Type, []networkingv1alpha.TrafficProtectionPolicyRuleSetType{
+			networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet,
+			networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+			networkingv1alpha.TrafficProtectionPolicyCustomRuleSet,
+		}))
+	}
+
+	isGeo := ruleSet.Type == networkingv1alpha.TrafficProtectionPolicyGeoRuleSet
+	switch {
+	case isGeo && ruleSet.Geo == nil:
+		allErrs = append(allErrs, field.Required(fldPath.Child("geo"), "geo must be specified if type is Geo"))
+	case !isGeo && ruleSet.Geo != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("geo"), "geo may only be specified if type is Geo"))
+	}
+
+	isCustomRules := ruleSet.Type == networkingv1alpha.TrafficProtectionPolicyCustomRuleSet
+	switch {
+	case isCustomRules && ruleSet.CustomRules == nil:
+		allErrs = append(allErrs, field.Required(fldPath.Child("customRules"), "customRules must be specified if type is CustomRules"))
+	case !isCustomRules && ruleSet.CustomRules != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("customRules"), "customRules may only be specified if type is CustomRules"))
+	}
+
+	return allErrs
+}
+
+func validateGeoRuleSet(geo *networkingv1alpha.GeoRuleSet, fldPath *field.Path) field.ErrorList {
+	if (len(geo.AllowCountries) > 0) == (len(geo.DenyCountries) > 0) {
+		return field.ErrorList{
+			field.Invalid(fldPath, geo, "exactly one of allowCountries or denyCountries must be specified"),
+		}
+	}
+	return nil
+}
+
+func validateTrafficProtectionPolicyTargetRefs(targetRefs []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	type target struct {
+		kind gatewayv1.Kind
+		name gatewayv1.ObjectName
+	}
+	// The section names targeted for each target, where an empty section name
+	// targets the whole target.
+	sectionNames := map[target]map[gatewayv1.SectionName]bool{}
+
+	for i, targetRef := range targetRefs {
+		targetRefPath := fldPath.Index(i)
+
+		if targetRef.Group != gatewayv1.GroupName {
+			allErrs = append(allErrs, field.NotSupported(targetRefPath.Child("group"), targetRef.Group, []string{gatewayv1.GroupName}))
+			continue
+		}
+		if targetRef.Kind != "Gateway" && targetRef.Kind != "HTTPRoute" {
+			allErrs = append(allErrs, field.NotSupported(targetRefPath.Child("kind"), targetRef.Kind, []string{"Gateway", "HTTPRoute"}))
+			continue
+		}
+
+		var sectionName gatewayv1.SectionName
+		if targetRef.SectionName != nil {
+			sectionName = *targetRef.SectionName
+		}
+
+		t := target{kind: targetRef.Kind, name: targetRef.Name}
+		targeted, ok := sectionNames[t]
+		if !ok {
+			targeted = map[gatewayv1.SectionName]bool{}
+			sectionNames[t] = targeted
+		}
+
+		switch {
+		case targeted[sectionName]:
+			allErrs = append(allErrs, field.Duplicate(targetRefPath, targetRef))
+		case sectionName == "" && len(targeted) > 0, sectionName != "" && targeted[""]:
+			allErrs = append(allErrs, field.Invalid(targetRefPath, targetRef,
+				fmt.Sprintf("%s %s cannot be targeted both as a whole and by section name", targetRef.Kind, targetRef.Name)))
+		}
+		targeted[sectionName] = true
+	}
+
+	return allErrs
+}
+
+func validateOWASPCoreRuleSet(owaspCRS networkingv1alpha.OWASPCRS, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	paranoiaLevelsPath := fldPath.Child("paranoiaLevels")
+	levels := owaspCRS.ParanoiaLevels
+	if levels.Blocking < 1 || levels.Blocking > 4 {
+		allErrs = append(allErrs, field.Invalid(paranoiaLevelsPath.Child("blocking"), levels.Blocking, "must be between 1 and 4"))
+	}
+	if levels.Detection < 1 || levels.Detection > 4 {
+		allErrs = append(allErrs, field.Invalid(paranoiaLevelsPath.Child("detection"), levels.Detection, "must be between 1 and 4"))
+	}
+	if levels.Detection < levels.Blocking {
+		// CRS rule 901500 denies every request with this configuration.
+		allErrs = append(allErrs, field.Invalid(paranoiaLevelsPath.Child("detection"), levels.Detection,
+			fmt.Sprintf("must be greater than or equal to the blocking paranoia level (%d)", levels.Blocking)))
+	}
+
+	scoreThresholdsPath := fldPath.Child("scoreThresholds")
+	thresholds := owaspCRS.ScoreThresholds
+	if thresholds.Inbound < 1 || thresholds.Inbound > 10000 {
+		allErrs = append(allErrs, field.Invalid(scoreThresholdsPath.Child("inbound"), thresholds.Inbound, "must be between 1 and 10000"))
+	}
+	if thresholds.Outbound < 1 || thresholds.Outbound > 10000 {
+		allErrs = append(allErrs, field.Invalid(scoreThresholdsPath.Child("outbound"), thresholds.Outbound, "must be between 1 and 10000"))
+	}
+
+	return allErrs
+}
+
+// ValidateTrafficProtectionPolicySectionName validates the section name of a
+// target of a TrafficProtectionPolicy against the listeners of a targeted
+// Gateway, or the rule names of a targeted HTTPRoute.
+func ValidateTrafficProtectionPolicySectionName(
+	targetRef gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName,
+	sectionNames []gatewayv1.SectionName,
+	fldPath *field.Path,
+) field.ErrorList {
+	if targetRef.SectionName == nil || slices.Contains(sectionNames, *targetRef.SectionName) {
+		return nil
+	}
+
+	return field.ErrorList{
+		field.NotFound(fldPath.Child("sectionName"), *targetRef.SectionName),
+	}
+}
+
+func validateCustomRuleSet(customRules *networkingv1alpha.CustomRuleSet, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	directivesPath := fldPath.Child("directives")
+	length := 0
+	ruleIDs := map[int]bool{}
+	chained := false
+	for i, directive := range customRules.Directives {
+		directivePath := directivesPath.Index(i)
+		length += len(directive)
+
+		if strings.ContainsAny(directive, "\r\n") {
+			allErrs = append(allErrs, field.Invalid(directivePath, directive, "must be a single line"))
+			continue
+		}
+
+		name, _, _ := strings.Cut(strings.TrimSpace(directive), " ")
+		requiresID, ok := customRuleDirectives[strings.ToLower(name)]
+		if !ok {
+			allErrs = append(allErrs, field.Forbidden(directivePath, fmt.Sprintf("directive %q is not allowed in custom rules", name)))
+			continue
+		}
+
+		if strings.Contains(strings.ToLower(directive), "ctl:ruleengine") {
+			allErrs = append(allErrs, field.Forbidden(directivePath, "the rule engine mode is set by spec.mode and may not be changed by custom rules"))
+		}
+
+		// The rules of a chain after the first one don't have an ID.
+		if !requiresID || chained {
+			chained = requiresID && customRuleChainPattern.MatchString(directive)
+			continue
+		}
+		chained = customRuleChainPattern.MatchString(directive)
+
+		matches := customRuleIDPattern.FindAllStringSubmatch(directive, -1)
+		if len(matches) != 1 {
+			allErrs = append(allErrs, field.Invalid(directivePath, directive, "must have exactly one id action"))
+			continue
+		}
+		id, err := strconv.Atoi(matches[0][1])
+		if err != nil || id < 1 || id > maxCustomRuleID {
+			allErrs = append(allErrs, field.Invalid(directivePath, directive, fmt.Sprintf("rule id must be between 1 and %d", maxCustomRuleID)))
+			continue
+		}
+		if ruleIDs[id] {
+			allErrs = append(allErrs, field.Duplicate(directivePath, id))
+		}
+		ruleIDs[id] = true
+	}
+
+	if length > maxCustomRulesLength {
+		allErrs = append(allErrs, field.TooLong(directivesPath, length, maxCustomRulesLength))
+	}
+
+	return allErrs
+}
+
+// TrafficProtectionPolicyWarnings returns the configurations in a
+// TrafficProtectionPolicy that are valid, but likely to be unintended.
+func TrafficProtectionPolicyWarnings(policy *networkingv1alpha.TrafficProtectionPolicy) []networkingv1alpha.Warning {
+	var warnings []networkingv1alpha.Warning
+
+	specPath := field.NewPath("spec")
+	switch policy.Spec.Mode {
+	case networkingv1alpha.TrafficProtectionPolicyObserve, "":
+		// Observing the OWASP Core Rule Set is the usual way to roll out a
+		// policy, while geo and custom rules are written to block requests.
+		for i, ruleSet := range policy.Spec.RuleSets {
+			if ruleSet.Type != networkingv1alpha.TrafficProtectionPolicyGeoRuleSet &&
+				ruleSet.Type != networkingv1alpha.TrafficProtectionPolicyCustomRuleSet {
+				continue
+			}
+			warnings = append(warnings, networkingv1alpha.Warning{
+				Code:    networkingv1alpha.WarningCodeTrafficProtectionObserveOnly,
+				Field:   specPath.Child("ruleSets").Index(i).String(),
+				Message: fmt.Sprintf("requests matched by the %s rules are logged but not blocked; set mode to Enforce to block them", ruleSet.Type),
+			})
+		}
+	case networkingv1alpha.TrafficProtectionPolicyEnforce:
+		if policy.Spec.SamplingPercentage > 0 && policy.Spec.SamplingPercentage < 100 {
+			warnings = append(warnings, networkingv1alpha.Warning{
+				Code:    networkingv1alpha.WarningCodeTrafficProtectionPartialSampling,
+				Field:   specPath.Child("samplingPercentage").String(),
+				Message: fmt.Sprintf("only %d%% of requests are inspected, so the remaining requests are never blocked", policy.Spec.SamplingPercentage),
+			})
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,466 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestValidateTrafficProtectionPolicy(t *testing.T) {
+	directivesPath := field.NewPath("spec", "ruleSets").Index(0).Child("customRules", "directives")
+
+	scenarios := map[string]struct {
+		directives     []string
+		expectedErrors field.ErrorList
+	}{
+		"rules": {
+			directives: []string{
+				`SecRule REQUEST_URI "@beginsWith /admin" "id:1000,phase:1,deny,status:403"`,
+				`SecAction "id:1001,phase:1,pass,nolog,setvar:tx.custom=1"`,
+				`SecRuleRemoveById 942100`,
+				`SecRuleUpdateTargetById 942100 "!ARGS:query"`,
+			},
+		},
+		"chained rules": {
+			directives: []string{
+				`SecRule REQUEST_METHOD "@streq POST" "id:1000,phase:1,deny,chain"`,
+				`SecRule REQUEST_URI "@beginsWith /admin"`,
+			},
+		},
+		"rule engine": {
+			directives: []string{"SecRuleEngine Off"},
+			expectedErrors: field.ErrorList{
+				field.Forbidden(directivesPath.Index(0), ""),
+			},
+		},
+		"include": {
+			directives: []string{"Include /etc/passwd"},
+			expectedErrors: field.ErrorList{
+				field.Forbidden(directivesPath.Index(0), ""),
+			},
+		},
+		"rule engine control action": {
+			directives: []string{`SecRule REQUEST_URI "@beginsWith /" "id:1000,phase:1,pass,ctl:ruleEngine=Off"`},
+			expectedErrors: field.ErrorList{
+				field.Forbidden(directivesPath.Index(0), ""),
+			},
+		},
+		"multiple lines": {
+			directives: []string{"SecRule REQUEST_URI \"@beginsWith /\" \"id:1000,phase:1,pass\"\nSecRuleEngine Off"},
+			expectedErrors: field.ErrorList{
+				field.Invalid(directivesPath.Index(0), "", ""),
+			},
+		},
+		"missing id": {
+			directives: []string{`SecRule REQUEST_URI "@beginsWith /admin" "phase:1,deny"`},
+			expectedErrors: field.ErrorList{
+				field.Invalid(directivesPath.Index(0), "", ""),
+			},
+		},
+		"reserved id": {
+			directives: []string{`SecAction "id:900110,phase:1,pass,nolog"`},
+			expectedErrors: field.ErrorList{
+				field.Invalid(directivesPath.Index(0), "", ""),
+			},
+		},
+		"duplicate id": {
+			directives: []string{
+				`SecAction "id:1000,phase:1,pass,nolog"`,
+				`SecAction "id:1000,phase:2,pass,nolog"`,
+			},
+			expectedErrors: field.ErrorList{
+				field.Duplicate(directivesPath.Index(1), ""),
+			},
+		},
+		"too long": {
+			directives: func() []string {
+				var directives []string
+				for i := range 9 {
+					directives = append(directives, fmt.Sprintf(`SecAction "id:%d,phase:1,pass,nolog,msg:'%s'"`, i+1, strings.Repeat("a", 4000)))
+				}
+				return directives
+			}(),
+			expectedErrors: field.ErrorList{
+				field.TooLong(directivesPath, "", 0),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			policy := &networkingv1alpha.TrafficProtectionPolicy{
+				Spec: networkingv1alpha.TrafficProtectionPolicySpec{
+					RuleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+						{
+							Type:        networkingv1alpha.TrafficProtectionPolicyCustomRuleSet,
+							CustomRules: &networkingv1alpha.CustomRuleSet{Directives: scenario.directives},
+						},
+					},
+				},
+			}
+			errs := ValidateTrafficProtectionPolicy(policy)
+			if diff := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateTrafficProtectionPolicyTargetRefs(t *testing.T) {
+	targetRefsPath := field.NewPath("spec", "targetRefs")
+
+	targetRef := func(group, kind, name string, sectionName *string) gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+		return gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.Group(group),
+				Kind:  gatewayv1.Kind(kind),
+				Name:  gatewayv1.ObjectName(name),
+			},
+			SectionName: (*gatewayv1.SectionName)(sectionName),
+		}
+	}
+
+	scenarios := map[string]struct {
+		targetRefs     []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName
+		expectedErrors field.ErrorList
+	}{
+		"gateway and route rules": {
+			targetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				targetRef(gatewayv1.GroupName, "Gateway", "gw", nil),
+				targetRef(gatewayv1.GroupName, "HTTPRoute", "route", ptr.To("a")),
+				targetRef(gatewayv1.GroupName, "HTTPRoute", "route", ptr.To("b")),
+			},
+		},
+		"unsupported group": {
+			targetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				targetRef("networking.datumapis.com", "HTTPProxy", "proxy", nil),
+			},
+			expectedErrors: field.ErrorList{
+				field.NotSupported(targetRefsPath.Index(0).Child("group"), "", []string{}),
+			},
+		},
+		"unsupported kind": {
+			targetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				targetRef(gatewayv1.GroupName, "GRPCRoute", "route", nil),
+			},
+			expectedErrors: field.ErrorList{
+				field.NotSupported(targetRefsPath.Index(0).Child("kind"), "", []string{}),
+			},
+		},
+		"duplicate target": {
+			targetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				targetRef(gatewayv1.GroupName, "Gateway", "gw", ptr.To("http")),
+				targetRef(gatewayv1.GroupName, "Gateway", "gw", ptr.To("http")),
+			},
+			expectedErrors: field.ErrorList{
+				field.Duplicate(targetRefsPath.Index(1), ""),
+			},
+		},
+		"whole target and section": {
+			targetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				targetRef(gatewayv1.GroupName, "Gateway", "gw", nil),
+				targetRef(gatewayv1.GroupName, "Gateway", "gw", ptr.To("http")),
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(targetRefsPath.Index(1), "", ""),
+			},
+		},
+		"section and whole target": {
+			targetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				targetRef(gatewayv1.GroupName, "HTTPRoute", "route", ptr.To("a")),
+				targetRef(gatewayv1.GroupName, "HTTPRoute", "route", nil),
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(targetRefsPath.Index(1), "", ""),
+			},
+		},
+		"same name different kinds": {
+			targetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				targetRef(gatewayv1.GroupName, "Gateway", "web", nil),
+				targetRef(gatewayv1.GroupName, "HTTPRoute", "web", ptr.To("a")),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			policy := &networkingv1alpha.TrafficProtectionPolicy{
+				Spec: networkingv1alpha.TrafficProtectionPolicySpec{TargetRefs: scenario.targetRefs},
+			}
+			errs := ValidateTrafficProtectionPolicy(policy)
+			if diff := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateTrafficProtectionPolicySpec(t *testing.T) {
+	specPath := field.NewPath("spec")
+	ruleSetPath := specPath.Child("ruleSets").Index(0)
+	customRules := &networkingv1alpha.CustomRuleSet{Directives: []string{`SecAction "id:1000,phase:1,pass,nolog"`}}
+
+	scenarios := map[string]struct {
+		spec           networkingv1alpha.TrafficProtectionPolicySpec
+		expectedErrors field.ErrorList
+	}{
+		"defaulted mode and sampling percentage": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{},
+		},
+		"enforce with partial sampling": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{
+				Mode:               networkingv1alpha.TrafficProtectionPolicyEnforce,
+				SamplingPercentage: 50,
+			},
+		},
+		"unsupported mode": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{Mode: "Block"},
+			expectedErrors: field.ErrorList{
+				field.NotSupported(specPath.Child("mode"), "", []string{}),
+			},
+		},
+		"sampling percentage out of range": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{SamplingPercentage: 101},
+			expectedErrors: field.ErrorList{
+				field.Invalid(specPath.Child("samplingPercentage"), "", ""),
+			},
+		},
+		"unsupported ruleset type": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{
+				RuleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{{Type: "RateLimit"}},
+			},
+			expectedErrors: field.ErrorList{
+				field.NotSupported(ruleSetPath.Child("type"), "", []string{}),
+			},
+		},
+		"geo ruleset without geo": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{
+				RuleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+					{Type: networkingv1alpha.TrafficProtectionPolicyGeoRuleSet},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Required(ruleSetPath.Child("geo"), ""),
+			},
+		},
+		"geo ruleset with custom rules": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{
+				RuleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+					{
+						Type:        networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+						Geo:         &networkingv1alpha.GeoRuleSet{DenyCountries: []networkingv1alpha.CountryCode{"KP"}},
+						CustomRules: customRules,
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Forbidden(ruleSetPath.Child("customRules"), ""),
+			},
+		},
+		"custom rules ruleset without custom rules": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{
+				RuleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+					{Type: networkingv1alpha.TrafficProtectionPolicyCustomRuleSet},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Required(ruleSetPath.Child("customRules"), ""),
+			},
+		},
+		"geo on custom rules ruleset": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{
+				RuleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+					{
+						Type:        networkingv1alpha.TrafficProtectionPolicyCustomRuleSet,
+						Geo:         &networkingv1alpha.GeoRuleSet{AllowCountries: []networkingv1alpha.CountryCode{"US"}},
+						CustomRules: customRules,
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Forbidden(ruleSetPath.Child("geo"), ""),
+			},
+		},
+		"geo with allowed and denied countries": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{
+				RuleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+					{
+						Type: networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+						Geo: &networkingv1alpha.GeoRuleSet{
+							AllowCountries: []networkingv1alpha.CountryCode{"US"},
+							DenyCountries:  []networkingv1alpha.CountryCode{"KP"},
+						},
+					},
+				},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(ruleSetPath.Child("geo"), "", ""),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			policy := &networkingv1alpha.TrafficProtectionPolicy{Spec: scenario.spec}
+			errs := ValidateTrafficProtectionPolicy(policy)
+			if diff := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateTrafficProtectionPolicyOWASPCoreRuleSet(t *testing.T) {
+	owaspCRSPath := field.NewPath("spec", "ruleSets").Index(0).Child("owaspCoreRuleSet")
+
+	scenarios := map[string]struct {
+		owaspCRS       networkingv1alpha.OWASPCRS
+		expectedErrors field.ErrorList
+	}{
+		"defaults": {
+			owaspCRS: networkingv1alpha.OWASPCRS{
+				ParanoiaLevels:  networkingv1alpha.ParanoiaLevels{Blocking: 1, Detection: 1},
+				ScoreThresholds: networkingv1alpha.OWASPScoreThresholds{Inbound: 5, Outbound: 4},
+			},
+		},
+		"higher detection paranoia level": {
+			owaspCRS: networkingv1alpha.OWASPCRS{
+				ParanoiaLevels:  networkingv1alpha.ParanoiaLevels{Blocking: 2, Detection: 4},
+				ScoreThresholds: networkingv1alpha.OWASPScoreThresholds{Inbound: 10000, Outbound: 1},
+			},
+		},
+		"inverted paranoia levels": {
+			owaspCRS: networkingv1alpha.OWASPCRS{
+				ParanoiaLevels:  networkingv1alpha.ParanoiaLevels{Blocking: 3, Detection: 1},
+				ScoreThresholds: networkingv1alpha.OWASPScoreThresholds{Inbound: 5, Outbound: 4},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(owaspCRSPath.Child("paranoiaLevels", "detection"), "", ""),
+			},
+		},
+		"paranoia levels out of range": {
+			owaspCRS: networkingv1alpha.OWASPCRS{
+				ParanoiaLevels:  networkingv1alpha.ParanoiaLevels{Blocking: 0, Detection: 5},
+				ScoreThresholds: networkingv1alpha.OWASPScoreThresholds{Inbound: 5, Outbound: 4},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(owaspCRSPath.Child("paranoiaLevels", "blocking"), "", ""),
+				field.Invalid(owaspCRSPath.Child("paranoiaLevels", "detection"), "", ""),
+			},
+		},
+		"score thresholds out of range": {
+			owaspCRS: networkingv1alpha.OWASPCRS{
+				ParanoiaLevels:  networkingv1alpha.ParanoiaLevels{Blocking: 1, Detection: 1},
+				ScoreThresholds: networkingv1alpha.OWASPScoreThresholds{Inbound: 0, Outbound: 10001},
+			},
+			expectedErrors: field.ErrorList{
+				field.Invalid(owaspCRSPath.Child("scoreThresholds", "inbound"), "", ""),
+				field.Invalid(owaspCRSPath.Child("scoreThresholds", "outbound"), "", ""),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			policy := &networkingv1alpha.TrafficProtectionPolicy{
+				Spec: networkingv1alpha.TrafficProtectionPolicySpec{
+					RuleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+						{
+							Type:             networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet,
+							OWASPCoreRuleSet: scenario.owaspCRS,
+						},
+					},
+				},
+			}
+			errs := ValidateTrafficProtectionPolicy(policy)
+			if diff := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateTrafficProtectionPolicySectionName(t *testing.T) {
+	fldPath := field.NewPath("spec", "targetRefs").Index(0)
+	sectionNames := []gatewayv1.SectionName{"http", "https"}
+
+	targetRef := gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+			Group: gatewayv1.GroupName,
+			Kind:  "Gateway",
+			Name:  "gw",
+		},
+	}
+	assert.Empty(t, ValidateTrafficProtectionPolicySectionName(targetRef, sectionNames, fldPath))
+
+	targetRef.SectionName = ptr.To[gatewayv1.SectionName]("https")
+	assert.Empty(t, ValidateTrafficProtectionPolicySectionName(targetRef, sectionNames, fldPath))
+
+	targetRef.SectionName = ptr.To[gatewayv1.SectionName]("grpc")
+	errs := ValidateTrafficProtectionPolicySectionName(targetRef, sectionNames, fldPath)
+	if diff := cmp.Diff(field.ErrorList{field.NotFound(fldPath.Child("sectionName"), "")}, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+		t.Errorf("unexpected errors (-want +got):\n%s", diff)
+	}
+}
+
+func TestTrafficProtectionPolicyWarnings(t *testing.T) {
+	scenarios := map[string]struct {
+		spec     networkingv1alpha.TrafficProtectionPolicySpec
+		expected []networkingv1alpha.Warning
+	}{
+		"observe": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{
+				Mode:               networkingv1alpha.TrafficProtectionPolicyObserve,
+				SamplingPercentage: 100,
+				RuleSets:           []networkingv1alpha.TrafficProtectionPolicyRuleSet{{Type: networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet}},
+			},
+		},
+		"observe with geo rules": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{
+				Mode:               networkingv1alpha.TrafficProtectionPolicyObserve,
+				SamplingPercentage: 100,
+				RuleSets: []networkingv1alpha.TrafficProtectionPolicyRuleSet{
+					{Type: networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet},
+					{
+						Type: networkingv1alpha.TrafficProtectionPolicyGeoRuleSet,
+						Geo:  &networkingv1alpha.GeoRuleSet{DenyCountries: []networkingv1alpha.CountryCode{"AQ"}},
+					},
+				},
+			},
+			expected: []networkingv1alpha.Warning{
+				{Code: networkingv1alpha.WarningCodeTrafficProtectionObserveOnly, Field: "spec.ruleSets[1]"},
+			},
+		},
+		"enforce": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{Mode: networkingv1alpha.TrafficProtectionPolicyEnforce, SamplingPercentage: 100},
+		},
+		"enforce with partial sampling": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{Mode: networkingv1alpha.TrafficProtectionPolicyEnforce, SamplingPercentage: 50},
+			expected: []networkingv1alpha.Warning{
+				{Code: networkingv1alpha.WarningCodeTrafficProtectionPartialSampling, Field: "spec.samplingPercentage"},
+			},
+		},
+		"disabled": {
+			spec: networkingv1alpha.TrafficProtectionPolicySpec{Mode: networkingv1alpha.TrafficProtectionPolicyDisabled},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			warnings := TrafficProtectionPolicyWarnings(&networkingv1alpha.TrafficProtectionPolicy{Spec: scenario.spec})
+			if diff := cmp.Diff(scenario.expected, warnings, cmpopts.IgnoreFields(networkingv1alpha.Warning{}, "Message")); diff != "" {
+				t.Errorf("unexpected warnings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
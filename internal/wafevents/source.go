@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package wafevents summarizes the requests detected or blocked by
+// TrafficProtectionPolicies, as recorded from the downstream Coraza logs.
+package wafevents
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// Label names of the WAF event metric.
+const (
+	LabelProject   = "project"
+	LabelNamespace = "namespace"
+	LabelPolicy    = "policy"
+	LabelAction    = "action"
+	LabelRuleID    = "rule_id"
+)
+
+// Values of the action label.
+const (
+	ActionBlocked  = "blocked"
+	ActionDetected = "detected"
+)
+
+// maxTopRules is the number of most triggered rules reported in a summary.
+const maxTopRules = 5
+
+// PolicyRef identifies a TrafficProtectionPolicy in the WAF event metric.
+type PolicyRef struct {
+	// Project is the project_name of the datum-gateway route metadata.
+	Project   string
+	Namespace string
+	Name      string
+}
+
+// RuleCount is the number of times a rule was triggered.
+type RuleCount struct {
+	RuleID string
+	Count  int64
+}
+
+// Summary summarizes the WAF events of a policy within a window of time.
+type Summary struct {
+	Blocked  int64
+	Detected int64
+	// TopRules are the rules triggered most often, most frequent first.
+	TopRules []RuleCount
+	// LastEventTime is nil when there were no events within the window.
+	LastEventTime *time.Time
+}
+
+// Source summarizes the WAF events of policies.
+type Source interface {
+	Summarize(ctx context.Context, policy PolicyRef, window time.Duration) (*Summary, error)
+}
+
+type prometheusSource struct {
+	api        promv1.API
+	metricName string
+}
+
+// NewPrometheusSource returns a Source that queries the WAF event counter
+// metricName from the Prometheus compatible API at address.
+func NewPrometheusSource(address, metricName string) (Source, error) {
+	client, err := promapi.NewClient(promapi.Config{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus client: %w", err)
+	}
+	return &prometheusSource{api: promv1.NewAPI(client), metricName: metricName}, nil
+}
+
+func (s *prometheusSource) Summarize(ctx context.Context, policy PolicyRef, window time.Duration) (*Summary, error) {
+	now := time.Now()
+	selector := s.selector(policy)
+	rangeSelector := fmt.Sprintf("%s[%s]", selector, model.Duration(window))
+
+	summary := &Summary{}
+
+	actions, err := s.queryVector(ctx, fmt.Sprintf("sum by (%s) (increase(%s))", LabelAction, rangeSelector), now)
+	if err != nil {
+		return nil, err
+	}
+	for _, sample := range actions {
+		switch string(sample.Metric[LabelAction]) {
+		case ActionBlocked:
+			summary.Blocked = roundCount(sample.Value)
+		case ActionDetected:
+			summary.Detected = roundCount(sample.Value)
+		}
+	}
+
+	rules, err := s.queryVector(ctx, fmt.Sprintf("topk(%d, sum by (%s) (increase(%s)) > 0)", maxTopRules, LabelRuleID, rangeSelector), now)
+	if err != nil {
+		return nil, err
+	}
+	for _, sample := range rules {
+		ruleID := string(sample.Metric[LabelRuleID])
+		if ruleID == "" {
+			continue
+		}
+		summary.TopRules = append(summary.TopRules, RuleCount{RuleID: ruleID, Count: roundCount(sample.Value)})
+	}
+	sort.SliceStable(summary.TopRules, func(i, j int) bool {
+		if summary.TopRules[i].Count != summary.TopRules[j].Count {
+			return summary.TopRules[i].Count > summary.TopRules[j].Count
+		}
+		return summary.TopRules[i].RuleID < summary.TopRules[j].RuleID
+	})
+
+	// The timestamp of the last step of the window in which the counter
+	// increased, with a one minute resolution.
+	last, err := s.queryVector(ctx, fmt.Sprintf("max(max_over_time((timestamp(sum(increase(%s[1m])) > 0))[%s:1m]))", selector, model.Duration(window)), now)
+	if err != nil {
+		return nil, err
+	}
+	if len(last) > 0 {
+		lastEventTime := time.Unix(int64(last[0].Value), 0)
+		summary.LastEventTime = &lastEventTime
+	}
+
+	return summary, nil
+}
+
+func (s *prometheusSource) selector(policy PolicyRef) string {
+	return fmt.Sprintf("%s{%s=%s,%s=%s,%s=%s}",
+		s.metricName,
+		LabelProject, strconv.Quote(policy.Project),
+		LabelNamespace, strconv.Quote(policy.Namespace),
+		LabelPolicy, strconv.Quote(policy.Name),
+	)
+}
+
+func (s *prometheusSource) queryVector(ctx context.Context, query string, ts time.Time) (model.Vector, error) {
+	value, _, err := s.api.Query(ctx, query, ts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query WAF events: %w", err)
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected WAF events query result type %s", value.Type())
+	}
+	return vector, nil
+}
+
+// roundCount rounds the extrapolated result of increase() to a whole count.
+func roundCount(value model.SampleValue) int64 {
+	return int64(float64(value) + 0.5)
+}
+
+// ProjectForClusterName returns the project_name stamped into the route
+// metadata for policies of the upstream cluster.
+func ProjectForClusterName(clusterName string) string {
+	return strings.ReplaceAll(clusterName, "/", "_")
+}
@@ -0,0 +1,81 @@
+package wafevents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusSourceSummarize(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		query := r.Form.Get("query")
+		queries = append(queries, query)
+
+		var result []map[string]any
+		switch {
+		case strings.HasPrefix(query, "sum by (action)"):
+			result = []map[string]any{
+				{"metric": map[string]string{"action": "blocked"}, "value": []any{1700000000, "12.4"}},
+				{"metric": map[string]string{"action": "detected"}, "value": []any{1700000000, "3"}},
+			}
+		case strings.HasPrefix(query, "topk("):
+			result = []map[string]any{
+				{"metric": map[string]string{"rule_id": "920350"}, "value": []any{1700000000, "4"}},
+				{"metric": map[string]string{"rule_id": "942100"}, "value": []any{1700000000, "11"}},
+			}
+		case strings.HasPrefix(query, "max("):
+			result = []map[string]any{
+				{"metric": map[string]string{}, "value": []any{1700000000, "1699999940"}},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"status": "success",
+			"data":   map[string]any{"resultType": "vector", "result": result},
+		}))
+	}))
+	defer server.Close()
+
+	source, err := NewPrometheusSource(server.URL, "datum_waf_events_total")
+	require.NoError(t, err)
+
+	summary, err := source.Summarize(context.Background(), PolicyRef{Project: "_project", Namespace: "default", Name: "tpp"}, time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(12), summary.Blocked)
+	assert.Equal(t, int64(3), summary.Detected)
+	assert.Equal(t, []RuleCount{{RuleID: "942100", Count: 11}, {RuleID: "920350", Count: 4}}, summary.TopRules)
+	if assert.NotNil(t, summary.LastEventTime) {
+		assert.Equal(t, time.Unix(1699999940, 0), *summary.LastEventTime)
+	}
+
+	require.Len(t, queries, 3)
+	for _, query := range queries {
+		assert.Contains(t, query, `datum_waf_events_total{project="_project",namespace="default",policy="tpp"}`)
+	}
+}
+
+func TestPrometheusSourceSummarizeNoEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+	}))
+	defer server.Close()
+
+	source, err := NewPrometheusSource(server.URL, "datum_waf_events_total")
+	require.NoError(t, err)
+
+	summary, err := source.Summarize(context.Background(), PolicyRef{Namespace: "default", Name: "tpp"}, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, &Summary{}, summary)
+}
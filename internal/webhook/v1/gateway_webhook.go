@@ -5,19 +5,30 @@ package v1
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"slices"
 	"sort"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
 	"go.datum.net/network-services-operator/internal/config"
 	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 	"go.datum.net/network-services-operator/internal/validation"
@@ -34,19 +45,28 @@ func SetupGatewayWebhookWithManager(mgr mcmanager.Manager, config config.Network
 		ValidProtocolTypes:         config.Gateway.ValidProtocolTypes,
 		GatewayDNSAddressFunc:      config.Gateway.GatewayDNSAddress,
 		SkipHostnameFQDNValidation: config.Gateway.DisableHostnameVerification,
+		StaticAddressesEnabled:     config.Gateway.StaticAddressesEnabled(),
+		IPFamilies:                 config.Gateway.IPFamilies,
 	}
 
 	return ctrl.NewWebhookManagedBy(mgr.GetLocalManager(), &gatewayv1.Gateway{}).
-		WithValidator(&GatewayCustomValidator{mgr: mgr, validationOpts: validationOpts}).
+		WithValidator(&GatewayCustomValidator{
+			mgr:                        mgr,
+			validationOpts:             validationOpts,
+			staticAddressSubnetClasses: config.Gateway.StaticAddressSubnetClasses,
+			deletionProtection:         config.Gateway.DeletionProtection.Enabled,
+		}).
 		WithDefaulter(&GatewayCustomDefaulter{mgr: mgr, config: config}).
 		Complete()
 }
 
-// +kubebuilder:webhook:path=/validate-gateway-networking-k8s-io-v1-gateway,mutating=false,failurePolicy=fail,sideEffects=None,groups=gateway.networking.k8s.io,resources=gateways,verbs=create;update,versions=v1,name=vgateway-v1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-gateway-networking-k8s-io-v1-gateway,mutating=false,failurePolicy=fail,sideEffects=None,groups=gateway.networking.k8s.io,resources=gateways,verbs=create;update;delete,versions=v1,name=vgateway-v1.kb.io,admissionReviewVersions=v1
 
 type GatewayCustomValidator struct {
-	mgr            mcmanager.Manager
-	validationOpts validation.GatewayValidationOptions
+	mgr                        mcmanager.Manager
+	validationOpts             validation.GatewayValidationOptions
+	staticAddressSubnetClasses []string
+	deletionProtection         bool
 }
 
 var _ admission.Validator[*gatewayv1.Gateway] = &GatewayCustomValidator{}
@@ -80,6 +100,12 @@ func (v *GatewayCustomValidator) ValidateCreate(ctx context.Context, gateway *ga
 		return nil, apierrors.NewInvalid(gateway.GetObjectKind().GroupVersionKind().GroupKind(), gateway.GetName(), errs)
 	}
 
+	if errs, err := validateStaticGatewayAddresses(ctx, clusterClient, v.staticAddressSubnetClasses, v.validationOpts.IPFamilies, gateway); err != nil {
+		return nil, err
+	} else if len(errs) > 0 {
+		return nil, apierrors.NewInvalid(gateway.GetObjectKind().GroupVersionKind().GroupKind(), gateway.GetName(), errs)
+	}
+
 	return nil, nil
 }
 
@@ -117,6 +143,12 @@ func (v *GatewayCustomValidator) ValidateUpdate(ctx context.Context, oldGateway,
 		return nil, apierrors.NewInvalid(oldGateway.GetObjectKind().GroupVersionKind().GroupKind(), newGateway.GetName(), errs)
 	}
 
+	if errs, err := validateStaticGatewayAddresses(ctx, clusterClient, v.staticAddressSubnetClasses, v.validationOpts.IPFamilies, newGateway); err != nil {
+		return nil, err
+	} else if len(errs) > 0 {
+		return nil, apierrors.NewInvalid(oldGateway.GetObjectKind().GroupVersionKind().GroupKind(), newGateway.GetName(), errs)
+	}
+
 	return nil, nil
 }
 
@@ -125,9 +157,192 @@ func (v *GatewayCustomValidator) ValidateDelete(ctx context.Context, gateway *ga
 	gatewaylog := logf.FromContext(ctx)
 	gatewaylog.Info("Validation for Gateway upon deletion", "name", gateway.GetName())
 
+	if !v.deletionProtection {
+		return nil, nil
+	}
+
+	clusterName, ok := mccontext.ClusterFrom(ctx)
+	if !ok {
+		return nil, fmt.Errorf("expected a cluster name in the context")
+	}
+
+	cluster, err := v.mgr.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	clusterClient := cluster.GetClient()
+
+	if shouldProcess, err := shouldProcess(ctx, clusterClient, v.validationOpts.ControllerName, gateway); !shouldProcess || err != nil {
+		return nil, err
+	}
+
+	referrer, err := gatewayDeletionReferrer(ctx, clusterClient, gateway)
+	if err != nil {
+		return nil, err
+	}
+	if referrer != "" {
+		return nil, apierrors.NewForbidden(
+			schema.GroupResource{Group: gatewayv1.GroupName, Resource: "gateways"},
+			gateway.GetName(),
+			fmt.Errorf("cannot delete Gateway while in use by %s, set the %s annotation to \"true\" to delete it anyway", referrer, gatewayutil.ForceDeleteAnnotation),
+		)
+	}
+
 	return nil, nil
 }
 
+// gatewayDeletionReferrer returns a description of a resource that still
+// references the gateway, protecting it from deletion. Routes of any kind in
+// the namespaces the listeners of the gateway accept routes from protect it.
+// Gateways with the force delete annotation, gateways in terminating
+// namespaces, and gateways of HTTPProxies that are gone or being deleted are
+// not protected.
+func gatewayDeletionReferrer(ctx context.Context, clusterClient client.Client, gateway *gatewayv1.Gateway) (string, error) {
+	if gateway.Annotations[gatewayutil.ForceDeleteAnnotation] == "true" || !gateway.DeletionTimestamp.IsZero() {
+		return "", nil
+	}
+
+	var namespace corev1.Namespace
+	if err := clusterClient.Get(ctx, client.ObjectKey{Name: gateway.Namespace}, &namespace); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get namespace %q: %w", gateway.Namespace, err)
+		}
+		return "", nil
+	}
+	if !namespace.DeletionTimestamp.IsZero() {
+		return "", nil
+	}
+
+	if isHTTPProxyGateway(gateway) {
+		// The HTTPRoute of an HTTPProxy is garbage collected along with its
+		// gateway, so only the HTTPProxy itself protects the gateway.
+		owner := metav1.GetControllerOf(gateway)
+		var httpProxy networkingv1alpha.HTTPProxy
+		if err := clusterClient.Get(ctx, client.ObjectKey{Namespace: gateway.Namespace, Name: owner.Name}, &httpProxy); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return "", fmt.Errorf("failed to get HTTPProxy %q: %w", owner.Name, err)
+			}
+			return "", nil
+		}
+		if httpProxy.UID != owner.UID || !httpProxy.DeletionTimestamp.IsZero() {
+			return "", nil
+		}
+		return fmt.Sprintf("HTTPProxy %q", httpProxy.Name), nil
+	}
+
+	namespaceAllowed, listOpts, err := gatewayRouteNamespaces(ctx, clusterClient, gateway)
+	if err != nil {
+		return "", err
+	}
+
+	routeLists := []client.ObjectList{
+		&gatewayv1.HTTPRouteList{},
+		&gatewayv1.GRPCRouteList{},
+		&gatewayv1alpha2.TCPRouteList{},
+		&gatewayv1alpha2.UDPRouteList{},
+	}
+	for _, routeList := range routeLists {
+		if err := clusterClient.List(ctx, routeList, listOpts...); err != nil {
+			// Route kinds that are not served by the cluster cannot reference
+			// the gateway.
+			if apimeta.IsNoMatchError(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to list routes: %w", err)
+		}
+
+		routes, err := apimeta.ExtractList(routeList)
+		if err != nil {
+			return "", fmt.Errorf("failed to extract routes: %w", err)
+		}
+		for _, obj := range routes {
+			route, ok := obj.(client.Object)
+			if !ok || !route.GetDeletionTimestamp().IsZero() || !namespaceAllowed(route.GetNamespace()) {
+				continue
+			}
+			kind, parentRefs := routeParentRefs(route)
+			for _, parentRef := range parentRefs {
+				if ptr.Deref(parentRef.Group, gatewayv1.GroupName) == gatewayv1.GroupName &&
+					ptr.Deref(parentRef.Kind, "Gateway") == "Gateway" &&
+					ptr.Deref(parentRef.Namespace, gatewayv1.Namespace(route.GetNamespace())) == gatewayv1.Namespace(gateway.Namespace) &&
+					string(parentRef.Name) == gateway.Name {
+					if route.GetNamespace() != gateway.Namespace {
+						return fmt.Sprintf("%s %q", kind, client.ObjectKeyFromObject(route)), nil
+					}
+					return fmt.Sprintf("%s %q", kind, route.GetName()), nil
+				}
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// gatewayRouteNamespaces returns a filter for the namespaces the listeners of
+// the gateway accept routes from, and the options to list those routes with.
+// Routes are only listed across namespaces when a listener accepts routes from
+// namespaces other than the gateway's own.
+func gatewayRouteNamespaces(ctx context.Context, clusterClient client.Client, gateway *gatewayv1.Gateway) (func(string) bool, []client.ListOption, error) {
+	var selectors []labels.Selector
+	fromAll := false
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.AllowedRoutes == nil || listener.AllowedRoutes.Namespaces == nil {
+			continue
+		}
+		switch ptr.Deref(listener.AllowedRoutes.Namespaces.From, gatewayv1.NamespacesFromSame) {
+		case gatewayv1.NamespacesFromAll:
+			fromAll = true
+		case gatewayv1.NamespacesFromSelector:
+			if listener.AllowedRoutes.Namespaces.Selector == nil {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(listener.AllowedRoutes.Namespaces.Selector)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid namespace selector on listener %q: %w", listener.Name, err)
+			}
+			selectors = append(selectors, selector)
+		}
+	}
+
+	if fromAll {
+		return func(string) bool { return true }, nil, nil
+	}
+	if len(selectors) == 0 {
+		return func(namespace string) bool { return namespace == gateway.Namespace },
+			[]client.ListOption{client.InNamespace(gateway.Namespace)}, nil
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := clusterClient.List(ctx, &namespaces); err != nil {
+		return nil, nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	allowed := sets.New(gateway.Namespace)
+	for _, namespace := range namespaces.Items {
+		for _, selector := range selectors {
+			if selector.Matches(labels.Set(namespace.Labels)) {
+				allowed.Insert(namespace.Name)
+				break
+			}
+		}
+	}
+	return allowed.Has, nil, nil
+}
+
+// routeParentRefs returns the kind and parent references of a route.
+func routeParentRefs(route client.Object) (string, []gatewayv1.ParentReference) {
+	switch route := route.(type) {
+	case *gatewayv1.HTTPRoute:
+		return "HTTPRoute", route.Spec.ParentRefs
+	case *gatewayv1.GRPCRoute:
+		return "GRPCRoute", route.Spec.ParentRefs
+	case *gatewayv1alpha2.TCPRoute:
+		return "TCPRoute", route.Spec.ParentRefs
+	case *gatewayv1alpha2.UDPRoute:
+		return "UDPRoute", route.Spec.ParentRefs
+	}
+	return "", nil
+}
+
 // +kubebuilder:webhook:path=/mutate-gateway-networking-k8s-io-v1-gateway,mutating=true,failurePolicy=fail,sideEffects=None,groups=gateway.networking.k8s.io,resources=gateways,verbs=create;update,versions=v1,name=mgateway-v1.kb.io,admissionReviewVersions=v1
 
 type GatewayCustomDefaulter struct {
@@ -173,11 +388,24 @@ func (d *GatewayCustomDefaulter) Default(ctx context.Context, gateway *gatewayv1
 		}
 
 		gatewayutil.SetDefaultListeners(gateway, d.config.Gateway)
+	} else if isHTTPProxyGateway(gateway) {
+		// Gateways programmed for an HTTPProxy always carry the default
+		// listeners.
+		gatewayutil.SetDefaultListenersIfMissing(gateway, d.config.Gateway)
 	}
 
+	gatewayutil.SetListenerDefaults(gateway, d.config.Gateway)
+
 	return nil
 }
 
+// isHTTPProxyGateway returns whether the gateway is programmed for an
+// HTTPProxy.
+func isHTTPProxyGateway(gateway *gatewayv1.Gateway) bool {
+	owner := metav1.GetControllerOf(gateway)
+	return owner != nil && owner.Kind == "HTTPProxy" && owner.APIVersion == networkingv1alpha.GroupVersion.String()
+}
+
 func shouldProcess(
 	ctx context.Context,
 	clusterClient client.Client,
@@ -229,3 +457,130 @@ func validateManagedGatewayClass(
 	sort.Strings(managed)
 	return field.NotSupported(field.NewPath("spec", "gatewayClassName"), gateway.Spec.GatewayClassName, managed), nil
 }
+
+// validateStaticGatewayAddresses requires each static address of the gateway
+// to be within a CIDR of an IPPool of a permitted subnet class in the namespace
+// of the gateway, and to not be requested by another gateway. Addresses
+// allocated to an IPAddressClaim are only requested by naming the claim, and
+// a claim is named by a single gateway at a time.
+func validateStaticGatewayAddresses(
+	ctx context.Context,
+	clusterClient client.Client,
+	subnetClasses []string,
+	ipFamilies []networkingv1alpha.IPFamily,
+	gateway *gatewayv1.Gateway,
+) (field.ErrorList, error) {
+	if len(gateway.Spec.Addresses) == 0 {
+		return nil, nil
+	}
+
+	var pools networkingv1alpha.IPPoolList
+	if err := clusterClient.List(ctx, &pools, client.InNamespace(gateway.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list IPPools in namespace %q: %w", gateway.Namespace, err)
+	}
+
+	var gateways gatewayv1.GatewayList
+	if err := clusterClient.List(ctx, &gateways, client.InNamespace(gateway.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list Gateways in namespace %q: %w", gateway.Namespace, err)
+	}
+
+	owners := map[netip.Addr]string{}
+	claimOwners := map[string]string{}
+	for _, g := range gateways.Items {
+		if g.Name == gateway.Name || g.DeletionTimestamp != nil {
+			continue
+		}
+		for _, address := range g.Spec.Addresses {
+			if ptr.Deref(address.Type, gatewayv1.IPAddressType) == gatewayv1.NamedAddressType {
+				claimOwners[address.Value] = g.Name
+			} else if ip, err := netip.ParseAddr(address.Value); err == nil {
+				owners[ip] = g.Name
+			}
+		}
+	}
+
+	claimAddresses := map[netip.Addr]string{}
+	for _, pool := range pools.Items {
+		for _, allocation := range pool.Status.Allocations {
+			if allocation.IPAddressClaimRef == nil {
+				continue
+			}
+			if prefix, err := netip.ParsePrefix(allocation.Prefix); err == nil {
+				claimAddresses[prefix.Addr()] = allocation.IPAddressClaimRef.Name
+			}
+		}
+	}
+
+	var errs field.ErrorList
+	addressesPath := field.NewPath("spec", "addresses")
+	families := map[networkingv1alpha.IPFamily]bool{}
+	for i, address := range gateway.Spec.Addresses {
+		valuePath := addressesPath.Index(i).Child("value")
+
+		if ptr.Deref(address.Type, gatewayv1.IPAddressType) == gatewayv1.NamedAddressType {
+			var claim networkingv1alpha.IPAddressClaim
+			if err := clusterClient.Get(ctx, client.ObjectKey{Namespace: gateway.Namespace, Name: address.Value}, &claim); err != nil {
+				if apierrors.IsNotFound(err) {
+					errs = append(errs, field.NotFound(valuePath, address.Value))
+					continue
+				}
+				return nil, fmt.Errorf("failed to get IPAddressClaim %q: %w", address.Value, err)
+			}
+
+			if owner, ok := claimOwners[claim.Name]; ok {
+				errs = append(errs, field.Invalid(valuePath, address.Value, fmt.Sprintf("IPAddressClaim is already used by Gateway %q", owner)))
+				continue
+			}
+			if !slices.Contains(ipFamilies, claim.Spec.IPFamily) {
+				errs = append(errs, field.Invalid(valuePath, address.Value, fmt.Sprintf("%s addresses are not supported by gateways", claim.Spec.IPFamily)))
+				continue
+			}
+			if families[claim.Spec.IPFamily] {
+				errs = append(errs, field.Invalid(valuePath, address.Value, fmt.Sprintf("only one %s address may be requested", claim.Spec.IPFamily)))
+				continue
+			}
+			families[claim.Spec.IPFamily] = true
+			continue
+		}
+
+		ip, err := netip.ParseAddr(address.Value)
+		if err != nil {
+			continue
+		}
+		family := networkingv1alpha.IPv6Protocol
+		if ip.Unmap().Is4() {
+			family = networkingv1alpha.IPv4Protocol
+		}
+		if families[family] {
+			errs = append(errs, field.Invalid(valuePath, address.Value, fmt.Sprintf("only one %s address may be requested", family)))
+			continue
+		}
+		families[family] = true
+
+		if owner, ok := owners[ip]; ok {
+			errs = append(errs, field.Invalid(valuePath, address.Value, fmt.Sprintf("address is already used by Gateway %q", owner)))
+			continue
+		}
+
+		if claim, ok := claimAddresses[ip]; ok {
+			errs = append(errs, field.Invalid(valuePath, address.Value, fmt.Sprintf("address is allocated to IPAddressClaim %q, which must be requested as a named address", claim)))
+			continue
+		}
+
+		if !slices.ContainsFunc(pools.Items, func(pool networkingv1alpha.IPPool) bool {
+			return slices.Contains(subnetClasses, pool.Spec.SubnetClass) && ipPoolContains(pool, ip)
+		}) {
+			errs = append(errs, field.Invalid(valuePath, address.Value, "address is not within an IPPool permitted for gateway addresses"))
+		}
+	}
+	return errs, nil
+}
+
+func ipPoolContains(pool networkingv1alpha.IPPool, ip netip.Addr) bool {
+	for _, cidr := range pool.Spec.CIDRs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -8,10 +8,17 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	gatewayutil "go.datum.net/network-services-operator/internal/util/gateway"
 )
 
 func TestValidateManagedGatewayClass(t *testing.T) {
@@ -79,3 +86,299 @@ func TestValidateManagedGatewayClass(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateStaticGatewayAddresses(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(scheme))
+
+	pool := func(name, subnetClass string, cidrs ...string) *networkingv1alpha.IPPool {
+		return &networkingv1alpha.IPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       networkingv1alpha.IPPoolSpec{SubnetClass: subnetClass, CIDRs: cidrs},
+		}
+	}
+	gatewayWithAddresses := func(name string, addresses ...string) *gatewayv1.Gateway {
+		gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		for _, address := range addresses {
+			gateway.Spec.Addresses = append(gateway.Spec.Addresses, gatewayv1.GatewaySpecAddress{Value: address})
+		}
+		return gateway
+	}
+
+	claim := func(name string, family networkingv1alpha.IPFamily) *networkingv1alpha.IPAddressClaim {
+		return &networkingv1alpha.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       networkingv1alpha.IPAddressClaimSpec{SubnetClass: "gateway-static", IPFamily: family},
+		}
+	}
+	withClaims := func(gateway *gatewayv1.Gateway, claims ...string) *gatewayv1.Gateway {
+		for _, claim := range claims {
+			gateway.Spec.Addresses = append(gateway.Spec.Addresses, gatewayv1.GatewaySpecAddress{
+				Type:  ptr.To(gatewayv1.NamedAddressType),
+				Value: claim,
+			})
+		}
+		return gateway
+	}
+
+	staticPool := pool("static", "gateway-static", "192.0.2.0/24")
+	staticPool.Status.Allocations = []networkingv1alpha.IPPoolAllocation{
+		{Prefix: "192.0.2.20/32", IPAddressClaimRef: &networkingv1alpha.LocalIPAddressClaimReference{Name: "blue"}},
+	}
+
+	clusterClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			staticPool,
+			pool("byoip", "byoip", "2001:db8::/48"),
+			pool("private", "private", "10.0.0.0/8"),
+			gatewayWithAddresses("other", "192.0.2.10"),
+			withClaims(gatewayWithAddresses("green"), "green"),
+			claim("blue", networkingv1alpha.IPv4Protocol),
+			claim("green", networkingv1alpha.IPv4Protocol),
+			claim("blue-v6", networkingv1alpha.IPv6Protocol),
+		).
+		Build()
+
+	tests := []struct {
+		name       string
+		gateway    *gatewayv1.Gateway
+		wantFields []string
+	}{
+		{
+			name:    "no addresses",
+			gateway: gatewayWithAddresses("test"),
+		},
+		{
+			name:    "addresses within permitted pools",
+			gateway: gatewayWithAddresses("test", "192.0.2.1", "2001:db8::1"),
+		},
+		{
+			name:       "address outside of permitted pools",
+			gateway:    gatewayWithAddresses("test", "10.0.0.1"),
+			wantFields: []string{"spec.addresses[0].value"},
+		},
+		{
+			name:       "address used by another gateway",
+			gateway:    gatewayWithAddresses("test", "2001:db8::1", "192.0.2.10"),
+			wantFields: []string{"spec.addresses[1].value"},
+		},
+		{
+			name:    "gateway keeps its own address",
+			gateway: gatewayWithAddresses("other", "192.0.2.10"),
+		},
+		{
+			name:    "ip address claims",
+			gateway: withClaims(gatewayWithAddresses("test"), "blue", "blue-v6"),
+		},
+		{
+			name:       "missing ip address claim",
+			gateway:    withClaims(gatewayWithAddresses("test"), "missing"),
+			wantFields: []string{"spec.addresses[0].value"},
+		},
+		{
+			name:       "ip address claim used by another gateway",
+			gateway:    withClaims(gatewayWithAddresses("test"), "green"),
+			wantFields: []string{"spec.addresses[0].value"},
+		},
+		{
+			name:       "ip address claim of a requested ip family",
+			gateway:    withClaims(gatewayWithAddresses("test", "192.0.2.1"), "blue"),
+			wantFields: []string{"spec.addresses[1].value"},
+		},
+		{
+			name:       "address allocated to an ip address claim",
+			gateway:    gatewayWithAddresses("test", "192.0.2.20"),
+			wantFields: []string{"spec.addresses[0].value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := validateStaticGatewayAddresses(
+				context.Background(),
+				clusterClient,
+				[]string{"gateway-static", "byoip"},
+				[]networkingv1alpha.IPFamily{networkingv1alpha.IPv4Protocol, networkingv1alpha.IPv6Protocol},
+				tt.gateway,
+			)
+			require.NoError(t, err)
+
+			var fields []string
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestGatewayDeletionReferrer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, gatewayv1alpha2.Install(scheme))
+	require.NoError(t, networkingv1alpha.AddToScheme(scheme))
+
+	deleted := metav1.Now()
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	terminating := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:              "terminating",
+		DeletionTimestamp: &deleted,
+		Finalizers:        []string{"kubernetes"},
+	}}
+	httpProxy := &networkingv1alpha.HTTPProxy{ObjectMeta: metav1.ObjectMeta{Name: "proxy", Namespace: "default", UID: types.UID("proxy-uid")}}
+	deletingHTTPProxy := &networkingv1alpha.HTTPProxy{ObjectMeta: metav1.ObjectMeta{
+		Name:              "deleting-proxy",
+		Namespace:         "default",
+		UID:               types.UID("deleting-proxy-uid"),
+		DeletionTimestamp: &deleted,
+		Finalizers:        []string{"test"},
+	}}
+	routeFor := func(namespace, name, gatewayName string) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: gatewayv1.HTTPRouteSpec{CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gatewayName)}},
+			}},
+		}
+	}
+
+	other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{"routes": "shared"}}}
+	grpcRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "grpc", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: []gatewayv1.ParentReference{{Name: "grpc-routed"}},
+		}},
+	}
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "tcp", Namespace: "other"},
+		Spec: gatewayv1alpha2.TCPRouteSpec{CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: []gatewayv1.ParentReference{{Namespace: ptr.To(gatewayv1.Namespace("default")), Name: "shared"}},
+		}},
+	}
+
+	clusterClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			namespace,
+			terminating,
+			other,
+			httpProxy,
+			deletingHTTPProxy,
+			routeFor("default", "route", "routed"),
+			routeFor("terminating", "route", "routed"),
+			grpcRoute,
+			tcpRoute,
+		).
+		Build()
+
+	gatewayFor := func(namespace, name string, opts ...func(*gatewayv1.Gateway)) *gatewayv1.Gateway {
+		gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		for _, opt := range opts {
+			opt(gateway)
+		}
+		return gateway
+	}
+	ownedBy := func(httpProxy *networkingv1alpha.HTTPProxy) func(*gatewayv1.Gateway) {
+		return func(gateway *gatewayv1.Gateway) {
+			gateway.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: networkingv1alpha.GroupVersion.String(),
+				Kind:       "HTTPProxy",
+				Name:       httpProxy.Name,
+				UID:        httpProxy.UID,
+				Controller: ptr.To(true),
+			}}
+		}
+	}
+
+	allowingRoutesFrom := func(namespaces gatewayv1.RouteNamespaces) func(*gatewayv1.Gateway) {
+		return func(gateway *gatewayv1.Gateway) {
+			gateway.Spec.Listeners = []gatewayv1.Listener{{
+				Name:          "tcp",
+				AllowedRoutes: &gatewayv1.AllowedRoutes{Namespaces: &namespaces},
+			}}
+		}
+	}
+
+	tests := []struct {
+		name         string
+		gateway      *gatewayv1.Gateway
+		wantReferrer string
+	}{
+		{
+			name:    "unreferenced gateway",
+			gateway: gatewayFor("default", "unreferenced"),
+		},
+		{
+			name:         "gateway referenced by an HTTPRoute",
+			gateway:      gatewayFor("default", "routed"),
+			wantReferrer: `HTTPRoute "route"`,
+		},
+		{
+			name:         "gateway referenced by a GRPCRoute",
+			gateway:      gatewayFor("default", "grpc-routed"),
+			wantReferrer: `GRPCRoute "grpc"`,
+		},
+		{
+			name:    "cross namespace route not allowed by the listeners",
+			gateway: gatewayFor("default", "shared"),
+		},
+		{
+			name: "cross namespace route allowed from all namespaces",
+			gateway: gatewayFor("default", "shared", allowingRoutesFrom(gatewayv1.RouteNamespaces{
+				From: ptr.To(gatewayv1.NamespacesFromAll),
+			})),
+			wantReferrer: `TCPRoute "other/tcp"`,
+		},
+		{
+			name: "cross namespace route allowed by a namespace selector",
+			gateway: gatewayFor("default", "shared", allowingRoutesFrom(gatewayv1.RouteNamespaces{
+				From:     ptr.To(gatewayv1.NamespacesFromSelector),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"routes": "shared"}},
+			})),
+			wantReferrer: `TCPRoute "other/tcp"`,
+		},
+		{
+			name: "cross namespace route not matched by a namespace selector",
+			gateway: gatewayFor("default", "shared", allowingRoutesFrom(gatewayv1.RouteNamespaces{
+				From:     ptr.To(gatewayv1.NamespacesFromSelector),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"routes": "private"}},
+			})),
+		},
+		{
+			name: "forced deletion",
+			gateway: gatewayFor("default", "routed", func(gateway *gatewayv1.Gateway) {
+				gateway.Annotations = map[string]string{gatewayutil.ForceDeleteAnnotation: "true"}
+			}),
+		},
+		{
+			name:    "terminating namespace",
+			gateway: gatewayFor("terminating", "routed"),
+		},
+		{
+			name:         "gateway of an HTTPProxy",
+			gateway:      gatewayFor("default", "proxy", ownedBy(httpProxy)),
+			wantReferrer: `HTTPProxy "proxy"`,
+		},
+		{
+			name:    "gateway of a deleting HTTPProxy",
+			gateway: gatewayFor("default", "deleting-proxy", ownedBy(deletingHTTPProxy)),
+		},
+		{
+			name: "gateway of a deleted HTTPProxy",
+			gateway: gatewayFor("default", "deleted-proxy", ownedBy(&networkingv1alpha.HTTPProxy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deleted-proxy", UID: types.UID("deleted-proxy-uid")},
+			})),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			referrer, err := gatewayDeletionReferrer(context.Background(), clusterClient, tt.gateway)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReferrer, referrer)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+
+	"go.datum.net/network-services-operator/internal/validation"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// SetupHostnameBlocklistWebhookWithManager registers the webhook for HostnameBlocklist in the manager.
+func SetupHostnameBlocklistWebhookWithManager(mgr mcmanager.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr.GetLocalManager(), &networkingv1alpha.HostnameBlocklist{}).
+		WithValidator(&HostnameBlocklistCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-networking-datumapis-com-v1alpha-hostnameblocklist,mutating=false,failurePolicy=fail,sideEffects=None,groups=networking.datumapis.com,resources=hostnameblocklists,verbs=create;update,versions=v1alpha,name=vhostnameblocklist-v1alpha.kb.io,admissionReviewVersions=v1
+
+type HostnameBlocklistCustomValidator struct{}
+
+var _ admission.Validator[*networkingv1alpha.HostnameBlocklist] = &HostnameBlocklistCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type HostnameBlocklist.
+func (v *HostnameBlocklistCustomValidator) ValidateCreate(ctx context.Context, blocklist *networkingv1alpha.HostnameBlocklist) (admission.Warnings, error) {
+	logf.FromContext(ctx).Info("Validation for HostnameBlocklist upon creation", "name", blocklist.GetName())
+
+	if errs := validation.ValidateHostnameBlocklist(blocklist); len(errs) > 0 {
+		return nil, errors.NewInvalid(networkingv1alpha.GroupVersion.WithKind("HostnameBlocklist").GroupKind(), blocklist.GetName(), errs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type HostnameBlocklist.
+func (v *HostnameBlocklistCustomValidator) ValidateUpdate(ctx context.Context, oldBlocklist, newBlocklist *networkingv1alpha.HostnameBlocklist) (admission.Warnings, error) {
+	logf.FromContext(ctx).Info("Validation for HostnameBlocklist upon update", "name", newBlocklist.GetName())
+
+	if errs := validation.ValidateHostnameBlocklist(newBlocklist); len(errs) > 0 {
+		return nil, errors.NewInvalid(networkingv1alpha.GroupVersion.WithKind("HostnameBlocklist").GroupKind(), newBlocklist.GetName(), errs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type HostnameBlocklist.
+func (v *HostnameBlocklistCustomValidator) ValidateDelete(ctx context.Context, blocklist *networkingv1alpha.HostnameBlocklist) (admission.Warnings, error) {
+	return nil, nil
+}
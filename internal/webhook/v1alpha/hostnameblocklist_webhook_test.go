@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestHostnameBlocklistValidatorRejectsInvalidPatterns(t *testing.T) {
+	ctx := context.Background()
+	validator := &HostnameBlocklistCustomValidator{}
+
+	valid := &networkingv1alpha.HostnameBlocklist{
+		ObjectMeta: metav1.ObjectMeta{Name: "abuse"},
+		Spec: networkingv1alpha.HostnameBlocklistSpec{
+			Patterns: []string{`.*\.phish\.example`},
+		},
+	}
+	_, err := validator.ValidateCreate(ctx, valid)
+	assert.NoError(t, err)
+
+	invalid := valid.DeepCopy()
+	invalid.Spec.Patterns = append(invalid.Spec.Patterns, `login-[0-9+\.example\.com`)
+
+	_, err = validator.ValidateCreate(ctx, invalid)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+	assert.Contains(t, err.Error(), "spec.patterns[1]")
+
+	_, err = validator.ValidateUpdate(ctx, valid, invalid)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err), "expected an invalid error, got %v", err)
+}
@@ -4,13 +4,19 @@ package v1alpha
 
 import (
 	"context"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 
+	"go.datum.net/network-services-operator/internal/config"
 	"go.datum.net/network-services-operator/internal/validation"
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
@@ -19,9 +25,14 @@ import (
 // nolint:unused
 
 // SetupHTTPProxyWebhookWithManager registers the webhook for HTTPProxy in the manager.
-func SetupHTTPProxyWebhookWithManager(mgr mcmanager.Manager) error {
+func SetupHTTPProxyWebhookWithManager(mgr mcmanager.Manager, config config.NetworkServicesOperator) error {
 	return ctrl.NewWebhookManagedBy(mgr.GetLocalManager(), &networkingv1alpha.HTTPProxy{}).
-		WithValidator(&HTTPProxyCustomValidator{mgr: mgr}).
+		WithValidator(&HTTPProxyCustomValidator{
+			mgr:           mgr,
+			ipFamilies:    config.Gateway.IPFamilies,
+			requestPolicy: config.HTTPProxy.RequestPolicy,
+		}).
+		WithDefaulter(&HTTPProxyCustomDefaulter{}).
 		Complete()
 }
 
@@ -29,6 +40,11 @@ func SetupHTTPProxyWebhookWithManager(mgr mcmanager.Manager) error {
 
 type HTTPProxyCustomValidator struct {
 	mgr mcmanager.Manager
+	// ipFamilies are the IP families enabled on gateways. Backends with an IP
+	// address of any other family are rejected.
+	ipFamilies []networkingv1alpha.IPFamily
+	// requestPolicy limits the timeouts and retries rules may configure.
+	requestPolicy config.HTTPProxyRequestPolicyConfig
 }
 
 var _ admission.Validator[*networkingv1alpha.HTTPProxy] = &HTTPProxyCustomValidator{}
@@ -45,26 +61,30 @@ func (v *HTTPProxyCustomValidator) ValidateCreate(ctx context.Context, httpProxy
 	//
 	// For now, validate any HTTPProxy based on this operator's validation rules.
 
-	if errs := validation.ValidateHTTPProxy(httpProxy); len(errs) > 0 {
+	errs, err := v.validate(ctx, httpProxy)
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
 		return nil, errors.NewInvalid(httpProxy.GetObjectKind().GroupVersionKind().GroupKind(), httpProxy.GetName(), errs)
 	}
 
-	// TODO(user): fill in your validation logic upon object creation.
-
-	return nil, nil
+	return validation.WarningMessages(validation.HTTPProxyWarnings(httpProxy)), nil
 }
 
 // ValidateUpdate implements admission.Validator so a webhook will be registered for the type HTTPProxy.
 func (v *HTTPProxyCustomValidator) ValidateUpdate(ctx context.Context, oldHTTPProxy, newHTTPProxy *networkingv1alpha.HTTPProxy) (admission.Warnings, error) {
 	logf.FromContext(ctx).Info("Validation for HTTPProxy upon update", "name", newHTTPProxy.GetName())
 
-	if errs := validation.ValidateHTTPProxy(newHTTPProxy); len(errs) > 0 {
+	errs, err := v.validate(ctx, newHTTPProxy)
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
 		return nil, errors.NewInvalid(oldHTTPProxy.GetObjectKind().GroupVersionKind().GroupKind(), newHTTPProxy.GetName(), errs)
 	}
 
-	// TODO(user): fill in your validation logic upon object update.
-
-	return nil, nil
+	return validation.WarningMessages(validation.HTTPProxyWarnings(newHTTPProxy)), nil
 }
 
 // ValidateDelete implements admission.Validator so a webhook will be registered for the type HTTPProxy.
@@ -75,3 +95,75 @@ func (v *HTTPProxyCustomValidator) ValidateDelete(ctx context.Context, httpProxy
 
 	return nil, nil
 }
+
+// +kubebuilder:webhook:path=/mutate-networking-datumapis-com-v1alpha-httpproxy,mutating=true,failurePolicy=fail,sideEffects=None,groups=networking.datumapis.com,resources=httpproxies,verbs=create;update,versions=v1alpha,name=mhttpproxy-v1alpha.kb.io,admissionReviewVersions=v1
+
+type HTTPProxyCustomDefaulter struct{}
+
+var _ admission.Defaulter[*networkingv1alpha.HTTPProxy] = &HTTPProxyCustomDefaulter{}
+
+// Default implements admission.Defaulter so a webhook will be registered for the Kind HTTPProxy.
+func (d *HTTPProxyCustomDefaulter) Default(ctx context.Context, httpProxy *networkingv1alpha.HTTPProxy) error {
+	logf.FromContext(ctx).Info("Defaulting for HTTPProxy", "name", httpProxy.GetName())
+
+	// Hostnames are programmed as listener hostnames and matched against
+	// Domains in their canonical form, so store them that way.
+	for i, hostname := range httpProxy.Spec.Hostnames {
+		httpProxy.Spec.Hostnames[i] = gatewayv1.Hostname(normalizeHostname(string(hostname)))
+	}
+
+	return nil
+}
+
+func (v *HTTPProxyCustomValidator) validate(ctx context.Context, httpProxy *networkingv1alpha.HTTPProxy) (field.ErrorList, error) {
+	errs := validation.ValidateHTTPProxy(httpProxy)
+	if len(v.ipFamilies) > 0 {
+		errs = append(errs, validation.ValidateHTTPProxyBackendIPFamilies(httpProxy, v.ipFamilies)...)
+	}
+	errs = append(errs, validation.ValidateHTTPProxyRequestPolicies(httpProxy, v.requestPolicy)...)
+	if len(errs) > 0 || len(httpProxy.Spec.Hostnames) == 0 {
+		return errs, nil
+	}
+
+	clusterName, ok := mccontext.ClusterFrom(ctx)
+	if !ok {
+		return nil, fmt.Errorf("expected a cluster name in the context")
+	}
+
+	upstreamCluster, err := v.mgr.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	upstreamClient := upstreamCluster.GetClient()
+
+	var httpProxies networkingv1alpha.HTTPProxyList
+	if err := upstreamClient.List(ctx, &httpProxies, client.InNamespace(httpProxy.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list HTTPProxies in namespace %q: %w", httpProxy.GetNamespace(), err)
+	}
+
+	return duplicateHTTPProxyHostnames(httpProxies.Items, httpProxy), nil
+}
+
+// duplicateHTTPProxyHostnames returns an error for each hostname of the
+// HTTPProxy that another HTTPProxy in the namespace already has. Only one of
+// them could be programmed with the hostname.
+func duplicateHTTPProxyHostnames(existing []networkingv1alpha.HTTPProxy, httpProxy *networkingv1alpha.HTTPProxy) field.ErrorList {
+	owners := map[string]string{}
+	for _, p := range existing {
+		if p.Name == httpProxy.Name || p.DeletionTimestamp != nil {
+			continue
+		}
+		for _, h := range p.Spec.Hostnames {
+			owners[normalizeHostname(string(h))] = p.Name
+		}
+	}
+
+	var errs field.ErrorList
+	hostnamesPath := field.NewPath("spec", "hostnames")
+	for i, h := range httpProxy.Spec.Hostnames {
+		if owner, ok := owners[normalizeHostname(string(h))]; ok {
+			errs = append(errs, field.Invalid(hostnamesPath.Index(i), h, fmt.Sprintf("hostname is already used by HTTPProxy %q", owner)))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+func TestDuplicateHTTPProxyHostnames(t *testing.T) {
+	proxy := func(name string, hostnames ...gatewayv1.Hostname) networkingv1alpha.HTTPProxy {
+		return networkingv1alpha.HTTPProxy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       networkingv1alpha.HTTPProxySpec{Hostnames: hostnames},
+		}
+	}
+
+	deleting := proxy("deleting", "www.example.org")
+	deleting.DeletionTimestamp = &metav1.Time{}
+
+	existing := []networkingv1alpha.HTTPProxy{
+		proxy("web", testDomainExampleCom, "www.example.com"),
+		proxy("api", testDomainAPIAutouserRun),
+		deleting,
+	}
+
+	scenarios := map[string]struct {
+		httpProxy      networkingv1alpha.HTTPProxy
+		expectedErrors field.ErrorList
+	}{
+		"unique hostnames": {
+			httpProxy: proxy("new", "blog.example.com", "www.example.org"),
+		},
+		"update keeps own hostnames": {
+			httpProxy: proxy("web", testDomainExampleCom, "www.example.com"),
+		},
+		"hostname used by another proxy": {
+			httpProxy: proxy("new", "blog.example.com", "API.AutoUser.Run."),
+			expectedErrors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "hostnames").Index(1), "", ""),
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			errs := duplicateHTTPProxyHostnames(existing, &scenario.httpProxy)
+			if diff := cmp.Diff(scenario.expectedErrors, errs, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHTTPProxyDefaultHostnames(t *testing.T) {
+	httpProxy := &networkingv1alpha.HTTPProxy{
+		Spec: networkingv1alpha.HTTPProxySpec{
+			Hostnames: []gatewayv1.Hostname{"WWW.Example.com.", testDomainAPIAutouserRun},
+		},
+	}
+
+	assert.NoError(t, (&HTTPProxyCustomDefaulter{}).Default(context.Background(), httpProxy))
+	assert.Equal(t, []gatewayv1.Hostname{"www.example.com", testDomainAPIAutouserRun}, httpProxy.Spec.Hostnames)
+}
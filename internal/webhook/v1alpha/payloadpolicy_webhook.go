@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+
+	"go.datum.net/network-services-operator/internal/config"
+	"go.datum.net/network-services-operator/internal/validation"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// SetupPayloadPolicyWebhookWithManager registers the webhook for PayloadPolicy in the manager.
+func SetupPayloadPolicyWebhookWithManager(mgr mcmanager.Manager, config config.NetworkServicesOperator) error {
+	return ctrl.NewWebhookManagedBy(mgr.GetLocalManager(), &networkingv1alpha.PayloadPolicy{}).
+		WithValidator(&PayloadPolicyCustomValidator{
+			payloadPolicy: config.Gateway.PayloadPolicy,
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-networking-datumapis-com-v1alpha-payloadpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=networking.datumapis.com,resources=payloadpolicies,verbs=create;update,versions=v1alpha,name=vpayloadpolicy-v1alpha.kb.io,admissionReviewVersions=v1
+
+type PayloadPolicyCustomValidator struct {
+	// payloadPolicy limits the request body limit policies may configure.
+	payloadPolicy config.GatewayPayloadPolicyConfig
+}
+
+var _ admission.Validator[*networkingv1alpha.PayloadPolicy] = &PayloadPolicyCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type PayloadPolicy.
+func (v *PayloadPolicyCustomValidator) ValidateCreate(ctx context.Context, policy *networkingv1alpha.PayloadPolicy) (admission.Warnings, error) {
+	logf.FromContext(ctx).Info("Validation for PayloadPolicy upon creation", "name", policy.GetName())
+
+	if errs := validation.ValidatePayloadPolicy(policy, v.payloadPolicy); len(errs) > 0 {
+		return nil, errors.NewInvalid(policy.GetObjectKind().GroupVersionKind().GroupKind(), policy.GetName(), errs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type PayloadPolicy.
+func (v *PayloadPolicyCustomValidator) ValidateUpdate(ctx context.Context, oldPolicy, newPolicy *networkingv1alpha.PayloadPolicy) (admission.Warnings, error) {
+	logf.FromContext(ctx).Info("Validation for PayloadPolicy upon update", "name", newPolicy.GetName())
+
+	if errs := validation.ValidatePayloadPolicy(newPolicy, v.payloadPolicy); len(errs) > 0 {
+		return nil, errors.NewInvalid(oldPolicy.GetObjectKind().GroupVersionKind().GroupKind(), newPolicy.GetName(), errs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type PayloadPolicy.
+func (v *PayloadPolicyCustomValidator) ValidateDelete(ctx context.Context, policy *networkingv1alpha.PayloadPolicy) (admission.Warnings, error) {
+	return nil, nil
+}
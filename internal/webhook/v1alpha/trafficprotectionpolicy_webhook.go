@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package v1alpha
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+
+	"go.datum.net/network-services-operator/internal/validation"
+
+	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+)
+
+// SetupTrafficProtectionPolicyWebhookWithManager registers the webhook for TrafficProtectionPolicy in the manager.
+func SetupTrafficProtectionPolicyWebhookWithManager(mgr mcmanager.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr.GetLocalManager(), &networkingv1alpha.TrafficProtectionPolicy{}).
+		WithValidator(&TrafficProtectionPolicyCustomValidator{mgr: mgr}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-networking-datumapis-com-v1alpha-trafficprotectionpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=networking.datumapis.com,resources=trafficprotectionpolicies,verbs=create;update,versions=v1alpha,name=vtrafficprotectionpolicy-v1alpha.kb.io,admissionReviewVersions=v1
+
+type TrafficProtectionPolicyCustomValidator struct {
+	mgr mcmanager.Manager
+}
+
+var _ admission.Validator[*networkingv1alpha.TrafficProtectionPolicy] = &TrafficProtectionPolicyCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type TrafficProtectionPolicy.
+func (v *TrafficProtectionPolicyCustomValidator) ValidateCreate(ctx context.Context, policy *networkingv1alpha.TrafficProtectionPolicy) (admission.Warnings, error) {
+	logf.FromContext(ctx).Info("Validation for TrafficProtectionPolicy upon creation", "name", policy.GetName())
+
+	errs, err := v.validate(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errors.NewInvalid(policy.GetObjectKind().GroupVersionKind().GroupKind(), policy.GetName(), errs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type TrafficProtectionPolicy.
+func (v *TrafficProtectionPolicyCustomValidator) ValidateUpdate(ctx context.Context, oldPolicy, newPolicy *networkingv1alpha.TrafficProtectionPolicy) (admission.Warnings, error) {
+	logf.FromContext(ctx).Info("Validation for TrafficProtectionPolicy upon update", "name", newPolicy.GetName())
+
+	errs, err := v.validate(ctx, newPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errors.NewInvalid(oldPolicy.GetObjectKind().GroupVersionKind().GroupKind(), newPolicy.GetName(), errs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type TrafficProtectionPolicy.
+func (v *TrafficProtectionPolicyCustomValidator) ValidateDelete(ctx context.Context, policy *networkingv1alpha.TrafficProtectionPolicy) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *TrafficProtectionPolicyCustomValidator) validate(ctx context.Context, policy *networkingv1alpha.TrafficProtectionPolicy) (field.ErrorList, error) {
+	errs := validation.ValidateTrafficProtectionPolicy(policy)
+	if len(errs) > 0 {
+		return errs, nil
+	}
+
+	clusterName, ok := mccontext.ClusterFrom(ctx)
+	if !ok {
+		return nil, fmt.Errorf("expected a cluster name in the context")
+	}
+
+	upstreamCluster, err := v.mgr.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	upstreamClient := upstreamCluster.GetClient()
+
+	// Section names can only be validated against targets that exist. Targets
+	// that do not exist yet are reported in the ancestor status of the policy.
+	targetRefsPath := field.NewPath("spec", "targetRefs")
+	for i, targetRef := range policy.Spec.TargetRefs {
+		if targetRef.SectionName == nil {
+			continue
+		}
+
+		key := client.ObjectKey{Namespace: policy.Namespace, Name: string(targetRef.Name)}
+		var sectionNames []gatewayv1.SectionName
+		switch targetRef.Kind {
+		case "Gateway":
+			var gateway gatewayv1.Gateway
+			if err := upstreamClient.Get(ctx, key, &gateway); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get Gateway %s: %w", key, err)
+			}
+			for _, l := range gateway.Spec.Listeners {
+				sectionNames = append(sectionNames, l.Name)
+			}
+		case "HTTPRoute":
+			var route gatewayv1.HTTPRoute
+			if err := upstreamClient.Get(ctx, key, &route); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get HTTPRoute %s: %w", key, err)
+			}
+			for _, rule := range route.Spec.Rules {
+				if rule.Name != nil {
+					sectionNames = append(sectionNames, *rule.Name)
+				}
+			}
+		}
+
+		errs = append(errs, validation.ValidateTrafficProtectionPolicySectionName(targetRef, sectionNames, targetRefsPath.Index(i))...)
+	}
+
+	return errs, nil
+}
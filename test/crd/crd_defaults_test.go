@@ -17,18 +17,23 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	networkingv1alpha "go.datum.net/network-services-operator/api/v1alpha"
+	networkingv1alpha2 "go.datum.net/network-services-operator/api/v1alpha2"
 )
 
 // testClient is nil when KUBEBUILDER_ASSETS is unset (plain `go test` without
@@ -41,9 +46,23 @@ func TestMain(m *testing.M) {
 		os.Exit(m.Run())
 	}
 
+	scheme := runtime.NewScheme()
+	if err := networkingv1alpha.AddToScheme(scheme); err != nil {
+		fmt.Fprintf(os.Stderr, "add scheme: %v\n", err)
+		os.Exit(1)
+	}
+	if err := networkingv1alpha2.AddToScheme(scheme); err != nil {
+		fmt.Fprintf(os.Stderr, "add scheme: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Registering the convertible types in the CRD install scheme points the
+	// conversion of their CRDs at the webhook server started below, the way
+	// the webhook patches in config/crd do for the deployed CRDs.
 	env := &envtest.Environment{
 		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
 		ErrorIfCRDPathMissing: true,
+		CRDInstallOptions:     envtest.CRDInstallOptions{Scheme: scheme},
 	}
 	cfg, err := env.Start()
 	if err != nil {
@@ -51,11 +70,23 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
-	scheme := runtime.NewScheme()
-	if err := networkingv1alpha.AddToScheme(scheme); err != nil {
-		fmt.Fprintf(os.Stderr, "add scheme: %v\n", err)
+	ctx, cancel := context.WithCancel(context.Background())
+	server := webhook.NewServer(webhook.Options{
+		Host:    env.WebhookInstallOptions.LocalServingHost,
+		Port:    env.WebhookInstallOptions.LocalServingPort,
+		CertDir: env.WebhookInstallOptions.LocalServingCertDir,
+	})
+	server.Register("/convert", conversion.NewWebhookHandler(scheme, conversion.NewRegistry()))
+	go func() {
+		if err := server.Start(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "webhook server: %v\n", err)
+		}
+	}()
+	if err := waitForWebhookServer(server); err != nil {
+		fmt.Fprintf(os.Stderr, "webhook server: %v\n", err)
 		os.Exit(1)
 	}
+
 	testClient, err = client.New(cfg, client.Options{Scheme: scheme})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "build client: %v\n", err)
@@ -63,10 +94,25 @@ func TestMain(m *testing.M) {
 	}
 
 	code := m.Run()
+	cancel()
 	_ = env.Stop()
 	os.Exit(code)
 }
 
+// waitForWebhookServer waits for the webhook server to accept connections, so
+// the first conversion request does not race its startup.
+func waitForWebhookServer(server webhook.Server) error {
+	checker := server.StartedChecker()
+	var err error
+	for range 50 {
+		if err = checker(nil); err == nil {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return err
+}
+
 func requireEnv(t *testing.T) client.Client {
 	t.Helper()
 	if testClient == nil {
@@ -137,3 +183,85 @@ func TestTPPRejectsInvertedParanoia(t *testing.T) {
 	require.Error(t, err, "detection<blocking must be rejected")
 	assert.Truef(t, apierrors.IsInvalid(err), "expected an Invalid error, got %v", err)
 }
+
+// TestHTTPProxyRejectsUnsupportedEndpoints asserts the CEL rules on backend
+// endpoints reject schemes other than http and https, and H2C backends with
+// https endpoints, before the request reaches the validating webhook.
+func TestHTTPProxyRejectsUnsupportedEndpoints(t *testing.T) {
+	cl := requireEnv(t)
+	ctx := context.Background()
+
+	scenarios := map[string]struct {
+		backend         networkingv1alpha.HTTPProxyRuleBackend
+		expectedMessage string
+	}{
+		"unsupported scheme": {
+			backend:         networkingv1alpha.HTTPProxyRuleBackend{Endpoint: "ftp://example.com"},
+			expectedMessage: "endpoint must be an http or https URL",
+		},
+		"h2c with https endpoint": {
+			backend: networkingv1alpha.HTTPProxyRuleBackend{
+				Endpoint: "https://example.com",
+				Protocol: networkingv1alpha.HTTPProxyBackendProtocolH2C,
+			},
+			expectedMessage: "H2C may only be used with http endpoints",
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			proxy := &networkingv1alpha.HTTPProxy{
+				ObjectMeta: metav1.ObjectMeta{GenerateName: "endpoint-", Namespace: "default"},
+				Spec: networkingv1alpha.HTTPProxySpec{
+					Rules: []networkingv1alpha.HTTPProxyRule{{
+						Backends: []networkingv1alpha.HTTPProxyRuleBackend{scenario.backend},
+					}},
+				},
+			}
+			err := cl.Create(ctx, proxy)
+			require.Error(t, err)
+			assert.Truef(t, apierrors.IsInvalid(err), "expected an Invalid error, got %v", err)
+			assert.ErrorContains(t, err, scenario.expectedMessage)
+		})
+	}
+}
+
+// TestTPPConversionWebhookRoundTrip asserts a v1alpha2 TrafficProtectionPolicy
+// survives a round trip through the conversion webhook and the v1alpha storage
+// version: the paranoia level left unset is stored as its v1alpha default, but
+// reads back unset.
+func TestTPPConversionWebhookRoundTrip(t *testing.T) {
+	cl := requireEnv(t)
+	ctx := context.Background()
+
+	tpp := &networkingv1alpha2.TrafficProtectionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "conversion", Namespace: "default"},
+		Spec: networkingv1alpha2.TrafficProtectionPolicySpec{
+			TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{gatewayTargetRef("gw")},
+			Mode:       networkingv1alpha.TrafficProtectionPolicyObserve,
+			RuleSets: []networkingv1alpha2.TrafficProtectionPolicyRuleSet{{
+				Type: networkingv1alpha.TrafficProtectionPolicyOWASPCoreRuleSet,
+				OWASPCoreRuleSet: &networkingv1alpha2.OWASPCRS{
+					ParanoiaLevels: &networkingv1alpha2.ParanoiaLevels{Blocking: ptr.To[int32](2)},
+				},
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, tpp))
+	t.Cleanup(func() { _ = cl.Delete(ctx, tpp) })
+
+	var hub networkingv1alpha.TrafficProtectionPolicy
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(tpp), &hub))
+	require.Len(t, hub.Spec.RuleSets, 1)
+	pl := hub.Spec.RuleSets[0].OWASPCoreRuleSet.ParanoiaLevels
+	assert.Equal(t, 2, pl.Blocking)
+	assert.Equal(t, 2, pl.Detection, "detection must default to the blocking level in v1alpha")
+
+	var got networkingv1alpha2.TrafficProtectionPolicy
+	require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(tpp), &got))
+	assert.Empty(t, got.Annotations)
+	require.Len(t, got.Spec.RuleSets, 1)
+	require.NotNil(t, got.Spec.RuleSets[0].OWASPCoreRuleSet)
+	assert.Equal(t, tpp.Spec.RuleSets[0].OWASPCoreRuleSet.ParanoiaLevels,
+		got.Spec.RuleSets[0].OWASPCoreRuleSet.ParanoiaLevels)
+}